@@ -86,7 +86,9 @@ func (as *apiService) prepare(opt *options.Option) error {
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
 	// new api server discovery client.
-	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.AccountServerName}}
+	discOpt := serviced.DiscoveryOption{
+		Services: []cc.Name{cc.CloudServerName, cc.AccountServerName, cc.DataServiceName},
+	}
 	dis, err := serviced.NewDiscovery(cc.ApiServer().Service, discOpt)
 	if err != nil {
 		return fmt.Errorf("new service discovery faield, err: %v", err)
@@ -96,7 +98,7 @@ func (as *apiService) prepare(opt *options.Option) error {
 	logs.Infof("create discovery success.")
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(cmd.WithLog()); err != nil {
+	if err := ctl.LoadCtl(cmd.WithLog(), ctl.WithOpenAPI()); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
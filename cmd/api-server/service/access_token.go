@@ -0,0 +1,335 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/cc"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/uuid"
+)
+
+const (
+	// bearerPrefix is the prefix of authorization header value with access token.
+	bearerPrefix = "Bearer "
+	// accessTokenAppCode is the app code of the request authenticated by access token.
+	accessTokenAppCode = "hcm-access-token"
+)
+
+// bizPathRegexp matches the biz id in the request path, e.g. /api/v1/cloud/bizs/100/cvms/list
+var bizPathRegexp = regexp.MustCompile(`/bizs/(\d+)(/|$)`)
+
+// tokenVerifier verify access token by data-service, the verified token is cached for a short time, and the last
+// used time of tokens is reported to data-service at intervals.
+type tokenVerifier struct {
+	client *dataservice.Client
+	ttl    time.Duration
+	// interval is the minimum interval to update the last used time of a token.
+	interval time.Duration
+
+	lock  sync.Mutex
+	cache map[string]*verifiedToken
+	// reported is the last used time reported of tokens, key is token id.
+	reported map[string]time.Time
+	// pending is the last used time to be reported of tokens, key is token id.
+	pending map[string]*lastUsed
+}
+
+type verifiedToken struct {
+	id string
+	// user is the user of the request, it is the owner of personal access token, or the namespaced user of
+	// service account, so that service accounts can not act as real users.
+	user      string
+	scope     coretoken.Scope
+	scopeJson string
+	expiresAt time.Time
+	// invalid the reason why the token is invalid, empty means the token is valid.
+	invalid  string
+	cachedAt time.Time
+}
+
+type lastUsed struct {
+	tenantID string
+	usedAt   time.Time
+}
+
+// newTokenVerifier create access token verifier.
+func newTokenVerifier(client *dataservice.Client, opt cc.AccessToken) *tokenVerifier {
+	v := &tokenVerifier{
+		client:   client,
+		ttl:      time.Duration(opt.CacheTTLSec) * time.Second,
+		interval: time.Duration(opt.LastUsedUpdateIntervalSec) * time.Second,
+		cache:    make(map[string]*verifiedToken),
+		reported: make(map[string]time.Time),
+		pending:  make(map[string]*lastUsed),
+	}
+
+	go v.loopReportLastUsed()
+
+	return v
+}
+
+// bearerToken returns the access token in authorization header, returns empty if the request is not authenticated
+// by access token.
+func bearerToken(header http.Header) string {
+	auth := header.Get("Authorization")
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return ""
+	}
+
+	raw := strings.TrimSpace(strings.TrimPrefix(auth, bearerPrefix))
+	if !coretoken.IsToken(raw) {
+		return ""
+	}
+
+	return raw
+}
+
+// Parse verify the access token and parse the request to kit, the user of the kit is the owner of personal access
+// token or sa:<name> of service account token, and the token scope is set to kit so that it is enforced when
+// authorize.
+func (v *tokenVerifier) Parse(r *http.Request, raw string) (*kit.Kit, error) {
+	tenantID := r.Header.Get(constant.TenantIDKey)
+	if len(tenantID) == 0 && !cc.TenantEnable() {
+		tenantID = constant.DefaultTenantID
+	}
+
+	rid := r.Header.Get(constant.RidKey)
+	if len(rid) == 0 {
+		rid = uuid.UUID()
+	}
+
+	token, err := v.verify(rid, tenantID, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkBizScope(token.scope, r.URL.Path); err != nil {
+		return nil, err
+	}
+
+	kt := &kit.Kit{
+		Ctx:        r.Context(),
+		User:       token.user,
+		Rid:        rid,
+		AppCode:    accessTokenAppCode,
+		TenantID:   tenantID,
+		TokenScope: token.scopeJson,
+	}
+
+	if err = kt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	v.markUsed(token.id, tenantID)
+
+	return kt, nil
+}
+
+// verify the access token, returns the valid token.
+func (v *tokenVerifier) verify(rid, tenantID, raw string) (*verifiedToken, error) {
+	hash := coretoken.HashToken(raw)
+	key := tenantID + "/" + hash
+
+	v.lock.Lock()
+	token, exists := v.cache[key]
+	v.lock.Unlock()
+
+	if !exists || time.Since(token.cachedAt) > v.ttl {
+		var err error
+		token, err = v.getToken(rid, tenantID, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		v.lock.Lock()
+		v.cache[key] = token
+		v.lock.Unlock()
+	}
+
+	if len(token.invalid) != 0 {
+		return nil, errf.New(errf.PermissionDenied, token.invalid)
+	}
+
+	if time.Now().After(token.expiresAt) {
+		return nil, errf.New(errf.PermissionDenied, "access token is expired")
+	}
+
+	return token, nil
+}
+
+// getToken get access token by hash from data-service.
+func (v *tokenVerifier) getToken(rid, tenantID, hash string) (*verifiedToken, error) {
+	kt := core.NewTenantBackendKit(tenantID)
+	kt.Rid = rid
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("token_hash", hash),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := v.client.Global.AccessToken.ListAccessToken(kt, req)
+	if err != nil {
+		logs.Errorf("list access token by hash failed, err: %v, rid: %s", err, rid)
+		return nil, errf.New(errf.Unknown, "verify access token failed")
+	}
+
+	token := &verifiedToken{cachedAt: time.Now()}
+	if len(result.Details) == 0 {
+		token.invalid = "access token is invalid"
+		return token, nil
+	}
+
+	detail := result.Details[0]
+	if detail.Revoked {
+		token.invalid = "access token is revoked"
+		return token, nil
+	}
+
+	expiresAt, err := time.Parse(constant.TimeStdFormat, detail.ExpiresAt)
+	if err != nil {
+		logs.Errorf("parse access token expires at failed, err: %v, id: %s, rid: %s", err, detail.ID, rid)
+		return nil, errf.New(errf.Unknown, "verify access token failed")
+	}
+
+	scopeJson, err := json.Marshal(detail.Scope)
+	if err != nil {
+		logs.Errorf("marshal access token scope failed, err: %v, id: %s, rid: %s", err, detail.ID, rid)
+		return nil, errf.New(errf.Unknown, "verify access token failed")
+	}
+
+	token.id = detail.ID
+	token.user = detail.Owner
+	if detail.OwnerType == coretoken.ServiceAccountOwner {
+		token.user = coretoken.ServiceAccountUser(detail.Owner)
+	}
+	token.scope = detail.Scope
+	token.scopeJson = string(scopeJson)
+	token.expiresAt = expiresAt
+	return token, nil
+}
+
+// checkBizScope the token limited to businesses can only call the apis of these businesses.
+func checkBizScope(scope coretoken.Scope, path string) error {
+	if len(scope.BizIDs) == 0 {
+		return nil
+	}
+
+	matches := bizPathRegexp.FindAllStringSubmatch(path, -1)
+	if len(matches) == 0 {
+		return errf.New(errf.PermissionDenied, "access token can only call the apis of the businesses in its scope")
+	}
+
+	for _, match := range matches {
+		bizID, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || !slice.IsItemInSlice(scope.BizIDs, bizID) {
+			return errf.Newf(errf.PermissionDenied, "business %s is out of the access token scope", match[1])
+		}
+	}
+
+	return nil
+}
+
+// markUsed mark the token is used, the last used time is reported at most once every interval.
+func (v *tokenVerifier) markUsed(id, tenantID string) {
+	now := time.Now()
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if reportedAt, exists := v.reported[id]; exists && now.Sub(reportedAt) < v.interval {
+		return
+	}
+
+	v.reported[id] = now
+	v.pending[id] = &lastUsed{tenantID: tenantID, usedAt: now}
+}
+
+func (v *tokenVerifier) loopReportLastUsed() {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		v.reportLastUsed()
+		v.cleanExpired()
+	}
+}
+
+// reportLastUsed report the pending last used time of tokens by tenant.
+func (v *tokenVerifier) reportLastUsed() {
+	v.lock.Lock()
+	pending := v.pending
+	v.pending = make(map[string]*lastUsed)
+	v.lock.Unlock()
+
+	tenantTokens := make(map[string][]dstoken.AccessTokenLastUsed)
+	for id, used := range pending {
+		tenantTokens[used.tenantID] = append(tenantTokens[used.tenantID], dstoken.AccessTokenLastUsed{
+			ID:         id,
+			LastUsedAt: used.usedAt.Format(constant.TimeStdFormat),
+		})
+	}
+
+	for tenantID, tokens := range tenantTokens {
+		kt := core.NewTenantBackendKit(tenantID)
+		for _, batch := range slice.Split(tokens, 500) {
+			req := &dstoken.BatchUpdateLastUsedReq{Tokens: batch}
+			if err := v.client.Global.AccessToken.BatchUpdateAccessTokenLastUsed(kt, req); err != nil {
+				logs.Errorf("update access token last used time failed, err: %v, tenant: %s, rid: %s", err,
+					tenantID, kt.Rid)
+			}
+		}
+	}
+}
+
+// cleanExpired clean the expired cache and reported records, so that they do not grow unlimitedly.
+func (v *tokenVerifier) cleanExpired() {
+	now := time.Now()
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for key, token := range v.cache {
+		if now.Sub(token.cachedAt) > v.ttl {
+			delete(v.cache, key)
+		}
+	}
+
+	for id, reportedAt := range v.reported {
+		if now.Sub(reportedAt) > v.interval {
+			delete(v.reported, id)
+		}
+	}
+}
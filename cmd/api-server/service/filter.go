@@ -26,8 +26,11 @@ import (
 	"net/http"
 	"regexp"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest/ratelimit"
 	"hcm/pkg/runtime/gwparser"
 
 	"github.com/emicklei/go-restful/v3"
@@ -39,13 +42,26 @@ func (p *proxy) restFilter() restful.FilterFunction {
 		r, w := req.Request, resp.ResponseWriter
 
 		// parse request
-		kt, err := gwparser.Parse(r.Context(), r.Header)
+		kt, err := p.parseRequest(r)
 		if err != nil {
 			w.WriteHeader(http.StatusForbidden)
 			fmt.Fprintf(w, errf.Error(err).Error())
 			return
 		}
+
+		// keep the idempotency key header, so that the retried requests are deduplicated by cloud-server.
+		idempotencyKey := r.Header.Get(constant.IdempotencyKey)
 		req.Request.Header = kt.Header()
+		if len(idempotencyKey) != 0 {
+			req.Request.Header.Set(constant.IdempotencyKey, idempotencyKey)
+		}
+
+		if p.limiter != nil {
+			sub := ratelimit.Subject{User: kt.User, AppCode: kt.AppCode, TenantID: kt.TenantID}
+			if !p.limiter.Admit(w, r, sub) {
+				return
+			}
+		}
 
 		body, err := peekRequest(r)
 		if err != nil {
@@ -62,6 +78,18 @@ func (p *proxy) restFilter() restful.FilterFunction {
 	}
 }
 
+// parseRequest parse the request to kit, the request with access token is verified by token verifier, others are
+// parsed by api-gateway parser.
+func (p *proxy) parseRequest(r *http.Request) (*kit.Kit, error) {
+	if p.tokenVerifier != nil {
+		if raw := bearerToken(r.Header); len(raw) != 0 {
+			return p.tokenVerifier.Parse(r, raw)
+		}
+	}
+
+	return gwparser.Parse(r.Context(), r.Header)
+}
+
 func peekRequest(req *http.Request) (string, error) {
 	if req.Body != nil {
 		byt, err := ioutil.ReadAll(req.Body)
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest/ratelimit"
 	"hcm/pkg/serviced"
 
 	"github.com/emicklei/go-restful/v3"
@@ -40,6 +41,10 @@ import (
 type proxy struct {
 	discovery map[cc.Name]*discovery.APIDiscovery
 	cli       *http.Client
+	// tokenVerifier verify the request authenticated by access token, it is nil when access token is disabled.
+	tokenVerifier *tokenVerifier
+	// limiter limits the requests by user, app code and tenant, it is nil when rate limit is disabled.
+	limiter *ratelimit.Limiter
 }
 
 // newProxy create new rest proxy.
@@ -29,11 +29,13 @@ import (
 	"time"
 
 	"hcm/pkg/cc"
+	apiclient "hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/rest/client"
+	"hcm/pkg/rest/ratelimit"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/tools/ssl"
@@ -69,6 +71,17 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
+	if opt := cc.ApiServer().AccessToken; opt.Enable {
+		apiClientSet := apiclient.NewClientSet(cli, dis)
+		p.tokenVerifier = newTokenVerifier(apiClientSet.DataService(), opt)
+	}
+
+	if opt := cc.ApiServer().RateLimit; opt.Enable {
+		if p.limiter, err = ratelimit.New(opt); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Service{
 		proxy: p,
 	}, nil
@@ -86,6 +86,10 @@ var genResourceFuncMap = map[meta.ResourceType]genResourceFunc{
 	meta.Image:                    genImageResource,
 	meta.TaskManagement:           genTaskManagementResource,
 	meta.CosBucket:                genCosBucket,
+	meta.Rbac:                     genRbacResource,
+	meta.ServiceAccount:           genServiceAccountResource,
+	meta.AssignRule:               genAssignRuleResource,
+	meta.EventSubscription:        genEventSubscriptionResource,
 }
 
 func genApplicationResources(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
@@ -35,6 +35,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/local"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/iam/sdk/auth"
 	"hcm/pkg/iam/sys"
@@ -48,6 +49,8 @@ import (
 type Auth struct {
 	// auth related operate.
 	auth auth.Authorizer
+	// local is the local authorizer, authorize by it instead of iam when it is set.
+	local *local.Authorizer
 	// ds data service's auth related api.
 	ds *dataservice.Client
 	// disableAuth defines whether iam authorization is disabled
@@ -85,6 +88,33 @@ func NewAuth(auth auth.Authorizer, ds *dataservice.Client, disableAuth bool, cmd
 	return i, nil
 }
 
+// NewLocalAuth new auth that authorize by the local authorizer.
+func NewLocalAuth(localAuth *local.Authorizer, ds *dataservice.Client, disableAuth bool, cmdbCli cmdb.Client,
+	disableWriteOpt *options.DisableWriteOption) (*Auth, error) {
+
+	if localAuth == nil {
+		return nil, errf.New(errf.InvalidParameter, "local authorizer is nil")
+	}
+
+	if ds == nil {
+		return nil, errf.New(errf.InvalidParameter, "data client is nil")
+	}
+
+	if disableWriteOpt == nil {
+		return nil, errf.New(errf.InvalidParameter, "disable write operation is nil")
+	}
+
+	i := &Auth{
+		local:           localAuth,
+		ds:              ds,
+		disableAuth:     disableAuth,
+		disableWriteOpt: disableWriteOpt,
+		cmdbCli:         cmdbCli,
+	}
+
+	return i, nil
+}
+
 // InitAuthService initialize the iam authorize service
 func (a *Auth) InitAuthService(c *capability.Capability) {
 	h := rest.NewHandler()
@@ -139,6 +169,14 @@ func (a *Auth) authorizeBatch(kt *kit.Kit, req *authserver.AuthorizeBatchReq, ex
 		return decisions, nil
 	}
 
+	if a.local != nil {
+		if exact {
+			decisions, _, err := a.local.Authorize(kt, req.User.UserName, req.Resources...)
+			return decisions, err
+		}
+		return a.local.AuthorizeAny(kt, req.User.UserName, req.Resources...)
+	}
+
 	// parse hcm resource to iam resource
 	opts, decisions, err := parseAttributesToBatchOptions(kt, req.User, req.Resources...)
 	if err != nil {
@@ -278,6 +316,10 @@ func (a *Auth) GetPermissionToApply(cts *rest.Contexts) (interface{}, error) {
 }
 
 func (a *Auth) getPermissionToApply(kt *kit.Kit, resources []meta.ResourceAttribute) (*meta.IamPermission, error) {
+	if a.local != nil {
+		return a.local.GetPermissionToApply(resources...), nil
+	}
+
 	permission := new(meta.IamPermission)
 	permission.SystemID = sys.SystemIDHCM
 	permission.SystemName = sys.SystemNameHCM
@@ -515,6 +557,11 @@ func (a *Auth) ListAuthorizedInstances(cts *rest.Contexts) (interface{}, error)
 		return nil, err
 	}
 
+	if a.local != nil {
+		input := &meta.ListAuthResInput{Type: req.Type, Action: req.Action}
+		return a.local.ListAuthorizedInstances(cts.Kit, req.User.UserName, input)
+	}
+
 	res := &meta.ResourceAttribute{
 		Basic: &meta.Basic{
 			Type:   req.Type,
@@ -560,6 +607,11 @@ func (a *Auth) RegisterResourceCreatorAction(cts *rest.Contexts) (interface{}, e
 		return nil, err
 	}
 
+	// local authorization mode do not grant creator permissions, they are granted by role bindings.
+	if a.local != nil {
+		return make([]client.CreatorActionPolicy, 0), nil
+	}
+
 	opts := &client.InstanceWithCreator{
 		System:  sys.SystemIDHCM,
 		Type:    req.Instance.Type,
@@ -592,6 +644,11 @@ func (a *Auth) GetApplyPermUrl(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	// there is no permission center to apply permission in local authorization mode.
+	if a.local != nil {
+		return "", nil
+	}
+
 	url, err := a.auth.GetApplyPermUrl(cts.Kit.Ctx, req)
 	if err != nil {
 		logs.Errorf("get iam apply permission url failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
@@ -798,3 +798,41 @@ func genCosBucket(a *meta.ResourceAttribute) (client.ActionID, []client.Resource
 func genCloudSelectionResource(*meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	return sys.CloudSelectionRecommend, make([]client.Resource, 0), nil
 }
+
+// genRbacResource rbac resource is managed by local authorizer, there is no related iam action.
+func genRbacResource(*meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return "", nil, errf.New(errf.InvalidParameter, "rbac resource is only supported in local authorization mode")
+}
+
+// genServiceAccountResource generate service account related iam resource, service accounts are managed by the
+// global configuration administrators.
+func genServiceAccountResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genAssignRuleResource generate assign rule related iam resource, assign rules can assign resources to any
+// business, so they are managed by the global configuration administrators.
+func genAssignRuleResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genEventSubscriptionResource generate event subscription related iam resource, subscriptions can receive the
+// change events of resources in any business, so they are managed by the global configuration administrators.
+func genEventSubscriptionResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
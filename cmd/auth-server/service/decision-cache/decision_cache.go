@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package decisioncache 鉴权结果缓存失效管理，各服务的鉴权器缓存鉴权结果，权限策略变更后调用失效接口更新缓存版本，
+// 鉴权器定期同步缓存版本，发现版本变化时清空缓存的鉴权结果
+package decisioncache
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"hcm/cmd/auth-server/service/capability"
+	authserver "hcm/pkg/api/auth-server"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// revisionKey is the etcd key of the decision cache revision, its modify revision is used as the cache revision, so
+// that it is shared by all the auth-server instances.
+const revisionKey = "/hcm/auth/decision_cache/revision"
+
+// DecisionCache authorization decision cache related operate.
+type DecisionCache struct {
+	etcdCli *etcd3.Client
+}
+
+// NewDecisionCache new decision cache.
+func NewDecisionCache(etcdCli *etcd3.Client) *DecisionCache {
+	return &DecisionCache{
+		etcdCli: etcdCli,
+	}
+}
+
+// InitService initialize the decision cache service.
+func (d *DecisionCache) InitService(c *capability.Capability) {
+	h := rest.NewHandler()
+
+	h.Add("InvalidateDecisionCache", http.MethodPost, "/auth/decision_cache/invalidate", d.Invalidate)
+	h.Add("GetDecisionCacheRevision", http.MethodGet, "/auth/decision_cache/revision", d.GetRevision)
+
+	h.Load(c.WebService)
+}
+
+// Invalidate invalidate the decision cache of all the authorizers by changing the cache revision.
+func (d *DecisionCache) Invalidate(cts *rest.Contexts) (interface{}, error) {
+	resp, err := d.etcdCli.Put(cts.Kit.Ctx, revisionKey, strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err != nil {
+		logs.Errorf("update decision cache revision failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	logs.Infof("decision cache is invalidated by user: %s, app code: %s, revision: %d, rid: %s", cts.Kit.User,
+		cts.Kit.AppCode, resp.Header.Revision, cts.Kit.Rid)
+
+	return &authserver.DecisionCacheRevision{Revision: resp.Header.Revision}, nil
+}
+
+// GetRevision get the decision cache revision.
+func (d *DecisionCache) GetRevision(cts *rest.Contexts) (interface{}, error) {
+	resp, err := d.etcdCli.Get(cts.Kit.Ctx, revisionKey)
+	if err != nil {
+		logs.Errorf("get decision cache revision failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	revision := new(authserver.DecisionCacheRevision)
+	if len(resp.Kvs) != 0 {
+		revision.Revision = resp.Kvs[0].ModRevision
+	}
+
+	return revision, nil
+}
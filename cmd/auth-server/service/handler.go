@@ -108,9 +108,15 @@ func (s *Service) apiSet() *restful.Container {
 		WebService: ws,
 	}
 
-	s.initial.InitInitialService(c)
-	s.iam.InitIAMService(c)
+	// iam callback and auth center initial apis are not needed in local authorization mode
+	if s.initial != nil {
+		s.initial.InitInitialService(c)
+	}
+	if s.iam != nil {
+		s.iam.InitIAMService(c)
+	}
 	s.auth.InitAuthService(c)
+	s.decisionCache.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
@@ -27,12 +27,14 @@ import (
 
 	"hcm/cmd/auth-server/options"
 	"hcm/cmd/auth-server/service/auth"
+	decisioncache "hcm/cmd/auth-server/service/decision-cache"
 	"hcm/cmd/auth-server/service/iam"
 	"hcm/cmd/auth-server/service/initial"
 	"hcm/pkg/cc"
 	apicli "hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/local"
 	pkgauth "hcm/pkg/iam/sdk/auth"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/logs"
@@ -42,6 +44,8 @@ import (
 	"hcm/pkg/thirdparty/api-gateway/cmdb"
 	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/ssl"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // Service do all the data service's work
@@ -61,13 +65,20 @@ type Service struct {
 	initial *initial.Initial
 	// auth logic module.
 	auth *auth.Auth
+	// decisionCache decision cache logic module.
+	decisionCache *decisioncache.DecisionCache
 }
 
 // NewService create a service instance.
 func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, disableAuth bool,
 	disableWriteOpt *options.DisableWriteOption) (*Service, error) {
 
-	cli, err := newClientSet(sd, iamSettings, esbSettings, disableAuth)
+	newClientSetFunc := newClientSet
+	if iamSettings.IsLocal() {
+		newClientSetFunc = newLocalClientSet
+	}
+
+	cli, err := newClientSetFunc(sd, iamSettings, esbSettings, disableAuth)
 	if err != nil {
 		return nil, fmt.Errorf("new client set failed, err: %v", err)
 	}
@@ -88,6 +99,16 @@ func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, di
 		return nil, err
 	}
 
+	etcdCfg, err := cc.AuthServer().Service.Etcd.ToConfig()
+	if err != nil {
+		return nil, err
+	}
+	etcdCli, err := etcd3.New(etcdCfg)
+	if err != nil {
+		return nil, fmt.Errorf("new etcd client failed, err: %v", err)
+	}
+	s.decisionCache = decisioncache.NewDecisionCache(etcdCli)
+
 	return s, nil
 }
 
@@ -95,16 +116,7 @@ func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb,
 
 	logs.Infof("start initialize the client set.")
 
-	tlsConfig := new(ssl.TLSConfig)
-	if iamSettings.TLS.Enable() {
-		tlsConfig = &ssl.TLSConfig{
-			InsecureSkipVerify: iamSettings.TLS.InsecureSkipVerify,
-			CertFile:           iamSettings.TLS.CertFile,
-			KeyFile:            iamSettings.TLS.KeyFile,
-			CAFile:             iamSettings.TLS.CAFile,
-			Password:           iamSettings.TLS.Password,
-		}
-	}
+	tlsConfig := newTLSConfig(iamSettings)
 
 	// initiate system api client set.
 	restCli, err := restcli.NewClient(tlsConfig)
@@ -163,6 +175,51 @@ func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb,
 	return cs, nil
 }
 
+func newTLSConfig(iamSettings cc.IAM) *ssl.TLSConfig {
+	tlsConfig := new(ssl.TLSConfig)
+	if iamSettings.TLS.Enable() {
+		tlsConfig = &ssl.TLSConfig{
+			InsecureSkipVerify: iamSettings.TLS.InsecureSkipVerify,
+			CertFile:           iamSettings.TLS.CertFile,
+			KeyFile:            iamSettings.TLS.KeyFile,
+			CAFile:             iamSettings.TLS.CAFile,
+			Password:           iamSettings.TLS.Password,
+		}
+	}
+
+	return tlsConfig
+}
+
+// newLocalClientSet new client set of local authorization mode, which do not depend on blueking iam.
+func newLocalClientSet(sd serviced.Discover, iamSettings cc.IAM, _ cc.Esb, _ bool) (*ClientSet, error) {
+	logs.Infof("start initialize the client set of local authorization mode.")
+
+	restCli, err := restcli.NewClient(newTLSConfig(iamSettings))
+	if err != nil {
+		return nil, err
+	}
+	apiClientSet := apicli.NewClientSet(restCli, sd)
+
+	cmdbCfg := cc.AuthServer().Cmdb
+	cmdbCli, err := cmdb.NewClient(&cmdbCfg, metrics.Register())
+	if err != nil {
+		return nil, err
+	}
+
+	localAuth, err := local.NewAuthorizer(apiClientSet.DataService(), iamSettings.Local.Admins)
+	if err != nil {
+		return nil, fmt.Errorf("new local authorizer failed, err: %v", err)
+	}
+
+	cs := &ClientSet{
+		ds:        apiClientSet.DataService(),
+		localAuth: localAuth,
+		cmdbCli:   cmdbCli,
+	}
+	logs.Infof("initialize the client set of local authorization mode success.")
+	return cs, nil
+}
+
 // ClientSet defines configure server's all the depends api client.
 type ClientSet struct {
 	// data service's sys api
@@ -171,6 +228,8 @@ type ClientSet struct {
 	sys *sys.Sys
 	// auth related operate.
 	auth pkgauth.Authorizer
+	// localAuth is the local authorizer, only set in local authorization mode.
+	localAuth *local.Authorizer
 	// cmdb client.
 	cmdbCli cmdb.Client
 }
@@ -179,6 +238,12 @@ type ClientSet struct {
 func (s *Service) initLogicModule() error {
 	var err error
 
+	if s.client.localAuth != nil {
+		s.auth, err = auth.NewLocalAuth(s.client.localAuth, s.client.ds, s.disableAuth, s.client.cmdbCli,
+			s.disableWriteOpt)
+		return err
+	}
+
 	s.initial, err = initial.NewInitial(s.client.sys, s.disableAuth)
 	if err != nil {
 		return err
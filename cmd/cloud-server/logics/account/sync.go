@@ -24,11 +24,14 @@ import (
 	"fmt"
 	"strings"
 
+	assignrule "hcm/cmd/cloud-server/logics/assign-rule"
+	"hcm/cmd/cloud-server/service/sync/aliyun"
 	"hcm/cmd/cloud-server/service/sync/aws"
 	"hcm/cmd/cloud-server/service/sync/azure"
 	"hcm/cmd/cloud-server/service/sync/gcp"
 	"hcm/cmd/cloud-server/service/sync/huawei"
 	"hcm/cmd/cloud-server/service/sync/lock"
+	"hcm/cmd/cloud-server/service/sync/openstack"
 	"hcm/cmd/cloud-server/service/sync/other"
 	"hcm/cmd/cloud-server/service/sync/tcloud"
 	"hcm/pkg/api/core"
@@ -86,6 +89,9 @@ func Sync(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID st
 			logs.Errorf("[%s] sync account %s failed on %s, err: %v, rid: %s", vendor, accountID, resType, err, kt.Rid)
 		}
 
+		// 同步失败前已同步的资源也需要按规则分配
+		assignrule.ApplyAfterSync(kt, cli.DataService(), accountID)
+
 	}(leaseID)
 
 	return nil
@@ -160,6 +166,14 @@ func newTCloudSyncer() tcloudSyncer {
 	return tcloudSyncer{generalSyncer{vendor: enumor.TCloud}}
 }
 
+func newAliyunSyncer() aliyunSyncer {
+	return aliyunSyncer{generalSyncer{vendor: enumor.Aliyun}}
+}
+
+func newOpenStackSyncer() openStackSyncer {
+	return openStackSyncer{generalSyncer{vendor: enumor.OpenStack}}
+}
+
 func newOtherSyncer() otherSyncer {
 	return otherSyncer{generalSyncer{vendor: enumor.Other}}
 }
@@ -175,17 +189,21 @@ var availableVendorSyncer = []VendorSyncer{
 	newHuaweiSyncer(),
 	newGcpSyncer(),
 	newAzureSyncer(),
+	newAliyunSyncer(),
+	newOpenStackSyncer(),
 	newOtherSyncer(),
 }
 
 // vendorSyncerMap
 var vendorSyncerMap = map[enumor.Vendor]VendorSyncer{
-	enumor.TCloud: newTCloudSyncer(),
-	enumor.Aws:    newAwsSyncer(),
-	enumor.HuaWei: newHuaweiSyncer(),
-	enumor.Gcp:    newGcpSyncer(),
-	enumor.Azure:  newAzureSyncer(),
-	enumor.Other:  newOtherSyncer(),
+	enumor.TCloud:    newTCloudSyncer(),
+	enumor.Aws:       newAwsSyncer(),
+	enumor.HuaWei:    newHuaweiSyncer(),
+	enumor.Gcp:       newGcpSyncer(),
+	enumor.Azure:     newAzureSyncer(),
+	enumor.Aliyun:    newAliyunSyncer(),
+	enumor.OpenStack: newOpenStackSyncer(),
+	enumor.Other:     newOtherSyncer(),
 }
 
 // CountZone ...
@@ -371,6 +389,56 @@ func (t azureSyncer) SyncAllResource(kt *kit.Kit, cli *client.ClientSet, account
 	return azure.SyncAllResource(kt, cli, opt)
 }
 
+// aliyunSyncer ...
+type aliyunSyncer struct {
+	generalSyncer
+}
+
+// CountRegion ...
+func (t aliyunSyncer) CountRegion(kt *kit.Kit, dataCli *dataservice.Client) (uint64, error) {
+	req := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewCountPage(),
+	}
+	result, err := dataCli.Aliyun.Region.ListRegion(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// SyncAllResource ...
+func (t aliyunSyncer) SyncAllResource(kt *kit.Kit, cli *client.ClientSet, account string,
+	syncPubRes bool) (reType enumor.CloudResourceType, err error) {
+
+	opt := &aliyun.SyncAllResourceOption{
+		AccountID:          account,
+		SyncPublicResource: syncPubRes,
+	}
+	return aliyun.SyncAllResource(kt, cli, opt)
+}
+
+// openStackSyncer ...
+type openStackSyncer struct {
+	generalSyncer
+}
+
+// CountRegion ...
+func (t openStackSyncer) CountRegion(kt *kit.Kit, dataCli *dataservice.Client) (uint64, error) {
+	// openstack 私有云没有公共资源，区域由账号确定，按有资源处理
+	return 1, nil
+}
+
+// SyncAllResource ...
+func (t openStackSyncer) SyncAllResource(kt *kit.Kit, cli *client.ClientSet, account string, syncPubRes bool) (
+	reType enumor.CloudResourceType, err error) {
+
+	opt := &openstack.SyncAllResourceOption{
+		AccountID: account,
+	}
+	return openstack.SyncAllResource(kt, cli, opt)
+}
+
 // otherSyncer ...
 type otherSyncer struct {
 	generalSyncer
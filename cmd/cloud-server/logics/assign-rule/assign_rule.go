@@ -0,0 +1,452 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 按规则将同步到的未分配资源自动分配到业务
+package assignrule
+
+import (
+	"fmt"
+
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/disk"
+	"hcm/cmd/cloud-server/logics/eip"
+	lblogic "hcm/cmd/cloud-server/logics/load-balancer"
+	"hcm/pkg/api/core"
+	coreassign "hcm/pkg/api/core/assign-rule"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// ApplyAfterSync 账号资源同步后按已启用的规则分配未分配的资源，分配失败不影响同步结果，只记录日志
+func ApplyAfterSync(kt *kit.Kit, cli *dataservice.Client, accountID string) {
+	matches, err := Apply(kt, cli, accountID)
+	if err != nil {
+		logs.Errorf("apply assign rules after sync failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+	}
+
+	if len(matches) != 0 {
+		logs.Infof("apply assign rules after sync, account: %s, assigned count: %d, rid: %s", accountID,
+			len(matches), kt.Rid)
+	}
+}
+
+// Apply 按已启用的规则将账号下未分配的资源分配到业务，返回成功分配的资源。
+// 每个规则使用单独的操作人执行分配，分配资源的审计记录中可以看到是由哪个规则分配的。
+func Apply(kt *kit.Kit, cli *dataservice.Client, accountID string) ([]coreassign.MatchResult, error) {
+	rules, err := ListEnabledRules(kt, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := Preview(kt, cli, accountID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleMap := make(map[string]coreassign.AssignRule, len(rules))
+	for _, rule := range rules {
+		ruleMap[rule.ID] = rule
+	}
+
+	// 按规则及资源类型分组分配，单组分配失败不影响其他组
+	groups := make(map[string]map[enumor.CloudResourceType][]coreassign.MatchResult)
+	for _, match := range matches {
+		if _, exists := groups[match.RuleID]; !exists {
+			groups[match.RuleID] = make(map[enumor.CloudResourceType][]coreassign.MatchResult)
+		}
+		groups[match.RuleID][match.ResType] = append(groups[match.RuleID][match.ResType], match)
+	}
+
+	assigned := make([]coreassign.MatchResult, 0, len(matches))
+	var lastErr error
+	for ruleID, typeMatches := range groups {
+		ruleKt := kt.NewSubKit()
+		ruleKt.User = coreassign.AssignUserPrefix + ruleID
+		for resType, one := range typeMatches {
+			if err = assign(ruleKt, cli, ruleMap[ruleID], resType, one); err != nil {
+				logs.Errorf("assign %s by rule %s failed, err: %v, account: %s, rid: %s", resType, ruleID, err,
+					accountID, ruleKt.Rid)
+				lastErr = err
+				continue
+			}
+			assigned = append(assigned, one...)
+		}
+	}
+
+	if lastErr != nil {
+		return assigned, fmt.Errorf("some resources assign by rule failed, last err: %v", lastErr)
+	}
+
+	return assigned, nil
+}
+
+func assign(kt *kit.Kit, cli *dataservice.Client, rule coreassign.AssignRule, resType enumor.CloudResourceType,
+	matches []coreassign.MatchResult) error {
+
+	ids := slice.Map(matches, func(one coreassign.MatchResult) string { return one.ID })
+
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		var err error
+		switch resType {
+		case enumor.CvmCloudResType:
+			if rule.BkCloudID == nil {
+				return fmt.Errorf("rule %s has no bk_cloud_id", rule.ID)
+			}
+			cvms := make([]logicscvm.AssignedCvmInfo, len(batch))
+			for idx, id := range batch {
+				cvms[idx] = logicscvm.AssignedCvmInfo{CvmID: id, BkBizID: rule.BkBizID, BkCloudID: *rule.BkCloudID}
+			}
+			err = logicscvm.Assign(kt, cli, cvms)
+		case enumor.DiskCloudResType:
+			err = disk.Assign(kt, cli, batch, uint64(rule.BkBizID), false)
+		case enumor.EipCloudResType:
+			err = eip.Assign(kt, cli, batch, uint64(rule.BkBizID), false)
+		case enumor.LoadBalancerCloudResType:
+			err = lblogic.AssignTCloud(kt, cli, batch, rule.BkBizID)
+		default:
+			return fmt.Errorf("res type %s is not supported", resType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListEnabledRules 查询已启用的规则
+func ListEnabledRules(kt *kit.Kit, cli *dataservice.Client) ([]coreassign.AssignRule, error) {
+	rules := make([]coreassign.AssignRule, 0)
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := cli.Global.AssignRule.ListAssignRule(kt, listReq)
+		if err != nil {
+			logs.Errorf("list enabled assign rule failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		rules = append(rules, result.Details...)
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+// Preview 预览账号下未分配的资源与规则的匹配结果，不执行分配。
+// 规则的业务需要在账号的使用业务范围内，否则该规则不参与匹配。
+func Preview(kt *kit.Kit, cli *dataservice.Client, accountID string, rules []coreassign.AssignRule) (
+	[]coreassign.MatchResult, error) {
+
+	rules, err := filterRulesByAccountBiz(kt, cli, accountID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := coreassign.NewMatcher(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]coreassign.MatchResult, 0)
+	if matcher.Empty() {
+		return matches, nil
+	}
+
+	for _, resType := range matcher.ResTypes() {
+		resources, err := listUnassignedRes(kt, cli, accountID, resType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range resources {
+			rule := matcher.Match(res)
+			if rule == nil {
+				continue
+			}
+			matches = append(matches, coreassign.MatchResult{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				BkBizID:  rule.BkBizID,
+				Resource: res,
+			})
+		}
+	}
+
+	return matches, nil
+}
+
+func filterRulesByAccountBiz(kt *kit.Kit, cli *dataservice.Client, accountID string,
+	rules []coreassign.AssignRule) ([]coreassign.AssignRule, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("account_id", accountID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"bk_biz_id"},
+	}
+	rels, err := cli.Global.Account.ListAccountBizRel(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list account biz relation failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return nil, err
+	}
+
+	bizIDs := make(map[int64]struct{}, len(rels.Details))
+	for _, rel := range rels.Details {
+		bizIDs[rel.BkBizID] = struct{}{}
+	}
+	if _, exists := bizIDs[constant.AttachedAllBiz]; exists {
+		return rules, nil
+	}
+
+	result := make([]coreassign.AssignRule, 0, len(rules))
+	for _, rule := range rules {
+		if _, exists := bizIDs[rule.BkBizID]; exists {
+			result = append(result, rule)
+		}
+	}
+
+	return result, nil
+}
+
+func listUnassignedRes(kt *kit.Kit, cli *dataservice.Client, accountID string, resType enumor.CloudResourceType) (
+	[]coreassign.Resource, error) {
+
+	switch resType {
+	case enumor.CvmCloudResType:
+		return listUnassignedCvm(kt, cli, accountID)
+	case enumor.DiskCloudResType:
+		return listUnassignedDisk(kt, cli, accountID)
+	case enumor.EipCloudResType:
+		return listUnassignedEip(kt, cli, accountID)
+	case enumor.LoadBalancerCloudResType:
+		return listUnassignedLoadBalancer(kt, cli, accountID)
+	default:
+		return nil, fmt.Errorf("res type %s is not supported", resType)
+	}
+}
+
+func unassignedReq(accountID string, fields []string) *core.ListReq {
+	return &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("bk_biz_id", constant.UnassignedBiz),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: fields,
+	}
+}
+
+func listUnassignedCvm(kt *kit.Kit, cli *dataservice.Client, accountID string) ([]coreassign.Resource, error) {
+	resources := make([]coreassign.Resource, 0)
+	listReq := unassignedReq(accountID, []string{"id", "cloud_id", "name", "account_id", "region", "cloud_vpc_ids"})
+	for {
+		result, err := cli.Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list unassigned cvm failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, coreassign.Resource{
+				ResType:     enumor.CvmCloudResType,
+				ID:          one.ID,
+				CloudID:     one.CloudID,
+				Name:        one.Name,
+				AccountID:   one.AccountID,
+				Region:      one.Region,
+				CloudVpcIDs: one.CloudVpcIDs,
+			})
+		}
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return resources, nil
+}
+
+// listUnassignedDisk 查询未分配且未绑定主机的硬盘，绑定主机的硬盘随主机一起分配
+func listUnassignedDisk(kt *kit.Kit, cli *dataservice.Client, accountID string) ([]coreassign.Resource, error) {
+	resources := make([]coreassign.Resource, 0)
+	listReq := unassignedReq(accountID, []string{"id", "cloud_id", "name", "account_id", "region"})
+	for {
+		result, err := cli.Global.ListDisk(kt, listReq)
+		if err != nil {
+			logs.Errorf("list unassigned disk failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		if len(result.Details) != 0 {
+			ids := make([]string, len(result.Details))
+			for idx, one := range result.Details {
+				ids[idx] = one.ID
+			}
+			relReq := &core.ListReq{
+				Filter: tools.ContainersExpression("disk_id", ids),
+				Page:   core.NewDefaultBasePage(),
+				Fields: []string{"disk_id"},
+			}
+			rels, err := cli.Global.ListDiskCvmRel(kt, relReq)
+			if err != nil {
+				logs.Errorf("list disk cvm rel failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			bound := make(map[string]struct{}, len(rels.Details))
+			for _, rel := range rels.Details {
+				bound[rel.DiskID] = struct{}{}
+			}
+
+			for _, one := range result.Details {
+				if _, exists := bound[one.ID]; exists {
+					continue
+				}
+				resources = append(resources, coreassign.Resource{
+					ResType:   enumor.DiskCloudResType,
+					ID:        one.ID,
+					CloudID:   one.CloudID,
+					Name:      one.Name,
+					AccountID: one.AccountID,
+					Region:    one.Region,
+				})
+			}
+		}
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return resources, nil
+}
+
+// listUnassignedEip 查询未分配且未绑定主机的弹性IP，绑定主机的弹性IP随主机一起分配
+func listUnassignedEip(kt *kit.Kit, cli *dataservice.Client, accountID string) ([]coreassign.Resource, error) {
+	resources := make([]coreassign.Resource, 0)
+	listReq := unassignedReq(accountID, []string{"id", "cloud_id", "name", "account_id", "region"})
+	for {
+		result, err := cli.Global.ListEip(kt, listReq)
+		if err != nil {
+			logs.Errorf("list unassigned eip failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		if len(result.Details) != 0 {
+			ids := make([]string, len(result.Details))
+			for idx, one := range result.Details {
+				ids[idx] = one.ID
+			}
+			relReq := &core.ListReq{
+				Filter: tools.ContainersExpression("eip_id", ids),
+				Page:   core.NewDefaultBasePage(),
+				Fields: []string{"eip_id"},
+			}
+			rels, err := cli.Global.ListEipCvmRel(kt, relReq)
+			if err != nil {
+				logs.Errorf("list eip cvm rel failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			bound := make(map[string]struct{}, len(rels.Details))
+			for _, rel := range rels.Details {
+				bound[rel.EipID] = struct{}{}
+			}
+
+			for _, one := range result.Details {
+				if _, exists := bound[one.ID]; exists {
+					continue
+				}
+				resources = append(resources, coreassign.Resource{
+					ResType:   enumor.EipCloudResType,
+					ID:        one.ID,
+					CloudID:   one.CloudID,
+					Name:      converter.PtrToVal(one.Name),
+					AccountID: one.AccountID,
+					Region:    one.Region,
+				})
+			}
+		}
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return resources, nil
+}
+
+// listUnassignedLoadBalancer 查询未分配的负载均衡，目前只支持分配腾讯云负载均衡
+func listUnassignedLoadBalancer(kt *kit.Kit, cli *dataservice.Client, accountID string) (
+	[]coreassign.Resource, error) {
+
+	resources := make([]coreassign.Resource, 0)
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("bk_biz_id", constant.UnassignedBiz),
+			tools.RuleEqual("vendor", enumor.TCloud),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "cloud_id", "name", "account_id", "region", "cloud_vpc_id", "tags"},
+	}
+	for {
+		result, err := cli.Global.LoadBalancer.ListLoadBalancer(kt, listReq)
+		if err != nil {
+			logs.Errorf("list unassigned load balancer failed, err: %v, account: %s, rid: %s", err, accountID,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			res := coreassign.Resource{
+				ResType:   enumor.LoadBalancerCloudResType,
+				ID:        one.ID,
+				CloudID:   one.CloudID,
+				Name:      one.Name,
+				AccountID: one.AccountID,
+				Region:    one.Region,
+				Tags:      one.Tags,
+			}
+			if len(one.CloudVpcID) != 0 {
+				res.CloudVpcIDs = []string{one.CloudVpcID}
+			}
+			resources = append(resources, res)
+		}
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return resources, nil
+}
@@ -24,6 +24,7 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/pkg/api/cloud-server/recycle"
 	"hcm/pkg/api/core"
 	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/client"
@@ -41,6 +42,7 @@ type Interface interface {
 		records []rr.CvmRecycleRecord) (*core.BatchOperateResult, error)
 	GetNotCmdbRecyclableHosts(kt *kit.Kit, bizHostsIds map[int64][]string) ([]string, error)
 	RecyclePreCheck(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) error
+	BatchRecycle(kt *kit.Kit, ids []string, cvmStatus map[string]*recycle.CvmDetail) (string, error)
 	BatchFinalizeRelRecord(kt *kit.Kit, resType enumor.CloudResourceType,
 		status enumor.RecycleRecordStatus, resIds []string) error
 }
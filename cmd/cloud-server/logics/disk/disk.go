@@ -30,6 +30,7 @@ import (
 	protoaudit "hcm/pkg/api/data-service/audit"
 	"hcm/pkg/api/data-service/cloud"
 	hcproto "hcm/pkg/api/hc-service/disk"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -47,11 +48,16 @@ import (
 type Interface interface {
 	DetachDisk(kt *kit.Kit, vendor enumor.Vendor, cvmID, diskID string) error
 	DeleteDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) error
-	DeleteRecycledDisk(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error)
+	DeleteRecycledDisk(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo,
+		records []recyclerecord.DiskRecycleRecord) (*core.BatchOperateResult, error)
 
 	BatchGetDiskInfo(kt *kit.Kit, cvmDetail map[string]*recycle.CvmDetail) (err error)
 	BatchDetach(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) (failed []string, err error)
 	BatchReattachDisk(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) (err error)
+
+	CreateDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcsnapshot.DiskSnapshotCreateReq) (
+		*core.CreateResult, error)
+	DeleteDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id string) error
 }
 type disk struct {
 	client *client.ClientSet
@@ -120,23 +126,29 @@ func (d *disk) DeleteDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) erro
 
 	switch vendor {
 	case enumor.TCloud:
-		return d.client.HCService().TCloud.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
+		err = d.client.HCService().TCloud.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
 	case enumor.Aws:
-		return d.client.HCService().Aws.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
+		err = d.client.HCService().Aws.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
 	case enumor.HuaWei:
-		return d.client.HCService().HuaWei.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
+		err = d.client.HCService().HuaWei.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
 	case enumor.Gcp:
-		return d.client.HCService().Gcp.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
+		err = d.client.HCService().Gcp.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
 	case enumor.Azure:
-		return d.client.HCService().Azure.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
+		err = d.client.HCService().Azure.Disk.DeleteDisk(kt.Ctx, kt.Header(), deleteReq)
 	default:
 		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
 	}
+	if err != nil {
+		return err
+	}
+
+	// 云硬盘删除后保留的快照不再随云硬盘处于回收状态
+	return d.releaseSnapshotByDisk(kt, diskID)
 }
 
 // DeleteRecycledDisk batch delete recycled disk.
-func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
-	*core.BatchOperateResult, error) {
+func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo,
+	records []recyclerecord.DiskRecycleRecord) (*core.BatchOperateResult, error) {
 
 	if len(basicInfoMap) == 0 {
 		return nil, nil
@@ -166,11 +178,24 @@ func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.Clo
 		return nil, errf.New(errf.InvalidParameter, "recycled disk is attached, cannot be deleted")
 	}
 
+	// 获取回收时的回收选项
+	recycleOpts := make(map[string]recyclerecord.DiskRecycleOptions, len(records))
+	for _, record := range records {
+		recycleOpts[record.ResID] = record.Detail
+	}
+
 	res := new(core.BatchOperateResult)
 
 	// delete disk
 	for _, id := range ids {
 		info := basicInfoMap[id]
+		if recycleOpts[id].WithSnapshot {
+			if err = d.deleteSnapshotByDisk(kt, info.Vendor, id); err != nil {
+				res.Failed = &core.FailedInfo{ID: id, Error: err}
+				return res, err
+			}
+		}
+
 		err = d.DeleteDisk(kt, info.Vendor, id)
 		if err != nil {
 			res.Failed = &core.FailedInfo{ID: id, Error: err}
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// CreateDiskSnapshot create disk snapshot.
+func (d *disk) CreateDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcsnapshot.DiskSnapshotCreateReq) (
+	*core.CreateResult, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return d.client.HCService().TCloud.DiskSnapshot.Create(kt, req)
+	case enumor.Aws:
+		return d.client.HCService().Aws.DiskSnapshot.Create(kt, req)
+	case enumor.HuaWei:
+		return d.client.HCService().HuaWei.DiskSnapshot.Create(kt, req)
+	default:
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("disk snapshot no support vendor: %s", vendor))
+	}
+}
+
+// DeleteDiskSnapshot delete disk snapshot.
+func (d *disk) DeleteDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, id string) error {
+	// create delete audit.
+	err := d.audit.ResDeleteAudit(kt, enumor.DiskSnapshotAuditResType, []string{id})
+	if err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	deleteReq := &hcsnapshot.DiskSnapshotDeleteReq{ID: id}
+
+	switch vendor {
+	case enumor.TCloud:
+		return d.client.HCService().TCloud.DiskSnapshot.Delete(kt, deleteReq)
+	case enumor.Aws:
+		return d.client.HCService().Aws.DiskSnapshot.Delete(kt, deleteReq)
+	case enumor.HuaWei:
+		return d.client.HCService().HuaWei.DiskSnapshot.Delete(kt, deleteReq)
+	default:
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("disk snapshot no support vendor: %s", vendor))
+	}
+}
+
+// deleteSnapshotByDisk 删除云硬盘的全部快照
+func (d *disk) deleteSnapshotByDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) error {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("disk_id", diskID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	for {
+		result, err := d.client.DataService().Global.DiskSnapshot.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list disk snapshot failed, err: %v, disk: %s, rid: %s", err, diskID, kt.Rid)
+			return err
+		}
+
+		for _, one := range result.Details {
+			if err = d.DeleteDiskSnapshot(kt, vendor, one.ID); err != nil {
+				logs.Errorf("delete disk snapshot failed, err: %v, id: %s, disk: %s, rid: %s", err, one.ID, diskID,
+					kt.Rid)
+				return err
+			}
+		}
+
+		// 快照删除后会从db中移除，因此始终查询第一页
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// releaseSnapshotByDisk 云硬盘删除后保留的快照移出回收站
+func (d *disk) releaseSnapshotByDisk(kt *kit.Kit, diskID string) error {
+	req := &dssnapshot.UpdateDiskSnapshotRecycleStatusReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("disk_id", diskID),
+			tools.RuleEqual("recycle_status", enumor.RecycleStatus),
+		),
+		RecycleStatus: enumor.RecoverStatus,
+	}
+	if err := d.client.DataService().Global.DiskSnapshot.UpdateRecycleStatus(kt, req); err != nil {
+		logs.Errorf("release disk snapshot from recycle bin failed, err: %v, disk: %s, rid: %s", err, diskID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resspec
+
+import (
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/tools/counter"
+)
+
+// BuildFlow 将执行计划转换为任务流，每个需要变更的资源对应一个任务，任务依赖前一个执行阶段的全部任务。
+// 没有需要变更的资源时返回nil。
+func (r *Result) BuildFlow() *ts.AddCustomFlowReq {
+	if len(r.tasks) == 0 {
+		return nil
+	}
+
+	ctrFunc := counter.NewNumStringCounter(1, 10)
+	tasks := make([]ts.CustomFlowTask, 0, len(r.tasks))
+	prevStageIDs := make([]action.ActIDType, 0)
+	curStageIDs := make([]action.ActIDType, 0)
+	curStage := r.tasks[0].stage
+	for _, one := range r.tasks {
+		if one.stage != curStage {
+			prevStageIDs = curStageIDs
+			curStageIDs = make([]action.ActIDType, 0)
+			curStage = one.stage
+		}
+
+		actionID := action.ActIDType(ctrFunc())
+		curStageIDs = append(curStageIDs, actionID)
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   actionID,
+			ActionName: enumor.ActionApplyResSpec,
+			Params:     one.opt,
+			DependOn:   prevStageIDs,
+		})
+	}
+
+	return &ts.AddCustomFlowReq{
+		Name:      enumor.FlowApplyResSpec,
+		Memo:      "apply res spec plan " + r.Plan.Digest,
+		ShareData: tableasync.NewShareData(r.refs),
+		Tasks:     tasks,
+	}
+}
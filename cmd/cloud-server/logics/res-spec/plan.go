@@ -0,0 +1,451 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resspec 根据声明式资源描述与云管中已同步的资源状态计算执行计划，并将执行计划转换为异步任务流。
+package resspec
+
+import (
+	"slices"
+
+	actionresspec "hcm/cmd/task-server/logics/action/res-spec"
+	csspec "hcm/pkg/api/cloud-server/res-spec"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// 执行阶段，同一阶段的变更可以并行执行，后一阶段依赖前一阶段全部完成。
+// 删除主机后才能删除其使用的子网、安全组，删除子网后才能删除VPC；创建顺序与之相反。
+const (
+	stageDeleteCvm = iota
+	stageDeleteNetwork
+	stageDeleteVpc
+	stageVpc
+	stageNetwork
+	stageCvm
+	stageCount
+)
+
+// Result 执行计划及执行计划中需要变更的资源对应的任务参数
+type Result struct {
+	Plan *csspec.Plan
+	// refs 已存在资源的引用，作为任务流的初始共享数据，供后序任务获取资源ID
+	refs  map[string]string
+	tasks []planTask
+}
+
+type planTask struct {
+	stage int
+	opt   *actionresspec.ApplyOption
+}
+
+// Plan 计算资源描述的执行计划，资源描述所属账号需为腾讯云账号。
+func Plan(kt *kit.Kit, cli *dataservice.Client, spec *csspec.Spec) (*Result, error) {
+	st, err := loadState(kt, cli, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		spec: spec,
+		st:   st,
+		refs: make(map[string]string),
+	}
+	if err = p.plan(); err != nil {
+		return nil, err
+	}
+
+	plan := &csspec.Plan{AccountID: spec.AccountID, Region: spec.Region, Changes: make([]csspec.Change, 0)}
+	tasks := make([]planTask, 0)
+	for stage := 0; stage < stageCount; stage++ {
+		plan.Changes = append(plan.Changes, p.changes[stage]...)
+		for _, opt := range p.opts[stage] {
+			tasks = append(tasks, planTask{stage: stage, opt: opt})
+		}
+	}
+	if err = plan.Seal(); err != nil {
+		return nil, err
+	}
+
+	return &Result{Plan: plan, refs: p.refs, tasks: tasks}, nil
+}
+
+type planner struct {
+	spec    *csspec.Spec
+	st      *state
+	refs    map[string]string
+	changes [stageCount][]csspec.Change
+	opts    [stageCount][]*actionresspec.ApplyOption
+}
+
+// add 记录变更，opt 为空表示无需执行任务，changes 为附属于该任务的其他变更，如安全组规则变更
+func (p *planner) add(stage int, opt *actionresspec.ApplyOption, change csspec.Change, changes ...csspec.Change) {
+	p.changes[stage] = append(p.changes[stage], change)
+	p.changes[stage] = append(p.changes[stage], changes...)
+	if opt == nil {
+		return
+	}
+
+	opt.AccountID = p.spec.AccountID
+	opt.Region = p.spec.Region
+	opt.Change = change
+	p.opts[stage] = append(p.opts[stage], opt)
+}
+
+func (p *planner) addRef(resType enumor.CloudResourceType, ref, id, cloudID string) {
+	p.refs[actionresspec.RefKey(resType, ref)] = actionresspec.RefValue{ID: id, CloudID: cloudID}.Encode()
+}
+
+func (p *planner) plan() error {
+	for i := range p.spec.Vpcs {
+		if err := p.planVpc(&p.spec.Vpcs[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range p.spec.SecurityGroups {
+		if err := p.planSecurityGroup(&p.spec.SecurityGroups[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range p.spec.Cvms {
+		if err := p.planCvm(&p.spec.Cvms[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) planVpc(spec *csspec.VpcSpec) error {
+	vpc, exist := p.st.vpcs[spec.Name]
+	change := csspec.Change{ResType: enumor.VpcCloudResType, Ref: spec.Name}
+	if exist {
+		change.ID, change.CloudID = vpc.ID, vpc.CloudID
+	}
+
+	switch {
+	case spec.State == csspec.Absent && !exist:
+		change.Action = csspec.ChangeNoOp
+		p.add(stageVpc, nil, change)
+
+	case spec.State == csspec.Absent:
+		if err := checkOperable(change, vpc.BkBizID, ""); err != nil {
+			return err
+		}
+		change.Action = csspec.ChangeDelete
+		p.add(stageDeleteVpc, new(actionresspec.ApplyOption), change)
+
+	case !exist:
+		change.Action = csspec.ChangeCreate
+		change.Diffs = []csspec.FieldDiff{
+			{Field: "name", After: spec.Name},
+			{Field: "ipv4_cidr", After: spec.IPv4Cidr},
+			{Field: "memo", After: converter.PtrToVal(spec.Memo)},
+		}
+		p.add(stageVpc, &actionresspec.ApplyOption{Vpc: spec}, change)
+
+	default:
+		cidrs := make([]string, 0)
+		if vpc.Extension != nil {
+			for _, one := range vpc.Extension.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+		}
+		if !slices.Contains(cidrs, spec.IPv4Cidr) {
+			return errf.Newf(errf.InvalidParameter, "vpc %s ipv4_cidr %s differs from existing %v, which can not "+
+				"be changed", spec.Name, spec.IPv4Cidr, cidrs)
+		}
+		p.addRef(enumor.VpcCloudResType, spec.Name, vpc.ID, vpc.CloudID)
+
+		change.Action = csspec.ChangeNoOp
+		var opt *actionresspec.ApplyOption
+		if diff, changed := memoDiff(spec.Memo, vpc.Memo); changed {
+			if err := checkOperable(change, vpc.BkBizID, ""); err != nil {
+				return err
+			}
+			change.Action = csspec.ChangeUpdate
+			change.Diffs = []csspec.FieldDiff{diff}
+			opt = &actionresspec.ApplyOption{Vpc: spec}
+		}
+		p.add(stageVpc, opt, change)
+	}
+
+	for i := range spec.Subnets {
+		if err := p.planSubnet(spec, &spec.Subnets[i], exist); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) planSubnet(vpcSpec *csspec.VpcSpec, spec *csspec.SubnetSpec, vpcExist bool) error {
+	ref := csspec.SubnetRef(vpcSpec.Name, spec.Name)
+	subnet, exist := p.st.subnets[ref]
+	change := csspec.Change{ResType: enumor.SubnetCloudResType, Ref: ref}
+	if exist {
+		change.ID, change.CloudID = subnet.ID, subnet.CloudID
+	}
+
+	switch {
+	case spec.State == csspec.Absent && !exist:
+		change.Action = csspec.ChangeNoOp
+		p.add(stageNetwork, nil, change)
+
+	case spec.State == csspec.Absent:
+		if err := checkOperable(change, subnet.BkBizID, ""); err != nil {
+			return err
+		}
+		change.Action = csspec.ChangeDelete
+		p.add(stageDeleteNetwork, new(actionresspec.ApplyOption), change)
+
+	case !exist:
+		change.Action = csspec.ChangeCreate
+		change.Diffs = []csspec.FieldDiff{
+			{Field: "name", After: spec.Name},
+			{Field: "zone", After: spec.Zone},
+			{Field: "ipv4_cidr", After: spec.IPv4Cidr},
+			{Field: "memo", After: converter.PtrToVal(spec.Memo)},
+		}
+		p.add(stageNetwork, &actionresspec.ApplyOption{VpcName: vpcSpec.Name, Subnet: spec}, change)
+
+	default:
+		if subnet.Zone != spec.Zone || !slices.Contains(subnet.Ipv4Cidr, spec.IPv4Cidr) {
+			return errf.Newf(errf.InvalidParameter, "subnet %s zone %s or ipv4_cidr %s differs from existing %s %v, "+
+				"which can not be changed", ref, spec.Zone, spec.IPv4Cidr, subnet.Zone, subnet.Ipv4Cidr)
+		}
+		p.addRef(enumor.SubnetCloudResType, ref, subnet.ID, subnet.CloudID)
+
+		change.Action = csspec.ChangeNoOp
+		var opt *actionresspec.ApplyOption
+		if diff, changed := memoDiff(spec.Memo, subnet.Memo); changed {
+			if err := checkOperable(change, subnet.BkBizID, ""); err != nil {
+				return err
+			}
+			change.Action = csspec.ChangeUpdate
+			change.Diffs = []csspec.FieldDiff{diff}
+			opt = &actionresspec.ApplyOption{VpcName: vpcSpec.Name, Subnet: spec}
+		}
+		p.add(stageNetwork, opt, change)
+	}
+
+	return nil
+}
+
+func (p *planner) planSecurityGroup(spec *csspec.SecurityGroupSpec) error {
+	sg, exist := p.st.sgs[spec.Name]
+	change := csspec.Change{ResType: enumor.SecurityGroupCloudResType, Ref: spec.Name}
+	if exist {
+		change.ID, change.CloudID = sg.ID, sg.CloudID
+	}
+
+	switch {
+	case spec.State == csspec.Absent && !exist:
+		change.Action = csspec.ChangeNoOp
+		p.add(stageNetwork, nil, change)
+
+	case spec.State == csspec.Absent:
+		if err := checkOperable(change, sg.BkBizID, ""); err != nil {
+			return err
+		}
+		change.Action = csspec.ChangeDelete
+		p.add(stageDeleteNetwork, new(actionresspec.ApplyOption), change)
+
+	case !exist:
+		change.Action = csspec.ChangeCreate
+		change.Diffs = []csspec.FieldDiff{
+			{Field: "name", After: spec.Name},
+			{Field: "memo", After: converter.PtrToVal(spec.Memo)},
+		}
+		opt := &actionresspec.ApplyOption{
+			SecurityGroup:      spec,
+			CreateIngressRules: spec.IngressRules,
+			CreateEgressRules:  spec.EgressRules,
+		}
+		ruleChanges := make([]csspec.Change, 0)
+		ruleChanges = append(ruleChanges, createRuleChanges(spec.Name, enumor.Ingress, spec.IngressRules)...)
+		ruleChanges = append(ruleChanges, createRuleChanges(spec.Name, enumor.Egress, spec.EgressRules)...)
+		p.add(stageNetwork, opt, change, ruleChanges...)
+
+	default:
+		p.addRef(enumor.SecurityGroupCloudResType, spec.Name, sg.ID, sg.CloudID)
+
+		opt := &actionresspec.ApplyOption{SecurityGroup: spec}
+		if diff, changed := memoDiff(spec.Memo, sg.Memo); changed {
+			change.Diffs = []csspec.FieldDiff{diff}
+		}
+
+		// 规则按集合比较，先列出需要删除的多余规则，再列出需要追加的缺少规则
+		desired := make(map[string]struct{})
+		for i := range spec.IngressRules {
+			desired[specRuleKey(enumor.Ingress, &spec.IngressRules[i])] = struct{}{}
+		}
+		for i := range spec.EgressRules {
+			desired[specRuleKey(enumor.Egress, &spec.EgressRules[i])] = struct{}{}
+		}
+
+		existRules := p.st.sgRules[sg.ID]
+		ruleChanges := make([]csspec.Change, 0)
+		keys := converter.MapKeyToSlice(existRules)
+		slices.Sort(keys)
+		for _, key := range keys {
+			if _, ok := desired[key]; ok {
+				continue
+			}
+			rule := existRules[key]
+			opt.DeleteRuleIDs = append(opt.DeleteRuleIDs, rule.ID)
+			ruleChanges = append(ruleChanges, csspec.Change{
+				Action:  csspec.ChangeDelete,
+				ResType: csspec.SecurityGroupRuleResType,
+				Ref:     spec.Name + "/" + key,
+				ID:      rule.ID,
+			})
+		}
+
+		opt.CreateIngressRules = missingRules(enumor.Ingress, spec.IngressRules, existRules)
+		opt.CreateEgressRules = missingRules(enumor.Egress, spec.EgressRules, existRules)
+		ruleChanges = append(ruleChanges, createRuleChanges(spec.Name, enumor.Ingress, opt.CreateIngressRules)...)
+		ruleChanges = append(ruleChanges, createRuleChanges(spec.Name, enumor.Egress, opt.CreateEgressRules)...)
+
+		// 安全组本身无变化但规则有变化时，同样作为安全组的更新执行
+		if len(change.Diffs) == 0 && len(ruleChanges) == 0 {
+			change.Action = csspec.ChangeNoOp
+			opt = nil
+		} else {
+			if err := checkOperable(change, sg.BkBizID, ""); err != nil {
+				return err
+			}
+			change.Action = csspec.ChangeUpdate
+		}
+		p.add(stageNetwork, opt, change, ruleChanges...)
+	}
+
+	return nil
+}
+
+func (p *planner) planCvm(spec *csspec.CvmSpec) error {
+	cvm, exist := p.st.cvms[spec.Name]
+	change := csspec.Change{ResType: enumor.CvmCloudResType, Ref: spec.Name}
+	if exist {
+		change.ID, change.CloudID = cvm.ID, cvm.CloudID
+	}
+
+	switch {
+	case spec.State == csspec.Absent && !exist:
+		change.Action = csspec.ChangeNoOp
+		p.add(stageCvm, nil, change)
+
+	case spec.State == csspec.Absent:
+		if err := checkOperable(change, cvm.BkBizID, cvm.RecycleStatus); err != nil {
+			return err
+		}
+		change.Action = csspec.ChangeDelete
+		p.add(stageDeleteCvm, new(actionresspec.ApplyOption), change)
+
+	case !exist:
+		change.Action = csspec.ChangeCreate
+		change.Diffs = []csspec.FieldDiff{
+			{Field: "name", After: spec.Name},
+			{Field: "zone", After: spec.Zone},
+			{Field: "instance_type", After: spec.InstanceType},
+			{Field: "cloud_image_id", After: spec.CloudImageID},
+			{Field: "vpc", After: spec.Vpc},
+			{Field: "subnet", After: spec.Subnet},
+			{Field: "security_groups", After: spec.SecurityGroups},
+			{Field: "instance_charge_type", After: spec.InstanceChargeType},
+		}
+		p.add(stageCvm, &actionresspec.ApplyOption{Cvm: spec}, change)
+
+	default:
+		// 已存在的主机不做变更，只校验不可变属性与资源描述一致，避免误以为资源描述已生效
+		if cvm.Zone != spec.Zone || cvm.MachineType != spec.InstanceType {
+			return errf.Newf(errf.InvalidParameter, "cvm %s zone %s or instance_type %s differs from existing %s %s, "+
+				"which can not be changed by spec", spec.Name, spec.Zone, spec.InstanceType, cvm.Zone, cvm.MachineType)
+		}
+		change.Action = csspec.ChangeNoOp
+		p.add(stageCvm, nil, change)
+	}
+
+	return nil
+}
+
+// checkOperable 与资源下的其他操作一致，不允许变更已分配到业务或已回收的资源
+func checkOperable(change csspec.Change, bizID int64, recycleStatus string) error {
+	if bizID != constant.UnassignedBiz && bizID != 0 {
+		return errf.Newf(errf.InvalidParameter, "%s %s is already assigned to biz %d, can not be changed by spec",
+			change.ResType, change.Ref, bizID)
+	}
+
+	if recycleStatus == enumor.RecycleStatus {
+		return errf.Newf(errf.InvalidParameter, "%s %s is in recycle bin, can not be changed by spec",
+			change.ResType, change.Ref)
+	}
+
+	return nil
+}
+
+// memoDiff 资源描述中未设置备注时不比较备注
+func memoDiff(desired, current *string) (csspec.FieldDiff, bool) {
+	if desired == nil || *desired == converter.PtrToVal(current) {
+		return csspec.FieldDiff{}, false
+	}
+
+	return csspec.FieldDiff{Field: "memo", Before: converter.PtrToVal(current), After: *desired}, true
+}
+
+func specRuleKey(typ enumor.SecurityGroupRuleType, rule *csspec.SGRuleSpec) string {
+	return string(typ) + "/" + rule.Key()
+}
+
+func ruleStateKey(rule *corecloud.TCloudSecurityGroupRule) string {
+	return string(rule.Type) + "/" + csspec.RuleKey(converter.PtrToVal(rule.Protocol), converter.PtrToVal(rule.Port),
+		converter.PtrToVal(rule.IPv4Cidr), converter.PtrToVal(rule.IPv6Cidr), rule.Action)
+}
+
+func missingRules(typ enumor.SecurityGroupRuleType, rules []csspec.SGRuleSpec,
+	exist map[string]*corecloud.TCloudSecurityGroupRule) []csspec.SGRuleSpec {
+
+	result := make([]csspec.SGRuleSpec, 0)
+	for i := range rules {
+		if _, ok := exist[specRuleKey(typ, &rules[i])]; !ok {
+			result = append(result, rules[i])
+		}
+	}
+	return result
+}
+
+func createRuleChanges(sgName string, typ enumor.SecurityGroupRuleType, rules []csspec.SGRuleSpec) []csspec.Change {
+	changes := make([]csspec.Change, 0, len(rules))
+	for i := range rules {
+		changes = append(changes, csspec.Change{
+			Action:  csspec.ChangeCreate,
+			ResType: csspec.SecurityGroupRuleResType,
+			Ref:     sgName + "/" + specRuleKey(typ, &rules[i]),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,176 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resspec
+
+import (
+	"testing"
+
+	actionresspec "hcm/cmd/task-server/logics/action/res-spec"
+	csspec "hcm/pkg/api/cloud-server/res-spec"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+const testSpec = `
+account_id: "00000001"
+region: ap-guangzhou
+vpcs:
+  - name: vpc-a
+    ipv4_cidr: 10.0.0.0/16
+    subnets:
+      - {name: subnet-a, zone: ap-guangzhou-3, ipv4_cidr: 10.0.1.0/24}
+security_groups:
+  - name: sg-a
+    ingress_rules:
+      - {protocol: TCP, port: 22, ipv4_cidr: 10.0.0.0/8, action: ACCEPT}
+cvms:
+  - name: web-1
+    zone: ap-guangzhou-3
+    instance_type: S5.MEDIUM2
+    cloud_image_id: img-xxxxxx
+    vpc: vpc-a
+    subnet: subnet-a
+    security_groups: [sg-a]
+    password: Passw0rd@123
+    instance_charge_type: POSTPAID_BY_HOUR
+    system_disk: {disk_type: CLOUD_PREMIUM, disk_size_gb: 50}
+  - name: web-0
+    state: absent
+`
+
+func testState() *state {
+	extraRule := &corecloud.TCloudSecurityGroupRule{ID: "rule-1", Type: enumor.Ingress,
+		Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("3306"),
+		IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT"}
+
+	return &state{
+		vpcs: map[string]*corecloud.Vpc[corecloud.TCloudVpcExtension]{
+			"vpc-a": {
+				BaseVpc: corecloud.BaseVpc{ID: "vpc-id", CloudID: "vpc-cloud", Name: "vpc-a",
+					BkBizID: constant.UnassignedBiz},
+				Extension: &corecloud.TCloudVpcExtension{Cidr: []corecloud.TCloudCidr{
+					{Type: enumor.Ipv4, Cidr: "10.0.0.0/16"}}},
+			},
+		},
+		subnets: map[string]*corecloud.BaseSubnet{},
+		sgs: map[string]*corecloud.BaseSecurityGroup{
+			"sg-a": {ID: "sg-id", CloudID: "sg-cloud", Name: "sg-a", BkBizID: constant.UnassignedBiz},
+		},
+		sgRules: map[string]map[string]*corecloud.TCloudSecurityGroupRule{
+			"sg-id": {ruleStateKey(extraRule): extraRule},
+		},
+		cvms: map[string]*corecvm.BaseCvm{
+			"web-0": {ID: "cvm-id", Name: "web-0", BkBizID: constant.UnassignedBiz},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	spec, err := csspec.ParseSpec(testSpec)
+	if err != nil {
+		t.Fatalf("parse spec failed, err: %v", err)
+	}
+
+	p := &planner{spec: spec, st: testState(), refs: make(map[string]string)}
+	if err = p.plan(); err != nil {
+		t.Fatalf("plan failed, err: %v", err)
+	}
+
+	expects := map[int][]csspec.ChangeAction{
+		stageDeleteCvm: {csspec.ChangeDelete},
+		stageVpc:       {csspec.ChangeNoOp},
+		// 子网创建、安全组更新（删除一条多余规则、追加一条缺少的规则）
+		stageNetwork: {csspec.ChangeCreate, csspec.ChangeUpdate, csspec.ChangeDelete, csspec.ChangeCreate},
+		stageCvm:     {csspec.ChangeCreate},
+	}
+	for stage := 0; stage < stageCount; stage++ {
+		if len(p.changes[stage]) != len(expects[stage]) {
+			t.Fatalf("stage %d expect %d changes, but got %+v", stage, len(expects[stage]), p.changes[stage])
+		}
+		for i, change := range p.changes[stage] {
+			if change.Action != expects[stage][i] {
+				t.Errorf("stage %d change %s expect %s, but got %s", stage, change.Ref, expects[stage][i],
+					change.Action)
+			}
+		}
+	}
+
+	sgOpt := p.opts[stageNetwork][1]
+	if len(sgOpt.DeleteRuleIDs) != 1 || sgOpt.DeleteRuleIDs[0] != "rule-1" || len(sgOpt.CreateIngressRules) != 1 {
+		t.Errorf("unexpected security group rule changes: %+v", sgOpt)
+	}
+
+	if _, exist := p.refs[actionresspec.RefKey(enumor.VpcCloudResType, "vpc-a")]; !exist {
+		t.Errorf("existing vpc should be saved as ref")
+	}
+}
+
+func TestPlanImmutable(t *testing.T) {
+	spec, err := csspec.ParseSpec(testSpec)
+	if err != nil {
+		t.Fatalf("parse spec failed, err: %v", err)
+	}
+
+	st := testState()
+	st.vpcs["vpc-a"].Extension.Cidr[0].Cidr = "10.1.0.0/16"
+	p := &planner{spec: spec, st: st, refs: make(map[string]string)}
+	if err = p.plan(); err == nil {
+		t.Errorf("changing vpc cidr should be rejected")
+	}
+
+	st = testState()
+	st.cvms["web-0"].BkBizID = 100
+	p = &planner{spec: spec, st: st, refs: make(map[string]string)}
+	if err = p.plan(); err == nil {
+		t.Errorf("deleting cvm assigned to biz should be rejected")
+	}
+}
+
+func TestBuildFlow(t *testing.T) {
+	result := &Result{
+		Plan: &csspec.Plan{Digest: "digest"},
+		tasks: []planTask{
+			{stage: stageDeleteCvm, opt: new(actionresspec.ApplyOption)},
+			{stage: stageNetwork, opt: new(actionresspec.ApplyOption)},
+			{stage: stageNetwork, opt: new(actionresspec.ApplyOption)},
+			{stage: stageCvm, opt: new(actionresspec.ApplyOption)},
+		},
+	}
+
+	flow := result.BuildFlow()
+	expects := [][]string{nil, {"1"}, {"1"}, {"2", "3"}}
+	for i, task := range flow.Tasks {
+		if len(task.DependOn) != len(expects[i]) {
+			t.Fatalf("task %s expect depend on %v, but got %v", task.ActionID, expects[i], task.DependOn)
+		}
+		for j := range task.DependOn {
+			if string(task.DependOn[j]) != expects[i][j] {
+				t.Errorf("task %s expect depend on %v, but got %v", task.ActionID, expects[i], task.DependOn)
+			}
+		}
+	}
+
+	if (&Result{Plan: &csspec.Plan{}}).BuildFlow() != nil {
+		t.Errorf("flow should be nil when there is no task")
+	}
+}
@@ -0,0 +1,228 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resspec
+
+import (
+	"fmt"
+
+	csspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// state 资源描述中声明的资源在云管中已同步的状态，资源按名称索引。
+type state struct {
+	vpcs    map[string]*corecloud.Vpc[corecloud.TCloudVpcExtension]
+	subnets map[string]*corecloud.BaseSubnet
+	sgs     map[string]*corecloud.BaseSecurityGroup
+	// sgRules 安全组ID -> 规则方向+规则标识 -> 规则
+	sgRules map[string]map[string]*corecloud.TCloudSecurityGroupRule
+	cvms    map[string]*corecvm.BaseCvm
+}
+
+func loadState(kt *kit.Kit, cli *dataservice.Client, spec *csspec.Spec) (*state, error) {
+	st := &state{
+		vpcs:    make(map[string]*corecloud.Vpc[corecloud.TCloudVpcExtension]),
+		subnets: make(map[string]*corecloud.BaseSubnet),
+		sgs:     make(map[string]*corecloud.BaseSecurityGroup),
+		sgRules: make(map[string]map[string]*corecloud.TCloudSecurityGroupRule),
+		cvms:    make(map[string]*corecvm.BaseCvm),
+	}
+
+	if err := st.loadVpcs(kt, cli, spec); err != nil {
+		logs.Errorf("load vpc state failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if err := st.loadSecurityGroups(kt, cli, spec); err != nil {
+		logs.Errorf("load security group state failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if err := st.loadCvms(kt, cli, spec); err != nil {
+		logs.Errorf("load cvm state failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return st, nil
+}
+
+// listByNameReq 按名称查询资源描述所在账号、地域下的资源
+func listByNameReq(spec *csspec.Spec, names []string) *core.ListReq {
+	return &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("account_id", spec.AccountID),
+			tools.RuleEqual("region", spec.Region),
+			tools.RuleIn("name", names),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+}
+
+func (st *state) loadVpcs(kt *kit.Kit, cli *dataservice.Client, spec *csspec.Spec) error {
+	if len(spec.Vpcs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(spec.Vpcs))
+	for _, one := range spec.Vpcs {
+		names = append(names, one.Name)
+	}
+
+	result, err := cli.TCloud.Vpc.ListVpcExt(kt.Ctx, kt.Header(), listByNameReq(spec, names))
+	if err != nil {
+		return err
+	}
+
+	for i := range result.Details {
+		vpc := &result.Details[i]
+		if _, exist := st.vpcs[vpc.Name]; exist {
+			return fmt.Errorf("there are multiple vpcs named %s, can not be managed by spec", vpc.Name)
+		}
+		st.vpcs[vpc.Name] = vpc
+	}
+
+	for _, one := range spec.Vpcs {
+		vpc, exist := st.vpcs[one.Name]
+		if !exist || len(one.Subnets) == 0 {
+			continue
+		}
+
+		subnetNames := make([]string, 0, len(one.Subnets))
+		for _, subnet := range one.Subnets {
+			subnetNames = append(subnetNames, subnet.Name)
+		}
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(tools.RuleEqual("vpc_id", vpc.ID), tools.RuleIn("name", subnetNames)),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnets, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return err
+		}
+
+		for i := range subnets.Details {
+			subnet := &subnets.Details[i]
+			ref := csspec.SubnetRef(one.Name, subnet.Name)
+			if _, exist := st.subnets[ref]; exist {
+				return fmt.Errorf("there are multiple subnets named %s, can not be managed by spec", ref)
+			}
+			st.subnets[ref] = subnet
+		}
+	}
+
+	return nil
+}
+
+func (st *state) loadSecurityGroups(kt *kit.Kit, cli *dataservice.Client, spec *csspec.Spec) error {
+	if len(spec.SecurityGroups) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(spec.SecurityGroups))
+	for _, one := range spec.SecurityGroups {
+		names = append(names, one.Name)
+	}
+
+	listReq := listByNameReq(spec, names)
+	req := &protocloud.SecurityGroupListReq{Filter: listReq.Filter, Page: listReq.Page}
+	result, err := cli.Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		return err
+	}
+
+	for i := range result.Details {
+		sg := &result.Details[i]
+		if _, exist := st.sgs[sg.Name]; exist {
+			return fmt.Errorf("there are multiple security groups named %s, can not be managed by spec", sg.Name)
+		}
+		st.sgs[sg.Name] = sg
+
+		rules, err := listSGRules(kt, cli, sg.ID)
+		if err != nil {
+			return err
+		}
+		st.sgRules[sg.ID] = rules
+	}
+
+	return nil
+}
+
+func listSGRules(kt *kit.Kit, cli *dataservice.Client, sgID string) (
+	map[string]*corecloud.TCloudSecurityGroupRule, error) {
+
+	rules := make(map[string]*corecloud.TCloudSecurityGroupRule)
+	req := &protocloud.TCloudSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := cli.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range result.Details {
+			rule := &result.Details[i]
+			rules[ruleStateKey(rule)] = rule
+		}
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (st *state) loadCvms(kt *kit.Kit, cli *dataservice.Client, spec *csspec.Spec) error {
+	if len(spec.Cvms) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(spec.Cvms))
+	for _, one := range spec.Cvms {
+		names = append(names, one.Name)
+	}
+
+	result, err := cli.Global.Cvm.ListCvm(kt, listByNameReq(spec, names))
+	if err != nil {
+		return err
+	}
+
+	for i := range result.Details {
+		cvm := &result.Details[i]
+		if _, exist := st.cvms[cvm.Name]; exist {
+			return fmt.Errorf("there are multiple cvms named %s, can not be managed by spec", cvm.Name)
+		}
+		st.cvms[cvm.Name] = cvm
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accesstoken
+
+import (
+	csaccesstoken "hcm/pkg/api/cloud-server/access-token"
+	coretoken "hcm/pkg/api/core/access-token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// CreateAccessToken create personal access token of the request user.
+func (svc *tokenSvc) CreateAccessToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(csaccesstoken.CreateAccessTokenReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.createToken(cts, coretoken.UserOwner, cts.Kit.User, req)
+}
+
+// ListAccessToken list personal access tokens of the request user.
+func (svc *tokenSvc) ListAccessToken(cts *rest.Contexts) (interface{}, error) {
+	return svc.listToken(cts, coretoken.UserOwner, cts.Kit.User)
+}
+
+// RevokeAccessToken revoke personal access token of the request user.
+func (svc *tokenSvc) RevokeAccessToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	return nil, svc.revokeToken(cts, coretoken.UserOwner, cts.Kit.User, id)
+}
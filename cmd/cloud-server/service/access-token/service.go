@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken 个人访问令牌及服务账号管理
+package accesstoken
+
+import (
+	"net/http"
+	"time"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csaccesstoken "hcm/pkg/api/cloud-server/access-token"
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initialize the access token service.
+func InitService(c *capability.Capability) {
+	svc := &tokenSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	// 个人访问令牌，只能管理自己的令牌
+	h.Add("CreateAccessToken", http.MethodPost, "/access_tokens/create", svc.CreateAccessToken).
+		Reads(new(csaccesstoken.CreateAccessTokenReq)).Writes(new(csaccesstoken.CreateAccessTokenResult))
+	h.Add("ListAccessToken", http.MethodPost, "/access_tokens/list", svc.ListAccessToken).
+		Reads(new(core.ListReq)).Writes(new(dstoken.ListAccessTokenResult))
+	h.Add("RevokeAccessToken", http.MethodPost, "/access_tokens/{id}/revoke", svc.RevokeAccessToken)
+
+	// 服务账号及其令牌
+	h.Add("CreateServiceAccount", http.MethodPost, "/service_accounts/create", svc.CreateServiceAccount).
+		Reads(new(csaccesstoken.CreateServiceAccountReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateServiceAccount", http.MethodPatch, "/service_accounts/{id}", svc.UpdateServiceAccount).
+		Reads(new(csaccesstoken.UpdateServiceAccountReq))
+	h.Add("ListServiceAccount", http.MethodPost, "/service_accounts/list", svc.ListServiceAccount).
+		Reads(new(core.ListReq)).Writes(new(dstoken.ListServiceAccountResult))
+	h.Add("BatchDeleteServiceAccount", http.MethodDelete, "/service_accounts/batch", svc.BatchDeleteServiceAccount).
+		Reads(new(csaccesstoken.BatchDeleteReq))
+	h.Add("CreateServiceAccountToken", http.MethodPost, "/service_accounts/{id}/access_tokens/create",
+		svc.CreateServiceAccountToken).
+		Reads(new(csaccesstoken.CreateAccessTokenReq)).Writes(new(csaccesstoken.CreateAccessTokenResult))
+	h.Add("ListServiceAccountToken", http.MethodPost, "/service_accounts/{id}/access_tokens/list",
+		svc.ListServiceAccountToken).Reads(new(core.ListReq)).Writes(new(dstoken.ListAccessTokenResult))
+	h.Add("RevokeServiceAccountToken", http.MethodPost, "/service_accounts/{id}/access_tokens/{token_id}/revoke",
+		svc.RevokeServiceAccountToken)
+
+	h.Load(c.WebService)
+}
+
+type tokenSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *tokenSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.ServiceAccount, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// createToken create access token for the owner, the request authenticated by access token can not create new
+// tokens, so that the token scope can not be escalated.
+func (svc *tokenSvc) createToken(cts *rest.Contexts, ownerType coretoken.OwnerType, owner string,
+	req *csaccesstoken.CreateAccessTokenReq) (*csaccesstoken.CreateAccessTokenResult, error) {
+
+	if len(cts.Kit.TokenScope) != 0 {
+		return nil, errf.New(errf.PermissionDenied, "access token can not be created by access token")
+	}
+
+	raw, hash, err := coretoken.GenerateToken()
+	if err != nil {
+		logs.Errorf("generate access token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, int(req.ExpiresInDays)).Format(constant.TimeStdFormat)
+	createReq := &dstoken.BatchCreateAccessTokenReq{
+		Tokens: []dstoken.AccessTokenCreate{{
+			Name:        req.Name,
+			OwnerType:   ownerType,
+			Owner:       owner,
+			TokenHash:   hash,
+			TokenPrefix: raw[:coretoken.DisplayPrefixLen],
+			Scope:       req.Scope,
+			ExpiresAt:   expiresAt,
+			Memo:        req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.AccessToken.BatchCreateAccessToken(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create access token failed, err: %v, owner: %s/%s, rid: %s", err, ownerType, owner,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create access token result is invalid")
+	}
+
+	return &csaccesstoken.CreateAccessTokenResult{ID: result.IDs[0], Token: raw, ExpiresAt: expiresAt}, nil
+}
+
+// listToken list access tokens of the owner.
+func (svc *tokenSvc) listToken(cts *rest.Contexts, ownerType coretoken.OwnerType, owner string) (
+	*dstoken.ListAccessTokenResult, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tools.And(req.Filter, tools.RuleEqual("owner_type", ownerType), tools.RuleEqual("owner", owner))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.AccessToken.ListAccessToken(cts.Kit, req)
+}
+
+// revokeToken revoke the access token of the owner.
+func (svc *tokenSvc) revokeToken(cts *rest.Contexts, ownerType coretoken.OwnerType, owner, id string) error {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("id", id),
+			tools.RuleEqual("owner_type", ownerType),
+			tools.RuleEqual("owner", owner),
+		),
+		Page: core.NewCountPage(),
+	}
+	result, err := svc.client.DataService().Global.AccessToken.ListAccessToken(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list access token failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return err
+	}
+
+	if result.Count == 0 {
+		return errf.Newf(errf.RecordNotFound, "access token %s is not found", id)
+	}
+
+	updateReq := &dstoken.BatchUpdateAccessTokenReq{
+		Tokens: []dstoken.AccessTokenUpdate{{ID: id, Revoked: converter.ValToPtr(true)}},
+	}
+	if err = svc.client.DataService().Global.AccessToken.BatchUpdateAccessToken(cts.Kit, updateReq); err != nil {
+		logs.Errorf("revoke access token failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accesstoken
+
+import (
+	csaccesstoken "hcm/pkg/api/cloud-server/access-token"
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateServiceAccount create service account.
+func (svc *tokenSvc) CreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(csaccesstoken.CreateServiceAccountReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	createReq := &dstoken.BatchCreateServiceAccountReq{ServiceAccounts: []dstoken.ServiceAccountCreate{*req}}
+	if err := createReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkUserConflict(cts.Kit, req.Name); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.AccessToken.BatchCreateServiceAccount(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create service account failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create service account result is invalid")
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// checkUserConflict 服务账号名称不能与已知的真实用户相同，包括当前用户、被授予角色的用户以及持有个人访问令牌的用户，
+// 避免在用户界面及审计中混淆服务账号与用户
+func (svc *tokenSvc) checkUserConflict(kt *kit.Kit, name string) error {
+	if name == kt.User {
+		return errf.Newf(errf.InvalidParameter, "service account name %s conflicts with user", name)
+	}
+
+	bindingReq := &core.ListReq{Filter: tools.EqualExpression("user", name), Page: core.NewCountPage()}
+	bindings, err := svc.client.DataService().Global.Rbac.ListRoleBinding(kt, bindingReq)
+	if err != nil {
+		logs.Errorf("count role binding of user failed, err: %v, user: %s, rid: %s", err, name, kt.Rid)
+		return err
+	}
+
+	tokenReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("owner_type", coretoken.UserOwner),
+			tools.RuleEqual("owner", name),
+		),
+		Page: core.NewCountPage(),
+	}
+	tokens, err := svc.client.DataService().Global.AccessToken.ListAccessToken(kt, tokenReq)
+	if err != nil {
+		logs.Errorf("count access token of user failed, err: %v, user: %s, rid: %s", err, name, kt.Rid)
+		return err
+	}
+
+	if bindings.Count > 0 || tokens.Count > 0 {
+		return errf.Newf(errf.InvalidParameter, "service account name %s conflicts with user", name)
+	}
+
+	return nil
+}
+
+// UpdateServiceAccount update service account.
+func (svc *tokenSvc) UpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csaccesstoken.UpdateServiceAccountReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dstoken.BatchUpdateServiceAccountReq{
+		ServiceAccounts: []dstoken.ServiceAccountUpdate{{ID: id, Memo: req.Memo}},
+	}
+	if err := svc.client.DataService().Global.AccessToken.BatchUpdateServiceAccount(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update service account failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service accounts.
+func (svc *tokenSvc) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.AccessToken.ListServiceAccount(cts.Kit, req)
+}
+
+// BatchDeleteServiceAccount batch delete service accounts, the access tokens of the service accounts are deleted
+// together.
+func (svc *tokenSvc) BatchDeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(csaccesstoken.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.AccessToken.BatchDeleteServiceAccount(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete service account failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateServiceAccountToken create access token of service account.
+func (svc *tokenSvc) CreateServiceAccountToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(csaccesstoken.CreateAccessTokenReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	name, err := svc.getServiceAccountName(cts, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.createToken(cts, coretoken.ServiceAccountOwner, name, req)
+}
+
+// ListServiceAccountToken list access tokens of service account.
+func (svc *tokenSvc) ListServiceAccountToken(cts *rest.Contexts) (interface{}, error) {
+	name, err := svc.getServiceAccountName(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.listToken(cts, coretoken.ServiceAccountOwner, name)
+}
+
+// RevokeServiceAccountToken revoke access token of service account.
+func (svc *tokenSvc) RevokeServiceAccountToken(cts *rest.Contexts) (interface{}, error) {
+	tokenID := cts.PathParameter("token_id").String()
+	if len(tokenID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "token_id is required")
+	}
+
+	name, err := svc.getServiceAccountName(cts, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.revokeToken(cts, coretoken.ServiceAccountOwner, name, tokenID)
+}
+
+// getServiceAccountName authorize the action on service account and get the name of the service account in path.
+func (svc *tokenSvc) getServiceAccountName(cts *rest.Contexts, action meta.Action) (string, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return "", errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorize(cts, action); err != nil {
+		return "", err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"name"},
+	}
+	result, err := svc.client.DataService().Global.AccessToken.ListServiceAccount(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "service account %s is not found", id)
+	}
+
+	return result.Details[0].Name, nil
+}
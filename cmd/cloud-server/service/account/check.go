@@ -62,6 +62,10 @@ func (a *accountSvc) CheckAccount(cts *rest.Contexts) (interface{}, error) {
 		_, err = ParseAndCheckGcpExtension(cts, a.client, req.Type, req.Extension)
 	case enumor.Azure:
 		_, err = ParseAndCheckAzureExtension(cts, a.client, req.Type, req.Extension)
+	case enumor.Aliyun:
+		_, err = ParseAndCheckAliyunExtension(cts, a.client, req.Type, req.Extension)
+	case enumor.OpenStack:
+		_, err = ParseAndCheckOpenStackExtension(cts, a.client, req.Type, req.Extension)
 	default:
 		err = fmt.Errorf("no support vendor: %s", req.Vendor)
 	}
@@ -281,6 +285,10 @@ func (a *accountSvc) CheckByID(cts *rest.Contexts) (interface{}, error) {
 		_, err = a.parseAndCheckGcpExtensionByID(cts, accountID, req.Extension)
 	case enumor.Azure:
 		_, err = a.parseAndCheckAzureExtensionByID(cts, accountID, req.Extension)
+	case enumor.Aliyun:
+		_, err = a.parseAndCheckAliyunExtensionByID(cts, accountID, req.Extension)
+	case enumor.OpenStack:
+		_, err = a.parseAndCheckOpenStackExtensionByID(cts, accountID, req.Extension)
 	default:
 		err = fmt.Errorf("no support vendor: %s", baseInfo.Vendor)
 	}
@@ -558,3 +566,158 @@ func CheckDuplicateMainAccount(cts *rest.Contexts, client *client.ClientSet, ven
 
 	return nil
 }
+
+// ParseAndCheckAliyunExtension  联通性校验，并检查字段是否匹配
+func ParseAndCheckAliyunExtension(
+	cts *rest.Contexts, client *client.ClientSet, accountType enumor.AccountType, reqExtension json.RawMessage,
+) (*proto.AliyunAccountExtensionCreateReq, error) {
+	// 解析Extension
+	extension := new(proto.AliyunAccountExtensionCreateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+	// 校验Extension
+	if err := extension.Validate(accountType); err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，账号是否正确
+	if accountType != enumor.RegistrationAccount || extension.IsFull() {
+		err := client.HCService().Aliyun.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.AliyunAccountCheckReq{
+				CloudMainAccountID: extension.CloudMainAccountID,
+				CloudSubAccountID:  extension.CloudSubAccountID,
+				CloudSecretID:      extension.CloudSecretID,
+				CloudSecretKey:     extension.CloudSecretKey,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extension, nil
+}
+
+func (a *accountSvc) parseAndCheckAliyunExtensionByID(
+	cts *rest.Contexts, accountID string, reqExtension json.RawMessage,
+) (*proto.AliyunAccountExtensionUpdateReq, error) {
+	// 解析Extension
+	extension := new(proto.AliyunAccountExtensionUpdateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+
+	// 查询账号其他信息
+	account, err := a.client.DataService().Aliyun.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验Extension
+	err = extension.Validate(account.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，账号是否正确
+	if account.Type != enumor.RegistrationAccount || extension.IsFull() {
+		err = a.client.HCService().Aliyun.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.AliyunAccountCheckReq{
+				// 传入数据库中的主账号信息，如果发生变更会报错
+				CloudMainAccountID: account.Extension.CloudMainAccountID,
+				CloudSubAccountID:  extension.CloudSubAccountID,
+				CloudSecretID:      extension.CloudSecretID,
+				CloudSecretKey:     extension.CloudSecretKey,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	return extension, nil
+}
+
+// ParseAndCheckOpenStackExtension  联通性校验，并检查字段是否匹配
+func ParseAndCheckOpenStackExtension(
+	cts *rest.Contexts, client *client.ClientSet, accountType enumor.AccountType, reqExtension json.RawMessage,
+) (*proto.OpenStackAccountExtensionCreateReq, error) {
+	// 解析Extension
+	extension := new(proto.OpenStackAccountExtensionCreateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+	// 校验Extension
+	if err := extension.Validate(accountType); err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，账号是否正确
+	if accountType != enumor.RegistrationAccount || extension.IsFull() {
+		err := client.HCService().OpenStack.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.OpenStackAccountCheckReq{
+				CloudAuthURL:   extension.CloudAuthURL,
+				CloudRegion:    extension.CloudRegion,
+				CloudSecretID:  extension.CloudSecretID,
+				CloudSecretKey: extension.CloudSecretKey,
+				CloudProjectID: extension.CloudProjectID,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extension, nil
+}
+
+func (a *accountSvc) parseAndCheckOpenStackExtensionByID(
+	cts *rest.Contexts, accountID string, reqExtension json.RawMessage,
+) (*proto.OpenStackAccountExtensionUpdateReq, error) {
+	// 解析Extension
+	extension := new(proto.OpenStackAccountExtensionUpdateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+
+	// 查询账号其他信息
+	account, err := a.client.DataService().OpenStack.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验Extension
+	err = extension.Validate(account.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，账号是否正确
+	if account.Type != enumor.RegistrationAccount || extension.IsFull() {
+		err = a.client.HCService().OpenStack.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.OpenStackAccountCheckReq{
+				// 传入数据库中的认证地址和项目信息，如果发生变更会报错
+				CloudAuthURL:   account.Extension.CloudAuthURL,
+				CloudRegion:    extension.CloudRegion,
+				CloudSecretID:  extension.CloudSecretID,
+				CloudSecretKey: extension.CloudSecretKey,
+				CloudProjectID: account.Extension.CloudProjectID,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extension, nil
+}
@@ -66,6 +66,10 @@ func (a *accountSvc) GetAccount(cts *rest.Contexts) (interface{}, error) {
 		return a.getGcpAccount(cts, accountID)
 	case enumor.Azure:
 		return a.getAzureAccount(cts, accountID)
+	case enumor.Aliyun:
+		return a.getAliyunAccount(cts, accountID)
+	case enumor.OpenStack:
+		return a.getOpenStackAccount(cts, accountID)
 	case enumor.Other:
 		return a.getOtherAccount(cts, accountID)
 	default:
@@ -274,6 +278,10 @@ func (a *accountSvc) GetAccountBySecret(cts *rest.Contexts) (interface{}, error)
 		return a.getAndCheckGcpAccountInfo(cts)
 	case enumor.HuaWei:
 		return a.getAndCheckHuaWeiAccountInfo(cts)
+	case enumor.Aliyun:
+		return a.getAndCheckAliyunAccountInfo(cts)
+	case enumor.OpenStack:
+		return a.getAndCheckOpenStackAccountInfo(cts)
 	}
 
 	return nil, nil
@@ -515,3 +523,88 @@ func (a *accountSvc) GetResCountBySecret(cts *rest.Contexts) (interface{}, error
 	}
 
 }
+
+func (a *accountSvc) getAliyunAccount(cts *rest.Contexts, accountID string) (interface{}, error) {
+	acc, err := a.client.DataService().Aliyun.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	if err != nil {
+		logs.Errorf("get aliyun acc failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	// 敏感信息不显示，置空
+	if acc != nil {
+		acc.Extension.CloudSecretKey = ""
+	}
+	if err = accountDetailFullFill(a, cts, acc); err != nil {
+		logs.Errorf("acc detail full fill failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	return acc, nil
+}
+
+func (a *accountSvc) getAndCheckAliyunAccountInfo(cts *rest.Contexts) (*cloud.AliyunInfoBySecret, error) {
+	req := new(account.AliyunAccountInfoBySecretReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	info, err := a.client.HCService().Aliyun.Account.GetBySecret(cts.Kit.Ctx, cts.Kit.Header(), req.AliyunSecret)
+	if err != nil {
+		logs.Errorf("fail to get account info, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	if req.DisableCheck {
+		return info, nil
+	}
+	if err = CheckDuplicateMainAccount(cts, a.client, enumor.Aliyun, enumor.ResourceAccount,
+		info.CloudMainAccountID); err != nil {
+		logs.Errorf("check whether main account duplicate fail, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	return info, nil
+}
+
+func (a *accountSvc) getOpenStackAccount(cts *rest.Contexts, accountID string) (interface{}, error) {
+	acc, err := a.client.DataService().OpenStack.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	if err != nil {
+		logs.Errorf("get openstack acc failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	// 敏感信息不显示，置空
+	if acc != nil {
+		acc.Extension.CloudSecretKey = ""
+	}
+	if err = accountDetailFullFill(a, cts, acc); err != nil {
+		logs.Errorf("acc detail full fill failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	return acc, nil
+}
+
+func (a *accountSvc) getAndCheckOpenStackAccountInfo(cts *rest.Contexts) (*cloud.OpenStackInfoBySecret, error) {
+	req := new(account.OpenStackAccountInfoBySecretReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	info, err := a.client.HCService().OpenStack.Account.GetBySecret(cts.Kit.Ctx, cts.Kit.Header(),
+		req.OpenStackSecret)
+	if err != nil {
+		logs.Errorf("fail to get account info, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	if req.DisableCheck {
+		return info, nil
+	}
+	if err = CheckDuplicateMainAccount(cts, a.client, enumor.OpenStack, enumor.ResourceAccount,
+		info.CloudProjectID); err != nil {
+		logs.Errorf("check whether main account duplicate fail, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	return info, nil
+}
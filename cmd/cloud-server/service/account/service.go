@@ -23,8 +23,14 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/adaptor/types/account"
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service/account"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/auth"
@@ -42,20 +48,26 @@ func InitAccountService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 	// 兼容登记账号校验，过渡方案，后期去除
-	h.Add("CheckAccount", http.MethodPost, "/accounts/check", svc.CheckAccount)
+	h.Add("CheckAccount", http.MethodPost, "/accounts/check", svc.CheckAccount).Reads(new(proto.AccountCheckReq))
 
 	h.Add("GetResCountBySecret", http.MethodPost, "/vendors/{vendor}/accounts/res_counts/by_secrets",
-		svc.GetResCountBySecret)
+		svc.GetResCountBySecret).Writes(new(hcproto.ResCount))
 	h.Add("GetAccountBySecret", http.MethodPost, "/vendors/{vendor}/accounts/secret", svc.GetAccountBySecret)
-	h.Add("CheckByID", http.MethodPost, "/accounts/{account_id}/check", svc.CheckByID)
-	h.Add("ListAccount", http.MethodPost, "/accounts/list", svc.ListAccount)
-	h.Add("ResourceList", http.MethodPost, "/accounts/resources/accounts/list", svc.ResourceList)
+	h.Add("CheckByID", http.MethodPost, "/accounts/{account_id}/check", svc.CheckByID).
+		Reads(new(proto.AccountCheckByIDReq))
+	h.Add("ListAccount", http.MethodPost, "/accounts/list", svc.ListAccount).
+		Reads(new(proto.AccountListReq)).Writes(new(cloud.AccountListResult))
+	h.Add("ResourceList", http.MethodPost, "/accounts/resources/accounts/list", svc.ResourceList).
+		Reads(new(proto.AccountListResourceReq))
 	h.Add("GetAccount", http.MethodGet, "/accounts/{account_id}", svc.GetAccount)
-	h.Add("GetSyncDetail", http.MethodGet, "/accounts/sync_details/{account_id}", svc.GetSyncDetail)
-	h.Add("UpdateAccount", http.MethodPatch, "/accounts/{account_id}", svc.UpdateAccount)
+	h.Add("GetSyncDetail", http.MethodGet, "/accounts/sync_details/{account_id}", svc.GetSyncDetail).
+		Writes(new(proto.SyncDetailRsp))
+	h.Add("UpdateAccount", http.MethodPatch, "/accounts/{account_id}", svc.UpdateAccount).
+		Reads(new(proto.AccountUpdateReq))
 	h.Add("SyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync", svc.SyncCloudResource)
 	h.Add("DeleteAccount", http.MethodDelete, "/accounts/{account_id}", svc.DeleteAccount)
-	h.Add("DeleteValidate", http.MethodPost, "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
+	h.Add("DeleteValidate", http.MethodPost, "/accounts/{account_id}/delete/validate", svc.DeleteValidate).
+		Writes(new(map[string]uint64))
 
 	h.Add("SyncCloudResourceByCond", http.MethodPost,
 		"/vendors/{vendor}/accounts/{account_id}/resources/{res}/sync_by_cond", svc.SyncCloudResourceByCond)
@@ -66,36 +78,42 @@ func InitAccountService(c *capability.Capability) {
 	// 获取账号配额
 	h.Add("GetBizTCloudZoneQuota", http.MethodPost,
 		"/bizs/{bk_biz_id}/vendors/tcloud/accounts/{account_id}/zones/quotas",
-		svc.GetBizTCloudZoneQuota)
+		svc.GetBizTCloudZoneQuota).Reads(new(proto.GetAccountZoneQuotaReq)).Writes(new(account.TCloudAccountQuota))
 	h.Add("GetBizHuaWeiRegionQuota", http.MethodPost,
-		"/bizs/{bk_biz_id}/vendors/huawei/accounts/{account_id}/regions/quotas", svc.GetBizHuaWeiRegionQuota)
+		"/bizs/{bk_biz_id}/vendors/huawei/accounts/{account_id}/regions/quotas", svc.GetBizHuaWeiRegionQuota).
+		Reads(new(proto.GetAccountRegionQuotaReq)).Writes(new(proto.HuaWeiGetAccountRegionQuotaResult))
 	h.Add("GetBizGcpRegionQuota", http.MethodPost, "/bizs/{bk_biz_id}/vendors/gcp/accounts/{account_id}/regions/quotas",
-		svc.GetBizGcpRegionQuota)
+		svc.GetBizGcpRegionQuota).Reads(new(proto.GetAccountRegionQuotaReq)).Writes(new(account.GcpProjectQuota))
 	h.Add("GetResTCloudZoneQuota", http.MethodPost, "/vendors/tcloud/accounts/{account_id}/zones/quotas",
-		svc.GetResTCloudZoneQuota)
+		svc.GetResTCloudZoneQuota).Reads(new(proto.GetAccountZoneQuotaReq)).Writes(new(account.TCloudAccountQuota))
 	h.Add("GetResHuaWeiRegionQuota", http.MethodPost,
-		"/vendors/huawei/accounts/{account_id}/regions/quotas", svc.GetResHuaWeiRegionQuota)
+		"/vendors/huawei/accounts/{account_id}/regions/quotas", svc.GetResHuaWeiRegionQuota).
+		Reads(new(proto.GetAccountRegionQuotaReq)).Writes(new(proto.HuaWeiGetAccountRegionQuotaResult))
 	h.Add("GetResGcpRegionQuota", http.MethodPost, "/vendors/gcp/accounts/{account_id}/regions/quotas",
-		svc.GetResGcpRegionQuota)
+		svc.GetResGcpRegionQuota).Reads(new(proto.GetAccountRegionQuotaReq)).Writes(new(account.GcpProjectQuota))
 
 	// Rel
-	h.Add("ListByBkBizID", http.MethodGet, "/accounts/bizs/{bk_biz_id}", svc.ListByBkBizID)
+	h.Add("ListByBkBizID", http.MethodGet, "/accounts/bizs/{bk_biz_id}", svc.ListByBkBizID).
+		Writes(new([]*cloud.AccountBizRelWithAccount))
 
 	// 安全所需OpenAPI
-	h.Add("ListWithExtension", http.MethodPost, "/accounts/extensions/list", svc.ListWithExtension)
-	h.Add("ListSecretKey", http.MethodPost, "/accounts/secrets/list", svc.ListSecretKey)
+	h.Add("ListWithExtension", http.MethodPost, "/accounts/extensions/list", svc.ListWithExtension).
+		Reads(new(proto.AccountListWithExtReq))
+	h.Add("ListSecretKey", http.MethodPost, "/accounts/secrets/list", svc.ListSecretKey).
+		Reads(new(proto.ListSecretKeyReq)).Writes(new([]proto.SecretKeyData))
 
 	// 通过密钥获取账号权限策略
 	h.Add("ListTCloudAuthPolicies", http.MethodPost, "/vendors/tcloud/accounts/auth_policies/list",
-		svc.ListTCloudAuthPolicies)
+		svc.ListTCloudAuthPolicies).
+		Reads(new(hcproto.ListTCloudAuthPolicyReq)).Writes(new([]*v20190116.ListGrantServiceAccessNode))
 
 	h.Add("GetTCloudNetworkAccountType", http.MethodGet, "/vendors/tcloud/accounts/{account_id}/network_type",
-		svc.GetTCloudNetworkAccountType)
+		svc.GetTCloudNetworkAccountType).Writes(new(v20170312.DescribeNetworkAccountTypeResponseParams))
 
 	h.Add("BizGetAccountUsageBizs", http.MethodGet, "/bizs/{bk_biz_id}/accounts/usage_bizs/{account_id}",
-		svc.BizGetAccountUsageBizs)
+		svc.BizGetAccountUsageBizs).Writes(new([]int64))
 	h.Add("GetAccountUsageBizs", http.MethodGet, "/accounts/usage_bizs/{account_id}",
-		svc.GetAccountUsageBizs)
+		svc.GetAccountUsageBizs).Writes(new([]int64))
 
 	h.Load(c.WebService)
 }
@@ -96,6 +96,10 @@ func (a *accountSvc) UpdateAccount(cts *rest.Contexts) (interface{}, error) {
 		return a.updateForGcp(cts, req, accountID)
 	case enumor.Azure:
 		return a.updateForAzure(cts, req, accountID)
+	case enumor.Aliyun:
+		return a.updateForAliyun(cts, req, accountID)
+	case enumor.OpenStack:
+		return a.updateForOpenStack(cts, req, accountID)
 	default:
 		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
 	}
@@ -341,3 +345,97 @@ func (a *accountSvc) updateForAzure(
 	return nil, nil
 
 }
+
+func (a *accountSvc) updateForAliyun(
+	cts *rest.Contexts, req *proto.AccountUpdateReq, accountID string,
+) (
+	interface{}, error,
+) {
+	// 解析Extension
+	var (
+		extension *proto.AliyunAccountExtensionUpdateReq
+		err       error
+	)
+	if req.Extension != nil {
+		extension, err = a.parseAndCheckAliyunExtensionByID(cts, accountID, req.Extension)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	var shouldUpdatedExtension *dataproto.AliyunAccountExtensionUpdateReq = nil
+	if req.Extension != nil {
+		shouldUpdatedExtension = &dataproto.AliyunAccountExtensionUpdateReq{
+			CloudSubAccountID: extension.CloudSubAccountID,
+			CloudSecretID:     &extension.CloudSecretID,
+			CloudSecretKey:    &extension.CloudSecretKey,
+		}
+	}
+
+	// 更新
+	_, err = a.client.DataService().Aliyun.Account.Update(
+		cts.Kit.Ctx,
+		cts.Kit.Header(),
+		accountID,
+		&dataproto.AccountUpdateReq[dataproto.AliyunAccountExtensionUpdateReq]{
+			Name:               req.Name,
+			Managers:           req.Managers,
+			RecycleReserveTime: req.RecycleReserveTime,
+			Memo:               req.Memo,
+			Extension:          shouldUpdatedExtension,
+		},
+	)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, nil
+
+}
+
+func (a *accountSvc) updateForOpenStack(
+	cts *rest.Contexts, req *proto.AccountUpdateReq, accountID string,
+) (
+	interface{}, error,
+) {
+	// 解析Extension
+	var (
+		extension *proto.OpenStackAccountExtensionUpdateReq
+		err       error
+	)
+	if req.Extension != nil {
+		extension, err = a.parseAndCheckOpenStackExtensionByID(cts, accountID, req.Extension)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	var shouldUpdatedExtension *dataproto.OpenStackAccountExtensionUpdateReq = nil
+	if req.Extension != nil {
+		shouldUpdatedExtension = &dataproto.OpenStackAccountExtensionUpdateReq{
+			CloudRegion:    &extension.CloudRegion,
+			CloudSecretID:  &extension.CloudSecretID,
+			CloudSecretKey: &extension.CloudSecretKey,
+		}
+	}
+
+	// 更新
+	_, err = a.client.DataService().OpenStack.Account.Update(
+		cts.Kit.Ctx,
+		cts.Kit.Header(),
+		accountID,
+		&dataproto.AccountUpdateReq[dataproto.OpenStackAccountExtensionUpdateReq]{
+			Name:               req.Name,
+			Managers:           req.Managers,
+			RecycleReserveTime: req.RecycleReserveTime,
+			Memo:               req.Memo,
+			Extension:          shouldUpdatedExtension,
+		},
+	)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, nil
+
+}
@@ -49,7 +49,7 @@ func (s *adminService) registerAdminService(c *restful.WebService) {
 	defer adminH.Load(c)
 
 	// 这里注册的接口都无法被webserver访问，只能被系统内部调用，无需鉴权
-	adminH.Add("Init", http.MethodPost, "/init", s.Init)
+	adminH.Add("Init", http.MethodPost, "/init", s.Init).Writes(new(apisysteminit.SystemInitResult))
 }
 
 type adminService struct {
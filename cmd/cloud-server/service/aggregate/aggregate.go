@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aggregate
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// authResTypes is the auth resource type of the resources which support aggregate query.
+var authResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:              meta.Cvm,
+	enumor.DiskCloudResType:             meta.Disk,
+	enumor.EipCloudResType:              meta.Eip,
+	enumor.VpcCloudResType:              meta.Vpc,
+	enumor.SubnetCloudResType:           meta.Subnet,
+	enumor.SecurityGroupCloudResType:    meta.SecurityGroup,
+	enumor.NetworkInterfaceCloudResType: meta.NetworkInterface,
+	enumor.LoadBalancerCloudResType:     meta.LoadBalancer,
+	enumor.CertCloudResType:             meta.Cert,
+}
+
+// AggregateResource aggregate resources in resource view.
+func (svc *aggregateSvc) AggregateResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.aggregate(cts, handler.ListResourceAuthRes)
+}
+
+// AggregateBizResource aggregate resources in biz view.
+func (svc *aggregateSvc) AggregateBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.aggregate(cts, handler.ListBizAuthRes)
+}
+
+func (svc *aggregateSvc) aggregate(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	resType := enumor.CloudResourceType(cts.PathParameter("type").String())
+	authResType, exists := authResTypes[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support aggregate", resType)
+	}
+
+	req := new(core.AggregateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// only aggregate the authorized resources
+	expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: authResType, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return &core.AggregateResult{Details: make([]map[string]interface{}, 0)}, nil
+	}
+
+	req.Filter = expr
+	result, err := svc.client.DataService().Global.Cloud.AggregateResource(cts.Kit, resType, req)
+	if err != nil {
+		logs.Errorf("aggregate %s failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package aggregate 资源通用聚合查询
+package aggregate
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the aggregate service.
+func InitService(c *capability.Capability) {
+	svc := &aggregateSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("AggregateResource", http.MethodPost, "/resources/{type}/aggregate", svc.AggregateResource).
+		Reads(new(core.AggregateReq)).Writes(new(core.AggregateResult))
+	h.Add("AggregateBizResource", http.MethodPost, "/bizs/{bk_biz_id}/resources/{type}/aggregate",
+		svc.AggregateBizResource).Reads(new(core.AggregateReq)).Writes(new(core.AggregateResult))
+
+	h.Load(c.WebService)
+}
+
+type aggregateSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
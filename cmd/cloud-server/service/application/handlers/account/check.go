@@ -60,6 +60,10 @@ func (a *ApplicationOfAddAccount) CheckReq() error {
 		_, err = accountsvc.ParseAndCheckGcpExtension(a.Cts, a.Client, a.req.Type, extensionJson)
 	case enumor.Azure:
 		_, err = accountsvc.ParseAndCheckAzureExtension(a.Cts, a.Client, a.req.Type, extensionJson)
+	case enumor.Aliyun:
+		_, err = accountsvc.ParseAndCheckAliyunExtension(a.Cts, a.Client, a.req.Type, extensionJson)
+	case enumor.OpenStack:
+		_, err = accountsvc.ParseAndCheckOpenStackExtension(a.Cts, a.Client, a.req.Type, extensionJson)
 	default:
 		err = fmt.Errorf("no support vendor: %s", a.req.Vendor)
 	}
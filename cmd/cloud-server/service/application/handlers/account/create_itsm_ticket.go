@@ -89,6 +89,20 @@ func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 			{Label: "应用程序名称", Value: req.Extension["cloud_application_name"]},
 			{Label: "客户端密钥ID", Value: req.Extension["cloud_client_secret_id"]},
 		}...)
+	case enumor.Aliyun:
+		formItems = append(formItems, []formItem{
+			{Label: "主账号ID", Value: req.Extension["cloud_main_account_id"]},
+			{Label: "子账号ID", Value: req.Extension["cloud_sub_account_id"]},
+			{Label: "AccessKey ID", Value: req.Extension["cloud_secret_id"]},
+		}...)
+	case enumor.OpenStack:
+		formItems = append(formItems, []formItem{
+			{Label: "认证地址", Value: req.Extension["cloud_auth_url"]},
+			{Label: "区域", Value: req.Extension["cloud_region"]},
+			{Label: "项目ID", Value: req.Extension["cloud_project_id"]},
+			{Label: "项目名称", Value: req.Extension["cloud_project_name"]},
+			{Label: "应用凭证ID", Value: req.Extension["cloud_secret_id"]},
+		}...)
 	}
 
 	// 负责人
@@ -48,6 +48,10 @@ func (a *ApplicationOfAddAccount) Deliver() (enumor.ApplicationStatus, map[strin
 		accountID, err = a.createForGcp()
 	case enumor.Azure:
 		accountID, err = a.createForAzure()
+	case enumor.Aliyun:
+		accountID, err = a.createForAliyun()
+	case enumor.OpenStack:
+		accountID, err = a.createForOpenStack()
 	}
 	// 交付失败
 	if err != nil {
@@ -211,3 +215,55 @@ func (a *ApplicationOfAddAccount) createForAzure() (string, error) {
 	}
 	return result.ID, err
 }
+
+func (a *ApplicationOfAddAccount) createForAliyun() (string, error) {
+	result, err := a.Client.DataService().Aliyun.Account.Create(
+		a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(),
+		&dataprotocloud.AccountCreateReq[dataprotocloud.AliyunAccountExtensionCreateReq]{
+			Name:     a.req.Name,
+			Managers: a.req.Managers,
+			Type:     a.req.Type,
+			Site:     a.req.Site,
+			Memo:     a.req.Memo,
+			BkBizIDs: a.req.BkBizIDs,
+			Extension: &dataprotocloud.AliyunAccountExtensionCreateReq{
+				CloudMainAccountID: a.req.Extension["cloud_main_account_id"],
+				CloudSubAccountID:  a.req.Extension["cloud_sub_account_id"],
+				CloudSecretID:      a.req.Extension["cloud_secret_id"],
+				CloudSecretKey:     a.req.Extension["cloud_secret_key"],
+			},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, err
+}
+
+func (a *ApplicationOfAddAccount) createForOpenStack() (string, error) {
+	result, err := a.Client.DataService().OpenStack.Account.Create(
+		a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(),
+		&dataprotocloud.AccountCreateReq[dataprotocloud.OpenStackAccountExtensionCreateReq]{
+			Name:     a.req.Name,
+			Managers: a.req.Managers,
+			Type:     a.req.Type,
+			Site:     a.req.Site,
+			Memo:     a.req.Memo,
+			BkBizIDs: a.req.BkBizIDs,
+			Extension: &dataprotocloud.OpenStackAccountExtensionCreateReq{
+				CloudAuthURL:     a.req.Extension["cloud_auth_url"],
+				CloudRegion:      a.req.Extension["cloud_region"],
+				CloudProjectID:   a.req.Extension["cloud_project_id"],
+				CloudProjectName: a.req.Extension["cloud_project_name"],
+				CloudSecretID:    a.req.Extension["cloud_secret_id"],
+				CloudSecretKey:   a.req.Extension["cloud_secret_key"],
+			},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, err
+}
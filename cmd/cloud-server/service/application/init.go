@@ -29,6 +29,7 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server/application"
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service"
 	"hcm/pkg/client"
@@ -60,24 +61,30 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 		cmdbCli:    c.CmdbCli,
 	}
 	h := rest.NewHandler()
-	h.Add("ListApplications", "POST", "/applications/list", svc.ListApplications)
-	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication)
+	h.Add("ListApplications", "POST", "/applications/list", svc.ListApplications).
+		Reads(new(proto.ApplicationListReq)).Writes(new(dataproto.ApplicationListResult))
+	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication).
+		Writes(new(proto.ApplicationGetResp))
 	h.Add("CancelApplication", "PATCH", "/applications/{application_id}/cancel", svc.CancelApplication)
-	h.Add("ApproveApplication", "POST", "/applications/approve", svc.ApproveApplication)
+	h.Add("ApproveApplication", "POST", "/applications/approve", svc.ApproveApplication).
+		Reads(new(proto.ItsmApproveResult))
 
-	h.Add("CreateForAddAccount", "POST", "/applications/types/add_account", svc.CreateForAddAccount)
+	h.Add("CreateForAddAccount", "POST", "/applications/types/add_account", svc.CreateForAddAccount).
+		Writes(new(core.CreateResult))
 	h.Add("CreateForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm", svc.CreateForCreateCvm)
 	h.Add("CreateForCreateVpc", "POST", "/vendors/{vendor}/applications/types/create_vpc", svc.CreateForCreateVpc)
 	h.Add("CreateForCreateDisk", "POST", "/vendors/{vendor}/applications/types/create_disk", svc.CreateForCreateDisk)
 	h.Add("CreateForCreateLB", "POST",
-		"/vendors/{vendor}/applications/types/create_load_balancer", svc.CreateForCreateLB)
+		"/vendors/{vendor}/applications/types/create_load_balancer", svc.CreateForCreateLB).
+		Writes(new(core.CreateResult))
 
 	h.Add("CreateForCreateMainAccount", "POST",
-		"/applications/types/create_main_account", svc.CreateForCreateMainAccount)
+		"/applications/types/create_main_account", svc.CreateForCreateMainAccount).Writes(new(core.CreateResult))
 	h.Add("CompleteForCreateMainAccount", "POST",
-		"/applications/types/complete_main_account", svc.CompleteForCreateMainAccount)
+		"/applications/types/complete_main_account", svc.CompleteForCreateMainAccount).
+		Reads(new(proto.MainAccountCompleteReq)).Writes(new(core.CreateResult))
 	h.Add("CreateForUpdateMainAccount", "POST",
-		"/applications/types/update_main_account", svc.CreateForUpdateMainAccount)
+		"/applications/types/update_main_account", svc.CreateForUpdateMainAccount).Writes(new(core.CreateResult))
 
 	bizH := rest.NewHandler()
 	bizH.Path("/bizs/{bk_biz_id}")
@@ -89,7 +96,8 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 }
 
 func bizService(h *rest.Handler, svc *applicationSvc) {
-	h.Add("ListBizApplications", "POST", "/applications/list", svc.ListBizApplications)
+	h.Add("ListBizApplications", "POST", "/applications/list", svc.ListBizApplications).
+		Reads(new(proto.ApplicationListReq)).Writes(new(dataproto.ApplicationListResult))
 }
 
 type applicationSvc struct {
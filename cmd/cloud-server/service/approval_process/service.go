@@ -42,7 +42,7 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetApprovalProcessServiceID", http.MethodGet, "/approval_processes/service_id",
-		svc.GetApprovalProcessServiceID)
+		svc.GetApprovalProcessServiceID).Writes(new([]int64))
 
 	h.Load(c.WebService)
 }
@@ -25,6 +25,10 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	cloudserver "hcm/pkg/api/cloud-server"
+	proto "hcm/pkg/api/cloud-server/argument-template"
+	"hcm/pkg/api/core"
+	argstpl "hcm/pkg/api/core/cloud/argument-template"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -40,19 +44,25 @@ func InitArgsTplService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// apis in biz
-	h.Add("ListBizArgsTpl", http.MethodPost, "/bizs/{bk_biz_id}/argument_templates/list", svc.ListBizArgsTpl)
+	h.Add("ListBizArgsTpl", http.MethodPost, "/bizs/{bk_biz_id}/argument_templates/list", svc.ListBizArgsTpl).
+		Reads(new(cloudserver.ListReq))
 	h.Add("ListBizArgsTplBindInstanceRule", http.MethodPost, "/bizs/{bk_biz_id}/argument_templates/instance/rule/list",
-		svc.ListBizArgsTplBindInstanceRule)
-	h.Add("CreateBizArgsTpl", http.MethodPost, "/bizs/{bk_biz_id}/argument_templates/create", svc.CreateBizArgsTpl)
-	h.Add("UpdateBizArgsTpl", http.MethodPut, "/bizs/{bk_biz_id}/argument_templates/{id}", svc.UpdateBizArgsTpl)
-	h.Add("DeleteBizArgsTpl", http.MethodDelete, "/bizs/{bk_biz_id}/argument_templates/batch", svc.DeleteBizArgsTpl)
+		svc.ListBizArgsTplBindInstanceRule).Reads(new(proto.ArgsTplBatchIDsReq))
+	h.Add("CreateBizArgsTpl", http.MethodPost, "/bizs/{bk_biz_id}/argument_templates/create", svc.CreateBizArgsTpl).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(argstpl.ArgsTplCreateResult))
+	h.Add("UpdateBizArgsTpl", http.MethodPut, "/bizs/{bk_biz_id}/argument_templates/{id}", svc.UpdateBizArgsTpl).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("DeleteBizArgsTpl", http.MethodDelete, "/bizs/{bk_biz_id}/argument_templates/batch", svc.DeleteBizArgsTpl).
+		Reads(new(core.BatchDeleteReq))
 
 	// apis in resource
-	h.Add("ListArgsTpl", http.MethodPost, "/argument_templates/list", svc.ListArgsTpl)
+	h.Add("ListArgsTpl", http.MethodPost, "/argument_templates/list", svc.ListArgsTpl).Reads(new(cloudserver.ListReq))
 	h.Add("ListArgsTplBindInstanceRule", http.MethodPost, "/argument_templates/instance/rule/list",
-		svc.ListArgsTplBindInstanceRule)
-	h.Add("AssignArgsTplToBiz", http.MethodPost, "/argument_templates/assign/bizs", svc.AssignArgsTplToBiz)
-	h.Add("CreateArgsTpl", http.MethodPost, "/argument_templates/create", svc.CreateArgsTpl)
+		svc.ListArgsTplBindInstanceRule).Reads(new(proto.ArgsTplBatchIDsReq))
+	h.Add("AssignArgsTplToBiz", http.MethodPost, "/argument_templates/assign/bizs", svc.AssignArgsTplToBiz).
+		Reads(new(proto.AssignArgsTplToBizReq))
+	h.Add("CreateArgsTpl", http.MethodPost, "/argument_templates/create", svc.CreateArgsTpl).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(argstpl.ArgsTplCreateResult))
 
 	h.Load(c.WebService)
 }
@@ -0,0 +1,259 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 资源自动分配业务规则管理
+package assignrule
+
+import (
+	"net/http"
+
+	logicsassign "hcm/cmd/cloud-server/logics/assign-rule"
+	"hcm/cmd/cloud-server/service/capability"
+	csassign "hcm/pkg/api/cloud-server/assign-rule"
+	"hcm/pkg/api/core"
+	coreassign "hcm/pkg/api/core/assign-rule"
+	dsassign "hcm/pkg/api/data-service/assign-rule"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initialize the assign rule service.
+func InitService(c *capability.Capability) {
+	svc := &assignRuleSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateAssignRule", http.MethodPost, "/assign_rules/create", svc.CreateAssignRule).
+		Reads(new(csassign.CreateAssignRuleReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateAssignRule", http.MethodPatch, "/assign_rules/{id}", svc.UpdateAssignRule).
+		Reads(new(csassign.UpdateAssignRuleReq))
+	h.Add("ListAssignRule", http.MethodPost, "/assign_rules/list", svc.ListAssignRule).
+		Reads(new(core.ListReq)).Writes(new(dsassign.ListAssignRuleResult))
+	h.Add("BatchDeleteAssignRule", http.MethodDelete, "/assign_rules/batch", svc.BatchDeleteAssignRule).
+		Reads(new(csassign.BatchDeleteReq))
+	h.Add("PreviewAssignRule", http.MethodPost, "/assign_rules/preview", svc.PreviewAssignRule).
+		Reads(new(csassign.PreviewAssignRuleReq)).Writes(new(csassign.AssignRuleMatchResult))
+	h.Add("ApplyAssignRule", http.MethodPost, "/assign_rules/apply", svc.ApplyAssignRule).
+		Reads(new(csassign.ApplyAssignRuleReq)).Writes(new(csassign.AssignRuleMatchResult))
+
+	h.Load(c.WebService)
+}
+
+type assignRuleSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *assignRuleSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AssignRule, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// CreateAssignRule create assign rule.
+func (svc *assignRuleSvc) CreateAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csassign.CreateAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	createReq := &dsassign.BatchCreateAssignRuleReq{Rules: []dsassign.AssignRuleCreate{*req}}
+	if err := createReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.AssignRule.BatchCreateAssignRule(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create assign rule failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create assign rule result is invalid")
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateAssignRule update assign rule.
+func (svc *assignRuleSvc) UpdateAssignRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csassign.UpdateAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsassign.BatchUpdateAssignRuleReq{
+		Rules: []dsassign.AssignRuleUpdate{{
+			ID:         id,
+			Name:       req.Name,
+			Priority:   req.Priority,
+			Enabled:    req.Enabled,
+			BkBizID:    req.BkBizID,
+			BkCloudID:  req.BkCloudID,
+			ResTypes:   req.ResTypes,
+			Conditions: req.Conditions,
+			Memo:       req.Memo,
+		}},
+	}
+	if err := svc.client.DataService().Global.AssignRule.BatchUpdateAssignRule(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update assign rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAssignRule list assign rules.
+func (svc *assignRuleSvc) ListAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.AssignRule.ListAssignRule(cts.Kit, req)
+}
+
+// BatchDeleteAssignRule batch delete assign rules.
+func (svc *assignRuleSvc) BatchDeleteAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csassign.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.AssignRule.BatchDeleteAssignRule(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete assign rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// PreviewAssignRule preview the matched unassigned resources of an account, the resources are not assigned.
+func (svc *assignRuleSvc) PreviewAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csassign.PreviewAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	dataCli := svc.client.DataService()
+	var rules []coreassign.AssignRule
+	if req.Rule != nil {
+		// 预览尚未创建的规则
+		rules = []coreassign.AssignRule{{
+			Name:       req.Rule.Name,
+			Priority:   converter.PtrToVal(req.Rule.Priority),
+			Enabled:    true,
+			BkBizID:    req.Rule.BkBizID,
+			BkCloudID:  req.Rule.BkCloudID,
+			ResTypes:   req.Rule.ResTypes,
+			Conditions: req.Rule.Conditions,
+		}}
+	} else {
+		var err error
+		rules, err = logicsassign.ListEnabledRules(cts.Kit, dataCli)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches, err := logicsassign.Preview(cts.Kit, dataCli, req.AccountID, rules)
+	if err != nil {
+		logs.Errorf("preview assign rule failed, err: %v, account: %s, rid: %s", err, req.AccountID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csassign.AssignRuleMatchResult{Details: matches}, nil
+}
+
+// ApplyAssignRule apply enabled assign rules to the unassigned resources of an account immediately, instead of
+// waiting for the next resource sync.
+func (svc *assignRuleSvc) ApplyAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csassign.ApplyAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := []meta.ResourceAttribute{
+		{Basic: &meta.Basic{Type: meta.AssignRule, Action: meta.Find}},
+		{Basic: &meta.Basic{Type: meta.CloudResource, Action: meta.Assign, ResourceID: req.AccountID}},
+	}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	matches, err := logicsassign.Apply(cts.Kit, svc.client.DataService(), req.AccountID)
+	if err != nil {
+		logs.Errorf("apply assign rule failed, err: %v, account: %s, rid: %s", err, req.AccountID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csassign.AssignRuleMatchResult{Details: matches}, nil
+}
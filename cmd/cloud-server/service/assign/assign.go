@@ -50,7 +50,8 @@ func InitService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("AssignResourceToBiz", http.MethodPost, "/resources/assign/bizs", s.AssignResourceToBiz)
+	h.Add("AssignResourceToBiz", http.MethodPost, "/resources/assign/bizs", s.AssignResourceToBiz).
+		Reads(new(proto.AssignResourceToBizReq))
 
 	h.Load(c.WebService)
 }
@@ -25,6 +25,8 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	coreasync "hcm/pkg/api/core/async"
+	taskserver "hcm/pkg/api/task-server"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -41,8 +43,9 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// async task apis in resource
-	h.Add("GetFlow", http.MethodGet, "/async_task/flows/{id}", svc.GetFlow)
-	h.Add("ListTask", http.MethodGet, "/async_task/flows/{id}/tasks/list", svc.ListTask)
+	h.Add("GetFlow", http.MethodGet, "/async_task/flows/{id}", svc.GetFlow).Writes(new(coreasync.AsyncFlow))
+	h.Add("ListTask", http.MethodGet, "/async_task/flows/{id}/tasks/list", svc.ListTask).
+		Writes(new(taskserver.ListTaskResult))
 
 	h.Load(c.WebService)
 }
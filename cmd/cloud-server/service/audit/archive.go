@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	coreaudit "hcm/pkg/api/core/audit"
+	"hcm/pkg/api/data-service/audit"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// ListAuditArchive list audit archive manifests, which requires the permission of all audits.
+func (svc svc) ListAuditArchive(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeAllAudit(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.ListArchive(cts.Kit, req)
+}
+
+// RehydrateAuditArchive restore the audits in the archive file, which requires the permission of all audits.
+func (svc svc) RehydrateAuditArchive(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorizeAllAudit(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.RehydrateArchive(cts.Kit, id)
+}
+
+// authorizeAllAudit 归档文件中包含所有账号和业务的审计，需要有全部审计的查看权限
+func (svc svc) authorizeAllAudit(kt *kit.Kit) error {
+	authInst, err := svc.authorizer.ListAuthorizedInstances(kt,
+		&meta.ListAuthResInput{Type: meta.Audit, Action: meta.Find})
+	if err != nil {
+		return err
+	}
+
+	if !authInst.IsAny {
+		return errf.New(errf.PermissionDenied, "permission denied, audit archive requires permission of all audits")
+	}
+
+	return nil
+}
+
+// SearchArchivedAudit search archived audits.
+func (svc svc) SearchArchivedAudit(cts *rest.Contexts) (interface{}, error) {
+	req := new(audit.SearchArchivedAuditReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authInst, err := svc.authorizer.ListAuthorizedInstances(cts.Kit,
+		&meta.ListAuthResInput{Type: meta.Audit, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+
+	// 没有全部审计的查看权限时，只能检索有权限的账号下的审计
+	if !authInst.IsAny {
+		accountIDs := authInst.IDs
+		if len(req.AccountIDs) != 0 {
+			accountIDs = slice.Intersection(req.AccountIDs, authInst.IDs)
+		}
+
+		if len(accountIDs) == 0 {
+			return &audit.SearchArchivedAuditResult{Details: make([]coreaudit.Audit, 0)}, nil
+		}
+		req.AccountIDs = accountIDs
+	}
+
+	return svc.client.DataService().Global.Audit.SearchArchivedAudit(cts.Kit, req)
+}
+
+// SearchBizArchivedAudit search biz archived audits.
+func (svc svc) SearchBizArchivedAudit(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(audit.SearchArchivedAuditReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	// validate biz and authorize
+	_, noPerm, err := handler.ListBizAuthRes(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: meta.Audit, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for search archived audit")
+	}
+
+	req.BkBizIDs = []int64{bizID}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.DataService().Global.Audit.SearchArchivedAudit(cts.Kit, req)
+}
+
+// ArchiveAuditTiming 定时将超过保留天数的审计归档到对象存储，并从审计表中删除
+func ArchiveAuditTiming(conf cc.AuditArchive, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	interval := time.Duration(conf.IntervalMin) * time.Minute
+	logs.Infof("audit archive enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		start := time.Now()
+		before := start.AddDate(0, 0, -int(conf.RetentionDays)).Format(constant.TimeStdFormat)
+		logs.Infof("audit archive start, before: %s, rid: %s", before, kt.Rid)
+
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			archiveAudit(tenantKt, cliSet, before, conf.BatchSize)
+		}
+
+		logs.Infof("audit archive end, cost: %s, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// archiveAudit 分批归档审计，每批归档后删除对应审计，直到没有需要归档的审计
+func archiveAudit(kt *kit.Kit, cliSet *client.ClientSet, before string, batchSize uint) {
+	req := &audit.ArchiveAuditReq{Before: before, Limit: batchSize}
+
+	var total uint64
+	for {
+		result, err := cliSet.DataService().Global.Audit.ArchiveAudit(kt, req)
+		if err != nil {
+			logs.Errorf("archive audit failed, err: %v, before: %s, rid: %s", err, before, kt.Rid)
+			return
+		}
+
+		total += result.Count
+		if result.Count < uint64(batchSize) {
+			break
+		}
+	}
+
+	logs.V(3).Infof("archive audit success, before: %s, count: %d, rid: %s", before, total, kt.Rid)
+}
@@ -49,18 +49,36 @@ func InitService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
-	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
-	h.Add("ListAuditAsyncFlow", http.MethodPost, "/audits/async_flow/list", svc.ListAuditAsyncFlow)
-	h.Add("ListAuditAsyncTask", http.MethodPost, "/audits/async_task/list", svc.ListAuditAsyncTask)
+	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit).Writes(new(coreaudit.RawAudit))
+	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit).
+		Reads(new(proto.AuditListReq)).Writes(new(audit.ListResult))
+	h.Add("ListAuditAsyncFlow", http.MethodPost, "/audits/async_flow/list", svc.ListAuditAsyncFlow).
+		Reads(new(proto.AuditAsyncFlowListReq)).Writes(new(audit.GetAsyncTaskResp))
+	h.Add("ListAuditAsyncTask", http.MethodPost, "/audits/async_task/list", svc.ListAuditAsyncTask).
+		Reads(new(proto.AuditAsyncTaskListReq)).Writes(new(audit.GetAsyncTaskResp))
+	h.Add("ListAuditArchive", http.MethodPost, "/audits/archives/list", svc.ListAuditArchive).
+		Reads(new(core.ListReq)).Writes(new(audit.ListArchiveResult))
+	h.Add("SearchArchivedAudit", http.MethodPost, "/audits/archives/search", svc.SearchArchivedAudit).
+		Reads(new(audit.SearchArchivedAuditReq)).Writes(new(audit.SearchArchivedAuditResult))
+	h.Add("RehydrateAuditArchive", http.MethodPost, "/audits/archives/{id}/rehydrate", svc.RehydrateAuditArchive).
+		Writes(new(audit.RehydrateArchiveResult))
+	h.Add("VerifyAuditChain", http.MethodPost, "/audits/chain/verify", svc.VerifyAuditChain).
+		Reads(new(audit.VerifyAuditChainReq)).Writes(new(audit.VerifyAuditChainResult))
+	h.Add("ListAuditCheckpoint", http.MethodPost, "/audits/chain/checkpoints/list", svc.ListAuditCheckpoint).
+		Reads(new(core.ListReq)).Writes(new(audit.ListCheckpointResult))
 
 	// biz audit apis
-	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit)
-	h.Add("ListBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/list", svc.ListBizAudit)
+	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit).
+		Writes(new(coreaudit.RawAudit))
+	h.Add("ListBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/list", svc.ListBizAudit).
+		Reads(new(proto.AuditListReq)).Writes(new(audit.ListResult))
 	h.Add("ListBizAuditAsyncFlow", http.MethodPost, "/bizs/{bk_biz_id}/audits/async_flow/list",
-		svc.ListBizAuditAsyncFlow)
+		svc.ListBizAuditAsyncFlow).Reads(new(proto.AuditAsyncFlowListReq)).Writes(new(audit.GetAsyncTaskResp))
 	h.Add("ListBizAuditAsyncTask", http.MethodPost, "/bizs/{bk_biz_id}/audits/async_task/list",
-		svc.ListBizAuditAsyncTask)
+		svc.ListBizAuditAsyncTask).Reads(new(proto.AuditAsyncTaskListReq)).Writes(new(audit.GetAsyncTaskResp))
+	h.Add("SearchBizArchivedAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/archives/search",
+		svc.SearchBizArchivedAudit).
+		Reads(new(audit.SearchArchivedAuditReq)).Writes(new(audit.SearchArchivedAuditResult))
 
 	h.Load(c.WebService)
 }
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/audit"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/serviced"
+)
+
+// VerifyAuditChain verify the audit hash chain, which requires the permission of all audits.
+func (svc svc) VerifyAuditChain(cts *rest.Contexts) (interface{}, error) {
+	req := new(audit.VerifyAuditChainReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeAllAudit(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.VerifyAuditChain(cts.Kit, req)
+}
+
+// ListAuditCheckpoint list audit hash chain checkpoints, which requires the permission of all audits.
+func (svc svc) ListAuditCheckpoint(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeAllAudit(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.ListAuditCheckpoint(cts.Kit, req)
+}
+
+// AuditCheckpointTiming 定时对各租户的审计哈希链头签名生成检查点，并写入审计归档
+func AuditCheckpointTiming(conf cc.AuditCheckpoint, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	interval := time.Duration(conf.IntervalMin) * time.Minute
+	logs.Infof("audit checkpoint enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			result, err := cliSet.DataService().Global.Audit.CreateAuditCheckpoint(tenantKt)
+			if err != nil {
+				logs.Errorf("create audit checkpoint failed, err: %v, tenant: %s, rid: %s", err, tenantID,
+					tenantKt.Rid)
+				continue
+			}
+
+			if len(result.ID) != 0 {
+				logs.Infof("create audit checkpoint success, tenant: %s, seq: %d, path: %s, rid: %s", tenantID,
+					result.Seq, result.Path, tenantKt.Rid)
+			}
+		}
+	}
+}
@@ -55,8 +55,11 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// clb apis in res
-	h.Add("QueryBandPackage", http.MethodPost, "/bandwidth_packages/query", svc.QueryBandPackage)
-	h.Add("QueryBizBandPackage", http.MethodPost, "/bizs/{bk_biz_id}/bandwidth_packages/query", svc.QueryBizBandPackage)
+	h.Add("QueryBandPackage", http.MethodPost, "/bandwidth_packages/query", svc.QueryBandPackage).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("QueryBizBandPackage", http.MethodPost,
+		"/bizs/{bk_biz_id}/bandwidth_packages/query", svc.QueryBizBandPackage).
+		Reads(new(cloudserver.ResourceCreateReq))
 
 	h.Load(c.WebService)
 }
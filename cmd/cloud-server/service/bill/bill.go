@@ -28,6 +28,7 @@ import (
 	cloudserver "hcm/pkg/api/cloud-server"
 	csbill "hcm/pkg/api/cloud-server/bill"
 	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/cloud/bill"
 	hcbill "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/enumor"
@@ -48,7 +49,8 @@ func InitBillService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListBills", "POST", "/vendors/{vendor}/bills/list", svc.ListBills)
-	h.Add("ListBillsConfig", "POST", "/bills/config/list", svc.ListBillsConfig)
+	h.Add("ListBillsConfig", "POST", "/bills/config/list", svc.ListBillsConfig).
+		Reads(new(cloudserver.ListReq)).Writes(new(bill.AccountBillConfigListResult))
 
 	h.Load(c.WebService)
 }
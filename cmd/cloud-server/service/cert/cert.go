@@ -25,6 +25,9 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	cloudserver "hcm/pkg/api/cloud-server"
+	proto "hcm/pkg/api/cloud-server/cert"
+	"hcm/pkg/api/core/cloud/cert"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -41,14 +44,18 @@ func InitCertService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// cert apis in biz
-	h.Add("ListBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/certs/list", svc.ListBizCert)
-	h.Add("CreateBizCert", http.MethodPost, "/bizs/{bk_biz_id}/certs/create", svc.CreateBizCert)
+	h.Add("ListBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/certs/list", svc.ListBizCert).
+		Reads(new(cloudserver.ListReq))
+	h.Add("CreateBizCert", http.MethodPost, "/bizs/{bk_biz_id}/certs/create", svc.CreateBizCert).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(cert.CertCreateResult))
 	h.Add("DeleteBizCert", http.MethodDelete, "/bizs/{bk_biz_id}/certs/{id}", svc.DeleteBizCert)
 
 	// cert apis in resource
-	h.Add("ListCert", http.MethodPost, "/certs/list", svc.ListCert)
-	h.Add("AssignCertToBiz", http.MethodPost, "/certs/assign/bizs", svc.AssignCertToBiz)
-	h.Add("CreateCert", http.MethodPost, "/certs/create", svc.CreateCert)
+	h.Add("ListCert", http.MethodPost, "/certs/list", svc.ListCert).Reads(new(cloudserver.ListReq))
+	h.Add("AssignCertToBiz", http.MethodPost, "/certs/assign/bizs", svc.AssignCertToBiz).
+		Reads(new(proto.AssignCertToBizReq))
+	h.Add("CreateCert", http.MethodPost, "/certs/create", svc.CreateCert).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(cert.CertCreateResult))
 	h.Add("DeleteCert", http.MethodDelete, "/certs/{id}", svc.DeleteCert)
 
 	h.Load(c.WebService)
@@ -24,6 +24,9 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	cssel "hcm/pkg/api/cloud-server/cloud-selection"
+	"hcm/pkg/api/core"
+	coresel "hcm/pkg/api/core/cloud-selection"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -44,27 +47,37 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 方案相关接口
-	h.Add("BatchDeleteScheme", http.MethodDelete, "/selections/schemes/batch", svc.BatchDeleteScheme)
-	h.Add("CreateScheme", http.MethodPost, "/selections/schemes/create", svc.CreateScheme)
-	h.Add("GetScheme", http.MethodGet, "/selections/schemes/{id}", svc.GetScheme)
-	h.Add("ListScheme", http.MethodPost, "/selections/schemes/list", svc.ListScheme)
-	h.Add("UpdateScheme", http.MethodPatch, "/selections/schemes/{id}", svc.UpdateScheme)
+	h.Add("BatchDeleteScheme", http.MethodDelete, "/selections/schemes/batch", svc.BatchDeleteScheme).
+		Reads(new(core.BatchDeleteReq))
+	h.Add("CreateScheme", http.MethodPost, "/selections/schemes/create", svc.CreateScheme).
+		Reads(new(cssel.SchemeCreateReq)).Writes(new(core.CreateResult))
+	h.Add("GetScheme", http.MethodGet, "/selections/schemes/{id}", svc.GetScheme).Writes(new(coresel.Scheme))
+	h.Add("ListScheme", http.MethodPost, "/selections/schemes/list", svc.ListScheme).Reads(new(core.ListReq))
+	h.Add("UpdateScheme", http.MethodPatch, "/selections/schemes/{id}", svc.UpdateScheme).
+		Reads(new(cssel.SchemeUpdateReq))
 
 	// 业务类型接口
-	h.Add("ListBizType", http.MethodPost, "/selections/biz_types/list", svc.ListBizType)
+	h.Add("ListBizType", http.MethodPost, "/selections/biz_types/list", svc.ListBizType).
+		Reads(new(core.ListReq)).Writes(new(core.ListResultT[coresel.BizType]))
 
 	// IDC接口
-	h.Add("ListIdc", http.MethodPost, "/selections/idcs/list", svc.ListIdc)
+	h.Add("ListIdc", http.MethodPost, "/selections/idcs/list", svc.ListIdc).
+		Reads(new(core.ListReq)).Writes(new([]coresel.IdcWithPrice))
 
 	// 查询支持国家
-	h.Add("ListAvailableCountry", http.MethodPost, "/selections/countries/list", svc.ListAvailableCountry)
-	h.Add("QueryUserDistribution", http.MethodPost, "/selections/user_distributions/query", svc.QueryUserDistribution)
-	h.Add("QueryPingLatency", http.MethodPost, "/selections/latency/ping/query", svc.QueryPingLatency)
-	h.Add("QueryBizLatency", http.MethodPost, "/selections/latency/biz/query", svc.QueryBizLatency)
+	h.Add("ListAvailableCountry", http.MethodPost, "/selections/countries/list", svc.ListAvailableCountry).
+		Writes(new(core.ListResultT[string]))
+	h.Add("QueryUserDistribution", http.MethodPost, "/selections/user_distributions/query", svc.QueryUserDistribution).
+		Reads(new(cssel.QueryDistReq)).Writes(new([]coresel.AreaValue[float64]))
+	h.Add("QueryPingLatency", http.MethodPost, "/selections/latency/ping/query", svc.QueryPingLatency).
+		Reads(new(cssel.AreaTopoIDCQueryReq)).Writes(new([]cssel.MultiIdcTopo))
+	h.Add("QueryBizLatency", http.MethodPost, "/selections/latency/biz/query", svc.QueryBizLatency).
+		Reads(new(cssel.AreaTopoIDCQueryReq)).Writes(new([]cssel.MultiIdcTopo))
 	h.Add("QueryServiceArea", http.MethodPost, "/selections/idcs/service_areas/{datasource}/query",
-		svc.QueryServiceArea)
+		svc.QueryServiceArea).Reads(new(cssel.AreaTopoIDCQueryReq)).Writes(new([]coresel.IdcServiceAreaRel))
 
-	h.Add("GenerateRecommendScheme", http.MethodPost, "/selections/schemes/generate", svc.GenerateRecommendScheme)
+	h.Add("GenerateRecommendScheme", http.MethodPost, "/selections/schemes/generate", svc.GenerateRecommendScheme).
+		Reads(new(cssel.GenSchemeReq)).Writes(new([]cssel.GeneratedSchemeResult))
 
 	h.Load(c.WebService)
 }
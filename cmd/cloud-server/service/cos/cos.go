@@ -25,6 +25,8 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/adaptor/types/cos"
+	cloudserver "hcm/pkg/api/cloud-server"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -40,9 +42,12 @@ func InitService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("CreateCosBucket", http.MethodPost, "/cos/buckets/create", svc.CreateCosBucket)
-	h.Add("DeleteCosBucket", http.MethodDelete, "/cos/buckets/delete", svc.DeleteCosBucket)
-	h.Add("ListCosBucket", http.MethodPost, "/cos/buckets/list", svc.ListCosBucket)
+	h.Add("CreateCosBucket", http.MethodPost, "/cos/buckets/create", svc.CreateCosBucket).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("DeleteCosBucket", http.MethodDelete, "/cos/buckets/delete", svc.DeleteCosBucket).
+		Reads(new(cloudserver.ResourceDeleteReq))
+	h.Add("ListCosBucket", http.MethodPost, "/cos/buckets/list", svc.ListCosBucket).
+		Reads(new(cloudserver.ResourceListReq)).Writes(new(cos.TCloudBucketListResult))
 
 	h.Load(c.WebService)
 }
@@ -28,6 +28,10 @@ import (
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
 	"hcm/cmd/cloud-server/service/capability"
+	cloudserver "hcm/pkg/api/cloud-server"
+	proto "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -49,46 +53,70 @@ func InitCvmService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetCvm", http.MethodGet, "/cvms/{id}", svc.GetCvm)
-	h.Add("ListCvmExt", http.MethodPost, "/cvms/list", svc.ListCvm)
-	h.Add("CreateCvm", http.MethodPost, "/cvms/create", svc.CreateCvm)
-	h.Add("InquiryPriceCvm", http.MethodPost, "/cvms/prices/inquiry", svc.InquiryPriceCvm)
-	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm)
-	h.Add("AssignCvmToBiz", http.MethodPost, "/cvms/assign/bizs", svc.AssignCvmToBiz)
-	h.Add("AssignCvmToBizPreview", http.MethodPost, "/cvms/assign/bizs/preview", svc.AssignCvmToBizPreview)
-	h.Add("ListAssignedCvmMatchHost", http.MethodPost, "/cvms/assign/hosts/match/list", svc.ListAssignedCvmMatchHost)
-	h.Add("BatchStartCvm", http.MethodPost, "/cvms/batch/start", svc.BatchStartCvm)
-	h.Add("BatchStopCvm", http.MethodPost, "/cvms/batch/stop", svc.BatchStopCvm)
-	h.Add("BatchRebootCvm", http.MethodPost, "/cvms/batch/reboot", svc.BatchRebootCvm)
-	h.Add("QueryCvmRelatedRes", http.MethodPost, "/cvms/rel_res/batch", svc.QueryCvmRelatedRes)
+	h.Add("ListCvmExt", http.MethodPost, "/cvms/list", svc.ListCvm).Reads(new(cloudserver.ListReq))
+	h.Add("CreateCvm", http.MethodPost, "/cvms/create", svc.CreateCvm).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(core.CreateResult))
+	h.Add("InquiryPriceCvm", http.MethodPost, "/cvms/prices/inquiry", svc.InquiryPriceCvm).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm).
+		Reads(new(cloudserver.BatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("AssignCvmToBiz", http.MethodPost, "/cvms/assign/bizs", svc.AssignCvmToBiz).
+		Reads(new(proto.AssignCvmToBizReq))
+	h.Add("AssignCvmToBizPreview", http.MethodPost, "/cvms/assign/bizs/preview", svc.AssignCvmToBizPreview).
+		Reads(new(proto.AssignCvmToBizPreviewReq)).Writes(new(proto.AssignCvmToBizPreviewData))
+	h.Add("ListAssignedCvmMatchHost", http.MethodPost, "/cvms/assign/hosts/match/list", svc.ListAssignedCvmMatchHost).
+		Reads(new(proto.ListAssignedCvmMatchHostReq)).Writes(new(proto.ListAssignedCvmMatchHostData))
+	h.Add("BatchStartCvm", http.MethodPost, "/cvms/batch/start", svc.BatchStartCvm).
+		Reads(new(proto.BatchStartCvmReq)).Writes(new(core.CreateResult))
+	h.Add("BatchStopCvm", http.MethodPost, "/cvms/batch/stop", svc.BatchStopCvm).
+		Reads(new(proto.BatchStopCvmReq)).Writes(new(core.CreateResult))
+	h.Add("BatchRebootCvm", http.MethodPost, "/cvms/batch/reboot", svc.BatchRebootCvm).
+		Reads(new(proto.BatchRebootCvmReq)).Writes(new(core.CreateResult))
+	h.Add("QueryCvmRelatedRes", http.MethodPost, "/cvms/rel_res/batch", svc.QueryCvmRelatedRes).
+		Reads(new(proto.BatchQueryCvmRelatedReq)).Writes(new([]proto.CvmRelatedInfo))
 
 	// 资源下回收相关接口
-	h.Add("RecycleCvm", http.MethodPost, "/cvms/recycle", svc.RecycleCvm)
-	h.Add("RecoverCvm", http.MethodPost, "/cvms/recover", svc.RecoverCvm)
+	h.Add("RecycleCvm", http.MethodPost, "/cvms/recycle", svc.RecycleCvm).
+		Reads(new(proto.CvmRecycleReq)).Writes(new(recycle.RecycleResult))
+	h.Add("RecoverCvm", http.MethodPost, "/cvms/recover", svc.RecoverCvm).Reads(new(proto.CvmRecoverReq))
 	h.Add("GetRecycledCvm", http.MethodGet, "/recycled/cvms/{id}", svc.GetRecyclingCvm)
-	h.Add("BatchDeleteRecycledCvm", http.MethodDelete, "/recycled/cvms/batch", svc.BatchDeleteRecycledCvm)
+	h.Add("BatchDeleteRecycledCvm", http.MethodDelete, "/recycled/cvms/batch", svc.BatchDeleteRecycledCvm).
+		Reads(new(proto.CvmDeleteRecycledReq)).Writes(new(core.BatchOperateResult))
 
 	// cvm apis in biz
 	h.Add("GetBizCvm", http.MethodGet, "/bizs/{bk_biz_id}/cvms/{id}", svc.GetBizCvm)
-	h.Add("ListBizCvmExt", http.MethodPost, "/bizs/{bk_biz_id}/cvms/list", svc.ListBizCvm)
-	h.Add("BatchDeleteBizCvm", http.MethodDelete, "/bizs/{bk_biz_id}/cvms/batch", svc.BatchDeleteBizCvm)
-	h.Add("BatchStartBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/start", svc.BatchStartBizCvm)
-	h.Add("BatchStopBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/stop", svc.BatchStopBizCvm)
-	h.Add("BatchRebootBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/reboot", svc.BatchRebootBizCvm)
-	h.Add("QueryBizCvmRelatedRes", http.MethodPost, "/bizs/{bk_biz_id}/cvms/rel_res/batch", svc.QueryBizCvmRelatedRes)
+	h.Add("ListBizCvmExt", http.MethodPost, "/bizs/{bk_biz_id}/cvms/list", svc.ListBizCvm).
+		Reads(new(cloudserver.ListReq))
+	h.Add("BatchDeleteBizCvm", http.MethodDelete, "/bizs/{bk_biz_id}/cvms/batch", svc.BatchDeleteBizCvm).
+		Reads(new(cloudserver.BatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("BatchStartBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/start", svc.BatchStartBizCvm).
+		Reads(new(proto.BatchStartCvmReq)).Writes(new(core.CreateResult))
+	h.Add("BatchStopBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/stop", svc.BatchStopBizCvm).
+		Reads(new(proto.BatchStopCvmReq)).Writes(new(core.CreateResult))
+	h.Add("BatchRebootBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/reboot", svc.BatchRebootBizCvm).
+		Reads(new(proto.BatchRebootCvmReq)).Writes(new(core.CreateResult))
+	h.Add("QueryBizCvmRelatedRes", http.MethodPost, "/bizs/{bk_biz_id}/cvms/rel_res/batch", svc.QueryBizCvmRelatedRes).
+		Reads(new(proto.BatchQueryCvmRelatedReq)).Writes(new([]proto.CvmRelatedInfo))
 	h.Add("ListCvmSecurityGroupRules", http.MethodPost,
-		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/{security_group_id}/rules/list", svc.ListCvmSecurityGroupRules)
+		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/{security_group_id}/rules/list",
+		svc.ListCvmSecurityGroupRules).
+		Reads(new(core.ListReq))
 
 	// 业务下回收接口
-	h.Add("RecycleBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/recycle", svc.RecycleBizCvm)
-	h.Add("RecoverBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/recover", svc.RecoverBizCvm)
+	h.Add("RecycleBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/recycle", svc.RecycleBizCvm).
+		Reads(new(proto.CvmRecycleReq)).Writes(new(recycle.RecycleResult))
+	h.Add("RecoverBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/recover", svc.RecoverBizCvm).
+		Reads(new(proto.CvmRecoverReq))
 	h.Add("GetBizRecycledCvm", http.MethodGet, "/bizs/{bk_biz_id}/recycled/cvms/{id}", svc.GetBizRecyclingCvm)
 	h.Add("BatchDeleteBizRecycledCvm", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/cvms/batch",
-		svc.BatchDeleteBizRecycledCvm)
+		svc.BatchDeleteBizRecycledCvm).Reads(new(proto.CvmDeleteRecycledReq)).Writes(new(core.BatchOperateResult))
 
 	h.Add("BatchAssociateSecurityGroups", http.MethodPost,
-		"/cvms/{cvm_id}/security_groups/batch_associate", svc.BatchAssociateSecurityGroups)
+		"/cvms/{cvm_id}/security_groups/batch_associate", svc.BatchAssociateSecurityGroups).
+		Reads(new(proto.BatchAssociateSecurityGroupsReq))
 	h.Add("BizBatchAssociateSecurityGroups", http.MethodPost,
-		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/batch_associate", svc.BizBatchAssociateSecurityGroups)
+		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/batch_associate", svc.BizBatchAssociateSecurityGroups).
+		Reads(new(proto.BatchAssociateSecurityGroupsReq))
 
 	initCvmServiceHooks(svc, h)
 
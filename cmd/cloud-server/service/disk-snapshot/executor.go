@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/disk"
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	dsdisk "hcm/pkg/api/data-service/cloud/disk"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/times"
+)
+
+// snapshotNameMaxLen 自动快照名称的最大长度，取各云厂商快照名称长度限制的最小值
+const snapshotNameMaxLen = 60
+
+// ExecDiskSnapshotPolicy 每小时整点执行定期快照策略，为命中执行时间的策略绑定的云硬盘创建快照，并清理超过保留天数的快照
+func ExecDiskSnapshotPolicy(sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("disk snapshot policy executor enable && start")
+
+	e := &executor{
+		snapshotCli: cliSet.DataService().Global.DiskSnapshot,
+		diskCli:     cliSet.DataService().Global,
+		diskLgc:     disk.NewDisk(cliSet, audit.NewAudit(cliSet.DataService())),
+	}
+
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Hour).Add(time.Hour)
+		time.Sleep(next.Sub(now))
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		logs.Infof("disk snapshot policy execute start, time: %s, rid: %s", next, kt.Rid)
+
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			e.execAllPolicy(tenantKt, next)
+		}
+
+		logs.Infof("disk snapshot policy execute end, cost: %s, rid: %s", time.Since(next), kt.Rid)
+	}
+}
+
+// snapshotClient 执行策略用到的数据服务快照接口
+type snapshotClient interface {
+	ListPolicy(kt *kit.Kit, req *core.ListReq) (*dssnapshot.ListDiskSnapshotPolicyResult, error)
+	List(kt *kit.Kit, req *core.ListReq) (*dssnapshot.ListDiskSnapshotResult, error)
+}
+
+// diskClient 执行策略用到的数据服务云硬盘接口
+type diskClient interface {
+	ListDisk(kt *kit.Kit, req *core.ListReq) (*dsdisk.ListResult, error)
+}
+
+type executor struct {
+	snapshotCli snapshotClient
+	diskCli     diskClient
+	diskLgc     disk.Interface
+}
+
+// execAllPolicy 执行租户下的全部策略，单个策略执行失败不影响其他策略
+func (e *executor) execAllPolicy(kt *kit.Kit, now time.Time) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	for {
+		result, err := e.snapshotCli.ListPolicy(kt, listReq)
+		if err != nil {
+			logs.Errorf("list disk snapshot policy failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, policy := range result.Details {
+			if policy.HitSchedule(int(now.Weekday()), now.Hour()) {
+				e.createPolicySnapshot(kt, &policy, now)
+			}
+
+			if err = e.cleanExpiredSnapshot(kt, &policy, now); err != nil {
+				logs.Errorf("clean expired disk snapshot failed, err: %v, policy: %s, rid: %s", err, policy.ID,
+					kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// createPolicySnapshot 为策略绑定的云硬盘创建快照，回收站中的云硬盘不创建快照
+func (e *executor) createPolicySnapshot(kt *kit.Kit, policy *coresnapshot.DiskSnapshotPolicy, now time.Time) {
+	if len(policy.DiskIDs) == 0 {
+		return
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("id", policy.DiskIDs),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	disks, err := e.diskCli.ListDisk(kt, listReq)
+	if err != nil {
+		logs.Errorf("list policy disk failed, err: %v, policy: %s, rid: %s", err, policy.ID, kt.Rid)
+		return
+	}
+
+	name := snapshotName(policy.Name, now)
+	for _, one := range disks.Details {
+		req := &hcsnapshot.DiskSnapshotCreateReq{DiskID: one.ID, Name: name, PolicyID: policy.ID}
+		if _, err = e.diskLgc.CreateDiskSnapshot(kt, enumor.Vendor(one.Vendor), req); err != nil {
+			logs.Errorf("create policy disk snapshot failed, err: %v, policy: %s, disk: %s, rid: %s", err,
+				policy.ID, one.ID, kt.Rid)
+			continue
+		}
+	}
+}
+
+// cleanExpiredSnapshot 删除策略创建的超过保留天数的快照，保留天数为0时不清理
+func (e *executor) cleanExpiredSnapshot(kt *kit.Kit, policy *coresnapshot.DiskSnapshotPolicy, now time.Time) error {
+	if policy.RetentionDays == 0 {
+		return nil
+	}
+
+	expireTime := now.AddDate(0, 0, -int(policy.RetentionDays))
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("policy_id", policy.ID),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+			&filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(),
+				Value: times.ConvStdTimeFormat(expireTime)},
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "vendor"},
+	}
+	result, err := e.snapshotCli.List(kt, listReq)
+	if err != nil {
+		return err
+	}
+
+	// 每次执行最多清理一页，剩余的快照在下次执行时清理
+	for _, one := range result.Details {
+		if err = e.diskLgc.DeleteDiskSnapshot(kt, one.Vendor, one.ID); err != nil {
+			logs.Errorf("delete expired disk snapshot failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			continue
+		}
+	}
+
+	return nil
+}
+
+// snapshotName 生成自动快照名称，格式为：策略名称-yyyyMMddHH
+func snapshotName(policyName string, now time.Time) string {
+	suffix := "-" + now.Format("2006010215")
+	runes := []rune(policyName)
+	if maxLen := snapshotNameMaxLen - len(suffix); len(runes) > maxLen {
+		runes = runes[:maxLen]
+	}
+
+	return string(runes) + suffix
+}
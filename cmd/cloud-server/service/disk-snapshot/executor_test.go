@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/cmd/cloud-server/logics/disk"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	dsdisk "hcm/pkg/api/data-service/cloud/disk"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/times"
+)
+
+type fakeSnapshotClient struct {
+	policies  []coresnapshot.DiskSnapshotPolicy
+	snapshots []coresnapshot.DiskSnapshot
+	listReqs  []*core.ListReq
+}
+
+func (f *fakeSnapshotClient) ListPolicy(_ *kit.Kit, _ *core.ListReq) (*dssnapshot.ListDiskSnapshotPolicyResult,
+	error) {
+
+	return &dssnapshot.ListDiskSnapshotPolicyResult{Details: f.policies}, nil
+}
+
+func (f *fakeSnapshotClient) List(_ *kit.Kit, req *core.ListReq) (*dssnapshot.ListDiskSnapshotResult, error) {
+	f.listReqs = append(f.listReqs, req)
+	return &dssnapshot.ListDiskSnapshotResult{Details: f.snapshots}, nil
+}
+
+type fakeDiskClient struct {
+	disks []*coredisk.BaseDisk
+}
+
+func (f *fakeDiskClient) ListDisk(_ *kit.Kit, _ *core.ListReq) (*dsdisk.ListResult, error) {
+	return &dsdisk.ListResult{Details: f.disks}, nil
+}
+
+// fakeDiskLogic 记录创建和删除的快照，只实现执行策略用到的方法
+type fakeDiskLogic struct {
+	disk.Interface
+	created  []hcsnapshot.DiskSnapshotCreateReq
+	deleted  []string
+	failedID string
+}
+
+func (f *fakeDiskLogic) CreateDiskSnapshot(_ *kit.Kit, _ enumor.Vendor, req *hcsnapshot.DiskSnapshotCreateReq) (
+	*core.CreateResult, error) {
+
+	f.created = append(f.created, *req)
+	return &core.CreateResult{ID: "snapshot-" + req.DiskID}, nil
+}
+
+func (f *fakeDiskLogic) DeleteDiskSnapshot(_ *kit.Kit, _ enumor.Vendor, id string) error {
+	if id == f.failedID {
+		return errors.New("snapshot is in use")
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestExecAllPolicy(t *testing.T) {
+	// 周三 02:00
+	now := time.Date(2024, 5, 1, 2, 0, 0, 0, time.Local)
+	snapshotCli := &fakeSnapshotClient{
+		policies: []coresnapshot.DiskSnapshotPolicy{
+			{ID: "policy-1", Name: "daily", Hours: []int{2}, RetentionDays: 7, DiskIDs: []string{"disk-1", "disk-2"}},
+			{ID: "policy-2", Name: "weekend", Hours: []int{2}, WeekDays: []int{0, 6}, DiskIDs: []string{"disk-3"}},
+		},
+		snapshots: []coresnapshot.DiskSnapshot{{ID: "snap-1", Vendor: enumor.TCloud},
+			{ID: "snap-2", Vendor: enumor.TCloud}, {ID: "snap-3", Vendor: enumor.TCloud}},
+	}
+	diskCli := &fakeDiskClient{disks: []*coredisk.BaseDisk{{ID: "disk-1", Vendor: string(enumor.TCloud)},
+		{ID: "disk-2", Vendor: string(enumor.TCloud)}}}
+	diskLgc := &fakeDiskLogic{failedID: "snap-2"}
+
+	e := &executor{snapshotCli: snapshotCli, diskCli: diskCli, diskLgc: diskLgc}
+	e.execAllPolicy(kit.New(), now)
+
+	// 只有命中执行时间的策略创建快照
+	expectCreated := []hcsnapshot.DiskSnapshotCreateReq{
+		{DiskID: "disk-1", Name: "daily-2024050102", PolicyID: "policy-1"},
+		{DiskID: "disk-2", Name: "daily-2024050102", PolicyID: "policy-1"},
+	}
+	if !reflect.DeepEqual(diskLgc.created, expectCreated) {
+		t.Errorf("unexpected created snapshots: %+v", diskLgc.created)
+	}
+
+	// 保留天数为0的策略不清理，删除失败的快照不影响其他快照
+	if len(snapshotCli.listReqs) != 1 {
+		t.Fatalf("only policy with retention days should be cleaned, list times: %d", len(snapshotCli.listReqs))
+	}
+	if !reflect.DeepEqual(diskLgc.deleted, []string{"snap-1", "snap-3"}) {
+		t.Errorf("unexpected deleted snapshots: %v", diskLgc.deleted)
+	}
+
+	rules := make(map[string]*filter.AtomRule)
+	for _, one := range snapshotCli.listReqs[0].Filter.Rules {
+		rule := one.(*filter.AtomRule)
+		rules[rule.Field] = rule
+	}
+	expireTime := times.ConvStdTimeFormat(now.AddDate(0, 0, -7))
+	if rules["policy_id"].Value != "policy-1" || rules["recycle_status"].Value != enumor.RecycleStatus ||
+		rules["created_at"].Op != filter.LessThan.Factory() || rules["created_at"].Value != expireTime {
+		t.Errorf("unexpected expired snapshot filter: %+v", snapshotCli.listReqs[0].Filter)
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 14, 0, 0, 0, time.Local)
+	if got := snapshotName("daily", now); got != "daily-2024050114" {
+		t.Errorf("unexpected snapshot name: %s", got)
+	}
+
+	// 名称超长时按字符截断策略名称，保留时间后缀
+	name := snapshotName(strings.Repeat("快", snapshotNameMaxLen), now)
+	if len([]rune(name)) != snapshotNameMaxLen || !strings.HasSuffix(name, "快-2024050114") {
+		t.Errorf("unexpected truncated snapshot name: %q", name)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	cssnapshot "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	"hcm/pkg/api/data-service/cloud"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// policySupportVendors 支持定期快照策略的云厂商
+var policySupportVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei}
+
+// ListDiskSnapshotPolicy list disk snapshot policy.
+func (svc *snapshotSvc) ListDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshotPolicy(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizDiskSnapshotPolicy list biz disk snapshot policy.
+func (svc *snapshotSvc) ListBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshotPolicy(cts, handler.ListBizAuthRes)
+}
+
+func (svc *snapshotSvc) listDiskSnapshotPolicy(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Disk, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return &dssnapshot.ListDiskSnapshotPolicyResult{Details: make([]coresnapshot.DiskSnapshotPolicy, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.DiskSnapshot.ListPolicy(cts.Kit, req)
+}
+
+// CreateDiskSnapshotPolicy create disk snapshot policy.
+func (svc *snapshotSvc) CreateDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshotPolicy(cts, constant.UnassignedBiz, handler.ResOperateAuth)
+}
+
+// CreateBizDiskSnapshotPolicy create biz disk snapshot policy.
+func (svc *snapshotSvc) CreateBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.createDiskSnapshotPolicy(cts, bizID, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) createDiskSnapshotPolicy(cts *rest.Contexts, bizID int64,
+	validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+
+	req := new(cssnapshot.DiskSnapshotPolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accountInfo, err := svc.getBasicInfo(cts.Kit, enumor.AccountCloudResType, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slice.IsItemInSlice(policySupportVendors, accountInfo.Vendor) {
+		return nil, errf.Newf(errf.InvalidParameter, "disk snapshot policy no support vendor: %s",
+			accountInfo.Vendor)
+	}
+
+	// validate biz and authorize
+	basicInfo := &types.CloudResourceBasicInfo{ID: req.AccountID, Vendor: accountInfo.Vendor,
+		AccountID: req.AccountID, BkBizID: bizID}
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &dssnapshot.BatchCreateDiskSnapshotPolicyReq{
+		Items: []dssnapshot.DiskSnapshotPolicyCreate{{
+			Vendor:        accountInfo.Vendor,
+			AccountID:     req.AccountID,
+			BkBizID:       bizID,
+			Name:          req.Name,
+			Hours:         req.Hours,
+			WeekDays:      req.WeekDays,
+			RetentionDays: req.RetentionDays,
+			Memo:          req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.DiskSnapshot.BatchCreatePolicy(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create disk snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create disk snapshot policy returns no id")
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateDiskSnapshotPolicy update disk snapshot policy.
+func (svc *snapshotSvc) UpdateDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateDiskSnapshotPolicy(cts, handler.ResOperateAuth)
+}
+
+// UpdateBizDiskSnapshotPolicy update biz disk snapshot policy.
+func (svc *snapshotSvc) UpdateBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateDiskSnapshotPolicy(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) updateDiskSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cssnapshot.DiskSnapshotPolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policy, err := svc.authorizePolicy(cts, validHandler, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := &dssnapshot.BatchUpdateDiskSnapshotPolicyReq{
+		Items: []dssnapshot.DiskSnapshotPolicyUpdate{{
+			ID:            policy.ID,
+			Name:          req.Name,
+			Hours:         req.Hours,
+			WeekDays:      req.WeekDays,
+			RetentionDays: req.RetentionDays,
+			Memo:          req.Memo,
+		}},
+	}
+	if err = svc.client.DataService().Global.DiskSnapshot.BatchUpdatePolicy(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update disk snapshot policy failed, err: %v, id: %s, rid: %s", err, policy.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// DeleteDiskSnapshotPolicy delete disk snapshot policy.
+func (svc *snapshotSvc) DeleteDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshotPolicy(cts, handler.ResOperateAuth)
+}
+
+// DeleteBizDiskSnapshotPolicy delete biz disk snapshot policy.
+func (svc *snapshotSvc) DeleteBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshotPolicy(cts, handler.BizOperateAuth)
+}
+
+// deleteDiskSnapshotPolicy 删除策略不会删除策略已创建的快照
+func (svc *snapshotSvc) deleteDiskSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	policy, err := svc.authorizePolicy(cts, validHandler, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteReq := &dssnapshot.DeleteDiskSnapshotPolicyReq{Filter: tools.EqualExpression("id", policy.ID)}
+	if err = svc.client.DataService().Global.DiskSnapshot.DeletePolicy(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("delete disk snapshot policy failed, err: %v, id: %s, rid: %s", err, policy.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BindDiskSnapshotPolicy bind disks to disk snapshot policy.
+func (svc *snapshotSvc) BindDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.bindDiskSnapshotPolicy(cts, handler.ResOperateAuth, true)
+}
+
+// BindBizDiskSnapshotPolicy bind biz disks to disk snapshot policy.
+func (svc *snapshotSvc) BindBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.bindDiskSnapshotPolicy(cts, handler.BizOperateAuth, true)
+}
+
+// UnbindDiskSnapshotPolicy unbind disks from disk snapshot policy.
+func (svc *snapshotSvc) UnbindDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.bindDiskSnapshotPolicy(cts, handler.ResOperateAuth, false)
+}
+
+// UnbindBizDiskSnapshotPolicy unbind biz disks from disk snapshot policy.
+func (svc *snapshotSvc) UnbindBizDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.bindDiskSnapshotPolicy(cts, handler.BizOperateAuth, false)
+}
+
+func (svc *snapshotSvc) bindDiskSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	isBind bool) (interface{}, error) {
+
+	req := new(cssnapshot.DiskSnapshotPolicyBindReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policy, err := svc.authorizePolicy(cts, validHandler, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	diskIDs := slice.Unique(req.DiskIDs)
+	if !isBind {
+		remain := slice.Filter(policy.DiskIDs, func(id string) bool { return !slice.IsItemInSlice(diskIDs, id) })
+		return nil, svc.updatePolicyDisks(cts.Kit, policy.ID, remain)
+	}
+
+	if err = svc.validateBindDisks(cts, validHandler, policy, diskIDs); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.updatePolicyDisks(cts.Kit, policy.ID, slice.Unique(append(policy.DiskIDs, diskIDs...)))
+}
+
+// validateBindDisks 校验待绑定的云硬盘权限，云硬盘需与策略属于同一账号，且一个云硬盘只能绑定一个策略
+func (svc *snapshotSvc) validateBindDisks(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	policy *coresnapshot.DiskSnapshotPolicy, diskIDs []string) error {
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.DiskCloudResType,
+		IDs:          diskIDs,
+		Fields:       types.ResWithRecycleBasicFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	if len(basicInfoMap) != len(diskIDs) {
+		return errf.New(errf.InvalidParameter, "some disks are not found")
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Update, BasicInfos: basicInfoMap})
+	if err != nil {
+		return err
+	}
+
+	for id, info := range basicInfoMap {
+		if info.AccountID != policy.AccountID {
+			return errf.Newf(errf.InvalidParameter, "disk: %s is not in policy account: %s", id, policy.AccountID)
+		}
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleNotEqual("id", policy.ID),
+			tools.RuleJsonOverlaps("disk_ids", diskIDs),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "disk_ids"},
+	}
+	result, err := svc.client.DataService().Global.DiskSnapshot.ListPolicy(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list disk snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return err
+	}
+
+	for _, one := range result.Details {
+		bound := slice.Intersection(one.DiskIDs, diskIDs)
+		if len(bound) != 0 {
+			return errf.Newf(errf.InvalidParameter, "disks: %v are already bound to policy: %s", bound, one.ID)
+		}
+	}
+
+	return nil
+}
+
+func (svc *snapshotSvc) updatePolicyDisks(kt *kit.Kit, id string, diskIDs []string) error {
+	if len(diskIDs) > int(core.DefaultMaxPageLimit) {
+		return fmt.Errorf("disk snapshot policy can bind at most %d disks", core.DefaultMaxPageLimit)
+	}
+
+	if diskIDs == nil {
+		diskIDs = make([]string, 0)
+	}
+
+	updateReq := &dssnapshot.BatchUpdateDiskSnapshotPolicyReq{
+		Items: []dssnapshot.DiskSnapshotPolicyUpdate{{ID: id, DiskIDs: diskIDs}},
+	}
+	if err := svc.client.DataService().Global.DiskSnapshot.BatchUpdatePolicy(kt, updateReq); err != nil {
+		logs.Errorf("update disk snapshot policy disks failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// authorizePolicy 查询url中的定期快照策略并鉴权
+func (svc *snapshotSvc) authorizePolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	action meta.Action) (*coresnapshot.DiskSnapshotPolicy, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.DiskSnapshot.ListPolicy(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list disk snapshot policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot policy: %s not found", id)
+	}
+	policy := result.Details[0]
+
+	basicInfo := &types.CloudResourceBasicInfo{ID: policy.ID, Vendor: policy.Vendor, AccountID: policy.AccountID,
+		BkBizID: policy.BkBizID}
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: action, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot 云硬盘快照及定期快照策略
+package disksnapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/disk"
+	"hcm/cmd/cloud-server/service/capability"
+	cssnapshot "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/api/core"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the disk snapshot service.
+func InitService(c *capability.Capability) {
+	svc := &snapshotSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		diskLgc:    c.Logics.Disk,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListDiskSnapshot", http.MethodPost, "/disk_snapshots/list", svc.ListDiskSnapshot).
+		Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotResult))
+	h.Add("CreateDiskSnapshot", http.MethodPost, "/disk_snapshots/create", svc.CreateDiskSnapshot).
+		Reads(new(cssnapshot.DiskSnapshotCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteDiskSnapshot", http.MethodDelete, "/disk_snapshots/{id}", svc.DeleteDiskSnapshot)
+	h.Add("RollbackDiskSnapshot", http.MethodPost, "/disk_snapshots/{id}/rollback", svc.RollbackDiskSnapshot).
+		Reads(new(cssnapshot.DiskSnapshotRollbackReq))
+
+	h.Add("ListDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/list", svc.ListDiskSnapshotPolicy).
+		Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotPolicyResult))
+	h.Add("CreateDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/create",
+		svc.CreateDiskSnapshotPolicy).Reads(new(cssnapshot.DiskSnapshotPolicyCreateReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateDiskSnapshotPolicy", http.MethodPatch, "/disk_snapshot_policies/{id}", svc.UpdateDiskSnapshotPolicy).
+		Reads(new(cssnapshot.DiskSnapshotPolicyUpdateReq))
+	h.Add("DeleteDiskSnapshotPolicy", http.MethodDelete, "/disk_snapshot_policies/{id}",
+		svc.DeleteDiskSnapshotPolicy)
+	h.Add("BindDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/{id}/disks/bind",
+		svc.BindDiskSnapshotPolicy).Reads(new(cssnapshot.DiskSnapshotPolicyBindReq))
+	h.Add("UnbindDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/{id}/disks/unbind",
+		svc.UnbindDiskSnapshotPolicy).Reads(new(cssnapshot.DiskSnapshotPolicyBindReq))
+
+	// disk snapshot apis in biz
+	h.Add("ListBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/list", svc.ListBizDiskSnapshot).
+		Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotResult))
+	h.Add("CreateBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/create",
+		svc.CreateBizDiskSnapshot).Reads(new(cssnapshot.DiskSnapshotCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBizDiskSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/disk_snapshots/{id}",
+		svc.DeleteBizDiskSnapshot)
+	h.Add("RollbackBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/{id}/rollback",
+		svc.RollbackBizDiskSnapshot).Reads(new(cssnapshot.DiskSnapshotRollbackReq))
+
+	h.Add("ListBizDiskSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshot_policies/list",
+		svc.ListBizDiskSnapshotPolicy).Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotPolicyResult))
+	h.Add("CreateBizDiskSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshot_policies/create",
+		svc.CreateBizDiskSnapshotPolicy).
+		Reads(new(cssnapshot.DiskSnapshotPolicyCreateReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateBizDiskSnapshotPolicy", http.MethodPatch, "/bizs/{bk_biz_id}/disk_snapshot_policies/{id}",
+		svc.UpdateBizDiskSnapshotPolicy).Reads(new(cssnapshot.DiskSnapshotPolicyUpdateReq))
+	h.Add("DeleteBizDiskSnapshotPolicy", http.MethodDelete, "/bizs/{bk_biz_id}/disk_snapshot_policies/{id}",
+		svc.DeleteBizDiskSnapshotPolicy)
+	h.Add("BindBizDiskSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshot_policies/{id}/disks/bind",
+		svc.BindBizDiskSnapshotPolicy).Reads(new(cssnapshot.DiskSnapshotPolicyBindReq))
+	h.Add("UnbindBizDiskSnapshotPolicy", http.MethodPost,
+		"/bizs/{bk_biz_id}/disk_snapshot_policies/{id}/disks/unbind", svc.UnbindBizDiskSnapshotPolicy).
+		Reads(new(cssnapshot.DiskSnapshotPolicyBindReq))
+
+	h.Load(c.WebService)
+}
+
+type snapshotSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	diskLgc    disk.Interface
+}
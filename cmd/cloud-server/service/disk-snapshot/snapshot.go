@@ -0,0 +1,235 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	cssnapshot "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	"hcm/pkg/api/data-service/cloud"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListDiskSnapshot list disk snapshot.
+func (svc *snapshotSvc) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizDiskSnapshot list biz disk snapshot.
+func (svc *snapshotSvc) ListBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListBizAuthRes)
+}
+
+func (svc *snapshotSvc) listDiskSnapshot(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 快照作为云硬盘的数据备份，按照云硬盘的权限进行鉴权
+	expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Disk, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return &dssnapshot.ListDiskSnapshotResult{Details: make([]coresnapshot.DiskSnapshot, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.DiskSnapshot.List(cts.Kit, req)
+}
+
+// CreateDiskSnapshot create disk snapshot.
+func (svc *snapshotSvc) CreateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// CreateBizDiskSnapshot create biz disk snapshot.
+func (svc *snapshotSvc) CreateBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) createDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cssnapshot.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.getBasicInfo(cts.Kit, enumor.DiskCloudResType, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &hcsnapshot.DiskSnapshotCreateReq{DiskID: req.DiskID, Name: req.Name}
+	result, err := svc.diskLgc.CreateDiskSnapshot(cts.Kit, basicInfo.Vendor, createReq)
+	if err != nil {
+		logs.Errorf("create disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteDiskSnapshot delete disk snapshot.
+func (svc *snapshotSvc) DeleteDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// DeleteBizDiskSnapshot delete biz disk snapshot.
+func (svc *snapshotSvc) DeleteBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) deleteDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.getBasicInfo(cts.Kit, enumor.DiskSnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Delete, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.diskLgc.DeleteDiskSnapshot(cts.Kit, basicInfo.Vendor, id); err != nil {
+		logs.Errorf("delete disk snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RollbackDiskSnapshot rollback disk by snapshot.
+func (svc *snapshotSvc) RollbackDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// RollbackBizDiskSnapshot rollback biz disk by snapshot.
+func (svc *snapshotSvc) RollbackBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) rollbackDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cssnapshot.DiskSnapshotRollbackReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.getBasicInfo(cts.Kit, enumor.DiskSnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	rollbackReq := &hcsnapshot.DiskSnapshotRollbackReq{ID: id, AutoStopInstance: req.AutoStopInstance}
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.DiskSnapshot.Rollback(cts.Kit, rollbackReq)
+	case enumor.HuaWei:
+		err = svc.client.HCService().HuaWei.DiskSnapshot.Rollback(cts.Kit, rollbackReq)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "disk snapshot rollback no support vendor: %s",
+			basicInfo.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("rollback disk snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *snapshotSvc) getBasicInfo(kt *kit.Kit, resType enumor.CloudResourceType, id string) (
+	*types.CloudResourceBasicInfo, error) {
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          []string{id},
+		Fields:       types.ResWithRecycleBasicFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+	if err != nil {
+		logs.Errorf("list %s basic info failed, err: %v, id: %s, rid: %s", resType, err, id, kt.Rid)
+		return nil, err
+	}
+
+	basicInfo, exists := basicInfoMap[id]
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "%s: %s not found", resType, id)
+	}
+
+	return &basicInfo, nil
+}
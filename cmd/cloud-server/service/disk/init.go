@@ -21,6 +21,12 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/adaptor/types/disk"
+	cloudserver "hcm/pkg/api/cloud-server"
+	cloudproto "hcm/pkg/api/cloud-server/disk"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service/disk"
 	"hcm/pkg/rest"
 )
 
@@ -35,47 +41,59 @@ func InitDiskService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListDisk", http.MethodPost, "/disks/list", svc.ListDisk)
+	h.Add("ListDisk", http.MethodPost, "/disks/list", svc.ListDisk).
+		Reads(new(cloudproto.DiskListReq)).Writes(new(cloudproto.DiskListResult))
 
 	h.Add("AttachDisk", http.MethodPost, "/disks/attach", svc.AttachDisk)
-	h.Add("DetachDisk", http.MethodPost, "/disks/detach", svc.DetachDisk)
-	h.Add("AssignDisk", http.MethodPost, "/disks/assign/bizs", svc.AssignDisk)
+	h.Add("DetachDisk", http.MethodPost, "/disks/detach", svc.DetachDisk).Reads(new(cloudproto.DiskDetachReq))
+	h.Add("AssignDisk", http.MethodPost, "/disks/assign/bizs", svc.AssignDisk).Reads(new(cloudproto.DiskAssignReq))
 
 	h.Add("GetDisk", http.MethodGet, "/disks/{id}", svc.GetDisk)
 	h.Add("DeleteDisk", http.MethodDelete, "/disks/{id}", svc.DeleteDisk)
-	h.Add("CreateDisk", http.MethodPost, "/disks/create", svc.CreateDisk)
-	h.Add("InquiryPriceDisk", http.MethodPost, "/disks/prices/inquiry", svc.InquiryPriceDisk)
+	h.Add("CreateDisk", http.MethodPost, "/disks/create", svc.CreateDisk).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(hcproto.BatchCreateResult))
+	h.Add("InquiryPriceDisk", http.MethodPost, "/disks/prices/inquiry", svc.InquiryPriceDisk).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(disk.InquiryPriceResult))
 
 	h.Add("ListDiskExtByCvmID", http.MethodGet, "/vendors/{vendor}/disks/cvms/{cvm_id}", svc.ListDiskExtByCvmID)
-	h.Add("ListRelWithCvm", http.MethodPost, "/disk_cvm_rels/with/cvms/list", svc.ListRelWithCvm)
-	h.Add("ListDiskCvmRel", http.MethodPost, "/disk_cvm_rels/list", svc.ListDiskCvmRel)
+	h.Add("ListRelWithCvm", http.MethodPost, "/disk_cvm_rels/with/cvms/list", svc.ListRelWithCvm).
+		Reads(new(cloudserver.ListWithCvmReq))
+	h.Add("ListDiskCvmRel", http.MethodPost, "/disk_cvm_rels/list", svc.ListDiskCvmRel).
+		Reads(new(core.ListReq)).Writes(new(cloud.DiskCvmRelListResult))
 	h.Add("ListRelDiskWithoutCvm", http.MethodPost, "/disk_cvm_rels/with/disks/without/cvm/list",
-		svc.ListRelDiskWithoutCvm)
+		svc.ListRelDiskWithoutCvm).Reads(new(cloudserver.ListDiskWithoutCvmReq))
 
 	// disk apis in biz
-	h.Add("ListBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/list", svc.ListBizDisk)
-	h.Add("ListBizRelWithCvm", http.MethodPost, "/bizs/{bk_biz_id}/disk_cvm_rels/with/cvms/list", svc.ListBizRelWithCvm)
+	h.Add("ListBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/list", svc.ListBizDisk).
+		Reads(new(cloudproto.DiskListReq)).Writes(new(cloudproto.DiskListResult))
+	h.Add("ListBizRelWithCvm", http.MethodPost,
+		"/bizs/{bk_biz_id}/disk_cvm_rels/with/cvms/list", svc.ListBizRelWithCvm).
+		Reads(new(cloudserver.ListWithCvmReq))
 	h.Add("ListBizDiskExtByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/vendors/{vendor}/disks/cvms/{cvm_id}",
 		svc.ListBizDiskExtByCvmID)
 	h.Add("ListBizRelDiskWithoutCvm", http.MethodPost, "/bizs/{bk_biz_id}/disk_cvm_rels/with/disks/without/cvm/list",
-		svc.ListBizRelDiskWithoutCvm)
+		svc.ListBizRelDiskWithoutCvm).Reads(new(cloudserver.ListDiskWithoutCvmReq))
 	h.Add("GetBizDisk", http.MethodGet, "/bizs/{bk_biz_id}/disks/{id}", svc.GetBizDisk)
 	h.Add("DeleteBizDisk", http.MethodDelete, "/bizs/{bk_biz_id}/disks/{id}", svc.DeleteBizDisk)
 	h.Add("AttachBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/attach", svc.AttachBizDisk)
-	h.Add("DetachBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/detach", svc.DetachBizDisk)
+	h.Add("DetachBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/detach", svc.DetachBizDisk).
+		Reads(new(cloudproto.DiskDetachReq))
 
 	// recycle operation in res
-	h.Add("RecycleDisk", http.MethodPost, "/disks/recycle", svc.RecycleDisk)
-	h.Add("RecoverDisk", http.MethodPost, "/disks/recover", svc.RecoverDisk)
+	h.Add("RecycleDisk", http.MethodPost, "/disks/recycle", svc.RecycleDisk).Reads(new(cloudproto.DiskRecycleReq))
+	h.Add("RecoverDisk", http.MethodPost, "/disks/recover", svc.RecoverDisk).Reads(new(cloudproto.DiskRecoverReq))
 	h.Add("GetRecycledDisk", http.MethodGet, "/recycled/disks/{id}", svc.GetRecycledDisk)
-	h.Add("BatchDeleteRecycledDisk", http.MethodDelete, "/recycled/disks/batch", svc.BatchDeleteRecycledDisk)
+	h.Add("BatchDeleteRecycledDisk", http.MethodDelete, "/recycled/disks/batch", svc.BatchDeleteRecycledDisk).
+		Reads(new(cloudproto.DiskDeleteRecycleReq)).Writes(new(core.BatchOperateResult))
 
 	// recycle operation in biz
-	h.Add("RecycleBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/recycle", svc.RecycleBizDisk)
-	h.Add("RecoverBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/recover", svc.RecoverBizDisk)
+	h.Add("RecycleBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/recycle", svc.RecycleBizDisk).
+		Reads(new(cloudproto.DiskRecycleReq))
+	h.Add("RecoverBizDisk", http.MethodPost, "/bizs/{bk_biz_id}/disks/recover", svc.RecoverBizDisk).
+		Reads(new(cloudproto.DiskRecoverReq))
 	h.Add("GetBizRecycledDisk", http.MethodGet, "/bizs/{bk_biz_id}/recycled/disks/{id}", svc.GetBizRecycledDisk)
 	h.Add("BatchDeleteBizRecycledDisk", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/disks/batch",
-		svc.BatchDeleteBizRecycledDisk)
+		svc.BatchDeleteBizRecycledDisk).Reads(new(cloudproto.DiskDeleteRecycleReq)).Writes(new(core.BatchOperateResult))
 
 	h.Load(c.WebService)
 }
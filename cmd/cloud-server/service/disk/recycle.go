@@ -39,6 +39,7 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
 )
 
 // RecycleDisk recycle disk.
@@ -216,9 +217,9 @@ func (svc *diskSvc) detachDiskByIDs(kt *kit.Kit, ids []string, basicInfoMap map[
 }
 
 // validateRecycleRecord 只能批量处理处于同一个回收任务的且是等待回收的记录。
-func (svc *diskSvc) validateRecycleRecord(records *dsrr.ListResult) error {
+func (svc *diskSvc) validateRecycleRecord(records []corerr.BaseRecycleRecord) error {
 	taskID := ""
-	for _, one := range records.Details {
+	for _, one := range records {
 		if len(taskID) == 0 {
 			taskID = one.TaskID
 		} else if taskID != one.TaskID {
@@ -272,7 +273,10 @@ func (svc *diskSvc) recoverDisk(cts *rest.Contexts, validHandler handler.ValidWi
 	if len(records.Details) != len(req.RecordIDs) {
 		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
 	}
-	if err = svc.validateRecycleRecord(records); err != nil {
+	baseRecords := slice.Map(records.Details, func(r corerr.RecycleRecord) corerr.BaseRecycleRecord {
+		return r.BaseRecycleRecord
+	})
+	if err = svc.validateRecycleRecord(baseRecords); err != nil {
 		return nil, err
 	}
 
@@ -349,7 +353,7 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 		Filter: tools.ContainersExpression("id", req.RecordIDs),
 		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
 	}
-	records, err := svc.client.DataService().Global.RecycleRecord.ListRecycleRecord(cts.Kit, listReq)
+	records, err := svc.client.DataService().Global.RecycleRecord.ListDiskRecycleRecord(cts.Kit, listReq)
 	if err != nil {
 		return nil, err
 	}
@@ -358,7 +362,10 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
 	}
 
-	if err = svc.validateRecycleRecord(records); err != nil {
+	baseRecords := slice.Map(records.Details, func(r corerr.DiskRecycleRecord) corerr.BaseRecycleRecord {
+		return r.BaseRecycleRecord
+	})
+	if err = svc.validateRecycleRecord(baseRecords); err != nil {
 		return nil, err
 	}
 
@@ -387,7 +394,7 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 }
 
 func (svc *diskSvc) destroyOneRecord(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
-	record corerr.RecycleRecord) error {
+	record corerr.DiskRecycleRecord) error {
 
 	basicInfoReq := cloud.ListResourceBasicInfoReq{
 		ResourceType: enumor.DiskCloudResType,
@@ -407,7 +414,8 @@ func (svc *diskSvc) destroyOneRecord(cts *rest.Contexts, validHandler handler.Va
 		return err
 	}
 
-	if _, err := svc.diskLgc.DeleteRecycledDisk(cts.Kit, basicInfoMap); err != nil {
+	if _, err := svc.diskLgc.DeleteRecycledDisk(cts.Kit, basicInfoMap,
+		[]corerr.DiskRecycleRecord{record}); err != nil {
 		return err
 	}
 
@@ -28,6 +28,9 @@ import (
 	"hcm/cmd/cloud-server/service/eip/gcp"
 	"hcm/cmd/cloud-server/service/eip/huawei"
 	"hcm/cmd/cloud-server/service/eip/tcloud"
+	proto "hcm/pkg/api/cloud-server"
+	cloudproto "hcm/pkg/api/cloud-server/eip"
+	"hcm/pkg/api/core"
 	"hcm/pkg/rest"
 )
 
@@ -47,26 +50,30 @@ func InitEipService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListEip", http.MethodPost, "/eips/list", svc.ListEip)
+	h.Add("ListEip", http.MethodPost, "/eips/list", svc.ListEip).Reads(new(cloudproto.EipListReq))
 	h.Add("RetrieveEip", http.MethodGet, "/eips/{id}", svc.RetrieveEip)
-	h.Add("AssignEip", http.MethodPost, "/eips/assign/bizs", svc.AssignEip)
-	h.Add("BatchDeleteEip", http.MethodDelete, "/eips/batch", svc.BatchDeleteEip)
+	h.Add("AssignEip", http.MethodPost, "/eips/assign/bizs", svc.AssignEip).Reads(new(cloudproto.EipAssignReq))
+	h.Add("BatchDeleteEip", http.MethodDelete, "/eips/batch", svc.BatchDeleteEip).
+		Reads(new(core.BatchDeleteReq)).Writes(new(core.CreateResult))
 	h.Add("ListEipExtByCvmID", http.MethodGet, "/vendors/{vendor}/eips/cvms/{cvm_id}", svc.ListEipExtByCvmID)
 	h.Add("ListRelEipWithoutCvm", http.MethodPost, "/eip_cvm_rels/with/eips/without/cvm/list",
-		svc.ListRelEipWithoutCvm)
-	h.Add("AssociateEip", http.MethodPost, "/eips/associate", svc.AssociateEip)
+		svc.ListRelEipWithoutCvm).Reads(new(proto.ListEipWithoutCvmReq))
+	h.Add("AssociateEip", http.MethodPost, "/eips/associate", svc.AssociateEip).Reads(new(cloudproto.AssociateReq))
 	h.Add("DisassociateEip", http.MethodPost, "/eips/disassociate", svc.DisassociateEip)
 	h.Add("CreateEip", http.MethodPost, "/eips/create", svc.CreateEip)
 
 	// eip apis in biz
-	h.Add("ListBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/list", svc.ListBizEip)
+	h.Add("ListBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/list", svc.ListBizEip).
+		Reads(new(cloudproto.EipListReq))
 	h.Add("ListBizEipExtByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/vendors/{vendor}/eips/cvms/{cvm_id}",
 		svc.ListBizEipExtByCvmID)
 	h.Add("ListBizRelEipWithoutCvm", http.MethodPost, "/bizs/{bk_biz_id}/eip_cvm_rels/with/eips/without/cvm/list",
-		svc.ListBizRelEipWithoutCvm)
+		svc.ListBizRelEipWithoutCvm).Reads(new(proto.ListEipWithoutCvmReq))
 	h.Add("RetrieveBizEip", http.MethodGet, "/bizs/{bk_biz_id}/eips/{id}", svc.RetrieveBizEip)
-	h.Add("BatchDeleteBizEip", http.MethodDelete, "/bizs/{bk_biz_id}/eips/batch", svc.BatchDeleteBizEip)
-	h.Add("AssociateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/associate", svc.AssociateBizEip)
+	h.Add("BatchDeleteBizEip", http.MethodDelete, "/bizs/{bk_biz_id}/eips/batch", svc.BatchDeleteBizEip).
+		Reads(new(core.BatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("AssociateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/associate", svc.AssociateBizEip).
+		Reads(new(cloudproto.AssociateReq))
 	h.Add("DisassociateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/disassociate", svc.DisassociateBizEip)
 	h.Add("CreateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/create", svc.CreateBizEip)
 
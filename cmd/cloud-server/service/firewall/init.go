@@ -24,6 +24,9 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -40,26 +43,30 @@ func InitFirewallService(cap *capability.Capability) {
 	h := rest.NewHandler()
 	// 资源下相关接口
 	h.Add("CreateGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/create",
-		svc.CreateGcpFirewallRule)
+		svc.CreateGcpFirewallRule).Reads(new(proto.GcpFirewallRuleCreateReq)).Writes(new(core.CreateResult))
 	h.Add("BatchDeleteGcpFirewallRule", http.MethodDelete, "/vendors/gcp/firewalls/rules/batch",
-		svc.BatchDeleteGcpFirewallRule)
-	h.Add("UpdateGcpFirewallRule", http.MethodPut, "/vendors/gcp/firewalls/rules/{id}", svc.UpdateGcpFirewallRule)
-	h.Add("ListGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/list", svc.ListGcpFirewallRule)
-	h.Add("GetGcpFirewallRule", http.MethodGet, "/vendors/gcp/firewalls/rules/{id}", svc.GetGcpFirewallRule)
+		svc.BatchDeleteGcpFirewallRule).Reads(new(proto.GcpFirewallRuleBatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateGcpFirewallRule", http.MethodPut, "/vendors/gcp/firewalls/rules/{id}", svc.UpdateGcpFirewallRule).
+		Reads(new(proto.GcpFirewallRuleUpdateReq))
+	h.Add("ListGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/list", svc.ListGcpFirewallRule).
+		Reads(new(proto.GcpFirewallRuleListReq))
+	h.Add("GetGcpFirewallRule", http.MethodGet, "/vendors/gcp/firewalls/rules/{id}", svc.GetGcpFirewallRule).
+		Writes(new(cloud.GcpFirewallRule))
 	h.Add("AssignGcpFirewallRuleToBiz", http.MethodPost, "/vendors/gcp/firewalls/rules/assign/bizs",
-		svc.AssignGcpFirewallRuleToBiz)
+		svc.AssignGcpFirewallRuleToBiz).Reads(new(proto.AssignGcpFirewallRuleToBizReq))
 
 	// 业务下相关接口
 	h.Add("CreateBizGcpFirewallRule", http.MethodPost, "/bizs/{bk_biz_id}/vendors/gcp/firewalls/rules/create",
-		svc.CreateBizGcpFirewallRule)
+		svc.CreateBizGcpFirewallRule).Reads(new(proto.GcpFirewallRuleCreateReq)).Writes(new(core.CreateResult))
 	h.Add("BatchDeleteBizGcpFirewallRule", http.MethodDelete, "/bizs/{bk_biz_id}/vendors/gcp/firewalls/rules/batch",
-		svc.BatchDeleteBizGcpFirewallRule)
+		svc.BatchDeleteBizGcpFirewallRule).
+		Reads(new(proto.GcpFirewallRuleBatchDeleteReq)).Writes(new(core.CreateResult))
 	h.Add("UpdateBizGcpFirewallRule", http.MethodPut, "/bizs/{bk_biz_id}/vendors/gcp/firewalls/rules/{id}",
-		svc.UpdateBizGcpFirewallRule)
+		svc.UpdateBizGcpFirewallRule).Reads(new(proto.GcpFirewallRuleUpdateReq))
 	h.Add("ListBizGcpFirewallRule", http.MethodPost, "/bizs/{bk_biz_id}/vendors/gcp/firewalls/rules/list",
-		svc.ListBizGcpFirewallRule)
+		svc.ListBizGcpFirewallRule).Reads(new(proto.GcpFirewallRuleListReq))
 	h.Add("GetBizGcpFirewallRule", http.MethodGet, "/bizs/{bk_biz_id}/vendors/gcp/firewalls/rules/{id}",
-		svc.GetBizGcpFirewallRule)
+		svc.GetBizGcpFirewallRule).Writes(new(cloud.GcpFirewallRule))
 
 	h.Load(cap.WebService)
 }
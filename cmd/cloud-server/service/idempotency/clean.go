@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency 幂等请求记录清理
+package idempotency
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	dsidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/client"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+const (
+	// cleanInterval 过期幂等请求记录清理周期
+	cleanInterval = time.Hour
+	// cleanBatchLimit 单次删除过期幂等请求记录的最大数量
+	cleanBatchLimit = 5000
+)
+
+// CleanExpiredIdempotencyRecord 定时清理所有租户过期的幂等请求记录，包括 hc-service 写入的记录
+func CleanExpiredIdempotencyRecord(sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("idempotency record clean enable && start, interval: %v", cleanInterval)
+
+	for {
+		time.Sleep(cleanInterval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		req := &dsidem.DeleteExpiredReq{Limit: cleanBatchLimit}
+
+		var total int64
+		for {
+			result, err := cliSet.DataService().Global.Idempotency.DeleteExpired(kt, req)
+			if err != nil {
+				logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, kt.Rid)
+				break
+			}
+
+			total += result.Deleted
+			if result.Deleted < cleanBatchLimit {
+				break
+			}
+		}
+
+		logs.V(3).Infof("clean expired idempotency record end, count: %d, rid: %s", total, kt.Rid)
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/cloud/image"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -38,7 +40,8 @@ func InitImageService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetImage", http.MethodGet, "/vendors/{vendor}/images/{id}", svc.RetrieveImage)
-	h.Add("ListImage", http.MethodPost, "/images/list", svc.ListImage)
+	h.Add("ListImage", http.MethodPost, "/images/list", svc.ListImage).
+		Reads(new(core.ListReq)).Writes(new(image.ListResult))
 
 	h.Add("TCloudQueryImage", http.MethodPost, "/vendors/tcloud/images/query_from_cloud", svc.TCloudQueryImage)
 	h.Add("TCLoudBizQueryImage", http.MethodPost, "/bizs/{bk_biz_id}/vendors/tcloud/images/query_from_cloud",
@@ -24,6 +24,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server/instance-type"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -46,10 +47,11 @@ func InitInstanceTypeService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 业务下。
-	h.Add("ListInBiz", http.MethodPost, "/bizs/{bk_biz_id}/instance_types/list", svc.ListInBiz)
+	h.Add("ListInBiz", http.MethodPost, "/bizs/{bk_biz_id}/instance_types/list", svc.ListInBiz).
+		Reads(new(proto.ListReq))
 
 	// 资源下。
-	h.Add("ListInRes", http.MethodPost, "/instance_types/list", svc.ListInRes)
+	h.Add("ListInRes", http.MethodPost, "/instance_types/list", svc.ListInRes).Reads(new(proto.ListReq))
 
 	h.Load(c.WebService)
 }
@@ -23,11 +23,19 @@ package loadbalancer
 import (
 	"net/http"
 
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/cvm"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
 	"hcm/cmd/cloud-server/service/capability"
+	loadbalancer "hcm/pkg/adaptor/types/load-balancer"
+	cloudserver "hcm/pkg/api/cloud-server"
+	cslb "hcm/pkg/api/cloud-server/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -44,20 +52,30 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// clb apis in res
-	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer).
+		Reads(new(cloudserver.ListReq))
 	h.Add("ListLoadBalancerWithDeleteProtection", http.MethodPost,
-		"/load_balancers/with/delete_protection/list", svc.ListLoadBalancerWithDeleteProtect)
-	h.Add("BatchCreateLB", http.MethodPost, "/load_balancers/create", svc.BatchCreateLB)
-	h.Add("InquiryPriceLoadBalancer", http.MethodPost, "/load_balancers/prices/inquiry", svc.InquiryPriceLoadBalancer)
-	h.Add("AssignLbToBiz", http.MethodPost, "/load_balancers/assign/bizs", svc.AssignLbToBiz)
-	h.Add("GetLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetLoadBalancer)
+		"/load_balancers/with/delete_protection/list", svc.ListLoadBalancerWithDeleteProtect).
+		Reads(new(cloudserver.ListReq))
+	h.Add("BatchCreateLB", http.MethodPost, "/load_balancers/create", svc.BatchCreateLB).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(hclb.BatchCreateResult))
+	h.Add("InquiryPriceLoadBalancer", http.MethodPost, "/load_balancers/prices/inquiry", svc.InquiryPriceLoadBalancer).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(loadbalancer.TCloudLBPrice))
+	h.Add("AssignLbToBiz", http.MethodPost, "/load_balancers/assign/bizs", svc.AssignLbToBiz).
+		Reads(new(cslb.AssignLbToBizReq))
+	h.Add("GetLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetLoadBalancer).
+		Writes(new(corelb.LoadBalancer[corelb.TCloudClbExtension]))
 	h.Add("TCloudDescribeResources", http.MethodPost,
-		"/vendors/tcloud/load_balancers/resources/describe", svc.TCloudDescribeResources)
-	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
-	h.Add("ListListenerCountByLbIDs", http.MethodPost, "/load_balancers/listeners/count", svc.ListListenerCountByLbIDs)
+		"/vendors/tcloud/load_balancers/resources/describe", svc.TCloudDescribeResources).
+		Reads(new(hclb.TCloudDescribeResourcesOption)).Writes(new(v20180317.DescribeResourcesResponseParams))
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer).
+		Reads(new(core.BatchDeleteReq))
+	h.Add("ListListenerCountByLbIDs", http.MethodPost, "/load_balancers/listeners/count", svc.ListListenerCountByLbIDs).
+		Reads(new(dataproto.ListListenerCountByLbIDsReq)).Writes(new(dataproto.ListListenerCountResp))
 	h.Add("GetLoadBalancerLockStatus", http.MethodGet,
-		"/load_balancers/{id}/lock/status", svc.GetLoadBalancerLockStatus)
-	h.Add("ListResLoadBalancerQuotas", http.MethodPost, "/load_balancers/quotas", svc.ListResLoadBalancerQuotas)
+		"/load_balancers/{id}/lock/status", svc.GetLoadBalancerLockStatus).Writes(new(cslb.ResourceFlowStatusResp))
+	h.Add("ListResLoadBalancerQuotas", http.MethodPost, "/load_balancers/quotas", svc.ListResLoadBalancerQuotas).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]loadbalancer.TCloudLoadBalancerQuota))
 
 	bizH := rest.NewHandler()
 	bizH.Path("/bizs/{bk_biz_id}")
@@ -72,115 +90,164 @@ func InitService(c *capability.Capability) {
 func bizService(h *rest.Handler, svc *lbSvc) {
 	// h.Add("BizBatchCreateLB", http.MethodPost, "/load_balancers/create", svc.BizBatchCreateLB)
 	h.Add("UpdateBizTCloudLoadBalancer", http.MethodPatch,
-		"/vendors/tcloud/load_balancers/{id}", svc.UpdateBizTCloudLoadBalancer)
+		"/vendors/tcloud/load_balancers/{id}", svc.UpdateBizTCloudLoadBalancer).Reads(new(hclb.TCloudLBUpdateReq))
 	h.Add("InquiryPriceBizLoadBalancer", http.MethodPost, "/load_balancers/prices/inquiry",
-		svc.InquiryPriceBizLoadBalancer)
-	h.Add("ListBizLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListBizLoadBalancer)
+		svc.InquiryPriceBizLoadBalancer).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(loadbalancer.TCloudLBPrice))
+	h.Add("ListBizLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListBizLoadBalancer).
+		Reads(new(cloudserver.ListReq))
 	h.Add("ListLoadBalancerWithDeleteProtection", http.MethodPost,
-		"/load_balancers/with/delete_protection/list", svc.ListBizLoadBalancerWithDelProtect)
-	h.Add("GetBizLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetBizLoadBalancer)
-	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteBizLoadBalancer)
+		"/load_balancers/with/delete_protection/list", svc.ListBizLoadBalancerWithDelProtect).
+		Reads(new(cloudserver.ListReq))
+	h.Add("GetBizLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetBizLoadBalancer).
+		Writes(new(corelb.LoadBalancer[corelb.TCloudClbExtension]))
+	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteBizLoadBalancer).
+		Reads(new(core.BatchDeleteReq))
 
-	h.Add("ListBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/list", svc.ListBizListener)
-	h.Add("GetBizListener", http.MethodGet, "/listeners/{id}", svc.GetBizListener)
+	h.Add("ListBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/list", svc.ListBizListener).
+		Reads(new(core.ListReq))
+	h.Add("GetBizListener", http.MethodGet, "/listeners/{id}", svc.GetBizListener).
+		Writes(new(cslb.GetTCloudListenerDetail))
 	h.Add("ListBizListenerDomains", http.MethodPost,
-		"/vendors/tcloud/listeners/{lbl_id}/domains/list", svc.ListBizListenerDomains)
+		"/vendors/tcloud/listeners/{lbl_id}/domains/list", svc.ListBizListenerDomains).
+		Writes(new(cslb.GetListenerDomainResult))
 	h.Add("ListBizListenerCountByLbIDs", http.MethodPost, "/load_balancers/listeners/count",
-		svc.ListBizListenerCountByLbIDs)
+		svc.ListBizListenerCountByLbIDs).
+		Reads(new(dataproto.ListListenerCountByLbIDsReq)).Writes(new(dataproto.ListListenerCountResp))
 	h.Add("GetBizLoadBalancerLockStatus", http.MethodGet,
-		"/load_balancers/{id}/lock/status", svc.GetBizLoadBalancerLockStatus)
-	h.Add("ListBizLoadBalancerQuotas", http.MethodPost, "/load_balancers/quotas", svc.ListBizLoadBalancerQuotas)
+		"/load_balancers/{id}/lock/status", svc.GetBizLoadBalancerLockStatus).Writes(new(cslb.ResourceFlowStatusResp))
+	h.Add("ListBizLoadBalancerQuotas", http.MethodPost, "/load_balancers/quotas", svc.ListBizLoadBalancerQuotas).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]loadbalancer.TCloudLoadBalancerQuota))
 
 	h.Add("TCloudCreateSnatIps", http.MethodPost,
-		"/vendors/tcloud/load_balancers/{lb_id}/snat_ips/create", svc.TCloudCreateSnatIps)
+		"/vendors/tcloud/load_balancers/{lb_id}/snat_ips/create", svc.TCloudCreateSnatIps).
+		Reads(new(cslb.TCloudCreateSnatIpReq))
 	h.Add("TCloudDeleteSnatIps", http.MethodDelete,
-		"/vendors/tcloud/load_balancers/{lb_id}/snat_ips", svc.TCloudDeleteSnatIps)
+		"/vendors/tcloud/load_balancers/{lb_id}/snat_ips", svc.TCloudDeleteSnatIps).
+		Reads(new(cslb.TCloudDeleteSnatIpReq))
 
 	// 目标组
 	h.Add("ListBizTargetsByTGID", http.MethodPost,
-		"/target_groups/{target_group_id}/targets/list", svc.ListBizTargetsByTGID)
+		"/target_groups/{target_group_id}/targets/list", svc.ListBizTargetsByTGID).
+		Reads(new(cloudserver.ListReq)).Writes(new(dataproto.TargetListResult))
 
 	h.Add("StatBizTargetWeight", http.MethodPost,
-		"/target_groups/targets/weight_stat", svc.StatBizTargetWeight)
+		"/target_groups/targets/weight_stat", svc.StatBizTargetWeight).
+		Reads(new(cslb.ListTargetWeightNumReq)).Writes(new([]cslb.TargetGroupRsWeightNum))
 	h.Add("AssociateBizTargetGroupListenerRel", http.MethodPost,
-		"/listeners/associate/target_group", svc.AssociateBizTargetGroupListenerRel)
+		"/listeners/associate/target_group", svc.AssociateBizTargetGroupListenerRel).
+		Reads(new(cslb.TargetGroupListenerRelAssociateReq)).Writes(new(core.BatchCreateResult))
 
-	h.Add("CreateBizTargetGroup", http.MethodPost, "/target_groups/create", svc.CreateBizTargetGroup)
-	h.Add("UpdateBizTargetGroup", http.MethodPatch, "/target_groups/{id}", svc.UpdateBizTargetGroup)
+	h.Add("CreateBizTargetGroup", http.MethodPost, "/target_groups/create", svc.CreateBizTargetGroup).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("UpdateBizTargetGroup", http.MethodPatch, "/target_groups/{id}", svc.UpdateBizTargetGroup).
+		Reads(new(cslb.TargetGroupUpdateReq))
 	h.Add("UpdateBizTargetGroupHealth", http.MethodPatch,
-		"/target_groups/{id}/health_check", svc.UpdateBizTargetGroupHealth)
-	h.Add("DeleteBizTargetGroup", http.MethodDelete, "/target_groups/batch", svc.DeleteBizTargetGroup)
-	h.Add("ListBizTargetGroup", http.MethodPost, "/target_groups/list", svc.ListBizTargetGroup)
-	h.Add("GetBizTargetGroup", http.MethodGet, "/target_groups/{id}", svc.GetBizTargetGroup)
+		"/target_groups/{id}/health_check", svc.UpdateBizTargetGroupHealth).Reads(new(hclb.HealthCheckUpdateReq))
+	h.Add("DeleteBizTargetGroup", http.MethodDelete, "/target_groups/batch", svc.DeleteBizTargetGroup).
+		Reads(new(core.BatchDeleteReq))
+	h.Add("ListBizTargetGroup", http.MethodPost, "/target_groups/list", svc.ListBizTargetGroup).
+		Reads(new(core.ListReq)).Writes(new(cslb.ListTargetGroupResult))
+	h.Add("GetBizTargetGroup", http.MethodGet, "/target_groups/{id}", svc.GetBizTargetGroup).
+		Writes(new(cslb.GetTargetGroupDetail))
 	// 与异步任务相关的操作
-	h.Add("BatchAddBizTargets", http.MethodPost, "/target_groups/targets/create", svc.BatchAddBizTargets)
-	h.Add("BatchRemoveBizTargets", http.MethodDelete, "/target_groups/targets/batch", svc.BatchRemoveBizTargets)
+	h.Add("BatchAddBizTargets", http.MethodPost, "/target_groups/targets/create", svc.BatchAddBizTargets).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("BatchRemoveBizTargets", http.MethodDelete, "/target_groups/targets/batch", svc.BatchRemoveBizTargets).
+		Reads(new(cloudserver.ResourceCreateReq))
 	h.Add("BatchModifyBizTargetPort",
-		http.MethodPatch, "/target_groups/{target_group_id}/targets/port", svc.BatchModifyBizTargetsPort)
+		http.MethodPatch, "/target_groups/{target_group_id}/targets/port", svc.BatchModifyBizTargetsPort).
+		Reads(new(cloudserver.ResourceCreateReq))
 	h.Add("BatchModifyBizTargetsWeight", http.MethodPatch,
-		"/target_groups/{target_group_id}/targets/weight", svc.BatchModifyBizTargetsWeight)
-	h.Add("BatchDeleteBizRule", http.MethodDelete, "/rule/batch", svc.BatchDeleteBizRule)
+		"/target_groups/{target_group_id}/targets/weight", svc.BatchModifyBizTargetsWeight).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(core.FlowStateResult))
+	h.Add("BatchDeleteBizRule", http.MethodDelete, "/rule/batch", svc.BatchDeleteBizRule).
+		Reads(new(cloudserver.ResourceDeleteReq)).Writes(new([]*core.FlowStateResult))
 
-	h.Add("CancelFlow", http.MethodPost, "/load_balancers/{lb_id}/async_flows/terminate", svc.BizTerminateFlow)
+	h.Add("CancelFlow", http.MethodPost, "/load_balancers/{lb_id}/async_flows/terminate", svc.BizTerminateFlow).
+		Reads(new(cslb.AsyncFlowTerminateReq))
 	h.Add("RetryTask", http.MethodPost, "/load_balancers/{lb_id}/async_tasks/retry", svc.BizRetryTask)
-	h.Add("CloneFlow", http.MethodPost, "/load_balancers/{lb_id}/async_flows/clone", svc.BizCloneFlow)
+	h.Add("CloneFlow", http.MethodPost, "/load_balancers/{lb_id}/async_flows/clone", svc.BizCloneFlow).
+		Reads(new(cslb.AsyncFlowCloneReq)).Writes(new(core.CreateResult))
 	h.Add("GetResultAfterTerminate", http.MethodPost,
-		"/load_balancers/{lb_id}/async_flows/result_after_terminate", svc.BizGetResultAfterTerminate)
+		"/load_balancers/{lb_id}/async_flows/result_after_terminate", svc.BizGetResultAfterTerminate).
+		Reads(new(cslb.TerminatedAsyncFlowResultReq)).Writes(new([]cslb.TerminatedAsyncFlowResult))
 
 	h.Add("ListBizTargetsHealthByTGID", http.MethodPost,
-		"/target_groups/{target_group_id}/targets/health", svc.ListBizTargetsHealthByTGID)
+		"/target_groups/{target_group_id}/targets/health", svc.ListBizTargetsHealthByTGID).
+		Reads(new(hclb.TCloudTargetHealthReq)).Writes(new(hclb.TCloudTargetHealthResp))
 
 	// 监听器
-	h.Add("CreateBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/create", svc.CreateBizListener)
-	h.Add("UpdateBizListener", http.MethodPatch, "/listeners/{id}", svc.UpdateBizListener)
-	h.Add("DeleteBizListener", http.MethodDelete, "/listeners/batch", svc.DeleteBizListener)
-	h.Add("UpdateBizDomainAttr", http.MethodPatch, "/listeners/{lbl_id}/domains", svc.UpdateBizDomainAttr)
-	h.Add("ListBizListenerWithTargets", http.MethodPost, "/listeners/with/targets/list", svc.ListBizListenerWithTargets)
+	h.Add("CreateBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/create", svc.CreateBizListener).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("UpdateBizListener", http.MethodPatch, "/listeners/{id}", svc.UpdateBizListener).
+		Reads(new(cloudserver.ResourceCreateReq))
+	h.Add("DeleteBizListener", http.MethodDelete, "/listeners/batch", svc.DeleteBizListener).
+		Reads(new(core.BatchDeleteReq))
+	h.Add("UpdateBizDomainAttr", http.MethodPatch, "/listeners/{lbl_id}/domains", svc.UpdateBizDomainAttr).
+		Reads(new(hclb.DomainAttrUpdateReq))
+	h.Add("ListBizListenerWithTargets", http.MethodPost,
+		"/listeners/with/targets/list", svc.ListBizListenerWithTargets).
+		Reads(new(dataproto.ListListenerWithTargetsReq)).Writes(new(dataproto.ListListenerWithTargetsResp))
 
 	h.Add("ListBizListenerTargetWeightStat", http.MethodPost, "/listeners/rs_weight_stat",
-		svc.ListBizListenerTargetWeightStat)
+		svc.ListBizListenerTargetWeightStat).
+		Reads(new(cslb.ListListenerTargetsStatReq)).Writes(new(map[string]*cslb.ListenerTargetsStat))
 
 	// excel导入
 	h.Add("ImportPreview", http.MethodPost,
-		"/vendors/{vendor}/load_balancers/operations/{operation_type}/preview", svc.ImportPreview)
+		"/vendors/{vendor}/load_balancers/operations/{operation_type}/preview", svc.ImportPreview).
+		Writes(new(cslb.UploadExcelFileBaseResp))
 	h.Add("ImportSubmit", http.MethodPost,
-		"/vendors/{vendor}/load_balancers/operations/{operation_type}/submit", svc.ImportSubmit)
+		"/vendors/{vendor}/load_balancers/operations/{operation_type}/submit", svc.ImportSubmit).
+		Reads(new(cslb.ImportExcelReq))
 	h.Add("ImportValidate", http.MethodPost,
-		"/vendors/{vendor}/load_balancers/operations/{operation_type}/validate", svc.ImportValidate)
+		"/vendors/{vendor}/load_balancers/operations/{operation_type}/validate", svc.ImportValidate).
+		Reads(new(cslb.ImportValidateReq)).Writes(new(cslb.UploadExcelFileBaseResp))
 }
 
 func bizURLRuleService(h *rest.Handler, svc *lbSvc) {
 	// 规则
 	h.Add("GetBizUrlRule", http.MethodGet,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/{rule_id}", svc.GetBizUrlRule)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/{rule_id}", svc.GetBizUrlRule).Writes(new(corelb.TCloudLbUrlRule))
 	h.Add("ListBizUrlRulesByListener", http.MethodPost,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/list", svc.ListBizUrlRulesByListener)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/list", svc.ListBizUrlRulesByListener).
+		Reads(new(core.ListReq)).Writes(new(dataproto.TCloudURLRuleListResult))
 	h.Add("ListBizRuleByTG", http.MethodPost,
-		"/vendors/{vendor}/target_groups/{target_group_id}/rules/list", svc.ListBizRuleByTG)
+		"/vendors/{vendor}/target_groups/{target_group_id}/rules/list", svc.ListBizRuleByTG).Reads(new(core.ListReq))
 	h.Add("CreateBizUrlRule", http.MethodPost,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/create", svc.CreateBizUrlRule)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/create", svc.CreateBizUrlRule).
+		Reads(new(cslb.TCloudRuleCreate)).Writes(new(hclb.BatchCreateResult))
 	h.Add("UpdateBizUrlRule", http.MethodPatch,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/{rule_id}", svc.UpdateBizUrlRule)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/{rule_id}", svc.UpdateBizUrlRule).
+		Reads(new(hclb.TCloudRuleUpdateReq))
 	h.Add("BatchDeleteBizUrlRule", http.MethodDelete,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/batch", svc.BatchDeleteBizUrlRule)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/batch", svc.BatchDeleteBizUrlRule).
+		Reads(new(hclb.TCloudRuleDeleteByIDReq))
 	h.Add("BatchDeleteBizUrlRuleByDomain", http.MethodDelete,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/by/domains/batch", svc.BatchDeleteBizUrlRuleByDomain)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/by/domains/batch", svc.BatchDeleteBizUrlRuleByDomain).
+		Reads(new(hclb.TCloudRuleDeleteByDomainReq))
 	h.Add("ListRuleBindingStatus", http.MethodPost,
-		"/vendors/{vendor}/listeners/{lbl_id}/rules/binding_status/list", svc.ListRuleBindingStatus)
+		"/vendors/{vendor}/listeners/{lbl_id}/rules/binding_status/list", svc.ListRuleBindingStatus).
+		Reads(new(cslb.RuleBindingStatusListReq)).Writes(new(cslb.RuleBindingStatusListResp))
 }
 
 func bizSopService(h *rest.Handler, svc *lbSvc) {
 	// 标准运维
 	h.Add("BatchBizAddTargetGroupRS", http.MethodPost,
-		"/sops/target_groups/targets/create", svc.BatchBizAddTargetGroupRS)
+		"/sops/target_groups/targets/create", svc.BatchBizAddTargetGroupRS).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]*core.FlowStateResult))
 	h.Add("BatchBizRemoveTargetGroupRS", http.MethodDelete,
-		"/sops/target_groups/targets/batch", svc.BatchBizRemoveTargetGroupRS)
+		"/sops/target_groups/targets/batch", svc.BatchBizRemoveTargetGroupRS).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]*core.FlowStateResult))
 	h.Add("BatchBizModifyWeightTargetGroup", http.MethodPatch,
-		"/sops/target_groups/targets/weight", svc.BatchBizModifyWeightTargetGroup)
+		"/sops/target_groups/targets/weight", svc.BatchBizModifyWeightTargetGroup).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]*core.FlowStateResult))
 	h.Add("BatchBizRuleOnline", http.MethodPost,
-		"/sops/rule/online", svc.BatchBizRuleOnline)
+		"/sops/rule/online", svc.BatchBizRuleOnline).Reads(new(cloudserver.ResourceCreateReq))
 	h.Add("BatchBizRuleOffline", http.MethodDelete,
-		"/sops/rule/offline", svc.BatchBizRuleOffline)
+		"/sops/rule/offline", svc.BatchBizRuleOffline).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new([]*core.FlowStateResult))
 }
 
 type lbSvc struct {
@@ -51,22 +51,27 @@ func InitNetworkInterfaceService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListNetworkInterface", "POST", "/network_interfaces/list", svc.ListNetworkInterface)
+	h.Add("ListNetworkInterface", "POST", "/network_interfaces/list", svc.ListNetworkInterface).
+		Reads(new(core.ListReq)).Writes(new(cloudserver.NetworkInterfaceListResult))
 	h.Add("ListNetworkInterfaceAssociate", "POST", "/network_interfaces/associate/list",
-		svc.ListNetworkInterfaceAssociate)
+		svc.ListNetworkInterfaceAssociate).
+		Reads(new(datacloudniproto.NetworkInterfaceListReq)).
+		Writes(new(cloudserver.NetworkInterfaceAssociateListResult))
 	h.Add("ListNetworkInterfaceExt", "POST", "/vendors/{vendor}/network_interfaces/list",
-		svc.ListNetworkInterfaceExt)
+		svc.ListNetworkInterfaceExt).Reads(new(core.ListReq))
 	h.Add("GetNetworkInterface", "GET", "/network_interfaces/{id}", svc.GetNetworkInterface)
 	h.Add("ListNetworkInterfaceExtByCvmID", "GET", "/vendors/{vendor}/network_interfaces/cvms/{cvm_id}",
 		svc.ListNetworkInterfaceExtByCvmID)
 	h.Add("AssignNetworkInterfaceToBiz", "POST", "/network_interfaces/assign/bizs",
-		svc.AssignNetworkInterfaceToBiz)
+		svc.AssignNetworkInterfaceToBiz).Reads(new(cloudserver.AssignNetworkInterfaceToBizReq))
 
 	// network interface biz apis
 	h.Add("ListBizNetworkInterface", "POST", "/bizs/{bk_biz_id}/network_interfaces/list",
-		svc.ListBizNetworkInterface)
+		svc.ListBizNetworkInterface).Reads(new(core.ListReq)).Writes(new(cloudserver.NetworkInterfaceListResult))
 	h.Add("ListBizNetworkInterfaceAssociate", "POST",
-		"/bizs/{bk_biz_id}/network_interfaces/associate/list", svc.ListBizNetworkInterfaceAssociate)
+		"/bizs/{bk_biz_id}/network_interfaces/associate/list", svc.ListBizNetworkInterfaceAssociate).
+		Reads(new(datacloudniproto.NetworkInterfaceListReq)).
+		Writes(new(cloudserver.NetworkInterfaceAssociateListResult))
 	h.Add("GetBizNetworkInterface", "GET", "/bizs/{bk_biz_id}/network_interfaces/{id}",
 		svc.GetBizNetworkInterface)
 	h.Add("ListBizNICExtByCvmID", "GET",
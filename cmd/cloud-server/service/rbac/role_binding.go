@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	csrbac "hcm/pkg/api/cloud-server/rbac"
+	"hcm/pkg/api/core"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateRoleBinding create role binding.
+func (svc *rbacSvc) CreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(csrbac.CreateRoleBindingReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsrbac.BatchCreateRoleBindingReq{Bindings: []dsrbac.RoleBindingCreate{*req}}
+	result, err := svc.client.DataService().Global.Rbac.BatchCreateRoleBinding(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create rbac role binding failed, err: %v, role: %s, user: %s, rid: %s", err, req.RoleID,
+			req.User, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create rbac role binding result is invalid")
+	}
+
+	svc.invalidateDecisionCache(cts.Kit)
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateRoleBinding update role binding.
+func (svc *rbacSvc) UpdateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csrbac.UpdateRoleBindingReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsrbac.BatchUpdateRoleBindingReq{
+		Bindings: []dsrbac.RoleBindingUpdate{{ID: id, ScopeType: req.ScopeType, ScopeIDs: req.ScopeIDs,
+			Memo: req.Memo}},
+	}
+	if err := svc.client.DataService().Global.Rbac.BatchUpdateRoleBinding(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update rbac role binding failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	svc.invalidateDecisionCache(cts.Kit)
+
+	return nil, nil
+}
+
+// ListRoleBinding list role bindings.
+func (svc *rbacSvc) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Rbac.ListRoleBinding(cts.Kit, req)
+}
+
+// BatchDeleteRoleBinding batch delete role bindings.
+func (svc *rbacSvc) BatchDeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Rbac.BatchDeleteRoleBinding(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete rbac role binding failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	svc.invalidateDecisionCache(cts.Kit)
+
+	return nil, nil
+}
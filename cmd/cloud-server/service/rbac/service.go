@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地鉴权角色及角色绑定管理，仅在本地鉴权模式下使用
+package rbac
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csrbac "hcm/pkg/api/cloud-server/rbac"
+	"hcm/pkg/api/core"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the local rbac service.
+func InitService(c *capability.Capability) {
+	svc := &rbacSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateRbacRole", http.MethodPost, "/rbac/roles/create", svc.CreateRole).
+		Reads(new(csrbac.CreateRoleReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateRbacRole", http.MethodPatch, "/rbac/roles/{id}", svc.UpdateRole).Reads(new(csrbac.UpdateRoleReq))
+	h.Add("ListRbacRole", http.MethodPost, "/rbac/roles/list", svc.ListRole).
+		Reads(new(core.ListReq)).Writes(new(dsrbac.ListRoleResult))
+	h.Add("BatchDeleteRbacRole", http.MethodDelete, "/rbac/roles/batch", svc.BatchDeleteRole).
+		Reads(new(dsrbac.BatchDeleteReq))
+
+	h.Add("CreateRbacRoleBinding", http.MethodPost, "/rbac/role_bindings/create", svc.CreateRoleBinding).
+		Reads(new(csrbac.CreateRoleBindingReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateRbacRoleBinding", http.MethodPatch, "/rbac/role_bindings/{id}", svc.UpdateRoleBinding).
+		Reads(new(csrbac.UpdateRoleBindingReq))
+	h.Add("ListRbacRoleBinding", http.MethodPost, "/rbac/role_bindings/list", svc.ListRoleBinding).
+		Reads(new(core.ListReq)).Writes(new(dsrbac.ListRoleBindingResult))
+	h.Add("BatchDeleteRbacRoleBinding", http.MethodDelete, "/rbac/role_bindings/batch", svc.BatchDeleteRoleBinding).
+		Reads(new(dsrbac.BatchDeleteReq))
+
+	h.Load(c.WebService)
+}
+
+type rbacSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *rbacSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Rbac, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// invalidateDecisionCache invalidate the authorization decision cache after the roles or role bindings are changed,
+// the cached decisions expire after their ttl if it is failed, so the error is only logged.
+func (svc *rbacSvc) invalidateDecisionCache(kt *kit.Kit) {
+	if _, err := svc.client.AuthServer().InvalidateDecisionCache(kt.Ctx, kt.Header()); err != nil {
+		logs.Errorf("invalidate decision cache failed, err: %v, rid: %s", err, kt.Rid)
+	}
+}
@@ -0,0 +1,400 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"fmt"
+	"time"
+
+	csrecommend "hcm/pkg/api/cloud-server/recommendation"
+	"hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	corerecord "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/api/data-service/cloud"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	dsrecord "hcm/pkg/api/data-service/recycle-record"
+	hccvm "hcm/pkg/api/hc-service/cvm"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// recommendAuthRes 优化建议对应的鉴权资源类型与执行操作
+var recommendAuthRes = map[enumor.RecommendType]struct {
+	resType meta.ResourceType
+	action  meta.Action
+}{
+	enumor.UnattachedDiskRecommend:    {resType: meta.Disk, action: meta.Recycle},
+	enumor.UnboundEipRecommend:        {resType: meta.Eip, action: meta.Delete},
+	enumor.StoppedCvmRecommend:        {resType: meta.Cvm, action: meta.Recycle},
+	enumor.EmptyLoadBalancerRecommend: {resType: meta.LoadBalancer, action: meta.Delete},
+	enumor.OversizedCvmRecommend:      {resType: meta.Cvm, action: meta.Update},
+}
+
+// ApplyRecommendation apply res recommendation.
+func (svc *recommendSvc) ApplyRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.applyRecommendation(cts, handler.ResOperateAuth)
+}
+
+// ApplyBizRecommendation apply biz res recommendation.
+func (svc *recommendSvc) ApplyBizRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.applyRecommendation(cts, handler.BizOperateAuth)
+}
+
+func (svc *recommendSvc) applyRecommendation(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrecommend.ApplyRecommendationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rec, err := svc.getRecommendation(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes, exists := recommendAuthRes[rec.RecType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported recommend type: %s", rec.RecType)
+	}
+
+	basicInfo, err := svc.authorizeRecommendation(cts, validHandler, rec, authRes.action)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	switch rec.RecType {
+	case enumor.UnattachedDiskRecommend:
+		result, err = svc.applyUnattachedDisk(cts.Kit, rec)
+	case enumor.UnboundEipRecommend:
+		err = svc.applyUnboundEip(cts.Kit, rec)
+	case enumor.StoppedCvmRecommend:
+		result, err = svc.applyStoppedCvm(cts.Kit, rec, basicInfo)
+	case enumor.EmptyLoadBalancerRecommend:
+		err = svc.applyEmptyLoadBalancer(cts.Kit, rec)
+	case enumor.OversizedCvmRecommend:
+		err = svc.applyOversizedCvm(cts.Kit, rec, req.ForceStop)
+	}
+	if err != nil {
+		logs.Errorf("apply recommendation failed, err: %v, id: %s, type: %s, res: %s, rid: %s", err, rec.ID,
+			rec.RecType, rec.ResID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.updateRecommendStatus(cts.Kit, rec.ID, enumor.AppliedRecommendStatus); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IgnoreRecommendation ignore res recommendation.
+func (svc *recommendSvc) IgnoreRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.ignoreRecommendation(cts, handler.ResOperateAuth)
+}
+
+// IgnoreBizRecommendation ignore biz res recommendation.
+func (svc *recommendSvc) IgnoreBizRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.ignoreRecommendation(cts, handler.BizOperateAuth)
+}
+
+// ignoreRecommendation 忽略优化建议，被忽略的资源在后续扫描中不会再生成同类建议
+func (svc *recommendSvc) ignoreRecommendation(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	rec, err := svc.getRecommendation(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = svc.authorizeRecommendation(cts, validHandler, rec, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.updateRecommendStatus(cts.Kit, rec.ID, enumor.IgnoredRecommendStatus)
+}
+
+// authorizeRecommendation 校验优化建议状态，并对建议关联的资源进行业务校验和鉴权
+func (svc *recommendSvc) authorizeRecommendation(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	rec *corerecommend.ResRecommendation, action meta.Action) (*types.CloudResourceBasicInfo, error) {
+
+	if rec.Status != enumor.PendingRecommendStatus {
+		return nil, errf.Newf(errf.InvalidParameter, "recommendation %s is %s, only pending can be operated",
+			rec.ID, rec.Status)
+	}
+
+	fields := append(types.CommonBasicInfoFields, "region")
+	if rec.ResType != enumor.LoadBalancerCloudResType {
+		fields = append(fields, "recycle_status")
+	}
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: rec.ResType,
+		IDs:          []string{rec.ResID},
+		Fields:       fields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	basicInfo, exists := basicInfoMap[rec.ResID]
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "%s %s not found", rec.ResType, rec.ResID)
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: recommendAuthRes[rec.RecType].resType, Action: action, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if basicInfo.RecycleStatus == enumor.RecycleStatus {
+		return nil, errf.Newf(errf.InvalidParameter, "%s %s is recycling", rec.ResType, rec.ResID)
+	}
+
+	return &basicInfo, nil
+}
+
+// applyUnattachedDisk 回收未挂载的云盘
+func (svc *recommendSvc) applyUnattachedDisk(kt *kit.Kit, rec *corerecommend.ResRecommendation) (
+	interface{}, error) {
+
+	relReq := &core.ListReq{
+		Filter: tools.EqualExpression("disk_id", rec.ResID),
+		Page:   &core.BasePage{Start: 0, Limit: 1},
+	}
+	relRes, err := svc.client.DataService().Global.ListDiskCvmRel(kt, relReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(relRes.Details) > 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "disk %s is attached to cvm %s now", rec.ResID,
+			relRes.Details[0].CvmID)
+	}
+
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.DiskAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos: []protoaudit.CloudResRecycleAuditInfo{
+			{ResID: rec.ResID, Data: corerecord.DiskRecycleOptions{}},
+		},
+	}
+	if err = svc.audit.ResRecycleAudit(kt, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	opt := &dsrecord.BatchRecycleReq{
+		ResType:            enumor.DiskCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              []dsrecord.RecycleReq{{ID: rec.ResID, Detail: corerecord.DiskRecycleOptions{}}},
+	}
+	taskID, err := svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// applyUnboundEip 删除未绑定的弹性IP
+func (svc *recommendSvc) applyUnboundEip(kt *kit.Kit, rec *corerecommend.ResRecommendation) error {
+	eipReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", rec.ResID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	eipRes, err := svc.client.DataService().Global.ListEip(kt, eipReq)
+	if err != nil {
+		return err
+	}
+	if len(eipRes.Details) == 0 {
+		return errf.Newf(errf.RecordNotFound, "eip %s not found", rec.ResID)
+	}
+	if len(converter.PtrToVal(eipRes.Details[0].InstanceID)) != 0 {
+		return errf.Newf(errf.InvalidParameter, "eip %s is bound to %s now", rec.ResID,
+			converter.PtrToVal(eipRes.Details[0].InstanceID))
+	}
+
+	if err = svc.audit.ResDeleteAudit(kt, enumor.EipAuditResType, []string{rec.ResID}); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return svc.eipLgc.DeleteEip(kt, rec.Vendor, rec.ResID)
+}
+
+// applyStoppedCvm 回收长期关机的主机，关联的云盘、弹性IP保留
+func (svc *recommendSvc) applyStoppedCvm(kt *kit.Kit, rec *corerecommend.ResRecommendation,
+	basicInfo *types.CloudResourceBasicInfo) (interface{}, error) {
+
+	// 扫描后主机可能已被重新开机，回收前的预检查会将运行中的主机关机，因此需要确认主机仍处于长期关机状态
+	cvmReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", rec.ResID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	cvmRes, err := svc.client.DataService().Global.Cvm.ListCvm(kt, cvmReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(cvmRes.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm %s not found", rec.ResID)
+	}
+	one := cvmRes.Details[0]
+	days := cc.CloudServer().Recommendation.StoppedCvmDays
+	_, stopped := isCvmStoppedBefore(one.Vendor, one.Status, one.StatusChangedAt, time.Now().AddDate(0, 0, -int(days)))
+	if !stopped {
+		return nil, errf.Newf(errf.InvalidParameter, "cvm %s has not been stopped for more than %d days now, "+
+			"status: %s", rec.ResID, days, one.Status)
+	}
+
+	basicInfoMap := map[string]types.CloudResourceBasicInfo{rec.ResID: *basicInfo}
+	if err := svc.cvmLgc.RecyclePreCheck(kt, basicInfoMap); err != nil {
+		logs.Errorf("recycle precheck fail, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	opts := corerecord.CvmRecycleOptions{}
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.CvmAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   []protoaudit.CloudResRecycleAuditInfo{{ResID: rec.ResID, Data: opts}},
+	}
+	if err := svc.audit.ResRecycleAudit(kt, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	cvmStatus := map[string]*recycle.CvmDetail{
+		rec.ResID: {
+			Vendor:           basicInfo.Vendor,
+			AccountID:        basicInfo.AccountID,
+			CvmID:            rec.ResID,
+			CvmRecycleDetail: corerecord.CvmRecycleDetail{CvmRecycleOptions: opts},
+		},
+	}
+	taskID, err := svc.cvmLgc.BatchRecycle(kt, []string{rec.ResID}, cvmStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// applyEmptyLoadBalancer 删除没有监听器的负载均衡
+func (svc *recommendSvc) applyEmptyLoadBalancer(kt *kit.Kit, rec *corerecommend.ResRecommendation) error {
+	if rec.Vendor != enumor.TCloud {
+		return errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", rec.Vendor)
+	}
+
+	lbReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", rec.ResID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	lbRes, err := svc.client.DataService().TCloud.LoadBalancer.ListLoadBalancer(kt, lbReq)
+	if err != nil {
+		return err
+	}
+	if len(lbRes.Details) == 0 {
+		return errf.Newf(errf.RecordNotFound, "load balancer %s not found", rec.ResID)
+	}
+	lb := lbRes.Details[0]
+	if lb.Extension != nil && converter.PtrToVal(lb.Extension.DeleteProtect) {
+		return fmt.Errorf("%s(%s) is protected for delection", lb.Name, lb.CloudID)
+	}
+
+	listenerReq := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", rec.ResID),
+		Page:   &core.BasePage{Start: 0, Limit: 1},
+	}
+	listenerRes, err := svc.client.DataService().Global.LoadBalancer.ListListener(kt, listenerReq)
+	if err != nil {
+		return err
+	}
+	if len(listenerRes.Details) > 0 {
+		return errf.Newf(errf.InvalidParameter, "load balancer %s has listener now", rec.ResID)
+	}
+
+	if err = svc.audit.ResDeleteAudit(kt, enumor.LoadBalancerAuditResType, []string{rec.ResID}); err != nil {
+		logs.Errorf("create load balancer delete audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	deleteReq := &hclb.BatchDeleteLoadBalancerReq{AccountID: lb.AccountID, Region: lb.Region,
+		IDs: []string{rec.ResID}}
+	return svc.client.HCService().TCloud.Clb.BatchDeleteLoadBalancer(kt, deleteReq)
+}
+
+// applyOversizedCvm 将主机调整为建议的规格
+func (svc *recommendSvc) applyOversizedCvm(kt *kit.Kit, rec *corerecommend.ResRecommendation,
+	forceStop bool) error {
+
+	if rec.Vendor != enumor.TCloud {
+		return errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", rec.Vendor)
+	}
+
+	if rec.Detail == nil || len(rec.Detail.TargetInstanceType) == 0 {
+		return errf.Newf(errf.InvalidParameter, "recommendation %s has no target instance type", rec.ID)
+	}
+
+	updateFields := map[string]interface{}{"machine_type": rec.Detail.TargetInstanceType}
+	if err := svc.audit.ResUpdateAudit(kt, enumor.CvmAuditResType, rec.ResID, updateFields); err != nil {
+		logs.Errorf("create update audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	resetReq := &hccvm.TCloudResetInstanceTypeReq{
+		AccountID:    rec.AccountID,
+		Region:       rec.Region,
+		ID:           rec.ResID,
+		InstanceType: rec.Detail.TargetInstanceType,
+		ForceStop:    forceStop,
+	}
+	return svc.client.HCService().TCloud.Cvm.ResetInstanceType(kt, resetReq)
+}
+
+func (svc *recommendSvc) updateRecommendStatus(kt *kit.Kit, id string, status enumor.RecommendStatus) error {
+	updateReq := &dsrecommend.BatchUpdateResRecommendationReq{
+		Items: []dsrecommend.ResRecommendationUpdate{{ID: id, Status: status}},
+	}
+	if err := svc.client.DataService().Global.Recommendation.BatchUpdate(kt, updateReq); err != nil {
+		logs.Errorf("update recommendation status failed, err: %v, id: %s, status: %s, rid: %s", err, id, status,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
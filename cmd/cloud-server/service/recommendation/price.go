@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	typedisk "hcm/pkg/adaptor/types/disk"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	hcdisk "hcm/pkg/api/hc-service/disk"
+	instancetype "hcm/pkg/api/hc-service/instance-type"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+const (
+	// hoursPerMonth 按量计费资源折算月度费用的小时数
+	hoursPerMonth = 730
+	// tcloudCurrency 腾讯云询价接口返回的币种
+	tcloudCurrency = "CNY"
+)
+
+// tcloudInstancePrice 计算腾讯云机型的月度费用，包年包月返回月单价，按量计费按小时单价折算
+func tcloudInstancePrice(chargeType string, price cvm.ItemPrice) float64 {
+	if chargeType == string(typecvm.Prepaid) {
+		return converter.PtrToVal(price.DiscountPrice)
+	}
+
+	return converter.PtrToVal(price.UnitPriceDiscount) * hoursPerMonth
+}
+
+// instanceTypeCache 单个账号扫描期间的机型列表缓存，key 为 region/zone/chargeType
+type instanceTypeCache map[string][]*instancetype.TCloudInstanceTypeResp
+
+// listTCloudInstanceType 查询可用区下指定计费模式的机型及价格
+func (s *scanner) listTCloudInstanceType(kt *kit.Kit, cache instanceTypeCache, accountID, region, zone,
+	chargeType string) ([]*instancetype.TCloudInstanceTypeResp, error) {
+
+	key := region + "/" + zone + "/" + chargeType
+	if types, exists := cache[key]; exists {
+		return types, nil
+	}
+
+	req := &instancetype.TCloudInstanceTypeListReq{
+		AccountID:          accountID,
+		Region:             region,
+		Zone:               zone,
+		InstanceChargeType: chargeType,
+	}
+	types, err := s.cliSet.HCService().TCloud.InstanceType.List(kt, req)
+	if err != nil {
+		return nil, err
+	}
+	cache[key] = types
+
+	return types, nil
+}
+
+// tcloudDiskPrice 通过询价接口估算腾讯云云硬盘的月度费用，询价失败时返回0
+func (s *scanner) tcloudDiskPrice(kt *kit.Kit, disk *coredisk.Disk[coredisk.TCloudExtension]) float64 {
+	if disk.Extension == nil {
+		return 0
+	}
+
+	chargeType := disk.Extension.DiskChargeType
+	req := &hcdisk.TCloudDiskCreateReq{
+		DiskBaseCreateReq: &hcdisk.DiskBaseCreateReq{
+			AccountID: disk.AccountID,
+			Region:    disk.Region,
+			Zone:      disk.Zone,
+			DiskSize:  disk.DiskSize,
+			DiskType:  disk.DiskType,
+			DiskCount: 1,
+		},
+		Extension: &hcdisk.TCloudDiskExtensionCreateReq{DiskChargeType: chargeType},
+	}
+	if chargeType == typedisk.TCloudDiskChargeTypeEnum.PREPAID {
+		req.Extension.DiskChargePrepaid = &hcdisk.TCloudDiskChargePrepaid{Period: converter.ValToPtr(uint64(1))}
+	}
+
+	result, err := s.cliSet.HCService().TCloud.Disk.InquiryPrice(kt, req)
+	if err != nil {
+		logs.Warnf("inquiry tcloud disk price failed, err: %v, disk: %s, rid: %s", err, disk.ID, kt.Rid)
+		return 0
+	}
+
+	return tcloudDiskMonthlyPrice(chargeType, result.DiscountPrice)
+}
+
+// tcloudDiskMonthlyPrice 计算腾讯云云硬盘的月度费用，包年包月询价结果为月单价，按量计费询价结果为小时单价
+func tcloudDiskMonthlyPrice(chargeType string, discountPrice float64) float64 {
+	if chargeType == typedisk.TCloudDiskChargeTypeEnum.PREPAID {
+		return discountPrice
+	}
+	return discountPrice * hoursPerMonth
+}
+
+// tcloudLoadBalancerPrice 通过询价接口估算腾讯云负载均衡实例的月度费用，不包含按流量计费的网络费用
+func (s *scanner) tcloudLoadBalancerPrice(kt *kit.Kit, lb *corelb.TCloudLoadBalancer) float64 {
+	req := &hclb.TCloudLoadBalancerCreateReq{
+		AccountID:                lb.AccountID,
+		Region:                   lb.Region,
+		Name:                     converter.ValToPtr(lb.Name),
+		LoadBalancerType:         typelb.TCloudLoadBalancerType(lb.LoadBalancerType),
+		CloudVpcID:               converter.ValToPtr(lb.CloudVpcID),
+		LoadBalancerPassToTarget: converter.ValToPtr(true),
+	}
+	if req.LoadBalancerType == typelb.InternalLoadBalancerType {
+		req.CloudSubnetID = converter.ValToPtr(lb.CloudSubnetID)
+	}
+
+	chargeType := ""
+	if lb.Extension != nil {
+		chargeType = converter.PtrToVal(lb.Extension.ChargeType)
+		req.SlaType = lb.Extension.SlaType
+		req.VipIsp = lb.Extension.VipIsp
+		req.InternetMaxBandwidthOut = lb.Extension.InternetMaxBandwidthOut
+		if lb.Extension.InternetChargeType != nil {
+			req.InternetChargeType = converter.ValToPtr(
+				typelb.TCloudLoadBalancerNetworkChargeType(converter.PtrToVal(lb.Extension.InternetChargeType)))
+		}
+	}
+
+	result, err := s.cliSet.HCService().TCloud.Clb.InquiryPrice(kt, req)
+	if err != nil {
+		logs.Warnf("inquiry tcloud load balancer price failed, err: %v, lb: %s, rid: %s", err, lb.ID, kt.Rid)
+		return 0
+	}
+
+	return tcloudLoadBalancerMonthlyPrice(chargeType, result.InstancePrice)
+}
+
+// tcloudLoadBalancerMonthlyPrice 计算腾讯云负载均衡实例的月度费用，包年包月返回月单价，按量计费按小时单价折算
+func tcloudLoadBalancerMonthlyPrice(chargeType string, price *typelb.ItemPrice) float64 {
+	if price == nil {
+		return 0
+	}
+	if chargeType == string(typelb.Prepaid) {
+		return converter.PtrToVal(price.DiscountPrice)
+	}
+	return converter.PtrToVal(price.UnitPriceDiscount) * hoursPerMonth
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"testing"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	typedisk "hcm/pkg/adaptor/types/disk"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/tools/converter"
+
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+func TestTCloudInstancePrice(t *testing.T) {
+	price := cvm.ItemPrice{DiscountPrice: converter.ValToPtr(300.0), UnitPriceDiscount: converter.ValToPtr(0.5)}
+
+	if got := tcloudInstancePrice(string(typecvm.Prepaid), price); got != 300 {
+		t.Errorf("prepaid instance should use monthly price, expect 300, but got %v", got)
+	}
+
+	if got := tcloudInstancePrice(string(typecvm.PostpaidByHour), price); got != 0.5*hoursPerMonth {
+		t.Errorf("postpaid instance should convert hourly price, expect %v, but got %v", 0.5*hoursPerMonth, got)
+	}
+}
+
+func TestTCloudDiskMonthlyPrice(t *testing.T) {
+	if got := tcloudDiskMonthlyPrice(typedisk.TCloudDiskChargeTypeEnum.PREPAID, 50); got != 50 {
+		t.Errorf("prepaid disk should use monthly price, expect 50, but got %v", got)
+	}
+
+	got := tcloudDiskMonthlyPrice(typedisk.TCloudDiskChargeTypeEnum.POSTPAID_BY_HOUR, 0.1)
+	if got != 0.1*hoursPerMonth {
+		t.Errorf("postpaid disk should convert hourly price, expect %v, but got %v", 0.1*hoursPerMonth, got)
+	}
+}
+
+func TestTCloudLoadBalancerMonthlyPrice(t *testing.T) {
+	price := &typelb.ItemPrice{DiscountPrice: converter.ValToPtr(100.0), UnitPriceDiscount: converter.ValToPtr(0.02)}
+
+	if got := tcloudLoadBalancerMonthlyPrice(string(typelb.Prepaid), price); got != 100 {
+		t.Errorf("prepaid load balancer should use monthly price, expect 100, but got %v", got)
+	}
+
+	if got := tcloudLoadBalancerMonthlyPrice("POSTPAID_BY_HOUR", price); got != 0.02*hoursPerMonth {
+		t.Errorf("postpaid load balancer should convert hourly price, expect %v, but got %v", 0.02*hoursPerMonth, got)
+	}
+
+	if got := tcloudLoadBalancerMonthlyPrice(string(typelb.Prepaid), nil); got != 0 {
+		t.Errorf("load balancer without instance price should be 0, but got %v", got)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"hcm/pkg/api/core"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListRecommendation list res recommendation.
+func (svc *recommendSvc) ListRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.listRecommendation(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizRecommendation list biz res recommendation.
+func (svc *recommendSvc) ListBizRecommendation(cts *rest.Contexts) (interface{}, error) {
+	return svc.listRecommendation(cts, handler.ListBizAuthRes)
+}
+
+func (svc *recommendSvc) listRecommendation(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 优化建议涉及多种资源，统一按照资源查看权限进行鉴权
+	expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Cvm, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return &dsrecommend.ListResRecommendationResult{Details: make([]corerecommend.ResRecommendation, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.Recommendation.List(cts.Kit, req)
+}
+
+// getRecommendation get res recommendation by id.
+func (svc *recommendSvc) getRecommendation(cts *rest.Contexts) (*corerecommend.ResRecommendation, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	listReq := core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Recommendation.List(cts.Kit, &listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "recommendation %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
@@ -0,0 +1,680 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"fmt"
+	"time"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	instancetype "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// cvmStoppedStatus 各云厂商主机的关机状态
+var cvmStoppedStatus = map[enumor.Vendor][]string{
+	enumor.TCloud: {"STOPPED"},
+	enumor.Aws:    {"stopped"},
+	enumor.HuaWei: {"SHUTOFF"},
+	enumor.Gcp:    {"TERMINATED"},
+	enumor.Azure:  {"PowerState/stopped", "PowerState/deallocated"},
+}
+
+const (
+	// tcloudCvmRunning 腾讯云主机运行中状态
+	tcloudCvmRunning = "RUNNING"
+	// tcloudStopCharging 腾讯云主机关机不收费模式
+	tcloudStopCharging = "STOP_CHARGING"
+	// tcloudInstanceTypeSell 腾讯云机型售卖中状态
+	tcloudInstanceTypeSell = "SELL"
+)
+
+// unattachedDisk 未挂载到主机的数据盘
+func (s *scanner) unattachedDisk(kt *kit.Kit, account *corecloud.BaseAccount) (
+	[]dsrecommend.ResRecommendationCreate, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", account.ID),
+			tools.RuleEqual("is_system_disk", false),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	result := make([]dsrecommend.ResRecommendationCreate, 0)
+	for {
+		disks, err := s.cliSet.DataService().Global.ListDisk(kt, listReq)
+		if err != nil {
+			logs.Errorf("list disk failed, err: %v, account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		ids := slice.Map(disks.Details, func(one *coredisk.BaseDisk) string { return one.ID })
+		attached, err := s.listAttachedDisk(kt, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range disks.Details {
+			if _, exists := attached[one.ID]; exists {
+				continue
+			}
+
+			result = append(result, dsrecommend.ResRecommendationCreate{
+				Vendor:     account.Vendor,
+				AccountID:  one.AccountID,
+				BkBizID:    one.BkBizID,
+				Region:     one.Region,
+				Zone:       one.Zone,
+				ResType:    enumor.DiskCloudResType,
+				ResID:      one.ID,
+				CloudResID: one.CloudID,
+				ResName:    one.Name,
+				RecType:    enumor.UnattachedDiskRecommend,
+				Detail:     &corerecommend.RecommendDetail{Reason: "disk is not attached to any cvm"},
+			})
+		}
+
+		if len(disks.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	if account.Vendor == enumor.TCloud {
+		if err := s.fillTCloudDiskSaving(kt, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *scanner) listAttachedDisk(kt *kit.Kit, ids []string) (map[string]struct{}, error) {
+	attached := make(map[string]struct{})
+	if len(ids) == 0 {
+		return attached, nil
+	}
+
+	relReq := &core.ListReq{
+		Filter: tools.ContainersExpression("disk_id", ids),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	rels, err := s.cliSet.DataService().Global.ListDiskCvmRel(kt, relReq)
+	if err != nil {
+		logs.Errorf("list disk cvm rel failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	for _, rel := range rels.Details {
+		attached[rel.DiskID] = struct{}{}
+	}
+
+	return attached, nil
+}
+
+func (s *scanner) fillTCloudDiskSaving(kt *kit.Kit, recs []dsrecommend.ResRecommendationCreate) error {
+	for _, batch := range slice.Split(recs, int(core.DefaultMaxPageLimit)) {
+		ids := slice.Map(batch, func(one dsrecommend.ResRecommendationCreate) string { return one.ResID })
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		}
+		disks, err := s.cliSet.DataService().TCloud.ListDisk(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list tcloud disk failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		diskMap := make(map[string]*coredisk.Disk[coredisk.TCloudExtension], len(disks.Details))
+		for _, one := range disks.Details {
+			diskMap[one.ID] = one
+		}
+
+		for idx := range batch {
+			disk, exists := diskMap[batch[idx].ResID]
+			if !exists || disk.Extension == nil {
+				continue
+			}
+			batch[idx].Detail.ChargeType = disk.Extension.DiskChargeType
+			batch[idx].MonthlySaving = s.tcloudDiskPrice(kt, disk)
+			batch[idx].Currency = tcloudCurrency
+		}
+	}
+
+	return nil
+}
+
+// unboundEip 未绑定任何实例的弹性IP，目前暂无弹性IP询价接口，不估算节省费用
+func (s *scanner) unboundEip(kt *kit.Kit, account *corecloud.BaseAccount) (
+	[]dsrecommend.ResRecommendationCreate, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", account.ID),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	result := make([]dsrecommend.ResRecommendationCreate, 0)
+	for {
+		eips, err := s.cliSet.DataService().Global.ListEip(kt, listReq)
+		if err != nil {
+			logs.Errorf("list eip failed, err: %v, account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range eips.Details {
+			if len(converter.PtrToVal(one.InstanceID)) != 0 {
+				continue
+			}
+
+			result = append(result, dsrecommend.ResRecommendationCreate{
+				Vendor:     account.Vendor,
+				AccountID:  one.AccountID,
+				BkBizID:    one.BkBizID,
+				Region:     one.Region,
+				ResType:    enumor.EipCloudResType,
+				ResID:      one.ID,
+				CloudResID: one.CloudID,
+				ResName:    converter.PtrToVal(one.Name),
+				RecType:    enumor.UnboundEipRecommend,
+				Detail:     &corerecommend.RecommendDetail{Reason: "eip is not bound to any instance"},
+			})
+		}
+
+		if len(eips.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return result, nil
+}
+
+// stoppedCvm 关机超过指定天数的主机，以主机状态最近一次变更的时间作为关机时间
+func (s *scanner) stoppedCvm(kt *kit.Kit, account *corecloud.BaseAccount) (
+	[]dsrecommend.ResRecommendationCreate, error) {
+
+	stoppedStatus, exists := cvmStoppedStatus[account.Vendor]
+	if !exists {
+		return nil, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", account.ID),
+			tools.RuleIn("status", stoppedStatus),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	deadline := s.now.AddDate(0, 0, -int(s.conf.StoppedCvmDays))
+	result := make([]dsrecommend.ResRecommendationCreate, 0)
+	for {
+		cvms, err := s.cliSet.DataService().Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm failed, err: %v, account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range cvms.Details {
+			stoppedAt, stopped := isCvmStoppedBefore(account.Vendor, one.Status, one.StatusChangedAt, deadline)
+			if !stopped {
+				continue
+			}
+
+			result = append(result, dsrecommend.ResRecommendationCreate{
+				Vendor:     account.Vendor,
+				AccountID:  one.AccountID,
+				BkBizID:    one.BkBizID,
+				Region:     one.Region,
+				Zone:       one.Zone,
+				ResType:    enumor.CvmCloudResType,
+				ResID:      one.ID,
+				CloudResID: one.CloudID,
+				ResName:    one.Name,
+				RecType:    enumor.StoppedCvmRecommend,
+				Detail: &corerecommend.RecommendDetail{
+					Reason: fmt.Sprintf("cvm has been stopped for more than %d days",
+						s.conf.StoppedCvmDays),
+					StoppedDays:         int64(s.now.Sub(stoppedAt).Hours() / 24),
+					CurrentInstanceType: one.MachineType,
+				},
+			})
+		}
+
+		if len(cvms.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	if account.Vendor == enumor.TCloud {
+		if err := s.fillTCloudCvmSaving(kt, account.ID, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// isCvmStoppedBefore 主机处于关机状态且状态变更时间在截止时间前，返回关机时间
+func isCvmStoppedBefore(vendor enumor.Vendor, status, statusChangedAt string, deadline time.Time) (time.Time, bool) {
+	stoppedStatus, exists := cvmStoppedStatus[vendor]
+	if !exists || !slice.IsItemInSlice(stoppedStatus, status) {
+		return time.Time{}, false
+	}
+
+	stoppedAt, err := time.Parse(constant.TimeStdFormat, statusChangedAt)
+	if err != nil || stoppedAt.After(deadline) {
+		return time.Time{}, false
+	}
+
+	return stoppedAt, true
+}
+
+// fillTCloudCvmSaving 按主机当前机型价格估算回收后节省的费用，关机不收费的主机不计算机型费用
+func (s *scanner) fillTCloudCvmSaving(kt *kit.Kit, accountID string, recs []dsrecommend.ResRecommendationCreate) error {
+	cache := make(instanceTypeCache)
+	for _, batch := range slice.Split(recs, int(core.DefaultMaxPageLimit)) {
+		ids := slice.Map(batch, func(one dsrecommend.ResRecommendationCreate) string { return one.ResID })
+		cvmMap, err := s.listTCloudCvm(kt, ids)
+		if err != nil {
+			return err
+		}
+
+		for idx := range batch {
+			one, exists := cvmMap[batch[idx].ResID]
+			if !exists || one.Extension == nil {
+				continue
+			}
+
+			chargeType := converter.PtrToVal(one.Extension.InstanceChargeType)
+			batch[idx].Detail.ChargeType = chargeType
+			batch[idx].Currency = tcloudCurrency
+			if converter.PtrToVal(one.Extension.StopChargingMode) == tcloudStopCharging {
+				continue
+			}
+
+			curr, err := s.findTCloudInstanceType(kt, cache, accountID, one, chargeType)
+			if err != nil || curr == nil {
+				continue
+			}
+			batch[idx].MonthlySaving = tcloudInstancePrice(chargeType, curr.Price)
+		}
+	}
+
+	return nil
+}
+
+func (s *scanner) listTCloudCvm(kt *kit.Kit, ids []string) (
+	map[string]corecvm.Cvm[corecvm.TCloudCvmExtension], error) {
+
+	listReq := &protocloud.CvmListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	cvms, err := s.cliSet.DataService().TCloud.Cvm.ListCvmExt(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list tcloud cvm failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	cvmMap := make(map[string]corecvm.Cvm[corecvm.TCloudCvmExtension], len(cvms.Details))
+	for _, one := range cvms.Details {
+		cvmMap[one.ID] = one
+	}
+
+	return cvmMap, nil
+}
+
+// findTCloudInstanceType 查询主机当前机型，支持的计费模式为包年包月和按量计费
+func (s *scanner) findTCloudInstanceType(kt *kit.Kit, cache instanceTypeCache, accountID string,
+	one corecvm.Cvm[corecvm.TCloudCvmExtension], chargeType string) (*instancetype.TCloudInstanceTypeResp, error) {
+
+	if chargeType != string(typecvm.Prepaid) && chargeType != string(typecvm.PostpaidByHour) {
+		return nil, nil
+	}
+
+	types, err := s.listTCloudInstanceType(kt, cache, accountID, one.Region, one.Zone, chargeType)
+	if err != nil {
+		logs.Warnf("list tcloud instance type failed, err: %v, cvm: %s, rid: %s", err, one.ID, kt.Rid)
+		return nil, err
+	}
+
+	for _, t := range types {
+		if t.InstanceType == one.MachineType {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// emptyLoadBalancer 没有任何监听器的负载均衡
+func (s *scanner) emptyLoadBalancer(kt *kit.Kit, account *corecloud.BaseAccount) (
+	[]dsrecommend.ResRecommendationCreate, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("account_id", account.ID),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	result := make([]dsrecommend.ResRecommendationCreate, 0)
+	for {
+		lbs, err := s.cliSet.DataService().TCloud.LoadBalancer.ListLoadBalancer(kt, listReq)
+		if err != nil {
+			logs.Errorf("list load balancer failed, err: %v, account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(lbs.Details))
+		for _, one := range lbs.Details {
+			ids = append(ids, one.ID)
+		}
+		withListener, err := s.listLoadBalancerWithListener(kt, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for idx := range lbs.Details {
+			one := &lbs.Details[idx]
+			if _, exists := withListener[one.ID]; exists {
+				continue
+			}
+			if one.Extension != nil && converter.PtrToVal(one.Extension.DeleteProtect) {
+				continue
+			}
+
+			detail := &corerecommend.RecommendDetail{Reason: "load balancer has no listener"}
+			if one.Extension != nil {
+				detail.ChargeType = converter.PtrToVal(one.Extension.ChargeType)
+			}
+			result = append(result, dsrecommend.ResRecommendationCreate{
+				Vendor:        account.Vendor,
+				AccountID:     one.AccountID,
+				BkBizID:       one.BkBizID,
+				Region:        one.Region,
+				ResType:       enumor.LoadBalancerCloudResType,
+				ResID:         one.ID,
+				CloudResID:    one.CloudID,
+				ResName:       one.Name,
+				RecType:       enumor.EmptyLoadBalancerRecommend,
+				Detail:        detail,
+				MonthlySaving: s.tcloudLoadBalancerPrice(kt, one),
+				Currency:      tcloudCurrency,
+			})
+		}
+
+		if len(lbs.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return result, nil
+}
+
+func (s *scanner) listLoadBalancerWithListener(kt *kit.Kit, lbIDs []string) (map[string]struct{}, error) {
+	withListener := make(map[string]struct{})
+	if len(lbIDs) == 0 {
+		return withListener, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("lb_id", lbIDs),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		Fields: []string{"lb_id"},
+	}
+	for {
+		listeners, err := s.cliSet.DataService().Global.LoadBalancer.ListListener(kt, listReq)
+		if err != nil {
+			logs.Errorf("list listener failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range listeners.Details {
+			withListener[one.LbID] = struct{}{}
+		}
+
+		if len(listeners.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return withListener, nil
+}
+
+// oversizedCvm 观察期内CPU、内存利用率峰值均低于阈值的运行中主机，建议调整为同机型族中CPU、内存减半的机型
+func (s *scanner) oversizedCvm(kt *kit.Kit, account *corecloud.BaseAccount) (
+	[]dsrecommend.ResRecommendationCreate, error) {
+
+	listReq := &protocloud.CvmListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", account.ID),
+			tools.RuleEqual("status", tcloudCvmRunning),
+			tools.RuleNotEqual("recycle_status", enumor.RecycleStatus),
+		),
+		Page: &core.BasePage{Start: 0, Limit: uint(constant.BatchOperationMaxLimit)},
+	}
+
+	cache := make(instanceTypeCache)
+	result := make([]dsrecommend.ResRecommendationCreate, 0)
+	for {
+		cvms, err := s.cliSet.DataService().TCloud.Cvm.ListCvmExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list tcloud cvm failed, err: %v, account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		ids := slice.Map(cvms.Details, func(one corecvm.Cvm[corecvm.TCloudCvmExtension]) string { return one.ID })
+		usages, err := s.listCvmMaxUsage(kt, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range cvms.Details {
+			usage, exists := usages[one.ID]
+			if !exists || !s.isLowUsage(usage) || one.Extension == nil {
+				continue
+			}
+
+			rec, err := s.buildOversizedCvm(kt, cache, account, one, usage)
+			if err != nil || rec == nil {
+				continue
+			}
+			result = append(result, *rec)
+		}
+
+		if len(cvms.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+		listReq.Page.Start += uint32(constant.BatchOperationMaxLimit)
+	}
+
+	return result, nil
+}
+
+// cvmMaxUsage 观察期内主机的利用率峰值
+type cvmMaxUsage struct {
+	cpuMax  *float64
+	memMax  *float64
+	cpuDays int
+}
+
+// isLowUsage 有效监控数据天数不少于观察期的一半，且CPU、内存利用率峰值均低于阈值
+func (s *scanner) isLowUsage(usage *cvmMaxUsage) bool {
+	if usage.cpuMax == nil || usage.memMax == nil {
+		return false
+	}
+
+	if usage.cpuDays < int(s.conf.ObserveDays+1)/2 {
+		return false
+	}
+
+	return *usage.cpuMax < s.conf.CpuUsageThreshold && *usage.memMax < s.conf.MemUsageThreshold
+}
+
+func (s *scanner) listCvmMaxUsage(kt *kit.Kit, ids []string) (map[string]*cvmMaxUsage, error) {
+	usages := make(map[string]*cvmMaxUsage)
+	if len(ids) == 0 {
+		return usages, nil
+	}
+
+	dates := make([]string, 0, s.conf.ObserveDays)
+	for day := 1; day <= int(s.conf.ObserveDays); day++ {
+		dates = append(dates, s.now.AddDate(0, 0, -day).Format(constant.DateLayout))
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", enumor.CvmCloudResType),
+			tools.RuleIn("res_id", ids),
+			tools.RuleIn("metric", []typemonitor.MetricName{typemonitor.CpuUsage, typemonitor.MemUsage}),
+			// stat_date 为日期字符串，不支持范围查询，枚举观察期内的日期
+			tools.RuleIn("stat_date", dates),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	for {
+		metrics, err := s.cliSet.DataService().Global.ResMetric.ListDaily(kt, listReq)
+		if err != nil {
+			logs.Errorf("list res metric daily failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range metrics.Details {
+			usage, exists := usages[one.ResID]
+			if !exists {
+				usage = new(cvmMaxUsage)
+				usages[one.ResID] = usage
+			}
+
+			switch typemonitor.MetricName(one.Metric) {
+			case typemonitor.CpuUsage:
+				usage.cpuDays++
+				usage.cpuMax = maxValue(usage.cpuMax, one.MaxValue)
+			case typemonitor.MemUsage:
+				usage.memMax = maxValue(usage.memMax, one.MaxValue)
+			}
+		}
+
+		if len(metrics.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return usages, nil
+}
+
+func maxValue(curr *float64, value float64) *float64 {
+	if curr == nil || *curr < value {
+		return &value
+	}
+	return curr
+}
+
+// buildOversizedCvm 在当前机型族中选择CPU、内存减半且价格最低的在售机型，节省费用为两者价格差
+func (s *scanner) buildOversizedCvm(kt *kit.Kit, cache instanceTypeCache, account *corecloud.BaseAccount,
+	one corecvm.Cvm[corecvm.TCloudCvmExtension], usage *cvmMaxUsage) (*dsrecommend.ResRecommendationCreate, error) {
+
+	chargeType := converter.PtrToVal(one.Extension.InstanceChargeType)
+	curr, err := s.findTCloudInstanceType(kt, cache, account.ID, one, chargeType)
+	if err != nil || curr == nil || curr.CPU < 2 {
+		return nil, err
+	}
+
+	types, err := s.listTCloudInstanceType(kt, cache, account.ID, one.Region, one.Zone, chargeType)
+	if err != nil {
+		return nil, err
+	}
+
+	currPrice := tcloudInstancePrice(chargeType, curr.Price)
+	target, targetPrice := selectDownsizeInstanceType(curr, types, chargeType)
+	if target == nil || currPrice <= targetPrice {
+		return nil, nil
+	}
+
+	return &dsrecommend.ResRecommendationCreate{
+		Vendor:     account.Vendor,
+		AccountID:  one.AccountID,
+		BkBizID:    one.BkBizID,
+		Region:     one.Region,
+		Zone:       one.Zone,
+		ResType:    enumor.CvmCloudResType,
+		ResID:      one.ID,
+		CloudResID: one.CloudID,
+		ResName:    one.Name,
+		RecType:    enumor.OversizedCvmRecommend,
+		Detail: &corerecommend.RecommendDetail{
+			Reason: fmt.Sprintf("max cpu usage < %.0f%% and max memory usage < %.0f%% in the past %d days",
+				s.conf.CpuUsageThreshold, s.conf.MemUsageThreshold, s.conf.ObserveDays),
+			ObserveDays:         int(s.conf.ObserveDays),
+			CpuMaxUsage:         usage.cpuMax,
+			MemMaxUsage:         usage.memMax,
+			ChargeType:          chargeType,
+			CurrentInstanceType: curr.InstanceType,
+			TargetInstanceType:  target.InstanceType,
+		},
+		MonthlySaving: currPrice - targetPrice,
+		Currency:      tcloudCurrency,
+	}, nil
+}
+
+// selectDownsizeInstanceType 在当前机型族中选择CPU、内存减半且价格最低的在售机型，返回机型及其月度费用
+func selectDownsizeInstanceType(curr *instancetype.TCloudInstanceTypeResp,
+	types []*instancetype.TCloudInstanceTypeResp, chargeType string) (*instancetype.TCloudInstanceTypeResp, float64) {
+
+	var target *instancetype.TCloudInstanceTypeResp
+	var targetPrice float64
+	for _, t := range types {
+		if t.InstanceFamily != curr.InstanceFamily || t.Status != tcloudInstanceTypeSell ||
+			t.CPU != curr.CPU/2 || t.Memory != curr.Memory/2 {
+			continue
+		}
+
+		price := tcloudInstancePrice(chargeType, t.Price)
+		if target == nil || price < targetPrice {
+			target, targetPrice = t, price
+		}
+	}
+
+	return target, targetPrice
+}
@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"testing"
+	"time"
+
+	instancetype "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+func TestIsCvmStoppedBefore(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	deadline := now.AddDate(0, 0, -30)
+	longAgo := now.AddDate(0, 0, -40).Format(constant.TimeStdFormat)
+	recently := now.AddDate(0, 0, -3).Format(constant.TimeStdFormat)
+
+	cases := []struct {
+		name            string
+		vendor          enumor.Vendor
+		status          string
+		statusChangedAt string
+		expect          bool
+	}{
+		{name: "stopped long ago", vendor: enumor.TCloud, status: "STOPPED", statusChangedAt: longAgo, expect: true},
+		{name: "azure deallocated", vendor: enumor.Azure, status: "PowerState/deallocated", statusChangedAt: longAgo,
+			expect: true},
+		{name: "stopped recently", vendor: enumor.TCloud, status: "STOPPED", statusChangedAt: recently, expect: false},
+		{name: "restarted", vendor: enumor.TCloud, status: "RUNNING", statusChangedAt: longAgo, expect: false},
+		{name: "status of other vendor", vendor: enumor.Aws, status: "STOPPED", statusChangedAt: longAgo,
+			expect: false},
+		{name: "unsupported vendor", vendor: enumor.Other, status: "STOPPED", statusChangedAt: longAgo, expect: false},
+		{name: "invalid status changed at", vendor: enumor.TCloud, status: "STOPPED", statusChangedAt: "",
+			expect: false},
+	}
+
+	for _, c := range cases {
+		stoppedAt, stopped := isCvmStoppedBefore(c.vendor, c.status, c.statusChangedAt, deadline)
+		if stopped != c.expect {
+			t.Errorf("%s: expect stopped %v, but got %v", c.name, c.expect, stopped)
+			continue
+		}
+		if stopped && stoppedAt.Format(constant.TimeStdFormat) != c.statusChangedAt {
+			t.Errorf("%s: expect stopped at %s, but got %v", c.name, c.statusChangedAt, stoppedAt)
+		}
+	}
+}
+
+func TestIsLowUsage(t *testing.T) {
+	s := &scanner{conf: cc.Recommendation{ObserveDays: 7, CpuUsageThreshold: 20, MemUsageThreshold: 30}}
+
+	cases := []struct {
+		name   string
+		usage  *cvmMaxUsage
+		expect bool
+	}{
+		{name: "low usage", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(10.0), memMax: converter.ValToPtr(20.0),
+			cpuDays: 7}, expect: true},
+		{name: "cpu over threshold", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(20.0),
+			memMax: converter.ValToPtr(20.0), cpuDays: 7}, expect: false},
+		{name: "memory over threshold", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(10.0),
+			memMax: converter.ValToPtr(35.0), cpuDays: 7}, expect: false},
+		{name: "not enough days", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(10.0),
+			memMax: converter.ValToPtr(20.0), cpuDays: 3}, expect: false},
+		{name: "half of days", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(10.0),
+			memMax: converter.ValToPtr(20.0), cpuDays: 4}, expect: true},
+		{name: "no memory data", usage: &cvmMaxUsage{cpuMax: converter.ValToPtr(10.0), cpuDays: 7}, expect: false},
+	}
+
+	for _, c := range cases {
+		if got := s.isLowUsage(c.usage); got != c.expect {
+			t.Errorf("%s: expect %v, but got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestMaxValue(t *testing.T) {
+	var curr *float64
+	for _, value := range []float64{3, 8, 5} {
+		curr = maxValue(curr, value)
+	}
+
+	if curr == nil || *curr != 8 {
+		t.Errorf("expect max value 8, but got %v", curr)
+	}
+}
+
+func TestSelectDownsizeInstanceType(t *testing.T) {
+	newType := func(name, family, status string, cpu, memory int64,
+		price float64) *instancetype.TCloudInstanceTypeResp {
+
+		return &instancetype.TCloudInstanceTypeResp{InstanceType: name, InstanceFamily: family, Status: status,
+			CPU: cpu, Memory: memory, Price: cvm.ItemPrice{DiscountPrice: converter.ValToPtr(price)}}
+	}
+
+	curr := newType("S5.LARGE16", "S5", tcloudInstanceTypeSell, 4, 16, 400)
+	types := []*instancetype.TCloudInstanceTypeResp{
+		curr,
+		newType("S5.MEDIUM8", "S5", tcloudInstanceTypeSell, 2, 8, 200),
+		newType("S5.MEDIUM8-CHEAP", "S5", tcloudInstanceTypeSell, 2, 8, 180),
+		newType("S5.MEDIUM8-SOLDOUT", "S5", "SOLD_OUT", 2, 8, 100),
+		newType("SA2.MEDIUM8", "SA2", tcloudInstanceTypeSell, 2, 8, 150),
+		newType("S5.MEDIUM4", "S5", tcloudInstanceTypeSell, 2, 4, 120),
+	}
+
+	target, price := selectDownsizeInstanceType(curr, types, "PREPAID")
+	if target == nil || target.InstanceType != "S5.MEDIUM8-CHEAP" || price != 180 {
+		t.Errorf("expect the cheapest half size type in the same family, but got %v, price: %v", target, price)
+	}
+
+	target, _ = selectDownsizeInstanceType(curr, types[:1], "PREPAID")
+	if target != nil {
+		t.Errorf("expect no target type, but got %v", target)
+	}
+}
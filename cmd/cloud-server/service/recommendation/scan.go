@@ -0,0 +1,219 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/slice"
+)
+
+// ScanResRecommendation 定时扫描闲置及规格过大的资源，生成资源优化建议
+func ScanResRecommendation(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("res recommendation scan enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		start := time.Now()
+		logs.Infof("res recommendation scan start, rid: %s", kt.Rid)
+
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		s := &scanner{cliSet: cliSet, conf: cc.CloudServer().Recommendation, now: start}
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			s.scanAllAccount(tenantKt)
+		}
+
+		logs.Infof("res recommendation scan end, cost: %s, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+type scanner struct {
+	cliSet *client.ClientSet
+	conf   cc.Recommendation
+	now    time.Time
+}
+
+// scanRule 单条优化建议规则，返回账号下命中规则的资源
+type scanRule func(kt *kit.Kit, account *corecloud.BaseAccount) ([]dsrecommend.ResRecommendationCreate, error)
+
+// scanAllAccount 扫描所有资源账号，单个账号扫描失败不影响其他账号
+func (s *scanner) scanAllAccount(kt *kit.Kit) {
+	listReq := &protocloud.AccountListReq{
+		Filter: tools.EqualExpression("type", enumor.ResourceAccount),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	for {
+		accounts, err := s.cliSet.DataService().Global.Account.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list resource account failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, one := range accounts.Details {
+			if err = s.scanAccount(kt, one); err != nil {
+				logs.Errorf("scan res recommendation failed, err: %v, account: %s, rid: %s", err, one.ID, kt.Rid)
+				continue
+			}
+		}
+
+		if len(accounts.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+func (s *scanner) scanAccount(kt *kit.Kit, account *corecloud.BaseAccount) error {
+	rules := []scanRule{s.unattachedDisk, s.unboundEip, s.stoppedCvm}
+	// 负载均衡及机型价格目前仅支持腾讯云
+	if account.Vendor == enumor.TCloud {
+		rules = append(rules, s.emptyLoadBalancer, s.oversizedCvm)
+	}
+
+	candidates := make([]dsrecommend.ResRecommendationCreate, 0)
+	for _, rule := range rules {
+		// 任一规则失败时不更新该账号的建议，避免误删上一轮仍然有效的建议
+		items, err := rule(kt, account)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, items...)
+	}
+
+	return s.reconcile(kt, account.ID, candidates)
+}
+
+// reconcile 按本轮扫描结果更新账号下的优化建议：待处理的建议全部重新生成；已忽略的资源不再生成建议；
+// 已处理的建议作为历史保留，若资源再次命中规则则重新生成待处理建议
+func (s *scanner) reconcile(kt *kit.Kit, accountID string,
+	candidates []dsrecommend.ResRecommendationCreate) error {
+
+	existing, err := s.listAccountRecommendation(kt, accountID)
+	if err != nil {
+		return err
+	}
+
+	candidateKeys := make(map[string]struct{}, len(candidates))
+	for _, one := range candidates {
+		candidateKeys[recommendKey(one.RecType, one.ResID)] = struct{}{}
+	}
+
+	ignored := make(map[string]struct{})
+	delIDs := make([]string, 0)
+	for _, one := range existing {
+		switch one.Status {
+		case enumor.IgnoredRecommendStatus:
+			ignored[recommendKey(one.RecType, one.ResID)] = struct{}{}
+		case enumor.AppliedRecommendStatus:
+			if _, exists := candidateKeys[recommendKey(one.RecType, one.ResID)]; exists {
+				delIDs = append(delIDs, one.ID)
+			}
+		default:
+			delIDs = append(delIDs, one.ID)
+		}
+	}
+
+	for _, ids := range slice.Split(delIDs, constant.BatchOperationMaxLimit) {
+		delReq := &dsrecommend.DeleteResRecommendationReq{Filter: tools.ContainersExpression("id", ids)}
+		if err = s.cliSet.DataService().Global.Recommendation.Delete(kt, delReq); err != nil {
+			logs.Errorf("delete res recommendation failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return err
+		}
+	}
+
+	creates := make([]dsrecommend.ResRecommendationCreate, 0, len(candidates))
+	for _, one := range candidates {
+		if _, exists := ignored[recommendKey(one.RecType, one.ResID)]; exists {
+			continue
+		}
+		creates = append(creates, one)
+	}
+
+	for _, items := range slice.Split(creates, constant.BatchOperationMaxLimit) {
+		createReq := &dsrecommend.BatchCreateResRecommendationReq{Items: items}
+		if _, err = s.cliSet.DataService().Global.Recommendation.BatchCreate(kt, createReq); err != nil {
+			logs.Errorf("create res recommendation failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return err
+		}
+	}
+
+	logs.V(3).Infof("scan res recommendation success, account: %s, deleted: %d, created: %d, rid: %s", accountID,
+		len(delIDs), len(creates), kt.Rid)
+
+	return nil
+}
+
+func (s *scanner) listAccountRecommendation(kt *kit.Kit, accountID string) (
+	[]corerecommend.ResRecommendation, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("account_id", accountID),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	result := make([]corerecommend.ResRecommendation, 0)
+	for {
+		res, err := s.cliSet.DataService().Global.Recommendation.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list res recommendation failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+		result = append(result, res.Details...)
+
+		if len(res.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return result, nil
+}
+
+func recommendKey(recType enumor.RecommendType, resID string) string {
+	return string(recType) + "/" + resID
+}
@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation 资源优化建议
+package recommendation
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/cmd/cloud-server/service/capability"
+	csrecommend "hcm/pkg/api/cloud-server/recommendation"
+	"hcm/pkg/api/core"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the res recommendation service.
+func InitService(c *capability.Capability) {
+	svc := &recommendSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+		cvmLgc:     c.Logics.Cvm,
+		eipLgc:     c.Logics.Eip,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListRecommendation", http.MethodPost, "/recommendations/list", svc.ListRecommendation).
+		Reads(new(core.ListReq)).Writes(new(dsrecommend.ListResRecommendationResult))
+	h.Add("ApplyRecommendation", http.MethodPost, "/recommendations/{id}/apply", svc.ApplyRecommendation).
+		Reads(new(csrecommend.ApplyRecommendationReq))
+	h.Add("IgnoreRecommendation", http.MethodPost, "/recommendations/{id}/ignore", svc.IgnoreRecommendation)
+
+	h.Add("ListBizRecommendation", http.MethodPost, "/bizs/{bk_biz_id}/recommendations/list",
+		svc.ListBizRecommendation).Reads(new(core.ListReq)).Writes(new(dsrecommend.ListResRecommendationResult))
+	h.Add("ApplyBizRecommendation", http.MethodPost, "/bizs/{bk_biz_id}/recommendations/{id}/apply",
+		svc.ApplyBizRecommendation).Reads(new(csrecommend.ApplyRecommendationReq))
+	h.Add("IgnoreBizRecommendation", http.MethodPost, "/bizs/{bk_biz_id}/recommendations/{id}/ignore",
+		svc.IgnoreBizRecommendation)
+
+	h.Load(c.WebService)
+}
+
+type recommendSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+	cvmLgc     cvm.Interface
+	eipLgc     eip.Interface
+}
@@ -65,7 +65,7 @@ func RecycleTiming(c *client.ClientSet, state serviced.State, conf cc.Recycle, c
 	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker, conf)
 }
 
-type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo) error
+type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo, record recyclerecord.RecycleRecord) error
 
 func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycleWorker, conf cc.Recycle) {
 	for {
@@ -216,7 +216,7 @@ func (r *recycle) execWorker(kt *kit.Kit, worker recycleWorker, record recyclere
 	// 因为cvm记录中的BkBizID已经在加入业务的时候被清掉了，所以要以recycle_record中的为准
 	basicInfo.BkBizID = record.BkBizID
 	err = rty.BaseExec(kt, func() error {
-		return worker(kt, &basicInfo)
+		return worker(kt, &basicInfo, record)
 	})
 	if err != nil {
 		// Failed after retry
@@ -229,8 +229,22 @@ func (r *recycle) execWorker(kt *kit.Kit, worker recycleWorker, record recyclere
 	logicsrecycle.MarkRecordSuccess(kt, r.client.DataService(), []string{record.ID})
 }
 
-func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
-	res, err := r.logics.Disk.DeleteRecycledDisk(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info})
+func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo,
+	record recyclerecord.RecycleRecord) error {
+
+	// 获取回收记录中的回收选项
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", record.ID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	records, err := r.client.DataService().Global.RecycleRecord.ListDiskRecycleRecord(kt, listReq)
+	if err != nil {
+		logs.Errorf("list disk recycle record failed, err: %v, id: %s, rid: %s", err, record.ID, kt.Rid)
+		return err
+	}
+
+	res, err := r.logics.Disk.DeleteRecycledDisk(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info},
+		records.Details)
 	if err != nil {
 		logs.Errorf("delete disk failed, err: %v, res: %+v, disk: %s, rid: %s", err, res, info.ID, kt.Rid)
 		return err
@@ -238,7 +252,9 @@ func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicI
 	return nil
 }
 
-func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo,
+	_ recyclerecord.RecycleRecord) error {
+
 	// 实际销毁CVM
 	res, err := r.logics.Cvm.DestroyRecycledCvm(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info}, nil)
 	if err != nil {
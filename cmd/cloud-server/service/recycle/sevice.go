@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -37,8 +38,9 @@ func InitService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListRecycleRecord", http.MethodPost, "/recycle_records/list", svc.ListRecycleRecord)
-	h.Add("ListBizRecycleRecord", http.MethodPost, "/bizs/{bk_biz_id}/recycle_records/list", svc.ListBizRecycleRecord)
+	h.Add("ListRecycleRecord", http.MethodPost, "/recycle_records/list", svc.ListRecycleRecord).Reads(new(core.ListReq))
+	h.Add("ListBizRecycleRecord", http.MethodPost, "/bizs/{bk_biz_id}/recycle_records/list", svc.ListBizRecycleRecord).
+		Reads(new(core.ListReq))
 
 	h.Load(c.WebService)
 }
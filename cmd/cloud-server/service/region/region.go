@@ -42,7 +42,8 @@ func InitRegionService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListRegion", http.MethodPost, "/vendors/{vendor}/regions/list", svc.ListRegion)
+	h.Add("ListRegion", http.MethodPost, "/vendors/{vendor}/regions/list", svc.ListRegion).
+		Reads(new(protoregion.RegionListReq))
 
 	h.Load(c.WebService)
 }
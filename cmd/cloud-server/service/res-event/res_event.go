@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent 资源变更事件订阅管理及推送记录查询
+package resevent
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csevent "hcm/pkg/api/cloud-server/res-event"
+	"hcm/pkg/api/core"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the res event service.
+func InitService(c *capability.Capability) {
+	svc := &resEventSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateEventSubscription", http.MethodPost, "/event_subscriptions/create", svc.CreateEventSubscription).
+		Reads(new(csevent.CreateSubscriptionReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateEventSubscription", http.MethodPatch, "/event_subscriptions/{id}", svc.UpdateEventSubscription).
+		Reads(new(csevent.UpdateSubscriptionReq))
+	h.Add("ListEventSubscription", http.MethodPost, "/event_subscriptions/list", svc.ListEventSubscription).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListSubscriptionResult))
+	h.Add("BatchDeleteEventSubscription", http.MethodDelete, "/event_subscriptions/batch",
+		svc.BatchDeleteEventSubscription).Reads(new(csevent.BatchDeleteReq))
+	h.Add("ListResEvent", http.MethodPost, "/res_events/list", svc.ListResEvent).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListEventResult))
+	h.Add("ListEventDelivery", http.MethodPost, "/event_deliveries/list", svc.ListEventDelivery).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListDeliveryResult))
+	h.Add("RetryEventDelivery", http.MethodPost, "/event_deliveries/retry", svc.RetryEventDelivery).
+		Reads(new(csevent.RetryDeliveryReq)).Writes(new(dsevent.RetryDeliveryResult))
+
+	h.Load(c.WebService)
+}
+
+type resEventSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *resEventSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.EventSubscription, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// CreateEventSubscription create event subscription.
+func (svc *resEventSvc) CreateEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.CreateSubscriptionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	createReq := &dsevent.BatchCreateSubscriptionReq{Subscriptions: []dsevent.SubscriptionCreate{*req}}
+	if err := createReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.ResEvent.BatchCreateSubscription(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create event subscription failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create event subscription result is invalid")
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateEventSubscription update event subscription.
+func (svc *resEventSvc) UpdateEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csevent.UpdateSubscriptionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsevent.BatchUpdateSubscriptionReq{
+		Subscriptions: []dsevent.SubscriptionUpdate{{
+			ID:      id,
+			Name:    req.Name,
+			URL:     req.URL,
+			Secret:  req.Secret,
+			Filter:  req.Filter,
+			Enabled: req.Enabled,
+			Memo:    req.Memo,
+		}},
+	}
+	if err := svc.client.DataService().Global.ResEvent.BatchUpdateSubscription(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update event subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListEventSubscription list event subscriptions.
+func (svc *resEventSvc) ListEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ResEvent.ListSubscription(cts.Kit, req)
+}
+
+// BatchDeleteEventSubscription batch delete event subscriptions.
+func (svc *resEventSvc) BatchDeleteEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.ResEvent.BatchDeleteSubscription(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete event subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResEvent list res change events.
+func (svc *resEventSvc) ListResEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ResEvent.ListEvent(cts.Kit, req)
+}
+
+// ListEventDelivery list event deliveries, which is the delivery log of subscriptions.
+func (svc *resEventSvc) ListEventDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ResEvent.ListDelivery(cts.Kit, req)
+}
+
+// RetryEventDelivery retry the failed event deliveries.
+func (svc *resEventSvc) RetryEventDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.RetryDeliveryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.ResEvent.RetryDelivery(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("retry event delivery failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	corereshistory "hcm/pkg/api/core/res-history"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// cleanBatchLimit 单次删除过期变更历史的最大数量
+const cleanBatchLimit = 5000
+
+// CleanExpiredResChangeHistory 定时清理超过保留天数的资源变更历史，保留天数可以按资源类型配置
+func CleanExpiredResChangeHistory(conf cc.ResChangeHistory, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	interval := time.Duration(conf.CleanIntervalMin) * time.Minute
+	logs.Infof("res change history clean enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		start := time.Now()
+		logs.Infof("res change history clean start, rid: %s", kt.Rid)
+
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			for _, resType := range corereshistory.SupportedResTypes {
+				days := conf.GetRetentionDays(resType)
+				before := start.AddDate(0, 0, -int(days)).Format(constant.DateTimeLayout)
+				cleanExpired(tenantKt, cliSet, resType, before)
+			}
+		}
+
+		logs.Infof("res change history clean end, cost: %s, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// cleanExpired 分批删除资源类型在指定时间之前的变更历史，避免单次删除数据量过大
+func cleanExpired(kt *kit.Kit, cliSet *client.ClientSet, resType enumor.CloudResourceType, before string) {
+	req := &dsreshistory.DeleteExpiredResChangeHistoryReq{
+		ResType: resType,
+		Before:  before,
+		Limit:   cleanBatchLimit,
+	}
+
+	var total int64
+	for {
+		result, err := cliSet.DataService().Global.ResChangeHistory.DeleteExpired(kt, req)
+		if err != nil {
+			logs.Errorf("delete expired %s change history failed, err: %v, before: %s, rid: %s", resType, err,
+				before, kt.Rid)
+			return
+		}
+
+		total += result.Deleted
+		if result.Deleted < cleanBatchLimit {
+			break
+		}
+	}
+
+	logs.V(3).Infof("clean expired %s change history success, before: %s, count: %d, rid: %s", resType, before,
+		total, kt.Rid)
+}
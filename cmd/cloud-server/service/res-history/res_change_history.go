@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	csreshistory "hcm/pkg/api/cloud-server/res-history"
+	"hcm/pkg/api/core"
+	corereshistory "hcm/pkg/api/core/res-history"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// authResTypes 资源类型对应的鉴权资源类型，查看变更历史需要有资源的查看权限
+var authResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:           meta.Cvm,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.LoadBalancerCloudResType:  meta.LoadBalancer,
+	enumor.DiskCloudResType:          meta.Disk,
+	enumor.EipCloudResType:           meta.Eip,
+}
+
+// ListResChangeHistory list res change history.
+func (svc *resHistorySvc) ListResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResChangeHistory(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizResChangeHistory list biz res change history.
+func (svc *resHistorySvc) ListBizResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResChangeHistory(cts, handler.ListBizAuthRes)
+}
+
+// DiffResChangeHistory diff any two versions of the resource.
+func (svc *resHistorySvc) DiffResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	return svc.diffResChangeHistory(cts, handler.ListResourceAuthRes)
+}
+
+// DiffBizResChangeHistory diff any two versions of the biz resource.
+func (svc *resHistorySvc) DiffBizResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	return svc.diffResChangeHistory(cts, handler.ListBizAuthRes)
+}
+
+func (svc *resHistorySvc) listResChangeHistory(cts *rest.Contexts, validHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	resType, id, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(csreshistory.ListResChangeHistoryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	exists, err := svc.authorize(cts, validHandler, resType, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &dsreshistory.ListResChangeHistoryResult{Details: make([]corereshistory.ResChangeHistory, 0)}, nil
+	}
+
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("res_type", resType),
+		tools.RuleEqual("res_id", id),
+	}
+	if len(req.StartTime) != 0 {
+		rules = append(rules, tools.RuleGreaterThanEqual("created_at", req.StartTime))
+	}
+	if len(req.EndTime) != 0 {
+		rules = append(rules, tools.RuleLessThanEqual("created_at", req.EndTime))
+	}
+
+	// 默认按版本倒序返回时间线
+	if len(req.Page.Sort) == 0 {
+		req.Page.Sort = "version"
+		req.Page.Order = core.Descending
+	}
+
+	listReq := &core.ListReq{Filter: tools.ExpressionAnd(rules...), Page: req.Page}
+	result, err := svc.client.DataService().Global.ResChangeHistory.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list res change history failed, err: %v, res: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (svc *resHistorySvc) diffResChangeHistory(cts *rest.Contexts, validHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	resType, id, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(csreshistory.DiffResChangeHistoryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	exists, err := svc.authorize(cts, validHandler, resType, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "%s %s has no change history", resType, id)
+	}
+
+	diffReq := &dsreshistory.DiffResChangeHistoryReq{
+		ResType:     resType,
+		ResID:       id,
+		FromVersion: req.FromVersion,
+		ToVersion:   req.ToVersion,
+	}
+	result, err := svc.client.DataService().Global.ResChangeHistory.Diff(cts.Kit, diffReq)
+	if err != nil {
+		logs.Errorf("diff res change history failed, err: %v, req: %+v, rid: %s", err, diffReq, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func parseResPath(cts *rest.Contexts) (enumor.CloudResourceType, string, error) {
+	resType := enumor.CloudResourceType(cts.PathParameter("res_type").String())
+	if !corereshistory.IsSupported(resType) {
+		return "", "", errf.Newf(errf.InvalidParameter, "resource type %s does not support change history", resType)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return "", "", errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	return resType, id, nil
+}
+
+// authorize 校验资源的查看权限，资源删除后仍可查看变更历史，所以使用最新版本变更历史中的业务进行校验，
+// 返回资源是否存在变更历史
+func (svc *resHistorySvc) authorize(cts *rest.Contexts, validHandler handler.ListAuthResHandler,
+	resType enumor.CloudResourceType, id string) (bool, error) {
+
+	// validate biz and authorize
+	_, noPerm, err := validHandler(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: authResTypes[resType], Action: meta.Find})
+	if err != nil {
+		return false, err
+	}
+	if noPerm {
+		return false, errf.New(errf.PermissionDenied, "permission denied for list res change history")
+	}
+
+	latest, err := svc.getLatestHistory(cts.Kit, resType, id)
+	if err != nil {
+		return false, err
+	}
+	if latest == nil {
+		return false, nil
+	}
+
+	if bizID, err := cts.PathParameter("bk_biz_id").Int64(); err == nil && bizID != latest.BkBizID {
+		return false, errf.Newf(errf.InvalidParameter, "%s %s is not in biz %d", resType, id, bizID)
+	}
+
+	return true, nil
+}
+
+func (svc *resHistorySvc) getLatestHistory(kt *kit.Kit, resType enumor.CloudResourceType, id string) (
+	*corereshistory.ResChangeHistory, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", resType),
+			tools.RuleEqual("res_id", id),
+		),
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "version", Order: core.Descending},
+		Fields: []string{"id", "res_type", "res_id", "bk_biz_id", "version"},
+	}
+	result, err := svc.client.DataService().Global.ResChangeHistory.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("get latest res change history failed, err: %v, res: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	return &result.Details[0], nil
+}
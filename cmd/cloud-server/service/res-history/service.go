@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory 资源变更历史
+package reshistory
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csreshistory "hcm/pkg/api/cloud-server/res-history"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the res change history service.
+func InitService(c *capability.Capability) {
+	svc := &resHistorySvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResChangeHistory", http.MethodPost, "/resources/{res_type}/{id}/change_histories/list",
+		svc.ListResChangeHistory).
+		Reads(new(csreshistory.ListResChangeHistoryReq)).Writes(new(dsreshistory.ListResChangeHistoryResult))
+	h.Add("DiffResChangeHistory", http.MethodPost, "/resources/{res_type}/{id}/change_histories/diff",
+		svc.DiffResChangeHistory).
+		Reads(new(csreshistory.DiffResChangeHistoryReq)).Writes(new(dsreshistory.DiffResChangeHistoryResult))
+
+	h.Add("ListBizResChangeHistory", http.MethodPost,
+		"/bizs/{bk_biz_id}/resources/{res_type}/{id}/change_histories/list", svc.ListBizResChangeHistory).
+		Reads(new(csreshistory.ListResChangeHistoryReq)).Writes(new(dsreshistory.ListResChangeHistoryResult))
+	h.Add("DiffBizResChangeHistory", http.MethodPost,
+		"/bizs/{bk_biz_id}/resources/{res_type}/{id}/change_histories/diff", svc.DiffBizResChangeHistory).
+		Reads(new(csreshistory.DiffResChangeHistoryReq)).Writes(new(dsreshistory.DiffResChangeHistoryResult))
+
+	h.Load(c.WebService)
+}
+
+type resHistorySvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
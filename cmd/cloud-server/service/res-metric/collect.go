@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric 资源监控数据
+package resmetric
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcresmetric "hcm/pkg/api/hc-service/res-metric"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// collectVendors 支持采集监控数据的云厂商
+var collectVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Azure, enumor.Gcp}
+
+// CollectResMetricDaily 定时采集前一天的主机、负载均衡监控数据，并按天聚合存储
+func CollectResMetricDaily(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("res metric daily collect enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		start := time.Now()
+		statDate := start.AddDate(0, 0, -1).Format(constant.DateLayout)
+		logs.Infof("res metric daily collect start, stat date: %s, rid: %s", statDate, kt.Rid)
+
+		tenantIDs, err := tenant.ListAllTenantID(kt, cliSet.DataService())
+		if err != nil {
+			logs.Errorf("failed to list all tenant ids, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			tenantKt := kt.NewSubKitWithTenant(tenantID)
+			tenantKt.RequestSource = enumor.AsynchronousTasks
+			for _, vendor := range collectVendors {
+				allAccountCollect(tenantKt, cliSet, vendor, statDate)
+			}
+		}
+
+		logs.Infof("res metric daily collect end, cost: %s, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// allAccountCollect 采集云厂商下所有资源账号的监控数据，单个账号采集失败不影响其他账号
+func allAccountCollect(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, statDate string) {
+	listReq := &protocloud.AccountListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", vendor),
+			tools.RuleEqual("type", enumor.ResourceAccount),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	for {
+		accounts, err := cliSet.DataService().Global.Account.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list %s account failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return
+		}
+
+		for _, one := range accounts.Details {
+			req := &hcresmetric.CollectResMetricDailyReq{AccountID: one.ID, StatDate: statDate}
+			result, err := collectDaily(kt, cliSet, vendor, req)
+			if err != nil {
+				logs.Errorf("collect %s res metric failed, err: %v, account: %s, rid: %s", vendor, err, one.ID,
+					kt.Rid)
+				continue
+			}
+			logs.V(3).Infof("collect %s res metric success, account: %s, count: %d, rid: %s", vendor, one.ID,
+				result.Count, kt.Rid)
+		}
+
+		if len(accounts.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+func collectDaily(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor,
+	req *hcresmetric.CollectResMetricDailyReq) (*hcresmetric.CollectResMetricDailyResult, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return cliSet.HCService().TCloud.ResMetric.CollectDaily(kt, req)
+	case enumor.Aws:
+		return cliSet.HCService().Aws.ResMetric.CollectDaily(kt, req)
+	case enumor.HuaWei:
+		return cliSet.HCService().HuaWei.ResMetric.CollectDaily(kt, req)
+	case enumor.Azure:
+		return cliSet.HCService().Azure.ResMetric.CollectDaily(kt, req)
+	case enumor.Gcp:
+		return cliSet.HCService().Gcp.ResMetric.CollectDaily(kt, req)
+	default:
+		return &hcresmetric.CollectResMetricDailyResult{}, nil
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	csresmetric "hcm/pkg/api/cloud-server/res-metric"
+	"hcm/pkg/api/core"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListCvmMetric list cvm daily metric.
+func (svc *resMetricSvc) ListCvmMetric(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResMetric(cts, handler.ListResourceAuthRes, enumor.CvmCloudResType, meta.Cvm,
+		typemonitor.CvmMetrics)
+}
+
+// ListBizCvmMetric list biz cvm daily metric.
+func (svc *resMetricSvc) ListBizCvmMetric(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResMetric(cts, handler.ListBizAuthRes, enumor.CvmCloudResType, meta.Cvm, typemonitor.CvmMetrics)
+}
+
+// ListLoadBalancerMetric list load balancer daily metric.
+func (svc *resMetricSvc) ListLoadBalancerMetric(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResMetric(cts, handler.ListResourceAuthRes, enumor.LoadBalancerCloudResType, meta.LoadBalancer,
+		typemonitor.LoadBalancerMetrics)
+}
+
+// ListBizLoadBalancerMetric list biz load balancer daily metric.
+func (svc *resMetricSvc) ListBizLoadBalancerMetric(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResMetric(cts, handler.ListBizAuthRes, enumor.LoadBalancerCloudResType, meta.LoadBalancer,
+		typemonitor.LoadBalancerMetrics)
+}
+
+func (svc *resMetricSvc) listResMetric(cts *rest.Contexts, validHandler handler.ListAuthResHandler,
+	resType enumor.CloudResourceType, authResType meta.ResourceType, supported []typemonitor.MetricName) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csresmetric.ListResMetricReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, resType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	_, noPerm, err := validHandler(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: authResType, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for list res metric")
+	}
+
+	if bizID, err := cts.PathParameter("bk_biz_id").Int64(); err == nil && bizID != basicInfo.BkBizID {
+		return nil, errf.Newf(errf.InvalidParameter, "%s %s is not in biz %d", resType, id, bizID)
+	}
+
+	metrics := req.Metrics
+	if len(metrics) == 0 {
+		metrics = supported
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", resType),
+			tools.RuleEqual("res_id", id),
+			tools.RuleIn("metric", metrics),
+			// stat_date 为日期字符串，不支持范围查询，枚举查询区间内的日期
+			tools.RuleIn("stat_date", req.ListDates()),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "stat_date", Order: core.Ascending},
+	}
+	result, err := svc.client.DataService().Global.ResMetric.ListDaily(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list res metric daily failed, err: %v, res: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dsresmetric.ListResMetricDailyResult{Count: uint64(len(result.Details)), Details: result.Details}, nil
+}
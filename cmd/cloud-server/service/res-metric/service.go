@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csresmetric "hcm/pkg/api/cloud-server/res-metric"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the res metric service.
+func InitService(c *capability.Capability) {
+	svc := &resMetricSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListCvmMetric", http.MethodPost, "/cvms/{id}/metrics/list", svc.ListCvmMetric).
+		Reads(new(csresmetric.ListResMetricReq)).Writes(new(dsresmetric.ListResMetricDailyResult))
+	h.Add("ListLoadBalancerMetric", http.MethodPost, "/load_balancers/{id}/metrics/list",
+		svc.ListLoadBalancerMetric).
+		Reads(new(csresmetric.ListResMetricReq)).Writes(new(dsresmetric.ListResMetricDailyResult))
+
+	h.Add("ListBizCvmMetric", http.MethodPost, "/bizs/{bk_biz_id}/cvms/{id}/metrics/list", svc.ListBizCvmMetric).
+		Reads(new(csresmetric.ListResMetricReq)).Writes(new(dsresmetric.ListResMetricDailyResult))
+	h.Add("ListBizLoadBalancerMetric", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{id}/metrics/list",
+		svc.ListBizLoadBalancerMetric).
+		Reads(new(csresmetric.ListResMetricReq)).Writes(new(dsresmetric.ListResMetricDailyResult))
+
+	h.Load(c.WebService)
+}
+
+type resMetricSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
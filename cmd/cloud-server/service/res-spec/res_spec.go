@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resspec 声明式资源描述，计算执行计划并按审阅过的执行计划异步变更资源
+package resspec
+
+import (
+	"net/http"
+
+	logicsspec "hcm/cmd/cloud-server/logics/res-spec"
+	"hcm/cmd/cloud-server/service/capability"
+	csspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the res spec service.
+func InitService(c *capability.Capability) {
+	svc := &resSpecSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("PlanResSpec", http.MethodPost, "/res_specs/plan", svc.PlanResSpec).
+		Reads(new(csspec.PlanReq)).Writes(new(csspec.Plan))
+	h.Add("ApplyResSpec", http.MethodPost, "/res_specs/apply", svc.ApplyResSpec).
+		Reads(new(csspec.ApplyReq)).Writes(new(csspec.ApplyResult))
+
+	h.Load(c.WebService)
+}
+
+type resSpecSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// PlanResSpec plan res spec, return changes to be applied for review.
+func (svc *resSpecSvc) PlanResSpec(cts *rest.Contexts) (interface{}, error) {
+	req := new(csspec.PlanReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.plan(cts.Kit, req.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Plan, nil
+}
+
+// ApplyResSpec apply res spec asynchronously, plan is recalculated and should be the same as the reviewed one.
+func (svc *resSpecSvc) ApplyResSpec(cts *rest.Contexts) (interface{}, error) {
+	req := new(csspec.ApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.plan(cts.Kit, req.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Plan.Digest != req.PlanDigest {
+		return nil, errf.New(errf.InvalidParameter, "plan digest mismatch, resources or spec have changed since "+
+			"planned, please plan and review again")
+	}
+
+	flowReq := result.BuildFlow()
+	if flowReq == nil {
+		return nil, errf.New(errf.InvalidParameter, "plan has no change to apply")
+	}
+
+	flow, err := svc.client.TaskServer().CreateCustomFlow(cts.Kit, flowReq)
+	if err != nil {
+		logs.Errorf("create apply res spec flow failed, err: %v, digest: %s, rid: %s", err, req.PlanDigest,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	logs.Infof("apply res spec, account: %s, region: %s, digest: %s, flow: %s, summary: %+v, rid: %s",
+		result.Plan.AccountID, result.Plan.Region, req.PlanDigest, flow.ID, result.Plan.Summary, cts.Kit.Rid)
+
+	return &csspec.ApplyResult{FlowID: flow.ID, Summary: result.Plan.Summary}, nil
+}
+
+func (svc *resSpecSvc) plan(kt *kit.Kit, raw string) (*logicsspec.Result, error) {
+	spec, err := csspec.ParseSpec(raw)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	info, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(kt, enumor.AccountCloudResType,
+		spec.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, err: %v, account: %s, rid: %s", err, spec.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if info.Vendor != enumor.TCloud {
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", info.Vendor)
+	}
+
+	// 查看执行计划需要有账号的查看权限，变更的资源在计算出执行计划后按变更动作鉴权
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Find,
+		ResourceID: spec.AccountID}}
+	if err = svc.authorizer.AuthorizeWithPerm(kt, authRes); err != nil {
+		return nil, err
+	}
+
+	result, err := logicsspec.Plan(kt, svc.client.DataService(), spec)
+	if err != nil {
+		logs.Errorf("plan res spec failed, err: %v, account: %s, rid: %s", err, spec.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if err = svc.authorizeChanges(kt, spec.AccountID, result.Plan.Changes); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+var changeResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+	csspec.SecurityGroupRuleResType:  meta.SecurityGroupRule,
+	enumor.CvmCloudResType:           meta.Cvm,
+}
+
+var changeActions = map[csspec.ChangeAction]meta.Action{
+	csspec.ChangeCreate: meta.Create,
+	csspec.ChangeUpdate: meta.Update,
+	csspec.ChangeDelete: meta.Delete,
+}
+
+// authorizeChanges 按资源类型和变更动作鉴权，与资源下单独操作各资源所需的权限一致
+func (svc *resSpecSvc) authorizeChanges(kt *kit.Kit, accountID string, changes []csspec.Change) error {
+	exists := make(map[meta.Basic]struct{})
+	authRes := make([]meta.ResourceAttribute, 0)
+	for _, one := range changes {
+		action, exist := changeActions[one.Action]
+		if !exist {
+			continue
+		}
+
+		basic := meta.Basic{Type: changeResTypes[one.ResType], Action: action, ResourceID: accountID}
+		if _, exist = exists[basic]; exist {
+			continue
+		}
+		exists[basic] = struct{}{}
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: basic.Type, Action: basic.Action,
+			ResourceID: basic.ResourceID}})
+	}
+
+	if len(authRes) == 0 {
+		return nil
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
@@ -40,7 +40,8 @@ func InitResourceGroupService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListAzureResourceGroup", http.MethodPost, "/vendors/azure/resource_groups/list", svc.ListAzureResourceGroup)
+	h.Add("ListAzureResourceGroup", http.MethodPost, "/vendors/azure/resource_groups/list", svc.ListAzureResourceGroup).
+		Reads(new(cloudproto.ResourceGroupListReq)).Writes(new(dataproto.AzureRGListResult))
 
 	h.Load(c.WebService)
 }
@@ -56,19 +56,25 @@ func InitRouteTableService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetRouteTable", "GET", "/route_tables/{id}", svc.GetRouteTable)
-	h.Add("ListRouteTable", "POST", "/route_tables/list", svc.ListRouteTable)
-	h.Add("CountRouteTableSubnets", "POST", "/route_tables/subnets/count", svc.CountRouteTableSubnets)
-	h.Add("AssignRouteTableToBiz", "POST", "/route_tables/assign/bizs", svc.AssignRouteTableToBiz)
+	h.Add("ListRouteTable", "POST", "/route_tables/list", svc.ListRouteTable).
+		Reads(new(core.ListReq)).Writes(new(cloudserver.RouteTableListResult))
+	h.Add("CountRouteTableSubnets", "POST", "/route_tables/subnets/count", svc.CountRouteTableSubnets).
+		Reads(new(core.CountReq)).Writes(new([]routetable.RouteTableSubnetsCountResult))
+	h.Add("AssignRouteTableToBiz", "POST", "/route_tables/assign/bizs", svc.AssignRouteTableToBiz).
+		Reads(new(cloudserver.AssignRouteTableToBizReq))
 
-	h.Add("ListRoute", "POST", "/vendors/{vendor}/route_tables/{route_table_id}/routes/list", svc.ListRoute)
+	h.Add("ListRoute", "POST", "/vendors/{vendor}/route_tables/{route_table_id}/routes/list", svc.ListRoute).
+		Reads(new(core.ListReq))
 
 	// route table & route apis in biz
 	h.Add("GetBizRouteTable", "GET", "/bizs/{bk_biz_id}/route_tables/{id}", svc.GetBizRouteTable)
-	h.Add("ListBizRouteTable", "POST", "/bizs/{bk_biz_id}/route_tables/list", svc.ListBizRouteTable)
-	h.Add("CountBizRTSubnets", "POST", "/bizs/{bk_biz_id}/route_tables/subnets/count", svc.CountBizRTSubnets)
+	h.Add("ListBizRouteTable", "POST", "/bizs/{bk_biz_id}/route_tables/list", svc.ListBizRouteTable).
+		Reads(new(core.ListReq)).Writes(new(cloudserver.RouteTableListResult))
+	h.Add("CountBizRTSubnets", "POST", "/bizs/{bk_biz_id}/route_tables/subnets/count", svc.CountBizRTSubnets).
+		Reads(new(core.CountReq)).Writes(new([]routetable.RouteTableSubnetsCountResult))
 
 	h.Add("ListBizRoute", "POST", "/bizs/{bk_biz_id}/vendors/{vendor}/route_tables/{route_table_id}/routes/list",
-		svc.ListBizRoute)
+		svc.ListBizRoute).Reads(new(core.ListReq))
 
 	h.Load(c.WebService)
 }
@@ -25,6 +25,12 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	securitygroup "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -44,70 +50,90 @@ func InitSecurityGroupService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 资源下安全组相关接口
-	h.Add("CreateSecurityGroup", http.MethodPost, "/security_groups/create", svc.CreateSecurityGroup)
+	h.Add("CreateSecurityGroup", http.MethodPost, "/security_groups/create", svc.CreateSecurityGroup).
+		Reads(new(proto.SecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("GetSecurityGroup", http.MethodGet, "/security_groups/{id}", svc.GetSecurityGroup)
-	h.Add("BatchUpdateSecurityGroup", http.MethodPatch, "/security_groups/{id}", svc.UpdateSecurityGroup)
+	h.Add("BatchUpdateSecurityGroup", http.MethodPatch, "/security_groups/{id}", svc.UpdateSecurityGroup).
+		Reads(new(proto.SecurityGroupUpdateReq))
 	h.Add("UpdateSecurityGroupMgmtAttr", http.MethodPatch, "/security_groups/{id}/mgmt_attrs",
-		svc.UpdateSGMgmtAttr)
+		svc.UpdateSGMgmtAttr).Reads(new(proto.SecurityGroupUpdateMgmtAttrReq))
 	h.Add("BatchUpdateSGMgmtAttr", http.MethodPatch, "/security_groups/mgmt_attrs/batch",
-		svc.BatchUpdateSGMgmtAttr)
-	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup)
-	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup)
-
-	h.Add("AssociateCvm", http.MethodPost, "/security_groups/associate/cvms", svc.AssociateCvm)
-	h.Add("DisassociateCvm", http.MethodPost, "/security_groups/disassociate/cvms", svc.DisassociateCvm)
-	h.Add("AssociateSubnet", http.MethodPost, "/security_groups/associate/subnets", svc.AssociateSubnet)
-	h.Add("DisAssociateSubnet", http.MethodPost, "/security_groups/disassociate/subnets", svc.DisAssociateSubnet)
+		svc.BatchUpdateSGMgmtAttr).Reads(new(proto.BatchUpdateSecurityGroupMgmtAttrReq))
+	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup).
+		Reads(new(proto.SecurityGroupBatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup).
+		Reads(new(proto.SecurityGroupListReq))
+
+	h.Add("AssociateCvm", http.MethodPost, "/security_groups/associate/cvms", svc.AssociateCvm).
+		Reads(new(proto.SecurityGroupAssociateCvmReq))
+	h.Add("DisassociateCvm", http.MethodPost, "/security_groups/disassociate/cvms", svc.DisassociateCvm).
+		Reads(new(proto.SecurityGroupAssociateCvmReq))
+	h.Add("AssociateSubnet", http.MethodPost, "/security_groups/associate/subnets", svc.AssociateSubnet).
+		Reads(new(proto.SecurityGroupAssociateSubnetReq))
+	h.Add("DisAssociateSubnet", http.MethodPost, "/security_groups/disassociate/subnets", svc.DisAssociateSubnet).
+		Reads(new(proto.SecurityGroupAssociateSubnetReq))
 	h.Add("AssociateNetworkInterface", http.MethodPost, "/security_groups/associate/network_interfaces",
-		svc.AssociateNetworkInterface)
+		svc.AssociateNetworkInterface).Reads(new(proto.SecurityGroupAssociateNIReq))
 	h.Add("DisAssociateNetworkInterface", http.MethodPost, "/security_groups/disassociate/network_interfaces",
-		svc.DisAssociateNetworkInterface)
-	h.Add("AssignBizPreview", http.MethodPost, "/security_groups/assign/bizs/preview", svc.AssignBizPreview)
-	h.Add("BatchAssignBiz", http.MethodPost, "/security_groups/assign/bizs/batch", svc.BatchAssignBiz)
+		svc.DisAssociateNetworkInterface).Reads(new(proto.SecurityGroupAssociateNIReq))
+	h.Add("AssignBizPreview", http.MethodPost, "/security_groups/assign/bizs/preview", svc.AssignBizPreview).
+		Reads(new(proto.BatchAssignBizReq)).Writes(new([]*proto.AssignBizPreviewResp))
+	h.Add("BatchAssignBiz", http.MethodPost, "/security_groups/assign/bizs/batch", svc.BatchAssignBiz).
+		Reads(new(proto.BatchAssignBizReq))
 
 	h.Add("CreateSecurityGroupRule", http.MethodPost,
 		"/vendors/{vendor}/security_groups/{security_group_id}/rules/create", svc.CreateSecurityGroupRule)
 	h.Add("ListSecurityGroupRule", http.MethodPost,
-		"/vendors/{vendor}/security_groups/{security_group_id}/rules/list", svc.ListSecurityGroupRule)
+		"/vendors/{vendor}/security_groups/{security_group_id}/rules/list", svc.ListSecurityGroupRule).
+		Reads(new(proto.SecurityGroupRuleListReq))
 	h.Add("UpdateSecurityGroupRule", http.MethodPut,
 		"/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.UpdateSecurityGroupRule)
 	h.Add("BatchUpdateSecurityGroupRule", http.MethodPut,
-		"/vendors/{vendor}/security_groups/{security_group_id}/rules/batch/update", svc.BatchUpdateSecurityGroupRule)
+		"/vendors/{vendor}/security_groups/{security_group_id}/rules/batch/update", svc.BatchUpdateSecurityGroupRule).
+		Reads(new(proto.TCloudSGRuleBatchUpdateReq))
 	h.Add("DeleteSecurityGroupRule", http.MethodDelete,
 		"/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.DeleteSecurityGroupRule)
 	h.Add("GetAzureDefaultSGRule", http.MethodGet, "/vendors/azure/default/security_groups/rules/{type}",
-		svc.GetAzureDefaultSGRule)
+		svc.GetAzureDefaultSGRule).Writes(new([]AzureDefaultSGRule))
 
 	h.Add("ListBizSecurityGroupsByResID", http.MethodGet,
-		"/security_groups/res/{res_type}/{res_id}", svc.ListSecurityGroupsByResID)
+		"/security_groups/res/{res_type}/{res_id}", svc.ListSecurityGroupsByResID).
+		Writes(new([]cloud.SGCommonRelWithBaseSecurityGroup))
 	h.Add("ListResourceIdBySecurityGroup", http.MethodPost,
-		"/security_group/{id}/common/list", svc.ListResourceIdBySecurityGroup)
+		"/security_group/{id}/common/list", svc.ListResourceIdBySecurityGroup).
+		Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelListResult))
 
 	h.Add("QueryRelatedResourceCount", http.MethodPost,
-		"/security_groups/related_resources/query_count", svc.QueryRelatedResourceCount)
+		"/security_groups/related_resources/query_count", svc.QueryRelatedResourceCount).
+		Reads(new(proto.SecurityGroupQueryRelatedResourceCountReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 	h.Add("ListSecurityGroupRelBusiness", http.MethodPost,
-		"/security_groups/{security_group_id}/related_resources/bizs/list", svc.ListSecurityGroupRelBusiness)
+		"/security_groups/{security_group_id}/related_resources/bizs/list", svc.ListSecurityGroupRelBusiness).
+		Writes(new(proto.ListSGRelBusinessResp))
 	h.Add("ListSGRelCVMByBizID", http.MethodPost,
-		"/security_groups/{sg_id}/related_resources/biz_resources/{res_biz_id}/cvms/list", svc.ListSGRelCVMByBizID)
+		"/security_groups/{sg_id}/related_resources/biz_resources/{res_biz_id}/cvms/list", svc.ListSGRelCVMByBizID).
+		Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithCVMListResp))
 	h.Add("ListSGRelLBByBizID", http.MethodPost,
 		"/security_groups/{sg_id}/related_resources/biz_resources/{res_biz_id}/load_balancers/list",
-		svc.ListSGRelLBByBizID)
+		svc.ListSGRelLBByBizID).Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithLBListResp))
 	h.Add("CountSecurityGroupRules", http.MethodPost, "/security_groups/rules/count",
-		svc.CountSecurityGroupRules)
+		svc.CountSecurityGroupRules).Reads(new(proto.ListSecurityGroupRuleCountReq)).Writes(new(map[string]int64))
 
 	h.Add("BatchAssociateCvm", http.MethodPost,
-		"/security_groups/associate/cvms/batch", svc.BatchAssociateCvm)
+		"/security_groups/associate/cvms/batch", svc.BatchAssociateCvm).
+		Reads(new(hcproto.SecurityGroupBatchAssociateCvmReq))
 	h.Add("BatchDisassociateCvm", http.MethodPost,
-		"/security_groups/disassociate/cvms/batch", svc.BatchDisassociateCvm)
+		"/security_groups/disassociate/cvms/batch", svc.BatchDisassociateCvm).
+		Reads(new(hcproto.SecurityGroupBatchAssociateCvmReq))
 
 	h.Add("BatchListResSecurityGroups", http.MethodPost, "/security_groups/res/{res_type}/batch",
-		svc.BatchListResSecurityGroups)
+		svc.BatchListResSecurityGroups).
+		Reads(new(proto.BatchGetResRelatedSecurityGroupsReq)).Writes(new([]proto.ResSGRel))
 	h.Add("ListSGRelCVM", http.MethodPost,
 		"/security_groups/{sg_id}/related_resources/cvms/list",
-		svc.ListSGRelCVM)
+		svc.ListSGRelCVM).Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithCVMListResp))
 	h.Add("ListSGRelLB", http.MethodPost,
 		"/security_groups/{sg_id}/related_resources/load_balancers/list",
-		svc.ListSGRelLB)
+		svc.ListSGRelLB).Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithLBListResp))
 
 	bizService(h, svc)
 	initSecurityGroupServiceHooks(svc, h)
@@ -118,75 +144,87 @@ func InitSecurityGroupService(c *capability.Capability) {
 func bizService(h *rest.Handler, svc *securityGroupSvc) {
 	// 业务下安全组相关接口
 	h.Add("CreateBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/create",
-		svc.CreateBizSecurityGroup)
+		svc.CreateBizSecurityGroup).Reads(new(proto.SecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("GetBizSecurityGroup", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/{id}", svc.GetBizSecurityGroup)
 	h.Add("UpdateBizSecurityGroup", http.MethodPatch, "/bizs/{bk_biz_id}/security_groups/{id}",
-		svc.UpdateBizSecurityGroup)
+		svc.UpdateBizSecurityGroup).Reads(new(proto.SecurityGroupUpdateReq))
 	h.Add("UpdateSecurityGroupMgmtAttr", http.MethodPatch, "/bizs/{bk_biz_id}/security_groups/{id}/mgmt_attrs",
-		svc.UpdateBizSGMgmtAttr)
+		svc.UpdateBizSGMgmtAttr).Reads(new(proto.SecurityGroupUpdateMgmtAttrReq))
 	h.Add("BatchDeleteBizSecurityGroup", http.MethodDelete, "/bizs/{bk_biz_id}/security_groups/batch",
-		svc.BatchDeleteBizSecurityGroup)
-	h.Add("ListBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/list", svc.ListBizSecurityGroup)
+		svc.BatchDeleteBizSecurityGroup).Reads(new(proto.SecurityGroupBatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("ListBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/list", svc.ListBizSecurityGroup).
+		Reads(new(proto.SecurityGroupListReq))
 
-	h.Add("AssociateBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/cvms", svc.AssociateBizCvm)
+	h.Add("AssociateBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/cvms", svc.AssociateBizCvm).
+		Reads(new(proto.SecurityGroupAssociateCvmReq))
 	h.Add("DisassociateCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/cvms",
-		svc.DisassociateBizCvm)
+		svc.DisassociateBizCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
 	h.Add("AssociateBizSubnet", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/subnets",
-		svc.AssociateBizSubnet)
+		svc.AssociateBizSubnet).Reads(new(proto.SecurityGroupAssociateSubnetReq))
 	h.Add("DisAssociateBizSubnet", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/subnets",
-		svc.DisAssociateBizSubnet)
+		svc.DisAssociateBizSubnet).Reads(new(proto.SecurityGroupAssociateSubnetReq))
 	h.Add("AssociateBizNIC", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/network_interfaces",
-		svc.AssociateBizNIC)
+		svc.AssociateBizNIC).Reads(new(proto.SecurityGroupAssociateNIReq))
 	h.Add("DisAssociateBizNIC", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/network_interfaces",
-		svc.DisAssociateBizNIC)
+		svc.DisAssociateBizNIC).Reads(new(proto.SecurityGroupAssociateNIReq))
 	h.Add("ListBizSecurityGroupsByResID", http.MethodGet,
-		"/bizs/{bk_biz_id}/security_groups/res/{res_type}/{res_id}", svc.ListBizSecurityGroupsByResID)
+		"/bizs/{bk_biz_id}/security_groups/res/{res_type}/{res_id}", svc.ListBizSecurityGroupsByResID).
+		Writes(new([]cloud.SGCommonRelWithBaseSecurityGroup))
 	h.Add("AssociateBizLb", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/associate/load_balancers", svc.AssociateBizLb)
+		"/bizs/{bk_biz_id}/security_groups/associate/load_balancers", svc.AssociateBizLb).
+		Reads(new(hclb.TCloudSetLbSecurityGroupReq))
 	h.Add("DisassociateBizLb", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/load_balancers",
-		svc.DisassociateBizLb)
+		svc.DisassociateBizLb).Reads(new(hclb.TCloudDisAssociateLbSecurityGroupReq))
 
 	h.Add("CreateBizSGRule", http.MethodPost,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/create", svc.CreateBizSGRule)
 	h.Add("ListBizSGRule", http.MethodPost,
-		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/list", svc.ListBizSGRule)
+		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/list", svc.ListBizSGRule).
+		Reads(new(proto.SecurityGroupRuleListReq))
 	h.Add("UpdateBizSGRule", http.MethodPut,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.UpdateBizSGRule)
 	h.Add("BatchUpdateBizSGRule", http.MethodPut,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/batch/update",
-		svc.BatchUpdateBizSGRule)
+		svc.BatchUpdateBizSGRule).Reads(new(proto.TCloudSGRuleBatchUpdateReq))
 	h.Add("DeleteBizSGRule", http.MethodDelete,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.DeleteBizSGRule)
 
 	h.Add("ListBizResourceIDBySecurityGroup", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_group/{id}/common/list", svc.ListBizResourceIDBySecurityGroup)
+		"/bizs/{bk_biz_id}/security_group/{id}/common/list", svc.ListBizResourceIDBySecurityGroup).
+		Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelListResult))
 
 	h.Add("QueryBizRelatedResourceCount", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/related_resources/query_count", svc.QueryBizRelatedResourceCount)
+		"/bizs/{bk_biz_id}/security_groups/related_resources/query_count", svc.QueryBizRelatedResourceCount).
+		Reads(new(proto.SecurityGroupQueryRelatedResourceCountReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 	h.Add("ListBizSecurityGroupRelBusiness", http.MethodPost,
 		"/bizs/{bk_biz_id}/security_groups/{security_group_id}/related_resources/bizs/list",
-		svc.ListBizSecurityGroupRelBusiness)
+		svc.ListBizSecurityGroupRelBusiness).Writes(new(proto.ListSGRelBusinessResp))
 	h.Add("ListBizSGRelCVMByBizID", http.MethodPost,
 		"/bizs/{bk_biz_id}/security_groups/{sg_id}/related_resources/biz_resources/{res_biz_id}/cvms/list",
-		svc.ListBizSGRelCVMByBizID)
+		svc.ListBizSGRelCVMByBizID).Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithCVMListResp))
 	h.Add("ListBizSGRelLBByBizID", http.MethodPost,
 		"/bizs/{bk_biz_id}/security_groups/{sg_id}/related_resources/biz_resources/{res_biz_id}/load_balancers/list",
-		svc.ListBizSGRelLBByBizID)
+		svc.ListBizSGRelLBByBizID).Reads(new(core.ListReq)).Writes(new(dataproto.SGCommonRelWithLBListResp))
 	h.Add("CountBizSecurityGroupRules", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/rules/count",
-		svc.CountBizSecurityGroupRules)
+		svc.CountBizSecurityGroupRules).Reads(new(proto.ListSecurityGroupRuleCountReq)).Writes(new(map[string]int64))
 	h.Add("BizListSGMaintainerInfos", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/maintainers_info/list", svc.BizListSGMaintainerInfos)
+		"/bizs/{bk_biz_id}/security_groups/maintainers_info/list", svc.BizListSGMaintainerInfos).
+		Reads(new(proto.ListSGMaintainerInfoReq)).Writes(new([]*proto.ListSGMaintainerInfoResult))
 
 	h.Add("CloneBizSecurityGroup", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/{id}/clone", svc.CloneBizSecurityGroup)
+		"/bizs/{bk_biz_id}/security_groups/{id}/clone", svc.CloneBizSecurityGroup).
+		Reads(new(proto.SecurityGroupCloneReq)).Writes(new(core.CreateResult))
 
 	h.Add("BatchAssociateBizCvm", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/associate/cvms/batch", svc.BatchAssociateBizCvm)
+		"/bizs/{bk_biz_id}/security_groups/associate/cvms/batch", svc.BatchAssociateBizCvm).
+		Reads(new(hcproto.SecurityGroupBatchAssociateCvmReq))
 	h.Add("BatchDisassociateBizCvm", http.MethodPost,
-		"/bizs/{bk_biz_id}/security_groups/disassociate/cvms/batch", svc.BatchDisassociateBizCvm)
+		"/bizs/{bk_biz_id}/security_groups/disassociate/cvms/batch", svc.BatchDisassociateBizCvm).
+		Reads(new(hcproto.SecurityGroupBatchAssociateCvmReq))
 
 	h.Add("BizBatchListResSecurityGroups", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/res/{res_type}/batch",
-		svc.BizBatchListResSecurityGroups)
+		svc.BizBatchListResSecurityGroups).
+		Reads(new(proto.BatchGetResRelatedSecurityGroupsReq)).Writes(new([]proto.ResSGRel))
 }
 
 type securityGroupSvc struct {
@@ -30,13 +30,16 @@ import (
 
 	"hcm/cmd/cloud-server/logics"
 	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	accesstoken "hcm/cmd/cloud-server/service/access-token"
 	"hcm/cmd/cloud-server/service/account"
 	"hcm/cmd/cloud-server/service/admin"
+	"hcm/cmd/cloud-server/service/aggregate"
 	"hcm/cmd/cloud-server/service/application"
 	appcvm "hcm/cmd/cloud-server/service/application/handlers/cvm"
 	approvalprocess "hcm/cmd/cloud-server/service/approval_process"
 	argstpl "hcm/cmd/cloud-server/service/argument-template"
 	"hcm/cmd/cloud-server/service/assign"
+	assignrule "hcm/cmd/cloud-server/service/assign-rule"
 	asynctask "hcm/cmd/cloud-server/service/async-task"
 	"hcm/cmd/cloud-server/service/audit"
 	bandwidthpackage "hcm/cmd/cloud-server/service/bandwidth-package"
@@ -47,14 +50,22 @@ import (
 	"hcm/cmd/cloud-server/service/cos"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
+	disksnapshot "hcm/cmd/cloud-server/service/disk-snapshot"
 	"hcm/cmd/cloud-server/service/eip"
 	"hcm/cmd/cloud-server/service/firewall"
+	"hcm/cmd/cloud-server/service/idempotency"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
 	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
+	"hcm/cmd/cloud-server/service/rbac"
+	"hcm/cmd/cloud-server/service/recommendation"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
+	resevent "hcm/cmd/cloud-server/service/res-event"
+	reshistory "hcm/cmd/cloud-server/service/res-history"
+	resmetric "hcm/cmd/cloud-server/service/res-metric"
+	resspec "hcm/cmd/cloud-server/service/res-spec"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
 	routetable "hcm/cmd/cloud-server/service/route-table"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
@@ -77,6 +88,8 @@ import (
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
+	restidem "hcm/pkg/rest/idempotency"
+	"hcm/pkg/rest/ratelimit"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/bkbase"
@@ -101,6 +114,8 @@ type Service struct {
 	bkBaseCli bkbase.Client
 	cmsiCli   cmsi.Client
 	cmdbCli   cmdb.Client
+	// limiter limits the requests by user, app code and tenant, it is nil when rate limit is disabled.
+	limiter *ratelimit.Limiter
 }
 
 // NewService create a service instance.
@@ -139,6 +154,43 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
 	}
 
+	if cc.CloudServer().ResMetric.Enable {
+		interval := time.Duration(cc.CloudServer().ResMetric.CollectIntervalMin) * time.Minute
+		go resmetric.CollectResMetricDaily(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().Recommendation.Enable {
+		interval := time.Duration(cc.CloudServer().Recommendation.ScanIntervalMin) * time.Minute
+		go recommendation.ScanResRecommendation(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().DiskSnapshot.EnablePolicy {
+		go disksnapshot.ExecDiskSnapshotPolicy(sd, apiClientSet)
+	}
+
+	if conf := cc.CloudServer().Idempotency; conf.Enable {
+		rest.SetIdempotencyStore(restidem.NewStore(conf, apiClientSet.DataService().Global.Idempotency))
+		go idempotency.CleanExpiredIdempotencyRecord(sd, apiClientSet)
+	}
+
+	if conf := cc.CloudServer().RateLimit; conf.Enable {
+		if svr.limiter, err = ratelimit.New(conf); err != nil {
+			return nil, err
+		}
+	}
+
+	if cc.CloudServer().ResChangeHistory.EnableClean {
+		go reshistory.CleanExpiredResChangeHistory(cc.CloudServer().ResChangeHistory, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().AuditArchive.Enable {
+		go audit.ArchiveAuditTiming(cc.CloudServer().AuditArchive, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().AuditCheckpoint.Enable {
+		go audit.AuditCheckpointTiming(cc.CloudServer().AuditCheckpoint, sd, apiClientSet)
+	}
+
 	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle, svr.cmdbCli)
 
 	go appcvm.TimingHandleDeliverApplication(svr.client, 2*time.Second)
@@ -167,7 +219,12 @@ func getCloudClientSvr(sd serviced.ServiceDiscover) (*client.ClientSet, *Service
 		return nil, nil, err
 	}
 	apiClientSet := client.NewClientSet(restCli, sd)
-	authorizer, err := auth.NewAuthorizer(sd, tls)
+	var authorizer auth.Authorizer
+	if opt := cc.CloudServer().AuthDecisionCache; opt.Enable {
+		authorizer, err = auth.NewAuthorizerWithCache(sd, tls, opt)
+	} else {
+		authorizer, err = auth.NewAuthorizer(sd, tls)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -287,6 +344,9 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	ws := new(restful.WebService)
 	ws.Path("/api/v1/cloud")
 	ws.Produces(restful.MIME_JSON)
+	if s.limiter != nil {
+		ws.Filter(s.limiter.Filter())
+	}
 
 	c := &capability.Capability{
 		WebService: ws,
@@ -339,6 +399,20 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 
 	cos.InitService(c)
 
+	resmetric.InitService(c)
+	reshistory.InitService(c)
+	rbac.InitService(c)
+	accesstoken.InitService(c)
+	assignrule.InitService(c)
+	resevent.InitService(c)
+	resspec.InitService(c)
+
+	recommendation.InitService(c)
+
+	disksnapshot.InitService(c)
+
+	aggregate.InitService(c)
+
 	admin.InitAdminService(c)
 
 	return restful.NewContainer().Add(c.WebService)
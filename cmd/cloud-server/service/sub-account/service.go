@@ -23,6 +23,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	cssubaccount "hcm/pkg/api/cloud-server/sub-account"
+	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -39,9 +41,11 @@ func InitService(c *capability.Capability) {
 
 	// 资源下接口
 	h.Add("GetSubAccount", http.MethodGet, "/sub_accounts/{id}", svc.GetSubAccount)
-	h.Add("ListSubAccount", http.MethodPost, "/sub_accounts/list", svc.ListSubAccount)
-	h.Add("ListSubAccountExt", http.MethodPost, "/vendors/{vendor}/sub_accounts/list", svc.ListSubAccountExt)
-	h.Add("UpdateSubAccount", http.MethodPatch, "/sub_accounts/{id}", svc.UpdateSubAccount)
+	h.Add("ListSubAccount", http.MethodPost, "/sub_accounts/list", svc.ListSubAccount).Reads(new(core.ListReq))
+	h.Add("ListSubAccountExt", http.MethodPost, "/vendors/{vendor}/sub_accounts/list", svc.ListSubAccountExt).
+		Reads(new(core.ListReq))
+	h.Add("UpdateSubAccount", http.MethodPatch, "/sub_accounts/{id}", svc.UpdateSubAccount).
+		Reads(new(cssubaccount.UpdateReq))
 
 	h.Load(c.WebService)
 }
@@ -62,25 +62,35 @@ func InitSubnetService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("CreateSubnet", "POST", "/subnets/create", svc.CreateSubnet)
+	h.Add("CreateSubnet", "POST", "/subnets/create", svc.CreateSubnet).
+		Reads(new(cloudserver.RawCreateReq)).Writes(new(core.CreateResult))
 	h.Add("GetSubnet", "GET", "/subnets/{id}", svc.GetSubnet)
-	h.Add("ListSubnet", "POST", "/subnets/list", svc.ListSubnet)
-	h.Add("UpdateSubnet", "PATCH", "/subnets/{id}", svc.UpdateSubnet)
-	h.Add("BatchDeleteSubnet", "DELETE", "/subnets/batch", svc.BatchDeleteSubnet)
-	h.Add("AssignSubnetToBiz", "POST", "/subnets/assign/bizs", svc.AssignSubnetToBiz)
+	h.Add("ListSubnet", "POST", "/subnets/list", svc.ListSubnet).
+		Reads(new(core.ListReq)).Writes(new(cloudserver.SubnetListResult))
+	h.Add("UpdateSubnet", "PATCH", "/subnets/{id}", svc.UpdateSubnet).Reads(new(cloudserver.SubnetUpdateReq))
+	h.Add("BatchDeleteSubnet", "DELETE", "/subnets/batch", svc.BatchDeleteSubnet).
+		Reads(new(core.BatchDeleteReq)).Writes(new(core.CreateResult))
+	h.Add("AssignSubnetToBiz", "POST", "/subnets/assign/bizs", svc.AssignSubnetToBiz).
+		Reads(new(cloudserver.AssignSubnetToBizReq))
 	h.Add("CountSubnetAvailableIPs", "POST", "/subnets/{id}/ips/count", svc.CountSubnetAvailableIPs)
 	h.Add("ListCountResSubnetAvailIPs", "POST", "/subnets/ips/count/list",
-		svc.ListCountResSubnetAvailIPs)
+		svc.ListCountResSubnetAvailIPs).
+		Reads(new(cloudserver.ListSubnetCountIPReq)).Writes(new(map[string]cloudserver.SubnetCountIPResult))
 
 	// subnet apis in biz
-	h.Add("CreateBizSubnet", "POST", "/bizs/{bk_biz_id}/subnets/create", svc.CreateBizSubnet)
+	h.Add("CreateBizSubnet", "POST", "/bizs/{bk_biz_id}/subnets/create", svc.CreateBizSubnet).
+		Reads(new(cloudserver.RawCreateReq)).Writes(new(core.CreateResult))
 	h.Add("GetBizSubnet", "GET", "/bizs/{bk_biz_id}/subnets/{id}", svc.GetBizSubnet)
-	h.Add("ListBizSubnet", "POST", "/bizs/{bk_biz_id}/subnets/list", svc.ListBizSubnet)
-	h.Add("UpdateBizSubnet", "PATCH", "/bizs/{bk_biz_id}/subnets/{id}", svc.UpdateBizSubnet)
-	h.Add("BatchDeleteBizSubnet", "DELETE", "/bizs/{bk_biz_id}/subnets/batch", svc.BatchDeleteBizSubnet)
+	h.Add("ListBizSubnet", "POST", "/bizs/{bk_biz_id}/subnets/list", svc.ListBizSubnet).
+		Reads(new(core.ListReq)).Writes(new(cloudserver.SubnetListResult))
+	h.Add("UpdateBizSubnet", "PATCH", "/bizs/{bk_biz_id}/subnets/{id}", svc.UpdateBizSubnet).
+		Reads(new(cloudserver.SubnetUpdateReq))
+	h.Add("BatchDeleteBizSubnet", "DELETE", "/bizs/{bk_biz_id}/subnets/batch", svc.BatchDeleteBizSubnet).
+		Reads(new(core.BatchDeleteReq)).Writes(new(core.CreateResult))
 	h.Add("CountBizSubnetAvailIPs", "POST", "/bizs/{bk_biz_id}/subnets/{id}/ips/count", svc.CountBizSubnetAvailIPs)
 	h.Add("ListCountBizSubnetAvailIPs", "POST", "/bizs/{bk_biz_id}/subnets/ips/count/list",
-		svc.ListCountBizSubnetAvailIPs)
+		svc.ListCountBizSubnetAvailIPs).
+		Reads(new(cloudserver.ListSubnetCountIPReq)).Writes(new(map[string]cloudserver.SubnetCountIPResult))
 
 	h.Load(c.WebService)
 }
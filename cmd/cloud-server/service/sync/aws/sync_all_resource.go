@@ -111,6 +111,7 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 
 var syncOrder = []enumor.CloudResourceType{
 	enumor.DiskCloudResType,
+	enumor.DiskSnapshotCloudResType,
 	enumor.VpcCloudResType,
 	enumor.SubnetCloudResType,
 	enumor.EipCloudResType,
@@ -121,6 +122,7 @@ var syncOrder = []enumor.CloudResourceType{
 }
 var syncFuncMap = map[enumor.CloudResourceType]ResSyncFunc{
 	enumor.DiskCloudResType:                SyncDisk,
+	enumor.DiskSnapshotCloudResType:        SyncDiskSnapshot,
 	enumor.VpcCloudResType:                 SyncVpc,
 	enumor.SubnetCloudResType:              SyncSubnet,
 	enumor.EipCloudResType:                 SyncEip,
@@ -100,6 +100,7 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 
 var syncOrder = []enumor.CloudResourceType{
 	enumor.DiskCloudResType,
+	enumor.DiskSnapshotCloudResType,
 	enumor.VpcCloudResType,
 	enumor.SubnetCloudResType,
 	enumor.EipCloudResType,
@@ -111,6 +112,7 @@ var syncOrder = []enumor.CloudResourceType{
 }
 var syncFuncMap = map[enumor.CloudResourceType]ResSyncFunc{
 	enumor.DiskCloudResType:                SyncDisk,
+	enumor.DiskSnapshotCloudResType:        SyncDiskSnapshot,
 	enumor.VpcCloudResType:                 SyncVpc,
 	enumor.SubnetCloudResType:              SyncSubnet,
 	enumor.EipCloudResType:                 SyncEip,
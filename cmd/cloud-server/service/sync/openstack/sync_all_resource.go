@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack ...
+package openstack
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/detail"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SyncAllResourceOption ...
+type SyncAllResourceOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// ResSyncFunc 资源同步函数，openstack 账号只纳管一个区域，由 hc-service 根据账号确定
+type ResSyncFunc func(kt *kit.Kit, cliSet *client.ClientSet, accountID string, sd *detail.SyncDetail) error
+
+// Validate SyncAllResourceOption
+func (opt *SyncAllResourceOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SyncAllResource sync resource.
+func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
+	opt *SyncAllResourceOption) (enumor.CloudResourceType, error) {
+
+	if err := opt.Validate(); err != nil {
+		return "", err
+	}
+	start := time.Now()
+	logs.V(3).Infof("openstack account[%s] sync all resource start, time: %v, opt: %v, rid: %s", opt.AccountID,
+		start, opt, kt.Rid)
+	var hitErr error
+	defer func() {
+		if hitErr != nil {
+			logs.Errorf("%s: sync all resource failed, err: %v, account: %s, rid: %s", constant.AccountSyncFailed,
+				hitErr, opt.AccountID, kt.Rid)
+			return
+		}
+		logs.V(3).Infof("openstack account[%s] sync all resource end, cost: %v, opt: %v, rid: %s", opt.AccountID,
+			time.Since(start), opt, kt.Rid)
+	}()
+
+	sd := &detail.SyncDetail{
+		Kt:        kt,
+		DataCli:   cliSet.DataService(),
+		AccountID: opt.AccountID,
+		Vendor:    string(enumor.OpenStack),
+	}
+
+	syncFuncMap := map[enumor.CloudResourceType]ResSyncFunc{
+		enumor.SubAccountCloudResType:    SyncSubAccount,
+		enumor.VpcCloudResType:           SyncVpc,
+		enumor.SubnetCloudResType:        SyncSubnet,
+		enumor.SecurityGroupCloudResType: SyncSG,
+		enumor.DiskCloudResType:          SyncDisk,
+		enumor.EipCloudResType:           SyncEip,
+		enumor.CvmCloudResType:           SyncCvm,
+	}
+
+	for _, resType := range getSyncOrder() {
+		if hitErr = syncFuncMap[resType](kt, cliSet, opt.AccountID, sd); hitErr != nil {
+			return resType, hitErr
+		}
+	}
+
+	return "", nil
+}
+
+// getSyncOrder 主机依赖网络、子网，需要在其之后同步
+func getSyncOrder() []enumor.CloudResourceType {
+	return []enumor.CloudResourceType{
+		enumor.SubAccountCloudResType,
+		enumor.VpcCloudResType,
+		enumor.SubnetCloudResType,
+		enumor.SecurityGroupCloudResType,
+		enumor.DiskCloudResType,
+		enumor.EipCloudResType,
+		enumor.CvmCloudResType,
+	}
+}
+
+// syncResource 同步单类资源的通用流程，记录同步状态及耗时，具体同步由 hc-service 对应接口完成
+func syncResource(kt *kit.Kit, accountID string, sd *detail.SyncDetail, resType enumor.CloudResourceType,
+	syncFunc func(kt *kit.Kit, req *sync.OpenStackSyncReq) error) error {
+
+	// 重新设置rid方便定位
+	kt = kt.NewSubKit()
+
+	start := time.Now()
+	logs.V(3).Infof("openstack account[%s] sync %s start, time: %v, rid: %s", accountID, resType, start, kt.Rid)
+
+	// 同步中
+	if err := sd.ResSyncStatusSyncing(resType); err != nil {
+		return err
+	}
+
+	defer func() {
+		logs.V(3).Infof("openstack account[%s] sync %s end, cost: %v, rid: %s", accountID, resType,
+			time.Since(start), kt.Rid)
+	}()
+
+	req := &sync.OpenStackSyncReq{
+		AccountID: accountID,
+	}
+	if err := syncFunc(kt, req); err != nil {
+		logs.Errorf("sync openstack %s failed, err: %v, req: %v, rid: %s", resType, err, req, kt.Rid)
+		return err
+	}
+
+	// 同步成功
+	if err := sd.ResSyncStatusSuccess(resType); err != nil {
+		return err
+	}
+
+	return nil
+}
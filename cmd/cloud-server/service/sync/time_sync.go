@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/logics/account"
+	assignrule "hcm/cmd/cloud-server/logics/assign-rule"
 	"hcm/cmd/cloud-server/service/sync/detail"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
@@ -146,6 +147,8 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 				Vendor:    string(acc.Vendor),
 			}
 			resName, err := syncer.SyncAllResource(kt, cliSet, acc.ID, syncPublicResource)
+			// 同步失败前已同步的资源也需要按规则分配
+			assignrule.ApplyAfterSync(kt, cliSet.DataService(), acc.ID)
 			if err != nil {
 				if resName != "" {
 					if err := sd.ResSyncStatusFailed(resName, err); err != nil {
@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	cloudtask "hcm/pkg/api/cloud-server/task"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/task"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -38,15 +41,16 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListBizTaskManagement", http.MethodPost, "/bizs/{bk_biz_id}/task_managements/list",
-		svc.ListBizTaskManagement)
+		svc.ListBizTaskManagement).Reads(new(core.ListReq))
 	h.Add("CancelBizTaskManagement", http.MethodPost, "/bizs/{bk_biz_id}/task_managements/cancel",
-		svc.CancelBizTaskManagement)
+		svc.CancelBizTaskManagement).Reads(new(task.CancelReq))
 	h.Add("ListBizTaskManagementState", http.MethodPost, "/bizs/{bk_biz_id}/task_managements/state/list",
-		svc.ListBizTaskManagementState)
+		svc.ListBizTaskManagementState).Reads(new(cloudtask.ManagementListStateReq))
 
-	h.Add("ListBizTaskDetail", http.MethodPost, "/bizs/{bk_biz_id}/task_details/list", svc.ListBizTaskDetail)
+	h.Add("ListBizTaskDetail", http.MethodPost, "/bizs/{bk_biz_id}/task_details/list", svc.ListBizTaskDetail).
+		Reads(new(core.ListReq))
 	h.Add("CountBizTaskDetailState", http.MethodPost, "/bizs/{bk_biz_id}/task_details/state/count",
-		svc.CountBizTaskDetailState)
+		svc.CountBizTaskDetailState).Reads(new(cloudtask.DetailStateCountReq))
 
 	h.Load(c.WebService)
 }
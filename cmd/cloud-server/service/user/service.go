@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/service/capability"
+	csuser "hcm/pkg/api/cloud-server/user"
+	"hcm/pkg/api/core"
+	coreuser "hcm/pkg/api/core/user"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
@@ -38,13 +41,18 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 业务收藏
-	h.Add("CreateBizCollection", http.MethodPost, "/bizs/{bk_biz_id}/collections/bizs/create", svc.CreateBizCollection)
-	h.Add("DeleteBizCollection", http.MethodDelete, "/bizs/{bk_biz_id}/collections/bizs", svc.DeleteBizCollection)
-	h.Add("GetBizCollection", http.MethodGet, "/bizs/{bk_biz_id}/collections/bizs", svc.GetBizCollection)
-
-	h.Add("CreateCollection", http.MethodPost, "/collections/create", svc.CreateCollection)
+	h.Add("CreateBizCollection", http.MethodPost, "/bizs/{bk_biz_id}/collections/bizs/create", svc.CreateBizCollection).
+		Reads(new(csuser.BizCollectionReq))
+	h.Add("DeleteBizCollection", http.MethodDelete, "/bizs/{bk_biz_id}/collections/bizs", svc.DeleteBizCollection).
+		Reads(new(csuser.BizCollectionReq))
+	h.Add("GetBizCollection", http.MethodGet, "/bizs/{bk_biz_id}/collections/bizs", svc.GetBizCollection).
+		Writes(new([]int64))
+
+	h.Add("CreateCollection", http.MethodPost, "/collections/create", svc.CreateCollection).
+		Reads(new(csuser.CreateCollectionReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteCollection", http.MethodDelete, "/collections/{id}", svc.DeleteCollection)
-	h.Add("ListResourceCollection", http.MethodGet, "/collections/{res_type}/list", svc.ListResourceCollection)
+	h.Add("ListResourceCollection", http.MethodGet, "/collections/{res_type}/list", svc.ListResourceCollection).
+		Writes(new([]coreuser.UserCollection))
 
 	h.Load(c.WebService)
 }
@@ -58,18 +58,21 @@ func InitVpcService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetVpc", "GET", "/vpcs/{id}", svc.GetVpc)
-	h.Add("ListVpc", "POST", "/vpcs/list", svc.ListVpc)
-	h.Add("CreateVpc", "POST", "/vpcs/create", svc.CreateVpc)
-	h.Add("UpdateVpc", "PATCH", "/vpcs/{id}", svc.UpdateVpc)
+	h.Add("ListVpc", "POST", "/vpcs/list", svc.ListVpc).Reads(new(core.ListReq)).Writes(new(csvpc.VpcListResult))
+	h.Add("CreateVpc", "POST", "/vpcs/create", svc.CreateVpc).
+		Reads(new(cloudserver.ResourceCreateReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateVpc", "PATCH", "/vpcs/{id}", svc.UpdateVpc).Reads(new(csvpc.VpcUpdateReq))
 	h.Add("DeleteVpc", "DELETE", "/vpcs/{id}", svc.DeleteVpc)
-	h.Add("AssignVpcToBiz", "POST", "/vpcs/assign/bizs", svc.AssignVpcToBiz)
-	h.Add("ListResVpcExt", "POST", "/vendors/{vendor}/vpcs/list", svc.ListResVpcExt)
+	h.Add("AssignVpcToBiz", "POST", "/vpcs/assign/bizs", svc.AssignVpcToBiz).Reads(new(csvpc.AssignVpcToBizReq))
+	h.Add("ListResVpcExt", "POST", "/vendors/{vendor}/vpcs/list", svc.ListResVpcExt).Reads(new(core.ListReq))
 
 	// vpc apis in biz
 	h.Add("GetBizVpc", "GET", "/bizs/{bk_biz_id}/vpcs/{id}", svc.GetBizVpc)
-	h.Add("ListBizVpc", "POST", "/bizs/{bk_biz_id}/vpcs/list", svc.ListBizVpc)
-	h.Add("ListBizVpcExt", "POST", "/bizs/{bk_biz_id}/vendors/{vendor}/vpcs/list", svc.ListBizVpcExt)
-	h.Add("UpdateBizVpc", "PATCH", "/bizs/{bk_biz_id}/vpcs/{id}", svc.UpdateBizVpc)
+	h.Add("ListBizVpc", "POST", "/bizs/{bk_biz_id}/vpcs/list", svc.ListBizVpc).
+		Reads(new(core.ListReq)).Writes(new(csvpc.VpcListResult))
+	h.Add("ListBizVpcExt", "POST", "/bizs/{bk_biz_id}/vendors/{vendor}/vpcs/list", svc.ListBizVpcExt).
+		Reads(new(core.ListReq))
+	h.Add("UpdateBizVpc", "PATCH", "/bizs/{bk_biz_id}/vpcs/{id}", svc.UpdateBizVpc).Reads(new(csvpc.VpcUpdateReq))
 	h.Add("DeleteBizVpc", "DELETE", "/bizs/{bk_biz_id}/vpcs/{id}", svc.DeleteBizVpc)
 
 	h.Load(c.WebService)
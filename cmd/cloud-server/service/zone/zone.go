@@ -64,7 +64,8 @@ func InitZoneService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListZone", http.MethodPost, "/vendors/{vendor}/regions/{region}/zones/list", svc.ListZone)
+	h.Add("ListZone", http.MethodPost, "/vendors/{vendor}/regions/{region}/zones/list", svc.ListZone).
+		Reads(new(cloudproto.ZoneListReq)).Writes(new(dataproto.ZoneListResult))
 
 	h.Load(c.WebService)
 }
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/runtime/ctl"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 )
@@ -75,6 +76,11 @@ func (ds *dataService) prepare(opt *options.Option) error {
 	network := cc.DataService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// refuse to start against an unmigrated db schema.
+	if err := checkSchema(); err != nil {
+		return err
+	}
+
 	svc, err := service.NewService()
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
@@ -91,10 +97,18 @@ func (ds *dataService) prepare(opt *options.Option) error {
 	ds.sd = sd
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	cmds := append(ctl.WithBasics(sd), cmd.WithVerifyAuditChain(svc.VerifyAuditChain))
+	cmds = append(cmds, cmd.WithAuditOutbox(svc.AuditOutboxStats, svc.RetryFailedAuditOutbox)...)
+	if err := ctl.LoadCtl(cmds...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
+	// stream audits to external SIEM.
+	svc.StreamAudit(sd)
+
+	// deliver res change events to the subscriptions.
+	svc.DispatchResEvent(sd)
+
 	return nil
 }
 
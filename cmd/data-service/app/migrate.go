@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"hcm/cmd/data-service/options"
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/migration"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Migrate run the db schema migration sub command.
+func Migrate(opt *options.MigrateOption) error {
+	if err := cc.LoadSettings(opt.Sys); err != nil {
+		return fmt.Errorf("load settings from config files failed, err: %v", err)
+	}
+
+	logCfg := cc.DataService().Log.Logs()
+	logCfg.AlsoToStdErr = true
+	logs.InitLogger(logCfg)
+	defer logs.CloseLogs()
+
+	migrator, closeDB, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	kt := kit.New()
+	switch opt.Action {
+	case "status":
+		list, err := migrator.Status(kt)
+		if err != nil {
+			return err
+		}
+		printStatus(list)
+
+	case "up":
+		applied, err := migrator.Up(kt, opt.Target, opt.DryRun)
+		printMigrations(applied, opt.DryRun, "applied")
+		if err != nil {
+			return err
+		}
+
+	case "baseline":
+		marked, err := migrator.Baseline(kt, opt.Target, opt.DryRun)
+		printMigrations(marked, opt.DryRun, "marked as applied")
+		if err != nil {
+			return err
+		}
+
+	case "repair":
+		if len(opt.Target) == 0 {
+			return fmt.Errorf("target version of the dirty migration is required")
+		}
+
+		if err = migrator.Repair(kt, opt.Target, opt.Retry); err != nil {
+			return err
+		}
+
+		if opt.Retry {
+			fmt.Printf("dirty migration %s is removed, run up action to execute it again\n", opt.Target)
+		} else {
+			fmt.Printf("dirty migration %s is marked as applied\n", opt.Target)
+		}
+
+	default:
+		return fmt.Errorf("unsupported migrate action: %s, supports: status, up, baseline, repair", opt.Action)
+	}
+
+	return nil
+}
+
+// checkSchema refuses to start data service if the db schema is not migrated to the version of this release.
+func checkSchema() error {
+	migrator, closeDB, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if err = migrator.Check(kit.New()); err != nil {
+		return fmt.Errorf("%v, please run '%s migrate up' first", err, os.Args[0])
+	}
+
+	return nil
+}
+
+func newMigrator() (*migration.Migrator, func(), error) {
+	migrations, err := migration.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := migration.Connect(cc.DataService().Database.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeDB := func() {
+		if err := db.Close(); err != nil {
+			logs.Errorf("close migration db failed, err: %v", err)
+		}
+	}
+
+	return migration.NewMigrator(db, migrations), closeDB, nil
+}
+
+func printStatus(list []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tKIND\tSTATE\tAPPLIED_AT\tBASELINE")
+	for _, one := range list {
+		appliedAt, baseline := "-", "-"
+		if one.Record != nil {
+			appliedAt = one.Record.AppliedAt.Format("2006-01-02 15:04:05")
+			baseline = fmt.Sprintf("%t", one.Record.Baseline)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", one.Version, one.Kind, one.State, appliedAt, baseline)
+	}
+	w.Flush()
+}
+
+func printMigrations(list []*migration.Migration, dryRun bool, action string) {
+	if dryRun {
+		action = "to be " + action
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("no migration %s\n", action)
+		return
+	}
+
+	fmt.Printf("%d migrations %s:\n", len(list), action)
+	for _, one := range list {
+		fmt.Printf("  [%s] %s\n", one.Kind, one.Version)
+	}
+}
@@ -32,6 +32,11 @@ import (
 func main() {
 	cc.InitService(cc.DataServiceName)
 
+	if options.IsMigrateCmd() {
+		migrate()
+		return
+	}
+
 	opts := options.InitOptions()
 	if err := app.Run(opts); err != nil {
 		fmt.Fprintf(os.Stderr, "start data service failed, err: %v", err)
@@ -39,3 +44,17 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// migrate run db schema migration sub command.
+func migrate() {
+	opts, err := options.InitMigrateOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "parse migrate options failed, err: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err = app.Migrate(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate failed, err: %v\n", err)
+		os.Exit(1)
+	}
+}
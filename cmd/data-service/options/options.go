@@ -21,6 +21,9 @@
 package options
 
 import (
+	"fmt"
+	"os"
+
 	"hcm/pkg/cc"
 	"hcm/pkg/runtime/flags"
 
@@ -47,3 +50,54 @@ func InitOptions() *Option {
 
 	return opt
 }
+
+// MigrateCmd is the sub command name of db schema migration.
+const MigrateCmd = "migrate"
+
+// MigrateOption defines the migrate sub command's flag options.
+type MigrateOption struct {
+	Sys *cc.SysOption
+	// Action is the migrate action, supports status, up, baseline, repair.
+	Action string
+	// Target is the target migration version of up action, the sql version of baseline action, or the dirty
+	// migration version of repair action.
+	Target string
+	// DryRun only prints the migrations to be applied.
+	DryRun bool
+	// Retry is used by repair action, removes the dirty record to execute the migration again by up action,
+	// otherwise the dirty migration is marked as applied.
+	Retry bool
+}
+
+// IsMigrateCmd check if the command line is the migrate sub command.
+func IsMigrateCmd() bool {
+	return len(os.Args) > 1 && os.Args[1] == MigrateCmd
+}
+
+// InitMigrateOptions init migrate sub command's options from command flags.
+// usage: bk-hcm-dataservice migrate status|up|baseline|repair --config-file=xxx [--target=xxx] [--dry-run]
+// [--retry]
+func InitMigrateOptions() (*MigrateOption, error) {
+	fs := pflag.NewFlagSet(MigrateCmd, pflag.ExitOnError)
+	sysOpt := flags.SysFlags(fs)
+	opt := &MigrateOption{Sys: sysOpt}
+
+	fs.StringVarP(&opt.Target, "target", "t", "",
+		"up: migrate until the target version(inclusive), default is latest. "+
+			"baseline: mark sql files whose SQLVER <= target as applied, default is sql_ver of hcm_version. "+
+			"repair: the dirty migration version, required")
+	fs.BoolVar(&opt.DryRun, "dry-run", false, "only print the migrations to be applied")
+	fs.BoolVar(&opt.Retry, "retry", false, "repair: remove the dirty record, so that up executes the migration "+
+		"again, default marks the dirty migration as applied")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() != 1 {
+		return nil, fmt.Errorf("migrate action is required, supports: status, up, baseline, repair")
+	}
+	opt.Action = fs.Arg(0)
+
+	return opt, nil
+}
@@ -0,0 +1,257 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accesstoken
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletoken "hcm/pkg/dal/table/access-token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateAccessToken batch create access tokens, the owner service accounts of the tokens should exist.
+func (svc *service) BatchCreateAccessToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchCreateAccessTokenReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accounts := make([]string, 0)
+	models := make([]tabletoken.AccessTokenTable, len(req.Tokens))
+	for idx, one := range req.Tokens {
+		if one.OwnerType == coretoken.ServiceAccountOwner {
+			accounts = append(accounts, one.Owner)
+		}
+
+		// validated before, the format is right
+		expiresAt, _ := time.Parse(constant.TimeStdFormat, one.ExpiresAt)
+		scope := tabletoken.Scope(one.Scope)
+		models[idx] = tabletoken.AccessTokenTable{
+			Name:        one.Name,
+			OwnerType:   one.OwnerType,
+			Owner:       one.Owner,
+			TokenHash:   one.TokenHash,
+			TokenPrefix: one.TokenPrefix,
+			Scope:       &scope,
+			ExpiresAt:   expiresAt,
+			Revoked:     converter.ValToPtr(false),
+			Memo:        one.Memo,
+			Creator:     cts.Kit.User,
+			Reviser:     cts.Kit.User,
+		}
+	}
+
+	if err := svc.checkServiceAccountExist(cts, slice.Unique(accounts)); err != nil {
+		return nil, err
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.AccessToken().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create access token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+func (svc *service) checkServiceAccountExist(cts *rest.Contexts, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: tools.ContainersExpression("name", names),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, names: %v, rid: %s", err, names, cts.Kit.Rid)
+		return err
+	}
+
+	if len(result.Details) != len(names) {
+		return errf.Newf(errf.RecordNotFound, "some service accounts of %v are not found", names)
+	}
+
+	return nil
+}
+
+// BatchUpdateAccessToken batch update access tokens.
+func (svc *service) BatchUpdateAccessToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchUpdateAccessTokenReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Tokens {
+			model := &tabletoken.AccessTokenTable{
+				Revoked: one.Revoked,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+			if err := svc.dao.AccessToken().UpdateByIDWithTx(cts.Kit, txn, one.ID, model); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update access token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateLastUsed batch update the last used time of access tokens, it is reported by api-server.
+func (svc *service) BatchUpdateLastUsed(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchUpdateLastUsedReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range req.Tokens {
+		// validated before, the format is right
+		lastUsedAt, _ := time.Parse(constant.TimeStdFormat, one.LastUsedAt)
+		if err := svc.dao.AccessToken().UpdateLastUsedAt(cts.Kit, one.ID, lastUsedAt); err != nil {
+			logs.Errorf("update access token last used time failed, err: %v, id: %s, rid: %s", err, one.ID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ListAccessToken list access tokens.
+func (svc *service) ListAccessToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.AccessToken().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list access token failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dstoken.ListAccessTokenResult{Count: result.Count}, nil
+	}
+
+	details := make([]coretoken.AccessToken, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = convAccessToken(one)
+	}
+
+	return &dstoken.ListAccessTokenResult{Details: details}, nil
+}
+
+// convAccessToken convert access token table to core access token, the token hash is never returned.
+func convAccessToken(one tabletoken.AccessTokenTable) coretoken.AccessToken {
+	token := coretoken.AccessToken{
+		ID:          one.ID,
+		Name:        one.Name,
+		OwnerType:   one.OwnerType,
+		Owner:       one.Owner,
+		TokenPrefix: one.TokenPrefix,
+		Revoked:     converter.PtrToVal(one.Revoked),
+		Memo:        one.Memo,
+		Creator:     one.Creator,
+		Reviser:     one.Reviser,
+		CreatedAt:   one.CreatedAt.String(),
+		UpdatedAt:   one.UpdatedAt.String(),
+	}
+
+	if one.Scope != nil {
+		token.Scope = coretoken.Scope(*one.Scope)
+	}
+
+	if !one.ExpiresAt.IsZero() {
+		token.ExpiresAt = one.ExpiresAt.In(time.Local).Format(constant.TimeStdFormat)
+	}
+
+	if one.LastUsedAt != nil && len(*one.LastUsedAt) != 0 {
+		token.LastUsedAt = converter.ValToPtr(one.LastUsedAt.String())
+	}
+
+	return token
+}
+
+// BatchDeleteAccessToken batch delete access tokens.
+func (svc *service) BatchDeleteAccessToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.AccessToken().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete access token failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
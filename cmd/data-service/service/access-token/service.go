@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken 服务账号及访问令牌
+package accesstoken
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the access token service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateServiceAccount", http.MethodPost, "/service_accounts/batch/create",
+		svc.BatchCreateServiceAccount).
+		Reads(new(dstoken.BatchCreateServiceAccountReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateServiceAccount", http.MethodPatch, "/service_accounts/batch", svc.BatchUpdateServiceAccount).
+		Reads(new(dstoken.BatchUpdateServiceAccountReq))
+	h.Add("ListServiceAccount", http.MethodPost, "/service_accounts/list", svc.ListServiceAccount).
+		Reads(new(core.ListReq)).Writes(new(dstoken.ListServiceAccountResult))
+	h.Add("BatchDeleteServiceAccount", http.MethodDelete, "/service_accounts/batch", svc.BatchDeleteServiceAccount).
+		Reads(new(dstoken.BatchDeleteReq))
+
+	h.Add("BatchCreateAccessToken", http.MethodPost, "/access_tokens/batch/create", svc.BatchCreateAccessToken).
+		Reads(new(dstoken.BatchCreateAccessTokenReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateAccessToken", http.MethodPatch, "/access_tokens/batch", svc.BatchUpdateAccessToken).
+		Reads(new(dstoken.BatchUpdateAccessTokenReq))
+	h.Add("ListAccessToken", http.MethodPost, "/access_tokens/list", svc.ListAccessToken).
+		Reads(new(core.ListReq)).Writes(new(dstoken.ListAccessTokenResult))
+	h.Add("BatchUpdateAccessTokenLastUsed", http.MethodPatch, "/access_tokens/last_used/batch",
+		svc.BatchUpdateLastUsed).Reads(new(dstoken.BatchUpdateLastUsedReq))
+	h.Add("BatchDeleteAccessToken", http.MethodDelete, "/access_tokens/batch", svc.BatchDeleteAccessToken).
+		Reads(new(dstoken.BatchDeleteReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
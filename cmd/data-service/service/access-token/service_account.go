@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accesstoken
+
+import (
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletoken "hcm/pkg/dal/table/access-token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateServiceAccount batch create service accounts.
+func (svc *service) BatchCreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchCreateServiceAccountReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tabletoken.ServiceAccountTable, len(req.ServiceAccounts))
+	for idx, one := range req.ServiceAccounts {
+		models[idx] = tabletoken.ServiceAccountTable{
+			Name:    one.Name,
+			Memo:    one.Memo,
+			Creator: cts.Kit.User,
+			Reviser: cts.Kit.User,
+		}
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.ServiceAccount().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+// BatchUpdateServiceAccount batch update service accounts.
+func (svc *service) BatchUpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchUpdateServiceAccountReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.ServiceAccounts {
+			model := &tabletoken.ServiceAccountTable{
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+			if err := svc.dao.ServiceAccount().UpdateByIDWithTx(cts.Kit, txn, one.ID, model); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service accounts.
+func (svc *service) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dstoken.ListServiceAccountResult{Count: result.Count}, nil
+	}
+
+	details := make([]coretoken.ServiceAccount, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = coretoken.ServiceAccount{
+			ID:        one.ID,
+			Name:      one.Name,
+			Memo:      one.Memo,
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		}
+	}
+
+	return &dstoken.ListServiceAccountResult{Details: details}, nil
+}
+
+// BatchDeleteServiceAccount batch delete service accounts, the access tokens of the service accounts are deleted
+// together, so that they can not be used anymore.
+func (svc *service) BatchDeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(dstoken.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id", "name"},
+		Filter: tools.ContainersExpression("id", req.IDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	accounts, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(accounts.Details) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(accounts.Details))
+	for idx, one := range accounts.Details {
+		names[idx] = one.Name
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		tokenExpr := tools.ExpressionAnd(
+			tools.RuleEqual("owner_type", coretoken.ServiceAccountOwner),
+			tools.RuleIn("owner", names),
+		)
+		if err := svc.dao.AccessToken().DeleteWithTx(cts.Kit, txn, tokenExpr); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.dao.ServiceAccount().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete service account failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/account-set"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
@@ -40,10 +42,14 @@ func InitService(cap *capability.Capability) {
 
 	h.Add("CreateMainAccount", http.MethodPost, "/vendors/{vendor}/main_accounts/create", svc.CreateMainAccount)
 	h.Add("GetMainAccount", http.MethodGet, "/vendors/{vendor}/main_accounts/{account_id}", svc.GetMainAccount)
-	h.Add("UpdateMainAccount", http.MethodPatch, "/main_accounts/{account_id}", svc.UpdateMainAccount)
-	h.Add("ListMainAccount", http.MethodPost, "/main_accounts/list", svc.ListMainAccount)
-
-	h.Add("GetMainAccountBasicInfo", http.MethodGet, "/main_accounts/basic_info/{account_id}", svc.GetMainAccountBasicInfo)
+	h.Add("UpdateMainAccount", http.MethodPatch, "/main_accounts/{account_id}", svc.UpdateMainAccount).
+		Reads(new(dataproto.MainAccountUpdateReq))
+	h.Add("ListMainAccount", http.MethodPost, "/main_accounts/list", svc.ListMainAccount).
+		Reads(new(core.ListReq)).Writes(new(dataproto.MainAccountListResult))
+
+	h.Add("GetMainAccountBasicInfo", http.MethodGet,
+		"/main_accounts/basic_info/{account_id}", svc.GetMainAccountBasicInfo).
+		Writes(new(dataproto.MainAccountGetBaseResult))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/account-set"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
@@ -41,9 +43,12 @@ func InitService(cap *capability.Capability) {
 	h.Add("CreateRootAccount", http.MethodPost, "/vendors/{vendor}/root_accounts/create", svc.CreateRootAccount)
 	h.Add("GetRootAccount", http.MethodGet, "/vendors/{vendor}/root_accounts/{account_id}", svc.GetRootAccount)
 	h.Add("UpdateRootAccount", http.MethodPatch, "/vendors/{vendor}/root_accounts/{account_id}", svc.UpdateRootAccount)
-	h.Add("ListRootAccount", http.MethodPost, "/root_accounts/list", svc.ListRootAccount)
+	h.Add("ListRootAccount", http.MethodPost, "/root_accounts/list", svc.ListRootAccount).
+		Reads(new(core.ListWithoutFieldReq)).Writes(new(dataproto.RootAccountListResult))
 
-	h.Add("GetRootAccountBasicInfo", http.MethodGet, "/root_accounts/basic_info/{account_id}", svc.GetRootAccountBasicInfo)
+	h.Add("GetRootAccountBasicInfo", http.MethodGet,
+		"/root_accounts/basic_info/{account_id}", svc.GetRootAccountBasicInfo).
+		Writes(new(dataproto.RootAccountGetBaseResult))
 
 	h.Load(cap.WebService)
 }
@@ -48,10 +48,14 @@ func InitApplicationService(cap *capability.Capability) {
 	}
 	h := rest.NewHandler()
 
-	h.Add("CreateApplication", "POST", "/applications/create", svc.CreateApplication)
-	h.Add("UpdateApplication", "PATCH", "/applications/{application_id}", svc.UpdateApplication)
-	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication)
-	h.Add("ListApplication", "POST", "/applications/list", svc.ListApplication)
+	h.Add("CreateApplication", "POST", "/applications/create", svc.CreateApplication).
+		Reads(new(proto.ApplicationCreateReq)).Writes(new(core.CreateResult))
+	h.Add("UpdateApplication", "PATCH", "/applications/{application_id}", svc.UpdateApplication).
+		Reads(new(proto.ApplicationUpdateReq))
+	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication).
+		Writes(new(proto.ApplicationResp))
+	h.Add("ListApplication", "POST", "/applications/list", svc.ListApplication).
+		Reads(new(proto.ApplicationListReq)).Writes(new(proto.ApplicationListResult))
 
 	h.Load(cap.WebService)
 }
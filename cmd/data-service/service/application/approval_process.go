@@ -46,10 +46,12 @@ func InitApprovalProcessService(cap *capability.Capability) {
 	}
 	h := rest.NewHandler()
 
-	h.Add("CreateApprovalProcesses", "POST", "/approval_processes/create", svc.CreateApprovalProcesses)
+	h.Add("CreateApprovalProcesses", "POST", "/approval_processes/create", svc.CreateApprovalProcesses).
+		Reads(new(proto.ApprovalProcessCreateReq)).Writes(new(core.CreateResult))
 	h.Add("UpdateApprovalProcesses", "PATCH", "/approval_processes/{approval_process_id}",
-		svc.UpdateApprovalProcesses)
-	h.Add("ListApprovalProcesses", "POST", "/approval_processes/list", svc.ListApprovalProcesses)
+		svc.UpdateApprovalProcesses).Reads(new(proto.ApprovalProcessUpdateReq))
+	h.Add("ListApprovalProcesses", "POST", "/approval_processes/list", svc.ListApprovalProcesses).
+		Reads(new(proto.ApprovalProcessListReq)).Writes(new(proto.ApprovalProcessListResult))
 
 	h.Load(cap.WebService)
 }
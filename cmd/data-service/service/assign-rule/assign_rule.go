@@ -0,0 +1,251 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package assignrule
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreassign "hcm/pkg/api/core/assign-rule"
+	dsassign "hcm/pkg/api/data-service/assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableassign "hcm/pkg/dal/table/assign-rule"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateAssignRule batch create assign rules.
+func (svc *service) BatchCreateAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsassign.BatchCreateAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tableassign.AssignRuleTable, len(req.Rules))
+	for idx, one := range req.Rules {
+		conditions := tableassign.Conditions(one.Conditions)
+		models[idx] = tableassign.AssignRuleTable{
+			Name:       one.Name,
+			Priority:   one.Priority,
+			Enabled:    one.Enabled,
+			BkBizID:    one.BkBizID,
+			BkCloudID:  one.BkCloudID,
+			ResTypes:   convResTypes(one.ResTypes),
+			Conditions: &conditions,
+			Memo:       one.Memo,
+			Creator:    cts.Kit.User,
+			Reviser:    cts.Kit.User,
+		}
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.AssignRule().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+// BatchUpdateAssignRule batch update assign rules.
+func (svc *service) BatchUpdateAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsassign.BatchUpdateAssignRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, len(req.Rules))
+	for idx, one := range req.Rules {
+		ids[idx] = one.ID
+	}
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	existing, err := svc.dao.AssignRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list assign rule failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+	ruleMap := make(map[string]tableassign.AssignRuleTable, len(existing.Details))
+	for _, one := range existing.Details {
+		ruleMap[one.ID] = one
+	}
+
+	models := make([]*tableassign.AssignRuleTable, len(req.Rules))
+	for idx, one := range req.Rules {
+		old, exists := ruleMap[one.ID]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "assign rule %s is not found", one.ID)
+		}
+
+		// 资源类型与管控区域需要结合原规则校验，避免更新后包含主机的规则没有管控区域
+		resTypes, bkCloudID := tableassign.ConvResTypes(old.ResTypes), old.BkCloudID
+		if len(one.ResTypes) != 0 {
+			resTypes = one.ResTypes
+		}
+		if one.BkCloudID != nil {
+			bkCloudID = one.BkCloudID
+		}
+		if err = coreassign.ValidateResTypes(resTypes, bkCloudID); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("rules[%d] is invalid, err: %v", idx, err))
+		}
+
+		models[idx] = &tableassign.AssignRuleTable{
+			Name:      one.Name,
+			Priority:  one.Priority,
+			Enabled:   one.Enabled,
+			BkBizID:   one.BkBizID,
+			BkCloudID: one.BkCloudID,
+			Memo:      one.Memo,
+			Reviser:   cts.Kit.User,
+		}
+		if len(one.ResTypes) != 0 {
+			models[idx].ResTypes = convResTypes(one.ResTypes)
+		}
+		if one.Conditions != nil {
+			conditions := tableassign.Conditions(*one.Conditions)
+			models[idx].Conditions = &conditions
+		}
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for idx, one := range req.Rules {
+			if err := svc.dao.AssignRule().UpdateByIDWithTx(cts.Kit, txn, one.ID, models[idx]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAssignRule list assign rules.
+func (svc *service) ListAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.AssignRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list assign rule failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsassign.ListAssignRuleResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreassign.AssignRule, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = convAssignRule(one)
+	}
+
+	return &dsassign.ListAssignRuleResult{Details: details}, nil
+}
+
+// BatchDeleteAssignRule batch delete assign rules.
+func (svc *service) BatchDeleteAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsassign.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.AssignRule().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete assign rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convResTypes(resTypes []enumor.CloudResourceType) tabletypes.StringArray {
+	result := make(tabletypes.StringArray, len(resTypes))
+	for idx, one := range resTypes {
+		result[idx] = string(one)
+	}
+	return result
+}
+
+func convAssignRule(one tableassign.AssignRuleTable) coreassign.AssignRule {
+	rule := coreassign.AssignRule{
+		ID:        one.ID,
+		Name:      one.Name,
+		BkBizID:   one.BkBizID,
+		BkCloudID: one.BkCloudID,
+		ResTypes:  tableassign.ConvResTypes(one.ResTypes),
+		Memo:      one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+	if one.Priority != nil {
+		rule.Priority = *one.Priority
+	}
+	if one.Enabled != nil {
+		rule.Enabled = *one.Enabled
+	}
+	if one.Conditions != nil {
+		rule.Conditions = coreassign.Conditions(*one.Conditions)
+	}
+	return rule
+}
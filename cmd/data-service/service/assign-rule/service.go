@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 资源自动分配业务规则
+package assignrule
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsassign "hcm/pkg/api/data-service/assign-rule"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the assign rule service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateAssignRule", http.MethodPost, "/assign_rules/batch/create", svc.BatchCreateAssignRule).
+		Reads(new(dsassign.BatchCreateAssignRuleReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateAssignRule", http.MethodPatch, "/assign_rules/batch", svc.BatchUpdateAssignRule).
+		Reads(new(dsassign.BatchUpdateAssignRuleReq))
+	h.Add("ListAssignRule", http.MethodPost, "/assign_rules/list", svc.ListAssignRule).
+		Reads(new(core.ListReq)).Writes(new(dsassign.ListAssignRuleResult))
+	h.Add("BatchDeleteAssignRule", http.MethodDelete, "/assign_rules/batch", svc.BatchDeleteAssignRule).
+		Reads(new(dsassign.BatchDeleteReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
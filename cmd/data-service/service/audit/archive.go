@@ -0,0 +1,508 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreaudit "hcm/pkg/api/core/audit"
+	proto "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/objectstore"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	// archivePathPrefix 归档文件在对象存储中的路径前缀
+	archivePathPrefix = "audit_archive"
+	// deleteBatchSize 单次删除已归档审计的数量，避免大事务长时间锁表
+	deleteBatchSize = 500
+	// maxSearchArchiveFiles 单次检索最多读取的归档文件数量
+	maxSearchArchiveFiles = 100
+)
+
+// InitArchiveService initial the audit archive service, which depends on object store.
+func InitArchiveService(cap *capability.Capability) {
+	svc := &archiveSvc{
+		dao:    cap.Dao,
+		ostore: cap.ObjectStore,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ArchiveAudit", http.MethodPost, "/audits/archive", svc.ArchiveAudit).
+		Reads(new(proto.ArchiveAuditReq)).Writes(new(proto.ArchiveAuditResult))
+	h.Add("ListAuditArchive", http.MethodPost, "/audits/archives/list", svc.ListAuditArchive).
+		Reads(new(core.ListReq)).Writes(new(proto.ListArchiveResult))
+	h.Add("SearchArchivedAudit", http.MethodPost, "/audits/archives/search", svc.SearchArchivedAudit).
+		Reads(new(proto.SearchArchivedAuditReq)).Writes(new(proto.SearchArchivedAuditResult))
+	h.Add("RehydrateAuditArchive", http.MethodPost, "/audits/archives/{id}/rehydrate", svc.RehydrateAuditArchive).
+		Writes(new(proto.RehydrateArchiveResult))
+
+	h.Load(cap.WebService)
+}
+
+type archiveSvc struct {
+	dao    dao.Set
+	ostore objectstore.Storage
+}
+
+// ArchiveAudit archive the audits before the specified time into object storage, one compressed jsonl file per
+// day, and then delete the archived audits in batches.
+func (svc *archiveSvc) ArchiveAudit(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ArchiveAuditReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	audits, err := svc.listAuditToArchive(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(audits) == 0 {
+		return &proto.ArchiveAuditResult{Count: 0, ArchiveIDs: make([]string, 0)}, nil
+	}
+
+	archived, err := svc.listArchivedIDRanges(cts.Kit, audits[0].ID, audits[len(audits)-1].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已归档过的审计（如归档后删除失败，或恢复后再次过期的审计）不再重复归档，直接删除
+	dateAudits := make(map[string][]tableaudit.AuditTable)
+	dates := make([]string, 0)
+	for _, one := range audits {
+		if archived.contains(one.ID) {
+			continue
+		}
+
+		createdAt, err := time.Parse(constant.TimeStdFormat, string(one.CreatedAt))
+		if err != nil {
+			return nil, fmt.Errorf("audit(%d) created_at %s is invalid, err: %v", one.ID, one.CreatedAt, err)
+		}
+
+		date := createdAt.In(time.Local).Format(constant.DateLayout)
+		if _, exists := dateAudits[date]; !exists {
+			dates = append(dates, date)
+		}
+		dateAudits[date] = append(dateAudits[date], one)
+	}
+
+	archiveIDs := make([]string, 0, len(dates))
+	for _, date := range dates {
+		id, err := svc.archive(cts.Kit, date, dateAudits[date])
+		if err != nil {
+			return nil, err
+		}
+		archiveIDs = append(archiveIDs, id)
+	}
+
+	ids := make([]uint64, 0, len(audits))
+	for _, one := range audits {
+		ids = append(ids, one.ID)
+	}
+
+	for _, batch := range slice.Split(ids, deleteBatchSize) {
+		if _, err := svc.dao.Audit().DeleteByIDs(cts.Kit, batch); err != nil {
+			logs.Errorf("delete archived audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return &proto.ArchiveAuditResult{Count: uint64(len(audits)), ArchiveIDs: archiveIDs}, nil
+}
+
+// listAuditToArchive list at most limit audits created before the specified time, ordered by id.
+func (svc *archiveSvc) listAuditToArchive(kt *kit.Kit, req *proto.ArchiveAuditReq) ([]tableaudit.AuditTable,
+	error) {
+
+	audits := make([]tableaudit.AuditTable, 0, req.Limit)
+	var lastID uint64
+	for uint(len(audits)) < req.Limit {
+		opt := &types.ListOption{
+			Filter: tools.ExpressionAnd(
+				&filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(), Value: req.Before},
+				tools.RuleGreaterThan("id", lastID),
+			),
+			Page: &core.BasePage{Start: 0, Limit: min(core.DefaultMaxPageLimit, req.Limit-uint(len(audits))),
+				Sort: "id", Order: core.Ascending},
+		}
+		result, err := svc.dao.Audit().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list audit to archive failed, err: %v, before: %s, rid: %s", err, req.Before, kt.Rid)
+			return nil, err
+		}
+
+		audits = append(audits, result.Details...)
+		if len(result.Details) < int(opt.Page.Limit) {
+			break
+		}
+		lastID = result.Details[len(result.Details)-1].ID
+	}
+
+	return audits, nil
+}
+
+// archive upload the audits of the same day as a compressed jsonl file, and record it in the archive manifest.
+func (svc *archiveSvc) archive(kt *kit.Kit, date string, audits []tableaudit.AuditTable) (string, error) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(gw)
+	for _, one := range audits {
+		if err := encoder.Encode(one); err != nil {
+			return "", fmt.Errorf("encode audit(%d) failed, err: %v", one.ID, err)
+		}
+	}
+	if err := gw.Close(); err != nil {
+		return "", fmt.Errorf("compress audit archive failed, err: %v", err)
+	}
+
+	content := buf.Bytes()
+	checksum := sha256.Sum256(content)
+
+	first, last := audits[0], audits[len(audits)-1]
+	day, _ := time.Parse(constant.DateLayout, date)
+	// 按日期分区存储，e.g: audit_archive/default/2024/06/01/100-200.jsonl.gz
+	path := fmt.Sprintf("%s/%s/%s/%d-%d.jsonl.gz", archivePathPrefix, kt.TenantID, day.Format("2006/01/02"),
+		first.ID, last.ID)
+
+	if err := svc.ostore.Upload(kt, path, bytes.NewReader(content)); err != nil {
+		logs.Errorf("upload audit archive failed, err: %v, path: %s, rid: %s", err, path, kt.Rid)
+		return "", err
+	}
+
+	minSeq, maxSeq := seqRange(audits)
+	archive := &tableaudit.ArchiveTable{
+		ArchiveDate: date,
+		Path:        path,
+		MinAuditID:  first.ID,
+		MaxAuditID:  last.ID,
+		MinSeq:      minSeq,
+		MaxSeq:      maxSeq,
+		StartTime:   string(first.CreatedAt),
+		EndTime:     string(last.CreatedAt),
+		Count:       uint64(len(audits)),
+		Size:        uint64(len(content)),
+		Sha256:      hex.EncodeToString(checksum[:]),
+	}
+	id, err := svc.dao.AuditArchive().Create(kt, archive)
+	if err != nil {
+		logs.Errorf("create audit archive failed, err: %v, path: %s, rid: %s", err, path, kt.Rid)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// seqRange returns the min and max seq of the audits in hash chain, historical audits without seq are ignored.
+func seqRange(audits []tableaudit.AuditTable) (uint64, uint64) {
+	var minSeq, maxSeq uint64
+	for _, one := range audits {
+		if one.Seq == 0 {
+			continue
+		}
+
+		if minSeq == 0 || one.Seq < minSeq {
+			minSeq = one.Seq
+		}
+		maxSeq = max(maxSeq, one.Seq)
+	}
+
+	return minSeq, maxSeq
+}
+
+type idRanges [][2]uint64
+
+func (r idRanges) contains(id uint64) bool {
+	for _, one := range r {
+		if id >= one[0] && id <= one[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listArchivedIDRanges list the audit id ranges of archives which overlap with [minID, maxID].
+func (svc *archiveSvc) listArchivedIDRanges(kt *kit.Kit, minID, maxID uint64) (idRanges, error) {
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleLessThanEqual("min_audit_id", maxID),
+			tools.RuleGreaterThanEqual("max_audit_id", minID),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "min_audit_id", "max_audit_id"},
+	}
+
+	ranges := make(idRanges, 0)
+	for {
+		result, err := svc.dao.AuditArchive().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list audit archive failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			ranges = append(ranges, [2]uint64{one.MinAuditID, one.MaxAuditID})
+		}
+
+		if len(result.Details) < int(opt.Page.Limit) {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return ranges, nil
+}
+
+// ListAuditArchive list audit archive manifests.
+func (svc *archiveSvc) ListAuditArchive(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.AuditArchive().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit archive failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list audit archive failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &proto.ListArchiveResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreaudit.Archive, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreaudit.Archive{
+			ID:          one.ID,
+			ArchiveDate: one.ArchiveDate,
+			Path:        one.Path,
+			MinAuditID:  one.MinAuditID,
+			MaxAuditID:  one.MaxAuditID,
+			MinSeq:      one.MinSeq,
+			MaxSeq:      one.MaxSeq,
+			StartTime:   one.StartTime,
+			EndTime:     one.EndTime,
+			Count:       one.Count,
+			Size:        one.Size,
+			Sha256:      one.Sha256,
+			Creator:     one.Creator,
+			CreatedAt:   one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.ListArchiveResult{Details: details}, nil
+}
+
+// SearchArchivedAudit search the archived audits in the archive files which overlap with the time range.
+func (svc *archiveSvc) SearchArchivedAudit(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.SearchArchivedAuditReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleLessThanEqual("start_time", req.EndTime),
+			tools.RuleGreaterThanEqual("end_time", req.StartTime),
+		),
+		Page: &core.BasePage{Start: 0, Limit: maxSearchArchiveFiles + 1, Sort: "max_audit_id",
+			Order: core.Descending},
+	}
+	archives, err := svc.dao.AuditArchive().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit archive failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(archives.Details) > maxSearchArchiveFiles {
+		return nil, errf.Newf(errf.InvalidParameter, "more than %d archive files in the time range, please narrow "+
+			"the time range", maxSearchArchiveFiles)
+	}
+
+	matched := make([]tableaudit.AuditTable, 0)
+	for _, archive := range archives.Details {
+		audits, err := svc.readArchive(cts.Kit, &archive)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range audits {
+			if req.Match(&one) {
+				matched = append(matched, one)
+			}
+		}
+
+		// 归档文件按审计ID倒序读取，已满足数量要求时无需继续读取更早的归档文件
+		if len(matched) >= int(req.Limit) {
+			break
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID > matched[j].ID })
+	if len(matched) > int(req.Limit) {
+		matched = matched[:req.Limit]
+	}
+
+	details := make([]coreaudit.Audit, 0, len(matched))
+	for _, one := range matched {
+		details = append(details, coreaudit.Audit{
+			ID:         one.ID,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			ResName:    one.ResName,
+			ResType:    one.ResType,
+			Action:     one.Action,
+			BkBizID:    one.BkBizID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			Operator:   one.Operator,
+			Detail:     one.Detail,
+			Source:     one.Source,
+			Rid:        one.Rid,
+			AppCode:    one.AppCode,
+			CreatedAt:  one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.SearchArchivedAuditResult{Details: details}, nil
+}
+
+// RehydrateAuditArchive restore the audits in the archive file into audit table.
+func (svc *archiveSvc) RehydrateAuditArchive(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.AuditArchive().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit archive failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "audit archive: %s not found", id)
+	}
+
+	audits, err := svc.readArchive(cts.Kit, &result.Details[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, batch := range slice.Split(audits, deleteBatchSize) {
+		if err := svc.dao.Audit().BatchRestore(cts.Kit, batch); err != nil {
+			logs.Errorf("restore archived audit failed, err: %v, archive: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return &proto.RehydrateArchiveResult{Count: uint64(len(audits))}, nil
+}
+
+// errArchiveChecksumMismatch the content of archive file is not matched with the checksum in archive manifest.
+var errArchiveChecksumMismatch = errors.New("audit archive checksum mismatch")
+
+// readArchive download the archive file, verify its checksum and decode the audits in it.
+func (svc *archiveSvc) readArchive(kt *kit.Kit, archive *tableaudit.ArchiveTable) ([]tableaudit.AuditTable, error) {
+	return readArchive(kt, svc.ostore, archive)
+}
+
+// readArchive download the archive file from object store, verify its checksum and decode the audits in it.
+// errArchiveChecksumMismatch is returned if the checksum is mismatched.
+func readArchive(kt *kit.Kit, ostore objectstore.Storage, archive *tableaudit.ArchiveTable) (
+	[]tableaudit.AuditTable, error) {
+
+	buf := new(bytes.Buffer)
+	if err := ostore.Download(kt, archive.Path, buf); err != nil {
+		logs.Errorf("download audit archive failed, err: %v, path: %s, rid: %s", err, archive.Path, kt.Rid)
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(checksum[:]) != archive.Sha256 {
+		logs.Errorf("audit archive %s checksum mismatch, path: %s, rid: %s", archive.ID, archive.Path, kt.Rid)
+		return nil, fmt.Errorf("%w, id: %s", errArchiveChecksumMismatch, archive.ID)
+	}
+
+	gr, err := gzip.NewReader(buf)
+	if err != nil {
+		return nil, fmt.Errorf("decompress audit archive %s failed, err: %v", archive.ID, err)
+	}
+	defer gr.Close()
+
+	audits := make([]tableaudit.AuditTable, 0, archive.Count)
+	decoder := json.NewDecoder(gr)
+	for {
+		one := tableaudit.AuditTable{}
+		if err := decoder.Decode(&one); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode audit archive %s failed, err: %v", archive.ID, err)
+		}
+		audits = append(audits, one)
+	}
+
+	return audits, nil
+}
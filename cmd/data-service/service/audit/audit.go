@@ -56,8 +56,9 @@ func InitAuditService(cap *capability.Capability) {
 		svc.cloudAudit.CloudResourceOperationAudit)
 	h.Add("CloudResourceRecycleAudit", http.MethodPost, "/cloud/resources/recycle_audits/create",
 		svc.cloudAudit.CloudResourceRecycleAudit)
-	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
-	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
+	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit).
+		Reads(new(core.ListReq)).Writes(new(proto.ListResult))
+	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit).Writes(new(coreaudit.Audit))
 
 	h.Load(cap.WebService)
 }
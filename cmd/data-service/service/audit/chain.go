@@ -0,0 +1,529 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreaudit "hcm/pkg/api/core/audit"
+	proto "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/objectstore"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitChainService initial the audit hash chain service, creating checkpoint depends on object store.
+func InitChainService(cap *capability.Capability) {
+	svc := &chainSvc{
+		dao:     cap.Dao,
+		ostore:  cap.ObjectStore,
+		signKey: cc.DataService().AuditChain.CheckpointSignKey,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("VerifyAuditChain", http.MethodPost, "/audits/chain/verify", svc.VerifyAuditChain).
+		Reads(new(proto.VerifyAuditChainReq)).Writes(new(proto.VerifyAuditChainResult))
+	h.Add("ListAuditCheckpoint", http.MethodPost, "/audits/chain/checkpoints/list", svc.ListAuditCheckpoint).
+		Reads(new(core.ListReq)).Writes(new(proto.ListCheckpointResult))
+	if cap.ObjectStore != nil {
+		h.Add("CreateAuditCheckpoint", http.MethodPost, "/audits/chain/checkpoints/create",
+			svc.CreateAuditCheckpoint).Writes(new(proto.CreateCheckpointResult))
+	}
+
+	h.Load(cap.WebService)
+}
+
+type chainSvc struct {
+	dao     dao.Set
+	ostore  objectstore.Storage
+	signKey string
+}
+
+// VerifyAuditChain walk the audit hash chain of the tenant in seq order and report the breaks.
+func (svc *chainSvc) VerifyAuditChain(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.VerifyAuditChainReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return VerifyChain(cts.Kit, svc.dao, svc.ostore, svc.signKey, req)
+}
+
+// VerifyChain walk the audit hash chain of the tenant from the start seq, recalculate the hash of each audit and
+// compare it with the recorded hash, the next audit's prev hash and the signed checkpoints.
+// 已归档的审计不在审计表中，缺失的序号需要由归档清单中序号范围覆盖的归档文件补齐，归档文件的sha256校验通过后，
+// 其中的审计与审计表中的审计一样参与哈希链校验，归档文件中也不存在的序号作为断裂点。
+func VerifyChain(kt *kit.Kit, daoSet dao.Set, ostore objectstore.Storage, signKey string,
+	req *proto.VerifyAuditChainReq) (*proto.VerifyAuditChainResult, error) {
+
+	head, err := daoSet.Audit().GetChainHead(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	start := max(req.StartSeq, 1)
+	result := &proto.VerifyAuditChainResult{
+		HeadSeq:  head.Seq,
+		StartSeq: start,
+		EndSeq:   head.Seq,
+		Breaks:   make([]proto.ChainBreak, 0),
+	}
+	if start > head.Seq {
+		return result, nil
+	}
+	end := min(start+req.Limit-1, head.Seq)
+	result.EndSeq = end
+
+	checkpoints, err := listCheckpointsInRange(kt, daoSet, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := chainTenantID(kt)
+	for _, one := range checkpoints {
+		result.CheckpointChecked++
+		if len(signKey) != 0 && !hmac.Equal([]byte(one.Signature),
+			[]byte(signCheckpoint(signKey, tenantID, one.Seq, one.Hash))) {
+
+			result.Breaks = append(result.Breaks, proto.ChainBreak{
+				Seq:     one.Seq,
+				Reason:  proto.ChainBreakCheckpointSignatureInvalid,
+				Message: fmt.Sprintf("signature of checkpoint %s is invalid", one.ID),
+			})
+		}
+	}
+
+	v := &chainVerifier{
+		kt:          kt,
+		daoSet:      daoSet,
+		ostore:      ostore,
+		checkpoints: checkpoints,
+		result:      result,
+		lastSeq:     start - 1,
+		archives:    make(map[string][]tableaudit.AuditTable),
+	}
+
+	// 开始序号的上一条审计存在时，用于校验第一条审计的 prev_hash
+	if start > 1 {
+		if err = v.initPrev(start - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	for cursor := start; cursor <= end; {
+		audits, err := listAuditBySeq(kt, daoSet, cursor, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range audits {
+			if one.Seq > v.lastSeq+1 {
+				if err = v.checkGap(v.lastSeq+1, one.Seq-1); err != nil {
+					return nil, err
+				}
+			}
+
+			v.check(one)
+			result.Checked++
+		}
+
+		if len(audits) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		cursor = v.lastSeq + 1
+	}
+
+	if v.lastSeq < end {
+		if err = v.checkGap(v.lastSeq+1, end); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// chainVerifier walk the audits of hash chain in seq order and record the breaks into result.
+type chainVerifier struct {
+	kt          *kit.Kit
+	daoSet      dao.Set
+	ostore      objectstore.Storage
+	checkpoints map[uint64]tableaudit.CheckpointTable
+	result      *proto.VerifyAuditChainResult
+
+	// prevHash is the hash of the last audit, it is used to check the prev hash of the next audit if prevKnown.
+	prevHash  string
+	prevKnown bool
+	lastSeq   uint64
+	// archives is the audits of archive files which are read, key is archive id.
+	archives map[string][]tableaudit.AuditTable
+}
+
+// initPrev set the hash of the audit before the start seq, which is in audit table or archive files.
+func (v *chainVerifier) initPrev(seq uint64) error {
+	prev, err := listAuditBySeq(v.kt, v.daoSet, seq, seq)
+	if err != nil {
+		return err
+	}
+
+	if len(prev) == 0 {
+		prev, err = v.listArchivedAudits(seq, seq)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(prev) != 0 {
+		v.prevHash, v.prevKnown = prev[0].Hash, true
+	}
+
+	return nil
+}
+
+// check the audit with the previous audit and the checkpoint, and move the chain forward.
+func (v *chainVerifier) check(one tableaudit.AuditTable) {
+	if v.prevKnown && one.PrevHash != v.prevHash {
+		v.result.Breaks = append(v.result.Breaks, proto.ChainBreak{
+			Seq:     one.Seq,
+			AuditID: one.ID,
+			Reason:  proto.ChainBreakPrevHashMismatch,
+			Message: fmt.Sprintf("prev_hash %s is not equal to hash %s of seq %d", one.PrevHash, v.prevHash,
+				v.lastSeq),
+		})
+	}
+
+	hash, err := one.ChainHash()
+	if err != nil || hash != one.Hash {
+		v.result.Breaks = append(v.result.Breaks, proto.ChainBreak{
+			Seq:     one.Seq,
+			AuditID: one.ID,
+			Reason:  proto.ChainBreakHashMismatch,
+			Message: fmt.Sprintf("recorded hash %s is not equal to calculated hash %s, err: %v", one.Hash,
+				hash, err),
+		})
+	}
+
+	if cp, exists := v.checkpoints[one.Seq]; exists && cp.Hash != one.Hash {
+		v.result.Breaks = append(v.result.Breaks, proto.ChainBreak{
+			Seq:     one.Seq,
+			AuditID: one.ID,
+			Reason:  proto.ChainBreakCheckpointMismatch,
+			Message: fmt.Sprintf("hash %s is not equal to hash %s of checkpoint %s", one.Hash, cp.Hash, cp.ID),
+		})
+	}
+
+	v.prevHash, v.prevKnown, v.lastSeq = one.Hash, true, one.Seq
+}
+
+// checkGap check the audits of seq in [from, to] which are not in audit table by the archive files, seqs that are
+// not in archive files either are reported as missing.
+func (v *chainVerifier) checkGap(from, to uint64) error {
+	archived, err := v.listArchivedAudits(from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range archived {
+		if one.Seq > v.lastSeq+1 {
+			v.missing(v.lastSeq+1, one.Seq-1)
+		}
+
+		v.check(one)
+		v.result.ArchiveChecked++
+	}
+
+	if v.lastSeq < to {
+		v.missing(v.lastSeq+1, to)
+	}
+
+	return nil
+}
+
+// missing report the audits of seq in [from, to] are missing, the prev hash of the next audit can not be checked.
+func (v *chainVerifier) missing(from, to uint64) {
+	v.result.Breaks = append(v.result.Breaks, proto.ChainBreak{
+		Seq:     from,
+		Reason:  proto.ChainBreakMissing,
+		Message: fmt.Sprintf("audits of seq %d-%d are missing in both audit table and archives", from, to),
+	})
+	v.prevKnown, v.lastSeq = false, to
+}
+
+// listArchivedAudits list the audits of seq in [from, to] in the archive files whose seq range overlaps with it,
+// ordered by seq. archive files whose checksum is mismatched are reported as breaks and their audits are ignored.
+func (v *chainVerifier) listArchivedAudits(from, to uint64) ([]tableaudit.AuditTable, error) {
+	if v.ostore == nil {
+		return nil, nil
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleLessThanEqual("min_seq", to),
+			tools.RuleGreaterThanEqual("max_seq", from),
+			tools.RuleGreaterThan("min_seq", 0),
+		),
+		Page: &core.BasePage{Start: 0, Limit: maxSearchArchiveFiles + 1, Sort: "min_seq", Order: core.Ascending},
+	}
+	archives, err := v.daoSet.AuditArchive().List(v.kt, opt)
+	if err != nil {
+		logs.Errorf("list audit archive failed, err: %v, seq: %d-%d, rid: %s", err, from, to, v.kt.Rid)
+		return nil, err
+	}
+
+	if len(archives.Details) > maxSearchArchiveFiles {
+		return nil, errf.Newf(errf.InvalidParameter, "more than %d archive files in seq %d-%d, please reduce "+
+			"the limit", maxSearchArchiveFiles, from, to)
+	}
+
+	seqAudits := make(map[uint64]tableaudit.AuditTable)
+	for _, archive := range archives.Details {
+		audits, err := v.readArchive(&archive)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range audits {
+			if one.Seq >= from && one.Seq <= to {
+				seqAudits[one.Seq] = one
+			}
+		}
+	}
+
+	result := make([]tableaudit.AuditTable, 0, len(seqAudits))
+	for _, one := range seqAudits {
+		result = append(result, one)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Seq < result[j].Seq })
+
+	return result, nil
+}
+
+// readArchive read the audits of archive file, and cache them for the following gaps in the same archive file.
+func (v *chainVerifier) readArchive(archive *tableaudit.ArchiveTable) ([]tableaudit.AuditTable, error) {
+	if audits, exists := v.archives[archive.ID]; exists {
+		return audits, nil
+	}
+
+	audits, err := readArchive(v.kt, v.ostore, archive)
+	if err != nil {
+		if !errors.Is(err, errArchiveChecksumMismatch) {
+			return nil, err
+		}
+
+		v.result.Breaks = append(v.result.Breaks, proto.ChainBreak{
+			Seq:    archive.MinSeq,
+			Reason: proto.ChainBreakArchiveChecksumMismatch,
+			Message: fmt.Sprintf("checksum of archive %s is not equal to %s in manifest, path: %s", archive.ID,
+				archive.Sha256, archive.Path),
+		})
+		audits = make([]tableaudit.AuditTable, 0)
+	}
+
+	v.archives[archive.ID] = audits
+	return audits, nil
+}
+
+// listAuditBySeq list at most one page of audits whose seq is in [start, end], ordered by seq.
+func listAuditBySeq(kt *kit.Kit, daoSet dao.Set, start, end uint64) ([]tableaudit.AuditTable, error) {
+	return daoSet.Audit().ListBySeq(kt, start, end, core.DefaultMaxPageLimit)
+}
+
+// listCheckpointsInRange list the checkpoints whose seq is in [start, end], returns the map of seq and checkpoint.
+func listCheckpointsInRange(kt *kit.Kit, daoSet dao.Set, start, end uint64) (
+	map[uint64]tableaudit.CheckpointTable, error) {
+
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleGreaterThanEqual("seq", start),
+			tools.RuleLessThanEqual("seq", end),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "seq", Order: core.Ascending},
+	}
+
+	checkpoints := make(map[uint64]tableaudit.CheckpointTable)
+	for {
+		result, err := daoSet.AuditCheckpoint().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list audit checkpoint failed, err: %v, seq: %d-%d, rid: %s", err, start, end, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			checkpoints[one.Seq] = one
+		}
+
+		if len(result.Details) < int(opt.Page.Limit) {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return checkpoints, nil
+}
+
+// CreateAuditCheckpoint sign the current head of the audit hash chain, write the checkpoint into the audit archive
+// of object storage and record it in database.
+func (svc *chainSvc) CreateAuditCheckpoint(cts *rest.Contexts) (interface{}, error) {
+	kt := cts.Kit
+	if len(svc.signKey) == 0 {
+		return nil, errf.New(errf.Aborted, "audit chain checkpoint sign key is not configured")
+	}
+
+	head, err := svc.dao.Audit().GetChainHead(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	if head.Seq == 0 {
+		return &proto.CreateCheckpointResult{}, nil
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.AllExpression(),
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "seq", Order: core.Descending},
+	}
+	latest, err := svc.dao.AuditCheckpoint().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list latest audit checkpoint failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	// 自上次检查点之后没有新的审计，无需重复生成检查点
+	if len(latest.Details) != 0 && latest.Details[0].Seq >= head.Seq {
+		return &proto.CreateCheckpointResult{Seq: latest.Details[0].Seq, Hash: latest.Details[0].Hash,
+			Path: latest.Details[0].Path}, nil
+	}
+
+	tenantID := chainTenantID(kt)
+	now := time.Now()
+	content := coreaudit.CheckpointContent{
+		TenantID:  tenantID,
+		Seq:       head.Seq,
+		Hash:      head.Hash,
+		Signature: signCheckpoint(svc.signKey, tenantID, head.Seq, head.Hash),
+		CreatedAt: now.Format(constant.TimeStdFormat),
+	}
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit checkpoint failed, err: %v", err)
+	}
+
+	// e.g: audit_archive/default/checkpoints/2024/06/01/1000.json
+	path := fmt.Sprintf("%s/%s/checkpoints/%s/%d.json", archivePathPrefix, tenantID, now.Format("2006/01/02"),
+		head.Seq)
+	if err := svc.ostore.Upload(kt, path, bytes.NewReader(raw)); err != nil {
+		logs.Errorf("upload audit checkpoint failed, err: %v, path: %s, rid: %s", err, path, kt.Rid)
+		return nil, err
+	}
+
+	checkpoint := &tableaudit.CheckpointTable{
+		Seq:       content.Seq,
+		Hash:      content.Hash,
+		Signature: content.Signature,
+		Path:      path,
+	}
+	id, err := svc.dao.AuditCheckpoint().Create(kt, checkpoint)
+	if err != nil {
+		logs.Errorf("create audit checkpoint failed, err: %v, seq: %d, rid: %s", err, head.Seq, kt.Rid)
+		return nil, err
+	}
+
+	return &proto.CreateCheckpointResult{ID: id, Seq: content.Seq, Hash: content.Hash, Path: path}, nil
+}
+
+// ListAuditCheckpoint list audit hash chain checkpoints.
+func (svc *chainSvc) ListAuditCheckpoint(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.AuditCheckpoint().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit checkpoint failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list audit checkpoint failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &proto.ListCheckpointResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreaudit.Checkpoint, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreaudit.Checkpoint{
+			ID:        one.ID,
+			Seq:       one.Seq,
+			Hash:      one.Hash,
+			Signature: one.Signature,
+			Path:      one.Path,
+			Creator:   one.Creator,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.ListCheckpointResult{Details: details}, nil
+}
+
+// signCheckpoint sign the checkpoint of the tenant's audit hash chain with hmac-sha256.
+func signCheckpoint(key, tenantID string, seq uint64, hash string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(fmt.Sprintf("%s:%d:%s", tenantID, seq, hash)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// chainTenantID returns the tenant id of the audit hash chain, which is the default tenant if tenant is not enabled.
+func chainTenantID(kt *kit.Kit) string {
+	if len(kt.TenantID) == 0 {
+		return constant.DefaultTenantID
+	}
+
+	return kt.TenantID
+}
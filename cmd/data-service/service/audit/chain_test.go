@@ -0,0 +1,217 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	proto "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao"
+	daoaudit "hcm/pkg/dal/dao/audit"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/objectstore"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+)
+
+type fakeDaoSet struct {
+	dao.Set
+	archives []tableaudit.ArchiveTable
+	audit    daoaudit.Interface
+	outbox   daoaudit.OutboxInterface
+}
+
+func (f *fakeDaoSet) Audit() daoaudit.Interface {
+	return f.audit
+}
+
+func (f *fakeDaoSet) AuditOutbox() daoaudit.OutboxInterface {
+	return f.outbox
+}
+
+func (f *fakeDaoSet) AuditArchive() daoaudit.ArchiveInterface {
+	return &fakeArchiveDao{archives: f.archives}
+}
+
+type fakeArchiveDao struct {
+	daoaudit.ArchiveInterface
+	archives []tableaudit.ArchiveTable
+}
+
+func (f *fakeArchiveDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditArchiveDetails, error) {
+	return &types.ListAuditArchiveDetails{Details: f.archives}, nil
+}
+
+type fakeStorage struct {
+	objectstore.Storage
+	files map[string][]byte
+}
+
+func (f *fakeStorage) Download(kt *kit.Kit, path string, w io.Writer) error {
+	content, exists := f.files[path]
+	if !exists {
+		return fmt.Errorf("%s not found", path)
+	}
+	_, err := w.Write(content)
+	return err
+}
+
+// buildChain build the hash chain of audits of seq in [1, count].
+func buildChain(t *testing.T, count int) []tableaudit.AuditTable {
+	createdAt := tabletypes.Time(time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local).Format(constant.TimeStdFormat))
+
+	audits := make([]tableaudit.AuditTable, 0, count)
+	prevHash := ""
+	for i := 1; i <= count; i++ {
+		one := tableaudit.AuditTable{ID: uint64(100 + i), ResID: fmt.Sprintf("res-%d", i), Seq: uint64(i),
+			PrevHash: prevHash, CreatedAt: createdAt}
+		hash, err := one.ChainHash()
+		if err != nil {
+			t.Fatalf("calculate chain hash failed, err: %v", err)
+		}
+		one.Hash = hash
+		prevHash = hash
+		audits = append(audits, one)
+	}
+
+	return audits
+}
+
+// buildArchive build the archive file of audits, returns the manifest and the file content.
+func buildArchive(t *testing.T, id string, audits []tableaudit.AuditTable) (tableaudit.ArchiveTable, []byte) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(gw)
+	for _, one := range audits {
+		if err := encoder.Encode(one); err != nil {
+			t.Fatalf("encode audit failed, err: %v", err)
+		}
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("compress audit failed, err: %v", err)
+	}
+
+	checksum := sha256.Sum256(buf.Bytes())
+	minSeq, maxSeq := seqRange(audits)
+	archive := tableaudit.ArchiveTable{ID: id, Path: id + ".jsonl.gz", MinSeq: minSeq, MaxSeq: maxSeq,
+		Count: uint64(len(audits)), Sha256: hex.EncodeToString(checksum[:])}
+	return archive, buf.Bytes()
+}
+
+func newVerifier(daoSet dao.Set, ostore objectstore.Storage, prev tableaudit.AuditTable) *chainVerifier {
+	return &chainVerifier{
+		kt:          kit.New(),
+		daoSet:      daoSet,
+		ostore:      ostore,
+		checkpoints: make(map[uint64]tableaudit.CheckpointTable),
+		result:      &proto.VerifyAuditChainResult{Breaks: make([]proto.ChainBreak, 0)},
+		prevHash:    prev.Hash,
+		prevKnown:   true,
+		lastSeq:     prev.Seq,
+		archives:    make(map[string][]tableaudit.AuditTable),
+	}
+}
+
+func TestCheckGapCoveredByArchive(t *testing.T) {
+	audits := buildChain(t, 6)
+	archive1, content1 := buildArchive(t, "a1", audits[1:3])
+	archive2, content2 := buildArchive(t, "a2", audits[3:5])
+	daoSet := &fakeDaoSet{archives: []tableaudit.ArchiveTable{archive1, archive2}}
+	ostore := &fakeStorage{files: map[string][]byte{archive1.Path: content1, archive2.Path: content2}}
+
+	v := newVerifier(daoSet, ostore, audits[0])
+	if err := v.checkGap(2, 5); err != nil {
+		t.Fatalf("check gap failed, err: %v", err)
+	}
+	v.check(audits[5])
+
+	if len(v.result.Breaks) != 0 {
+		t.Errorf("gap covered by archives should not break, breaks: %+v", v.result.Breaks)
+	}
+	if v.result.ArchiveChecked != 4 {
+		t.Errorf("unexpected archive checked count: %d", v.result.ArchiveChecked)
+	}
+}
+
+func TestCheckGapNotCovered(t *testing.T) {
+	audits := buildChain(t, 6)
+	// 序号4既不在审计表中，也不在归档文件中
+	archive1, content1 := buildArchive(t, "a1", audits[1:3])
+	archive2, content2 := buildArchive(t, "a2", audits[4:5])
+	daoSet := &fakeDaoSet{archives: []tableaudit.ArchiveTable{archive1, archive2}}
+	ostore := &fakeStorage{files: map[string][]byte{archive1.Path: content1, archive2.Path: content2}}
+
+	v := newVerifier(daoSet, ostore, audits[0])
+	if err := v.checkGap(2, 5); err != nil {
+		t.Fatalf("check gap failed, err: %v", err)
+	}
+
+	if len(v.result.Breaks) != 1 || v.result.Breaks[0].Reason != proto.ChainBreakMissing ||
+		v.result.Breaks[0].Seq != 4 {
+		t.Errorf("unexpected breaks: %+v", v.result.Breaks)
+	}
+}
+
+func TestCheckGapWithoutObjectStore(t *testing.T) {
+	audits := buildChain(t, 3)
+	v := newVerifier(&fakeDaoSet{}, nil, audits[0])
+	if err := v.checkGap(2, 2); err != nil {
+		t.Fatalf("check gap failed, err: %v", err)
+	}
+	// 缺失审计后无法校验下一条审计的 prev_hash
+	v.check(audits[2])
+
+	if len(v.result.Breaks) != 1 || v.result.Breaks[0].Reason != proto.ChainBreakMissing {
+		t.Errorf("unexpected breaks: %+v", v.result.Breaks)
+	}
+}
+
+func TestCheckGapArchiveTampered(t *testing.T) {
+	audits := buildChain(t, 3)
+	archive, content := buildArchive(t, "a1", audits[1:2])
+	tampered := append([]byte{}, content...)
+	tampered[len(tampered)-1]++
+	daoSet := &fakeDaoSet{archives: []tableaudit.ArchiveTable{archive}}
+	ostore := &fakeStorage{files: map[string][]byte{archive.Path: tampered}}
+
+	v := newVerifier(daoSet, ostore, audits[0])
+	if err := v.checkGap(2, 2); err != nil {
+		t.Fatalf("check gap failed, err: %v", err)
+	}
+
+	reasons := make([]proto.ChainBreakReason, 0)
+	for _, one := range v.result.Breaks {
+		reasons = append(reasons, one.Reason)
+	}
+	if len(reasons) != 2 || reasons[0] != proto.ChainBreakArchiveChecksumMismatch ||
+		reasons[1] != proto.ChainBreakMissing {
+		t.Errorf("unexpected breaks: %+v", v.result.Breaks)
+	}
+}
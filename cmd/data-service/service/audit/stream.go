@@ -0,0 +1,267 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/siem"
+)
+
+// maxStreamRetryDelay 推送失败后重试的最大间隔
+const maxStreamRetryDelay = time.Hour
+
+// StreamAudit 定时从发件箱中按顺序读取待推送的审计，推送到所有外部SIEM，全部推送成功后删除发件箱记录，
+// 推送失败时整批按指数退避重试，超过最大重试次数后标记为失败，不再自动重试。发件箱及审计均从主库读取，避免从库延迟导致
+// 重复推送或误判审计缺失，审计已不存在时只有确认已归档才删除发件箱记录
+func StreamAudit(conf cc.AuditStream, sinks []siem.Sink, daoSet dao.Set, state serviced.State) {
+	interval := time.Duration(conf.IntervalSec) * time.Second
+	logs.Infof("audit stream enable && start, interval: %v, sinks: %d", interval, len(sinks))
+
+	for {
+		time.Sleep(interval)
+
+		if !state.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		for {
+			count, err := streamAuditBatch(kt, conf, sinks, daoSet)
+			if err != nil {
+				logs.Errorf("stream audit failed, err: %v, rid: %s", err, kt.Rid)
+				break
+			}
+
+			if count < conf.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+// streamAuditBatch deliver one batch of pending audits in the outbox, returns the count of outbox handled.
+// outbox rows are deleted only if their audits are delivered or confirmed archived, the others are left for retry.
+func streamAuditBatch(kt *kit.Kit, conf cc.AuditStream, sinks []siem.Sink, daoSet dao.Set) (uint, error) {
+	outboxes, err := daoSet.AuditOutbox().ListPending(kt, conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(outboxes) == 0 {
+		return 0, nil
+	}
+
+	audits, missing, err := listOutboxAudits(kt, daoSet, outboxes)
+	if err != nil {
+		return 0, err
+	}
+
+	archivedIDs, unknownIDs, err := checkOutboxArchived(kt, daoSet, missing)
+	if err != nil {
+		return 0, err
+	}
+
+	missingIDs := make(map[uint64]struct{}, len(missing))
+	for _, one := range missing {
+		missingIDs[one.ID] = struct{}{}
+	}
+	sendIDs := make([]uint64, 0, len(audits))
+	for _, one := range outboxes {
+		if _, exists := missingIDs[one.ID]; !exists {
+			sendIDs = append(sendIDs, one.ID)
+		}
+	}
+
+	if len(audits) != 0 {
+		if sendErr := sendToSinks(kt, sinks, audits); sendErr != nil {
+			if err := retryOutbox(kt, conf, daoSet, outboxes, append(sendIDs, unknownIDs...),
+				sendErr.Error()); err != nil {
+				return 0, err
+			}
+
+			if len(archivedIDs) != 0 {
+				if err := daoSet.AuditOutbox().DeleteByIDs(kt, archivedIDs); err != nil {
+					return 0, err
+				}
+			}
+			return 0, sendErr
+		}
+	}
+
+	// 审计表和归档中都找不到的审计可能是写入后尚未可见，保留发件箱记录稍后重试，超过最大重试次数后标记为失败
+	if len(unknownIDs) != 0 {
+		reason := "audit is not found in database or archives"
+		if err := retryOutbox(kt, conf, daoSet, outboxes, unknownIDs, reason); err != nil {
+			return 0, err
+		}
+	}
+
+	deleteIDs := append(sendIDs, archivedIDs...)
+	if len(deleteIDs) != 0 {
+		if err := daoSet.AuditOutbox().DeleteByIDs(kt, deleteIDs); err != nil {
+			return 0, err
+		}
+	}
+
+	return uint(len(outboxes)), nil
+}
+
+// retryOutbox delay the next delivery of outbox by exponential backoff.
+func retryOutbox(kt *kit.Kit, conf cc.AuditStream, daoSet dao.Set, outboxes []tableaudit.OutboxTable,
+	ids []uint64, reason string) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	delay := min(time.Duration(conf.IntervalSec)*time.Second<<min(outboxes[0].RetryCount, 16), maxStreamRetryDelay)
+	return daoSet.AuditOutbox().Retry(kt, ids, reason, uint64(delay.Seconds()), conf.MaxRetry)
+}
+
+// listOutboxAudits list the audits of outbox in the order of outbox from primary database, returns the audits and
+// the outboxes whose audit no longer exists (e.g. archived).
+func listOutboxAudits(kt *kit.Kit, daoSet dao.Set, outboxes []tableaudit.OutboxTable) ([]tableaudit.AuditTable,
+	[]tableaudit.OutboxTable, error) {
+
+	tenantSeqs := make(map[string][]uint64)
+	for _, one := range outboxes {
+		tenantSeqs[one.TenantID] = append(tenantSeqs[one.TenantID], one.Seq)
+	}
+
+	auditMap := make(map[string]tableaudit.AuditTable, len(outboxes))
+	for tenantID, seqs := range tenantSeqs {
+		audits, err := daoSet.Audit().ListBySeqs(kt.NewSubKitWithTenant(tenantID), seqs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, one := range audits {
+			one.TenantID = tenantID
+			auditMap[outboxKey(tenantID, one.Seq)] = one
+		}
+	}
+
+	audits := make([]tableaudit.AuditTable, 0, len(outboxes))
+	missing := make([]tableaudit.OutboxTable, 0)
+	for _, one := range outboxes {
+		audit, exists := auditMap[outboxKey(one.TenantID, one.Seq)]
+		if !exists {
+			missing = append(missing, one)
+			continue
+		}
+		audits = append(audits, audit)
+	}
+
+	return audits, missing, nil
+}
+
+// checkOutboxArchived check whether the audits of outboxes are archived by the seq range of archive manifests,
+// returns the outbox ids of archived audits and the outbox ids whose audits are not found in archives.
+func checkOutboxArchived(kt *kit.Kit, daoSet dao.Set, outboxes []tableaudit.OutboxTable) ([]uint64, []uint64,
+	error) {
+
+	tenantOutboxes := make(map[string][]tableaudit.OutboxTable)
+	for _, one := range outboxes {
+		tenantOutboxes[one.TenantID] = append(tenantOutboxes[one.TenantID], one)
+	}
+
+	archivedIDs, unknownIDs := make([]uint64, 0), make([]uint64, 0)
+	for tenantID, list := range tenantOutboxes {
+		minSeq, maxSeq := list[0].Seq, list[0].Seq
+		for _, one := range list {
+			minSeq, maxSeq = min(minSeq, one.Seq), max(maxSeq, one.Seq)
+		}
+
+		subKit := kt.NewSubKitWithTenant(tenantID)
+		subKit.Ctx = orm.WithPrimary(subKit.Ctx)
+		opt := &types.ListOption{
+			Filter: tools.ExpressionAnd(
+				tools.RuleLessThanEqual("min_seq", maxSeq),
+				tools.RuleGreaterThanEqual("max_seq", minSeq),
+				tools.RuleGreaterThan("min_seq", 0),
+			),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"id", "min_seq", "max_seq"},
+		}
+		archives, err := daoSet.AuditArchive().List(subKit, opt)
+		if err != nil {
+			logs.Errorf("list audit archive by seq failed, err: %v, tenant: %s, seq: %d-%d, rid: %s", err,
+				tenantID, minSeq, maxSeq, kt.Rid)
+			return nil, nil, err
+		}
+
+		for _, one := range list {
+			archived := false
+			for _, archive := range archives.Details {
+				if one.Seq >= archive.MinSeq && one.Seq <= archive.MaxSeq {
+					archived = true
+					break
+				}
+			}
+
+			if archived {
+				archivedIDs = append(archivedIDs, one.ID)
+				continue
+			}
+
+			logs.Warnf("audit of outbox %d is not found, tenant: %s, seq: %d, rid: %s", one.ID, one.TenantID,
+				one.Seq, kt.Rid)
+			unknownIDs = append(unknownIDs, one.ID)
+		}
+	}
+
+	return archivedIDs, unknownIDs, nil
+}
+
+func outboxKey(tenantID string, seq uint64) string {
+	return fmt.Sprintf("%s/%d", tenantID, seq)
+}
+
+// sendToSinks send audits to all sinks, returns the joined errors of failed sinks.
+func sendToSinks(kt *kit.Kit, sinks []siem.Sink, audits []tableaudit.AuditTable) error {
+	errs := make([]string, 0)
+	for _, sink := range sinks {
+		if err := sink.Send(kt, audits); err != nil {
+			logs.Errorf("send audit to sink %s failed, err: %v, count: %d, rid: %s", sink.Name(), err, len(audits),
+				kt.Rid)
+			errs = append(errs, fmt.Sprintf("%s: %v", sink.Name(), err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"hcm/pkg/cc"
+	daoaudit "hcm/pkg/dal/dao/audit"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/siem"
+)
+
+type fakeAuditDao struct {
+	daoaudit.Interface
+	audits []tableaudit.AuditTable
+}
+
+func (f *fakeAuditDao) ListBySeqs(kt *kit.Kit, seqs []uint64) ([]tableaudit.AuditTable, error) {
+	result := make([]tableaudit.AuditTable, 0)
+	for _, one := range f.audits {
+		for _, seq := range seqs {
+			if one.Seq == seq {
+				result = append(result, one)
+			}
+		}
+	}
+	return result, nil
+}
+
+type fakeOutboxDao struct {
+	daoaudit.OutboxInterface
+	outboxes []tableaudit.OutboxTable
+	deleted  []uint64
+	retried  map[uint64]string
+}
+
+func (f *fakeOutboxDao) ListPending(kt *kit.Kit, limit uint) ([]tableaudit.OutboxTable, error) {
+	return f.outboxes, nil
+}
+
+func (f *fakeOutboxDao) DeleteByIDs(kt *kit.Kit, ids []uint64) error {
+	f.deleted = append(f.deleted, ids...)
+	return nil
+}
+
+func (f *fakeOutboxDao) Retry(kt *kit.Kit, ids []uint64, reason string, delaySec uint64, maxRetry uint) error {
+	for _, id := range ids {
+		f.retried[id] = reason
+	}
+	return nil
+}
+
+type fakeSink struct {
+	err  error
+	sent []uint64
+}
+
+func (f *fakeSink) Name() string {
+	return "fake"
+}
+
+func (f *fakeSink) Send(kt *kit.Kit, audits []tableaudit.AuditTable) error {
+	for _, one := range audits {
+		f.sent = append(f.sent, one.Seq)
+	}
+	return f.err
+}
+
+func (f *fakeSink) Close() error {
+	return nil
+}
+
+func newStreamDaoSet() (*fakeDaoSet, *fakeOutboxDao) {
+	outbox := &fakeOutboxDao{
+		outboxes: []tableaudit.OutboxTable{
+			{ID: 1, TenantID: "default", Seq: 10},
+			{ID: 2, TenantID: "default", Seq: 11},
+			{ID: 3, TenantID: "default", Seq: 12},
+		},
+		retried: make(map[uint64]string),
+	}
+
+	// 序号10的审计已归档，序号12的审计在审计表和归档中都不存在
+	daoSet := &fakeDaoSet{
+		archives: []tableaudit.ArchiveTable{{ID: "a1", MinSeq: 1, MaxSeq: 10}},
+		audit:    &fakeAuditDao{audits: []tableaudit.AuditTable{{ID: 111, Seq: 11}}},
+		outbox:   outbox,
+	}
+	return daoSet, outbox
+}
+
+func TestStreamAuditBatch(t *testing.T) {
+	daoSet, outbox := newStreamDaoSet()
+	sink := new(fakeSink)
+	conf := cc.AuditStream{IntervalSec: 10, BatchSize: 100, MaxRetry: 3}
+
+	count, err := streamAuditBatch(kit.New(), conf, []siem.Sink{sink}, daoSet)
+	if err != nil {
+		t.Fatalf("stream audit failed, err: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("unexpected count: %d", count)
+	}
+
+	if !reflect.DeepEqual(sink.sent, []uint64{11}) {
+		t.Errorf("unexpected sent seqs: %v", sink.sent)
+	}
+
+	// 只删除已推送及确认已归档的发件箱记录，找不到审计的记录保留重试
+	sort.Slice(outbox.deleted, func(i, j int) bool { return outbox.deleted[i] < outbox.deleted[j] })
+	if !reflect.DeepEqual(outbox.deleted, []uint64{1, 2}) {
+		t.Errorf("unexpected deleted outbox: %v", outbox.deleted)
+	}
+
+	if _, exists := outbox.retried[3]; !exists || len(outbox.retried) != 1 {
+		t.Errorf("unexpected retried outbox: %v", outbox.retried)
+	}
+}
+
+func TestStreamAuditBatchSendFailed(t *testing.T) {
+	daoSet, outbox := newStreamDaoSet()
+	sink := &fakeSink{err: errors.New("unavailable")}
+	conf := cc.AuditStream{IntervalSec: 10, BatchSize: 100, MaxRetry: 3}
+
+	if _, err := streamAuditBatch(kit.New(), conf, []siem.Sink{sink}, daoSet); err == nil {
+		t.Fatalf("stream audit should fail")
+	}
+
+	if !reflect.DeepEqual(outbox.deleted, []uint64{1}) {
+		t.Errorf("unexpected deleted outbox: %v", outbox.deleted)
+	}
+
+	if len(outbox.retried) != 2 || len(outbox.retried[2]) == 0 || len(outbox.retried[3]) == 0 {
+		t.Errorf("unexpected retried outbox: %v", outbox.retried)
+	}
+}
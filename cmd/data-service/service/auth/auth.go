@@ -41,7 +41,8 @@ func InitAuthService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("ListAuthInstances", "POST", "/list/auth/instances", svr.ListAuthInstances)
+	h.Add("ListAuthInstances", "POST", "/list/auth/instances", svr.ListAuthInstances).
+		Reads(new(dataservice.ListInstancesReq))
 	h.Load(cap.WebService)
 }
 
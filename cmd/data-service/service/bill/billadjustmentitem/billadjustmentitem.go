@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,12 +37,16 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillAdjustmentItem", http.MethodPost, "/bills/adjustment_items/create", svc.CreateBillAdjustmentItem)
-	h.Add("DeleteBillAdjustmentItem", http.MethodDelete, "/bills/adjustment_items", svc.DeleteBillAdjustmentItem)
-	h.Add("UpdateBillAdjustmentItem", http.MethodPut, "/bills/adjustment_items", svc.UpdateBillAdjustmentItem)
-	h.Add("ListBillAdjustmentItem", http.MethodPost, "/bills/adjustment_items/list", svc.ListBillAdjustmentItem)
+	h.Add("CreateBillAdjustmentItem", http.MethodPost, "/bills/adjustment_items/create", svc.CreateBillAdjustmentItem).
+		Reads(new(dsbill.BatchBillAdjustmentItemCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("DeleteBillAdjustmentItem", http.MethodDelete, "/bills/adjustment_items", svc.DeleteBillAdjustmentItem).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillAdjustmentItem", http.MethodPut, "/bills/adjustment_items", svc.UpdateBillAdjustmentItem).
+		Reads(new(dsbill.BillAdjustmentItemUpdateReq))
+	h.Add("ListBillAdjustmentItem", http.MethodPost, "/bills/adjustment_items/list", svc.ListBillAdjustmentItem).
+		Reads(new(dsbill.BillAdjustmentItemListReq)).Writes(new(dsbill.BillAdjustmentItemListResult))
 	h.Add("BatchConfirmBillAdjustmentItem", http.MethodPost,
-		"/bills/adjustment_items/confirm", svc.BatchConfirmBillAdjustmentItem)
+		"/bills/adjustment_items/confirm", svc.BatchConfirmBillAdjustmentItem).Reads(new(core.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,10 +37,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillDailyPullTask", http.MethodPost, "/bills/dailypulltasks", svc.CreateBillDailyPullTask)
-	h.Add("DeleteBillDailyPullTask", http.MethodDelete, "/bills/dailypulltasks", svc.DeleteBillDailyPullTask)
-	h.Add("UpdateBillDailyPullTask", http.MethodPut, "/bills/dailypulltasks", svc.UpdateBillDailyPullTask)
-	h.Add("ListBillDailyPullTask", http.MethodGet, "/bills/dailypulltasks", svc.ListBillDailyPullTask)
+	h.Add("CreateBillDailyPullTask", http.MethodPost, "/bills/dailypulltasks", svc.CreateBillDailyPullTask).
+		Reads(new(dsbill.BillDailyPullTaskCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBillDailyPullTask", http.MethodDelete, "/bills/dailypulltasks", svc.DeleteBillDailyPullTask).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillDailyPullTask", http.MethodPut, "/bills/dailypulltasks", svc.UpdateBillDailyPullTask).
+		Reads(new(dsbill.BillDailyPullTaskUpdateReq))
+	h.Add("ListBillDailyPullTask", http.MethodGet, "/bills/dailypulltasks", svc.ListBillDailyPullTask).
+		Reads(new(dsbill.BillDailyPullTaskListReq)).Writes(new(dsbill.BillDailyPullTaskListResult))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,10 +37,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillExchangeRate", http.MethodPost, "/bills/exchange_rates/batch/create", svc.CreateBillExchangeRate)
-	h.Add("BatchDeleteExchangeRate", http.MethodDelete, "/bills/exchange_rates/batch", svc.BatchDeleteExchangeRate)
-	h.Add("UpdateBillExchangeRate", http.MethodPatch, "/bills/exchange_rates", svc.UpdateBillExchangeRate)
-	h.Add("ListBillExchangeRate", http.MethodPost, "/bills/exchange_rates/list", svc.ListBillExchangeRate)
+	h.Add("CreateBillExchangeRate", http.MethodPost, "/bills/exchange_rates/batch/create", svc.CreateBillExchangeRate).
+		Reads(new(dsbill.BatchCreateBillExchangeRateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchDeleteExchangeRate", http.MethodDelete, "/bills/exchange_rates/batch", svc.BatchDeleteExchangeRate).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillExchangeRate", http.MethodPatch, "/bills/exchange_rates", svc.UpdateBillExchangeRate).
+		Reads(new(dsbill.ExchangeRateUpdateReq))
+	h.Add("ListBillExchangeRate", http.MethodPost, "/bills/exchange_rates/list", svc.ListBillExchangeRate).
+		Reads(new(core.ListReq)).Writes(new(dsbill.ExchangeRateListResult))
 
 	h.Load(cap.WebService)
 }
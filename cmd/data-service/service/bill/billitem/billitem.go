@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -36,15 +39,19 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListBillItemExt", http.MethodPost, "/vendors/{vendor}/bills/items/list", svc.ListBillItemExt)
-	h.Add("ListBillItem", http.MethodPost, "/bills/items/list", svc.ListBillItem)
-	h.Add("SumBillItemCost", http.MethodPost, "/bills/items/sum", svc.SumBillItemCost)
+	h.Add("ListBillItem", http.MethodPost, "/bills/items/list", svc.ListBillItem).
+		Reads(new(dsbill.BillItemListReq)).Writes(new(dsbill.BillItemBaseListResult))
+	h.Add("SumBillItemCost", http.MethodPost, "/bills/items/sum", svc.SumBillItemCost).
+		Reads(new(dsbill.BillItemSumReq)).Writes(new(dsbill.BillItemSumResult))
 
-	h.Add("ListBillItemRaw", http.MethodPost, "/bills/items/list_with_extension", svc.ListBillItemRaw)
+	h.Add("ListBillItemRaw", http.MethodPost, "/bills/items/list_with_extension", svc.ListBillItemRaw).
+		Reads(new(dsbill.BillItemListReq)).Writes(new(core.ListResultT[*bill.BillItemRaw]))
 
 	h.Add("CreateBillItem", http.MethodPost, "/vendors/{vendor}/bills/items/create", svc.CreateBillItem)
 	h.Add("CreateBillItemRaw", http.MethodPost, "/vendors/{vendor}/bills/rawitems/create", svc.CreateBillItemRaw)
-	h.Add("DeleteBillItem", http.MethodDelete, "/bills/items", svc.DeleteBillItem)
-	h.Add("UpdateBillItem", http.MethodPut, "/vendors/{vendor}/bills/items/update", svc.UpdateBillItem)
+	h.Add("DeleteBillItem", http.MethodDelete, "/bills/items", svc.DeleteBillItem).Reads(new(dsbill.BillItemDeleteReq))
+	h.Add("UpdateBillItem", http.MethodPut, "/vendors/{vendor}/bills/items/update", svc.UpdateBillItem).
+		Reads(new(dsbill.BillItemUpdateReq))
 
 	h.Load(cap.WebService)
 }
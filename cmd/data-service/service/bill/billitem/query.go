@@ -64,7 +64,7 @@ func (svc *service) ListBillItemRaw(cts *rest.Contexts) (any, error) {
 		})
 	}
 
-	return &core.ListResultT[*bill.BillItemRaw]{Details: details, Count: data.Count}, nil
+	return &core.ListResultT[*bill.BillItemRaw]{Details: details, Count: data.Count, NextCursor: data.NextCursor}, nil
 }
 
 // ListBillItem list bill item with options
@@ -93,7 +93,7 @@ func (svc *service) ListBillItem(cts *rest.Contexts) (interface{}, error) {
 		details[idx] = convBillItem(&d)
 	}
 
-	return &dataproto.BillItemBaseListResult{Details: details, Count: data.Count}, nil
+	return &dataproto.BillItemBaseListResult{Details: details, Count: data.Count, NextCursor: data.NextCursor}, nil
 }
 
 // SumBillItemCost sum bill item with given filter
@@ -169,7 +169,7 @@ func listBillItemExt[E bill.BillItemExtension](cts *rest.Contexts, svc *service,
 		}
 	}
 
-	return &core.ListResultT[*bill.BillItem[E]]{Details: details, Count: data.Count}, nil
+	return &core.ListResultT[*bill.BillItem[E]]{Details: details, Count: data.Count, NextCursor: data.NextCursor}, nil
 }
 
 func convBillItemExt[E bill.BillItemExtension](m *tablebill.AccountBillItem) (*bill.BillItem[E], error) {
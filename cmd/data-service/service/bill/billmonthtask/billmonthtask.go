@@ -24,7 +24,11 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types/bill"
 	"hcm/pkg/rest"
 )
 
@@ -34,10 +38,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillMonthTask", http.MethodPost, "/bills/month_tasks/create", svc.CreateBillMonthTask)
-	h.Add("DeleteBillMonthTask", http.MethodDelete, "/bills/month_tasks/batch", svc.DeleteBillMonthTask)
-	h.Add("UpdateBillMonthTask", http.MethodPut, "/bills/month_tasks", svc.UpdateBillMonthTask)
-	h.Add("ListBillMonthTask", http.MethodGet, "/bills/month_tasks/list", svc.ListBillMonthTask)
+	h.Add("CreateBillMonthTask", http.MethodPost, "/bills/month_tasks/create", svc.CreateBillMonthTask).
+		Reads(new(dsbill.BillMonthTaskCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBillMonthTask", http.MethodDelete, "/bills/month_tasks/batch", svc.DeleteBillMonthTask).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillMonthTask", http.MethodPut, "/bills/month_tasks", svc.UpdateBillMonthTask).
+		Reads(new(dsbill.BillMonthTaskUpdateReq))
+	h.Add("ListBillMonthTask", http.MethodGet, "/bills/month_tasks/list", svc.ListBillMonthTask).
+		Reads(new(dsbill.BillMonthTaskListReq)).Writes(new(bill.ListAccountBillMonthPullTaskDetails))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,10 +37,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillSummaryDaily", http.MethodPost, "/bills/summarydailys", svc.CreateBillSummaryDaily)
-	h.Add("DeleteBillSummaryDaily", http.MethodDelete, "/bills/summarydailys", svc.DeleteBillSummaryDaily)
-	h.Add("UpdateBillSummaryDaily", http.MethodPut, "/bills/summarydailys", svc.UpdateBillSummaryDaily)
-	h.Add("ListBillSummaryDaily", http.MethodGet, "/bills/summarydailys", svc.ListBillSummaryDaily)
+	h.Add("CreateBillSummaryDaily", http.MethodPost, "/bills/summarydailys", svc.CreateBillSummaryDaily).
+		Reads(new(dsbill.BillSummaryDailyCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBillSummaryDaily", http.MethodDelete, "/bills/summarydailys", svc.DeleteBillSummaryDaily).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillSummaryDaily", http.MethodPut, "/bills/summarydailys", svc.UpdateBillSummaryDaily).
+		Reads(new(dsbill.BillSummaryDailyUpdateReq))
+	h.Add("ListBillSummaryDaily", http.MethodGet, "/bills/summarydailys", svc.ListBillSummaryDaily).
+		Reads(new(dsbill.BillSummaryDailyListReq)).Writes(new(dsbill.BillSummaryDailyListResult))
 
 	h.Load(cap.WebService)
 }
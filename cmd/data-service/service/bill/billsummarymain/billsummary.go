@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,12 +37,17 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("BatchCreateBillSummaryMain", http.MethodPost, "/bills/summarymains", svc.BatchCreateBillSummaryMain)
-	h.Add("DeleteBillSummaryMain", http.MethodDelete, "/bills/summarymains", svc.DeleteBillSummaryMain)
-	h.Add("UpdateBillSummaryMain", http.MethodPut, "/bills/summarymains", svc.UpdateBillSummaryMain)
-	h.Add("ListBillSummaryMain", http.MethodGet, "/bills/summarymains", svc.ListBillSummaryMain)
+	h.Add("BatchCreateBillSummaryMain", http.MethodPost, "/bills/summarymains", svc.BatchCreateBillSummaryMain).
+		Reads(new(dsbill.BillSummaryMainCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBillSummaryMain", http.MethodDelete, "/bills/summarymains", svc.DeleteBillSummaryMain).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillSummaryMain", http.MethodPut, "/bills/summarymains", svc.UpdateBillSummaryMain).
+		Reads(new(dsbill.BillSummaryMainUpdateReq))
+	h.Add("ListBillSummaryMain", http.MethodGet, "/bills/summarymains", svc.ListBillSummaryMain).
+		Reads(new(dsbill.BillSummaryMainListReq)).Writes(new(dsbill.BillSummaryMainListResult))
 
-	h.Add("ListBillSummaryBiz", http.MethodGet, "/bills/summarybiz", svc.ListBillSummaryBiz)
+	h.Add("ListBillSummaryBiz", http.MethodGet, "/bills/summarybiz", svc.ListBillSummaryBiz).
+		Reads(new(core.ListReq)).Writes(new(dsbill.BillSummaryBizListResult))
 
 	h.Load(cap.WebService)
 }
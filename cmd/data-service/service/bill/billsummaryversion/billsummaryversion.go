@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,10 +37,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillSummaryVersion", http.MethodPost, "/bills/summaryversions", svc.CreateBillSummaryVersion)
-	h.Add("DeleteBillSummaryVersion", http.MethodDelete, "/bills/summaryversions", svc.DeleteBillSummaryVersion)
-	h.Add("UpdateBillSummaryVersion", http.MethodPut, "/bills/summaryversions", svc.UpdateBillSummaryVersion)
-	h.Add("ListBillSummaryVersion", http.MethodGet, "/bills/summaryversions", svc.ListBillSummaryVersion)
+	h.Add("CreateBillSummaryVersion", http.MethodPost, "/bills/summaryversions", svc.CreateBillSummaryVersion).
+		Reads(new(dsbill.BillSummaryVersionCreateReq)).Writes(new(core.CreateResult))
+	h.Add("DeleteBillSummaryVersion", http.MethodDelete, "/bills/summaryversions", svc.DeleteBillSummaryVersion).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillSummaryVersion", http.MethodPut, "/bills/summaryversions", svc.UpdateBillSummaryVersion).
+		Reads(new(dsbill.BillSummaryVersionUpdateReq))
+	h.Add("ListBillSummaryVersion", http.MethodGet, "/bills/summaryversions", svc.ListBillSummaryVersion).
+		Reads(new(dsbill.BillSummaryVersionListReq)).Writes(new(dsbill.BillSummaryVersionListResult))
 
 	h.Load(cap.WebService)
 }
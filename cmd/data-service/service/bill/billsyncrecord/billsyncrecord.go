@@ -22,6 +22,9 @@ package billsyncrecord
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 	"net/http"
@@ -33,10 +36,14 @@ func InitService(cap *capability.Capability) {
 		dao: cap.Dao,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateBillSyncRecord", http.MethodPost, "/bills/sync_records/create", svc.CreateBillSyncRecord)
-	h.Add("DeleteBillSyncRecord", http.MethodDelete, "/bills/sync_records", svc.DeleteBillSyncRecord)
-	h.Add("UpdateBillSyncRecord", http.MethodPut, "/bills/sync_records", svc.UpdateBillSyncRecord)
-	h.Add("ListBillSyncRecord", http.MethodPost, "/bills/sync_records/list", svc.ListBillSyncRecord)
+	h.Add("CreateBillSyncRecord", http.MethodPost, "/bills/sync_records/create", svc.CreateBillSyncRecord).
+		Reads(new(dsbill.BatchBillSyncRecordCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("DeleteBillSyncRecord", http.MethodDelete, "/bills/sync_records", svc.DeleteBillSyncRecord).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("UpdateBillSyncRecord", http.MethodPut, "/bills/sync_records", svc.UpdateBillSyncRecord).
+		Reads(new(dsbill.BillSyncRecordUpdateReq))
+	h.Add("ListBillSyncRecord", http.MethodPost, "/bills/sync_records/list", svc.ListBillSyncRecord).
+		Reads(new(dsbill.BillSyncRecordListReq)).Writes(new(dsbill.BillSyncRecordListResult))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/rest"
 )
@@ -34,15 +36,16 @@ func InitService(cap *capability.Capability) {
 		ostore: cap.ObjectStore,
 	}
 	h := rest.NewHandler()
-	h.Add("CreateRawBill", http.MethodPost, "bills/rawbills", svc.CreateRawBill)
+	h.Add("CreateRawBill", http.MethodPost, "bills/rawbills", svc.CreateRawBill).
+		Reads(new(dsbill.RawBillCreateReq)).Writes(new(core.CreateResult))
 	h.Add("ListRawBill", http.MethodGet,
 		"bills/rawbills/{vendor}/{root_account_id}/{account_id}/{bill_year}/{bill_month}/{version}/{bill_date}",
-		svc.ListRawBill)
+		svc.ListRawBill).Writes(new(dsbill.RawBillItemNameListResult))
 	h.Add("QueryRawBillDetail", http.MethodGet,
 		"bills/rawbills/{vendor}/{root_account_id}/{account_id}"+
 			"/{bill_year}/{bill_month}/{version}/{bill_date}/{bill_name}",
-		svc.QueryRawBillDetail)
-	h.Add("DeleteRawBill", http.MethodDelete, "bills/rawbills", svc.DeleteRawBill)
+		svc.QueryRawBillDetail).Writes(new(dsbill.RawBillItemQueryResult))
+	h.Add("DeleteRawBill", http.MethodDelete, "bills/rawbills", svc.DeleteRawBill).Reads(new(dsbill.RawBillDeleteReq))
 
 	h.Load(cap.WebService)
 }
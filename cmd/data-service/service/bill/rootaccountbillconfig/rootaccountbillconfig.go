@@ -22,6 +22,9 @@ package rootaccountbillconfig
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -33,16 +36,17 @@ func InitService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("ListRootAccountBillConfig", "POST", "/bills/root_account_config/list", svc.ListRootAccountBillConfig)
+	h.Add("ListRootAccountBillConfig", "POST", "/bills/root_account_config/list", svc.ListRootAccountBillConfig).
+		Reads(new(core.ListReq)).Writes(new(dsbill.RootAccountBillConfigListResult))
 	h.Add("ListRootAccountBillConfigExt", "POST", "/vendors/{vendor}/bills/root_account_config/list",
-		svc.ListRootAccountBillConfigExt)
+		svc.ListRootAccountBillConfigExt).Reads(new(core.ListReq))
 	h.Add("GetRootBillConfig", "GET", "/vendors/{vendor}/bills/root_account_config/{id}", svc.GetRootAccountBillConfig)
 	h.Add("BatchCreateRootAccountBillConfig", "POST", "/vendors/{vendor}/bills/root_account_config/batch/create",
 		svc.BatchCreateRootAccountBillConfig)
 	h.Add("BatchUpdateRootAccountBillConfig", "PATCH", "/vendors/{vendor}/bills/root_account_config/batch",
 		svc.BatchUpdateRootAccountBillConfig)
 	h.Add("BatchDeleteRootAccountBillConfig", "DELETE", "/bills/root_account_config/batch",
-		svc.BatchDeleteRootAccountBillConfig)
+		svc.BatchDeleteRootAccountBillConfig).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreselection "hcm/pkg/api/core/cloud-selection"
+	dsselection "hcm/pkg/api/data-service/cloud-selection"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -36,19 +39,19 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListScheme", http.MethodPost, "/clouds/selections/schemes/list",
-		svc.ListScheme)
+		svc.ListScheme).Reads(new(core.ListReq)).Writes(new(core.ListResultT[coreselection.Scheme]))
 	h.Add("BatchDeleteScheme", http.MethodDelete, "/clouds/selections/schemes/batch",
-		svc.BatchDeleteScheme)
+		svc.BatchDeleteScheme).Reads(new(core.BatchDeleteReq))
 	h.Add("CreateScheme", http.MethodPost, "/clouds/selections/schemes/create",
-		svc.CreateScheme)
+		svc.CreateScheme).Reads(new(dsselection.SchemeCreateReq)).Writes(new(core.CreateResult))
 	h.Add("UpdateScheme", http.MethodPatch, "/clouds/selections/schemes/{id}",
-		svc.UpdateScheme)
+		svc.UpdateScheme).Reads(new(dsselection.SchemeUpdateReq))
 
 	h.Add("ListIdc", http.MethodPost, "/clouds/selections/idcs/list",
-		svc.ListIdc)
+		svc.ListIdc).Reads(new(core.ListReq)).Writes(new(core.ListResultT[coreselection.Idc]))
 
 	h.Add("ListBizType", http.MethodPost, "/clouds/selections/biz_types/list",
-		svc.ListBizType)
+		svc.ListBizType).Reads(new(core.ListReq)).Writes(new(core.ListResultT[coreselection.BizType]))
 
 	h.Load(cap.WebService)
 }
@@ -21,6 +21,8 @@ package accountbizrel
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -33,9 +35,12 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("UpdateAccountBizRel", "PUT", "/account_biz_rels/accounts/{account_id}", svc.UpdateAccountBizRel)
-	h.Add("ListAccountBizRel", "POST", "/account_biz_rels/list", svc.ListAccountBizRel)
-	h.Add("ListWithAccount", "POST", "/account_biz_rels/with/accounts/list", svc.ListWithAccount)
+	h.Add("UpdateAccountBizRel", "PUT", "/account_biz_rels/accounts/{account_id}", svc.UpdateAccountBizRel).
+		Reads(new(protocloud.AccountBizRelUpdateReq))
+	h.Add("ListAccountBizRel", "POST", "/account_biz_rels/list", svc.ListAccountBizRel).
+		Reads(new(core.ListReq)).Writes(new(protocloud.AccountBizRelListResult))
+	h.Add("ListWithAccount", "POST", "/account_biz_rels/with/accounts/list", svc.ListWithAccount).
+		Reads(new(protocloud.AccountBizRelWithAccountListReq)).Writes(new([]*protocloud.AccountBizRelWithAccount))
 
 	h.Load(cap.WebService)
 }
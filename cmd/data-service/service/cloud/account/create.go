@@ -50,6 +50,10 @@ func (svc *service) CreateAccount(cts *rest.Contexts) (interface{}, error) {
 		return createAccount[protocloud.AwsAccountExtensionCreateReq](vendor, svc, cts)
 	case enumor.HuaWei:
 		return createAccount[protocloud.HuaWeiAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.Aliyun:
+		return createAccount[protocloud.AliyunAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.OpenStack:
+		return createAccount[protocloud.OpenStackAccountExtensionCreateReq](vendor, svc, cts)
 	case enumor.Gcp:
 		return createAccount[protocloud.GcpAccountExtensionCreateReq](vendor, svc, cts)
 	case enumor.Azure:
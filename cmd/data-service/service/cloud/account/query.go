@@ -122,6 +122,10 @@ func (svc *service) GetAccount(cts *rest.Contexts) (interface{}, error) {
 		account, err = convertToAccountResult[protocore.AwsAccountExtension](baseAccount, dbAccount.Extension, svc)
 	case enumor.HuaWei:
 		account, err = convertToAccountResult[protocore.HuaWeiAccountExtension](baseAccount, dbAccount.Extension, svc)
+	case enumor.Aliyun:
+		account, err = convertToAccountResult[protocore.AliyunAccountExtension](baseAccount, dbAccount.Extension, svc)
+	case enumor.OpenStack:
+		account, err = convertToAccountResult[protocore.OpenStackAccountExtension](baseAccount, dbAccount.Extension, svc)
 	case enumor.Gcp:
 		account, err = convertToAccountResult[protocore.GcpAccountExtension](baseAccount, dbAccount.Extension, svc)
 	case enumor.Azure:
@@ -357,6 +361,10 @@ func (svc *service) ListAccountWithExtension(cts *rest.Contexts) (interface{}, e
 			extension, err = convertToAccountExtension[protocore.AwsAccountExtension](account.Extension, svc)
 		case enumor.HuaWei:
 			extension, err = convertToAccountExtension[protocore.HuaWeiAccountExtension](account.Extension, svc)
+		case enumor.Aliyun:
+			extension, err = convertToAccountExtension[protocore.AliyunAccountExtension](account.Extension, svc)
+		case enumor.OpenStack:
+			extension, err = convertToAccountExtension[protocore.OpenStackAccountExtension](account.Extension, svc)
 		case enumor.Gcp:
 			extension, err = convertToAccountExtension[protocore.GcpAccountExtension](account.Extension, svc)
 		case enumor.Azure:
@@ -21,6 +21,7 @@ package account
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
@@ -38,10 +39,13 @@ func InitService(cap *capability.Capability) {
 	h.Add("CreateAccount", "POST", "/vendors/{vendor}/accounts/create", svc.CreateAccount)
 	h.Add("UpdateAccount", "PATCH", "/vendors/{vendor}/accounts/{account_id}", svc.UpdateAccount)
 	h.Add("GetAccount", "GET", "/vendors/{vendor}/accounts/{account_id}", svc.GetAccount)
-	h.Add("ListAccount", "POST", "/accounts/list", svc.ListAccount)
-	h.Add("ListAccountWithExtension", "POST", "/accounts/extensions/list", svc.ListAccountWithExtension)
-	h.Add("DeleteAccount", "DELETE", "/accounts", svc.DeleteAccount)
-	h.Add("DeleteValidate", "POST", "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
+	h.Add("ListAccount", "POST", "/accounts/list", svc.ListAccount).
+		Reads(new(protocloud.AccountListReq)).Writes(new(protocloud.AccountListResult))
+	h.Add("ListAccountWithExtension", "POST", "/accounts/extensions/list", svc.ListAccountWithExtension).
+		Reads(new(protocloud.AccountListReq)).Writes(new(protocloud.AccountWithExtensionListResult))
+	h.Add("DeleteAccount", "DELETE", "/accounts", svc.DeleteAccount).Reads(new(protocloud.AccountDeleteReq))
+	h.Add("DeleteValidate", "POST", "/accounts/{account_id}/delete/validate", svc.DeleteValidate).
+		Writes(new(map[string]uint64))
 
 	h.Load(cap.WebService)
 }
@@ -52,6 +52,10 @@ func (svc *service) UpdateAccount(cts *rest.Contexts) (interface{}, error) {
 		return updateAccount[protocloud.AwsAccountExtensionUpdateReq](accountID, svc, cts)
 	case enumor.HuaWei:
 		return updateAccount[protocloud.HuaWeiAccountExtensionUpdateReq](accountID, svc, cts)
+	case enumor.Aliyun:
+		return updateAccount[protocloud.AliyunAccountExtensionUpdateReq](accountID, svc, cts)
+	case enumor.OpenStack:
+		return updateAccount[protocloud.OpenStackAccountExtensionUpdateReq](accountID, svc, cts)
 	case enumor.Gcp:
 		return updateAccount[protocloud.GcpAccountExtensionUpdateReq](accountID, svc, cts)
 	case enumor.Azure:
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/rest"
+)
+
+// AggregateResource group the resources matched by filter, and calculate the aggregations of each group.
+func (svc cloudSvc) AggregateResource(cts *rest.Contexts) (interface{}, error) {
+	resType := enumor.CloudResourceType(cts.PathParameter("type").String())
+	if len(resType) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource type is required")
+	}
+
+	req := new(core.AggregateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	opt := &types.AggregateOption{
+		Filter:       req.Filter,
+		GroupBy:      req.GroupBy,
+		Aggregations: req.Aggregations,
+		Limit:        req.Limit,
+	}
+	return svc.dao.Aggregate().Aggregate(cts.Kit, resType, opt)
+}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	coreargstpl "hcm/pkg/api/core/cloud/argument-template"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -38,11 +40,16 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListArgsTpl", http.MethodPost, "/argument_templates/list", svc.ListArgsTpl)
-	h.Add("ListArgsTplExt", http.MethodPost, "/vendors/{vendor}/argument_templates/list", svc.ListArgsTplExt)
+	h.Add("ListArgsTpl", http.MethodPost, "/argument_templates/list", svc.ListArgsTpl).
+		Reads(new(protocloud.ArgsTplListReq)).Writes(new(protocloud.ArgsTplListResult))
+	h.Add("ListArgsTplExt", http.MethodPost, "/vendors/{vendor}/argument_templates/list", svc.ListArgsTplExt).
+		Reads(new(protocloud.ArgsTplListReq)).
+		Writes(new(protocloud.ArgsTplExtListResult[coreargstpl.TCloudArgsTplExtension]))
 	h.Add("CreateArgsTpl", http.MethodPost, "/vendors/{vendor}/argument_templates/create", svc.CreateArgsTpl)
-	h.Add("BatchUpdateArgsTpl", http.MethodPut, "/argument_templates", svc.BatchUpdateArgsTpl)
-	h.Add("BatchDeleteArgsTpl", http.MethodDelete, "/argument_templates/batch", svc.BatchDeleteArgsTpl)
+	h.Add("BatchUpdateArgsTpl", http.MethodPut, "/argument_templates", svc.BatchUpdateArgsTpl).
+		Reads(new(protocloud.ArgsTplBatchUpdateExprReq))
+	h.Add("BatchDeleteArgsTpl", http.MethodDelete, "/argument_templates/batch", svc.BatchDeleteArgsTpl).
+		Reads(new(protocloud.ArgsTplBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
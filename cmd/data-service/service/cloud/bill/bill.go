@@ -22,6 +22,9 @@ package bill
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -33,15 +36,17 @@ func InitBillConfigService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("ListBillConfig", "POST", "/bills/config/list", svc.ListBillConfig)
-	h.Add("ListBillConfigExt", "POST", "/vendors/{vendor}/bills/config/list", svc.ListBillConfigExt)
+	h.Add("ListBillConfig", "POST", "/bills/config/list", svc.ListBillConfig).
+		Reads(new(core.ListReq)).Writes(new(dsbill.AccountBillConfigListResult))
+	h.Add("ListBillConfigExt", "POST", "/vendors/{vendor}/bills/config/list", svc.ListBillConfigExt).
+		Reads(new(core.ListReq))
 	h.Add("GetBillConfig", "GET", "/vendors/{vendor}/bills/config/{id}", svc.GetBillConfig)
 	h.Add("BatchCreateAccountBillConfig", "POST", "/vendors/{vendor}/bills/config/batch/create",
 		svc.BatchCreateAccountBillConfig)
 	h.Add("BatchUpdateAccountBillConfig", "PATCH", "/vendors/{vendor}/bills/config/batch",
 		svc.BatchUpdateAccountBillConfig)
 	h.Add("BatchDeleteAccountBillConfig", "DELETE", "/bills/config/batch",
-		svc.BatchDeleteAccountBillConfig)
+		svc.BatchDeleteAccountBillConfig).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -24,6 +24,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	corecert "hcm/pkg/api/core/cloud/cert"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud/eip"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -38,12 +41,16 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListCert", http.MethodPost, "/certs/list", svc.ListCert)
-	h.Add("ListCertExt", http.MethodPost, "/vendors/{vendor}/certs/list", svc.ListCertExt)
+	h.Add("ListCert", http.MethodPost, "/certs/list", svc.ListCert).
+		Reads(new(protocloud.CertListReq)).Writes(new(protocloud.CertListResult))
+	h.Add("ListCertExt", http.MethodPost, "/vendors/{vendor}/certs/list", svc.ListCertExt).
+		Reads(new(dataproto.EipListReq)).Writes(new(protocloud.CertExtListResult[corecert.TCloudCertExtension]))
 	h.Add("CreateCert", http.MethodPost, "/vendors/{vendor}/certs/create", svc.CreateCert)
-	h.Add("BatchUpdateCert", http.MethodPatch, "/certs", svc.BatchUpdateCert)
+	h.Add("BatchUpdateCert", http.MethodPatch, "/certs", svc.BatchUpdateCert).
+		Reads(new(protocloud.CertBatchUpdateExprReq))
 	h.Add("BatchUpdateCertExt", http.MethodPatch, "/vendors/{vendor}/certs", svc.BatchUpdateCertExt)
-	h.Add("BatchDeleteCert", http.MethodDelete, "/certs/batch", svc.BatchDeleteCert)
+	h.Add("BatchDeleteCert", http.MethodDelete, "/certs/batch", svc.BatchDeleteCert).
+		Reads(new(protocloud.CertBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
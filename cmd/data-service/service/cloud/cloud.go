@@ -56,11 +56,17 @@ func InitCloudService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("GetResBasicInfo", http.MethodPost, "/cloud/resources/basics/{type}/id/{id}", svc.GetResourceBasicInfo)
-	h.Add("ListResBasicInfo", http.MethodPost, "/cloud/resources/basics/list", svc.ListResourceBasicInfo)
+	h.Add("GetResBasicInfo", http.MethodPost, "/cloud/resources/basics/{type}/id/{id}", svc.GetResourceBasicInfo).
+		Reads(new(protocloud.GetResourceBasicInfoReq)).Writes(new(types.CloudResourceBasicInfo))
+	h.Add("ListResBasicInfo", http.MethodPost, "/cloud/resources/basics/list", svc.ListResourceBasicInfo).
+		Reads(new(protocloud.ListResourceBasicInfoReq)).Writes(new(map[string]types.CloudResourceBasicInfo))
 	h.Add("BatchListResBasicInfo", http.MethodPost, "/cloud/resources/basics/batch/list",
-		svc.BatchListResourceBasicInfo)
-	h.Add("AssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs", svc.AssignResourceToBiz)
+		svc.BatchListResourceBasicInfo).
+		Reads(new(protocloud.BatchListResourceBasicInfoReq)).Writes(new(map[string]types.CloudResourceBasicInfo))
+	h.Add("AssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs", svc.AssignResourceToBiz).
+		Reads(new(protocloud.AssignResourceToBizReq))
+	h.Add("AggregateResource", http.MethodPost, "/cloud/resources/{type}/aggregate", svc.AggregateResource).
+		Reads(new(core.AggregateReq)).Writes(new(core.AggregateResult))
 
 	h.Load(cap.WebService)
 }
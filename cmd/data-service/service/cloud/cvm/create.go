@@ -53,6 +53,10 @@ func (svc *cvmSvc) BatchCreateCvm(cts *rest.Contexts) (interface{}, error) {
 		return batchCreateCvm[corecvm.AwsCvmExtension](cts, svc, vendor)
 	case enumor.HuaWei:
 		return batchCreateCvm[corecvm.HuaWeiCvmExtension](cts, svc, vendor)
+	case enumor.Aliyun:
+		return batchCreateCvm[corecvm.AliyunCvmExtension](cts, svc, vendor)
+	case enumor.OpenStack:
+		return batchCreateCvm[corecvm.OpenStackCvmExtension](cts, svc, vendor)
 	case enumor.Azure:
 		return batchCreateCvm[corecvm.AzureCvmExtension](cts, svc, vendor)
 	case enumor.Gcp:
@@ -25,6 +25,7 @@ import (
 
 	"hcm/cmd/data-service/service/capability"
 	"hcm/cmd/data-service/service/cloud/logics/cmdb"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -44,10 +45,14 @@ func InitService(cap *capability.Capability) {
 	h.Add("CreateCvm", http.MethodPost, "/vendors/{vendor}/cvms/batch/create", svc.BatchCreateCvm)
 	h.Add("BatchUpdateCvm", http.MethodPatch, "/vendors/{vendor}/cvms/batch/update", svc.BatchUpdateCvm)
 	h.Add("GetCvm", http.MethodGet, "/vendors/{vendor}/cvms/{id}", svc.GetCvm)
-	h.Add("ListCvm", http.MethodPost, "/cvms/list", svc.ListCvm)
-	h.Add("ListCvmExt", http.MethodPost, "/vendors/{vendor}/cvms/list", svc.ListCvmExt)
-	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm)
-	h.Add("BatchUpdateCvmCommonInfo", http.MethodPatch, "/cvms/common/info/batch/update", svc.BatchUpdateCvmCommonInfo)
+	h.Add("ListCvm", http.MethodPost, "/cvms/list", svc.ListCvm).
+		Reads(new(protocloud.CvmListReq)).Writes(new(protocloud.CvmListResult))
+	h.Add("ListCvmExt", http.MethodPost, "/vendors/{vendor}/cvms/list", svc.ListCvmExt).
+		Reads(new(protocloud.CvmExtListReq))
+	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm).
+		Reads(new(protocloud.CvmBatchDeleteReq))
+	h.Add("BatchUpdateCvmCommonInfo", http.MethodPatch, "/cvms/common/info/batch/update", svc.BatchUpdateCvmCommonInfo).
+		Reads(new(protocloud.CvmCommonInfoBatchUpdateReq))
 
 	h.Load(cap.WebService)
 }
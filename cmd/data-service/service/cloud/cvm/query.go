@@ -69,7 +69,7 @@ func (svc *cvmSvc) ListCvm(cts *rest.Contexts) (interface{}, error) {
 		details = append(details, *convTableToBaseCvm(&one))
 	}
 
-	return &protocloud.CvmListResult{Details: details}, nil
+	return &protocloud.CvmListResult{Details: details, NextCursor: result.NextCursor}, nil
 }
 
 // GetCvm cvm.
@@ -100,6 +100,10 @@ func (svc *cvmSvc) GetCvm(cts *rest.Contexts) (interface{}, error) {
 		return convCvmGetResult[corecvm.AwsCvmExtension](base, cvmTable.Extension)
 	case enumor.HuaWei:
 		return convCvmGetResult[corecvm.HuaWeiCvmExtension](base, cvmTable.Extension)
+	case enumor.Aliyun:
+		return convCvmGetResult[corecvm.AliyunCvmExtension](base, cvmTable.Extension)
+	case enumor.OpenStack:
+		return convCvmGetResult[corecvm.OpenStackCvmExtension](base, cvmTable.Extension)
 	case enumor.Azure:
 		return convCvmGetResult[corecvm.AzureCvmExtension](base, cvmTable.Extension)
 	case enumor.Gcp:
@@ -150,6 +154,7 @@ func convTableToBaseCvm(one *tablecvm.Table) *corecvm.BaseCvm {
 		Memo:                 one.Memo,
 		Status:               one.Status,
 		RecycleStatus:        one.RecycleStatus,
+		StatusChangedAt:      one.StatusChangedAt.String(),
 		PrivateIPv4Addresses: one.PrivateIPv4Addresses,
 		PrivateIPv6Addresses: one.PrivateIPv6Addresses,
 		PublicIPv4Addresses:  one.PublicIPv4Addresses,
@@ -220,25 +225,29 @@ func (svc *cvmSvc) ListCvmExt(cts *rest.Contexts) (interface{}, error) {
 
 	switch vendor {
 	case enumor.TCloud:
-		return convCvmListResult[corecvm.TCloudCvmExtension](result.Details)
+		return convCvmListResult[corecvm.TCloudCvmExtension](result)
 	case enumor.Aws:
-		return convCvmListResult[corecvm.AwsCvmExtension](result.Details)
+		return convCvmListResult[corecvm.AwsCvmExtension](result)
 	case enumor.HuaWei:
-		return convCvmListResult[corecvm.HuaWeiCvmExtension](result.Details)
+		return convCvmListResult[corecvm.HuaWeiCvmExtension](result)
+	case enumor.Aliyun:
+		return convCvmListResult[corecvm.AliyunCvmExtension](result)
+	case enumor.OpenStack:
+		return convCvmListResult[corecvm.OpenStackCvmExtension](result)
 	case enumor.Azure:
-		return convCvmListResult[corecvm.AzureCvmExtension](result.Details)
+		return convCvmListResult[corecvm.AzureCvmExtension](result)
 	case enumor.Gcp:
-		return convCvmListResult[corecvm.GcpCvmExtension](result.Details)
+		return convCvmListResult[corecvm.GcpCvmExtension](result)
 
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
 	}
 }
 
-func convCvmListResult[T corecvm.Extension](tables []tablecvm.Table) (*protocloud.CvmExtListResult[T], error) {
+func convCvmListResult[T corecvm.Extension](result *types.ListCvmDetails) (*protocloud.CvmExtListResult[T], error) {
 
-	details := make([]corecvm.Cvm[T], 0, len(tables))
-	for _, one := range tables {
+	details := make([]corecvm.Cvm[T], 0, len(result.Details))
+	for _, one := range result.Details {
 		extension := new(T)
 		if len(one.Extension) != 0 {
 			if err := json.UnmarshalFromString(string(one.Extension), &extension); err != nil {
@@ -253,6 +262,7 @@ func convCvmListResult[T corecvm.Extension](tables []tablecvm.Table) (*protoclou
 	}
 
 	return &protocloud.CvmExtListResult[T]{
-		Details: details,
+		Details:    details,
+		NextCursor: result.NextCursor,
 	}, nil
 }
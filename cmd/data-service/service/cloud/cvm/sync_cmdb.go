@@ -86,6 +86,12 @@ func SyncCvmToCmdb(kt *kit.Kit, accountID string, bkBizID int64) error {
 		case enumor.HuaWei:
 			err = upsertCmdbHosts[corecvm.HuaWeiCvmExtension](svc, kt, enumor.HuaWei,
 				converter.SliceToPtr(result.Details))
+		case enumor.Aliyun:
+			err = upsertCmdbHosts[corecvm.AliyunCvmExtension](svc, kt, enumor.Aliyun,
+				converter.SliceToPtr(result.Details))
+		case enumor.OpenStack:
+			err = upsertCmdbHosts[corecvm.OpenStackCvmExtension](svc, kt, enumor.OpenStack,
+				converter.SliceToPtr(result.Details))
 		case enumor.Gcp:
 			err = upsertCmdbHosts[corecvm.GcpCvmExtension](svc, kt, enumor.Gcp,
 				converter.SliceToPtr(result.Details))
@@ -54,6 +54,10 @@ func (svc *cvmSvc) BatchUpdateCvm(cts *rest.Contexts) (interface{}, error) {
 		return batchUpdateCvm[corecvm.AwsCvmExtension](cts, svc, vendor)
 	case enumor.HuaWei:
 		return batchUpdateCvm[corecvm.HuaWeiCvmExtension](cts, svc, vendor)
+	case enumor.Aliyun:
+		return batchUpdateCvm[corecvm.AliyunCvmExtension](cts, svc, vendor)
+	case enumor.OpenStack:
+		return batchUpdateCvm[corecvm.OpenStackCvmExtension](cts, svc, vendor)
 	case enumor.Azure:
 		return batchUpdateCvm[corecvm.AzureCvmExtension](cts, svc, vendor)
 	case enumor.Gcp:
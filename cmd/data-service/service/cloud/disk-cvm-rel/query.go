@@ -251,6 +251,10 @@ func (svc *relSvc) ListWithDiskExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoDiskExtWithCvmIDs[coredisk.AzureExtension](data)
 	case enumor.HuaWei:
 		return toProtoDiskExtWithCvmIDs[coredisk.HuaWeiExtension](data)
+	case enumor.Aliyun:
+		return toProtoDiskExtWithCvmIDs[coredisk.AliyunExtension](data)
+	case enumor.OpenStack:
+		return toProtoDiskExtWithCvmIDs[coredisk.OpenStackExtension](data)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,13 +35,20 @@ func InitService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("BatchCreate", http.MethodPost, "/disk_cvm_rels/batch/create", svc.BatchCreate)
-	h.Add("ListDiskCvmRel", http.MethodPost, "/disk_cvm_rels/list", svc.ListDiskCvmRel)
-	h.Add("ListDiskWithoutCvm", http.MethodPost, "/disk_cvm_rels/with/disks/without/cvm/list", svc.ListDiskWithoutCvm)
-	h.Add("ListWithDisk", http.MethodPost, "/disk_cvm_rels/with/disks/list", svc.ListWithDisk)
-	h.Add("ListWithCvm", http.MethodPost, "/disk_cvm_rels/with/cvms/list", svc.ListWithCvm)
-	h.Add("ListWithDiskExt", http.MethodPost, "/vendors/{vendor}/disk_cvm_rels/with/disks/list", svc.ListWithDiskExt)
-	h.Add("BatchDelete", http.MethodDelete, "/disk_cvm_rels/batch", svc.BatchDelete)
+	h.Add("BatchCreate", http.MethodPost, "/disk_cvm_rels/batch/create", svc.BatchCreate).
+		Reads(new(cloud.DiskCvmRelBatchCreateReq))
+	h.Add("ListDiskCvmRel", http.MethodPost, "/disk_cvm_rels/list", svc.ListDiskCvmRel).
+		Reads(new(cloud.DiskCvmRelListReq)).Writes(new(cloud.DiskCvmRelListResult))
+	h.Add("ListDiskWithoutCvm", http.MethodPost, "/disk_cvm_rels/with/disks/without/cvm/list", svc.ListDiskWithoutCvm).
+		Reads(new(cloud.ListDiskWithoutCvmReq)).Writes(new(cloud.ListDiskWithoutCvmResult))
+	h.Add("ListWithDisk", http.MethodPost, "/disk_cvm_rels/with/disks/list", svc.ListWithDisk).
+		Reads(new(cloud.DiskCvmRelWithDiskListReq)).Writes(new([]*cloud.DiskWithCvmID))
+	h.Add("ListWithCvm", http.MethodPost, "/disk_cvm_rels/with/cvms/list", svc.ListWithCvm).
+		Reads(new(cloud.ListWithCvmReq)).Writes(new(cloud.ListCvmResult))
+	h.Add("ListWithDiskExt", http.MethodPost, "/vendors/{vendor}/disk_cvm_rels/with/disks/list", svc.ListWithDiskExt).
+		Reads(new(cloud.DiskCvmRelWithDiskExtListReq))
+	h.Add("BatchDelete", http.MethodDelete, "/disk_cvm_rels/batch", svc.BatchDelete).
+		Reads(new(cloud.DiskCvmRelDeleteReq))
 
 	h.Load(cap.WebService)
 }
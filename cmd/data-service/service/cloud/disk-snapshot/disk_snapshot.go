@@ -0,0 +1,238 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDiskSnapshot batch create disk snapshot.
+func (svc *service) BatchCreateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.BatchCreateDiskSnapshotReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablesnapshot.DiskSnapshotTable, 0, len(req.Items))
+	for _, item := range req.Items {
+		models = append(models, tablesnapshot.DiskSnapshotTable{
+			Vendor:           item.Vendor,
+			AccountID:        item.AccountID,
+			CloudID:          item.CloudID,
+			BkBizID:          item.BkBizID,
+			Name:             item.Name,
+			Region:           item.Region,
+			Zone:             item.Zone,
+			DiskID:           item.DiskID,
+			CloudDiskID:      item.CloudDiskID,
+			DiskSize:         item.DiskSize,
+			Status:           item.Status,
+			PolicyID:         item.PolicyID,
+			CloudCreatedTime: item.CloudCreatedTime,
+			Memo:             item.Memo,
+		})
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.DiskSnapshot().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("create disk snapshot failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create disk snapshot but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateDiskSnapshot batch update disk snapshot.
+func (svc *service) BatchUpdateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.BatchUpdateDiskSnapshotReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablesnapshot.DiskSnapshotTable{
+				BkBizID:     item.BkBizID,
+				Name:        item.Name,
+				Zone:        item.Zone,
+				DiskID:      item.DiskID,
+				CloudDiskID: item.CloudDiskID,
+				DiskSize:    item.DiskSize,
+				Status:      item.Status,
+				Memo:        item.Memo,
+			}
+			if err := svc.dao.DiskSnapshot().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				return nil, fmt.Errorf("update disk snapshot failed, err: %v, id: %s", err, item.ID)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// UpdateDiskSnapshotRecycleStatus update disk snapshot recycle status by filter.
+func (svc *service) UpdateDiskSnapshotRecycleStatus(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.UpdateDiskSnapshotRecycleStatusReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		model := &tablesnapshot.DiskSnapshotTable{RecycleStatus: req.RecycleStatus}
+		if err := svc.dao.DiskSnapshot().UpdateWithTx(cts.Kit, txn, req.Filter, model); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("update disk snapshot recycle status failed, err: %v, filter: %v, rid: %s", err, req.Filter,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListDiskSnapshot list disk snapshot.
+func (svc *service) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	res, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list disk snapshot failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dssnapshot.ListDiskSnapshotResult{Count: res.Count}, nil
+	}
+
+	details := make([]coresnapshot.DiskSnapshot, 0, len(res.Details))
+	for _, one := range res.Details {
+		details = append(details, coresnapshot.DiskSnapshot{
+			ID:               one.ID,
+			Vendor:           one.Vendor,
+			AccountID:        one.AccountID,
+			CloudID:          one.CloudID,
+			BkBizID:          one.BkBizID,
+			Name:             one.Name,
+			Region:           one.Region,
+			Zone:             one.Zone,
+			DiskID:           one.DiskID,
+			CloudDiskID:      one.CloudDiskID,
+			DiskSize:         one.DiskSize,
+			Status:           one.Status,
+			PolicyID:         one.PolicyID,
+			RecycleStatus:    one.RecycleStatus,
+			CloudCreatedTime: one.CloudCreatedTime,
+			Memo:             one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dssnapshot.ListDiskSnapshotResult{Details: details}, nil
+}
+
+// DeleteDiskSnapshot delete disk snapshot.
+func (svc *service) DeleteDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.DeleteDiskSnapshotReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.DiskSnapshot().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete disk snapshot failed, err: %v, filter: %v, rid: %s", err, req.Filter, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,258 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDiskSnapshotPolicy batch create disk snapshot policy.
+func (svc *service) BatchCreateDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.BatchCreateDiskSnapshotPolicyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablesnapshot.DiskSnapshotPolicyTable, 0, len(req.Items))
+	for _, item := range req.Items {
+		hours, weekDays, diskIDs, err := marshalPolicyFields(item.Hours, item.WeekDays, item.DiskIDs, true)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablesnapshot.DiskSnapshotPolicyTable{
+			Vendor:        item.Vendor,
+			AccountID:     item.AccountID,
+			BkBizID:       item.BkBizID,
+			Name:          item.Name,
+			Hours:         hours,
+			WeekDays:      weekDays,
+			RetentionDays: item.RetentionDays,
+			DiskIDs:       diskIDs,
+			Memo:          item.Memo,
+		})
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.DiskSnapshotPolicy().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("create disk snapshot policy failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create disk snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create disk snapshot policy but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateDiskSnapshotPolicy batch update disk snapshot policy.
+func (svc *service) BatchUpdateDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.BatchUpdateDiskSnapshotPolicyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			hours, weekDays, diskIDs, err := marshalPolicyFields(item.Hours, item.WeekDays, item.DiskIDs, false)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			model := &tablesnapshot.DiskSnapshotPolicyTable{
+				Name:     item.Name,
+				Hours:    hours,
+				WeekDays: weekDays,
+				DiskIDs:  diskIDs,
+				Memo:     item.Memo,
+			}
+			if item.RetentionDays != nil {
+				model.RetentionDays = *item.RetentionDays
+			}
+			if err = svc.dao.DiskSnapshotPolicy().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				return nil, fmt.Errorf("update disk snapshot policy failed, err: %v, id: %s", err, item.ID)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update disk snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// toJsonField 将策略的切片字段转为json字段，values为nil时：创建场景存储为空数组，更新场景返回空值表示不更新该字段
+func toJsonField[T any](values []T, forCreate bool) (tabletype.JsonField, error) {
+	if values == nil {
+		if !forCreate {
+			return "", nil
+		}
+		values = make([]T, 0)
+	}
+
+	return tabletype.NewJsonField(values)
+}
+
+// marshalPolicyFields 将策略的执行时间及绑定的云硬盘转为json字段
+func marshalPolicyFields(hours, weekDays []int, diskIDs []string, forCreate bool) (tabletype.JsonField,
+	tabletype.JsonField, tabletype.JsonField, error) {
+
+	hoursField, err := toJsonField(hours, forCreate)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	weekDaysField, err := toJsonField(weekDays, forCreate)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	diskIDsField, err := toJsonField(diskIDs, forCreate)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return hoursField, weekDaysField, diskIDsField, nil
+}
+
+// ListDiskSnapshotPolicy list disk snapshot policy.
+func (svc *service) ListDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	res, err := svc.dao.DiskSnapshotPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list disk snapshot policy failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dssnapshot.ListDiskSnapshotPolicyResult{Count: res.Count}, nil
+	}
+
+	details := make([]coresnapshot.DiskSnapshotPolicy, 0, len(res.Details))
+	for _, one := range res.Details {
+		policy := coresnapshot.DiskSnapshotPolicy{
+			ID:            one.ID,
+			Vendor:        one.Vendor,
+			AccountID:     one.AccountID,
+			BkBizID:       one.BkBizID,
+			Name:          one.Name,
+			RetentionDays: one.RetentionDays,
+			Memo:          one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		fields := []struct {
+			value tabletype.JsonField
+			dest  interface{}
+		}{{one.Hours, &policy.Hours}, {one.WeekDays, &policy.WeekDays}, {one.DiskIDs, &policy.DiskIDs}}
+		for _, field := range fields {
+			if len(field.value) == 0 {
+				continue
+			}
+			if err = json.UnmarshalFromString(string(field.value), field.dest); err != nil {
+				return nil, fmt.Errorf("unmarshal disk snapshot policy failed, err: %v, id: %s", err, one.ID)
+			}
+		}
+
+		details = append(details, policy)
+	}
+
+	return &dssnapshot.ListDiskSnapshotPolicyResult{Details: details}, nil
+}
+
+// DeleteDiskSnapshotPolicy delete disk snapshot policy.
+func (svc *service) DeleteDiskSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.DeleteDiskSnapshotPolicyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.DiskSnapshotPolicy().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete disk snapshot policy failed, err: %v, filter: %v, rid: %s", err, req.Filter,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the disk snapshot service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateDiskSnapshot", http.MethodPost, "/disk_snapshots/batch/create", svc.BatchCreateDiskSnapshot).
+		Reads(new(dssnapshot.BatchCreateDiskSnapshotReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateDiskSnapshot", http.MethodPatch, "/disk_snapshots/batch/update", svc.BatchUpdateDiskSnapshot).
+		Reads(new(dssnapshot.BatchUpdateDiskSnapshotReq))
+	h.Add("UpdateDiskSnapshotRecycleStatus", http.MethodPatch, "/disk_snapshots/recycle_status/update",
+		svc.UpdateDiskSnapshotRecycleStatus).Reads(new(dssnapshot.UpdateDiskSnapshotRecycleStatusReq))
+	h.Add("ListDiskSnapshot", http.MethodPost, "/disk_snapshots/list", svc.ListDiskSnapshot).
+		Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotResult))
+	h.Add("DeleteDiskSnapshot", http.MethodDelete, "/disk_snapshots/batch", svc.DeleteDiskSnapshot).
+		Reads(new(dssnapshot.DeleteDiskSnapshotReq))
+
+	h.Add("BatchCreateDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/batch/create",
+		svc.BatchCreateDiskSnapshotPolicy).
+		Reads(new(dssnapshot.BatchCreateDiskSnapshotPolicyReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateDiskSnapshotPolicy", http.MethodPatch, "/disk_snapshot_policies/batch/update",
+		svc.BatchUpdateDiskSnapshotPolicy).Reads(new(dssnapshot.BatchUpdateDiskSnapshotPolicyReq))
+	h.Add("ListDiskSnapshotPolicy", http.MethodPost, "/disk_snapshot_policies/list", svc.ListDiskSnapshotPolicy).
+		Reads(new(core.ListReq)).Writes(new(dssnapshot.ListDiskSnapshotPolicyResult))
+	h.Add("DeleteDiskSnapshotPolicy", http.MethodDelete, "/disk_snapshot_policies/batch",
+		svc.DeleteDiskSnapshotPolicy).Reads(new(dssnapshot.DeleteDiskSnapshotPolicyReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
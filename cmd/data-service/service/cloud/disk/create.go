@@ -53,6 +53,10 @@ func (dSvc *diskSvc) BatchCreateDiskExt(cts *rest.Contexts) (interface{}, error)
 		return batchCreateDiskExt[coredisk.AzureExtension](cts, dSvc, vendor)
 	case enumor.HuaWei:
 		return batchCreateDiskExt[coredisk.HuaWeiExtension](cts, dSvc, vendor)
+	case enumor.Aliyun:
+		return batchCreateDiskExt[coredisk.AliyunExtension](cts, dSvc, vendor)
+	case enumor.OpenStack:
+		return batchCreateDiskExt[coredisk.OpenStackExtension](cts, dSvc, vendor)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/disk"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -41,15 +43,17 @@ func InitService(cap *capability.Capability) {
 	// 获取单个云盘
 	h.Add("RetrieveDiskExt", http.MethodGet, "/vendors/{vendor}/disks/{id}", svc.RetrieveDiskExt)
 	// 查询云盘列表 (不带 extension 字段)
-	h.Add("ListDisk", http.MethodPost, "/disks/list", svc.ListDisk)
+	h.Add("ListDisk", http.MethodPost, "/disks/list", svc.ListDisk).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ListResult))
 	// 查询云盘列表 (带 extension 字段)
-	h.Add("ListDiskExt", http.MethodPost, "/vendors/{vendor}/disks/list", svc.ListDiskExt)
+	h.Add("ListDiskExt", http.MethodPost, "/vendors/{vendor}/disks/list", svc.ListDiskExt).Reads(new(core.ListReq))
 	// 批量更新云盘数据(支持 extension 字段)
 	h.Add("BatchUpdateDiskExt", http.MethodPatch, "/vendors/{vendor}/disks", svc.BatchUpdateDiskExt)
 	// 批量更新云盘基础数据
-	h.Add("BatchUpdateDisk", http.MethodPatch, "/disks", svc.BatchUpdateDisk)
-	h.Add("BatchDeleteDisk", http.MethodDelete, "/disks/batch", svc.BatchDeleteDisk)
-	h.Add("CountDisk", http.MethodPost, "/disks/count", svc.CountDisk)
+	h.Add("BatchUpdateDisk", http.MethodPatch, "/disks", svc.BatchUpdateDisk).Reads(new(dataproto.DiskBatchUpdateReq))
+	h.Add("BatchDeleteDisk", http.MethodDelete, "/disks/batch", svc.BatchDeleteDisk).Reads(new(dataproto.DiskDeleteReq))
+	h.Add("CountDisk", http.MethodPost, "/disks/count", svc.CountDisk).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ListResult))
 
 	h.Load(cap.WebService)
 }
@@ -70,6 +70,10 @@ func (dSvc *diskSvc) RetrieveDiskExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoDiskExtResult[coredisk.AzureExtension](diskData)
 	case enumor.HuaWei:
 		return toProtoDiskExtResult[coredisk.HuaWeiExtension](diskData)
+	case enumor.Aliyun:
+		return toProtoDiskExtResult[coredisk.AliyunExtension](diskData)
+	case enumor.OpenStack:
+		return toProtoDiskExtResult[coredisk.OpenStackExtension](diskData)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -143,6 +147,10 @@ func (dSvc *diskSvc) ListDiskExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoDiskExtListResult[coredisk.AzureExtension](data)
 	case enumor.HuaWei:
 		return toProtoDiskExtListResult[coredisk.HuaWeiExtension](data)
+	case enumor.Aliyun:
+		return toProtoDiskExtListResult[coredisk.AliyunExtension](data)
+	case enumor.OpenStack:
+		return toProtoDiskExtListResult[coredisk.OpenStackExtension](data)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -56,6 +56,10 @@ func (dSvc *diskSvc) BatchUpdateDiskExt(cts *rest.Contexts) (interface{}, error)
 		return batchUpdateDiskExt[coredisk.AzureExtension](cts, dSvc)
 	case enumor.HuaWei:
 		return batchUpdateDiskExt[coredisk.HuaWeiExtension](cts, dSvc)
+	case enumor.Aliyun:
+		return batchUpdateDiskExt[coredisk.AliyunExtension](cts, dSvc)
+	case enumor.OpenStack:
+		return batchUpdateDiskExt[coredisk.OpenStackExtension](cts, dSvc)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -127,6 +127,10 @@ func (svc *relSvc) ListWithEipExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoEipExtWithCvmIDs[dataproto.AzureEipExtensionResult](data)
 	case enumor.HuaWei:
 		return toProtoEipExtWithCvmIDs[dataproto.HuaWeiEipExtensionResult](data)
+	case enumor.Aliyun:
+		return toProtoEipExtWithCvmIDs[dataproto.AliyunEipExtensionResult](data)
+	case enumor.OpenStack:
+		return toProtoEipExtWithCvmIDs[dataproto.OpenStackEipExtensionResult](data)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
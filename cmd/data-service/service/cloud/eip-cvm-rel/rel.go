@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,12 +35,18 @@ func InitService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("BatchCreateEipCvmRels", http.MethodPost, "/eip_cvm_rels/batch/create", svc.BatchCreateEipCvmRels)
-	h.Add("ListEipCvmRels", http.MethodPost, "/eip_cvm_rels/list", svc.ListEipCvmRels)
-	h.Add("ListEipWithoutCvm", http.MethodPost, "/eip_cvm_rels/with/eips/without/cvm/list", svc.ListEipWithoutCvm)
-	h.Add("ListWithEip", http.MethodPost, "/eip_cvm_rels/with/eips/list", svc.ListWithEip)
-	h.Add("ListWithEipExt", http.MethodPost, "/vendors/{vendor}/eip_cvm_rels/with/eips/list", svc.ListWithEipExt)
-	h.Add("BatchDeleteEipCvmRels", http.MethodDelete, "/eip_cvm_rels/batch", svc.BatchDeleteEipCvmRels)
+	h.Add("BatchCreateEipCvmRels", http.MethodPost, "/eip_cvm_rels/batch/create", svc.BatchCreateEipCvmRels).
+		Reads(new(cloud.EipCvmRelBatchCreateReq))
+	h.Add("ListEipCvmRels", http.MethodPost, "/eip_cvm_rels/list", svc.ListEipCvmRels).
+		Reads(new(cloud.EipCvmRelListReq)).Writes(new(cloud.EipCvmRelListResult))
+	h.Add("ListEipWithoutCvm", http.MethodPost, "/eip_cvm_rels/with/eips/without/cvm/list", svc.ListEipWithoutCvm).
+		Reads(new(cloud.ListEipWithoutCvmReq)).Writes(new(cloud.ListEipWithoutCvmResult))
+	h.Add("ListWithEip", http.MethodPost, "/eip_cvm_rels/with/eips/list", svc.ListWithEip).
+		Reads(new(cloud.EipCvmRelWithEipListReq)).Writes(new([]*cloud.EipWithCvmID))
+	h.Add("ListWithEipExt", http.MethodPost, "/vendors/{vendor}/eip_cvm_rels/with/eips/list", svc.ListWithEipExt).
+		Reads(new(cloud.EipCvmRelWithEipExtListReq))
+	h.Add("BatchDeleteEipCvmRels", http.MethodDelete, "/eip_cvm_rels/batch", svc.BatchDeleteEipCvmRels).
+		Reads(new(cloud.EipCvmRelDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -49,6 +49,10 @@ func (svc *eipSvc) BatchCreateEipExt(cts *rest.Contexts) (interface{}, error) {
 		return batchCreateEipExt[dataproto.GcpEipExtensionCreateReq](cts, svc, vendor)
 	case enumor.HuaWei:
 		return batchCreateEipExt[dataproto.HuaWeiEipExtensionCreateReq](cts, svc, vendor)
+	case enumor.Aliyun:
+		return batchCreateEipExt[dataproto.AliyunEipExtensionCreateReq](cts, svc, vendor)
+	case enumor.OpenStack:
+		return batchCreateEipExt[dataproto.OpenStackEipExtensionCreateReq](cts, svc, vendor)
 	case enumor.Azure:
 		return batchCreateEipExt[dataproto.AzureEipExtensionCreateReq](cts, svc, vendor)
 	default:
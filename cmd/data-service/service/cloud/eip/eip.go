@@ -24,6 +24,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	dataproto "hcm/pkg/api/data-service/cloud/eip"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -36,11 +37,12 @@ func InitEipService(cap *capability.Capability) {
 
 	h.Add("BatchCreateEipExt", http.MethodPost, "/vendors/{vendor}/eips/batch/create", svc.BatchCreateEipExt)
 	h.Add("RetrieveEipExt", http.MethodGet, "/vendors/{vendor}/eips/{id}", svc.RetrieveEipExt)
-	h.Add("ListEip", http.MethodPost, "/eips/list", svc.ListEip)
-	h.Add("ListEipExt", http.MethodPost, "/vendors/{vendor}/eips/list", svc.ListEipExt)
+	h.Add("ListEip", http.MethodPost, "/eips/list", svc.ListEip).
+		Reads(new(dataproto.EipListReq)).Writes(new(dataproto.EipListResult))
+	h.Add("ListEipExt", http.MethodPost, "/vendors/{vendor}/eips/list", svc.ListEipExt).Reads(new(dataproto.EipListReq))
 	h.Add("BatchUpdateEipExt", http.MethodPatch, "/vendors/{vendor}/eips", svc.BatchUpdateEipExt)
-	h.Add("BatchUpdateEip", http.MethodPatch, "/eips", svc.BatchUpdateEip)
-	h.Add("BatchDeleteEip", http.MethodDelete, "/eips/batch", svc.BatchDeleteEip)
+	h.Add("BatchUpdateEip", http.MethodPatch, "/eips", svc.BatchUpdateEip).Reads(new(dataproto.EipBatchUpdateReq))
+	h.Add("BatchDeleteEip", http.MethodDelete, "/eips/batch", svc.BatchDeleteEip).Reads(new(dataproto.EipDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -69,6 +69,10 @@ func (svc *eipSvc) RetrieveEipExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoEipExtResult[dataproto.AzureEipExtensionResult](eipData)
 	case enumor.HuaWei:
 		return toProtoEipExtResult[dataproto.HuaWeiEipExtensionResult](eipData)
+	case enumor.Aliyun:
+		return toProtoEipExtResult[dataproto.AliyunEipExtensionResult](eipData)
+	case enumor.OpenStack:
+		return toProtoEipExtResult[dataproto.OpenStackEipExtensionResult](eipData)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -139,6 +143,10 @@ func (svc *eipSvc) ListEipExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoEipExtListResult[dataproto.GcpEipExtensionResult](data)
 	case enumor.HuaWei:
 		return toProtoEipExtListResult[dataproto.HuaWeiEipExtensionResult](data)
+	case enumor.Aliyun:
+		return toProtoEipExtListResult[dataproto.AliyunEipExtensionResult](data)
+	case enumor.OpenStack:
+		return toProtoEipExtListResult[dataproto.OpenStackEipExtensionResult](data)
 	case enumor.Azure:
 		return toProtoEipExtListResult[dataproto.AzureEipExtensionResult](data)
 	default:
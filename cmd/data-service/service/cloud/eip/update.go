@@ -55,6 +55,10 @@ func (svc *eipSvc) BatchUpdateEipExt(cts *rest.Contexts) (interface{}, error) {
 		return batchUpdateEipExt[dataproto.AzureEipExtensionUpdateReq](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateEipExt[dataproto.HuaWeiEipExtensionUpdateReq](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateEipExt[dataproto.AliyunEipExtensionUpdateReq](cts, svc)
+	case enumor.OpenStack:
+		return batchUpdateEipExt[dataproto.OpenStackEipExtensionUpdateReq](cts, svc)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -49,6 +49,8 @@ func (svc *imageSvc) BatchCreateImageExt(cts *rest.Contexts) (interface{}, error
 		return batchCreateImageExt[coreimage.GcpExtension](cts, svc, vendor)
 	case enumor.HuaWei:
 		return batchCreateImageExt[coreimage.HuaWeiExtension](cts, svc, vendor)
+	case enumor.Aliyun:
+		return batchCreateImageExt[coreimage.AliyunExtension](cts, svc, vendor)
 	case enumor.Azure:
 		return batchCreateImageExt[coreimage.AzureExtension](cts, svc, vendor)
 	default:
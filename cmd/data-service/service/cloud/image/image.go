@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/image"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -38,10 +40,11 @@ func InitService(cap *capability.Capability) {
 
 	h.Add("BatchCreateImageExt", http.MethodPost, "/vendors/{vendor}/images/batch/create", pSvc.BatchCreateImageExt)
 	h.Add("GetImageExt", http.MethodGet, "/vendors/{vendor}/images/{id}", pSvc.GetImageExt)
-	h.Add("ListImage", http.MethodPost, "/images/list", pSvc.ListImage)
-	h.Add("ListImageExt", http.MethodPost, "/vendors/{vendor}/images/list", pSvc.ListImageExt)
+	h.Add("ListImage", http.MethodPost, "/images/list", pSvc.ListImage).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ListResult))
+	h.Add("ListImageExt", http.MethodPost, "/vendors/{vendor}/images/list", pSvc.ListImageExt).Reads(new(core.ListReq))
 	h.Add("BatchUpdateImageExt", http.MethodPatch, "/vendors/{vendor}/images", pSvc.BatchUpdateImageExt)
-	h.Add("BatchDeleteImage", http.MethodDelete, "/images/batch", pSvc.BatchDeleteImage)
+	h.Add("BatchDeleteImage", http.MethodDelete, "/images/batch", pSvc.BatchDeleteImage).Reads(new(dataproto.DeleteReq))
 
 	h.Load(cap.WebService)
 }
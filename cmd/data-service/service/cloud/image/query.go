@@ -70,6 +70,8 @@ func (svc *imageSvc) GetImageExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoImageExtResult[coreimage.AzureExtension](imageData)
 	case enumor.HuaWei:
 		return toProtoImageExtResult[coreimage.HuaWeiExtension](imageData)
+	case enumor.Aliyun:
+		return toProtoImageExtResult[coreimage.AliyunExtension](imageData)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -140,6 +142,8 @@ func (svc *imageSvc) ListImageExt(cts *rest.Contexts) (interface{}, error) {
 		return toProtoImageExtListResult[coreimage.GcpExtension](data)
 	case enumor.HuaWei:
 		return toProtoImageExtListResult[coreimage.HuaWeiExtension](data)
+	case enumor.Aliyun:
+		return toProtoImageExtListResult[coreimage.AliyunExtension](data)
 	case enumor.Azure:
 		return toProtoImageExtListResult[coreimage.AzureExtension](data)
 	default:
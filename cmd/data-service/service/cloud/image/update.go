@@ -54,6 +54,8 @@ func (svc *imageSvc) BatchUpdateImageExt(cts *rest.Contexts) (interface{}, error
 		return batchUpdateImageExt[coreimage.GcpExtension](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateImageExt[coreimage.HuaWeiExtension](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateImageExt[coreimage.AliyunExtension](cts, svc)
 	case enumor.Azure:
 		return batchUpdateImageExt[coreimage.AzureExtension](cts, svc)
 	default:
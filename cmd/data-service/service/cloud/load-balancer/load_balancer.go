@@ -24,6 +24,10 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dataproto "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -39,66 +43,97 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 负载均衡
-	h.Add("GetLoadBalancer", http.MethodGet, "/vendors/{vendor}/load_balancers/{id}", svc.GetLoadBalancer)
-	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
-	h.Add("ListLoadBalancerRaw", http.MethodPost, "/load_balancers/list_with_extension", svc.ListLoadBalancerRaw)
-	h.Add("ListLoadBalancerExt", http.MethodPost, "/vendors/{vendor}/load_balancers/list", svc.ListLoadBalancerExt)
+	h.Add("GetLoadBalancer", http.MethodGet, "/vendors/{vendor}/load_balancers/{id}", svc.GetLoadBalancer).
+		Writes(new(corelb.LoadBalancer[corelb.TCloudClbExtension]))
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer).
+		Reads(new(core.ListReq)).Writes(new(dataproto.LbListResult))
+	h.Add("ListLoadBalancerRaw", http.MethodPost, "/load_balancers/list_with_extension", svc.ListLoadBalancerRaw).
+		Reads(new(core.ListReq)).Writes(new(dataproto.LbRawListResult))
+	h.Add("ListLoadBalancerExt", http.MethodPost, "/vendors/{vendor}/load_balancers/list", svc.ListLoadBalancerExt).
+		Writes(new(dataproto.LbExtListResult[corelb.TCloudClbExtension]))
 	h.Add("BatchCreateLoadBalancer", http.MethodPost, "/vendors/{vendor}/load_balancers/batch/create",
 		svc.BatchCreateLoadBalancer)
 	h.Add("BatchUpdateLoadBalancer",
 		http.MethodPatch, "/vendors/{vendor}/load_balancers/batch/update", svc.BatchUpdateLoadBalancer)
-	h.Add("BatchUpdateLbBizInfo", http.MethodPatch, "/load_balancers/bizs/batch/update", svc.BatchUpdateLbBizInfo)
-	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+	h.Add("BatchUpdateLbBizInfo", http.MethodPatch, "/load_balancers/bizs/batch/update", svc.BatchUpdateLbBizInfo).
+		Reads(new(dataproto.BizBatchUpdateReq))
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer).
+		Reads(new(dataproto.LoadBalancerBatchDeleteReq))
 
 	// 监听器
-	h.Add("GetListener", http.MethodGet, "/vendors/{vendor}/listeners/{id}", svc.GetListener)
-	h.Add("ListListener", http.MethodPost, "/load_balancers/listeners/list", svc.ListListener)
-	h.Add("ListListenerExt", http.MethodPost, "/vendors/tcloud/load_balancers/listeners/list", svc.ListListenerExt)
+	h.Add("GetListener", http.MethodGet, "/vendors/{vendor}/listeners/{id}", svc.GetListener).
+		Writes(new(corelb.Listener[corelb.TCloudListenerExtension]))
+	h.Add("ListListener", http.MethodPost, "/load_balancers/listeners/list", svc.ListListener).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ListenerListResult))
+	h.Add("ListListenerExt", http.MethodPost, "/vendors/tcloud/load_balancers/listeners/list", svc.ListListenerExt).
+		Reads(new(core.ListReq))
 	h.Add("BatchCreateListener", http.MethodPost, "/vendors/{vendor}/listeners/batch/create", svc.BatchCreateListener)
 	h.Add("BatchCreateListenerWithRule", http.MethodPost, "/vendors/{vendor}/listeners/rules/batch/create",
-		svc.BatchCreateListenerWithRule)
+		svc.BatchCreateListenerWithRule).
+		Reads(new(dataproto.ListenerWithRuleBatchCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateListener", http.MethodPatch, "/vendors/{vendor}/listeners/batch/update", svc.BatchUpdateListener)
-	h.Add("BatchDeleteListener", http.MethodDelete, "/listeners/batch", svc.BatchDeleteListener)
-	h.Add("CountListenerByLbIDs", http.MethodPost, "/load_balancers/listeners/count", svc.CountListenerByLbIDs)
+	h.Add("BatchDeleteListener", http.MethodDelete, "/listeners/batch", svc.BatchDeleteListener).
+		Reads(new(dataproto.LoadBalancerBatchDeleteReq))
+	h.Add("CountListenerByLbIDs", http.MethodPost, "/load_balancers/listeners/count", svc.CountListenerByLbIDs).
+		Reads(new(dataproto.ListListenerCountByLbIDsReq)).Writes(new(dataproto.ListListenerCountResp))
 	h.Add("BatchUpdateListenerBizInfo", http.MethodPatch,
-		"/load_balancers/listeners/bizs/batch/update", svc.BatchUpdateListenerBizInfo)
+		"/load_balancers/listeners/bizs/batch/update", svc.BatchUpdateListenerBizInfo).
+		Reads(new(dataproto.BizBatchUpdateReq))
 	h.Add("ListListenerWithTargets", http.MethodPost, "/load_balancers/listeners/with/targets/list",
-		svc.ListListenerWithTargets)
-	h.Add("ListBatchListeners", http.MethodPost, "/load_balancers/listeners/batch/list", svc.ListBatchListeners)
+		svc.ListListenerWithTargets).
+		Reads(new(dataproto.ListListenerWithTargetsReq)).Writes(new(dataproto.ListListenerWithTargetsResp))
+	h.Add("ListBatchListeners", http.MethodPost, "/load_balancers/listeners/batch/list", svc.ListBatchListeners).
+		Reads(new(dataproto.BatchDeleteListenerReq)).Writes(new(dataproto.BatchListListenerResp))
 
 	// url规则
 	h.Add("BatchCreateTCloudUrlRule",
-		http.MethodPost, "/vendors/tcloud/url_rules/batch/create", svc.BatchCreateTCloudUrlRule)
+		http.MethodPost, "/vendors/tcloud/url_rules/batch/create", svc.BatchCreateTCloudUrlRule).
+		Reads(new(dataproto.TCloudUrlRuleBatchCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateTCloudUrlRule",
-		http.MethodPatch, "/vendors/tcloud/url_rules/batch/update", svc.BatchUpdateTCloudUrlRule)
+		http.MethodPatch, "/vendors/tcloud/url_rules/batch/update", svc.BatchUpdateTCloudUrlRule).
+		Reads(new(dataproto.TCloudUrlRuleBatchUpdateReq))
 	h.Add("BatchDeleteTCloudUrlRule",
-		http.MethodDelete, "/vendors/tcloud/url_rules/batch", svc.BatchDeleteTCloudUrlRule)
-	h.Add("ListTCloudUrlRule", http.MethodPost, "/vendors/tcloud/load_balancers/url_rules/list", svc.ListTCloudUrlRule)
+		http.MethodDelete, "/vendors/tcloud/url_rules/batch", svc.BatchDeleteTCloudUrlRule).
+		Reads(new(dataproto.LoadBalancerBatchDeleteReq))
+	h.Add("ListTCloudUrlRule", http.MethodPost, "/vendors/tcloud/load_balancers/url_rules/list", svc.ListTCloudUrlRule).
+		Reads(new(core.ListReq)).Writes(new(dataproto.TCloudURLRuleListResult))
 
 	// 目标组
 	h.Add("BatchCreateTargetGroup", http.MethodPost,
 		"/vendors/{vendor}/target_groups/batch/create", svc.BatchCreateTargetGroup)
 	h.Add("BatchCreateTargetGroupWithRel", http.MethodPost,
 		"/vendors/{vendor}/target_groups/with/rels/batch/create", svc.BatchCreateTargetGroupWithRel)
-	h.Add("GetTargetGroup", http.MethodGet, "/vendors/{vendor}/target_groups/{id}", svc.GetTargetGroup)
-	h.Add("ListTargetGroup", http.MethodPost, "/load_balancers/target_groups/list", svc.ListTargetGroup)
-	h.Add("UpdateTargetGroup", http.MethodPatch, "/vendors/{vendor}/target_groups", svc.UpdateTargetGroup)
-	h.Add("BatchDeleteTargetGroup", http.MethodDelete, "/target_groups/batch", svc.BatchDeleteTargetGroup)
+	h.Add("GetTargetGroup", http.MethodGet, "/vendors/{vendor}/target_groups/{id}", svc.GetTargetGroup).
+		Writes(new(corelb.BaseTargetGroup))
+	h.Add("ListTargetGroup", http.MethodPost, "/load_balancers/target_groups/list", svc.ListTargetGroup).
+		Reads(new(core.ListReq)).Writes(new(dataproto.TargetGroupListResult))
+	h.Add("UpdateTargetGroup", http.MethodPatch, "/vendors/{vendor}/target_groups", svc.UpdateTargetGroup).
+		Reads(new(dataproto.TargetGroupUpdateReq))
+	h.Add("BatchDeleteTargetGroup", http.MethodDelete, "/target_groups/batch", svc.BatchDeleteTargetGroup).
+		Reads(new(dataproto.TargetGroupBatchDeleteReq))
 	h.Add("BatchUpdateListenerBizInfo", http.MethodPatch,
-		"/load_balancers/target_groups/bizs/batch/update", svc.BatchUpdateTargetGroupBizInfo)
+		"/load_balancers/target_groups/bizs/batch/update", svc.BatchUpdateTargetGroupBizInfo).
+		Reads(new(dataproto.BizBatchUpdateReq))
 	// RS
-	h.Add("BatchDeleteTarget", http.MethodDelete, "/load_balancers/targets/batch", svc.BatchDeleteTarget)
-	h.Add("BatchUpdateTarget", http.MethodPatch, "/load_balancers/targets/batch/update", svc.BatchUpdateTarget)
-	h.Add("ListTarget", http.MethodPost, "/load_balancers/targets/list", svc.ListTarget)
-	h.Add("BatchCreateTarget", http.MethodPost, "/targets/batch/create", svc.BatchCreateTarget)
+	h.Add("BatchDeleteTarget", http.MethodDelete, "/load_balancers/targets/batch", svc.BatchDeleteTarget).
+		Reads(new(dataproto.LoadBalancerBatchDeleteReq))
+	h.Add("BatchUpdateTarget", http.MethodPatch, "/load_balancers/targets/batch/update", svc.BatchUpdateTarget).
+		Reads(new(dataproto.TargetBatchUpdateReq))
+	h.Add("ListTarget", http.MethodPost, "/load_balancers/targets/list", svc.ListTarget).
+		Reads(new(core.ListReq)).Writes(new(dataproto.TargetListResult))
+	h.Add("BatchCreateTarget", http.MethodPost, "/targets/batch/create", svc.BatchCreateTarget).
+		Reads(new(dataproto.TargetBatchCreateReq)).Writes(new(core.BatchCreateResult))
 
 	// 目标组 规则关联关系
 	h.Add("CreateTargetGroupListenerRel", http.MethodPost,
-		"/target_group_listener_rels/create", svc.CreateTargetGroupListenerRel)
+		"/target_group_listener_rels/create", svc.CreateTargetGroupListenerRel).
+		Reads(new(dataproto.TargetGroupListenerRelCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("ListTargetGroupListenerRel", http.MethodPost,
-		"/target_group_listener_rels/list", svc.ListTargetGroupListenerRel)
+		"/target_group_listener_rels/list", svc.ListTargetGroupListenerRel).
+		Reads(new(core.ListReq)).Writes(new(dataproto.TargetListenerRuleRelListResult))
 	h.Add("BatchUpdateListenerRuleRelStatusByTGID", http.MethodPatch,
-		"/target_group_listener_rels/target_groups/{tg_id}/update", svc.BatchUpdateListenerRuleRelStatusByTGID)
+		"/target_group_listener_rels/target_groups/{tg_id}/update", svc.BatchUpdateListenerRuleRelStatusByTGID).
+		Reads(new(dataproto.TGListenerRelStatusUpdateReq))
 
 	// 资源与Flow相关的接口
 	resFlowRel(h)
@@ -109,17 +144,25 @@ func InitService(cap *capability.Capability) {
 // resFlowRel 资源与Flow相关的接口
 func resFlowRel(h *rest.Handler) {
 	// 资源跟Flow锁定
-	h.Add("CreateResFlowLock", http.MethodPost, "/res_flow_locks/create", svc.CreateResFlowLock)
-	h.Add("DeleteResFlowLock", http.MethodDelete, "/res_flow_locks/batch", svc.DeleteResFlowLock)
-	h.Add("ListResFlowLock", http.MethodPost, "/res_flow_locks/list", svc.ListResFlowLock)
-	h.Add("ResFlowLock", http.MethodPost, "/res_flow_locks/lock", svc.ResFlowLock)
-	h.Add("ResFlowUnLock", http.MethodPost, "/res_flow_locks/unlock", svc.ResFlowUnLock)
+	h.Add("CreateResFlowLock", http.MethodPost, "/res_flow_locks/create", svc.CreateResFlowLock).
+		Reads(new(dataproto.ResFlowLockCreateReq))
+	h.Add("DeleteResFlowLock", http.MethodDelete, "/res_flow_locks/batch", svc.DeleteResFlowLock).
+		Reads(new(dataproto.ResFlowLockDeleteReq))
+	h.Add("ListResFlowLock", http.MethodPost, "/res_flow_locks/list", svc.ListResFlowLock).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ResFlowLockListResult))
+	h.Add("ResFlowLock", http.MethodPost, "/res_flow_locks/lock", svc.ResFlowLock).Reads(new(dataproto.ResFlowLockReq))
+	h.Add("ResFlowUnLock", http.MethodPost, "/res_flow_locks/unlock", svc.ResFlowUnLock).
+		Reads(new(dataproto.ResFlowLockReq))
 
 	// 资源跟Flow关联关系
-	h.Add("BatchCreateResFlowRel", http.MethodPost, "/res_flow_rels/batch/create", svc.BatchCreateResFlowRel)
-	h.Add("BatchUpdateResFlowRel", http.MethodPatch, "/res_flow_rels/batch/update", svc.BatchUpdateResFlowRel)
-	h.Add("BatchDeleteResFlowRel", http.MethodDelete, "/res_flow_rels/batch", svc.BatchDeleteResFlowRel)
-	h.Add("ListResFlowRel", http.MethodPost, "/res_flow_rels/list", svc.ListResFlowRel)
+	h.Add("BatchCreateResFlowRel", http.MethodPost, "/res_flow_rels/batch/create", svc.BatchCreateResFlowRel).
+		Reads(new(dataproto.ResFlowRelBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateResFlowRel", http.MethodPatch, "/res_flow_rels/batch/update", svc.BatchUpdateResFlowRel).
+		Reads(new(dataproto.ResFlowRelBatchUpdateReq))
+	h.Add("BatchDeleteResFlowRel", http.MethodDelete, "/res_flow_rels/batch", svc.BatchDeleteResFlowRel).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("ListResFlowRel", http.MethodPost, "/res_flow_rels/list", svc.ListResFlowRel).
+		Reads(new(core.ListReq)).Writes(new(dataproto.ResFlowRelListResult))
 }
 
 type lbSvc struct {
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,11 +35,15 @@ func InitService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("BatchCreateNetworkCvmRels", http.MethodPost, "/network_cvm_rels/batch/create", svc.BatchCreateNetworkCvmRels)
-	h.Add("ListNetworkCvmRels", http.MethodPost, "/network_cvm_rels/list", svc.ListNetworkCvmRels)
+	h.Add("BatchCreateNetworkCvmRels", http.MethodPost,
+		"/network_cvm_rels/batch/create", svc.BatchCreateNetworkCvmRels).
+		Reads(new(cloud.NetworkInterfaceCvmRelBatchCreateReq))
+	h.Add("ListNetworkCvmRels", http.MethodPost, "/network_cvm_rels/list", svc.ListNetworkCvmRels).
+		Reads(new(cloud.NetworkInterfaceCvmRelListReq)).Writes(new(cloud.NetworkInterfaceCvmRelListResult))
 	h.Add("ListWithExtension", http.MethodPost, "/vendors/{vendor}/network_cvm_rels/with/interfaces/list",
-		svc.ListWithExtension)
-	h.Add("BatchDeleteNetworkCvmRels", http.MethodDelete, "/network_cvm_rels/batch", svc.BatchDeleteNetworkCvmRels)
+		svc.ListWithExtension).Reads(new(cloud.NetworkInterfaceCvmRelWithExtListReq))
+	h.Add("BatchDeleteNetworkCvmRels", http.MethodDelete, "/network_cvm_rels/batch", svc.BatchDeleteNetworkCvmRels).
+		Reads(new(cloud.NetworkInterfaceCvmRelDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -58,14 +58,18 @@ func InitNetInterfaceService(cap *capability.Capability) {
 	h.Add("BatchUpdateNetworkInterface", "PATCH", "/vendors/{vendor}/network_interfaces/batch",
 		svc.BatchUpdateNetworkInterface)
 	h.Add("BatchUpdateNetworkInterfaceCommonInfo", "PATCH",
-		"/network_interfaces/common/info/batch/update", svc.BatchUpdateNetworkInterfaceCommonInfo)
+		"/network_interfaces/common/info/batch/update", svc.BatchUpdateNetworkInterfaceCommonInfo).
+		Reads(new(datacloudniproto.NetworkInterfaceCommonInfoBatchUpdateReq))
 	h.Add("BatchDeleteNetworkInterface", "DELETE", "/network_interfaces/batch",
-		svc.BatchDeleteNetworkInterface)
-	h.Add("ListNetworkInterface", "POST", "/network_interfaces/list", svc.ListNetworkInterface)
+		svc.BatchDeleteNetworkInterface).Reads(new(dataservice.BatchDeleteReq))
+	h.Add("ListNetworkInterface", "POST", "/network_interfaces/list", svc.ListNetworkInterface).
+		Reads(new(core.ListReq)).Writes(new(datacloudniproto.NetworkInterfaceListResult))
 	h.Add("ListNetworkInterfaceAssociate", "POST", "/network_interfaces/associate/list",
-		svc.ListNetworkInterfaceAssociate)
+		svc.ListNetworkInterfaceAssociate).
+		Reads(new(datacloudniproto.NetworkInterfaceListReq)).
+		Writes(new(datacloudniproto.NetworkInterfaceAssociateListResult))
 	h.Add("ListNetworkInterfaceExt", "POST", "/vendors/{vendor}/network_interfaces/list",
-		svc.ListNetworkInterfaceExt)
+		svc.ListNetworkInterfaceExt).Reads(new(core.ListReq))
 	h.Add("GetNetworkInterface", "GET", "/vendors/{vendor}/network_interfaces/{id}",
 		svc.GetNetworkInterface)
 
@@ -22,6 +22,7 @@ package region
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
@@ -35,9 +36,10 @@ func InitRegionService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("BatchCreateRegion", "POST", "/vendors/{vendor}/regions/batch/create", svc.BatchCreateRegion)
+	h.Add("BatchCreateRegion", "POST", "/vendors/{vendor}/regions/batch/create", svc.BatchCreateRegion).
+		Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateRegion", "PATCH", "/vendors/{vendor}/regions/batch", svc.BatchUpdateRegion)
-	h.Add("ListRegion", "POST", "/vendors/{vendor}/regions/list", svc.ListRegion)
+	h.Add("ListRegion", "POST", "/vendors/{vendor}/regions/list", svc.ListRegion).Reads(new(core.ListReq))
 	h.Add("BatchDeleteRegion", "DELETE", "/vendors/{vendor}/regions/batch", svc.BatchDeleteRegion)
 
 	h.Load(cap.WebService)
@@ -57,6 +59,8 @@ func (svc *regionSvc) BatchCreateRegion(cts *rest.Contexts) (interface{}, error)
 	switch vendor {
 	case enumor.TCloud:
 		return svc.BatchCreateTCloudRegion(cts)
+	case enumor.Aliyun:
+		return svc.BatchCreateAliyunRegion(cts)
 	case enumor.Aws:
 		return svc.BatchCreateAwsRegion(cts)
 	case enumor.Gcp:
@@ -81,6 +85,8 @@ func (svc *regionSvc) BatchUpdateRegion(cts *rest.Contexts) (interface{}, error)
 	switch vendor {
 	case enumor.TCloud:
 		err = svc.BatchUpdateTCloudRegion(cts)
+	case enumor.Aliyun:
+		err = svc.BatchUpdateAliyunRegion(cts)
 	case enumor.Aws:
 		err = svc.BatchUpdateAwsRegion(cts)
 	case enumor.Gcp:
@@ -104,6 +110,8 @@ func (svc *regionSvc) ListRegion(cts *rest.Contexts) (interface{}, error) {
 	switch vendor {
 	case enumor.TCloud:
 		return svc.ListTCloudRegion(cts)
+	case enumor.Aliyun:
+		return svc.ListAliyunRegion(cts)
 	case enumor.Aws:
 		return svc.ListAwsRegion(cts)
 	case enumor.Gcp:
@@ -128,6 +136,8 @@ func (svc *regionSvc) BatchDeleteRegion(cts *rest.Contexts) (interface{}, error)
 	switch vendor {
 	case enumor.TCloud:
 		err = svc.BatchDeleteTCloudRegion(cts)
+	case enumor.Aliyun:
+		err = svc.BatchDeleteAliyunRegion(cts)
 	case enumor.Aws:
 		err = svc.BatchDeleteAwsRegion(cts)
 	case enumor.Gcp:
@@ -21,6 +21,8 @@ package resusagebizrel
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -34,8 +36,9 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("SetResUsageBizRel", "PUT",
-		"/res_usage_biz_rels/res_types/{res_type}/{res_id}", svc.SetResUsageBizRel)
-	h.Add("ListResUsageBizRel", "POST", "/res_usage_biz_rels/list", svc.ListResUsageBizRel)
+		"/res_usage_biz_rels/res_types/{res_type}/{res_id}", svc.SetResUsageBizRel).
+		Reads(new(protocloud.ResUsageBizRelUpdateReq))
+	h.Add("ListResUsageBizRel", "POST", "/res_usage_biz_rels/list", svc.ListResUsageBizRel).Reads(new(core.ListReq))
 
 	h.Load(cap.WebService)
 }
@@ -47,13 +47,19 @@ func InitAzureResourceGroupService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListAzureResourceGroup", "POST", "/vendors/azure/resource_groups/list", svc.ListAzureResourceGroup)
+	h.Add("ListAzureResourceGroup", "POST", "/vendors/azure/resource_groups/list", svc.ListAzureResourceGroup).
+		Reads(new(protorg.AzureRGListReq)).Writes(new(protorg.AzureRGListResult))
 
-	h.Add("DeleteAzureResourceGroup", "DELETE", "/vendors/azure/resource_groups/batch", svc.DeleteAzureResourceGroup)
+	h.Add("DeleteAzureResourceGroup", "DELETE", "/vendors/azure/resource_groups/batch", svc.DeleteAzureResourceGroup).
+		Reads(new(protorg.AzureRGBatchDeleteReq))
 
-	h.Add("CreateAzureResourceGroup", "POST", "/vendors/azure/resource_groups/batch/create", svc.CreateAzureResourceGroup)
+	h.Add("CreateAzureResourceGroup", "POST",
+		"/vendors/azure/resource_groups/batch/create", svc.CreateAzureResourceGroup).
+		Reads(new(protorg.AzureRGBatchCreateReq)).Writes(new(core.BatchCreateResult))
 
-	h.Add("UpdateAzureResourceGroup", "PUT", "/vendors/azure/resource_groups/batch/update", svc.UpdateAzureResourceGroup)
+	h.Add("UpdateAzureResourceGroup", "PUT",
+		"/vendors/azure/resource_groups/batch/update", svc.UpdateAzureResourceGroup).
+		Reads(new(protorg.AzureRGBatchUpdateReq))
 
 	h.Load(cap.WebService)
 }
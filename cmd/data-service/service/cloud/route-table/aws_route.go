@@ -49,11 +49,15 @@ func initAwsRouteService(svc *routeTableSvc, cap *capability.Capability) {
 	// TODO confirm if we should allow batch operation without route table id
 	h.Path("/vendors/aws/route_tables/{route_table_id}/routes")
 
-	h.Add("BatchCreateAwsRoute", "POST", "/batch/create", svc.BatchCreateAwsRoute)
-	h.Add("BatchUpdateAwsRoute", "PATCH", "/batch", svc.BatchUpdateAwsRoute)
-	h.Add("ListAwsRoute", "POST", "/list", svc.ListAwsRoute)
-	h.Add("ListAllAwsRoute", "POST", "/list/all", svc.ListAllAwsRoute)
-	h.Add("BatchDeleteAwsRoute", "DELETE", "/batch", svc.BatchDeleteAwsRoute)
+	h.Add("BatchCreateAwsRoute", "POST", "/batch/create", svc.BatchCreateAwsRoute).
+		Reads(new(protocloud.AwsRouteBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateAwsRoute", "PATCH", "/batch", svc.BatchUpdateAwsRoute).
+		Reads(new(protocloud.AwsRouteBatchUpdateReq))
+	h.Add("ListAwsRoute", "POST", "/list", svc.ListAwsRoute).
+		Reads(new(core.ListReq)).Writes(new(protocloud.AwsRouteListResult))
+	h.Add("ListAllAwsRoute", "POST", "/list/all", svc.ListAllAwsRoute).
+		Reads(new(protocloud.AwsRouteListReq)).Writes(new(protocloud.AwsRouteListResult))
+	h.Add("BatchDeleteAwsRoute", "DELETE", "/batch", svc.BatchDeleteAwsRoute).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
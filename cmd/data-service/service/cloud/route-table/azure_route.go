@@ -47,11 +47,15 @@ func initAzureRouteService(svc *routeTableSvc, cap *capability.Capability) {
 	// TODO confirm if we should allow batch operation without route table id
 	h.Path("/vendors/azure/route_tables/{route_table_id}/routes")
 
-	h.Add("BatchCreateAzureRoute", "POST", "/batch/create", svc.BatchCreateAzureRoute)
-	h.Add("BatchUpdateAzureRoute", "PATCH", "/batch", svc.BatchUpdateAzureRoute)
-	h.Add("ListAzureRoute", "POST", "/list", svc.ListAzureRoute)
-	h.Add("ListAzureRoute", "POST", "/list/all", svc.ListAllAzureRoute)
-	h.Add("BatchDeleteAzureRoute", "DELETE", "/batch", svc.BatchDeleteAzureRoute)
+	h.Add("BatchCreateAzureRoute", "POST", "/batch/create", svc.BatchCreateAzureRoute).
+		Reads(new(protocloud.AzureRouteBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateAzureRoute", "PATCH", "/batch", svc.BatchUpdateAzureRoute).
+		Reads(new(protocloud.AzureRouteBatchUpdateReq))
+	h.Add("ListAzureRoute", "POST", "/list", svc.ListAzureRoute).
+		Reads(new(core.ListReq)).Writes(new(protocloud.AzureRouteListResult))
+	h.Add("ListAzureRoute", "POST", "/list/all", svc.ListAllAzureRoute).
+		Reads(new(protocloud.AzureRouteListReq)).Writes(new(protocloud.AzureRouteListResult))
+	h.Add("BatchDeleteAzureRoute", "DELETE", "/batch", svc.BatchDeleteAzureRoute).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -53,10 +53,12 @@ func initGcpRouteService(svc *routeTableSvc, cap *capability.Capability) {
 
 	h.Path("/vendors/gcp")
 
-	h.Add("BatchCreateGcpRoute", "POST", "/routes/batch/create", svc.BatchCreateGcpRoute)
-	h.Add("ListGcpRoute", "POST", "/routes/list", svc.ListGcpRoute)
+	h.Add("BatchCreateGcpRoute", "POST", "/routes/batch/create", svc.BatchCreateGcpRoute).
+		Reads(new(protocloud.GcpRouteBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("ListGcpRoute", "POST", "/routes/list", svc.ListGcpRoute).
+		Reads(new(protocloud.GcpRouteListReq)).Writes(new(protocloud.GcpRouteListResult))
 	h.Add("BatchDeleteGcpRoute", "DELETE", "/route_tables/{route_table_id}/routes/batch",
-		svc.BatchDeleteGcpRoute)
+		svc.BatchDeleteGcpRoute).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -47,11 +47,16 @@ func initHuaWeiRouteService(svc *routeTableSvc, cap *capability.Capability) {
 	// TODO confirm if we should allow batch operation without route table id
 	h.Path("/vendors/huawei/route_tables/{route_table_id}/routes")
 
-	h.Add("BatchCreateHuaWeiRoute", "POST", "/batch/create", svc.BatchCreateHuaWeiRoute)
-	h.Add("BatchUpdateHuaWeiRoute", "PATCH", "/batch", svc.BatchUpdateHuaWeiRoute)
-	h.Add("ListHuaWeiRoute", "POST", "/list", svc.ListHuaWeiRoute)
-	h.Add("ListAllHuaWeiRoute", "POST", "/list/all", svc.ListAllHuaWeiRoute)
-	h.Add("BatchDeleteHuaWeiRoute", "DELETE", "/batch", svc.BatchDeleteHuaWeiRoute)
+	h.Add("BatchCreateHuaWeiRoute", "POST", "/batch/create", svc.BatchCreateHuaWeiRoute).
+		Reads(new(protocloud.HuaWeiRouteBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateHuaWeiRoute", "PATCH", "/batch", svc.BatchUpdateHuaWeiRoute).
+		Reads(new(protocloud.HuaWeiRouteBatchUpdateReq))
+	h.Add("ListHuaWeiRoute", "POST", "/list", svc.ListHuaWeiRoute).
+		Reads(new(core.ListReq)).Writes(new(protocloud.HuaWeiRouteListResult))
+	h.Add("ListAllHuaWeiRoute", "POST", "/list/all", svc.ListAllHuaWeiRoute).
+		Reads(new(protocloud.HuaWeiRouteListReq)).Writes(new(protocloud.HuaWeiRouteListResult))
+	h.Add("BatchDeleteHuaWeiRoute", "DELETE", "/batch", svc.BatchDeleteHuaWeiRoute).
+		Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -70,13 +70,16 @@ func initRouteTableService(svc *routeTableSvc, cap *capability.Capability) {
 	h.Add("BatchCreateRouteTable", "POST", "/vendors/{vendor}/route_tables/batch/create",
 		svc.BatchCreateRouteTable)
 	h.Add("BatchUpdateRouteTableBaseInfo", "PATCH", "/route_tables/base/batch",
-		svc.BatchUpdateRouteTableBaseInfo)
+		svc.BatchUpdateRouteTableBaseInfo).Reads(new(protocloud.RouteTableBaseInfoBatchUpdateReq))
 	h.Add("GetRouteTable", "GET", "/vendors/{vendor}/route_tables/{id}", svc.GetRouteTable)
-	h.Add("ListRouteTable", "POST", "/route_tables/list", svc.ListRouteTable)
+	h.Add("ListRouteTable", "POST", "/route_tables/list", svc.ListRouteTable).
+		Reads(new(core.ListReq)).Writes(new(protocloud.RouteTableListResult))
 	h.Add("ListRouteTableWithExtension", "POST", "/vendors/{vendor}/route_tables/list",
-		svc.ListRouteTableWithExtension)
-	h.Add("BatchDeleteRouteTable", "DELETE", "/route_tables/batch", svc.BatchDeleteRouteTable)
-	h.Add("CountRouteTableSubnets", "POST", "/route_tables/subnets/count", svc.CountRouteTableSubnets)
+		svc.ListRouteTableWithExtension).Reads(new(core.ListReq))
+	h.Add("BatchDeleteRouteTable", "DELETE", "/route_tables/batch", svc.BatchDeleteRouteTable).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("CountRouteTableSubnets", "POST", "/route_tables/subnets/count", svc.CountRouteTableSubnets).
+		Reads(new(dataservice.CountReq)).Writes(new([]protocloud.RouteTableSubnetsCountResult))
 
 	h.Load(cap.WebService)
 }
@@ -49,11 +49,16 @@ func initTCloudRouteService(svc *routeTableSvc, cap *capability.Capability) {
 	// TODO confirm if we should allow batch operation without route table id
 	h.Path("/vendors/tcloud/route_tables/{route_table_id}/routes")
 
-	h.Add("BatchCreateTCloudRoute", "POST", "/batch/create", svc.BatchCreateTCloudRoute)
-	h.Add("BatchUpdateTCloudRoute", "PATCH", "/batch", svc.BatchUpdateTCloudRoute)
-	h.Add("ListTCloudRoute", "POST", "/list", svc.ListTCloudRoute)
-	h.Add("ListAllTCloudRoute", "POST", "/list/all", svc.ListAllTCloudRoute)
-	h.Add("BatchDeleteTCloudRoute", "DELETE", "/batch", svc.BatchDeleteTCloudRoute)
+	h.Add("BatchCreateTCloudRoute", "POST", "/batch/create", svc.BatchCreateTCloudRoute).
+		Reads(new(protocloud.TCloudRouteBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateTCloudRoute", "PATCH", "/batch", svc.BatchUpdateTCloudRoute).
+		Reads(new(protocloud.TCloudRouteBatchUpdateReq))
+	h.Add("ListTCloudRoute", "POST", "/list", svc.ListTCloudRoute).
+		Reads(new(core.ListReq)).Writes(new(protocloud.TCloudRouteListResult))
+	h.Add("ListAllTCloudRoute", "POST", "/list/all", svc.ListAllTCloudRoute).
+		Reads(new(protocloud.TCloudRouteListReq)).Writes(new(protocloud.TCloudRouteListResult))
+	h.Add("BatchDeleteTCloudRoute", "DELETE", "/batch", svc.BatchDeleteTCloudRoute).
+		Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
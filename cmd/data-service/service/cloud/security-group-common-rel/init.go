@@ -23,6 +23,10 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	proto "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -36,18 +40,23 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateSgCommonRels", http.MethodPost, "/security_group_common_rels/batch/create",
-		svc.BatchCreateSgCommonRels)
+		svc.BatchCreateSgCommonRels).Reads(new(protocloud.SGCommonRelBatchCreateReq))
 	h.Add("BatchUpsertSgCommonRels", http.MethodPost, "/security_group_common_rels/batch/upsert",
-		svc.BatchUpsertSgCommonRels)
+		svc.BatchUpsertSgCommonRels).Reads(new(protocloud.SGCommonRelBatchUpsertReq))
 	h.Add("BatchDeleteSgCommonRels", http.MethodDelete, "/security_group_common_rels/batch",
-		svc.BatchDeleteSgCommonRels)
-	h.Add("ListSgCommonRels", http.MethodPost, "/security_group_common_rels/list", svc.ListSgCommonRels)
+		svc.BatchDeleteSgCommonRels).Reads(new(proto.BatchDeleteReq))
+	h.Add("ListSgCommonRels", http.MethodPost, "/security_group_common_rels/list", svc.ListSgCommonRels).
+		Reads(new(core.ListReq)).Writes(new(protocloud.SGCommonRelListResult))
 	h.Add("ListWithSecurityGroup", http.MethodPost, "/security_group_common_rels/with/security_group/list",
-		svc.ListWithSecurityGroup)
+		svc.ListWithSecurityGroup).
+		Reads(new(protocloud.SGCommonRelWithSecurityGroupListReq)).
+		Writes(new([]corecloud.SGCommonRelWithBaseSecurityGroup))
 	h.Add("ListSgCommonRelWithCVM", http.MethodPost, "/security_group_common_rels/with/cvm/list",
-		svc.ListWithCVMSummary)
+		svc.ListWithCVMSummary).
+		Reads(new(protocloud.SGCommonRelListReq)).Writes(new(protocloud.SGCommonRelWithCVMListResp))
 	h.Add("ListSgCommonRelWithLB", http.MethodPost, "/security_group_common_rels/with/load_balancer/list",
-		svc.ListWithLBSummary)
+		svc.ListWithLBSummary).
+		Reads(new(protocloud.SGCommonRelListReq)).Writes(new(protocloud.SGCommonRelWithLBListResp))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,10 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	proto "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,11 +39,15 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateSgCvmRels", http.MethodPost, "/security_group_cvm_rels/batch/create", svc.BatchCreateSgCvmRels)
-	h.Add("BatchDeleteSgCvmRels", http.MethodDelete, "/security_group_cvm_rels/batch", svc.BatchDeleteSgCvmRels)
-	h.Add("ListSgCvmRels", http.MethodPost, "/security_group_cvm_rels/list", svc.ListSgCvmRels)
+	h.Add("BatchCreateSgCvmRels", http.MethodPost, "/security_group_cvm_rels/batch/create", svc.BatchCreateSgCvmRels).
+		Reads(new(protocloud.SGCvmRelBatchCreateReq))
+	h.Add("BatchDeleteSgCvmRels", http.MethodDelete, "/security_group_cvm_rels/batch", svc.BatchDeleteSgCvmRels).
+		Reads(new(proto.BatchDeleteReq))
+	h.Add("ListSgCvmRels", http.MethodPost, "/security_group_cvm_rels/list", svc.ListSgCvmRels).
+		Reads(new(core.ListReq)).Writes(new(protocloud.SGCvmRelListResult))
 	h.Add("ListWithSecurityGroup", http.MethodPost, "/security_group_cvm_rels/with/security_group/list",
-		svc.ListWithSecurityGroup)
+		svc.ListWithSecurityGroup).
+		Reads(new(protocloud.SGCvmRelWithSecurityGroupListReq)).Writes(new([]corecloud.SGCvmRelWithBaseSecurityGroup))
 
 	h.Load(cap.WebService)
 }
@@ -50,13 +50,13 @@ func initAwsSGRuleService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateAwsRule", "POST", "/vendors/aws/security_groups/{security_group_id}/rules/batch/create",
-		svc.BatchCreateAwsRule)
+		svc.BatchCreateAwsRule).Reads(new(protocloud.AwsSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateAwsRule", "PUT", "/vendors/aws/security_groups/{security_group_id}/rules/batch",
-		svc.BatchUpdateAwsRule)
+		svc.BatchUpdateAwsRule).Reads(new(protocloud.AwsSGRuleBatchUpdateReq))
 	h.Add("ListAwsRule", "POST", "/vendors/aws/security_groups/{security_group_id}/rules/list",
-		svc.ListAwsRule)
+		svc.ListAwsRule).Reads(new(protocloud.AwsSGRuleListReq)).Writes(new(protocloud.AwsSGRuleListResult))
 	h.Add("DeleteAwsRule", "DELETE", "/vendors/aws/security_groups/{security_group_id}/rules/batch",
-		svc.DeleteAwsRule)
+		svc.DeleteAwsRule).Reads(new(protocloud.AwsSGRuleBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -51,13 +51,13 @@ func initAzureSGRuleService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateAzureRule", "POST", "/vendors/azure/security_groups/{security_group_id}/rules/batch/create",
-		svc.BatchCreateAzureRule)
+		svc.BatchCreateAzureRule).Reads(new(protocloud.AzureSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateAzureRule", "PUT", "/vendors/azure/security_groups/{security_group_id}/rules/batch",
-		svc.BatchUpdateAzureRule)
+		svc.BatchUpdateAzureRule).Reads(new(protocloud.AzureSGRuleBatchUpdateReq))
 	h.Add("ListAzureRule", "POST", "/vendors/azure/security_groups/{security_group_id}/rules/list",
-		svc.ListAzureRule)
+		svc.ListAzureRule).Reads(new(protocloud.AzureSGRuleListReq)).Writes(new(protocloud.AzureSGRuleListResult))
 	h.Add("DeleteAzureRule", "DELETE", "/vendors/azure/security_groups/{security_group_id}/rules/batch",
-		svc.DeleteAzureRule)
+		svc.DeleteAzureRule).Reads(new(protocloud.AzureSGRuleBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
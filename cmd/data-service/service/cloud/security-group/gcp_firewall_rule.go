@@ -49,12 +49,14 @@ func InitGcpFirewallRuleService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/batch/create",
-		svc.BatchCreateGcpFirewallRule)
+		svc.BatchCreateGcpFirewallRule).
+		Reads(new(protocloud.GcpFirewallRuleBatchCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateGcpFirewallRule", http.MethodPatch, "/vendors/gcp/firewalls/rules/batch/update",
-		svc.BatchUpdateGcpFirewallRule)
-	h.Add("ListGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/list", svc.ListGcpFirewallRule)
+		svc.BatchUpdateGcpFirewallRule).Reads(new(protocloud.GcpFirewallRuleBatchUpdateReq))
+	h.Add("ListGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/list", svc.ListGcpFirewallRule).
+		Reads(new(protocloud.GcpFirewallRuleListReq)).Writes(new(protocloud.GcpFirewallRuleListResult))
 	h.Add("BatchDeleteGcpFirewallRule", http.MethodDelete, "/vendors/gcp/firewalls/rules/batch",
-		svc.BatchDeleteGcpFirewallRule)
+		svc.BatchDeleteGcpFirewallRule).Reads(new(protocloud.GcpFirewallRuleBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
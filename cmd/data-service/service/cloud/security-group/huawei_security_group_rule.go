@@ -50,13 +50,13 @@ func initHuaWeiSGRuleService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateHuaWeiRule", "POST", "/vendors/huawei/security_groups/{security_group_id}/rules/batch/create",
-		svc.BatchCreateHuaWeiRule)
+		svc.BatchCreateHuaWeiRule).Reads(new(protocloud.HuaWeiSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateHuaWeiRule", "PUT", "/vendors/huawei/security_groups/{security_group_id}/rules/batch",
-		svc.BatchUpdateHuaWeiRule)
+		svc.BatchUpdateHuaWeiRule).Reads(new(protocloud.HuaWeiSGRuleBatchUpdateReq))
 	h.Add("ListHuaWeiRule", "POST", "/vendors/huawei/security_groups/{security_group_id}/rules/list",
-		svc.ListHuaWeiRule)
+		svc.ListHuaWeiRule).Reads(new(protocloud.HuaWeiSGRuleListReq)).Writes(new(protocloud.HuaWeiSGRuleListResult))
 	h.Add("DeleteHuaWeiRule", "DELETE", "/vendors/huawei/security_groups/{security_group_id}/rules/batch",
-		svc.DeleteHuaWeiRule)
+		svc.DeleteHuaWeiRule).Reads(new(protocloud.HuaWeiSGRuleBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -70,16 +70,19 @@ func initSecurityGroupService(cap *capability.Capability) {
 		svc.BatchUpdateSecurityGroup)
 	h.Add("GetSecurityGroup", http.MethodGet, "/vendors/{vendor}/security_groups/{id}",
 		svc.GetSecurityGroup)
-	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup)
-	h.Add("ListSecurityGroupExt", http.MethodPost, "/vendors/{vendor}/security_groups/list", svc.ListSecurityGroupExt)
-	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup)
+	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup).
+		Reads(new(protocloud.SecurityGroupListReq)).Writes(new(protocloud.SecurityGroupListResult))
+	h.Add("ListSecurityGroupExt", http.MethodPost, "/vendors/{vendor}/security_groups/list", svc.ListSecurityGroupExt).
+		Reads(new(core.ListReq))
+	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup).
+		Reads(new(protocloud.SecurityGroupBatchDeleteReq))
 	h.Add("BatchUpdateSecurityGroupCommonInfo", http.MethodPatch, "/security_groups/common/info/batch/update",
-		svc.BatchUpdateSecurityGroupCommonInfo)
+		svc.BatchUpdateSecurityGroupCommonInfo).Reads(new(protocloud.SecurityGroupCommonInfoBatchUpdateReq))
 	h.Add("BatchUpdateSecurityGroupMgmtAttr", http.MethodPatch, "/security_groups/mgmt_attrs/batch/update",
-		svc.BatchUpdateSecurityGroupMgmtAttr)
+		svc.BatchUpdateSecurityGroupMgmtAttr).Reads(new(protocloud.BatchUpdateSecurityGroupMgmtAttrReq))
 
 	h.Add("CountSecurityGroupRules", http.MethodPost, "/vendors/{vendor}/security_groups/rules/count",
-		svc.CountSecurityGroupRules)
+		svc.CountSecurityGroupRules).Reads(new(protocloud.CountSecurityGroupRuleReq)).Writes(new(map[string]int64))
 
 	h.Load(cap.WebService)
 }
@@ -102,6 +105,10 @@ func (svc *securityGroupSvc) BatchCreateSecurityGroup(cts *rest.Contexts) (inter
 		return batchCreateSecurityGroup[corecloud.AwsSecurityGroupExtension](vendor, svc, cts)
 	case enumor.HuaWei:
 		return batchCreateSecurityGroup[corecloud.HuaWeiSecurityGroupExtension](vendor, svc, cts)
+	case enumor.Aliyun:
+		return batchCreateSecurityGroup[corecloud.AliyunSecurityGroupExtension](vendor, svc, cts)
+	case enumor.OpenStack:
+		return batchCreateSecurityGroup[corecloud.OpenStackSecurityGroupExtension](vendor, svc, cts)
 	case enumor.Azure:
 		return batchCreateSecurityGroup[corecloud.AzureSecurityGroupExtension](vendor, svc, cts)
 	default:
@@ -123,6 +130,10 @@ func (svc *securityGroupSvc) BatchUpdateSecurityGroup(cts *rest.Contexts) (inter
 		return batchUpdateSecurityGroup[corecloud.AwsSecurityGroupExtension](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateSecurityGroup[corecloud.HuaWeiSecurityGroupExtension](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateSecurityGroup[corecloud.AliyunSecurityGroupExtension](cts, svc)
+	case enumor.OpenStack:
+		return batchUpdateSecurityGroup[corecloud.OpenStackSecurityGroupExtension](cts, svc)
 	case enumor.Azure:
 		return batchUpdateSecurityGroup[corecloud.AzureSecurityGroupExtension](cts, svc)
 	default:
@@ -287,6 +298,9 @@ func (svc *securityGroupSvc) deleteSecurityGroupRule(kt *kit.Kit, txn *sqlx.Tx,
 			err = svc.dao.HuaWeiSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
 		case enumor.Azure:
 			err = svc.dao.AzureSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
+		case enumor.Aliyun, enumor.OpenStack:
+			// 阿里云、OpenStack安全组规则暂不纳管，没有需要删除的规则
+			continue
 		default:
 			return fmt.Errorf("vendor: %s not support", vendor)
 		}
@@ -339,6 +353,10 @@ func (svc *securityGroupSvc) GetSecurityGroup(cts *rest.Contexts) (interface{},
 		return convertToSGResult[corecloud.AwsSecurityGroupExtension](base, sgTable.Extension)
 	case enumor.HuaWei:
 		return convertToSGResult[corecloud.HuaWeiSecurityGroupExtension](base, sgTable.Extension)
+	case enumor.Aliyun:
+		return convertToSGResult[corecloud.AliyunSecurityGroupExtension](base, sgTable.Extension)
+	case enumor.OpenStack:
+		return convertToSGResult[corecloud.OpenStackSecurityGroupExtension](base, sgTable.Extension)
 	case enumor.Azure:
 		return convertToSGResult[corecloud.AzureSecurityGroupExtension](base, sgTable.Extension)
 	default:
@@ -699,6 +717,10 @@ func (svc *securityGroupSvc) ListSecurityGroupExt(cts *rest.Contexts) (interface
 		return convSecurityGroupExtListResult[corecloud.AzureSecurityGroupExtension](sgDetails, sgBizInfo)
 	case enumor.HuaWei:
 		return convSecurityGroupExtListResult[corecloud.HuaWeiSecurityGroupExtension](sgDetails, sgBizInfo)
+	case enumor.Aliyun:
+		return convSecurityGroupExtListResult[corecloud.AliyunSecurityGroupExtension](sgDetails, sgBizInfo)
+	case enumor.OpenStack:
+		return convSecurityGroupExtListResult[corecloud.OpenStackSecurityGroupExtension](sgDetails, sgBizInfo)
 	default:
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
 	}
@@ -52,14 +52,15 @@ func initTCloudSGRuleService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateTCloudRule", "POST", "/vendors/tcloud/security_groups/{security_group_id}/rules/batch/create",
-		svc.BatchCreateTCloudRule)
+		svc.BatchCreateTCloudRule).Reads(new(protocloud.TCloudSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("BatchUpdateTCloudRule", "PUT", "/vendors/tcloud/security_groups/{security_group_id}/rules/batch",
-		svc.BatchUpdateTCloudRule)
+		svc.BatchUpdateTCloudRule).Reads(new(protocloud.TCloudSGRuleBatchUpdateReq))
 	h.Add("ListTCloudRule", "POST", "/vendors/tcloud/security_groups/{security_group_id}/rules/list",
-		svc.ListTCloudRule)
+		svc.ListTCloudRule).Reads(new(protocloud.TCloudSGRuleListReq)).Writes(new(protocloud.TCloudSGRuleListResult))
 	h.Add("DeleteTCloudRule", "DELETE", "/vendors/tcloud/security_groups/{security_group_id}/rules/batch",
-		svc.DeleteTCloudRule)
-	h.Add("ListTCloudRuleExt", "POST", "/vendors/tcloud/security_groups/rules/list", svc.ListTCloudRuleExt)
+		svc.DeleteTCloudRule).Reads(new(protocloud.TCloudSGRuleBatchDeleteReq))
+	h.Add("ListTCloudRuleExt", "POST", "/vendors/tcloud/security_groups/rules/list", svc.ListTCloudRuleExt).
+		Reads(new(protocloud.TCloudSGRuleListReq)).Writes(new(protocloud.TCloudSGRuleListExtResult))
 
 	h.Load(cap.WebService)
 }
@@ -62,6 +62,8 @@ func (svc *service) GetSubAccount(cts *rest.Contexts) (interface{}, error) {
 		account, err = convCoreSubAccount[coresubaccount.GcpExtension](dbAccount)
 	case enumor.Azure:
 		account, err = convCoreSubAccount[coresubaccount.AzureExtension](dbAccount)
+	case enumor.OpenStack:
+		account, err = convCoreSubAccount[coresubaccount.OpenStackExtension](dbAccount)
 	}
 
 	if err != nil {
@@ -183,6 +185,8 @@ func (svc *service) ListSubAccountExt(cts *rest.Contexts) (interface{}, error) {
 		return convListExtResult[coresubaccount.AzureExtension](result.Details)
 	case enumor.Gcp:
 		return convListExtResult[coresubaccount.GcpExtension](result.Details)
+	case enumor.OpenStack:
+		return convListExtResult[coresubaccount.OpenStackExtension](result.Details)
 
 	default:
 		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dssubaccount "hcm/pkg/api/data-service/cloud/sub-account"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,13 +38,18 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateSubAccount", http.MethodPost, "/sub_accounts/batch/create", svc.BatchCreateSubAccount)
-	h.Add("BatchDeleteSubAccount", http.MethodDelete, "/sub_accounts/batch", svc.BatchDeleteSubAccount)
-	h.Add("ListSubAccount", http.MethodPost, "/sub_accounts/list", svc.ListSubAccount)
-	h.Add("BatchUpdateSubAccount", http.MethodPatch, "/sub_accounts/batch/update", svc.BatchUpdateSubAccount)
+	h.Add("BatchCreateSubAccount", http.MethodPost, "/sub_accounts/batch/create", svc.BatchCreateSubAccount).
+		Reads(new(dssubaccount.CreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchDeleteSubAccount", http.MethodDelete, "/sub_accounts/batch", svc.BatchDeleteSubAccount).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("ListSubAccount", http.MethodPost, "/sub_accounts/list", svc.ListSubAccount).
+		Reads(new(core.ListReq)).Writes(new(dssubaccount.ListResult))
+	h.Add("BatchUpdateSubAccount", http.MethodPatch, "/sub_accounts/batch/update", svc.BatchUpdateSubAccount).
+		Reads(new(dssubaccount.UpdateReq))
 
 	h.Add("GetSubAccount", http.MethodGet, "/vendors/{vendor}/sub_accounts/{id}", svc.GetSubAccount)
-	h.Add("ListExt", http.MethodPost, "/vendors/{vendor}/sub_accounts/list", svc.ListSubAccountExt)
+	h.Add("ListExt", http.MethodPost, "/vendors/{vendor}/sub_accounts/list", svc.ListSubAccountExt).
+		Reads(new(core.ListReq))
 
 	h.Load(cap.WebService)
 }
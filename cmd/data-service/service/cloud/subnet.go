@@ -55,11 +55,13 @@ func InitSubnetService(cap *capability.Capability) {
 
 	h.Add("BatchCreateSubnet", "POST", "/vendors/{vendor}/subnets/batch/create", svc.BatchCreateSubnet)
 	h.Add("BatchUpdateSubnet", "PATCH", "/vendors/{vendor}/subnets/batch", svc.BatchUpdateSubnet)
-	h.Add("BatchUpdateSubnetBaseInfo", "PATCH", "/subnets/base/batch", svc.BatchUpdateSubnetBaseInfo)
+	h.Add("BatchUpdateSubnetBaseInfo", "PATCH", "/subnets/base/batch", svc.BatchUpdateSubnetBaseInfo).
+		Reads(new(protocloud.SubnetBaseInfoBatchUpdateReq))
 	h.Add("GetSubnet", "GET", "/vendors/{vendor}/subnets/{id}", svc.GetSubnet)
-	h.Add("ListSubnet", "POST", "/subnets/list", svc.ListSubnet)
-	h.Add("ListSubnetExt", "POST", "/vendors/{vendor}/subnets/list", svc.ListSubnetExt)
-	h.Add("DeleteSubnet", "DELETE", "/subnets/batch", svc.BatchDeleteSubnet)
+	h.Add("ListSubnet", "POST", "/subnets/list", svc.ListSubnet).
+		Reads(new(core.ListReq)).Writes(new(protocloud.SubnetListResult))
+	h.Add("ListSubnetExt", "POST", "/vendors/{vendor}/subnets/list", svc.ListSubnetExt).Reads(new(core.ListReq))
+	h.Add("DeleteSubnet", "DELETE", "/subnets/batch", svc.BatchDeleteSubnet).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -84,6 +86,10 @@ func (svc *subnetSvc) BatchCreateSubnet(cts *rest.Contexts) (interface{}, error)
 		return batchCreateSubnet[protocloud.GcpSubnetCreateExt](cts, vendor, svc)
 	case enumor.HuaWei:
 		return batchCreateSubnet[protocloud.HuaWeiSubnetCreateExt](cts, vendor, svc)
+	case enumor.Aliyun:
+		return batchCreateSubnet[protocloud.AliyunSubnetCreateExt](cts, vendor, svc)
+	case enumor.OpenStack:
+		return batchCreateSubnet[protocloud.OpenStackSubnetCreateExt](cts, vendor, svc)
 	case enumor.Azure:
 		return batchCreateSubnet[protocloud.AzureSubnetCreateExt](cts, vendor, svc)
 	}
@@ -182,6 +188,10 @@ func (svc *subnetSvc) BatchUpdateSubnet(cts *rest.Contexts) (interface{}, error)
 		return batchUpdateSubnet[protocloud.GcpSubnetUpdateExt](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateSubnet[protocloud.HuaWeiSubnetUpdateExt](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateSubnet[protocloud.AliyunSubnetUpdateExt](cts, svc)
+	case enumor.OpenStack:
+		return batchUpdateSubnet[protocloud.OpenStackSubnetUpdateExt](cts, svc)
 	case enumor.Azure:
 		return batchUpdateSubnet[protocloud.AzureSubnetUpdateExt](cts, svc)
 	}
@@ -343,6 +353,10 @@ func (svc *subnetSvc) GetSubnet(cts *rest.Contexts) (interface{}, error) {
 		return convertToSubnetResult[protocore.GcpSubnetExtension](base, dbSubnet.Extension)
 	case enumor.HuaWei:
 		return convertToSubnetResult[protocore.HuaWeiSubnetExtension](base, dbSubnet.Extension)
+	case enumor.Aliyun:
+		return convertToSubnetResult[protocore.AliyunSubnetExtension](base, dbSubnet.Extension)
+	case enumor.OpenStack:
+		return convertToSubnetResult[protocore.OpenStackSubnetExtension](base, dbSubnet.Extension)
 	case enumor.Azure:
 		return convertToSubnetResult[protocore.AzureSubnetExtension](base, dbSubnet.Extension)
 	}
@@ -531,6 +545,10 @@ func (svc *subnetSvc) ListSubnetExt(cts *rest.Contexts) (interface{}, error) {
 		return conSubnetExtListResult[protocore.AzureSubnetExtension](listResp.Details)
 	case enumor.HuaWei:
 		return conSubnetExtListResult[protocore.HuaWeiSubnetExtension](listResp.Details)
+	case enumor.Aliyun:
+		return conSubnetExtListResult[protocore.AliyunSubnetExtension](listResp.Details)
+	case enumor.OpenStack:
+		return conSubnetExtListResult[protocore.OpenStackSubnetExtension](listResp.Details)
 	case enumor.Gcp:
 		return conSubnetExtListResult[protocore.GcpSubnetExtension](listResp.Details)
 	default:
@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dssync "hcm/pkg/api/data-service/cloud/sync"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,10 +38,14 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListAccountSyncDetail", http.MethodPost, "/account_sync_details/list", svc.ListAccountSyncDetail)
-	h.Add("BatchDeleteAccountSD", http.MethodDelete, "/account_sync_details/batch", svc.BatchDeleteAccountSD)
-	h.Add("BatchCreateAccountSD", http.MethodPost, "/account_sync_details/batch/create", svc.BatchCreateAccountSD)
-	h.Add("BatchUpdateAccountSD", http.MethodPatch, "/account_sync_details/batch/update", svc.BatchUpdateAccountSD)
+	h.Add("ListAccountSyncDetail", http.MethodPost, "/account_sync_details/list", svc.ListAccountSyncDetail).
+		Reads(new(core.ListReq)).Writes(new(dssync.ListResult))
+	h.Add("BatchDeleteAccountSD", http.MethodDelete, "/account_sync_details/batch", svc.BatchDeleteAccountSD).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("BatchCreateAccountSD", http.MethodPost, "/account_sync_details/batch/create", svc.BatchCreateAccountSD).
+		Reads(new(dssync.CreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateAccountSD", http.MethodPatch, "/account_sync_details/batch/update", svc.BatchUpdateAccountSD).
+		Reads(new(dssync.UpdateReq))
 
 	h.Load(cap.WebService)
 }
@@ -55,11 +55,12 @@ func InitVpcService(cap *capability.Capability) {
 
 	h.Add("BatchCreateVpc", "POST", "/vendors/{vendor}/vpcs/batch/create", svc.BatchCreateVpc)
 	h.Add("BatchUpdateVpc", "PATCH", "/vendors/{vendor}/vpcs/batch", svc.BatchUpdateVpc)
-	h.Add("BatchUpdateVpcBaseInfo", "PATCH", "/vpcs/base/batch", svc.BatchUpdateVpcBaseInfo)
+	h.Add("BatchUpdateVpcBaseInfo", "PATCH", "/vpcs/base/batch", svc.BatchUpdateVpcBaseInfo).
+		Reads(new(protocloud.VpcBaseInfoBatchUpdateReq))
 	h.Add("GetVpc", "GET", "/vendors/{vendor}/vpcs/{id}", svc.GetVpc)
-	h.Add("ListVpc", "POST", "/vpcs/list", svc.ListVpc)
-	h.Add("ListVpcExt", "POST", "/vendors/{vendor}/vpcs/list", svc.ListVpcExt)
-	h.Add("DeleteVpc", "DELETE", "/vpcs/batch", svc.BatchDeleteVpc)
+	h.Add("ListVpc", "POST", "/vpcs/list", svc.ListVpc).Reads(new(core.ListReq)).Writes(new(protocloud.VpcListResult))
+	h.Add("ListVpcExt", "POST", "/vendors/{vendor}/vpcs/list", svc.ListVpcExt).Reads(new(core.ListReq))
+	h.Add("DeleteVpc", "DELETE", "/vpcs/batch", svc.BatchDeleteVpc).Reads(new(dataservice.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -84,6 +85,10 @@ func (svc *vpcSvc) BatchCreateVpc(cts *rest.Contexts) (interface{}, error) {
 		return batchCreateVpc[protocloud.GcpVpcCreateExt](cts, vendor, svc)
 	case enumor.HuaWei:
 		return batchCreateVpc[protocloud.HuaWeiVpcCreateExt](cts, vendor, svc)
+	case enumor.Aliyun:
+		return batchCreateVpc[protocloud.AliyunVpcCreateExt](cts, vendor, svc)
+	case enumor.OpenStack:
+		return batchCreateVpc[protocloud.OpenStackVpcCreateExt](cts, vendor, svc)
 	case enumor.Azure:
 		return batchCreateVpc[protocloud.AzureVpcCreateExt](cts, vendor, svc)
 	}
@@ -166,6 +171,10 @@ func (svc *vpcSvc) BatchUpdateVpc(cts *rest.Contexts) (interface{}, error) {
 		return batchUpdateVpc[protocloud.GcpVpcUpdateExt](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateVpc[protocloud.HuaWeiVpcUpdateExt](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateVpc[protocloud.AliyunVpcUpdateExt](cts, svc)
+	case enumor.OpenStack:
+		return batchUpdateVpc[protocloud.OpenStackVpcUpdateExt](cts, svc)
 	case enumor.Azure:
 		return batchUpdateVpc[protocloud.AzureVpcUpdateExt](cts, svc)
 	}
@@ -329,6 +338,10 @@ func (svc *vpcSvc) GetVpc(cts *rest.Contexts) (interface{}, error) {
 		return convertToVpcResult[protocore.GcpVpcExtension](base, dbVpc.Extension)
 	case enumor.HuaWei:
 		return convertToVpcResult[protocore.HuaWeiVpcExtension](base, dbVpc.Extension)
+	case enumor.Aliyun:
+		return convertToVpcResult[protocore.AliyunVpcExtension](base, dbVpc.Extension)
+	case enumor.OpenStack:
+		return convertToVpcResult[protocore.OpenStackVpcExtension](base, dbVpc.Extension)
 	case enumor.Azure:
 		return convertToVpcResult[protocore.AzureVpcExtension](base, dbVpc.Extension)
 	}
@@ -516,6 +529,10 @@ func (svc *vpcSvc) ListVpcExt(cts *rest.Contexts) (interface{}, error) {
 		return conVpcExtListResult[protocore.AzureVpcExtension](listResp.Details)
 	case enumor.HuaWei:
 		return conVpcExtListResult[protocore.HuaWeiVpcExtension](listResp.Details)
+	case enumor.Aliyun:
+		return conVpcExtListResult[protocore.AliyunVpcExtension](listResp.Details)
+	case enumor.OpenStack:
+		return conVpcExtListResult[protocore.OpenStackVpcExtension](listResp.Details)
 	case enumor.Gcp:
 		return conVpcExtListResult[protocore.GcpVpcExtension](listResp.Details)
 	default:
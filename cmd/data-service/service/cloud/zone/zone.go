@@ -55,9 +55,11 @@ func InitZoneService(cap *capability.Capability) {
 
 	h.Add("BatchUpdateZone", http.MethodPatch, "/vendors/{vendor}/zones/batch/update", svc.BatchUpdateZone)
 
-	h.Add("ListZone", http.MethodPost, "/zones/list", svc.ListZone)
+	h.Add("ListZone", http.MethodPost, "/zones/list", svc.ListZone).
+		Reads(new(protocloud.ZoneListReq)).Writes(new(protocloud.ZoneListResult))
 
-	h.Add("BatchDeleteZone", http.MethodDelete, "/zones/batch", svc.BatchDeleteZone)
+	h.Add("BatchDeleteZone", http.MethodDelete, "/zones/batch", svc.BatchDeleteZone).
+		Reads(new(protocloud.ZoneBatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -80,6 +82,8 @@ func (svc *zoneSvc) BatchCreateZone(cts *rest.Contexts) (interface{}, error) {
 		return batchCreateZone[zone.AwsZoneExtension](vendor, svc, cts)
 	case enumor.HuaWei:
 		return batchCreateZone[zone.HuaWeiZoneExtension](vendor, svc, cts)
+	case enumor.Aliyun:
+		return batchCreateZone[zone.AliyunZoneExtension](vendor, svc, cts)
 	case enumor.Gcp:
 		return batchCreateZone[zone.GcpZoneExtension](vendor, svc, cts)
 	default:
@@ -101,6 +105,8 @@ func (svc *zoneSvc) BatchUpdateZone(cts *rest.Contexts) (interface{}, error) {
 		return batchUpdateZone[zone.AwsZoneExtension](cts, svc)
 	case enumor.HuaWei:
 		return batchUpdateZone[zone.HuaWeiZoneExtension](cts, svc)
+	case enumor.Aliyun:
+		return batchUpdateZone[zone.AliyunZoneExtension](cts, svc)
 	case enumor.Azure:
 		return batchUpdateZone[zone.GcpZoneExtension](cts, svc)
 	default:
@@ -39,8 +39,9 @@ func InitService(cap *capability.Capability) {
 		ostore: cap.ObjectStore,
 	}
 	h := rest.NewHandler()
-	h.Add("GenerateTemporalUrl", http.MethodPost, "/cos/temporal_urls/{action}/generate", svc.GenerateTemporalUrl)
-	h.Add("UploadFile", http.MethodPost, "/cos/upload", svc.UploadFile)
+	h.Add("GenerateTemporalUrl", http.MethodPost, "/cos/temporal_urls/{action}/generate", svc.GenerateTemporalUrl).
+		Reads(new(cos.GenerateTemporalUrlReq)).Writes(new(cos.GenerateTemporalUrlResult))
+	h.Add("UploadFile", http.MethodPost, "/cos/upload", svc.UploadFile).Reads(new(cos.UploadFileReq))
 
 	h.Load(cap.WebService)
 }
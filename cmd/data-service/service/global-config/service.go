@@ -23,7 +23,9 @@ package globalconfig
 import (
 	"net/http"
 
+	"encoding/json"
 	"hcm/cmd/data-service/service/capability"
+	datagconf "hcm/pkg/api/data-service/global_config"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -37,10 +39,14 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	// common api
-	h.Add("ListGlobalConfigs", http.MethodPost, "/global_configs/list", svc.ListGlobalConfigs)
-	h.Add("BatchCreateGlobalConfigs", http.MethodPost, "/global_configs/batch/create", svc.BatchCreateGlobalConfigs)
-	h.Add("BatchUpdateGlobalConfigs", http.MethodPatch, "/global_configs/batch", svc.BatchUpdateGlobalConfigs)
-	h.Add("BatchDeleteGlobalConfigs", http.MethodDelete, "/global_configs/batch", svc.BatchDeleteGlobalConfigs)
+	h.Add("ListGlobalConfigs", http.MethodPost, "/global_configs/list", svc.ListGlobalConfigs).
+		Reads(new(datagconf.ListReq)).Writes(new(datagconf.ListResp))
+	h.Add("BatchCreateGlobalConfigs", http.MethodPost, "/global_configs/batch/create", svc.BatchCreateGlobalConfigs).
+		Reads(new(datagconf.BatchCreateReqT[json.RawMessage]))
+	h.Add("BatchUpdateGlobalConfigs", http.MethodPatch, "/global_configs/batch", svc.BatchUpdateGlobalConfigs).
+		Reads(new(datagconf.BatchUpdateReqT[json.RawMessage]))
+	h.Add("BatchDeleteGlobalConfigs", http.MethodDelete, "/global_configs/batch", svc.BatchDeleteGlobalConfigs).
+		Reads(new(datagconf.BatchDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency 幂等请求记录
+package idempotency
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	dsidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	daoidem "hcm/pkg/dal/dao/idempotency"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the idempotency record service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("AcquireIdempotencyKey", http.MethodPost, "/idempotency_records/acquire", svc.AcquireIdempotencyKey).
+		Reads(new(dsidem.AcquireReq)).Writes(new(dsidem.AcquireResult))
+	h.Add("CompleteIdempotencyRecord", http.MethodPatch, "/idempotency_records/complete",
+		svc.CompleteIdempotencyRecord).Reads(new(dsidem.CompleteReq))
+	h.Add("ReleaseIdempotencyKey", http.MethodDelete, "/idempotency_records/release", svc.ReleaseIdempotencyKey).
+		Reads(new(dsidem.ReleaseReq))
+	h.Add("DeleteExpiredIdempotencyRecord", http.MethodDelete, "/idempotency_records/expired",
+		svc.DeleteExpiredIdempotencyRecord).Reads(new(dsidem.DeleteExpiredReq)).Writes(new(dsidem.DeleteExpiredResult))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// AcquireIdempotencyKey acquire idempotency key for the request.
+func (svc *service) AcquireIdempotencyKey(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsidem.AcquireReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &daoidem.AcquireOption{
+		Scope:          req.Scope,
+		Key:            req.Key,
+		RequestHash:    req.RequestHash,
+		TTLSec:         req.TTLSec,
+		LockTimeoutSec: req.LockTimeoutSec,
+	}
+	record, err := svc.dao.IdempotencyRecord().Acquire(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("acquire idempotency key failed, err: %v, scope: %s, key: %s, rid: %s", err, req.Scope, req.Key,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	if record == nil {
+		return &dsidem.AcquireResult{Acquired: true}, nil
+	}
+
+	result := &dsidem.AcquireResult{
+		Acquired: false,
+		Record: &dsidem.Record{
+			RequestHash: record.RequestHash,
+			State:       record.State,
+			ExpiredAt:   string(record.ExpiredAt),
+		},
+	}
+	if record.Response != nil {
+		result.Record.Response = *record.Response
+	}
+
+	return result, nil
+}
+
+// CompleteIdempotencyRecord record the response of the request which holds the idempotency key.
+func (svc *service) CompleteIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsidem.CompleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := svc.dao.IdempotencyRecord().Complete(cts.Kit, req.Scope, req.Key, req.RequestHash, req.Response)
+	if err != nil {
+		logs.Errorf("complete idempotency record failed, err: %v, scope: %s, key: %s, rid: %s", err, req.Scope,
+			req.Key, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ReleaseIdempotencyKey release the idempotency key held by the failed request.
+func (svc *service) ReleaseIdempotencyKey(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsidem.ReleaseReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.IdempotencyRecord().Release(cts.Kit, req.Scope, req.Key, req.RequestHash); err != nil {
+		logs.Errorf("release idempotency key failed, err: %v, scope: %s, key: %s, rid: %s", err, req.Scope,
+			req.Key, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// DeleteExpiredIdempotencyRecord delete expired idempotency records of all tenants.
+func (svc *service) DeleteExpiredIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsidem.DeleteExpiredReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	deleted, err := svc.dao.IdempotencyRecord().DeleteExpired(cts.Kit, req.Limit)
+	if err != nil {
+		logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dsidem.DeleteExpiredResult{Deleted: deleted}, nil
+}
@@ -0,0 +1,178 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateRole batch create roles.
+func (svc *service) BatchCreateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchCreateRoleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablerbac.RoleTable, len(req.Roles))
+	for idx, one := range req.Roles {
+		models[idx] = tablerbac.RoleTable{
+			Name:     one.Name,
+			Policies: one.Policies,
+			Memo:     one.Memo,
+			Creator:  cts.Kit.User,
+			Reviser:  cts.Kit.User,
+		}
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.RbacRole().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+// BatchUpdateRole batch update roles.
+func (svc *service) BatchUpdateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchUpdateRoleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Roles {
+			model := &tablerbac.RoleTable{
+				Name:     one.Name,
+				Policies: one.Policies,
+				Memo:     one.Memo,
+				Reviser:  cts.Kit.User,
+			}
+			if err := svc.dao.RbacRole().UpdateByIDWithTx(cts.Kit, txn, one.ID, model); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRole list roles.
+func (svc *service) ListRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RbacRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsrbac.ListRoleResult{Count: result.Count}, nil
+	}
+
+	details := make([]corerbac.Role, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = convRole(one)
+	}
+
+	return &dsrbac.ListRoleResult{Details: details}, nil
+}
+
+func convRole(one tablerbac.RoleTable) corerbac.Role {
+	return corerbac.Role{
+		ID:        one.ID,
+		Name:      one.Name,
+		Policies:  one.Policies,
+		Memo:      one.Memo,
+		Creator:   one.Creator,
+		Reviser:   one.Reviser,
+		CreatedAt: one.CreatedAt.String(),
+		UpdatedAt: one.UpdatedAt.String(),
+	}
+}
+
+// BatchDeleteRole batch delete roles, the role bindings of the roles are deleted together.
+func (svc *service) BatchDeleteRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := svc.dao.RbacRoleBinding().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("role_id", req.IDs))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.dao.RbacRole().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete rbac role failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
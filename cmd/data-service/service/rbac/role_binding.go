@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateRoleBinding batch create role bindings.
+func (svc *service) BatchCreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchCreateRoleBindingReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	roleIDs := make([]string, 0, len(req.Bindings))
+	for _, one := range req.Bindings {
+		roleIDs = append(roleIDs, one.RoleID)
+	}
+	roleIDs = slice.Unique(roleIDs)
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: tools.ContainersExpression("id", roleIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	roles, err := svc.dao.RbacRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role failed, err: %v, ids: %v, rid: %s", err, roleIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(roles.Details) != len(roleIDs) {
+		return nil, errf.Newf(errf.RecordNotFound, "some roles of %v are not found", roleIDs)
+	}
+
+	models := make([]tablerbac.RoleBindingTable, len(req.Bindings))
+	for idx, one := range req.Bindings {
+		models[idx] = tablerbac.RoleBindingTable{
+			RoleID:    one.RoleID,
+			User:      one.User,
+			ScopeType: one.ScopeType,
+			ScopeIDs:  one.ScopeIDs,
+			Memo:      one.Memo,
+			Creator:   cts.Kit.User,
+			Reviser:   cts.Kit.User,
+		}
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.RbacRoleBinding().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+// BatchUpdateRoleBinding batch update role bindings.
+func (svc *service) BatchUpdateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchUpdateRoleBindingReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Bindings {
+			model := &tablerbac.RoleBindingTable{
+				ScopeType: one.ScopeType,
+				ScopeIDs:  one.ScopeIDs,
+				Memo:      one.Memo,
+				Reviser:   cts.Kit.User,
+			}
+			if err := svc.dao.RbacRoleBinding().UpdateByIDWithTx(cts.Kit, txn, one.ID, model); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRoleBinding list role bindings.
+func (svc *service) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RbacRoleBinding().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role binding failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsrbac.ListRoleBindingResult{Count: result.Count}, nil
+	}
+
+	details := make([]corerbac.RoleBinding, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = corerbac.RoleBinding{
+			ID:        one.ID,
+			RoleID:    one.RoleID,
+			User:      one.User,
+			ScopeType: one.ScopeType,
+			ScopeIDs:  one.ScopeIDs,
+			Memo:      one.Memo,
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		}
+	}
+
+	return &dsrbac.ListRoleBindingResult{Details: details}, nil
+}
+
+// BatchDeleteRoleBinding batch delete role bindings.
+func (svc *service) BatchDeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrbac.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.RbacRoleBinding().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete rbac role binding failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
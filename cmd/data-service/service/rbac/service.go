@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地鉴权角色及角色绑定
+package rbac
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the local rbac service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateRbacRole", http.MethodPost, "/rbac/roles/batch/create", svc.BatchCreateRole).
+		Reads(new(dsrbac.BatchCreateRoleReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateRbacRole", http.MethodPatch, "/rbac/roles/batch", svc.BatchUpdateRole).
+		Reads(new(dsrbac.BatchUpdateRoleReq))
+	h.Add("ListRbacRole", http.MethodPost, "/rbac/roles/list", svc.ListRole).
+		Reads(new(core.ListReq)).Writes(new(dsrbac.ListRoleResult))
+	h.Add("BatchDeleteRbacRole", http.MethodDelete, "/rbac/roles/batch", svc.BatchDeleteRole).
+		Reads(new(dsrbac.BatchDeleteReq))
+
+	h.Add("BatchCreateRbacRoleBinding", http.MethodPost, "/rbac/role_bindings/batch/create",
+		svc.BatchCreateRoleBinding).Reads(new(dsrbac.BatchCreateRoleBindingReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateRbacRoleBinding", http.MethodPatch, "/rbac/role_bindings/batch", svc.BatchUpdateRoleBinding).
+		Reads(new(dsrbac.BatchUpdateRoleBindingReq))
+	h.Add("ListRbacRoleBinding", http.MethodPost, "/rbac/role_bindings/list", svc.ListRoleBinding).
+		Reads(new(core.ListReq)).Writes(new(dsrbac.ListRoleBindingResult))
+	h.Add("BatchDeleteRbacRoleBinding", http.MethodDelete, "/rbac/role_bindings/batch", svc.BatchDeleteRoleBinding).
+		Reads(new(dsrbac.BatchDeleteReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
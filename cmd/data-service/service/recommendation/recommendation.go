@@ -0,0 +1,217 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recommendation
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablerecommend "hcm/pkg/dal/table/recommendation"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateResRecommendation batch create res recommendation.
+func (svc *service) BatchCreateResRecommendation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrecommend.BatchCreateResRecommendationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablerecommend.ResRecommendationTable, 0, len(req.Items))
+	for _, item := range req.Items {
+		detail, err := json.MarshalToString(item.Detail)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablerecommend.ResRecommendationTable{
+			Vendor:        item.Vendor,
+			AccountID:     item.AccountID,
+			BkBizID:       item.BkBizID,
+			Region:        item.Region,
+			Zone:          item.Zone,
+			ResType:       item.ResType,
+			ResID:         item.ResID,
+			CloudResID:    item.CloudResID,
+			ResName:       item.ResName,
+			RecType:       item.RecType,
+			Action:        item.RecType.Action(),
+			Detail:        tabletype.JsonField(detail),
+			MonthlySaving: item.MonthlySaving,
+			Currency:      item.Currency,
+			Status:        enumor.PendingRecommendStatus,
+		})
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.ResRecommendation().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("create res recommendation failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create res recommendation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create res recommendation but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateResRecommendation batch update res recommendation.
+func (svc *service) BatchUpdateResRecommendation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrecommend.BatchUpdateResRecommendationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablerecommend.ResRecommendationTable{Status: item.Status}
+			if err := svc.dao.ResRecommendation().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				return nil, fmt.Errorf("update res recommendation failed, err: %v, id: %s", err, item.ID)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update res recommendation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResRecommendation list res recommendation.
+func (svc *service) ListResRecommendation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	res, err := svc.dao.ResRecommendation().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res recommendation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list res recommendation failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dsrecommend.ListResRecommendationResult{Count: res.Count}, nil
+	}
+
+	details := make([]corerecommend.ResRecommendation, 0, len(res.Details))
+	for _, one := range res.Details {
+		detail := new(corerecommend.RecommendDetail)
+		if len(one.Detail) != 0 {
+			if err = json.UnmarshalFromString(string(one.Detail), detail); err != nil {
+				return nil, fmt.Errorf("unmarshal res recommendation detail failed, err: %v", err)
+			}
+		}
+
+		details = append(details, corerecommend.ResRecommendation{
+			ID:            one.ID,
+			Vendor:        one.Vendor,
+			AccountID:     one.AccountID,
+			BkBizID:       one.BkBizID,
+			Region:        one.Region,
+			Zone:          one.Zone,
+			ResType:       one.ResType,
+			ResID:         one.ResID,
+			CloudResID:    one.CloudResID,
+			ResName:       one.ResName,
+			RecType:       one.RecType,
+			Action:        one.Action,
+			Detail:        detail,
+			MonthlySaving: one.MonthlySaving,
+			Currency:      one.Currency,
+			Status:        one.Status,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsrecommend.ListResRecommendationResult{Details: details}, nil
+}
+
+// DeleteResRecommendation delete res recommendation.
+func (svc *service) DeleteResRecommendation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsrecommend.DeleteResRecommendationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.ResRecommendation().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete res recommendation failed, err: %v, filter: %v, rid: %s", err, req.Filter, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
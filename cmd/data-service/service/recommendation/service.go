@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation ...
+package recommendation
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the res recommendation service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateResRecommendation", http.MethodPost, "/res_recommendations/batch/create",
+		svc.BatchCreateResRecommendation).
+		Reads(new(dsrecommend.BatchCreateResRecommendationReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateResRecommendation", http.MethodPatch, "/res_recommendations/batch/update",
+		svc.BatchUpdateResRecommendation).Reads(new(dsrecommend.BatchUpdateResRecommendationReq))
+	h.Add("ListResRecommendation", http.MethodPost, "/res_recommendations/list", svc.ListResRecommendation).
+		Reads(new(core.ListReq)).Writes(new(dsrecommend.ListResRecommendationResult))
+	h.Add("DeleteResRecommendation", http.MethodDelete, "/res_recommendations/batch", svc.DeleteResRecommendation).
+		Reads(new(dsrecommend.DeleteResRecommendationReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
@@ -53,12 +53,16 @@ func InitRecycleRecordService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("BatchRecycleCloudResource", "POST", "/cloud/resources/batch/recycle", svc.BatchRecycleCloudResource)
-	h.Add("BatchRecoverCloudResource", "POST", "/cloud/resources/batch/recover", svc.BatchRecoverCloudResource)
-	h.Add("ListRecycleRecord", "POST", "/recycle_records/list", svc.ListRecycleRecord)
-	h.Add("BatchUpdateRecycleRecord", "PATCH", "/recycle_records/batch", svc.BatchUpdateRecycleRecord)
+	h.Add("BatchRecycleCloudResource", "POST", "/cloud/resources/batch/recycle", svc.BatchRecycleCloudResource).
+		Reads(new(protodata.BatchRecycleReq))
+	h.Add("BatchRecoverCloudResource", "POST", "/cloud/resources/batch/recover", svc.BatchRecoverCloudResource).
+		Reads(new(protodata.BatchRecoverReq))
+	h.Add("ListRecycleRecord", "POST", "/recycle_records/list", svc.ListRecycleRecord).
+		Reads(new(core.ListReq)).Writes(new(protodata.ListResult))
+	h.Add("BatchUpdateRecycleRecord", "PATCH", "/recycle_records/batch", svc.BatchUpdateRecycleRecord).
+		Reads(new(protodata.BatchUpdateReq))
 	h.Add("BatchUpdateRecycleStatus", "PATCH", "/recycle_records/recycle_status/batch",
-		svc.BatchUpdateRecycleStatus)
+		svc.BatchUpdateRecycleStatus).Reads(new(protodata.BatchUpdateRecycleStatusReq))
 
 	h.Load(cap.WebService)
 }
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	corereshistory "hcm/pkg/api/core/res-history"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ListResEvent list res events.
+func (svc *service) ListResEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResEvent().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res event failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsevent.ListEventResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreevent.Event, len(result.Details))
+	for idx, one := range result.Details {
+		if details[idx], err = convEvent(one); err != nil {
+			logs.Errorf("convert res event %d failed, err: %v, rid: %s", one.ID, err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return &dsevent.ListEventResult{Details: details}, nil
+}
+
+// ListEventDelivery list event deliveries.
+func (svc *service) ListEventDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.EventDelivery().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event delivery failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsevent.ListDeliveryResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreevent.Delivery, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = coreevent.Delivery{
+			ID:             one.ID,
+			SubscriptionID: one.SubscriptionID,
+			EventID:        one.EventID,
+			State:          one.State,
+			RetryCount:     one.RetryCount,
+			NextRetryAt:    one.NextRetryAt.String(),
+			StatusCode:     one.StatusCode,
+			LastError:      one.LastError,
+			CreatedAt:      one.CreatedAt.String(),
+			UpdatedAt:      one.UpdatedAt.String(),
+		}
+	}
+
+	return &dsevent.ListDeliveryResult{Details: details}, nil
+}
+
+// RetryEventDelivery reset the failed event deliveries to pending, so that they can be delivered again.
+func (svc *service) RetryEventDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsevent.RetryDeliveryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	count, err := svc.dao.EventDelivery().RetryFailed(cts.Kit, req.IDs)
+	if err != nil {
+		logs.Errorf("retry event delivery failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dsevent.RetryDeliveryResult{Count: count}, nil
+}
+
+func convEvent(one tableevent.ResEventTable) (coreevent.Event, error) {
+	diffs := make([]corereshistory.FieldDiff, 0)
+	if len(one.Diff) != 0 {
+		if err := json.UnmarshalFromString(string(one.Diff), &diffs); err != nil {
+			return coreevent.Event{}, err
+		}
+	}
+
+	return coreevent.Event{
+		ID:          one.ID,
+		EventType:   one.EventType,
+		ResType:     one.ResType,
+		ResID:       one.ResID,
+		CloudResID:  one.CloudResID,
+		ResName:     one.ResName,
+		Vendor:      one.Vendor,
+		AccountID:   one.AccountID,
+		BkBizID:     one.BkBizID,
+		PrevBkBizID: one.PrevBkBizID,
+		Version:     one.Version,
+		Diff:        diffs,
+		Source:      one.Source,
+		Rid:         one.Rid,
+		Operator:    one.Operator,
+		TenantID:    one.TenantID,
+		CreatedAt:   one.CreatedAt.String(),
+	}, nil
+}
@@ -0,0 +1,410 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/siem"
+	"hcm/pkg/tools/concurrence"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/ssl"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// maxDeliveryRetryDelay 推送失败后重试的最大间隔
+	maxDeliveryRetryDelay = time.Hour
+	// deliveryConcurrency 同时推送的最大请求数
+	deliveryConcurrency = 10
+	// cleanupInterval 清理过期事件及推送记录的周期
+	cleanupInterval = time.Hour
+	// cleanupBatchSize 单次清理的最大记录数
+	cleanupBatchSize = 1000
+)
+
+// Dispatcher 资源变更事件分发器，定时将待分发的事件按订阅的过滤条件生成推送记录，并将待推送的记录推送到订阅的
+// URL，推送失败时按指数退避重试，超过最大重试次数后标记为失败
+type Dispatcher struct {
+	conf   cc.ResEvent
+	dao    dao.Set
+	cipher cryptography.Crypto
+	client *http.Client
+}
+
+// NewDispatcher create res event dispatcher.
+func NewDispatcher(conf cc.ResEvent, daoSet dao.Set, cipher cryptography.Crypto) (*Dispatcher, error) {
+	cli, err := client.NewClient(&ssl.TLSConfig{
+		InsecureSkipVerify: conf.TLS.InsecureSkipVerify,
+		CertFile:           conf.TLS.CertFile,
+		KeyFile:            conf.TLS.KeyFile,
+		CAFile:             conf.TLS.CAFile,
+		Password:           conf.TLS.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cli.Timeout = time.Duration(conf.TimeoutSec) * time.Second
+
+	guard, err := newTargetGuard(conf.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 目标地址在建立连接时校验，不使用环境变量中的代理，否则校验的是代理地址
+	transport, ok := cli.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport type %T", cli.Transport)
+	}
+	transport.Proxy = nil
+	transport.Dial = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   guard.control,
+	}).DialContext
+
+	return &Dispatcher{conf: conf, dao: daoSet, cipher: cipher, client: cli}, nil
+}
+
+// Run dispatch and deliver res events periodically, only the master instance does it.
+func (d *Dispatcher) Run(state serviced.State) {
+	interval := time.Duration(d.conf.IntervalSec) * time.Second
+	logs.Infof("res event dispatcher enable && start, interval: %v", interval)
+
+	lastCleanup := time.Now()
+	for {
+		time.Sleep(interval)
+
+		if !state.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		for {
+			count, err := d.dispatchBatch(kt)
+			if err != nil {
+				logs.Errorf("dispatch res event failed, err: %v, rid: %s", err, kt.Rid)
+				break
+			}
+
+			if count < d.conf.BatchSize {
+				break
+			}
+		}
+
+		for {
+			count, err := d.deliverBatch(kt)
+			if err != nil {
+				logs.Errorf("deliver res event failed, err: %v, rid: %s", err, kt.Rid)
+				break
+			}
+
+			if count < d.conf.BatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			d.cleanup(kt)
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+// dispatchBatch generate deliveries of one batch of pending events for the matched subscriptions, returns the
+// count of events handled.
+func (d *Dispatcher) dispatchBatch(kt *kit.Kit) (uint, error) {
+	events, err := d.dao.ResEvent().ListPending(kt, d.conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	tenantSubs := make(map[string][]tableevent.EventSubscriptionTable)
+	ids := make([]uint64, 0, len(events))
+	deliveries := make([]tableevent.EventDeliveryTable, 0)
+	for _, one := range events {
+		ids = append(ids, one.ID)
+
+		subs, exists := tenantSubs[one.TenantID]
+		if !exists {
+			subs, err = d.listEnabledSubscriptions(kt.NewSubKitWithTenant(one.TenantID))
+			if err != nil {
+				return 0, err
+			}
+			tenantSubs[one.TenantID] = subs
+		}
+
+		event := &coreevent.Event{EventType: one.EventType, ResType: one.ResType, Vendor: one.Vendor,
+			BkBizID: one.BkBizID, PrevBkBizID: one.PrevBkBizID}
+		for _, sub := range subs {
+			if sub.Filter == nil || !coreevent.SubscriptionFilter(*sub.Filter).Match(event) {
+				continue
+			}
+
+			deliveries = append(deliveries, tableevent.EventDeliveryTable{
+				TenantID:       one.TenantID,
+				SubscriptionID: sub.ID,
+				EventID:        one.ID,
+			})
+		}
+	}
+
+	_, err = d.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := d.dao.EventDelivery().BatchCreateWithTx(kt, txn, deliveries); err != nil {
+			return nil, err
+		}
+
+		return nil, d.dao.ResEvent().MarkDispatchedWithTx(kt, txn, ids)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(len(events)), nil
+}
+
+// listEnabledSubscriptions list all the enabled subscriptions of the tenant of kit.
+func (d *Dispatcher) listEnabledSubscriptions(kt *kit.Kit) ([]tableevent.EventSubscriptionTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	subs := make([]tableevent.EventSubscriptionTable, 0)
+	for {
+		result, err := d.dao.EventSubscription().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list enabled event subscription failed, err: %v, tenant: %s, rid: %s", err, kt.TenantID,
+				kt.Rid)
+			return nil, err
+		}
+
+		subs = append(subs, result.Details...)
+		if len(result.Details) < int(opt.Page.Limit) {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return subs, nil
+}
+
+// deliverBatch deliver one batch of pending deliveries, returns the count of deliveries handled.
+func (d *Dispatcher) deliverBatch(kt *kit.Kit) (uint, error) {
+	deliveries, err := d.dao.EventDelivery().ListPending(kt, d.conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	eventIDs := make([]uint64, 0, len(deliveries))
+	tenantSubIDs := make(map[string][]string)
+	for _, one := range deliveries {
+		eventIDs = append(eventIDs, one.EventID)
+		tenantSubIDs[one.TenantID] = append(tenantSubIDs[one.TenantID], one.SubscriptionID)
+	}
+
+	events, err := d.dao.ResEvent().ListByIDs(kt, slice.Unique(eventIDs))
+	if err != nil {
+		return 0, err
+	}
+	eventMap := make(map[uint64]tableevent.ResEventTable, len(events))
+	for _, one := range events {
+		eventMap[one.ID] = one
+	}
+
+	subMap := make(map[string]tableevent.EventSubscriptionTable)
+	for tenantID, subIDs := range tenantSubIDs {
+		opt := &types.ListOption{
+			Filter: tools.ContainersExpression("id", slice.Unique(subIDs)),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := d.dao.EventSubscription().List(kt.NewSubKitWithTenant(tenantID), opt)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			subMap[subscriptionKey(tenantID, one.ID)] = one
+		}
+	}
+
+	// 单个推送记录的失败不影响其他推送记录，失败原因记录在推送记录中
+	_ = concurrence.BaseExec(deliveryConcurrency, deliveries, func(one tableevent.EventDeliveryTable) error {
+		event, exists := eventMap[one.EventID]
+		if !exists {
+			d.markFailed(kt, one.ID, "event is not found, it may be expired")
+			return nil
+		}
+
+		sub, exists := subMap[subscriptionKey(one.TenantID, one.SubscriptionID)]
+		if !exists || sub.Enabled == nil || !*sub.Enabled {
+			d.markFailed(kt, one.ID, "subscription is deleted or disabled")
+			return nil
+		}
+
+		d.deliver(kt, one, event, sub)
+		return nil
+	})
+
+	return uint(len(deliveries)), nil
+}
+
+func subscriptionKey(tenantID, id string) string {
+	return fmt.Sprintf("%s/%s", tenantID, id)
+}
+
+// deliver post the event to the subscription, and record the result of delivery.
+func (d *Dispatcher) deliver(kt *kit.Kit, delivery tableevent.EventDeliveryTable, event tableevent.ResEventTable,
+	sub tableevent.EventSubscriptionTable) {
+
+	statusCode, err := d.send(kt, delivery, event, sub)
+	if err == nil {
+		if err = d.dao.EventDelivery().MarkSucceeded(kt, delivery.ID, statusCode); err != nil {
+			logs.Errorf("mark event delivery %d succeeded failed, err: %v, rid: %s", delivery.ID, err, kt.Rid)
+		}
+		return
+	}
+
+	logs.Warnf("deliver event %d to subscription %s failed, err: %v, delivery: %d, rid: %s", event.ID, sub.ID, err,
+		delivery.ID, kt.Rid)
+
+	delay := min(time.Duration(d.conf.IntervalSec)*time.Second<<min(delivery.RetryCount, 16), maxDeliveryRetryDelay)
+	err = d.dao.EventDelivery().Retry(kt, delivery.ID, statusCode, err.Error(), uint64(delay.Seconds()),
+		d.conf.MaxRetry)
+	if err != nil {
+		logs.Errorf("update event delivery %d retry failed, err: %v, rid: %s", delivery.ID, err, kt.Rid)
+	}
+}
+
+// send post the event as json to the url of subscription with hmac signature, any response status other than
+// 2xx is regarded as failure, returns the response status code.
+func (d *Dispatcher) send(kt *kit.Kit, delivery tableevent.EventDeliveryTable, event tableevent.ResEventTable,
+	sub tableevent.EventSubscriptionTable) (int, error) {
+
+	payload, err := convEvent(event)
+	if err != nil {
+		return 0, fmt.Errorf("convert event failed, err: %v", err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("marshal event failed, err: %v", err)
+	}
+
+	secret, err := d.cipher.DecryptFromBase64(sub.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("decrypt subscription secret failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+	req.Header.Set(coreevent.EventTypeHeader, string(event.EventType))
+	req.Header.Set(coreevent.DeliveryIDHeader, strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set(siem.WebhookTimestampHeader, timestamp)
+	req.Header.Set(siem.WebhookSignatureHeader, "sha256="+siem.SignWebhook(secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errTargetNotAllowed) {
+			return 0, errTargetNotAllowed
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// 推送结果只记录状态码，不记录响应内容，避免订阅地址的响应内容通过推送记录泄露
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("subscription responds status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) markFailed(kt *kit.Kit, id uint64, reason string) {
+	if err := d.dao.EventDelivery().MarkFailed(kt, []uint64{id}, reason); err != nil {
+		logs.Errorf("mark event delivery %d failed failed, err: %v, rid: %s", id, err, kt.Rid)
+	}
+}
+
+// cleanup delete the dispatched events and finished deliveries exceed the retention days.
+func (d *Dispatcher) cleanup(kt *kit.Kit) {
+	before := time.Now().AddDate(0, 0, -int(d.conf.RetentionDays)).Format(constant.DateTimeLayout)
+
+	for {
+		deleted, err := d.dao.EventDelivery().DeleteExpired(kt, before, cleanupBatchSize)
+		if err != nil {
+			logs.Errorf("delete expired event delivery failed, err: %v, rid: %s", err, kt.Rid)
+			break
+		}
+
+		if deleted < cleanupBatchSize {
+			break
+		}
+	}
+
+	for {
+		deleted, err := d.dao.ResEvent().DeleteExpired(kt, before, cleanupBatchSize)
+		if err != nil {
+			logs.Errorf("delete expired res event failed, err: %v, rid: %s", err, kt.Rid)
+			break
+		}
+
+		if deleted < cleanupBatchSize {
+			break
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent 资源变更事件订阅及推送
+package resevent
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the res event service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao:    cap.Dao,
+		cipher: cap.Cipher,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateEventSubscription", http.MethodPost, "/event_subscriptions/batch/create",
+		svc.BatchCreateEventSubscription).
+		Reads(new(dsevent.BatchCreateSubscriptionReq)).Writes(new(core.BatchCreateResult))
+	h.Add("BatchUpdateEventSubscription", http.MethodPatch, "/event_subscriptions/batch",
+		svc.BatchUpdateEventSubscription).Reads(new(dsevent.BatchUpdateSubscriptionReq))
+	h.Add("ListEventSubscription", http.MethodPost, "/event_subscriptions/list", svc.ListEventSubscription).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListSubscriptionResult))
+	h.Add("BatchDeleteEventSubscription", http.MethodDelete, "/event_subscriptions/batch",
+		svc.BatchDeleteEventSubscription).Reads(new(dsevent.BatchDeleteReq))
+
+	h.Add("ListResEvent", http.MethodPost, "/res_events/list", svc.ListResEvent).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListEventResult))
+	h.Add("ListEventDelivery", http.MethodPost, "/event_deliveries/list", svc.ListEventDelivery).
+		Reads(new(core.ListReq)).Writes(new(dsevent.ListDeliveryResult))
+	h.Add("RetryEventDelivery", http.MethodPost, "/event_deliveries/retry", svc.RetryEventDelivery).
+		Reads(new(dsevent.RetryDeliveryReq)).Writes(new(dsevent.RetryDeliveryResult))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao    dao.Set
+	cipher cryptography.Crypto
+}
@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateEventSubscription batch create event subscriptions.
+func (svc *service) BatchCreateEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsevent.BatchCreateSubscriptionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tableevent.EventSubscriptionTable, len(req.Subscriptions))
+	for idx, one := range req.Subscriptions {
+		enabled := one.Enabled
+		if enabled == nil {
+			enabled = converter.ValToPtr(true)
+		}
+		filter := tableevent.Filter(one.Filter)
+		models[idx] = tableevent.EventSubscriptionTable{
+			Name:    one.Name,
+			URL:     one.URL,
+			Secret:  svc.cipher.EncryptToBase64(one.Secret),
+			Filter:  &filter,
+			Enabled: enabled,
+			Memo:    one.Memo,
+			Creator: cts.Kit.User,
+			Reviser: cts.Kit.User,
+		}
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.EventSubscription().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create event subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids.([]string)}, nil
+}
+
+// BatchUpdateEventSubscription batch update event subscriptions.
+func (svc *service) BatchUpdateEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsevent.BatchUpdateSubscriptionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]*tableevent.EventSubscriptionTable, len(req.Subscriptions))
+	for idx, one := range req.Subscriptions {
+		models[idx] = &tableevent.EventSubscriptionTable{
+			Name:    one.Name,
+			URL:     one.URL,
+			Enabled: one.Enabled,
+			Memo:    one.Memo,
+			Reviser: cts.Kit.User,
+		}
+		if len(one.Secret) != 0 {
+			models[idx].Secret = svc.cipher.EncryptToBase64(one.Secret)
+		}
+		if one.Filter != nil {
+			filter := tableevent.Filter(*one.Filter)
+			models[idx].Filter = &filter
+		}
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for idx, one := range req.Subscriptions {
+			if err := svc.dao.EventSubscription().UpdateByIDWithTx(cts.Kit, txn, one.ID, models[idx]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update event subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListEventSubscription list event subscriptions, the secret of subscription is never returned.
+func (svc *service) ListEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.EventSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event subscription failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsevent.ListSubscriptionResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreevent.Subscription, len(result.Details))
+	for idx, one := range result.Details {
+		details[idx] = convSubscription(one)
+	}
+
+	return &dsevent.ListSubscriptionResult{Details: details}, nil
+}
+
+// BatchDeleteEventSubscription batch delete event subscriptions, the pending deliveries of the deleted
+// subscriptions will be marked as failed when they are delivered.
+func (svc *service) BatchDeleteEventSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsevent.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.EventSubscription().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete event subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convSubscription(one tableevent.EventSubscriptionTable) coreevent.Subscription {
+	subscription := coreevent.Subscription{
+		ID:   one.ID,
+		Name: one.Name,
+		URL:  one.URL,
+		Memo: one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+	if one.Filter != nil {
+		subscription.SubscriptionFilter = coreevent.SubscriptionFilter(*one.Filter)
+	}
+	if one.Enabled != nil {
+		subscription.Enabled = *one.Enabled
+	}
+	return subscription
+}
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// errTargetNotAllowed the resolved address of subscription url is not allowed to be delivered to.
+var errTargetNotAllowed = errors.New("subscription url resolves to an address that is not allowed")
+
+// sharedAddressSpace 运营商级NAT地址段，部分云厂商用于内部服务，与私有地址一样不允许推送
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// targetGuard 限制推送请求的目标地址，订阅地址由用户配置，为避免通过订阅访问内网服务（SSRF），在域名解析后、建立连接前
+// 校验目标IP，不允许推送到私有、回环、链路本地等地址，除非在允许的网段内
+type targetGuard struct {
+	allowed []*net.IPNet
+}
+
+// newTargetGuard create target guard, allowedCIDRs are the internal networks allowed to be delivered to.
+func newTargetGuard(allowedCIDRs []string) (*targetGuard, error) {
+	g := &targetGuard{allowed: make([]*net.IPNet, 0, len(allowedCIDRs))}
+	for _, cidr := range allowedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("allowed cidr %s is invalid, err: %v", cidr, err)
+		}
+		g.allowed = append(g.allowed, ipNet)
+	}
+
+	return g, nil
+}
+
+// control is the control function of net.Dialer, it is called with the resolved address before connecting, so the
+// address can not be bypassed by dns rebinding or redirection.
+func (g *targetGuard) control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w, address: %s", errTargetNotAllowed, address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !g.allow(ip) {
+		return errTargetNotAllowed
+	}
+
+	return nil
+}
+
+// allow returns whether the ip is allowed to be delivered to.
+func (g *targetGuard) allow(ip net.IP) bool {
+	for _, one := range g.allowed {
+		if one.Contains(ip) {
+			return true
+		}
+	}
+
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestTargetGuardAllow(t *testing.T) {
+	guard, err := newTargetGuard([]string{"10.0.1.0/24"})
+	if err != nil {
+		t.Fatalf("new target guard failed, err: %v", err)
+	}
+
+	cases := map[string]bool{
+		"1.1.1.1":         true,
+		"2606:4700::1111": true,
+		"10.0.1.8":        true,
+		"10.0.2.8":        false,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"0.0.0.0":         false,
+		"169.254.0.23":    false,
+		"fe80::1":         false,
+		"192.168.1.1":     false,
+		"172.16.0.1":      false,
+		"100.64.0.1":      false,
+		"fd00::1":         false,
+		"::ffff:10.0.2.8": false,
+	}
+	for ip, expect := range cases {
+		if allowed := guard.allow(net.ParseIP(ip)); allowed != expect {
+			t.Errorf("ip %s allowed: %v, expect: %v", ip, allowed, expect)
+		}
+	}
+}
+
+func TestTargetGuardControl(t *testing.T) {
+	guard, err := newTargetGuard(nil)
+	if err != nil {
+		t.Fatalf("new target guard failed, err: %v", err)
+	}
+
+	if err = guard.control("tcp4", "127.0.0.1:8080", nil); !errors.Is(err, errTargetNotAllowed) {
+		t.Errorf("loopback address should not be allowed, err: %v", err)
+	}
+
+	if err = guard.control("tcp4", "1.1.1.1:443", nil); err != nil {
+		t.Errorf("public address should be allowed, err: %v", err)
+	}
+
+	if _, err = newTargetGuard([]string{"10.0.0.1"}); err == nil {
+		t.Errorf("invalid cidr should be rejected")
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corereshistory "hcm/pkg/api/core/res-history"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablereshistory "hcm/pkg/dal/table/res-history"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ListResChangeHistory list res change history.
+func (svc *service) ListResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	res, err := svc.dao.ResChangeHistory().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res change history failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list res change history failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dsreshistory.ListResChangeHistoryResult{Count: res.Count}, nil
+	}
+
+	details := make([]corereshistory.ResChangeHistory, 0, len(res.Details))
+	for _, one := range res.Details {
+		history, err := convResChangeHistory(cts.Kit, &one)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *history)
+	}
+
+	return &dsreshistory.ListResChangeHistoryResult{Details: details}, nil
+}
+
+// DiffResChangeHistory diff the snapshots of any two versions of the resource.
+func (svc *service) DiffResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsreshistory.DiffResChangeHistoryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", req.ResType),
+			tools.RuleEqual("res_id", req.ResID),
+			tools.RuleIn("version", []uint64{req.FromVersion, req.ToVersion}),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	res, err := svc.dao.ResChangeHistory().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res change history failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	histories := make(map[uint64]*corereshistory.ResChangeHistory, len(res.Details))
+	for _, one := range res.Details {
+		history, err := convResChangeHistory(cts.Kit, &one)
+		if err != nil {
+			return nil, err
+		}
+		histories[one.Version] = history
+	}
+
+	from, exists := histories[req.FromVersion]
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "%s(%s) version %d not found", req.ResType, req.ResID,
+			req.FromVersion)
+	}
+
+	to, exists := histories[req.ToVersion]
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "%s(%s) version %d not found", req.ResType, req.ResID,
+			req.ToVersion)
+	}
+
+	diff := corereshistory.DiffSnapshot(from.Snapshot, to.Snapshot)
+
+	// the snapshots are not returned, only the diff of them.
+	from.Snapshot, to.Snapshot = nil, nil
+	from.Diff, to.Diff = nil, nil
+
+	return &dsreshistory.DiffResChangeHistoryResult{From: from, To: to, Diff: diff}, nil
+}
+
+// DeleteExpiredResChangeHistory delete expired res change history.
+func (svc *service) DeleteExpiredResChangeHistory(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsreshistory.DeleteExpiredResChangeHistoryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	deleted, err := svc.dao.ResChangeHistory().DeleteExpired(cts.Kit, req.ResType, req.Before, req.Limit)
+	if err != nil {
+		logs.Errorf("delete expired res change history failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dsreshistory.DeleteExpiredResChangeHistoryResult{Deleted: deleted}, nil
+}
+
+func convResChangeHistory(kt *kit.Kit, one *tablereshistory.ResChangeHistoryTable) (*corereshistory.ResChangeHistory,
+	error) {
+
+	history := &corereshistory.ResChangeHistory{
+		ID:         one.ID,
+		ResType:    one.ResType,
+		ResID:      one.ResID,
+		CloudResID: one.CloudResID,
+		ResName:    one.ResName,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+		BkBizID:    one.BkBizID,
+		Version:    one.Version,
+		Action:     one.Action,
+		Diff:       make([]corereshistory.FieldDiff, 0),
+		Source:     one.Source,
+		Rid:        one.Rid,
+		Creator:    one.Creator,
+		CreatedAt:  one.CreatedAt.String(),
+	}
+
+	if len(one.Diff) != 0 {
+		if err := json.UnmarshalFromString(string(one.Diff), &history.Diff); err != nil {
+			logs.Errorf("unmarshal res change history(%s) diff failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	if len(one.Snapshot) != 0 {
+		if err := json.UnmarshalFromString(string(one.Snapshot), &history.Snapshot); err != nil {
+			logs.Errorf("unmarshal res change history(%s) snapshot failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory ...
+package reshistory
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the res change history service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResChangeHistory", http.MethodPost, "/res_change_histories/list", svc.ListResChangeHistory).
+		Reads(new(core.ListReq)).Writes(new(dsreshistory.ListResChangeHistoryResult))
+	h.Add("DiffResChangeHistory", http.MethodPost, "/res_change_histories/diff", svc.DiffResChangeHistory).
+		Reads(new(dsreshistory.DiffResChangeHistoryReq)).Writes(new(dsreshistory.DiffResChangeHistoryResult))
+	h.Add("DeleteExpiredResChangeHistory", http.MethodDelete, "/res_change_histories/expired",
+		svc.DeleteExpiredResChangeHistory).
+		Reads(new(dsreshistory.DeleteExpiredResChangeHistoryReq)).
+		Writes(new(dsreshistory.DeleteExpiredResChangeHistoryResult))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
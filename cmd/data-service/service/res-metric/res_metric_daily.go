@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreresmetric "hcm/pkg/api/core/res-metric"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tableresmetric "hcm/pkg/dal/table/res-metric"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpsertResMetricDaily 批量写入天级监控数据，先删除同一资源、指标、日期的旧数据再写入
+func (svc *service) BatchUpsertResMetricDaily(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsresmetric.BatchUpsertResMetricDailyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tableresmetric.ResMetricDailyTable, 0, len(req.Items))
+	for _, item := range req.Items {
+		models = append(models, tableresmetric.ResMetricDailyTable{
+			Vendor:      item.Vendor,
+			AccountID:   item.AccountID,
+			Region:      item.Region,
+			ResType:     item.ResType,
+			ResID:       item.ResID,
+			CloudResID:  item.CloudResID,
+			Metric:      item.Metric,
+			StatDate:    item.StatDate,
+			AvgValue:    item.AvgValue,
+			MaxValue:    item.MaxValue,
+			MinValue:    item.MinValue,
+			SampleCount: item.SampleCount,
+		})
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.ResMetricDaily().BatchUpsertWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch upsert res metric daily failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResMetricDaily list res metric daily.
+func (svc *service) ListResMetricDaily(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	res, err := svc.dao.ResMetricDaily().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res metric daily failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list res metric daily failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dsresmetric.ListResMetricDailyResult{Count: res.Count}, nil
+	}
+
+	details := make([]coreresmetric.ResMetricDaily, 0, len(res.Details))
+	for _, one := range res.Details {
+		details = append(details, coreresmetric.ResMetricDaily{
+			ID:          one.ID,
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			Region:      one.Region,
+			ResType:     one.ResType,
+			ResID:       one.ResID,
+			CloudResID:  one.CloudResID,
+			Metric:      one.Metric,
+			StatDate:    one.StatDate,
+			AvgValue:    one.AvgValue,
+			MaxValue:    one.MaxValue,
+			MinValue:    one.MinValue,
+			SampleCount: one.SampleCount,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsresmetric.ListResMetricDailyResult{Details: details}, nil
+}
@@ -28,9 +28,11 @@ import (
 	"strconv"
 	"time"
 
+	accesstoken "hcm/cmd/data-service/service/access-token"
 	mainaccount "hcm/cmd/data-service/service/account-set/main-account"
 	rootaccount "hcm/cmd/data-service/service/account-set/root-account"
 	"hcm/cmd/data-service/service/application"
+	assignrule "hcm/cmd/data-service/service/assign-rule"
 	"hcm/cmd/data-service/service/audit"
 	"hcm/cmd/data-service/service/auth"
 	"hcm/cmd/data-service/service/bill/billadjustmentitem"
@@ -56,6 +58,7 @@ import (
 	"hcm/cmd/data-service/service/cloud/cvm"
 	"hcm/cmd/data-service/service/cloud/disk"
 	diskcvmrel "hcm/cmd/data-service/service/cloud/disk-cvm-rel"
+	disksnapshot "hcm/cmd/data-service/service/cloud/disk-snapshot"
 	"hcm/cmd/data-service/service/cloud/eip"
 	eipcvmrel "hcm/cmd/data-service/service/cloud/eip-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/image"
@@ -74,22 +77,31 @@ import (
 	"hcm/cmd/data-service/service/cloud/zone"
 	"hcm/cmd/data-service/service/cos"
 	globalconfig "hcm/cmd/data-service/service/global-config"
+	"hcm/cmd/data-service/service/idempotency"
+	"hcm/cmd/data-service/service/rbac"
+	"hcm/cmd/data-service/service/recommendation"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
+	resevent "hcm/cmd/data-service/service/res-event"
+	reshistory "hcm/cmd/data-service/service/res-history"
+	resmetric "hcm/cmd/data-service/service/res-metric"
 	"hcm/cmd/data-service/service/task"
 	"hcm/cmd/data-service/service/tenant"
 	"hcm/cmd/data-service/service/user"
+	proto "hcm/pkg/api/data-service/audit"
 	"hcm/pkg/cc"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/handler"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/cmdb"
+	"hcm/pkg/thirdparty/siem"
 	"hcm/pkg/tools/ssl"
 
 	"github.com/emicklei/go-restful/v3"
@@ -102,11 +114,17 @@ type Service struct {
 	cipher      cryptography.Crypto
 	objectStore objectstore.Storage
 	cmdbClient  cmdb.Client
+	auditSinks  []siem.Sink
+	// eventDispatcher 资源变更事件分发器，未开启资源变更事件推送时为空
+	eventDispatcher *resevent.Dispatcher
 }
 
 // NewService create a service instance.
 func NewService() (*Service, error) {
-	dao, err := dao.NewDaoSet(cc.DataService().Database)
+	streamConf := cc.DataService().AuditStream
+	eventConf := cc.DataService().ResEvent
+	dao, err := dao.NewDaoSet(cc.DataService().Database, dao.WithAuditOutbox(streamConf.Enable),
+		dao.WithResEvent(eventConf.Enable))
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +153,21 @@ func NewService() (*Service, error) {
 		objectStore: oStore,
 		cmdbClient:  cmdbCli,
 	}
+
+	if streamConf.Enable {
+		svr.auditSinks, err = siem.NewSinks(streamConf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if eventConf.Enable {
+		svr.eventDispatcher, err = resevent.NewDispatcher(eventConf, dao, cipher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return svr, nil
 }
 
@@ -227,6 +260,7 @@ func (s *Service) apiSet() *restful.Container {
 	region.InitRegionService(capability)
 	resourcegroup.InitAzureResourceGroupService(capability)
 	audit.InitAuditService(capability)
+	audit.InitChainService(capability)
 	eip.InitEipService(capability)
 	zone.InitZoneService(capability)
 	image.InitService(capability)
@@ -264,6 +298,7 @@ func (s *Service) apiSet() *restful.Container {
 	if capability.ObjectStore != nil {
 		rawbill.InitService(capability)
 		cos.InitService(capability)
+		audit.InitArchiveService(capability)
 	}
 	cert.InitService(capability)
 	loadbalancer.InitService(capability)
@@ -275,12 +310,65 @@ func (s *Service) apiSet() *restful.Container {
 
 	task.InitService(capability)
 	tenant.InitService(capability)
+	resmetric.InitService(capability)
+	reshistory.InitService(capability)
+	recommendation.InitService(capability)
+	disksnapshot.InitService(capability)
 
 	resusagebizrel.InitService(capability)
+	rbac.InitService(capability)
+	accesstoken.InitService(capability)
+	assignrule.InitService(capability)
+	resevent.InitService(capability)
+	idempotency.InitService(capability)
 
 	return restful.NewContainer().Add(capability.WebService)
 }
 
+// VerifyAuditChain verify the audit hash chain of the tenant, used by the control tool.
+func (s *Service) VerifyAuditChain(kt *kit.Kit, startSeq, limit uint64) (interface{}, error) {
+	req := &proto.VerifyAuditChainReq{StartSeq: startSeq, Limit: limit}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return audit.VerifyChain(kt, s.dao, s.objectStore, cc.DataService().AuditChain.CheckpointSignKey, req)
+}
+
+// StreamAudit start streaming audits to external SIEM if it is enabled, only the master instance streams audits.
+func (s *Service) StreamAudit(state serviced.State) {
+	if !cc.DataService().AuditStream.Enable {
+		return
+	}
+
+	go audit.StreamAudit(cc.DataService().AuditStream, s.auditSinks, s.dao, state)
+}
+
+// DispatchResEvent start dispatching and delivering res change events to the subscriptions if it is enabled, only
+// the master instance delivers events.
+func (s *Service) DispatchResEvent(state serviced.State) {
+	if s.eventDispatcher == nil {
+		return
+	}
+
+	go s.eventDispatcher.Run(state)
+}
+
+// AuditOutboxStats count the audits to be streamed group by state, used by the control tool.
+func (s *Service) AuditOutboxStats(kt *kit.Kit) (interface{}, error) {
+	return s.dao.AuditOutbox().CountByState(kt)
+}
+
+// RetryFailedAuditOutbox reset the audits failed to be streamed to pending, used by the control tool.
+func (s *Service) RetryFailedAuditOutbox(kt *kit.Kit) (interface{}, error) {
+	count, err := s.dao.AuditOutbox().ResetFailed(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]int64{"count": count}, nil
+}
+
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
 	if shutdown.IsShuttingDown() {
@@ -23,6 +23,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/task"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,16 +37,25 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("CreateTaskManagement", http.MethodPost, "/task_managements/create", svc.CreateTaskManagement)
-	h.Add("DeleteTaskManagement", http.MethodDelete, "/task_managements/delete", svc.DeleteTaskManagement)
-	h.Add("UpdateTaskManagement", http.MethodPatch, "/task_managements/update", svc.UpdateTaskManagement)
-	h.Add("ListTaskManagement", http.MethodPost, "/task_managements/list", svc.ListTaskManagement)
-	h.Add("CancelTaskManagement", http.MethodPatch, "/task_managements/cancel", svc.CancelTaskManagement)
+	h.Add("CreateTaskManagement", http.MethodPost, "/task_managements/create", svc.CreateTaskManagement).
+		Reads(new(task.CreateManagementReq)).Writes(new(core.BatchCreateResult))
+	h.Add("DeleteTaskManagement", http.MethodDelete, "/task_managements/delete", svc.DeleteTaskManagement).
+		Reads(new(task.DeleteManagementReq))
+	h.Add("UpdateTaskManagement", http.MethodPatch, "/task_managements/update", svc.UpdateTaskManagement).
+		Reads(new(task.UpdateManagementReq))
+	h.Add("ListTaskManagement", http.MethodPost, "/task_managements/list", svc.ListTaskManagement).
+		Reads(new(core.ListReq)).Writes(new(task.ListManagementResult))
+	h.Add("CancelTaskManagement", http.MethodPatch, "/task_managements/cancel", svc.CancelTaskManagement).
+		Reads(new(task.CancelReq))
 
-	h.Add("CreateTaskDetail", http.MethodPost, "/task_details/create", svc.CreateTaskDetail)
-	h.Add("UpdateTaskDetail", http.MethodPatch, "/task_details/update", svc.UpdateTaskDetail)
-	h.Add("DeleteTaskDetail", http.MethodDelete, "/task_details/delete", svc.DeleteTaskDetail)
-	h.Add("ListTaskDetail", http.MethodPost, "/task_details/list", svc.ListTaskDetail)
+	h.Add("CreateTaskDetail", http.MethodPost, "/task_details/create", svc.CreateTaskDetail).
+		Reads(new(task.CreateDetailReq)).Writes(new(core.BatchCreateResult))
+	h.Add("UpdateTaskDetail", http.MethodPatch, "/task_details/update", svc.UpdateTaskDetail).
+		Reads(new(task.UpdateDetailReq))
+	h.Add("DeleteTaskDetail", http.MethodDelete, "/task_details/delete", svc.DeleteTaskDetail).
+		Reads(new(task.DeleteDetailReq))
+	h.Add("ListTaskDetail", http.MethodPost, "/task_details/list", svc.ListTaskDetail).
+		Reads(new(core.ListReq)).Writes(new(task.ListDetailResult))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/tenant"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,9 +37,11 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("CreateTenant", http.MethodPost, "/tenants/create", svc.CreateTenant)
-	h.Add("UpdateTenant", http.MethodPatch, "/tenants/update", svc.UpdateTenant)
-	h.Add("ListTenant", http.MethodPost, "/tenants/list", svc.ListTenant)
+	h.Add("CreateTenant", http.MethodPost, "/tenants/create", svc.CreateTenant).
+		Reads(new(tenant.CreateTenantReq)).Writes(new(core.BatchCreateResult))
+	h.Add("UpdateTenant", http.MethodPatch, "/tenants/update", svc.UpdateTenant).Reads(new(tenant.UpdateTenantReq))
+	h.Add("ListTenant", http.MethodPost, "/tenants/list", svc.ListTenant).
+		Reads(new(core.ListReq)).Writes(new(tenant.ListTenantResult))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsuser "hcm/pkg/api/data-service/user"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -35,9 +38,12 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListUserCollection", http.MethodPost, "/users/collections/list", svc.ListUserCollection)
-	h.Add("BatchDeleteUserCollection", http.MethodDelete, "/users/collections/batch", svc.BatchDeleteUserCollection)
-	h.Add("CreateUserCollection", http.MethodPost, "/users/collections/create", svc.CreateUserCollection)
+	h.Add("ListUserCollection", http.MethodPost, "/users/collections/list", svc.ListUserCollection).
+		Reads(new(core.ListReq))
+	h.Add("BatchDeleteUserCollection", http.MethodDelete, "/users/collections/batch", svc.BatchDeleteUserCollection).
+		Reads(new(dataservice.BatchDeleteReq))
+	h.Add("CreateUserCollection", http.MethodPost, "/users/collections/create", svc.CreateUserCollection).
+		Reads(new(dsuser.UserCollectionCreateReq)).Writes(new(core.CreateResult))
 
 	h.Load(cap.WebService)
 }
@@ -21,10 +21,12 @@ package cloudadaptor
 
 import (
 	"hcm/pkg/adaptor"
+	"hcm/pkg/adaptor/aliyun"
 	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/openstack"
 	"hcm/pkg/adaptor/tcloud"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
@@ -86,6 +88,26 @@ func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (*huawei.Hu
 	return cli.adaptor.HuaWei(secret)
 }
 
+// Aliyun return aliyun client.
+func (cli *CloudAdaptorClient) Aliyun(kt *kit.Kit, accountID string) (*aliyun.Aliyun, error) {
+	secret, err := cli.secretCli.AliyunSecret(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.adaptor.Aliyun(secret)
+}
+
+// OpenStack return openstack client.
+func (cli *CloudAdaptorClient) OpenStack(kt *kit.Kit, accountID string) (*openstack.OpenStack, error) {
+	credential, err := cli.secretCli.OpenStackCredential(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.adaptor.OpenStack(credential)
+}
+
 // Gcp return gcp client.
 func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpCredential(kt, accountID)
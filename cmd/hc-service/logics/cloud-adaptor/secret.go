@@ -109,6 +109,54 @@ func (cli *SecretClient) HuaWeiSecret(kt *kit.Kit, accountID string) (*types.Bas
 	return secret, nil
 }
 
+// AliyunSecret get aliyun secret and validate secret.
+func (cli *SecretClient) AliyunSecret(kt *kit.Kit, accountID string) (*types.BaseSecret, error) {
+	account, err := cli.data.Aliyun.Account.Get(kt.Ctx, kt.Header(), accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get aliyun account failed, err: %v", err)
+	}
+
+	if account.Extension == nil {
+		return nil, errors.New("aliyun account extension is nil")
+	}
+
+	secret := &types.BaseSecret{
+		CloudSecretID:  account.Extension.CloudSecretID,
+		CloudSecretKey: account.Extension.CloudSecretKey,
+	}
+
+	if err := secret.Validate(); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// OpenStackCredential get openstack credential and validate credential.
+func (cli *SecretClient) OpenStackCredential(kt *kit.Kit, accountID string) (*types.OpenStackCredential, error) {
+	account, err := cli.data.OpenStack.Account.Get(kt.Ctx, kt.Header(), accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get openstack account failed, err: %v", err)
+	}
+
+	if account.Extension == nil {
+		return nil, errors.New("openstack account extension is nil")
+	}
+
+	credential := &types.OpenStackCredential{
+		AuthURL:        account.Extension.CloudAuthURL,
+		Region:         account.Extension.CloudRegion,
+		CloudSecretID:  account.Extension.CloudSecretID,
+		CloudSecretKey: account.Extension.CloudSecretKey,
+	}
+
+	if err := credential.Validate(); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
 // AzureCredential get azure credential and validate credential.
 func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.AzureCredential, error) {
 	account, err := cli.data.Azure.Account.Get(kt.Ctx, kt.Header(), accountID)
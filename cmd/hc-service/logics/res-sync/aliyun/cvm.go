@@ -0,0 +1,575 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncCvmOption ...
+type SyncCvmOption struct {
+}
+
+// Validate ...
+func (opt SyncCvmOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Cvm ...
+func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmFromCloud, err := cli.listCvmFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	cvmFromDB, err := cli.listCvmFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cvmFromCloud) == 0 && len(cvmFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.AliyunCvm, corecvm.Cvm[corecvm.AliyunCvmExtension]](
+		cvmFromCloud, cvmFromDB, isCvmChange)
+
+	if len(delCloudIDs) > 0 {
+		if err := cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createCvm(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateCvm(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// cvmRelMaps cvm 关联的 vpc、子网、镜像的云上ID与本地ID映射
+type cvmRelMaps struct {
+	vpcMap    map[string]string
+	subnetMap map[string]string
+	imageMap  map[string]string
+}
+
+func (cli *client) getCvmRelMaps(kt *kit.Kit, accountID string, region string, cvms []typescvm.AliyunCvm) (
+	*cvmRelMaps, error) {
+
+	cloudVpcIDs := make([]string, 0, len(cvms))
+	cloudSubnetIDs := make([]string, 0, len(cvms))
+	cloudImageIDs := make([]string, 0, len(cvms))
+	for _, one := range cvms {
+		cloudVpcIDs = append(cloudVpcIDs, one.VpcAttributes.VpcID)
+		cloudSubnetIDs = append(cloudSubnetIDs, one.VpcAttributes.VSwitchID)
+		cloudImageIDs = append(cloudImageIDs, one.ImageID)
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetMap, err := cli.getSubnetMap(kt, accountID, region, cloudSubnetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	imageMap, err := cli.getImageMap(kt, accountID, region, cloudImageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cvmRelMaps{vpcMap: vpcMap, subnetMap: subnetMap, imageMap: imageMap}, nil
+}
+
+func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]typescvm.AliyunCvm) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("cvm updateMap is <= 0, not update")
+	}
+
+	cvms := make([]typescvm.AliyunCvm, 0, len(updateMap))
+	for _, one := range updateMap {
+		cvms = append(cvms, one)
+	}
+	relMaps, err := cli.getCvmRelMaps(kt, accountID, region, cvms)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchUpdateWithExtension[corecvm.AliyunCvmExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		vpcID, exist := relMaps.vpcMap[one.VpcAttributes.VpcID]
+		if !exist {
+			return fmt.Errorf("cvm %s can not find vpc", one.InstanceID)
+		}
+
+		subnetID, exist := relMaps.subnetMap[one.VpcAttributes.VSwitchID]
+		if !exist {
+			return fmt.Errorf("cvm %s can not find subnet", one.InstanceID)
+		}
+
+		updateOne := protocloud.CvmBatchUpdateWithExtension[corecvm.AliyunCvmExtension]{
+			CvmBatchUpdate: protocloud.CvmBatchUpdate{
+				ID:             id,
+				Name:           one.InstanceName,
+				CloudVpcIDs:    []string{one.VpcAttributes.VpcID},
+				VpcIDs:         []string{vpcID},
+				CloudSubnetIDs: []string{one.VpcAttributes.VSwitchID},
+				SubnetIDs:      []string{subnetID},
+				CloudImageID:   one.ImageID,
+				ImageID:        relMaps.imageMap[one.ImageID],
+				// 备注字段云上没有，仅限hcm内部使用
+				Memo:                 nil,
+				Status:               one.Status,
+				PrivateIPv4Addresses: one.VpcAttributes.PrivateIpAddress.IpAddress,
+				PublicIPv4Addresses:  one.PublicIPv4Addresses(),
+				CloudLaunchedTime:    one.StartTime,
+				CloudExpiredTime:     one.ExpiredTime,
+				OsName:               one.OSName,
+				MachineType:          one.InstanceType,
+			},
+			Extension: buildCvmExtension(one),
+		}
+
+		lists = append(lists, updateOne)
+	}
+
+	updateReq := protocloud.CvmBatchUpdateReq[corecvm.AliyunCvmExtension]{
+		Cvms: lists,
+	}
+	if err := cli.dbCli.Aliyun.Cvm.BatchUpdateCvm(kt.Ctx, kt.Header(), &updateReq); err != nil {
+		logs.Errorf("[%s] request aliyun dataservice BatchUpdateCvm failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to update cvm success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createCvm(kt *kit.Kit, accountID string, region string, addSlice []typescvm.AliyunCvm) error {
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("cvm addSlice is <= 0, not create")
+	}
+
+	relMaps, err := cli.getCvmRelMaps(kt, accountID, region, addSlice)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchCreate[corecvm.AliyunCvmExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		vpcID, exist := relMaps.vpcMap[one.VpcAttributes.VpcID]
+		if !exist {
+			return fmt.Errorf("cvm %s can not find vpc", one.InstanceID)
+		}
+
+		subnetID, exist := relMaps.subnetMap[one.VpcAttributes.VSwitchID]
+		if !exist {
+			return fmt.Errorf("cvm %s can not find subnet", one.InstanceID)
+		}
+
+		addOne := protocloud.CvmBatchCreate[corecvm.AliyunCvmExtension]{
+			CloudID:        one.InstanceID,
+			Name:           one.InstanceName,
+			BkBizID:        constant.UnassignedBiz,
+			BkHostID:       constant.UnBindBkHostID,
+			BkCloudID:      constant.UnassignedBkCloudID,
+			AccountID:      accountID,
+			Region:         region,
+			Zone:           one.ZoneID,
+			CloudVpcIDs:    []string{one.VpcAttributes.VpcID},
+			VpcIDs:         []string{vpcID},
+			CloudSubnetIDs: []string{one.VpcAttributes.VSwitchID},
+			SubnetIDs:      []string{subnetID},
+			CloudImageID:   one.ImageID,
+			ImageID:        relMaps.imageMap[one.ImageID],
+			OsName:         one.OSName,
+			// 备注字段云上没有，仅限hcm内部使用
+			Memo:                 nil,
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.VpcAttributes.PrivateIpAddress.IpAddress,
+			PublicIPv4Addresses:  one.PublicIPv4Addresses(),
+			MachineType:          one.InstanceType,
+			CloudCreatedTime:     one.CreationTime,
+			CloudLaunchedTime:    one.StartTime,
+			CloudExpiredTime:     one.ExpiredTime,
+			Extension:            buildCvmExtension(one),
+		}
+		lists = append(lists, addOne)
+	}
+
+	createReq := protocloud.CvmBatchCreateReq[corecvm.AliyunCvmExtension]{
+		Cvms: lists,
+	}
+	if _, err = cli.dbCli.Aliyun.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to create aliyun cvm failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) getSubnetMap(kt *kit.Kit, accountID string, region string,
+	cloudSubnetIDs []string) (map[string]string, error) {
+
+	subnetMap := make(map[string]string)
+	for _, parts := range slice.Split(slice.Unique(cloudSubnetIDs), constant.CloudResourceSyncMaxLimit) {
+		subnetParams := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  parts,
+		}
+		subnetFromDB, err := cli.listSubnetFromDB(kt, subnetParams)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range subnetFromDB {
+			subnetMap[subnet.CloudID] = subnet.ID
+		}
+	}
+
+	return subnetMap, nil
+}
+
+func (cli *client) getImageMap(kt *kit.Kit, accountID string, region string,
+	cloudImageIDs []string) (map[string]string, error) {
+
+	imageMap := make(map[string]string)
+	for _, parts := range slice.Split(slice.Unique(cloudImageIDs), constant.CloudResourceSyncMaxLimit) {
+		imageParams := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  parts,
+		}
+		imageFromDB, err := cli.listImageFromDBForCvm(kt, imageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range imageFromDB {
+			imageMap[image.CloudID] = image.ID
+		}
+	}
+
+	return imageMap, nil
+}
+
+func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delCvmFromCloud, err := cli.listCvmFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delCvmFromCloud) > 0 {
+		logs.Errorf("[%s] validate cvm not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.Aliyun, checkParams, len(delCvmFromCloud), kt.Rid)
+		return fmt.Errorf("validate cvm not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.CvmBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete cvm failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to delete cvm success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listCvmFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typescvm.AliyunCvm, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typescvm.AliyunListOption{
+		AliyunListOption: adcore.AliyunListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListCvm(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.Aliyun,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listCvmFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corecvm.Cvm[corecvm.AliyunCvmExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &protocloud.CvmListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aliyun.Cvm.ListCvmExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.Aliyun,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// RemoveCvmDeleteFromCloud ...
+func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &protocloud.CvmListReq{
+		Field: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Aliyun.Cvm.ListCvmExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list cvm failed, err: %v, req: %v, rid: %s", enumor.Aliyun,
+				err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listCvmFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.InstanceID)
+			}
+
+			cloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if len(cloudIDs) > 0 {
+				if err := cli.deleteCvm(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func buildCvmExtension(one typescvm.AliyunCvm) *corecvm.AliyunCvmExtension {
+	return &corecvm.AliyunCvmExtension{
+		InstanceChargeType:      one.InstanceChargeType,
+		InternetChargeType:      one.InternetChargeType,
+		InternetMaxBandwidthOut: one.InternetMaxBandwidthOut,
+		Cpu:                     one.Cpu,
+		Memory:                  one.Memory,
+		CloudSecurityGroupIDs:   one.SecurityGroupIDs.SecurityGroupID,
+		CloudResourceGroupID:    one.ResourceGroupID,
+		HostName:                one.HostName,
+		DeletionProtection:      one.DeletionProtection,
+		StoppedMode:             one.StoppedMode,
+		SerialNumber:            one.SerialNumber,
+	}
+}
+
+func isCvmChange(cloud typescvm.AliyunCvm, db corecvm.Cvm[corecvm.AliyunCvmExtension]) bool {
+	if db.Name != cloud.InstanceName {
+		return true
+	}
+
+	if len(db.CloudVpcIDs) == 0 || db.CloudVpcIDs[0] != cloud.VpcAttributes.VpcID {
+		return true
+	}
+
+	if len(db.CloudSubnetIDs) == 0 || db.CloudSubnetIDs[0] != cloud.VpcAttributes.VSwitchID {
+		return true
+	}
+
+	if db.CloudImageID != cloud.ImageID {
+		return true
+	}
+
+	if db.OsName != cloud.OSName {
+		return true
+	}
+
+	if db.Status != cloud.Status {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.VpcAttributes.PrivateIpAddress.IpAddress, db.PrivateIPv4Addresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PublicIPv4Addresses(), db.PublicIPv4Addresses) {
+		return true
+	}
+
+	if db.MachineType != cloud.InstanceType {
+		return true
+	}
+
+	if db.CloudLaunchedTime != cloud.StartTime {
+		return true
+	}
+
+	if db.CloudExpiredTime != cloud.ExpiredTime {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	return isCvmExtensionChange(buildCvmExtension(cloud), db.Extension)
+}
+
+func isCvmExtensionChange(cloud *corecvm.AliyunCvmExtension, db *corecvm.AliyunCvmExtension) bool {
+	if cloud.InstanceChargeType != db.InstanceChargeType {
+		return true
+	}
+
+	if cloud.InternetChargeType != db.InternetChargeType {
+		return true
+	}
+
+	if cloud.InternetMaxBandwidthOut != db.InternetMaxBandwidthOut {
+		return true
+	}
+
+	if cloud.Cpu != db.Cpu || cloud.Memory != db.Memory {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudSecurityGroupIDs, db.CloudSecurityGroupIDs) {
+		return true
+	}
+
+	if cloud.CloudResourceGroupID != db.CloudResourceGroupID {
+		return true
+	}
+
+	if cloud.HostName != db.HostName {
+		return true
+	}
+
+	if cloud.DeletionProtection != db.DeletionProtection {
+		return true
+	}
+
+	if cloud.StoppedMode != db.StoppedMode {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,418 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/api/core"
+	cloudcore "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSGOption ...
+type SyncSGOption struct {
+}
+
+// Validate ...
+func (opt SyncSGOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SecurityGroup ...
+func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncSGOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sgFromCloud, err := cli.listSGFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sgFromCloud) == 0 && len(sgFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[securitygroup.AliyunSG,
+		cloudcore.SecurityGroup[cloudcore.AliyunSecurityGroupExtension]](sgFromCloud, sgFromDB, isSGChange)
+
+	if len(delCloudIDs) > 0 {
+		if err := cli.deleteSG(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		_, err := cli.createSG(kt, params.AccountID, params.Region, addSlice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSG(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	// 阿里云安全组规则暂不纳管
+	return new(SyncResult), nil
+}
+
+func (cli *client) updateSG(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]securitygroup.AliyunSG) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("sg updateMap is <= 0, not update")
+	}
+
+	cloudVpcIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		cloudVpcIDs = append(cloudVpcIDs, one.VpcID)
+	}
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		return err
+	}
+
+	securityGroups := make([]protocloud.SecurityGroupBatchUpdate[cloudcore.AliyunSecurityGroupExtension], 0)
+
+	for id, one := range updateMap {
+		securityGroup := protocloud.SecurityGroupBatchUpdate[cloudcore.AliyunSecurityGroupExtension]{
+			ID:   id,
+			Name: one.SecurityGroupName,
+			Memo: converter.ValToPtr(one.Description),
+			Extension: &cloudcore.AliyunSecurityGroupExtension{
+				VpcID:             vpcMap[one.VpcID],
+				CloudVpcID:        converter.ValToPtr(one.VpcID),
+				SecurityGroupType: one.SecurityGroupType,
+				ResourceGroupID:   one.ResourceGroupID,
+			},
+		}
+
+		securityGroups = append(securityGroups, securityGroup)
+	}
+
+	updateReq := &protocloud.SecurityGroupBatchUpdateReq[cloudcore.AliyunSecurityGroupExtension]{
+		SecurityGroups: securityGroups,
+	}
+	if err := cli.dbCli.Aliyun.SecurityGroup.BatchUpdateSecurityGroup(kt.Ctx, kt.Header(),
+		updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchUpdateSecurityGroup failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sg to update sg success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createSG(kt *kit.Kit, accountID string, region string,
+	addSlice []securitygroup.AliyunSG) ([]string, error) {
+
+	if len(addSlice) <= 0 {
+		return nil, fmt.Errorf("sg addSlice is <= 0, not create")
+	}
+
+	cloudVpcIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		cloudVpcIDs = append(cloudVpcIDs, one.VpcID)
+	}
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &protocloud.SecurityGroupBatchCreateReq[cloudcore.AliyunSecurityGroupExtension]{
+		SecurityGroups: []protocloud.SecurityGroupBatchCreate[cloudcore.AliyunSecurityGroupExtension]{},
+	}
+
+	for _, one := range addSlice {
+		securityGroup := protocloud.SecurityGroupBatchCreate[cloudcore.AliyunSecurityGroupExtension]{
+			CloudID:   one.SecurityGroupID,
+			BkBizID:   constant.UnassignedBiz,
+			Region:    region,
+			Name:      one.SecurityGroupName,
+			Memo:      converter.ValToPtr(one.Description),
+			AccountID: accountID,
+			MgmtBizID: constant.UnassignedBiz,
+			Extension: &cloudcore.AliyunSecurityGroupExtension{
+				VpcID:             vpcMap[one.VpcID],
+				CloudVpcID:        converter.ValToPtr(one.VpcID),
+				SecurityGroupType: one.SecurityGroupType,
+				ResourceGroupID:   one.ResourceGroupID,
+			},
+		}
+		createReq.SecurityGroups = append(createReq.SecurityGroups, securityGroup)
+	}
+
+	results, err := cli.dbCli.Aliyun.SecurityGroup.BatchCreateSecurityGroup(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to BatchCreateSecurityGroup failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync sg to create sg success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(addSlice), kt.Rid)
+
+	return results.IDs, nil
+}
+
+func (cli *client) deleteSG(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("sg delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delSGFromCloud, err := cli.listSGFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delSGFromCloud) > 0 {
+		logs.Errorf("[%s] validate sg not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.Aliyun, checkParams, len(delSGFromCloud), kt.Rid)
+		return fmt.Errorf("validate sg not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.SecurityGroup.BatchDeleteSecurityGroup(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete sg failed, err: %v, rid: %s", enumor.Aliyun,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sg to delete sg success, accountID: %s, count: %d, rid: %s", enumor.Aliyun,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listSGFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]securitygroup.AliyunSG, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &securitygroup.AliyunListOption{
+		AliyunListOption: adcore.AliyunListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListSecurityGroup(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list sg from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.Aliyun,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listSGFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]cloudcore.SecurityGroup[cloudcore.AliyunSecurityGroupExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "account_id",
+					Op:    filter.Equal.Factory(),
+					Value: params.AccountID,
+				},
+				&filter.AtomRule{
+					Field: "cloud_id",
+					Op:    filter.In.Factory(),
+					Value: params.CloudIDs,
+				},
+				&filter.AtomRule{
+					Field: "region",
+					Op:    filter.Equal.Factory(),
+					Value: params.Region,
+				},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aliyun.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list sg from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.Aliyun,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// RemoveSecurityGroupDeleteFromCloud ...
+func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "account_id",
+					Op:    filter.Equal.Factory(),
+					Value: accountID,
+				},
+				&filter.AtomRule{
+					Field: "region",
+					Op:    filter.Equal.Factory(),
+					Value: region,
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Aliyun.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list sg failed, err: %v, req: %v, rid: %s", enumor.Aliyun,
+				err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listSGFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.SecurityGroupID)
+			}
+
+			cloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if len(cloudIDs) > 0 {
+				if err := cli.deleteSG(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func isSGChange(cloud securitygroup.AliyunSG, db cloudcore.SecurityGroup[cloudcore.AliyunSecurityGroupExtension]) bool {
+
+	if cloud.SecurityGroupName != db.BaseSecurityGroup.Name {
+		return true
+	}
+
+	if cloud.Description != converter.PtrToVal(db.BaseSecurityGroup.Memo) {
+		return true
+	}
+
+	if cloud.VpcID != converter.PtrToVal(db.Extension.CloudVpcID) {
+		return true
+	}
+
+	if cloud.SecurityGroupType != db.Extension.SecurityGroupType {
+		return true
+	}
+
+	if cloud.ResourceGroupID != db.Extension.ResourceGroupID {
+		return true
+	}
+
+	return false
+}
+
+// getVpcMap 获取云上vpc ID与本地vpc ID的映射关系
+func (cli *client) getVpcMap(kt *kit.Kit, accountID, region string, cloudVpcIDs []string) (map[string]string, error) {
+	vpcMap := make(map[string]string)
+	cloudVpcIDs = slice.Unique(cloudVpcIDs)
+	for _, ids := range slice.Split(cloudVpcIDs, constant.CloudResourceSyncMaxLimit) {
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  ids,
+		}
+		vpcs, err := cli.listVpcFromDB(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range vpcs {
+			vpcMap[vpc.CloudID] = vpc.ID
+		}
+	}
+
+	return vpcMap, nil
+}
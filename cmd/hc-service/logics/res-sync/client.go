@@ -21,10 +21,12 @@ package ressync
 
 import (
 	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/logics/res-sync/aliyun"
 	"hcm/cmd/hc-service/logics/res-sync/aws"
 	"hcm/cmd/hc-service/logics/res-sync/azure"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/logics/res-sync/openstack"
 	"hcm/cmd/hc-service/logics/res-sync/other"
 	"hcm/cmd/hc-service/logics/res-sync/tcloud"
 	dataservice "hcm/pkg/client/data-service"
@@ -38,6 +40,8 @@ type Interface interface {
 	HuaWei(kt *kit.Kit, accountID string) (huawei.Interface, error)
 	Gcp(kt *kit.Kit, accountID string) (gcp.Interface, error)
 	Azure(kt *kit.Kit, accountID string) (azure.Interface, error)
+	Aliyun(kt *kit.Kit, accountID string) (aliyun.Interface, error)
+	OpenStack(kt *kit.Kit, accountID string) (openstack.Interface, error)
 	Other(kt *kit.Kit, accountID string) (other.Interface, error)
 }
 
@@ -107,6 +111,26 @@ func (cli *client) Azure(kt *kit.Kit, accountID string) (azure.Interface, error)
 	return azure.NewClient(cli.dataCli, cloudCli), nil
 }
 
+// Aliyun ...
+func (cli *client) Aliyun(kt *kit.Kit, accountID string) (aliyun.Interface, error) {
+	cloudCli, err := cli.ad.Aliyun(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return aliyun.NewClient(cli.dataCli, cloudCli), nil
+}
+
+// OpenStack ...
+func (cli *client) OpenStack(kt *kit.Kit, accountID string) (openstack.Interface, error) {
+	cloudCli, err := cli.ad.OpenStack(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openstack.NewClient(cli.dataCli, cloudCli), nil
+}
+
 // Other ...
 func (cli *client) Other(kt *kit.Kit, accountID string) (other.Interface, error) {
 	return other.NewClient(cli.dataCli, accountID), nil
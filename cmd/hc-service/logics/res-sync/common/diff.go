@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/types/cert"
 	typescvm "hcm/pkg/adaptor/types/cvm"
 	typesdisk "hcm/pkg/adaptor/types/disk"
+	typessnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	typeseip "hcm/pkg/adaptor/types/eip"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	typesimage "hcm/pkg/adaptor/types/image"
@@ -51,6 +52,7 @@ import (
 	cloudcoreroutetable "hcm/pkg/api/core/cloud/route-table"
 	coresubaccount "hcm/pkg/api/core/cloud/sub-account"
 	corezone "hcm/pkg/api/core/cloud/zone"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
 	corerecyclerecord "hcm/pkg/api/core/recycle-record"
 	dataeip "hcm/pkg/api/data-service/cloud/eip"
 	"hcm/pkg/thirdparty/api-gateway/cmdb"
@@ -67,6 +69,7 @@ type CloudResType interface {
 		typesregion.TCloudRegion |
 		typesregion.AwsRegion |
 		typesregion.GcpRegion |
+		typesregion.AliyunRegion |
 
 		typesresourcegroup.AzureResourceGroup |
 
@@ -74,12 +77,14 @@ type CloudResType interface {
 		typeszone.HuaWeiZone |
 		typeszone.GcpZone |
 		typeszone.AwsZone |
+		typeszone.AliyunZone |
 
 		typesimage.TCloudImage |
 		typesimage.HuaWeiImage |
 		typesimage.AwsImage |
 		typesimage.AzureImage |
 		typesimage.GcpImage |
+		typesimage.AliyunImage |
 
 		typessecuritygrouprule.HuaWeiSGRule |
 		typessecuritygrouprule.AwsSGRule |
@@ -90,23 +95,31 @@ type CloudResType interface {
 		types.GcpVpc |
 		types.HuaWeiVpc |
 		types.AzureVpc |
+		types.AliyunVpc |
+		types.OpenStackVpc |
 
 		adtysubnet.TCloudSubnet |
 		adtysubnet.AwsSubnet |
 		adtysubnet.HuaWeiSubnet |
 		adtysubnet.GcpSubnet |
 		adtysubnet.AzureSubnet |
+		adtysubnet.AliyunSubnet |
+		adtysubnet.OpenStackSubnet |
 
 		typesdisk.TCloudDisk |
 		typesdisk.HuaWeiDisk |
 		typesdisk.AwsDisk |
 		typesdisk.GcpDisk |
 		typesdisk.AzureDisk |
+		typesdisk.AliyunDisk |
+		typesdisk.OpenStackDisk |
 
 		securitygroup.TCloudSG |
 		securitygroup.HuaWeiSG |
 		securitygroup.AwsSG |
 		securitygroup.AzureSecurityGroup |
+		securitygroup.AliyunSG |
+		securitygroup.OpenStackSG |
 
 		firewallrule.GcpFirewall |
 
@@ -116,6 +129,8 @@ type CloudResType interface {
 		typescvm.AwsCvm |
 		typescvm.GcpCvm |
 		typescvm.AzureCvm |
+		typescvm.AliyunCvm |
+		typescvm.OpenStackCvm |
 		cmdb.HostWithCloudID |
 
 		*typeseip.TCloudEip |
@@ -123,6 +138,8 @@ type CloudResType interface {
 		*typeseip.GcpEip |
 		*typeseip.AwsEip |
 		*typeseip.AzureEip |
+		*typeseip.AliyunEip |
+		*typeseip.OpenStackEip |
 
 		typesroutetable.TCloudRouteTable |
 		typesroutetable.HuaWeiRouteTable |
@@ -144,6 +161,7 @@ type CloudResType interface {
 		account.AwsAccount |
 		account.AzureAccount |
 		account.GcpAccount |
+		account.OpenStackProject |
 
 		corerecyclerecord.EipBindInfo |
 		corerecyclerecord.DiskAttachInfo |
@@ -157,7 +175,9 @@ type CloudResType interface {
 		typeslb.TCloudClb |
 		typeslb.TCloudListener |
 		typeslb.TCloudUrlRule |
-		typeslb.Backend
+		typeslb.Backend |
+
+		typessnapshot.Snapshot
 }
 
 // TestCloudRes 测试云资源类型
@@ -180,6 +200,7 @@ type DBResType interface {
 		coreregion.TCloudRegion |
 		coreregion.AwsRegion |
 		coreregion.GcpRegion |
+		coreregion.AliyunRegion |
 
 		coreresourcegroup.AzureRG |
 
@@ -190,6 +211,7 @@ type DBResType interface {
 		coreimage.Image[coreimage.AwsExtension] |
 		coreimage.Image[coreimage.AzureExtension] |
 		coreimage.Image[coreimage.GcpExtension] |
+		coreimage.Image[coreimage.AliyunExtension] |
 
 		cloudcore.HuaWeiSecurityGroupRule |
 		cloudcore.AwsSecurityGroupRule |
@@ -200,23 +222,31 @@ type DBResType interface {
 		cloudcore.Vpc[cloudcore.GcpVpcExtension] |
 		cloudcore.Vpc[cloudcore.HuaWeiVpcExtension] |
 		cloudcore.Vpc[cloudcore.AzureVpcExtension] |
+		cloudcore.Vpc[cloudcore.AliyunVpcExtension] |
+		cloudcore.Vpc[cloudcore.OpenStackVpcExtension] |
 
 		cloudcore.Subnet[cloudcore.TCloudSubnetExtension] |
 		cloudcore.Subnet[cloudcore.AwsSubnetExtension] |
 		cloudcore.Subnet[cloudcore.HuaWeiSubnetExtension] |
 		cloudcore.Subnet[cloudcore.GcpSubnetExtension] |
 		cloudcore.Subnet[cloudcore.AzureSubnetExtension] |
+		cloudcore.Subnet[cloudcore.AliyunSubnetExtension] |
+		cloudcore.Subnet[cloudcore.OpenStackSubnetExtension] |
 
 		*coredisk.Disk[coredisk.TCloudExtension] |
 		*coredisk.Disk[coredisk.HuaWeiExtension] |
 		*coredisk.Disk[coredisk.AwsExtension] |
 		*coredisk.Disk[coredisk.GcpExtension] |
 		*coredisk.Disk[coredisk.AzureExtension] |
+		*coredisk.Disk[coredisk.AliyunExtension] |
+		*coredisk.Disk[coredisk.OpenStackExtension] |
 
 		cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.HuaWeiSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.AwsSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.AzureSecurityGroupExtension] |
+		cloudcore.SecurityGroup[cloudcore.AliyunSecurityGroupExtension] |
+		cloudcore.SecurityGroup[cloudcore.OpenStackSecurityGroupExtension] |
 
 		cloudcore.GcpFirewallRule |
 
@@ -225,6 +255,8 @@ type DBResType interface {
 		corecvm.Cvm[corecvm.AwsCvmExtension] |
 		corecvm.Cvm[corecvm.GcpCvmExtension] |
 		corecvm.Cvm[corecvm.AzureCvmExtension] |
+		corecvm.Cvm[corecvm.AliyunCvmExtension] |
+		corecvm.Cvm[corecvm.OpenStackCvmExtension] |
 		corecvm.Cvm[corecvm.OtherCvmExtension] |
 
 		*dataeip.EipExtResult[dataeip.TCloudEipExtensionResult] |
@@ -232,6 +264,8 @@ type DBResType interface {
 		*dataeip.EipExtResult[dataeip.GcpEipExtensionResult] |
 		*dataeip.EipExtResult[dataeip.AwsEipExtensionResult] |
 		*dataeip.EipExtResult[dataeip.AzureEipExtensionResult] |
+		*dataeip.EipExtResult[dataeip.AliyunEipExtensionResult] |
+		*dataeip.EipExtResult[dataeip.OpenStackEipExtensionResult] |
 
 		cloudcoreroutetable.TCloudRouteTable |
 		cloudcoreroutetable.HuaWeiRouteTable |
@@ -253,6 +287,7 @@ type DBResType interface {
 		coresubaccount.SubAccount[coresubaccount.AwsExtension] |
 		coresubaccount.SubAccount[coresubaccount.AzureExtension] |
 		coresubaccount.SubAccount[coresubaccount.GcpExtension] |
+		coresubaccount.SubAccount[coresubaccount.OpenStackExtension] |
 
 		corerecyclerecord.EipBindInfo |
 		corerecyclerecord.DiskAttachInfo |
@@ -264,7 +299,9 @@ type DBResType interface {
 		corelb.TCloudLoadBalancer |
 		corelb.TCloudLbUrlRule |
 		corelb.TCloudListener |
-		corelb.BaseTarget
+		corelb.BaseTarget |
+
+		coresnapshot.DiskSnapshot
 }
 
 // Diff 对比云和db资源，划分出新增数据，更新数据，删除数据。
@@ -0,0 +1,547 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncCvmOption ...
+type SyncCvmOption struct {
+}
+
+// Validate ...
+func (opt SyncCvmOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Cvm ...
+func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmFromCloud, err := cli.listCvmFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	cvmFromDB, err := cli.listCvmFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cvmFromCloud) == 0 && len(cvmFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.OpenStackCvm, corecvm.Cvm[corecvm.OpenStackCvmExtension]](
+		cvmFromCloud, cvmFromDB, isCvmChange)
+
+	if len(delCloudIDs) > 0 {
+		if err := cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createCvm(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateCvm(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// cvmRelMaps cvm 关联的 vpc、子网的云上ID与本地ID映射，openstack 镜像不纳管，仅记录云上镜像ID
+type cvmRelMaps struct {
+	vpcMap    map[string]string
+	subnetMap map[string]string
+}
+
+func (cli *client) getCvmRelMaps(kt *kit.Kit, accountID string, region string, cvms []typescvm.OpenStackCvm) (
+	*cvmRelMaps, error) {
+
+	cloudVpcIDs := make([]string, 0, len(cvms))
+	cloudSubnetIDs := make([]string, 0, len(cvms))
+	for _, one := range cvms {
+		cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcIDs...)
+		cloudSubnetIDs = append(cloudSubnetIDs, one.CloudSubnetIDs...)
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, cloudVpcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetMap, err := cli.getSubnetMap(kt, accountID, region, cloudSubnetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cvmRelMaps{vpcMap: vpcMap, subnetMap: subnetMap}, nil
+}
+
+// convCloudIDs 将云上ID转换为本地ID，找不到时返回错误
+func convCloudIDs(cloudIDs []string, idMap map[string]string) ([]string, error) {
+	ids := make([]string, 0, len(cloudIDs))
+	for _, cloudID := range cloudIDs {
+		id, exist := idMap[cloudID]
+		if !exist {
+			return nil, fmt.Errorf("cloud id %s not found in db", cloudID)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]typescvm.OpenStackCvm) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("cvm updateMap is <= 0, not update")
+	}
+
+	cvms := make([]typescvm.OpenStackCvm, 0, len(updateMap))
+	for _, one := range updateMap {
+		cvms = append(cvms, one)
+	}
+	relMaps, err := cli.getCvmRelMaps(kt, accountID, region, cvms)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchUpdateWithExtension[corecvm.OpenStackCvmExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		vpcIDs, err := convCloudIDs(one.CloudVpcIDs, relMaps.vpcMap)
+		if err != nil {
+			return fmt.Errorf("cvm %s can not find vpc, err: %v", one.ID, err)
+		}
+
+		subnetIDs, err := convCloudIDs(one.CloudSubnetIDs, relMaps.subnetMap)
+		if err != nil {
+			return fmt.Errorf("cvm %s can not find subnet, err: %v", one.ID, err)
+		}
+
+		updateOne := protocloud.CvmBatchUpdateWithExtension[corecvm.OpenStackCvmExtension]{
+			CvmBatchUpdate: protocloud.CvmBatchUpdate{
+				ID:             id,
+				Name:           one.Name,
+				CloudVpcIDs:    one.CloudVpcIDs,
+				VpcIDs:         vpcIDs,
+				CloudSubnetIDs: one.CloudSubnetIDs,
+				SubnetIDs:      subnetIDs,
+				CloudImageID:   one.Image.ID,
+				// 备注字段云上没有，仅限hcm内部使用
+				Memo:                 nil,
+				Status:               one.Status,
+				PrivateIPv4Addresses: one.PrivateIPv4Addresses(),
+				PrivateIPv6Addresses: one.PrivateIPv6Addresses(),
+				PublicIPv4Addresses:  one.PublicIPv4Addresses(),
+				CloudLaunchedTime:    one.LaunchedAt,
+				MachineType:          one.Flavor.OriginalName,
+			},
+			Extension: buildCvmExtension(one),
+		}
+
+		lists = append(lists, updateOne)
+	}
+
+	updateReq := protocloud.CvmBatchUpdateReq[corecvm.OpenStackCvmExtension]{
+		Cvms: lists,
+	}
+	if err := cli.dbCli.OpenStack.Cvm.BatchUpdateCvm(kt.Ctx, kt.Header(), &updateReq); err != nil {
+		logs.Errorf("[%s] request openstack dataservice BatchUpdateCvm failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to update cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createCvm(kt *kit.Kit, accountID string, region string, addSlice []typescvm.OpenStackCvm) error {
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("cvm addSlice is <= 0, not create")
+	}
+
+	relMaps, err := cli.getCvmRelMaps(kt, accountID, region, addSlice)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchCreate[corecvm.OpenStackCvmExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		vpcIDs, err := convCloudIDs(one.CloudVpcIDs, relMaps.vpcMap)
+		if err != nil {
+			return fmt.Errorf("cvm %s can not find vpc, err: %v", one.ID, err)
+		}
+
+		subnetIDs, err := convCloudIDs(one.CloudSubnetIDs, relMaps.subnetMap)
+		if err != nil {
+			return fmt.Errorf("cvm %s can not find subnet, err: %v", one.ID, err)
+		}
+
+		addOne := protocloud.CvmBatchCreate[corecvm.OpenStackCvmExtension]{
+			CloudID:        one.ID,
+			Name:           one.Name,
+			BkBizID:        constant.UnassignedBiz,
+			BkHostID:       constant.UnBindBkHostID,
+			BkCloudID:      constant.UnassignedBkCloudID,
+			AccountID:      accountID,
+			Region:         region,
+			Zone:           one.AvailabilityZone,
+			CloudVpcIDs:    one.CloudVpcIDs,
+			VpcIDs:         vpcIDs,
+			CloudSubnetIDs: one.CloudSubnetIDs,
+			SubnetIDs:      subnetIDs,
+			CloudImageID:   one.Image.ID,
+			// 备注字段云上没有，仅限hcm内部使用
+			Memo:                 nil,
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.PrivateIPv4Addresses(),
+			PrivateIPv6Addresses: one.PrivateIPv6Addresses(),
+			PublicIPv4Addresses:  one.PublicIPv4Addresses(),
+			MachineType:          one.Flavor.OriginalName,
+			CloudCreatedTime:     one.Created,
+			CloudLaunchedTime:    one.LaunchedAt,
+			Extension:            buildCvmExtension(one),
+		}
+		lists = append(lists, addOne)
+	}
+
+	createReq := protocloud.CvmBatchCreateReq[corecvm.OpenStackCvmExtension]{
+		Cvms: lists,
+	}
+	if _, err = cli.dbCli.OpenStack.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to create openstack cvm failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) getSubnetMap(kt *kit.Kit, accountID string, region string,
+	cloudSubnetIDs []string) (map[string]string, error) {
+
+	subnetMap := make(map[string]string)
+	for _, parts := range slice.Split(slice.Unique(cloudSubnetIDs), constant.CloudResourceSyncMaxLimit) {
+		subnetParams := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  parts,
+		}
+		subnetFromDB, err := cli.listSubnetFromDB(kt, subnetParams)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range subnetFromDB {
+			subnetMap[subnet.CloudID] = subnet.ID
+		}
+	}
+
+	return subnetMap, nil
+}
+
+func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delCvmFromCloud, err := cli.listCvmFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delCvmFromCloud) > 0 {
+		logs.Errorf("[%s] validate cvm not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delCvmFromCloud), kt.Rid)
+		return fmt.Errorf("validate cvm not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.CvmBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete cvm failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to delete cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listCvmFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typescvm.OpenStackCvm, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typescvm.OpenStackListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListCvm(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listCvmFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corecvm.Cvm[corecvm.OpenStackCvmExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &protocloud.CvmListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.Cvm.ListCvmExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// RemoveCvmDeleteFromCloud ...
+func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &protocloud.CvmListReq{
+		Field: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.OpenStack.Cvm.ListCvmExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list cvm failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listCvmFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.ID)
+			}
+
+			cloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if len(cloudIDs) > 0 {
+				if err := cli.deleteCvm(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func buildCvmExtension(one typescvm.OpenStackCvm) *corecvm.OpenStackCvmExtension {
+	sgNames := make([]string, 0, len(one.SecurityGroups))
+	for _, sg := range one.SecurityGroups {
+		sgNames = append(sgNames, sg.Name)
+	}
+
+	volumeIDs := make([]string, 0, len(one.VolumesAttached))
+	for _, volume := range one.VolumesAttached {
+		volumeIDs = append(volumeIDs, volume.ID)
+	}
+
+	return &corecvm.OpenStackCvmExtension{
+		FlavorName:              one.Flavor.OriginalName,
+		Vcpus:                   one.Flavor.Vcpus,
+		Ram:                     one.Flavor.Ram,
+		Disk:                    one.Flavor.Disk,
+		KeyName:                 one.KeyName,
+		CloudSecurityGroupNames: slice.Unique(sgNames),
+		CloudVolumeIDs:          volumeIDs,
+		CloudProjectID:          one.TenantID,
+		HostID:                  one.HostID,
+		PowerState:              one.PowerState,
+	}
+}
+
+func isCvmChange(cloud typescvm.OpenStackCvm, db corecvm.Cvm[corecvm.OpenStackCvmExtension]) bool {
+	if db.Name != cloud.Name {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudVpcIDs, db.CloudVpcIDs) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudSubnetIDs, db.CloudSubnetIDs) {
+		return true
+	}
+
+	if db.CloudImageID != cloud.Image.ID {
+		return true
+	}
+
+	if db.Status != cloud.Status {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PrivateIPv4Addresses(), db.PrivateIPv4Addresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PrivateIPv6Addresses(), db.PrivateIPv6Addresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PublicIPv4Addresses(), db.PublicIPv4Addresses) {
+		return true
+	}
+
+	if db.MachineType != cloud.Flavor.OriginalName {
+		return true
+	}
+
+	if db.CloudLaunchedTime != cloud.LaunchedAt {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	return isCvmExtensionChange(buildCvmExtension(cloud), db.Extension)
+}
+
+func isCvmExtensionChange(cloud *corecvm.OpenStackCvmExtension, db *corecvm.OpenStackCvmExtension) bool {
+	if cloud.FlavorName != db.FlavorName || cloud.Vcpus != db.Vcpus || cloud.Ram != db.Ram ||
+		cloud.Disk != db.Disk {
+		return true
+	}
+
+	if cloud.KeyName != db.KeyName {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudSecurityGroupNames, db.CloudSecurityGroupNames) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudVolumeIDs, db.CloudVolumeIDs) {
+		return true
+	}
+
+	if cloud.CloudProjectID != db.CloudProjectID || cloud.HostID != db.HostID {
+		return true
+	}
+
+	if cloud.PowerState != db.PowerState {
+		return true
+	}
+
+	return false
+}
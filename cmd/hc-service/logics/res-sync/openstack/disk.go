@@ -0,0 +1,387 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	adaptordisk "hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	"hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncDiskOption ...
+type SyncDiskOption struct {
+}
+
+// Validate ...
+func (opt SyncDiskOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Disk ...
+func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskFromCloud, err := cli.listDiskFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	diskFromDB, err := cli.listDiskFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(diskFromCloud) == 0 && len(diskFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.OpenStackDisk,
+		*coredisk.Disk[coredisk.OpenStackExtension]](
+		diskFromCloud, diskFromDB, isDiskChange)
+
+	if len(delCloudIDs) > 0 {
+		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delDiskFromCloud, err := cli.listDiskFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delDiskFromCloud) > 0 {
+		logs.Errorf("[%s] validate disk not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delDiskFromCloud), kt.Rid)
+		return fmt.Errorf("validate disk not exist failed, before delete")
+	}
+
+	deleteReq := &disk.DiskDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to delete disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]adaptordisk.OpenStackDisk) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("updateMap is <= 0, not update")
+	}
+
+	disks := make([]*disk.DiskExtUpdateReq[coredisk.OpenStackExtension], 0)
+
+	for id, one := range updateMap {
+		disk := &disk.DiskExtUpdateReq[coredisk.OpenStackExtension]{
+			ID:           id,
+			Name:         one.Name,
+			Memo:         one.Description,
+			Status:       one.Status,
+			IsSystemDisk: converter.ValToPtr(one.IsSystemDisk()),
+			Extension:    convertDiskExtension(one),
+		}
+
+		disks = append(disks, disk)
+	}
+
+	var updateReq disk.DiskExtBatchUpdateReq[coredisk.OpenStackExtension]
+	for _, disk := range disks {
+		updateReq = append(updateReq, disk)
+	}
+	if _, err := cli.dbCli.OpenStack.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice openstack BatchUpdateDisk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
+	addSlice []adaptordisk.OpenStackDisk) error {
+
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("addSlice is <= 0, not create")
+	}
+
+	var createReq disk.DiskExtBatchCreateReq[coredisk.OpenStackExtension]
+
+	for _, one := range addSlice {
+		disk := &disk.DiskExtCreateReq[coredisk.OpenStackExtension]{
+			AccountID:    accountID,
+			Name:         one.Name,
+			CloudID:      one.ID,
+			Region:       region,
+			Zone:         one.AvailabilityZone,
+			DiskSize:     one.Size,
+			DiskType:     one.VolumeType,
+			Status:       one.Status,
+			Memo:         one.Description,
+			IsSystemDisk: one.IsSystemDisk(),
+			Extension:    convertDiskExtension(one),
+		}
+
+		createReq = append(createReq, disk)
+	}
+
+	_, err := cli.dbCli.OpenStack.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to create openstack disk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listDiskFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]adaptordisk.OpenStackDisk, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adaptordisk.OpenStackDiskListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListDisk(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list disk from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listDiskFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*coredisk.Disk[coredisk.OpenStackExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "account_id",
+					Op:    filter.Equal.Factory(),
+					Value: params.AccountID,
+				},
+				&filter.AtomRule{
+					Field: "cloud_id",
+					Op:    filter.In.Factory(),
+					Value: params.CloudIDs,
+				},
+				&filter.AtomRule{
+					Field: "region",
+					Op:    filter.Equal.Factory(),
+					Value: params.Region,
+				},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.ListDisk(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list disk from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// RemoveDiskDeleteFromCloud ...
+func (cli *client) RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.ListDisk(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list disk failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listDiskFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.ID)
+			}
+
+			cloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if len(cloudIDs) > 0 {
+				if err := cli.deleteDisk(kt, accountID, region, cloudIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func convertDiskExtension(one adaptordisk.OpenStackDisk) *coredisk.OpenStackExtension {
+	return &coredisk.OpenStackExtension{
+		VolumeType:     one.VolumeType,
+		Bootable:       one.IsSystemDisk(),
+		Encrypted:      one.Encrypted,
+		Multiattach:    one.Multiattach,
+		CloudProjectID: one.ProjectID,
+		InstanceIDs:    one.InstanceIDs(),
+	}
+}
+
+func isDiskChange(cloud adaptordisk.OpenStackDisk, db *coredisk.Disk[coredisk.OpenStackExtension]) bool {
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if cloud.Status != db.Status {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Description, db.Memo) {
+		return true
+	}
+
+	if cloud.IsSystemDisk() != db.IsSystemDisk {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	if cloud.VolumeType != db.Extension.VolumeType {
+		return true
+	}
+
+	if cloud.Encrypted != db.Extension.Encrypted || cloud.Multiattach != db.Extension.Multiattach {
+		return true
+	}
+
+	if cloud.ProjectID != db.Extension.CloudProjectID {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.InstanceIDs(), db.Extension.InstanceIDs) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,334 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"errors"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/cloud"
+	coresubaccount "hcm/pkg/api/core/cloud/sub-account"
+	dataservice "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dssubaccount "hcm/pkg/api/data-service/cloud/sub-account"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSubAccountOption define sync account option.
+type SyncSubAccountOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate SyncSubAccountOption
+func (opt SyncSubAccountOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SubAccount sync sub account, openstack 子账号为当前用户可访问的 keystone 项目。
+func (cli *client) SubAccount(kt *kit.Kit, opt *SyncSubAccountOption) (*SyncResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	fromCloud, err := cli.listSubAccountFromCloud(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDB, err := cli.listSubAccountFromDB(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fromCloud) == 0 && len(fromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typeaccount.OpenStackProject,
+		coresubaccount.SubAccount[coresubaccount.OpenStackExtension]](fromCloud, fromDB, isSubAccountChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteSubAccount(kt, opt, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	account, err := cli.dbCli.OpenStack.Account.Get(kt.Ctx, kt.Header(), opt.AccountID)
+	if err != nil {
+		logs.Errorf("request ds to get account failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createSubAccount(kt, account, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSubAccount(kt, account, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) updateSubAccount(kt *kit.Kit,
+	account *protocloud.AccountGetResult[protocore.OpenStackAccountExtension],
+	updateMap map[string]typeaccount.OpenStackProject) error {
+
+	if len(updateMap) <= 0 {
+		return errors.New("updateMap is required")
+	}
+
+	updateItems := make([]dssubaccount.UpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		ext, err := core.MarshalStruct(convSubAccountExtension(one))
+		if err != nil {
+			return err
+		}
+
+		tmpRes := dssubaccount.UpdateField{
+			ID:          id,
+			Name:        one.Name,
+			Vendor:      enumor.OpenStack,
+			Site:        account.Site,
+			AccountID:   account.ID,
+			AccountType: subAccountType(account, one),
+			Extension:   ext,
+			// Managers/BizIDs由用户设置不继承资源账号。
+			Managers: nil,
+			BkBizIDs: nil,
+			Memo:     converter.ValToPtr(one.Description),
+		}
+		updateItems = append(updateItems, tmpRes)
+	}
+
+	updateReq := &dssubaccount.UpdateReq{
+		Items: updateItems,
+	}
+	if err := cli.dbCli.Global.SubAccount.BatchUpdate(kt, updateReq); err != nil {
+		logs.Errorf("[%s] update sub account failed, err: %v, account: %s, rid: %s", enumor.OpenStack,
+			err, account.ID, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sub account to update sub account success, accountID: %s, count: %d, rid: %s",
+		enumor.OpenStack, account.ID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createSubAccount(kt *kit.Kit,
+	account *protocloud.AccountGetResult[protocore.OpenStackAccountExtension],
+	addSlice []typeaccount.OpenStackProject) error {
+
+	if len(addSlice) <= 0 {
+		return errors.New("addSlice is required")
+	}
+
+	createResources := make([]dssubaccount.CreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		ext, err := core.MarshalStruct(convSubAccountExtension(one))
+		if err != nil {
+			return err
+		}
+
+		tmpRes := dssubaccount.CreateField{
+			CloudID:     one.ID,
+			Name:        one.Name,
+			Vendor:      enumor.OpenStack,
+			Site:        account.Site,
+			AccountID:   account.ID,
+			AccountType: subAccountType(account, one),
+			Extension:   ext,
+			// Managers/BizIDs由用户设置不继承资源账号。
+			Managers: nil,
+			BkBizIDs: nil,
+			Memo:     converter.ValToPtr(one.Description),
+		}
+		createResources = append(createResources, tmpRes)
+	}
+
+	createReq := &dssubaccount.CreateReq{
+		Items: createResources,
+	}
+	if _, err := cli.dbCli.Global.SubAccount.BatchCreate(kt, createReq); err != nil {
+		logs.Errorf("[%s] create sub account failed, err: %v, account: %s, rid: %s", enumor.OpenStack,
+			err, account.ID, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sub account to create sub account success, accountID: %s, count: %d, rid: %s",
+		enumor.OpenStack, account.ID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteSubAccount(kt *kit.Kit, opt *SyncSubAccountOption, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return errors.New("delCloudIDs is required")
+	}
+
+	delFromCloud, err := cli.listSubAccountFromCloud(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	delCloudMap := converter.StringSliceToMap(delCloudIDs)
+	for _, one := range delFromCloud {
+		if _, exist := delCloudMap[one.GetCloudID()]; exist {
+			logs.Errorf("[%s] validate account not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+				enumor.OpenStack, opt, len(delFromCloud), kt.Rid)
+			return errors.New("validate account not exist failed, before delete")
+		}
+	}
+
+	for _, parts := range slice.Split(delCloudIDs, constant.CloudResourceSyncMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("account_id", opt.AccountID),
+				tools.RuleIn("cloud_id", parts),
+			),
+		}
+		if err = cli.dbCli.Global.SubAccount.BatchDelete(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] delete sub account failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+				err, opt.AccountID, opt, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync sub account to delete sub account success, accountID: %s, count: %d, rid: %s",
+		enumor.OpenStack, opt.AccountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+// subAccountType 应用凭证所属的项目标记为当前账号
+func subAccountType(account *protocloud.AccountGetResult[protocore.OpenStackAccountExtension],
+	project typeaccount.OpenStackProject) string {
+
+	if account.Extension != nil && account.Extension.CloudProjectID == project.ID {
+		return string(enumor.CurrentAccount)
+	}
+	return ""
+}
+
+func convSubAccountExtension(project typeaccount.OpenStackProject) *coresubaccount.OpenStackExtension {
+	return &coresubaccount.OpenStackExtension{
+		CloudDomainID: project.DomainID,
+		CloudParentID: project.ParentID,
+		Enabled:       project.Enabled,
+	}
+}
+
+func isSubAccountChange(cloud typeaccount.OpenStackProject,
+	db coresubaccount.SubAccount[coresubaccount.OpenStackExtension]) bool {
+
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(converter.ValToPtr(cloud.Description), db.Memo) {
+		return true
+	}
+
+	if db.Extension == nil {
+		return true
+	}
+
+	if cloud.DomainID != db.Extension.CloudDomainID || cloud.ParentID != db.Extension.CloudParentID {
+		return true
+	}
+
+	if cloud.Enabled != db.Extension.Enabled {
+		return true
+	}
+
+	return false
+}
+
+func (cli *client) listSubAccountFromCloud(kt *kit.Kit, opt *SyncSubAccountOption) (
+	[]typeaccount.OpenStackProject, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	results, err := cli.cloudCli.ListProject(kt)
+	if err != nil {
+		logs.Errorf("[%s] list sub account from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.OpenStack, err, opt.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (cli *client) listSubAccountFromDB(kt *kit.Kit, opt *SyncSubAccountOption) (
+	[]coresubaccount.SubAccount[coresubaccount.OpenStackExtension], error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.OpenStack},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: opt.AccountID},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	results := make([]coresubaccount.SubAccount[coresubaccount.OpenStackExtension], 0)
+	for {
+		resp, err := cli.dbCli.OpenStack.SubAccount.ListExt(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list sub account from db failed, err: %v, account: %s, req: %v, rid: %s",
+				enumor.OpenStack, err, opt.AccountID, req, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, resp.Details...)
+
+		if len(resp.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		req.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return results, nil
+}
@@ -255,3 +255,74 @@ func (svc *service) AzureAccountCheck(cts *rest.Contexts) (interface{}, error) {
 
 	return nil, err
 }
+
+// AliyunAccountCheck 根据传入秘钥去云上获取数据，并和传入其他数据对比，要求和云上获取数据一致
+func (svc *service) AliyunAccountCheck(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AliyunAccountCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Adaptor().Aliyun(
+		&types.BaseSecret{
+			CloudSecretID:  req.CloudSecretID,
+			CloudSecretKey: req.CloudSecretKey,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	infoBySecret, err := client.GetAccountInfoBySecret(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	if infoBySecret.CloudMainAccountID != req.CloudMainAccountID {
+		return nil, errf.New(errf.InvalidParameter,
+			"CloudMainAccountID does not match the account to which the secret belongs")
+	}
+
+	if infoBySecret.CloudSubAccountID != req.CloudSubAccountID {
+		return nil, errf.New(errf.InvalidParameter,
+			"CloudSubAccountID does not match the account to which the secret belongs")
+	}
+
+	return nil, nil
+}
+
+// OpenStackAccountCheck 根据传入应用凭证去云上获取数据，并和传入其他数据对比，要求和云上获取数据一致
+func (svc *service) OpenStackAccountCheck(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.OpenStackAccountCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Adaptor().OpenStack(
+		&types.OpenStackCredential{
+			AuthURL:        req.CloudAuthURL,
+			Region:         req.CloudRegion,
+			CloudSecretID:  req.CloudSecretID,
+			CloudSecretKey: req.CloudSecretKey,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	infoBySecret, err := client.GetAccountInfoBySecret(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	if infoBySecret.CloudProjectID != req.CloudProjectID {
+		return nil, errf.New(errf.InvalidParameter,
+			"CloudProjectID does not match the project to which the secret belongs")
+	}
+
+	return nil, nil
+}
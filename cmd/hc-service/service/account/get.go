@@ -112,6 +112,52 @@ func (svc *service) HuaWeiGetInfoBySecret(cts *rest.Contexts) (interface{}, erro
 
 }
 
+// AliyunGetInfoBySecret 根据秘钥信息去云上获取账号信息
+func (svc *service) AliyunGetInfoBySecret(cts *rest.Contexts) (interface{}, error) {
+	// 1. 参数解析与校验
+	req := new(cloud.AliyunSecret)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Adaptor().Aliyun(&types.BaseSecret{
+		CloudSecretID:  req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 2. 云上信息获取
+	return client.GetAccountInfoBySecret(cts.Kit)
+}
+
+// OpenStackGetInfoBySecret 根据应用凭证去云上获取账号信息
+func (svc *service) OpenStackGetInfoBySecret(cts *rest.Contexts) (interface{}, error) {
+	// 1. 参数解析与校验
+	req := new(cloud.OpenStackSecret)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Adaptor().OpenStack(&types.OpenStackCredential{
+		AuthURL:        req.CloudAuthURL,
+		Region:         req.CloudRegion,
+		CloudSecretID:  req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 2. 云上信息获取
+	return client.GetAccountInfoBySecret(cts.Kit)
+}
+
 // GcpGetInfoBySecret 根据秘钥信息去云上获取账号信息
 func (svc *service) GcpGetInfoBySecret(cts *rest.Contexts) (interface{}, error) {
 	// 1. 参数解析与校验
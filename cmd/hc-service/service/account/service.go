@@ -23,8 +23,13 @@ package account
 import (
 	"net/http"
 
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/api/core/cloud"
+	proto "hcm/pkg/api/hc-service/account"
 	"hcm/pkg/rest"
 )
 
@@ -36,46 +41,68 @@ func InitAccountService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 	// 联通性和云上字段匹配校验
-	h.Add("TCloudAccountCheck", http.MethodPost, "/vendors/tcloud/accounts/check", svc.TCloudAccountCheck)
-	h.Add("AwsAccountCheck", http.MethodPost, "/vendors/aws/accounts/check", svc.AwsAccountCheck)
-	h.Add("HuaWeiAccountCheck", http.MethodPost, "/vendors/huawei/accounts/check", svc.HuaWeiAccountCheck)
-	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck)
-	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck)
+	h.Add("TCloudAccountCheck", http.MethodPost, "/vendors/tcloud/accounts/check", svc.TCloudAccountCheck).
+		Reads(new(proto.TCloudAccountCheckReq))
+	h.Add("AwsAccountCheck", http.MethodPost, "/vendors/aws/accounts/check", svc.AwsAccountCheck).
+		Reads(new(proto.AwsAccountCheckReq))
+	h.Add("HuaWeiAccountCheck", http.MethodPost, "/vendors/huawei/accounts/check", svc.HuaWeiAccountCheck).
+		Reads(new(proto.HuaWeiAccountCheckReq))
+	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck).
+		Reads(new(proto.GcpAccountCheckReq))
+	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck).
+		Reads(new(proto.AzureAccountCheckReq))
+	h.Add("AliyunAccountCheck", http.MethodPost, "/vendors/aliyun/accounts/check", svc.AliyunAccountCheck).
+		Reads(new(proto.AliyunAccountCheckReq))
+	h.Add("OpenStackAccountCheck", http.MethodPost, "/vendors/openstack/accounts/check", svc.OpenStackAccountCheck).
+		Reads(new(proto.OpenStackAccountCheckReq))
 
 	// 获取账号配额
 	h.Add("GetTCloudAccountZoneQuota", http.MethodPost, "/vendors/tcloud/accounts/zones/quotas",
-		svc.GetTCloudAccountZoneQuota)
+		svc.GetTCloudAccountZoneQuota).
+		Reads(new(proto.GetTCloudAccountZoneQuotaReq)).Writes(new(typeaccount.TCloudAccountQuota))
 	h.Add("GetHuaWeiAccountRegionQuota", http.MethodPost, "/vendors/huawei/accounts/regions/quotas",
-		svc.GetHuaWeiAccountRegionQuota)
+		svc.GetHuaWeiAccountRegionQuota).
+		Reads(new(proto.GetHuaWeiAccountRegionQuotaReq)).Writes(new(typeaccount.HuaWeiAccountQuota))
 	h.Add("GetGcpAccountRegionQuota", http.MethodPost, "/vendors/gcp/accounts/regions/quotas",
-		svc.GetGcpAccountRegionQuota)
+		svc.GetGcpAccountRegionQuota).
+		Reads(new(proto.GetGcpAccountRegionQuotaReq)).Writes(new(typeaccount.GcpProjectQuota))
 
 	// 通过秘钥获取账号信息
-	h.Add("TCloudGetInfoBySecret", http.MethodPost, "/vendors/tcloud/accounts/secret", svc.TCloudGetInfoBySecret)
-	h.Add("AwsGetInfoBySecret", http.MethodPost, "/vendors/aws/accounts/secret", svc.AwsGetInfoBySecret)
-	h.Add("HuaWeiGetInfoBySecret", http.MethodPost, "/vendors/huawei/accounts/secret", svc.HuaWeiGetInfoBySecret)
-	h.Add("GcpGetInfoBySecret", http.MethodPost, "/vendors/gcp/accounts/secret", svc.GcpGetInfoBySecret)
-	h.Add("AzureGetInfoBySecret", http.MethodPost, "/vendors/azure/accounts/secret", svc.AzureGetInfoBySecret)
+	h.Add("TCloudGetInfoBySecret", http.MethodPost, "/vendors/tcloud/accounts/secret", svc.TCloudGetInfoBySecret).
+		Reads(new(cloud.TCloudSecret)).Writes(new(cloud.TCloudInfoBySecret))
+	h.Add("AwsGetInfoBySecret", http.MethodPost, "/vendors/aws/accounts/secret", svc.AwsGetInfoBySecret).
+		Reads(new(cloud.AwsSecret)).Writes(new(cloud.AwsInfoBySecret))
+	h.Add("HuaWeiGetInfoBySecret", http.MethodPost, "/vendors/huawei/accounts/secret", svc.HuaWeiGetInfoBySecret).
+		Reads(new(cloud.HuaWeiSecret)).Writes(new(cloud.HuaWeiInfoBySecret))
+	h.Add("GcpGetInfoBySecret", http.MethodPost, "/vendors/gcp/accounts/secret", svc.GcpGetInfoBySecret).
+		Reads(new(cloud.GcpSecret)).Writes(new(cloud.GcpInfoBySecret))
+	h.Add("AzureGetInfoBySecret", http.MethodPost, "/vendors/azure/accounts/secret", svc.AzureGetInfoBySecret).
+		Reads(new(cloud.AzureSecret)).Writes(new(cloud.AzureInfoBySecret))
+	h.Add("AliyunGetInfoBySecret", http.MethodPost, "/vendors/aliyun/accounts/secret", svc.AliyunGetInfoBySecret).
+		Reads(new(cloud.AliyunSecret)).Writes(new(cloud.AliyunInfoBySecret))
+	h.Add("OpenStackGetInfoBySecret", http.MethodPost, "/vendors/openstack/accounts/secret",
+		svc.OpenStackGetInfoBySecret).Reads(new(cloud.OpenStackSecret)).Writes(new(cloud.OpenStackInfoBySecret))
 
 	// 通过秘钥获取资源数量
 	h.Add("HuaWeiGetResCountBySecret", http.MethodPost, "/vendors/huawei/accounts/res_counts/by_secrets",
-		svc.HuaWeiGetResCountBySecret)
+		svc.HuaWeiGetResCountBySecret).Reads(new(cloud.HuaWeiSecret)).Writes(new(proto.ResCount))
 	h.Add("GetGcpResCountBySecret", http.MethodPost, "/vendors/gcp/accounts/res_counts/by_secrets",
-		svc.GetGcpResCountBySecret)
+		svc.GetGcpResCountBySecret).Reads(new(cloud.GcpCredential)).Writes(new(proto.ResCount))
 	h.Add("GetAzureResCountBySecret", http.MethodPost, "/vendors/azure/accounts/res_counts/by_secrets",
-		svc.GetAzureResCountBySecret)
+		svc.GetAzureResCountBySecret).Reads(new(cloud.AzureAuthSecret)).Writes(new(proto.ResCount))
 	h.Add("TCloudGetResCountBySecret", http.MethodPost, "/vendors/tcloud/accounts/res_counts/by_secrets",
-		svc.TCloudGetResCountBySecret)
+		svc.TCloudGetResCountBySecret).Reads(new(cloud.TCloudSecret)).Writes(new(proto.ResCount))
 	h.Add("AwsGetResCountBySecret", http.MethodPost, "/vendors/aws/accounts/res_counts/by_secrets",
-		svc.AwsGetResCountBySecret)
+		svc.AwsGetResCountBySecret).Reads(new(cloud.AwsSecret)).Writes(new(proto.ResCount))
 
 	// 通过密钥获取账号权限策略
 	h.Add("ListTCloudAuthPolicies", http.MethodPost, "/vendors/tcloud/accounts/auth_policies/list",
-		svc.ListTCloudAuthPolicies)
+		svc.ListTCloudAuthPolicies).
+		Reads(new(proto.ListTCloudAuthPolicyReq)).Writes(new([]*v20190116.ListGrantServiceAccessNode))
 
 	// 获取腾讯云账号用户网络类型
 	h.Add("GetTCloudNetworkAccountType", http.MethodGet, "/vendors/tcloud/accounts/{account_id}/network_type",
-		svc.GetTCloudNetworkAccountType)
+		svc.GetTCloudNetworkAccountType).Writes(new(v20170312.DescribeNetworkAccountTypeResponseParams))
 
 	initAccountServiceHooks(svc, h)
 
@@ -47,10 +47,13 @@ func (svc *argsTplSvc) initTCloudArgsTplService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("CreateTCloudAddress", http.MethodPost, "/vendors/tcloud/argument_templates/create",
-		svc.CreateTCloudArgsTpl)
-	h.Add("UpdateTCloudArgsTpl", http.MethodPut, "/vendors/tcloud/argument_templates/{id}", svc.UpdateTCloudArgsTpl)
-	h.Add("DeleteTCloudArgsTpl", http.MethodDelete, "/vendors/tcloud/argument_templates", svc.DeleteTCloudArgsTpl)
-	h.Add("ListTCloudArgsTpl", http.MethodPost, "/vendors/tcloud/argument_templates/list", svc.ListTCloudArgsTpl)
+		svc.CreateTCloudArgsTpl).Reads(new(protoargstpl.TCloudCreateReq))
+	h.Add("UpdateTCloudArgsTpl", http.MethodPut, "/vendors/tcloud/argument_templates/{id}", svc.UpdateTCloudArgsTpl).
+		Reads(new(protoargstpl.TCloudUpdateReq)).Writes(new(string))
+	h.Add("DeleteTCloudArgsTpl", http.MethodDelete, "/vendors/tcloud/argument_templates", svc.DeleteTCloudArgsTpl).
+		Reads(new(protoargstpl.TCloudDeleteReq))
+	h.Add("ListTCloudArgsTpl", http.MethodPost, "/vendors/tcloud/argument_templates/list", svc.ListTCloudArgsTpl).
+		Reads(new(protoargstpl.ArgsTplListReq))
 
 	h.Load(cap.WebService)
 }
@@ -54,7 +54,8 @@ func (svc bwPkgSvc) initTCloudBwPkgService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListBandwidthPackage", http.MethodPost,
-		"/vendors/tcloud/bandwidth_packages/list", svc.ListTCloudBandwidthPackage)
+		"/vendors/tcloud/bandwidth_packages/list", svc.ListTCloudBandwidthPackage).
+		Reads(new(hcbwpkg.ListTCloudBwPkgOption)).Writes(new(types.TCloudListBwPkgResult))
 
 	h.Load(cap.WebService)
 }
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/api/core"
 	billcore "hcm/pkg/api/core/bill"
 	"hcm/pkg/api/core/cloud"
+	hcbill "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/client"
 	dataserviceclient "hcm/pkg/client/data-service"
 	"hcm/pkg/dal/dao/tools"
@@ -46,24 +47,38 @@ func InitBillService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("AwsGetBillList", "POST", "/vendors/aws/bills/list", v.AwsGetBillList)
-	h.Add("AwsBillsPipeline", "POST", "/vendors/aws/bills/pipeline", v.AwsBillPipeline)
+	h.Add("AwsGetBillList", "POST", "/vendors/aws/bills/list", v.AwsGetBillList).
+		Reads(new(hcbill.AwsBillListReq)).Writes(new(hcbill.AwsBillListResult))
+	h.Add("AwsBillsPipeline", "POST", "/vendors/aws/bills/pipeline", v.AwsBillPipeline).
+		Reads(new(hcbill.BillPipelineReq))
 	h.Add("AwsBillConfigDelete", "DELETE", "/vendors/aws/bills/{id}", v.AwsBillConfigDelete)
-	h.Add("TCloudGetBillList", "POST", "/vendors/tcloud/bills/list", v.TCloudGetBillList)
-	h.Add("HuaWeiGetBillList", "POST", "/vendors/huawei/bills/list", v.HuaWeiGetBillList)
-	h.Add("HuaWeiGetFeeRecordList", "POST", "/vendors/huawei/feerecords/list", v.HuaWeiGetFeeRecordList)
-	h.Add("AzureGetBillList", "POST", "/vendors/azure/bills/list", v.AzureGetBillList)
-	h.Add("GcpGetBillList", "POST", "/vendors/gcp/bills/list", v.GcpGetBillList)
-	h.Add("GcpGetRootAccountBillList", "POST", "/vendors/gcp/root_account_bills/list", v.GcpGetRootAccountBillList)
-	h.Add("GcpQueryCreditList", "POST", "/vendors/gcp/root_account_bills/credits/list", v.GcpQueryCreditList)
-	h.Add("AwsGetRootAccountBillList", "POST", "/vendors/aws/root_account_bills/list", v.AwsGetRootAccountBillList)
+	h.Add("TCloudGetBillList", "POST", "/vendors/tcloud/bills/list", v.TCloudGetBillList).
+		Reads(new(hcbill.TCloudBillListReq)).Writes(new(hcbill.TCloudBillListResult))
+	h.Add("HuaWeiGetBillList", "POST", "/vendors/huawei/bills/list", v.HuaWeiGetBillList).
+		Reads(new(hcbill.HuaWeiBillListReq)).Writes(new(hcbill.HuaWeiBillListResult))
+	h.Add("HuaWeiGetFeeRecordList", "POST", "/vendors/huawei/feerecords/list", v.HuaWeiGetFeeRecordList).
+		Reads(new(hcbill.HuaWeiFeeRecordListReq)).Writes(new(hcbill.HuaWeiRootBillListResult))
+	h.Add("AzureGetBillList", "POST", "/vendors/azure/bills/list", v.AzureGetBillList).
+		Reads(new(hcbill.AzureBillListReq)).Writes(new(hcbill.AzureBillListResult))
+	h.Add("GcpGetBillList", "POST", "/vendors/gcp/bills/list", v.GcpGetBillList).
+		Reads(new(hcbill.GcpBillListReq)).Writes(new(hcbill.GcpBillListResult))
+	h.Add("GcpGetRootAccountBillList", "POST", "/vendors/gcp/root_account_bills/list", v.GcpGetRootAccountBillList).
+		Reads(new(hcbill.GcpRootAccountBillListReq)).Writes(new(hcbill.GcpBillListResult))
+	h.Add("GcpQueryCreditList", "POST", "/vendors/gcp/root_account_bills/credits/list", v.GcpQueryCreditList).
+		Reads(new(hcbill.GcpRootAccountBillListReq)).Writes(new(hcbill.GcpBillListResult))
+	h.Add("AwsGetRootAccountBillList", "POST", "/vendors/aws/root_account_bills/list", v.AwsGetRootAccountBillList).
+		Reads(new(hcbill.AwsRootBillListReq)).Writes(new(hcbill.AwsBillListResult))
 	h.Add("AzureGetRootAccountBillList", "POST",
-		"/vendors/azure/root_account_bills/list", v.AzureGetRootAccountBillList)
+		"/vendors/azure/root_account_bills/list", v.AzureGetRootAccountBillList).
+		Reads(new(hcbill.AzureRootBillListReq)).Writes(new(hcbill.AzureBillListResult))
 	h.Add("AwsGetRootAccountSpTotalUsage", "GET",
-		"/vendors/aws/root_account_bills/sp_usage_total", v.AwsGetRootAccountSpTotalUsage)
+		"/vendors/aws/root_account_bills/sp_usage_total", v.AwsGetRootAccountSpTotalUsage).
+		Reads(new(hcbill.AwsRootSpUsageTotalReq)).Writes(new(hcbill.AwsSpUsageTotalResult))
 	h.Add("AwsListRootOutsideMonthBill", "GET",
-		"/vendors/aws/root_account_bills/list_outside_month_bills", v.AwsListRootOutsideMonthBill)
-	h.Add("AwsListRootBillItems", "POST", "/vendors/aws/root_account_bills/list_items", v.AwsListRootBillItems)
+		"/vendors/aws/root_account_bills/list_outside_month_bills", v.AwsListRootOutsideMonthBill).
+		Reads(new(hcbill.AwsRootOutsideMonthBillListReq)).Writes(new(hcbill.AwsBillListResult))
+	h.Add("AwsListRootBillItems", "POST", "/vendors/aws/root_account_bills/list_items", v.AwsListRootBillItems).
+		Reads(new(hcbill.AwsRootBillItemsListReq)).Writes(new(hcbill.AwsBillListResult))
 
 	h.Load(cap.WebService)
 }
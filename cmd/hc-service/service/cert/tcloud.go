@@ -42,9 +42,12 @@ import (
 func (svc *certSvc) initTCloudCertService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("CreateTCloudCert", http.MethodPost, "/vendors/tcloud/certs/create", svc.CreateTCloudCert)
-	h.Add("DeleteTCloudCert", http.MethodDelete, "/vendors/tcloud/certs", svc.DeleteTCloudCert)
-	h.Add("ListTCloudCert", http.MethodPost, "/vendors/tcloud/certs/list", svc.ListTCloudCert)
+	h.Add("CreateTCloudCert", http.MethodPost, "/vendors/tcloud/certs/create", svc.CreateTCloudCert).
+		Reads(new(protocert.TCloudCreateReq)).Writes(new(cert.CertCreateResult))
+	h.Add("DeleteTCloudCert", http.MethodDelete, "/vendors/tcloud/certs", svc.DeleteTCloudCert).
+		Reads(new(protocert.TCloudDeleteReq))
+	h.Add("ListTCloudCert", http.MethodPost, "/vendors/tcloud/certs/list", svc.ListTCloudCert).
+		Reads(new(protocert.TCloudListOption)).Writes(new([]typecert.TCloudCert))
 
 	h.Load(cap.WebService)
 }
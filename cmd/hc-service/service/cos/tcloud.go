@@ -34,9 +34,12 @@ import (
 
 func (svc *cosSvc) initTCloudCosService(cap *capability.Capability) {
 	h := rest.NewHandler()
-	h.Add("CreateTCloudCosBucket", http.MethodPost, "/vendors/tcloud/cos/buckets/create", svc.CreateTCloudCosBucket)
-	h.Add("DeleteTCloudCosBucket", http.MethodDelete, "/vendors/tcloud/cos/buckets/delete", svc.DeleteTCloudCosBucket)
-	h.Add("ListTCloudCosBucket", http.MethodPost, "/vendors/tcloud/cos/buckets/list", svc.ListTCloudCosBucket)
+	h.Add("CreateTCloudCosBucket", http.MethodPost, "/vendors/tcloud/cos/buckets/create", svc.CreateTCloudCosBucket).
+		Reads(new(protocos.TCloudCreateBucketReq))
+	h.Add("DeleteTCloudCosBucket", http.MethodDelete, "/vendors/tcloud/cos/buckets/delete", svc.DeleteTCloudCosBucket).
+		Reads(new(protocos.TCloudDeleteBucketReq))
+	h.Add("ListTCloudCosBucket", http.MethodPost, "/vendors/tcloud/cos/buckets/list", svc.ListTCloudCosBucket).
+		Reads(new(protocos.TCloudBucketListReq)).Writes(new(typecos.TCloudBucketListResult))
 	h.Load(cap.WebService)
 }
 
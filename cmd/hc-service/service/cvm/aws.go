@@ -49,17 +49,24 @@ import (
 func (svc *cvmSvc) initAwsCvmService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/create", svc.BatchCreateAwsCvm)
-	h.Add("BatchStartAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/start", svc.BatchStartAwsCvm)
-	h.Add("BatchStopAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/stop", svc.BatchStopAwsCvm)
-	h.Add("BatchRebootAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/reboot", svc.BatchRebootAwsCvm)
-	h.Add("BatchDeleteAwsCvm", http.MethodDelete, "/vendors/aws/cvms/batch", svc.BatchDeleteAwsCvm)
+	h.Add("BatchCreateAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/create", svc.BatchCreateAwsCvm).
+		Reads(new(protocvm.AwsBatchCreateReq)).Writes(new(protocvm.BatchCreateResult))
+	h.Add("BatchStartAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/start", svc.BatchStartAwsCvm).
+		Reads(new(protocvm.AwsBatchStartReq))
+	h.Add("BatchStopAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/stop", svc.BatchStopAwsCvm).
+		Reads(new(protocvm.AwsBatchStopReq))
+	h.Add("BatchRebootAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/reboot", svc.BatchRebootAwsCvm).
+		Reads(new(protocvm.AwsBatchRebootReq))
+	h.Add("BatchDeleteAwsCvm", http.MethodDelete, "/vendors/aws/cvms/batch", svc.BatchDeleteAwsCvm).
+		Reads(new(protocvm.AwsBatchDeleteReq))
 
 	h.Add("BatchAssociateAwsSecurityGroup", http.MethodPost, "/vendors/aws/cvms/security_groups/batch/associate",
-		svc.BatchAssociateAwsSecurityGroup)
+		svc.BatchAssociateAwsSecurityGroup).Reads(new(protocvm.AwsCvmBatchAssociateSecurityGroupReq))
 
 	h.Add("ListAwsCvmNetworkInterface", http.MethodPost, "/vendors/aws/cvms/network_interfaces/list",
-		svc.ListAwsCvmNetworkInterface)
+		svc.ListAwsCvmNetworkInterface).
+		Reads(new(protocvm.ListCvmNetworkInterfaceReq)).
+		Writes(new(map[string]*protocvm.ListCvmNetworkInterfaceRespItem))
 
 	h.Load(cap.WebService)
 }
@@ -43,11 +43,14 @@ import (
 func (svc *cvmSvc) initAzureCvmService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("CreateAzureCvm", http.MethodPost, "/vendors/azure/cvms/create", svc.CreateAzureCvm)
+	h.Add("CreateAzureCvm", http.MethodPost, "/vendors/azure/cvms/create", svc.CreateAzureCvm).
+		Reads(new(protocvm.AzureCreateReq)).Writes(new(protocvm.AzureCreateResp))
 	h.Add("StartAzureCvm", http.MethodPost, "/vendors/azure/cvms/{id}/start", svc.StartAzureCvm)
-	h.Add("StopAzureCvm", http.MethodPost, "/vendors/azure/cvms/{id}/stop", svc.StopAzureCvm)
+	h.Add("StopAzureCvm", http.MethodPost, "/vendors/azure/cvms/{id}/stop", svc.StopAzureCvm).
+		Reads(new(protocvm.AzureStopReq))
 	h.Add("RebootAzureCvm", http.MethodPost, "/vendors/azure/cvms/{id}/reboot", svc.RebootAzureCvm)
-	h.Add("DeleteAzureCvm", http.MethodDelete, "/vendors/azure/cvms/{id}", svc.DeleteAzureCvm)
+	h.Add("DeleteAzureCvm", http.MethodDelete, "/vendors/azure/cvms/{id}", svc.DeleteAzureCvm).
+		Reads(new(protocvm.AzureDeleteReq))
 
 	h.Load(cap.WebService)
 }
@@ -41,7 +41,8 @@ import (
 func (svc *cvmSvc) initGcpCvmService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateGcpCvm", http.MethodPost, "/vendors/gcp/cvms/batch/create", svc.BatchCreateGcpCvm)
+	h.Add("BatchCreateGcpCvm", http.MethodPost, "/vendors/gcp/cvms/batch/create", svc.BatchCreateGcpCvm).
+		Reads(new(protocvm.GcpBatchCreateReq)).Writes(new(protocvm.BatchCreateResult))
 	h.Add("StartGcpCvm", http.MethodPost, "/vendors/gcp/cvms/{id}/start", svc.StartGcpCvm)
 	h.Add("StopGcpCvm", http.MethodPost, "/vendors/gcp/cvms/{id}/stop", svc.StopGcpCvm)
 	h.Add("RebootGcpCvm", http.MethodPost, "/vendors/gcp/cvms/{id}/reboot", svc.RebootGcpCvm)
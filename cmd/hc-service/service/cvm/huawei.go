@@ -41,13 +41,21 @@ import (
 func (svc *cvmSvc) initHuaWeiCvmService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/create", svc.BatchCreateHuaWeiCvm)
-	h.Add("InquiryPriceHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/prices/inquiry", svc.InquiryPriceHuaWeiCvm)
-	h.Add("BatchStartHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/start", svc.BatchStartHuaWeiCvm)
-	h.Add("BatchStopHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/stop", svc.BatchStopHuaWeiCvm)
-	h.Add("BatchRebootHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/reboot", svc.BatchRebootHuaWeiCvm)
-	h.Add("BatchDeleteHuaWeiCvm", http.MethodDelete, "/vendors/huawei/cvms/batch", svc.BatchDeleteHuaWeiCvm)
-	h.Add("BatchResetHuaWeiCvmPwd", http.MethodPost, "/vendors/huawei/cvms/batch/reset/pwd", svc.BatchResetHuaWeiCvmPwd)
+	h.Add("BatchCreateHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/create", svc.BatchCreateHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchCreateReq)).Writes(new(protocvm.BatchCreateResult))
+	h.Add("InquiryPriceHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/prices/inquiry", svc.InquiryPriceHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchCreateReq)).Writes(new(typecvm.InquiryPriceResult))
+	h.Add("BatchStartHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/start", svc.BatchStartHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchStartReq))
+	h.Add("BatchStopHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/stop", svc.BatchStopHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchStopReq))
+	h.Add("BatchRebootHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/reboot", svc.BatchRebootHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchRebootReq))
+	h.Add("BatchDeleteHuaWeiCvm", http.MethodDelete, "/vendors/huawei/cvms/batch", svc.BatchDeleteHuaWeiCvm).
+		Reads(new(protocvm.HuaWeiBatchDeleteReq))
+	h.Add("BatchResetHuaWeiCvmPwd", http.MethodPost,
+		"/vendors/huawei/cvms/batch/reset/pwd", svc.BatchResetHuaWeiCvmPwd).
+		Reads(new(protocvm.HuaWeiBatchResetPwdReq))
 
 	h.Load(cap.WebService)
 }
@@ -48,19 +48,32 @@ import (
 func (svc *cvmSvc) initTCloudCvmService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/create", svc.BatchCreateTCloudCvm)
-	h.Add("InquiryPriceTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/prices/inquiry", svc.InquiryPriceTCloudCvm)
-	h.Add("BatchStartTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/start", svc.BatchStartTCloudCvm)
-	h.Add("BatchStopTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/stop", svc.BatchStopTCloudCvm)
-	h.Add("BatchRebootTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/reboot", svc.BatchRebootTCloudCvm)
-	h.Add("BatchDeleteTCloudCvm", http.MethodDelete, "/vendors/tcloud/cvms/batch", svc.BatchDeleteTCloudCvm)
-	h.Add("BatchResetTCloudCvmPwd", http.MethodPost, "/vendors/tcloud/cvms/batch/reset/pwd", svc.BatchResetTCloudCvmPwd)
-	h.Add("BatchResetTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/reset", svc.BatchResetTCloudCvm)
+	h.Add("BatchCreateTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/create", svc.BatchCreateTCloudCvm).
+		Reads(new(protocvm.TCloudBatchCreateReq)).Writes(new(protocvm.BatchCreateResult))
+	h.Add("InquiryPriceTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/prices/inquiry", svc.InquiryPriceTCloudCvm).
+		Reads(new(protocvm.TCloudBatchCreateReq)).Writes(new(typecvm.InquiryPriceResult))
+	h.Add("BatchStartTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/start", svc.BatchStartTCloudCvm).
+		Reads(new(protocvm.TCloudBatchStartReq))
+	h.Add("BatchStopTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/stop", svc.BatchStopTCloudCvm).
+		Reads(new(protocvm.TCloudBatchStopReq))
+	h.Add("BatchRebootTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/reboot", svc.BatchRebootTCloudCvm).
+		Reads(new(protocvm.TCloudBatchRebootReq))
+	h.Add("BatchDeleteTCloudCvm", http.MethodDelete, "/vendors/tcloud/cvms/batch", svc.BatchDeleteTCloudCvm).
+		Reads(new(protocvm.TCloudBatchDeleteReq))
+	h.Add("BatchResetTCloudCvmPwd", http.MethodPost,
+		"/vendors/tcloud/cvms/batch/reset/pwd", svc.BatchResetTCloudCvmPwd).
+		Reads(new(protocvm.TCloudBatchResetPwdReq))
+	h.Add("BatchResetTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/reset", svc.BatchResetTCloudCvm).
+		Reads(new(protocvm.TCloudBatchResetReq))
+	h.Add("ResetTCloudCvmInstanceType", http.MethodPost, "/vendors/tcloud/cvms/instance_type/reset",
+		svc.ResetTCloudCvmInstanceType).Reads(new(protocvm.TCloudResetInstanceTypeReq))
 
 	h.Add("ListTCloudCvmNetworkInterface", http.MethodPost, "/vendors/tcloud/cvms/network_interfaces/list",
-		svc.ListTCloudCvmNetworkInterface)
+		svc.ListTCloudCvmNetworkInterface).
+		Reads(new(protocvm.ListCvmNetworkInterfaceReq)).
+		Writes(new(map[string]*protocvm.ListCvmNetworkInterfaceRespItem))
 	h.Add("BatchAssociateTCloudSecurityGroup", http.MethodPost, "/vendors/tcloud/cvms/security_groups/batch/associate",
-		svc.BatchAssociateTCloudSecurityGroup)
+		svc.BatchAssociateTCloudSecurityGroup).Reads(new(protocvm.TCloudCvmBatchAssociateSecurityGroupReq))
 
 	h.Load(cap.WebService)
 }
@@ -444,6 +457,67 @@ func (svc *cvmSvc) BatchStopTCloudCvm(cts *rest.Contexts) (interface{}, error) {
 	return nil, nil
 }
 
+// ResetTCloudCvmInstanceType 调整主机规格
+func (svc *cvmSvc) ResetTCloudCvmInstanceType(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.TCloudResetInstanceTypeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"cloud_id"},
+		Filter: tools.EqualExpression("id", req.ID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dataCli.Global.Cvm.ListCvm(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("request dataservice list tcloud cvm failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", req.ID)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID := listResp.Details[0].CloudID
+	opt := &typecvm.TCloudResetInstanceTypeOption{
+		Region:       req.Region,
+		CloudID:      cloudID,
+		InstanceType: req.InstanceType,
+		ForceStop:    req.ForceStop,
+	}
+	if err = client.ResetInstanceType(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to reset tcloud cvm instance type failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{cloudID},
+	}
+
+	_, err = syncClient.Cvm(cts.Kit, params, &synctcloud.SyncCvmOption{})
+	if err != nil {
+		logs.Errorf("sync tcloud cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 // BatchRebootTCloudCvm ...
 func (svc *cvmSvc) BatchRebootTCloudCvm(cts *rest.Contexts) (interface{}, error) {
 	req := new(protocvm.TCloudBatchRebootReq)
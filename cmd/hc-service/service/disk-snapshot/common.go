@@ -0,0 +1,348 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typessnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// getDisk 查询指定厂商的云硬盘
+func (svc *service) getDisk(kt *kit.Kit, vendor enumor.Vendor, id string) (*coredisk.BaseDisk, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.ListDisk(kt, req)
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk: %s not found", id)
+	}
+
+	disk := result.Details[0]
+	if disk.Vendor != string(vendor) {
+		return nil, errf.Newf(errf.InvalidParameter, "disk: %s is not %s disk", id, vendor)
+	}
+
+	return disk, nil
+}
+
+// getSnapshot 查询指定厂商的快照
+func (svc *service) getSnapshot(kt *kit.Kit, vendor enumor.Vendor, id string) (*coresnapshot.DiskSnapshot, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.DiskSnapshot.List(kt, req)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found", id)
+	}
+
+	snapshot := result.Details[0]
+	if snapshot.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "disk snapshot: %s is not %s snapshot", id, vendor)
+	}
+
+	return &snapshot, nil
+}
+
+// createSnapshotRecord 云上快照创建成功后立即写入db，便于记录快照所属的定期快照策略
+func (svc *service) createSnapshotRecord(kt *kit.Kit, disk *coredisk.BaseDisk, policyID string,
+	snapshot typessnapshot.Snapshot) (string, error) {
+
+	zone := snapshot.Zone
+	if len(zone) == 0 {
+		zone = disk.Zone
+	}
+
+	req := &dssnapshot.BatchCreateDiskSnapshotReq{
+		Items: []dssnapshot.DiskSnapshotCreate{{
+			Vendor:           enumor.Vendor(disk.Vendor),
+			AccountID:        disk.AccountID,
+			CloudID:          snapshot.CloudID,
+			BkBizID:          disk.BkBizID,
+			Name:             snapshot.Name,
+			Region:           disk.Region,
+			Zone:             zone,
+			DiskID:           disk.ID,
+			CloudDiskID:      disk.CloudID,
+			DiskSize:         snapshot.DiskSize,
+			Status:           snapshot.Status,
+			PolicyID:         policyID,
+			CloudCreatedTime: snapshot.CreatedTime,
+		}},
+	}
+	result, err := svc.dataCli.Global.DiskSnapshot.BatchCreate(kt, req)
+	if err != nil {
+		logs.Errorf("create disk snapshot record failed, err: %v, cloud_id: %s, rid: %s", err, snapshot.CloudID,
+			kt.Rid)
+		return "", err
+	}
+
+	if len(result.IDs) == 0 {
+		return "", errf.Newf(errf.Aborted, "create disk snapshot record %s returns no id", snapshot.CloudID)
+	}
+
+	return result.IDs[0], nil
+}
+
+// deleteSnapshotRecord 删除db中的快照记录
+func (svc *service) deleteSnapshotRecord(kt *kit.Kit, id string) error {
+	req := &dssnapshot.DeleteDiskSnapshotReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.dataCli.Global.DiskSnapshot.Delete(kt, req); err != nil {
+		logs.Errorf("delete disk snapshot record failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// syncSnapshot 将云上指定账号和地域的快照同步到db
+func (svc *service) syncSnapshot(kt *kit.Kit, vendor enumor.Vendor, accountID, region string,
+	fromCloud []typessnapshot.Snapshot) error {
+
+	fromDB, err := svc.listSnapshotFromDB(kt, accountID, region)
+	if err != nil {
+		return err
+	}
+
+	diskMap, err := svc.listDiskByCloudID(kt, accountID, fromCloud)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typessnapshot.Snapshot, coresnapshot.DiskSnapshot](fromCloud,
+		fromDB, func(cloud typessnapshot.Snapshot, db coresnapshot.DiskSnapshot) bool {
+			return isSnapshotChange(cloud, db, diskMap)
+		})
+
+	if len(delCloudIDs) > 0 {
+		if err = svc.deleteSnapshotByCloudID(kt, accountID, delCloudIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = svc.updateSnapshot(kt, updateMap, diskMap); err != nil {
+			return err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = svc.createSnapshot(kt, vendor, accountID, region, addSlice, diskMap); err != nil {
+			return err
+		}
+	}
+
+	logs.Infof("sync %s disk snapshot success, account: %s, region: %s, add: %d, update: %d, delete: %d, rid: %s",
+		vendor, accountID, region, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (svc *service) listSnapshotFromDB(kt *kit.Kit, accountID, region string) ([]coresnapshot.DiskSnapshot, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	result := make([]coresnapshot.DiskSnapshot, 0)
+	for {
+		resp, err := svc.dataCli.Global.DiskSnapshot.List(kt, req)
+		if err != nil {
+			logs.Errorf("list disk snapshot from db failed, err: %v, account: %s, region: %s, rid: %s", err,
+				accountID, region, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// listDiskByCloudID 查询快照关联的云硬盘，key为云硬盘的云上ID
+func (svc *service) listDiskByCloudID(kt *kit.Kit, accountID string, snapshots []typessnapshot.Snapshot) (
+	map[string]*coredisk.BaseDisk, error) {
+
+	cloudDiskIDs := make([]string, 0, len(snapshots))
+	for _, one := range snapshots {
+		if len(one.CloudDiskID) != 0 {
+			cloudDiskIDs = append(cloudDiskIDs, one.CloudDiskID)
+		}
+	}
+	cloudDiskIDs = slice.Unique(cloudDiskIDs)
+
+	diskMap := make(map[string]*coredisk.BaseDisk, len(cloudDiskIDs))
+	for _, ids := range slice.Split(cloudDiskIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("account_id", accountID),
+				tools.RuleIn("cloud_id", ids),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		result, err := svc.dataCli.Global.ListDisk(kt, req)
+		if err != nil {
+			logs.Errorf("list disk failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, disk := range result.Details {
+			diskMap[disk.CloudID] = disk
+		}
+	}
+
+	return diskMap, nil
+}
+
+func (svc *service) createSnapshot(kt *kit.Kit, vendor enumor.Vendor, accountID, region string,
+	addSlice []typessnapshot.Snapshot, diskMap map[string]*coredisk.BaseDisk) error {
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		req := &dssnapshot.BatchCreateDiskSnapshotReq{
+			Items: make([]dssnapshot.DiskSnapshotCreate, 0, len(batch)),
+		}
+		for _, one := range batch {
+			item := dssnapshot.DiskSnapshotCreate{
+				Vendor:           vendor,
+				AccountID:        accountID,
+				CloudID:          one.CloudID,
+				BkBizID:          constant.UnassignedBiz,
+				Name:             one.Name,
+				Region:           region,
+				Zone:             one.Zone,
+				CloudDiskID:      one.CloudDiskID,
+				DiskSize:         one.DiskSize,
+				Status:           one.Status,
+				CloudCreatedTime: one.CreatedTime,
+			}
+			if disk, exist := diskMap[one.CloudDiskID]; exist {
+				item.DiskID = disk.ID
+				item.BkBizID = disk.BkBizID
+				if len(item.Zone) == 0 {
+					item.Zone = disk.Zone
+				}
+			}
+			req.Items = append(req.Items, item)
+		}
+
+		if _, err := svc.dataCli.Global.DiskSnapshot.BatchCreate(kt, req); err != nil {
+			logs.Errorf("batch create disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) updateSnapshot(kt *kit.Kit, updateMap map[string]typessnapshot.Snapshot,
+	diskMap map[string]*coredisk.BaseDisk) error {
+
+	items := make([]dssnapshot.DiskSnapshotUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		item := dssnapshot.DiskSnapshotUpdate{
+			ID:          id,
+			Name:        one.Name,
+			Zone:        one.Zone,
+			CloudDiskID: one.CloudDiskID,
+			DiskSize:    one.DiskSize,
+			Status:      one.Status,
+		}
+		if disk, exist := diskMap[one.CloudDiskID]; exist {
+			item.DiskID = disk.ID
+			item.BkBizID = disk.BkBizID
+		}
+		items = append(items, item)
+	}
+
+	for _, batch := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		req := &dssnapshot.BatchUpdateDiskSnapshotReq{Items: batch}
+		if err := svc.dataCli.Global.DiskSnapshot.BatchUpdate(kt, req); err != nil {
+			logs.Errorf("batch update disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) deleteSnapshotByCloudID(kt *kit.Kit, accountID string, cloudIDs []string) error {
+	for _, batch := range slice.Split(cloudIDs, constant.BatchOperationMaxLimit) {
+		req := &dssnapshot.DeleteDiskSnapshotReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("account_id", accountID),
+				tools.RuleIn("cloud_id", batch),
+			),
+		}
+		if err := svc.dataCli.Global.DiskSnapshot.Delete(kt, req); err != nil {
+			logs.Errorf("batch delete disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isSnapshotChange(cloud typessnapshot.Snapshot, db coresnapshot.DiskSnapshot,
+	diskMap map[string]*coredisk.BaseDisk) bool {
+
+	if cloud.Name != db.Name || cloud.Status != db.Status || cloud.DiskSize != db.DiskSize ||
+		cloud.CloudDiskID != db.CloudDiskID {
+		return true
+	}
+
+	if len(cloud.Zone) != 0 && cloud.Zone != db.Zone {
+		return true
+	}
+
+	if disk, exist := diskMap[cloud.CloudDiskID]; exist && (disk.ID != db.DiskID || disk.BkBizID != db.BkBizID) {
+		return true
+	}
+
+	return false
+}
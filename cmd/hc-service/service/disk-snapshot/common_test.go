@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"testing"
+
+	typessnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+)
+
+func TestIsSnapshotChange(t *testing.T) {
+	db := coresnapshot.DiskSnapshot{Name: "daily", Zone: "ap-guangzhou-3", CloudDiskID: "disk-a", DiskID: "00000001",
+		BkBizID: 2, DiskSize: 50, Status: "NORMAL"}
+	cloud := typessnapshot.Snapshot{Name: "daily", Zone: "ap-guangzhou-3", CloudDiskID: "disk-a", DiskSize: 50,
+		Status: "NORMAL"}
+	diskMap := map[string]*coredisk.BaseDisk{"disk-a": {ID: "00000001", BkBizID: 2}}
+
+	if isSnapshotChange(cloud, db, diskMap) {
+		t.Errorf("snapshot should not be changed")
+	}
+
+	// 云上未返回可用区时不比较可用区
+	noZone := cloud
+	noZone.Zone = ""
+	if isSnapshotChange(noZone, db, diskMap) {
+		t.Errorf("snapshot without zone should not be changed")
+	}
+
+	changed := cloud
+	changed.Status = "CREATING"
+	if !isSnapshotChange(changed, db, diskMap) {
+		t.Errorf("status change should be detected")
+	}
+
+	// 云硬盘分配到其他业务后，快照的业务跟随变更
+	reassigned := map[string]*coredisk.BaseDisk{"disk-a": {ID: "00000001", BkBizID: 3}}
+	if !isSnapshotChange(cloud, db, reassigned) {
+		t.Errorf("disk biz change should be detected")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	typessnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+const huaweiSnapshotListLimit = 1000
+
+// CreateHuaWeiDiskSnapshot create huawei disk snapshot.
+func (svc *service) CreateHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	disk, err := svc.getDisk(cts.Kit, enumor.HuaWei, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, disk.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.HuaWeiCreateOption{
+		Region:      disk.Region,
+		CloudDiskID: disk.CloudID,
+		Name:        req.Name,
+	}
+	cloudID, err := client.CreateDiskSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create huawei disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	snapshot := typessnapshot.Snapshot{CloudID: cloudID, Name: req.Name, DiskSize: disk.DiskSize}
+	listOpt := &typessnapshot.HuaWeiListOption{
+		Region:  disk.Region,
+		CloudID: cloudID,
+		Limit:   1,
+	}
+	list, err := client.ListDiskSnapshot(cts.Kit, listOpt)
+	if err != nil {
+		// 快照已在云上创建，查询详情失败时使用已知信息入库，状态等待同步更新
+		logs.Warnf("list created huawei disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID,
+			cts.Kit.Rid)
+	}
+	if len(list) != 0 {
+		snapshot = list[0].ToSnapshot()
+	}
+
+	id, err := svc.createSnapshotRecord(cts.Kit, disk, req.PolicyID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// DeleteHuaWeiDiskSnapshot delete huawei disk snapshot.
+func (svc *service) DeleteHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := svc.getSnapshot(cts.Kit, enumor.HuaWei, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.HuaWeiDeleteOption{
+		Region:  snapshot.Region,
+		CloudID: snapshot.CloudID,
+	}
+	if err = client.DeleteDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei disk snapshot failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotRecord(cts.Kit, req.ID)
+}
+
+// RollbackHuaWeiDiskSnapshot rollback huawei disk by snapshot.
+func (svc *service) RollbackHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotRollbackReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := svc.getSnapshot(cts.Kit, enumor.HuaWei, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.HuaWeiRollbackOption{
+		Region:      snapshot.Region,
+		CloudID:     snapshot.CloudID,
+		CloudDiskID: snapshot.CloudDiskID,
+	}
+	if err = client.RollbackDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("rollback huawei disk snapshot failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SyncHuaWeiDiskSnapshot sync huawei disk snapshot.
+func (svc *service) SyncHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromCloud := make([]typessnapshot.Snapshot, 0)
+	opt := &typessnapshot.HuaWeiListOption{
+		Region: req.Region,
+		Offset: 0,
+		Limit:  huaweiSnapshotListLimit,
+	}
+	for {
+		list, err := client.ListDiskSnapshot(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list huawei disk snapshot failed, err: %v, account: %s, region: %s, rid: %s", err,
+				req.AccountID, req.Region, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list {
+			fromCloud = append(fromCloud, one.ToSnapshot())
+		}
+
+		if int32(len(list)) < opt.Limit {
+			break
+		}
+		opt.Offset += opt.Limit
+	}
+
+	return nil, svc.syncSnapshot(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, fromCloud)
+}
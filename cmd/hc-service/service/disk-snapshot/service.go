@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot 云硬盘快照
+package disksnapshot
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/rest"
+)
+
+// InitDiskSnapshotService initial the disk snapshot service
+func InitDiskSnapshotService(cap *capability.Capability) {
+	svc := &service{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	// 创建快照
+	h.Add("CreateTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/create",
+		svc.CreateTCloudDiskSnapshot).Reads(new(proto.DiskSnapshotCreateReq)).Writes(new(core.CreateResult))
+	h.Add("CreateAwsDiskSnapshot", http.MethodPost, "/vendors/aws/disk_snapshots/create", svc.CreateAwsDiskSnapshot).
+		Reads(new(proto.DiskSnapshotCreateReq)).Writes(new(core.CreateResult))
+	h.Add("CreateHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/create",
+		svc.CreateHuaWeiDiskSnapshot).Reads(new(proto.DiskSnapshotCreateReq)).Writes(new(core.CreateResult))
+
+	// 删除快照
+	h.Add("DeleteTCloudDiskSnapshot", http.MethodDelete, "/vendors/tcloud/disk_snapshots",
+		svc.DeleteTCloudDiskSnapshot).Reads(new(proto.DiskSnapshotDeleteReq))
+	h.Add("DeleteAwsDiskSnapshot", http.MethodDelete, "/vendors/aws/disk_snapshots", svc.DeleteAwsDiskSnapshot).
+		Reads(new(proto.DiskSnapshotDeleteReq))
+	h.Add("DeleteHuaWeiDiskSnapshot", http.MethodDelete, "/vendors/huawei/disk_snapshots",
+		svc.DeleteHuaWeiDiskSnapshot).Reads(new(proto.DiskSnapshotDeleteReq))
+
+	// 快照回滚，aws不支持使用快照回滚云硬盘
+	h.Add("RollbackTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/rollback",
+		svc.RollbackTCloudDiskSnapshot).Reads(new(proto.DiskSnapshotRollbackReq))
+	h.Add("RollbackHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/rollback",
+		svc.RollbackHuaWeiDiskSnapshot).Reads(new(proto.DiskSnapshotRollbackReq))
+
+	// 同步快照
+	h.Add("SyncTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/sync", svc.SyncTCloudDiskSnapshot).
+		Reads(new(proto.DiskSnapshotSyncReq))
+	h.Add("SyncAwsDiskSnapshot", http.MethodPost, "/vendors/aws/disk_snapshots/sync", svc.SyncAwsDiskSnapshot).
+		Reads(new(proto.DiskSnapshotSyncReq))
+	h.Add("SyncHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/sync", svc.SyncHuaWeiDiskSnapshot).
+		Reads(new(proto.DiskSnapshotSyncReq))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
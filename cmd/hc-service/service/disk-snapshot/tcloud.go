@@ -0,0 +1,193 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	typescore "hcm/pkg/adaptor/types/core"
+	typessnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateTCloudDiskSnapshot create tcloud disk snapshot.
+func (svc *service) CreateTCloudDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	disk, err := svc.getDisk(cts.Kit, enumor.TCloud, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, disk.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.TCloudCreateOption{
+		Region:      disk.Region,
+		CloudDiskID: disk.CloudID,
+		Name:        req.Name,
+	}
+	cloudID, err := client.CreateDiskSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create tcloud disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	snapshot := typessnapshot.Snapshot{CloudID: cloudID, Name: req.Name, DiskSize: disk.DiskSize}
+	listOpt := &typessnapshot.TCloudListOption{
+		Region:   disk.Region,
+		CloudIDs: []string{cloudID},
+		Page:     &typescore.TCloudPage{Offset: 0, Limit: typescore.TCloudQueryLimit},
+	}
+	list, err := client.ListDiskSnapshot(cts.Kit, listOpt)
+	if err != nil {
+		// 快照已在云上创建，查询详情失败时使用已知信息入库，状态等待同步更新
+		logs.Warnf("list created tcloud disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID,
+			cts.Kit.Rid)
+	}
+	if len(list) != 0 {
+		snapshot = list[0].ToSnapshot()
+	}
+
+	id, err := svc.createSnapshotRecord(cts.Kit, disk, req.PolicyID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// DeleteTCloudDiskSnapshot delete tcloud disk snapshot.
+func (svc *service) DeleteTCloudDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := svc.getSnapshot(cts.Kit, enumor.TCloud, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.TCloudDeleteOption{
+		Region:   snapshot.Region,
+		CloudIDs: []string{snapshot.CloudID},
+	}
+	if err = client.DeleteDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud disk snapshot failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotRecord(cts.Kit, req.ID)
+}
+
+// RollbackTCloudDiskSnapshot rollback tcloud disk by snapshot.
+func (svc *service) RollbackTCloudDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotRollbackReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshot, err := svc.getSnapshot(cts.Kit, enumor.TCloud, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, snapshot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typessnapshot.TCloudRollbackOption{
+		Region:           snapshot.Region,
+		CloudID:          snapshot.CloudID,
+		CloudDiskID:      snapshot.CloudDiskID,
+		AutoStopInstance: req.AutoStopInstance,
+	}
+	if err = client.RollbackDiskSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("rollback tcloud disk snapshot failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SyncTCloudDiskSnapshot sync tcloud disk snapshot.
+func (svc *service) SyncTCloudDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.DiskSnapshotSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromCloud := make([]typessnapshot.Snapshot, 0)
+	opt := &typessnapshot.TCloudListOption{
+		Region: req.Region,
+		Page:   &typescore.TCloudPage{Offset: 0, Limit: typescore.TCloudQueryLimit},
+	}
+	for {
+		list, err := client.ListDiskSnapshot(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list tcloud disk snapshot failed, err: %v, account: %s, region: %s, rid: %s", err,
+				req.AccountID, req.Region, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list {
+			fromCloud = append(fromCloud, one.ToSnapshot())
+		}
+
+		if uint64(len(list)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Offset += opt.Page.Limit
+	}
+
+	return nil, svc.syncSnapshot(cts.Kit, enumor.TCloud, req.AccountID, req.Region, fromCloud)
+}
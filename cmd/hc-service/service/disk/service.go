@@ -24,6 +24,9 @@ import (
 
 	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
 )
@@ -38,36 +41,56 @@ func InitDiskService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 硬盘创建
-	h.Add("CreateTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/create", d.CreateTCloudDisk)
-	h.Add("CreateGcpDisk", http.MethodPost, "/vendors/gcp/disks/create", d.CreateGcpDisk)
-	h.Add("CreateAzureDisk", http.MethodPost, "/vendors/azure/disks/create", d.CreateAzureDisk)
-	h.Add("CreateHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/create", d.CreateHuaWeiDisk)
-	h.Add("CreateAwsDisk", http.MethodPost, "/vendors/aws/disks/create", d.CreateAwsDisk)
+	h.Add("CreateTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/create", d.CreateTCloudDisk).
+		Reads(new(proto.TCloudDiskCreateReq)).Writes(new(proto.BatchCreateResult))
+	h.Add("CreateGcpDisk", http.MethodPost, "/vendors/gcp/disks/create", d.CreateGcpDisk).
+		Reads(new(proto.GcpDiskCreateReq)).Writes(new(proto.BatchCreateResult))
+	h.Add("CreateAzureDisk", http.MethodPost, "/vendors/azure/disks/create", d.CreateAzureDisk).
+		Reads(new(proto.AzureDiskCreateReq)).Writes(new(proto.BatchCreateResult))
+	h.Add("CreateHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/create", d.CreateHuaWeiDisk).
+		Reads(new(proto.HuaWeiDiskCreateReq)).Writes(new(proto.BatchCreateResult))
+	h.Add("CreateAwsDisk", http.MethodPost, "/vendors/aws/disks/create", d.CreateAwsDisk).
+		Reads(new(proto.AwsDiskCreateReq)).Writes(new(proto.BatchCreateResult))
 
 	// 删除云盘
-	h.Add("DeleteTCloudDisk", http.MethodDelete, "/vendors/tcloud/disks", d.DeleteTCloudDisk)
-	h.Add("DeleteGcpDisk", http.MethodDelete, "/vendors/gcp/disks", d.DeleteGcpDisk)
-	h.Add("DeleteAzureDisk", http.MethodDelete, "/vendors/azure/disks", d.DeleteAzureDisk)
-	h.Add("DeleteHuaWeiDisk", http.MethodDelete, "/vendors/huawei/disks", d.DeleteHuaWeiDisk)
-	h.Add("DeleteAwsDisk", http.MethodDelete, "/vendors/aws/disks", d.DeleteAwsDisk)
+	h.Add("DeleteTCloudDisk", http.MethodDelete, "/vendors/tcloud/disks", d.DeleteTCloudDisk).
+		Reads(new(proto.DiskDeleteReq))
+	h.Add("DeleteGcpDisk", http.MethodDelete, "/vendors/gcp/disks", d.DeleteGcpDisk).Reads(new(proto.DiskDeleteReq))
+	h.Add("DeleteAzureDisk", http.MethodDelete, "/vendors/azure/disks", d.DeleteAzureDisk).
+		Reads(new(proto.DiskDeleteReq))
+	h.Add("DeleteHuaWeiDisk", http.MethodDelete, "/vendors/huawei/disks", d.DeleteHuaWeiDisk).
+		Reads(new(proto.DiskDeleteReq))
+	h.Add("DeleteAwsDisk", http.MethodDelete, "/vendors/aws/disks", d.DeleteAwsDisk).Reads(new(proto.DiskDeleteReq))
 
 	// 挂载云盘
-	h.Add("AttachTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/attach", d.AttachTCloudDisk)
-	h.Add("AttachGcpDisk", http.MethodPost, "/vendors/gcp/disks/attach", d.AttachGcpDisk)
-	h.Add("AttachAzureDisk", http.MethodPost, "/vendors/azure/disks/attach", d.AttachAzureDisk)
-	h.Add("AttachHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/attach", d.AttachHuaWeiDisk)
-	h.Add("AttachAwsDisk", http.MethodPost, "/vendors/aws/disks/attach", d.AttachAwsDisk)
+	h.Add("AttachTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/attach", d.AttachTCloudDisk).
+		Reads(new(proto.TCloudDiskAttachReq))
+	h.Add("AttachGcpDisk", http.MethodPost, "/vendors/gcp/disks/attach", d.AttachGcpDisk).
+		Reads(new(proto.GcpDiskAttachReq))
+	h.Add("AttachAzureDisk", http.MethodPost, "/vendors/azure/disks/attach", d.AttachAzureDisk).
+		Reads(new(proto.AzureDiskAttachReq))
+	h.Add("AttachHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/attach", d.AttachHuaWeiDisk).
+		Reads(new(proto.HuaWeiDiskAttachReq))
+	h.Add("AttachAwsDisk", http.MethodPost, "/vendors/aws/disks/attach", d.AttachAwsDisk).
+		Reads(new(proto.AwsDiskAttachReq))
 
 	// 卸载云盘
-	h.Add("DetachTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/detach", d.DetachTCloudDisk)
-	h.Add("DetachGcpDisk", http.MethodPost, "/vendors/gcp/disks/detach", d.DetachGcpDisk)
-	h.Add("DetachAzureDisk", http.MethodPost, "/vendors/azure/disks/detach", d.DetachAzureDisk)
-	h.Add("DetachHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/detach", d.DetachHuaWeiDisk)
-	h.Add("DetachAwsDisk", http.MethodPost, "/vendors/aws/disks/detach", d.DetachAwsDisk)
+	h.Add("DetachTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/detach", d.DetachTCloudDisk).
+		Reads(new(proto.DiskDetachReq))
+	h.Add("DetachGcpDisk", http.MethodPost, "/vendors/gcp/disks/detach", d.DetachGcpDisk).
+		Reads(new(proto.DiskDetachReq))
+	h.Add("DetachAzureDisk", http.MethodPost, "/vendors/azure/disks/detach", d.DetachAzureDisk).
+		Reads(new(proto.DiskDetachReq))
+	h.Add("DetachHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/detach", d.DetachHuaWeiDisk).
+		Reads(new(proto.DiskDetachReq))
+	h.Add("DetachAwsDisk", http.MethodPost, "/vendors/aws/disks/detach", d.DetachAwsDisk).
+		Reads(new(proto.DiskDetachReq))
 
 	// 询价
-	h.Add("InquiryPriceTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/prices/inquiry", d.InquiryPriceTCloudDisk)
-	h.Add("InquiryPriceHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/prices/inquiry", d.InquiryPriceHuaWeiDisk)
+	h.Add("InquiryPriceTCloudDisk", http.MethodPost, "/vendors/tcloud/disks/prices/inquiry", d.InquiryPriceTCloudDisk).
+		Reads(new(proto.TCloudDiskCreateReq)).Writes(new(cvm.InquiryPriceResult))
+	h.Add("InquiryPriceHuaWeiDisk", http.MethodPost, "/vendors/huawei/disks/prices/inquiry", d.InquiryPriceHuaWeiDisk).
+		Reads(new(proto.HuaWeiDiskCreateReq)).Writes(new(disk.InquiryPriceResult))
 
 	h.Load(cap.WebService)
 }
@@ -48,9 +48,11 @@ func InitFirewallService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("CreateGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/create", sg.CreateGcpFirewallRule)
+	h.Add("CreateGcpFirewallRule", http.MethodPost, "/vendors/gcp/firewalls/rules/create", sg.CreateGcpFirewallRule).
+		Reads(new(proto.GcpFirewallRuleCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteGcpFirewallRule", http.MethodDelete, "/vendors/gcp/firewalls/rules/{id}", sg.DeleteGcpFirewallRule)
-	h.Add("UpdateGcpFirewallRule", http.MethodPut, "/vendors/gcp/firewalls/rules/{id}", sg.UpdateGcpFirewallRule)
+	h.Add("UpdateGcpFirewallRule", http.MethodPut, "/vendors/gcp/firewalls/rules/{id}", sg.UpdateGcpFirewallRule).
+		Reads(new(proto.GcpFirewallRuleUpdateReq))
 
 	h.Load(cap.WebService)
 }
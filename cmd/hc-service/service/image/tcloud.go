@@ -31,7 +31,8 @@ import (
 func (svc *imageSvc) initTCloudImageService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
-	h.Add("ListImage", http.MethodPost, "/vendors/tcloud/images/list", svc.ListImage)
+	h.Add("ListImage", http.MethodPost, "/vendors/tcloud/images/list", svc.ListImage).
+		Reads(new(image.TCloudImageListOption))
 
 	h.Load(cap.WebService)
 }
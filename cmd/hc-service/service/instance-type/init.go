@@ -22,6 +22,7 @@ package instancetype
 import (
 	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	proto "hcm/pkg/api/hc-service/instance-type"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
 )
@@ -40,11 +41,16 @@ func InitInstanceTypeService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("ListForTCloud", "POST", "/vendors/tcloud/instance_types/list", i.ListForTCloud)
-	h.Add("ListForAws", "POST", "/vendors/aws/instance_types/list", i.ListForAws)
-	h.Add("ListForHuaWei", "POST", "/vendors/huawei/instance_types/list", i.ListForHuaWei)
-	h.Add("ListForAzure", "POST", "/vendors/azure/instance_types/list", i.ListForAzure)
-	h.Add("ListForGcp", "POST", "/vendors/gcp/instance_types/list", i.ListForGcp)
+	h.Add("ListForTCloud", "POST", "/vendors/tcloud/instance_types/list", i.ListForTCloud).
+		Reads(new(proto.TCloudInstanceTypeListReq)).Writes(new([]*proto.TCloudInstanceTypeResp))
+	h.Add("ListForAws", "POST", "/vendors/aws/instance_types/list", i.ListForAws).
+		Reads(new(proto.AwsInstanceTypeListReq)).Writes(new([]*proto.AwsInstanceTypeResp))
+	h.Add("ListForHuaWei", "POST", "/vendors/huawei/instance_types/list", i.ListForHuaWei).
+		Reads(new(proto.HuaWeiInstanceTypeListReq)).Writes(new([]*proto.HuaWeiInstanceTypeResp))
+	h.Add("ListForAzure", "POST", "/vendors/azure/instance_types/list", i.ListForAzure).
+		Reads(new(proto.AzureInstanceTypeListReq)).Writes(new([]*proto.AzureInstanceTypeResp))
+	h.Add("ListForGcp", "POST", "/vendors/gcp/instance_types/list", i.ListForGcp).
+		Reads(new(proto.GcpInstanceTypeListReq)).Writes(new([]*proto.GcpInstanceTypeResp))
 
 	h.Load(cap.WebService)
 }
@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
 	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/tcloud"
 	adcore "hcm/pkg/adaptor/types/core"
 	typelb "hcm/pkg/adaptor/types/load-balancer"
@@ -47,65 +49,92 @@ func (svc *clbSvc) initTCloudClbService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("BatchCreateTCloudClb", http.MethodPost,
-		"/vendors/tcloud/load_balancers/batch/create", svc.BatchCreateTCloudClb)
+		"/vendors/tcloud/load_balancers/batch/create", svc.BatchCreateTCloudClb).
+		Reads(new(protolb.TCloudLoadBalancerCreateReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("InquiryPriceTCloudLB", http.MethodPost,
-		"/vendors/tcloud/load_balancers/prices/inquiry", svc.InquiryPriceTCloudLB)
-	h.Add("ListTCloudClb", http.MethodPost, "/vendors/tcloud/load_balancers/list", svc.ListTCloudClb)
+		"/vendors/tcloud/load_balancers/prices/inquiry", svc.InquiryPriceTCloudLB).
+		Reads(new(protolb.TCloudLoadBalancerCreateReq)).Writes(new(typelb.TCloudLBPrice))
+	h.Add("ListTCloudClb", http.MethodPost, "/vendors/tcloud/load_balancers/list", svc.ListTCloudClb).
+		Reads(new(protolb.TCloudListOption)).Writes(new([]typelb.TCloudClb))
 	h.Add("TCloudDescribeResources", http.MethodPost,
-		"/vendors/tcloud/load_balancers/resources/describe", svc.TCloudDescribeResources)
-	h.Add("TCloudUpdateCLB", http.MethodPatch, "/vendors/tcloud/load_balancers/{id}", svc.TCloudUpdateCLB)
+		"/vendors/tcloud/load_balancers/resources/describe", svc.TCloudDescribeResources).
+		Reads(new(protolb.TCloudDescribeResourcesOption)).Writes(new(v20180317.DescribeResourcesResponseParams))
+	h.Add("TCloudUpdateCLB", http.MethodPatch, "/vendors/tcloud/load_balancers/{id}", svc.TCloudUpdateCLB).
+		Reads(new(protolb.TCloudLBUpdateReq))
 	h.Add("BatchDeleteTCloudLoadBalancer", http.MethodDelete,
-		"/vendors/tcloud/load_balancers/batch", svc.BatchDeleteTCloudLoadBalancer)
-	h.Add("ListQuotaTCloudLB", http.MethodPost, "/vendors/tcloud/load_balancers/quota", svc.ListTCloudLBQuota)
+		"/vendors/tcloud/load_balancers/batch", svc.BatchDeleteTCloudLoadBalancer).
+		Reads(new(protolb.BatchDeleteLoadBalancerReq))
+	h.Add("ListQuotaTCloudLB", http.MethodPost, "/vendors/tcloud/load_balancers/quota", svc.ListTCloudLBQuota).
+		Reads(new(protolb.TCloudListLoadBalancerQuotaReq)).Writes(new([]typelb.TCloudLoadBalancerQuota))
 	h.Add("TCloudCreateSnatIps", http.MethodPost,
-		"/vendors/tcloud/load_balancers/snat_ips/create", svc.TCloudCreateSnatIps)
+		"/vendors/tcloud/load_balancers/snat_ips/create", svc.TCloudCreateSnatIps).
+		Reads(new(protolb.TCloudCreateSnatIpReq))
 	h.Add("TCloudDeleteSnatIps", http.MethodDelete,
-		"/vendors/tcloud/load_balancers/snat_ips", svc.TCloudDeleteSnatIps)
+		"/vendors/tcloud/load_balancers/snat_ips", svc.TCloudDeleteSnatIps).Reads(new(protolb.TCloudDeleteSnatIpReq))
 
 	h.Add("TCloudCreateUrlRule", http.MethodPost,
-		"/vendors/tcloud/listeners/{lbl_id}/rules/batch/create", svc.TCloudCreateUrlRule)
+		"/vendors/tcloud/listeners/{lbl_id}/rules/batch/create", svc.TCloudCreateUrlRule).
+		Reads(new(protolb.TCloudRuleBatchCreateReq)).Writes(new(poller.BaseDoneResult))
 	h.Add("TCloudUpdateUrlRule", http.MethodPatch,
-		"/vendors/tcloud/listeners/{lbl_id}/rules/{rule_id}", svc.TCloudUpdateUrlRule)
+		"/vendors/tcloud/listeners/{lbl_id}/rules/{rule_id}", svc.TCloudUpdateUrlRule).
+		Reads(new(protolb.TCloudRuleUpdateReq))
 	h.Add("TCloudBatchDeleteUrlRule", http.MethodDelete,
-		"/vendors/tcloud/listeners/{lbl_id}/rules/batch", svc.TCloudBatchDeleteUrlRule)
+		"/vendors/tcloud/listeners/{lbl_id}/rules/batch", svc.TCloudBatchDeleteUrlRule).
+		Reads(new(protolb.TCloudRuleDeleteByIDReq))
 	h.Add("TCloudBatchDeleteUrlRuleByDomain", http.MethodDelete,
-		"/vendors/tcloud/listeners/{lbl_id}/rules/by/domain/batch", svc.TCloudBatchDeleteUrlRuleByDomain)
+		"/vendors/tcloud/listeners/{lbl_id}/rules/by/domain/batch", svc.TCloudBatchDeleteUrlRuleByDomain).
+		Reads(new(protolb.TCloudRuleDeleteByDomainReq))
 
 	// 监听器
 	h.Add("CreateTCloudListenerWithTargetGroup", http.MethodPost,
-		"/vendors/tcloud/listeners/create_with_target_group", svc.CreateTCloudListenerWithTargetGroup)
-	h.Add("UpdateTCloudListener", http.MethodPatch, "/vendors/tcloud/listeners/{id}", svc.UpdateTCloudListener)
+		"/vendors/tcloud/listeners/create_with_target_group", svc.CreateTCloudListenerWithTargetGroup).
+		Reads(new(protolb.ListenerWithRuleCreateReq)).Writes(new(protolb.ListenerWithRuleCreateResult))
+	h.Add("UpdateTCloudListener", http.MethodPatch, "/vendors/tcloud/listeners/{id}", svc.UpdateTCloudListener).
+		Reads(new(protolb.ListenerWithRuleUpdateReq))
 	h.Add("UpdateTCloudListenerHealthCheck", http.MethodPatch,
-		"/vendors/tcloud/listeners/{lbl_id}/health_check", svc.UpdateTCloudListenerHealthCheck)
-	h.Add("DeleteTCloudListener", http.MethodDelete, "/vendors/tcloud/listeners/batch", svc.DeleteTCloudListener)
+		"/vendors/tcloud/listeners/{lbl_id}/health_check", svc.UpdateTCloudListenerHealthCheck).
+		Reads(new(protolb.HealthCheckUpdateReq))
+	h.Add("DeleteTCloudListener", http.MethodDelete, "/vendors/tcloud/listeners/batch", svc.DeleteTCloudListener).
+		Reads(new(core.BatchDeleteReq))
 	// 仅创建监听器
-	h.Add("CreateTCloudListener", http.MethodPost, "/vendors/tcloud/listeners/create", svc.CreateTCloudListener)
+	h.Add("CreateTCloudListener", http.MethodPost, "/vendors/tcloud/listeners/create", svc.CreateTCloudListener).
+		Reads(new(protolb.TCloudListenerCreateReq)).Writes(new(protolb.ListenerCreateResult))
 
 	// 域名、规则
 	h.Add("UpdateTCloudDomainAttr", http.MethodPatch,
-		"/vendors/tcloud/listeners/{lbl_id}/domains", svc.UpdateTCloudDomainAttr)
+		"/vendors/tcloud/listeners/{lbl_id}/domains", svc.UpdateTCloudDomainAttr).
+		Reads(new(protolb.DomainAttrUpdateReq))
 
 	// 目标组
 	h.Add("BatchCreateTCloudTargets", http.MethodPost,
-		"/vendors/tcloud/target_groups/{target_group_id}/targets/create", svc.BatchCreateTCloudTargets)
+		"/vendors/tcloud/target_groups/{target_group_id}/targets/create", svc.BatchCreateTCloudTargets).
+		Reads(new(protolb.TCloudBatchOperateTargetReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("BatchRemoveTCloudTargets", http.MethodDelete,
-		"/vendors/tcloud/target_groups/{target_group_id}/targets/batch", svc.BatchRemoveTCloudTargets)
+		"/vendors/tcloud/target_groups/{target_group_id}/targets/batch", svc.BatchRemoveTCloudTargets).
+		Reads(new(protolb.TCloudBatchOperateTargetReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("BatchModifyTCloudTargetsPort", http.MethodPatch,
-		"/vendors/tcloud/target_groups/{target_group_id}/targets/port", svc.BatchModifyTCloudTargetsPort)
+		"/vendors/tcloud/target_groups/{target_group_id}/targets/port", svc.BatchModifyTCloudTargetsPort).
+		Reads(new(protolb.TCloudBatchOperateTargetReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("BatchModifyTCloudTargetsWeight", http.MethodPatch,
-		"/vendors/tcloud/target_groups/{target_group_id}/targets/weight", svc.BatchModifyTCloudTargetsWeight)
+		"/vendors/tcloud/target_groups/{target_group_id}/targets/weight", svc.BatchModifyTCloudTargetsWeight).
+		Reads(new(protolb.TCloudBatchOperateTargetReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("ListTCloudTargetsHealth", http.MethodPost,
-		"/vendors/tcloud/load_balancers/targets/health", svc.ListTCloudTargetsHealth)
+		"/vendors/tcloud/load_balancers/targets/health", svc.ListTCloudTargetsHealth).
+		Reads(new(protolb.TCloudTargetHealthReq)).Writes(new(protolb.TCloudTargetHealthResp))
 
 	h.Add("RegisterTargetToListenerRule", http.MethodPost,
-		"/vendors/tcloud/load_balancers/{lb_id}/targets/create", svc.RegisterTargetToListenerRule)
+		"/vendors/tcloud/load_balancers/{lb_id}/targets/create", svc.RegisterTargetToListenerRule).
+		Reads(new(protolb.BatchRegisterTCloudTargetReq))
 	h.Add("BatchRemoveTCloudListenerTargets", http.MethodDelete,
-		"/vendors/tcloud/load_balancers/{lb_id}/targets/batch", svc.BatchRemoveTCloudListenerTargets)
+		"/vendors/tcloud/load_balancers/{lb_id}/targets/batch", svc.BatchRemoveTCloudListenerTargets).
+		Reads(new(protolb.TCloudBatchUnbindRsReq)).Writes(new(protolb.BatchCreateResult))
 	h.Add("BatchModifyTCloudListenerTargetsWeight", http.MethodPatch,
-		"/vendors/tcloud/load_balancers/{lb_id}/targets/weight", svc.BatchModifyTCloudListenerTargetsWeight)
+		"/vendors/tcloud/load_balancers/{lb_id}/targets/weight", svc.BatchModifyTCloudListenerTargetsWeight).
+		Reads(new(protolb.TCloudBatchModifyRsWeightReq)).Writes(new(protolb.BatchCreateResult))
 
 	h.Add("QueryListenerTargetsByCloudIDs", http.MethodPost,
-		"/vendors/tcloud/targets/query_by_cloud_ids", svc.QueryListenerTargetsByCloudIDs)
+		"/vendors/tcloud/targets/query_by_cloud_ids", svc.QueryListenerTargetsByCloudIDs).
+		Reads(new(protolb.QueryTCloudListenerTargets)).Writes(new([]typelb.TCloudListenerTarget))
 	h.Load(cap.WebService)
 }
 
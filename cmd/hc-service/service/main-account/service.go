@@ -25,6 +25,7 @@ import (
 
 	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	proto "hcm/pkg/api/hc-service/main-account"
 	"hcm/pkg/rest"
 )
 
@@ -37,8 +38,10 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	// 创建二级账号目前只支持AWS和GCP
-	h.Add("AwsCreateMainAccount", http.MethodPost, "/vendors/aws/main_accounts/create", svc.AwsCreateMainAccount)
-	h.Add("GcpCreateMainAccount", http.MethodPost, "/vendors/gcp/main_accounts/create", svc.GcpCreateMainAccount)
+	h.Add("AwsCreateMainAccount", http.MethodPost, "/vendors/aws/main_accounts/create", svc.AwsCreateMainAccount).
+		Reads(new(proto.CreateAwsMainAccountReq)).Writes(new(proto.CreateAwsMainAccountResp))
+	h.Add("GcpCreateMainAccount", http.MethodPost, "/vendors/gcp/main_accounts/create", svc.GcpCreateMainAccount).
+		Reads(new(proto.CreateGcpMainAccountReq)).Writes(new(proto.CreateGcpMainAccountResp))
 
 	h.Load(cap.WebService)
 
@@ -0,0 +1,301 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	"errors"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/api/core"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	hcresmetric "hcm/pkg/api/hc-service/res-metric"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// metricGetter 获取资源监控数据的方法，屏蔽各云厂商adaptor之间的差异
+type metricGetter func(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error)
+
+// resource 待采集监控数据的资源
+type resource struct {
+	ID      string
+	CloudID string
+	Region  string
+}
+
+// CollectResMetricDaily 采集账号下主机、负载均衡指定日期的监控数据，按天聚合后写入
+func (svc *service) CollectResMetricDaily(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(hcresmetric.CollectResMetricDailyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmGetter, lbGetter, err := svc.getMetricGetter(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, _ := time.ParseInLocation(constant.DateLayout, req.StatDate, time.Local)
+	end := start.AddDate(0, 0, 1)
+
+	cvms, err := svc.listCvm(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := svc.collect(cts.Kit, vendor, req, enumor.CvmCloudResType, cvms, typemonitor.CvmMetrics, cvmGetter,
+		start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	lbs, err := svc.listLoadBalancer(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	lbItems, err := svc.collect(cts.Kit, vendor, req, enumor.LoadBalancerCloudResType, lbs,
+		typemonitor.LoadBalancerMetrics, lbGetter, start, end)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, lbItems...)
+
+	for _, batch := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		upsertReq := &dsresmetric.BatchUpsertResMetricDailyReq{Items: batch}
+		if err = svc.dataCli.Global.ResMetric.BatchUpsertDaily(cts.Kit, upsertReq); err != nil {
+			logs.Errorf("batch upsert res metric daily failed, err: %v, account: %s, rid: %s", err, req.AccountID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return &hcresmetric.CollectResMetricDailyResult{Count: len(items)}, nil
+}
+
+// getMetricGetter 返回主机、负载均衡的监控数据获取方法。负载均衡目前只纳管了腾讯云，其他云厂商的负载均衡
+// 获取方法直接返回不支持的错误，避免纳管后静默跳过采集
+func (svc *service) getMetricGetter(kt *kit.Kit, vendor enumor.Vendor, accountID string) (metricGetter,
+	metricGetter, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		cli, err := svc.ad.TCloud(kt, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli.GetCvmMetric, cli.GetLoadBalancerMetric, nil
+	case enumor.Aws:
+		cli, err := svc.ad.Aws(kt, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli.GetCvmMetric, unsupportedGetter(vendor, enumor.LoadBalancerCloudResType), nil
+	case enumor.HuaWei:
+		cli, err := svc.ad.HuaWei(kt, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli.GetCvmMetric, unsupportedGetter(vendor, enumor.LoadBalancerCloudResType), nil
+	case enumor.Azure:
+		cli, err := svc.ad.Azure(kt, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli.GetCvmMetric, unsupportedGetter(vendor, enumor.LoadBalancerCloudResType), nil
+	case enumor.Gcp:
+		cli, err := svc.ad.Gcp(kt, accountID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cli.GetCvmMetric, unsupportedGetter(vendor, enumor.LoadBalancerCloudResType), nil
+	default:
+		return nil, nil, errf.Newf(errf.InvalidParameter, "%s does not support collect res metric", vendor)
+	}
+}
+
+// unsupportedGetter 返回云厂商不支持采集该类资源监控数据的错误
+func unsupportedGetter(vendor enumor.Vendor, resType enumor.CloudResourceType) metricGetter {
+	return func(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+		return nil, errf.Newf(errf.InvalidParameter, "%s does not support collect %s metric", vendor, resType)
+	}
+}
+
+// collect 按地域分批查询资源的监控数据并聚合。云厂商不支持的指标跳过，其他查询失败直接返回错误，
+// 避免只写入部分指标的聚合数据
+func (svc *service) collect(kt *kit.Kit, vendor enumor.Vendor, req *hcresmetric.CollectResMetricDailyReq,
+	resType enumor.CloudResourceType, resources []resource, metrics []typemonitor.MetricName, getter metricGetter,
+	start, end time.Time) ([]dsresmetric.ResMetricDailyUpsert, error) {
+
+	regionRes := make(map[string][]resource)
+	for _, one := range resources {
+		regionRes[one.Region] = append(regionRes[one.Region], one)
+	}
+
+	items := make([]dsresmetric.ResMetricDailyUpsert, 0)
+	for region, list := range regionRes {
+		for _, batch := range slice.Split(list, typemonitor.MaxMetricCloudIDs) {
+			cloudIDMap := make(map[string]resource, len(batch))
+			cloudIDs := make([]string, 0, len(batch))
+			for _, one := range batch {
+				cloudIDMap[one.CloudID] = one
+				cloudIDs = append(cloudIDs, one.CloudID)
+			}
+
+			for _, metric := range metrics {
+				opt := &typemonitor.GetMetricOption{
+					Region:     region,
+					MetricName: metric,
+					CloudIDs:   cloudIDs,
+					StartTime:  start,
+					EndTime:    end,
+					Period:     typemonitor.DefaultMetricPeriod,
+				}
+				data, err := getter(kt, opt)
+				if err != nil {
+					if errors.Is(err, typemonitor.ErrMetricNotSupported) {
+						continue
+					}
+					logs.Errorf("get %s metric failed, err: %v, vendor: %s, region: %s, metric: %s, rid: %s",
+						resType, err, vendor, region, metric, kt.Rid)
+					return nil, err
+				}
+
+				for _, one := range data {
+					res, exist := cloudIDMap[one.CloudID]
+					if !exist || len(one.Points) == 0 {
+						continue
+					}
+
+					avg, maxVal, minVal := aggregate(one.Points)
+					items = append(items, dsresmetric.ResMetricDailyUpsert{
+						Vendor:      vendor,
+						AccountID:   req.AccountID,
+						Region:      region,
+						ResType:     resType,
+						ResID:       res.ID,
+						CloudResID:  res.CloudID,
+						Metric:      string(metric),
+						StatDate:    req.StatDate,
+						AvgValue:    avg,
+						MaxValue:    maxVal,
+						MinValue:    minVal,
+						SampleCount: int64(len(one.Points)),
+					})
+				}
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// aggregate 计算监控数据点的平均值、最大值、最小值
+func aggregate(points []typemonitor.MetricPoint) (float64, float64, float64) {
+	var sum float64
+	maxVal, minVal := points[0].Value, points[0].Value
+	for _, point := range points {
+		sum += point.Value
+		if point.Value > maxVal {
+			maxVal = point.Value
+		}
+		if point.Value < minVal {
+			minVal = point.Value
+		}
+	}
+
+	return sum / float64(len(points)), maxVal, minVal
+}
+
+func (svc *service) listCvm(kt *kit.Kit, vendor enumor.Vendor, accountID string) ([]resource, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", vendor),
+			tools.RuleEqual("account_id", accountID),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "cloud_id", "region"},
+	}
+
+	result := make([]resource, 0)
+	for {
+		resp, err := svc.dataCli.Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			result = append(result, resource{ID: one.ID, CloudID: one.CloudID, Region: one.Region})
+		}
+
+		if uint(len(resp.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (svc *service) listLoadBalancer(kt *kit.Kit, vendor enumor.Vendor, accountID string) ([]resource, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", vendor),
+			tools.RuleEqual("account_id", accountID),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "cloud_id", "region"},
+	}
+
+	result := make([]resource, 0)
+	for {
+		resp, err := svc.dataCli.Global.LoadBalancer.ListLoadBalancer(kt, listReq)
+		if err != nil {
+			logs.Errorf("list load balancer failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			result = append(result, resource{ID: one.ID, CloudID: one.CloudID, Region: one.Region})
+		}
+
+		if uint(len(resp.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
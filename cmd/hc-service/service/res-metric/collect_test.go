@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	hcresmetric "hcm/pkg/api/hc-service/res-metric"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestAggregate(t *testing.T) {
+	avg, maxVal, minVal := aggregate([]typemonitor.MetricPoint{{Value: 30}, {Value: 10}, {Value: 20}, {Value: 40}})
+	if avg != 25 || maxVal != 40 || minVal != 10 {
+		t.Errorf("unexpected aggregate result, avg: %v, max: %v, min: %v", avg, maxVal, minVal)
+	}
+
+	avg, maxVal, minVal = aggregate([]typemonitor.MetricPoint{{Value: -1}})
+	if avg != -1 || maxVal != -1 || minVal != -1 {
+		t.Errorf("unexpected aggregate result of one point, avg: %v, max: %v, min: %v", avg, maxVal, minVal)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	resources := []resource{{ID: "00000001", CloudID: "ins-1", Region: "ap-guangzhou"},
+		{ID: "00000002", CloudID: "ins-2", Region: "ap-shanghai"}}
+	// 超过单次查询上限的资源分批查询
+	for i := 3; i <= typemonitor.MaxMetricCloudIDs+2; i++ {
+		resources = append(resources, resource{ID: fmt.Sprintf("%08d", i), CloudID: fmt.Sprintf("ins-%d", i),
+			Region: "ap-guangzhou"})
+	}
+
+	calls := make(map[string]int)
+	getter := func(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+		calls[opt.Region+"/"+string(opt.MetricName)]++
+
+		if len(opt.CloudIDs) > typemonitor.MaxMetricCloudIDs {
+			return nil, fmt.Errorf("too many cloud ids: %d", len(opt.CloudIDs))
+		}
+
+		if opt.MetricName == typemonitor.MemUsage {
+			return nil, typemonitor.ErrMetricNotSupported
+		}
+
+		data := make([]typemonitor.MetricData, 0, len(opt.CloudIDs)+1)
+		for _, id := range opt.CloudIDs {
+			if id == "ins-3" {
+				// 没有数据点的资源不写入
+				data = append(data, typemonitor.MetricData{CloudID: id})
+				continue
+			}
+			data = append(data, typemonitor.MetricData{CloudID: id, Points: []typemonitor.MetricPoint{
+				{Timestamp: opt.StartTime.Unix(), Value: 10}, {Timestamp: opt.StartTime.Unix() + 300, Value: 20}}})
+		}
+		// 不属于查询资源的数据被忽略
+		data = append(data, typemonitor.MetricData{CloudID: "unknown",
+			Points: []typemonitor.MetricPoint{{Value: 1}}})
+		return data, nil
+	}
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	req := &hcresmetric.CollectResMetricDailyReq{AccountID: "account", StatDate: "2024-05-01"}
+	items, err := new(service).collect(kit.New(), enumor.TCloud, req, enumor.CvmCloudResType, resources,
+		typemonitor.CvmMetrics, getter, start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("collect failed, err: %v", err)
+	}
+
+	// 每个地域每个指标按批查询，ap-guangzhou 11个资源分2批
+	cpu := string(typemonitor.CpuUsage)
+	if calls["ap-guangzhou/"+cpu] != 2 || calls["ap-shanghai/"+cpu] != 1 {
+		t.Errorf("unexpected getter calls: %v", calls)
+	}
+
+	// cpu、入流量、出流量各有 len(resources)-1 个资源有数据
+	if len(items) != 3*(len(resources)-1) {
+		t.Fatalf("unexpected item count: %d", len(items))
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ResID != items[j].ResID {
+			return items[i].ResID < items[j].ResID
+		}
+		return items[i].Metric < items[j].Metric
+	})
+	expect := dsresmetric.ResMetricDailyUpsert{
+		Vendor:      enumor.TCloud,
+		AccountID:   "account",
+		Region:      "ap-guangzhou",
+		ResType:     enumor.CvmCloudResType,
+		ResID:       "00000001",
+		CloudResID:  "ins-1",
+		Metric:      string(typemonitor.CpuUsage),
+		StatDate:    "2024-05-01",
+		AvgValue:    15,
+		MaxValue:    20,
+		MinValue:    10,
+		SampleCount: 2,
+	}
+	if items[0] != expect {
+		t.Errorf("unexpected item: %+v", items[0])
+	}
+
+	for _, item := range items {
+		if item.ResID == "00000003" || item.Metric == string(typemonitor.MemUsage) {
+			t.Errorf("unexpected item: %+v", item)
+		}
+	}
+}
+
+func TestCollectGetterFailed(t *testing.T) {
+	resources := []resource{{ID: "00000001", CloudID: "ins-1", Region: "ap-guangzhou"}}
+	getter := func(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+		if opt.MetricName == typemonitor.NetOutRate {
+			return nil, errors.New("throttled")
+		}
+		return []typemonitor.MetricData{{CloudID: "ins-1", Points: []typemonitor.MetricPoint{{Value: 1}}}}, nil
+	}
+
+	// 任一指标查询失败时不返回部分指标的数据，避免写入不完整的聚合结果
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	req := &hcresmetric.CollectResMetricDailyReq{AccountID: "account", StatDate: "2024-05-01"}
+	items, err := new(service).collect(kit.New(), enumor.TCloud, req, enumor.CvmCloudResType, resources,
+		typemonitor.CvmMetrics, getter, start, start.AddDate(0, 0, 1))
+	if err == nil || items != nil {
+		t.Errorf("collect should fail, items: %v, err: %v", items, err)
+	}
+}
+
+func TestUnsupportedGetter(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	req := &hcresmetric.CollectResMetricDailyReq{AccountID: "account", StatDate: "2024-05-01"}
+	getter := unsupportedGetter(enumor.Aws, enumor.LoadBalancerCloudResType)
+
+	// 没有资源时不需要查询监控数据
+	items, err := new(service).collect(kit.New(), enumor.Aws, req, enumor.LoadBalancerCloudResType, nil,
+		typemonitor.LoadBalancerMetrics, getter, start, start.AddDate(0, 0, 1))
+	if err != nil || len(items) != 0 {
+		t.Errorf("collect without resource failed, items: %v, err: %v", items, err)
+	}
+
+	resources := []resource{{ID: "00000001", CloudID: "lb-1", Region: "us-east-1"}}
+	_, err = new(service).collect(kit.New(), enumor.Aws, req, enumor.LoadBalancerCloudResType, resources,
+		typemonitor.LoadBalancerMetrics, getter, start, start.AddDate(0, 0, 1))
+	if err == nil {
+		t.Errorf("collect unsupported load balancer metric should fail")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric 资源监控数据采集
+package resmetric
+
+import (
+	"net/http"
+
+	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	hcresmetric "hcm/pkg/api/hc-service/res-metric"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the res metric service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CollectResMetricDaily", http.MethodPost, "/vendors/{vendor}/res_metrics/daily/collect",
+		svc.CollectResMetricDaily).
+		Reads(new(hcresmetric.CollectResMetricDailyReq)).Writes(new(hcresmetric.CollectResMetricDailyResult))
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	ad      *cloudclient.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
@@ -23,6 +23,7 @@ package routetable
 import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	hcproto "hcm/pkg/api/hc-service/route-table"
 	"hcm/pkg/client"
 	"hcm/pkg/rest"
 )
@@ -36,10 +37,14 @@ func InitRouteTableService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("TCloudRouteTableUpdate", "PATCH", "/vendors/tcloud/route_tables/{id}", r.TCloudRouteTableUpdate)
-	h.Add("AwsRouteTableUpdate", "PATCH", "/vendors/aws/route_tables/{id}", r.AwsRouteTableUpdate)
-	h.Add("HuaWeiRouteTableUpdate", "PATCH", "/vendors/huawei/route_tables/{id}", r.HuaWeiRouteTableUpdate)
-	h.Add("AzureRouteTableUpdate", "PATCH", "/vendors/azure/route_tables/{id}", r.AzureRouteTableUpdate)
+	h.Add("TCloudRouteTableUpdate", "PATCH", "/vendors/tcloud/route_tables/{id}", r.TCloudRouteTableUpdate).
+		Reads(new(hcproto.RouteTableUpdateReq))
+	h.Add("AwsRouteTableUpdate", "PATCH", "/vendors/aws/route_tables/{id}", r.AwsRouteTableUpdate).
+		Reads(new(hcproto.RouteTableUpdateReq))
+	h.Add("HuaWeiRouteTableUpdate", "PATCH", "/vendors/huawei/route_tables/{id}", r.HuaWeiRouteTableUpdate).
+		Reads(new(hcproto.RouteTableUpdateReq))
+	h.Add("AzureRouteTableUpdate", "PATCH", "/vendors/azure/route_tables/{id}", r.AzureRouteTableUpdate).
+		Reads(new(hcproto.RouteTableUpdateReq))
 
 	h.Add("TCloudRouteTableDelete", "DELETE", "/vendors/tcloud/route_tables/{id}", r.TCloudRouteTableDelete)
 	h.Add("AwsRouteTableDelete", "DELETE", "/vendors/aws/route_tables/{id}", r.AwsRouteTableDelete)
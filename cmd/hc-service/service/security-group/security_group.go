@@ -23,6 +23,9 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
 )
@@ -39,59 +42,69 @@ func InitSecurityGroupService(cap *capability.Capability) {
 	tcloudService(h, sg)
 
 	h.Add("AwsSecurityGroupAssociateCvm", "POST", "/vendors/aws/security_groups/associate/cvms",
-		sg.AwsSecurityGroupAssociateCvm)
+		sg.AwsSecurityGroupAssociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
 	h.Add("AwsSecurityGroupDisassociateCvm", "POST", "/vendors/aws/security_groups/disassociate/cvms",
-		sg.AwsSecurityGroupDisassociateCvm)
-	h.Add("CreateAwsSecurityGroup", "POST", "/vendors/aws/security_groups/create", sg.CreateAwsSecurityGroup)
+		sg.AwsSecurityGroupDisassociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
+	h.Add("CreateAwsSecurityGroup", "POST", "/vendors/aws/security_groups/create", sg.CreateAwsSecurityGroup).
+		Reads(new(proto.AwsSecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteAwsSecurityGroup", "DELETE", "/vendors/aws/security_groups/{id}", sg.DeleteAwsSecurityGroup)
 	h.Add("BatchCreateAwsSGRule", "POST", "/vendors/aws/security_groups/{security_group_id}/rules/batch/create",
-		sg.BatchCreateAwsSGRule)
+		sg.BatchCreateAwsSGRule).Reads(new(proto.AwsSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("UpdateAwsSGRule", "PUT", "/vendors/aws/security_groups/{security_group_id}/rules/{id}",
-		sg.UpdateAwsSGRule)
+		sg.UpdateAwsSGRule).Reads(new(proto.AwsSGRuleUpdateReq))
 	h.Add("DeleteAwsSGRule", "DELETE", "/vendors/aws/security_groups/{security_group_id}/rules/{id}",
 		sg.DeleteAwsSGRule)
 	h.Add("AwsListSecurityGroupStatistic", "POST", "/vendors/aws/security_groups/statistic",
-		sg.AwsListSecurityGroupStatistic)
+		sg.AwsListSecurityGroupStatistic).
+		Reads(new(proto.ListSecurityGroupStatisticReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 
 	h.Add("HuaWeiSecurityGroupAssociateCvm", "POST", "/vendors/huawei/security_groups/associate/cvms",
-		sg.HuaWeiSecurityGroupAssociateCvm)
+		sg.HuaWeiSecurityGroupAssociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
 	h.Add("HuaWeiSecurityGroupDisassociateCvm", "POST", "/vendors/huawei/security_groups/disassociate/cvms",
-		sg.HuaWeiSecurityGroupDisassociateCvm)
-	h.Add("CreateHuaWeiSecurityGroup", "POST", "/vendors/huawei/security_groups/create", sg.CreateHuaWeiSecurityGroup)
+		sg.HuaWeiSecurityGroupDisassociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
+	h.Add("CreateHuaWeiSecurityGroup", "POST", "/vendors/huawei/security_groups/create", sg.CreateHuaWeiSecurityGroup).
+		Reads(new(proto.HuaWeiSecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteHuaWeiSecurityGroup", "DELETE", "/vendors/huawei/security_groups/{id}", sg.DeleteHuaWeiSecurityGroup)
-	h.Add("UpdateHuaWeiSecurityGroup", "PATCH", "/vendors/huawei/security_groups/{id}", sg.UpdateHuaWeiSecurityGroup)
+	h.Add("UpdateHuaWeiSecurityGroup", "PATCH", "/vendors/huawei/security_groups/{id}", sg.UpdateHuaWeiSecurityGroup).
+		Reads(new(proto.SecurityGroupUpdateReq))
 	h.Add("CreateHuaWeiSGRule", "POST", "/vendors/huawei/security_groups/{security_group_id}/rules/create",
-		sg.CreateHuaWeiSGRule)
+		sg.CreateHuaWeiSGRule).Reads(new(proto.HuaWeiSGRuleCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteHuaWeiSGRule", "DELETE", "/vendors/huawei/security_groups/{security_group_id}/rules/{id}",
 		sg.DeleteHuaWeiSGRule)
 	h.Add("HuaweiListSecurityGroupStatistic", "POST", "/vendors/huawei/security_groups/statistic",
-		sg.HuaweiListSecurityGroupStatistic)
+		sg.HuaweiListSecurityGroupStatistic).
+		Reads(new(proto.ListSecurityGroupStatisticReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 
 	h.Add("AzureSecurityGroupAssociateSubnet", "POST", "/vendors/azure/security_groups/associate/subnets",
-		sg.AzureSecurityGroupAssociateSubnet)
+		sg.AzureSecurityGroupAssociateSubnet).Reads(new(proto.AzureSecurityGroupAssociateSubnetReq))
 	h.Add("AzureSecurityGroupAssociateNI", "POST", "/vendors/azure/security_groups/associate/network_interfaces",
-		sg.AzureSecurityGroupAssociateNI)
+		sg.AzureSecurityGroupAssociateNI).Reads(new(proto.AzureSecurityGroupAssociateNIReq))
 	h.Add("AzureSecurityGroupDisassociateSubnet", "POST", "/vendors/azure/security_groups/disassociate/subnets",
-		sg.AzureSGDisassociateSubnet)
+		sg.AzureSGDisassociateSubnet).Reads(new(proto.AzureSecurityGroupAssociateSubnetReq))
 	h.Add("AzureSecurityGroupDisassociateNI", "POST", "/vendors/azure/security_groups/disassociate/network_interfaces",
-		sg.AzureSecurityGroupDisassociateNI)
-	h.Add("CreateAzureSecurityGroup", "POST", "/vendors/azure/security_groups/create", sg.CreateAzureSecurityGroup)
+		sg.AzureSecurityGroupDisassociateNI).Reads(new(proto.AzureSecurityGroupAssociateNIReq))
+	h.Add("CreateAzureSecurityGroup", "POST", "/vendors/azure/security_groups/create", sg.CreateAzureSecurityGroup).
+		Reads(new(proto.AzureSecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteAzureSecurityGroup", "DELETE", "/vendors/azure/security_groups/{id}", sg.DeleteAzureSecurityGroup)
-	h.Add("UpdateAzureSecurityGroup", "PATCH", "/vendors/azure/security_groups/{id}", sg.UpdateAzureSecurityGroup)
+	h.Add("UpdateAzureSecurityGroup", "PATCH", "/vendors/azure/security_groups/{id}", sg.UpdateAzureSecurityGroup).
+		Reads(new(proto.AzureSecurityGroupUpdateReq))
 	h.Add("BatchCreateAzureSGRule", "POST", "/vendors/azure/security_groups/{security_group_id}/rules/batch/create",
-		sg.BatchCreateAzureSGRule)
+		sg.BatchCreateAzureSGRule).Reads(new(proto.AzureSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("UpdateAzureSGRule", "PUT", "/vendors/azure/security_groups/{security_group_id}/rules/{id}",
-		sg.UpdateAzureSGRule)
+		sg.UpdateAzureSGRule).Reads(new(proto.AzureSGRuleUpdateReq))
 	h.Add("DeleteAzureSGRule", "DELETE", "/vendors/azure/security_groups/{security_group_id}/rules/{id}",
 		sg.DeleteAzureSGRule)
 	h.Add("AzureListSecurityGroupStatistic", "POST", "/vendors/azure/security_groups/statistic",
-		sg.AzureListSecurityGroupStatistic)
+		sg.AzureListSecurityGroupStatistic).
+		Reads(new(proto.ListSecurityGroupStatisticReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 
 	// CLB负载均衡
 	h.Add("TCloudSGAssociateLoadBalancer", "POST",
-		"/vendors/tcloud/security_groups/associate/load_balancers", sg.TCloudSGAssociateLoadBalancer)
+		"/vendors/tcloud/security_groups/associate/load_balancers", sg.TCloudSGAssociateLoadBalancer).
+		Reads(new(hclb.TCloudSetLbSecurityGroupReq))
 	h.Add("TCloudSGDisassociateLoadBalancer", "POST",
-		"/vendors/tcloud/security_groups/disassociate/load_balancers", sg.TCloudSGDisassociateLoadBalancer)
+		"/vendors/tcloud/security_groups/disassociate/load_balancers", sg.TCloudSGDisassociateLoadBalancer).
+		Reads(new(hclb.TCloudDisAssociateLbSecurityGroupReq))
 
 	initSecurityGroupServiceHooks(sg, h)
 
@@ -100,28 +113,33 @@ func InitSecurityGroupService(cap *capability.Capability) {
 
 func tcloudService(h *rest.Handler, sg *securityGroup) {
 	h.Add("TCloudSecurityGroupAssociateCvm", "POST", "/vendors/tcloud/security_groups/associate/cvms",
-		sg.TCloudSecurityGroupAssociateCvm)
+		sg.TCloudSecurityGroupAssociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
 	h.Add("TCloudSecurityGroupDisassociateCvm", "POST", "/vendors/tcloud/security_groups/disassociate/cvms",
-		sg.TCloudSecurityGroupDisassociateCvm)
-	h.Add("CreateTCloudSecurityGroup", "POST", "/vendors/tcloud/security_groups/create", sg.CreateTCloudSecurityGroup)
+		sg.TCloudSecurityGroupDisassociateCvm).Reads(new(proto.SecurityGroupAssociateCvmReq))
+	h.Add("CreateTCloudSecurityGroup", "POST", "/vendors/tcloud/security_groups/create", sg.CreateTCloudSecurityGroup).
+		Reads(new(proto.TCloudSecurityGroupCreateReq)).Writes(new(core.CreateResult))
 	h.Add("DeleteTCloudSecurityGroup", "DELETE", "/vendors/tcloud/security_groups/{id}", sg.DeleteTCloudSecurityGroup)
-	h.Add("UpdateTCloudSecurityGroup", "PATCH", "/vendors/tcloud/security_groups/{id}", sg.UpdateTCloudSecurityGroup)
+	h.Add("UpdateTCloudSecurityGroup", "PATCH", "/vendors/tcloud/security_groups/{id}", sg.UpdateTCloudSecurityGroup).
+		Reads(new(proto.SecurityGroupUpdateReq))
 	h.Add("BatchCreateTCloudSGRule", "POST", "/vendors/tcloud/security_groups/{security_group_id}/rules/batch/create",
-		sg.BatchCreateTCloudSGRule)
+		sg.BatchCreateTCloudSGRule).Reads(new(proto.TCloudSGRuleCreateReq)).Writes(new(core.BatchCreateResult))
 	h.Add("UpdateTCloudSGRule", "PUT", "/vendors/tcloud/security_groups/{security_group_id}/rules/{id}",
-		sg.UpdateTCloudSGRule)
+		sg.UpdateTCloudSGRule).Reads(new(proto.TCloudSGRuleUpdateReq))
 	h.Add("BatchUpdateTCloudSGRule", "PUT", "/vendors/tcloud/security_groups/{security_group_id}/rules/batch/update",
-		sg.BatchUpdateTCloudSGRule)
+		sg.BatchUpdateTCloudSGRule).Reads(new(proto.TCloudSGRuleBatchUpdateReq))
 	h.Add("DeleteTCloudSGRule", "DELETE", "/vendors/tcloud/security_groups/{security_group_id}/rules/{id}",
 		sg.DeleteTCloudSGRule)
 	h.Add("TCloudSGBatchAssociateCloudCvm", "POST",
-		"/vendors/tcloud/security_groups/associate/cvms/batch", sg.TCloudSGBatchAssociateCvm)
+		"/vendors/tcloud/security_groups/associate/cvms/batch", sg.TCloudSGBatchAssociateCvm).
+		Reads(new(proto.SecurityGroupBatchAssociateCvmReq))
 	h.Add("TCloudSGBatchDisassociateCloudCvm", "POST",
-		"/vendors/tcloud/security_groups/disassociate/cvms/batch", sg.TCloudSGBatchDisassociateCvm)
+		"/vendors/tcloud/security_groups/disassociate/cvms/batch", sg.TCloudSGBatchDisassociateCvm).
+		Reads(new(proto.SecurityGroupBatchAssociateCvmReq))
 	h.Add("TCloudListSecurityGroupStatistic", "POST", "/vendors/tcloud/security_groups/statistic",
-		sg.TCloudListSecurityGroupStatistic)
+		sg.TCloudListSecurityGroupStatistic).
+		Reads(new(proto.ListSecurityGroupStatisticReq)).Writes(new(proto.ListSecurityGroupStatisticResp))
 	h.Add("TCloudCloneSecurityGroup", "POST", "/vendors/tcloud/security_groups/clone",
-		sg.TCloudCloneSecurityGroup)
+		sg.TCloudCloneSecurityGroup).Reads(new(proto.TCloudSecurityGroupCloneReq)).Writes(new(core.CreateResult))
 }
 
 type securityGroup struct {
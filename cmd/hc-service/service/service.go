@@ -39,12 +39,14 @@ import (
 	"hcm/cmd/hc-service/service/cos"
 	"hcm/cmd/hc-service/service/cvm"
 	"hcm/cmd/hc-service/service/disk"
+	disksnapshot "hcm/cmd/hc-service/service/disk-snapshot"
 	"hcm/cmd/hc-service/service/eip"
 	"hcm/cmd/hc-service/service/firewall"
 	"hcm/cmd/hc-service/service/image"
 	instancetype "hcm/cmd/hc-service/service/instance-type"
 	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
 	mainaccount "hcm/cmd/hc-service/service/main-account"
+	resmetric "hcm/cmd/hc-service/service/res-metric"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
 	"hcm/cmd/hc-service/service/subnet"
@@ -59,6 +61,7 @@ import (
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
+	restidem "hcm/pkg/rest/idempotency"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/cmdb"
@@ -95,6 +98,10 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
+	if conf := cc.HCService().Idempotency; conf.Enable {
+		rest.SetIdempotencyStore(restidem.NewStore(conf, cliSet.DataService().Global.Idempotency))
+	}
+
 	svr := &Service{
 		clientSet:    cliSet,
 		cloudAdaptor: cloudAdaptor,
@@ -178,6 +185,7 @@ func (s *Service) apiSet() *restful.Container {
 	vpc.InitVpcService(c)
 	subnet.InitSubnetService(c)
 	disk.InitDiskService(c)
+	disksnapshot.InitDiskSnapshotService(c)
 	cvm.InitCvmService(c)
 	routetable.InitRouteTableService(c)
 	eip.InitEipService(c)
@@ -192,6 +200,7 @@ func (s *Service) apiSet() *restful.Container {
 	image.InitImageService(c)
 	tag.InitTagService(c)
 	cos.InitCosService(c)
+	resmetric.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
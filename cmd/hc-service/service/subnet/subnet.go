@@ -33,6 +33,7 @@ import (
 	"hcm/cmd/hc-service/service/capability"
 	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	proto "hcm/pkg/api/hc-service/subnet"
 	"hcm/pkg/client"
 	dataclient "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
@@ -54,17 +55,25 @@ func InitSubnetService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("TCloudSubnetBatchCreate", "POST", "/vendors/tcloud/subnets/batch/create", s.TCloudSubnetBatchCreate)
-	h.Add("AwsSubnetCreate", "POST", "/vendors/aws/subnets/create", s.AwsSubnetCreate)
-	h.Add("HuaWeiSubnetCreate", "POST", "/vendors/huawei/subnets/create", s.HuaWeiSubnetCreate)
-	h.Add("GcpSubnetCreate", "POST", "/vendors/gcp/subnets/create", s.GcpSubnetCreate)
-	h.Add("AzureSubnetCreate", "POST", "/vendors/azure/subnets/create", s.AzureSubnetCreate)
-
-	h.Add("TCloudSubnetUpdate", "PATCH", "/vendors/tcloud/subnets/{id}", s.TCloudSubnetUpdate)
-	h.Add("AwsSubnetUpdate", "PATCH", "/vendors/aws/subnets/{id}", s.AwsSubnetUpdate)
-	h.Add("HuaWeiSubnetUpdate", "PATCH", "/vendors/huawei/subnets/{id}", s.HuaWeiSubnetUpdate)
-	h.Add("GcpSubnetUpdate", "PATCH", "/vendors/gcp/subnets/{id}", s.GcpSubnetUpdate)
-	h.Add("AzureSubnetUpdate", "PATCH", "/vendors/azure/subnets/{id}", s.AzureSubnetUpdate)
+	h.Add("TCloudSubnetBatchCreate", "POST", "/vendors/tcloud/subnets/batch/create", s.TCloudSubnetBatchCreate).
+		Reads(new(proto.TCloudSubnetBatchCreateReq)).Writes(new(core.BatchCreateResult))
+	h.Add("AwsSubnetCreate", "POST", "/vendors/aws/subnets/create", s.AwsSubnetCreate).
+		Reads(new(proto.SubnetCreateReq[proto.AwsSubnetCreateExt])).Writes(new(core.CreateResult))
+	h.Add("HuaWeiSubnetCreate", "POST", "/vendors/huawei/subnets/create", s.HuaWeiSubnetCreate).
+		Reads(new(proto.SubnetCreateReq[proto.HuaWeiSubnetCreateExt])).Writes(new(core.CreateResult))
+	h.Add("GcpSubnetCreate", "POST", "/vendors/gcp/subnets/create", s.GcpSubnetCreate).
+		Reads(new(proto.SubnetCreateReq[proto.GcpSubnetCreateExt])).Writes(new(core.CreateResult))
+	h.Add("AzureSubnetCreate", "POST", "/vendors/azure/subnets/create", s.AzureSubnetCreate).
+		Reads(new(proto.SubnetCreateReq[proto.AzureSubnetCreateExt])).Writes(new(core.CreateResult))
+
+	h.Add("TCloudSubnetUpdate", "PATCH", "/vendors/tcloud/subnets/{id}", s.TCloudSubnetUpdate).
+		Reads(new(proto.SubnetUpdateReq))
+	h.Add("AwsSubnetUpdate", "PATCH", "/vendors/aws/subnets/{id}", s.AwsSubnetUpdate).Reads(new(proto.SubnetUpdateReq))
+	h.Add("HuaWeiSubnetUpdate", "PATCH", "/vendors/huawei/subnets/{id}", s.HuaWeiSubnetUpdate).
+		Reads(new(proto.SubnetUpdateReq))
+	h.Add("GcpSubnetUpdate", "PATCH", "/vendors/gcp/subnets/{id}", s.GcpSubnetUpdate).Reads(new(proto.SubnetUpdateReq))
+	h.Add("AzureSubnetUpdate", "PATCH", "/vendors/azure/subnets/{id}", s.AzureSubnetUpdate).
+		Reads(new(proto.SubnetUpdateReq))
 
 	h.Add("TCloudSubnetDelete", "DELETE", "/vendors/tcloud/subnets/{id}", s.TCloudSubnetDelete)
 	h.Add("AwsSubnetDelete", "DELETE", "/vendors/aws/subnets/{id}", s.AwsSubnetDelete)
@@ -73,11 +82,16 @@ func InitSubnetService(cap *capability.Capability) {
 	h.Add("AzureSubnetDelete", "DELETE", "/vendors/azure/subnets/{id}", s.AzureSubnetDelete)
 
 	// count subnet available ips
-	h.Add("TCloudListSubnetCountIP", "POST", "/vendors/tcloud/subnets/ips/count/list", s.TCloudListSubnetCountIP)
-	h.Add("AwsListSubnetCountIP", "POST", "/vendors/aws/subnets/ips/count/list", s.AwsListSubnetCountIP)
-	h.Add("AzureListSubnetCountIP", "POST", "/vendors/azure/subnets/ips/count/list", s.AzureListSubnetCountIP)
-	h.Add("HuaWeiSubnetCountIP", "POST", "/vendors/huawei/subnets/{id}/ips/count", s.HuaWeiSubnetCountIP)
-	h.Add("GcpSubnetCountIP", "POST", "/vendors/gcp/subnets/ips/count/list", s.GcpSubnetCountIP)
+	h.Add("TCloudListSubnetCountIP", "POST", "/vendors/tcloud/subnets/ips/count/list", s.TCloudListSubnetCountIP).
+		Reads(new(proto.ListCountIPReq)).Writes(new(map[string]proto.AvailIPResult))
+	h.Add("AwsListSubnetCountIP", "POST", "/vendors/aws/subnets/ips/count/list", s.AwsListSubnetCountIP).
+		Reads(new(proto.ListCountIPReq)).Writes(new(map[string]proto.AvailIPResult))
+	h.Add("AzureListSubnetCountIP", "POST", "/vendors/azure/subnets/ips/count/list", s.AzureListSubnetCountIP).
+		Reads(new(proto.ListAzureCountIPReq)).Writes(new(map[string]proto.AvailIPResult))
+	h.Add("HuaWeiSubnetCountIP", "POST", "/vendors/huawei/subnets/{id}/ips/count", s.HuaWeiSubnetCountIP).
+		Writes(new(proto.AvailIPResult))
+	h.Add("GcpSubnetCountIP", "POST", "/vendors/gcp/subnets/ips/count/list", s.GcpSubnetCountIP).
+		Reads(new(proto.ListCountIPReq)).Writes(new(map[string]proto.AvailIPResult))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -46,13 +47,14 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
-	h.Add("SyncSecurityGroupUsageBiz", "POST", "/security_groups/usage_biz_rels/sync", v.SyncSecurityGroupUsageBiz)
+	h.Add("SyncSecurityGroupUsageBiz", "POST", "/security_groups/usage_biz_rels/sync", v.SyncSecurityGroupUsageBiz).
+		Reads(new(sync.AzureSyncReq))
 	h.Add("SyncNetworkInterface", "POST", "/network_interfaces/sync", v.SyncNetworkInterface)
 	h.Add("SyncRoute", "POST", "/route_tables/sync", v.SyncRouteTable)
-	h.Add("SyncResourceGroup", "POST", "/resource_groups/sync", v.SyncResourceGroup)
-	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
-	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
-	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount)
+	h.Add("SyncResourceGroup", "POST", "/resource_groups/sync", v.SyncResourceGroup).Reads(new(sync.AzureGlobalSyncReq))
+	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion).Reads(new(sync.AzureGlobalSyncReq))
+	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage).Reads(new(sync.AzureImageReq))
+	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount).Reads(new(sync.AzureGlobalSyncReq))
 
 	h.Load(cap.WebService)
 }
@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -47,10 +48,10 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
 	h.Add("SyncRoute", "POST", "/routes/sync", v.SyncRoute)
-	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone)
+	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone).Reads(new(sync.GcpGlobalSyncReq))
 	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
-	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount)
+	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount).Reads(new(sync.GcpGlobalSyncReq))
 
 	h.Load(cap.WebService)
 }
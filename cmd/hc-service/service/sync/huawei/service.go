@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -44,14 +45,15 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncSubnet", "POST", "/subnets/sync", v.SyncSubnet)
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
-	h.Add("SyncSecurityGroupUsageBiz", "POST", "/security_groups/usage_biz_rels/sync", v.SyncSecurityGroupUsageBiz)
+	h.Add("SyncSecurityGroupUsageBiz", "POST", "/security_groups/usage_biz_rels/sync", v.SyncSecurityGroupUsageBiz).
+		Reads(new(sync.HuaWeiSyncReq))
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
 	h.Add("SyncRoute", "POST", "/route_tables/sync", v.SyncRouteTable)
-	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone)
-	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
+	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone).Reads(new(sync.HuaWeiSyncReq))
+	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion).Reads(new(sync.HuaWeiGlobalSyncReq))
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
-	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount)
+	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount).Reads(new(sync.HuaWeiGlobalSyncReq))
 
 	h.Load(cap.WebService)
 }
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/openstack"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	typeeip "hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// SyncEip ....
+func (svc *service) SyncEip(cts *rest.Contexts) (interface{}, error) {
+	return nil, handler.ResourceSync(cts, newResHandler(svc.syncCli, eipSyncer))
+}
+
+var eipSyncer = resSyncer[*typeeip.OpenStackEip]{
+	resType: enumor.EipCloudResType,
+	list: func(kt *kit.Kit, cli openstack.Interface, opt typecore.OpenStackListOption) (
+		[]*typeeip.OpenStackEip, error) {
+
+		listOpt := &typeeip.OpenStackEipListOption{OpenStackListOption: opt}
+		result, err := cli.CloudCli().ListEip(kt, listOpt)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	},
+	cloudID: func(one *typeeip.OpenStackEip) string {
+		return one.ID
+	},
+	sync: func(kt *kit.Kit, cli openstack.Interface, params *openstack.SyncBaseParams) error {
+		_, err := cli.Eip(kt, params, new(openstack.SyncEipOption))
+		return err
+	},
+	removeDeleteFromCloud: openstack.Interface.RemoveEipDeleteFromCloud,
+}
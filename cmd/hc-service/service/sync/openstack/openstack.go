@@ -0,0 +1,171 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack ...
+package openstack
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/openstack"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// defaultPrepare 解析同步请求并构建同步客户端，openstack 账号只纳管一个区域，区域由账号信息确定
+func defaultPrepare(cts *rest.Contexts, cli ressync.Interface) (*sync.OpenStackSyncReq, openstack.Interface,
+	string, error) {
+
+	req := new(sync.OpenStackSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, nil, "", errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	syncCli, err := cli.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	region, err := syncCli.CloudCli().Region(cts.Kit)
+	if err != nil {
+		logs.Errorf("get openstack account region failed, err: %v, account: %s, rid: %s", err, req.AccountID,
+			cts.Kit.Rid)
+		return nil, nil, "", err
+	}
+
+	return req, syncCli, region, nil
+}
+
+// resSyncer 资源相关的同步实现，各资源只需定义云上资源的查询、同步及清理方式，同步流程由 resHandler 统一实现
+type resSyncer[T any] struct {
+	resType enumor.CloudResourceType
+	// list 按分页条件查询云上资源
+	list func(kt *kit.Kit, cli openstack.Interface, opt typecore.OpenStackListOption) ([]T, error)
+	// cloudID 返回云上资源的云ID，同时作为下一页查询的 marker
+	cloudID func(one T) string
+	// sync 同步指定云ID的资源
+	sync func(kt *kit.Kit, cli openstack.Interface, params *openstack.SyncBaseParams) error
+	// removeDeleteFromCloud 删除云上已删除的资源
+	removeDeleteFromCloud func(cli openstack.Interface, kt *kit.Kit, accountID string, region string) error
+}
+
+// newResHandler 基于资源的同步实现构建同步handler
+func newResHandler[T any](cli ressync.Interface, syncer resSyncer[T]) *resHandler[T] {
+	return &resHandler[T]{cli: cli, syncer: syncer}
+}
+
+// resHandler openstack 资源同步handler，按 marker 分页查询云上资源
+type resHandler[T any] struct {
+	cli    ressync.Interface
+	syncer resSyncer[T]
+
+	// Prepare 构建参数
+	request *sync.OpenStackSyncReq
+	syncCli openstack.Interface
+	region  string
+	// marker 上一页最后一个资源的ID，用于分页查询
+	marker string
+}
+
+// Prepare ...
+func (hd *resHandler[T]) Prepare(cts *rest.Contexts) error {
+	request, syncCli, region, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+	hd.region = region
+
+	return nil
+}
+
+// Next ...
+func (hd *resHandler[T]) Next(kt *kit.Kit) ([]string, error) {
+	listOpt := typecore.OpenStackListOption{
+		Page: &typecore.OpenStackPage{
+			Limit:  constant.CloudResourceSyncMaxLimit,
+			Marker: hd.marker,
+		},
+	}
+
+	result, err := hd.syncer.list(kt, hd.syncCli, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list openstack %s failed, err: %v, opt: %v, rid: %s", hd.syncer.resType, err,
+			listOpt, kt.Rid)
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(result))
+	for _, one := range result {
+		cloudIDs = append(cloudIDs, hd.syncer.cloudID(one))
+	}
+	hd.marker = cloudIDs[len(cloudIDs)-1]
+
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *resHandler[T]) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &openstack.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.region,
+		CloudIDs:  cloudIDs,
+	}
+	if err := hd.syncer.sync(kt, hd.syncCli, params); err != nil {
+		logs.Errorf("sync openstack %s failed, err: %v, opt: %v, rid: %s", hd.syncer.resType, err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *resHandler[T]) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncer.removeDeleteFromCloud(hd.syncCli, kt, hd.request.AccountID, hd.region)
+	if err != nil {
+		logs.Errorf("remove %s delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			hd.syncer.resType, err, hd.request.AccountID, hd.region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *resHandler[T]) Name() enumor.CloudResourceType {
+	return hd.syncer.resType
+}
+
+var _ handler.Handler = new(resHandler[any])
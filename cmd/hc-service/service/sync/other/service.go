@@ -24,6 +24,7 @@ import (
 	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
@@ -43,8 +44,9 @@ func InitService(cap *capability.Capability) {
 
 	h.Add("SyncHostWithRelRes", "POST", "/hosts/with/relation_resources/sync", v.SyncHostWithRelRes)
 	h.Add("SyncHostWithRelResByCond", "POST", "/hosts/with/relation_resources/by_condition/sync",
-		v.SyncHostWithRelResByCond)
-	h.Add("DeleteHost", "DELETE", "/hosts/by_condition/delete", v.DeleteHostByCond)
+		v.SyncHostWithRelResByCond).Reads(new(sync.OtherSyncHostByCondReq))
+	h.Add("DeleteHost", "DELETE", "/hosts/by_condition/delete", v.DeleteHostByCond).
+		Reads(new(sync.OtherDelHostByCondReq))
 
 	h.Load(cap.WebService)
 }
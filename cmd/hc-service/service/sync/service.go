@@ -21,10 +21,12 @@ package sync
 
 import (
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/cmd/hc-service/service/sync/aliyun"
 	"hcm/cmd/hc-service/service/sync/aws"
 	"hcm/cmd/hc-service/service/sync/azure"
 	"hcm/cmd/hc-service/service/sync/gcp"
 	"hcm/cmd/hc-service/service/sync/huawei"
+	"hcm/cmd/hc-service/service/sync/openstack"
 	"hcm/cmd/hc-service/service/sync/other"
 	"hcm/cmd/hc-service/service/sync/tcloud"
 )
@@ -36,5 +38,7 @@ func InitService(cap *capability.Capability) {
 	gcp.InitService(cap)
 	huawei.InitService(cap)
 	azure.InitService(cap)
+	aliyun.InitService(cap)
+	openstack.InitService(cap)
 	other.InitService(cap)
 }
@@ -42,7 +42,8 @@ func InitTagService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("TCloudBatchTagRes", "POST", "/vendors/tcloud/tags/tag_resources/batch", v.TCloudBatchTagRes)
+	h.Add("TCloudBatchTagRes", "POST", "/vendors/tcloud/tags/tag_resources/batch", v.TCloudBatchTagRes).
+		Reads(new(apitag.TCloudBatchTagResRequest)).Writes(new(typestag.TCloudTagResourcesResp))
 
 	h.Load(cap.WebService)
 }
@@ -25,6 +25,8 @@ import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/logics/subnet"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	hcservice "hcm/pkg/api/hc-service/vpc"
 	"hcm/pkg/client"
 	"hcm/pkg/rest"
 )
@@ -40,17 +42,22 @@ func InitVpcService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("TCloudVpcCreate", "POST", "/vendors/tcloud/vpcs/create", v.TCloudVpcCreate)
-	h.Add("AwsVpcCreate", "POST", "/vendors/aws/vpcs/create", v.AwsVpcCreate)
-	h.Add("HuaWeiVpcCreate", "POST", "/vendors/huawei/vpcs/create", v.HuaWeiVpcCreate)
-	h.Add("GcpVpcCreate", "POST", "/vendors/gcp/vpcs/create", v.GcpVpcCreate)
-	h.Add("AzureVpcCreate", "POST", "/vendors/azure/vpcs/create", v.AzureVpcCreate)
+	h.Add("TCloudVpcCreate", "POST", "/vendors/tcloud/vpcs/create", v.TCloudVpcCreate).
+		Reads(new(hcservice.VpcCreateReq[hcservice.TCloudVpcCreateExt])).Writes(new(core.CreateResult))
+	h.Add("AwsVpcCreate", "POST", "/vendors/aws/vpcs/create", v.AwsVpcCreate).
+		Reads(new(hcservice.VpcCreateReq[hcservice.AwsVpcCreateExt])).Writes(new(core.CreateResult))
+	h.Add("HuaWeiVpcCreate", "POST", "/vendors/huawei/vpcs/create", v.HuaWeiVpcCreate).
+		Reads(new(hcservice.VpcCreateReq[hcservice.HuaWeiVpcCreateExt])).Writes(new(core.CreateResult))
+	h.Add("GcpVpcCreate", "POST", "/vendors/gcp/vpcs/create", v.GcpVpcCreate).
+		Reads(new(hcservice.VpcCreateReq[hcservice.GcpVpcCreateExt])).Writes(new(core.CreateResult))
+	h.Add("AzureVpcCreate", "POST", "/vendors/azure/vpcs/create", v.AzureVpcCreate).
+		Reads(new(hcservice.VpcCreateReq[hcservice.AzureVpcCreateExt])).Writes(new(core.CreateResult))
 
-	h.Add("TCloudVpcUpdate", "PATCH", "/vendors/tcloud/vpcs/{id}", v.TCloudVpcUpdate)
-	h.Add("AwsVpcUpdate", "PATCH", "/vendors/aws/vpcs/{id}", v.AwsVpcUpdate)
-	h.Add("HuaWeiVpcUpdate", "PATCH", "/vendors/huawei/vpcs/{id}", v.HuaWeiVpcUpdate)
-	h.Add("GcpVpcUpdate", "PATCH", "/vendors/gcp/vpcs/{id}", v.GcpVpcUpdate)
-	h.Add("AzureVpcUpdate", "PATCH", "/vendors/azure/vpcs/{id}", v.AzureVpcUpdate)
+	h.Add("TCloudVpcUpdate", "PATCH", "/vendors/tcloud/vpcs/{id}", v.TCloudVpcUpdate).Reads(new(hcservice.VpcUpdateReq))
+	h.Add("AwsVpcUpdate", "PATCH", "/vendors/aws/vpcs/{id}", v.AwsVpcUpdate).Reads(new(hcservice.VpcUpdateReq))
+	h.Add("HuaWeiVpcUpdate", "PATCH", "/vendors/huawei/vpcs/{id}", v.HuaWeiVpcUpdate).Reads(new(hcservice.VpcUpdateReq))
+	h.Add("GcpVpcUpdate", "PATCH", "/vendors/gcp/vpcs/{id}", v.GcpVpcUpdate).Reads(new(hcservice.VpcUpdateReq))
+	h.Add("AzureVpcUpdate", "PATCH", "/vendors/azure/vpcs/{id}", v.AzureVpcUpdate).Reads(new(hcservice.VpcUpdateReq))
 
 	h.Add("TCloudVpcDelete", "DELETE", "/vendors/tcloud/vpcs/{id}", v.TCloudVpcDelete)
 	h.Add("AwsVpcDelete", "DELETE", "/vendors/aws/vpcs/{id}", v.AwsVpcDelete)
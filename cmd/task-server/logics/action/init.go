@@ -31,6 +31,7 @@ import (
 	actioneip "hcm/cmd/task-server/logics/action/eip"
 	actionfirewall "hcm/cmd/task-server/logics/action/firewall"
 	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
+	actionresspec "hcm/cmd/task-server/logics/action/res-spec"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
 	actionflow "hcm/cmd/task-server/logics/flow"
@@ -91,4 +92,6 @@ func register() {
 
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerAction{})
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerListenerAction{})
+
+	action.RegisterAction(actionresspec.ApplyResSpecAction{})
 }
@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionresspec 声明式资源描述执行计划相关异步任务
+package actionresspec
+
+import (
+	"errors"
+	"fmt"
+
+	resspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+var _ action.Action = new(ApplyResSpecAction)
+var _ action.ParameterAction = new(ApplyResSpecAction)
+
+// ApplyResSpecAction 执行执行计划中的一个资源变更，任务流中的资源按 删除主机 -> 删除子网、安全组 -> 删除VPC ->
+// 创建、更新VPC -> 创建、更新子网、安全组 -> 创建主机 的顺序执行，后序任务通过共享数据获取前序任务创建的资源ID。
+type ApplyResSpecAction struct{}
+
+// ApplyOption define apply res spec change option.
+type ApplyOption struct {
+	AccountID string         `json:"account_id" validate:"required"`
+	Region    string         `json:"region" validate:"required"`
+	Change    resspec.Change `json:"change"`
+
+	// VpcName 子网所属VPC名称，仅子网变更需要
+	VpcName       string                     `json:"vpc_name,omitempty"`
+	Vpc           *resspec.VpcSpec           `json:"vpc,omitempty"`
+	Subnet        *resspec.SubnetSpec        `json:"subnet,omitempty"`
+	SecurityGroup *resspec.SecurityGroupSpec `json:"security_group,omitempty"`
+	Cvm           *resspec.CvmSpec           `json:"cvm,omitempty"`
+
+	// CreateIngressRules、CreateEgressRules、DeleteRuleIDs 安全组需要追加和删除的规则
+	CreateIngressRules []resspec.SGRuleSpec `json:"create_ingress_rules,omitempty"`
+	CreateEgressRules  []resspec.SGRuleSpec `json:"create_egress_rules,omitempty"`
+	DeleteRuleIDs      []string             `json:"delete_rule_ids,omitempty"`
+}
+
+// Validate ApplyOption.
+func (opt ApplyOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.Change.ResType) == 0 || len(opt.Change.Ref) == 0 {
+		return errors.New("change res_type and ref are required")
+	}
+
+	if opt.Change.Action != resspec.ChangeDelete {
+		var spec interface{}
+		switch opt.Change.ResType {
+		case enumor.VpcCloudResType:
+			spec = opt.Vpc
+		case enumor.SubnetCloudResType:
+			spec = opt.Subnet
+		case enumor.SecurityGroupCloudResType:
+			spec = opt.SecurityGroup
+		case enumor.CvmCloudResType:
+			spec = opt.Cvm
+		}
+		if spec == nil {
+			return fmt.Errorf("%s %s spec is required", opt.Change.ResType, opt.Change.Ref)
+		}
+	}
+
+	return nil
+}
+
+// ParameterNew return request params.
+func (act ApplyResSpecAction) ParameterNew() (params interface{}) {
+	return new(ApplyOption)
+}
+
+// Name return action name.
+func (act ApplyResSpecAction) Name() enumor.ActionName {
+	return enumor.ActionApplyResSpec
+}
+
+// Run apply res spec change.
+func (act ApplyResSpecAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*ApplyOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var err error
+	switch opt.Change.ResType {
+	case enumor.VpcCloudResType:
+		err = applyVpc(kt, opt)
+	case enumor.SubnetCloudResType:
+		err = applySubnet(kt, opt)
+	case enumor.SecurityGroupCloudResType:
+		err = applySecurityGroup(kt, opt)
+	case enumor.CvmCloudResType:
+		err = applyCvm(kt, opt)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "res type: %s not support", opt.Change.ResType)
+	}
+	if err != nil {
+		logs.Errorf("apply %s %s %s failed, err: %v, rid: %s", opt.Change.Action, opt.Change.ResType,
+			opt.Change.Ref, err, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RefKey 资源引用在任务流共享数据中的key
+func RefKey(resType enumor.CloudResourceType, ref string) string {
+	return fmt.Sprintf("%s:%s", resType, ref)
+}
+
+// RefValue 资源引用在任务流共享数据中的值，已存在的资源在创建任务流时写入，新创建的资源由创建任务写入。
+type RefValue struct {
+	ID      string `json:"id"`
+	CloudID string `json:"cloud_id"`
+}
+
+// Encode RefValue.
+func (v RefValue) Encode() string {
+	// 结构简单，序列化不会失败
+	content, _ := json.Marshal(v)
+	return string(content)
+}
+
+func saveRef(kt run.ExecuteKit, resType enumor.CloudResourceType, ref string, val RefValue) error {
+	return kt.ShareData().Set(kt.Kit(), RefKey(resType, ref), val.Encode())
+}
+
+func getRef(kt run.ExecuteKit, resType enumor.CloudResourceType, ref string) (*RefValue, error) {
+	content, exist := kt.ShareData().Get(RefKey(resType, ref))
+	if !exist {
+		return nil, fmt.Errorf("%s %s not found in share data", resType, ref)
+	}
+
+	val := new(RefValue)
+	if err := json.UnmarshalFromString(content, val); err != nil {
+		return nil, fmt.Errorf("decode %s %s share data failed, err: %v", resType, ref, err)
+	}
+
+	return val, nil
+}
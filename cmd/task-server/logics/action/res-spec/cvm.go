@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionresspec
+
+import (
+	"errors"
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	resspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/api/core"
+	hccvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+)
+
+func applyCvm(kt run.ExecuteKit, opt *ApplyOption) error {
+	hcCli := actcli.GetHCService().TCloud.Cvm
+	change := opt.Change
+
+	switch change.Action {
+	case resspec.ChangeCreate:
+		exist, err := existCvm(kt, opt.AccountID, opt.Region, change.Ref)
+		if err != nil {
+			return err
+		}
+		if exist {
+			return nil
+		}
+
+		req, err := buildCreateCvmReq(kt, opt)
+		if err != nil {
+			return err
+		}
+
+		result, err := hcCli.BatchCreateCvm(kt.Kit(), req)
+		if err != nil {
+			return err
+		}
+		if len(result.FailedMessage) != 0 {
+			return errors.New(result.FailedMessage)
+		}
+		return nil
+
+	case resspec.ChangeDelete:
+		req := &hccvm.TCloudBatchDeleteReq{
+			AccountID: opt.AccountID,
+			Region:    opt.Region,
+			IDs:       []string{change.ID},
+		}
+		return hcCli.BatchDeleteCvm(kt.Kit(), req)
+
+	default:
+		return fmt.Errorf("cvm change action: %s not support", change.Action)
+	}
+}
+
+func buildCreateCvmReq(kt run.ExecuteKit, opt *ApplyOption) (*hccvm.TCloudBatchCreateReq, error) {
+	spec := opt.Cvm
+
+	vpc, err := getRef(kt, enumor.VpcCloudResType, spec.Vpc)
+	if err != nil {
+		return nil, err
+	}
+
+	subnet, err := getRef(kt, enumor.SubnetCloudResType, resspec.SubnetRef(spec.Vpc, spec.Subnet))
+	if err != nil {
+		return nil, err
+	}
+
+	sgCloudIDs := make([]string, 0, len(spec.SecurityGroups))
+	for _, name := range spec.SecurityGroups {
+		sg, err := getRef(kt, enumor.SecurityGroupCloudResType, name)
+		if err != nil {
+			return nil, err
+		}
+		sgCloudIDs = append(sgCloudIDs, sg.CloudID)
+	}
+
+	return &hccvm.TCloudBatchCreateReq{
+		AccountID:               opt.AccountID,
+		Region:                  opt.Region,
+		Name:                    spec.Name,
+		Zone:                    spec.Zone,
+		InstanceType:            spec.InstanceType,
+		CloudImageID:            spec.CloudImageID,
+		Password:                spec.Password,
+		RequiredCount:           1,
+		CloudSecurityGroupIDs:   sgCloudIDs,
+		CloudVpcID:              vpc.CloudID,
+		CloudSubnetID:           subnet.CloudID,
+		InstanceChargeType:      spec.InstanceChargeType,
+		InstanceChargePrepaid:   spec.InstanceChargePrepaid,
+		SystemDisk:              spec.SystemDisk,
+		DataDisk:                spec.DataDisk,
+		PublicIPAssigned:        spec.PublicIPAssigned,
+		InternetMaxBandwidthOut: spec.InternetMaxBandwidthOut,
+		InternetChargeType:      spec.InternetChargeType,
+	}, nil
+}
+
+// existCvm 任务重试时主机可能已经创建成功，按名称查询，避免重复创建
+func existCvm(kt run.ExecuteKit, accountID, region, name string) (bool, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("name", name),
+		),
+		Page: core.NewCountPage(),
+	}
+	result, err := actcli.GetDataService().Global.Cvm.ListCvm(kt.Kit(), req)
+	if err != nil {
+		return false, err
+	}
+
+	return result.Count != 0, nil
+}
@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionresspec
+
+import (
+	"errors"
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	resspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	hcsubnet "hcm/pkg/api/hc-service/subnet"
+	hcvpc "hcm/pkg/api/hc-service/vpc"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+)
+
+func applyVpc(kt run.ExecuteKit, opt *ApplyOption) error {
+	hcCli := actcli.GetHCService().TCloud.Vpc
+	change := opt.Change
+
+	switch change.Action {
+	case resspec.ChangeCreate:
+		// 任务重试时VPC可能已经创建成功，先按名称查询，避免重复创建
+		vpc, err := findVpc(kt, opt.AccountID, opt.Region, change.Ref)
+		if err != nil {
+			return err
+		}
+
+		if vpc == nil {
+			req := &hcvpc.VpcCreateReq[hcvpc.TCloudVpcCreateExt]{
+				BaseVpcCreateReq: &hcvpc.BaseVpcCreateReq{
+					AccountID: opt.AccountID,
+					Name:      opt.Vpc.Name,
+					Category:  enumor.BizVpcCategory,
+					Memo:      opt.Vpc.Memo,
+					BkBizID:   constant.UnassignedBiz,
+				},
+				Extension: &hcvpc.TCloudVpcCreateExt{
+					Region:   opt.Region,
+					IPv4Cidr: opt.Vpc.IPv4Cidr,
+				},
+			}
+			if _, err = hcCli.Create(kt.Kit().Ctx, kt.Kit().Header(), req); err != nil {
+				return err
+			}
+
+			if vpc, err = findVpc(kt, opt.AccountID, opt.Region, change.Ref); err != nil {
+				return err
+			}
+			if vpc == nil {
+				return fmt.Errorf("vpc %s not found after created", change.Ref)
+			}
+		}
+
+		return saveRef(kt, enumor.VpcCloudResType, change.Ref, RefValue{ID: vpc.ID, CloudID: vpc.CloudID})
+
+	case resspec.ChangeUpdate:
+		return hcCli.Update(kt.Kit().Ctx, kt.Kit().Header(), change.ID, &hcvpc.VpcUpdateReq{Memo: opt.Vpc.Memo})
+
+	case resspec.ChangeDelete:
+		return hcCli.Delete(kt.Kit().Ctx, kt.Kit().Header(), change.ID)
+
+	default:
+		return fmt.Errorf("vpc change action: %s not support", change.Action)
+	}
+}
+
+func applySubnet(kt run.ExecuteKit, opt *ApplyOption) error {
+	hcCli := actcli.GetHCService().TCloud.Subnet
+	change := opt.Change
+
+	switch change.Action {
+	case resspec.ChangeCreate:
+		vpc, err := getRef(kt, enumor.VpcCloudResType, opt.VpcName)
+		if err != nil {
+			return err
+		}
+
+		subnet, err := findSubnet(kt, vpc.CloudID, opt.Subnet.Name)
+		if err != nil {
+			return err
+		}
+
+		if subnet == nil {
+			req := &hcsubnet.TCloudSubnetBatchCreateReq{
+				BkBizID:    constant.UnassignedBiz,
+				AccountID:  opt.AccountID,
+				Region:     opt.Region,
+				CloudVpcID: vpc.CloudID,
+				Subnets: []hcsubnet.TCloudOneSubnetCreateReq{{
+					IPv4Cidr: opt.Subnet.IPv4Cidr,
+					Name:     opt.Subnet.Name,
+					Zone:     opt.Subnet.Zone,
+					Memo:     opt.Subnet.Memo,
+				}},
+			}
+			if _, err = hcCli.BatchCreate(kt.Kit().Ctx, kt.Kit().Header(), req); err != nil {
+				return err
+			}
+
+			if subnet, err = findSubnet(kt, vpc.CloudID, opt.Subnet.Name); err != nil {
+				return err
+			}
+			if subnet == nil {
+				return fmt.Errorf("subnet %s not found after created", change.Ref)
+			}
+		}
+
+		return saveRef(kt, enumor.SubnetCloudResType, change.Ref, RefValue{ID: subnet.ID, CloudID: subnet.CloudID})
+
+	case resspec.ChangeUpdate:
+		req := &hcsubnet.SubnetUpdateReq{Memo: opt.Subnet.Memo}
+		return hcCli.Update(kt.Kit().Ctx, kt.Kit().Header(), change.ID, req)
+
+	case resspec.ChangeDelete:
+		return hcCli.Delete(kt.Kit(), change.ID)
+
+	default:
+		return fmt.Errorf("subnet change action: %s not support", change.Action)
+	}
+}
+
+func findVpc(kt run.ExecuteKit, accountID, region, name string) (*corecloud.BaseVpc, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("name", name),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := actcli.GetDataService().Global.Vpc.List(kt.Kit().Ctx, kt.Kit().Header(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(result.Details) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &result.Details[0], nil
+	default:
+		return nil, errors.New("there are multiple vpcs named " + name)
+	}
+}
+
+func findSubnet(kt run.ExecuteKit, cloudVpcID, name string) (*corecloud.BaseSubnet, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("cloud_vpc_id", cloudVpcID),
+			tools.RuleEqual("name", name),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := actcli.GetDataService().Global.Subnet.List(kt.Kit().Ctx, kt.Kit().Header(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(result.Details) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &result.Details[0], nil
+	default:
+		return nil, errors.New("there are multiple subnets named " + name)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionresspec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	resspec "hcm/pkg/api/cloud-server/res-spec"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/tools/converter"
+)
+
+func applySecurityGroup(kt run.ExecuteKit, opt *ApplyOption) error {
+	hcCli := actcli.GetHCService().TCloud.SecurityGroup
+	change := opt.Change
+
+	sgID := change.ID
+	switch change.Action {
+	case resspec.ChangeCreate:
+		sg, err := findSecurityGroup(kt, opt.AccountID, opt.Region, change.Ref)
+		if err != nil {
+			return err
+		}
+
+		if sg == nil {
+			manager := opt.SecurityGroup.Manager
+			if len(manager) == 0 {
+				manager = kt.Kit().User
+			}
+			bakManager := opt.SecurityGroup.BakManager
+			if len(bakManager) == 0 {
+				bakManager = manager
+			}
+			req := &hcproto.TCloudSecurityGroupCreateReq{
+				Region:     opt.Region,
+				Name:       opt.SecurityGroup.Name,
+				Memo:       opt.SecurityGroup.Memo,
+				AccountID:  opt.AccountID,
+				BkBizID:    constant.UnassignedBiz,
+				MgmtType:   enumor.MgmtTypePlatform,
+				MgmtBizID:  constant.UnassignedBiz,
+				Manager:    manager,
+				BakManager: bakManager,
+			}
+			if _, err = hcCli.CreateSecurityGroup(kt.Kit().Ctx, kt.Kit().Header(), req); err != nil {
+				return err
+			}
+
+			if sg, err = findSecurityGroup(kt, opt.AccountID, opt.Region, change.Ref); err != nil {
+				return err
+			}
+			if sg == nil {
+				return fmt.Errorf("security group %s not found after created", change.Ref)
+			}
+		}
+		sgID = sg.ID
+
+		// 任务重试时部分规则可能已经创建成功，只追加缺少的规则
+		if err = setMissingRules(kt, opt, sgID); err != nil {
+			return err
+		}
+
+		if err = saveRef(kt, enumor.SecurityGroupCloudResType, change.Ref,
+			RefValue{ID: sg.ID, CloudID: sg.CloudID}); err != nil {
+			return err
+		}
+
+	case resspec.ChangeUpdate:
+		for _, diff := range change.Diffs {
+			if diff.Field != "memo" {
+				continue
+			}
+			req := &hcproto.SecurityGroupUpdateReq{Memo: opt.SecurityGroup.Memo}
+			if err := hcCli.UpdateSecurityGroup(kt.Kit().Ctx, kt.Kit().Header(), sgID, req); err != nil {
+				return err
+			}
+		}
+
+	case resspec.ChangeDelete:
+		return hcCli.DeleteSecurityGroup(kt.Kit(), sgID)
+
+	default:
+		return fmt.Errorf("security group change action: %s not support", change.Action)
+	}
+
+	// 先删除多余规则再追加缺少的规则
+	for _, ruleID := range opt.DeleteRuleIDs {
+		if err := hcCli.DeleteSecurityGroupRule(kt.Kit().Ctx, kt.Kit().Header(), sgID, ruleID); err != nil {
+			return fmt.Errorf("delete security group rule %s failed, err: %v", ruleID, err)
+		}
+	}
+
+	if len(opt.CreateIngressRules) != 0 {
+		req := &hcproto.TCloudSGRuleCreateReq{
+			AccountID:      opt.AccountID,
+			IngressRuleSet: convSGRuleCreate(opt.CreateIngressRules),
+		}
+		if _, err := hcCli.BatchCreateSecurityGroupRule(kt.Kit().Ctx, kt.Kit().Header(), sgID, req); err != nil {
+			return fmt.Errorf("create ingress rules failed, err: %v", err)
+		}
+	}
+
+	if len(opt.CreateEgressRules) != 0 {
+		req := &hcproto.TCloudSGRuleCreateReq{
+			AccountID:     opt.AccountID,
+			EgressRuleSet: convSGRuleCreate(opt.CreateEgressRules),
+		}
+		if _, err := hcCli.BatchCreateSecurityGroupRule(kt.Kit().Ctx, kt.Kit().Header(), sgID, req); err != nil {
+			return fmt.Errorf("create egress rules failed, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func setMissingRules(kt run.ExecuteKit, opt *ApplyOption, sgID string) error {
+	existKeys := make(map[string]struct{})
+	req := &protocloud.TCloudSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := actcli.GetDataService().TCloud.SecurityGroup.ListSecurityGroupRule(kt.Kit().Ctx,
+			kt.Kit().Header(), req, sgID)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range result.Details {
+			key := resspec.RuleKey(converter.PtrToVal(one.Protocol), converter.PtrToVal(one.Port),
+				converter.PtrToVal(one.IPv4Cidr), converter.PtrToVal(one.IPv6Cidr), one.Action)
+			existKeys[string(one.Type)+"/"+key] = struct{}{}
+		}
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	missing := func(typ enumor.SecurityGroupRuleType, rules []resspec.SGRuleSpec) []resspec.SGRuleSpec {
+		result := make([]resspec.SGRuleSpec, 0, len(rules))
+		for _, one := range rules {
+			if _, exist := existKeys[string(typ)+"/"+one.Key()]; !exist {
+				result = append(result, one)
+			}
+		}
+		return result
+	}
+	opt.CreateIngressRules = missing(enumor.Ingress, opt.SecurityGroup.IngressRules)
+	opt.CreateEgressRules = missing(enumor.Egress, opt.SecurityGroup.EgressRules)
+
+	return nil
+}
+
+func convSGRuleCreate(rules []resspec.SGRuleSpec) []hcproto.TCloudSGRuleCreate {
+	result := make([]hcproto.TCloudSGRuleCreate, 0, len(rules))
+	for _, one := range rules {
+		rule := hcproto.TCloudSGRuleCreate{
+			Protocol: converter.ValToPtr(strings.ToUpper(one.Protocol)),
+			Port:     converter.ValToPtr(strings.ToUpper(string(one.Port))),
+			Action:   strings.ToUpper(one.Action),
+		}
+		if len(one.IPv4Cidr) != 0 {
+			rule.IPv4Cidr = converter.ValToPtr(one.IPv4Cidr)
+		}
+		if len(one.IPv6Cidr) != 0 {
+			rule.IPv6Cidr = converter.ValToPtr(one.IPv6Cidr)
+		}
+		if len(one.Memo) != 0 {
+			rule.Memo = converter.ValToPtr(one.Memo)
+		}
+		result = append(result, rule)
+	}
+
+	return result
+}
+
+func findSecurityGroup(kt run.ExecuteKit, accountID, region, name string) (*corecloud.BaseSecurityGroup, error) {
+	req := &protocloud.SecurityGroupListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("account_id", accountID),
+			tools.RuleEqual("region", region),
+			tools.RuleEqual("name", name),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := actcli.GetDataService().Global.SecurityGroup.ListSecurityGroup(kt.Kit().Ctx,
+		kt.Kit().Header(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(result.Details) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &result.Details[0], nil
+	default:
+		return nil, errors.New("there are multiple security groups named " + name)
+	}
+}
@@ -92,7 +92,7 @@ func (s *webService) prepare(opt *options.Option) error {
 	s.svc = svc
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(cmd.WithLog()); err != nil {
+	if err := ctl.LoadCtl(cmd.WithLog(), ctl.WithOpenAPI()); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -27,6 +27,7 @@ import (
 	"regexp"
 	"strings"
 
+	"hcm/cmd/web-server/service/oidc"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -39,7 +40,8 @@ import (
 )
 
 type loginVerifyRespData struct {
-	UserName string `json:"username"`
+	UserName   string `json:"username"`
+	Department string `json:"department"`
 }
 
 func isITSMCallbackRequest(req *restful.Request) bool {
@@ -55,9 +57,25 @@ func isSystemAdminRequest(req *restful.Request) bool {
 	return strings.Contains(req.Request.RequestURI, "/api/v1/cloud/admin/system")
 }
 
-func newCheckLogin(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) func(
+func newCheckLogin(esbClient esb.Client, oidcRP *oidc.RelyingParty, bkLoginUrl, bkLoginCookieName string) func(
 	*restful.Request) (*rest.Response, error) {
 
+	// 开启OIDC登录时，校验OIDC登录后签发的会话cookie
+	if oidcRP != nil {
+		return func(req *restful.Request) (*rest.Response, error) {
+			session, err := oidcRP.VerifySession(req.Request)
+			if err != nil {
+				return nil, err
+			}
+			return &rest.Response{
+				Data: loginVerifyRespData{
+					UserName:   session.Username,
+					Department: session.Department,
+				},
+			}, nil
+		}
+	}
+
 	if bkLoginCookieName == "bk_ticket" {
 		// 解析Login URL
 		oaLoginClient, err := newOALoginClient(bkLoginUrl)
@@ -108,13 +126,15 @@ func newCheckLogin(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) f
 }
 
 // NewUserAuthenticateFilter ...
-func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) restful.FilterFunction {
+func NewUserAuthenticateFilter(esbClient esb.Client, oidcRP *oidc.RelyingParty, bkLoginUrl,
+	bkLoginCookieName string) restful.FilterFunction {
 
-	checkLogin := newCheckLogin(esbClient, bkLoginUrl, bkLoginCookieName)
+	checkLogin := newCheckLogin(esbClient, oidcRP, bkLoginUrl, bkLoginCookieName)
 
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		var err error
 		username := ""
+		department := ""
 		// 系统管理操作仅能从后台发起，外部请求直接拒绝
 		if isSystemAdminRequest(req) {
 			resp.WriteErrorString(http.StatusForbidden, "system admin request can not be called outside hcm system")
@@ -136,6 +156,7 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 				dataContent, ok := ret.Data.(loginVerifyRespData)
 				if ok {
 					username = dataContent.UserName
+					department = dataContent.Department
 				} else {
 					logs.Errorf("change ret data to loginVerifyRespData failed")
 				}
@@ -145,6 +166,12 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 		// 这里直接修改请求的Header，后面需要用，可以直接从Header头里取
 		req.Request.Header.Set(constant.UserKey, username)
 		req.Request.Header.Set(constant.AppCodeKey, constant.WebSourceAppCode)
+		// 部门只能由登录信息设置，令牌权限范围只能由api-server设置，不能由外部请求传入
+		req.Request.Header.Del(constant.UserDepartmentKey)
+		req.Request.Header.Del(constant.TokenScopeKey)
+		if len(department) != 0 {
+			req.Request.Header.Set(constant.UserDepartmentKey, department)
+		}
 
 		// 使用Kit便于校验通用的Header是否满足
 		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
@@ -0,0 +1,273 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// stubIdP is a minimal openid provider, it issues an id token for the code it received by the last
+// authorization request.
+type stubIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// audience of the issued id token, defaults to the client id.
+	audience string
+
+	challenge string
+	nonce     string
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &stubIdP{key: key, audience: "hcm"}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JwksURI:               idp.server.URL + "/jwks",
+			EndSessionEndpoint:    idp.server.URL + "/logout",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []jsonWebKey{{
+			Kid: "k1",
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "code" || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                idp.server.URL,
+			"aud":                idp.audience,
+			"sub":                "1",
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              idp.nonce,
+			"preferred_username": "tom",
+			"org":                map[string]interface{}{"departments": []string{"ieg", "hcm"}},
+		})
+		token.Header["kid"] = "k1"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+
+	return idp
+}
+
+// authorize records the authorization request as the provider would, and returns the callback url.
+func (idp *stubIdP) authorize(t *testing.T, authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("code challenge method should be S256, but got %s", query.Get("code_challenge_method"))
+	}
+	idp.challenge = query.Get("code_challenge")
+	idp.nonce = query.Get("nonce")
+
+	return cc.CallbackPath + "?code=code&state=" + url.QueryEscape(query.Get("state"))
+}
+
+func do(handler restful.RouteFunction, target string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
+	}
+
+	w := httptest.NewRecorder()
+	handler(restful.NewRequest(r), restful.NewResponse(w))
+	return w
+}
+
+func newTestRelyingParty(t *testing.T, idp *stubIdP) *RelyingParty {
+	rp, err := NewRelyingParty(context.Background(), cc.OIDC{
+		Enable:            true,
+		Issuer:            idp.server.URL,
+		ClientID:          "hcm",
+		RedirectURL:       "https://hcm.example.com" + cc.CallbackPath,
+		UsernameClaim:     "preferred_username",
+		DepartmentClaim:   "org.departments",
+		SessionCookieName: "hcm_session",
+		SessionSecret:     strings.Repeat("s", 32),
+		SessionTTLSec:     60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rp
+}
+
+func TestLogin(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.server.Close()
+	rp := newTestRelyingParty(t, idp)
+
+	login := do(rp.Login, cc.LoginPath+"?c_url="+url.QueryEscape("https://hcm.example.com/cvm"), nil)
+	if login.Code != http.StatusFound {
+		t.Fatalf("login should redirect, but got %d", login.Code)
+	}
+
+	callback := do(rp.Callback, idp.authorize(t, login.Header().Get("Location")), login.Result().Cookies())
+	if callback.Code != http.StatusFound || callback.Header().Get("Location") != "https://hcm.example.com/cvm" {
+		t.Fatalf("callback should redirect to return url, but got %d, %s, body: %s", callback.Code,
+			callback.Header().Get("Location"), callback.Body.String())
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/web/users", nil)
+	for _, cookie := range callback.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+	session, err := rp.VerifySession(r)
+	if err != nil {
+		t.Fatalf("verify session failed, err: %v", err)
+	}
+
+	if session.Username != "tom" || session.Department != "ieg,hcm" {
+		t.Errorf("session is not mapped from claims, got %+v", session)
+	}
+}
+
+func TestLoginRejected(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.server.Close()
+	rp := newTestRelyingParty(t, idp)
+
+	// 伪造的state
+	login := do(rp.Login, cc.LoginPath, nil)
+	idp.authorize(t, login.Header().Get("Location"))
+	callback := do(rp.Callback, cc.CallbackPath+"?code=code&state=forged", login.Result().Cookies())
+	if callback.Code != http.StatusUnauthorized {
+		t.Errorf("forged state should be rejected, but got %d", callback.Code)
+	}
+	if strings.Contains(callback.Body.String(), "state mismatch") {
+		t.Errorf("failure detail should not be returned to browser, but got %s", callback.Body.String())
+	}
+
+	// 颁发给其他客户端的id token
+	idp.audience = "other"
+	login = do(rp.Login, cc.LoginPath, nil)
+	callback = do(rp.Callback, idp.authorize(t, login.Header().Get("Location")), login.Result().Cookies())
+	if callback.Code != http.StatusUnauthorized {
+		t.Errorf("id token of other audience should be rejected, but got %d", callback.Code)
+	}
+
+	// 无登录状态cookie
+	idp.audience = "hcm"
+	login = do(rp.Login, cc.LoginPath, nil)
+	callback = do(rp.Callback, idp.authorize(t, login.Header().Get("Location")), nil)
+	if callback.Code != http.StatusUnauthorized {
+		t.Errorf("callback without state cookie should be rejected, but got %d", callback.Code)
+	}
+
+	// 站外的返回地址
+	if got := rp.returnURL("https://evil.example.com/"); got != "/" {
+		t.Errorf("return url on other site should be rejected, but got %s", got)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.server.Close()
+	rp := newTestRelyingParty(t, idp)
+
+	// GET请求只返回确认登出的表单，不删除会话
+	page := do(rp.Login, cc.LoginPath+"?is_from_logout=1", nil)
+	if page.Code != http.StatusOK || !strings.Contains(page.Body.String(), `action="`+LogoutPath+`"`) ||
+		len(page.Result().Cookies()) != 0 {
+		t.Errorf("logout by get should return the confirm form, but got %d, %s", page.Code, page.Body.String())
+	}
+
+	logout := func(method, origin string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, LogoutPath, nil)
+		if len(origin) != 0 {
+			r.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		rp.Logout(restful.NewRequest(r), restful.NewResponse(w))
+		return w
+	}
+
+	for _, c := range []struct {
+		method string
+		origin string
+	}{
+		{http.MethodGet, "https://hcm.example.com"},
+		{http.MethodPost, ""},
+		{http.MethodPost, "https://evil.example.com"},
+	} {
+		if w := logout(c.method, c.origin); w.Code != http.StatusForbidden {
+			t.Errorf("%s logout from %q should be rejected, but got %d", c.method, c.origin, w.Code)
+		}
+	}
+
+	w := logout(http.MethodPost, "https://hcm.example.com")
+	cookies := w.Result().Cookies()
+	if w.Code != http.StatusFound || len(cookies) != 1 || cookies[0].Name != "hcm_session" || cookies[0].MaxAge >= 0 {
+		t.Errorf("logout should delete session cookie, but got %d, %v", w.Code, cookies)
+	}
+}
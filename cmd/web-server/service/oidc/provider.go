@@ -0,0 +1,301 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// discoveryPath is the well known path of openid provider configuration.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// providerMetadata is the openid provider configuration, only used fields are defined.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+func (m *providerMetadata) validate(issuer string) error {
+	if strings.TrimSuffix(m.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return fmt.Errorf("issuer %s in provider metadata mismatch with configured %s", m.Issuer, issuer)
+	}
+
+	if len(m.AuthorizationEndpoint) == 0 {
+		return errors.New("authorization_endpoint is not set in provider metadata")
+	}
+
+	if len(m.TokenEndpoint) == 0 {
+		return errors.New("token_endpoint is not set in provider metadata")
+	}
+
+	if len(m.JwksURI) == 0 {
+		return errors.New("jwks_uri is not set in provider metadata")
+	}
+
+	return nil
+}
+
+// discover fetches the openid provider configuration of the issuer.
+func discover(ctx context.Context, cli *http.Client, issuer string) (*providerMetadata, error) {
+	metadata := new(providerMetadata)
+	if err := getJSON(ctx, cli, strings.TrimSuffix(issuer, "/")+discoveryPath, metadata); err != nil {
+		return nil, fmt.Errorf("discover openid provider failed, err: %v", err)
+	}
+
+	if err := metadata.validate(issuer); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func getJSON(ctx context.Context, cli *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("get %s failed, status: %d, body: %s", url, resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// jwksMinRefreshInterval limits how often the key set is refetched because of an unknown key id, so that
+// forged tokens can not be used to flood the provider.
+const jwksMinRefreshInterval = time.Minute
+
+// keySet caches the json web key set of the provider, it is refetched when the key id of a token is unknown,
+// which happens after the provider rotates its signing keys.
+type keySet struct {
+	cli *http.Client
+	uri string
+
+	lock        sync.Mutex
+	keys        map[string]interface{}
+	refreshedAt time.Time
+}
+
+func newKeySet(cli *http.Client, uri string) *keySet {
+	return &keySet{cli: cli, uri: uri, keys: make(map[string]interface{})}
+}
+
+// get the public key of the key id, an empty key id matches the only key in the set.
+func (s *keySet) get(ctx context.Context, kid string) (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if key, exists := s.lookup(kid); exists {
+		return key, nil
+	}
+
+	if time.Since(s.refreshedAt) < jwksMinRefreshInterval {
+		return nil, fmt.Errorf("key %s is not found in jwks", kid)
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, exists := s.lookup(kid); exists {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("key %s is not found in jwks", kid)
+}
+
+func (s *keySet) lookup(kid string) (interface{}, bool) {
+	if len(kid) == 0 && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, exists := s.keys[kid]
+	return key, exists
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (s *keySet) refresh(ctx context.Context) error {
+	jwks := new(struct {
+		Keys []jsonWebKey `json:"keys"`
+	})
+	if err := getJSON(ctx, s.cli, s.uri, jwks); err != nil {
+		return fmt.Errorf("get jwks failed, err: %v", err)
+	}
+	s.refreshedAt = time.Now()
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		// 只使用签名密钥，加密密钥跳过
+		if len(jwk.Use) != 0 && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// 不支持的密钥类型跳过，不影响其他密钥的使用
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	s.keys = keys
+
+	return nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// idTokenSigningMethods are the accepted signing algorithms of id token, symmetric algorithms and none
+// are not accepted since the client secret must not be used to verify the provider's identity.
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384",
+	"ES512"}
+
+// verifyIDToken verifies the id token signature against the provider key set, and checks the issuer,
+// audience, expiry and nonce of the token. It returns the claims of the token.
+func (rp *RelyingParty) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(idTokenSigningMethods))
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return rp.keys.get(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse id token failed, err: %v", err)
+	}
+
+	if !claims.VerifyIssuer(rp.metadata.Issuer, true) {
+		return nil, errors.New("id token issuer mismatch")
+	}
+
+	if !claims.VerifyAudience(rp.cfg.ClientID, true) {
+		return nil, errors.New("id token audience mismatch")
+	}
+
+	// 多个audience时，azp需为本客户端
+	if aud, ok := claims["aud"].([]interface{}); ok && len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != rp.cfg.ClientID {
+			return nil, errors.New("id token authorized party mismatch")
+		}
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("id token is expired")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,352 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package oidc implements the openid connect relying party of web-server, it logs users in with the
+// authorization code flow with PKCE, and keeps the login user in a signed session cookie.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/tools/ssl"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+// RelyingParty is the openid connect relying party.
+type RelyingParty struct {
+	cfg      cc.OIDC
+	cli      *http.Client
+	metadata *providerMetadata
+	keys     *keySet
+	oauth2   *oauth2.Config
+	secret   []byte
+	// secure marks the cookies secure when web-server is accessed by https.
+	secure bool
+	// origin is the external scheme and host of web-server, return urls must be on it.
+	origin string
+}
+
+// NewRelyingParty discovers the provider configuration of the issuer and creates a relying party.
+func NewRelyingParty(ctx context.Context, cfg cc.OIDC) (*RelyingParty, error) {
+	var tlsConfig *ssl.TLSConfig
+	if cfg.TLS.Enable() || cfg.TLS.InsecureSkipVerify {
+		tlsConfig = &ssl.TLSConfig{
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+			CertFile:           cfg.TLS.CertFile,
+			KeyFile:            cfg.TLS.KeyFile,
+			CAFile:             cfg.TLS.CAFile,
+			Password:           cfg.TLS.Password,
+		}
+	}
+
+	cli, err := client.NewClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	cli.Timeout = 30 * time.Second
+
+	metadata, err := discover(ctx, cli, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect, err := url.Parse(cfg.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse oidc redirect url failed, err: %v", err)
+	}
+
+	rp := &RelyingParty{
+		cfg:      cfg,
+		cli:      cli,
+		metadata: metadata,
+		keys:     newKeySet(cli, metadata.JwksURI),
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  metadata.AuthorizationEndpoint,
+				TokenURL: metadata.TokenEndpoint,
+			},
+			RedirectURL: cfg.RedirectURL,
+			Scopes:      cfg.Scopes,
+		},
+		secret: []byte(cfg.SessionSecret),
+		secure: redirect.Scheme == "https",
+		origin: redirect.Scheme + "://" + redirect.Host,
+	}
+
+	return rp, nil
+}
+
+// Login redirects the user to the provider to authorize. The frontend jumps to login url with c_url as the
+// return url, and with is_from_logout when user logs out.
+func (rp *RelyingParty) Login(req *restful.Request, resp *restful.Response) {
+	// 登出会修改登录状态，不能由GET请求触发，否则其他站点可通过链接或图片让用户登出，这里返回确认登出的表单
+	if req.QueryParameter("is_from_logout") == "1" {
+		resp.Header().Set("Content-Type", "text/html; charset=utf-8")
+		resp.Header().Set("Cache-Control", "no-store")
+		resp.Header().Set("X-Frame-Options", "DENY")
+		resp.WriteHeader(http.StatusOK)
+		_, _ = resp.Write([]byte(logoutPage))
+		return
+	}
+
+	rid := req.Request.Header.Get(constant.RidKey)
+
+	state := &loginState{
+		State:     randomString(),
+		Nonce:     randomString(),
+		Verifier:  oauth2.GenerateVerifier(),
+		ReturnURL: rp.returnURL(req.QueryParameter("c_url")),
+	}
+	cookie, err := rp.newStateCookie(state)
+	if err != nil {
+		logs.Errorf("create oidc login state cookie failed, err: %v, rid: %s", err, rid)
+		resp.WriteErrorString(http.StatusInternalServerError, "create login state failed")
+		return
+	}
+	http.SetCookie(resp.ResponseWriter, cookie)
+
+	authURL := rp.oauth2.AuthCodeURL(state.State, oauth2.S256ChallengeOption(state.Verifier),
+		oauth2.SetAuthURLParam("nonce", state.Nonce))
+	http.Redirect(resp.ResponseWriter, req.Request, authURL, http.StatusFound)
+}
+
+// Callback handles the authorization response of the provider, it exchanges the code for tokens, verifies
+// the id token and sets the session cookie.
+func (rp *RelyingParty) Callback(req *restful.Request, resp *restful.Response) {
+	rid := req.Request.Header.Get(constant.RidKey)
+
+	session, returnURL, err := rp.callback(req)
+	// 登录状态只能使用一次，无论成功与否都删除
+	http.SetCookie(resp.ResponseWriter, rp.cookie(stateCookieName, "", cc.LoginPath, -1))
+	if err != nil {
+		// 失败原因可能包含IdP返回的信息，只记录在日志中，不返回给浏览器
+		logs.Errorf("oidc login callback failed, err: %v, rid: %s", err, rid)
+		resp.WriteErrorString(http.StatusUnauthorized, "oidc login failed, rid: "+rid)
+		return
+	}
+
+	cookie, err := rp.newSessionCookie(session)
+	if err != nil {
+		logs.Errorf("create session cookie failed, err: %v, rid: %s", err, rid)
+		resp.WriteErrorString(http.StatusInternalServerError, "create session failed")
+		return
+	}
+	http.SetCookie(resp.ResponseWriter, cookie)
+
+	logs.Infof("user %s login by oidc, rid: %s", session.Username, rid)
+	http.Redirect(resp.ResponseWriter, req.Request, returnURL, http.StatusFound)
+}
+
+func (rp *RelyingParty) callback(req *restful.Request) (*Session, string, error) {
+	state, err := rp.parseStateCookie(req.Request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if errCode := req.QueryParameter("error"); len(errCode) != 0 {
+		return nil, "", fmt.Errorf("provider returns error: %s, %s", errCode,
+			req.QueryParameter("error_description"))
+	}
+
+	if req.QueryParameter("state") != state.State {
+		return nil, "", errors.New("state mismatch")
+	}
+
+	code := req.QueryParameter("code")
+	if len(code) == 0 {
+		return nil, "", errors.New("authorization code is empty")
+	}
+
+	ctx := context.WithValue(req.Request.Context(), oauth2.HTTPClient, rp.cli)
+	token, err := rp.oauth2.Exchange(ctx, code, oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return nil, "", fmt.Errorf("exchange token failed, err: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || len(rawIDToken) == 0 {
+		return nil, "", errors.New("id token is not returned by provider")
+	}
+
+	claims, err := rp.verifyIDToken(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session, err := rp.mapClaims(claims)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, state.ReturnURL, nil
+}
+
+// mapClaims maps the id token claims to session by the configured claim names, a claim name can be a dot
+// separated path to a nested claim.
+func (rp *RelyingParty) mapClaims(claims jwt.MapClaims) (*Session, error) {
+	username := claimString(claims, rp.cfg.UsernameClaim)
+	if len(username) == 0 {
+		return nil, fmt.Errorf("username claim %s is not found in id token", rp.cfg.UsernameClaim)
+	}
+
+	session := &Session{Username: username}
+	if len(rp.cfg.DepartmentClaim) != 0 {
+		session.Department = claimString(claims, rp.cfg.DepartmentClaim)
+	}
+
+	return session, nil
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	var value interface{} = claims
+	for _, key := range strings.Split(name, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		// 多值声明（如多个部门）使用逗号拼接
+		values := make([]string, 0, len(v))
+		for _, one := range v {
+			if s, ok := one.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return strings.Join(values, ",")
+	default:
+		return ""
+	}
+}
+
+// LogoutPath is the path of oidc logout api, it only accepts POST requests from web-server pages.
+const LogoutPath = cc.LoginPath + "/logout"
+
+// logoutPage is the page to confirm logout, it posts to the logout api so that logout can not be triggered by
+// a cross-site link.
+const logoutPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>HCM</title></head>
+<body>
+<form method="post" action="` + LogoutPath + `">
+<p>确认退出登录？</p>
+<button type="submit">退出登录</button>
+</form>
+</body>
+</html>
+`
+
+// Logout deletes the session cookie and redirects the user to the provider to end the provider session.
+func (rp *RelyingParty) Logout(req *restful.Request, resp *restful.Response) {
+	if req.Request.Method != http.MethodPost || !rp.isSameOrigin(req.Request) {
+		logs.Errorf("reject oidc logout request, method: %s, origin: %s, referer: %s, rid: %s", req.Request.Method,
+			req.Request.Header.Get("Origin"), req.Request.Referer(), req.Request.Header.Get(constant.RidKey))
+		resp.WriteErrorString(http.StatusForbidden, "logout must be posted from hcm")
+		return
+	}
+
+	http.SetCookie(resp.ResponseWriter, rp.cookie(rp.cfg.SessionCookieName, "", "/", -1))
+
+	if len(rp.metadata.EndSessionEndpoint) == 0 {
+		http.Redirect(resp.ResponseWriter, req.Request, rp.cfg.PostLogoutRedirectURL, http.StatusFound)
+		return
+	}
+
+	endSession, err := url.Parse(rp.metadata.EndSessionEndpoint)
+	if err != nil {
+		logs.Errorf("parse end session endpoint failed, err: %v, rid: %s", err,
+			req.Request.Header.Get(constant.RidKey))
+		http.Redirect(resp.ResponseWriter, req.Request, rp.cfg.PostLogoutRedirectURL, http.StatusFound)
+		return
+	}
+
+	query := endSession.Query()
+	query.Set("client_id", rp.cfg.ClientID)
+	query.Set("post_logout_redirect_uri", rp.cfg.PostLogoutRedirectURL)
+	endSession.RawQuery = query.Encode()
+
+	http.Redirect(resp.ResponseWriter, req.Request, endSession.String(), http.StatusFound)
+}
+
+// isSameOrigin checks whether the request is sent from the pages of web-server by the Origin header, or by the
+// Referer header when Origin is not sent.
+func (rp *RelyingParty) isSameOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if len(origin) == 0 {
+		referer, err := url.Parse(req.Referer())
+		if err != nil || len(referer.Host) == 0 {
+			return false
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	return origin == rp.origin
+}
+
+// returnURL returns the url to jump to after login, only urls of web-server are allowed to avoid open redirect.
+func (rp *RelyingParty) returnURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || len(raw) == 0 {
+		return "/"
+	}
+
+	// 浏览器会将 // 和 /\ 开头的地址视为其他站点
+	if len(u.Scheme) == 0 && len(u.Host) == 0 && strings.HasPrefix(raw, "/") &&
+		!strings.HasPrefix(raw, "//") && !strings.HasPrefix(raw, "/\\") {
+		return u.String()
+	}
+
+	if u.Scheme+"://"+u.Host == rp.origin {
+		return u.String()
+	}
+
+	return "/"
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand 读取失败说明系统随机数不可用，无法安全地继续登录
+		panic(fmt.Sprintf("read crypto random failed, err: %v", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// sessionAudience is the audience of session cookie token.
+	sessionAudience = "hcm-session"
+	// stateAudience is the audience of login state cookie token, it differs from session audience so that a
+	// state cookie can never be used as a session cookie.
+	stateAudience = "hcm-oidc-state"
+	// stateCookieName is the name of the cookie which keeps the login state during the authorization.
+	stateCookieName = "hcm_oidc_state"
+	// stateTTL is the max duration of a login authorization.
+	stateTTL = 10 * time.Minute
+	// tokenIssuer is the issuer of the cookie tokens.
+	tokenIssuer = "hcm-web-server"
+)
+
+// Session is the login session of a user.
+type Session struct {
+	Username   string `json:"username"`
+	Department string `json:"department,omitempty"`
+}
+
+type sessionClaims struct {
+	Session
+	jwt.RegisteredClaims
+}
+
+// loginState is kept in a cookie between the login redirect and the callback, so that web-server instances
+// do not need to share state.
+type loginState struct {
+	State     string `json:"state"`
+	Nonce     string `json:"nonce"`
+	Verifier  string `json:"verifier"`
+	ReturnURL string `json:"return_url"`
+}
+
+type stateClaims struct {
+	loginState
+	jwt.RegisteredClaims
+}
+
+func (rp *RelyingParty) sign(claims jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(rp.secret)
+}
+
+// parse the token signed by sign, registered is the registered claims embedded in claims.
+func (rp *RelyingParty) parse(token string, claims jwt.Claims, registered *jwt.RegisteredClaims,
+	audience string) error {
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	_, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return rp.secret, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !registered.VerifyIssuer(tokenIssuer, true) || !registered.VerifyAudience(audience, true) {
+		return errors.New("token is not issued for " + audience)
+	}
+
+	if !registered.VerifyExpiresAt(time.Now(), true) {
+		return errors.New("token is expired")
+	}
+
+	return nil
+}
+
+func (rp *RelyingParty) newRegisteredClaims(audience string, ttl time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Issuer:    tokenIssuer,
+		Audience:  jwt.ClaimStrings{audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+}
+
+// VerifySession verifies the session cookie of the request and returns the session.
+func (rp *RelyingParty) VerifySession(req *http.Request) (*Session, error) {
+	cookie, err := req.Cookie(rp.cfg.SessionCookieName)
+	// Note: err只有一个ErrNoCookie可能，所以这里是无登录会话的情况
+	if err != nil || cookie.Value == "" {
+		return nil, fmt.Errorf("%s cookie don't exists", rp.cfg.SessionCookieName)
+	}
+
+	claims := new(sessionClaims)
+	if err := rp.parse(cookie.Value, claims, &claims.RegisteredClaims, sessionAudience); err != nil {
+		return nil, fmt.Errorf("session is invalid, err: %v", err)
+	}
+
+	if len(claims.Username) == 0 {
+		return nil, errors.New("session username is empty")
+	}
+
+	return &claims.Session, nil
+}
+
+func (rp *RelyingParty) newSessionCookie(session *Session) (*http.Cookie, error) {
+	ttl := time.Duration(rp.cfg.SessionTTLSec) * time.Second
+	token, err := rp.sign(&sessionClaims{
+		Session:          *session,
+		RegisteredClaims: rp.newRegisteredClaims(sessionAudience, ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rp.cookie(rp.cfg.SessionCookieName, token, "/", int(ttl.Seconds())), nil
+}
+
+func (rp *RelyingParty) newStateCookie(state *loginState) (*http.Cookie, error) {
+	token, err := rp.sign(&stateClaims{
+		loginState:       *state,
+		RegisteredClaims: rp.newRegisteredClaims(stateAudience, stateTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rp.cookie(stateCookieName, token, cc.LoginPath, int(stateTTL.Seconds())), nil
+}
+
+func (rp *RelyingParty) parseStateCookie(req *http.Request) (*loginState, error) {
+	cookie, err := req.Cookie(stateCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil, errors.New("login state cookie don't exists, the login may be expired")
+	}
+
+	claims := new(stateClaims)
+	if err := rp.parse(cookie.Value, claims, &claims.RegisteredClaims, stateAudience); err != nil {
+		return nil, fmt.Errorf("login state is invalid, err: %v", err)
+	}
+
+	return &claims.loginState, nil
+}
+
+// cookie returns a http only cookie, a negative max age deletes the cookie. SameSite is lax since the callback
+// is a top level navigation from the provider which must carry the state cookie.
+func (rp *RelyingParty) cookie(name, value, path string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		Secure:   rp.secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
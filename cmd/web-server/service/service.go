@@ -30,6 +30,7 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	authsvc "hcm/cmd/web-server/service/auth"
@@ -39,6 +40,7 @@ import (
 	"hcm/cmd/web-server/service/cmdb"
 	"hcm/cmd/web-server/service/itsm"
 	"hcm/cmd/web-server/service/notice"
+	"hcm/cmd/web-server/service/oidc"
 	templateSvc "hcm/cmd/web-server/service/template"
 	"hcm/cmd/web-server/service/user"
 	"hcm/cmd/web-server/service/version"
@@ -79,6 +81,8 @@ type Service struct {
 	// noticeCli notification center client
 	noticeCli pkgnotice.Client
 	cmdbCli   pkgcmdb.Client
+	// oidcRP oidc登录的relying party，未开启oidc登录时为nil
+	oidcRP *oidc.RelyingParty
 }
 
 // NewService create a service instance.
@@ -141,6 +145,15 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
+	var oidcRP *oidc.RelyingParty
+	if oidcCfg := cc.WebServer().OIDC; oidcCfg.Enable {
+		oidcRP, err = oidc.NewRelyingParty(context.Background(), oidcCfg)
+		if err != nil {
+			logs.Errorf("failed to create oidc relying party, err: %v", err)
+			return nil, err
+		}
+	}
+
 	return &Service{
 		client:     apiClientSet,
 		esbClient:  esbClient,
@@ -149,6 +162,7 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		itsmCli:    itsmCli,
 		noticeCli:  noticeCli,
 		cmdbCli:    cmdbCli,
+		oidcRP:     oidcRP,
 	}, nil
 }
 
@@ -201,6 +215,9 @@ func (s *Service) ListenAndServeRest() error {
 	// Add container filter to respond to OPTIONS
 	container.Filter(container.OPTIONSFilter)
 	container.Add(s.staticFileSet())
+	if s.oidcRP != nil {
+		container.Add(s.oidcSet())
+	}
 	container.Add(s.apiSet())
 	container.Add(s.proxyApiSet("/api/v1/cloud"))
 	container.Add(s.proxyApiSet("/api/v1/account"))
@@ -261,7 +278,8 @@ func (s *Service) apiSet() *restful.WebService {
 	ws.Filter(NewCompleteRequestIDFilter())
 	// Note: 所有API接口都需要经过用户认证
 	ws.Path("/api/v1/web").Filter(
-		NewUserAuthenticateFilter(s.esbClient, cc.WebServer().Web.BkLoginUrl, cc.WebServer().Web.BkLoginCookieName),
+		NewUserAuthenticateFilter(s.esbClient, s.oidcRP, cc.WebServer().Web.BkLoginUrl,
+			cc.WebServer().Web.BkLoginCookieName),
 	)
 
 	c := &capability.Capability{
@@ -288,6 +306,21 @@ func (s *Service) apiSet() *restful.WebService {
 	return ws
 }
 
+// oidcSet 处理OIDC登录、回调及登出，这些接口无需用户认证
+func (s *Service) oidcSet() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path(cc.LoginPath)
+	ws.Filter(NewCompleteRequestIDFilter())
+
+	ws.Route(ws.GET("").To(s.oidcRP.Login))
+	// 前端登录弹窗使用的登录地址
+	ws.Route(ws.GET("/plain").To(s.oidcRP.Login))
+	ws.Route(ws.GET(strings.TrimPrefix(cc.CallbackPath, cc.LoginPath)).To(s.oidcRP.Callback))
+	ws.Route(ws.POST(strings.TrimPrefix(oidc.LogoutPath, cc.LoginPath)).To(s.oidcRP.Logout))
+
+	return ws
+}
+
 // proxyApiSet 处理代理API
 func (s *Service) proxyApiSet(apiPath string) *restful.WebService {
 	ws := new(restful.WebService)
@@ -296,7 +329,8 @@ func (s *Service) proxyApiSet(apiPath string) *restful.WebService {
 	ws.Filter(NewCompleteRequestIDFilter())
 	// Note: 所有API接口都需要经过用户认证
 	ws.Path(apiPath).Filter(
-		NewUserAuthenticateFilter(s.esbClient, cc.WebServer().Web.BkLoginUrl, cc.WebServer().Web.BkLoginCookieName),
+		NewUserAuthenticateFilter(s.esbClient, s.oidcRP, cc.WebServer().Web.BkLoginUrl,
+			cc.WebServer().Web.BkLoginCookieName),
 	)
 	ws.Route(ws.GET("{.*}").To(s.proxy.Do))
 	ws.Route(ws.POST("{.*}").To(s.proxy.Do))
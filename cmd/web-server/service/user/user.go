@@ -23,6 +23,7 @@ package user
 import (
 	"hcm/cmd/web-server/service/capability"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/rest"
 )
 
@@ -44,5 +45,11 @@ type userSvc struct {
 
 // GetUser get user info
 func (u *userSvc) GetUser(cts *rest.Contexts) (interface{}, error) {
-	return map[string]string{"username": cts.Kit.User}, nil
+	user := map[string]string{"username": cts.Kit.User}
+	// 部门仅在OIDC登录并配置了部门声明映射时存在
+	if department := cts.Request.Request.Header.Get(constant.UserDepartmentKey); len(department) != 0 {
+		user["department"] = department
+	}
+
+	return user, nil
 }
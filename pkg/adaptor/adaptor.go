@@ -23,10 +23,12 @@
 package adaptor
 
 import (
+	"hcm/pkg/adaptor/aliyun"
 	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/openstack"
 	"hcm/pkg/adaptor/tcloud"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/criteria/enumor"
@@ -65,3 +67,13 @@ func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error)
 func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
 	return huawei.NewHuaWei(s)
 }
+
+// Aliyun returns Aliyun operations.
+func (a *Adaptor) Aliyun(s *types.BaseSecret) (*aliyun.Aliyun, error) {
+	return aliyun.NewAliyun(s)
+}
+
+// OpenStack returns OpenStack operations.
+func (a *Adaptor) OpenStack(credential *types.OpenStackCredential) (*openstack.OpenStack, error) {
+	return openstack.NewOpenStack(credential)
+}
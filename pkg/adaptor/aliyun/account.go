@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+type callerIdentity struct {
+	AccountID    string `json:"AccountId"`
+	UserID       string `json:"UserId"`
+	Arn          string `json:"Arn"`
+	IdentityType string `json:"IdentityType"`
+	PrincipalID  string `json:"PrincipalId"`
+}
+
+// GetAccountInfoBySecret 根据秘钥获取账号信息，主账号ID为秘钥所属阿里云账号，子账号ID为RAM用户ID。
+// 如果秘钥属于阿里云账号本身，子账号ID与主账号ID一致。
+// reference: https://help.aliyun.com/zh/ram/developer-reference/api-sts-2015-04-01-getcalleridentity
+func (a *Aliyun) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AliyunInfoBySecret, error) {
+	resp := new(callerIdentity)
+	if err := a.clientSet.call(kt, stsProduct, "GetCallerIdentity", nil, resp); err != nil {
+		logs.Errorf("get aliyun caller identity failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("get aliyun caller identity failed, err: %v", err)
+	}
+
+	info := &cloud.AliyunInfoBySecret{
+		CloudMainAccountID: resp.AccountID,
+		CloudSubAccountID:  resp.UserID,
+	}
+	if resp.IdentityType == "Account" {
+		info.CloudSubAccountID = resp.AccountID
+	}
+
+	return info, nil
+}
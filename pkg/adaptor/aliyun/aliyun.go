@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package aliyun ...
+package aliyun
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/errf"
+)
+
+// NewAliyun new aliyun.
+func NewAliyun(s *types.BaseSecret) (*Aliyun, error) {
+	if err := validateSecret(s); err != nil {
+		return nil, err
+	}
+	return &Aliyun{clientSet: newClientSet(s)}, nil
+}
+
+// Aliyun is aliyun operator.
+type Aliyun struct {
+	clientSet *clientSet
+}
+
+func validateSecret(s *types.BaseSecret) error {
+	if s == nil {
+		return errf.New(errf.InvalidParameter, "secret is required")
+	}
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// listParams 根据通用查询条件生成请求参数，idsKey 为按ID查询时使用的参数名，资源ID以JSON数组格式传递
+func listParams(opt core.AliyunListOption, idsKey string) map[string]string {
+	params := map[string]string{
+		"RegionId": opt.Region,
+	}
+
+	if len(opt.CloudIDs) != 0 {
+		ids, _ := json.Marshal(opt.CloudIDs)
+		params[idsKey] = string(ids)
+		params["PageSize"] = strconv.Itoa(core.AliyunIDQueryLimit)
+	}
+
+	setPage(params, opt.Page)
+
+	return params
+}
+
+// setPage 设置分页参数
+func setPage(params map[string]string, page *core.AliyunPage) {
+	if page == nil {
+		return
+	}
+
+	params["PageNumber"] = strconv.FormatInt(page.PageNumber, 10)
+	params["PageSize"] = strconv.FormatInt(page.PageSize, 10)
+}
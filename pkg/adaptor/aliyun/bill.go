@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	typesbill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// GetBillList 查询实例账单，指定账单日期时按天粒度查询，否则按月粒度查询
+// reference: https://help.aliyun.com/zh/user-center/developer-reference/api-bssopenapi-2017-12-14-describeinstancebill
+func (a *Aliyun) GetBillList(kt *kit.Kit, opt *typesbill.AliyunBillListOption) (
+	*typesbill.AliyunBillListResult, error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"BillingCycle": opt.BillingCycle,
+		"Granularity":  "MONTHLY",
+	}
+	if len(opt.BillingDate) != 0 {
+		params["BillingDate"] = opt.BillingDate
+		params["Granularity"] = "DAILY"
+	}
+	if opt.Page != nil {
+		if opt.Page.MaxResults > 0 {
+			params["MaxResults"] = strconv.Itoa(int(opt.Page.MaxResults))
+		}
+		if len(opt.Page.NextToken) != 0 {
+			params["NextToken"] = opt.Page.NextToken
+		}
+	}
+
+	resp := new(struct {
+		Success bool   `json:"Success"`
+		Code    string `json:"Code"`
+		Message string `json:"Message"`
+		Data    struct {
+			NextToken string            `json:"NextToken"`
+			Items     []json.RawMessage `json:"Items"`
+		} `json:"Data"`
+	})
+	if err := a.clientSet.call(kt, bssProduct, "DescribeInstanceBill", params, resp); err != nil {
+		logs.Errorf("list aliyun bill failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun bill failed, err: %v", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("list aliyun bill failed, code: %s, message: %s", resp.Code, resp.Message)
+	}
+
+	return &typesbill.AliyunBillListResult{NextToken: resp.Data.NextToken, Details: resp.Data.Items}, nil
+}
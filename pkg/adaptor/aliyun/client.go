@@ -0,0 +1,172 @@
+package aliyun
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/uuid"
+)
+
+const (
+	// defaultTimeout 单次请求的超时时间
+	defaultTimeout = 30 * time.Second
+	// timestampLayout 阿里云公共参数Timestamp的格式，UTC时间
+	timestampLayout = "2006-01-02T15:04:05Z"
+)
+
+// product 阿里云云产品，包含接入地址与API版本
+type product struct {
+	// Endpoint 接入地址，使用中心化地址，地域通过RegionId参数指定
+	Endpoint string
+	// Version API版本
+	Version string
+}
+
+var (
+	// ecsProduct 云服务器ECS，包含地域、可用区、实例、云盘、安全组、镜像
+	ecsProduct = product{Endpoint: "ecs.aliyuncs.com", Version: "2014-05-26"}
+	// vpcProduct 专有网络VPC，包含VPC、交换机、弹性公网IP
+	vpcProduct = product{Endpoint: "vpc.aliyuncs.com", Version: "2016-04-28"}
+	// stsProduct 访问控制STS，用于获取秘钥所属身份
+	stsProduct = product{Endpoint: "sts.aliyuncs.com", Version: "2015-04-01"}
+	// bssProduct 费用与成本，用于获取账单
+	bssProduct = product{Endpoint: "business.aliyuncs.com", Version: "2017-12-14"}
+)
+
+// clientSet 阿里云 RPC 风格 OpenAPI 客户端，签名算法为 HMAC-SHA1，签名版本 1.0。
+// reference: https://help.aliyun.com/zh/sdk/product-overview/rpc-mechanism
+type clientSet struct {
+	secret *types.BaseSecret
+	client *http.Client
+	// scheme 和 hostFunc 方便在测试时替换为本地服务
+	scheme   string
+	hostFunc func(p product) string
+}
+
+func newClientSet(secret *types.BaseSecret) *clientSet {
+	return &clientSet{
+		secret:   secret,
+		client:   &http.Client{Timeout: defaultTimeout},
+		scheme:   "https",
+		hostFunc: func(p product) string { return p.Endpoint },
+	}
+}
+
+// Error 阿里云 OpenAPI 返回的错误
+type Error struct {
+	RequestID string `json:"RequestId"`
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+}
+
+// Error ...
+func (e *Error) Error() string {
+	return fmt.Sprintf("aliyun api failed, code: %s, message: %s, request_id: %s", e.Code, e.Message, e.RequestID)
+}
+
+// call 调用阿里云 OpenAPI，params 为接口参数，结果解析到 result 中。
+func (c *clientSet) call(kt *kit.Kit, p product, action string, params map[string]string, result interface{}) error {
+	query := c.signedQuery(http.MethodGet, p, action, params, time.Now().UTC(), uuid.UUID())
+	reqURL := fmt.Sprintf("%s://%s/?%s", c.scheme, c.hostFunc(p), query)
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request aliyun %s failed, err: %v", action, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read aliyun %s response failed, err: %v", action, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := new(Error)
+		if err = json.Unmarshal(body, apiErr); err != nil || len(apiErr.Code) == 0 {
+			return fmt.Errorf("aliyun %s failed, status: %d, body: %s", action, resp.StatusCode, body)
+		}
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unmarshal aliyun %s response failed, err: %v", action, err)
+	}
+
+	return nil
+}
+
+// signedQuery 组装公共参数并计算签名，返回编码后的请求参数
+func (c *clientSet) signedQuery(method string, p product, action string, params map[string]string,
+	now time.Time, nonce string) string {
+
+	all := map[string]string{
+		"Format":           "JSON",
+		"Version":          p.Version,
+		"AccessKeyId":      c.secret.CloudSecretID,
+		"SignatureMethod":  "HMAC-SHA1",
+		"Timestamp":        now.Format(timestampLayout),
+		"SignatureVersion": "1.0",
+		"SignatureNonce":   nonce,
+		"Action":           action,
+	}
+	for k, v := range params {
+		all[k] = v
+	}
+
+	canonicalized := canonicalize(all)
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(canonicalized)
+	all["Signature"] = sign(stringToSign, c.secret.CloudSecretKey+"&")
+
+	return canonicalize(all)
+}
+
+// canonicalize 按参数名排序后拼接
+func canonicalize(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, percentEncode(k)+"="+percentEncode(params[k]))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// percentEncode 按 RFC3986 规则编码
+func percentEncode(s string) string {
+	encoded := url.QueryEscape(s)
+	encoded = strings.ReplaceAll(encoded, "+", "%20")
+	encoded = strings.ReplaceAll(encoded, "*", "%2A")
+	encoded = strings.ReplaceAll(encoded, "%7E", "~")
+	return encoded
+}
+
+func sign(stringToSign, key string) string {
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/kit"
+)
+
+// TestSignedQuery 使用阿里云文档中的示例校验签名结果
+func TestSignedQuery(t *testing.T) {
+	cli := newClientSet(&types.BaseSecret{CloudSecretID: "testid", CloudSecretKey: "testsecret"})
+	now, _ := time.Parse(timestampLayout, "2016-02-23T12:46:24Z")
+	p := product{Endpoint: "ecs.aliyuncs.com", Version: "2014-05-26"}
+	query := cli.signedQuery(http.MethodGet, p, "DescribeRegions", map[string]string{"Format": "XML"}, now,
+		"3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf")
+
+	want := "Signature=OLeaidS1JvxuMvnyHOwuJ%2BuX5qY%3D"
+	if !strings.Contains(query, want) {
+		t.Errorf("signed query should contain %s, got: %s", want, query)
+	}
+}
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch query.Get("Action") {
+		case "DescribeVpcs":
+			if query.Get("RegionId") != "cn-hangzhou" || query.Get("Signature") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"RequestId":"r1","Code":"InvalidParameter","Message":"bad request"}`))
+				return
+			}
+			w.Write([]byte(`{"TotalCount":1,"Vpcs":{"Vpc":[{"VpcId":"vpc-1","VpcName":"test",` +
+				`"RegionId":"cn-hangzhou","CidrBlock":"10.0.0.0/8","Status":"Available"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"RequestId":"r2","Code":"InvalidAction.NotFound","Message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	cli := newClientSet(&types.BaseSecret{CloudSecretID: "testid", CloudSecretKey: "testsecret"})
+	cli.scheme = "http"
+	cli.hostFunc = func(product) string { return strings.TrimPrefix(server.URL, "http://") }
+	ali := &Aliyun{clientSet: cli}
+	kt := kit.New()
+
+	opt := &types.AliyunVpcListOption{AliyunListOption: core.AliyunListOption{
+		Region: "cn-hangzhou",
+		Page:   &core.AliyunPage{PageNumber: 1, PageSize: core.AliyunQueryLimit},
+	}}
+	result, err := ali.ListVpc(kt, opt)
+	if err != nil {
+		t.Fatalf("list vpc failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 || result.Details[0].CloudID != "vpc-1" {
+		t.Fatalf("unexpected vpc list result: %+v", result)
+	}
+
+	if cidr := result.Details[0].Extension.Cidr; len(cidr) != 1 || cidr[0].Cidr != "10.0.0.0/8" {
+		t.Errorf("unexpected vpc cidr: %+v", cidr)
+	}
+
+	err = cli.call(kt, ecsProduct, "UnknownAction", nil, nil)
+	apiErr, ok := err.(*Error)
+	if !ok || apiErr.Code != "InvalidAction.NotFound" {
+		t.Errorf("call unknown action should return api error, got: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListCvm 查询实例列表
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-describeinstances
+func (a *Aliyun) ListCvm(kt *kit.Kit, opt *typecvm.AliyunListOption) (*typecvm.AliyunListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := new(struct {
+		TotalCount int64 `json:"TotalCount"`
+		Instances  struct {
+			Instance []typecvm.AliyunCvm `json:"Instance"`
+		} `json:"Instances"`
+	})
+
+	params := listParams(opt.AliyunListOption, "InstanceIds")
+	if err := a.clientSet.call(kt, ecsProduct, "DescribeInstances", params, resp); err != nil {
+		logs.Errorf("list aliyun cvm failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun cvm failed, err: %v", err)
+	}
+
+	return &typecvm.AliyunListResult{TotalCount: resp.TotalCount, Details: resp.Instances.Instance}, nil
+}
+
+// StartCvm 批量启动实例
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-startinstances
+func (a *Aliyun) StartCvm(kt *kit.Kit, opt *typecvm.AliyunOperateOption) error {
+	if opt == nil {
+		return fmt.Errorf("start option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	return a.operateCvm(kt, "StartInstances", opt.Region, opt.CloudIDs, nil)
+}
+
+// StopCvm 批量停止实例
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-stopinstances
+func (a *Aliyun) StopCvm(kt *kit.Kit, opt *typecvm.AliyunStopOption) error {
+	if opt == nil {
+		return fmt.Errorf("stop option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{"ForceStop": strconv.FormatBool(opt.ForceStop)}
+	if len(opt.StoppedMode) != 0 {
+		params["StoppedMode"] = opt.StoppedMode
+	}
+
+	return a.operateCvm(kt, "StopInstances", opt.Region, opt.CloudIDs, params)
+}
+
+// RebootCvm 批量重启实例
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-rebootinstances
+func (a *Aliyun) RebootCvm(kt *kit.Kit, opt *typecvm.AliyunOperateOption) error {
+	if opt == nil {
+		return fmt.Errorf("reboot option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	return a.operateCvm(kt, "RebootInstances", opt.Region, opt.CloudIDs, nil)
+}
+
+// DeleteCvm 批量释放实例
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-deleteinstances
+func (a *Aliyun) DeleteCvm(kt *kit.Kit, opt *typecvm.AliyunDeleteOption) error {
+	if opt == nil {
+		return fmt.Errorf("delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{"Force": strconv.FormatBool(opt.Force)}
+	for idx, id := range opt.CloudIDs {
+		params[fmt.Sprintf("InstanceId.%d", idx+1)] = id
+	}
+	params["RegionId"] = opt.Region
+
+	if err := a.clientSet.call(kt, ecsProduct, "DeleteInstances", params, nil); err != nil {
+		logs.Errorf("delete aliyun cvm failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+		return fmt.Errorf("delete aliyun cvm failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// operateCvm 调用批量操作实例的接口，实例ID以JSON数组格式传递
+func (a *Aliyun) operateCvm(kt *kit.Kit, action, region string, cloudIDs []string,
+	extra map[string]string) error {
+
+	ids, err := json.Marshal(cloudIDs)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]string{
+		"RegionId":    region,
+		"InstanceIds": string(ids),
+	}
+	for k, v := range extra {
+		params[k] = v
+	}
+
+	if err = a.clientSet.call(kt, ecsProduct, action, params, nil); err != nil {
+		logs.Errorf("aliyun %s failed, err: %v, ids: %v, rid: %s", action, err, cloudIDs, kt.Rid)
+		return fmt.Errorf("aliyun %s failed, err: %v", action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// CreateDisk 创建按量付费的数据盘，返回云盘ID
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-createdisk
+func (a *Aliyun) CreateDisk(kt *kit.Kit, opt *disk.AliyunDiskCreateOption) (string, error) {
+	if opt == nil {
+		return "", fmt.Errorf("create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", err
+	}
+
+	params := map[string]string{
+		"RegionId":     opt.Region,
+		"ZoneId":       opt.Zone,
+		"DiskCategory": opt.DiskCategory,
+		"Size":         strconv.FormatInt(opt.DiskSize, 10),
+		"Encrypted":    strconv.FormatBool(opt.Encrypted),
+	}
+	if len(opt.DiskName) != 0 {
+		params["DiskName"] = opt.DiskName
+	}
+	if len(opt.PerformanceLevel) != 0 {
+		params["PerformanceLevel"] = opt.PerformanceLevel
+	}
+	if len(opt.Description) != 0 {
+		params["Description"] = opt.Description
+	}
+
+	resp := new(struct {
+		DiskID string `json:"DiskId"`
+	})
+	if err := a.clientSet.call(kt, ecsProduct, "CreateDisk", params, resp); err != nil {
+		logs.Errorf("create aliyun disk failed, err: %v, rid: %s", err, kt.Rid)
+		return "", fmt.Errorf("create aliyun disk failed, err: %v", err)
+	}
+
+	return resp.DiskID, nil
+}
+
+// ListDisk 查询云盘列表
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-describedisks
+func (a *Aliyun) ListDisk(kt *kit.Kit, opt *disk.AliyunDiskListOption) (*disk.AliyunDiskListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := new(struct {
+		TotalCount int64 `json:"TotalCount"`
+		Disks      struct {
+			Disk []disk.AliyunDisk `json:"Disk"`
+		} `json:"Disks"`
+	})
+
+	params := listParams(opt.AliyunListOption, "DiskIds")
+	if err := a.clientSet.call(kt, ecsProduct, "DescribeDisks", params, resp); err != nil {
+		logs.Errorf("list aliyun disk failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun disk failed, err: %v", err)
+	}
+
+	return &disk.AliyunDiskListResult{TotalCount: resp.TotalCount, Details: resp.Disks.Disk}, nil
+}
+
+// DeleteDisk 释放按量付费的数据盘
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-deletedisk
+func (a *Aliyun) DeleteDisk(kt *kit.Kit, opt *disk.AliyunDiskDeleteOption) error {
+	if opt == nil {
+		return fmt.Errorf("delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{"DiskId": opt.CloudID}
+	if err := a.clientSet.call(kt, ecsProduct, "DeleteDisk", params, nil); err != nil {
+		logs.Errorf("delete aliyun disk failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return fmt.Errorf("delete aliyun disk failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// AttachDisk 挂载数据盘到实例
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-attachdisk
+func (a *Aliyun) AttachDisk(kt *kit.Kit, opt *disk.AliyunDiskAttachOption) error {
+	if opt == nil {
+		return fmt.Errorf("attach option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{
+		"InstanceId":         opt.CloudCvmID,
+		"DiskId":             opt.CloudDiskID,
+		"DeleteWithInstance": strconv.FormatBool(opt.DeleteWithInstance),
+	}
+	if err := a.clientSet.call(kt, ecsProduct, "AttachDisk", params, nil); err != nil {
+		logs.Errorf("attach aliyun disk failed, err: %v, disk: %s, cvm: %s, rid: %s", err, opt.CloudDiskID,
+			opt.CloudCvmID, kt.Rid)
+		return fmt.Errorf("attach aliyun disk failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// DetachDisk 从实例卸载数据盘
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-detachdisk
+func (a *Aliyun) DetachDisk(kt *kit.Kit, opt *disk.AliyunDiskDetachOption) error {
+	if opt == nil {
+		return fmt.Errorf("detach option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{
+		"InstanceId": opt.CloudCvmID,
+		"DiskId":     opt.CloudDiskID,
+	}
+	if err := a.clientSet.call(kt, ecsProduct, "DetachDisk", params, nil); err != nil {
+		logs.Errorf("detach aliyun disk failed, err: %v, disk: %s, cvm: %s, rid: %s", err, opt.CloudDiskID,
+			opt.CloudCvmID, kt.Rid)
+		return fmt.Errorf("detach aliyun disk failed, err: %v", err)
+	}
+
+	return nil
+}
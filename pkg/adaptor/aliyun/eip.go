@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// eipIDQueryLimit DescribeEipAddresses 接口单次按ID查询的最大数量
+const eipIDQueryLimit = 50
+
+type describeEipAddressesResp struct {
+	TotalCount   int64 `json:"TotalCount"`
+	EipAddresses struct {
+		EipAddress []*eip.AliyunEip `json:"EipAddress"`
+	} `json:"EipAddresses"`
+}
+
+// ListEip 查询弹性公网IP列表
+// reference: https://help.aliyun.com/zh/eip/developer-reference/api-vpc-2016-04-28-describeeipaddresses-eips
+func (a *Aliyun) ListEip(kt *kit.Kit, opt *eip.AliyunEipListOption) (*eip.AliyunEipListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.CloudIDs) == 0 {
+		params := map[string]string{"RegionId": opt.Region}
+		if len(opt.Ips) != 0 {
+			params["EipAddress"] = strings.Join(opt.Ips, ",")
+		}
+		setPage(params, opt.Page)
+		return a.describeEipAddresses(kt, params)
+	}
+
+	result := &eip.AliyunEipListResult{Details: make([]*eip.AliyunEip, 0, len(opt.CloudIDs))}
+	for _, ids := range slice.Split(opt.CloudIDs, eipIDQueryLimit) {
+		params := map[string]string{
+			"RegionId":     opt.Region,
+			"AllocationId": strings.Join(ids, ","),
+			"PageSize":     strconv.Itoa(core.AliyunIDQueryLimit),
+		}
+		one, err := a.describeEipAddresses(kt, params)
+		if err != nil {
+			return nil, err
+		}
+		result.Details = append(result.Details, one.Details...)
+	}
+	result.TotalCount = int64(len(result.Details))
+
+	return result, nil
+}
+
+func (a *Aliyun) describeEipAddresses(kt *kit.Kit, params map[string]string) (*eip.AliyunEipListResult, error) {
+	resp := new(describeEipAddressesResp)
+	if err := a.clientSet.call(kt, vpcProduct, "DescribeEipAddresses", params, resp); err != nil {
+		logs.Errorf("list aliyun eip failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun eip failed, err: %v", err)
+	}
+
+	return &eip.AliyunEipListResult{TotalCount: resp.TotalCount, Details: resp.EipAddresses.EipAddress}, nil
+}
+
+// DeleteEip 释放弹性公网IP
+// reference: https://help.aliyun.com/zh/eip/developer-reference/api-vpc-2016-04-28-releaseeipaddress-eips
+func (a *Aliyun) DeleteEip(kt *kit.Kit, opt *eip.AliyunEipDeleteOption) error {
+	if opt == nil {
+		return fmt.Errorf("delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	params := map[string]string{"RegionId": opt.Region, "AllocationId": opt.CloudID}
+	if err := a.clientSet.call(kt, vpcProduct, "ReleaseEipAddress", params, nil); err != nil {
+		logs.Errorf("release aliyun eip failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return fmt.Errorf("release aliyun eip failed, err: %v", err)
+	}
+
+	return nil
+}
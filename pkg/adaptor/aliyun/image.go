@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+type aliyunImage struct {
+	ImageID      string `json:"ImageId"`
+	ImageName    string `json:"ImageName"`
+	Architecture string `json:"Architecture"`
+	Platform     string `json:"Platform"`
+	Status       string `json:"Status"`
+	OSType       string `json:"OSType"`
+	ImageFamily  string `json:"ImageFamily"`
+	// Size 镜像大小，单位：GiB。
+	Size            uint64 `json:"Size"`
+	ImageOwnerAlias string `json:"ImageOwnerAlias"`
+}
+
+// ListImage 查询镜像列表
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-describeimages
+func (a *Aliyun) ListImage(kt *kit.Kit, opt *image.AliyunImageListOption) (*image.AliyunImageListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{"RegionId": opt.Region}
+	if len(opt.ImageOwnerAlias) != 0 {
+		params["ImageOwnerAlias"] = opt.ImageOwnerAlias
+	}
+	if len(opt.CloudIDs) != 0 {
+		params["ImageId"] = strings.Join(opt.CloudIDs, ",")
+		params["PageSize"] = strconv.Itoa(core.AliyunIDQueryLimit)
+	}
+	setPage(params, opt.Page)
+
+	resp := new(struct {
+		TotalCount int64 `json:"TotalCount"`
+		Images     struct {
+			Image []aliyunImage `json:"Image"`
+		} `json:"Images"`
+	})
+	if err := a.clientSet.call(kt, ecsProduct, "DescribeImages", params, resp); err != nil {
+		logs.Errorf("list aliyun image failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun image failed, err: %v", err)
+	}
+
+	details := make([]image.AliyunImage, 0, len(resp.Images.Image))
+	for _, one := range resp.Images.Image {
+		details = append(details, image.AliyunImage{
+			CloudID:      one.ImageID,
+			Name:         one.ImageName,
+			Architecture: one.Architecture,
+			Platform:     one.Platform,
+			State:        one.Status,
+			Type:         one.ImageOwnerAlias,
+			OsType:       image.GetOsTypeByPlatform(enumor.Aliyun, one.OSType),
+			Region:       opt.Region,
+			ImageFamily:  one.ImageFamily,
+			ImageSize:    one.Size,
+		})
+	}
+
+	return &image.AliyunImageListResult{TotalCount: resp.TotalCount, Details: details}, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListSecurityGroup 查询安全组列表
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-describesecuritygroups
+func (a *Aliyun) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AliyunListOption) (
+	*securitygroup.AliyunListResult, error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := new(struct {
+		TotalCount     int64 `json:"TotalCount"`
+		SecurityGroups struct {
+			SecurityGroup []securitygroup.AliyunSG `json:"SecurityGroup"`
+		} `json:"SecurityGroups"`
+	})
+
+	params := listParams(opt.AliyunListOption, "SecurityGroupIds")
+	if err := a.clientSet.call(kt, ecsProduct, "DescribeSecurityGroups", params, resp); err != nil {
+		logs.Errorf("list aliyun security group failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun security group failed, err: %v", err)
+	}
+
+	return &securitygroup.AliyunListResult{
+		TotalCount: resp.TotalCount,
+		Details:    resp.SecurityGroups.SecurityGroup,
+	}, nil
+}
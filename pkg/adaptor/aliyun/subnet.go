@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+type aliyunVSwitch struct {
+	VSwitchID               string `json:"VSwitchId"`
+	VSwitchName             string `json:"VSwitchName"`
+	VpcID                   string `json:"VpcId"`
+	ZoneID                  string `json:"ZoneId"`
+	Status                  string `json:"Status"`
+	CidrBlock               string `json:"CidrBlock"`
+	Ipv6CidrBlock           string `json:"Ipv6CidrBlock"`
+	IsDefault               bool   `json:"IsDefault"`
+	AvailableIpAddressCount int64  `json:"AvailableIpAddressCount"`
+	NetworkAclID            string `json:"NetworkAclId"`
+	Description             string `json:"Description"`
+	RouteTable              struct {
+		RouteTableID string `json:"RouteTableId"`
+	} `json:"RouteTable"`
+}
+
+type describeVSwitchesResp struct {
+	TotalCount int64 `json:"TotalCount"`
+	VSwitches  struct {
+		VSwitch []aliyunVSwitch `json:"VSwitch"`
+	} `json:"VSwitches"`
+}
+
+// ListSubnet 查询交换机列表，交换机接口按ID查询时仅支持单个ID，所以按ID逐个查询。
+// reference: https://help.aliyun.com/zh/vpc/developer-reference/api-vpc-2016-04-28-describevswitches
+func (a *Aliyun) ListSubnet(kt *kit.Kit, opt *adtysubnet.AliyunSubnetListOption) (
+	*adtysubnet.AliyunSubnetListResult, error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.CloudIDs) == 0 {
+		params := map[string]string{"RegionId": opt.Region}
+		if len(opt.CloudVpcID) != 0 {
+			params["VpcId"] = opt.CloudVpcID
+		}
+		setPage(params, opt.Page)
+		return a.describeVSwitches(kt, opt.Region, params)
+	}
+
+	result := &adtysubnet.AliyunSubnetListResult{Details: make([]adtysubnet.AliyunSubnet, 0, len(opt.CloudIDs))}
+	for _, id := range opt.CloudIDs {
+		params := map[string]string{"RegionId": opt.Region, "VSwitchId": id}
+		one, err := a.describeVSwitches(kt, opt.Region, params)
+		if err != nil {
+			return nil, err
+		}
+		result.Details = append(result.Details, one.Details...)
+	}
+	result.TotalCount = int64(len(result.Details))
+
+	return result, nil
+}
+
+func (a *Aliyun) describeVSwitches(kt *kit.Kit, region string, params map[string]string) (
+	*adtysubnet.AliyunSubnetListResult, error) {
+
+	resp := new(describeVSwitchesResp)
+	if err := a.clientSet.call(kt, vpcProduct, "DescribeVSwitches", params, resp); err != nil {
+		logs.Errorf("list aliyun vswitch failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun vswitch failed, err: %v", err)
+	}
+
+	details := make([]adtysubnet.AliyunSubnet, 0, len(resp.VSwitches.VSwitch))
+	for _, one := range resp.VSwitches.VSwitch {
+		details = append(details, convertSubnet(one, region))
+	}
+
+	return &adtysubnet.AliyunSubnetListResult{TotalCount: resp.TotalCount, Details: details}, nil
+}
+
+func convertSubnet(data aliyunVSwitch, region string) adtysubnet.AliyunSubnet {
+	subnet := adtysubnet.AliyunSubnet{
+		CloudVpcID: data.VpcID,
+		CloudID:    data.VSwitchID,
+		Name:       data.VSwitchName,
+		Region:     region,
+		Extension: &adtysubnet.AliyunSubnetExtension{
+			Zone:                    data.ZoneID,
+			Status:                  data.Status,
+			IsDefault:               data.IsDefault,
+			AvailableIpAddressCount: data.AvailableIpAddressCount,
+			CloudNetworkAclID:       data.NetworkAclID,
+			CloudRouteTableID:       data.RouteTable.RouteTableID,
+		},
+	}
+
+	if len(data.Description) != 0 {
+		subnet.Memo = &data.Description
+	}
+
+	if len(data.CidrBlock) != 0 {
+		subnet.Ipv4Cidr = []string{data.CidrBlock}
+	}
+
+	if len(data.Ipv6CidrBlock) != 0 {
+		subnet.Ipv6Cidr = []string{data.Ipv6CidrBlock}
+	}
+
+	return subnet
+}
@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// vpcIDQueryLimit DescribeVpcs 接口单次按ID查询的最大数量
+const vpcIDQueryLimit = 20
+
+type aliyunVpc struct {
+	VpcID           string `json:"VpcId"`
+	VpcName         string `json:"VpcName"`
+	RegionID        string `json:"RegionId"`
+	Status          string `json:"Status"`
+	CidrBlock       string `json:"CidrBlock"`
+	Ipv6CidrBlock   string `json:"Ipv6CidrBlock"`
+	IsDefault       bool   `json:"IsDefault"`
+	VRouterID       string `json:"VRouterId"`
+	ResourceGroupID string `json:"ResourceGroupId"`
+	Description     string `json:"Description"`
+	SecondaryCidrs  struct {
+		SecondaryCidrBlock []string `json:"SecondaryCidrBlock"`
+	} `json:"SecondaryCidrBlocks"`
+}
+
+type describeVpcsResp struct {
+	TotalCount int64 `json:"TotalCount"`
+	Vpcs       struct {
+		Vpc []aliyunVpc `json:"Vpc"`
+	} `json:"Vpcs"`
+}
+
+// ListVpc 查询专有网络列表
+// reference: https://help.aliyun.com/zh/vpc/developer-reference/api-vpc-2016-04-28-describevpcs
+func (a *Aliyun) ListVpc(kt *kit.Kit, opt *types.AliyunVpcListOption) (*types.AliyunVpcListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.CloudIDs) == 0 {
+		params := map[string]string{"RegionId": opt.Region}
+		setPage(params, opt.Page)
+		return a.describeVpcs(kt, params)
+	}
+
+	result := &types.AliyunVpcListResult{Details: make([]types.AliyunVpc, 0, len(opt.CloudIDs))}
+	for _, ids := range slice.Split(opt.CloudIDs, vpcIDQueryLimit) {
+		params := map[string]string{
+			"RegionId": opt.Region,
+			"VpcId":    strings.Join(ids, ","),
+			"PageSize": strconv.Itoa(core.AliyunQueryLimit),
+		}
+		one, err := a.describeVpcs(kt, params)
+		if err != nil {
+			return nil, err
+		}
+		result.Details = append(result.Details, one.Details...)
+	}
+	result.TotalCount = int64(len(result.Details))
+
+	return result, nil
+}
+
+func (a *Aliyun) describeVpcs(kt *kit.Kit, params map[string]string) (*types.AliyunVpcListResult, error) {
+	resp := new(describeVpcsResp)
+	if err := a.clientSet.call(kt, vpcProduct, "DescribeVpcs", params, resp); err != nil {
+		logs.Errorf("list aliyun vpc failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list aliyun vpc failed, err: %v", err)
+	}
+
+	details := make([]types.AliyunVpc, 0, len(resp.Vpcs.Vpc))
+	for _, one := range resp.Vpcs.Vpc {
+		details = append(details, convertVpc(one))
+	}
+
+	return &types.AliyunVpcListResult{TotalCount: resp.TotalCount, Details: details}, nil
+}
+
+func convertVpc(data aliyunVpc) types.AliyunVpc {
+	vpc := types.AliyunVpc{
+		CloudID: data.VpcID,
+		Name:    data.VpcName,
+		Region:  data.RegionID,
+		Extension: &cloud.AliyunVpcExtension{
+			Cidr:            make([]cloud.AliyunCidr, 0),
+			Status:          data.Status,
+			IsDefault:       data.IsDefault,
+			VRouterID:       data.VRouterID,
+			ResourceGroupID: data.ResourceGroupID,
+		},
+	}
+
+	if len(data.Description) != 0 {
+		vpc.Memo = &data.Description
+	}
+
+	cidrs := append([]string{data.CidrBlock}, data.SecondaryCidrs.SecondaryCidrBlock...)
+	for _, cidr := range cidrs {
+		if len(cidr) == 0 {
+			continue
+		}
+		vpc.Extension.Cidr = append(vpc.Extension.Cidr, cloud.AliyunCidr{Type: enumor.Ipv4, Cidr: cidr})
+	}
+
+	if len(data.Ipv6CidrBlock) != 0 {
+		vpc.Extension.Cidr = append(vpc.Extension.Cidr, cloud.AliyunCidr{Type: enumor.Ipv6, Cidr: data.Ipv6CidrBlock})
+	}
+
+	return vpc
+}
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aliyun
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListZone 查询地域下的可用区
+// reference: https://help.aliyun.com/zh/ecs/developer-reference/api-ecs-2014-05-26-describezones
+func (a *Aliyun) ListZone(kt *kit.Kit, opt *zone.AliyunZoneListOption) ([]zone.AliyunZone, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := new(struct {
+		Zones struct {
+			Zone []zone.AliyunZone `json:"Zone"`
+		} `json:"Zones"`
+	})
+
+	params := map[string]string{
+		"RegionId":       opt.Region,
+		"AcceptLanguage": "zh-CN",
+		"Verbose":        "false",
+	}
+	if err := a.clientSet.call(kt, ecsProduct, "DescribeZones", params, resp); err != nil {
+		logs.Errorf("list aliyun zone failed, err: %v, region: %s, rid: %s", err, opt.Region, kt.Rid)
+		return nil, fmt.Errorf("list aliyun zone failed, err: %v", err)
+	}
+
+	return resp.Zones.Zone, nil
+}
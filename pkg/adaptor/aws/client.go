@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	curservice "github.com/aws/aws-sdk-go/service/costandusagereportservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/organizations"
@@ -215,3 +216,20 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 
 	return cloudformation.New(sess, aws.NewConfig().WithRegion(region)), nil
 }
+
+func (c *clientSet) cloudWatchClient(region string) (*cloudwatch.CloudWatch, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudwatch.New(sess), nil
+}
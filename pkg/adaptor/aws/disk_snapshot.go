@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	disksnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// CreateDiskSnapshot 创建云硬盘快照
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+func (a *Aws) CreateDiskSnapshot(kt *kit.Kit, opt *disksnapshot.AwsCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "aws disk snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.CreateSnapshotInput{VolumeId: aws.String(opt.CloudDiskID)}
+	if len(opt.Name) != 0 {
+		req.TagSpecifications = []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(opt.Name)}},
+		}}
+	}
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws disk snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.SnapshotId), nil
+}
+
+// ListDiskSnapshot 查询当前账号拥有的云硬盘快照
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeSnapshots.html
+func (a *Aws) ListDiskSnapshot(kt *kit.Kit, opt *disksnapshot.AwsListOption) (*disksnapshot.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "aws disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String("self")}}
+	if len(opt.CloudIDs) != 0 {
+		req.SnapshotIds = aws.StringSlice(opt.CloudIDs)
+	} else if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	}
+	if len(opt.CloudDiskIDs) != 0 {
+		req.Filters = []*ec2.Filter{{Name: aws.String("volume-id"), Values: aws.StringSlice(opt.CloudDiskIDs)}}
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]disksnapshot.AwsSnapshot, 0, len(resp.Snapshots))
+	for _, one := range resp.Snapshots {
+		details = append(details, disksnapshot.AwsSnapshot{Snapshot: one})
+	}
+
+	return &disksnapshot.AwsListResult{NextToken: resp.NextToken, Details: details}, nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteSnapshot.html
+func (a *Aws) DeleteDiskSnapshot(kt *kit.Kit, opt *disksnapshot.AwsDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "aws disk snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws ec2 client failed, err: %v", err)
+	}
+
+	req := &ec2.DeleteSnapshotInput{SnapshotId: aws.String(opt.CloudID)}
+	if _, err = client.DeleteSnapshotWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws disk snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
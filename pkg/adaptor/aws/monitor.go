@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+type awsMetric struct {
+	namespace string
+	name      string
+	stat      string
+	// toMbps 指标单位为字节数总和，需要换算为Mbps
+	toMbps bool
+}
+
+// awsCvmMetricMap hcm指标与aws主机指标的对应关系，内存指标需要实例安装CloudWatch Agent
+var awsCvmMetricMap = map[typemonitor.MetricName]awsMetric{
+	typemonitor.CpuUsage:   {namespace: "AWS/EC2", name: "CPUUtilization", stat: cloudwatch.StatisticAverage},
+	typemonitor.MemUsage:   {namespace: "CWAgent", name: "mem_used_percent", stat: cloudwatch.StatisticAverage},
+	typemonitor.NetInRate:  {namespace: "AWS/EC2", name: "NetworkIn", stat: cloudwatch.StatisticSum, toMbps: true},
+	typemonitor.NetOutRate: {namespace: "AWS/EC2", name: "NetworkOut", stat: cloudwatch.StatisticSum, toMbps: true},
+}
+
+// GetCvmMetric 查询主机监控数据
+// reference: https://docs.aws.amazon.com/AmazonCloudWatch/latest/APIReference/API_GetMetricData.html
+func (a *Aws) GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get cvm metric option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metric, ok := awsCvmMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	client, err := a.clientSet.cloudWatchClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new cloud watch client failed, err: %v", err)
+	}
+
+	queries := make([]*cloudwatch.MetricDataQuery, 0, len(opt.CloudIDs))
+	for idx, id := range opt.CloudIDs {
+		queries = append(queries, &cloudwatch.MetricDataQuery{
+			Id: aws.String("m" + strconv.Itoa(idx)),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					Namespace:  aws.String(metric.namespace),
+					MetricName: aws.String(metric.name),
+					Dimensions: []*cloudwatch.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(id)}},
+				},
+				Period: aws.Int64(opt.Period),
+				Stat:   aws.String(metric.stat),
+			},
+			ReturnData: aws.Bool(true),
+		})
+	}
+
+	req := &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         aws.Time(opt.StartTime),
+		EndTime:           aws.Time(opt.EndTime),
+		ScanBy:            aws.String(cloudwatch.ScanByTimestampAscending),
+	}
+
+	pointMap := make(map[string][]typemonitor.MetricPoint)
+	err = client.GetMetricDataPagesWithContext(kt.Ctx, req, func(page *cloudwatch.GetMetricDataOutput, _ bool) bool {
+		metric.parsePage(page, opt.CloudIDs, opt.Period, pointMap)
+		return true
+	})
+	if err != nil {
+		logs.Errorf("get aws metric data failed, err: %v, metric: %s, rid: %s", err, metric.name, kt.Rid)
+		return nil, err
+	}
+
+	data := make([]typemonitor.MetricData, 0, len(pointMap))
+	for _, id := range opt.CloudIDs {
+		if points, exist := pointMap[id]; exist {
+			data = append(data, typemonitor.MetricData{CloudID: id, Points: points})
+		}
+	}
+
+	return data, nil
+}
+
+// parsePage 解析一页查询结果的数据点，查询结果的ID为 m+实例在cloudIDs中的下标
+func (m awsMetric) parsePage(page *cloudwatch.GetMetricDataOutput, cloudIDs []string, period int64,
+	pointMap map[string][]typemonitor.MetricPoint) {
+
+	for _, result := range page.MetricDataResults {
+		if result == nil || result.Id == nil {
+			continue
+		}
+
+		idx, err := strconv.Atoi(strings.TrimPrefix(*result.Id, "m"))
+		if err != nil || idx < 0 || idx >= len(cloudIDs) {
+			continue
+		}
+
+		cloudID := cloudIDs[idx]
+		for i, ts := range result.Timestamps {
+			if ts == nil || i >= len(result.Values) || result.Values[i] == nil {
+				continue
+			}
+
+			value := *result.Values[i]
+			if m.toMbps {
+				value = value * 8 / float64(period) / 1e6
+			}
+			pointMap[cloudID] = append(pointMap[cloudID], typemonitor.MetricPoint{Timestamp: ts.Unix(),
+				Value: value})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+func TestParsePage(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	page := &cloudwatch.GetMetricDataOutput{
+		MetricDataResults: []*cloudwatch.MetricDataResult{
+			{Id: aws.String("m1"), Timestamps: []*time.Time{aws.Time(ts)}, Values: []*float64{aws.Float64(7.5e6)}},
+			{Id: aws.String("m0"), Timestamps: []*time.Time{aws.Time(ts), aws.Time(ts.Add(time.Minute))},
+				Values: []*float64{aws.Float64(3.75e6), nil}},
+			// 不属于查询实例的结果被忽略
+			{Id: aws.String("m2"), Timestamps: []*time.Time{aws.Time(ts)}, Values: []*float64{aws.Float64(1)}},
+			{Id: aws.String("x"), Timestamps: []*time.Time{aws.Time(ts)}, Values: []*float64{aws.Float64(1)}},
+			nil,
+		},
+	}
+
+	pointMap := make(map[string][]typemonitor.MetricPoint)
+	awsCvmMetricMap[typemonitor.NetInRate].parsePage(page, []string{"i-0", "i-1"}, 60, pointMap)
+
+	// 周期内的字节总数换算为Mbps: bytes * 8 / 60 / 1e6
+	expect := map[string][]typemonitor.MetricPoint{
+		"i-0": {{Timestamp: 1700000000, Value: 0.5}},
+		"i-1": {{Timestamp: 1700000000, Value: 1}},
+	}
+	if !reflect.DeepEqual(pointMap, expect) {
+		t.Errorf("unexpected points: %+v", pointMap)
+	}
+
+	pointMap = make(map[string][]typemonitor.MetricPoint)
+	awsCvmMetricMap[typemonitor.CpuUsage].parsePage(page, []string{"i-0", "i-1"}, 60, pointMap)
+	if pointMap["i-1"][0].Value != 7.5e6 {
+		t.Errorf("cpu usage should not be converted, got %+v", pointMap)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -144,6 +145,15 @@ func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error
 }
 
 // newClientSecretCredential ...
+func (c *clientSet) armClient() (*arm.Client, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	return arm.NewClient("hcm/monitor", "v1.0.0", credential, nil)
+}
+
 func (c *clientSet) newClientSecretCredential() (*azidentity.ClientSecretCredential, error) {
 	return azidentity.NewClientSecretCredential(
 		c.credential.CloudTenantID,
@@ -0,0 +1,158 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const azureMonitorAPIVersion = "2018-01-01"
+
+type azureMetric struct {
+	name        string
+	aggregation string
+	// toMbps 指标为统计周期内的字节总数，需要换算为Mbps
+	toMbps bool
+}
+
+// azureCvmMetricMap hcm指标与azure虚拟机指标的对应关系，azure平台指标不提供内存利用率
+var azureCvmMetricMap = map[typemonitor.MetricName]azureMetric{
+	typemonitor.CpuUsage:   {name: "Percentage CPU", aggregation: "Average"},
+	typemonitor.NetInRate:  {name: "Network In Total", aggregation: "Total", toMbps: true},
+	typemonitor.NetOutRate: {name: "Network Out Total", aggregation: "Total", toMbps: true},
+}
+
+// GetCvmMetric 查询虚拟机监控数据，azure的CloudID为资源的完整ID，需逐个资源查询
+// reference: https://learn.microsoft.com/en-us/rest/api/monitor/metrics/list
+func (az *Azure) GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get cvm metric option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metric, ok := azureCvmMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	data := make([]typemonitor.MetricData, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		points, err := az.listResourceMetric(kt, id, metric, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, typemonitor.MetricData{CloudID: id, Points: points})
+	}
+
+	return data, nil
+}
+
+func (az *Azure) listResourceMetric(kt *kit.Kit, resourceID string, metric azureMetric,
+	opt *typemonitor.GetMetricOption) ([]typemonitor.MetricPoint, error) {
+
+	client, err := az.clientSet.armClient()
+	if err != nil {
+		return nil, fmt.Errorf("new arm client failed, err: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("api-version", azureMonitorAPIVersion)
+	query.Set("metricnames", metric.name)
+	query.Set("aggregation", metric.aggregation)
+	query.Set("interval", fmt.Sprintf("PT%dM", opt.Period/60))
+	query.Set("timespan", opt.StartTime.UTC().Format(time.RFC3339)+"/"+opt.EndTime.UTC().Format(time.RFC3339))
+
+	endpoint := runtime.JoinPaths(client.Endpoint(), resourceID, "/providers/Microsoft.Insights/metrics")
+	req, err := runtime.NewRequest(kt.Ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req.Raw().URL.RawQuery = query.Encode()
+	req.Raw().Header.Set("Accept", "application/json")
+
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		logs.Errorf("request azure monitor failed, err: %v, resource: %s, rid: %s", err, resourceID, kt.Rid)
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	result := new(azureMetricResp)
+	if err = runtime.UnmarshalAsJSON(resp, result); err != nil {
+		return nil, err
+	}
+
+	return metric.parseResp(result, opt.Period), nil
+}
+
+// parseResp 解析指标查询结果，字节总数类指标按统计周期换算为Mbps
+func (m azureMetric) parseResp(result *azureMetricResp, period int64) []typemonitor.MetricPoint {
+	points := make([]typemonitor.MetricPoint, 0)
+	for _, one := range result.Value {
+		for _, series := range one.Timeseries {
+			for _, point := range series.Data {
+				value := point.Average
+				if m.toMbps {
+					value = point.Total
+				}
+				if value == nil {
+					continue
+				}
+
+				val := *value
+				if m.toMbps {
+					val = val * 8 / float64(period) / 1e6
+				}
+				points = append(points, typemonitor.MetricPoint{Timestamp: point.TimeStamp.Unix(), Value: val})
+			}
+		}
+	}
+
+	return points
+}
+
+type azureMetricResp struct {
+	Value []struct {
+		Timeseries []struct {
+			Data []struct {
+				TimeStamp time.Time `json:"timeStamp"`
+				Average   *float64  `json:"average"`
+				Total     *float64  `json:"total"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"value"`
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+)
+
+func TestParseResp(t *testing.T) {
+	body := []byte(`{"value": [{"name": {"value": "Network In Total"}, "timeseries": [{"data": [
+		{"timeStamp": "2023-11-14T22:13:00Z", "total": 3750000},
+		{"timeStamp": "2023-11-14T22:18:00Z", "average": 10},
+		{"timeStamp": "2023-11-14T22:23:00Z"}
+	]}]}]}`)
+	result := new(azureMetricResp)
+	if err := json.Unmarshal(body, result); err != nil {
+		t.Fatal(err)
+	}
+
+	// 字节总数指标只取total，并按周期换算为Mbps
+	points := azureCvmMetricMap[typemonitor.NetInRate].parseResp(result, 60)
+	expect := []typemonitor.MetricPoint{{Timestamp: 1700000000 - 20, Value: 0.5}}
+	if !reflect.DeepEqual(points, expect) {
+		t.Errorf("unexpected network points: %+v", points)
+	}
+
+	// 百分比指标只取average
+	points = azureCvmMetricMap[typemonitor.CpuUsage].parseResp(result, 60)
+	expect = []typemonitor.MetricPoint{{Timestamp: 1700000000 + 280, Value: 10}}
+	if !reflect.DeepEqual(points, expect) {
+		t.Errorf("unexpected cpu points: %+v", points)
+	}
+}
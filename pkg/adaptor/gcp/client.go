@@ -32,6 +32,7 @@ import (
 	res "google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/compute/v1"
 	iam "google.golang.org/api/iam/v1"
+	monitoring "google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/option"
 )
 
@@ -113,3 +114,13 @@ func (c *clientSet) billingClient(kt *kit.Kit) (*cloudbilling.APIService, error)
 	}
 	return service, nil
 }
+
+func (c *clientSet) monitoringClient(kt *kit.Kit) (*monitoring.Service, error) {
+	opt := option.WithCredentialsJSON(c.credential.Json)
+	service, err := monitoring.NewService(kt.Ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+type gcpMetric struct {
+	metricType string
+	aligner    string
+	// scale 换算系数，将gcp指标单位换算为hcm指标单位
+	scale float64
+}
+
+// gcpCvmMetricMap hcm指标与gcp主机指标的对应关系，内存指标需要实例安装Ops Agent
+var gcpCvmMetricMap = map[typemonitor.MetricName]gcpMetric{
+	typemonitor.CpuUsage: {metricType: "compute.googleapis.com/instance/cpu/utilization", aligner: "ALIGN_MEAN",
+		scale: 100},
+	typemonitor.MemUsage: {metricType: "agent.googleapis.com/memory/percent_used", aligner: "ALIGN_MEAN", scale: 1},
+	typemonitor.NetInRate: {metricType: "compute.googleapis.com/instance/network/received_bytes_count",
+		aligner: "ALIGN_RATE", scale: 8 / 1e6},
+	typemonitor.NetOutRate: {metricType: "compute.googleapis.com/instance/network/sent_bytes_count",
+		aligner: "ALIGN_RATE", scale: 8 / 1e6},
+}
+
+// GetCvmMetric 查询主机监控数据
+// reference: https://cloud.google.com/monitoring/api/ref_v3/rest/v3/projects.timeSeries/list
+func (g *Gcp) GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get cvm metric option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metric, ok := gcpCvmMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	client, err := g.clientSet.monitoringClient(kt)
+	if err != nil {
+		return nil, fmt.Errorf("new monitoring client failed, err: %v", err)
+	}
+
+	ids := make([]string, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		ids = append(ids, fmt.Sprintf("%q", id))
+	}
+	filter := fmt.Sprintf(`metric.type = "%s" AND resource.labels.instance_id = one_of(%s)`, metric.metricType,
+		strings.Join(ids, ","))
+
+	call := client.Projects.TimeSeries.List("projects/" + g.CloudProjectID()).
+		Filter(filter).
+		IntervalStartTime(opt.StartTime.UTC().Format(time.RFC3339)).
+		IntervalEndTime(opt.EndTime.UTC().Format(time.RFC3339)).
+		AggregationAlignmentPeriod(fmt.Sprintf("%ds", opt.Period)).
+		AggregationPerSeriesAligner(metric.aligner)
+
+	pointMap := make(map[string][]typemonitor.MetricPoint)
+	err = call.Pages(kt.Ctx, func(page *monitoring.ListTimeSeriesResponse) error {
+		metric.parsePage(page, pointMap)
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list gcp time series failed, err: %v, metric: %s, rid: %s", err, metric.metricType, kt.Rid)
+		return nil, err
+	}
+
+	data := make([]typemonitor.MetricData, 0, len(pointMap))
+	for _, id := range opt.CloudIDs {
+		if points, exist := pointMap[id]; exist {
+			data = append(data, typemonitor.MetricData{CloudID: id, Points: points})
+		}
+	}
+
+	return data, nil
+}
+
+// parsePage 解析一页时间序列的数据点，实例ID取自资源的instance_id标签，时间取统计区间的结束时间
+func (m gcpMetric) parsePage(page *monitoring.ListTimeSeriesResponse, pointMap map[string][]typemonitor.MetricPoint) {
+	for _, series := range page.TimeSeries {
+		if series == nil || series.Resource == nil {
+			continue
+		}
+
+		cloudID := series.Resource.Labels["instance_id"]
+		for _, point := range series.Points {
+			if point == nil || point.Value == nil || point.Value.DoubleValue == nil || point.Interval == nil {
+				continue
+			}
+
+			ts, err := time.Parse(time.RFC3339, point.Interval.EndTime)
+			if err != nil {
+				continue
+			}
+			pointMap[cloudID] = append(pointMap[cloudID], typemonitor.MetricPoint{Timestamp: ts.Unix(),
+				Value: *point.Value.DoubleValue * m.scale})
+		}
+	}
+}
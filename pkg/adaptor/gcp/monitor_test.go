@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"reflect"
+	"testing"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+func TestParsePage(t *testing.T) {
+	value := func(v float64) *monitoring.TypedValue { return &monitoring.TypedValue{DoubleValue: &v} }
+	page := &monitoring.ListTimeSeriesResponse{
+		TimeSeries: []*monitoring.TimeSeries{
+			{
+				Resource: &monitoring.MonitoredResource{Labels: map[string]string{"instance_id": "111"}},
+				Points: []*monitoring.Point{
+					{Interval: &monitoring.TimeInterval{EndTime: "2023-11-14T22:13:20Z"}, Value: value(0.25)},
+					// 时间格式错误或没有数值的数据点被忽略
+					{Interval: &monitoring.TimeInterval{EndTime: "invalid"}, Value: value(0.5)},
+					{Interval: &monitoring.TimeInterval{EndTime: "2023-11-14T22:14:20Z"},
+						Value: &monitoring.TypedValue{}},
+				},
+			},
+			{Points: []*monitoring.Point{{Interval: &monitoring.TimeInterval{EndTime: "2023-11-14T22:13:20Z"}}}},
+			nil,
+		},
+	}
+
+	// cpu利用率为0-1的小数，换算为百分比
+	pointMap := make(map[string][]typemonitor.MetricPoint)
+	gcpCvmMetricMap[typemonitor.CpuUsage].parsePage(page, pointMap)
+	expect := map[string][]typemonitor.MetricPoint{"111": {{Timestamp: 1700000000, Value: 25}}}
+	if !reflect.DeepEqual(pointMap, expect) {
+		t.Errorf("unexpected points: %+v", pointMap)
+	}
+
+	// 网络指标为Byte/s，换算为Mbps
+	pointMap = make(map[string][]typemonitor.MetricPoint)
+	page.TimeSeries[0].Points[0].Value = value(250000)
+	gcpCvmMetricMap[typemonitor.NetOutRate].parsePage(page, pointMap)
+	if got := pointMap["111"][0].Value; got != 2 {
+		t.Errorf("unexpected network rate: %v", got)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	disksnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// CreateDiskSnapshot 创建云硬盘快照
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2003.html
+func (h *HuaWei) CreateDiskSnapshot(kt *kit.Kit, opt *disksnapshot.HuaWeiCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "huawei disk snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	snapshotOpt := &model.CreateSnapshotOption{VolumeId: opt.CloudDiskID, Force: converter.ValToPtr(true)}
+	if len(opt.Name) != 0 {
+		snapshotOpt.Name = converter.ValToPtr(opt.Name)
+	}
+	req := &model.CreateSnapshotRequest{Body: &model.CreateSnapshotRequestBody{Snapshot: snapshotOpt}}
+
+	resp, err := client.CreateSnapshot(req)
+	if err != nil {
+		logs.Errorf("create huawei disk snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	if resp.Snapshot == nil {
+		return "", errf.New(errf.Unknown, "huawei create snapshot return empty snapshot")
+	}
+
+	return converter.PtrToVal(resp.Snapshot.Id), nil
+}
+
+// ListDiskSnapshot 查询云硬盘快照列表
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2006.html
+func (h *HuaWei) ListDiskSnapshot(kt *kit.Kit, opt *disksnapshot.HuaWeiListOption) (
+	[]disksnapshot.HuaWeiSnapshot, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "huawei disk snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.ListSnapshotsRequest{
+		Offset: converter.ValToPtr(opt.Offset),
+		Limit:  converter.ValToPtr(opt.Limit),
+	}
+	if len(opt.CloudID) != 0 {
+		req.Id = converter.ValToPtr(opt.CloudID)
+	}
+	if len(opt.CloudDiskID) != 0 {
+		req.VolumeId = converter.ValToPtr(opt.CloudDiskID)
+	}
+
+	resp, err := client.ListSnapshots(req)
+	if err != nil {
+		logs.Errorf("list huawei disk snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	snapshots := make([]disksnapshot.HuaWeiSnapshot, 0)
+	if resp.Snapshots == nil {
+		return snapshots, nil
+	}
+
+	for _, one := range *resp.Snapshots {
+		snapshots = append(snapshots, disksnapshot.HuaWeiSnapshot{SnapshotList: one})
+	}
+
+	return snapshots, nil
+}
+
+// DeleteDiskSnapshot 删除云硬盘快照
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2005.html
+func (h *HuaWei) DeleteDiskSnapshot(kt *kit.Kit, opt *disksnapshot.HuaWeiDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei disk snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshot(&model.DeleteSnapshotRequest{SnapshotId: opt.CloudID}); err != nil {
+		logs.Errorf("delete huawei disk snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackDiskSnapshot 使用快照回滚云硬盘
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2004.html
+func (h *HuaWei) RollbackDiskSnapshot(kt *kit.Kit, opt *disksnapshot.HuaWeiRollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei disk snapshot rollback option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.RollbackSnapshotRequest{
+		SnapshotId: opt.CloudID,
+		Body: &model.RollbackSnapshotRequestBody{
+			Rollback: &model.RollbackSnapshotOption{VolumeId: opt.CloudDiskID},
+		},
+	}
+	if _, err = client.RollbackSnapshot(req); err != nil {
+		logs.Errorf("rollback huawei disk snapshot failed, err: %v, snapshot: %s, disk: %s, rid: %s", err,
+			opt.CloudID, opt.CloudDiskID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+	"strconv"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ces/v1/model"
+)
+
+const (
+	huaweiEcsNamespace  = "SYS.ECS"
+	huaweiEcsDimension  = "instance_id"
+	huaweiMetricAverage = "average"
+)
+
+type huaweiMetric struct {
+	name string
+	// toMbps 指标单位为Byte/s，需要换算为Mbps
+	toMbps bool
+}
+
+// huaweiCvmMetricMap hcm指标与华为云主机指标的对应关系
+var huaweiCvmMetricMap = map[typemonitor.MetricName]huaweiMetric{
+	typemonitor.CpuUsage:   {name: "cpu_util"},
+	typemonitor.MemUsage:   {name: "mem_util"},
+	typemonitor.NetInRate:  {name: "network_incoming_bytes_rate_inband", toMbps: true},
+	typemonitor.NetOutRate: {name: "network_outgoing_bytes_rate_inband", toMbps: true},
+}
+
+// GetCvmMetric 查询主机监控数据
+// reference: https://support.huaweicloud.com/api-ces/ces_03_0034.html
+func (h *HuaWei) GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get cvm metric option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metric, ok := huaweiCvmMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	client, err := h.clientSet.cesClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new ces client failed, err: %v", err)
+	}
+
+	metrics := make([]model.MetricInfo, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		metrics = append(metrics, model.MetricInfo{
+			Namespace:  huaweiEcsNamespace,
+			MetricName: metric.name,
+			Dimensions: []model.MetricsDimension{{Name: huaweiEcsDimension, Value: id}},
+		})
+	}
+
+	req := &model.BatchListMetricDataRequest{
+		Body: &model.BatchListMetricDataRequestBody{
+			Metrics: metrics,
+			Period:  strconv.FormatInt(opt.Period, 10),
+			Filter:  huaweiMetricAverage,
+			From:    opt.StartTime.UnixMilli(),
+			To:      opt.EndTime.UnixMilli(),
+		},
+	}
+
+	resp, err := client.BatchListMetricData(req)
+	if err != nil {
+		logs.Errorf("batch list huawei metric data failed, err: %v, metric: %s, rid: %s", err, metric.name, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Metrics == nil {
+		return make([]typemonitor.MetricData, 0), nil
+	}
+
+	return metric.parseMetricData(*resp.Metrics), nil
+}
+
+// parseMetricData 解析批量查询的指标数据，实例ID取自instance_id维度
+func (m huaweiMetric) parseMetricData(metrics []model.BatchMetricData) []typemonitor.MetricData {
+	data := make([]typemonitor.MetricData, 0, len(metrics))
+	for _, one := range metrics {
+		cloudID := ""
+		if one.Dimensions != nil {
+			for _, dim := range *one.Dimensions {
+				if dim.Name == huaweiEcsDimension {
+					cloudID = dim.Value
+				}
+			}
+		}
+
+		points := make([]typemonitor.MetricPoint, 0, len(one.Datapoints))
+		for _, point := range one.Datapoints {
+			if point.Average == nil {
+				continue
+			}
+
+			value := *point.Average
+			if m.toMbps {
+				value = value * 8 / 1e6
+			}
+			points = append(points, typemonitor.MetricPoint{Timestamp: point.Timestamp / 1000, Value: value})
+		}
+
+		data = append(data, typemonitor.MetricData{CloudID: cloudID, Points: points})
+	}
+
+	return data
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"reflect"
+	"testing"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ces/v1/model"
+)
+
+func TestParseMetricData(t *testing.T) {
+	avg := func(v float64) *float64 { return &v }
+	metrics := []model.BatchMetricData{
+		{
+			MetricName: "network_incoming_bytes_rate_inband",
+			Dimensions: &[]model.MetricsDimension{{Name: huaweiEcsDimension, Value: "ecs-1"}},
+			Datapoints: []model.DatapointForBatchMetric{
+				{Average: avg(250000), Timestamp: 1700000000000},
+				// 无平均值的数据点被忽略
+				{Timestamp: 1700000300000},
+			},
+		},
+		{MetricName: "network_incoming_bytes_rate_inband", Datapoints: []model.DatapointForBatchMetric{}},
+	}
+
+	data := huaweiCvmMetricMap[typemonitor.NetInRate].parseMetricData(metrics)
+
+	// 毫秒时间戳换算为秒，Byte/s换算为Mbps
+	expect := []typemonitor.MetricData{
+		{CloudID: "ecs-1", Points: []typemonitor.MetricPoint{{Timestamp: 1700000000, Value: 2}}},
+		{CloudID: "", Points: []typemonitor.MetricPoint{}},
+	}
+	if !reflect.DeepEqual(data, expect) {
+		t.Errorf("unexpected data: %+v", data)
+	}
+
+	data = huaweiCvmMetricMap[typemonitor.CpuUsage].parseMetricData(metrics[:1])
+	if data[0].Points[0].Value != 250000 {
+		t.Errorf("cpu usage should not be converted, got %+v", data)
+	}
+}
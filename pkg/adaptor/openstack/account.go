@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// GetAccountInfoBySecret 根据应用凭证获取账号信息，应用凭证只能访问创建时所在的项目。
+func (o *OpenStack) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.OpenStackInfoBySecret, error) {
+	tk, err := o.clientSet.getToken(kt)
+	if err != nil {
+		logs.Errorf("openstack keystone auth failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("openstack keystone auth failed, err: %v", err)
+	}
+
+	return &cloud.OpenStackInfoBySecret{
+		CloudProjectID:   tk.Project.ID,
+		CloudProjectName: tk.Project.Name,
+		CloudUserID:      tk.User.ID,
+		CloudUserName:    tk.User.Name,
+	}, nil
+}
+
+// ListProject 查询当前用户可访问的项目
+// reference: https://docs.openstack.org/api-ref/identity/v3/#get-available-project-scopes
+func (o *OpenStack) ListProject(kt *kit.Kit) ([]typeaccount.OpenStackProject, error) {
+	resp := new(struct {
+		Projects []typeaccount.OpenStackProject `json:"projects"`
+	})
+	if err := o.clientSet.get(kt, identityService, "/auth/projects", nil, resp); err != nil {
+		logs.Errorf("list openstack project failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list openstack project failed, err: %v", err)
+	}
+
+	return resp.Projects, nil
+}
@@ -0,0 +1,342 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/kit"
+)
+
+const (
+	// defaultTimeout 单次请求的超时时间
+	defaultTimeout = 30 * time.Second
+	// tokenRefreshAhead token 过期前提前刷新的时间
+	tokenRefreshAhead = 5 * time.Minute
+	// computeMicroVersion nova 接口微版本，2.47 开始实例详情中内嵌规格信息
+	computeMicroVersion = "2.47"
+)
+
+// service openstack 服务类型，用于从服务目录中查找接入地址
+type service string
+
+const (
+	identityService service = "identity"
+	computeService  service = "compute"
+	networkService  service = "network"
+	volumeService   service = "volumev3"
+)
+
+// clientSet openstack 客户端，使用 keystone v3 应用凭证认证，token 在过期前缓存复用。
+// reference: https://docs.openstack.org/api-ref/identity/v3/#authenticating-with-an-application-credential
+type clientSet struct {
+	credential *types.OpenStackCredential
+	client     *http.Client
+
+	lock  sync.Mutex
+	token *token
+}
+
+func newClientSet(credential *types.OpenStackCredential) *clientSet {
+	return &clientSet{
+		credential: credential,
+		client:     &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// token keystone 颁发的 token 及其作用域信息
+type token struct {
+	ID        string
+	ExpiresAt time.Time
+	Project   tokenProject
+	User      tokenUser
+	Catalog   []catalogEntry
+}
+
+type tokenProject struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Domain struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"domain"`
+}
+
+type tokenUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type catalogEntry struct {
+	Type      string `json:"type"`
+	Endpoints []struct {
+		Interface string `json:"interface"`
+		RegionID  string `json:"region_id"`
+		URL       string `json:"url"`
+	} `json:"endpoints"`
+}
+
+type authTokenResp struct {
+	Token struct {
+		ExpiresAt time.Time      `json:"expires_at"`
+		Project   tokenProject   `json:"project"`
+		User      tokenUser      `json:"user"`
+		Catalog   []catalogEntry `json:"catalog"`
+	} `json:"token"`
+}
+
+// Error openstack 接口返回的错误
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+// Error ...
+func (e *Error) Error() string {
+	return fmt.Sprintf("openstack api failed, status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// isNotFoundErr 资源不存在
+func isNotFoundErr(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// identityURL 返回 keystone v3 接口地址
+func (c *clientSet) identityURL() string {
+	base := strings.TrimSuffix(c.credential.AuthURL, "/")
+	return strings.TrimSuffix(base, "/v3") + "/v3"
+}
+
+// getToken 获取缓存的 token，即将过期时重新认证
+func (c *clientSet) getToken(kt *kit.Kit) (*token, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != nil && time.Now().Add(tokenRefreshAhead).Before(c.token.ExpiresAt) {
+		return c.token, nil
+	}
+
+	tk, err := c.authenticate(kt)
+	if err != nil {
+		return nil, err
+	}
+	c.token = tk
+
+	return tk, nil
+}
+
+// resetToken 清除缓存的 token，下次请求时重新认证
+func (c *clientSet) resetToken(tk *token) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token == tk {
+		c.token = nil
+	}
+}
+
+// authenticate 使用应用凭证向 keystone 申请 token
+func (c *clientSet) authenticate(kt *kit.Kit) (*token, error) {
+	reqBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"application_credential"},
+				"application_credential": map[string]string{
+					"id":     c.credential.CloudSecretID,
+					"secret": c.credential.CloudSecretKey,
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, c.identityURL()+"/auth/tokens",
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request keystone auth failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read keystone auth response failed, err: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, &Error{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	result := new(authTokenResp)
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return nil, fmt.Errorf("unmarshal keystone auth response failed, err: %v", err)
+	}
+
+	tk := &token{
+		ID:        resp.Header.Get("X-Subject-Token"),
+		ExpiresAt: result.Token.ExpiresAt,
+		Project:   result.Token.Project,
+		User:      result.Token.User,
+		Catalog:   result.Token.Catalog,
+	}
+	if len(tk.ID) == 0 {
+		return nil, fmt.Errorf("keystone auth response has no X-Subject-Token header")
+	}
+
+	return tk, nil
+}
+
+// endpoint 从服务目录中查找服务的 public 接入地址，账号未指定区域时使用第一个匹配的区域
+func (c *clientSet) endpoint(tk *token, svc service) (string, error) {
+	for _, entry := range tk.Catalog {
+		if entry.Type != string(svc) {
+			continue
+		}
+
+		for _, one := range entry.Endpoints {
+			if one.Interface != "public" {
+				continue
+			}
+
+			if len(c.credential.Region) != 0 && one.RegionID != c.credential.Region {
+				continue
+			}
+
+			return strings.TrimSuffix(one.URL, "/"), nil
+		}
+	}
+
+	return "", fmt.Errorf("openstack service %s not found in catalog, region: %s", svc, c.credential.Region)
+}
+
+// region 返回账号实际使用的区域
+func (c *clientSet) region(kt *kit.Kit) (string, error) {
+	if len(c.credential.Region) != 0 {
+		return c.credential.Region, nil
+	}
+
+	tk, err := c.getToken(kt)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range tk.Catalog {
+		if entry.Type != string(computeService) {
+			continue
+		}
+
+		for _, one := range entry.Endpoints {
+			if one.Interface == "public" {
+				return one.RegionID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("openstack compute service not found in catalog")
+}
+
+// get 调用 openstack 服务的 GET 接口，path 为相对于服务接入地址的路径，token 失效时重新认证一次
+func (c *clientSet) get(kt *kit.Kit, svc service, path string, query url.Values, result interface{}) error {
+	err := c.doGet(kt, svc, path, query, result)
+	if apiErr, ok := err.(*Error); ok && apiErr.StatusCode == http.StatusUnauthorized {
+		return c.doGet(kt, svc, path, query, result)
+	}
+
+	return err
+}
+
+func (c *clientSet) doGet(kt *kit.Kit, svc service, path string, query url.Values, result interface{}) error {
+	tk, err := c.getToken(kt)
+	if err != nil {
+		return err
+	}
+
+	var base string
+	if svc == identityService {
+		base = c.identityURL()
+	} else {
+		if base, err = c.endpoint(tk, svc); err != nil {
+			return err
+		}
+	}
+
+	// neutron 服务目录中的地址一般不带版本号
+	if svc == networkService && !strings.HasSuffix(base, "/v2.0") {
+		base += "/v2.0"
+	}
+
+	reqURL := base + path
+	if len(query) != 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Auth-Token", tk.ID)
+	req.Header.Set("Accept", "application/json")
+	if svc == computeService {
+		req.Header.Set("X-OpenStack-Nova-API-Version", computeMicroVersion)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request openstack %s %s failed, err: %v", svc, path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read openstack %s %s response failed, err: %v", svc, path, err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.resetToken(tk)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &Error{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unmarshal openstack %s %s response failed, err: %v", svc, path, err)
+	}
+
+	return nil
+}
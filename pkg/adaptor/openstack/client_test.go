@@ -0,0 +1,200 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+)
+
+// newFakeServer 启动本地 keystone、nova、neutron 服务，返回服务地址和认证次数
+func newFakeServer(t *testing.T) (*httptest.Server, *int) {
+	authCount := new(int)
+	var server *httptest.Server
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		req := new(struct {
+			Auth struct {
+				Identity struct {
+					Methods               []string          `json:"methods"`
+					ApplicationCredential map[string]string `json:"application_credential"`
+				} `json:"identity"`
+			} `json:"auth"`
+		})
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		cred := req.Auth.Identity.ApplicationCredential
+		if cred["id"] != "app-id" || cred["secret"] != "app-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"code":401,"message":"invalid application credential"}}`))
+			return
+		}
+
+		*authCount++
+		w.Header().Set("X-Subject-Token", "token-1")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token": map[string]interface{}{
+				"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+				"project":    map[string]interface{}{"id": "proj-1", "name": "demo"},
+				"user":       map[string]interface{}{"id": "user-1", "name": "admin"},
+				"catalog": []map[string]interface{}{
+					{"type": "compute", "endpoints": []map[string]string{
+						{"interface": "internal", "region_id": "RegionOne", "url": "http://invalid"},
+						{"interface": "public", "region_id": "RegionOne", "url": server.URL + "/compute/v2.1/"},
+					}},
+					{"type": "network", "endpoints": []map[string]string{
+						{"interface": "public", "region_id": "RegionOne", "url": server.URL + "/network"},
+					}},
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("/compute/v2.1/servers/detail", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("X-OpenStack-Nova-API-Version") != computeMicroVersion || r.URL.Query().Get("limit") != "10" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"servers":[{"id":"vm-1","name":"web","status":"ACTIVE","image":"",` +
+			`"OS-EXT-AZ:availability_zone":"nova","flavor":{"original_name":"m1.small","vcpus":1,"ram":2048},` +
+			`"addresses":{"private":[{"addr":"10.0.0.5","version":4,"OS-EXT-IPS:type":"fixed"},` +
+			`{"addr":"172.24.4.10","version":4,"OS-EXT-IPS:type":"floating"}]}}]}`))
+	})
+
+	mux.HandleFunc("/compute/v2.1/servers/vm-2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"itemNotFound":{"code":404,"message":"Instance vm-2 could not be found."}}`))
+	})
+
+	mux.HandleFunc("/network/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("device_id") != "vm-1" {
+			w.Write([]byte(`{"ports":[]}`))
+			return
+		}
+		w.Write([]byte(`{"ports":[{"id":"port-1","network_id":"net-1",` +
+			`"fixed_ips":[{"subnet_id":"subnet-1","ip_address":"10.0.0.5"}]}]}`))
+	})
+
+	server = httptest.NewServer(mux)
+	return server, authCount
+}
+
+func TestListCvm(t *testing.T) {
+	server, authCount := newFakeServer(t)
+	defer server.Close()
+
+	cli, err := NewOpenStack(&types.OpenStackCredential{
+		AuthURL:        server.URL + "/identity/v3/",
+		CloudSecretID:  "app-id",
+		CloudSecretKey: "app-secret",
+	})
+	if err != nil {
+		t.Fatalf("new openstack failed, err: %v", err)
+	}
+	kt := kit.New()
+
+	opt := &typecvm.OpenStackListOption{OpenStackListOption: core.OpenStackListOption{
+		Page: &core.OpenStackPage{Limit: 10},
+	}}
+	result, err := cli.ListCvm(kt, opt)
+	if err != nil {
+		t.Fatalf("list cvm failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		t.Fatalf("unexpected cvm list result: %+v", result)
+	}
+
+	cvm := result.Details[0]
+	if cvm.ID != "vm-1" || cvm.Flavor.OriginalName != "m1.small" || len(cvm.Image.ID) != 0 {
+		t.Errorf("unexpected cvm: %+v", cvm)
+	}
+
+	if ips := cvm.PrivateIPv4Addresses(); len(ips) != 1 || ips[0] != "10.0.0.5" {
+		t.Errorf("unexpected private ips: %v", ips)
+	}
+
+	if ips := cvm.PublicIPv4Addresses(); len(ips) != 1 || ips[0] != "172.24.4.10" {
+		t.Errorf("unexpected public ips: %v", ips)
+	}
+
+	if len(cvm.CloudVpcIDs) != 1 || cvm.CloudVpcIDs[0] != "net-1" || len(cvm.CloudSubnetIDs) != 1 ||
+		cvm.CloudSubnetIDs[0] != "subnet-1" {
+		t.Errorf("unexpected cvm network: %v, %v", cvm.CloudVpcIDs, cvm.CloudSubnetIDs)
+	}
+
+	// 已删除的实例按ID查询时被忽略
+	opt = &typecvm.OpenStackListOption{OpenStackListOption: core.OpenStackListOption{CloudIDs: []string{"vm-2"}}}
+	result, err = cli.ListCvm(kt, opt)
+	if err != nil || len(result.Details) != 0 {
+		t.Errorf("list deleted cvm should return empty result, result: %+v, err: %v", result, err)
+	}
+
+	// token 在有效期内复用
+	if *authCount != 1 {
+		t.Errorf("token should be cached, auth count: %d", *authCount)
+	}
+
+	region, err := cli.Region(kt)
+	if err != nil || region != "RegionOne" {
+		t.Errorf("region should be taken from catalog, region: %s, err: %v", region, err)
+	}
+
+	info, err := cli.GetAccountInfoBySecret(kt)
+	if err != nil || info.CloudProjectID != "proj-1" || info.CloudUserName != "admin" {
+		t.Errorf("unexpected account info: %+v, err: %v", info, err)
+	}
+}
+
+func TestAuthFailed(t *testing.T) {
+	server, _ := newFakeServer(t)
+	defer server.Close()
+
+	cli, err := NewOpenStack(&types.OpenStackCredential{
+		AuthURL:        server.URL + "/identity",
+		CloudSecretID:  "app-id",
+		CloudSecretKey: "wrong",
+	})
+	if err != nil {
+		t.Fatalf("new openstack failed, err: %v", err)
+	}
+
+	_, err = cli.GetAccountInfoBySecret(kit.New())
+	if err == nil {
+		t.Errorf("auth with wrong secret should fail")
+	}
+}
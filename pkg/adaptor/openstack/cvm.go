@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+	"net/url"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// ListCvm 查询实例列表，按ID查询时逐个获取，已删除的实例会被忽略。实例所在网络和子网通过实例端口获取。
+// reference: https://docs.openstack.org/api-ref/compute/#list-servers-detailed
+func (o *OpenStack) ListCvm(kt *kit.Kit, opt *typecvm.OpenStackListOption) (*typecvm.OpenStackListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	details := make([]typecvm.OpenStackCvm, 0)
+	if len(opt.CloudIDs) == 0 {
+		resp := new(struct {
+			Servers []typecvm.OpenStackCvm `json:"servers"`
+		})
+		if err := o.clientSet.get(kt, computeService, "/servers/detail", pageQuery(opt.Page), resp); err != nil {
+			logs.Errorf("list openstack server failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list openstack server failed, err: %v", err)
+		}
+		details = resp.Servers
+	} else {
+		for _, id := range opt.CloudIDs {
+			resp := new(struct {
+				Server typecvm.OpenStackCvm `json:"server"`
+			})
+			err := o.clientSet.get(kt, computeService, "/servers/"+id, nil, resp)
+			if isNotFoundErr(err) {
+				continue
+			}
+			if err != nil {
+				logs.Errorf("get openstack server failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+				return nil, fmt.Errorf("get openstack server failed, err: %v", err)
+			}
+			details = append(details, resp.Server)
+		}
+	}
+
+	for i := range details {
+		if err := o.fillCvmNetwork(kt, &details[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &typecvm.OpenStackListResult{Details: details}, nil
+}
+
+type openstackPort struct {
+	ID        string `json:"id"`
+	NetworkID string `json:"network_id"`
+	FixedIps  []struct {
+		SubnetID  string `json:"subnet_id"`
+		IpAddress string `json:"ip_address"`
+	} `json:"fixed_ips"`
+}
+
+// fillCvmNetwork 根据实例端口设置实例所在的网络和子网
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-ports
+func (o *OpenStack) fillCvmNetwork(kt *kit.Kit, cvm *typecvm.OpenStackCvm) error {
+	resp := new(struct {
+		Ports []openstackPort `json:"ports"`
+	})
+	query := url.Values{"device_id": []string{cvm.ID}}
+	if err := o.clientSet.get(kt, networkService, "/ports", query, resp); err != nil {
+		logs.Errorf("list openstack server port failed, err: %v, id: %s, rid: %s", err, cvm.ID, kt.Rid)
+		return fmt.Errorf("list openstack server port failed, err: %v", err)
+	}
+
+	vpcIDs := make([]string, 0, len(resp.Ports))
+	subnetIDs := make([]string, 0, len(resp.Ports))
+	for _, port := range resp.Ports {
+		vpcIDs = append(vpcIDs, port.NetworkID)
+		for _, ip := range port.FixedIps {
+			subnetIDs = append(subnetIDs, ip.SubnetID)
+		}
+	}
+	cvm.CloudVpcIDs = slice.Unique(vpcIDs)
+	cvm.CloudSubnetIDs = slice.Unique(subnetIDs)
+
+	return nil
+}
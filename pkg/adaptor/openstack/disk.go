@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	typedisk "hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListDisk 查询云硬盘列表，按ID查询时逐个获取，已删除的卷会被忽略
+// reference: https://docs.openstack.org/api-ref/block-storage/v3/#list-accessible-volumes-with-details
+func (o *OpenStack) ListDisk(kt *kit.Kit, opt *typedisk.OpenStackDiskListOption) (*typedisk.OpenStackDiskListResult,
+	error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.CloudIDs) == 0 {
+		resp := new(struct {
+			Volumes []typedisk.OpenStackDisk `json:"volumes"`
+		})
+		if err := o.clientSet.get(kt, volumeService, "/volumes/detail", pageQuery(opt.Page), resp); err != nil {
+			logs.Errorf("list openstack volume failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list openstack volume failed, err: %v", err)
+		}
+		return &typedisk.OpenStackDiskListResult{Details: resp.Volumes}, nil
+	}
+
+	details := make([]typedisk.OpenStackDisk, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		resp := new(struct {
+			Volume typedisk.OpenStackDisk `json:"volume"`
+		})
+		err := o.clientSet.get(kt, volumeService, "/volumes/"+id, nil, resp)
+		if isNotFoundErr(err) {
+			continue
+		}
+		if err != nil {
+			logs.Errorf("get openstack volume failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			return nil, fmt.Errorf("get openstack volume failed, err: %v", err)
+		}
+		details = append(details, resp.Volume)
+	}
+
+	return &typedisk.OpenStackDiskListResult{Details: details}, nil
+}
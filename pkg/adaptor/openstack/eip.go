@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	typeseip "hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListEip 查询浮动IP列表
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-floating-ips
+func (o *OpenStack) ListEip(kt *kit.Kit, opt *typeseip.OpenStackEipListOption) (*typeseip.OpenStackEipListResult,
+	error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := pageQuery(opt.Page)
+	if len(opt.CloudIDs) != 0 {
+		query = idsQuery(opt.CloudIDs)
+	}
+
+	resp := new(struct {
+		FloatingIps []*typeseip.OpenStackEip `json:"floatingips"`
+	})
+	if err := o.clientSet.get(kt, networkService, "/floatingips", query, resp); err != nil {
+		logs.Errorf("list openstack floating ip failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list openstack floating ip failed, err: %v", err)
+	}
+
+	return &typeseip.OpenStackEipListResult{Details: resp.FloatingIps}, nil
+}
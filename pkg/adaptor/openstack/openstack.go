@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack ...
+package openstack
+
+import (
+	"net/url"
+	"strconv"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// NewOpenStack new openstack.
+func NewOpenStack(credential *types.OpenStackCredential) (*OpenStack, error) {
+	if err := validateCredential(credential); err != nil {
+		return nil, err
+	}
+	return &OpenStack{clientSet: newClientSet(credential)}, nil
+}
+
+// OpenStack is openstack operator.
+type OpenStack struct {
+	clientSet *clientSet
+}
+
+func validateCredential(credential *types.OpenStackCredential) error {
+	if credential == nil {
+		return errf.New(errf.InvalidParameter, "credential is required")
+	}
+
+	if err := credential.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Region 返回账号纳管的区域
+func (o *OpenStack) Region(kt *kit.Kit) (string, error) {
+	return o.clientSet.region(kt)
+}
+
+// pageQuery 根据分页条件生成请求参数，neutron、nova、cinder 均使用 limit/marker 分页
+func pageQuery(page *core.OpenStackPage) url.Values {
+	query := url.Values{}
+	if page == nil {
+		return query
+	}
+
+	query.Set("limit", strconv.FormatInt(page.Limit, 10))
+	if len(page.Marker) != 0 {
+		query.Set("marker", page.Marker)
+	}
+
+	return query
+}
+
+// idsQuery neutron 支持通过多个 id 参数按ID过滤
+func idsQuery(cloudIDs []string) url.Values {
+	query := url.Values{}
+	for _, id := range cloudIDs {
+		query.Add("id", id)
+	}
+	return query
+}
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListSecurityGroup 查询安全组列表
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-security-groups
+func (o *OpenStack) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.OpenStackListOption) (
+	*securitygroup.OpenStackListResult, error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := pageQuery(opt.Page)
+	if len(opt.CloudIDs) != 0 {
+		query = idsQuery(opt.CloudIDs)
+	}
+
+	resp := new(struct {
+		SecurityGroups []securitygroup.OpenStackSG `json:"security_groups"`
+	})
+	if err := o.clientSet.get(kt, networkService, "/security-groups", query, resp); err != nil {
+		logs.Errorf("list openstack security group failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list openstack security group failed, err: %v", err)
+	}
+
+	return &securitygroup.OpenStackListResult{Details: resp.SecurityGroups}, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+type openstackSubnet struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	NetworkID      string   `json:"network_id"`
+	Cidr           string   `json:"cidr"`
+	IpVersion      int64    `json:"ip_version"`
+	GatewayIp      string   `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+	ProjectID      string   `json:"project_id"`
+}
+
+// ListSubnet 查询子网列表
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-subnets
+func (o *OpenStack) ListSubnet(kt *kit.Kit, opt *adtysubnet.OpenStackSubnetListOption) (
+	*adtysubnet.OpenStackSubnetListResult, error) {
+
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := pageQuery(opt.Page)
+	if len(opt.CloudIDs) != 0 {
+		query = idsQuery(opt.CloudIDs)
+	}
+	if len(opt.CloudVpcID) != 0 {
+		query.Set("network_id", opt.CloudVpcID)
+	}
+
+	resp := new(struct {
+		Subnets []openstackSubnet `json:"subnets"`
+	})
+	if err := o.clientSet.get(kt, networkService, "/subnets", query, resp); err != nil {
+		logs.Errorf("list openstack subnet failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list openstack subnet failed, err: %v", err)
+	}
+
+	region, err := o.clientSet.region(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]adtysubnet.OpenStackSubnet, 0, len(resp.Subnets))
+	for _, one := range resp.Subnets {
+		details = append(details, convertSubnet(one, region))
+	}
+
+	return &adtysubnet.OpenStackSubnetListResult{Details: details}, nil
+}
+
+func convertSubnet(data openstackSubnet, region string) adtysubnet.OpenStackSubnet {
+	subnet := adtysubnet.OpenStackSubnet{
+		CloudVpcID: data.NetworkID,
+		CloudID:    data.ID,
+		Name:       data.Name,
+		Region:     region,
+		Extension: &adtysubnet.OpenStackSubnetExtension{
+			GatewayIp:      data.GatewayIp,
+			EnableDhcp:     data.EnableDhcp,
+			DnsNameservers: data.DnsNameservers,
+			CloudProjectID: data.ProjectID,
+		},
+	}
+
+	if data.IpVersion == 6 {
+		subnet.Ipv6Cidr = []string{data.Cidr}
+	} else {
+		subnet.Ipv4Cidr = []string{data.Cidr}
+	}
+
+	if len(data.Description) != 0 {
+		subnet.Memo = &data.Description
+	}
+
+	return subnet
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+type openstackNetwork struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+	AdminStateUp bool   `json:"admin_state_up"`
+	Shared       bool   `json:"shared"`
+	External     bool   `json:"router:external"`
+	NetworkType  string `json:"provider:network_type"`
+	MTU          int64  `json:"mtu"`
+	ProjectID    string `json:"project_id"`
+}
+
+// ListVpc 查询网络列表
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-networks
+func (o *OpenStack) ListVpc(kt *kit.Kit, opt *types.OpenStackVpcListOption) (*types.OpenStackVpcListResult, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := pageQuery(opt.Page)
+	if len(opt.CloudIDs) != 0 {
+		query = idsQuery(opt.CloudIDs)
+	}
+
+	resp := new(struct {
+		Networks []openstackNetwork `json:"networks"`
+	})
+	if err := o.clientSet.get(kt, networkService, "/networks", query, resp); err != nil {
+		logs.Errorf("list openstack network failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list openstack network failed, err: %v", err)
+	}
+
+	region, err := o.clientSet.region(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]types.OpenStackVpc, 0, len(resp.Networks))
+	for _, one := range resp.Networks {
+		details = append(details, convertVpc(one, region))
+	}
+
+	return &types.OpenStackVpcListResult{Details: details}, nil
+}
+
+func convertVpc(data openstackNetwork, region string) types.OpenStackVpc {
+	vpc := types.OpenStackVpc{
+		CloudID: data.ID,
+		Name:    data.Name,
+		Region:  region,
+		Extension: &cloud.OpenStackVpcExtension{
+			Status:         data.Status,
+			AdminStateUp:   data.AdminStateUp,
+			Shared:         data.Shared,
+			External:       data.External,
+			NetworkType:    data.NetworkType,
+			MTU:            data.MTU,
+			CloudProjectID: data.ProjectID,
+		},
+	}
+
+	if len(data.Description) != 0 {
+		vpc.Memo = &data.Description
+	}
+
+	return vpc
+}
@@ -60,6 +60,7 @@ type ClientSet interface {
 	CertClient() (*ssl.Client, error)
 	TagClient() (*tag.Client, error)
 	CosClient(opt *typescos.ClientOpt) (*cos.Client, error)
+	MonitorClient(region string) (*common.Client, error)
 }
 
 // clientSet to get tcloud sdk client set
@@ -175,6 +176,14 @@ func (c *clientSet) TagClient() (*tag.Client, error) {
 	return client, nil
 }
 
+// MonitorClient tcloud monitor client, the monitor sdk is not imported, so use common client instead.
+func (c *clientSet) MonitorClient(region string) (*common.Client, error) {
+	client := common.NewCommonClient(c.credential, region, c.profile)
+	client.WithHttpTransport(metric.GetTCloudRecordRoundTripper(nil))
+
+	return client, nil
+}
+
 var cosUrlMap = map[typescos.UrlType]string{
 	typescos.NormalUrl:            "https://service.cos.myqcloud.com",
 	typescos.UrlWithNameAndRegion: "https://%s.cos.%s.myqcloud.com",
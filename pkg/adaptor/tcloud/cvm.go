@@ -256,6 +256,48 @@ func (t *TCloudImpl) StopCvm(kt *kit.Kit, opt *typecvm.TCloudStopOption) error {
 	return nil
 }
 
+// ResetInstanceType reference: https://cloud.tencent.com/document/api/213/15744
+func (t *TCloudImpl) ResetInstanceType(kt *kit.Kit, opt *typecvm.TCloudResetInstanceTypeOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reset instance type option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud client failed, err: %v", err)
+	}
+
+	req := cvm.NewResetInstancesTypeRequest()
+	req.InstanceIds = common.StringPtrs([]string{opt.CloudID})
+	req.InstanceType = common.StringPtr(opt.InstanceType)
+	req.ForceStop = common.BoolPtr(opt.ForceStop)
+
+	_, err = client.ResetInstancesTypeWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("reset cvm instance type failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	// wait until cvm done
+	handler := &resetInstanceTypePollingHandler{
+		opt.Region,
+	}
+	respPoller := poller.Poller[*TCloudImpl, []*cvm.Instance, poller.BaseDoneResult]{Handler: handler}
+	res, err := respPoller.PollUntilDone(t, kt, []*string{common.StringPtr(opt.CloudID)},
+		types.NewBatchOperateCvmPollerOpt())
+	if err != nil {
+		logs.Errorf("poll reset cvm instance type failed, err: %v, res: %#v, rid: %s", err, res, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
 // RebootCvm reference: https://cloud.tencent.com/document/api/213/15742
 func (t *TCloudImpl) RebootCvm(kt *kit.Kit, opt *typecvm.TCloudRebootOption) error {
 
@@ -621,6 +663,22 @@ func (h *resetpwdCvmPollingHandler) Poll(client *TCloudImpl, kt *kit.Kit, cloudI
 	return poll(client, kt, h.region, cloudIDs)
 }
 
+type resetInstanceTypePollingHandler struct {
+	region string
+}
+
+// Done ...
+func (h *resetInstanceTypePollingHandler) Done(cvms []*cvm.Instance) (bool, *poller.BaseDoneResult) {
+	return done(cvms, "SUCCESS")
+}
+
+// Poll ...
+func (h *resetInstanceTypePollingHandler) Poll(client *TCloudImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]*cvm.Instance, error) {
+
+	return poll(client, kt, h.region, cloudIDs)
+}
+
 type rebootCvmPollingHandler struct {
 	region string
 }
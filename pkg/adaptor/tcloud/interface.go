@@ -32,10 +32,12 @@ import (
 	typescos "hcm/pkg/adaptor/types/cos"
 	"hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/adaptor/types/disk"
+	disksnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	"hcm/pkg/adaptor/types/eip"
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/adaptor/types/instance-type"
 	typelb "hcm/pkg/adaptor/types/load-balancer"
+	typemonitor "hcm/pkg/adaptor/types/monitor"
 	networkinterface "hcm/pkg/adaptor/types/network-interface"
 	"hcm/pkg/adaptor/types/region"
 	"hcm/pkg/adaptor/types/route-table"
@@ -94,6 +96,10 @@ type TCloud interface {
 	DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error
 	AttachDisk(kt *kit.Kit, opt *disk.TCloudDiskAttachOption) error
 	DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error
+	CreateDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudCreateOption) (string, error)
+	ListDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)
+	DeleteDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudDeleteOption) error
+	RollbackDiskSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudRollbackOption) error
 	ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)
 	CountEip(kt *kit.Kit, region string) (int32, error)
 	DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error
@@ -129,6 +135,7 @@ type TCloud interface {
 	StopCvm(kt *kit.Kit, opt *cvm.TCloudStopOption) error
 	RebootCvm(kt *kit.Kit, opt *cvm.TCloudRebootOption) error
 	ResetCvmPwd(kt *kit.Kit, opt *cvm.TCloudResetPwdOption) error
+	ResetInstanceType(kt *kit.Kit, opt *cvm.TCloudResetInstanceTypeOption) error
 	CreateCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)
 	InquiryPriceCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (
 		*cvm.InquiryPriceResult, error)
@@ -212,4 +219,7 @@ type TCloud interface {
 	CreateBucket(kt *kit.Kit, opt *typescos.TCloudBucketCreateOption) error
 	DeleteBucket(kt *kit.Kit, opt *typescos.TCloudBucketDeleteOption) error
 	ListBuckets(kt *kit.Kit, opt *typescos.TCloudBucketListOption) (*typescos.TCloudBucketListResult, error)
+
+	GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error)
+	GetLoadBalancerMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error)
 }
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	monitorService    = "monitor"
+	monitorAPIVersion = "2018-07-24"
+	monitorCvmNS      = "QCE/CVM"
+	monitorClbNS      = "QCE/LB_PUBLIC"
+)
+
+// tcloudCvmMetricMap hcm指标与腾讯云主机指标的对应关系
+var tcloudCvmMetricMap = map[typemonitor.MetricName]string{
+	typemonitor.CpuUsage:   "CpuUsage",
+	typemonitor.MemUsage:   "MemUsage",
+	typemonitor.NetInRate:  "LanIntraffic",
+	typemonitor.NetOutRate: "LanOuttraffic",
+}
+
+// tcloudClbMetricMap hcm指标与腾讯云负载均衡指标的对应关系
+var tcloudClbMetricMap = map[typemonitor.MetricName]string{
+	typemonitor.LbQps: "TotalReq",
+}
+
+// GetCvmMetric 查询主机监控数据
+// reference: https://cloud.tencent.com/document/api/248/31014
+func (t *TCloudImpl) GetCvmMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) ([]typemonitor.MetricData, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get cvm metric option is required")
+	}
+
+	metricName, ok := tcloudCvmMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	return t.getMonitorData(kt, opt, monitorCvmNS, metricName, "InstanceId")
+}
+
+// GetLoadBalancerMetric 查询负载均衡监控数据
+// reference: https://cloud.tencent.com/document/api/248/51898
+func (t *TCloudImpl) GetLoadBalancerMetric(kt *kit.Kit, opt *typemonitor.GetMetricOption) (
+	[]typemonitor.MetricData, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "get load balancer metric option is required")
+	}
+
+	metricName, ok := tcloudClbMetricMap[opt.MetricName]
+	if !ok {
+		return nil, typemonitor.ErrMetricNotSupported
+	}
+
+	return t.getMonitorData(kt, opt, monitorClbNS, metricName, "loadBalancerId")
+}
+
+func (t *TCloudImpl) getMonitorData(kt *kit.Kit, opt *typemonitor.GetMetricOption, namespace, metricName,
+	dimension string) ([]typemonitor.MetricData, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.MonitorClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud monitor client failed, err: %v", err)
+	}
+
+	instances := make([]map[string]interface{}, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		instances = append(instances, map[string]interface{}{
+			"Dimensions": []map[string]string{{"Name": dimension, "Value": id}},
+		})
+	}
+
+	req := tchttp.NewCommonRequest(monitorService, monitorAPIVersion, "GetMonitorData")
+	params := map[string]interface{}{
+		"Namespace":  namespace,
+		"MetricName": metricName,
+		"Instances":  instances,
+		"Period":     opt.Period,
+		"StartTime":  opt.StartTime.Format(time.RFC3339),
+		"EndTime":    opt.EndTime.Format(time.RFC3339),
+	}
+	if err = req.SetActionParameters(params); err != nil {
+		return nil, err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("get tcloud monitor data failed, err: %v, namespace: %s, metric: %s, rid: %s", err, namespace,
+			metricName, kt.Rid)
+		return nil, err
+	}
+
+	return parseMonitorData(resp.GetBody(), dimension)
+}
+
+// parseMonitorData 解析GetMonitorData的返回结果，实例ID取自指定的维度
+func parseMonitorData(body []byte, dimension string) ([]typemonitor.MetricData, error) {
+	result := new(tcloudMonitorDataResp)
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("unmarshal tcloud monitor data failed, err: %v", err)
+	}
+
+	if result.Response.Error != nil {
+		return nil, fmt.Errorf("get tcloud monitor data failed, code: %s, message: %s",
+			result.Response.Error.Code, result.Response.Error.Message)
+	}
+
+	data := make([]typemonitor.MetricData, 0, len(result.Response.DataPoints))
+	for _, one := range result.Response.DataPoints {
+		cloudID := ""
+		for _, dim := range one.Dimensions {
+			if dim.Name == dimension {
+				cloudID = dim.Value
+			}
+		}
+
+		points := make([]typemonitor.MetricPoint, 0, len(one.Timestamps))
+		for idx := range one.Timestamps {
+			if idx >= len(one.Values) {
+				break
+			}
+			points = append(points, typemonitor.MetricPoint{Timestamp: int64(one.Timestamps[idx]),
+				Value: one.Values[idx]})
+		}
+
+		data = append(data, typemonitor.MetricData{CloudID: cloudID, Points: points})
+	}
+
+	return data, nil
+}
+
+type tcloudMonitorDataResp struct {
+	Response struct {
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+		DataPoints []struct {
+			Dimensions []struct {
+				Name  string `json:"Name"`
+				Value string `json:"Value"`
+			} `json:"Dimensions"`
+			Timestamps []float64 `json:"Timestamps"`
+			Values     []float64 `json:"Values"`
+		} `json:"DataPoints"`
+	} `json:"Response"`
+}
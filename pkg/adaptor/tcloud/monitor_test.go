@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"reflect"
+	"testing"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+)
+
+func TestParseMonitorData(t *testing.T) {
+	body := []byte(`{"Response": {"DataPoints": [
+		{"Dimensions": [{"Name": "InstanceId", "Value": "ins-1"}], "Timestamps": [1700000000, 1700000300],
+			"Values": [12.5, 30]},
+		{"Dimensions": [{"Name": "InstanceId", "Value": "ins-2"}], "Timestamps": [1700000000, 1700000300],
+			"Values": [1]}
+	], "RequestId": "req-1"}}`)
+
+	data, err := parseMonitorData(body, "InstanceId")
+	if err != nil {
+		t.Fatalf("parse monitor data failed, err: %v", err)
+	}
+
+	expect := []typemonitor.MetricData{
+		{CloudID: "ins-1", Points: []typemonitor.MetricPoint{{Timestamp: 1700000000, Value: 12.5},
+			{Timestamp: 1700000300, Value: 30}}},
+		// 数值少于时间点时，多出的时间点被忽略
+		{CloudID: "ins-2", Points: []typemonitor.MetricPoint{{Timestamp: 1700000000, Value: 1}}},
+	}
+	if !reflect.DeepEqual(data, expect) {
+		t.Errorf("unexpected data: %+v", data)
+	}
+
+	// 负载均衡使用loadBalancerId维度
+	data, err = parseMonitorData([]byte(`{"Response": {"DataPoints": [{"Dimensions": [
+		{"Name": "loadBalancerId", "Value": "lb-1"}], "Timestamps": [1700000000], "Values": [100]}]}}`),
+		"loadBalancerId")
+	if err != nil || len(data) != 1 || data[0].CloudID != "lb-1" {
+		t.Errorf("unexpected load balancer data: %+v, err: %v", data, err)
+	}
+
+	_, err = parseMonitorData([]byte(`{"Response": {"Error": {"Code": "AuthFailure", "Message": "denied"}}}`),
+		"InstanceId")
+	if err == nil {
+		t.Errorf("error response should be returned")
+	}
+}
@@ -637,3 +637,49 @@ func (opt *AwsRootDeductBillListOpt) Validate() error {
 	}
 	return validator.Validate.Struct(opt)
 }
+
+// AliyunBillListOption define aliyun bill list option.
+type AliyunBillListOption struct {
+	// BillingCycle 账期，格式为YYYY-MM
+	BillingCycle string `json:"billing_cycle" validate:"required"`
+	// BillingDate 账单日期，格式为YYYY-MM-DD，为空时查询整个账期
+	BillingDate string `json:"billing_date" validate:"omitempty"`
+	// Page 分页信息
+	Page *AliyunBillPage `json:"page" validate:"omitempty"`
+}
+
+// AliyunBillQueryLimit 阿里云账单单次查询最大数量
+const AliyunBillQueryLimit = 300
+
+// Validate aliyun bill list option.
+func (opt AliyunBillListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse("2006-01", opt.BillingCycle); err != nil {
+		return errf.New(errf.InvalidParameter, "billing_cycle should be in YYYY-MM format")
+	}
+
+	if opt.Page != nil {
+		if opt.Page.MaxResults > AliyunBillQueryLimit {
+			return errf.New(errf.InvalidParameter, "aliyun.page.max_results should be <= 300")
+		}
+	}
+
+	return nil
+}
+
+// AliyunBillPage define aliyun bill page option.
+type AliyunBillPage struct {
+	// MaxResults 每页查询数量
+	MaxResults int32 `json:"max_results,omitempty"`
+	// NextToken 下一页的标记
+	NextToken string `json:"next_token" validate:"omitempty"`
+}
+
+// AliyunBillListResult define aliyun bill list result.
+type AliyunBillListResult struct {
+	NextToken string      `json:"next_token,omitempty"`
+	Details   interface{} `json:"details"`
+}
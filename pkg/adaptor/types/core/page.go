@@ -34,6 +34,14 @@ const (
 	GcpQueryLimit = 500
 	// HuaWeiQueryLimit is huawei maximum query limit
 	HuaWeiQueryLimit = 2000
+	// AliyunQueryLimit is aliyun maximum query limit, vpc and vswitch api page size should <= 50
+	AliyunQueryLimit = 50
+	// AliyunIDQueryLimit is aliyun maximum ids count of one query
+	AliyunIDQueryLimit = 100
+	// OpenStackQueryLimit is openstack maximum query limit
+	OpenStackQueryLimit = 500
+	// OpenStackIDQueryLimit is openstack maximum ids count of one query
+	OpenStackIDQueryLimit = 100
 	// GcpSelfLinkMaxQueryLimit gcp selfLink 最大允许查询数量，如果数量过多，导致请求体太大，gcp会报错
 	GcpSelfLinkMaxQueryLimit = 50
 )
@@ -148,3 +156,40 @@ func (h HuaWeiCvmOffsetPage) Validate() error {
 
 	return nil
 }
+
+// AliyunPage define aliyun page option.
+type AliyunPage struct {
+	// PageNumber 页码，从1开始
+	PageNumber int64 `json:"page_number"`
+	PageSize   int64 `json:"page_size"`
+}
+
+// Validate aliyun page option.
+func (a AliyunPage) Validate() error {
+	if a.PageNumber < 1 {
+		return errf.New(errf.InvalidParameter, "aliyun.page_number should >= 1")
+	}
+
+	if a.PageSize <= 0 || a.PageSize > AliyunQueryLimit {
+		return errf.New(errf.InvalidParameter, "aliyun.page_size should > 0 and <= 50")
+	}
+
+	return nil
+}
+
+// OpenStackPage define openstack page option.
+type OpenStackPage struct {
+	// Limit 每页数量
+	Limit int64 `json:"limit"`
+	// Marker 上一页最后一个资源的ID，第一页为空
+	Marker string `json:"marker"`
+}
+
+// Validate openstack page option.
+func (o OpenStackPage) Validate() error {
+	if o.Limit <= 0 || o.Limit > OpenStackQueryLimit {
+		return errf.New(errf.InvalidParameter, "openstack.limit should > 0 and <= 500")
+	}
+
+	return nil
+}
@@ -209,6 +209,65 @@ func (a HuaWeiListOption) Validate() error {
 	return nil
 }
 
+// AliyunListOption defines basic aliyun list options.
+type AliyunListOption struct {
+	Region   string      `json:"region" validate:"required"`
+	CloudIDs []string    `json:"cloud_ids" validate:"omitempty"`
+	Page     *AliyunPage `json:"page" validate:"omitempty"`
+}
+
+// Validate aliyun list option.
+func (opt AliyunListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errf.New(errf.InvalidParameter, "only one of cloud_ids and page can be set")
+	}
+
+	if len(opt.CloudIDs) > AliyunIDQueryLimit {
+		return errf.New(errf.InvalidParameter, "aliyun resource ids length should <= 100")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OpenStackListOption defines basic openstack list options.
+type OpenStackListOption struct {
+	CloudIDs []string       `json:"cloud_ids" validate:"omitempty"`
+	Page     *OpenStackPage `json:"page" validate:"omitempty"`
+}
+
+// Validate openstack list option.
+func (opt OpenStackListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errf.New(errf.InvalidParameter, "only one of cloud_ids and page can be set")
+	}
+
+	if len(opt.CloudIDs) > OpenStackIDQueryLimit {
+		return errf.New(errf.InvalidParameter, "openstack resource ids length should <= 100")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AzureListByIDOption azure list by id option.
 type AzureListByIDOption struct {
 	ResourceGroupName string   `json:"resource_group_name" validate:"required"`
@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- List --------------------------
+
+// AliyunListOption defines options to list aliyun cvm instances.
+type AliyunListOption struct {
+	core.AliyunListOption `json:",inline"`
+}
+
+// Validate aliyun cvm list option.
+func (opt AliyunListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunListResult defines aliyun list cvm result.
+type AliyunListResult struct {
+	TotalCount int64       `json:"total_count"`
+	Details    []AliyunCvm `json:"details"`
+}
+
+// AliyunCvm define aliyun cvm instance.
+type AliyunCvm struct {
+	InstanceID   string `json:"InstanceId"`
+	InstanceName string `json:"InstanceName"`
+	RegionID     string `json:"RegionId"`
+	ZoneID       string `json:"ZoneId"`
+	// Status 实例状态，Pending、Running、Starting、Stopping、Stopped。
+	Status       string `json:"Status"`
+	InstanceType string `json:"InstanceType"`
+	Cpu          int64  `json:"Cpu"`
+	// Memory 内存大小，单位：MiB。
+	Memory                  int64  `json:"Memory"`
+	ImageID                 string `json:"ImageId"`
+	OSName                  string `json:"OSName"`
+	OSType                  string `json:"OSType"`
+	HostName                string `json:"HostName"`
+	Description             string `json:"Description"`
+	InstanceChargeType      string `json:"InstanceChargeType"`
+	InternetChargeType      string `json:"InternetChargeType"`
+	InternetMaxBandwidthOut int64  `json:"InternetMaxBandwidthOut"`
+	CreationTime            string `json:"CreationTime"`
+	StartTime               string `json:"StartTime"`
+	ExpiredTime             string `json:"ExpiredTime"`
+	ResourceGroupID         string `json:"ResourceGroupId"`
+	DeletionProtection      bool   `json:"DeletionProtection"`
+	StoppedMode             string `json:"StoppedMode"`
+	SerialNumber            string `json:"SerialNumber"`
+	SecurityGroupIDs        struct {
+		SecurityGroupID []string `json:"SecurityGroupId"`
+	} `json:"SecurityGroupIds"`
+	VpcAttributes struct {
+		VpcID            string `json:"VpcId"`
+		VSwitchID        string `json:"VSwitchId"`
+		PrivateIpAddress struct {
+			IpAddress []string `json:"IpAddress"`
+		} `json:"PrivateIpAddress"`
+	} `json:"VpcAttributes"`
+	PublicIpAddress struct {
+		IpAddress []string `json:"IpAddress"`
+	} `json:"PublicIpAddress"`
+	EipAddress struct {
+		IpAddress    string `json:"IpAddress"`
+		AllocationID string `json:"AllocationId"`
+	} `json:"EipAddress"`
+}
+
+// GetCloudID ...
+func (cvm AliyunCvm) GetCloudID() string {
+	return cvm.InstanceID
+}
+
+// PublicIPv4Addresses 返回实例的公网IP，包含分配的公网IP和绑定的弹性公网IP
+func (cvm AliyunCvm) PublicIPv4Addresses() []string {
+	ips := make([]string, 0, len(cvm.PublicIpAddress.IpAddress)+1)
+	ips = append(ips, cvm.PublicIpAddress.IpAddress...)
+	if len(cvm.EipAddress.IpAddress) != 0 {
+		ips = append(ips, cvm.EipAddress.IpAddress)
+	}
+	return ips
+}
+
+// -------------------------- Operation --------------------------
+
+// AliyunOperateOption defines options to start, stop, reboot or delete aliyun cvm instances.
+type AliyunOperateOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1"`
+}
+
+// Validate aliyun cvm operate option.
+func (opt AliyunOperateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.AliyunIDQueryLimit {
+		return fmt.Errorf("cloud_ids should <= %d", core.AliyunIDQueryLimit)
+	}
+
+	return nil
+}
+
+// AliyunStopOption defines options to stop aliyun cvm instances.
+type AliyunStopOption struct {
+	AliyunOperateOption `json:",inline"`
+	// ForceStop 是否强制关机
+	ForceStop bool `json:"force_stop"`
+	// StoppedMode 停机模式，StopCharging：节省停机模式，KeepCharging：普通停机模式。
+	StoppedMode string `json:"stopped_mode" validate:"omitempty,eq=StopCharging|eq=KeepCharging"`
+}
+
+// Validate aliyun cvm stop option.
+func (opt AliyunStopOption) Validate() error {
+	if err := opt.AliyunOperateOption.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// AliyunDeleteOption defines options to delete aliyun cvm instances.
+type AliyunDeleteOption struct {
+	AliyunOperateOption `json:",inline"`
+	// Force 是否强制释放运行中的实例
+	Force bool `json:"force"`
+}
+
+// Validate aliyun cvm delete option.
+func (opt AliyunDeleteOption) Validate() error {
+	return opt.AliyunOperateOption.Validate()
+}
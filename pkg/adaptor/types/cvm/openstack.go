@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"encoding/json"
+
+	"hcm/pkg/adaptor/types/core"
+)
+
+// OpenStackListOption defines options to list openstack servers.
+type OpenStackListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack cvm list option.
+func (opt OpenStackListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackListResult defines openstack list cvm result.
+type OpenStackListResult struct {
+	Details []OpenStackCvm `json:"details"`
+}
+
+// OpenStackCvm define openstack server.
+type OpenStackCvm struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Status 实例状态，例如ACTIVE、BUILD、SHUTOFF、SUSPENDED、ERROR。
+	Status           string `json:"status"`
+	TenantID         string `json:"tenant_id"`
+	HostID           string `json:"hostId"`
+	KeyName          string `json:"key_name"`
+	Created          string `json:"created"`
+	Updated          string `json:"updated"`
+	LaunchedAt       string `json:"OS-SRV-USG:launched_at"`
+	AvailabilityZone string `json:"OS-EXT-AZ:availability_zone"`
+	PowerState       int64  `json:"OS-EXT-STS:power_state"`
+	// Image 从云硬盘启动的实例该字段为空
+	Image  OpenStackCvmImage  `json:"image"`
+	Flavor OpenStackCvmFlavor `json:"flavor"`
+	// Addresses 实例地址，key为网络名称
+	Addresses       map[string][]OpenStackAddress `json:"addresses"`
+	SecurityGroups  []OpenStackNamedResource      `json:"security_groups"`
+	VolumesAttached []OpenStackIDResource         `json:"os-extended-volumes:volumes_attached"`
+	Metadata        map[string]string             `json:"metadata"`
+
+	// CloudVpcIDs 和 CloudSubnetIDs 由实例端口获取，非 nova 接口返回字段
+	CloudVpcIDs    []string `json:"cloud_vpc_ids"`
+	CloudSubnetIDs []string `json:"cloud_subnet_ids"`
+}
+
+// OpenStackCvmImage define openstack server image.
+type OpenStackCvmImage struct {
+	ID string `json:"id"`
+}
+
+// UnmarshalJSON 从云硬盘启动的实例 image 字段为空字符串
+func (img *OpenStackCvmImage) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '{' {
+		return nil
+	}
+
+	type alias OpenStackCvmImage
+	return json.Unmarshal(data, (*alias)(img))
+}
+
+// OpenStackCvmFlavor define openstack server flavor, embedded since compute api microversion 2.47.
+type OpenStackCvmFlavor struct {
+	OriginalName string `json:"original_name"`
+	Vcpus        int64  `json:"vcpus"`
+	// Ram 内存大小，单位：MiB。
+	Ram int64 `json:"ram"`
+	// Disk 根盘大小，单位：GiB。
+	Disk int64 `json:"disk"`
+}
+
+// OpenStackAddress define openstack server address.
+type OpenStackAddress struct {
+	Addr    string `json:"addr"`
+	Version int64  `json:"version"`
+	// Type 地址类型，fixed：内网地址，floating：浮动IP。
+	Type    string `json:"OS-EXT-IPS:type"`
+	MacAddr string `json:"OS-EXT-IPS-MAC:mac_addr"`
+}
+
+// OpenStackNamedResource define openstack resource referenced by name.
+type OpenStackNamedResource struct {
+	Name string `json:"name"`
+}
+
+// OpenStackIDResource define openstack resource referenced by id.
+type OpenStackIDResource struct {
+	ID string `json:"id"`
+}
+
+// GetCloudID ...
+func (cvm OpenStackCvm) GetCloudID() string {
+	return cvm.ID
+}
+
+// PrivateIPv4Addresses 返回实例的内网IPv4地址
+func (cvm OpenStackCvm) PrivateIPv4Addresses() []string {
+	return cvm.addresses("fixed", 4)
+}
+
+// PublicIPv4Addresses 返回实例绑定的浮动IPv4地址
+func (cvm OpenStackCvm) PublicIPv4Addresses() []string {
+	return cvm.addresses("floating", 4)
+}
+
+// PrivateIPv6Addresses 返回实例的IPv6地址
+func (cvm OpenStackCvm) PrivateIPv6Addresses() []string {
+	return cvm.addresses("fixed", 6)
+}
+
+func (cvm OpenStackCvm) addresses(addrType string, version int64) []string {
+	ips := make([]string, 0)
+	for _, addrs := range cvm.Addresses {
+		for _, one := range addrs {
+			if one.Type == addrType && one.Version == version {
+				ips = append(ips, one.Addr)
+			}
+		}
+	}
+	return ips
+}
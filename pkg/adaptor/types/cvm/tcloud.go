@@ -158,6 +158,20 @@ func (opt TCloudResetPwdOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// TCloudResetInstanceTypeOption defines options to change tcloud cvm instance type.
+type TCloudResetInstanceTypeOption struct {
+	Region       string `json:"region" validate:"required"`
+	CloudID      string `json:"cloud_id" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+	// 是否对运行中的实例选择强制关机，调整机型需要实例处于关机状态。
+	ForceStop bool `json:"force_stop"`
+}
+
+// Validate tcloud cvm operation option.
+func (opt TCloudResetInstanceTypeOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
 // -------------------------- Create --------------------------
 //
 
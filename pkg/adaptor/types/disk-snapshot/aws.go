@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AwsCreateOption aws创建快照参数
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+type AwsCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	Name        string `json:"name" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (opt AwsCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsListOption aws查询快照参数，仅查询当前账号拥有的快照
+type AwsListOption struct {
+	core.AwsListOption `json:",inline"`
+	CloudDiskIDs       []string `json:"cloud_disk_ids" validate:"omitempty,max=200"`
+}
+
+// Validate ...
+func (opt AwsListOption) Validate() error {
+	if err := opt.AwsListOption.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// AwsListResult aws查询快照结果
+type AwsListResult struct {
+	NextToken *string       `json:"next_token,omitempty"`
+	Details   []AwsSnapshot `json:"details"`
+}
+
+// AwsDeleteOption aws删除快照参数
+type AwsDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt AwsDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsSnapshot aws快照
+type AwsSnapshot struct {
+	*ec2.Snapshot
+}
+
+// GetCloudID ...
+func (s AwsSnapshot) GetCloudID() string {
+	return converter.PtrToVal(s.SnapshotId)
+}
+
+// GetName 获取快照名称，aws快照名称记录在 Name 标签中
+func (s AwsSnapshot) GetName() string {
+	for _, tag := range s.Tags {
+		if converter.PtrToVal(tag.Key) == "Name" {
+			return converter.PtrToVal(tag.Value)
+		}
+	}
+	return ""
+}
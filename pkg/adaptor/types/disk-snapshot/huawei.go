@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/pkg/criteria/validator"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// HuaWeiCreateOption 华为云创建快照参数
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2003.html
+type HuaWeiCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	Name        string `json:"name" validate:"omitempty,max=64"`
+}
+
+// Validate ...
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiListOption 华为云查询快照参数
+type HuaWeiListOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"omitempty"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"omitempty"`
+	Offset      int32  `json:"offset" validate:"omitempty"`
+	Limit       int32  `json:"limit" validate:"required,max=1000"`
+}
+
+// Validate ...
+func (opt HuaWeiListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiDeleteOption 华为云删除快照参数
+type HuaWeiDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt HuaWeiDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiRollbackOption 华为云回滚快照参数，回滚前云硬盘需处于未挂载状态
+type HuaWeiRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+}
+
+// Validate ...
+func (opt HuaWeiRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiSnapshot 华为云快照
+type HuaWeiSnapshot struct {
+	model.SnapshotList
+}
+
+// GetCloudID ...
+func (s HuaWeiSnapshot) GetCloudID() string {
+	return s.Id
+}
@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// Snapshot 各云厂商快照的通用信息，用于快照同步
+type Snapshot struct {
+	CloudID     string `json:"cloud_id"`
+	Name        string `json:"name"`
+	Zone        string `json:"zone"`
+	CloudDiskID string `json:"cloud_disk_id"`
+	DiskSize    uint64 `json:"disk_size"`
+	Status      string `json:"status"`
+	CreatedTime string `json:"created_time"`
+}
+
+// GetCloudID ...
+func (s Snapshot) GetCloudID() string {
+	return s.CloudID
+}
+
+// ToSnapshot 转换为通用快照信息
+func (s TCloudSnapshot) ToSnapshot() Snapshot {
+	zone := ""
+	if s.Placement != nil {
+		zone = converter.PtrToVal(s.Placement.Zone)
+	}
+
+	return Snapshot{
+		CloudID:     converter.PtrToVal(s.SnapshotId),
+		Name:        converter.PtrToVal(s.SnapshotName),
+		Zone:        zone,
+		CloudDiskID: converter.PtrToVal(s.DiskId),
+		DiskSize:    converter.PtrToVal(s.DiskSize),
+		Status:      converter.PtrToVal(s.SnapshotState),
+		CreatedTime: converter.PtrToVal(s.CreateTime),
+	}
+}
+
+// ToSnapshot 转换为通用快照信息，aws快照不区分可用区
+func (s AwsSnapshot) ToSnapshot() Snapshot {
+	return Snapshot{
+		CloudID:     converter.PtrToVal(s.SnapshotId),
+		Name:        s.GetName(),
+		CloudDiskID: converter.PtrToVal(s.VolumeId),
+		DiskSize:    uint64(converter.PtrToVal(s.VolumeSize)),
+		Status:      converter.PtrToVal(s.State),
+		CreatedTime: times.ConvStdTimeFormat(converter.PtrToVal(s.StartTime)),
+	}
+}
+
+// ToSnapshot 转换为通用快照信息，华为云快照不返回可用区
+func (s HuaWeiSnapshot) ToSnapshot() Snapshot {
+	return Snapshot{
+		CloudID:     s.Id,
+		Name:        converter.PtrToVal(s.Name),
+		CloudDiskID: s.VolumeId,
+		DiskSize:    uint64(s.Size),
+		Status:      s.Status,
+		CreatedTime: s.CreatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot 云硬盘快照
+package disksnapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+)
+
+// TCloudCreateOption 腾讯云创建快照参数
+// reference: https://cloud.tencent.com/document/api/362/15648
+type TCloudCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	Name        string `json:"name" validate:"omitempty,max=60"`
+}
+
+// Validate ...
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudListOption 腾讯云查询快照参数
+type TCloudListOption struct {
+	Region       string           `json:"region" validate:"required"`
+	CloudIDs     []string         `json:"cloud_ids" validate:"omitempty"`
+	CloudDiskIDs []string         `json:"cloud_disk_ids" validate:"omitempty"`
+	Page         *core.TCloudPage `json:"page" validate:"required"`
+}
+
+// Validate ...
+func (opt TCloudListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.TCloudQueryLimit || len(opt.CloudDiskIDs) > core.TCloudQueryLimit {
+		return errf.New(errf.InvalidParameter, "tcloud resource ids length should <= 100")
+	}
+
+	return opt.Page.Validate()
+}
+
+// TCloudDeleteOption 腾讯云删除快照参数
+type TCloudDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate ...
+func (opt TCloudDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudRollbackOption 腾讯云回滚快照参数
+type TCloudRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// AutoStopInstance 回滚前是否自动关闭云硬盘挂载的实例，回滚完成后自动开机
+	AutoStopInstance bool `json:"auto_stop_instance"`
+}
+
+// Validate ...
+func (opt TCloudRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudSnapshot 腾讯云快照
+type TCloudSnapshot struct {
+	*cbs.Snapshot
+}
+
+// GetCloudID ...
+func (s TCloudSnapshot) GetCloudID() string {
+	return converter.PtrToVal(s.SnapshotId)
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// AliyunDiskCreateOption define aliyun disk create option.
+type AliyunDiskCreateOption struct {
+	Region   string `json:"region" validate:"required"`
+	Zone     string `json:"zone" validate:"required"`
+	DiskName string `json:"disk_name" validate:"omitempty"`
+	// DiskCategory 云盘种类，例如cloud_efficiency、cloud_ssd、cloud_essd。
+	DiskCategory string `json:"disk_category" validate:"required"`
+	// DiskSize 云盘容量，单位：GiB。
+	DiskSize         int64  `json:"disk_size" validate:"required,min=1"`
+	PerformanceLevel string `json:"performance_level" validate:"omitempty"`
+	Encrypted        bool   `json:"encrypted"`
+	Description      string `json:"description" validate:"omitempty"`
+}
+
+// Validate aliyun disk create option.
+func (opt *AliyunDiskCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AliyunDiskListOption define aliyun disk list option.
+type AliyunDiskListOption struct {
+	core.AliyunListOption `json:",inline"`
+}
+
+// Validate aliyun disk list option.
+func (opt AliyunDiskListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunDiskListResult define aliyun disk list result.
+type AliyunDiskListResult struct {
+	TotalCount int64        `json:"total_count"`
+	Details    []AliyunDisk `json:"details"`
+}
+
+// AliyunDiskDeleteOption define aliyun disk delete option.
+type AliyunDiskDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *AliyunDiskDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AliyunDiskAttachOption define aliyun disk attach option.
+type AliyunDiskAttachOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudCvmID  string `json:"cloud_cvm_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// DeleteWithInstance 释放实例时是否同时释放云盘
+	DeleteWithInstance bool `json:"delete_with_instance"`
+}
+
+// Validate ...
+func (opt *AliyunDiskAttachOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AliyunDiskDetachOption define aliyun disk detach option.
+type AliyunDiskDetachOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudCvmID  string `json:"cloud_cvm_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *AliyunDiskDetachOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AliyunDisk define aliyun disk.
+type AliyunDisk struct {
+	DiskID   string `json:"DiskId"`
+	DiskName string `json:"DiskName"`
+	RegionID string `json:"RegionId"`
+	ZoneID   string `json:"ZoneId"`
+	// Size 云盘容量，单位：GiB。
+	Size     uint64 `json:"Size"`
+	Category string `json:"Category"`
+	// Type 云盘类型，system：系统盘，data：数据盘。
+	Type               string `json:"Type"`
+	Status             string `json:"Status"`
+	InstanceID         string `json:"InstanceId"`
+	Encrypted          bool   `json:"Encrypted"`
+	DeleteWithInstance bool   `json:"DeleteWithInstance"`
+	Portable           bool   `json:"Portable"`
+	DiskChargeType     string `json:"DiskChargeType"`
+	ExpiredTime        string `json:"ExpiredTime"`
+	CreationTime       string `json:"CreationTime"`
+	Description        string `json:"Description"`
+	PerformanceLevel   string `json:"PerformanceLevel"`
+	Device             string `json:"Device"`
+}
+
+// GetCloudID ...
+func (disk AliyunDisk) GetCloudID() string {
+	return disk.DiskID
+}
+
+// IsSystemDisk 是否为系统盘
+func (disk AliyunDisk) IsSystemDisk() bool {
+	return disk.Type == "system"
+}
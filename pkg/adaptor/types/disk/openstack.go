@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"hcm/pkg/adaptor/types/core"
+)
+
+// OpenStackDiskListOption define openstack volume list option.
+type OpenStackDiskListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack disk list option.
+func (opt OpenStackDiskListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackDiskListResult define openstack volume list result.
+type OpenStackDiskListResult struct {
+	Details []OpenStackDisk `json:"details"`
+}
+
+// OpenStackDisk define openstack volume.
+type OpenStackDisk struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Description      *string `json:"description"`
+	AvailabilityZone string  `json:"availability_zone"`
+	// Size 卷容量，单位：GiB。
+	Size uint64 `json:"size"`
+	// Status 卷状态，例如available、in-use、creating、error。
+	Status     string `json:"status"`
+	VolumeType string `json:"volume_type"`
+	// Bootable 是否为启动盘，取值为字符串 true 或 false。
+	Bootable    string                    `json:"bootable"`
+	Encrypted   bool                      `json:"encrypted"`
+	Multiattach bool                      `json:"multiattach"`
+	ProjectID   string                    `json:"os-vol-tenant-attr:tenant_id"`
+	CreatedAt   string                    `json:"created_at"`
+	Attachments []OpenStackDiskAttachment `json:"attachments"`
+}
+
+// OpenStackDiskAttachment define openstack volume attachment.
+type OpenStackDiskAttachment struct {
+	ServerID string `json:"server_id"`
+	Device   string `json:"device"`
+}
+
+// GetCloudID ...
+func (disk OpenStackDisk) GetCloudID() string {
+	return disk.ID
+}
+
+// IsSystemDisk 启动盘视为系统盘
+func (disk OpenStackDisk) IsSystemDisk() bool {
+	return disk.Bootable == "true"
+}
+
+// InstanceIDs 返回卷挂载的实例ID
+func (disk OpenStackDisk) InstanceIDs() []string {
+	ids := make([]string, 0, len(disk.Attachments))
+	for _, one := range disk.Attachments {
+		ids = append(ids, one.ServerID)
+	}
+	return ids
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// AliyunEipListOption define aliyun eip list option.
+type AliyunEipListOption struct {
+	core.AliyunListOption `json:",inline"`
+	Ips                   []string `json:"ips" validate:"omitempty"`
+}
+
+// Validate aliyun eip list option.
+func (opt *AliyunEipListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunEipListResult define aliyun eip list result.
+type AliyunEipListResult struct {
+	TotalCount int64        `json:"total_count"`
+	Details    []*AliyunEip `json:"details"`
+}
+
+// AliyunEip define aliyun eip.
+type AliyunEip struct {
+	AllocationID     string `json:"AllocationId"`
+	Name             string `json:"Name"`
+	RegionID         string `json:"RegionId"`
+	IpAddress        string `json:"IpAddress"`
+	PrivateIpAddress string `json:"PrivateIpAddress"`
+	// Status EIP状态，Associating、Unassociating、InUse、Available、Releasing。
+	Status string `json:"Status"`
+	// InstanceType 当前绑定的实例类型，例如EcsInstance、SlbInstance、Nat、NetworkInterface。
+	InstanceType string `json:"InstanceType"`
+	InstanceID   string `json:"InstanceId"`
+	// Bandwidth 带宽峰值，单位：Mbps。
+	Bandwidth          string `json:"Bandwidth"`
+	InternetChargeType string `json:"InternetChargeType"`
+	ChargeType         string `json:"ChargeType"`
+	ISP                string `json:"ISP"`
+	AllocationTime     string `json:"AllocationTime"`
+	ExpiredTime        string `json:"ExpiredTime"`
+	Description        string `json:"Description"`
+}
+
+// GetCloudID ...
+func (eip *AliyunEip) GetCloudID() string {
+	return eip.AllocationID
+}
+
+// AliyunEipDeleteOption define aliyun eip delete option.
+type AliyunEipDeleteOption struct {
+	CloudID string `json:"cloud_id" validate:"required"`
+	Region  string `json:"region" validate:"required"`
+}
+
+// Validate ...
+func (opt *AliyunEipDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
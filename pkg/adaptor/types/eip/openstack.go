@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+)
+
+// OpenStackEipListOption define openstack floating ip list option.
+type OpenStackEipListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack eip list option.
+func (opt *OpenStackEipListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackEipListResult define openstack floating ip list result.
+type OpenStackEipListResult struct {
+	Details []*OpenStackEip `json:"details"`
+}
+
+// OpenStackEip define openstack floating ip.
+type OpenStackEip struct {
+	ID                string  `json:"id"`
+	FloatingIpAddress string  `json:"floating_ip_address"`
+	FixedIpAddress    *string `json:"fixed_ip_address"`
+	FloatingNetworkID string  `json:"floating_network_id"`
+	PortID            *string `json:"port_id"`
+	RouterID          *string `json:"router_id"`
+	// Status 浮动IP状态，ACTIVE、DOWN、ERROR。
+	Status      string `json:"status"`
+	ProjectID   string `json:"project_id"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	// PortDetails 绑定端口信息，需要 neutron 开启 fip-port-details 扩展
+	PortDetails *OpenStackEipPortDetails `json:"port_details"`
+}
+
+// OpenStackEipPortDetails define openstack floating ip port details.
+type OpenStackEipPortDetails struct {
+	DeviceID    string `json:"device_id"`
+	DeviceOwner string `json:"device_owner"`
+}
+
+// GetCloudID ...
+func (eip *OpenStackEip) GetCloudID() string {
+	return eip.ID
+}
+
+// InstanceID 返回浮动IP绑定的实例ID，未绑定实例时为空
+func (eip *OpenStackEip) InstanceID() string {
+	// 实例端口的 device_owner 格式为 compute:<可用区>
+	if eip.PortDetails == nil || !strings.HasPrefix(eip.PortDetails.DeviceOwner, "compute:") {
+		return ""
+	}
+	return eip.PortDetails.DeviceID
+}
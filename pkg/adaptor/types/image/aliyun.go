@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// AliyunImageListOption define aliyun image list option.
+type AliyunImageListOption struct {
+	core.AliyunListOption `json:",inline"`
+	// ImageOwnerAlias 镜像来源，system：阿里云提供的公共镜像，self：自定义镜像，others：共享镜像，marketplace：镜像市场镜像。
+	ImageOwnerAlias string `json:"image_owner_alias" validate:"omitempty"`
+}
+
+// Validate aliyun image list option.
+func (opt AliyunImageListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunImageListResult define aliyun image list result.
+type AliyunImageListResult struct {
+	TotalCount int64         `json:"total_count"`
+	Details    []AliyunImage `json:"details"`
+}
+
+// AliyunImage define aliyun image.
+type AliyunImage struct {
+	CloudID      string        `json:"cloud_id"`
+	Name         string        `json:"name"`
+	Architecture string        `json:"architecture"`
+	Platform     string        `json:"platform"`
+	State        string        `json:"state"`
+	Type         string        `json:"type"`
+	OsType       enumor.OsType `json:"os_type"`
+	Region       string        `json:"region"`
+	ImageFamily  string        `json:"image_family"`
+	// ImageSize 镜像大小，单位：GiB。
+	ImageSize uint64 `json:"image_size"`
+}
+
+// GetCloudID ...
+func (image AliyunImage) GetCloudID() string {
+	return image.CloudID
+}
@@ -34,6 +34,19 @@ func GetOsTypeByPlatform(vendor enumor.Vendor, platform string) enumor.OsType {
 		return getGcpOsTypeByPlatform(platform)
 	case enumor.Azure:
 		return getAzureOsTypeByPlatform(platform)
+	case enumor.Aliyun:
+		return getAliyunOsTypeByPlatform(platform)
+	default:
+		return enumor.OtherOsType
+	}
+}
+
+func getAliyunOsTypeByPlatform(platform string) enumor.OsType {
+	switch platform {
+	case "linux":
+		return enumor.LinuxOsType
+	case "windows":
+		return enumor.WindowsOsType
 	default:
 		return enumor.OtherOsType
 	}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package monitor 云资源监控指标相关的类型定义
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// MetricName 监控指标名称，用于屏蔽各云厂商之间的指标命名差异
+type MetricName string
+
+const (
+	// CpuUsage CPU利用率，单位：%
+	CpuUsage MetricName = "cpu_usage"
+	// MemUsage 内存利用率，单位：%
+	MemUsage MetricName = "mem_usage"
+	// NetInRate 内网入带宽，单位：Mbps
+	NetInRate MetricName = "net_in_rate"
+	// NetOutRate 内网出带宽，单位：Mbps
+	NetOutRate MetricName = "net_out_rate"
+	// LbQps 负载均衡每秒请求数，单位：次/秒
+	LbQps MetricName = "lb_qps"
+)
+
+// CvmMetrics 主机支持的监控指标
+var CvmMetrics = []MetricName{CpuUsage, MemUsage, NetInRate, NetOutRate}
+
+// LoadBalancerMetrics 负载均衡支持的监控指标
+var LoadBalancerMetrics = []MetricName{LbQps}
+
+// Validate MetricName.
+func (m MetricName) Validate() error {
+	switch m {
+	case CpuUsage, MemUsage, NetInRate, NetOutRate, LbQps:
+	default:
+		return fmt.Errorf("unsupported metric name: %s", m)
+	}
+
+	return nil
+}
+
+const (
+	// DefaultMetricPeriod 默认统计粒度，单位：秒
+	DefaultMetricPeriod int64 = 300
+	// MaxMetricCloudIDs 单次查询的最大资源数
+	MaxMetricCloudIDs = 10
+)
+
+// GetMetricOption 查询监控指标数据参数
+type GetMetricOption struct {
+	Region     string     `json:"region"`
+	MetricName MetricName `json:"metric_name" validate:"required"`
+	CloudIDs   []string   `json:"cloud_ids" validate:"required,min=1,max=10"`
+	StartTime  time.Time  `json:"start_time" validate:"required"`
+	EndTime    time.Time  `json:"end_time" validate:"required"`
+	// Period 统计粒度，单位：秒
+	Period int64 `json:"period" validate:"required,min=60"`
+}
+
+// Validate GetMetricOption.
+func (opt GetMetricOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if err := opt.MetricName.Validate(); err != nil {
+		return err
+	}
+
+	if !opt.StartTime.Before(opt.EndTime) {
+		return errors.New("start_time should be earlier than end_time")
+	}
+
+	return nil
+}
+
+// MetricData 单个资源的监控数据序列
+type MetricData struct {
+	CloudID string        `json:"cloud_id"`
+	Points  []MetricPoint `json:"points"`
+}
+
+// MetricPoint 监控数据点
+type MetricPoint struct {
+	// Timestamp unix时间戳，单位：秒
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// ErrMetricNotSupported 云厂商不支持该监控指标
+var ErrMetricNotSupported = errors.New("metric is not supported by vendor")
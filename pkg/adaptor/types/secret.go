@@ -70,3 +70,18 @@ type AzureCredential struct {
 func (a *AzureCredential) Validate() error {
 	return validator.Validate.Struct(a)
 }
+
+// OpenStackCredential define openstack credential information, secret is keystone application credential.
+type OpenStackCredential struct {
+	// AuthURL keystone 认证地址，如 https://keystone.example.com:5000
+	AuthURL string `json:"auth_url" validate:"required"`
+	// Region 区域，为空时使用服务目录中的第一个区域
+	Region         string `json:"region" validate:"omitempty"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate OpenStackCredential
+func (o *OpenStackCredential) Validate() error {
+	return validator.Validate.Struct(o)
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/adaptor/types/core"
+)
+
+// AliyunListOption define aliyun security group list option.
+type AliyunListOption struct {
+	core.AliyunListOption `json:",inline"`
+}
+
+// Validate aliyun security group list option.
+func (opt AliyunListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunListResult define aliyun security group list result.
+type AliyunListResult struct {
+	TotalCount int64      `json:"total_count"`
+	Details    []AliyunSG `json:"details"`
+}
+
+// AliyunSG define aliyun security group.
+type AliyunSG struct {
+	SecurityGroupID   string `json:"SecurityGroupId"`
+	SecurityGroupName string `json:"SecurityGroupName"`
+	Description       string `json:"Description"`
+	VpcID             string `json:"VpcId"`
+	CreationTime      string `json:"CreationTime"`
+	// SecurityGroupType 安全组类型，normal：普通安全组，enterprise：企业安全组。
+	SecurityGroupType string `json:"SecurityGroupType"`
+	ResourceGroupID   string `json:"ResourceGroupId"`
+	Tags              struct {
+		Tag []AliyunTag `json:"Tag"`
+	} `json:"Tags"`
+}
+
+// AliyunTag define aliyun resource tag.
+type AliyunTag struct {
+	TagKey   string `json:"TagKey"`
+	TagValue string `json:"TagValue"`
+}
+
+// GetCloudID ...
+func (sg AliyunSG) GetCloudID() string {
+	return sg.SecurityGroupID
+}
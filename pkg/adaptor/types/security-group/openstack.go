@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/adaptor/types/core"
+)
+
+// OpenStackListOption define openstack security group list option.
+type OpenStackListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack security group list option.
+func (opt OpenStackListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackListResult define openstack security group list result.
+type OpenStackListResult struct {
+	Details []OpenStackSG `json:"details"`
+}
+
+// OpenStackSG define openstack security group.
+type OpenStackSG struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ProjectID   string `json:"project_id"`
+	// Stateful 是否为有状态安全组，无状态安全组不会自动放通回包
+	Stateful  bool     `json:"stateful"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	Tags      []string `json:"tags"`
+}
+
+// GetCloudID ...
+func (sg OpenStackSG) GetCloudID() string {
+	return sg.ID
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package adtysubnet
+
+import (
+	"hcm/pkg/adaptor/types/core"
+)
+
+// -------------------------- List --------------------------
+
+// AliyunSubnetListOption defines aliyun list subnet(vswitch) options.
+type AliyunSubnetListOption struct {
+	core.AliyunListOption `json:",inline"`
+	CloudVpcID            string `json:"cloud_vpc_id,omitempty"`
+}
+
+// Validate aliyun list option.
+func (opt AliyunSubnetListOption) Validate() error {
+	return opt.AliyunListOption.Validate()
+}
+
+// AliyunSubnetListResult defines aliyun list subnet result.
+type AliyunSubnetListResult struct {
+	TotalCount int64          `json:"total_count"`
+	Details    []AliyunSubnet `json:"details"`
+}
+
+// AliyunSubnetExtension defines aliyun subnet extensional info.
+type AliyunSubnetExtension struct {
+	Zone                    string `json:"zone"`
+	Status                  string `json:"status"`
+	IsDefault               bool   `json:"is_default"`
+	AvailableIpAddressCount int64  `json:"available_ip_address_count"`
+	CloudNetworkAclID       string `json:"cloud_network_acl_id,omitempty"`
+	CloudRouteTableID       string `json:"cloud_route_table_id,omitempty"`
+}
+
+// AliyunSubnet defines aliyun subnet.
+type AliyunSubnet Subnet[AliyunSubnetExtension]
+
+// GetCloudID ...
+func (subnet AliyunSubnet) GetCloudID() string {
+	return subnet.CloudID
+}
@@ -77,5 +77,6 @@ type Subnet[T SubnetExtension] struct {
 
 // SubnetExtension defines subnet extensional info.
 type SubnetExtension interface {
-	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension
+	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension |
+		AliyunSubnetExtension | OpenStackSubnetExtension
 }
@@ -269,6 +269,37 @@ type HuaWeiVpcListResult struct {
 	Details    []HuaWeiVpc `json:"details"`
 }
 
+// AliyunVpcListOption defines aliyun list vpc options.
+type AliyunVpcListOption struct {
+	core.AliyunListOption `json:",inline"`
+}
+
+// Validate aliyun list option.
+func (v AliyunVpcListOption) Validate() error {
+	return v.AliyunListOption.Validate()
+}
+
+// AliyunVpcListResult defines aliyun list vpc result.
+type AliyunVpcListResult struct {
+	TotalCount int64       `json:"total_count"`
+	Details    []AliyunVpc `json:"details"`
+}
+
+// OpenStackVpcListOption defines openstack list network options.
+type OpenStackVpcListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack list option.
+func (v OpenStackVpcListOption) Validate() error {
+	return v.OpenStackListOption.Validate()
+}
+
+// OpenStackVpcListResult defines openstack list network result.
+type OpenStackVpcListResult struct {
+	Details []OpenStackVpc `json:"details"`
+}
+
 // Vpc defines vpc struct.
 type Vpc[T VpcExtension] struct {
 	CloudID   string  `json:"cloud_id"`
@@ -289,7 +320,7 @@ type AzureVpcExtension struct {
 // VpcExtension defines vpc extensional info.
 type VpcExtension interface {
 	cloud.TCloudVpcExtension | cloud.AwsVpcExtension | cloud.GcpVpcExtension | AzureVpcExtension |
-		cloud.HuaWeiVpcExtension
+		cloud.HuaWeiVpcExtension | cloud.AliyunVpcExtension | cloud.OpenStackVpcExtension
 }
 
 // TCloudVpc defines tencent cloud vpc.
@@ -332,6 +363,22 @@ func (vpc HuaWeiVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// AliyunVpc defines aliyun vpc.
+type AliyunVpc Vpc[cloud.AliyunVpcExtension]
+
+// GetCloudID ...
+func (vpc AliyunVpc) GetCloudID() string {
+	return vpc.CloudID
+}
+
+// OpenStackVpc defines openstack network.
+type OpenStackVpc Vpc[cloud.OpenStackVpcExtension]
+
+// GetCloudID ...
+func (vpc OpenStackVpc) GetCloudID() string {
+	return vpc.CloudID
+}
+
 // VpcUsage define vpc usage.
 type VpcUsage struct {
 	ID           *string  `json:"id"`
@@ -100,3 +100,15 @@ type GetNoAuthSkipUrlResp struct {
 	rest.BaseResp `json:",inline"`
 	Data          string `json:"data"`
 }
+
+// DecisionCacheRevision the revision of the authorization decision cache, it is changed every time the cache is
+// invalidated, the authorizers flush their cached decisions when they find the revision changed.
+type DecisionCacheRevision struct {
+	Revision int64 `json:"revision"`
+}
+
+// DecisionCacheRevisionResp authorization decision cache revision response.
+type DecisionCacheRevisionResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *DecisionCacheRevision `json:"data"`
+}
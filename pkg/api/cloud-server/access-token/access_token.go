@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken ...
+package accesstoken
+
+import (
+	coretoken "hcm/pkg/api/core/access-token"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateAccessTokenReq defines create access token request, the token is owned by the request user or the
+// service account.
+type CreateAccessTokenReq struct {
+	Name  string          `json:"name" validate:"required,lte=255"`
+	Scope coretoken.Scope `json:"scope"`
+	// ExpiresInDays 有效期天数，令牌必须设置有效期
+	ExpiresInDays uint    `json:"expires_in_days" validate:"required"`
+	Memo          *string `json:"memo"`
+}
+
+// Validate CreateAccessTokenReq.
+func (req *CreateAccessTokenReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := coretoken.ValidateExpiry(req.ExpiresInDays, coretoken.MaxExpiresInDays); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateMemo(req.Memo, false); err != nil {
+		return err
+	}
+
+	return req.Scope.Validate()
+}
+
+// CreateAccessTokenResult defines create access token result, the token is only returned once.
+type CreateAccessTokenResult struct {
+	ID        string `json:"id"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// CreateServiceAccountReq defines create service account request.
+type CreateServiceAccountReq = dstoken.ServiceAccountCreate
+
+// UpdateServiceAccountReq defines update service account request.
+type UpdateServiceAccountReq struct {
+	Memo *string `json:"memo" validate:"required"`
+}
+
+// Validate UpdateServiceAccountReq.
+func (req *UpdateServiceAccountReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validator.ValidateMemo(req.Memo, false)
+}
+
+// BatchDeleteReq defines batch delete service account request.
+type BatchDeleteReq = dstoken.BatchDeleteReq
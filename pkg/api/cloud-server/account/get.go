@@ -77,6 +77,28 @@ func (req *HuaWeiAccountInfoBySecretReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// AliyunAccountInfoBySecretReq ...
+type AliyunAccountInfoBySecretReq struct {
+	DisableCheck        bool `json:"disable_check" validate:"omitempty"`
+	*cloud.AliyunSecret `json:",inline" validate:"required"`
+}
+
+// Validate ...
+func (req *AliyunAccountInfoBySecretReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// OpenStackAccountInfoBySecretReq ...
+type OpenStackAccountInfoBySecretReq struct {
+	DisableCheck           bool `json:"disable_check" validate:"omitempty"`
+	*cloud.OpenStackSecret `json:",inline" validate:"required"`
+}
+
+// Validate ...
+func (req *OpenStackAccountInfoBySecretReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // AzureAccountInfoBySecretReq ...
 type AzureAccountInfoBySecretReq struct {
 	DisableCheck       bool `json:"disable_check" validate:"omitempty"`
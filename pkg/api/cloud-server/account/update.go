@@ -107,6 +107,58 @@ func (req *HuaWeiAccountExtensionUpdateReq) IsFull() bool {
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
+// AliyunAccountExtensionUpdateReq ...
+type AliyunAccountExtensionUpdateReq struct {
+	CloudSubAccountID string `json:"cloud_sub_account_id" validate:"required"`
+	CloudSecretID     string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey    string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *AliyunAccountExtensionUpdateReq) Validate(accountType enumor.AccountType) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	// 登记账号密钥可为空，其他类型则必填
+	if accountType != enumor.RegistrationAccount && !req.IsFull() {
+		return secretEmptyError
+	}
+
+	return nil
+}
+
+// IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
+func (req *AliyunAccountExtensionUpdateReq) IsFull() bool {
+	return req.CloudSecretID != "" && req.CloudSecretKey != ""
+}
+
+// OpenStackAccountExtensionUpdateReq ...
+type OpenStackAccountExtensionUpdateReq struct {
+	CloudRegion    string `json:"cloud_region" validate:"omitempty"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *OpenStackAccountExtensionUpdateReq) Validate(accountType enumor.AccountType) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	// 登记账号密钥可为空，其他类型则必填
+	if accountType != enumor.RegistrationAccount && !req.IsFull() {
+		return secretEmptyError
+	}
+
+	return nil
+}
+
+// IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
+func (req *OpenStackAccountExtensionUpdateReq) IsFull() bool {
+	return req.CloudSecretID != "" && req.CloudSecretKey != ""
+}
+
 // GcpAccountExtensionUpdateReq ...
 type GcpAccountExtensionUpdateReq struct {
 	CloudProjectName        string `json:"cloud_project_name" validate:"omitempty"`
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule ...
+package assignrule
+
+import (
+	coreassign "hcm/pkg/api/core/assign-rule"
+	dsassign "hcm/pkg/api/data-service/assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateAssignRuleReq defines create assign rule request.
+type CreateAssignRuleReq = dsassign.AssignRuleCreate
+
+// UpdateAssignRuleReq defines update assign rule request, only set fields will be updated.
+type UpdateAssignRuleReq struct {
+	Name       string                     `json:"name" validate:"omitempty,lte=64"`
+	Priority   *uint32                    `json:"priority"`
+	Enabled    *bool                      `json:"enabled"`
+	BkBizID    int64                      `json:"bk_biz_id" validate:"omitempty,min=1"`
+	BkCloudID  *int64                     `json:"bk_cloud_id" validate:"omitempty,min=0"`
+	ResTypes   []enumor.CloudResourceType `json:"res_types"`
+	Conditions *coreassign.Conditions     `json:"conditions"`
+	Memo       *string                    `json:"memo"`
+}
+
+// Validate UpdateAssignRuleReq.
+func (req *UpdateAssignRuleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Conditions != nil {
+		if err := req.Conditions.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.ValidateMemo(req.Memo, false)
+}
+
+// BatchDeleteReq defines batch delete assign rule request.
+type BatchDeleteReq = dsassign.BatchDeleteReq
+
+// PreviewAssignRuleReq defines preview assign rule request, preview the given rule if it is set, otherwise
+// preview all enabled rules.
+type PreviewAssignRuleReq struct {
+	AccountID string               `json:"account_id" validate:"required"`
+	Rule      *CreateAssignRuleReq `json:"rule"`
+}
+
+// Validate PreviewAssignRuleReq.
+func (req *PreviewAssignRuleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Rule != nil {
+		return req.Rule.Validate()
+	}
+
+	return nil
+}
+
+// ApplyAssignRuleReq defines apply enabled assign rules to an account's unassigned resources request.
+type ApplyAssignRuleReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate ApplyAssignRuleReq.
+func (req *ApplyAssignRuleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AssignRuleMatchResult defines the matched resources of preview or apply assign rules.
+type AssignRuleMatchResult struct {
+	Details []coreassign.MatchResult `json:"details"`
+}
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"errors"
+	"fmt"
+
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// DiskSnapshotCreateReq 创建云硬盘快照请求
+type DiskSnapshotCreateReq struct {
+	DiskID string `json:"disk_id" validate:"required"`
+	Name   string `json:"name" validate:"omitempty,max=60"`
+}
+
+// Validate DiskSnapshotCreateReq.
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotRollbackReq 使用快照回滚云硬盘请求
+type DiskSnapshotRollbackReq struct {
+	// AutoStopInstance 回滚前自动关闭云硬盘挂载的主机并在回滚后开机，仅腾讯云支持
+	AutoStopInstance bool `json:"auto_stop_instance"`
+}
+
+// Validate DiskSnapshotRollbackReq.
+func (req *DiskSnapshotRollbackReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotPolicyCreateReq 创建定期快照策略请求
+type DiskSnapshotPolicyCreateReq struct {
+	AccountID     string  `json:"account_id" validate:"required"`
+	Name          string  `json:"name" validate:"required,max=255"`
+	Hours         []int   `json:"hours" validate:"required,min=1,max=24"`
+	WeekDays      []int   `json:"week_days" validate:"omitempty,max=7"`
+	RetentionDays uint64  `json:"retention_days"`
+	Memo          *string `json:"memo"`
+}
+
+// Validate DiskSnapshotPolicyCreateReq.
+func (req *DiskSnapshotPolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return dssnapshot.ValidateSchedule(req.Hours, req.WeekDays)
+}
+
+// DiskSnapshotPolicyUpdateReq 更新定期快照策略请求，为空的字段不更新
+type DiskSnapshotPolicyUpdateReq struct {
+	Name          string  `json:"name" validate:"omitempty,max=255"`
+	Hours         []int   `json:"hours" validate:"omitempty,max=24"`
+	WeekDays      []int   `json:"week_days" validate:"omitempty,max=7"`
+	RetentionDays *uint64 `json:"retention_days"`
+	Memo          *string `json:"memo"`
+}
+
+// Validate DiskSnapshotPolicyUpdateReq.
+func (req *DiskSnapshotPolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Hours != nil && len(req.Hours) == 0 {
+		return errors.New("hours can not be empty")
+	}
+
+	return dssnapshot.ValidateSchedule(req.Hours, req.WeekDays)
+}
+
+// DiskSnapshotPolicyBindReq 定期快照策略绑定或解绑云硬盘请求
+type DiskSnapshotPolicyBindReq struct {
+	DiskIDs []string `json:"disk_ids" validate:"required,min=1"`
+}
+
+// Validate DiskSnapshotPolicyBindReq.
+func (req *DiskSnapshotPolicyBindReq) Validate() error {
+	if len(req.DiskIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("disk_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
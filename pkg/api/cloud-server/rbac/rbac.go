@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac ...
+package rbac
+
+import (
+	"errors"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateRoleReq defines create role request.
+type CreateRoleReq = dsrbac.RoleCreate
+
+// UpdateRoleReq defines update role request, only the set fields are updated.
+type UpdateRoleReq struct {
+	Name     string            `json:"name" validate:"lte=255"`
+	Policies []corerbac.Policy `json:"policies"`
+	Memo     *string           `json:"memo"`
+}
+
+// Validate UpdateRoleReq.
+func (req *UpdateRoleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.Policies == nil && req.Memo == nil {
+		return errors.New("at least one of name, policies and memo should be set")
+	}
+
+	if req.Policies != nil {
+		return corerbac.ValidatePolicies(req.Policies)
+	}
+
+	return nil
+}
+
+// CreateRoleBindingReq defines create role binding request.
+type CreateRoleBindingReq = dsrbac.RoleBindingCreate
+
+// UpdateRoleBindingReq defines update role binding request, scope type and scope ids are updated together.
+type UpdateRoleBindingReq struct {
+	ScopeType corerbac.ScopeType `json:"scope_type"`
+	ScopeIDs  []string           `json:"scope_ids"`
+	Memo      *string            `json:"memo"`
+}
+
+// Validate UpdateRoleBindingReq.
+func (req *UpdateRoleBindingReq) Validate() error {
+	if len(req.ScopeType) == 0 {
+		if len(req.ScopeIDs) != 0 {
+			return errors.New("scope_type is required when scope_ids is set")
+		}
+
+		if req.Memo == nil {
+			return errors.New("at least one of scope_type and memo should be set")
+		}
+
+		return nil
+	}
+
+	return corerbac.ValidateScope(req.ScopeType, req.ScopeIDs)
+}
@@ -0,0 +1,36 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation ...
+package recommendation
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// ApplyRecommendationReq 执行资源优化建议请求
+type ApplyRecommendationReq struct {
+	// ForceStop 调整主机规格时，若主机处于运行中是否强制关机，仅对规格调整建议生效
+	ForceStop bool `json:"force_stop"`
+}
+
+// Validate ApplyRecommendationReq.
+func (req *ApplyRecommendationReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
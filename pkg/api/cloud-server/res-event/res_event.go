@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent ...
+package resevent
+
+import (
+	coreevent "hcm/pkg/api/core/res-event"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateSubscriptionReq defines create event subscription request.
+type CreateSubscriptionReq = dsevent.SubscriptionCreate
+
+// UpdateSubscriptionReq defines update event subscription request, only set fields will be updated.
+type UpdateSubscriptionReq struct {
+	Name    string                        `json:"name" validate:"omitempty,lte=64"`
+	URL     string                        `json:"url"`
+	Secret  string                        `json:"secret"`
+	Filter  *coreevent.SubscriptionFilter `json:"filter"`
+	Enabled *bool                         `json:"enabled"`
+	Memo    *string                       `json:"memo"`
+}
+
+// Validate UpdateSubscriptionReq.
+func (req *UpdateSubscriptionReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	update := &dsevent.SubscriptionUpdate{URL: req.URL, Secret: req.Secret, Filter: req.Filter}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	return validator.ValidateMemo(req.Memo, false)
+}
+
+// BatchDeleteReq defines batch delete event subscription request.
+type BatchDeleteReq = dsevent.BatchDeleteReq
+
+// RetryDeliveryReq defines retry the failed event deliveries request.
+type RetryDeliveryReq = dsevent.RetryDeliveryReq
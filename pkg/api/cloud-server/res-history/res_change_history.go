@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory ...
+package reshistory
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// ListResChangeHistoryReq 查询资源变更历史（时间线）请求
+type ListResChangeHistoryReq struct {
+	// StartTime 变更时间的开始时间（包含），为空时不限制，格式：2006-01-02T15:04:05Z07:00
+	StartTime string `json:"start_time" validate:"omitempty"`
+	// EndTime 变更时间的结束时间（包含），为空时不限制，格式：2006-01-02T15:04:05Z07:00
+	EndTime string         `json:"end_time" validate:"omitempty"`
+	Page    *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate ListResChangeHistoryReq.
+func (req *ListResChangeHistoryReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	var start, end time.Time
+	var err error
+	if len(req.StartTime) != 0 {
+		if start, err = time.Parse(constant.TimeStdFormat, req.StartTime); err != nil {
+			return fmt.Errorf("start_time is invalid, should be like: %s", constant.TimeStdFormat)
+		}
+	}
+
+	if len(req.EndTime) != 0 {
+		if end, err = time.Parse(constant.TimeStdFormat, req.EndTime); err != nil {
+			return fmt.Errorf("end_time is invalid, should be like: %s", constant.TimeStdFormat)
+		}
+	}
+
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return errors.New("end_time should not be earlier than start_time")
+	}
+
+	return req.Page.Validate(core.NewDefaultPageOption())
+}
+
+// DiffResChangeHistoryReq 对比资源任意两个版本的请求
+type DiffResChangeHistoryReq struct {
+	FromVersion uint64 `json:"from_version" validate:"required,min=1"`
+	ToVersion   uint64 `json:"to_version" validate:"required,min=1"`
+}
+
+// Validate DiffResChangeHistoryReq.
+func (req *DiffResChangeHistoryReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.FromVersion == req.ToVersion {
+		return errors.New("from_version and to_version should be different")
+	}
+
+	return nil
+}
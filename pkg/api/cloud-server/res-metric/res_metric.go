@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric ...
+package resmetric
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	typemonitor "hcm/pkg/adaptor/types/monitor"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// MaxResMetricQueryDays 单次查询天级监控数据的最大天数
+const MaxResMetricQueryDays = 90
+
+// ListResMetricReq 查询资源天级监控数据请求
+type ListResMetricReq struct {
+	// Metrics 指标名称，为空时查询资源支持的全部指标
+	Metrics []typemonitor.MetricName `json:"metrics" validate:"omitempty,max=10"`
+	// StartDate 开始日期，格式：2006-01-02
+	StartDate string `json:"start_date" validate:"required"`
+	// EndDate 结束日期（包含），格式：2006-01-02
+	EndDate string `json:"end_date" validate:"required"`
+}
+
+// Validate ListResMetricReq.
+func (req *ListResMetricReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, metric := range req.Metrics {
+		if err := metric.Validate(); err != nil {
+			return err
+		}
+	}
+
+	start, err := time.Parse(constant.DateLayout, req.StartDate)
+	if err != nil {
+		return fmt.Errorf("start_date is invalid, err: %v", err)
+	}
+
+	end, err := time.Parse(constant.DateLayout, req.EndDate)
+	if err != nil {
+		return fmt.Errorf("end_date is invalid, err: %v", err)
+	}
+
+	if end.Before(start) {
+		return errors.New("end_date should not be earlier than start_date")
+	}
+
+	if end.Sub(start) >= MaxResMetricQueryDays*24*time.Hour {
+		return fmt.Errorf("query date range should <= %d days", MaxResMetricQueryDays)
+	}
+
+	return nil
+}
+
+// ListDates 返回查询区间内的全部日期，需要在 Validate 之后调用
+func (req *ListResMetricReq) ListDates() []string {
+	start, _ := time.Parse(constant.DateLayout, req.StartDate)
+	end, _ := time.Parse(constant.DateLayout, req.EndDate)
+
+	dates := make([]string, 0)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(constant.DateLayout))
+	}
+
+	return dates
+}
@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resspec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/json"
+)
+
+// SecurityGroupRuleResType 安全组规则在执行计划中的资源类型
+const SecurityGroupRuleResType enumor.CloudResourceType = "security_group_rule"
+
+// PlanReq defines plan res spec request.
+type PlanReq struct {
+	// Spec YAML或JSON格式的资源描述
+	Spec string `json:"spec" validate:"required"`
+}
+
+// Validate PlanReq.
+func (r *PlanReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// ApplyReq defines apply res spec request.
+type ApplyReq struct {
+	// Spec 需与生成执行计划时的资源描述一致
+	Spec string `json:"spec" validate:"required"`
+	// PlanDigest 审阅过的执行计划摘要，执行前会重新计算执行计划，摘要不一致说明资源状态已变化，需要重新审阅
+	PlanDigest string `json:"plan_digest" validate:"required,len=64"`
+}
+
+// Validate ApplyReq.
+func (r *ApplyReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// ApplyResult defines apply res spec result.
+type ApplyResult struct {
+	FlowID  string      `json:"flow_id"`
+	Summary PlanSummary `json:"summary"`
+}
+
+// ChangeAction 资源变更动作
+type ChangeAction string
+
+const (
+	// ChangeCreate 创建资源
+	ChangeCreate ChangeAction = "create"
+	// ChangeUpdate 更新资源
+	ChangeUpdate ChangeAction = "update"
+	// ChangeDelete 删除资源
+	ChangeDelete ChangeAction = "delete"
+	// ChangeNoOp 资源与期望状态一致，无需变更
+	ChangeNoOp ChangeAction = "no_op"
+)
+
+// Plan 执行计划，按执行顺序列出资源描述中每个资源的变更。
+type Plan struct {
+	AccountID string      `json:"account_id"`
+	Region    string      `json:"region"`
+	Digest    string      `json:"digest"`
+	Summary   PlanSummary `json:"summary"`
+	Changes   []Change    `json:"changes"`
+}
+
+// PlanSummary 执行计划中各变更动作的资源数量
+type PlanSummary struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+	NoOp   int `json:"no_op"`
+}
+
+// Change 单个资源的变更
+type Change struct {
+	Action  ChangeAction             `json:"action"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// Ref 资源在资源描述中的引用名，子网为 vpc名称/子网名称，安全组规则为 安全组名称/方向/规则标识
+	Ref     string      `json:"ref"`
+	ID      string      `json:"id,omitempty"`
+	CloudID string      `json:"cloud_id,omitempty"`
+	Diffs   []FieldDiff `json:"diffs,omitempty"`
+}
+
+// FieldDiff 资源字段变更前后的值
+type FieldDiff struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Seal 统计执行计划并计算摘要，摘要覆盖账号、地域及所有变更，用于执行时确认资源状态未发生变化。
+func (p *Plan) Seal() error {
+	p.Summary = PlanSummary{}
+	for _, one := range p.Changes {
+		switch one.Action {
+		case ChangeCreate:
+			p.Summary.Create++
+		case ChangeUpdate:
+			p.Summary.Update++
+		case ChangeDelete:
+			p.Summary.Delete++
+		default:
+			p.Summary.NoOp++
+		}
+	}
+
+	content, err := json.Marshal(struct {
+		AccountID string   `json:"account_id"`
+		Region    string   `json:"region"`
+		Changes   []Change `json:"changes"`
+	}{AccountID: p.AccountID, Region: p.Region, Changes: p.Changes})
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(content)
+	p.Digest = hex.EncodeToString(sum[:])
+	return nil
+}
@@ -0,0 +1,411 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resspec 声明式资源描述，描述某个账号某个地域下期望的资源状态，由云管计算执行计划并异步执行。
+package resspec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/json"
+)
+
+// MaxSpecLen 资源描述内容的最大长度
+const MaxSpecLen = 1 << 20
+
+// ResState 资源期望状态
+type ResState string
+
+const (
+	// Present 资源应存在，不存在时创建，属性不一致时更新
+	Present ResState = "present"
+	// Absent 资源不应存在，存在时删除
+	Absent ResState = "absent"
+)
+
+// Validate ResState.
+func (s ResState) Validate() error {
+	switch s {
+	case Present, Absent:
+		return nil
+	default:
+		return fmt.Errorf("unsupported state: %s", s)
+	}
+}
+
+// ParseSpec 解析YAML或JSON格式的资源描述，JSON是YAML的子集，统一按YAML解析后转换为JSON再解码，
+// 以便资源描述中的字段与接口字段使用相同的 json tag。
+func ParseSpec(raw string) (*Spec, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("spec is required")
+	}
+
+	if len(raw) > MaxSpecLen {
+		return nil, fmt.Errorf("spec length should <= %d", MaxSpecLen)
+	}
+
+	var content interface{}
+	if err := yaml.Unmarshal([]byte(raw), &content); err != nil {
+		return nil, fmt.Errorf("parse spec failed, err: %v", err)
+	}
+
+	js, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("convert spec to json failed, err: %v", err)
+	}
+
+	spec := new(Spec)
+	if err = json.Unmarshal(js, spec); err != nil {
+		return nil, fmt.Errorf("decode spec failed, err: %v", err)
+	}
+
+	spec.setDefault()
+	if err = spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// Spec 声明式资源描述，资源通过名称与云管中已同步的资源进行匹配，名称在账号地域内需唯一。
+type Spec struct {
+	AccountID      string              `json:"account_id" validate:"required"`
+	Region         string              `json:"region" validate:"required"`
+	Vpcs           []VpcSpec           `json:"vpcs" validate:"omitempty,max=20,dive"`
+	SecurityGroups []SecurityGroupSpec `json:"security_groups" validate:"omitempty,max=50,dive"`
+	Cvms           []CvmSpec           `json:"cvms" validate:"omitempty,max=100,dive"`
+}
+
+func (s *Spec) setDefault() {
+	for i := range s.Vpcs {
+		if s.Vpcs[i].State == "" {
+			s.Vpcs[i].State = Present
+		}
+		for j := range s.Vpcs[i].Subnets {
+			if s.Vpcs[i].Subnets[j].State == "" {
+				s.Vpcs[i].Subnets[j].State = Present
+			}
+		}
+	}
+
+	for i := range s.SecurityGroups {
+		if s.SecurityGroups[i].State == "" {
+			s.SecurityGroups[i].State = Present
+		}
+	}
+
+	for i := range s.Cvms {
+		if s.Cvms[i].State == "" {
+			s.Cvms[i].State = Present
+		}
+	}
+}
+
+// Validate Spec.
+func (s *Spec) Validate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.Vpcs) == 0 && len(s.SecurityGroups) == 0 && len(s.Cvms) == 0 {
+		return errors.New("spec should declare at least one resource")
+	}
+
+	vpcs := make(map[string]*VpcSpec, len(s.Vpcs))
+	subnets := make(map[string]*SubnetSpec)
+	for i := range s.Vpcs {
+		vpc := &s.Vpcs[i]
+		if err := vpc.Validate(); err != nil {
+			return fmt.Errorf("vpc %s is invalid, err: %v", vpc.Name, err)
+		}
+		if _, exist := vpcs[vpc.Name]; exist {
+			return fmt.Errorf("vpc name %s is duplicated", vpc.Name)
+		}
+		vpcs[vpc.Name] = vpc
+
+		for j := range vpc.Subnets {
+			ref := SubnetRef(vpc.Name, vpc.Subnets[j].Name)
+			if _, exist := subnets[ref]; exist {
+				return fmt.Errorf("subnet %s is duplicated", ref)
+			}
+			subnets[ref] = &vpc.Subnets[j]
+		}
+	}
+
+	sgs := make(map[string]*SecurityGroupSpec, len(s.SecurityGroups))
+	for i := range s.SecurityGroups {
+		sg := &s.SecurityGroups[i]
+		if err := sg.Validate(); err != nil {
+			return fmt.Errorf("security group %s is invalid, err: %v", sg.Name, err)
+		}
+		if _, exist := sgs[sg.Name]; exist {
+			return fmt.Errorf("security group name %s is duplicated", sg.Name)
+		}
+		sgs[sg.Name] = sg
+	}
+
+	cvms := make(map[string]struct{}, len(s.Cvms))
+	for i := range s.Cvms {
+		cvm := &s.Cvms[i]
+		if err := cvm.Validate(); err != nil {
+			return fmt.Errorf("cvm %s is invalid, err: %v", cvm.Name, err)
+		}
+		if _, exist := cvms[cvm.Name]; exist {
+			return fmt.Errorf("cvm name %s is duplicated", cvm.Name)
+		}
+		cvms[cvm.Name] = struct{}{}
+
+		if cvm.State == Absent {
+			continue
+		}
+
+		// 主机引用的网络资源必须在资源描述中声明且期望存在，以便执行时确定创建顺序并获取云上ID
+		if vpc, exist := vpcs[cvm.Vpc]; !exist || vpc.State != Present {
+			return fmt.Errorf("cvm %s refers vpc %s which is not declared as present", cvm.Name, cvm.Vpc)
+		}
+		ref := SubnetRef(cvm.Vpc, cvm.Subnet)
+		if subnet, exist := subnets[ref]; !exist || subnet.State != Present {
+			return fmt.Errorf("cvm %s refers subnet %s which is not declared as present", cvm.Name, ref)
+		}
+		for _, name := range cvm.SecurityGroups {
+			if sg, exist := sgs[name]; !exist || sg.State != Present {
+				return fmt.Errorf("cvm %s refers security group %s which is not declared as present", cvm.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SubnetRef 子网在资源描述中的引用名，子网名称只需在所属VPC内唯一。
+func SubnetRef(vpcName, subnetName string) string {
+	return vpcName + "/" + subnetName
+}
+
+// VpcSpec VPC期望状态
+type VpcSpec struct {
+	Name     string       `json:"name" validate:"required,max=60"`
+	State    ResState     `json:"state" validate:"required"`
+	IPv4Cidr string       `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
+	Memo     *string      `json:"memo" validate:"omitempty,max=255"`
+	Subnets  []SubnetSpec `json:"subnets" validate:"omitempty,max=50,dive"`
+}
+
+// Validate VpcSpec.
+func (v *VpcSpec) Validate() error {
+	if err := v.State.Validate(); err != nil {
+		return err
+	}
+
+	if v.State == Absent {
+		// VPC删除前需要先删除其下所有子网，声明删除VPC时不允许同时声明期望存在的子网
+		for _, one := range v.Subnets {
+			if one.State != Absent {
+				return fmt.Errorf("subnet %s should be absent when vpc is absent", one.Name)
+			}
+		}
+		return nil
+	}
+
+	if len(v.IPv4Cidr) == 0 {
+		return errors.New("ipv4_cidr is required")
+	}
+
+	for _, one := range v.Subnets {
+		if err := one.Validate(); err != nil {
+			return fmt.Errorf("subnet %s is invalid, err: %v", one.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SubnetSpec 子网期望状态
+type SubnetSpec struct {
+	Name     string   `json:"name" validate:"required,max=60"`
+	State    ResState `json:"state" validate:"required"`
+	Zone     string   `json:"zone" validate:"omitempty"`
+	IPv4Cidr string   `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
+	Memo     *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubnetSpec.
+func (s *SubnetSpec) Validate() error {
+	if err := s.State.Validate(); err != nil {
+		return err
+	}
+
+	if s.State == Absent {
+		return nil
+	}
+
+	if len(s.Zone) == 0 || len(s.IPv4Cidr) == 0 {
+		return errors.New("zone and ipv4_cidr are required")
+	}
+
+	return nil
+}
+
+// SecurityGroupSpec 安全组期望状态，规则按集合比较，缺少的规则追加创建，多余的规则删除。
+type SecurityGroupSpec struct {
+	Name         string       `json:"name" validate:"required,max=60"`
+	State        ResState     `json:"state" validate:"required"`
+	Memo         *string      `json:"memo" validate:"omitempty,max=100"`
+	Manager      string       `json:"manager" validate:"omitempty"`
+	BakManager   string       `json:"bak_manager" validate:"omitempty"`
+	IngressRules []SGRuleSpec `json:"ingress_rules" validate:"omitempty,max=100,dive"`
+	EgressRules  []SGRuleSpec `json:"egress_rules" validate:"omitempty,max=100,dive"`
+}
+
+// Validate SecurityGroupSpec.
+func (s *SecurityGroupSpec) Validate() error {
+	if err := s.State.Validate(); err != nil {
+		return err
+	}
+
+	if s.State == Absent {
+		return nil
+	}
+
+	for typ, rules := range map[string][]SGRuleSpec{"ingress": s.IngressRules, "egress": s.EgressRules} {
+		keys := make(map[string]struct{}, len(rules))
+		for _, rule := range rules {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("%s rule is invalid, err: %v", typ, err)
+			}
+			key := rule.Key()
+			if _, exist := keys[key]; exist {
+				return fmt.Errorf("%s rule %s is duplicated", typ, key)
+			}
+			keys[key] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// SGRuleSpec 安全组规则期望状态
+type SGRuleSpec struct {
+	Protocol string   `json:"protocol" validate:"required"`
+	Port     RulePort `json:"port" validate:"required"`
+	IPv4Cidr string   `json:"ipv4_cidr" validate:"omitempty"`
+	IPv6Cidr string   `json:"ipv6_cidr" validate:"omitempty"`
+	Action   string   `json:"action" validate:"required"`
+	Memo     string   `json:"memo" validate:"omitempty,max=100"`
+}
+
+// Validate SGRuleSpec.
+func (r *SGRuleSpec) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if (len(r.IPv4Cidr) == 0) == (len(r.IPv6Cidr) == 0) {
+		return errors.New("one of ipv4_cidr and ipv6_cidr is required")
+	}
+
+	switch strings.ToUpper(r.Action) {
+	case "ACCEPT", "DROP":
+	default:
+		return fmt.Errorf("unsupported action: %s", r.Action)
+	}
+
+	return nil
+}
+
+// Key 规则的唯一标识，用于与已有规则比较，不区分大小写且不包含备注。
+func (r *SGRuleSpec) Key() string {
+	return RuleKey(r.Protocol, string(r.Port), r.IPv4Cidr, r.IPv6Cidr, r.Action)
+}
+
+// RulePort 规则端口，如 22、80,443、3000-4000、ALL，YAML中未加引号的单个端口会被解析为数字，这里兼容数字格式。
+type RulePort string
+
+// UnmarshalJSON RulePort.
+func (p *RulePort) UnmarshalJSON(raw []byte) error {
+	if len(raw) != 0 && raw[0] == '"' {
+		var port string
+		if err := json.Unmarshal(raw, &port); err != nil {
+			return err
+		}
+		*p = RulePort(port)
+		return nil
+	}
+
+	if string(raw) != "null" {
+		*p = RulePort(raw)
+	}
+	return nil
+}
+
+// RuleKey 由规则的生效字段生成规则唯一标识
+func RuleKey(protocol, port, ipv4Cidr, ipv6Cidr, action string) string {
+	return strings.ToUpper(strings.Join([]string{protocol, port, ipv4Cidr, ipv6Cidr, action}, "|"))
+}
+
+// CvmSpec 主机期望状态，已存在的主机仅校验不可变属性，不做变更。
+type CvmSpec struct {
+	Name                    string                               `json:"name" validate:"required,max=60"`
+	State                   ResState                             `json:"state" validate:"required"`
+	Zone                    string                               `json:"zone" validate:"omitempty"`
+	InstanceType            string                               `json:"instance_type" validate:"omitempty"`
+	CloudImageID            string                               `json:"cloud_image_id" validate:"omitempty"`
+	Vpc                     string                               `json:"vpc" validate:"omitempty"`
+	Subnet                  string                               `json:"subnet" validate:"omitempty"`
+	SecurityGroups          []string                             `json:"security_groups" validate:"omitempty,max=5"`
+	Password                string                               `json:"password" validate:"omitempty"`
+	InstanceChargeType      typecvm.TCloudInstanceChargeType     `json:"instance_charge_type" validate:"omitempty"`
+	InstanceChargePrepaid   *typecvm.TCloudInstanceChargePrepaid `json:"instance_charge_prepaid" validate:"omitempty"`
+	SystemDisk              *typecvm.TCloudSystemDisk            `json:"system_disk" validate:"omitempty"`
+	DataDisk                []typecvm.TCloudDataDisk             `json:"data_disk" validate:"omitempty"`
+	PublicIPAssigned        bool                                 `json:"public_ip_assigned" validate:"omitempty"`
+	InternetMaxBandwidthOut int64                                `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	InternetChargeType      typecvm.TCloudInternetChargeType     `json:"internet_charge_type" validate:"omitempty"`
+}
+
+// Validate CvmSpec.
+func (c *CvmSpec) Validate() error {
+	if err := c.State.Validate(); err != nil {
+		return err
+	}
+
+	if c.State == Absent {
+		return nil
+	}
+
+	if len(c.Zone) == 0 || len(c.InstanceType) == 0 || len(c.CloudImageID) == 0 {
+		return errors.New("zone, instance_type and cloud_image_id are required")
+	}
+
+	if len(c.Vpc) == 0 || len(c.Subnet) == 0 || len(c.SecurityGroups) == 0 {
+		return errors.New("vpc, subnet and security_groups are required")
+	}
+
+	if len(c.Password) == 0 || len(c.InstanceChargeType) == 0 || c.SystemDisk == nil {
+		return errors.New("password, instance_charge_type and system_disk are required")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resspec
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+account_id: "00000001"
+region: ap-guangzhou
+vpcs:
+  - name: vpc-a
+    ipv4_cidr: 10.0.0.0/16
+    subnets:
+      - name: subnet-a
+        zone: ap-guangzhou-3
+        ipv4_cidr: 10.0.1.0/24
+security_groups:
+  - name: sg-a
+    ingress_rules:
+      - protocol: tcp
+        port: 22
+        ipv4_cidr: 10.0.0.0/8
+        action: accept
+cvms:
+  - name: web-1
+    zone: ap-guangzhou-3
+    instance_type: S5.MEDIUM2
+    cloud_image_id: img-xxxxxx
+    vpc: vpc-a
+    subnet: subnet-a
+    security_groups: [sg-a]
+    password: Passw0rd@123
+    instance_charge_type: POSTPAID_BY_HOUR
+    system_disk:
+      disk_type: CLOUD_PREMIUM
+      disk_size_gb: 50
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(testSpec)
+	if err != nil {
+		t.Fatalf("parse spec failed, err: %v", err)
+	}
+
+	if spec.Vpcs[0].State != Present || spec.Vpcs[0].Subnets[0].State != Present || spec.Cvms[0].State != Present {
+		t.Errorf("state should default to present")
+	}
+
+	rule := spec.SecurityGroups[0].IngressRules[0]
+	if rule.Port != "22" || rule.Key() != "TCP|22|10.0.0.0/8||ACCEPT" {
+		t.Errorf("unexpected rule port %s or key %s", rule.Port, rule.Key())
+	}
+
+	if spec.Cvms[0].SystemDisk == nil || *spec.Cvms[0].SystemDisk.DiskSizeGB != 50 {
+		t.Errorf("cvm system disk is not decoded")
+	}
+
+	// JSON格式同样可以解析
+	if _, err = ParseSpec(`{"account_id": "00000001", "region": "ap-guangzhou", ` +
+		`"security_groups": [{"name": "sg-a", "state": "absent"}]}`); err != nil {
+		t.Errorf("parse json spec failed, err: %v", err)
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	cases := map[string]string{
+		"absent subnet ref": strings.Replace(testSpec, "        ipv4_cidr: 10.0.1.0/24",
+			"        ipv4_cidr: 10.0.1.0/24\n        state: absent", 1),
+		"undeclared security group": strings.Replace(testSpec, "security_groups: [sg-a]",
+			"security_groups: [sg-b]", 1),
+		"vpc absent with present subnet": strings.Replace(testSpec, "    ipv4_cidr: 10.0.0.0/16",
+			"    ipv4_cidr: 10.0.0.0/16\n    state: absent", 1),
+		"duplicated rule": strings.Replace(testSpec, "        action: accept",
+			"        action: accept\n      - {protocol: TCP, port: '22', ipv4_cidr: 10.0.0.0/8, action: ACCEPT}", 1),
+		"unknown state": strings.Replace(testSpec, "  - name: sg-a", "  - name: sg-a\n    state: deleted", 1),
+	}
+
+	for name, raw := range cases {
+		_, err := ParseSpec(raw)
+		if err == nil {
+			t.Errorf("case %s should be invalid", name)
+		}
+	}
+}
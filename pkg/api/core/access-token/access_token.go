@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken 个人访问令牌及服务账号，供CI等外部自动化直接调用api-server
+package accesstoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	// TokenPrefix 令牌前缀，用于api-server识别令牌认证的请求
+	TokenPrefix = "hcm_"
+	// DisplayPrefixLen 保存用于展示的令牌前缀长度，便于用户识别令牌，不足以推算出令牌
+	DisplayPrefixLen = 12
+	// MaxExpiresInDays 令牌最长有效期天数
+	MaxExpiresInDays = 365
+	// ServiceAccountUserPrefix 服务账号调用时用户名的前缀，与真实用户名隔离，避免服务账号冒充用户
+	ServiceAccountUserPrefix = "sa:"
+)
+
+// serviceAccountNameRegexp 服务账号名称，加上前缀后不超过用户名的最大长度64
+var serviceAccountNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.-]{0,60}$`)
+
+// reservedServiceAccountNames 不能作为服务账号名称的内置用户名
+var reservedServiceAccountNames = map[string]struct{}{"admin": {}, "administrator": {}, "root": {}, "system": {}}
+
+// OwnerType 令牌所属者类型
+type OwnerType string
+
+const (
+	// UserOwner 个人访问令牌，以用户身份调用
+	UserOwner OwnerType = "user"
+	// ServiceAccountOwner 服务账号令牌，以服务账号身份调用
+	ServiceAccountOwner OwnerType = "service_account"
+)
+
+// Validate OwnerType.
+func (o OwnerType) Validate() error {
+	switch o {
+	case UserOwner, ServiceAccountOwner:
+	default:
+		return fmt.Errorf("unsupported owner type: %s", o)
+	}
+
+	return nil
+}
+
+// GenerateToken 生成令牌，返回令牌原文及哈希，令牌原文只在创建时返回给用户，不落库
+func GenerateToken() (raw string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate token failed, err: %v", err)
+	}
+
+	raw = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return raw, HashToken(raw), nil
+}
+
+// HashToken 令牌哈希，令牌为高熵随机串，无需加盐
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsToken 是否为hcm签发的令牌
+func IsToken(raw string) bool {
+	return strings.HasPrefix(raw, TokenPrefix) && len(raw) > DisplayPrefixLen
+}
+
+// Scope 令牌权限范围，令牌只能在所属者自身权限内，对范围内的操作及业务生效
+type Scope struct {
+	// Policies 允许的资源类型及操作，* 表示所有资源类型或操作
+	Policies []corerbac.Policy `json:"policies"`
+	// BizIDs 允许的业务，为空时不限制业务；不为空时只能调用这些业务下的接口
+	BizIDs []int64 `json:"biz_ids"`
+}
+
+// Validate Scope.
+func (s Scope) Validate() error {
+	if err := corerbac.ValidatePolicies(s.Policies); err != nil {
+		return err
+	}
+
+	for _, bizID := range s.BizIDs {
+		if bizID <= 0 {
+			return fmt.Errorf("biz id %d is invalid", bizID)
+		}
+	}
+
+	return nil
+}
+
+// AllowBiz 是否允许访问业务
+func (s Scope) AllowBiz(bizID int64) bool {
+	if len(s.BizIDs) == 0 {
+		return true
+	}
+
+	return slice.IsItemInSlice(s.BizIDs, bizID)
+}
+
+// AllowAction 是否允许对资源类型的操作
+func (s Scope) AllowAction(resType meta.ResourceType, action meta.Action) bool {
+	if action == meta.SkipAction {
+		return true
+	}
+
+	for _, policy := range s.Policies {
+		if policy.Match(resType, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allow 是否允许对资源的操作，限制了业务时，资源需属于范围内的业务
+func (s Scope) Allow(res meta.ResourceAttribute) bool {
+	if res.Basic == nil {
+		return false
+	}
+
+	if !s.AllowAction(res.Type, res.Action) {
+		return false
+	}
+
+	if len(s.BizIDs) == 0 || res.Action == meta.SkipAction {
+		return true
+	}
+
+	bizID := res.BizID
+	if bizID <= 0 && res.Type == meta.Biz {
+		bizID, _ = strconv.ParseInt(res.ResourceID, 10, 64)
+	}
+
+	return bizID > 0 && s.AllowBiz(bizID)
+}
+
+// ValidateExpiry 校验令牌有效期天数
+func ValidateExpiry(expiresInDays, maxDays uint) error {
+	if expiresInDays == 0 {
+		return errors.New("expires_in_days is required")
+	}
+
+	if expiresInDays > maxDays {
+		return fmt.Errorf("expires_in_days should be no more than %d", maxDays)
+	}
+
+	return nil
+}
+
+// ValidateServiceAccountName 校验服务账号名称，名称不能是内置用户名
+func ValidateServiceAccountName(name string) error {
+	if !serviceAccountNameRegexp.MatchString(name) {
+		return errors.New("service account name should start with a letter, and only contain letters, digits, " +
+			"'_', '.', '-', the max length is 61")
+	}
+
+	if _, exists := reservedServiceAccountNames[strings.ToLower(name)]; exists {
+		return fmt.Errorf("service account name %s is reserved", name)
+	}
+
+	return nil
+}
+
+// ServiceAccountUser 服务账号调用时的用户名，即 sa:<服务账号名称>
+func ServiceAccountUser(name string) string {
+	return ServiceAccountUserPrefix + name
+}
+
+// ServiceAccount 服务账号，以 sa:<服务账号名称> 作为调用时的用户名，其权限需授予该用户名
+type ServiceAccount struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Memo    *string `json:"memo"`
+	Creator string  `json:"creator"`
+	Reviser string  `json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AccessToken 访问令牌，只保存令牌哈希
+type AccessToken struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerType OwnerType `json:"owner_type"`
+	// Owner 个人访问令牌为用户名，服务账号令牌为服务账号名称，调用时的用户名为 ServiceAccountUser(Owner)
+	Owner string `json:"owner"`
+	// TokenPrefix 令牌前缀，用于识别令牌
+	TokenPrefix string `json:"token_prefix"`
+	Scope       Scope  `json:"scope"`
+	ExpiresAt   string `json:"expires_at"`
+	Revoked     bool   `json:"revoked"`
+	// LastUsedAt 最近使用时间，按一定间隔更新，未使用过为空
+	LastUsedAt *string `json:"last_used_at"`
+	Memo       *string `json:"memo"`
+	Creator    string  `json:"creator"`
+	Reviser    string  `json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accesstoken
+
+import (
+	"testing"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/iam/meta"
+)
+
+func TestGenerateToken(t *testing.T) {
+	raw, hash, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("generate token failed, err: %v", err)
+	}
+
+	if !IsToken(raw) {
+		t.Errorf("generated token %s is not recognized", raw)
+	}
+
+	if HashToken(raw) != hash {
+		t.Errorf("hash of generated token is not matched")
+	}
+
+	if IsToken("hcm_") || IsToken("Bearer xxx") {
+		t.Errorf("invalid token should not be recognized")
+	}
+}
+
+func TestValidateServiceAccountName(t *testing.T) {
+	for _, name := range []string{"ci-robot", "deploy.bot_1", "a"} {
+		if err := ValidateServiceAccountName(name); err != nil {
+			t.Errorf("service account name %s should be valid, err: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "admin", "Admin", "sa:admin", "1robot", "robot user", string(make([]byte, 62))}
+	for _, name := range invalid {
+		if err := ValidateServiceAccountName(name); err == nil {
+			t.Errorf("service account name %q should be invalid", name)
+		}
+	}
+
+	if user := ServiceAccountUser("admin"); user != "sa:admin" {
+		t.Errorf("unexpected service account user: %s", user)
+	}
+}
+
+func TestScopeAllow(t *testing.T) {
+	scope := Scope{
+		Policies: []corerbac.Policy{
+			{ResourceType: meta.Cvm, Actions: []meta.Action{meta.Find}},
+			{ResourceType: meta.Biz, Actions: []meta.Action{corerbac.Wildcard}},
+		},
+		BizIDs: []int64{100},
+	}
+
+	cases := []struct {
+		name    string
+		res     meta.ResourceAttribute
+		allowed bool
+	}{
+		{
+			name:    "find cvm in scope biz",
+			res:     meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}, BizID: 100},
+			allowed: true,
+		},
+		{
+			name:    "find cvm out of scope biz",
+			res:     meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}, BizID: 200},
+			allowed: false,
+		},
+		{
+			name:    "find cvm without biz",
+			res:     meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}},
+			allowed: false,
+		},
+		{
+			name:    "delete cvm out of scope action",
+			res:     meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Delete}, BizID: 100},
+			allowed: false,
+		},
+		{
+			name: "access scope biz by resource id",
+			res: meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access,
+				ResourceID: "100"}},
+			allowed: true,
+		},
+		{
+			name:    "skip action",
+			res:     meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Disk, Action: meta.SkipAction}},
+			allowed: true,
+		},
+	}
+
+	for _, c := range cases {
+		if got := scope.Allow(c.res); got != c.allowed {
+			t.Errorf("%s: expect allowed %v, but got %v", c.name, c.allowed, got)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/runtime/filter"
+)
+
+const (
+	// AggregateMaxGroupBy is the max number of group by columns of an aggregate request.
+	AggregateMaxGroupBy = 5
+	// AggregateMaxAggregations is the max number of aggregations of an aggregate request.
+	AggregateMaxAggregations = 10
+	// AggregateMaxLimit is the max number of groups returned by an aggregate request.
+	AggregateMaxLimit = uint(1000)
+)
+
+// AggregateOp is the aggregate function.
+type AggregateOp string
+
+const (
+	// AggCount count the rows, field is optional.
+	AggCount AggregateOp = "count"
+	// AggSum sum the numeric field.
+	AggSum AggregateOp = "sum"
+	// AggAvg average the numeric field.
+	AggAvg AggregateOp = "avg"
+	// AggMin minimum value of the field.
+	AggMin AggregateOp = "min"
+	// AggMax maximum value of the field.
+	AggMax AggregateOp = "max"
+)
+
+// Validate the aggregate op.
+func (op AggregateOp) Validate() error {
+	switch op {
+	case AggCount, AggSum, AggAvg, AggMin, AggMax:
+	default:
+		return fmt.Errorf("unsupported aggregate op: %s", op)
+	}
+
+	return nil
+}
+
+// SQLFunc returns the sql function name of the aggregate op.
+func (op AggregateOp) SQLFunc() string {
+	switch op {
+	case AggCount:
+		return "COUNT"
+	case AggSum:
+		return "SUM"
+	case AggAvg:
+		return "AVG"
+	case AggMin:
+		return "MIN"
+	case AggMax:
+		return "MAX"
+	default:
+		return ""
+	}
+}
+
+var aggAliasRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// Aggregation defines an aggregate function on a field.
+type Aggregation struct {
+	Op AggregateOp `json:"op"`
+	// Field is the column to aggregate, it is optional for count, which means count all rows.
+	Field string `json:"field,omitempty"`
+	// Alias is the key of the aggregated value in result, default is 'count' for counting
+	// all rows, otherwise is '{op}_{field}'.
+	Alias string `json:"alias,omitempty"`
+}
+
+// Name returns the key of the aggregated value in result.
+func (a Aggregation) Name() string {
+	if len(a.Alias) != 0 {
+		return a.Alias
+	}
+
+	if len(a.Field) == 0 {
+		return string(a.Op)
+	}
+
+	return fmt.Sprintf("%s_%s", a.Op, a.Field)
+}
+
+// Validate the aggregation.
+func (a Aggregation) Validate() error {
+	if err := a.Op.Validate(); err != nil {
+		return err
+	}
+
+	if len(a.Field) == 0 && a.Op != AggCount {
+		return fmt.Errorf("field is required for %s aggregation", a.Op)
+	}
+
+	if !aggAliasRegexp.MatchString(a.Name()) {
+		return fmt.Errorf("invalid aggregation alias: %s, should match %s", a.Name(), aggAliasRegexp.String())
+	}
+
+	return nil
+}
+
+// AggregateReq is a standard aggregate operation http request, which groups the resources matched by
+// filter with the group by columns, and calculates the aggregations of each group.
+type AggregateReq struct {
+	Filter       *filter.Expression `json:"filter"`
+	GroupBy      []string           `json:"group_by,omitempty"`
+	Aggregations []Aggregation      `json:"aggregations"`
+	// Limit is the max number of returned groups, default and max value is AggregateMaxLimit.
+	Limit uint `json:"limit,omitempty"`
+}
+
+// Validate AggregateReq.
+func (r *AggregateReq) Validate() (err error) {
+	defer func() {
+		if err != nil {
+			err = errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}()
+
+	if r.Filter == nil {
+		return errors.New("filter is required")
+	}
+
+	if len(r.GroupBy) > AggregateMaxGroupBy {
+		return fmt.Errorf("group_by should <= %d", AggregateMaxGroupBy)
+	}
+
+	if len(r.Aggregations) == 0 || len(r.Aggregations) > AggregateMaxAggregations {
+		return fmt.Errorf("aggregations is required and should <= %d", AggregateMaxAggregations)
+	}
+
+	if r.Limit > AggregateMaxLimit {
+		return fmt.Errorf("limit should <= %d", AggregateMaxLimit)
+	}
+
+	names := make(map[string]struct{}, len(r.GroupBy)+len(r.Aggregations))
+	for _, col := range r.GroupBy {
+		if _, exists := names[col]; exists {
+			return fmt.Errorf("group_by column %s is duplicated", col)
+		}
+		names[col] = struct{}{}
+	}
+
+	for _, one := range r.Aggregations {
+		if err = one.Validate(); err != nil {
+			return err
+		}
+
+		if _, exists := names[one.Name()]; exists {
+			return fmt.Errorf("aggregation %s is conflict with other group_by column or aggregation", one.Name())
+		}
+		names[one.Name()] = struct{}{}
+	}
+
+	return nil
+}
+
+// AggregateResult is a standard aggregate operation result, each detail contains the group by
+// columns and aggregated values of a group, keyed by column name and aggregation name.
+type AggregateResult struct {
+	Details []map[string]interface{} `json:"details"`
+}
@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 同步资源自动分配业务规则
+package assignrule
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	// MaxConditionValueLen 单个条件中允许的最大取值数量
+	MaxConditionValueLen = 100
+	// MaxNameRegexLen 名称正则表达式的最大长度
+	MaxNameRegexLen = 256
+	// AssignUserPrefix 规则自动分配时的操作人前缀，操作人为该前缀加规则ID，便于在审计中区分手动分配
+	AssignUserPrefix = "assign_rule:"
+)
+
+// SupportedResTypes 支持自动分配的资源类型，硬盘及弹性IP只分配未绑定主机的资源，已绑定的随主机一起分配
+var SupportedResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.EipCloudResType,
+	enumor.LoadBalancerCloudResType,
+}
+
+// AssignRule 资源自动分配业务规则
+type AssignRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Priority 优先级，数值越小越先匹配，资源只会被第一个匹配的规则分配
+	Priority uint32 `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+	BkBizID  int64  `json:"bk_biz_id"`
+	// BkCloudID 主机分配到业务时使用的管控区域，规则包含主机资源时必填
+	BkCloudID  *int64                     `json:"bk_cloud_id"`
+	ResTypes   []enumor.CloudResourceType `json:"res_types"`
+	Conditions Conditions                 `json:"conditions"`
+	Memo       *string                    `json:"memo"`
+	core.Revision
+}
+
+// ValidateResTypes validate res types and bk cloud id of assign rule.
+func ValidateResTypes(resTypes []enumor.CloudResourceType, bkCloudID *int64) error {
+	if len(resTypes) == 0 {
+		return errors.New("res_types is required")
+	}
+
+	if len(slice.Unique(resTypes)) != len(resTypes) {
+		return errors.New("res_types has duplicate value")
+	}
+
+	for _, resType := range resTypes {
+		if !slice.IsItemInSlice(SupportedResTypes, resType) {
+			return fmt.Errorf("res type %s is not supported, supported: %v", resType, SupportedResTypes)
+		}
+	}
+
+	if slice.IsItemInSlice(resTypes, enumor.CvmCloudResType) && bkCloudID == nil {
+		return errors.New("bk_cloud_id is required when res_types contains cvm")
+	}
+
+	return nil
+}
+
+// Conditions 规则匹配条件，各条件之间为且的关系，同一条件的多个取值之间为或的关系，未设置的条件不参与匹配
+type Conditions struct {
+	AccountIDs  []string `json:"account_ids,omitempty"`
+	Regions     []string `json:"regions,omitempty"`
+	CloudVpcIDs []string `json:"cloud_vpc_ids,omitempty"`
+	// Tags 资源需包含全部标签，标签值为空时只要求包含该标签键，没有标签的资源类型不会被设置了标签条件的规则匹配
+	Tags map[string]string `json:"tags,omitempty"`
+	// NameRegex 资源名称正则表达式
+	NameRegex string `json:"name_regex,omitempty"`
+}
+
+// Validate Conditions.
+func (c Conditions) Validate() error {
+	if len(c.AccountIDs) == 0 && len(c.Regions) == 0 && len(c.CloudVpcIDs) == 0 && len(c.Tags) == 0 &&
+		len(c.NameRegex) == 0 {
+		return errors.New("at least one condition is required")
+	}
+
+	if len(c.AccountIDs) > MaxConditionValueLen || len(c.Regions) > MaxConditionValueLen ||
+		len(c.CloudVpcIDs) > MaxConditionValueLen || len(c.Tags) > MaxConditionValueLen {
+		return fmt.Errorf("condition values should <= %d", MaxConditionValueLen)
+	}
+
+	for key := range c.Tags {
+		if len(key) == 0 {
+			return errors.New("tag key can not be empty")
+		}
+	}
+
+	if len(c.NameRegex) > MaxNameRegexLen {
+		return fmt.Errorf("name_regex length should <= %d", MaxNameRegexLen)
+	}
+
+	if _, err := regexp.Compile(c.NameRegex); err != nil {
+		return fmt.Errorf("name_regex is invalid, err: %v", err)
+	}
+
+	return nil
+}
+
+// Resource 参与规则匹配的资源信息
+type Resource struct {
+	ResType     enumor.CloudResourceType `json:"res_type"`
+	ID          string                   `json:"id"`
+	CloudID     string                   `json:"cloud_id"`
+	Name        string                   `json:"name"`
+	AccountID   string                   `json:"account_id"`
+	Region      string                   `json:"region"`
+	CloudVpcIDs []string                 `json:"cloud_vpc_ids"`
+	Tags        map[string]string        `json:"tags"`
+}
+
+// MatchResult 资源与规则的匹配结果
+type MatchResult struct {
+	RuleID   string `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	BkBizID  int64  `json:"bk_biz_id"`
+	Resource `json:",inline"`
+}
+
+// Matcher 按优先级匹配资源的规则集合
+type Matcher struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	rule      AssignRule
+	nameRegex *regexp.Regexp
+}
+
+// NewMatcher 使用规则生成匹配器，会忽略未启用的规则，规则按优先级升序排列，优先级相同时按ID排列
+func NewMatcher(rules []AssignRule) (*Matcher, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		one := compiledRule{rule: rule}
+		if len(rule.Conditions.NameRegex) != 0 {
+			reg, err := regexp.Compile(rule.Conditions.NameRegex)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s name_regex is invalid, err: %v", rule.ID, err)
+			}
+			one.nameRegex = reg
+		}
+		compiled = append(compiled, one)
+	}
+
+	sort.SliceStable(compiled, func(i, j int) bool {
+		if compiled[i].rule.Priority != compiled[j].rule.Priority {
+			return compiled[i].rule.Priority < compiled[j].rule.Priority
+		}
+		return compiled[i].rule.ID < compiled[j].rule.ID
+	})
+
+	return &Matcher{rules: compiled}, nil
+}
+
+// Empty return if matcher has no rule.
+func (m *Matcher) Empty() bool {
+	return len(m.rules) == 0
+}
+
+// ResTypes return the resource types of enabled rules.
+func (m *Matcher) ResTypes() []enumor.CloudResourceType {
+	resTypes := make([]enumor.CloudResourceType, 0)
+	for _, one := range m.rules {
+		resTypes = append(resTypes, one.rule.ResTypes...)
+	}
+	return slice.Unique(resTypes)
+}
+
+// Match 返回第一个匹配资源的规则，没有匹配的规则时返回nil
+func (m *Matcher) Match(res Resource) *AssignRule {
+	for i := range m.rules {
+		if m.rules[i].match(res) {
+			return &m.rules[i].rule
+		}
+	}
+
+	return nil
+}
+
+func (c compiledRule) match(res Resource) bool {
+	cond := c.rule.Conditions
+
+	if !slice.IsItemInSlice(c.rule.ResTypes, res.ResType) {
+		return false
+	}
+
+	if len(cond.AccountIDs) != 0 && !slice.IsItemInSlice(cond.AccountIDs, res.AccountID) {
+		return false
+	}
+
+	if len(cond.Regions) != 0 && !slice.IsItemInSlice(cond.Regions, res.Region) {
+		return false
+	}
+
+	if len(cond.CloudVpcIDs) != 0 {
+		matched := false
+		for _, vpcID := range res.CloudVpcIDs {
+			if slice.IsItemInSlice(cond.CloudVpcIDs, vpcID) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for key, value := range cond.Tags {
+		resValue, exists := res.Tags[key]
+		if !exists || (len(value) != 0 && resValue != value) {
+			return false
+		}
+	}
+
+	if c.nameRegex != nil && !c.nameRegex.MatchString(res.Name) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package assignrule
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestMatcher(t *testing.T) {
+	cloudID := int64(0)
+	rules := []AssignRule{
+		{
+			ID: "00000001", Priority: 10, Enabled: true, BkBizID: 1, BkCloudID: &cloudID,
+			ResTypes:   []enumor.CloudResourceType{enumor.CvmCloudResType},
+			Conditions: Conditions{AccountIDs: []string{"acc1"}, NameRegex: "^web-"},
+		},
+		{
+			ID: "00000002", Priority: 1, Enabled: false, BkBizID: 2,
+			ResTypes:   []enumor.CloudResourceType{enumor.CvmCloudResType},
+			Conditions: Conditions{AccountIDs: []string{"acc1"}},
+		},
+		{
+			ID: "00000003", Priority: 20, Enabled: true, BkBizID: 3, BkCloudID: &cloudID,
+			ResTypes: []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.LoadBalancerCloudResType},
+			Conditions: Conditions{Regions: []string{"ap-guangzhou"}, CloudVpcIDs: []string{"vpc-1"},
+				Tags: map[string]string{"team": "", "env": "prod"}},
+		},
+	}
+
+	matcher, err := NewMatcher(rules)
+	if err != nil {
+		t.Fatalf("new matcher failed, err: %v", err)
+	}
+
+	cases := []struct {
+		res    Resource
+		expect string
+	}{
+		{Resource{ResType: enumor.CvmCloudResType, AccountID: "acc1", Name: "web-1"}, "00000001"},
+		{Resource{ResType: enumor.CvmCloudResType, AccountID: "acc1", Name: "db-1"}, ""},
+		{Resource{ResType: enumor.DiskCloudResType, AccountID: "acc1", Name: "web-1"}, ""},
+		{Resource{ResType: enumor.LoadBalancerCloudResType, Region: "ap-guangzhou", CloudVpcIDs: []string{"vpc-1"},
+			Tags: map[string]string{"team": "a", "env": "prod"}}, "00000003"},
+		{Resource{ResType: enumor.LoadBalancerCloudResType, Region: "ap-guangzhou", CloudVpcIDs: []string{"vpc-1"},
+			Tags: map[string]string{"env": "prod"}}, ""},
+		{Resource{ResType: enumor.CvmCloudResType, AccountID: "acc1", Name: "web-2", Region: "ap-guangzhou",
+			CloudVpcIDs: []string{"vpc-1"}, Tags: map[string]string{"team": "a", "env": "prod"}}, "00000001"},
+	}
+
+	for idx, c := range cases {
+		rule := matcher.Match(c.res)
+		got := ""
+		if rule != nil {
+			got = rule.ID
+		}
+		if got != c.expect {
+			t.Errorf("case %d expect matched rule %q, but got %q", idx, c.expect, got)
+		}
+	}
+}
+
+func TestValidateResTypes(t *testing.T) {
+	if err := ValidateResTypes([]enumor.CloudResourceType{enumor.CvmCloudResType}, nil); err == nil {
+		t.Errorf("cvm rule without bk_cloud_id should be invalid")
+	}
+
+	if err := ValidateResTypes([]enumor.CloudResourceType{enumor.SubnetCloudResType}, nil); err == nil {
+		t.Errorf("unsupported res type should be invalid")
+	}
+
+	if err := ValidateResTypes([]enumor.CloudResourceType{enumor.EipCloudResType}, nil); err != nil {
+		t.Errorf("eip rule should be valid, err: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+// Archive 审计归档清单，每个归档文件对应一条记录
+type Archive struct {
+	ID string `json:"id"`
+	// ArchiveDate 归档审计的日期，格式：2006-01-02
+	ArchiveDate string `json:"archive_date"`
+	// Path 归档文件在对象存储中的路径
+	Path       string `json:"path"`
+	MinAuditID uint64 `json:"min_audit_id"`
+	MaxAuditID uint64 `json:"max_audit_id"`
+	MinSeq     uint64 `json:"min_seq"`
+	MaxSeq     uint64 `json:"max_seq"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	Count      uint64 `json:"count"`
+	// Size 归档文件大小，单位：字节
+	Size      uint64 `json:"size"`
+	Sha256    string `json:"sha256"`
+	Creator   string `json:"creator"`
+	CreatedAt string `json:"created_at"`
+}
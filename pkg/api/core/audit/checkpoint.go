@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+// Checkpoint 审计哈希链签名检查点
+type Checkpoint struct {
+	ID string `json:"id"`
+	// Seq 检查点对应的审计哈希链序号
+	Seq uint64 `json:"seq"`
+	// Hash 检查点对应的审计哈希
+	Hash string `json:"hash"`
+	// Signature 检查点的 HMAC-SHA256 签名
+	Signature string `json:"signature"`
+	// Path 检查点文件在对象存储中的路径
+	Path      string `json:"path"`
+	Creator   string `json:"creator"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CheckpointContent 写入对象存储的检查点文件内容
+type CheckpointContent struct {
+	TenantID  string `json:"tenant_id"`
+	Seq       uint64 `json:"seq"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+	CreatedAt string `json:"created_at"`
+}
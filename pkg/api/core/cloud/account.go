@@ -109,6 +109,52 @@ func (e *HuaWeiAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) er
 	return nil
 }
 
+// AliyunAccountExtension define aliyun account extension.
+type AliyunAccountExtension struct {
+	CloudMainAccountID string `json:"cloud_main_account_id"`
+	CloudSubAccountID  string `json:"cloud_sub_account_id"`
+	CloudSecretID      string `json:"cloud_secret_id"`
+	CloudSecretKey     string `json:"cloud_secret_key,omitempty"`
+}
+
+// DecryptSecretKey ...
+func (e *AliyunAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
+	if e.CloudSecretKey != "" {
+		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
+		if err != nil {
+			return err
+		}
+		e.CloudSecretKey = plainSecretKey
+	}
+
+	return nil
+}
+
+// OpenStackAccountExtension define openstack account extension, secret is keystone application credential.
+type OpenStackAccountExtension struct {
+	// CloudAuthURL keystone 认证地址
+	CloudAuthURL string `json:"cloud_auth_url"`
+	// CloudRegion 账号纳管的区域，为空时使用服务目录中的第一个区域
+	CloudRegion      string `json:"cloud_region"`
+	CloudProjectID   string `json:"cloud_project_id"`
+	CloudProjectName string `json:"cloud_project_name"`
+	CloudSecretID    string `json:"cloud_secret_id"`
+	CloudSecretKey   string `json:"cloud_secret_key,omitempty"`
+}
+
+// DecryptSecretKey ...
+func (e *OpenStackAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
+	if e.CloudSecretKey != "" {
+		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
+		if err != nil {
+			return err
+		}
+		e.CloudSecretKey = plainSecretKey
+	}
+
+	return nil
+}
+
 // GcpAccountExtension define gcp account extension.
 type GcpAccountExtension struct {
 	Email                   string `json:"email"`
@@ -26,7 +26,8 @@ import (
 
 // AccountInfoBySecret 根据秘钥获取的账号字段
 type AccountInfoBySecret interface {
-	TCloudInfoBySecret | AwsInfoBySecret | AzureInfoBySecret | GcpInfoBySecret | HuaWeiInfoBySecret
+	TCloudInfoBySecret | AwsInfoBySecret | AzureInfoBySecret | GcpInfoBySecret | HuaWeiInfoBySecret |
+		AliyunInfoBySecret | OpenStackInfoBySecret
 }
 
 // TCloudInfoBySecret 腾讯云根据秘钥获取的字段
@@ -49,6 +50,20 @@ type HuaWeiInfoBySecret struct {
 	CloudIamUsername    string `json:"cloud_iam_username"`
 }
 
+// AliyunInfoBySecret 阿里云根据秘钥获取的字段
+type AliyunInfoBySecret struct {
+	CloudMainAccountID string `json:"cloud_main_account_id"`
+	CloudSubAccountID  string `json:"cloud_sub_account_id"`
+}
+
+// OpenStackInfoBySecret OpenStack 根据秘钥获取的字段，应用凭证限定在单个项目内
+type OpenStackInfoBySecret struct {
+	CloudProjectID   string `json:"cloud_project_id"`
+	CloudProjectName string `json:"cloud_project_name"`
+	CloudUserID      string `json:"cloud_user_id"`
+	CloudUserName    string `json:"cloud_user_name"`
+}
+
 // GcpInfoBySecret GCP 根据秘钥获取的字段
 type GcpInfoBySecret struct {
 	CloudProjectInfos []GcpProjectInfo `json:"cloud_project_infos"`
@@ -84,7 +99,7 @@ type AzureSubscriptionInfo struct {
 
 // AccountSecret 账号所需秘钥
 type AccountSecret interface {
-	TCloudSecret | AwsSecret | HuaWeiSecret | GcpSecret | AzureSecret
+	TCloudSecret | AwsSecret | HuaWeiSecret | GcpSecret | AzureSecret | AliyunSecret | OpenStackSecret
 	Validate() error
 }
 
@@ -122,6 +137,30 @@ func (sk HuaWeiSecret) Validate() error {
 	return validator.Validate.Struct(sk)
 }
 
+// AliyunSecret 阿里云秘钥
+type AliyunSecret struct {
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate ...
+func (sk AliyunSecret) Validate() error {
+	return validator.Validate.Struct(sk)
+}
+
+// OpenStackSecret OpenStack 秘钥
+type OpenStackSecret struct {
+	CloudAuthURL   string `json:"cloud_auth_url" validate:"required"`
+	CloudRegion    string `json:"cloud_region" validate:"omitempty"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate ...
+func (sk OpenStackSecret) Validate() error {
+	return validator.Validate.Struct(sk)
+}
+
 // GcpSecret GCP 秘钥
 type GcpSecret struct {
 	CloudServiceSecretKey string `json:"cloud_service_secret_key" validate:"required"`
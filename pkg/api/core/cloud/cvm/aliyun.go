@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+// AliyunCvmExtension cvm extension.
+type AliyunCvmExtension struct {
+	// InstanceChargeType 实例计费方式。PrePaid：包年包月，PostPaid：按量付费。
+	InstanceChargeType string `json:"instance_charge_type,omitempty"`
+	// InternetChargeType 网络计费方式。PayByBandwidth：按固定带宽计费，PayByTraffic：按使用流量计费。
+	InternetChargeType string `json:"internet_charge_type,omitempty"`
+	// InternetMaxBandwidthOut 公网出带宽最大值，单位：Mbit/s。
+	InternetMaxBandwidthOut int64 `json:"internet_max_bandwidth_out,omitempty"`
+	Cpu                     int64 `json:"cpu,omitempty"`
+	// Memory 内存大小，单位：MiB。
+	Memory                int64    `json:"memory,omitempty"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids,omitempty"`
+	// CloudResourceGroupID 实例所属的资源组ID。
+	CloudResourceGroupID string `json:"cloud_resource_group_id,omitempty"`
+	// HostName 实例主机名。
+	HostName string `json:"host_name,omitempty"`
+	// DeletionProtection 实例释放保护属性，开启后不能通过控制台或API释放实例。
+	DeletionProtection bool `json:"deletion_protection,omitempty"`
+	// StoppedMode 实例停机后是否继续收费。KeepCharging，StopCharging，Not-applicable。
+	StoppedMode string `json:"stopped_mode,omitempty"`
+	// SerialNumber 实例序列号。
+	SerialNumber string `json:"serial_number,omitempty"`
+}
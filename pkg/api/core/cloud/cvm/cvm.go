@@ -85,6 +85,8 @@ type BaseCvm struct {
 	*/
 	Status        string `json:"status"`
 	RecycleStatus string `json:"recycle_status,omitempty"`
+	// StatusChangedAt 主机状态最近一次变更的时间
+	StatusChangedAt string `json:"status_changed_at,omitempty"`
 
 	// PrivateIPv4Addresses 内网IP
 	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
@@ -125,5 +127,6 @@ func (b BaseCvm) GetCloudID() string {
 
 // Extension cvm extension.
 type Extension interface {
-	TCloudCvmExtension | AwsCvmExtension | HuaWeiCvmExtension | AzureCvmExtension | GcpCvmExtension | OtherCvmExtension
+	TCloudCvmExtension | AwsCvmExtension | HuaWeiCvmExtension | AzureCvmExtension | GcpCvmExtension | OtherCvmExtension |
+		AliyunCvmExtension | OpenStackCvmExtension
 }
@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+// OpenStackCvmExtension cvm extension.
+type OpenStackCvmExtension struct {
+	// FlavorName 实例规格名称
+	FlavorName string `json:"flavor_name,omitempty"`
+	Vcpus      int64  `json:"vcpus,omitempty"`
+	// Ram 内存大小，单位：MiB。
+	Ram int64 `json:"ram,omitempty"`
+	// Disk 根盘大小，单位：GiB。
+	Disk                    int64    `json:"disk,omitempty"`
+	KeyName                 string   `json:"key_name,omitempty"`
+	CloudSecurityGroupNames []string `json:"cloud_security_group_names,omitempty"`
+	CloudVolumeIDs          []string `json:"cloud_volume_ids,omitempty"`
+	CloudProjectID          string   `json:"cloud_project_id,omitempty"`
+	// HostID 宿主机标识，同一项目内相同宿主机的该值相同
+	HostID string `json:"host_id,omitempty"`
+	// PowerState 电源状态，0：NOSTATE，1：RUNNING，3：PAUSED，4：SHUTDOWN，6：CRASHED，7：SUSPENDED。
+	PowerState int64 `json:"power_state,omitempty"`
+}
@@ -58,7 +58,8 @@ func (disk Disk[T]) GetCloudID() string {
 
 // Extension ...
 type Extension interface {
-	TCloudExtension | AwsExtension | AzureExtension | GcpExtension | HuaWeiExtension
+	TCloudExtension | AwsExtension | AzureExtension | GcpExtension | HuaWeiExtension | AliyunExtension |
+		OpenStackExtension
 }
 
 // TCloudExtension ...
@@ -158,3 +159,29 @@ type HuaWeiDiskAttachment struct {
 	// 云硬盘ID
 	DiskId string `json:"disk_id"`
 }
+
+// AliyunExtension ...
+type AliyunExtension struct {
+	// ChargeType 云盘的付费方式，PrePaid：包年包月，PostPaid：按量付费。
+	ChargeType string `json:"charge_type" validate:"omitempty"`
+	ExpireTime string `json:"expire_time" validate:"omitempty"`
+	// Category 云盘种类，例如cloud_efficiency、cloud_ssd、cloud_essd。
+	Category           string  `json:"category"`
+	PerformanceLevel   string  `json:"performance_level,omitempty"`
+	Encrypted          *bool   `json:"encrypted,omitempty"`
+	InstanceID         *string `json:"instance_id,omitempty"`
+	DeleteWithInstance *bool   `json:"delete_with_instance,omitempty"`
+	Portable           *bool   `json:"portable,omitempty"`
+}
+
+// OpenStackExtension ...
+type OpenStackExtension struct {
+	// VolumeType 卷类型
+	VolumeType string `json:"volume_type"`
+	Bootable   bool   `json:"bootable"`
+	Encrypted  bool   `json:"encrypted"`
+	// Multiattach 是否可以挂载到多台主机
+	Multiattach    bool     `json:"multiattach"`
+	CloudProjectID string   `json:"cloud_project_id"`
+	InstanceIDs    []string `json:"instance_ids,omitempty"`
+}
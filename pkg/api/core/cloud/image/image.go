@@ -40,7 +40,7 @@ type BaseImage struct {
 
 // Extension ...
 type Extension interface {
-	TCloudExtension | AwsExtension | GcpExtension | HuaWeiExtension | AzureExtension
+	TCloudExtension | AwsExtension | GcpExtension | HuaWeiExtension | AzureExtension | AliyunExtension
 }
 
 // Image ...
@@ -90,3 +90,10 @@ type AzureExtension struct {
 	Offer     string `json:"offer" validate:"required"`
 	Sku       string `json:"sku" validate:"required"`
 }
+
+// AliyunExtension ...
+type AliyunExtension struct {
+	Region      string `json:"region"`
+	ImageFamily string `json:"image_family,omitempty"`
+	ImageSize   uint64 `json:"image_size"`
+}
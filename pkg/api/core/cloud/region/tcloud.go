@@ -25,7 +25,7 @@ import (
 
 // Region define region.
 type Region interface {
-	TCloudRegion | AwsRegion | GcpRegion
+	TCloudRegion | AwsRegion | GcpRegion | AliyunRegion
 }
 
 // TCloudRegion define tcloud region.
@@ -72,7 +72,8 @@ func (sg SecurityGroup[T]) GetCloudID() string {
 // SecurityGroupExtension define security group extension.
 type SecurityGroupExtension interface {
 	TCloudSecurityGroupExtension | AwsSecurityGroupExtension | HuaWeiSecurityGroupExtension |
-		AzureSecurityGroupExtension
+		AzureSecurityGroupExtension | AliyunSecurityGroupExtension |
+		OpenStackSecurityGroupExtension
 }
 
 // TCloudSecurityGroupExtension define tcloud security group extension.
@@ -99,6 +100,23 @@ type HuaWeiSecurityGroupExtension struct {
 	CloudEnterpriseProjectID string `json:"cloud_enterprise_project_id"`
 }
 
+// AliyunSecurityGroupExtension define aliyun security group extension.
+type AliyunSecurityGroupExtension struct {
+	VpcID string `json:"vpc_id"`
+	// CloudVpcID 安全组所属的专有网络ID。
+	CloudVpcID *string `json:"cloud_vpc_id"`
+	// SecurityGroupType 安全组类型，normal：普通安全组，enterprise：企业安全组。
+	SecurityGroupType string `json:"security_group_type"`
+	// ResourceGroupID 资源组ID。
+	ResourceGroupID string `json:"resource_group_id"`
+}
+
+// OpenStackSecurityGroupExtension define openstack security group extension.
+type OpenStackSecurityGroupExtension struct {
+	CloudProjectID string `json:"cloud_project_id"`
+	Stateful       bool   `json:"stateful"`
+}
+
 // AzureSecurityGroupExtension define azure security group extension.
 type AzureSecurityGroupExtension struct {
 	// ResourceGroupName 资源组名称。
@@ -42,7 +42,7 @@ func (account SubAccount[Ext]) GetCloudID() string {
 
 // Extension account extension.
 type Extension interface {
-	TCloudExtension | AwsExtension | HuaWeiExtension | AzureExtension | GcpExtension
+	TCloudExtension | AwsExtension | HuaWeiExtension | AzureExtension | GcpExtension | OpenStackExtension
 }
 
 // BaseSubAccount 云账号
@@ -104,3 +104,11 @@ type GcpExtension struct {
 	CloudProjectID   string `json:"cloud_project_id"`
 	CloudProjectName string `json:"cloud_project_name"`
 }
+
+// OpenStackExtension define openstack extension, sub account is keystone project.
+type OpenStackExtension struct {
+	CloudDomainID string `json:"cloud_domain_id"`
+	// CloudParentID 父项目ID，顶层项目为所属域ID
+	CloudParentID string `json:"cloud_parent_id"`
+	Enabled       bool   `json:"enabled"`
+}
@@ -62,7 +62,8 @@ type BaseSubnet struct {
 
 // SubnetExtension defines subnet extensional info.
 type SubnetExtension interface {
-	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension
+	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension |
+		AliyunSubnetExtension | OpenStackSubnetExtension
 }
 
 // TCloudSubnetExtension defines tencent cloud subnet extensional info.
@@ -109,6 +110,22 @@ type HuaWeiSubnetExtension struct {
 	NtpAddresses []string `json:"ntp_addresses"`
 }
 
+// AliyunSubnetExtension defines aliyun subnet(vswitch) extensional info.
+type AliyunSubnetExtension struct {
+	Status                  string `json:"status"`
+	IsDefault               bool   `json:"is_default"`
+	AvailableIpAddressCount int64  `json:"available_ip_address_count"`
+	CloudNetworkAclID       string `json:"cloud_network_acl_id,omitempty"`
+}
+
+// OpenStackSubnetExtension defines openstack subnet extensional info.
+type OpenStackSubnetExtension struct {
+	GatewayIp      string   `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+	CloudProjectID string   `json:"cloud_project_id"`
+}
+
 // TCloudSubnet defines tencent cloud subnet.
 type TCloudSubnet Subnet[TCloudSubnetExtension]
 
@@ -123,3 +140,9 @@ type AzureSubnet Subnet[AzureSubnetExtension]
 
 // HuaWeiSubnet defines huawei subnet.
 type HuaWeiSubnet Subnet[HuaWeiSubnetExtension]
+
+// AliyunSubnet defines aliyun subnet.
+type AliyunSubnet Subnet[AliyunSubnetExtension]
+
+// OpenStackSubnet defines openstack subnet.
+type OpenStackSubnet Subnet[OpenStackSubnetExtension]
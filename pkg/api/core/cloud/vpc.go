@@ -56,7 +56,8 @@ type BaseVpc struct {
 
 // VpcExtension defines vpc extensional info.
 type VpcExtension interface {
-	TCloudVpcExtension | AwsVpcExtension | GcpVpcExtension | AzureVpcExtension | HuaWeiVpcExtension
+	TCloudVpcExtension | AwsVpcExtension | GcpVpcExtension | AzureVpcExtension | HuaWeiVpcExtension |
+		AliyunVpcExtension | OpenStackVpcExtension
 }
 
 // TCloudVpcExtension defines tencent cloud vpc extensional info.
@@ -129,6 +130,34 @@ type HuaWeiCidr struct {
 	Cidr string               `json:"cidr"`
 }
 
+// AliyunVpcExtension defines aliyun vpc extensional info.
+type AliyunVpcExtension struct {
+	Cidr            []AliyunCidr `json:"cidr"`
+	Status          string       `json:"status"`
+	IsDefault       bool         `json:"is_default"`
+	VRouterID       string       `json:"vrouter_id"`
+	ResourceGroupID string       `json:"resource_group_id"`
+}
+
+// AliyunCidr aliyun cidr
+type AliyunCidr struct {
+	Type enumor.IPAddressType `json:"type"`
+	Cidr string               `json:"cidr"`
+}
+
+// OpenStackVpcExtension defines openstack network extensional info.
+type OpenStackVpcExtension struct {
+	Status       string `json:"status"`
+	AdminStateUp bool   `json:"admin_state_up"`
+	Shared       bool   `json:"shared"`
+	// External 是否为外部网络，外部网络用于分配浮动IP
+	External bool `json:"external"`
+	// NetworkType 网络类型，例如vxlan、vlan、flat
+	NetworkType    string `json:"network_type,omitempty"`
+	MTU            int64  `json:"mtu,omitempty"`
+	CloudProjectID string `json:"cloud_project_id"`
+}
+
 // TCloudVpc defines tencent cloud vpc.
 type TCloudVpc Vpc[TCloudVpcExtension]
 
@@ -143,3 +172,9 @@ type AzureVpc Vpc[AzureVpcExtension]
 
 // HuaWeiVpc defines huawei vpc.
 type HuaWeiVpc Vpc[HuaWeiVpcExtension]
+
+// AliyunVpc defines aliyun vpc.
+type AliyunVpc Vpc[AliyunVpcExtension]
+
+// OpenStackVpc defines openstack vpc.
+type OpenStackVpc Vpc[OpenStackVpcExtension]
@@ -57,7 +57,7 @@ type Zone[Extension ZoneExtension] struct {
 
 // ZoneExtension define zone extension.
 type ZoneExtension interface {
-	TCloudZoneExtension | AwsZoneExtension | HuaWeiZoneExtension | GcpZoneExtension
+	TCloudZoneExtension | AwsZoneExtension | HuaWeiZoneExtension | GcpZoneExtension | AliyunZoneExtension
 }
 
 // TCloudZoneExtension define tcloud zone extension.
@@ -77,3 +77,9 @@ type GcpZoneExtension struct {
 // AwsZoneExtension define aws zone extension.
 type AwsZoneExtension struct {
 }
+
+// AliyunZoneExtension define aliyun zone extension.
+type AliyunZoneExtension struct {
+	// ZoneType 可用区类型，AvailabilityZone：普通可用区，CdcZone：云盒可用区。
+	ZoneType string `json:"zone_type"`
+}
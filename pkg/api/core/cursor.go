@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CursorKey is the stable key of cursor pagination, the resource's identity 'id' is unique and indexed.
+const CursorKey = "id"
+
+// Cursor is the position of the last returned resource in cursor pagination, it is encoded as an
+// opaque string to the caller, and the caller should not parse or construct it by itself.
+type Cursor struct {
+	// Key is the sort key of cursor.
+	Key string `json:"k"`
+	// Value is the key value of the last returned resource.
+	Value string `json:"v"`
+	// Order is the sort direction when the cursor is generated.
+	Order Order `json:"o"`
+}
+
+// EncodeCursor encode the position of the last returned resource to an opaque cursor.
+func EncodeCursor(value string, order Order) string {
+	raw, err := json.Marshal(Cursor{Key: CursorKey, Value: value, Order: order.Order()})
+	if err != nil {
+		// marshal a struct with only string fields never fails.
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor decode the opaque cursor.
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid page.cursor, it should be the next_cursor returned by previous page")
+	}
+
+	c := new(Cursor)
+	if err = json.Unmarshal(raw, c); err != nil {
+		return nil, errors.New("invalid page.cursor, it should be the next_cursor returned by previous page")
+	}
+
+	if c.Key != CursorKey {
+		return nil, fmt.Errorf("unsupported page.cursor key: %s", c.Key)
+	}
+
+	if len(c.Value) == 0 {
+		return nil, errors.New("page.cursor value is empty")
+	}
+
+	if err = c.Order.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// DiskSnapshot 云硬盘快照
+type DiskSnapshot struct {
+	ID               string        `json:"id"`
+	Vendor           enumor.Vendor `json:"vendor"`
+	AccountID        string        `json:"account_id"`
+	CloudID          string        `json:"cloud_id"`
+	BkBizID          int64         `json:"bk_biz_id"`
+	Name             string        `json:"name"`
+	Region           string        `json:"region"`
+	Zone             string        `json:"zone"`
+	DiskID           string        `json:"disk_id"`
+	CloudDiskID      string        `json:"cloud_disk_id"`
+	DiskSize         uint64        `json:"disk_size"`
+	Status           string        `json:"status"`
+	PolicyID         string        `json:"policy_id"`
+	RecycleStatus    string        `json:"recycle_status"`
+	CloudCreatedTime string        `json:"cloud_created_time"`
+	Memo             *string       `json:"memo"`
+	core.Revision    `json:",inline"`
+}
+
+// DiskSnapshotPolicy 云硬盘定期快照策略
+type DiskSnapshotPolicy struct {
+	ID        string        `json:"id"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Name      string        `json:"name"`
+	// Hours 每天执行快照的整点，取值0-23
+	Hours []int `json:"hours"`
+	// WeekDays 每周执行快照的日期，取值0-6，0表示周日，为空表示每天执行
+	WeekDays []int `json:"week_days"`
+	// RetentionDays 策略创建的快照保留天数，为0时不自动删除
+	RetentionDays uint64   `json:"retention_days"`
+	DiskIDs       []string `json:"disk_ids"`
+	Memo          *string  `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// HitSchedule 判断指定的星期和整点是否命中策略的执行时间
+func (p *DiskSnapshotPolicy) HitSchedule(weekDay int, hour int) bool {
+	if len(p.WeekDays) != 0 {
+		hit := false
+		for _, one := range p.WeekDays {
+			if one == weekDay {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+
+	for _, one := range p.Hours {
+		if one == hour {
+			return true
+		}
+	}
+	return false
+}
+
+// GetID ...
+func (s DiskSnapshot) GetID() string {
+	return s.ID
+}
+
+// GetCloudID ...
+func (s DiskSnapshot) GetCloudID() string {
+	return s.CloudID
+}
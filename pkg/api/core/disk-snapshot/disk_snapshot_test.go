@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"testing"
+)
+
+func TestHitSchedule(t *testing.T) {
+	cases := []struct {
+		policy  DiskSnapshotPolicy
+		weekDay int
+		hour    int
+		expect  bool
+	}{
+		// 未配置星期时每天执行
+		{DiskSnapshotPolicy{Hours: []int{2, 14}}, 0, 2, true},
+		{DiskSnapshotPolicy{Hours: []int{2, 14}}, 6, 14, true},
+		{DiskSnapshotPolicy{Hours: []int{2, 14}}, 3, 3, false},
+		{DiskSnapshotPolicy{Hours: []int{0}, WeekDays: []int{1, 5}}, 5, 0, true},
+		{DiskSnapshotPolicy{Hours: []int{0}, WeekDays: []int{1, 5}}, 0, 0, false},
+		{DiskSnapshotPolicy{Hours: []int{0}, WeekDays: []int{1, 5}}, 1, 23, false},
+		// 未配置整点时不执行
+		{DiskSnapshotPolicy{WeekDays: []int{1}}, 1, 0, false},
+	}
+
+	for idx, c := range cases {
+		if got := c.policy.HitSchedule(c.weekDay, c.hour); got != c.expect {
+			t.Errorf("case %d expect %v, but got %v", idx, c.expect, got)
+		}
+	}
+}
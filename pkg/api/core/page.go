@@ -159,6 +159,23 @@ type BasePage struct {
 	// Order is the direction when do sort operation.
 	// it works only when the Sort is set.
 	Order Order `json:"order"`
+	// UseCursor defines to query resources with cursor pagination, which is ordered by the
+	// stable key 'id' and does not need to skip the previous pages like Start does.
+	// Note:
+	// 1. Count, Start must not be set, and Sort must be empty or 'id'.
+	// 2. the next_cursor in the list result is used to query the next page, it is empty
+	//   when there are no more resources.
+	// 3. only the resources whose list api supports it can use cursor pagination, such as cvm and
+	//   bill item, the others reject the cursor page.
+	UseCursor bool `json:"use_cursor,omitempty"`
+	// Cursor is the next_cursor returned by previous page, empty means query the first page.
+	// Cursor only works when the UseCursor = true.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// IsCursor returns whether this page uses cursor pagination.
+func (bp BasePage) IsCursor() bool {
+	return bp.UseCursor
 }
 
 // Validate the base page's options.
@@ -174,6 +191,14 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 		return errors.New("at most one page options is allows")
 	}
 
+	if bp.UseCursor {
+		return bp.validateCursor(opt...)
+	}
+
+	if len(bp.Cursor) != 0 {
+		return errors.New("page.cursor only works when page.use_cursor is enabled")
+	}
+
 	if bp.Count {
 		if bp.Start > 0 {
 			return errors.New("count is enabled, page.start should be 0")
@@ -243,3 +268,52 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 
 	return nil
 }
+
+// validateCursor validate the cursor pagination options.
+func (bp BasePage) validateCursor(opt ...*PageOption) error {
+	if bp.Count {
+		return errors.New("page.use_cursor is enabled, page.count should be false")
+	}
+
+	if bp.Start > 0 {
+		return errors.New("page.use_cursor is enabled, page.start should be 0")
+	}
+
+	if len(bp.Sort) != 0 && bp.Sort != CursorKey {
+		return fmt.Errorf("page.use_cursor is enabled, page.sort should be empty or %s", CursorKey)
+	}
+
+	if err := bp.Order.Validate(); err != nil {
+		return err
+	}
+
+	maxLimit := DefaultMaxPageLimit
+	if len(opt) != 0 && opt[0].MaxLimit > 0 {
+		maxLimit = opt[0].MaxLimit
+	}
+
+	// cursor pagination is used to traverse resources page by page, so unlimited query is not allowed.
+	if bp.Limit <= 0 {
+		return errors.New("page.limit value should >= 1")
+	}
+
+	if bp.Limit > maxLimit {
+		return fmt.Errorf("invalid page.limit max value: %d", maxLimit)
+	}
+
+	if len(bp.Cursor) == 0 {
+		return nil
+	}
+
+	cursor, err := DecodeCursor(bp.Cursor)
+	if err != nil {
+		return err
+	}
+
+	if cursor.Order != bp.Order.Order() {
+		return fmt.Errorf("page.order %s is not the same as the order %s of page.cursor", bp.Order.Order(),
+			cursor.Order)
+	}
+
+	return nil
+}
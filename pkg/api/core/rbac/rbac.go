@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地鉴权的角色及角色绑定
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/iam/meta"
+)
+
+// Wildcard 通配符，匹配任意资源类型或操作
+const Wildcard = "*"
+
+// ScopeType 角色绑定的授权范围类型
+type ScopeType string
+
+const (
+	// GlobalScope 全局范围，授权所有资源
+	GlobalScope ScopeType = "global"
+	// BizScope 业务范围，授权指定业务下的资源
+	BizScope ScopeType = "biz"
+	// ResourceScope 资源范围，授权指定ID的资源实例
+	ResourceScope ScopeType = "resource"
+)
+
+// Validate ScopeType.
+func (s ScopeType) Validate() error {
+	switch s {
+	case GlobalScope, BizScope, ResourceScope:
+	default:
+		return fmt.Errorf("unsupported scope type: %s", s)
+	}
+
+	return nil
+}
+
+// Policy 角色策略，授予对某类资源的操作权限
+type Policy struct {
+	// ResourceType 资源类型，* 表示所有资源类型
+	ResourceType meta.ResourceType `json:"resource_type"`
+	// Actions 操作列表，* 表示所有操作
+	Actions []meta.Action `json:"actions"`
+}
+
+// Validate Policy.
+func (p Policy) Validate() error {
+	if len(p.ResourceType) == 0 {
+		return errors.New("resource_type is required")
+	}
+
+	if len(p.Actions) == 0 {
+		return errors.New("actions is required")
+	}
+
+	for _, action := range p.Actions {
+		if len(action) == 0 {
+			return errors.New("action can not be empty")
+		}
+	}
+
+	return nil
+}
+
+// Match 策略是否授予对资源类型的操作权限
+func (p Policy) Match(resType meta.ResourceType, action meta.Action) bool {
+	if p.ResourceType != Wildcard && p.ResourceType != resType {
+		return false
+	}
+
+	for _, one := range p.Actions {
+		if one == Wildcard || one == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidatePolicies validate role policies.
+func ValidatePolicies(policies []Policy) error {
+	if len(policies) == 0 {
+		return errors.New("policies is required")
+	}
+
+	for idx, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("policies[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateScope validate role binding scope.
+func ValidateScope(scopeType ScopeType, scopeIDs []string) error {
+	if err := scopeType.Validate(); err != nil {
+		return err
+	}
+
+	switch scopeType {
+	case GlobalScope:
+		if len(scopeIDs) != 0 {
+			return errors.New("scope_ids should be empty when scope type is global")
+		}
+
+	case BizScope:
+		if len(scopeIDs) == 0 {
+			return errors.New("scope_ids is required when scope type is biz")
+		}
+
+		for _, id := range scopeIDs {
+			if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+				return fmt.Errorf("biz id %s is invalid", id)
+			}
+		}
+
+	case ResourceScope:
+		if len(scopeIDs) == 0 {
+			return errors.New("scope_ids is required when scope type is resource")
+		}
+	}
+
+	return nil
+}
+
+// Role 本地鉴权角色
+type Role struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Policies []Policy `json:"policies"`
+	Memo     *string  `json:"memo"`
+	Creator  string   `json:"creator"`
+	Reviser  string   `json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt string `json:"updated_at"`
+}
+
+// RoleBinding 本地鉴权角色绑定，将角色在指定范围内授予用户
+type RoleBinding struct {
+	ID        string    `json:"id"`
+	RoleID    string    `json:"role_id"`
+	User      string    `json:"user"`
+	ScopeType ScopeType `json:"scope_type"`
+	// ScopeIDs 授权范围ID列表，biz为业务ID，resource为资源ID，global为空
+	ScopeIDs []string `json:"scope_ids"`
+	Memo     *string  `json:"memo"`
+	Creator  string   `json:"creator"`
+	Reviser  string   `json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation ...
+package recommendation
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ResRecommendation 资源优化建议
+type ResRecommendation struct {
+	ID            string                   `json:"id"`
+	Vendor        enumor.Vendor            `json:"vendor"`
+	AccountID     string                   `json:"account_id"`
+	BkBizID       int64                    `json:"bk_biz_id"`
+	Region        string                   `json:"region"`
+	Zone          string                   `json:"zone"`
+	ResType       enumor.CloudResourceType `json:"res_type"`
+	ResID         string                   `json:"res_id"`
+	CloudResID    string                   `json:"cloud_res_id"`
+	ResName       string                   `json:"res_name"`
+	RecType       enumor.RecommendType     `json:"rec_type"`
+	Action        enumor.RecommendAction   `json:"action"`
+	Detail        *RecommendDetail         `json:"detail"`
+	MonthlySaving float64                  `json:"monthly_saving"`
+	Currency      string                   `json:"currency"`
+	Status        enumor.RecommendStatus   `json:"status"`
+	core.Revision `json:",inline"`
+}
+
+// RecommendDetail 优化建议的判定依据
+type RecommendDetail struct {
+	// Reason 判定原因
+	Reason string `json:"reason"`
+	// StoppedDays 主机关机天数
+	StoppedDays int64 `json:"stopped_days,omitempty"`
+	// ObserveDays 规格评估所使用的监控数据天数
+	ObserveDays int `json:"observe_days,omitempty"`
+	// CpuMaxUsage 观察期内CPU利用率峰值，单位：%
+	CpuMaxUsage *float64 `json:"cpu_max_usage,omitempty"`
+	// MemMaxUsage 观察期内内存利用率峰值，单位：%
+	MemMaxUsage *float64 `json:"mem_max_usage,omitempty"`
+	// ChargeType 资源计费模式
+	ChargeType string `json:"charge_type,omitempty"`
+	// CurrentInstanceType 当前主机规格
+	CurrentInstanceType string `json:"current_instance_type,omitempty"`
+	// TargetInstanceType 建议调整的主机规格
+	TargetInstanceType string `json:"target_instance_type,omitempty"`
+}
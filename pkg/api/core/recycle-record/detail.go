@@ -94,7 +94,10 @@ type CvmRecycleOptions struct {
 }
 
 // DiskRecycleOptions disk recycle record options.
-type DiskRecycleOptions struct{}
+type DiskRecycleOptions struct {
+	// WithSnapshot 销毁云硬盘时是否同时删除云硬盘的快照
+	WithSnapshot bool `json:"with_snapshot"`
+}
 
 // DiskRelatedRecycleOpt 磁盘作为关联资源回收时的回收选项，记录关联的cvm_id
 type DiskRelatedRecycleOpt struct {
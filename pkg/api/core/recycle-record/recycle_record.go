@@ -30,6 +30,9 @@ type RecycleRecord RecycleRecordT[any]
 // CvmRecycleRecord RecycleRecordT for Cvm
 type CvmRecycleRecord RecycleRecordT[CvmRecycleDetail]
 
+// DiskRecycleRecord RecycleRecordT for Disk
+type DiskRecycleRecord RecycleRecordT[DiskRecycleOptions]
+
 // RecycleRecordT  recycle record with detail
 type RecycleRecordT[T any] struct {
 	BaseRecycleRecord `json:",inline"`
@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent 资源变更事件及事件订阅
+package resevent
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"hcm/pkg/api/core"
+	corereshistory "hcm/pkg/api/core/res-history"
+	"hcm/pkg/criteria/enumor"
+)
+
+// EventType 资源变更事件类型
+type EventType string
+
+const (
+	// ResCreated 资源创建，包括同步时新增的资源
+	ResCreated EventType = "resource.created"
+	// ResUpdated 资源属性变更
+	ResUpdated EventType = "resource.updated"
+	// ResReassigned 资源所属业务变更，包括分配、回收到未分配
+	ResReassigned EventType = "resource.reassigned"
+	// ResDeleted 资源删除，包括同步时云上已删除的资源
+	ResDeleted EventType = "resource.deleted"
+)
+
+// EventTypes 支持的事件类型
+var EventTypes = []EventType{ResCreated, ResUpdated, ResReassigned, ResDeleted}
+
+// Validate EventType.
+func (e EventType) Validate() error {
+	for _, one := range EventTypes {
+		if one == e {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported event type: %s", e)
+}
+
+// bizIDField 资源快照中的业务ID字段
+const bizIDField = "bk_biz_id"
+
+// ParseEventType 根据资源变更的操作及字段级变更确定事件类型，业务变更时同时返回变更前的业务ID。
+// 资源首次记录变更历史时没有上一版本，字段级变更为空，此时的更新作为 resource.updated 事件
+func ParseEventType(action enumor.AuditAction, diffs []corereshistory.FieldDiff) (EventType, int64) {
+	switch action {
+	case enumor.Create:
+		return ResCreated, 0
+	case enumor.Delete:
+		return ResDeleted, 0
+	}
+
+	for _, diff := range diffs {
+		if diff.Field == bizIDField {
+			prevBizID, _ := diff.Before.(float64)
+			return ResReassigned, int64(prevBizID)
+		}
+	}
+
+	return ResUpdated, 0
+}
+
+// Event 资源变更事件，推送到订阅方的请求体
+type Event struct {
+	ID         uint64                   `json:"id"`
+	EventType  EventType                `json:"event_type"`
+	ResType    enumor.CloudResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	CloudResID string                   `json:"cloud_res_id"`
+	ResName    string                   `json:"res_name"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	// PrevBkBizID 业务变更前的业务ID，仅 resource.reassigned 事件有效
+	PrevBkBizID int64 `json:"prev_bk_biz_id"`
+	// Version 资源变更历史的版本号，同一资源递增，订阅方可据此丢弃乱序到达的旧事件
+	Version uint64                     `json:"version"`
+	Diff    []corereshistory.FieldDiff `json:"diff"`
+	Source  enumor.RequestSourceType   `json:"source"`
+	Rid     string                     `json:"rid"`
+	// Operator 触发资源变更的用户
+	Operator  string `json:"operator"`
+	TenantID  string `json:"tenant_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SubscriptionFilter 事件订阅的过滤条件，各条件之间为且的关系，条件为空时不限制
+type SubscriptionFilter struct {
+	EventTypes []EventType                `json:"event_types"`
+	ResTypes   []enumor.CloudResourceType `json:"res_types"`
+	Vendors    []enumor.Vendor            `json:"vendors"`
+	// BkBizIDs 资源所属的业务，-1 表示未分配业务，业务变更事件的变更前或变更后业务匹配即可
+	BkBizIDs []int64 `json:"bk_biz_ids"`
+}
+
+// MaxFilterValueLen 单个过滤条件的最大数量
+const MaxFilterValueLen = 100
+
+// Validate SubscriptionFilter.
+func (f SubscriptionFilter) Validate() error {
+	if len(f.EventTypes) > MaxFilterValueLen || len(f.ResTypes) > MaxFilterValueLen ||
+		len(f.Vendors) > MaxFilterValueLen || len(f.BkBizIDs) > MaxFilterValueLen {
+		return fmt.Errorf("filter values should <= %d", MaxFilterValueLen)
+	}
+
+	for _, one := range f.EventTypes {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, one := range f.ResTypes {
+		if !corereshistory.IsSupported(one) {
+			return fmt.Errorf("resource type %s does not support change event", one)
+		}
+	}
+
+	for _, one := range f.Vendors {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, one := range f.BkBizIDs {
+		if one == 0 || one < -1 {
+			return errors.New("bk_biz_ids is invalid")
+		}
+	}
+
+	return nil
+}
+
+// Match returns whether the event matches the subscription filter.
+func (f SubscriptionFilter) Match(event *Event) bool {
+	if len(f.EventTypes) != 0 && !contains(f.EventTypes, event.EventType) {
+		return false
+	}
+
+	if len(f.ResTypes) != 0 && !contains(f.ResTypes, event.ResType) {
+		return false
+	}
+
+	if len(f.Vendors) != 0 && !contains(f.Vendors, event.Vendor) {
+		return false
+	}
+
+	if len(f.BkBizIDs) != 0 {
+		if contains(f.BkBizIDs, event.BkBizID) {
+			return true
+		}
+
+		return event.EventType == ResReassigned && contains(f.BkBizIDs, event.PrevBkBizID)
+	}
+
+	return true
+}
+
+func contains[T comparable](values []T, target T) bool {
+	for _, one := range values {
+		if one == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	// EventTypeHeader 推送请求中事件类型的请求头
+	EventTypeHeader = "X-Hcm-Event"
+	// DeliveryIDHeader 推送请求中推送记录ID的请求头，推送失败重试时不变，订阅方可据此去重
+	DeliveryIDHeader = "X-Hcm-Delivery"
+
+	// MinSecretLen 订阅签名密钥的最小长度
+	MinSecretLen = 16
+	// MaxSecretLen 订阅签名密钥的最大长度
+	MaxSecretLen = 128
+	// MaxURLLen 订阅推送地址的最大长度
+	MaxURLLen = 512
+)
+
+// ValidateURL validate the url of subscription, only http and https are supported.
+func ValidateURL(rawURL string) error {
+	if len(rawURL) == 0 || len(rawURL) > MaxURLLen {
+		return fmt.Errorf("url length should between 1 and %d", MaxURLLen)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("url is invalid, err: %v", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return errors.New("url should be an absolute http or https url")
+	}
+
+	return nil
+}
+
+// ValidateSecret validate the signature secret of subscription.
+func ValidateSecret(secret string) error {
+	if len(secret) < MinSecretLen || len(secret) > MaxSecretLen {
+		return fmt.Errorf("secret length should between %d and %d", MinSecretLen, MaxSecretLen)
+	}
+
+	return nil
+}
+
+// Subscription 资源变更事件订阅，事件通过 HTTP POST 推送到订阅的 URL，请求体使用订阅的密钥签名
+type Subscription struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	SubscriptionFilter
+	Enabled bool    `json:"enabled"`
+	Memo    *string `json:"memo"`
+	core.Revision
+}
+
+// DeliveryState 事件推送状态
+type DeliveryState string
+
+const (
+	// DeliveryPending 待推送，包括推送失败待重试
+	DeliveryPending DeliveryState = "pending"
+	// DeliverySucceeded 推送成功
+	DeliverySucceeded DeliveryState = "succeeded"
+	// DeliveryFailed 推送失败且超过最大重试次数，不再自动重试，可手动重试
+	DeliveryFailed DeliveryState = "failed"
+)
+
+// Delivery 事件推送记录，每个事件推送到每个匹配的订阅产生一条记录
+type Delivery struct {
+	ID             uint64        `json:"id"`
+	SubscriptionID string        `json:"subscription_id"`
+	EventID        uint64        `json:"event_id"`
+	State          DeliveryState `json:"state"`
+	RetryCount     uint          `json:"retry_count"`
+	NextRetryAt    string        `json:"next_retry_at"`
+	// StatusCode 最近一次推送订阅方响应的 HTTP 状态码，请求失败时为0
+	StatusCode int    `json:"status_code"`
+	LastError  string `json:"last_error"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
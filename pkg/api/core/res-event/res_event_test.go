@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"testing"
+
+	corereshistory "hcm/pkg/api/core/res-history"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestParseEventType(t *testing.T) {
+	cases := []struct {
+		action    enumor.AuditAction
+		diffs     []corereshistory.FieldDiff
+		expect    EventType
+		prevBizID int64
+	}{
+		{enumor.Create, nil, ResCreated, 0},
+		{enumor.Delete, []corereshistory.FieldDiff{{Field: "name"}}, ResDeleted, 0},
+		{enumor.Update, nil, ResUpdated, 0},
+		{enumor.Update, []corereshistory.FieldDiff{{Field: "name", Before: "a", After: "b"}}, ResUpdated, 0},
+		{enumor.Update, []corereshistory.FieldDiff{{Field: "bk_biz_id", Before: float64(-1), After: float64(2)},
+			{Field: "name", Before: "a", After: "b"}}, ResReassigned, -1},
+	}
+
+	for idx, c := range cases {
+		eventType, prevBizID := ParseEventType(c.action, c.diffs)
+		if eventType != c.expect || prevBizID != c.prevBizID {
+			t.Errorf("case %d expect %s(%d), but got %s(%d)", idx, c.expect, c.prevBizID, eventType, prevBizID)
+		}
+	}
+}
+
+func TestSubscriptionFilterMatch(t *testing.T) {
+	filter := SubscriptionFilter{
+		EventTypes: []EventType{ResCreated, ResReassigned},
+		Vendors:    []enumor.Vendor{enumor.TCloud},
+		BkBizIDs:   []int64{2},
+	}
+
+	cases := []struct {
+		event  Event
+		expect bool
+	}{
+		{Event{EventType: ResCreated, Vendor: enumor.TCloud, BkBizID: 2}, true},
+		{Event{EventType: ResUpdated, Vendor: enumor.TCloud, BkBizID: 2}, false},
+		{Event{EventType: ResCreated, Vendor: enumor.Aws, BkBizID: 2}, false},
+		{Event{EventType: ResCreated, Vendor: enumor.TCloud, BkBizID: -1}, false},
+		{Event{EventType: ResReassigned, Vendor: enumor.TCloud, BkBizID: -1, PrevBkBizID: 2}, true},
+		{Event{EventType: ResReassigned, Vendor: enumor.TCloud, BkBizID: 3, PrevBkBizID: 4}, false},
+	}
+
+	for idx, c := range cases {
+		if got := filter.Match(&c.event); got != c.expect {
+			t.Errorf("case %d expect match %v, but got %v", idx, c.expect, got)
+		}
+	}
+
+	if !(SubscriptionFilter{}).Match(&Event{EventType: ResDeleted, Vendor: enumor.Aws}) {
+		t.Errorf("empty filter should match all events")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	"reflect"
+	"sort"
+)
+
+// DiffSnapshot 对比两个资源快照，返回按字段名排序的字段级变更。对象类型的字段会递归对比其中的嵌套字段，
+// 数组等其他类型的字段作为整体对比。
+func DiffSnapshot(before, after map[string]interface{}) []FieldDiff {
+	diffs := make([]FieldDiff, 0)
+	diffObject("", before, after, &diffs)
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+
+	return diffs
+}
+
+func diffObject(prefix string, before, after map[string]interface{}, diffs *[]FieldDiff) {
+	for key, beforeVal := range before {
+		afterVal, exists := after[key]
+		if !exists {
+			*diffs = append(*diffs, FieldDiff{Field: prefix + key, Before: beforeVal})
+			continue
+		}
+
+		diffValue(prefix+key, beforeVal, afterVal, diffs)
+	}
+
+	for key, afterVal := range after {
+		if _, exists := before[key]; !exists {
+			*diffs = append(*diffs, FieldDiff{Field: prefix + key, After: afterVal})
+		}
+	}
+}
+
+func diffValue(field string, before, after interface{}, diffs *[]FieldDiff) {
+	beforeObj, beforeIsObj := before.(map[string]interface{})
+	afterObj, afterIsObj := after.(map[string]interface{})
+	if beforeIsObj && afterIsObj {
+		diffObject(field+".", beforeObj, afterObj, diffs)
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*diffs = append(*diffs, FieldDiff{Field: field, Before: before, After: after})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/tools/json"
+)
+
+func TestDiffSnapshot(t *testing.T) {
+	before := map[string]interface{}{
+		"name":    "sg-1",
+		"memo":    "old",
+		"deleted": "x",
+		"tags":    []interface{}{"a", "b"},
+		"extension": map[string]interface{}{
+			"vpc_id": "vpc-1",
+			"rules":  map[string]interface{}{"count": float64(1)},
+		},
+	}
+	after := map[string]interface{}{
+		"name":  "sg-1",
+		"memo":  "new",
+		"added": float64(3),
+		"tags":  []interface{}{"a", "c"},
+		"extension": map[string]interface{}{
+			"vpc_id": "vpc-1",
+			"rules":  map[string]interface{}{"count": float64(2)},
+		},
+	}
+
+	expected := []FieldDiff{
+		{Field: "added", After: float64(3)},
+		{Field: "deleted", Before: "x"},
+		{Field: "extension.rules.count", Before: float64(1), After: float64(2)},
+		{Field: "memo", Before: "old", After: "new"},
+		{Field: "tags", Before: []interface{}{"a", "b"}, After: []interface{}{"a", "c"}},
+	}
+
+	diffs := DiffSnapshot(before, after)
+	if !reflect.DeepEqual(diffs, expected) {
+		got, _ := json.Marshal(diffs)
+		t.Errorf("diff snapshot is not expected, got: %s", got)
+		return
+	}
+
+	if diffs = DiffSnapshot(after, after); len(diffs) != 0 {
+		t.Errorf("diff same snapshot should be empty, got: %v", diffs)
+		return
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory ...
+package reshistory
+
+import (
+	"hcm/pkg/criteria/enumor"
+)
+
+// SupportedResTypes 支持记录变更历史的资源类型
+var SupportedResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.SecurityGroupCloudResType,
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.LoadBalancerCloudResType,
+	enumor.DiskCloudResType,
+	enumor.EipCloudResType,
+}
+
+// IsSupported returns whether the resource type supports change history.
+func IsSupported(resType enumor.CloudResourceType) bool {
+	for _, one := range SupportedResTypes {
+		if one == resType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResChangeHistory 资源变更历史，每次资源变更对应一个版本
+type ResChangeHistory struct {
+	ID         string                   `json:"id"`
+	ResType    enumor.CloudResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	CloudResID string                   `json:"cloud_res_id"`
+	ResName    string                   `json:"res_name"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	Version    uint64                   `json:"version"`
+	Action     enumor.AuditAction       `json:"action"`
+	// Snapshot 该版本的资源快照，删除时为删除前的快照
+	Snapshot map[string]interface{} `json:"snapshot,omitempty"`
+	// Diff 相对上一版本的字段级变更
+	Diff      []FieldDiff              `json:"diff"`
+	Source    enumor.RequestSourceType `json:"source"`
+	Rid       string                   `json:"rid"`
+	Creator   string                   `json:"creator"`
+	CreatedAt string                   `json:"created_at"`
+}
+
+// FieldDiff 字段级变更，json类型字段中的嵌套字段通过 '.' 关联，e.g: "extension.vpc_id"
+type FieldDiff struct {
+	Field string `json:"field"`
+	// Before 变更前的值，新增字段时为空
+	Before interface{} `json:"before"`
+	// After 变更后的值，删除字段时为空
+	After interface{} `json:"after"`
+}
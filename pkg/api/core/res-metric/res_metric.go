@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric ...
+package resmetric
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ResMetricDaily 资源监控指标天级聚合数据
+type ResMetricDaily struct {
+	ID            string                   `json:"id"`
+	Vendor        enumor.Vendor            `json:"vendor"`
+	AccountID     string                   `json:"account_id"`
+	Region        string                   `json:"region"`
+	ResType       enumor.CloudResourceType `json:"res_type"`
+	ResID         string                   `json:"res_id"`
+	CloudResID    string                   `json:"cloud_res_id"`
+	Metric        string                   `json:"metric"`
+	StatDate      string                   `json:"stat_date"`
+	AvgValue      float64                  `json:"avg_value"`
+	MaxValue      float64                  `json:"max_value"`
+	MinValue      float64                  `json:"min_value"`
+	SampleCount   int64                    `json:"sample_count"`
+	core.Revision `json:",inline"`
+}
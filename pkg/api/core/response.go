@@ -44,6 +44,9 @@ type ListResult struct {
 type ListResultT[T any] struct {
 	Count   uint64 `json:"count"`
 	Details []T    `json:"details"`
+	// NextCursor is the cursor to query next page when page.use_cursor is enabled,
+	// empty means there are no more resources.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // BaseResp define base resp.
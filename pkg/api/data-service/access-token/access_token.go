@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken ...
+package accesstoken
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/access-token"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// ServiceAccountCreate defines the service account to create.
+type ServiceAccountCreate struct {
+	Name string  `json:"name" validate:"required,lte=61"`
+	Memo *string `json:"memo"`
+}
+
+// BatchCreateServiceAccountReq defines batch create service account request.
+type BatchCreateServiceAccountReq struct {
+	ServiceAccounts []ServiceAccountCreate `json:"service_accounts" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate BatchCreateServiceAccountReq.
+func (req *BatchCreateServiceAccountReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.ServiceAccounts {
+		if err := coretoken.ValidateServiceAccountName(req.ServiceAccounts[idx].Name); err != nil {
+			return fmt.Errorf("service_accounts[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ServiceAccountUpdate defines the service account to update.
+type ServiceAccountUpdate struct {
+	ID   string  `json:"id" validate:"required"`
+	Memo *string `json:"memo" validate:"required"`
+}
+
+// BatchUpdateServiceAccountReq defines batch update service account request.
+type BatchUpdateServiceAccountReq struct {
+	ServiceAccounts []ServiceAccountUpdate `json:"service_accounts" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate BatchUpdateServiceAccountReq.
+func (req *BatchUpdateServiceAccountReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ListServiceAccountResult defines list service account result.
+type ListServiceAccountResult = core.ListResultT[coretoken.ServiceAccount]
+
+// AccessTokenCreate defines the access token to create, the token is generated by the caller and only the hash
+// of it is stored.
+type AccessTokenCreate struct {
+	Name        string              `json:"name" validate:"required,lte=255"`
+	OwnerType   coretoken.OwnerType `json:"owner_type" validate:"required"`
+	Owner       string              `json:"owner" validate:"required,lte=64"`
+	TokenHash   string              `json:"token_hash" validate:"required,len=64"`
+	TokenPrefix string              `json:"token_prefix" validate:"required,lte=16"`
+	Scope       coretoken.Scope     `json:"scope"`
+	// ExpiresAt 过期时间，格式为 constant.TimeStdFormat
+	ExpiresAt string  `json:"expires_at" validate:"required"`
+	Memo      *string `json:"memo"`
+}
+
+// Validate AccessTokenCreate.
+func (t *AccessTokenCreate) Validate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if err := t.OwnerType.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.TimeStdFormat, t.ExpiresAt); err != nil {
+		return fmt.Errorf("expires_at is invalid, err: %v", err)
+	}
+
+	return t.Scope.Validate()
+}
+
+// BatchCreateAccessTokenReq defines batch create access token request.
+type BatchCreateAccessTokenReq struct {
+	Tokens []AccessTokenCreate `json:"tokens" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateAccessTokenReq.
+func (req *BatchCreateAccessTokenReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Tokens {
+		if err := req.Tokens[idx].Validate(); err != nil {
+			return fmt.Errorf("tokens[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// AccessTokenUpdate defines the access token to update, a revoked token can not be restored.
+type AccessTokenUpdate struct {
+	ID      string  `json:"id" validate:"required"`
+	Revoked *bool   `json:"revoked"`
+	Memo    *string `json:"memo"`
+}
+
+// BatchUpdateAccessTokenReq defines batch update access token request.
+type BatchUpdateAccessTokenReq struct {
+	Tokens []AccessTokenUpdate `json:"tokens" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate BatchUpdateAccessTokenReq.
+func (req *BatchUpdateAccessTokenReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx, token := range req.Tokens {
+		if token.Revoked == nil && token.Memo == nil {
+			return fmt.Errorf("tokens[%d] is invalid, at least one of revoked and memo should be set", idx)
+		}
+
+		if token.Revoked != nil && !*token.Revoked {
+			return fmt.Errorf("tokens[%d] is invalid, revoked token can not be restored", idx)
+		}
+	}
+
+	return nil
+}
+
+// AccessTokenLastUsed defines the last used time of access token.
+type AccessTokenLastUsed struct {
+	ID string `json:"id" validate:"required"`
+	// LastUsedAt 最近使用时间，格式为 constant.TimeStdFormat
+	LastUsedAt string `json:"last_used_at" validate:"required"`
+}
+
+// BatchUpdateLastUsedReq defines batch update access token last used time request.
+type BatchUpdateLastUsedReq struct {
+	Tokens []AccessTokenLastUsed `json:"tokens" validate:"required,min=1,max=500,dive"`
+}
+
+// Validate BatchUpdateLastUsedReq.
+func (req *BatchUpdateLastUsedReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx, token := range req.Tokens {
+		if _, err := time.Parse(constant.TimeStdFormat, token.LastUsedAt); err != nil {
+			return fmt.Errorf("tokens[%d] is invalid, last_used_at is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ListAccessTokenResult defines list access token result.
+type ListAccessTokenResult = core.ListResultT[coretoken.AccessToken]
+
+// BatchDeleteReq defines batch delete service account or access token request.
+type BatchDeleteReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, id := range req.IDs {
+		if len(id) == 0 {
+			return errors.New("id can not be empty")
+		}
+	}
+
+	return nil
+}
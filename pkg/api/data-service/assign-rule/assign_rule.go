@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule ...
+package assignrule
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreassign "hcm/pkg/api/core/assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// AssignRuleCreate defines the assign rule to create.
+type AssignRuleCreate struct {
+	Name       string                     `json:"name" validate:"required,lte=64"`
+	Priority   *uint32                    `json:"priority" validate:"required"`
+	Enabled    *bool                      `json:"enabled" validate:"required"`
+	BkBizID    int64                      `json:"bk_biz_id" validate:"required,min=1"`
+	BkCloudID  *int64                     `json:"bk_cloud_id" validate:"omitempty,min=0"`
+	ResTypes   []enumor.CloudResourceType `json:"res_types" validate:"required"`
+	Conditions coreassign.Conditions      `json:"conditions"`
+	Memo       *string                    `json:"memo"`
+}
+
+// Validate AssignRuleCreate.
+func (r *AssignRuleCreate) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if err := coreassign.ValidateResTypes(r.ResTypes, r.BkCloudID); err != nil {
+		return err
+	}
+
+	return r.Conditions.Validate()
+}
+
+// BatchCreateAssignRuleReq defines batch create assign rule request.
+type BatchCreateAssignRuleReq struct {
+	Rules []AssignRuleCreate `json:"rules" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateAssignRuleReq.
+func (req *BatchCreateAssignRuleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Rules {
+		if err := req.Rules[idx].Validate(); err != nil {
+			return fmt.Errorf("rules[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// AssignRuleUpdate defines the assign rule to update, only set fields will be updated.
+type AssignRuleUpdate struct {
+	ID         string                     `json:"id" validate:"required"`
+	Name       string                     `json:"name" validate:"omitempty,lte=64"`
+	Priority   *uint32                    `json:"priority"`
+	Enabled    *bool                      `json:"enabled"`
+	BkBizID    int64                      `json:"bk_biz_id" validate:"omitempty,min=1"`
+	BkCloudID  *int64                     `json:"bk_cloud_id" validate:"omitempty,min=0"`
+	ResTypes   []enumor.CloudResourceType `json:"res_types"`
+	Conditions *coreassign.Conditions     `json:"conditions"`
+	Memo       *string                    `json:"memo"`
+}
+
+// BatchUpdateAssignRuleReq defines batch update assign rule request.
+type BatchUpdateAssignRuleReq struct {
+	Rules []AssignRuleUpdate `json:"rules" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate BatchUpdateAssignRuleReq.
+func (req *BatchUpdateAssignRuleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx, rule := range req.Rules {
+		if rule.Conditions != nil {
+			if err := rule.Conditions.Validate(); err != nil {
+				return fmt.Errorf("rules[%d] is invalid, err: %v", idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListAssignRuleResult defines list assign rule result.
+type ListAssignRuleResult = core.ListResultT[coreassign.AssignRule]
+
+// BatchDeleteReq defines batch delete assign rule request.
+type BatchDeleteReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, id := range req.IDs {
+		if len(id) == 0 {
+			return errors.New("id can not be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/audit"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/tools/slice"
+)
+
+// -------------------------- Archive --------------------------
+
+// ArchiveAuditReq 将指定时间之前的审计归档到对象存储，并从审计表中删除
+type ArchiveAuditReq struct {
+	// Before 归档该时间之前的审计，格式：2006-01-02T15:04:05Z07:00
+	Before string `json:"before" validate:"required"`
+	// Limit 单次最多归档的审计数量
+	Limit uint `json:"limit" validate:"required,min=1,max=5000"`
+}
+
+// Validate ArchiveAuditReq.
+func (req *ArchiveAuditReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.TimeStdFormat, req.Before); err != nil {
+		return fmt.Errorf("invalid before: %s, should be like: %s", req.Before, constant.TimeStdFormat)
+	}
+
+	return nil
+}
+
+// ArchiveAuditResult 归档审计的结果
+type ArchiveAuditResult struct {
+	// Count 本次处理的审计数量，小于请求的 limit 时说明已没有需要归档的审计
+	Count uint64 `json:"count"`
+	// ArchiveIDs 本次生成的归档清单ID
+	ArchiveIDs []string `json:"archive_ids"`
+}
+
+// -------------------------- List Archive --------------------------
+
+// ListArchiveResult defines list audit archive result.
+type ListArchiveResult = core.ListResultT[audit.Archive]
+
+// -------------------------- Search Archived Audit --------------------------
+
+// MaxSearchArchiveDays 单次检索已归档审计的最大天数
+const MaxSearchArchiveDays = 31
+
+// SearchArchivedAuditReq 检索已归档的审计，按审计创建时间确定需要读取的归档文件
+type SearchArchivedAuditReq struct {
+	// StartTime 审计创建时间的开始时间（包含），格式：2006-01-02T15:04:05Z07:00
+	StartTime string `json:"start_time" validate:"required"`
+	// EndTime 审计创建时间的结束时间（包含），格式：2006-01-02T15:04:05Z07:00
+	EndTime    string                   `json:"end_time" validate:"required"`
+	ResType    enumor.AuditResourceType `json:"res_type" validate:"omitempty"`
+	ResID      string                   `json:"res_id" validate:"omitempty"`
+	CloudResID string                   `json:"cloud_res_id" validate:"omitempty"`
+	Action     enumor.AuditAction       `json:"action" validate:"omitempty"`
+	Operator   string                   `json:"operator" validate:"omitempty"`
+	Rid        string                   `json:"rid" validate:"omitempty"`
+	// BkBizIDs 审计所属业务，为空时不限制
+	BkBizIDs []int64 `json:"bk_biz_ids" validate:"omitempty,max=500"`
+	// AccountIDs 审计所属账号，为空时不限制
+	AccountIDs []string `json:"account_ids" validate:"omitempty"`
+	// Limit 最多返回的审计数量
+	Limit uint `json:"limit" validate:"required,min=1,max=500"`
+
+	start time.Time
+	end   time.Time
+}
+
+// Validate SearchArchivedAuditReq.
+func (req *SearchArchivedAuditReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	var err error
+	if req.start, err = time.Parse(constant.TimeStdFormat, req.StartTime); err != nil {
+		return fmt.Errorf("start_time is invalid, should be like: %s", constant.TimeStdFormat)
+	}
+
+	if req.end, err = time.Parse(constant.TimeStdFormat, req.EndTime); err != nil {
+		return fmt.Errorf("end_time is invalid, should be like: %s", constant.TimeStdFormat)
+	}
+
+	if req.end.Before(req.start) {
+		return errors.New("end_time should not be earlier than start_time")
+	}
+
+	if req.end.Sub(req.start) > MaxSearchArchiveDays*24*time.Hour {
+		return fmt.Errorf("search time range should <= %d days", MaxSearchArchiveDays)
+	}
+
+	return nil
+}
+
+// Match 判断已归档的审计是否满足检索条件，需要在 Validate 之后调用
+func (req *SearchArchivedAuditReq) Match(one *tableaudit.AuditTable) bool {
+	createdAt, err := time.Parse(constant.TimeStdFormat, string(one.CreatedAt))
+	if err != nil || createdAt.Before(req.start) || createdAt.After(req.end) {
+		return false
+	}
+
+	if (len(req.ResType) != 0 && one.ResType != req.ResType) ||
+		(len(req.ResID) != 0 && one.ResID != req.ResID) ||
+		(len(req.CloudResID) != 0 && one.CloudResID != req.CloudResID) ||
+		(len(req.Action) != 0 && one.Action != req.Action) ||
+		(len(req.Operator) != 0 && one.Operator != req.Operator) ||
+		(len(req.Rid) != 0 && one.Rid != req.Rid) {
+		return false
+	}
+
+	if len(req.BkBizIDs) != 0 && !slice.IsItemInSlice(req.BkBizIDs, one.BkBizID) {
+		return false
+	}
+
+	if len(req.AccountIDs) != 0 && !slice.IsItemInSlice(req.AccountIDs, one.AccountID) {
+		return false
+	}
+
+	return true
+}
+
+// SearchArchivedAuditResult 检索已归档审计的结果，按审计ID倒序返回
+type SearchArchivedAuditResult struct {
+	Details []audit.Audit `json:"details"`
+}
+
+// -------------------------- Rehydrate Archive --------------------------
+
+// RehydrateArchiveResult 将归档文件中的审计恢复到审计表的结果
+type RehydrateArchiveResult struct {
+	// Count 恢复的审计数量，已存在于审计表中的审计会被忽略
+	Count uint64 `json:"count"`
+}
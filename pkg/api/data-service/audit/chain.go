@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/audit"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Verify Chain --------------------------
+
+// VerifyAuditChainReq 按序号顺序校验当前租户的审计哈希链
+type VerifyAuditChainReq struct {
+	// StartSeq 开始校验的序号（包含），为0时从1开始
+	StartSeq uint64 `json:"start_seq" validate:"omitempty"`
+	// Limit 本次最多校验的审计数量
+	Limit uint64 `json:"limit" validate:"required,min=1,max=100000"`
+}
+
+// Validate VerifyAuditChainReq.
+func (req *VerifyAuditChainReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ChainBreakReason 哈希链断裂原因
+type ChainBreakReason string
+
+const (
+	// ChainBreakMissing 序号不连续，审计表和归档文件中都不存在该序号的审计，审计被删除
+	ChainBreakMissing ChainBreakReason = "missing"
+	// ChainBreakPrevHashMismatch 审计记录的上一条哈希与上一条审计的哈希不一致
+	ChainBreakPrevHashMismatch ChainBreakReason = "prev_hash_mismatch"
+	// ChainBreakHashMismatch 审计内容重新计算的哈希与记录的哈希不一致，审计内容被篡改
+	ChainBreakHashMismatch ChainBreakReason = "hash_mismatch"
+	// ChainBreakCheckpointMismatch 审计的哈希与检查点记录的哈希不一致
+	ChainBreakCheckpointMismatch ChainBreakReason = "checkpoint_mismatch"
+	// ChainBreakCheckpointSignatureInvalid 检查点签名校验失败，检查点被篡改
+	ChainBreakCheckpointSignatureInvalid ChainBreakReason = "checkpoint_signature_invalid"
+	// ChainBreakArchiveChecksumMismatch 归档文件的sha256与归档清单记录的不一致，归档文件被篡改，其中的审计不参与校验
+	ChainBreakArchiveChecksumMismatch ChainBreakReason = "archive_checksum_mismatch"
+)
+
+// ChainBreak 哈希链断裂点
+type ChainBreak struct {
+	Seq uint64 `json:"seq"`
+	// AuditID 断裂点对应的审计ID，审计缺失时为0
+	AuditID uint64           `json:"audit_id"`
+	Reason  ChainBreakReason `json:"reason"`
+	Message string           `json:"message"`
+}
+
+// VerifyAuditChainResult 审计哈希链校验结果
+type VerifyAuditChainResult struct {
+	// HeadSeq 当前哈希链头的序号
+	HeadSeq uint64 `json:"head_seq"`
+	// StartSeq 本次校验的开始序号
+	StartSeq uint64 `json:"start_seq"`
+	// EndSeq 本次校验的结束序号，小于 HeadSeq 时可从 EndSeq+1 继续校验
+	EndSeq uint64 `json:"end_seq"`
+	// Checked 本次校验的审计表中的审计数量
+	Checked uint64 `json:"checked"`
+	// ArchiveChecked 本次校验的归档文件中的审计数量
+	ArchiveChecked uint64 `json:"archive_checked"`
+	// CheckpointChecked 本次校验的检查点数量
+	CheckpointChecked uint64       `json:"checkpoint_checked"`
+	Breaks            []ChainBreak `json:"breaks"`
+}
+
+// -------------------------- Checkpoint --------------------------
+
+// CreateCheckpointResult 生成审计哈希链检查点的结果
+type CreateCheckpointResult struct {
+	// ID 检查点ID，哈希链自上次检查点后没有新的审计时为空
+	ID   string `json:"id"`
+	Seq  uint64 `json:"seq"`
+	Hash string `json:"hash"`
+	Path string `json:"path"`
+}
+
+// ListCheckpointResult defines list audit checkpoint result.
+type ListCheckpointResult = core.ListResultT[audit.Checkpoint]
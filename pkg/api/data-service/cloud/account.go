@@ -35,7 +35,8 @@ import (
 // AccountExtensionCreateReq account extension create req.
 type AccountExtensionCreateReq interface {
 	TCloudAccountExtensionCreateReq | AwsAccountExtensionCreateReq | HuaWeiAccountExtensionCreateReq |
-		GcpAccountExtensionCreateReq | AzureAccountExtensionCreateReq | OtherAccountExtensionCreateReq
+		GcpAccountExtensionCreateReq | AzureAccountExtensionCreateReq | OtherAccountExtensionCreateReq |
+		AliyunAccountExtensionCreateReq | OpenStackAccountExtensionCreateReq
 }
 
 // OtherAccountExtensionCreateReq ...
@@ -123,6 +124,34 @@ func (req *AzureAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.
 	req.CloudClientSecretKey = cipher.EncryptToBase64(req.CloudClientSecretKey)
 }
 
+// AliyunAccountExtensionCreateReq ...
+type AliyunAccountExtensionCreateReq struct {
+	CloudMainAccountID string `json:"cloud_main_account_id" validate:"required"`
+	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
+	CloudSecretID      string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey     string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *AliyunAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+}
+
+// OpenStackAccountExtensionCreateReq ...
+type OpenStackAccountExtensionCreateReq struct {
+	CloudAuthURL     string `json:"cloud_auth_url" validate:"required"`
+	CloudRegion      string `json:"cloud_region" validate:"omitempty"`
+	CloudProjectID   string `json:"cloud_project_id" validate:"required"`
+	CloudProjectName string `json:"cloud_project_name" validate:"omitempty"`
+	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *OpenStackAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+}
+
 // AccountCreateReq ...
 type AccountCreateReq[T AccountExtensionCreateReq] struct {
 	Name      string                 `json:"name" validate:"required"`
@@ -145,7 +174,8 @@ func (c *AccountCreateReq[T]) Validate() error {
 // Note: 对于允许为空字符串的字段，则其类型需要定义为指针，正常情况下，Json合并时空值会被忽略
 type AccountExtensionUpdateReq interface {
 	TCloudAccountExtensionUpdateReq | AwsAccountExtensionUpdateReq | HuaWeiAccountExtensionUpdateReq |
-		GcpAccountExtensionUpdateReq | AzureAccountExtensionUpdateReq
+		GcpAccountExtensionUpdateReq | AzureAccountExtensionUpdateReq | AliyunAccountExtensionUpdateReq |
+		OpenStackAccountExtensionUpdateReq
 }
 
 // TCloudAccountExtensionUpdateReq ...
@@ -237,6 +267,40 @@ func (req *AzureAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.
 	}
 }
 
+// AliyunAccountExtensionUpdateReq ...
+type AliyunAccountExtensionUpdateReq struct {
+	CloudMainAccountID string  `json:"cloud_main_account_id,omitempty" validate:"omitempty"`
+	CloudSubAccountID  string  `json:"cloud_sub_account_id,omitempty" validate:"omitempty"`
+	CloudSecretID      *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
+	CloudSecretKey     *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *AliyunAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	if req.CloudSecretKey != nil {
+		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		req.CloudSecretKey = &encryptedCloudSecretKey
+	}
+}
+
+// OpenStackAccountExtensionUpdateReq ...
+type OpenStackAccountExtensionUpdateReq struct {
+	CloudAuthURL     string  `json:"cloud_auth_url,omitempty" validate:"omitempty"`
+	CloudRegion      *string `json:"cloud_region,omitempty" validate:"omitempty"`
+	CloudProjectID   string  `json:"cloud_project_id,omitempty" validate:"omitempty"`
+	CloudProjectName string  `json:"cloud_project_name,omitempty" validate:"omitempty"`
+	CloudSecretID    *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
+	CloudSecretKey   *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *OpenStackAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	if req.CloudSecretKey != nil {
+		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		req.CloudSecretKey = &encryptedCloudSecretKey
+	}
+}
+
 // AccountUpdateReq ...
 type AccountUpdateReq[T AccountExtensionUpdateReq] struct {
 	Name               string   `json:"name" validate:"omitempty"`
@@ -258,7 +322,8 @@ func (u *AccountUpdateReq[T]) Validate() error {
 // AccountExtensionGetResp ...
 type AccountExtensionGetResp interface {
 	cloud.TCloudAccountExtension | cloud.AwsAccountExtension | cloud.HuaWeiAccountExtension |
-		cloud.GcpAccountExtension | cloud.AzureAccountExtension | cloud.OtherAccountExtension
+		cloud.GcpAccountExtension | cloud.AzureAccountExtension | cloud.OtherAccountExtension |
+		cloud.AliyunAccountExtension | cloud.OpenStackAccountExtension
 }
 
 // AccountGetResult ...
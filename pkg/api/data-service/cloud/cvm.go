@@ -172,8 +172,9 @@ func (req *CvmListReq) Validate() error {
 
 // CvmListResult define cvm list result.
 type CvmListResult struct {
-	Count   uint64            `json:"count"`
-	Details []corecvm.BaseCvm `json:"details"`
+	Count      uint64            `json:"count"`
+	Details    []corecvm.BaseCvm `json:"details"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 // CvmListResp define list resp.
@@ -196,8 +197,9 @@ func (req *CvmExtListReq) Validate() error {
 
 // CvmExtListResult define cvm with extension list result.
 type CvmExtListResult[T corecvm.Extension] struct {
-	Count   uint64           `json:"count,omitempty"`
-	Details []corecvm.Cvm[T] `json:"details,omitempty"`
+	Count      uint64           `json:"count,omitempty"`
+	Details    []corecvm.Cvm[T] `json:"details,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
 
 // CvmExtListResp define list resp.
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+// OpenStackEipExtensionCreateReq ...
+type OpenStackEipExtensionCreateReq struct {
+	CloudNetworkID *string `json:"cloud_network_id"`
+	CloudPortID    *string `json:"cloud_port_id"`
+	CloudRouterID  *string `json:"cloud_router_id"`
+	CloudProjectID *string `json:"cloud_project_id"`
+}
+
+// OpenStackEipExtensionResult ...
+type OpenStackEipExtensionResult struct {
+	CloudNetworkID *string `json:"cloud_network_id"`
+	CloudPortID    *string `json:"cloud_port_id"`
+	CloudRouterID  *string `json:"cloud_router_id"`
+	CloudProjectID *string `json:"cloud_project_id"`
+}
+
+// OpenStackEipExtensionUpdateReq ...
+type OpenStackEipExtensionUpdateReq struct {
+	CloudNetworkID *string `json:"cloud_network_id"`
+	CloudPortID    *string `json:"cloud_port_id"`
+	CloudRouterID  *string `json:"cloud_router_id"`
+	CloudProjectID *string `json:"cloud_project_id"`
+}
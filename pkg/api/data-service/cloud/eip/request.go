@@ -63,7 +63,9 @@ type EipExtensionCreateReq interface {
 		AwsEipExtensionCreateReq |
 		AzureEipExtensionCreateReq |
 		GcpEipExtensionCreateReq |
-		HuaWeiEipExtensionCreateReq
+		HuaWeiEipExtensionCreateReq |
+		AliyunEipExtensionCreateReq |
+		OpenStackEipExtensionCreateReq
 }
 
 // EipListReq ...
@@ -84,7 +86,9 @@ type EipExtensionUpdateReq interface {
 		AwsEipExtensionUpdateReq |
 		AzureEipExtensionUpdateReq |
 		GcpEipExtensionUpdateReq |
-		HuaWeiEipExtensionUpdateReq
+		HuaWeiEipExtensionUpdateReq |
+		AliyunEipExtensionUpdateReq |
+		OpenStackEipExtensionUpdateReq
 }
 
 // EipExtBatchUpdateReq ...
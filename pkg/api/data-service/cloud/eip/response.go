@@ -49,7 +49,9 @@ type EipExtensionResult interface {
 		AwsEipExtensionResult |
 		GcpEipExtensionResult |
 		AzureEipExtensionResult |
-		HuaWeiEipExtensionResult
+		HuaWeiEipExtensionResult |
+		AliyunEipExtensionResult |
+		OpenStackEipExtensionResult
 }
 
 // EipListResp ...
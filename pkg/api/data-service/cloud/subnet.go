@@ -57,7 +57,8 @@ func (c *SubnetBatchCreateReq[T]) Validate() error {
 
 // SubnetCreateExtension defines create subnet extensional info.
 type SubnetCreateExtension interface {
-	TCloudSubnetCreateExt | AwsSubnetCreateExt | GcpSubnetCreateExt | AzureSubnetCreateExt | HuaWeiSubnetCreateExt
+	TCloudSubnetCreateExt | AwsSubnetCreateExt | GcpSubnetCreateExt | AzureSubnetCreateExt | HuaWeiSubnetCreateExt |
+		AliyunSubnetCreateExt | OpenStackSubnetCreateExt
 }
 
 // TCloudSubnetCreateExt defines create tencent cloud subnet extensional info.
@@ -103,6 +104,22 @@ type HuaWeiSubnetCreateExt struct {
 	NtpAddresses []string `json:"ntp_addresses" validate:"required"`
 }
 
+// AliyunSubnetCreateExt defines create aliyun subnet(vswitch) extensional info.
+type AliyunSubnetCreateExt struct {
+	Status                  string `json:"status" validate:"required"`
+	IsDefault               bool   `json:"is_default" validate:"omitempty"`
+	AvailableIpAddressCount int64  `json:"available_ip_address_count" validate:"omitempty"`
+	CloudNetworkAclID       string `json:"cloud_network_acl_id,omitempty" validate:"omitempty"`
+}
+
+// OpenStackSubnetCreateExt defines create openstack subnet extensional info.
+type OpenStackSubnetCreateExt struct {
+	GatewayIp      string   `json:"gateway_ip" validate:"omitempty"`
+	EnableDhcp     bool     `json:"enable_dhcp" validate:"omitempty"`
+	DnsNameservers []string `json:"dns_nameservers" validate:"omitempty"`
+	CloudProjectID string   `json:"cloud_project_id" validate:"omitempty"`
+}
+
 // -------------------------- Update --------------------------
 
 // SubnetBatchUpdateReq defines batch update subnet request.
@@ -136,7 +153,8 @@ type SubnetUpdateBaseInfo struct {
 
 // SubnetUpdateExtension defines subnet update request extensional info.
 type SubnetUpdateExtension interface {
-	TCloudSubnetUpdateExt | AwsSubnetUpdateExt | GcpSubnetUpdateExt | AzureSubnetUpdateExt | HuaWeiSubnetUpdateExt
+	TCloudSubnetUpdateExt | AwsSubnetUpdateExt | GcpSubnetUpdateExt | AzureSubnetUpdateExt | HuaWeiSubnetUpdateExt |
+		AliyunSubnetUpdateExt | OpenStackSubnetUpdateExt
 }
 
 // TCloudSubnetUpdateExt defines update tencent cloud subnet extensional info.
@@ -183,6 +201,21 @@ type HuaWeiSubnetUpdateExt struct {
 	NtpAddresses []string `json:"ntp_addresses,omitempty" validate:"omitempty"`
 }
 
+// AliyunSubnetUpdateExt defines update aliyun subnet(vswitch) extensional info.
+type AliyunSubnetUpdateExt struct {
+	Status                  string  `json:"status,omitempty" validate:"omitempty"`
+	IsDefault               *bool   `json:"is_default,omitempty" validate:"omitempty"`
+	AvailableIpAddressCount *int64  `json:"available_ip_address_count,omitempty" validate:"omitempty"`
+	CloudNetworkAclID       *string `json:"cloud_network_acl_id,omitempty" validate:"omitempty"`
+}
+
+// OpenStackSubnetUpdateExt defines update openstack subnet extensional info.
+type OpenStackSubnetUpdateExt struct {
+	GatewayIp      *string  `json:"gateway_ip,omitempty" validate:"omitempty"`
+	EnableDhcp     *bool    `json:"enable_dhcp,omitempty" validate:"omitempty"`
+	DnsNameservers []string `json:"dns_nameservers,omitempty" validate:"omitempty"`
+}
+
 // SubnetBaseInfoBatchUpdateReq defines batch update subnet base info request.
 type SubnetBaseInfoBatchUpdateReq struct {
 	Subnets []SubnetBaseInfoUpdateReq `json:"subnets" validate:"required"`
@@ -47,7 +47,8 @@ type VpcCreateReq[T VpcCreateExtension] struct {
 
 // VpcCreateExtension defines create vpc extensional info.
 type VpcCreateExtension interface {
-	TCloudVpcCreateExt | AwsVpcCreateExt | GcpVpcCreateExt | AzureVpcCreateExt | HuaWeiVpcCreateExt
+	TCloudVpcCreateExt | AwsVpcCreateExt | GcpVpcCreateExt | AzureVpcCreateExt | HuaWeiVpcCreateExt |
+		AliyunVpcCreateExt | OpenStackVpcCreateExt
 }
 
 // TCloudVpcCreateExt defines create tencent cloud vpc extensional info.
@@ -93,6 +94,26 @@ type HuaWeiVpcCreateExt struct {
 	EnterpriseProjectID string       `json:"enterprise_project_id" validate:"omitempty"`
 }
 
+// AliyunVpcCreateExt defines aliyun vpc extensional info.
+type AliyunVpcCreateExt struct {
+	Cidr            []AliyunCidr `json:"cidr" validate:"required,min=1"`
+	Status          string       `json:"status" validate:"required"`
+	IsDefault       bool         `json:"is_default" validate:"omitempty"`
+	VRouterID       string       `json:"vrouter_id" validate:"omitempty"`
+	ResourceGroupID string       `json:"resource_group_id" validate:"omitempty"`
+}
+
+// OpenStackVpcCreateExt defines openstack network extensional info.
+type OpenStackVpcCreateExt struct {
+	Status         string `json:"status" validate:"required"`
+	AdminStateUp   bool   `json:"admin_state_up" validate:"omitempty"`
+	Shared         bool   `json:"shared" validate:"omitempty"`
+	External       bool   `json:"external" validate:"omitempty"`
+	NetworkType    string `json:"network_type" validate:"omitempty"`
+	MTU            int64  `json:"mtu" validate:"omitempty"`
+	CloudProjectID string `json:"cloud_project_id" validate:"omitempty"`
+}
+
 // Validate VpcBatchCreateReq.
 func (c *VpcBatchCreateReq[T]) Validate() error {
 	return validator.Validate.Struct(c)
@@ -127,7 +148,8 @@ type VpcUpdateBaseInfo struct {
 
 // VpcUpdateExtension defines vpc update request extensional info.
 type VpcUpdateExtension interface {
-	TCloudVpcUpdateExt | AwsVpcUpdateExt | GcpVpcUpdateExt | AzureVpcUpdateExt | HuaWeiVpcUpdateExt
+	TCloudVpcUpdateExt | AwsVpcUpdateExt | GcpVpcUpdateExt | AzureVpcUpdateExt | HuaWeiVpcUpdateExt |
+		AliyunVpcUpdateExt | OpenStackVpcUpdateExt
 }
 
 // TCloudVpcUpdateExt defines tencent cloud vpc extensional info.
@@ -170,6 +192,25 @@ type HuaWeiVpcUpdateExt struct {
 	EnterpriseProjectId *string      `json:"enterprise_project_id,omitempty" validate:"omitempty"`
 }
 
+// AliyunVpcUpdateExt defines aliyun vpc extensional info.
+type AliyunVpcUpdateExt struct {
+	Cidr            []AliyunCidr `json:"cidr,omitempty" validate:"omitempty"`
+	Status          string       `json:"status,omitempty" validate:"omitempty"`
+	IsDefault       *bool        `json:"is_default,omitempty" validate:"omitempty"`
+	VRouterID       *string      `json:"vrouter_id,omitempty" validate:"omitempty"`
+	ResourceGroupID *string      `json:"resource_group_id,omitempty" validate:"omitempty"`
+}
+
+// OpenStackVpcUpdateExt defines openstack network extensional info.
+type OpenStackVpcUpdateExt struct {
+	Status       string  `json:"status,omitempty" validate:"omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty" validate:"omitempty"`
+	Shared       *bool   `json:"shared,omitempty" validate:"omitempty"`
+	External     *bool   `json:"external,omitempty" validate:"omitempty"`
+	NetworkType  *string `json:"network_type,omitempty" validate:"omitempty"`
+	MTU          *int64  `json:"mtu,omitempty" validate:"omitempty"`
+}
+
 // VpcBaseInfoBatchUpdateReq defines batch update vpc base info request.
 type VpcBaseInfoBatchUpdateReq struct {
 	Vpcs []VpcBaseInfoUpdateReq `json:"vpcs" validate:"required"`
@@ -248,3 +289,9 @@ type HuaWeiCidr struct {
 	Type enumor.IPAddressType `json:"type" validate:"required"`
 	Cidr string               `json:"cidr" validate:"required"`
 }
+
+// AliyunCidr aliyun cidr
+type AliyunCidr struct {
+	Type enumor.IPAddressType `json:"type" validate:"required"`
+	Cidr string               `json:"cidr" validate:"required"`
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// BatchCreateDiskSnapshotReq batch create disk snapshot request.
+type BatchCreateDiskSnapshotReq struct {
+	Items []DiskSnapshotCreate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchCreateDiskSnapshotReq.
+func (req *BatchCreateDiskSnapshotReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotCreate disk snapshot create field.
+type DiskSnapshotCreate struct {
+	Vendor           enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID        string        `json:"account_id" validate:"required"`
+	CloudID          string        `json:"cloud_id" validate:"required"`
+	BkBizID          int64         `json:"bk_biz_id"`
+	Name             string        `json:"name"`
+	Region           string        `json:"region" validate:"required"`
+	Zone             string        `json:"zone"`
+	DiskID           string        `json:"disk_id"`
+	CloudDiskID      string        `json:"cloud_disk_id"`
+	DiskSize         uint64        `json:"disk_size"`
+	Status           string        `json:"status"`
+	PolicyID         string        `json:"policy_id"`
+	CloudCreatedTime string        `json:"cloud_created_time"`
+	Memo             *string       `json:"memo"`
+}
+
+// -------------------------- Update --------------------------
+
+// BatchUpdateDiskSnapshotReq batch update disk snapshot request.
+type BatchUpdateDiskSnapshotReq struct {
+	Items []DiskSnapshotUpdate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchUpdateDiskSnapshotReq.
+func (req *BatchUpdateDiskSnapshotReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotUpdate disk snapshot update field, empty field will not be updated.
+type DiskSnapshotUpdate struct {
+	ID          string  `json:"id" validate:"required"`
+	BkBizID     int64   `json:"bk_biz_id"`
+	Name        string  `json:"name"`
+	Zone        string  `json:"zone"`
+	DiskID      string  `json:"disk_id"`
+	CloudDiskID string  `json:"cloud_disk_id"`
+	DiskSize    uint64  `json:"disk_size"`
+	Status      string  `json:"status"`
+	Memo        *string `json:"memo"`
+}
+
+// UpdateDiskSnapshotRecycleStatusReq update disk snapshot recycle status by filter request.
+type UpdateDiskSnapshotRecycleStatusReq struct {
+	Filter        *filter.Expression `json:"filter" validate:"required"`
+	RecycleStatus string             `json:"recycle_status" validate:"required"`
+}
+
+// Validate UpdateDiskSnapshotRecycleStatusReq.
+func (req *UpdateDiskSnapshotRecycleStatusReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// ListDiskSnapshotResult list disk snapshot result.
+type ListDiskSnapshotResult = core.ListResultT[coresnapshot.DiskSnapshot]
+
+// -------------------------- Delete --------------------------
+
+// DeleteDiskSnapshotReq disk snapshot delete request.
+type DeleteDiskSnapshotReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate DeleteDiskSnapshotReq.
+func (req *DeleteDiskSnapshotReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
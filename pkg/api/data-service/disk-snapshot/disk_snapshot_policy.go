@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// BatchCreateDiskSnapshotPolicyReq batch create disk snapshot policy request.
+type BatchCreateDiskSnapshotPolicyReq struct {
+	Items []DiskSnapshotPolicyCreate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchCreateDiskSnapshotPolicyReq.
+func (req *BatchCreateDiskSnapshotPolicyReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, item := range req.Items {
+		if err := ValidateSchedule(item.Hours, item.WeekDays); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotPolicyCreate disk snapshot policy create field.
+type DiskSnapshotPolicyCreate struct {
+	Vendor        enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID     string        `json:"account_id" validate:"required"`
+	BkBizID       int64         `json:"bk_biz_id"`
+	Name          string        `json:"name" validate:"required,max=255"`
+	Hours         []int         `json:"hours" validate:"required,min=1"`
+	WeekDays      []int         `json:"week_days"`
+	RetentionDays uint64        `json:"retention_days"`
+	DiskIDs       []string      `json:"disk_ids"`
+	Memo          *string       `json:"memo"`
+}
+
+// -------------------------- Update --------------------------
+
+// BatchUpdateDiskSnapshotPolicyReq batch update disk snapshot policy request.
+type BatchUpdateDiskSnapshotPolicyReq struct {
+	Items []DiskSnapshotPolicyUpdate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchUpdateDiskSnapshotPolicyReq.
+func (req *BatchUpdateDiskSnapshotPolicyReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, item := range req.Items {
+		if item.Hours != nil && len(item.Hours) == 0 {
+			return errors.New("hours can not be empty")
+		}
+		if err := ValidateSchedule(item.Hours, item.WeekDays); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotPolicyUpdate disk snapshot policy update field, nil field will not be updated.
+type DiskSnapshotPolicyUpdate struct {
+	ID            string   `json:"id" validate:"required"`
+	Name          string   `json:"name" validate:"omitempty,max=255"`
+	Hours         []int    `json:"hours"`
+	WeekDays      []int    `json:"week_days"`
+	RetentionDays *uint64  `json:"retention_days"`
+	DiskIDs       []string `json:"disk_ids"`
+	Memo          *string  `json:"memo"`
+}
+
+// ValidateSchedule 校验定期快照策略执行时间的取值范围
+func ValidateSchedule(hours []int, weekDays []int) error {
+	for _, hour := range hours {
+		if hour < 0 || hour > 23 {
+			return fmt.Errorf("hour %d is invalid, should be in [0, 23]", hour)
+		}
+	}
+
+	for _, day := range weekDays {
+		if day < 0 || day > 6 {
+			return fmt.Errorf("week day %d is invalid, should be in [0, 6]", day)
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// ListDiskSnapshotPolicyResult list disk snapshot policy result.
+type ListDiskSnapshotPolicyResult = core.ListResultT[coresnapshot.DiskSnapshotPolicy]
+
+// -------------------------- Delete --------------------------
+
+// DeleteDiskSnapshotPolicyReq disk snapshot policy delete request.
+type DeleteDiskSnapshotPolicyReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate DeleteDiskSnapshotPolicyReq.
+func (req *DeleteDiskSnapshotPolicyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency ...
+package idempotency
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Acquire --------------------------
+
+// AcquireReq 获取幂等键，幂等键未被使用、已过期或占用的请求处理超时时由当前请求占用
+type AcquireReq struct {
+	// Scope 幂等键作用域，由服务名、应用编码及用户组成
+	Scope       string `json:"scope" validate:"required,max=255"`
+	Key         string `json:"key" validate:"required,max=128"`
+	RequestHash string `json:"request_hash" validate:"required,len=64"`
+	// TTLSec 幂等记录保留时间，单位：秒
+	TTLSec uint64 `json:"ttl_sec" validate:"required,min=1"`
+	// LockTimeoutSec 请求处理超时时间，单位：秒
+	LockTimeoutSec uint64 `json:"lock_timeout_sec" validate:"required,min=1"`
+}
+
+// Validate AcquireReq.
+func (req *AcquireReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AcquireResult 获取幂等键的结果，获取失败时返回占用幂等键的请求记录
+type AcquireResult struct {
+	Acquired bool    `json:"acquired"`
+	Record   *Record `json:"record,omitempty"`
+}
+
+// Record 幂等请求记录
+type Record struct {
+	RequestHash string                  `json:"request_hash"`
+	State       enumor.IdempotencyState `json:"state"`
+	// Response 请求成功时的响应数据，仅 completed 状态有值
+	Response  string `json:"response"`
+	ExpiredAt string `json:"expired_at"`
+}
+
+// -------------------------- Complete --------------------------
+
+// CompleteReq 记录占用幂等键的请求的响应数据
+type CompleteReq struct {
+	Scope       string `json:"scope" validate:"required,max=255"`
+	Key         string `json:"key" validate:"required,max=128"`
+	RequestHash string `json:"request_hash" validate:"required,len=64"`
+	Response    string `json:"response"`
+}
+
+// Validate CompleteReq.
+func (req *CompleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Release --------------------------
+
+// ReleaseReq 释放请求失败时占用的幂等键，以便使用相同的幂等键重试
+type ReleaseReq struct {
+	Scope       string `json:"scope" validate:"required,max=255"`
+	Key         string `json:"key" validate:"required,max=128"`
+	RequestHash string `json:"request_hash" validate:"required,len=64"`
+}
+
+// Validate ReleaseReq.
+func (req *ReleaseReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete --------------------------
+
+// DeleteExpiredReq 删除所有租户过期的幂等请求记录
+type DeleteExpiredReq struct {
+	// Limit 单次最多删除的数量
+	Limit uint `json:"limit" validate:"required,min=1,max=10000"`
+}
+
+// Validate DeleteExpiredReq.
+func (req *DeleteExpiredReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeleteExpiredResult 删除过期幂等请求记录的结果
+type DeleteExpiredResult struct {
+	Deleted int64 `json:"deleted"`
+}
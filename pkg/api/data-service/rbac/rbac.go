@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac ...
+package rbac
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/validator"
+)
+
+// RoleCreate defines the role to create.
+type RoleCreate struct {
+	Name     string            `json:"name" validate:"required,lte=255"`
+	Policies []corerbac.Policy `json:"policies" validate:"required,min=1"`
+	Memo     *string           `json:"memo"`
+}
+
+// Validate RoleCreate.
+func (r *RoleCreate) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	return corerbac.ValidatePolicies(r.Policies)
+}
+
+// BatchCreateRoleReq defines batch create role request.
+type BatchCreateRoleReq struct {
+	Roles []RoleCreate `json:"roles" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateRoleReq.
+func (req *BatchCreateRoleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Roles {
+		if err := req.Roles[idx].Validate(); err != nil {
+			return fmt.Errorf("roles[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// RoleUpdate defines the role to update, only the set fields are updated.
+type RoleUpdate struct {
+	ID       string            `json:"id" validate:"required"`
+	Name     string            `json:"name" validate:"lte=255"`
+	Policies []corerbac.Policy `json:"policies"`
+	Memo     *string           `json:"memo"`
+}
+
+// BatchUpdateRoleReq defines batch update role request.
+type BatchUpdateRoleReq struct {
+	Roles []RoleUpdate `json:"roles" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchUpdateRoleReq.
+func (req *BatchUpdateRoleReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx, role := range req.Roles {
+		if role.Policies == nil {
+			continue
+		}
+
+		if err := corerbac.ValidatePolicies(role.Policies); err != nil {
+			return fmt.Errorf("roles[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ListRoleResult defines list role result.
+type ListRoleResult = core.ListResultT[corerbac.Role]
+
+// RoleBindingCreate defines the role binding to create.
+type RoleBindingCreate struct {
+	RoleID    string             `json:"role_id" validate:"required"`
+	User      string             `json:"user" validate:"required,lte=64"`
+	ScopeType corerbac.ScopeType `json:"scope_type" validate:"required"`
+	ScopeIDs  []string           `json:"scope_ids"`
+	Memo      *string            `json:"memo"`
+}
+
+// Validate RoleBindingCreate.
+func (r *RoleBindingCreate) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	return corerbac.ValidateScope(r.ScopeType, r.ScopeIDs)
+}
+
+// BatchCreateRoleBindingReq defines batch create role binding request.
+type BatchCreateRoleBindingReq struct {
+	Bindings []RoleBindingCreate `json:"bindings" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateRoleBindingReq.
+func (req *BatchCreateRoleBindingReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Bindings {
+		if err := req.Bindings[idx].Validate(); err != nil {
+			return fmt.Errorf("bindings[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// RoleBindingUpdate defines the role binding to update, scope type and scope ids are updated together.
+type RoleBindingUpdate struct {
+	ID        string             `json:"id" validate:"required"`
+	ScopeType corerbac.ScopeType `json:"scope_type"`
+	ScopeIDs  []string           `json:"scope_ids"`
+	Memo      *string            `json:"memo"`
+}
+
+// BatchUpdateRoleBindingReq defines batch update role binding request.
+type BatchUpdateRoleBindingReq struct {
+	Bindings []RoleBindingUpdate `json:"bindings" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchUpdateRoleBindingReq.
+func (req *BatchUpdateRoleBindingReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx, binding := range req.Bindings {
+		if len(binding.ScopeType) == 0 {
+			if len(binding.ScopeIDs) != 0 {
+				return fmt.Errorf("bindings[%d] is invalid, scope_type is required when scope_ids is set", idx)
+			}
+			continue
+		}
+
+		if err := corerbac.ValidateScope(binding.ScopeType, binding.ScopeIDs); err != nil {
+			return fmt.Errorf("bindings[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ListRoleBindingResult defines list role binding result.
+type ListRoleBindingResult = core.ListResultT[corerbac.RoleBinding]
+
+// BatchDeleteReq defines batch delete role or role binding request.
+type BatchDeleteReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, id := range req.IDs {
+		if len(id) == 0 {
+			return errors.New("id can not be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation ...
+package recommendation
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerecommend "hcm/pkg/api/core/recommendation"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// BatchCreateResRecommendationReq batch create res recommendation request.
+type BatchCreateResRecommendationReq struct {
+	Items []ResRecommendationCreate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchCreateResRecommendationReq.
+func (req *BatchCreateResRecommendationReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, item := range req.Items {
+		if err := item.RecType.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ResRecommendationCreate res recommendation create field.
+type ResRecommendationCreate struct {
+	Vendor        enumor.Vendor                  `json:"vendor" validate:"required"`
+	AccountID     string                         `json:"account_id" validate:"required"`
+	BkBizID       int64                          `json:"bk_biz_id"`
+	Region        string                         `json:"region"`
+	Zone          string                         `json:"zone"`
+	ResType       enumor.CloudResourceType       `json:"res_type" validate:"required"`
+	ResID         string                         `json:"res_id" validate:"required"`
+	CloudResID    string                         `json:"cloud_res_id" validate:"required"`
+	ResName       string                         `json:"res_name"`
+	RecType       enumor.RecommendType           `json:"rec_type" validate:"required"`
+	Detail        *corerecommend.RecommendDetail `json:"detail" validate:"required"`
+	MonthlySaving float64                        `json:"monthly_saving"`
+	Currency      string                         `json:"currency"`
+}
+
+// -------------------------- Update --------------------------
+
+// BatchUpdateResRecommendationReq batch update res recommendation request.
+type BatchUpdateResRecommendationReq struct {
+	Items []ResRecommendationUpdate `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchUpdateResRecommendationReq.
+func (req *BatchUpdateResRecommendationReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ResRecommendationUpdate res recommendation update field.
+type ResRecommendationUpdate struct {
+	ID     string                 `json:"id" validate:"required"`
+	Status enumor.RecommendStatus `json:"status" validate:"required"`
+}
+
+// -------------------------- List --------------------------
+
+// ListResRecommendationResult list res recommendation result.
+type ListResRecommendationResult = core.ListResultT[corerecommend.ResRecommendation]
+
+// -------------------------- Delete --------------------------
+
+// DeleteResRecommendationReq res recommendation delete request.
+type DeleteResRecommendationReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate DeleteResRecommendationReq.
+func (req *DeleteResRecommendationReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
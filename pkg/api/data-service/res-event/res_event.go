@@ -0,0 +1,176 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent ...
+package resevent
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/criteria/validator"
+)
+
+// SubscriptionCreate defines the event subscription to create.
+type SubscriptionCreate struct {
+	Name   string                       `json:"name" validate:"required,lte=64"`
+	URL    string                       `json:"url" validate:"required"`
+	Secret string                       `json:"secret" validate:"required"`
+	Filter coreevent.SubscriptionFilter `json:"filter"`
+	// Enabled 是否启用，为空时默认启用
+	Enabled *bool   `json:"enabled"`
+	Memo    *string `json:"memo"`
+}
+
+// Validate SubscriptionCreate.
+func (s *SubscriptionCreate) Validate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if err := coreevent.ValidateURL(s.URL); err != nil {
+		return err
+	}
+
+	if err := coreevent.ValidateSecret(s.Secret); err != nil {
+		return err
+	}
+
+	return s.Filter.Validate()
+}
+
+// BatchCreateSubscriptionReq defines batch create event subscription request.
+type BatchCreateSubscriptionReq struct {
+	Subscriptions []SubscriptionCreate `json:"subscriptions" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchCreateSubscriptionReq.
+func (req *BatchCreateSubscriptionReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Subscriptions {
+		if err := req.Subscriptions[idx].Validate(); err != nil {
+			return fmt.Errorf("subscriptions[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// SubscriptionUpdate defines the event subscription to update, only set fields will be updated.
+type SubscriptionUpdate struct {
+	ID     string                        `json:"id" validate:"required"`
+	Name   string                        `json:"name" validate:"omitempty,lte=64"`
+	URL    string                        `json:"url"`
+	Secret string                        `json:"secret"`
+	Filter *coreevent.SubscriptionFilter `json:"filter"`
+	// Enabled 为 false 时停用订阅，停用后不再为该订阅分发事件，未推送的记录不再推送
+	Enabled *bool   `json:"enabled"`
+	Memo    *string `json:"memo"`
+}
+
+// Validate SubscriptionUpdate.
+func (s *SubscriptionUpdate) Validate() error {
+	if len(s.URL) != 0 {
+		if err := coreevent.ValidateURL(s.URL); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Secret) != 0 {
+		if err := coreevent.ValidateSecret(s.Secret); err != nil {
+			return err
+		}
+	}
+
+	if s.Filter != nil {
+		return s.Filter.Validate()
+	}
+
+	return nil
+}
+
+// BatchUpdateSubscriptionReq defines batch update event subscription request.
+type BatchUpdateSubscriptionReq struct {
+	Subscriptions []SubscriptionUpdate `json:"subscriptions" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate BatchUpdateSubscriptionReq.
+func (req *BatchUpdateSubscriptionReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for idx := range req.Subscriptions {
+		if err := req.Subscriptions[idx].Validate(); err != nil {
+			return fmt.Errorf("subscriptions[%d] is invalid, err: %v", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// ListSubscriptionResult defines list event subscription result.
+type ListSubscriptionResult = core.ListResultT[coreevent.Subscription]
+
+// BatchDeleteReq defines batch delete event subscription request.
+type BatchDeleteReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate BatchDeleteReq.
+func (req *BatchDeleteReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, id := range req.IDs {
+		if len(id) == 0 {
+			return errors.New("id can not be empty")
+		}
+	}
+
+	return nil
+}
+
+// ListEventResult defines list res event result.
+type ListEventResult = core.ListResultT[coreevent.Event]
+
+// ListDeliveryResult defines list event delivery result.
+type ListDeliveryResult = core.ListResultT[coreevent.Delivery]
+
+// RetryDeliveryReq defines retry the failed event deliveries request.
+type RetryDeliveryReq struct {
+	IDs []uint64 `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate RetryDeliveryReq.
+func (req *RetryDeliveryReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RetryDeliveryResult defines retry the failed event deliveries result.
+type RetryDeliveryResult struct {
+	// Count 重置为待推送的记录数量，非失败状态的推送记录不会重试
+	Count int64 `json:"count"`
+}
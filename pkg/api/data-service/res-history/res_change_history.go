@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory ...
+package reshistory
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	corereshistory "hcm/pkg/api/core/res-history"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- List --------------------------
+
+// ListResChangeHistoryResult defines list result.
+type ListResChangeHistoryResult = core.ListResultT[corereshistory.ResChangeHistory]
+
+// -------------------------- Diff --------------------------
+
+// DiffResChangeHistoryReq 对比资源任意两个版本的快照
+type DiffResChangeHistoryReq struct {
+	ResType     enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID       string                   `json:"res_id" validate:"required"`
+	FromVersion uint64                   `json:"from_version" validate:"required,min=1"`
+	ToVersion   uint64                   `json:"to_version" validate:"required,min=1"`
+}
+
+// Validate DiffResChangeHistoryReq.
+func (req *DiffResChangeHistoryReq) Validate() error {
+	if !corereshistory.IsSupported(req.ResType) {
+		return fmt.Errorf("resource type %s does not support change history", req.ResType)
+	}
+
+	if req.FromVersion == req.ToVersion {
+		return errors.New("from_version and to_version should be different")
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DiffResChangeHistoryResult 两个版本的变更历史（不包含快照）及从 from_version 到 to_version 的字段级变更
+type DiffResChangeHistoryResult struct {
+	From *corereshistory.ResChangeHistory `json:"from"`
+	To   *corereshistory.ResChangeHistory `json:"to"`
+	Diff []corereshistory.FieldDiff       `json:"diff"`
+}
+
+// -------------------------- Delete --------------------------
+
+// DeleteExpiredResChangeHistoryReq 删除指定资源类型过期的变更历史
+type DeleteExpiredResChangeHistoryReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	// Before 删除该时间之前的变更历史，格式：2006-01-02 15:04:05
+	Before string `json:"before" validate:"required"`
+	// Limit 单次最多删除的数量
+	Limit uint `json:"limit" validate:"required,min=1,max=10000"`
+}
+
+// Validate DeleteExpiredResChangeHistoryReq.
+func (req *DeleteExpiredResChangeHistoryReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.DateTimeLayout, req.Before); err != nil {
+		return fmt.Errorf("invalid before: %s, should be in %s format", req.Before, constant.DateTimeLayout)
+	}
+
+	return nil
+}
+
+// DeleteExpiredResChangeHistoryResult 删除过期变更历史的结果
+type DeleteExpiredResChangeHistoryResult struct {
+	Deleted int64 `json:"deleted"`
+}
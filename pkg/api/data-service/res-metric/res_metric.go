@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric ...
+package resmetric
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreresmetric "hcm/pkg/api/core/res-metric"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Upsert --------------------------
+
+// BatchUpsertResMetricDailyReq 批量写入天级监控数据，相同资源、指标、日期的数据将被覆盖
+type BatchUpsertResMetricDailyReq struct {
+	Items []ResMetricDailyUpsert `json:"items" validate:"required,min=1,dive,required"`
+}
+
+// Validate BatchUpsertResMetricDailyReq.
+func (req *BatchUpsertResMetricDailyReq) Validate() error {
+	if len(req.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, item := range req.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ResMetricDailyUpsert 天级监控数据
+type ResMetricDailyUpsert struct {
+	Vendor      enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID   string                   `json:"account_id" validate:"required"`
+	Region      string                   `json:"region"`
+	ResType     enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID       string                   `json:"res_id" validate:"required"`
+	CloudResID  string                   `json:"cloud_res_id" validate:"required"`
+	Metric      string                   `json:"metric" validate:"required"`
+	StatDate    string                   `json:"stat_date" validate:"required,len=10"`
+	AvgValue    float64                  `json:"avg_value"`
+	MaxValue    float64                  `json:"max_value"`
+	MinValue    float64                  `json:"min_value"`
+	SampleCount int64                    `json:"sample_count"`
+}
+
+// Validate ResMetricDailyUpsert.
+func (req ResMetricDailyUpsert) Validate() error {
+	if req.MinValue > req.MaxValue {
+		return errors.New("min_value should <= max_value")
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// ListResMetricDailyResult defines list result.
+type ListResMetricDailyResult = core.ListResultT[coreresmetric.ResMetricDaily]
@@ -102,3 +102,32 @@ type AzureAccountCheckReq struct {
 func (r *AzureAccountCheckReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
+
+// AliyunAccountCheckReq ...
+type AliyunAccountCheckReq struct {
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+
+	CloudMainAccountID string `json:"cloud_main_account_id" validate:"required"`
+	CloudSubAccountID  string `json:"cloud_sub_account_id" validate:"required"`
+}
+
+// Validate ...
+func (r *AliyunAccountCheckReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// OpenStackAccountCheckReq ...
+type OpenStackAccountCheckReq struct {
+	CloudAuthURL   string `json:"cloud_auth_url" validate:"required"`
+	CloudRegion    string `json:"cloud_region" validate:"omitempty"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+
+	CloudProjectID string `json:"cloud_project_id" validate:"required"`
+}
+
+// Validate ...
+func (r *OpenStackAccountCheckReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
@@ -162,6 +162,20 @@ type BatchCreateResp struct {
 	Data          *BatchCreateResult `json:"data"`
 }
 
+// TCloudResetInstanceTypeReq defines options to change cvm instance type request.
+type TCloudResetInstanceTypeReq struct {
+	AccountID    string `json:"account_id" validate:"required"`
+	Region       string `json:"region" validate:"required"`
+	ID           string `json:"id" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+	ForceStop    bool   `json:"force_stop"`
+}
+
+// Validate request.
+func (req *TCloudResetInstanceTypeReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // TCloudBatchResetReq defines options to reset cvm request.
 type TCloudBatchResetReq struct {
 	AccountID string   `json:"account_id" validate:"required"`
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot ...
+package disksnapshot
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// DiskSnapshotCreateReq 创建云硬盘快照请求
+type DiskSnapshotCreateReq struct {
+	DiskID string `json:"disk_id" validate:"required"`
+	Name   string `json:"name" validate:"omitempty,max=255"`
+	// PolicyID 由定期快照策略创建时传入策略ID
+	PolicyID string `json:"policy_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotDeleteReq 删除云硬盘快照请求
+type DiskSnapshotDeleteReq struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotRollbackReq 使用快照回滚云硬盘请求
+type DiskSnapshotRollbackReq struct {
+	ID string `json:"id" validate:"required"`
+	// AutoStopInstance 回滚前自动关闭云硬盘挂载的主机并在回滚后开机，仅腾讯云支持
+	AutoStopInstance bool `json:"auto_stop_instance"`
+}
+
+// Validate ...
+func (req *DiskSnapshotRollbackReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotSyncReq 同步云硬盘快照请求
+type DiskSnapshotSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+}
+
+// Validate ...
+func (req *DiskSnapshotSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric ...
+package resmetric
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// CollectResMetricDailyReq 采集账号下资源指定日期的监控数据，并按天聚合后写入
+type CollectResMetricDailyReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// StatDate 统计日期，格式：2006-01-02
+	StatDate string `json:"stat_date" validate:"required"`
+}
+
+// Validate CollectResMetricDailyReq.
+func (req *CollectResMetricDailyReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.ParseInLocation(constant.DateLayout, req.StatDate, time.Local); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CollectResMetricDailyResult 监控数据采集结果
+type CollectResMetricDailyResult struct {
+	// Count 写入的天级聚合数据条数
+	Count int `json:"count"`
+}
@@ -110,6 +110,37 @@ func (req *HuaWeiSubnetSyncReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// AliyunGlobalSyncReq aliyun sync request
+type AliyunGlobalSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate aliyun sync request.
+func (req *AliyunGlobalSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AliyunSyncReq aliyun sync request
+type AliyunSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+}
+
+// Validate aliyun sync request.
+func (req *AliyunSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// OpenStackSyncReq openstack sync request, region is determined by account.
+type OpenStackSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate openstack sync request.
+func (req *OpenStackSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // GcpCvmSyncReq gcp sync request
 type GcpCvmSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
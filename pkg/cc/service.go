@@ -78,10 +78,12 @@ type Setting interface {
 
 // ApiServerSetting defines api server used setting options.
 type ApiServerSetting struct {
-	Network Network      `yaml:"network"`
-	Service Service      `yaml:"service"`
-	Log     LogOption    `yaml:"log"`
-	Tenant  TenantConfig `yaml:"tenant"`
+	Network     Network      `yaml:"network"`
+	Service     Service      `yaml:"service"`
+	Log         LogOption    `yaml:"log"`
+	Tenant      TenantConfig `yaml:"tenant"`
+	AccessToken AccessToken  `yaml:"accessToken"`
+	RateLimit   RateLimit    `yaml:"rateLimit"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -94,6 +96,8 @@ func (s *ApiServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.AccessToken.trySetDefault()
+	s.RateLimit.trySetDefault()
 
 	return
 }
@@ -109,6 +113,14 @@ func (s ApiServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.AccessToken.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -141,6 +153,17 @@ type CloudServerSetting struct {
 	Tenant         TenantConfig   `yaml:"tenant"`
 	Cmdb           ApiGateway     `yaml:"cmdb"`
 	CCHostPoolBiz  int64          `yaml:"ccHostPoolBiz"`
+	ResMetric      ResMetric      `yaml:"resMetric"`
+	Recommendation Recommendation `yaml:"recommendation"`
+	DiskSnapshot   DiskSnapshot   `yaml:"diskSnapshot"`
+
+	ResChangeHistory ResChangeHistory `yaml:"resChangeHistory"`
+	AuditArchive     AuditArchive     `yaml:"auditArchive"`
+	AuditCheckpoint  AuditCheckpoint  `yaml:"auditCheckpoint"`
+	Idempotency      Idempotency      `yaml:"idempotency"`
+	RateLimit        RateLimit        `yaml:"rateLimit"`
+
+	AuthDecisionCache AuthDecisionCache `yaml:"authDecisionCache"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -153,6 +176,9 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Idempotency.trySetDefault()
+	s.RateLimit.trySetDefault()
+	s.AuthDecisionCache.trySetDefault()
 
 	return
 }
@@ -199,6 +225,38 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.ResMetric.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Recommendation.validate(); err != nil {
+		return err
+	}
+
+	if err := s.ResChangeHistory.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuditArchive.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuditCheckpoint.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Idempotency.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuthDecisionCache.validate(); err != nil {
+		return err
+	}
+
 	if s.CCHostPoolBiz == 0 {
 		return fmt.Errorf("ccHostPoolBiz should not be empty")
 	}
@@ -221,6 +279,9 @@ type DataServiceSetting struct {
 	Crypto      Crypto       `yaml:"crypto"`
 	Cmdb        ApiGateway   `yaml:"cmdb"`
 	Tenant      TenantConfig `yaml:"tenant"`
+	AuditChain  AuditChain   `yaml:"auditChain"`
+	AuditStream AuditStream  `yaml:"auditStream"`
+	ResEvent    ResEvent     `yaml:"resEvent"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -234,6 +295,8 @@ func (s *DataServiceSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
 	s.Database.trySetDefault()
+	s.AuditStream.trySetDefault()
+	s.ResEvent.trySetDefault()
 
 	return
 }
@@ -260,6 +323,18 @@ func (s DataServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.AuditChain.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuditStream.validate(); err != nil {
+		return err
+	}
+
+	if err := s.ResEvent.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -277,6 +352,7 @@ type HCServiceSetting struct {
 	Tenant        TenantConfig `yaml:"tenant"`
 	Cmdb          ApiGateway   `yaml:"cmdb"`
 	CCHostPoolBiz int64        `yaml:"ccHostPoolBiz"`
+	Idempotency   Idempotency  `yaml:"idempotency"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -290,6 +366,7 @@ func (s *HCServiceSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
 	s.SyncConfig.trySetDefault()
+	s.Idempotency.trySetDefault()
 
 	return
 }
@@ -316,6 +393,10 @@ func (s HCServiceSetting) Validate() error {
 		return fmt.Errorf("ccHostPoolBiz should not be empty")
 	}
 
+	if err := s.Idempotency.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -346,6 +427,7 @@ func (s *AuthServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.IAM.trySetDefault()
 
 	return
 }
@@ -360,8 +442,11 @@ func (s AuthServerSetting) Validate() error {
 		return err
 	}
 
-	if err := s.Esb.validate(); err != nil {
-		return err
+	// esb is only used by blueking iam
+	if !s.IAM.IsLocal() {
+		if err := s.Esb.validate(); err != nil {
+			return err
+		}
 	}
 
 	if err := s.Cmdb.validate(); err != nil {
@@ -393,6 +478,7 @@ type WebServerSetting struct {
 	TemplatePath  string        `yaml:"templatePath"`
 	Tenant        TenantConfig  `yaml:"tenant"`
 	Cmdb          ApiGateway    `yaml:"cmdb"`
+	OIDC          OIDC          `yaml:"oidc"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -410,6 +496,12 @@ func (s *WebServerSetting) trySetDefault() {
 		s.TemplatePath = "template"
 	}
 
+	s.OIDC.trySetDefault()
+	// 开启OIDC登录时，前端登录地址使用OIDC登录接口
+	if s.OIDC.Enable {
+		s.Web.BkLoginUrl = s.OIDC.LoginURL()
+	}
+
 	return
 }
 
@@ -444,6 +536,10 @@ func (s WebServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.OIDC.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -24,11 +24,16 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/ssl"
@@ -215,11 +220,36 @@ type DataBase struct {
 	// Limiter defines request's to ORM's limitation for each sharding, and
 	// each sharding have the independent request limitation.
 	Limiter *Limiter `yaml:"limiter"`
+	// Replicas defines read replicas of resource database, orm select and count requests
+	// are routed to the healthy replicas by weight.
+	Replicas []ReplicaDB `yaml:"replicas"`
+	// ReplicaHealthCheckIntervalSec defines the interval in seconds to ping the replicas.
+	ReplicaHealthCheckIntervalSec uint `yaml:"replicaHealthCheckIntervalSec"`
+	// ReadAfterWriteStickySec defines the seconds that the read requests of a request id are
+	// routed to primary after it writes, to avoid reading stale data of replication lag.
+	ReadAfterWriteStickySec uint `yaml:"readAfterWriteStickySec"`
+	// IDGenerator defines how the unique ids of resource tables are generated.
+	IDGenerator IDGenerator `yaml:"idGenerator"`
+	// ResChangeHistory defines how the change histories of resources are recorded when they are changed.
+	ResChangeHistory ResChangeHistoryRecord `yaml:"resChangeHistory"`
 }
 
 // trySetDefault set the sharding default value if user not configured.
 func (s *DataBase) trySetDefault() {
 	s.Resource.trySetDefault()
+	s.IDGenerator.trySetDefault()
+
+	for i := range s.Replicas {
+		s.Replicas[i].trySetDefault(s.Resource)
+	}
+
+	if s.ReplicaHealthCheckIntervalSec == 0 {
+		s.ReplicaHealthCheckIntervalSec = 5
+	}
+
+	if s.ReadAfterWriteStickySec == 0 {
+		s.ReadAfterWriteStickySec = 5
+	}
 
 	if s.MaxSlowLogLatencyMS == 0 {
 		s.MaxSlowLogLatencyMS = 100
@@ -248,9 +278,186 @@ func (s DataBase) validate() error {
 		}
 	}
 
+	for idx, one := range s.Replicas {
+		if err := one.validate(); err != nil {
+			return fmt.Errorf("database replicas[%d] is invalid, %v", idx, err)
+		}
+	}
+
+	if err := s.IDGenerator.validate(); err != nil {
+		return err
+	}
+
+	if err := s.ResChangeHistory.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// IDGenMode is the mode of id generator.
+type IDGenMode string
+
+const (
+	// TableIDGenMode locks the resource's row of id_generator table to allocate ids for each request.
+	TableIDGenMode IDGenMode = "table"
+	// SegmentIDGenMode leases a segment of ids from id_generator table at once and caches them in memory of each
+	// process, ids are unique and have the same format as table mode, but are only increasing within a process.
+	// resources paginated by id cursor(cvm, account_bill_item) always use table mode.
+	SegmentIDGenMode IDGenMode = "segment"
+)
+
+// maxIDGenSegmentSize is the max count of ids leased at once in segment mode.
+const maxIDGenSegmentSize = 100000
+
+// IDGenerator defines the id generator related runtime.
+type IDGenerator struct {
+	// Mode is the default mode of all resources, default is table.
+	Mode IDGenMode `yaml:"mode"`
+	// SegmentSize is the default count of ids leased at once in segment mode, default is 1000.
+	SegmentSize uint `yaml:"segmentSize"`
+	// Resources defines the id generator options of specified resource tables, which override the default options.
+	Resources map[string]IDGeneratorResource `yaml:"resources"`
+}
+
+// IDGeneratorResource defines the id generator options of a resource table.
+type IDGeneratorResource struct {
+	Mode        IDGenMode `yaml:"mode"`
+	SegmentSize uint      `yaml:"segmentSize"`
+}
+
+func (g *IDGenerator) trySetDefault() {
+	if len(g.Mode) == 0 {
+		g.Mode = TableIDGenMode
+	}
+
+	if g.SegmentSize == 0 {
+		g.SegmentSize = 1000
+	}
+
+	for name, one := range g.Resources {
+		if len(one.Mode) == 0 {
+			one.Mode = g.Mode
+		}
+
+		if one.SegmentSize == 0 {
+			one.SegmentSize = g.SegmentSize
+		}
+		g.Resources[name] = one
+	}
+}
+
+func (g IDGenerator) validate() error {
+	validate := func(mode IDGenMode, segmentSize uint) error {
+		switch mode {
+		case TableIDGenMode, SegmentIDGenMode:
+		default:
+			return fmt.Errorf("unsupported mode: %s", mode)
+		}
+
+		if segmentSize > maxIDGenSegmentSize {
+			return fmt.Errorf("segmentSize should <= %d", maxIDGenSegmentSize)
+		}
+
+		return nil
+	}
+
+	if err := validate(g.Mode, g.SegmentSize); err != nil {
+		return fmt.Errorf("idGenerator is invalid, %v", err)
+	}
+
+	for name, one := range g.Resources {
+		if err := validate(one.Mode, one.SegmentSize); err != nil {
+			return fmt.Errorf("idGenerator.resources[%s] is invalid, %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ResChangeHistoryRecord defines how the change histories of resources are recorded.
+type ResChangeHistoryRecord struct {
+	// Resources defines the record options of specified resource types, resource types not configured are recorded
+	// with all fields except reviser, updated_at and sync_time.
+	Resources map[enumor.CloudResourceType]ResChangeHistoryResource `yaml:"resources"`
+}
+
+// ResChangeHistoryResource defines the record options of a resource type.
+type ResChangeHistoryResource struct {
+	// Disable the change history of the resource type is not recorded.
+	Disable bool `yaml:"disable"`
+	// IgnoredFields are the fields not recorded in the snapshot, changes of them do not produce new versions.
+	IgnoredFields []string `yaml:"ignoredFields"`
+}
+
+func (r ResChangeHistoryRecord) validate() error {
+	for resType, one := range r.Resources {
+		for _, field := range one.IgnoredFields {
+			if len(field) == 0 {
+				return fmt.Errorf("resChangeHistory.resources[%s].ignoredFields has empty field", resType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Of returns the record options of the resource type.
+func (r ResChangeHistoryRecord) Of(resType enumor.CloudResourceType) ResChangeHistoryResource {
+	return r.Resources[resType]
+}
+
+// ModeOf returns the id generator mode and segment size of the resource.
+func (g IDGenerator) ModeOf(resource string) (IDGenMode, uint) {
+	if one, exists := g.Resources[resource]; exists {
+		return one.Mode, one.SegmentSize
+	}
+
+	return g.Mode, g.SegmentSize
+}
+
+// ReplicaDB defines read replica database related runtime.
+type ReplicaDB struct {
+	ResourceDB `yaml:",inline"`
+	// Weight is the relative weight to select this replica, default is 1.
+	Weight uint `yaml:"weight"`
+}
+
+// trySetDefault set the replica's default value if user not configured, the database, user
+// and password are inherited from primary.
+func (r *ReplicaDB) trySetDefault(primary ResourceDB) {
+	if len(r.Database) == 0 {
+		r.Database = primary.Database
+	}
+
+	if len(r.User) == 0 {
+		r.User = primary.User
+		r.Password = primary.Password
+	}
+
+	if len(r.TimeZone) == 0 {
+		r.TimeZone = primary.TimeZone
+	}
+
+	// endpoints of replica has no default value, it is checked in validate.
+	if len(r.Endpoints) != 0 {
+		r.ResourceDB.trySetDefault()
+	}
+
+	if r.Weight == 0 {
+		r.Weight = 1
+	}
+}
+
+// validate replica runtime.
+func (r ReplicaDB) validate() error {
+	if len(r.Endpoints) == 0 {
+		return errors.New("replica endpoints is not set")
+	}
+
+	return r.ResourceDB.validate()
+}
+
 // ResourceDB defines database related runtime.
 type ResourceDB struct {
 	// Endpoints is a seed list of host:port addresses of database nodes.
@@ -493,6 +700,8 @@ func (s SysOption) CheckV() {
 
 // IAM defines all the iam related runtime.
 type IAM struct {
+	// Mode is the authorization mode, default is iam.
+	Mode AuthMode `yaml:"mode"`
 	// Endpoints is a seed list of host:port addresses of iam nodes.
 	Endpoints []string `yaml:"endpoints"`
 	// AppCode blueking belong to hcm's appcode.
@@ -500,10 +709,51 @@ type IAM struct {
 	// AppSecret blueking belong to hcm app's secret.
 	AppSecret string    `yaml:"appSecret"`
 	TLS       TLSConfig `yaml:"tls"`
+	// Local defines the local authorizer options, only used in local mode.
+	Local LocalAuth `yaml:"local"`
+}
+
+// AuthMode defines the authorization mode.
+type AuthMode string
+
+const (
+	// IAMAuthMode authorize by blueking iam.
+	IAMAuthMode AuthMode = "iam"
+	// LocalAuthMode authorize by the roles and role bindings stored in hcm's own database.
+	LocalAuthMode AuthMode = "local"
+)
+
+// LocalAuth defines the local authorizer options.
+type LocalAuth struct {
+	// Admins are the users who have all the permissions, they are used to initialize roles and role bindings.
+	Admins []string `yaml:"admins"`
+}
+
+// trySetDefault set the iam default value if user not configured.
+func (s *IAM) trySetDefault() {
+	if len(s.Mode) == 0 {
+		s.Mode = IAMAuthMode
+	}
+}
+
+// IsLocal returns whether the authorization is done by the local authorizer.
+func (s IAM) IsLocal() bool {
+	return s.Mode == LocalAuthMode
 }
 
 // validate iam runtime.
 func (s IAM) validate() error {
+	switch s.Mode {
+	case IAMAuthMode:
+	case LocalAuthMode:
+		if len(s.Local.Admins) == 0 {
+			return errors.New("iam local admins is not set")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported iam mode: %s", s.Mode)
+	}
+
 	if len(s.Endpoints) == 0 {
 		return errors.New("iam endpoints is not set")
 	}
@@ -558,6 +808,111 @@ func (s Web) validate() error {
 	return nil
 }
 
+// OIDC defines the openid connect single sign-on related runtime.
+type OIDC struct {
+	// Enable 是否开启OIDC登录，开启后替代蓝鲸登录
+	Enable bool `yaml:"enable"`
+	// Issuer IdP的issuer地址，用于发现OIDC配置（issuer + /.well-known/openid-configuration）
+	Issuer       string `yaml:"issuer"`
+	ClientID     string `yaml:"clientID"`
+	ClientSecret string `yaml:"clientSecret"`
+	// RedirectURL 登录回调地址，需为hcm web的外部访问地址 + /login/oidc/callback，需在IdP中注册
+	RedirectURL string `yaml:"redirectURL"`
+	// PostLogoutRedirectURL 登出后IdP跳转回的地址，默认为hcm web的外部访问地址
+	PostLogoutRedirectURL string   `yaml:"postLogoutRedirectURL"`
+	Scopes                []string `yaml:"scopes"`
+	// UsernameClaim 用作hcm用户名的ID Token声明
+	UsernameClaim string `yaml:"usernameClaim"`
+	// DepartmentClaim 用作用户部门的ID Token声明，为空时不映射部门
+	DepartmentClaim string `yaml:"departmentClaim"`
+	// SessionCookieName 登录会话cookie名称
+	SessionCookieName string `yaml:"sessionCookieName"`
+	// SessionSecret 登录会话cookie的签名密钥
+	SessionSecret string `yaml:"sessionSecret"`
+	// SessionTTLSec 登录会话有效期
+	SessionTTLSec uint `yaml:"sessionTTLSec"`
+	// TLS 请求IdP的tls配置
+	TLS TLSConfig `yaml:"tls"`
+}
+
+const (
+	// LoginPath is the path of oidc login api.
+	LoginPath = "/login/oidc"
+	// CallbackPath is the path of oidc login callback api.
+	CallbackPath = LoginPath + "/callback"
+)
+
+// LoginURL returns the oidc login url, which is on the same host as the redirect url.
+func (o OIDC) LoginURL() string {
+	u, err := url.Parse(o.RedirectURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host + LoginPath
+}
+
+func (o *OIDC) trySetDefault() {
+	if !o.Enable {
+		return
+	}
+
+	if len(o.Scopes) == 0 {
+		o.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	if len(o.UsernameClaim) == 0 {
+		o.UsernameClaim = "preferred_username"
+	}
+
+	if len(o.SessionCookieName) == 0 {
+		o.SessionCookieName = "hcm_session"
+	}
+
+	if o.SessionTTLSec == 0 {
+		o.SessionTTLSec = 8 * 3600
+	}
+
+	if len(o.PostLogoutRedirectURL) == 0 {
+		if u, err := url.Parse(o.RedirectURL); err == nil {
+			o.PostLogoutRedirectURL = u.Scheme + "://" + u.Host + "/"
+		}
+	}
+}
+
+func (o OIDC) validate() error {
+	if !o.Enable {
+		return nil
+	}
+
+	if len(o.Issuer) == 0 {
+		return errors.New("oidc issuer is not set")
+	}
+
+	if len(o.ClientID) == 0 {
+		return errors.New("oidc client id is not set")
+	}
+
+	u, err := url.Parse(o.RedirectURL)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return errors.New("oidc redirect url should be an absolute url")
+	}
+
+	if u.Path != CallbackPath {
+		return fmt.Errorf("oidc redirect url path should be %s", CallbackPath)
+	}
+
+	if len(o.SessionSecret) < 32 {
+		return errors.New("oidc session secret should be at least 32 characters")
+	}
+
+	if err := o.TLS.validate(); err != nil {
+		return fmt.Errorf("validate oidc tls failed, err: %v", err)
+	}
+
+	return nil
+}
+
 // Esb defines the esb related runtime.
 type Esb struct {
 	// Endpoints is a seed list of host:port addresses of esb nodes.
@@ -680,6 +1035,373 @@ func (c BillConfig) validate() error {
 	return nil
 }
 
+// ResMetric 资源监控数据采集配置
+type ResMetric struct {
+	Enable bool `yaml:"enable"`
+	// CollectIntervalMin 采集周期，每个周期采集前一天的监控数据并按天聚合，单位：分钟
+	CollectIntervalMin uint64 `yaml:"collectIntervalMin"`
+}
+
+func (c ResMetric) validate() error {
+	if c.Enable && c.CollectIntervalMin < 60 {
+		return errors.New("ResMetric.CollectIntervalMin must >= 60")
+	}
+
+	return nil
+}
+
+// Recommendation 资源优化建议配置
+type Recommendation struct {
+	Enable bool `yaml:"enable"`
+	// ScanIntervalMin 扫描周期，单位：分钟
+	ScanIntervalMin uint64 `yaml:"scanIntervalMin"`
+	// StoppedCvmDays 主机关机超过该天数则建议回收
+	StoppedCvmDays uint `yaml:"stoppedCvmDays"`
+	// ObserveDays 评估主机规格时使用的监控数据天数
+	ObserveDays uint `yaml:"observeDays"`
+	// CpuUsageThreshold 观察期内CPU利用率峰值低于该值则建议降配，单位：%
+	CpuUsageThreshold float64 `yaml:"cpuUsageThreshold"`
+	// MemUsageThreshold 观察期内内存利用率峰值低于该值则建议降配，单位：%
+	MemUsageThreshold float64 `yaml:"memUsageThreshold"`
+}
+
+func (c Recommendation) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.ScanIntervalMin < 60 {
+		return errors.New("Recommendation.ScanIntervalMin must >= 60")
+	}
+
+	if c.StoppedCvmDays == 0 {
+		return errors.New("Recommendation.StoppedCvmDays must > 0")
+	}
+
+	if c.ObserveDays == 0 || c.ObserveDays > 90 {
+		return errors.New("Recommendation.ObserveDays must between 1 and 90")
+	}
+
+	if c.CpuUsageThreshold <= 0 || c.CpuUsageThreshold > 100 {
+		return errors.New("Recommendation.CpuUsageThreshold must between 0 and 100")
+	}
+
+	if c.MemUsageThreshold <= 0 || c.MemUsageThreshold > 100 {
+		return errors.New("Recommendation.MemUsageThreshold must between 0 and 100")
+	}
+
+	return nil
+}
+
+// DiskSnapshot 云硬盘快照配置
+type DiskSnapshot struct {
+	// EnablePolicy 是否每小时整点执行定期快照策略
+	EnablePolicy bool `yaml:"enablePolicy"`
+}
+
+// ResChangeHistory 资源变更历史配置
+type ResChangeHistory struct {
+	// EnableClean 是否定期清理超过保留天数的变更历史
+	EnableClean bool `yaml:"enableClean"`
+	// CleanIntervalMin 清理周期，单位：分钟
+	CleanIntervalMin uint64 `yaml:"cleanIntervalMin"`
+	// DefaultRetentionDays 变更历史默认保留天数
+	DefaultRetentionDays uint `yaml:"defaultRetentionDays"`
+	// RetentionDays 各资源类型的变更历史保留天数，未配置的资源类型使用默认保留天数
+	RetentionDays map[enumor.CloudResourceType]uint `yaml:"retentionDays"`
+}
+
+func (c ResChangeHistory) validate() error {
+	if !c.EnableClean {
+		return nil
+	}
+
+	if c.CleanIntervalMin < 60 {
+		return errors.New("ResChangeHistory.CleanIntervalMin must >= 60")
+	}
+
+	if c.DefaultRetentionDays == 0 {
+		return errors.New("ResChangeHistory.DefaultRetentionDays must > 0")
+	}
+
+	for resType, days := range c.RetentionDays {
+		if days == 0 {
+			return fmt.Errorf("ResChangeHistory.RetentionDays of %s must > 0", resType)
+		}
+	}
+
+	return nil
+}
+
+// GetRetentionDays 获取资源类型的变更历史保留天数
+func (c ResChangeHistory) GetRetentionDays(resType enumor.CloudResourceType) uint {
+	if days, exists := c.RetentionDays[resType]; exists {
+		return days
+	}
+
+	return c.DefaultRetentionDays
+}
+
+// AuditArchive 审计归档配置，归档依赖 data-service 配置对象存储
+type AuditArchive struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 归档周期，单位：分钟
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// RetentionDays 审计在审计表中的保留天数，超过该天数的审计将被归档到对象存储，并从审计表中删除
+	RetentionDays uint `yaml:"retentionDays"`
+	// BatchSize 单次归档的审计数量
+	BatchSize uint `yaml:"batchSize"`
+}
+
+func (c AuditArchive) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.IntervalMin < 60 {
+		return errors.New("AuditArchive.IntervalMin must >= 60")
+	}
+
+	if c.RetentionDays == 0 {
+		return errors.New("AuditArchive.RetentionDays must > 0")
+	}
+
+	if c.BatchSize == 0 || c.BatchSize > 5000 {
+		return errors.New("AuditArchive.BatchSize must between 1 and 5000")
+	}
+
+	return nil
+}
+
+// AuditChain 审计哈希链配置。同一租户的审计写入时会对该租户的哈希链头加锁直到事务结束，所以同一租户的审计写入是串行的，
+// 单个租户的审计写入吞吐约为 1 / 写入审计的事务平均耗时，长事务(如资源同步)会阻塞该租户其他请求的审计写入
+type AuditChain struct {
+	// CheckpointSignKey 哈希链检查点的签名密钥（HMAC-SHA256），为空时不允许生成检查点，校验时跳过签名校验
+	CheckpointSignKey string `yaml:"checkpointSignKey"`
+}
+
+func (c AuditChain) validate() error {
+	if len(c.CheckpointSignKey) != 0 && len(c.CheckpointSignKey) < 16 {
+		return errors.New("AuditChain.CheckpointSignKey length must >= 16")
+	}
+
+	return nil
+}
+
+// AuditStream 审计推送配置，审计写入时在同一事务内写入发件箱，由后台按顺序推送到所有外部SIEM，
+// 全部推送成功后删除，推送失败时整批重试，即至少推送一次，SIEM 可按租户ID及哈希链序号去重
+type AuditStream struct {
+	Enable bool `yaml:"enable"`
+	// IntervalSec 推送周期，也是推送失败后重试的基础间隔，单位：秒
+	IntervalSec uint64 `yaml:"intervalSec"`
+	// BatchSize 单次推送的审计数量
+	BatchSize uint `yaml:"batchSize"`
+	// MaxRetry 推送失败的最大重试次数，超过后不再自动重试，可通过控制工具重置后重新推送
+	MaxRetry uint               `yaml:"maxRetry"`
+	Webhooks []AuditWebhookSink `yaml:"webhooks"`
+	Syslogs  []AuditSyslogSink  `yaml:"syslogs"`
+	Kafkas   []AuditKafkaSink   `yaml:"kafkas"`
+}
+
+func (s *AuditStream) trySetDefault() {
+	if s.IntervalSec == 0 {
+		s.IntervalSec = 5
+	}
+
+	if s.BatchSize == 0 {
+		s.BatchSize = 100
+	}
+
+	if s.MaxRetry == 0 {
+		s.MaxRetry = 20
+	}
+
+	for i := range s.Syslogs {
+		if len(s.Syslogs[i].AppName) == 0 {
+			s.Syslogs[i].AppName = "hcm"
+		}
+
+		if s.Syslogs[i].Facility == 0 {
+			// 13: log audit
+			s.Syslogs[i].Facility = 13
+		}
+	}
+}
+
+func (s AuditStream) validate() error {
+	if !s.Enable {
+		return nil
+	}
+
+	if s.BatchSize > 500 {
+		return errors.New("AuditStream.BatchSize must <= 500")
+	}
+
+	if len(s.Webhooks)+len(s.Syslogs)+len(s.Kafkas) == 0 {
+		return errors.New("AuditStream has no sink configured")
+	}
+
+	names := make(map[string]struct{})
+	checkName := func(name string) error {
+		if len(name) == 0 {
+			return errors.New("AuditStream sink name is not set")
+		}
+
+		if _, exists := names[name]; exists {
+			return fmt.Errorf("AuditStream sink name %s is duplicated", name)
+		}
+		names[name] = struct{}{}
+		return nil
+	}
+
+	for _, one := range s.Webhooks {
+		if err := checkName(one.Name); err != nil {
+			return err
+		}
+
+		if len(one.URL) == 0 {
+			return fmt.Errorf("AuditStream webhook %s url is not set", one.Name)
+		}
+	}
+
+	for _, one := range s.Syslogs {
+		if err := checkName(one.Name); err != nil {
+			return err
+		}
+
+		if len(one.Address) == 0 {
+			return fmt.Errorf("AuditStream syslog %s address is not set", one.Name)
+		}
+
+		if one.Facility > 23 {
+			return fmt.Errorf("AuditStream syslog %s facility must between 0 and 23", one.Name)
+		}
+	}
+
+	for _, one := range s.Kafkas {
+		if err := checkName(one.Name); err != nil {
+			return err
+		}
+
+		if len(one.Brokers) == 0 || len(one.Topic) == 0 {
+			return fmt.Errorf("AuditStream kafka %s brokers and topic must be set", one.Name)
+		}
+	}
+
+	return nil
+}
+
+// AuditWebhookSink 审计推送的 HTTP webhook，请求体使用 HMAC-SHA256 签名
+type AuditWebhookSink struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Secret 请求签名的密钥，为空时不签名
+	Secret     string    `yaml:"secret"`
+	TimeoutSec uint      `yaml:"timeoutSec"`
+	TLS        TLSConfig `yaml:"tls"`
+}
+
+// AuditSyslogSink 审计推送的 RFC5424 syslog，通过 TCP 或 TLS 发送
+type AuditSyslogSink struct {
+	Name string `yaml:"name"`
+	// Address syslog 服务地址，格式：host:port
+	Address string `yaml:"address"`
+	// EnableTLS 是否使用 TLS 连接
+	EnableTLS bool      `yaml:"enableTLS"`
+	TLS       TLSConfig `yaml:"tls"`
+	// Facility syslog facility，默认为 13（log audit）
+	Facility   uint8  `yaml:"facility"`
+	AppName    string `yaml:"appName"`
+	TimeoutSec uint   `yaml:"timeoutSec"`
+}
+
+// AuditKafkaSink 审计推送的 Kafka 兼容消息队列，需要注册 Kafka 生产者实现
+type AuditKafkaSink struct {
+	Name    string   `yaml:"name"`
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// ResEvent 资源变更事件推送配置，资源变更历史写入时在同一事务内写入事件，由后台分发到匹配的订阅并推送，
+// 推送失败时按指数退避重试，即至少推送一次，订阅方可按推送ID去重。
+// 只有支持变更历史的资源类型(主机、安全组及其规则、VPC、子网、负载均衡、硬盘、弹性IP)会产生事件
+type ResEvent struct {
+	Enable bool `yaml:"enable"`
+	// IntervalSec 分发、推送周期，也是推送失败后重试的基础间隔，单位：秒
+	IntervalSec uint64 `yaml:"intervalSec"`
+	// BatchSize 单次分发的事件数量及单次推送的推送记录数量
+	BatchSize uint `yaml:"batchSize"`
+	// MaxRetry 推送失败的最大重试次数，超过后不再自动重试，可通过接口手动重试
+	MaxRetry uint `yaml:"maxRetry"`
+	// TimeoutSec 推送请求的超时时间，单位：秒
+	TimeoutSec uint `yaml:"timeoutSec"`
+	// RetentionDays 已分发的事件及已结束的推送记录的保留天数
+	RetentionDays uint `yaml:"retentionDays"`
+	// TLS 推送请求的 TLS 配置，用于订阅地址使用自签名证书等场景
+	TLS TLSConfig `yaml:"tls"`
+	// AllowedCIDRs 允许推送的内网网段，订阅地址解析到私有、回环、链路本地等地址时默认不允许推送
+	AllowedCIDRs []string `yaml:"allowedCIDRs"`
+}
+
+func (e *ResEvent) trySetDefault() {
+	if e.IntervalSec == 0 {
+		e.IntervalSec = 5
+	}
+
+	if e.BatchSize == 0 {
+		e.BatchSize = 100
+	}
+
+	if e.MaxRetry == 0 {
+		e.MaxRetry = 10
+	}
+
+	if e.TimeoutSec == 0 {
+		e.TimeoutSec = 10
+	}
+
+	if e.RetentionDays == 0 {
+		e.RetentionDays = 7
+	}
+}
+
+func (e ResEvent) validate() error {
+	if !e.Enable {
+		return nil
+	}
+
+	if e.BatchSize > 500 {
+		return errors.New("ResEvent.BatchSize must <= 500")
+	}
+
+	for _, cidr := range e.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("ResEvent.AllowedCIDRs %s is invalid, err: %v", cidr, err)
+		}
+	}
+
+	return nil
+}
+
+// AuditCheckpoint 审计哈希链检查点配置，检查点依赖 data-service 配置对象存储及签名密钥
+type AuditCheckpoint struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 生成检查点的周期，单位：分钟
+	IntervalMin uint64 `yaml:"intervalMin"`
+}
+
+func (c AuditCheckpoint) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.IntervalMin < 10 {
+		return errors.New("AuditCheckpoint.IntervalMin must >= 10")
+	}
+
+	return nil
+}
+
 // ApiGateway defines the api gateway config.
 type ApiGateway struct {
 	// Endpoints is a seed list of host:port addresses of api gateway.
@@ -1149,3 +1871,283 @@ func (r *SyncConcurrentRule) Validate() error {
 type TenantConfig struct {
 	Enabled bool `yaml:"enabled"`
 }
+
+// AccessToken 访问令牌认证配置，开启后api-server支持通过 Authorization: Bearer <token> 认证
+type AccessToken struct {
+	// Enable 是否开启访问令牌认证
+	Enable bool `yaml:"enable"`
+	// CacheTTLSec 令牌校验结果缓存时间，撤销令牌在该时间后生效，单位：秒
+	CacheTTLSec uint `yaml:"cacheTTLSec"`
+	// LastUsedUpdateIntervalSec 令牌最近使用时间更新间隔，单位：秒
+	LastUsedUpdateIntervalSec uint `yaml:"lastUsedUpdateIntervalSec"`
+}
+
+func (a *AccessToken) trySetDefault() {
+	if a.CacheTTLSec == 0 {
+		a.CacheTTLSec = 10
+	}
+
+	if a.LastUsedUpdateIntervalSec == 0 {
+		a.LastUsedUpdateIntervalSec = 60
+	}
+}
+
+func (a AccessToken) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	if a.CacheTTLSec > 300 {
+		return errors.New("accessToken.cacheTTLSec must <= 300")
+	}
+
+	return nil
+}
+
+// Idempotency 幂等请求配置，开启后携带 Idempotency-Key 请求头的POST请求只会被执行一次，重放请求直接返回首次请求的结果
+type Idempotency struct {
+	// Enable 是否开启幂等请求
+	Enable bool `yaml:"enable"`
+	// TTLSec 幂等记录保留时间，超过该时间后相同的幂等键可以重新使用，单位：秒
+	TTLSec uint64 `yaml:"ttlSec"`
+	// LockTimeoutSec 请求处理的超时时间，超过该时间仍未完成的请求标记为结果未知，幂等记录过期后才能重新使用，单位：秒
+	LockTimeoutSec uint64 `yaml:"lockTimeoutSec"`
+}
+
+func (i *Idempotency) trySetDefault() {
+	if i.TTLSec == 0 {
+		i.TTLSec = 86400
+	}
+
+	if i.LockTimeoutSec == 0 {
+		i.LockTimeoutSec = 600
+	}
+}
+
+func (i Idempotency) validate() error {
+	if !i.Enable {
+		return nil
+	}
+
+	if i.LockTimeoutSec > i.TTLSec {
+		return errors.New("idempotency.lockTimeoutSec must <= idempotency.ttlSec")
+	}
+
+	return nil
+}
+
+// RateLimit 请求限流配置，按用户、应用、租户维度使用令牌桶对请求限流，超出限制的请求返回 429
+type RateLimit struct {
+	// Enable 是否开启请求限流
+	Enable bool `yaml:"enable"`
+	// Default 未匹配任何路由分组的请求使用的限流规则
+	Default RateLimitRule `yaml:"default"`
+	// Groups 路由分组限流规则，按配置顺序匹配，请求使用第一个匹配的分组的限流规则
+	Groups []RateLimitGroup `yaml:"groups"`
+	// IdleTimeoutSec 令牌桶空闲超时时间，超过该时间未使用的令牌桶会被清理，单位：秒
+	IdleTimeoutSec uint `yaml:"idleTimeoutSec"`
+	// ExemptAppCodes 不限流的应用，用于 hcm 后台任务等内部调用方，未配置时默认为 hcm
+	ExemptAppCodes []string `yaml:"exemptAppCodes"`
+	// SharedAppCodes 多个用户共用的应用，这些应用的请求不按应用维度限流，只按用户、租户维度限流，
+	// 如 web-server 转发的所有浏览器请求均使用 hcm-web-server，未配置时默认为 hcm-web-server
+	SharedAppCodes []string `yaml:"sharedAppCodes"`
+}
+
+func (r *RateLimit) trySetDefault() {
+	if r.IdleTimeoutSec == 0 {
+		r.IdleTimeoutSec = 600
+	}
+
+	if r.ExemptAppCodes == nil {
+		r.ExemptAppCodes = []string{constant.BackendOperationAppCodeKey}
+	}
+
+	if r.SharedAppCodes == nil {
+		r.SharedAppCodes = []string{constant.WebSourceAppCode}
+	}
+
+	r.Default.trySetDefault()
+	for idx := range r.Groups {
+		r.Groups[idx].trySetDefault()
+	}
+}
+
+func (r RateLimit) validate() error {
+	if !r.Enable {
+		return nil
+	}
+
+	if err := r.Default.validate(); err != nil {
+		return fmt.Errorf("rateLimit.default %v", err)
+	}
+
+	names := make(map[string]struct{}, len(r.Groups))
+	for _, group := range r.Groups {
+		if err := group.validate(); err != nil {
+			return fmt.Errorf("rateLimit.groups %v", err)
+		}
+
+		if _, exists := names[group.Name]; exists {
+			return fmt.Errorf("rateLimit.groups name %s is duplicated", group.Name)
+		}
+		names[group.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// RateLimitGroup 路由分组限流规则
+type RateLimitGroup struct {
+	// Name 分组名称，用于限流指标的 group 标签
+	Name string `yaml:"name"`
+	// Paths 分组包含的请求路径的正则表达式，如 ^/api/v1/cloud/cvms/
+	Paths []string `yaml:"paths"`
+	// Methods 分组包含的请求方法，为空时匹配所有方法
+	Methods []string `yaml:"methods"`
+
+	RateLimitRule `yaml:",inline"`
+}
+
+func (g RateLimitGroup) validate() error {
+	if len(g.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if g.Name == DefaultRateLimitGroup {
+		return fmt.Errorf("name %s is reserved", DefaultRateLimitGroup)
+	}
+
+	if len(g.Paths) == 0 {
+		return fmt.Errorf("%s paths is required", g.Name)
+	}
+
+	for _, path := range g.Paths {
+		if _, err := regexp.Compile(path); err != nil {
+			return fmt.Errorf("%s path %s is invalid, err: %v", g.Name, path, err)
+		}
+	}
+
+	for _, method := range g.Methods {
+		switch strings.ToUpper(method) {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return fmt.Errorf("%s method %s is invalid", g.Name, method)
+		}
+	}
+
+	if err := g.RateLimitRule.validate(); err != nil {
+		return fmt.Errorf("%s %v", g.Name, err)
+	}
+
+	return nil
+}
+
+// DefaultRateLimitGroup is the group name of the requests that do not match any rate limit group.
+const DefaultRateLimitGroup = "default"
+
+// RateLimitRule 限流规则，每个维度使用独立的令牌桶，请求需要同时通过所有配置的维度的限流，未配置的维度不限流
+type RateLimitRule struct {
+	// User 每个用户的限流配额
+	User *RateLimitQuota `yaml:"user"`
+	// App 每个应用(app_code)的限流配额
+	App *RateLimitQuota `yaml:"app"`
+	// Tenant 每个租户的限流配额
+	Tenant *RateLimitQuota `yaml:"tenant"`
+}
+
+func (r *RateLimitRule) trySetDefault() {
+	for _, quota := range []*RateLimitQuota{r.User, r.App, r.Tenant} {
+		if quota != nil {
+			quota.trySetDefault()
+		}
+	}
+}
+
+func (r RateLimitRule) validate() error {
+	quotas := map[string]*RateLimitQuota{"user": r.User, "app": r.App, "tenant": r.Tenant}
+	for dimension, quota := range quotas {
+		if quota == nil {
+			continue
+		}
+
+		if err := quota.validate(); err != nil {
+			return fmt.Errorf("%s %v", dimension, err)
+		}
+	}
+
+	return nil
+}
+
+// RateLimitQuota 令牌桶限流配额
+type RateLimitQuota struct {
+	// QPS 令牌生成速率，即每秒允许的平均请求数
+	QPS float64 `yaml:"qps"`
+	// Burst 令牌桶容量，即允许的突发请求数，默认为 QPS 向上取整
+	Burst uint `yaml:"burst"`
+}
+
+func (q *RateLimitQuota) trySetDefault() {
+	if q.Burst == 0 {
+		q.Burst = uint(math.Ceil(q.QPS))
+	}
+}
+
+func (q RateLimitQuota) validate() error {
+	if q.QPS <= 0 {
+		return errors.New("qps should > 0")
+	}
+
+	if q.Burst == 0 {
+		return errors.New("burst should >= 1")
+	}
+
+	return nil
+}
+
+// AuthDecisionCache 鉴权结果缓存配置，开启后在有效期内相同用户对相同资源的相同操作的鉴权结果直接使用缓存，不再请求 auth-server
+type AuthDecisionCache struct {
+	// Enable 是否开启鉴权结果缓存
+	Enable bool `yaml:"enable"`
+	// TTLSec 有权限的鉴权结果缓存时间，单位：秒
+	TTLSec uint `yaml:"ttlSec"`
+	// NegativeTTLSec 无权限的鉴权结果缓存时间，用户申请权限后需要在该时间后生效，单位：秒
+	NegativeTTLSec uint `yaml:"negativeTTLSec"`
+	// MaxEntries 最多缓存的鉴权结果数量，超过后淘汰最久未使用的鉴权结果
+	MaxEntries uint `yaml:"maxEntries"`
+	// SyncIntervalSec 从 auth-server 同步缓存版本的间隔，权限策略变更后缓存在该时间内失效，单位：秒
+	SyncIntervalSec uint `yaml:"syncIntervalSec"`
+}
+
+func (a *AuthDecisionCache) trySetDefault() {
+	if a.TTLSec == 0 {
+		a.TTLSec = 30
+	}
+
+	if a.NegativeTTLSec == 0 {
+		a.NegativeTTLSec = 5
+	}
+
+	if a.MaxEntries == 0 {
+		a.MaxEntries = 100000
+	}
+
+	if a.SyncIntervalSec == 0 {
+		a.SyncIntervalSec = 5
+	}
+}
+
+func (a AuthDecisionCache) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	if a.TTLSec > 600 {
+		return errors.New("authDecisionCache.ttlSec must <= 600")
+	}
+
+	if a.NegativeTTLSec > a.TTLSec {
+		return errors.New("authDecisionCache.negativeTTLSec must <= authDecisionCache.ttlSec")
+	}
+
+	return nil
+}
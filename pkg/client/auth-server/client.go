@@ -210,3 +210,44 @@ func (c *Client) GetApplyPermUrl(ctx context.Context, h http.Header, req *meta.I
 
 	return resp.Data, err
 }
+
+// InvalidateDecisionCache invalidate the authorization decision cache of all the authorizers, it should be called
+// when the permission policies are changed.
+func (c *Client) InvalidateDecisionCache(ctx context.Context, h http.Header) (*authserver.DecisionCacheRevision,
+	error) {
+
+	resp := new(authserver.DecisionCacheRevisionResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		SubResourcef("/auth/decision_cache/invalidate").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// GetDecisionCacheRevision get the revision of the authorization decision cache.
+func (c *Client) GetDecisionCacheRevision(ctx context.Context, h http.Header) (*authserver.DecisionCacheRevision,
+	error) {
+
+	resp := new(authserver.DecisionCacheRevisionResp)
+
+	err := c.client.Get().
+		WithContext(ctx).
+		SubResourcef("/auth/decision_cache/revision").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
@@ -22,12 +22,14 @@ package dataservice
 import (
 	"fmt"
 
+	"hcm/pkg/client/data-service/aliyun"
 	"hcm/pkg/client/data-service/aws"
 	"hcm/pkg/client/data-service/azure"
 	"hcm/pkg/client/data-service/gcp"
 	"hcm/pkg/client/data-service/global"
 	"hcm/pkg/client/data-service/huawei"
 	"hcm/pkg/client/data-service/kaopu"
+	"hcm/pkg/client/data-service/openstack"
 	"hcm/pkg/client/data-service/other"
 	"hcm/pkg/client/data-service/tcloud"
 	"hcm/pkg/client/data-service/zenlayer"
@@ -38,15 +40,17 @@ import (
 
 // Client is data-service api client.
 type Client struct {
-	Global   *global.Client
-	TCloud   *tcloud.Client
-	Aws      *aws.Client
-	HuaWei   *huawei.Client
-	Gcp      *gcp.Client
-	Azure    *azure.Client
-	Zenlayer *zenlayer.Client
-	Kaopu    *kaopu.Client
-	Other    *other.Client
+	Global    *global.Client
+	TCloud    *tcloud.Client
+	Aws       *aws.Client
+	HuaWei    *huawei.Client
+	Aliyun    *aliyun.Client
+	OpenStack *openstack.Client
+	Gcp       *gcp.Client
+	Azure     *azure.Client
+	Zenlayer  *zenlayer.Client
+	Kaopu     *kaopu.Client
+	Other     *other.Client
 }
 
 // NewClient create a new data-service api client.
@@ -66,6 +70,12 @@ func NewClient(c *client.Capability, version string) *Client {
 		HuaWei: huawei.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.HuaWei)),
 		),
+		Aliyun: aliyun.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Aliyun)),
+		),
+		OpenStack: openstack.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.OpenStack)),
+		),
 		Gcp: gcp.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Gcp)),
 		),
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dstoken "hcm/pkg/api/data-service/access-token"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// AccessTokenClient is data service service account and access token api client.
+type AccessTokenClient struct {
+	client rest.ClientInterface
+}
+
+// NewAccessTokenClient create a new service account and access token api client.
+func NewAccessTokenClient(client rest.ClientInterface) *AccessTokenClient {
+	return &AccessTokenClient{
+		client: client,
+	}
+}
+
+// BatchCreateServiceAccount batch create service accounts.
+func (a *AccessTokenClient) BatchCreateServiceAccount(kt *kit.Kit, req *dstoken.BatchCreateServiceAccountReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dstoken.BatchCreateServiceAccountReq, core.BatchCreateResult](
+		a.client, rest.POST, kt, req, "/service_accounts/batch/create")
+}
+
+// BatchUpdateServiceAccount batch update service accounts.
+func (a *AccessTokenClient) BatchUpdateServiceAccount(kt *kit.Kit, req *dstoken.BatchUpdateServiceAccountReq) error {
+	return common.RequestNoResp[dstoken.BatchUpdateServiceAccountReq](
+		a.client, rest.PATCH, kt, req, "/service_accounts/batch")
+}
+
+// ListServiceAccount list service accounts.
+func (a *AccessTokenClient) ListServiceAccount(kt *kit.Kit, req *core.ListReq) (
+	*dstoken.ListServiceAccountResult, error) {
+
+	return common.Request[core.ListReq, dstoken.ListServiceAccountResult](
+		a.client, rest.POST, kt, req, "/service_accounts/list")
+}
+
+// BatchDeleteServiceAccount batch delete service accounts and their access tokens.
+func (a *AccessTokenClient) BatchDeleteServiceAccount(kt *kit.Kit, req *dstoken.BatchDeleteReq) error {
+	return common.RequestNoResp[dstoken.BatchDeleteReq](a.client, rest.DELETE, kt, req, "/service_accounts/batch")
+}
+
+// BatchCreateAccessToken batch create access tokens.
+func (a *AccessTokenClient) BatchCreateAccessToken(kt *kit.Kit, req *dstoken.BatchCreateAccessTokenReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dstoken.BatchCreateAccessTokenReq, core.BatchCreateResult](
+		a.client, rest.POST, kt, req, "/access_tokens/batch/create")
+}
+
+// BatchUpdateAccessToken batch update access tokens.
+func (a *AccessTokenClient) BatchUpdateAccessToken(kt *kit.Kit, req *dstoken.BatchUpdateAccessTokenReq) error {
+	return common.RequestNoResp[dstoken.BatchUpdateAccessTokenReq](
+		a.client, rest.PATCH, kt, req, "/access_tokens/batch")
+}
+
+// BatchUpdateAccessTokenLastUsed batch update the last used time of access tokens.
+func (a *AccessTokenClient) BatchUpdateAccessTokenLastUsed(kt *kit.Kit, req *dstoken.BatchUpdateLastUsedReq) error {
+	return common.RequestNoResp[dstoken.BatchUpdateLastUsedReq](
+		a.client, rest.PATCH, kt, req, "/access_tokens/last_used/batch")
+}
+
+// ListAccessToken list access tokens.
+func (a *AccessTokenClient) ListAccessToken(kt *kit.Kit, req *core.ListReq) (*dstoken.ListAccessTokenResult, error) {
+	return common.Request[core.ListReq, dstoken.ListAccessTokenResult](
+		a.client, rest.POST, kt, req, "/access_tokens/list")
+}
+
+// BatchDeleteAccessToken batch delete access tokens.
+func (a *AccessTokenClient) BatchDeleteAccessToken(kt *kit.Kit, req *dstoken.BatchDeleteReq) error {
+	return common.RequestNoResp[dstoken.BatchDeleteReq](a.client, rest.DELETE, kt, req, "/access_tokens/batch")
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsassign "hcm/pkg/api/data-service/assign-rule"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// AssignRuleClient is data service assign rule api client.
+type AssignRuleClient struct {
+	client rest.ClientInterface
+}
+
+// NewAssignRuleClient create a new assign rule api client.
+func NewAssignRuleClient(client rest.ClientInterface) *AssignRuleClient {
+	return &AssignRuleClient{
+		client: client,
+	}
+}
+
+// BatchCreateAssignRule batch create assign rules.
+func (a *AssignRuleClient) BatchCreateAssignRule(kt *kit.Kit, req *dsassign.BatchCreateAssignRuleReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsassign.BatchCreateAssignRuleReq, core.BatchCreateResult](
+		a.client, rest.POST, kt, req, "/assign_rules/batch/create")
+}
+
+// BatchUpdateAssignRule batch update assign rules.
+func (a *AssignRuleClient) BatchUpdateAssignRule(kt *kit.Kit, req *dsassign.BatchUpdateAssignRuleReq) error {
+	return common.RequestNoResp[dsassign.BatchUpdateAssignRuleReq](a.client, rest.PATCH, kt, req, "/assign_rules/batch")
+}
+
+// ListAssignRule list assign rules.
+func (a *AssignRuleClient) ListAssignRule(kt *kit.Kit, req *core.ListReq) (*dsassign.ListAssignRuleResult, error) {
+	return common.Request[core.ListReq, dsassign.ListAssignRuleResult](
+		a.client, rest.POST, kt, req, "/assign_rules/list")
+}
+
+// BatchDeleteAssignRule batch delete assign rules.
+func (a *AssignRuleClient) BatchDeleteAssignRule(kt *kit.Kit, req *dsassign.BatchDeleteReq) error {
+	return common.RequestNoResp[dsassign.BatchDeleteReq](a.client, rest.DELETE, kt, req, "/assign_rules/batch")
+}
@@ -218,3 +218,53 @@ func (a *AuditClient) GetAuditRaw(kt *kit.Kit, id uint64) (
 	return common.Request[common.Empty, coreaudit.RawAudit](a.client, rest.GET, kt, nil,
 		"/audits/%d", id)
 }
+
+// ArchiveAudit archive the audits before the specified time into object storage.
+func (a *AuditClient) ArchiveAudit(kt *kit.Kit, req *protoaudit.ArchiveAuditReq) (*protoaudit.ArchiveAuditResult,
+	error) {
+
+	return common.Request[protoaudit.ArchiveAuditReq, protoaudit.ArchiveAuditResult](a.client, rest.POST, kt, req,
+		"/audits/archive")
+}
+
+// ListArchive list audit archive manifests.
+func (a *AuditClient) ListArchive(kt *kit.Kit, req *core.ListReq) (*protoaudit.ListArchiveResult, error) {
+	return common.Request[core.ListReq, protoaudit.ListArchiveResult](a.client, rest.POST, kt, req,
+		"/audits/archives/list")
+}
+
+// SearchArchivedAudit search the archived audits.
+func (a *AuditClient) SearchArchivedAudit(kt *kit.Kit, req *protoaudit.SearchArchivedAuditReq) (
+	*protoaudit.SearchArchivedAuditResult, error) {
+
+	return common.Request[protoaudit.SearchArchivedAuditReq, protoaudit.SearchArchivedAuditResult](a.client,
+		rest.POST, kt, req, "/audits/archives/search")
+}
+
+// RehydrateArchive restore the audits in the archive file into audit table.
+func (a *AuditClient) RehydrateArchive(kt *kit.Kit, id string) (*protoaudit.RehydrateArchiveResult, error) {
+	return common.Request[common.Empty, protoaudit.RehydrateArchiveResult](a.client, rest.POST, kt, nil,
+		"/audits/archives/%s/rehydrate", id)
+}
+
+// VerifyAuditChain verify the audit hash chain of the tenant.
+func (a *AuditClient) VerifyAuditChain(kt *kit.Kit, req *protoaudit.VerifyAuditChainReq) (
+	*protoaudit.VerifyAuditChainResult, error) {
+
+	return common.Request[protoaudit.VerifyAuditChainReq, protoaudit.VerifyAuditChainResult](a.client, rest.POST, kt,
+		req, "/audits/chain/verify")
+}
+
+// CreateAuditCheckpoint create signed checkpoint of the audit hash chain head.
+func (a *AuditClient) CreateAuditCheckpoint(kt *kit.Kit) (*protoaudit.CreateCheckpointResult, error) {
+	return common.Request[common.Empty, protoaudit.CreateCheckpointResult](a.client, rest.POST, kt, nil,
+		"/audits/chain/checkpoints/create")
+}
+
+// ListAuditCheckpoint list audit hash chain checkpoints.
+func (a *AuditClient) ListAuditCheckpoint(kt *kit.Kit, req *core.ListReq) (*protoaudit.ListCheckpointResult,
+	error) {
+
+	return common.Request[core.ListReq, protoaudit.ListCheckpointResult](a.client, rest.POST, kt, req,
+		"/audits/chain/checkpoints/list")
+}
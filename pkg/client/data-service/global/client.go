@@ -69,6 +69,17 @@ type Client struct {
 	GlobalConfig *GlobalConfigsClient
 
 	ResUsageBizRel *ResUsageBizRelClient
+
+	ResMetric      *ResMetricClient
+	Recommendation *RecommendationClient
+	DiskSnapshot   *DiskSnapshotClient
+
+	ResChangeHistory *ResChangeHistoryClient
+	Rbac             *RbacClient
+	AccessToken      *AccessTokenClient
+	AssignRule       *AssignRuleClient
+	ResEvent         *ResEventClient
+	Idempotency      *IdempotencyClient
 }
 
 type restClient struct {
@@ -118,5 +129,15 @@ func NewClient(client rest.ClientInterface) *Client {
 		Tenant:         NewTenantClient(client),
 		GlobalConfig:   NewGlobalConfigClient(client),
 		ResUsageBizRel: NewResUsageBizRelRelClient(client),
+		ResMetric:      NewResMetricClient(client),
+		Recommendation: NewRecommendationClient(client),
+		DiskSnapshot:   NewDiskSnapshotClient(client),
+
+		ResChangeHistory: NewResChangeHistoryClient(client),
+		Rbac:             NewRbacClient(client),
+		AccessToken:      NewAccessTokenClient(client),
+		AssignRule:       NewAssignRuleClient(client),
+		ResEvent:         NewResEventClient(client),
+		Idempotency:      NewIdempotencyClient(client),
 	}
 }
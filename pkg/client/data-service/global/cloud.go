@@ -23,7 +23,9 @@ import (
 	"context"
 	"net/http"
 
+	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/types"
@@ -116,6 +118,14 @@ func (cli *CloudClient) BatchListResBasicInfo(kt *kit.Kit, req *protocloud.Batch
 	return resp.Data, nil
 }
 
+// AggregateResource aggregate cloud resource by group by columns.
+func (cli *CloudClient) AggregateResource(kt *kit.Kit, resType enumor.CloudResourceType, req *core.AggregateReq) (
+	*core.AggregateResult, error) {
+
+	return common.Request[core.AggregateReq, core.AggregateResult](cli.client, rest.POST, kt, req,
+		"/cloud/resources/%s/aggregate", resType)
+}
+
 // AssignResourceToBiz assign an account's cloud resource to biz, **only for ui**.
 func (cli *CloudClient) AssignResourceToBiz(ctx context.Context, h http.Header,
 	req *protocloud.AssignResourceToBizReq) error {
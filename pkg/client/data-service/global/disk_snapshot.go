@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dssnapshot "hcm/pkg/api/data-service/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// DiskSnapshotClient is data service disk snapshot and snapshot policy api client.
+type DiskSnapshotClient struct {
+	client rest.ClientInterface
+}
+
+// NewDiskSnapshotClient create a new disk snapshot api client.
+func NewDiskSnapshotClient(client rest.ClientInterface) *DiskSnapshotClient {
+	return &DiskSnapshotClient{
+		client: client,
+	}
+}
+
+// BatchCreate batch create disk snapshot.
+func (d *DiskSnapshotClient) BatchCreate(kt *kit.Kit, req *dssnapshot.BatchCreateDiskSnapshotReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssnapshot.BatchCreateDiskSnapshotReq, core.BatchCreateResult](d.client, rest.POST, kt,
+		req, "/disk_snapshots/batch/create")
+}
+
+// BatchUpdate batch update disk snapshot.
+func (d *DiskSnapshotClient) BatchUpdate(kt *kit.Kit, req *dssnapshot.BatchUpdateDiskSnapshotReq) error {
+	return common.RequestNoResp[dssnapshot.BatchUpdateDiskSnapshotReq](d.client, rest.PATCH, kt, req,
+		"/disk_snapshots/batch/update")
+}
+
+// UpdateRecycleStatus update disk snapshot recycle status by filter.
+func (d *DiskSnapshotClient) UpdateRecycleStatus(kt *kit.Kit,
+	req *dssnapshot.UpdateDiskSnapshotRecycleStatusReq) error {
+
+	return common.RequestNoResp[dssnapshot.UpdateDiskSnapshotRecycleStatusReq](d.client, rest.PATCH, kt, req,
+		"/disk_snapshots/recycle_status/update")
+}
+
+// List disk snapshot.
+func (d *DiskSnapshotClient) List(kt *kit.Kit, req *core.ListReq) (*dssnapshot.ListDiskSnapshotResult, error) {
+	return common.Request[core.ListReq, dssnapshot.ListDiskSnapshotResult](d.client, rest.POST, kt, req,
+		"/disk_snapshots/list")
+}
+
+// Delete disk snapshot.
+func (d *DiskSnapshotClient) Delete(kt *kit.Kit, req *dssnapshot.DeleteDiskSnapshotReq) error {
+	return common.RequestNoResp[dssnapshot.DeleteDiskSnapshotReq](d.client, rest.DELETE, kt, req,
+		"/disk_snapshots/batch")
+}
+
+// BatchCreatePolicy batch create disk snapshot policy.
+func (d *DiskSnapshotClient) BatchCreatePolicy(kt *kit.Kit, req *dssnapshot.BatchCreateDiskSnapshotPolicyReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssnapshot.BatchCreateDiskSnapshotPolicyReq, core.BatchCreateResult](d.client, rest.POST,
+		kt, req, "/disk_snapshot_policies/batch/create")
+}
+
+// BatchUpdatePolicy batch update disk snapshot policy.
+func (d *DiskSnapshotClient) BatchUpdatePolicy(kt *kit.Kit, req *dssnapshot.BatchUpdateDiskSnapshotPolicyReq) error {
+	return common.RequestNoResp[dssnapshot.BatchUpdateDiskSnapshotPolicyReq](d.client, rest.PATCH, kt, req,
+		"/disk_snapshot_policies/batch/update")
+}
+
+// ListPolicy list disk snapshot policy.
+func (d *DiskSnapshotClient) ListPolicy(kt *kit.Kit, req *core.ListReq) (*dssnapshot.ListDiskSnapshotPolicyResult,
+	error) {
+
+	return common.Request[core.ListReq, dssnapshot.ListDiskSnapshotPolicyResult](d.client, rest.POST, kt, req,
+		"/disk_snapshot_policies/list")
+}
+
+// DeletePolicy delete disk snapshot policy.
+func (d *DiskSnapshotClient) DeletePolicy(kt *kit.Kit, req *dssnapshot.DeleteDiskSnapshotPolicyReq) error {
+	return common.RequestNoResp[dssnapshot.DeleteDiskSnapshotPolicyReq](d.client, rest.DELETE, kt, req,
+		"/disk_snapshot_policies/batch")
+}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	dsidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// IdempotencyClient is data service idempotency record api client.
+type IdempotencyClient struct {
+	client rest.ClientInterface
+}
+
+// NewIdempotencyClient create a new idempotency record api client.
+func NewIdempotencyClient(client rest.ClientInterface) *IdempotencyClient {
+	return &IdempotencyClient{
+		client: client,
+	}
+}
+
+// Acquire acquire idempotency key for the request.
+func (r *IdempotencyClient) Acquire(kt *kit.Kit, req *dsidem.AcquireReq) (*dsidem.AcquireResult, error) {
+	return common.Request[dsidem.AcquireReq, dsidem.AcquireResult](
+		r.client, rest.POST, kt, req, "/idempotency_records/acquire")
+}
+
+// Complete record the response of the request which holds the idempotency key.
+func (r *IdempotencyClient) Complete(kt *kit.Kit, req *dsidem.CompleteReq) error {
+	return common.RequestNoResp[dsidem.CompleteReq](r.client, rest.PATCH, kt, req, "/idempotency_records/complete")
+}
+
+// Release release the idempotency key held by the failed request.
+func (r *IdempotencyClient) Release(kt *kit.Kit, req *dsidem.ReleaseReq) error {
+	return common.RequestNoResp[dsidem.ReleaseReq](r.client, rest.DELETE, kt, req, "/idempotency_records/release")
+}
+
+// DeleteExpired delete expired idempotency records of all tenants.
+func (r *IdempotencyClient) DeleteExpired(kt *kit.Kit, req *dsidem.DeleteExpiredReq) (*dsidem.DeleteExpiredResult,
+	error) {
+
+	return common.Request[dsidem.DeleteExpiredReq, dsidem.DeleteExpiredResult](
+		r.client, rest.DELETE, kt, req, "/idempotency_records/expired")
+}
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsrbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// RbacClient is data service local rbac api client.
+type RbacClient struct {
+	client rest.ClientInterface
+}
+
+// NewRbacClient create a new local rbac api client.
+func NewRbacClient(client rest.ClientInterface) *RbacClient {
+	return &RbacClient{
+		client: client,
+	}
+}
+
+// BatchCreateRole batch create roles.
+func (r *RbacClient) BatchCreateRole(kt *kit.Kit, req *dsrbac.BatchCreateRoleReq) (*core.BatchCreateResult, error) {
+	return common.Request[dsrbac.BatchCreateRoleReq, core.BatchCreateResult](
+		r.client, rest.POST, kt, req, "/rbac/roles/batch/create")
+}
+
+// BatchUpdateRole batch update roles.
+func (r *RbacClient) BatchUpdateRole(kt *kit.Kit, req *dsrbac.BatchUpdateRoleReq) error {
+	return common.RequestNoResp[dsrbac.BatchUpdateRoleReq](r.client, rest.PATCH, kt, req, "/rbac/roles/batch")
+}
+
+// ListRole list roles.
+func (r *RbacClient) ListRole(kt *kit.Kit, req *core.ListReq) (*dsrbac.ListRoleResult, error) {
+	return common.Request[core.ListReq, dsrbac.ListRoleResult](r.client, rest.POST, kt, req, "/rbac/roles/list")
+}
+
+// BatchDeleteRole batch delete roles and their role bindings.
+func (r *RbacClient) BatchDeleteRole(kt *kit.Kit, req *dsrbac.BatchDeleteReq) error {
+	return common.RequestNoResp[dsrbac.BatchDeleteReq](r.client, rest.DELETE, kt, req, "/rbac/roles/batch")
+}
+
+// BatchCreateRoleBinding batch create role bindings.
+func (r *RbacClient) BatchCreateRoleBinding(kt *kit.Kit, req *dsrbac.BatchCreateRoleBindingReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsrbac.BatchCreateRoleBindingReq, core.BatchCreateResult](
+		r.client, rest.POST, kt, req, "/rbac/role_bindings/batch/create")
+}
+
+// BatchUpdateRoleBinding batch update role bindings.
+func (r *RbacClient) BatchUpdateRoleBinding(kt *kit.Kit, req *dsrbac.BatchUpdateRoleBindingReq) error {
+	return common.RequestNoResp[dsrbac.BatchUpdateRoleBindingReq](
+		r.client, rest.PATCH, kt, req, "/rbac/role_bindings/batch")
+}
+
+// ListRoleBinding list role bindings.
+func (r *RbacClient) ListRoleBinding(kt *kit.Kit, req *core.ListReq) (*dsrbac.ListRoleBindingResult, error) {
+	return common.Request[core.ListReq, dsrbac.ListRoleBindingResult](
+		r.client, rest.POST, kt, req, "/rbac/role_bindings/list")
+}
+
+// BatchDeleteRoleBinding batch delete role bindings.
+func (r *RbacClient) BatchDeleteRoleBinding(kt *kit.Kit, req *dsrbac.BatchDeleteReq) error {
+	return common.RequestNoResp[dsrbac.BatchDeleteReq](r.client, rest.DELETE, kt, req, "/rbac/role_bindings/batch")
+}
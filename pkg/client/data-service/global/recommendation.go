@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsrecommend "hcm/pkg/api/data-service/recommendation"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// RecommendationClient is data service res recommendation api client.
+type RecommendationClient struct {
+	client rest.ClientInterface
+}
+
+// NewRecommendationClient create a new res recommendation api client.
+func NewRecommendationClient(client rest.ClientInterface) *RecommendationClient {
+	return &RecommendationClient{
+		client: client,
+	}
+}
+
+// BatchCreate batch create res recommendation.
+func (r *RecommendationClient) BatchCreate(kt *kit.Kit, req *dsrecommend.BatchCreateResRecommendationReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsrecommend.BatchCreateResRecommendationReq, core.BatchCreateResult](r.client, rest.POST,
+		kt, req, "/res_recommendations/batch/create")
+}
+
+// BatchUpdate batch update res recommendation.
+func (r *RecommendationClient) BatchUpdate(kt *kit.Kit, req *dsrecommend.BatchUpdateResRecommendationReq) error {
+	return common.RequestNoResp[dsrecommend.BatchUpdateResRecommendationReq](r.client, rest.PATCH, kt, req,
+		"/res_recommendations/batch/update")
+}
+
+// List res recommendation.
+func (r *RecommendationClient) List(kt *kit.Kit, req *core.ListReq) (*dsrecommend.ListResRecommendationResult,
+	error) {
+
+	return common.Request[core.ListReq, dsrecommend.ListResRecommendationResult](r.client, rest.POST, kt, req,
+		"/res_recommendations/list")
+}
+
+// Delete res recommendation.
+func (r *RecommendationClient) Delete(kt *kit.Kit, req *dsrecommend.DeleteResRecommendationReq) error {
+	return common.RequestNoResp[dsrecommend.DeleteResRecommendationReq](r.client, rest.DELETE, kt, req,
+		"/res_recommendations/batch")
+}
@@ -120,6 +120,14 @@ func (r *RecycleRecordClient) ListCvmRecycleRecord(kt *kit.Kit, request *core.Li
 		"/recycle_records/list")
 }
 
+// ListDiskRecycleRecord list disk recycle record.
+func (r *RecycleRecordClient) ListDiskRecycleRecord(kt *kit.Kit, request *core.ListReq) (
+	*core.ListResultT[rr.DiskRecycleRecord], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[rr.DiskRecycleRecord]](r.client, rest.POST, kt, request,
+		"/recycle_records/list")
+}
+
 // BatchUpdateRecycleRecord batch update recycle record.
 func (r *RecycleRecordClient) BatchUpdateRecycleRecord(kt *kit.Kit, request *proto.BatchUpdateReq) error {
 
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsreshistory "hcm/pkg/api/data-service/res-history"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResChangeHistoryClient is data service res change history api client.
+type ResChangeHistoryClient struct {
+	client rest.ClientInterface
+}
+
+// NewResChangeHistoryClient create a new res change history api client.
+func NewResChangeHistoryClient(client rest.ClientInterface) *ResChangeHistoryClient {
+	return &ResChangeHistoryClient{
+		client: client,
+	}
+}
+
+// List res change history.
+func (r *ResChangeHistoryClient) List(kt *kit.Kit, req *core.ListReq) (*dsreshistory.ListResChangeHistoryResult,
+	error) {
+
+	return common.Request[core.ListReq, dsreshistory.ListResChangeHistoryResult](r.client, rest.POST, kt, req,
+		"/res_change_histories/list")
+}
+
+// Diff the snapshots of two versions of the resource.
+func (r *ResChangeHistoryClient) Diff(kt *kit.Kit, req *dsreshistory.DiffResChangeHistoryReq) (
+	*dsreshistory.DiffResChangeHistoryResult, error) {
+
+	return common.Request[dsreshistory.DiffResChangeHistoryReq, dsreshistory.DiffResChangeHistoryResult](r.client,
+		rest.POST, kt, req, "/res_change_histories/diff")
+}
+
+// DeleteExpired delete expired res change history.
+func (r *ResChangeHistoryClient) DeleteExpired(kt *kit.Kit, req *dsreshistory.DeleteExpiredResChangeHistoryReq) (
+	*dsreshistory.DeleteExpiredResChangeHistoryResult, error) {
+
+	return common.Request[dsreshistory.DeleteExpiredResChangeHistoryReq,
+		dsreshistory.DeleteExpiredResChangeHistoryResult](r.client, rest.DELETE, kt, req,
+		"/res_change_histories/expired")
+}
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsevent "hcm/pkg/api/data-service/res-event"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResEventClient is data service res event api client.
+type ResEventClient struct {
+	client rest.ClientInterface
+}
+
+// NewResEventClient create a new res event api client.
+func NewResEventClient(client rest.ClientInterface) *ResEventClient {
+	return &ResEventClient{
+		client: client,
+	}
+}
+
+// BatchCreateSubscription batch create event subscriptions.
+func (r *ResEventClient) BatchCreateSubscription(kt *kit.Kit, req *dsevent.BatchCreateSubscriptionReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsevent.BatchCreateSubscriptionReq, core.BatchCreateResult](
+		r.client, rest.POST, kt, req, "/event_subscriptions/batch/create")
+}
+
+// BatchUpdateSubscription batch update event subscriptions.
+func (r *ResEventClient) BatchUpdateSubscription(kt *kit.Kit, req *dsevent.BatchUpdateSubscriptionReq) error {
+	return common.RequestNoResp[dsevent.BatchUpdateSubscriptionReq](
+		r.client, rest.PATCH, kt, req, "/event_subscriptions/batch")
+}
+
+// ListSubscription list event subscriptions.
+func (r *ResEventClient) ListSubscription(kt *kit.Kit, req *core.ListReq) (*dsevent.ListSubscriptionResult,
+	error) {
+
+	return common.Request[core.ListReq, dsevent.ListSubscriptionResult](
+		r.client, rest.POST, kt, req, "/event_subscriptions/list")
+}
+
+// BatchDeleteSubscription batch delete event subscriptions.
+func (r *ResEventClient) BatchDeleteSubscription(kt *kit.Kit, req *dsevent.BatchDeleteReq) error {
+	return common.RequestNoResp[dsevent.BatchDeleteReq](r.client, rest.DELETE, kt, req, "/event_subscriptions/batch")
+}
+
+// ListEvent list res events.
+func (r *ResEventClient) ListEvent(kt *kit.Kit, req *core.ListReq) (*dsevent.ListEventResult, error) {
+	return common.Request[core.ListReq, dsevent.ListEventResult](r.client, rest.POST, kt, req, "/res_events/list")
+}
+
+// ListDelivery list event deliveries.
+func (r *ResEventClient) ListDelivery(kt *kit.Kit, req *core.ListReq) (*dsevent.ListDeliveryResult, error) {
+	return common.Request[core.ListReq, dsevent.ListDeliveryResult](
+		r.client, rest.POST, kt, req, "/event_deliveries/list")
+}
+
+// RetryDelivery reset the failed event deliveries to pending.
+func (r *ResEventClient) RetryDelivery(kt *kit.Kit, req *dsevent.RetryDeliveryReq) (*dsevent.RetryDeliveryResult,
+	error) {
+
+	return common.Request[dsevent.RetryDeliveryReq, dsevent.RetryDeliveryResult](
+		r.client, rest.POST, kt, req, "/event_deliveries/retry")
+}
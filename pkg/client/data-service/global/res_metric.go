@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dsresmetric "hcm/pkg/api/data-service/res-metric"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResMetricClient is data service res metric api client.
+type ResMetricClient struct {
+	client rest.ClientInterface
+}
+
+// NewResMetricClient create a new res metric api client.
+func NewResMetricClient(client rest.ClientInterface) *ResMetricClient {
+	return &ResMetricClient{
+		client: client,
+	}
+}
+
+// BatchUpsertDaily batch upsert res metric daily.
+func (r *ResMetricClient) BatchUpsertDaily(kt *kit.Kit, req *dsresmetric.BatchUpsertResMetricDailyReq) error {
+	return common.RequestNoResp[dsresmetric.BatchUpsertResMetricDailyReq](r.client, rest.POST, kt, req,
+		"/res_metrics/daily/batch/upsert")
+}
+
+// ListDaily list res metric daily.
+func (r *ResMetricClient) ListDaily(kt *kit.Kit, req *core.ListReq) (*dsresmetric.ListResMetricDailyResult, error) {
+	return common.Request[core.ListReq, dsresmetric.ListResMetricDailyResult](r.client, rest.POST, kt, req,
+		"/res_metrics/daily/list")
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// SubnetClient is data service subnet api client.
+type SubnetClient struct {
+	client rest.ClientInterface
+}
+
+// NewSubnetClient create a new subnet api client.
+func NewSubnetClient(client rest.ClientInterface) *SubnetClient {
+	return &SubnetClient{
+		client: client,
+	}
+}
+
+// BatchCreate batch create openstack subnet.
+func (v *SubnetClient) BatchCreate(ctx context.Context, h http.Header,
+	req *protocloud.SubnetBatchCreateReq[protocloud.OpenStackSubnetCreateExt]) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := v.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/subnets/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// Get openstack subnet.
+func (v *SubnetClient) Get(ctx context.Context, h http.Header, id string) (
+	*corecloud.Subnet[corecloud.OpenStackSubnetExtension], error) {
+
+	resp := new(protocloud.SubnetGetResp[corecloud.OpenStackSubnetExtension])
+
+	err := v.client.Get().
+		WithContext(ctx).
+		SubResourcef("/subnets/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdate openstack subnet.
+func (v *SubnetClient) BatchUpdate(ctx context.Context, h http.Header,
+	req *protocloud.SubnetBatchUpdateReq[protocloud.OpenStackSubnetUpdateExt]) error {
+
+	resp := new(rest.BaseResp)
+
+	err := v.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/subnets/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSubnetExt list subnet with extension.
+func (v *SubnetClient) ListSubnetExt(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.SubnetExtListResult[corecloud.OpenStackSubnetExtension], error) {
+
+	resp := new(protocloud.SubnetExtListResp[corecloud.OpenStackSubnetExtension])
+
+	err := v.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/subnets/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -39,6 +39,8 @@ type Client struct {
 	InstanceType  *InstanceTypeClient
 	Bill          *BillClient
 	MainAccount   *MainAccountClient
+	ResMetric     *ResMetricClient
+	DiskSnapshot  *DiskSnapshotClient
 }
 
 // NewClient create a new aws api client.
@@ -58,5 +60,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:  NewInstanceTypeClient(client),
 		Bill:          NewBillClient(client),
 		MainAccount:   NewMainAccountClient(client),
+		ResMetric:     NewResMetricClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
 	}
 }
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewDiskSnapshotClient create a new disk snapshot api client.
+func NewDiskSnapshotClient(client rest.ClientInterface) *DiskSnapshotClient {
+	return &DiskSnapshotClient{
+		client: client,
+	}
+}
+
+// DiskSnapshotClient is hc service disk snapshot api client.
+type DiskSnapshotClient struct {
+	client rest.ClientInterface
+}
+
+// Create disk snapshot.
+func (cli *DiskSnapshotClient) Create(kt *kit.Kit, req *proto.DiskSnapshotCreateReq) (*core.CreateResult, error) {
+	return common.Request[proto.DiskSnapshotCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/disk_snapshots/create")
+}
+
+// Delete disk snapshot.
+func (cli *DiskSnapshotClient) Delete(kt *kit.Kit, req *proto.DiskSnapshotDeleteReq) error {
+	return common.RequestNoResp[proto.DiskSnapshotDeleteReq](cli.client, rest.DELETE, kt, req, "/disk_snapshots")
+}
+
+// Sync disk snapshot.
+func (cli *DiskSnapshotClient) Sync(kt *kit.Kit, req *proto.DiskSnapshotSyncReq) error {
+	return common.RequestNoResp[proto.DiskSnapshotSyncReq](cli.client, rest.POST, kt, req, "/disk_snapshots/sync")
+}
@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	hcresmetric "hcm/pkg/api/hc-service/res-metric"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewResMetricClient create a new res metric api client.
+func NewResMetricClient(client rest.ClientInterface) *ResMetricClient {
+	return &ResMetricClient{
+		client: client,
+	}
+}
+
+// ResMetricClient is hc service res metric api client.
+type ResMetricClient struct {
+	client rest.ClientInterface
+}
+
+// CollectDaily collect res metric of the specified date and save daily aggregate.
+func (cli *ResMetricClient) CollectDaily(kt *kit.Kit, req *hcresmetric.CollectResMetricDailyReq) (
+	*hcresmetric.CollectResMetricDailyResult, error) {
+
+	return common.Request[hcresmetric.CollectResMetricDailyReq, hcresmetric.CollectResMetricDailyResult](
+		cli.client, rest.POST, kt, req, "/res_metrics/daily/collect")
+}
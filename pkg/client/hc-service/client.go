@@ -22,10 +22,12 @@ package hcservice
 import (
 	"fmt"
 
+	"hcm/pkg/client/hc-service/aliyun"
 	"hcm/pkg/client/hc-service/aws"
 	"hcm/pkg/client/hc-service/azure"
 	"hcm/pkg/client/hc-service/gcp"
 	"hcm/pkg/client/hc-service/huawei"
+	"hcm/pkg/client/hc-service/openstack"
 	"hcm/pkg/client/hc-service/other"
 	"hcm/pkg/client/hc-service/tcloud"
 	"hcm/pkg/criteria/enumor"
@@ -35,12 +37,14 @@ import (
 
 // Client is hc-service api client.
 type Client struct {
-	TCloud *tcloud.Client
-	Aws    *aws.Client
-	HuaWei *huawei.Client
-	Gcp    *gcp.Client
-	Azure  *azure.Client
-	Other  *other.Client
+	TCloud    *tcloud.Client
+	Aws       *aws.Client
+	HuaWei    *huawei.Client
+	Gcp       *gcp.Client
+	Azure     *azure.Client
+	Aliyun    *aliyun.Client
+	OpenStack *openstack.Client
+	Other     *other.Client
 }
 
 // NewClient create a new hc-service api client.
@@ -63,6 +67,12 @@ func NewClient(c *client.Capability, version string) *Client {
 		Azure: azure.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Azure)),
 		),
+		Aliyun: aliyun.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Aliyun)),
+		),
+		OpenStack: openstack.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.OpenStack)),
+		),
 		Other: other.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Other)),
 		),
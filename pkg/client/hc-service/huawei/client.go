@@ -39,6 +39,8 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	ResMetric        *ResMetricClient
+	DiskSnapshot     *DiskSnapshotClient
 }
 
 // NewClient create a new huawei api client.
@@ -58,5 +60,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		ResMetric:        NewResMetricClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 	}
 }
@@ -43,6 +43,8 @@ type Client struct {
 	Clb           *ClbClient
 	BandPkg       *BandwidthPackageClient
 	Cos           *CosClient
+	ResMetric     *ResMetricClient
+	DiskSnapshot  *DiskSnapshotClient
 }
 
 // NewClient create a new tcloud api client.
@@ -66,5 +68,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Clb:           NewClbClient(client),
 		BandPkg:       NewBandPkgClient(client),
 		Cos:           NewCosClient(client),
+		ResMetric:     NewResMetricClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
 	}
 }
@@ -137,6 +137,12 @@ func (cli *CvmClient) BatchRebootCvm(kt *kit.Kit, request *protocvm.TCloudBatchR
 	return nil
 }
 
+// ResetInstanceType ....
+func (cli *CvmClient) ResetInstanceType(kt *kit.Kit, request *protocvm.TCloudResetInstanceTypeReq) error {
+	return common.RequestNoResp[protocvm.TCloudResetInstanceTypeReq](cli.client, rest.POST, kt, request,
+		"/cvms/instance_type/reset")
+}
+
 // BatchResetCvmPwd ....
 func (cli *CvmClient) BatchResetCvmPwd(ctx context.Context, h http.Header,
 	request *protocvm.TCloudBatchRebootReq) error {
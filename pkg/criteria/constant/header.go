@@ -46,6 +46,20 @@ const (
 
 	// BKGWAuthKey is blueking api gateway authorization header key.
 	BKGWAuthKey = "X-Bkapi-Authorization"
+
+	// UserDepartmentKey is operator department header key, it is set by web-server when user logs in by oidc.
+	UserDepartmentKey = "X-Bkhcm-User-Department"
+
+	// TokenScopeKey is access token scope header key, it is set by api-server when request is authenticated by
+	// access token, and is used to limit the permission of the request.
+	TokenScopeKey = "X-Bkhcm-Token-Scope"
+
+	// IdempotencyKey is idempotency key header key, POST requests with the same idempotency key are executed only
+	// once, and the result of the first request is returned to the replayed requests.
+	IdempotencyKey = "Idempotency-Key"
+
+	// IdempotentReplayedKey is the response header key which marks the response is replayed by idempotency key.
+	IdempotentReplayedKey = "Idempotent-Replayed"
 )
 
 const (
@@ -59,6 +59,7 @@ var defaultFlowNameMap = map[FlowName]struct{}{
 	FlowBillMainAccountSummary: {},
 	FlowBillRootAccountSummary: {},
 	FlowBillMonthTask:          {},
+	FlowApplyResSpec:           {},
 }
 
 // ValidateDefault validate default FlowName.
@@ -173,3 +174,9 @@ const (
 	FlowBillRootAccountSummary FlowName = "bill_root_account_summary"
 	FlowBillMonthTask          FlowName = "bill_month_task"
 )
+
+// 声明式资源描述相关Flow
+const (
+	// FlowApplyResSpec 按声明式资源描述的执行计划变更资源
+	FlowApplyResSpec FlowName = "apply_res_spec"
+)
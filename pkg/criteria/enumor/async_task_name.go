@@ -48,6 +48,7 @@ func (v ActionName) Validate() error {
 	case ActionBatchTaskTCloudCreateL7Rule, ActionBatchTaskTCloudBindTarget, ActionBatchTaskTCloudCreateListener,
 		ActionBatchTaskTCloudUnBindTarget, ActionBatchTaskTCloudModifyRsWeight, ActionBatchTaskDeleteListener:
 	case ActionSyncTCloudLoadBalancer, SyncTCloudLoadBalancerListener:
+	case ActionApplyResSpec:
 
 	default:
 		return fmt.Errorf("unsupported action name type: %s", v)
@@ -155,3 +156,9 @@ const (
 	// SyncTCloudLoadBalancerListener ...
 	SyncTCloudLoadBalancerListener = "sync_tcloud_load_balancer_listener"
 )
+
+// 声明式资源描述相关Action
+const (
+	// ActionApplyResSpec 执行声明式资源描述计划中的一个资源变更
+	ActionApplyResSpec ActionName = "apply_res_spec"
+)
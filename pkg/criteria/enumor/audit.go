@@ -50,6 +50,7 @@ const (
 	UrlRuleDomainAuditResType     AuditResourceType = "url_rule_domain"
 	MainAccountAuditResType       AuditResourceType = "main_account"
 	RootAccountAuditResType       AuditResourceType = "root_account"
+	DiskSnapshotAuditResType      AuditResourceType = "disk_snapshot"
 )
 
 // AuditResourceTypeEnums resource type map.
@@ -75,6 +76,7 @@ var AuditResourceTypeEnums = map[AuditResourceType]struct{}{
 	UrlRuleDomainAuditResType:     {},
 	MainAccountAuditResType:       {},
 	RootAccountAuditResType:       {},
+	DiskSnapshotAuditResType:      {},
 }
 
 // Exist judge enum value exist.
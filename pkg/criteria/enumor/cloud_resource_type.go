@@ -88,6 +88,7 @@ const (
 	ListenerCloudResType         CloudResourceType = "listener"
 	TargetGroupCloudResType      CloudResourceType = "target_group"
 	TCLoudUrlRuleCloudResType    CloudResourceType = "tcloud_url_rule"
+	DiskSnapshotCloudResType     CloudResourceType = "disk_snapshot"
 	// SecurityGroupUsageBizRelResType 安全组使用业务关联关系
 	SecurityGroupUsageBizRelResType CloudResourceType = "security_group_usage_biz_rel"
 )
@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// RecommendType 资源优化建议类型
+type RecommendType string
+
+const (
+	// UnattachedDiskRecommend 未挂载的云硬盘
+	UnattachedDiskRecommend RecommendType = "unattached_disk"
+	// UnboundEipRecommend 未绑定的弹性公网IP
+	UnboundEipRecommend RecommendType = "unbound_eip"
+	// StoppedCvmRecommend 长期关机的主机
+	StoppedCvmRecommend RecommendType = "stopped_cvm"
+	// EmptyLoadBalancerRecommend 没有监听器的负载均衡
+	EmptyLoadBalancerRecommend RecommendType = "empty_load_balancer"
+	// OversizedCvmRecommend 规格超出实际使用的主机
+	OversizedCvmRecommend RecommendType = "oversized_cvm"
+)
+
+// Validate RecommendType.
+func (r RecommendType) Validate() error {
+	switch r {
+	case UnattachedDiskRecommend, UnboundEipRecommend, StoppedCvmRecommend, EmptyLoadBalancerRecommend,
+		OversizedCvmRecommend:
+	default:
+		return fmt.Errorf("unsupported recommend type: %s", r)
+	}
+
+	return nil
+}
+
+// Action 返回优化建议对应的处理动作
+func (r RecommendType) Action() RecommendAction {
+	switch r {
+	case UnattachedDiskRecommend, StoppedCvmRecommend:
+		return RecycleRecommendAction
+	case UnboundEipRecommend, EmptyLoadBalancerRecommend:
+		return DeleteRecommendAction
+	case OversizedCvmRecommend:
+		return DownsizeRecommendAction
+	default:
+		return ""
+	}
+}
+
+// RecommendAction 优化建议的处理动作
+type RecommendAction string
+
+const (
+	// RecycleRecommendAction 回收资源，进入回收站
+	RecycleRecommendAction RecommendAction = "recycle"
+	// DeleteRecommendAction 直接释放资源
+	DeleteRecommendAction RecommendAction = "delete"
+	// DownsizeRecommendAction 降低主机规格
+	DownsizeRecommendAction RecommendAction = "downsize"
+)
+
+// RecommendStatus 优化建议状态
+type RecommendStatus string
+
+const (
+	// PendingRecommendStatus 待处理
+	PendingRecommendStatus RecommendStatus = "pending"
+	// AppliedRecommendStatus 已处理
+	AppliedRecommendStatus RecommendStatus = "applied"
+	// IgnoredRecommendStatus 已忽略
+	IgnoredRecommendStatus RecommendStatus = "ignored"
+)
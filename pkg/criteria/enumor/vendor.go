@@ -49,6 +49,10 @@ const (
 	Zenlayer Vendor = "zenlayer"
 	// Kaopu is kaopu cloud.
 	Kaopu Vendor = "kaopu"
+	// Aliyun is alibaba cloud.
+	Aliyun Vendor = "aliyun"
+	// OpenStack is openstack private cloud.
+	OpenStack Vendor = "openstack"
 
 	// Other special vendor, for other cloud
 	Other Vendor = "other"
@@ -109,6 +113,18 @@ var (
 			MainAccountIDField: "cloud_main_account_id",
 			SecretKeyField:     "cloud_secret_key",
 		},
+		Aliyun: {
+			NameEn:             "Alibaba Cloud",
+			NameZh:             "阿里云",
+			MainAccountIDField: "cloud_main_account_id",
+			SecretKeyField:     "cloud_secret_key",
+		},
+		OpenStack: {
+			NameEn:             "OpenStack",
+			NameZh:             "OpenStack私有云",
+			MainAccountIDField: "cloud_project_id",
+			SecretKeyField:     "cloud_secret_key",
+		},
 		Other: {
 			NameEn:             "Other",
 			NameZh:             "其他云厂商",
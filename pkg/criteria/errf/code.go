@@ -74,4 +74,8 @@ const (
 	BillItemImportEmptyDataError int32 = 2000017
 	// SyncRepeatLockError CLB、安全组重复同步
 	SyncRepeatLockError int32 = 2000024
+	// IdempotencyKeyConflict 幂等键已被不同的请求使用，或使用相同幂等键的请求正在处理中
+	IdempotencyKeyConflict int32 = 2000025
+	// TooManyRequests 请求超出限流配额
+	TooManyRequests int32 = 2000026
 )
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package aggregate 资源通用聚合查询的Package
+package aggregate
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud"
+	tablecert "hcm/pkg/dal/table/cloud/cert"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabledisk "hcm/pkg/dal/table/cloud/disk"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tableni "hcm/pkg/dal/table/cloud/network-interface"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+// Aggregate only used for aggregate query of core resources.
+type Aggregate interface {
+	Aggregate(kt *kit.Kit, resType enumor.CloudResourceType, opt *types.AggregateOption) (
+		*core.AggregateResult, error)
+}
+
+var _ Aggregate = new(Dao)
+
+// Dao aggregate dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+type resource struct {
+	table   table.Name
+	columns *utils.Columns
+}
+
+// resources is the core resources which support aggregate query.
+var resources = map[enumor.CloudResourceType]resource{
+	enumor.CvmCloudResType:              {table: table.CvmTable, columns: tablecvm.TableColumns},
+	enumor.DiskCloudResType:             {table: table.DiskTable, columns: tabledisk.DiskColumns},
+	enumor.EipCloudResType:              {table: table.EipTable, columns: tableeip.EipColumns},
+	enumor.VpcCloudResType:              {table: table.VpcTable, columns: cloud.VpcColumns},
+	enumor.SubnetCloudResType:           {table: table.SubnetTable, columns: cloud.SubnetColumns},
+	enumor.SecurityGroupCloudResType:    {table: table.SecurityGroupTable, columns: cloud.SecurityGroupColumns},
+	enumor.NetworkInterfaceCloudResType: {table: table.NetworkInterfaceTable, columns: tableni.NetworkInterfaceColumns},
+	enumor.LoadBalancerCloudResType:     {table: table.LoadBalancerTable, columns: tablelb.LoadBalancerColumns},
+	enumor.CertCloudResType:             {table: table.SslCertTable, columns: tablecert.SslCertTableColumns},
+}
+
+// IsSupported returns whether the resource type supports aggregate query.
+func IsSupported(resType enumor.CloudResourceType) bool {
+	_, exists := resources[resType]
+	return exists
+}
+
+// Aggregate group the resources matched by filter, and calculate the aggregations of each group.
+func (dao Dao) Aggregate(kt *kit.Kit, resType enumor.CloudResourceType, opt *types.AggregateOption) (
+	*core.AggregateResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "aggregate options is nil")
+	}
+
+	res, exists := resources[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support aggregate", resType)
+	}
+
+	if err := opt.Validate(res.columns.ColumnTypes()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, opt.SelectExpr(), res.table, whereExpr, opt.GroupExpr())
+
+	rows := make([]struct {
+		Detail tabletype.JsonField `db:"detail"`
+	}, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &rows, sql, whereValue)
+	if err != nil {
+		logs.Errorf("aggregate %s failed, err: %v, filter: %s, rid: %s", resType, err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		detail := make(map[string]interface{})
+		if err = json.UnmarshalFromString(string(row.Detail), &detail); err != nil {
+			logs.Errorf("unmarshal aggregate detail failed, err: %v, detail: %s, rid: %s", err, row.Detail, kt.Rid)
+			return nil, err
+		}
+		details = append(details, detail)
+	}
+
+	return &core.AggregateResult{Details: details}, nil
+}
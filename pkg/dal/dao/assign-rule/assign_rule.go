@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 资源自动分配业务规则
+package assignrule
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableassign "hcm/pkg/dal/table/assign-rule"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AssignRule only used for assign rule.
+type AssignRule interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableassign.AssignRuleTable) ([]string, error)
+	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tableassign.AssignRuleTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableassign.AssignRuleTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ AssignRule = new(AssignRuleDao)
+
+// AssignRuleDao assign rule dao.
+type AssignRuleDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx create assign rules with tx.
+func (dao AssignRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableassign.AssignRuleTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.AssignRuleTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AssignRuleTable,
+		tableassign.AssignRuleColumns.ColumnExpr(),
+		tableassign.AssignRuleColumns.ColonNameExpr())
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, models)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AssignRuleTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.AssignRuleTable, err)
+	}
+
+	return ids, nil
+}
+
+// UpdateByIDWithTx update assign rule by id with tx.
+func (dao AssignRuleDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
+	model *tableassign.AssignRuleTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AssignRuleTable, setExpr)
+
+	toUpdate["id"] = id
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.Errorf("update assign rule failed, id: %s, toUpdate: %+v, err: %v, rid: %s", id, toUpdate, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List assign rules.
+func (dao AssignRuleDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableassign.AssignRuleTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableassign.AssignRuleColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AssignRuleTable, whereExpr)
+
+		count, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count assign rule failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableassign.AssignRuleTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableassign.AssignRuleColumns.FieldsNamedExpr(opt.Fields),
+		table.AssignRuleTable, whereExpr, pageExpr)
+
+	details := make([]tableassign.AssignRuleTable, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		logs.ErrorJson("select assign rule failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableassign.AssignRuleTable]{Details: details}, nil
+}
+
+// DeleteWithTx delete assign rules with tx.
+func (dao AssignRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AssignRuleTable, whereExpr)
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.ErrorJson("delete assign rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
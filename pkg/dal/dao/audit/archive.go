@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// ArchiveInterface define audit archive interface.
+type ArchiveInterface interface {
+	Create(kt *kit.Kit, one *audit.ArchiveTable) (string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditArchiveDetails, error)
+}
+
+var _ ArchiveInterface = new(ArchiveDao)
+
+// ArchiveDao audit archive dao.
+type ArchiveDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create audit archive.
+func (d ArchiveDao) Create(kt *kit.Kit, one *audit.ArchiveTable) (string, error) {
+	if one == nil {
+		return "", errf.New(errf.InvalidParameter, "audit archive is nil")
+	}
+
+	ids, err := d.IDGen.Batch(kt, table.AuditArchiveTable, 1)
+	if err != nil {
+		return "", err
+	}
+	one.ID = ids[0]
+	one.Creator = kt.User
+
+	if err := one.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s)`, table.AuditArchiveTable, audit.ArchiveColumns.ColumnExpr(),
+		audit.ArchiveColumns.ColonNameExpr())
+	err = d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Insert(kt.Ctx, sql, one)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditArchiveTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.AuditArchiveTable, err)
+	}
+
+	return one.ID, nil
+}
+
+// List audit archive.
+func (d ArchiveDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditArchiveDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(audit.ArchiveColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AuditArchiveTable, whereExpr)
+
+		count, err := d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count audit archive failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListAuditArchiveDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, audit.ArchiveColumns.FieldsNamedExpr(opt.Fields),
+		table.AuditArchiveTable, whereExpr, pageExpr)
+
+	details := make([]audit.ArchiveTable, 0)
+	err = d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListAuditArchiveDetails{Details: details}, nil
+}
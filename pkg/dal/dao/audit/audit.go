@@ -22,8 +22,11 @@ package audit
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
@@ -31,9 +34,11 @@ import (
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
 	"hcm/pkg/dal/table/audit"
+	tabletypes "hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -44,20 +49,33 @@ type Interface interface {
 	BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error
 	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails, error)
+	DeleteByIDs(kt *kit.Kit, ids []uint64) (int64, error)
+	BatchRestore(kt *kit.Kit, audits []audit.AuditTable) error
+	GetChainHead(kt *kit.Kit) (*audit.ChainHeadTable, error)
+	ListBySeq(kt *kit.Kit, startSeq, endSeq uint64, limit uint) ([]audit.AuditTable, error)
+	ListBySeqs(kt *kit.Kit, seqs []uint64) ([]audit.AuditTable, error)
 }
 
 var _ Interface = new(Dao)
 
-// NewAudit new audit.
-func NewAudit(orm orm.Interface) Interface {
-	return &Dao{
+// NewAudit new audit, created audits are also written into the outbox for streaming if the outbox is enabled.
+func NewAudit(orm orm.Interface, enableOutbox bool) Interface {
+	d := &Dao{
 		Orm: orm,
 	}
+
+	if enableOutbox {
+		d.Outbox = &OutboxDao{Orm: orm}
+	}
+
+	return d
 }
 
 // Dao audit dao.
 type Dao struct {
 	Orm orm.Interface
+	// Outbox is the audit streaming outbox, audits are not streamed if it is nil.
+	Outbox OutboxInterface
 }
 
 // Create audit.
@@ -67,40 +85,260 @@ func (d Dao) Create(kt *kit.Kit, one *audit.AuditTable) error {
 
 // BatchCreate batch create audit.
 func (d Dao) BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error {
+	_, err := d.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, d.BatchCreateWithTx(kt, txn, audits)
+	})
+	return err
+}
+
+// createColumns 创建审计时写入的列，id 由数据库生成，tenant_id 由 orm 注入
+var createColumns = []string{"res_id", "cloud_res_id", "res_name", "res_type", "action", "bk_biz_id", "vendor",
+	"account_id", "operator", "source", "rid", "app_code", "detail", "seq", "prev_hash", "hash", "created_at"}
+
+// auditInsertExpr returns the column and value expressions of inserting audits, created_at is written by the named
+// parameter createdAtName, which is the time in utc database layout so that it must be written in utc session.
+func auditInsertExpr(columns []string, createdAtName string) (string, string) {
+	values := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column == "created_at" {
+			values = append(values, ":"+createdAtName)
+			continue
+		}
+		values = append(values, ":"+column)
+	}
+
+	return strings.Join(columns, ", "), strings.Join(values, ", ")
+}
+
+// chainAudit 写入哈希链审计时使用的数据，created_at 需要与参与哈希计算的时间一致，所以不使用数据库的当前时间，
+// 而是在会话时区为UTC时写入UTC时间，不受数据库会话时区与服务时区不一致、夏令时切换的影响
+type chainAudit struct {
+	audit.AuditTable `db:",inline"`
+	ChainCreatedAt   string `db:"chain_created_at"`
+}
+
+// BatchCreateWithTx batch create audit with tx, audits are appended to the hash chain of the tenant.
+func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
 	for _, one := range audits {
 		if err := one.CreateValidate(); err != nil {
 			return err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
-	err := d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().BulkInsert(kt.Ctx, sql, audits)
+	if len(audits) == 0 {
+		return nil
+	}
+
+	createdAt, err := d.appendChain(kt, tx, audits)
+	if err != nil {
+		logs.Errorf("append audit hash chain failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	chained := make([]chainAudit, 0, len(audits))
+	for _, one := range audits {
+		chained = append(chained, chainAudit{AuditTable: *one, ChainCreatedAt: createdAt})
+	}
+
+	columns, values := auditInsertExpr(createColumns, "chain_created_at")
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s)`, table.AuditTable, columns, values)
+	err = withUTCSession(kt, tx, func() error {
+		return d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, chained)
+	})
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
 		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
 	}
 
+	if d.Outbox != nil {
+		seqs := make([]uint64, 0, len(audits))
+		for _, one := range audits {
+			seqs = append(seqs, one.Seq)
+		}
+
+		if err = d.Outbox.BatchCreateWithTx(kt, tx, chainTenantID(kt), seqs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// BatchCreateWithTx batch create audit with tx.
-func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
+// appendChain lock the hash chain head of the tenant, set the chain fields of audits, and move the head forward.
+// the head is locked until the transaction ends, so audits of the same tenant are chained in order, which means
+// audit writes of the same tenant are serialized, and the throughput is bounded by the transactions that create
+// audits, so the transactions creating audits should be kept short.
+// returns the created time of audits in UTC and database time layout, it must be written in UTC session.
+func (d Dao) appendChain(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) (string, error) {
+	head, err := d.lockChainHead(kt, tx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
 	for _, one := range audits {
-		if err := one.CreateValidate(); err != nil {
-			return err
+		one.Seq = head.Seq + 1
+		one.PrevHash = head.Hash
+		one.CreatedAt = tabletypes.Time(now.Format(constant.TimeStdFormat))
+
+		hash, err := one.ChainHash()
+		if err != nil {
+			return "", err
 		}
+		one.Hash = hash
+
+		head.Seq, head.Hash = one.Seq, one.Hash
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET seq = :seq, hash = :hash WHERE tenant_id = :tenant_id`,
+		table.AuditChainHeadTable)
+	args := map[string]interface{}{"seq": head.Seq, "hash": head.Hash, "tenant_id": head.TenantID}
+	if _, err := d.Orm.Txn(tx).Update(kt.Ctx, sql, args); err != nil {
+		return "", fmt.Errorf("update audit chain head failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
-	err := d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, audits)
+	return now.Format(constant.DateTimeLayout), nil
+}
+
+// withUTCSession execute fn with the session time zone set to UTC, and restore the session time zone after that.
+// the string of timestamp written in UTC session is taken as UTC time, so the created time of audits written to db
+// is exactly the time in hash calculation, even if the db session time zone is different from the local time zone
+// or in daylight saving time transition.
+func withUTCSession(kt *kit.Kit, tx *sqlx.Tx, fn func() error) error {
+	_, err := tx.ExecContext(kt.Ctx, "SET @hcm_prev_time_zone = @@session.time_zone, time_zone = '+00:00'")
 	if err != nil {
-		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
+		return fmt.Errorf("set session time zone to utc failed, err: %v", err)
 	}
 
-	return nil
+	fnErr := fn()
+
+	if _, err = tx.ExecContext(kt.Ctx, "SET time_zone = @hcm_prev_time_zone"); err != nil {
+		logs.Errorf("restore session time zone failed, err: %v, rid: %s", err, kt.Rid)
+		if fnErr == nil {
+			return fmt.Errorf("restore session time zone failed, err: %v", err)
+		}
+	}
+
+	return fnErr
+}
+
+// unixAudit 查询哈希链审计时使用的数据，created_at 通过 UNIX_TIMESTAMP 读取，与数据库会话时区无关，保证重新计算哈希时
+// 使用的时间与写入时一致
+type unixAudit struct {
+	audit.AuditTable `db:",inline"`
+	ChainCreatedAt   *int64 `db:"chain_created_at"`
+}
+
+// chainCreatedAtExpr select the created_at of audits as unix timestamp.
+const chainCreatedAtExpr = "UNIX_TIMESTAMP(created_at) AS chain_created_at"
+
+// toAudits convert the audits with unix created time to audits, created time is in local time zone.
+func toAudits(list []unixAudit) []audit.AuditTable {
+	audits := make([]audit.AuditTable, 0, len(list))
+	for _, one := range list {
+		if one.ChainCreatedAt != nil {
+			one.CreatedAt = tabletypes.Time(time.Unix(*one.ChainCreatedAt, 0).In(time.Local).
+				Format(constant.TimeStdFormat))
+		}
+		audits = append(audits, one.AuditTable)
+	}
+	return audits
+}
+
+// lockChainHead get the hash chain head of the tenant with exclusive lock, create it if not exists.
+func (d Dao) lockChainHead(kt *kit.Kit, tx *sqlx.Tx) (*audit.ChainHeadTable, error) {
+	tenantID := chainTenantID(kt)
+
+	sql := fmt.Sprintf(`INSERT IGNORE INTO %s (tenant_id, seq, hash) VALUES(:tenant_id, 0, '')`,
+		table.AuditChainHeadTable)
+	if err := d.Orm.Txn(tx).Insert(kt.Ctx, sql, map[string]interface{}{"tenant_id": tenantID}); err != nil {
+		return nil, fmt.Errorf("init audit chain head failed, err: %v", err)
+	}
+
+	sql = fmt.Sprintf(`SELECT tenant_id, seq, hash FROM %s WHERE tenant_id = :tenant_id FOR UPDATE`,
+		table.AuditChainHeadTable)
+	heads := make([]audit.ChainHeadTable, 0)
+	err := d.Orm.Txn(tx).Select(kt.Ctx, &heads, sql, map[string]interface{}{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("lock audit chain head failed, err: %v", err)
+	}
+
+	if len(heads) == 0 {
+		return nil, fmt.Errorf("audit chain head of tenant %s not found", tenantID)
+	}
+
+	return &heads[0], nil
+}
+
+// GetChainHead get the hash chain head of the tenant.
+func (d Dao) GetChainHead(kt *kit.Kit) (*audit.ChainHeadTable, error) {
+	sql := fmt.Sprintf(`SELECT tenant_id, seq, hash, updated_at FROM %s WHERE tenant_id = :tenant_id`,
+		table.AuditChainHeadTable)
+	heads := make([]audit.ChainHeadTable, 0)
+	err := d.Orm.Do().Select(kt.Ctx, &heads, sql, map[string]interface{}{"tenant_id": chainTenantID(kt)})
+	if err != nil {
+		logs.Errorf("get audit chain head failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(heads) == 0 {
+		return &audit.ChainHeadTable{TenantID: chainTenantID(kt)}, nil
+	}
+
+	return &heads[0], nil
+}
+
+// ListBySeq list at most limit audits of the tenant's hash chain whose seq is in [startSeq, endSeq], ordered by seq.
+func (d Dao) ListBySeq(kt *kit.Kit, startSeq, endSeq uint64, limit uint) ([]audit.AuditTable, error) {
+	// 开启多租户时默认租户不注入租户ID，所以显式指定租户ID，只查询该租户哈希链中的审计
+	sql := fmt.Sprintf(`SELECT %s, %s FROM %s WHERE tenant_id = :tenant_id AND seq >= :start_seq AND seq <= :end_seq
+		ORDER BY seq LIMIT :limit`, audit.AuditColumns.NamedExpr(), chainCreatedAtExpr, table.AuditTable)
+	args := map[string]interface{}{
+		"tenant_id": chainTenantID(kt),
+		"start_seq": startSeq,
+		"end_seq":   endSeq,
+		"limit":     limit,
+	}
+
+	audits := make([]unixAudit, 0)
+	if err := d.Orm.Do().Select(kt.Ctx, &audits, sql, args); err != nil {
+		logs.Errorf("list audit by seq failed, err: %v, seq: %d-%d, rid: %s", err, startSeq, endSeq, kt.Rid)
+		return nil, err
+	}
+
+	return toAudits(audits), nil
+}
+
+// ListBySeqs list audits of the tenant's hash chain by seqs, ordered by seq. it reads from primary database, so
+// that audits just created are not taken as missing because of replication lag.
+func (d Dao) ListBySeqs(kt *kit.Kit, seqs []uint64) ([]audit.AuditTable, error) {
+	if len(seqs) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "seqs is required")
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("seq", seqs).SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+	whereValue["tenant_id"] = chainTenantID(kt)
+
+	sql := fmt.Sprintf(`SELECT %s, %s FROM %s %s AND tenant_id = :tenant_id ORDER BY seq`,
+		audit.AuditColumns.NamedExpr(), chainCreatedAtExpr, table.AuditTable, whereExpr)
+	audits := make([]unixAudit, 0, len(seqs))
+	if err := d.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &audits, sql, whereValue); err != nil {
+		logs.Errorf("list audit by seqs failed, err: %v, count: %d, rid: %s", err, len(seqs), kt.Rid)
+		return nil, err
+	}
+
+	return toAudits(audits), nil
+}
+
+func chainTenantID(kt *kit.Kit) string {
+	if len(kt.TenantID) == 0 {
+		return constant.DefaultTenantID
+	}
+
+	return kt.TenantID
 }
 
 // List audit.
@@ -137,14 +375,88 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 		return nil, err
 	}
 
-	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, audit.AuditColumns.FieldsNamedExpr(opt.Fields),
-		table.AuditTable, whereExpr, pageExpr)
+	fieldsExpr := audit.AuditColumns.FieldsNamedExpr(opt.Fields)
+	if len(opt.Fields) == 0 || slice.IsItemInSlice(opt.Fields, "created_at") {
+		// 归档的审计恢复后需要能通过哈希链校验，所以按与会话时区无关的方式读取创建时间
+		fieldsExpr += ", " + chainCreatedAtExpr
+	}
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, fieldsExpr, table.AuditTable, whereExpr, pageExpr)
 
-	details := make([]audit.AuditTable, 0)
+	details := make([]unixAudit, 0)
 	err = d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListAuditDetails{Details: details}, nil
+	return &types.ListAuditDetails{Details: toAudits(details)}, nil
+}
+
+// DeleteByIDs delete audit by ids, used to delete archived audits.
+func (d Dao) DeleteByIDs(kt *kit.Kit, ids []uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("id", ids).SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AuditTable, whereExpr)
+	deleted, err := d.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.Errorf("delete audit failed, err: %v, count: %d, rid: %s", err, len(ids), kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// restoreColumns 恢复已归档审计时写入的列，保留审计原有的ID和创建时间
+var restoreColumns = []string{"id", "res_id", "cloud_res_id", "res_name", "res_type", "action", "bk_biz_id", "vendor",
+	"account_id", "operator", "source", "rid", "app_code", "detail", "seq", "prev_hash", "hash", "tenant_id",
+	"created_at"}
+
+// restoreAudit 恢复已归档审计时使用的数据，created_at 需要转换为UTC的数据库时间格式，在会话时区为UTC时写入
+type restoreAudit struct {
+	audit.AuditTable `db:",inline"`
+	RestoreCreatedAt string `db:"restore_created_at"`
+}
+
+// BatchRestore batch restore archived audits, audits already exist will be ignored.
+func (d Dao) BatchRestore(kt *kit.Kit, audits []audit.AuditTable) error {
+	if len(audits) == 0 {
+		return nil
+	}
+
+	restores := make([]restoreAudit, 0, len(audits))
+	for _, one := range audits {
+		createdAt, err := time.Parse(constant.TimeStdFormat, string(one.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("audit(%d) created_at %s is invalid, err: %v", one.ID, one.CreatedAt, err)
+		}
+
+		one.TenantID = kt.TenantID
+		if one.Detail == nil {
+			one.Detail = new(audit.BasicDetail)
+		}
+		restores = append(restores, restoreAudit{
+			AuditTable:       one,
+			RestoreCreatedAt: createdAt.UTC().Format(constant.DateTimeLayout),
+		})
+	}
+
+	columns, values := auditInsertExpr(restoreColumns, "restore_created_at")
+	sql := fmt.Sprintf(`INSERT IGNORE INTO %s (%s) VALUES(%s)`, table.AuditTable, columns, values)
+	_, err := d.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, withUTCSession(kt, txn, func() error {
+			return d.Orm.Txn(txn).BulkInsert(kt.Ctx, sql, restores)
+		})
+	})
+	if err != nil {
+		logs.Errorf("restore %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
+		return fmt.Errorf("restore %s failed, err: %v", table.AuditTable, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/dal/table/audit"
+)
+
+func TestAuditInsertExpr(t *testing.T) {
+	columns, values := auditInsertExpr([]string{"res_id", "seq", "created_at"}, "chain_created_at")
+	if columns != "res_id, seq, created_at" {
+		t.Errorf("unexpected columns: %s", columns)
+	}
+	if values != ":res_id, :seq, :chain_created_at" {
+		t.Errorf("unexpected values: %s", values)
+	}
+}
+
+func TestCreateColumns(t *testing.T) {
+	// 创建审计时除数据库生成的 id 外，需要写入审计表的所有列
+	expect := make([]string, 0)
+	for _, column := range audit.AuditColumns.Columns() {
+		if column != "id" {
+			expect = append(expect, column)
+		}
+	}
+
+	if !reflect.DeepEqual(createColumns, expect) {
+		t.Errorf("create columns %v is not equal to audit columns %v", createColumns, expect)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// CheckpointInterface define audit checkpoint interface.
+type CheckpointInterface interface {
+	Create(kt *kit.Kit, one *audit.CheckpointTable) (string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditCheckpointDetails, error)
+}
+
+var _ CheckpointInterface = new(CheckpointDao)
+
+// CheckpointDao audit checkpoint dao.
+type CheckpointDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create audit checkpoint.
+func (d CheckpointDao) Create(kt *kit.Kit, one *audit.CheckpointTable) (string, error) {
+	if one == nil {
+		return "", errf.New(errf.InvalidParameter, "audit checkpoint is nil")
+	}
+
+	ids, err := d.IDGen.Batch(kt, table.AuditCheckpointTable, 1)
+	if err != nil {
+		return "", err
+	}
+	one.ID = ids[0]
+	one.TenantID = chainTenantID(kt)
+	one.Creator = kt.User
+
+	if err := one.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s, tenant_id) VALUES(%s, :tenant_id)`, table.AuditCheckpointTable,
+		audit.CheckpointColumns.ColumnExpr(), audit.CheckpointColumns.ColonNameExpr())
+	err = d.Orm.Do().Insert(kt.Ctx, sql, one)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditCheckpointTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.AuditCheckpointTable, err)
+	}
+
+	return one.ID, nil
+}
+
+// List audit checkpoint.
+func (d CheckpointDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditCheckpointDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(audit.CheckpointColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	// 检查点按租户的哈希链维护，显式指定租户ID
+	expr, err := tools.And(opt.Filter, tools.RuleEqual("tenant_id", chainTenantID(kt)))
+	if err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AuditCheckpointTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count audit checkpoint failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListAuditCheckpointDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, audit.CheckpointColumns.FieldsNamedExpr(opt.Fields),
+		table.AuditCheckpointTable, whereExpr, pageExpr)
+
+	details := make([]audit.CheckpointTable, 0)
+	err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListAuditCheckpointDetails{Details: details}, nil
+}
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxOutboxErrorLength 推送失败原因的最大长度，与 last_error 字段长度一致
+const maxOutboxErrorLength = 1024
+
+// OutboxInterface define audit outbox interface, the outbox is operated across tenants by the audit streaming
+// dispatcher, so the tenant id is specified explicitly.
+type OutboxInterface interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, tenantID string, seqs []uint64) error
+	ListPending(kt *kit.Kit, limit uint) ([]audit.OutboxTable, error)
+	DeleteByIDs(kt *kit.Kit, ids []uint64) error
+	Retry(kt *kit.Kit, ids []uint64, reason string, delaySec uint64, maxRetry uint) error
+	ResetFailed(kt *kit.Kit) (int64, error)
+	CountByState(kt *kit.Kit) (map[audit.OutboxState]uint64, error)
+}
+
+var _ OutboxInterface = new(OutboxDao)
+
+// OutboxDao audit outbox dao.
+type OutboxDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx batch create audit outbox with tx, it should be in the same tx with audit creation.
+func (d OutboxDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, tenantID string, seqs []uint64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	outboxes := make([]audit.OutboxTable, 0, len(seqs))
+	for _, seq := range seqs {
+		outboxes = append(outboxes, audit.OutboxTable{TenantID: tenantID, Seq: seq, State: audit.OutboxPending})
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (tenant_id, seq, state) VALUES(:tenant_id, :seq, :state)`,
+		table.AuditOutboxTable)
+	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, outboxes); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditOutboxTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.AuditOutboxTable, err)
+	}
+
+	return nil
+}
+
+// ListPending list the pending audit outbox which reaches the retry time, ordered by id. it reads from primary
+// database, otherwise outbox rows that are already deleted may be delivered again from a lagging replica.
+func (d OutboxDao) ListPending(kt *kit.Kit, limit uint) ([]audit.OutboxTable, error) {
+	sql := fmt.Sprintf(`SELECT id, tenant_id, seq, state, retry_count, next_retry_at, last_error, created_at FROM %s
+		WHERE state = :state AND next_retry_at <= now() ORDER BY id LIMIT :limit`, table.AuditOutboxTable)
+
+	outboxes := make([]audit.OutboxTable, 0)
+	args := map[string]interface{}{"state": audit.OutboxPending, "limit": limit}
+	if err := d.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &outboxes, sql, args); err != nil {
+		logs.Errorf("list pending audit outbox failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return outboxes, nil
+}
+
+// DeleteByIDs delete audit outbox by ids after the audits are delivered.
+func (d OutboxDao) DeleteByIDs(kt *kit.Kit, ids []uint64) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("id", ids).SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AuditOutboxTable, whereExpr)
+	if _, err = d.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.Errorf("delete audit outbox failed, err: %v, count: %d, rid: %s", err, len(ids), kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Retry record the failure of audit outbox, and delay its next delivery. the audit outbox is marked as failed and
+// no longer retried automatically when the retry count reaches the max retry count.
+func (d OutboxDao) Retry(kt *kit.Kit, ids []uint64, reason string, delaySec uint64, maxRetry uint) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("id", ids).SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	if len(reason) > maxOutboxErrorLength {
+		reason = reason[:maxOutboxErrorLength]
+	}
+	whereValue["last_error"] = reason
+	whereValue["delay_sec"] = delaySec
+	whereValue["max_retry"] = maxRetry
+	whereValue["failed"] = audit.OutboxFailed
+	whereValue["pending"] = audit.OutboxPending
+
+	// mysql 按顺序执行赋值，判断状态时 retry_count 已经是加1后的值
+	sql := fmt.Sprintf(`UPDATE %s SET retry_count = retry_count + 1, last_error = :last_error,
+		next_retry_at = DATE_ADD(now(), INTERVAL :delay_sec SECOND),
+		state = IF(retry_count >= :max_retry, :failed, :pending) %s`, table.AuditOutboxTable, whereExpr)
+	if _, err = d.Orm.Do().Update(kt.Ctx, sql, whereValue); err != nil {
+		logs.Errorf("update audit outbox retry failed, err: %v, count: %d, rid: %s", err, len(ids), kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ResetFailed reset the failed audit outbox to pending, so that they can be delivered again.
+func (d OutboxDao) ResetFailed(kt *kit.Kit) (int64, error) {
+	sql := fmt.Sprintf(`UPDATE %s SET state = :pending, retry_count = 0, next_retry_at = now() WHERE state = :failed`,
+		table.AuditOutboxTable)
+	args := map[string]interface{}{"pending": audit.OutboxPending, "failed": audit.OutboxFailed}
+	updated, err := d.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("reset failed audit outbox failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// CountByState count audit outbox group by state.
+func (d OutboxDao) CountByState(kt *kit.Kit) (map[audit.OutboxState]uint64, error) {
+	sql := fmt.Sprintf(`SELECT state, COUNT(*) AS count FROM %s GROUP BY state`, table.AuditOutboxTable)
+
+	counts := make([]struct {
+		State audit.OutboxState `db:"state"`
+		Count uint64            `db:"count"`
+	}, 0)
+	if err := d.Orm.Do().Select(kt.Ctx, &counts, sql, map[string]interface{}{}); err != nil {
+		logs.Errorf("count audit outbox failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[audit.OutboxState]uint64, len(counts))
+	for _, one := range counts {
+		result[one.State] = one.Count
+	}
+
+	return result, nil
+}
@@ -122,7 +122,7 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption)
 	if err != nil {
 		return nil, err
 	}
@@ -144,9 +144,12 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 		return &typesbill.ListAccountBillItemDetails{Count: count}, nil
 	}
 
-	opt.Page.Order = ""
+	if !opt.Page.IsCursor() {
+		// cursor page is ordered by id with the direction of its cursor.
+		opt.Page.Order = ""
+	}
 	opt.Page.Sort = ""
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.CursorPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +179,9 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 			err, shardingOpt.String(), opt, detailIDs, kt.Rid)
 		return nil, err
 	}
-	return &typesbill.ListAccountBillItemDetails{Details: details}, nil
+	// details selected by ids are not ordered, so the cursor is generated by the ordered ids.
+	nextCursor := types.NextCursor(opt.Page, detailIDs[len(detailIDs)-1], len(detailIDs))
+	return &typesbill.ListAccountBillItemDetails{Details: details, NextCursor: nextCursor}, nil
 }
 
 // SumCost sum cost by given filter
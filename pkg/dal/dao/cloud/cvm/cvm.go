@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -56,9 +57,10 @@ var _ Interface = new(Dao)
 
 // Dao cvm dao.
 type Dao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx cvm.
@@ -110,6 +112,11 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tablecvm.Ta
 		return nil, err
 	}
 
+	if err = dao.ResHistory.RecordWithTx(kt, tx, enumor.CvmCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record cvm change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -138,6 +145,15 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := dao.ResHistory.ListResIDsWithTx(kt, txn, enumor.CvmCloudResType, expr)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = dao.touchStatusChangedAt(kt, txn, expr, model.Status); err != nil {
+			return nil, err
+		}
+
 		effected, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -149,6 +165,11 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 			logs.Infof("update cvm, but record not found, sql: %s, rid: %v", sql, kt.Rid)
 		}
 
+		if err = dao.ResHistory.RecordWithTx(kt, txn, enumor.CvmCloudResType, enumor.Update, ids); err != nil {
+			logs.Errorf("record cvm change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -176,6 +197,10 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tabl
 
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
 
+	if err = dao.touchStatusChangedAt(kt, tx, tools.EqualExpression("id", id), model.Status); err != nil {
+		return err
+	}
+
 	toUpdate["id"] = id
 	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
@@ -183,6 +208,38 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tabl
 		return err
 	}
 
+	if err = dao.ResHistory.RecordWithTx(kt, tx, enumor.CvmCloudResType, enumor.Update, []string{id}); err != nil {
+		logs.Errorf("record cvm change history failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// touchStatusChangedAt refresh the status changed time of cvms whose status is going to be changed, it must be
+// called before the status is updated in the same transaction. status is empty means the status is not updated.
+func (dao Dao) touchStatusChangedAt(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression, status string) error {
+	if len(status) == 0 {
+		return nil
+	}
+
+	changedExpr, err := tools.And(expr, tools.RuleNotEqual("status", status))
+	if err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := changedExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET status_changed_at = now() %s`, table.CvmTable, whereExpr)
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.Errorf("update cvm status changed time failed, err: %v, filter: %s, rid: %s", err, changedExpr, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -200,7 +257,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +275,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 		return &types.ListCvmDetails{Count: count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.CursorPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +289,12 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 		return nil, err
 	}
 
-	return &types.ListCvmDetails{Details: details}, nil
+	result := &types.ListCvmDetails{Details: details}
+	if len(details) != 0 {
+		result.NextCursor = types.NextCursor(opt.Page, details[len(details)-1].ID, len(details))
+	}
+
+	return result, nil
 }
 
 // ListWithTx cvm with tx.
@@ -249,7 +311,7 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +329,7 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 		return &types.ListCvmDetails{Count: count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.CursorPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +343,12 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 		return nil, err
 	}
 
-	return &types.ListCvmDetails{Details: details}, nil
+	result := &types.ListCvmDetails{Details: details}
+	if len(details) != 0 {
+		result.NextCursor = types.NextCursor(opt.Page, details[len(details)-1].ID, len(details))
+	}
+
+	return result, nil
 }
 
 // DeleteWithTx cvm.
@@ -295,6 +362,17 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return err
 	}
 
+	// record the snapshot before the cvms are deleted.
+	ids, err := dao.ResHistory.ListResIDsWithTx(kt, tx, enumor.CvmCloudResType, expr)
+	if err != nil {
+		return err
+	}
+
+	if err = dao.ResHistory.RecordWithTx(kt, tx, enumor.CvmCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record cvm change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CvmTable, whereExpr)
 	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -0,0 +1,228 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot 云硬盘快照的Package
+package disksnapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgen "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typessnapshot "hcm/pkg/dal/dao/types/disk-snapshot"
+	"hcm/pkg/dal/table"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DiskSnapshot only used for disk snapshot.
+type DiskSnapshot interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablesnapshot.DiskSnapshotTable) ([]string, error)
+	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tablesnapshot.DiskSnapshotTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typessnapshot.ListDiskSnapshot, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+	UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression, model *tablesnapshot.DiskSnapshotTable) error
+}
+
+var _ DiskSnapshot = new(DiskSnapshotDao)
+
+// DiskSnapshotDao disk snapshot dao.
+type DiskSnapshotDao struct {
+	Orm   orm.Interface
+	IDGen idgen.IDGenInterface
+}
+
+// BatchCreateWithTx create disk snapshot.
+func (dao DiskSnapshotDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tablesnapshot.DiskSnapshotTable) ([]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	tableName := table.DiskSnapshotTable
+	ids, err := dao.IDGen.Batch(kt, tableName, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		models[index].Creator = kt.User
+		models[index].Reviser = kt.User
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
+		tablesnapshot.DiskSnapshotColumns.ColumnExpr(), tablesnapshot.DiskSnapshotColumns.ColonNameExpr())
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, models)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+	}
+
+	return ids, nil
+}
+
+// UpdateByIDWithTx update disk snapshot.
+func (dao DiskSnapshotDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
+	model *tablesnapshot.DiskSnapshotTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	model.Reviser = kt.User
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.DiskSnapshotTable, setExpr)
+
+	toUpdate["id"] = id
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.Errorf("update disk snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List disk snapshot.
+func (dao DiskSnapshotDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*typessnapshot.ListDiskSnapshot, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tablesnapshot.DiskSnapshotColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.DiskSnapshotTable, whereExpr)
+
+		count, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.Errorf("count disk snapshot failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typessnapshot.ListDiskSnapshot{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`,
+		tablesnapshot.DiskSnapshotColumns.FieldsNamedExpr(opt.Fields), table.DiskSnapshotTable, whereExpr,
+		pageExpr)
+
+	details := make([]tablesnapshot.DiskSnapshotTable, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typessnapshot.ListDiskSnapshot{Details: details}, nil
+}
+
+// DeleteWithTx delete disk snapshot.
+func (dao DiskSnapshotDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.DiskSnapshotTable, whereExpr)
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.Errorf("delete disk snapshot failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateWithTx update disk snapshot by filter, used to update fields of multiple snapshots at once.
+func (dao DiskSnapshotDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression,
+	model *tablesnapshot.DiskSnapshotTable) error {
+
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	model.Reviser = kt.User
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, table.DiskSnapshotTable, setExpr, whereExpr)
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(kt.Ctx, sql,
+		tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.Errorf("update disk snapshot failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/cloud"
@@ -57,9 +58,10 @@ var _ Disk = new(DiskDao)
 
 // DiskDao disk dao.
 type DiskDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx 批量创建云盘数据
@@ -112,6 +114,11 @@ func (diskDao DiskDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, disks []*disk
 		return nil, err
 	}
 
+	if err = diskDao.ResHistory.RecordWithTx(kt, tx, enumor.DiskCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record disk change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -136,6 +143,11 @@ func (diskDao DiskDao) Update(kt *kit.Kit, filterExpr *filter.Expression, update
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, table.DiskTable, setExpr, whereExpr)
 
 	_, err = diskDao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := diskDao.ResHistory.ListResIDsWithTx(kt, txn, enumor.DiskCloudResType, filterExpr)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := diskDao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -148,6 +160,11 @@ func (diskDao DiskDao) Update(kt *kit.Kit, filterExpr *filter.Expression, update
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = diskDao.ResHistory.RecordWithTx(kt, txn, enumor.DiskCloudResType, enumor.Update, ids); err != nil {
+			logs.Errorf("record disk change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -175,6 +192,12 @@ func (diskDao DiskDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, diskID string,
 		return err
 	}
 
+	err = diskDao.ResHistory.RecordWithTx(kt, tx, enumor.DiskCloudResType, enumor.Update, []string{diskID})
+	if err != nil {
+		logs.Errorf("record disk change history failed, err: %v, id: %s, rid: %s", err, diskID, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -241,6 +264,17 @@ func (diskDao DiskDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter
 		return err
 	}
 
+	// record the snapshot before the disks are deleted.
+	ids, err := diskDao.ResHistory.ListResIDsWithTx(kt, tx, enumor.DiskCloudResType, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	if err = diskDao.ResHistory.RecordWithTx(kt, tx, enumor.DiskCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record disk change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.DiskTable, whereExpr)
 	_, err = diskDao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/cloud"
@@ -57,9 +58,10 @@ var _ Eip = new(EipDao)
 
 // EipDao eip dao.
 type EipDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx ...
@@ -116,6 +118,12 @@ func (eipDao EipDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, eips []*eip.Eip
 		logs.Errorf("batch create audit failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
 	}
+
+	if err = eipDao.ResHistory.RecordWithTx(kt, tx, enumor.EipCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record eip change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -195,6 +203,12 @@ func (eipDao EipDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, eipID string, up
 		return err
 	}
 
+	err = eipDao.ResHistory.RecordWithTx(kt, tx, enumor.EipCloudResType, enumor.Update, []string{eipID})
+	if err != nil {
+		logs.Errorf("record eip change history failed, err: %v, id: %s, rid: %s", err, eipID, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -222,6 +236,11 @@ func (eipDao EipDao) Update(kt *kit.Kit, filterExpr *filter.Expression, updateDa
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, table.EipTable, setExpr, whereExpr)
 
 	_, err = eipDao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := eipDao.ResHistory.ListResIDsWithTx(kt, txn, enumor.EipCloudResType, filterExpr)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := eipDao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -234,6 +253,11 @@ func (eipDao EipDao) Update(kt *kit.Kit, filterExpr *filter.Expression, updateDa
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = eipDao.ResHistory.RecordWithTx(kt, txn, enumor.EipCloudResType, enumor.Update, ids); err != nil {
+			logs.Errorf("record eip change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -254,6 +278,17 @@ func (eipDao EipDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.E
 		return err
 	}
 
+	// record the snapshot before the eips are deleted.
+	ids, err := eipDao.ResHistory.ListResIDsWithTx(kt, tx, enumor.EipCloudResType, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	if err = eipDao.ResHistory.RecordWithTx(kt, tx, enumor.EipCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record eip change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EipTable, whereExpr)
 	_, err = eipDao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
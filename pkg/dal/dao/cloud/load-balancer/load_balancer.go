@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgen "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	typeslb "hcm/pkg/dal/dao/types/load-balancer"
@@ -56,9 +57,10 @@ var _ LoadBalancerInterface = new(LoadBalancerDao)
 
 // LoadBalancerDao load balancer dao.
 type LoadBalancerDao struct {
-	Orm   orm.Interface
-	IDGen idgen.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgen.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx create load balancer.
@@ -112,6 +114,11 @@ func (dao LoadBalancerDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return nil, err
 	}
 
+	if err = dao.ResHistory.RecordWithTx(kt, tx, enumor.LoadBalancerCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record load balancer change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -139,6 +146,11 @@ func (dao LoadBalancerDao) Update(kt *kit.Kit, expr *filter.Expression, model *t
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := dao.ResHistory.ListResIDsWithTx(kt, txn, enumor.LoadBalancerCloudResType, expr)
+		if err != nil {
+			return nil, err
+		}
+
 		effect, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -150,6 +162,12 @@ func (dao LoadBalancerDao) Update(kt *kit.Kit, expr *filter.Expression, model *t
 			logs.Infof("update load balancer, but record not found, sql: %s, rid: %v", sql, kt.Rid)
 		}
 
+		err = dao.ResHistory.RecordWithTx(kt, txn, enumor.LoadBalancerCloudResType, enumor.Update, ids)
+		if err != nil {
+			logs.Errorf("record load balancer change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -186,6 +204,12 @@ func (dao LoadBalancerDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return err
 	}
 
+	err = dao.ResHistory.RecordWithTx(kt, tx, enumor.LoadBalancerCloudResType, enumor.Update, []string{id})
+	if err != nil {
+		logs.Errorf("record load balancer change history failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -249,6 +273,17 @@ func (dao LoadBalancerDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return err
 	}
 
+	// record the snapshot before the load balancers are deleted.
+	ids, err := dao.ResHistory.ListResIDsWithTx(kt, tx, enumor.LoadBalancerCloudResType, expr)
+	if err != nil {
+		return err
+	}
+
+	if err = dao.ResHistory.RecordWithTx(kt, tx, enumor.LoadBalancerCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record load balancer change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.LoadBalancerTable, whereExpr)
 	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -55,9 +56,10 @@ var _ AwsSGRule = new(AwsSGRuleDao)
 
 // AwsSGRuleDao aws security group rule dao.
 type AwsSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx rule.
@@ -91,6 +93,15 @@ func (dao *AwsSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*cl
 		return nil, err
 	}
 
+	sgIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		sgIDs = append(sgIDs, rule.SecurityGroupID)
+	}
+
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -173,6 +184,12 @@ func (dao *AwsSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.AwsSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update aws security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -184,6 +201,10 @@ func (dao *AwsSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -261,11 +282,21 @@ func (dao *AwsSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AwsSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// 规则变更后对应的安全组需要记录新的变更历史
+		sgIDs, err := listRuleSGIDsWithTx(kt, txn, dao.Orm, table.AwsSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete aws security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
 		}
 
+		if err = recordSGRuleChange(kt, txn, dao.ResHistory, sgIDs); err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -288,11 +319,21 @@ func (dao *AwsSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AwsSecurityGroupRuleTable, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.AwsSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete aws security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -55,9 +56,10 @@ var _ AzureSGRule = new(AzureSGRuleDao)
 
 // AzureSGRuleDao azure security group rule dao.
 type AzureSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx rule.
@@ -91,6 +93,15 @@ func (dao *AzureSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*
 		return nil, err
 	}
 
+	sgIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		sgIDs = append(sgIDs, rule.SecurityGroupID)
+	}
+
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -175,6 +186,12 @@ func (dao *AzureSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.AzureSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update azure security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -186,6 +203,10 @@ func (dao *AzureSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -262,11 +283,21 @@ func (dao *AzureSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AzureSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// 规则变更后对应的安全组需要记录新的变更历史
+		sgIDs, err := listRuleSGIDsWithTx(kt, txn, dao.Orm, table.AzureSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete azure security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
 		}
 
+		if err = recordSGRuleChange(kt, txn, dao.ResHistory, sgIDs); err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -289,11 +320,21 @@ func (dao *AzureSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AzureSecurityGroupRuleTable, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.AzureSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete azure security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
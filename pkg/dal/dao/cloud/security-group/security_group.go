@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -55,9 +56,10 @@ var _ SecurityGroup = new(SecurityGroupDao)
 
 // SecurityGroupDao security group dao.
 type SecurityGroupDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateWithTx sg with tx.
@@ -111,6 +113,11 @@ func (s SecurityGroupDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, sgs []*clo
 		return nil, err
 	}
 
+	if err = s.ResHistory.RecordWithTx(kt, tx, enumor.SecurityGroupCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record security group change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -138,6 +145,11 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, sg.TableName(), setExpr, whereExpr)
 
 	_, err = s.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := s.ResHistory.ListResIDsWithTx(kt, txn, enumor.SecurityGroupCloudResType, expr)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := s.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -150,6 +162,12 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		err = s.ResHistory.RecordWithTx(kt, txn, enumor.SecurityGroupCloudResType, enumor.Update, ids)
+		if err != nil {
+			logs.Errorf("record security group change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -184,6 +202,12 @@ func (s SecurityGroupDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return err
 	}
 
+	err = s.ResHistory.RecordWithTx(kt, tx, enumor.SecurityGroupCloudResType, enumor.Update, []string{id})
+	if err != nil {
+		logs.Errorf("record security group change history failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -312,6 +336,17 @@ func (s SecurityGroupDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Ex
 		return err
 	}
 
+	// record the snapshot before the security groups are deleted.
+	ids, err := s.ResHistory.ListResIDsWithTx(kt, tx, enumor.SecurityGroupCloudResType, expr)
+	if err != nil {
+		return err
+	}
+
+	if err = s.ResHistory.RecordWithTx(kt, tx, enumor.SecurityGroupCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record security group change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SecurityGroupTable, whereExpr)
 	_, err = s.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -341,3 +376,34 @@ func ListSecurityGroup(kt *kit.Kit, ormi orm.Interface, ids []string) (map[strin
 
 	return idSgMap, nil
 }
+
+// listRuleSGIDsWithTx list the security group ids of the rules matched by the where expression.
+func listRuleSGIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, ormi orm.Interface, ruleTable table.Name, whereExpr string,
+	whereValue map[string]interface{}) ([]string, error) {
+
+	sql := fmt.Sprintf(`SELECT DISTINCT security_group_id FROM %s %s`, ruleTable, whereExpr)
+	sgIDs := make([]string, 0)
+	if err := ormi.Txn(tx).Select(kt.Ctx, &sgIDs, sql, whereValue); err != nil {
+		logs.Errorf("list %s security group ids failed, err: %v, rid: %s", ruleTable, err, kt.Rid)
+		return nil, err
+	}
+
+	return sgIDs, nil
+}
+
+// recordSGRuleChange record the change history of the security groups whose rules are changed, the snapshot of
+// security group contains its rules.
+func recordSGRuleChange(kt *kit.Kit, tx *sqlx.Tx, resHistory reshistory.ResChangeHistory, sgIDs []string) error {
+	if len(sgIDs) == 0 {
+		return nil
+	}
+
+	err := resHistory.RecordWithTx(kt, tx, enumor.SecurityGroupCloudResType, enumor.Update, sgIDs)
+	if err != nil {
+		logs.Errorf("record security group rule change history failed, err: %v, sg ids: %v, rid: %s", err, sgIDs,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -56,9 +57,10 @@ var _ TCloudSGRule = new(TCloudSGRuleDao)
 
 // TCloudSGRuleDao tcloud security group rule dao.
 type TCloudSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ResHistory reshistory.ResChangeHistory
 }
 
 // BatchCreateOrUpdateWithTx rule.
@@ -92,6 +94,15 @@ func (dao *TCloudSGRuleDao) BatchCreateOrUpdateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return nil, err
 	}
 
+	sgIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		sgIDs = append(sgIDs, rule.SecurityGroupID)
+	}
+
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -177,6 +188,12 @@ func (dao *TCloudSGRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// 规则变更后对应的安全组需要记录新的变更历史
+		sgIDs, err := listRuleSGIDsWithTx(kt, txn, dao.Orm, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update tcloud security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -188,6 +205,10 @@ func (dao *TCloudSGRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = recordSGRuleChange(kt, txn, dao.ResHistory, sgIDs); err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -222,6 +243,12 @@ func (dao *TCloudSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update tcloud security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -233,6 +260,10 @@ func (dao *TCloudSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -357,11 +388,21 @@ func (dao *TCloudSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// 规则变更后对应的安全组需要记录新的变更历史
+		sgIDs, err := listRuleSGIDsWithTx(kt, txn, dao.Orm, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete tcloud security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
 		}
 
+		if err = recordSGRuleChange(kt, txn, dao.ResHistory, sgIDs); err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -384,11 +425,21 @@ func (dao *TCloudSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
 
+	// 规则变更后对应的安全组需要记录新的变更历史
+	sgIDs, err := listRuleSGIDsWithTx(kt, tx, dao.Orm, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete tcloud security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
 	}
 
+	if err = recordSGRuleChange(kt, tx, dao.ResHistory, sgIDs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
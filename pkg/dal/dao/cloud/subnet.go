@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,17 +55,21 @@ var _ Subnet = new(subnetDao)
 
 // subnetDao subnet dao.
 type subnetDao struct {
-	orm   orm.Interface
-	idGen idgenerator.IDGenInterface
-	audit audit.Interface
+	orm        orm.Interface
+	idGen      idgenerator.IDGenInterface
+	audit      audit.Interface
+	resHistory reshistory.ResChangeHistory
 }
 
 // NewSubnetDao create a subnet dao.
-func NewSubnetDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface) Subnet {
+func NewSubnetDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface,
+	resHistory reshistory.ResChangeHistory) Subnet {
+
 	return &subnetDao{
-		orm:   orm,
-		idGen: idGen,
-		audit: audit,
+		orm:        orm,
+		idGen:      idGen,
+		audit:      audit,
+		resHistory: resHistory,
 	}
 }
 
@@ -132,6 +137,11 @@ func (s *subnetDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []cloud.S
 		return nil, err
 	}
 
+	if err = s.resHistory.RecordWithTx(kt, tx, enumor.SubnetCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record subnet change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -160,6 +170,11 @@ func (s *subnetDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = s.orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := s.resHistory.ListResIDsWithTx(kt, txn, enumor.SubnetCloudResType, filterExpr)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := s.orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -172,6 +187,11 @@ func (s *subnetDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cl
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = s.resHistory.RecordWithTx(kt, txn, enumor.SubnetCloudResType, enumor.Update, ids); err != nil {
+			logs.Errorf("record subnet change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -252,6 +272,17 @@ func (s *subnetDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filt
 		return err
 	}
 
+	// record the snapshot before the subnets are deleted.
+	ids, err := s.resHistory.ListResIDsWithTx(kt, tx, enumor.SubnetCloudResType, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	if err = s.resHistory.RecordWithTx(kt, tx, enumor.SubnetCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record subnet change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SubnetTable, whereExpr)
 	_, err = s.orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
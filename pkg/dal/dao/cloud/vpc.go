@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	reshistory "hcm/pkg/dal/dao/res-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -53,17 +54,21 @@ var _ Vpc = new(vpcDao)
 
 // vpcDao vpc dao.
 type vpcDao struct {
-	orm   orm.Interface
-	idGen idgenerator.IDGenInterface
-	audit audit.Interface
+	orm        orm.Interface
+	idGen      idgenerator.IDGenInterface
+	audit      audit.Interface
+	resHistory reshistory.ResChangeHistory
 }
 
 // NewVpcDao create a vpc dao.
-func NewVpcDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface) Vpc {
+func NewVpcDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface,
+	resHistory reshistory.ResChangeHistory) Vpc {
+
 	return &vpcDao{
-		orm:   orm,
-		idGen: idGen,
-		audit: audit,
+		orm:        orm,
+		idGen:      idGen,
+		audit:      audit,
+		resHistory: resHistory,
 	}
 }
 
@@ -123,6 +128,11 @@ func (v *vpcDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []cloud.VpcT
 		return nil, err
 	}
 
+	if err = v.resHistory.RecordWithTx(kt, tx, enumor.VpcCloudResType, enumor.Create, ids); err != nil {
+		logs.Errorf("record vpc change history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
 	return ids, nil
 }
 
@@ -150,6 +160,11 @@ func (v *vpcDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cloud
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = v.orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := v.resHistory.ListResIDsWithTx(kt, txn, enumor.VpcCloudResType, filterExpr)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := v.orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(txn).Update(
 			kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
@@ -162,6 +177,11 @@ func (v *vpcDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cloud
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = v.resHistory.RecordWithTx(kt, txn, enumor.VpcCloudResType, enumor.Update, ids); err != nil {
+			logs.Errorf("record vpc change history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -241,6 +261,17 @@ func (v *vpcDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.
 		return err
 	}
 
+	// record the snapshot before the vpcs are deleted.
+	ids, err := v.resHistory.ListResIDsWithTx(kt, tx, enumor.VpcCloudResType, filterExpr)
+	if err != nil {
+		return err
+	}
+
+	if err = v.resHistory.RecordWithTx(kt, tx, enumor.VpcCloudResType, enumor.Delete, ids); err != nil {
+		logs.Errorf("record vpc change history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.VpcTable, whereExpr)
 	_, err = v.orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -27,8 +27,11 @@ import (
 	"time"
 
 	"hcm/pkg/cc"
+	accesstoken "hcm/pkg/dal/dao/access-token"
 	accountset "hcm/pkg/dal/dao/account-set"
+	"hcm/pkg/dal/dao/aggregate"
 	"hcm/pkg/dal/dao/application"
+	assignrule "hcm/pkg/dal/dao/assign-rule"
 	daoasync "hcm/pkg/dal/dao/async"
 	"hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/auth"
@@ -41,6 +44,7 @@ import (
 	"hcm/pkg/dal/dao/cloud/cvm"
 	"hcm/pkg/dal/dao/cloud/disk"
 	diskcvmrel "hcm/pkg/dal/dao/cloud/disk-cvm-rel"
+	disksnapshot "hcm/pkg/dal/dao/cloud/disk-snapshot"
 	"hcm/pkg/dal/dao/cloud/eip"
 	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
 	cimage "hcm/pkg/dal/dao/cloud/image"
@@ -59,8 +63,14 @@ import (
 	"hcm/pkg/dal/dao/cloud/zone"
 	globalconfig "hcm/pkg/dal/dao/global-config"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/idempotency"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/rbac"
+	"hcm/pkg/dal/dao/recommendation"
 	recyclerecord "hcm/pkg/dal/dao/recycle-record"
+	resevent "hcm/pkg/dal/dao/res-event"
+	reshistory "hcm/pkg/dal/dao/res-history"
+	resmetric "hcm/pkg/dal/dao/res-metric"
 	"hcm/pkg/dal/dao/task"
 	"hcm/pkg/dal/dao/tenant"
 	daouser "hcm/pkg/dal/dao/user"
@@ -74,6 +84,9 @@ import (
 // Set defines all the DAO to be operated.
 type Set interface {
 	Audit() audit.Interface
+	AuditArchive() audit.ArchiveInterface
+	AuditCheckpoint() audit.CheckpointInterface
+	AuditOutbox() audit.OutboxInterface
 	Auth() auth.Auth
 	Account() cloud.Account
 	SubAccount() daosubaccount.SubAccount
@@ -94,6 +107,7 @@ type Set interface {
 	Zone() zone.Zone
 	AccountSyncDetail() daosync.AccountSyncDetail
 	TCloudRegion() region.TCloudRegion
+	AliyunRegion() region.AliyunRegion
 	AwsRegion() region.AwsRegion
 	GcpRegion() region.GcpRegion
 	Cvm() cvm.Interface
@@ -145,32 +159,101 @@ type Set interface {
 	GlobalConfig() globalconfig.Interface
 	ResUsageBizRel() cloud.ResUsageBizRel
 	Tenant() tenant.Tenant
+	ResMetricDaily() resmetric.ResMetricDaily
+	ResRecommendation() recommendation.ResRecommendation
+	DiskSnapshot() disksnapshot.DiskSnapshot
+	DiskSnapshotPolicy() disksnapshot.DiskSnapshotPolicy
+	Aggregate() aggregate.Aggregate
+	ResChangeHistory() reshistory.ResChangeHistory
+	RbacRole() rbac.Role
+	RbacRoleBinding() rbac.RoleBinding
+	ServiceAccount() accesstoken.ServiceAccount
+	AccessToken() accesstoken.AccessToken
+	AssignRule() assignrule.AssignRule
+	ResEvent() resevent.ResEvent
+	EventSubscription() resevent.EventSubscription
+	EventDelivery() resevent.EventDelivery
+	IdempotencyRecord() idempotency.Record
 
 	Txn() *Txn
 }
 
 // NewDaoSet create the DAO set instance.
-func NewDaoSet(opt cc.DataBase) (Set, error) {
+func NewDaoSet(opt cc.DataBase, options ...Option) (Set, error) {
+	setOpt := new(setOption)
+	for _, one := range options {
+		one(setOpt)
+	}
+
+	if err := reshistory.ValidateConf(opt.ResChangeHistory); err != nil {
+		return nil, fmt.Errorf("database resChangeHistory is invalid, %v", err)
+	}
+
 	db, err := connect(opt.Resource)
 	if err != nil {
 		return nil, fmt.Errorf("init sharding failed, err: %v", err)
 	}
 
+	replicas := make([]orm.Replica, 0, len(opt.Replicas))
+	for _, one := range opt.Replicas {
+		replicaDB, err := connect(one.ResourceDB)
+		if err != nil {
+			return nil, fmt.Errorf("connect to replica %v failed, err: %v", one.Endpoints, err)
+		}
+
+		replicas = append(replicas, orm.Replica{
+			Name:   "replica-" + strings.Join(one.Endpoints, ","),
+			DB:     replicaDB,
+			Weight: one.Weight,
+		})
+	}
+
 	ormInst := orm.InitOrm(db, orm.MetricsRegisterer(metrics.Register()),
-		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS))
+		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS),
+		orm.Replicas(replicas...), orm.ReplicaHealthCheckIntervalSec(opt.ReplicaHealthCheckIntervalSec),
+		orm.ReadAfterWriteStickySec(opt.ReadAfterWriteStickySec))
+
+	idGen := idgenerator.NewWithConfig(db, idgenerator.DefaultMaxRetryCount, opt.IDGenerator)
 
-	idGen := idgenerator.New(db, idgenerator.DefaultMaxRetryCount)
+	resHistory := &reshistory.ResChangeHistoryDao{Orm: ormInst, IDGen: idGen, Conf: opt.ResChangeHistory}
+	if setOpt.resEvent {
+		resHistory.Event = &resevent.ResEventDao{Orm: ormInst}
+	}
 
 	s := &set{
-		idGen: idGen,
-		orm:   ormInst,
-		db:    db,
-		audit: audit.NewAudit(ormInst),
+		idGen:      idGen,
+		orm:        ormInst,
+		db:         db,
+		audit:      audit.NewAudit(ormInst, setOpt.auditOutbox),
+		resHistory: resHistory,
 	}
 
 	return s, nil
 }
 
+// Option defines the option of dao set.
+type Option func(opt *setOption)
+
+type setOption struct {
+	auditOutbox bool
+	resEvent    bool
+}
+
+// WithAuditOutbox write created audits into the outbox in the same transaction, so that they can be streamed.
+func WithAuditOutbox(enable bool) Option {
+	return func(opt *setOption) {
+		opt.auditOutbox = enable
+	}
+}
+
+// WithResEvent write res change events in the same transaction with res change history, so that they can be
+// delivered to the event subscriptions.
+func WithResEvent(enable bool) Option {
+	return func(opt *setOption) {
+		opt.resEvent = enable
+	}
+}
+
 // connect to mysql
 func connect(opt cc.ResourceDB) (*sqlx.DB, error) {
 	db, err := sqlx.Connect("mysql", uri(opt))
@@ -202,10 +285,11 @@ func uri(opt cc.ResourceDB) string {
 }
 
 type set struct {
-	idGen idgenerator.IDGenInterface
-	orm   orm.Interface
-	db    *sqlx.DB
-	audit audit.Interface
+	idGen      idgenerator.IDGenInterface
+	orm        orm.Interface
+	db         *sqlx.DB
+	audit      audit.Interface
+	resHistory reshistory.ResChangeHistory
 }
 
 // EipCvmRel return EipCvmRel dao.
@@ -247,18 +331,20 @@ func (s *set) NiCvmRel() nicvmrel.NiCvmRel {
 // Disk return Disk dao.
 func (s *set) Disk() disk.Disk {
 	return &disk.DiskDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
 // Eip return Eip dao.
 func (s *set) Eip() eip.Eip {
 	return &eip.EipDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
@@ -329,12 +415,12 @@ func (s *set) AccountBizRel() cloud.AccountBizRel {
 
 // Vpc returns vpc dao.
 func (s *set) Vpc() cloud.Vpc {
-	return cloud.NewVpcDao(s.orm, s.idGen, s.audit)
+	return cloud.NewVpcDao(s.orm, s.idGen, s.audit, s.resHistory)
 }
 
 // Subnet returns subnet dao.
 func (s *set) Subnet() cloud.Subnet {
-	return cloud.NewSubnetDao(s.orm, s.idGen, s.audit)
+	return cloud.NewSubnetDao(s.orm, s.idGen, s.audit, s.resHistory)
 }
 
 // Auth return auth dao.
@@ -354,9 +440,10 @@ func (s *set) Cloud() cloud.Cloud {
 // SecurityGroup return security group dao.
 func (s *set) SecurityGroup() securitygroup.SecurityGroup {
 	return &securitygroup.SecurityGroupDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
@@ -370,9 +457,10 @@ func (s *set) SGCvmRel() sgcvmrel.Interface {
 // TCloudSGRule return tcloud security group rule dao.
 func (s *set) TCloudSGRule() securitygroup.TCloudSGRule {
 	return &securitygroup.TCloudSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
@@ -388,36 +476,40 @@ func (s *set) GcpFirewallRule() cloud.GcpFirewallRule {
 // AwsSGRule return aws security group rule dao.
 func (s *set) AwsSGRule() securitygroup.AwsSGRule {
 	return &securitygroup.AwsSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
 // HuaWeiSGRule return huawei security group rule dao.
 func (s *set) HuaWeiSGRule() securitygroup.HuaWeiSGRule {
 	return &securitygroup.HuaWeiSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
 // AzureSGRule return azure security group rule dao.
 func (s *set) AzureSGRule() securitygroup.AzureSGRule {
 	return &securitygroup.AzureSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
 // Cvm return cvm dao.
 func (s *set) Cvm() cvm.Interface {
 	return &cvm.Dao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
@@ -426,6 +518,11 @@ func (s *set) TCloudRegion() region.TCloudRegion {
 	return region.NewTCloudRegionDao(s.orm, s.idGen)
 }
 
+// AliyunRegion returns aliyun region dao.
+func (s *set) AliyunRegion() region.AliyunRegion {
+	return region.NewAliyunRegionDao(s.orm, s.idGen)
+}
+
 // AwsRegion returns aws region dao.
 func (s *set) AwsRegion() region.AwsRegion {
 	return region.NewAwsRegionDao(s.orm, s.idGen)
@@ -446,6 +543,27 @@ func (s *set) Route() routetable.Route {
 	return routetable.NewRouteDao(s.orm, s.idGen, s.audit)
 }
 
+// AuditArchive return audit archive dao.
+func (s *set) AuditArchive() audit.ArchiveInterface {
+	return &audit.ArchiveDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AuditCheckpoint return audit checkpoint dao.
+func (s *set) AuditCheckpoint() audit.CheckpointInterface {
+	return &audit.CheckpointDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AuditOutbox return audit outbox dao.
+func (s *set) AuditOutbox() audit.OutboxInterface {
+	return &audit.OutboxDao{Orm: s.orm}
+}
+
 // Audit return audit dao.
 func (s *set) Audit() audit.Interface {
 	return s.audit
@@ -665,9 +783,10 @@ func (s *set) Cert() cert.Interface {
 // LoadBalancer return load balancer dao.
 func (s *set) LoadBalancer() loadbalancer.LoadBalancerInterface {
 	return &loadbalancer.LoadBalancerDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ResHistory: s.resHistory,
 	}
 }
 
@@ -782,9 +901,122 @@ func (s *set) GlobalConfig() globalconfig.Interface {
 	}
 }
 
+// ResMetricDaily return res metric daily dao.
+func (s *set) ResMetricDaily() resmetric.ResMetricDaily {
+	return &resmetric.ResMetricDailyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResRecommendation return res recommendation dao.
+func (s *set) ResRecommendation() recommendation.ResRecommendation {
+	return &recommendation.ResRecommendationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// DiskSnapshot return disk snapshot dao.
+func (s *set) DiskSnapshot() disksnapshot.DiskSnapshot {
+	return &disksnapshot.DiskSnapshotDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// DiskSnapshotPolicy return disk snapshot policy dao.
+func (s *set) DiskSnapshotPolicy() disksnapshot.DiskSnapshotPolicy {
+	return &disksnapshot.DiskSnapshotPolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Aggregate return aggregate dao.
+func (s *set) Aggregate() aggregate.Aggregate {
+	return &aggregate.Dao{
+		Orm: s.orm,
+	}
+}
+
+// ResChangeHistory return res change history dao.
+func (s *set) ResChangeHistory() reshistory.ResChangeHistory {
+	return s.resHistory
+}
+
+// RbacRole return local rbac role dao.
+func (s *set) RbacRole() rbac.Role {
+	return &rbac.RoleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RbacRoleBinding return local rbac role binding dao.
+func (s *set) RbacRoleBinding() rbac.RoleBinding {
+	return &rbac.RoleBindingDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ServiceAccount return service account dao.
+func (s *set) ServiceAccount() accesstoken.ServiceAccount {
+	return &accesstoken.ServiceAccountDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccessToken return access token dao.
+func (s *set) AccessToken() accesstoken.AccessToken {
+	return &accesstoken.AccessTokenDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AssignRule return assign rule dao.
+func (s *set) AssignRule() assignrule.AssignRule {
+	return &assignrule.AssignRuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // ResUsageBizRel return resource biz relation dao.
 func (s *set) ResUsageBizRel() cloud.ResUsageBizRel {
 	return &cloud.ResUsageBizRelDao{
 		Orm: s.orm,
 	}
 }
+
+// ResEvent return res event dao.
+func (s *set) ResEvent() resevent.ResEvent {
+	return &resevent.ResEventDao{
+		Orm: s.orm,
+	}
+}
+
+// EventSubscription return event subscription dao.
+func (s *set) EventSubscription() resevent.EventSubscription {
+	return &resevent.EventSubscriptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// EventDelivery return event delivery dao.
+func (s *set) EventDelivery() resevent.EventDelivery {
+	return &resevent.EventDeliveryDao{
+		Orm: s.orm,
+	}
+}
+
+// IdempotencyRecord return idempotency record dao.
+func (s *set) IdempotencyRecord() idempotency.Record {
+	return &idempotency.RecordDao{
+		Orm: s.orm,
+	}
+}
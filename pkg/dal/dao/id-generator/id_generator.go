@@ -75,68 +75,84 @@ func (ig idGenerator) Batch(kt *kit.Kit, resource table.Name, count int) ([]stri
 		return nil, err
 	}
 
+	maxID, err := ig.lease(kt, resource, uint64(count))
+	if err != nil {
+		return nil, err
+	}
+
+	// generate the id list that can be used.
+	ids := make([]string, count)
+	for idx := 0; idx < count; idx++ {
+		ids[idx] = formatID(maxID + uint64(idx+1))
+	}
+
+	return ids, nil
+}
+
+// lease move the max id of resource forward by count with row lock of id_generator table, the ids in range
+// (returned max id, returned max id + count] are leased to the caller.
+func (ig idGenerator) lease(kt *kit.Kit, resource table.Name, count uint64) (uint64, error) {
 	txn, err := ig.db.BeginTx(kt.Ctx, new(sql.TxOptions))
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but begin txn failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but begin txn failed, err: %v", resource, err)
 	}
+	// rollback is a no-op after the transaction is committed.
+	defer txn.Rollback()
 
 	// get current max id
 	queryExpr := fmt.Sprintf(`SELECT max_id from id_generator WHERE resource = "%s" FOR UPDATE`, resource)
 
 	rows, err := txn.QueryContext(kt.Ctx, queryExpr)
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but query max id failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but query max id failed, err: %v", resource, err)
 	}
 
 	var maxIDStr string
 	for rows.Next() {
 		if err := rows.Scan(&maxIDStr); err != nil {
-			return nil, fmt.Errorf("gen %s unique id, but scan max id failed, err: %v", resource, err)
+			return 0, fmt.Errorf("gen %s unique id, but scan max id failed, err: %v", resource, err)
 		}
 		break
 	}
 
 	err = rows.Close()
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but close rows failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but close rows failed, err: %v", resource, err)
 	}
 
 	// generate new max id and update it
 	maxID, err := strconv.ParseUint(maxIDStr, 36, 64)
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but parse max id failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but parse max id failed, err: %v", resource, err)
 	}
 
-	newMaxID := strconv.FormatUint(maxID+uint64(count), 36)
-	newMaxID = fmt.Sprintf("%08s", newMaxID)
+	newMaxID := formatID(maxID + count)
 
 	updateExpr := fmt.Sprintf(`UPDATE id_generator SET max_id = "%s" WHERE resource = "%s" AND max_id = "%s"`,
 		newMaxID, resource, maxIDStr)
 
 	result, err := txn.ExecContext(kt.Ctx, updateExpr)
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but update max_id failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but update max_id failed, err: %v", resource, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but get rows affected failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but get rows affected failed, err: %v", resource, err)
 	}
 
 	if rowsAffected != 1 {
-		return nil, fmt.Errorf("gen %s unique id, but rows affected %d is not 1", resource, rowsAffected)
+		return 0, fmt.Errorf("gen %s unique id, but rows affected %d is not 1", resource, rowsAffected)
 	}
 
 	if err := txn.Commit(); err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but commit failed, err: %v", resource, err)
+		return 0, fmt.Errorf("gen %s unique id, but commit failed, err: %v", resource, err)
 	}
 
-	// generate the id list that can be used.
-	ids := make([]string, count)
-	for idx := 0; idx < count; idx++ {
-		id := maxID + uint64(idx+1)
-		ids[idx] = fmt.Sprintf("%08s", strconv.FormatUint(id, 36))
-	}
+	return maxID, nil
+}
 
-	return ids, nil
+// formatID format the id as base-36 string with at least 8 characters.
+func formatID(id uint64) string {
+	return fmt.Sprintf("%08s", strconv.FormatUint(id, 36))
 }
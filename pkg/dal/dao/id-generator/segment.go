@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idgenerator
+
+import (
+	"sync"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// NewWithConfig create an id generator instance which generates ids of each resource with the configured mode.
+func NewWithConfig(db *sqlx.DB, retryCount int, conf cc.IDGenerator) IDGenInterface {
+	tableGen := &idGenerator{db: db, maxRetryCount: retryCount}
+
+	newGen := func(mode cc.IDGenMode, segmentSize uint) IDGenInterface {
+		if mode == cc.SegmentIDGenMode {
+			return newSegmentGenerator(tableGen.lease, segmentSize)
+		}
+		return tableGen
+	}
+
+	r := &router{
+		defaultGen: newGen(conf.Mode, conf.SegmentSize),
+		resources:  make(map[table.Name]IDGenInterface, len(conf.Resources)),
+	}
+	for name, one := range conf.Resources {
+		r.resources[table.Name(name)] = newGen(one.Mode, one.SegmentSize)
+	}
+
+	for name := range orderedIDResources {
+		mode := conf.Mode
+		if one, exists := conf.Resources[string(name)]; exists {
+			mode = one.Mode
+		}
+		if mode == cc.SegmentIDGenMode {
+			logs.Warnf("id generator segment mode is not supported by %s whose ids must be increasing, use table mode",
+				name)
+		}
+		r.resources[name] = tableGen
+	}
+
+	return r
+}
+
+// orderedIDResources are the resources paginated by id cursor(types.CursorPageSQLOption) or traversed by
+// id > last id(tools.RuleIDGreaterThan), which requires ids to be increasing in the order of creation. segment mode
+// only keeps ids increasing in one process, rows created later by another process may get smaller ids and be
+// skipped by the cursor, so these resources always use table mode.
+// cvm: cursor pagination of cvm list. account_bill_item: cursor pagination and export of bill items.
+var orderedIDResources = map[table.Name]struct{}{
+	table.CvmTable:             {},
+	table.AccountBillItemTable: {},
+}
+
+// router route the id generation of resource to the generator of its configured mode.
+type router struct {
+	defaultGen IDGenInterface
+	resources  map[table.Name]IDGenInterface
+}
+
+func (r *router) generator(resource table.Name) IDGenInterface {
+	if gen, exists := r.resources[resource]; exists {
+		return gen
+	}
+	return r.defaultGen
+}
+
+// Batch generate unique resource id list by the generator of the resource.
+func (r *router) Batch(kt *kit.Kit, resource table.Name, count int) ([]string, error) {
+	return r.generator(resource).Batch(kt, resource, count)
+}
+
+// One generate one unique resource id by the generator of the resource.
+func (r *router) One(kt *kit.Kit, resource table.Name) (string, error) {
+	return r.generator(resource).One(kt, resource)
+}
+
+// leaseFunc lease count ids of resource, returns the max id before leasing.
+type leaseFunc func(kt *kit.Kit, resource table.Name, count uint64) (uint64, error)
+
+// segmentGenerator leases a segment of ids from id_generator table at once and allocates ids from the segment in
+// memory, so that bulk inserts do not need to lock the id_generator row every time. ids not used before the process
+// exits are skipped, which leaves gaps in ids but never duplicates.
+type segmentGenerator struct {
+	lease leaseFunc
+	size  uint64
+
+	lock     sync.Mutex
+	segments map[table.Name]*segment
+}
+
+// segment is the leased id range (next-1, max] of a resource.
+type segment struct {
+	lock sync.Mutex
+	next uint64
+	max  uint64
+}
+
+func newSegmentGenerator(lease leaseFunc, size uint) *segmentGenerator {
+	return &segmentGenerator{
+		lease:    lease,
+		size:     uint64(max(size, 1)),
+		segments: make(map[table.Name]*segment),
+	}
+}
+
+func (sg *segmentGenerator) segment(resource table.Name) *segment {
+	sg.lock.Lock()
+	defer sg.lock.Unlock()
+
+	seg, exists := sg.segments[resource]
+	if !exists {
+		seg = &segment{next: 1, max: 0}
+		sg.segments[resource] = seg
+	}
+
+	return seg
+}
+
+// Batch allocate ids from the leased segment of resource, a new segment is leased when the segment is used up.
+func (sg *segmentGenerator) Batch(kt *kit.Kit, resource table.Name, count int) ([]string, error) {
+	if err := resource.Validate(); err != nil {
+		return nil, err
+	}
+
+	seg := sg.segment(resource)
+	seg.lock.Lock()
+	defer seg.lock.Unlock()
+
+	ids := make([]string, 0, count)
+	for len(ids) < count {
+		if seg.next > seg.max {
+			// lease at least the count still needed, so that a large batch only leases once.
+			leaseCount := max(uint64(count-len(ids)), sg.size)
+			maxID, err := sg.lease(kt, resource, leaseCount)
+			if err != nil {
+				return nil, err
+			}
+			seg.next, seg.max = maxID+1, maxID+leaseCount
+		}
+
+		ids = append(ids, formatID(seg.next))
+		seg.next++
+	}
+
+	return ids, nil
+}
+
+// One allocate one id from the leased segment of resource.
+func (sg *segmentGenerator) One(kt *kit.Kit, resource table.Name) (string, error) {
+	ids, err := sg.Batch(kt, resource, 1)
+	if err != nil {
+		return "", err
+	}
+
+	return ids[0], nil
+}
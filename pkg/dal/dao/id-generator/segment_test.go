@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+)
+
+func TestSegmentGeneratorBatch(t *testing.T) {
+	var lock sync.Mutex
+	var maxID uint64
+	leaseTimes := 0
+	lease := func(kt *kit.Kit, resource table.Name, count uint64) (uint64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		leaseTimes++
+		old := maxID
+		maxID += count
+		return old, nil
+	}
+
+	gen := newSegmentGenerator(lease, 10)
+
+	ids, err := gen.Batch(kit.New(), table.AccountTable, 3)
+	if err != nil {
+		t.Fatalf("batch generate ids failed, err: %v", err)
+	}
+	if ids[0] != "00000001" || ids[2] != "00000003" || leaseTimes != 1 {
+		t.Errorf("unexpected ids: %v, lease times: %d", ids, leaseTimes)
+	}
+
+	// 7 ids left in the segment, the rest 13 ids are leased at once.
+	ids, err = gen.Batch(kit.New(), table.AccountTable, 20)
+	if err != nil {
+		t.Fatalf("batch generate ids failed, err: %v", err)
+	}
+	if len(ids) != 20 || ids[0] != "00000004" || ids[19] != "0000000n" || leaseTimes != 2 {
+		t.Errorf("unexpected ids: %v, lease times: %d", ids, leaseTimes)
+	}
+
+	// concurrent generation never returns duplicated ids.
+	seen := sync.Map{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids, err := gen.Batch(kit.New(), table.AccountTable, 7)
+			if err != nil {
+				t.Errorf("batch generate ids failed, err: %v", err)
+				return
+			}
+			for _, id := range ids {
+				if _, loaded := seen.LoadOrStore(id, struct{}{}); loaded {
+					t.Errorf("id %s is duplicated", id)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewWithConfigOrderedIDResources(t *testing.T) {
+	conf := cc.IDGenerator{
+		Mode:        cc.SegmentIDGenMode,
+		SegmentSize: 1000,
+		Resources: map[string]cc.IDGeneratorResource{
+			string(table.AccountBillItemTable): {Mode: cc.SegmentIDGenMode, SegmentSize: 5000},
+		},
+	}
+	r := NewWithConfig(nil, 1, conf).(*router)
+
+	for _, name := range []table.Name{table.CvmTable, table.AccountBillItemTable} {
+		if _, ok := r.generator(name).(*idGenerator); !ok {
+			t.Errorf("%s should use table mode, but got %T", name, r.generator(name))
+		}
+	}
+
+	if _, ok := r.generator(table.AccountTable).(*segmentGenerator); !ok {
+		t.Errorf("%s should use segment mode, but got %T", table.AccountTable, r.generator(table.AccountTable))
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency 幂等请求记录
+package idempotency
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	tableidem "hcm/pkg/dal/table/idempotency"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Record define idempotency record interface, records are isolated by the tenant id of kit, and the expired records
+// are cleaned across tenants.
+type Record interface {
+	// Acquire try to acquire the idempotency key for the request, returns nil if the key is acquired, otherwise
+	// returns the record which holds the key. Only the expired record can be taken over by the request, the
+	// processing record which reaches the lock timeout is marked as unknown, because it may have been succeeded.
+	Acquire(kt *kit.Kit, opt *AcquireOption) (*tableidem.RecordTable, error)
+	// Complete record the response of the request which holds the idempotency key, the record in unknown state can
+	// also be completed by the request which holds it.
+	Complete(kt *kit.Kit, scope, key, requestHash, response string) error
+	// Release delete the uncompleted record of the request which holds the idempotency key, so that the key can be
+	// reused, it is used when the request is failed.
+	Release(kt *kit.Kit, scope, key, requestHash string) error
+	// DeleteExpired delete the expired records of all tenants, at most limit records are deleted once.
+	DeleteExpired(kt *kit.Kit, limit uint) (int64, error)
+}
+
+// AcquireOption is option to acquire idempotency key.
+type AcquireOption struct {
+	Scope          string
+	Key            string
+	RequestHash    string
+	TTLSec         uint64
+	LockTimeoutSec uint64
+}
+
+// Validate AcquireOption.
+func (opt *AcquireOption) Validate() error {
+	if len(opt.Scope) == 0 || len(opt.Key) == 0 || len(opt.RequestHash) == 0 {
+		return errf.New(errf.InvalidParameter, "scope, key and request_hash are required")
+	}
+
+	if opt.TTLSec == 0 || opt.LockTimeoutSec == 0 {
+		return errf.New(errf.InvalidParameter, "ttl_sec and lock_timeout_sec are required")
+	}
+
+	return nil
+}
+
+var _ Record = new(RecordDao)
+
+// RecordDao idempotency record dao.
+type RecordDao struct {
+	Orm orm.Interface
+}
+
+// Acquire try to acquire the idempotency key for the request.
+func (dao RecordDao) Acquire(kt *kit.Kit, opt *AcquireOption) (*tableidem.RecordTable, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "acquire option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	args := map[string]interface{}{
+		"tenant_id":        tenantID(kt),
+		"scope":            opt.Scope,
+		"idem_key":         opt.Key,
+		"request_hash":     opt.RequestHash,
+		"state":            enumor.IdempotencyProcessing,
+		"ttl_sec":          opt.TTLSec,
+		"lock_timeout_sec": opt.LockTimeoutSec,
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (tenant_id, scope, idem_key, request_hash, state, lock_expired_at, expired_at)
+		VALUES(:tenant_id, :scope, :idem_key, :request_hash, :state, DATE_ADD(now(), INTERVAL :lock_timeout_sec SECOND),
+		DATE_ADD(now(), INTERVAL :ttl_sec SECOND))`, table.IdempotencyRecordTable)
+	err := dao.Orm.Do().Insert(kt.Ctx, sql, args)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !errf.IsDuplicated(err) {
+		logs.Errorf("insert %s failed, err: %v, key: %s, rid: %s", table.IdempotencyRecordTable, err, opt.Key, kt.Rid)
+		return nil, err
+	}
+
+	// 幂等键已被使用，只有过期的记录由当前请求接管，其余情况返回已有记录
+	sql = fmt.Sprintf(`UPDATE %s SET request_hash = :request_hash, state = :state, response = NULL,
+		lock_expired_at = DATE_ADD(now(), INTERVAL :lock_timeout_sec SECOND),
+		expired_at = DATE_ADD(now(), INTERVAL :ttl_sec SECOND) WHERE tenant_id = :tenant_id AND scope = :scope AND
+		idem_key = :idem_key AND expired_at <= now()`, table.IdempotencyRecordTable)
+	updated, err := dao.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("take over idempotency record failed, err: %v, key: %s, rid: %s", err, opt.Key, kt.Rid)
+		return nil, err
+	}
+
+	if updated > 0 {
+		return nil, nil
+	}
+
+	// 处理超时的请求可能已经执行成功，不能由重试的请求接管，标记为结果未知，直到记录过期后幂等键才能重新使用
+	sql = fmt.Sprintf(`UPDATE %s SET state = :unknown WHERE tenant_id = :tenant_id AND scope = :scope AND
+		idem_key = :idem_key AND state = :state AND lock_expired_at <= now()`, table.IdempotencyRecordTable)
+	args["unknown"] = enumor.IdempotencyUnknown
+	if _, err = dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("mark timeout idempotency record unknown failed, err: %v, key: %s, rid: %s", err, opt.Key, kt.Rid)
+		return nil, err
+	}
+
+	record, err := dao.get(kt, opt.Scope, opt.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (dao RecordDao) get(kt *kit.Kit, scope, key string) (*tableidem.RecordTable, error) {
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE tenant_id = :tenant_id AND scope = :scope AND idem_key = :idem_key`,
+		tableidem.RecordColumns.NamedExpr(), table.IdempotencyRecordTable)
+	args := map[string]interface{}{"tenant_id": tenantID(kt), "scope": scope, "idem_key": key}
+
+	records := make([]tableidem.RecordTable, 0)
+	if err := dao.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &records, sql, args); err != nil {
+		logs.Errorf("get idempotency record failed, err: %v, key: %s, rid: %s", err, key, kt.Rid)
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "idempotency record of key %s not found", key)
+	}
+
+	return &records[0], nil
+}
+
+// Complete record the response of the request which holds the idempotency key.
+func (dao RecordDao) Complete(kt *kit.Kit, scope, key, requestHash, response string) error {
+	sql := fmt.Sprintf(`UPDATE %s SET state = :completed, response = :response WHERE tenant_id = :tenant_id AND
+		scope = :scope AND idem_key = :idem_key AND request_hash = :request_hash AND state IN (:processing, :unknown)`,
+		table.IdempotencyRecordTable)
+	args := map[string]interface{}{
+		"completed":    enumor.IdempotencyCompleted,
+		"processing":   enumor.IdempotencyProcessing,
+		"unknown":      enumor.IdempotencyUnknown,
+		"response":     response,
+		"tenant_id":    tenantID(kt),
+		"scope":        scope,
+		"idem_key":     key,
+		"request_hash": requestHash,
+	}
+
+	updated, err := dao.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("complete idempotency record failed, err: %v, key: %s, rid: %s", err, key, kt.Rid)
+		return err
+	}
+
+	if updated == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "uncompleted idempotency record of key %s not found", key)
+	}
+
+	return nil
+}
+
+// Release delete the uncompleted record of the request which holds the idempotency key.
+func (dao RecordDao) Release(kt *kit.Kit, scope, key, requestHash string) error {
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE tenant_id = :tenant_id AND scope = :scope AND idem_key = :idem_key AND
+		request_hash = :request_hash AND state IN (:processing, :unknown)`, table.IdempotencyRecordTable)
+	args := map[string]interface{}{
+		"tenant_id":    tenantID(kt),
+		"scope":        scope,
+		"idem_key":     key,
+		"request_hash": requestHash,
+		"processing":   enumor.IdempotencyProcessing,
+		"unknown":      enumor.IdempotencyUnknown,
+	}
+
+	if _, err := dao.Orm.Do().Delete(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("release idempotency record failed, err: %v, key: %s, rid: %s", err, key, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired delete the expired records of all tenants.
+func (dao RecordDao) DeleteExpired(kt *kit.Kit, limit uint) (int64, error) {
+	if limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "limit is required")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE expired_at <= now() LIMIT %d`, table.IdempotencyRecordTable, limit)
+	deleted, err := dao.Orm.Do().Delete(kt.Ctx, sql, map[string]interface{}{})
+	if err != nil {
+		logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func tenantID(kt *kit.Kit) string {
+	if len(kt.TenantID) == 0 {
+		return constant.DefaultTenantID
+	}
+
+	return kt.TenantID
+}
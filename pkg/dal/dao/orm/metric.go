@@ -52,6 +52,36 @@ func initMetric(register prometheus.Registerer) *metric {
 		}, []string{"cmd"})
 	register.MustRegister(m.errCounter)
 
+	m.endpointCmdLagMS = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   metrics.Namespace,
+		Subsystem:   metrics.OrmCmdSubSys,
+		Name:        "endpoint_cmd_lag_milliseconds",
+		Help:        "the lags(milliseconds) to exec a ORM command on each db endpoint",
+		ConstLabels: labels,
+		Buckets:     []float64{1, 2, 3, 4, 5, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 200, 400, 800, 1000, 1500, 2000},
+	}, []string{"endpoint", "cmd"})
+	register.MustRegister(m.endpointCmdLagMS)
+
+	m.endpointErrCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.OrmCmdSubSys,
+			Name:        "endpoint_total_err_count",
+			Help:        "the total error count when exec a ORM command on each db endpoint",
+			ConstLabels: labels,
+		}, []string{"endpoint", "cmd"})
+	register.MustRegister(m.endpointErrCounter)
+
+	m.endpointHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.OrmCmdSubSys,
+			Name:        "endpoint_healthy",
+			Help:        "whether the db endpoint is healthy, 1 is healthy and 0 is unhealthy",
+			ConstLabels: labels,
+		}, []string{"endpoint"})
+	register.MustRegister(m.endpointHealthy)
+
 	return m
 }
 
@@ -61,4 +91,13 @@ type metric struct {
 
 	// errCounter record the total error count when exec an orm command.
 	errCounter *prometheus.CounterVec
+
+	// endpointCmdLagMS record the cost time to exec an orm command on each db endpoint.
+	endpointCmdLagMS *prometheus.HistogramVec
+
+	// endpointErrCounter record the total error count when exec an orm command on each db endpoint.
+	endpointErrCounter *prometheus.CounterVec
+
+	// endpointHealthy record whether the db endpoint is healthy.
+	endpointHealthy *prometheus.GaugeVec
 }
@@ -44,6 +44,12 @@ type options struct {
 	mc *metric
 	// slowRequestMS db slow request time, beyond this time, the db request will be logged. unit: millisecond
 	slowRequestMS time.Duration
+	// replicas db read replicas, select and count requests are routed to them.
+	replicas []Replica
+	// replicaHealthCheckInterval the interval to ping the replicas.
+	replicaHealthCheckInterval time.Duration
+	// readAfterWriteSticky the duration that read requests of a request id are routed to primary after it writes.
+	readAfterWriteSticky time.Duration
 }
 
 // Option orm option func defines.
@@ -77,6 +83,27 @@ func SlowRequestMS(ms uint) Option {
 	}
 }
 
+// Replicas set db read replicas.
+func Replicas(replicas ...Replica) Option {
+	return func(opt *options) {
+		opt.replicas = append(opt.replicas, replicas...)
+	}
+}
+
+// ReplicaHealthCheckIntervalSec set the interval to ping the replicas.
+func ReplicaHealthCheckIntervalSec(sec uint) Option {
+	return func(opt *options) {
+		opt.replicaHealthCheckInterval = time.Duration(sec) * time.Second
+	}
+}
+
+// ReadAfterWriteStickySec set the seconds that read requests of a request id are routed to primary after it writes.
+func ReadAfterWriteStickySec(sec uint) Option {
+	return func(opt *options) {
+		opt.readAfterWriteSticky = time.Duration(sec) * time.Second
+	}
+}
+
 // TableShardingOpt defines table name generation options.
 type TableShardingOpt interface {
 	// Match check if table name match this sharding option
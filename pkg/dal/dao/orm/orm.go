@@ -98,8 +98,20 @@ func InitOrm(db *sqlx.DB, opts ...Option) Interface {
 		ormOpts.slowRequestMS = 50 * time.Millisecond
 	}
 
+	if ormOpts.replicaHealthCheckInterval == 0 {
+		ormOpts.replicaHealthCheckInterval = 5 * time.Second
+	}
+
+	if ormOpts.readAfterWriteSticky == 0 {
+		ormOpts.readAfterWriteSticky = 5 * time.Second
+	}
+
+	rt := newRouter(db, ormOpts.replicas, ormOpts.readAfterWriteSticky, ormOpts.mc)
+	go rt.runHealthCheck(ormOpts.replicaHealthCheckInterval)
+
 	return &runtimeOrm{
 		db:             db,
+		router:         rt,
 		mc:             ormOpts.mc,
 		ingressLimiter: ormOpts.ingressLimiter,
 		logLimiter:     ormOpts.logLimiter,
@@ -109,12 +121,26 @@ func InitOrm(db *sqlx.DB, opts ...Option) Interface {
 
 type runtimeOrm struct {
 	db             *sqlx.DB
+	router         *router
 	ingressLimiter *rate.Limiter
 	logLimiter     *rate.Limiter
 	mc             *metric
 	slowRequestMS  time.Duration
 }
 
+// observe records the cost time of orm command, both in total and on the db endpoint.
+func (o *runtimeOrm) observe(endpoint, cmd string, start time.Time) {
+	lag := float64(time.Since(start).Milliseconds())
+	o.mc.cmdLagMS.With(prm.Labels{"cmd": cmd}).Observe(lag)
+	o.mc.endpointCmdLagMS.With(prm.Labels{"endpoint": endpoint, "cmd": cmd}).Observe(lag)
+}
+
+// incErr records the error of orm command, both in total and on the db endpoint.
+func (o *runtimeOrm) incErr(endpoint, cmd string) {
+	o.mc.errCounter.With(prm.Labels{"cmd": cmd}).Inc()
+	o.mc.endpointErrCounter.With(prm.Labels{"endpoint": endpoint, "cmd": cmd}).Inc()
+}
+
 func (o *runtimeOrm) logSlowCmd(ctx context.Context, sql string, latency time.Duration) {
 	if latency < o.slowRequestMS {
 		return
@@ -239,6 +265,9 @@ func (o *runtimeOrm) autoTxn(kit *kit.Kit, run TxnFunc) (bool, interface{}, erro
 		return false, nil, fmt.Errorf("commit sharding transaction failed, err: %v", err)
 	}
 
+	// transaction is always executed on primary, pin the following reads of this request to primary.
+	o.router.markWritten(kit.Ctx)
+
 	return false, result, nil
 }
 
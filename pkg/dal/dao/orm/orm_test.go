@@ -724,6 +724,14 @@ func TestInjectJoinTenantID_HCMQueries(t *testing.T) {
 			origin:       "SELECT account_id as group_field, COUNT(*) as count FROM subnet WHERE vendor=\"tcloud\" GROUP BY account_id",
 			wantReplaced: "SELECT account_id as group_field, COUNT(*) as count FROM subnet WHERE subnet.tenant_id = :tenant_id AND vendor=\"tcloud\" GROUP BY account_id",
 		},
+		{
+			name:         "HCM用到的SELECT-场景19",
+			args:         map[string]interface{}{"id": 1},
+			tenantID:     "tenant-1",
+			enableTenant: true,
+			origin:       "SELECT JSON_OBJECT('region', region, 'count', COUNT(*)) AS detail FROM cvm WHERE vendor = :vendor GROUP BY region ORDER BY region LIMIT 1000",
+			wantReplaced: "SELECT JSON_OBJECT('region', region, 'count', COUNT(*)) AS detail FROM cvm WHERE cvm.tenant_id = :tenant_id AND vendor = :vendor GROUP BY region ORDER BY region LIMIT 1000",
+		},
 	}
 
 	runInjectTenantIDTest(t, tests)
@@ -0,0 +1,223 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+	prm "github.com/prometheus/client_golang/prometheus"
+)
+
+// primaryEndpoint is the endpoint label of primary database in metrics.
+const primaryEndpoint = "primary"
+
+// pingTimeout is the timeout of replica health check.
+const pingTimeout = 3 * time.Second
+
+// Replica defines a read replica of primary database.
+type Replica struct {
+	// Name is the name of replica, used as the endpoint label of metrics.
+	Name string
+	DB   *sqlx.DB
+	// Weight is the relative weight to select this replica.
+	Weight uint
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose select and count requests are routed to primary database,
+// used when the caller can not tolerate the replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func isPrimaryPinned(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
+
+type replica struct {
+	Replica
+	healthy atomic.Bool
+}
+
+// router routes select and count requests to the healthy replicas by weight, and other requests
+// to primary. read requests of a request id which has written recently are also routed to primary.
+type router struct {
+	primary   *sqlx.DB
+	replicas  []*replica
+	stickyTTL time.Duration
+	// written records the request ids which have written to primary, rid -> expire time.
+	written sync.Map
+	mc      *metric
+}
+
+func newRouter(primary *sqlx.DB, replicas []Replica, stickyTTL time.Duration, mc *metric) *router {
+	r := &router{
+		primary:   primary,
+		replicas:  make([]*replica, 0, len(replicas)),
+		stickyTTL: stickyTTL,
+		mc:        mc,
+	}
+
+	mc.endpointHealthy.With(prm.Labels{"endpoint": primaryEndpoint}).Set(1)
+	for _, one := range replicas {
+		if one.DB == nil || one.Weight == 0 {
+			continue
+		}
+
+		rep := &replica{Replica: one}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+		mc.endpointHealthy.With(prm.Labels{"endpoint": one.Name}).Set(1)
+	}
+
+	return r
+}
+
+// reader returns the db to execute read request and its endpoint name.
+func (r *router) reader(ctx context.Context) (*sqlx.DB, string) {
+	if len(r.replicas) == 0 || isPrimaryPinned(ctx) || r.hasWritten(ctx) {
+		return r.primary, primaryEndpoint
+	}
+
+	total := 0
+	for _, one := range r.replicas {
+		if one.healthy.Load() {
+			total += int(one.Weight)
+		}
+	}
+
+	// all replicas are unhealthy, fall back to primary.
+	if total == 0 {
+		return r.primary, primaryEndpoint
+	}
+
+	pick := rand.Intn(total)
+	for _, one := range r.replicas {
+		if !one.healthy.Load() {
+			continue
+		}
+
+		if pick < int(one.Weight) {
+			return one.DB, one.Name
+		}
+		pick -= int(one.Weight)
+	}
+
+	return r.primary, primaryEndpoint
+}
+
+// markWritten records the request id has written to primary, the following read requests
+// of this request id are routed to primary in sticky ttl.
+func (r *router) markWritten(ctx context.Context) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	rid := rootRid(ctx)
+	if len(rid) == 0 {
+		return
+	}
+
+	r.written.Store(rid, time.Now().Add(r.stickyTTL))
+}
+
+func (r *router) hasWritten(ctx context.Context) bool {
+	rid := rootRid(ctx)
+	if len(rid) == 0 {
+		return false
+	}
+
+	expire, exist := r.written.Load(rid)
+	if !exist {
+		return false
+	}
+
+	return time.Now().Before(expire.(time.Time))
+}
+
+// rootRid returns the request id without the sub kit suffix, so that the sub kits of a request
+// are regarded as the same request.
+func rootRid(ctx context.Context) string {
+	rid, _ := ctx.Value(constant.RidKey).(string)
+	if idx := strings.Index(rid, "/"); idx > 0 {
+		return rid[:idx]
+	}
+
+	return rid
+}
+
+// runHealthCheck pings replicas periodically, and cleans the expired written request ids.
+func (r *router) runHealthCheck(interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.checkReplicas()
+		r.cleanWritten()
+	}
+}
+
+func (r *router) checkReplicas() {
+	for _, one := range r.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+		err := one.DB.PingContext(ctx)
+		cancel()
+
+		healthy := err == nil
+		if one.healthy.Swap(healthy) != healthy {
+			if healthy {
+				logs.Infof("db replica %s recovered", one.Name)
+			} else {
+				logs.Errorf("db replica %s is unhealthy, err: %v", one.Name, err)
+			}
+		}
+
+		value := float64(0)
+		if healthy {
+			value = 1
+		}
+		r.mc.endpointHealthy.With(prm.Labels{"endpoint": one.Name}).Set(value)
+	}
+}
+
+func (r *router) cleanWritten() {
+	now := time.Now()
+	r.written.Range(func(key, value any) bool {
+		if now.After(value.(time.Time)) {
+			r.written.Delete(key)
+		}
+		return true
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRouter(replicas ...Replica) *router {
+	return newRouter(new(sqlx.DB), replicas, time.Minute, initMetric(prometheus.NewRegistry()))
+}
+
+func ridCtx(rid string) context.Context {
+	return context.WithValue(context.Background(), constant.RidKey, rid)
+}
+
+func TestRouterReader(t *testing.T) {
+	// no replica, all reads are routed to primary.
+	rt := testRouter()
+	db, endpoint := rt.reader(ridCtx("rid-1"))
+	assert.Equal(t, rt.primary, db)
+	assert.Equal(t, primaryEndpoint, endpoint)
+
+	replicaA := Replica{Name: "replica-a", DB: new(sqlx.DB), Weight: 3}
+	replicaB := Replica{Name: "replica-b", DB: new(sqlx.DB), Weight: 1}
+	rt = testRouter(replicaA, replicaB)
+
+	counts := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		_, endpoint = rt.reader(ridCtx("rid-1"))
+		counts[endpoint]++
+	}
+	assert.Zero(t, counts[primaryEndpoint])
+	assert.InDelta(t, 3000, counts["replica-a"], 300)
+	assert.InDelta(t, 1000, counts["replica-b"], 300)
+
+	// unhealthy replica is skipped, and all unhealthy falls back to primary.
+	rt.replicas[0].healthy.Store(false)
+	for i := 0; i < 100; i++ {
+		_, endpoint = rt.reader(ridCtx("rid-1"))
+		assert.Equal(t, "replica-b", endpoint)
+	}
+
+	rt.replicas[1].healthy.Store(false)
+	_, endpoint = rt.reader(ridCtx("rid-1"))
+	assert.Equal(t, primaryEndpoint, endpoint)
+}
+
+func TestRouterPinPrimary(t *testing.T) {
+	rt := testRouter(Replica{Name: "replica-a", DB: new(sqlx.DB), Weight: 1})
+
+	_, endpoint := rt.reader(WithPrimary(ridCtx("rid-1")))
+	assert.Equal(t, primaryEndpoint, endpoint)
+
+	// read after write of the same request, including sub kit, is routed to primary.
+	rt.markWritten(ridCtx("rid-1"))
+	_, endpoint = rt.reader(ridCtx("rid-1"))
+	assert.Equal(t, primaryEndpoint, endpoint)
+	_, endpoint = rt.reader(ridCtx("rid-1/sub"))
+	assert.Equal(t, primaryEndpoint, endpoint)
+
+	_, endpoint = rt.reader(ridCtx("rid-2"))
+	assert.Equal(t, "replica-a", endpoint)
+
+	// sticky is expired.
+	rt.written.Store("rid-1", time.Now().Add(-time.Second))
+	_, endpoint = rt.reader(ridCtx("rid-1"))
+	assert.Equal(t, "replica-a", endpoint)
+
+	rt.cleanWritten()
+	_, exist := rt.written.Load("rid-1")
+	assert.False(t, exist)
+}
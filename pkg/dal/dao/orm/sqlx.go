@@ -26,7 +26,6 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
-	prm "github.com/prometheus/client_golang/prometheus"
 )
 
 var (
@@ -50,32 +49,33 @@ func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map
 	}
 
 	start := time.Now()
+	db, endpoint := do.ro.router.reader(ctx)
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(endpoint, "select")
 		return err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(endpoint, "select")
 		return err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(endpoint, "select")
 		return err
 	}
 
 	if err = sqlx.StructScan(rows, dest); err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(endpoint, "select")
 		return err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "select"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(endpoint, "select", start)
 
 	return nil
 }
@@ -87,35 +87,36 @@ func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}
 	}
 
 	start := time.Now()
+	db, endpoint := do.ro.router.reader(ctx)
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(endpoint, "count")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(endpoint, "count")
 		return 0, err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(endpoint, "count")
 		return 0, err
 	}
 
 	count := uint64(0)
 	for rows.Next() {
 		if err := rows.Scan(&count); err != nil {
-			do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+			do.ro.incErr(endpoint, "count")
 			return 0, err
 		}
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "count"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(endpoint, "count", start)
 
 	return count, nil
 }
@@ -130,30 +131,31 @@ func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
+	do.ro.router.markWritten(ctx)
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "delete"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "delete", start)
 
 	return rowsAffected, nil
 }
@@ -172,30 +174,31 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
+	do.ro.router.markWritten(ctx)
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "update"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "update", start)
 
 	return rowsAffected, nil
 }
@@ -210,12 +213,13 @@ func (do *do) Insert(ctx context.Context, expr string, data interface{}) error {
 
 	_, err := do.db.NamedExecContext(ctx, expr, data)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
+		do.ro.incErr(primaryEndpoint, "insert")
 		return err
 	}
 
+	do.ro.router.markWritten(ctx)
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "insert", start)
 
 	return nil
 }
@@ -230,18 +234,19 @@ func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
 
 	result, err := do.db.ExecContext(ctx, expr)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "exec"}).Inc()
+		do.ro.incErr(primaryEndpoint, "exec")
 		return 0, err
 	}
 
 	effected, err := result.RowsAffected()
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "exec"}).Inc()
+		do.ro.incErr(primaryEndpoint, "exec")
 		return 0, err
 	}
 
+	do.ro.router.markWritten(ctx)
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "exec"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "exec", start)
 
 	return effected, nil
 }
@@ -257,12 +262,13 @@ func (do *do) BulkInsert(ctx context.Context, expr string, args interface{}) err
 
 	_, err := do.db.NamedExecContext(ctx, expr, args)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
+		do.ro.incErr(primaryEndpoint, "bulk-insert")
 		return err
 	}
 
+	do.ro.router.markWritten(ctx)
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "bulk-insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "bulk-insert", start)
 
 	return nil
 }
@@ -284,32 +290,32 @@ func (do *doTxn) Count(ctx context.Context, expr string, arg map[string]interfac
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(primaryEndpoint, "count")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(primaryEndpoint, "count")
 		return 0, err
 	}
 
 	rows, err := do.tx.QueryContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+		do.ro.incErr(primaryEndpoint, "count")
 		return 0, err
 	}
 
 	count := uint64(0)
 	for rows.Next() {
 		if err := rows.Scan(&count); err != nil {
-			do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
+			do.ro.incErr(primaryEndpoint, "count")
 			return 0, err
 		}
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "count"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "count", start)
 
 	return count, nil
 }
@@ -324,29 +330,29 @@ func (do *doTxn) Select(ctx context.Context, dest interface{}, expr string, arg
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(primaryEndpoint, "select")
 		return err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(primaryEndpoint, "select")
 		return err
 	}
 
 	rows, err := do.tx.QueryContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(primaryEndpoint, "select")
 		return err
 	}
 
 	if err = sqlx.StructScan(rows, dest); err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
+		do.ro.incErr(primaryEndpoint, "select")
 		return err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "select"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "select", start)
 
 	return nil
 }
@@ -361,30 +367,30 @@ func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interfa
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
+		do.ro.incErr(primaryEndpoint, "delete")
 		return 0, err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "delete"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "delete", start)
 
 	return rowsAffected, nil
 }
@@ -403,12 +409,12 @@ func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) erro
 
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
+		do.ro.incErr(primaryEndpoint, "insert")
 		return err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "insert", start)
 
 	return nil
 }
@@ -424,12 +430,12 @@ func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{})
 
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
+		do.ro.incErr(primaryEndpoint, "bulk-insert")
 		return err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "bulk-insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "bulk-insert", start)
 
 	return nil
 }
@@ -448,30 +454,30 @@ func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interfa
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	query, args, err = sqlx.In(query, args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
+		do.ro.incErr(primaryEndpoint, "update")
 		return 0, err
 	}
 
 	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "update"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observe(primaryEndpoint, "update", start)
 
 	return rowsAffected, nil
 }
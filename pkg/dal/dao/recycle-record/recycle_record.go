@@ -250,6 +250,19 @@ func (r *Dao) UpdateResource(kt *kit.Kit, tx *sqlx.Tx, opt *rrtypes.ResourceUpda
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	// 云硬盘的快照随云硬盘一起进入回收站或恢复
+	if opt.ResType == enumor.DiskCloudResType {
+		snapshotSql := fmt.Sprintf(`update %s set recycle_status = :recycle_status where disk_id in (:id)`,
+			table.DiskSnapshotTable)
+		_, err = r.orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Update(
+			kt.Ctx, snapshotSql, tools.MapMerge(updateData, whereValue))
+		if err != nil {
+			logs.ErrorJson("update disk snapshot recycle status failed, err: %v, disk ids: %+v, rid: %v", err,
+				opt.IDs, kt.Rid)
+			return err
+		}
+	}
+
 	return nil
 }
 
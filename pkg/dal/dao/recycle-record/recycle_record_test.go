@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	rrtypes "hcm/pkg/dal/dao/types/recycle-record"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// testConfig 加载dao依赖的data-service运行时配置，只包含通过校验所需的配置项
+const testConfig = `
+network:
+  bindIP: 127.0.0.1
+service:
+  etcd:
+    endpoints:
+      - 127.0.0.1:2379
+database:
+  resource:
+    endpoints:
+      - 127.0.0.1:3306
+    database: hcm
+    user: root
+    password: admin
+cmdb:
+  endpoints:
+    - http://127.0.0.1
+  appCode: hcm
+  appSecret: secret
+crypto:
+  aesGcm:
+    key: 0123456789abcdef
+    nonce: 0123456789ab
+`
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "hcm-dao-test")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	file := filepath.Join(dir, "data_service.yaml")
+	if err = os.WriteFile(file, []byte(testConfig), 0o600); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	cc.InitService(cc.DataServiceName)
+	err = cc.LoadSettings(&cc.SysOption{ConfigFile: file})
+	os.RemoveAll(dir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	os.Exit(m.Run())
+}
+
+type updateCall struct {
+	table string
+	sql   string
+	args  map[string]interface{}
+}
+
+// fakeOrm 记录事务中执行的update语句，只实现UpdateResource用到的方法
+type fakeOrm struct {
+	orm.Interface
+	updates []updateCall
+	// missing 模拟不存在的资源数量
+	missing int
+}
+
+func (f *fakeOrm) ModifySQLOpts(...orm.ModifySQLOpt) orm.Interface {
+	return f
+}
+
+func (f *fakeOrm) Txn(*sqlx.Tx) orm.DoOrmWithTransaction {
+	return &fakeTxn{orm: f}
+}
+
+type fakeTxn struct {
+	orm.DoOrmWithTransaction
+	orm *fakeOrm
+}
+
+func (t *fakeTxn) Update(_ context.Context, expr string, args map[string]interface{}) (int64, error) {
+	name := strings.Fields(expr)[1]
+	t.orm.updates = append(t.orm.updates, updateCall{table: name, sql: expr, args: args})
+	return int64(len(args["id"].([]string)) - t.orm.missing), nil
+}
+
+func TestUpdateResourceCascadeDiskSnapshot(t *testing.T) {
+	diskIDs := []string{"00000001", "00000002"}
+
+	for _, status := range []string{enumor.RecycleStatus, enumor.RecoverStatus} {
+		fake := new(fakeOrm)
+		dao := &Dao{orm: fake}
+		opt := &rrtypes.ResourceUpdateOptions{ResType: enumor.DiskCloudResType, IDs: diskIDs, Status: status}
+		if err := dao.UpdateResource(kit.New(), nil, opt); err != nil {
+			t.Fatalf("update disk resource failed, err: %v", err)
+		}
+
+		// 云硬盘和其快照的回收状态在同一事务中更新
+		if len(fake.updates) != 2 || fake.updates[0].table != string(table.DiskTable) ||
+			fake.updates[1].table != string(table.DiskSnapshotTable) {
+			t.Fatalf("unexpected updates: %+v", fake.updates)
+		}
+
+		snapshot := fake.updates[1]
+		expect := map[string]interface{}{"recycle_status": status, "id": diskIDs}
+		if !strings.Contains(snapshot.sql, "where disk_id in (:id)") || !reflect.DeepEqual(snapshot.args, expect) {
+			t.Errorf("unexpected disk snapshot update: %+v", snapshot)
+		}
+	}
+}
+
+func TestUpdateResourceNotCascade(t *testing.T) {
+	// 其他资源不更新快照
+	fake := new(fakeOrm)
+	dao := &Dao{orm: fake}
+	opt := &rrtypes.ResourceUpdateOptions{ResType: enumor.CvmCloudResType, IDs: []string{"00000001"},
+		Status: enumor.RecycleStatus}
+	if err := dao.UpdateResource(kit.New(), nil, opt); err != nil {
+		t.Fatalf("update cvm resource failed, err: %v", err)
+	}
+	if len(fake.updates) != 1 || fake.updates[0].table != string(table.CvmTable) {
+		t.Errorf("unexpected updates: %+v", fake.updates)
+	}
+
+	// 云硬盘不存在时返回错误，不更新快照
+	fake = &fakeOrm{missing: 1}
+	dao = &Dao{orm: fake}
+	opt = &rrtypes.ResourceUpdateOptions{ResType: enumor.DiskCloudResType, IDs: []string{"00000001", "00000002"},
+		Status: enumor.RecycleStatus}
+	if err := dao.UpdateResource(kit.New(), nil, opt); err == nil {
+		t.Errorf("update missing disk should return error")
+	}
+	if len(fake.updates) != 1 {
+		t.Errorf("disk snapshot should not be updated, updates: %+v", fake.updates)
+	}
+}
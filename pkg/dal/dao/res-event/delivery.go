@@ -0,0 +1,272 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxDeliveryErrorLength 推送失败原因的最大长度，与 last_error 字段长度一致
+const maxDeliveryErrorLength = 1024
+
+// EventDelivery define event delivery interface, deliveries are delivered across tenants by the background
+// dispatcher, so the tenant id is specified explicitly.
+type EventDelivery interface {
+	// BatchCreateWithTx create deliveries with tx, the tenant id of deliveries should be set by caller.
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableevent.EventDeliveryTable) error
+	// ListPending list the pending deliveries of all tenants which reach the retry time, ordered by id.
+	ListPending(kt *kit.Kit, limit uint) ([]tableevent.EventDeliveryTable, error)
+	MarkSucceeded(kt *kit.Kit, id uint64, statusCode int) error
+	// Retry record the failure of delivery and delay its next delivery, the delivery is marked as failed when the
+	// retry count reaches the max retry count.
+	Retry(kt *kit.Kit, id uint64, statusCode int, reason string, delaySec uint64, maxRetry uint) error
+	// MarkFailed mark deliveries as failed directly, e.g. the subscription is deleted.
+	MarkFailed(kt *kit.Kit, ids []uint64, reason string) error
+	// List deliveries of the tenant of kit.
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableevent.EventDeliveryTable], error)
+	// RetryFailed reset the failed deliveries of the tenant of kit to pending, returns the count of reset deliveries.
+	RetryFailed(kt *kit.Kit, ids []uint64) (int64, error)
+	// DeleteExpired delete the finished deliveries of all tenants created before the given time, at most limit
+	// deliveries are deleted once.
+	DeleteExpired(kt *kit.Kit, before string, limit uint) (int64, error)
+}
+
+var _ EventDelivery = new(EventDeliveryDao)
+
+// EventDeliveryDao event delivery dao.
+type EventDeliveryDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx batch create event deliveries with tx.
+func (dao EventDeliveryDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tableevent.EventDeliveryTable) error {
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	for idx := range models {
+		if len(models[idx].TenantID) == 0 || len(models[idx].SubscriptionID) == 0 || models[idx].EventID == 0 {
+			return errf.New(errf.InvalidParameter, "tenant_id, subscription_id and event_id are required")
+		}
+		models[idx].State = coreevent.DeliveryPending
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (tenant_id, subscription_id, event_id, state) VALUES(:tenant_id,
+		:subscription_id, :event_id, :state)`, table.EventDeliveryTable)
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.EventDeliveryTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.EventDeliveryTable, err)
+	}
+
+	return nil
+}
+
+// ListPending list the pending deliveries which reach the retry time, ordered by id. it reads from primary database,
+// otherwise the deliveries that are already succeeded may be delivered again from a lagging replica.
+func (dao EventDeliveryDao) ListPending(kt *kit.Kit, limit uint) ([]tableevent.EventDeliveryTable, error) {
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE state = :state AND next_retry_at <= now() ORDER BY id LIMIT :limit`,
+		tableevent.EventDeliveryColumns.NamedExpr(), table.EventDeliveryTable)
+
+	deliveries := make([]tableevent.EventDeliveryTable, 0)
+	args := map[string]interface{}{"state": coreevent.DeliveryPending, "limit": limit}
+	if err := dao.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &deliveries, sql, args); err != nil {
+		logs.Errorf("list pending event delivery failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// MarkSucceeded mark the delivery as succeeded.
+func (dao EventDeliveryDao) MarkSucceeded(kt *kit.Kit, id uint64, statusCode int) error {
+	sql := fmt.Sprintf(`UPDATE %s SET state = :state, status_code = :status_code, last_error = '' WHERE id = :id`,
+		table.EventDeliveryTable)
+	args := map[string]interface{}{"state": coreevent.DeliverySucceeded, "status_code": statusCode, "id": id}
+	if _, err := dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("mark event delivery %d succeeded failed, err: %v, rid: %s", id, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Retry record the failure of delivery, and delay its next delivery.
+func (dao EventDeliveryDao) Retry(kt *kit.Kit, id uint64, statusCode int, reason string, delaySec uint64,
+	maxRetry uint) error {
+
+	if len(reason) > maxDeliveryErrorLength {
+		reason = reason[:maxDeliveryErrorLength]
+	}
+
+	args := map[string]interface{}{
+		"id":          id,
+		"status_code": statusCode,
+		"last_error":  reason,
+		"delay_sec":   delaySec,
+		"max_retry":   maxRetry,
+		"failed":      coreevent.DeliveryFailed,
+		"pending":     coreevent.DeliveryPending,
+	}
+
+	// mysql 按顺序执行赋值，判断状态时 retry_count 已经是加1后的值
+	sql := fmt.Sprintf(`UPDATE %s SET retry_count = retry_count + 1, status_code = :status_code,
+		last_error = :last_error, next_retry_at = DATE_ADD(now(), INTERVAL :delay_sec SECOND),
+		state = IF(retry_count >= :max_retry, :failed, :pending) WHERE id = :id`, table.EventDeliveryTable)
+	if _, err := dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("update event delivery %d retry failed, err: %v, rid: %s", id, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// MarkFailed mark deliveries as failed.
+func (dao EventDeliveryDao) MarkFailed(kt *kit.Kit, ids []uint64, reason string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	if len(reason) > maxDeliveryErrorLength {
+		reason = reason[:maxDeliveryErrorLength]
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET state = :state, last_error = :last_error WHERE id IN (:ids)`,
+		table.EventDeliveryTable)
+	args := map[string]interface{}{"state": coreevent.DeliveryFailed, "last_error": reason, "ids": ids}
+	if _, err := dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("mark event delivery failed failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List event deliveries of the tenant.
+func (dao EventDeliveryDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableevent.EventDeliveryTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableevent.EventDeliveryColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(opt.Filter, tools.RuleEqual("tenant_id", tenantID(kt)))
+	if err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventDeliveryTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event delivery failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableevent.EventDeliveryTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableevent.EventDeliveryColumns.FieldsNamedExpr(opt.Fields),
+		table.EventDeliveryTable, whereExpr, pageExpr)
+
+	details := make([]tableevent.EventDeliveryTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select event delivery failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableevent.EventDeliveryTable]{Details: details}, nil
+}
+
+// RetryFailed reset the failed deliveries to pending, so that they can be delivered again.
+func (dao EventDeliveryDao) RetryFailed(kt *kit.Kit, ids []uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET state = :pending, retry_count = 0, next_retry_at = now() WHERE
+		tenant_id = :tenant_id AND state = :failed AND id IN (:ids)`, table.EventDeliveryTable)
+	args := map[string]interface{}{
+		"pending":   coreevent.DeliveryPending,
+		"failed":    coreevent.DeliveryFailed,
+		"tenant_id": tenantID(kt),
+		"ids":       ids,
+	}
+	updated, err := dao.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("reset failed event delivery failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// DeleteExpired delete the succeeded or failed deliveries created before the given time.
+func (dao EventDeliveryDao) DeleteExpired(kt *kit.Kit, before string, limit uint) (int64, error) {
+	if len(before) == 0 || limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "before and limit are required")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE state IN (:states) AND created_at < :before LIMIT %d`,
+		table.EventDeliveryTable, limit)
+	args := map[string]interface{}{
+		"states": []coreevent.DeliveryState{coreevent.DeliverySucceeded, coreevent.DeliveryFailed},
+		"before": before,
+	}
+	deleted, err := dao.Orm.Do().Delete(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("delete expired event delivery failed, err: %v, before: %s, rid: %s", err, before, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
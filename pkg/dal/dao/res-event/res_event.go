@@ -0,0 +1,219 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent 资源变更事件及事件订阅、推送记录
+package resevent
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ResEvent define res event interface, events are dispatched across tenants by the background dispatcher, so the
+// tenant id is specified explicitly.
+type ResEvent interface {
+	// BatchCreateWithTx create res events with tx, it should be in the same tx with the res change history.
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableevent.ResEventTable) error
+	// ListPending list the pending events of all tenants ordered by id.
+	ListPending(kt *kit.Kit, limit uint) ([]tableevent.ResEventTable, error)
+	// ListByIDs list events of all tenants by ids.
+	ListByIDs(kt *kit.Kit, ids []uint64) ([]tableevent.ResEventTable, error)
+	MarkDispatchedWithTx(kt *kit.Kit, tx *sqlx.Tx, ids []uint64) error
+	// List events of the tenant of kit.
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableevent.ResEventTable], error)
+	// DeleteExpired delete the dispatched events of all tenants created before the given time, at most limit events
+	// are deleted once.
+	DeleteExpired(kt *kit.Kit, before string, limit uint) (int64, error)
+}
+
+var _ ResEvent = new(ResEventDao)
+
+// ResEventDao res event dao.
+type ResEventDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx batch create res events with tx.
+func (dao ResEventDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableevent.ResEventTable) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for idx := range models {
+		models[idx].TenantID = tenantID(kt)
+		models[idx].State = tableevent.EventPending
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (tenant_id, event_type, res_type, res_id, cloud_res_id, res_name, vendor,
+		account_id, bk_biz_id, prev_bk_biz_id, version, diff, source, rid, operator, state) VALUES(:tenant_id,
+		:event_type, :res_type, :res_id, :cloud_res_id, :res_name, :vendor, :account_id, :bk_biz_id, :prev_bk_biz_id,
+		:version, :diff, :source, :rid, :operator, :state)`, table.ResEventTable)
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResEventTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResEventTable, err)
+	}
+
+	return nil
+}
+
+// ListPending list the pending res events ordered by id. it reads from primary database, otherwise the events that
+// are already dispatched may be dispatched again from a lagging replica.
+func (dao ResEventDao) ListPending(kt *kit.Kit, limit uint) ([]tableevent.ResEventTable, error) {
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE state = :state ORDER BY id LIMIT :limit`,
+		tableevent.ResEventColumns.NamedExpr(), table.ResEventTable)
+
+	events := make([]tableevent.ResEventTable, 0)
+	args := map[string]interface{}{"state": tableevent.EventPending, "limit": limit}
+	if err := dao.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &events, sql, args); err != nil {
+		logs.Errorf("list pending res event failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ListByIDs list res events by ids from primary database, the events of deliveries read from primary may not be
+// replicated yet.
+func (dao ResEventDao) ListByIDs(kt *kit.Kit, ids []uint64) ([]tableevent.ResEventTable, error) {
+	if len(ids) == 0 {
+		return make([]tableevent.ResEventTable, 0), nil
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE id IN (:ids)`, tableevent.ResEventColumns.NamedExpr(),
+		table.ResEventTable)
+
+	events := make([]tableevent.ResEventTable, 0)
+	err := dao.Orm.Do().Select(orm.WithPrimary(kt.Ctx), &events, sql, map[string]interface{}{"ids": ids})
+	if err != nil {
+		logs.Errorf("list res event by ids failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// MarkDispatchedWithTx mark res events as dispatched with tx, it should be in the same tx with the creation of
+// deliveries.
+func (dao ResEventDao) MarkDispatchedWithTx(kt *kit.Kit, tx *sqlx.Tx, ids []uint64) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET state = :state WHERE id IN (:ids)`, table.ResEventTable)
+	args := map[string]interface{}{"state": tableevent.EventDispatched, "ids": ids}
+	if _, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("mark res event dispatched failed, err: %v, count: %d, rid: %s", err, len(ids), kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List res events of the tenant.
+func (dao ResEventDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableevent.ResEventTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableevent.ResEventColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(opt.Filter, tools.RuleEqual("tenant_id", tenantID(kt)))
+	if err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResEventTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count res event failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableevent.ResEventTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableevent.ResEventColumns.FieldsNamedExpr(opt.Fields),
+		table.ResEventTable, whereExpr, pageExpr)
+
+	details := make([]tableevent.ResEventTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select res event failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableevent.ResEventTable]{Details: details}, nil
+}
+
+// DeleteExpired delete the dispatched res events created before the given time.
+func (dao ResEventDao) DeleteExpired(kt *kit.Kit, before string, limit uint) (int64, error) {
+	if len(before) == 0 || limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "before and limit are required")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE state = :state AND created_at < :before LIMIT %d`,
+		table.ResEventTable, limit)
+	deleted, err := dao.Orm.Do().Delete(kt.Ctx, sql,
+		map[string]interface{}{"state": tableevent.EventDispatched, "before": before})
+	if err != nil {
+		logs.Errorf("delete expired res event failed, err: %v, before: %s, rid: %s", err, before, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// tenantID returns the tenant id of kit, the default tenant is used when tenant is not set.
+func tenantID(kt *kit.Kit) string {
+	if len(kt.TenantID) == 0 {
+		return constant.DefaultTenantID
+	}
+
+	return kt.TenantID
+}
@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableevent "hcm/pkg/dal/table/res-event"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// EventSubscription only used for event subscription.
+type EventSubscription interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableevent.EventSubscriptionTable) ([]string, error)
+	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tableevent.EventSubscriptionTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableevent.EventSubscriptionTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ EventSubscription = new(EventSubscriptionDao)
+
+// EventSubscriptionDao event subscription dao.
+type EventSubscriptionDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx create event subscriptions with tx.
+func (dao EventSubscriptionDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tableevent.EventSubscriptionTable) ([]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.EventSubscriptionTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		models[index].TenantID = tenantID(kt)
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s, tenant_id)	VALUES(%s, :tenant_id)`, table.EventSubscriptionTable,
+		tableevent.EventSubscriptionColumns.ColumnExpr(), tableevent.EventSubscriptionColumns.ColonNameExpr())
+	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.EventSubscriptionTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.EventSubscriptionTable, err)
+	}
+
+	return ids, nil
+}
+
+// UpdateByIDWithTx update event subscription by id with tx.
+func (dao EventSubscriptionDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
+	model *tableevent.EventSubscriptionTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id AND tenant_id = :tenant_id`, table.EventSubscriptionTable,
+		setExpr)
+
+	toUpdate["id"] = id
+	toUpdate["tenant_id"] = tenantID(kt)
+	if _, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate); err != nil {
+		logs.Errorf("update event subscription failed, id: %s, err: %v, rid: %s", id, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List event subscriptions of the tenant.
+func (dao EventSubscriptionDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableevent.EventSubscriptionTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableevent.EventSubscriptionColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(opt.Filter, tools.RuleEqual("tenant_id", tenantID(kt)))
+	if err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventSubscriptionTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event subscription failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableevent.EventSubscriptionTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableevent.EventSubscriptionColumns.FieldsNamedExpr(opt.Fields),
+		table.EventSubscriptionTable, whereExpr, pageExpr)
+
+	details := make([]tableevent.EventSubscriptionTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select event subscription failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableevent.EventSubscriptionTable]{Details: details}, nil
+}
+
+// DeleteWithTx delete event subscriptions of the tenant with tx.
+func (dao EventSubscriptionDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	tenantExpr, err := tools.And(expr, tools.RuleEqual("tenant_id", tenantID(kt)))
+	if err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := tenantExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EventSubscriptionTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete event subscription failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
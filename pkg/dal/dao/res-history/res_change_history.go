@@ -0,0 +1,640 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory 资源变更历史的Package
+package reshistory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/res-event"
+	corereshistory "hcm/pkg/api/core/res-history"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgen "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	resevent "hcm/pkg/dal/dao/res-event"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesreshistory "hcm/pkg/dal/dao/types/res-history"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabledisk "hcm/pkg/dal/table/cloud/disk"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tableevent "hcm/pkg/dal/table/res-event"
+	tablereshistory "hcm/pkg/dal/table/res-history"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ResChangeHistory only used for res change history.
+type ResChangeHistory interface {
+	// RecordWithTx 记录资源在当前事务中变更后的快照，创建、更新需要在资源变更后调用，删除需要在资源删除前调用。
+	// 快照与上一版本相同的创建、更新不会产生新的版本，也不会对版本记录加锁。未开启变更历史的资源类型不做记录。版本号通过对res_change_version中资源的记录加锁分配，
+	// 同一资源的并发变更会串行记录，不会产生冲突的版本号。
+	RecordWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, action enumor.AuditAction,
+		ids []string) error
+	// ListResIDsWithTx 查询匹配条件的资源ID，用于按条件变更资源前确定需要记录变更历史的资源，未开启变更历史的资源类型返回空
+	ListResIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, expr *filter.Expression) (
+		[]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesreshistory.ListResChangeHistory, error)
+	// DeleteExpired 删除指定资源类型在before之前的变更历史，单次最多删除limit条
+	DeleteExpired(kt *kit.Kit, resType enumor.CloudResourceType, before string, limit uint) (int64, error)
+}
+
+var _ ResChangeHistory = new(ResChangeHistoryDao)
+
+// ResChangeHistoryDao res change history dao.
+type ResChangeHistoryDao struct {
+	Orm   orm.Interface
+	IDGen idgen.IDGenInterface
+	// Event 不为空时，每个资源变更版本在同一事务内写入一个资源变更事件，用于推送到事件订阅
+	Event resevent.ResEvent
+	// Conf 各资源类型的变更历史记录配置
+	Conf cc.ResChangeHistoryRecord
+}
+
+// ValidateConf validate the record options of resource types, only resource types supporting change history and their
+// columns can be configured.
+func ValidateConf(conf cc.ResChangeHistoryRecord) error {
+	for resType, one := range conf.Resources {
+		res, exists := resources[resType]
+		if !exists {
+			return fmt.Errorf("resource type %s does not support change history", resType)
+		}
+
+		for _, field := range one.IgnoredFields {
+			if field == "id" || !slice.IsItemInSlice(res.columns.Columns(), field) {
+				return fmt.Errorf("ignored field %s of resource type %s is invalid", field, resType)
+			}
+		}
+	}
+
+	return nil
+}
+
+type resource struct {
+	table   table.Name
+	columns *utils.Columns
+	// extend 不为空时，用于在资源快照中补充关联资源的快照，关联资源变更时资源也会产生新的版本
+	extend func(dao ResChangeHistoryDao, kt *kit.Kit, tx *sqlx.Tx, snapshots map[string]map[string]interface{}) error
+}
+
+// resources is the resources which support change history.
+var resources = map[enumor.CloudResourceType]resource{
+	enumor.CvmCloudResType: {table: table.CvmTable, columns: tablecvm.TableColumns},
+	enumor.SecurityGroupCloudResType: {table: table.SecurityGroupTable, columns: cloud.SecurityGroupColumns,
+		extend: extendSGRules},
+	enumor.VpcCloudResType:          {table: table.VpcTable, columns: cloud.VpcColumns},
+	enumor.SubnetCloudResType:       {table: table.SubnetTable, columns: cloud.SubnetColumns},
+	enumor.LoadBalancerCloudResType: {table: table.LoadBalancerTable, columns: tablelb.LoadBalancerColumns},
+	enumor.DiskCloudResType:         {table: table.DiskTable, columns: tabledisk.DiskColumns},
+	enumor.EipCloudResType:          {table: table.EipTable, columns: tableeip.EipColumns},
+}
+
+// sgRuleResources is the security group rule tables of vendors.
+var sgRuleResources = map[enumor.Vendor]resource{
+	enumor.TCloud: {table: table.TCloudSecurityGroupRuleTable, columns: cloud.TCloudSGRuleColumns},
+	enumor.Aws:    {table: table.AwsSecurityGroupRuleTable, columns: cloud.AwsSGRuleColumns},
+	enumor.HuaWei: {table: table.HuaWeiSecurityGroupRuleTable, columns: cloud.HuaWeiSGRuleColumns},
+	enumor.Azure:  {table: table.AzureSecurityGroupRuleTable, columns: cloud.AzureSGRuleColumns},
+}
+
+// ignoredSnapshotColumns 每次变更都会修改的字段，不记录到快照中，避免没有实际变更时也产生新的版本
+var ignoredSnapshotColumns = map[string]bool{
+	"reviser":    true,
+	"updated_at": true,
+	"sync_time":  true,
+}
+
+// recordBatchSize 单次查询快照的资源数量
+const recordBatchSize = 500
+
+// RecordWithTx record the snapshot of resources changed in the transaction.
+func (dao ResChangeHistoryDao) RecordWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	action enumor.AuditAction, ids []string) error {
+
+	res, exists := resources[resType]
+	if !exists {
+		return errf.Newf(errf.InvalidParameter, "resource type %s does not support change history", resType)
+	}
+
+	if dao.Conf.Of(resType).Disable {
+		return nil
+	}
+
+	for _, batch := range slice.Split(slice.Unique(ids), recordBatchSize) {
+		if err := dao.recordBatch(kt, tx, resType, res, action, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao ResChangeHistoryDao) recordBatch(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	res resource, action enumor.AuditAction, ids []string) error {
+
+	snapshots, err := dao.listSnapshots(kt, tx, res, dao.Conf.Of(resType).IgnoredFields, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	if res.extend != nil {
+		if err = res.extend(dao, kt, tx, snapshots); err != nil {
+			return err
+		}
+	}
+
+	if action != enumor.Delete {
+		if err = dao.removeUnchanged(kt, tx, resType, snapshots); err != nil {
+			return err
+		}
+
+		if len(snapshots) == 0 {
+			return nil
+		}
+	}
+
+	latest, err := dao.lockVersions(kt, tx, resType, snapshots)
+	if err != nil {
+		return err
+	}
+
+	models := make([]*tablereshistory.ResChangeHistoryTable, 0, len(snapshots))
+	modelDiffs := make([][]corereshistory.FieldDiff, 0, len(snapshots))
+	for _, id := range ids {
+		snapshot, exists := snapshots[id]
+		if !exists {
+			continue
+		}
+
+		prev := latest[id]
+		diffs := make([]corereshistory.FieldDiff, 0)
+		if prev.Version > 0 {
+			prevSnapshot := make(map[string]interface{})
+			if err = json.UnmarshalFromString(string(prev.Snapshot), &prevSnapshot); err != nil {
+				logs.Errorf("unmarshal %s(%s) snapshot failed, err: %v, rid: %s", resType, id, err, kt.Rid)
+				return err
+			}
+
+			diffs = corereshistory.DiffSnapshot(prevSnapshot, snapshot)
+			if len(diffs) == 0 && action != enumor.Delete {
+				continue
+			}
+		}
+
+		model, err := newHistoryModel(kt, resType, id, action, prev.Version+1, snapshot, diffs)
+		if err != nil {
+			return err
+		}
+		models = append(models, model)
+		modelDiffs = append(modelDiffs, diffs)
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	historyIDs, err := dao.IDGen.Batch(kt, table.ResChangeHistoryTable, len(models))
+	if err != nil {
+		return err
+	}
+
+	for idx, model := range models {
+		model.ID = historyIDs[idx]
+		if err = model.InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ResChangeHistoryTable,
+		tablereshistory.ResChangeHistoryColumns.ColumnExpr(), tablereshistory.ResChangeHistoryColumns.ColonNameExpr())
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, models)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResChangeHistoryTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResChangeHistoryTable, err)
+	}
+
+	if err = dao.updateVersions(kt, tx, resType, action, models); err != nil {
+		return err
+	}
+
+	if dao.Event == nil {
+		return nil
+	}
+
+	events := make([]tableevent.ResEventTable, len(models))
+	for idx, model := range models {
+		events[idx] = newEventModel(model, modelDiffs[idx])
+	}
+
+	return dao.Event.BatchCreateWithTx(kt, tx, events)
+}
+
+// newEventModel convert the res change history to res change event.
+func newEventModel(model *tablereshistory.ResChangeHistoryTable,
+	diffs []corereshistory.FieldDiff) tableevent.ResEventTable {
+
+	eventType, prevBizID := coreevent.ParseEventType(model.Action, diffs)
+	return tableevent.ResEventTable{
+		EventType:   eventType,
+		ResType:     model.ResType,
+		ResID:       model.ResID,
+		CloudResID:  model.CloudResID,
+		ResName:     model.ResName,
+		Vendor:      model.Vendor,
+		AccountID:   model.AccountID,
+		BkBizID:     model.BkBizID,
+		PrevBkBizID: prevBizID,
+		Version:     model.Version,
+		Diff:        model.Diff,
+		Source:      model.Source,
+		Rid:         model.Rid,
+		Operator:    model.Creator,
+	}
+}
+
+func newHistoryModel(kt *kit.Kit, resType enumor.CloudResourceType, id string, action enumor.AuditAction,
+	version uint64, snapshot map[string]interface{}, diffs []corereshistory.FieldDiff) (
+	*tablereshistory.ResChangeHistoryTable, error) {
+
+	snapshotJson, err := tabletype.NewJsonField(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	diffJson, err := tabletype.NewJsonField(diffs)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &tablereshistory.ResChangeHistoryTable{
+		ResType:  resType,
+		ResID:    id,
+		BkBizID:  -1,
+		Version:  version,
+		Action:   action,
+		Snapshot: snapshotJson,
+		Diff:     diffJson,
+		Source:   kt.GetRequestSource(),
+		Rid:      kt.Rid,
+		Creator:  kt.User,
+	}
+
+	model.CloudResID, _ = snapshot["cloud_id"].(string)
+	model.ResName, _ = snapshot["name"].(string)
+	model.AccountID, _ = snapshot["account_id"].(string)
+	if vendor, ok := snapshot["vendor"].(string); ok {
+		model.Vendor = enumor.Vendor(vendor)
+	}
+	if bizID, ok := snapshot["bk_biz_id"].(float64); ok {
+		model.BkBizID = int64(bizID)
+	}
+
+	return model, nil
+}
+
+// removeUnchanged remove the snapshots which are the same as the latest versions without locking them, so that
+// updates without any change of recorded fields do not lock or write the versions. the resource rows are locked by
+// the update of the transaction, the versions are checked again after they are locked.
+func (dao ResChangeHistoryDao) removeUnchanged(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	snapshots map[string]map[string]interface{}) error {
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE res_type = :res_type AND res_id IN (:res_ids)`,
+		tablereshistory.ResChangeVersionColumns.NamedExpr(), table.ResChangeVersionTable)
+	versions := make([]tablereshistory.ResChangeVersionTable, 0, len(ids))
+	err := dao.Orm.Txn(tx).Select(kt.Ctx, &versions, sql, map[string]interface{}{"res_type": resType, "res_ids": ids})
+	if err != nil {
+		logs.Errorf("list %s version failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return err
+	}
+
+	for _, one := range versions {
+		if one.Version == 0 {
+			continue
+		}
+
+		prevSnapshot := make(map[string]interface{})
+		if err = json.UnmarshalFromString(string(one.Snapshot), &prevSnapshot); err != nil {
+			logs.Errorf("unmarshal %s(%s) snapshot failed, err: %v, rid: %s", resType, one.ResID, err, kt.Rid)
+			return err
+		}
+
+		if len(corereshistory.DiffSnapshot(prevSnapshot, snapshots[one.ResID])) == 0 {
+			delete(snapshots, one.ResID)
+		}
+	}
+
+	return nil
+}
+
+// listSnapshots list the snapshot of resources without the ignored fields, returns the map of resource id and
+// snapshot.
+func (dao ResChangeHistoryDao) listSnapshots(kt *kit.Kit, tx *sqlx.Tx, res resource, ignored []string,
+	ids []string) (map[string]map[string]interface{}, error) {
+
+	sql := fmt.Sprintf(`SELECT id, JSON_OBJECT(%s) AS snapshot FROM %s WHERE id IN (:ids)`,
+		snapshotPairs(res.columns, ignored), res.table)
+
+	rows := make([]struct {
+		ID       string              `db:"id"`
+		Snapshot tabletype.JsonField `db:"snapshot"`
+	}, 0)
+	err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Select(kt.Ctx, &rows, sql,
+		map[string]interface{}{"ids": ids})
+	if err != nil {
+		logs.Errorf("list %s snapshot failed, err: %v, ids: %v, rid: %s", res.table, err, ids, kt.Rid)
+		return nil, err
+	}
+
+	snapshots := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		snapshot := make(map[string]interface{})
+		if err = json.UnmarshalFromString(string(row.Snapshot), &snapshot); err != nil {
+			logs.Errorf("unmarshal %s(%s) snapshot failed, err: %v, rid: %s", res.table, row.ID, err, kt.Rid)
+			return nil, err
+		}
+		snapshots[row.ID] = snapshot
+	}
+
+	return snapshots, nil
+}
+
+// lockVersions get the latest version of resources with exclusive lock, returns the map of resource id and version.
+// 资源没有变更历史时会先插入版本号为0的记录，同一资源并发记录变更历史时会在这里串行执行，保证分配的版本号不冲突。
+func (dao ResChangeHistoryDao) lockVersions(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	snapshots map[string]map[string]interface{}) (map[string]tablereshistory.ResChangeVersionTable, error) {
+
+	// 按资源ID排序加锁，避免并发事务交叉加锁导致死锁
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	inits := make([]tablereshistory.ResChangeVersionTable, len(ids))
+	for idx, id := range ids {
+		inits[idx] = tablereshistory.ResChangeVersionTable{ResType: resType, ResID: id, Version: 0,
+			Snapshot: "{}"}
+	}
+
+	// 使用ON DUPLICATE KEY UPDATE而不是INSERT IGNORE，记录已存在时直接加排他锁，避免共享锁升级为排他锁时死锁
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s) ON DUPLICATE KEY UPDATE version = version`,
+		table.ResChangeVersionTable, tablereshistory.ResChangeVersionColumns.ColumnExpr(),
+		tablereshistory.ResChangeVersionColumns.ColonNameExpr())
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, inits); err != nil {
+		logs.Errorf("init %s version failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return nil, fmt.Errorf("init %s version failed, err: %v", resType, err)
+	}
+
+	sql = fmt.Sprintf(`SELECT %s FROM %s WHERE res_type = :res_type AND res_id IN (:res_ids) FOR UPDATE`,
+		tablereshistory.ResChangeVersionColumns.NamedExpr(), table.ResChangeVersionTable)
+	versions := make([]tablereshistory.ResChangeVersionTable, 0, len(ids))
+	err := dao.Orm.Txn(tx).Select(kt.Ctx, &versions, sql, map[string]interface{}{"res_type": resType, "res_ids": ids})
+	if err != nil {
+		logs.Errorf("lock %s version failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return nil, err
+	}
+
+	latest := make(map[string]tablereshistory.ResChangeVersionTable, len(versions))
+	for _, one := range versions {
+		latest[one.ResID] = one
+	}
+
+	return latest, nil
+}
+
+// updateVersions update the latest version of resources to the recorded histories, the version of deleted
+// resources are removed.
+func (dao ResChangeHistoryDao) updateVersions(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	action enumor.AuditAction, models []*tablereshistory.ResChangeHistoryTable) error {
+
+	if action == enumor.Delete {
+		ids := make([]string, len(models))
+		for idx, model := range models {
+			ids[idx] = model.ResID
+		}
+
+		sql := fmt.Sprintf(`DELETE FROM %s WHERE res_type = :res_type AND res_id IN (:res_ids)`,
+			table.ResChangeVersionTable)
+		_, err := dao.Orm.Txn(tx).Delete(kt.Ctx, sql, map[string]interface{}{"res_type": resType, "res_ids": ids})
+		if err != nil {
+			logs.Errorf("delete %s version failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	versions := make([]tablereshistory.ResChangeVersionTable, len(models))
+	for idx, model := range models {
+		versions[idx] = tablereshistory.ResChangeVersionTable{ResType: resType, ResID: model.ResID,
+			Version: model.Version, Snapshot: model.Snapshot}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s) ON DUPLICATE KEY UPDATE version = VALUES(version), `+
+		`snapshot = VALUES(snapshot)`, table.ResChangeVersionTable,
+		tablereshistory.ResChangeVersionColumns.ColumnExpr(), tablereshistory.ResChangeVersionColumns.ColonNameExpr())
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, versions); err != nil {
+		logs.Errorf("update %s version failed, err: %v, rid: %s", resType, err, kt.Rid)
+		return fmt.Errorf("update %s version failed, err: %v", resType, err)
+	}
+
+	return nil
+}
+
+// extendSGRules add the rules of security groups to the snapshots, as the map of rule id and rule snapshot.
+func extendSGRules(dao ResChangeHistoryDao, kt *kit.Kit, tx *sqlx.Tx,
+	snapshots map[string]map[string]interface{}) error {
+
+	vendorIDs := make(map[enumor.Vendor][]string)
+	for id, snapshot := range snapshots {
+		snapshot["rules"] = make(map[string]interface{})
+		vendor, _ := snapshot["vendor"].(string)
+		vendorIDs[enumor.Vendor(vendor)] = append(vendorIDs[enumor.Vendor(vendor)], id)
+	}
+
+	for vendor, ids := range vendorIDs {
+		res, exists := sgRuleResources[vendor]
+		if !exists {
+			continue
+		}
+
+		sql := fmt.Sprintf(`SELECT id, security_group_id, JSON_OBJECT(%s) AS snapshot FROM %s `+
+			`WHERE security_group_id IN (:ids)`, snapshotPairs(res.columns, nil), res.table)
+		rows := make([]struct {
+			ID              string              `db:"id"`
+			SecurityGroupID string              `db:"security_group_id"`
+			Snapshot        tabletype.JsonField `db:"snapshot"`
+		}, 0)
+		if err := dao.Orm.Txn(tx).Select(kt.Ctx, &rows, sql, map[string]interface{}{"ids": ids}); err != nil {
+			logs.Errorf("list %s snapshot failed, err: %v, sg ids: %v, rid: %s", res.table, err, ids, kt.Rid)
+			return err
+		}
+
+		for _, row := range rows {
+			rule := make(map[string]interface{})
+			if err := json.UnmarshalFromString(string(row.Snapshot), &rule); err != nil {
+				logs.Errorf("unmarshal %s(%s) snapshot failed, err: %v, rid: %s", res.table, row.ID, err, kt.Rid)
+				return err
+			}
+			snapshots[row.SecurityGroupID]["rules"].(map[string]interface{})[row.ID] = rule
+		}
+	}
+
+	return nil
+}
+
+// snapshotPairs returns the key value pairs of the JSON_OBJECT expression of the snapshot columns, the default
+// ignored columns and the configured ignored fields of resource type are excluded.
+func snapshotPairs(columns *utils.Columns, ignored []string) string {
+	pairs := make([]string, 0)
+	for _, column := range columns.Columns() {
+		if ignoredSnapshotColumns[column] || slice.IsItemInSlice(ignored, column) {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("'%s', %s", column, column))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// ListResIDsWithTx list the ids of resources matched by the filter expression.
+func (dao ResChangeHistoryDao) ListResIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	expr *filter.Expression) ([]string, error) {
+
+	res, exists := resources[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support change history", resType)
+	}
+
+	if dao.Conf.Of(resType).Disable {
+		return make([]string, 0), nil
+	}
+
+	if expr == nil {
+		return nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT id FROM %s %s`, res.table, whereExpr)
+	ids := make([]string, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Select(kt.Ctx, &ids, sql, whereValue)
+	if err != nil {
+		logs.Errorf("list %s ids failed, err: %v, filter: %s, rid: %s", resType, err, expr, kt.Rid)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// List res change history.
+func (dao ResChangeHistoryDao) List(kt *kit.Kit, opt *types.ListOption) (*typesreshistory.ListResChangeHistory,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tablereshistory.ResChangeHistoryColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResChangeHistoryTable, whereExpr)
+
+		count, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.Errorf("count res change history failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesreshistory.ListResChangeHistory{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`,
+		tablereshistory.ResChangeHistoryColumns.FieldsNamedExpr(opt.Fields), table.ResChangeHistoryTable, whereExpr,
+		pageExpr)
+
+	details := make([]tablereshistory.ResChangeHistoryTable, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typesreshistory.ListResChangeHistory{Details: details}, nil
+}
+
+// DeleteExpired delete the res change history created before the given time.
+func (dao ResChangeHistoryDao) DeleteExpired(kt *kit.Kit, resType enumor.CloudResourceType, before string,
+	limit uint) (int64, error) {
+
+	if len(resType) == 0 || len(before) == 0 || limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "res type, before and limit are required")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE res_type = :res_type AND created_at < :before LIMIT %d`,
+		table.ResChangeHistoryTable, limit)
+	deleted, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Delete(kt.Ctx, sql,
+		map[string]interface{}{"res_type": resType, "before": before})
+	if err != nil {
+		logs.Errorf("delete expired %s change history failed, err: %v, before: %s, rid: %s", resType, err, before,
+			kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+)
+
+func TestValidateConf(t *testing.T) {
+	conf := cc.ResChangeHistoryRecord{Resources: map[enumor.CloudResourceType]cc.ResChangeHistoryResource{
+		enumor.CvmCloudResType: {IgnoredFields: []string{"recycle_status"}},
+		enumor.VpcCloudResType: {Disable: true},
+	}}
+	if err := ValidateConf(conf); err != nil {
+		t.Errorf("validate conf failed, err: %v", err)
+	}
+
+	invalids := []map[enumor.CloudResourceType]cc.ResChangeHistoryResource{
+		{enumor.CvmCloudResType: {IgnoredFields: []string{"not_exist"}}},
+		{enumor.CvmCloudResType: {IgnoredFields: []string{"id"}}},
+		{enumor.RouteTableCloudResType: {Disable: true}},
+	}
+	for _, one := range invalids {
+		if err := ValidateConf(cc.ResChangeHistoryRecord{Resources: one}); err == nil {
+			t.Errorf("validate invalid conf %v should fail", one)
+		}
+	}
+}
+
+func TestSnapshotPairs(t *testing.T) {
+	pairs := snapshotPairs(tablecvm.TableColumns, []string{"recycle_status"})
+
+	if !strings.Contains(pairs, "'cloud_id', cloud_id") {
+		t.Errorf("snapshot pairs should contain cloud_id, got %s", pairs)
+	}
+
+	for _, column := range []string{"recycle_status", "updated_at", "reviser", "sync_time"} {
+		if strings.Contains(pairs, "'"+column+"'") {
+			t.Errorf("snapshot pairs should not contain %s, got %s", column, pairs)
+		}
+	}
+}
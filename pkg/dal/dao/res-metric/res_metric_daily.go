@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric 资源监控指标的Package
+package resmetric
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgen "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesresmetric "hcm/pkg/dal/dao/types/res-metric"
+	"hcm/pkg/dal/table"
+	tableresmetric "hcm/pkg/dal/table/res-metric"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ResMetricDaily only used for res metric daily.
+type ResMetricDaily interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableresmetric.ResMetricDailyTable) ([]string, error)
+	BatchUpsertWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableresmetric.ResMetricDailyTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesresmetric.ListResMetricDaily, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ ResMetricDaily = new(ResMetricDailyDao)
+
+// ResMetricDailyDao res metric daily dao.
+type ResMetricDailyDao struct {
+	Orm   orm.Interface
+	IDGen idgen.IDGenInterface
+}
+
+// BatchCreateWithTx create res metric daily.
+func (dao ResMetricDailyDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tableresmetric.ResMetricDailyTable) ([]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	tableName := table.ResMetricDailyTable
+	ids, err := dao.IDGen.Batch(kt, tableName, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		models[index].Creator = kt.User
+		models[index].Reviser = kt.User
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName, tableresmetric.ResMetricDailyColumns.ColumnExpr(),
+		tableresmetric.ResMetricDailyColumns.ColonNameExpr())
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).BulkInsert(kt.Ctx, sql, models)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+	}
+
+	return ids, nil
+}
+
+// BatchUpsertWithTx 写入天级监控数据，先删除同一资源、指标、日期的旧数据再写入，重复写入同一批数据结果不变。
+// 同一批数据中同一资源、指标、日期重复时以最后一条为准。
+func (dao ResMetricDailyDao) BatchUpsertWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tableresmetric.ResMetricDailyTable) ([]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to upsert cannot be empty")
+	}
+
+	models = uniqueDailyModels(models)
+	for _, expr := range dailyDeleteExprs(models) {
+		if err := dao.DeleteWithTx(kt, tx, expr); err != nil {
+			return nil, err
+		}
+	}
+
+	return dao.BatchCreateWithTx(kt, tx, models)
+}
+
+// dailyKey 天级监控数据的唯一键
+type dailyKey struct {
+	resType  string
+	resID    string
+	metric   string
+	statDate string
+}
+
+func uniqueDailyModels(models []tableresmetric.ResMetricDailyTable) []tableresmetric.ResMetricDailyTable {
+	indexes := make(map[dailyKey]int, len(models))
+	result := make([]tableresmetric.ResMetricDailyTable, 0, len(models))
+	for _, one := range models {
+		key := dailyKey{resType: string(one.ResType), resID: one.ResID, metric: one.Metric, statDate: one.StatDate}
+		if idx, exists := indexes[key]; exists {
+			result[idx] = one
+			continue
+		}
+
+		indexes[key] = len(result)
+		result = append(result, one)
+	}
+
+	return result
+}
+
+// dailyDeleteExprs 按资源类型、指标、日期分组，生成使用 res_id IN 删除旧数据的条件
+func dailyDeleteExprs(models []tableresmetric.ResMetricDailyTable) []*filter.Expression {
+	groups := make(map[dailyKey][]string)
+	for _, one := range models {
+		key := dailyKey{resType: string(one.ResType), metric: one.Metric, statDate: one.StatDate}
+		groups[key] = append(groups[key], one.ResID)
+	}
+
+	keys := make([]dailyKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].resType != keys[j].resType {
+			return keys[i].resType < keys[j].resType
+		}
+		if keys[i].metric != keys[j].metric {
+			return keys[i].metric < keys[j].metric
+		}
+		return keys[i].statDate < keys[j].statDate
+	})
+
+	exprs := make([]*filter.Expression, 0, len(keys))
+	for _, key := range keys {
+		exprs = append(exprs, tools.ExpressionAnd(
+			tools.RuleEqual("res_type", key.resType),
+			tools.RuleEqual("metric", key.metric),
+			tools.RuleEqual("stat_date", key.statDate),
+			tools.RuleIn("res_id", groups[key]),
+		))
+	}
+
+	return exprs
+}
+
+// List res metric daily.
+func (dao ResMetricDailyDao) List(kt *kit.Kit, opt *types.ListOption) (*typesresmetric.ListResMetricDaily, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableresmetric.ResMetricDailyColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResMetricDailyTable, whereExpr)
+
+		count, err := dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.Errorf("count res metric daily failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesresmetric.ListResMetricDaily{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableresmetric.ResMetricDailyColumns.FieldsNamedExpr(opt.Fields),
+		table.ResMetricDailyTable, whereExpr, pageExpr)
+
+	details := make([]tableresmetric.ResMetricDailyTable, 0)
+	err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Do().Select(kt.Ctx, &details, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typesresmetric.ListResMetricDaily{Details: details}, nil
+}
+
+// DeleteWithTx delete res metric daily.
+func (dao ResMetricDailyDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.ResMetricDailyTable, whereExpr)
+	_, err = dao.Orm.ModifySQLOpts(orm.NewInjectTenantIDOpt(kt.TenantID)).Txn(tx).Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.Errorf("delete res metric daily failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
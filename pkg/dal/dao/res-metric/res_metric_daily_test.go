@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resmetric
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	idgen "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableresmetric "hcm/pkg/dal/table/res-metric"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+
+	_ "github.com/go-sql-driver/mysql" // import mysql drive, used to create conn.
+	"github.com/jmoiron/sqlx"
+)
+
+// testConfig 加载dao依赖的data-service运行时配置，只包含通过校验所需的配置项
+const testConfig = `
+network:
+  bindIP: 127.0.0.1
+service:
+  etcd:
+    endpoints:
+      - 127.0.0.1:2379
+database:
+  resource:
+    endpoints:
+      - 127.0.0.1:3306
+    database: hcm
+    user: root
+    password: admin
+cmdb:
+  endpoints:
+    - http://127.0.0.1
+  appCode: hcm
+  appSecret: secret
+crypto:
+  aesGcm:
+    key: 0123456789abcdef
+    nonce: 0123456789ab
+`
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "hcm-dao-test")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	file := filepath.Join(dir, "data_service.yaml")
+	if err = os.WriteFile(file, []byte(testConfig), 0o600); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	cc.InitService(cc.DataServiceName)
+	err = cc.LoadSettings(&cc.SysOption{ConfigFile: file})
+	os.RemoveAll(dir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	os.Exit(m.Run())
+}
+
+func testDailyModel(resID, metric string, avg float64) tableresmetric.ResMetricDailyTable {
+	return tableresmetric.ResMetricDailyTable{
+		Vendor:      enumor.TCloud,
+		AccountID:   "account",
+		Region:      "ap-guangzhou",
+		ResType:     enumor.CvmCloudResType,
+		ResID:       resID,
+		CloudResID:  "ins-" + resID,
+		Metric:      metric,
+		StatDate:    "2024-05-01",
+		AvgValue:    avg,
+		MaxValue:    avg,
+		MinValue:    avg,
+		SampleCount: 1,
+	}
+}
+
+func TestUniqueDailyModels(t *testing.T) {
+	models := []tableresmetric.ResMetricDailyTable{
+		testDailyModel("00000001", "cpu_usage", 1),
+		testDailyModel("00000002", "cpu_usage", 2),
+		testDailyModel("00000001", "mem_usage", 3),
+		testDailyModel("00000001", "cpu_usage", 4),
+	}
+
+	// 重复的唯一键保留最后一条，位置不变
+	expect := []tableresmetric.ResMetricDailyTable{models[3], models[1], models[2]}
+	if got := uniqueDailyModels(models); !reflect.DeepEqual(got, expect) {
+		t.Errorf("unexpected unique models: %+v", got)
+	}
+}
+
+func TestDailyDeleteExprs(t *testing.T) {
+	models := []tableresmetric.ResMetricDailyTable{
+		testDailyModel("00000002", "mem_usage", 1),
+		testDailyModel("00000001", "cpu_usage", 1),
+		testDailyModel("00000002", "cpu_usage", 1),
+	}
+
+	expect := []*filter.Expression{
+		tools.ExpressionAnd(
+			tools.RuleEqual("res_type", string(enumor.CvmCloudResType)),
+			tools.RuleEqual("metric", "cpu_usage"),
+			tools.RuleEqual("stat_date", "2024-05-01"),
+			tools.RuleIn("res_id", []string{"00000001", "00000002"}),
+		),
+		tools.ExpressionAnd(
+			tools.RuleEqual("res_type", string(enumor.CvmCloudResType)),
+			tools.RuleEqual("metric", "mem_usage"),
+			tools.RuleEqual("stat_date", "2024-05-01"),
+			tools.RuleIn("res_id", []string{"00000002"}),
+		),
+	}
+	if got := dailyDeleteExprs(models); !reflect.DeepEqual(got, expect) {
+		t.Errorf("unexpected delete exprs: %v", got)
+	}
+}
+
+// TestBatchUpsertWithTx 需要本地的 hcm 数据库，无法连接时跳过
+func TestBatchUpsertWithTx(t *testing.T) {
+	source := "root:admin@tcp(127.0.0.1:3306)/hcm?parseTime=true&charset=utf8mb4"
+	db, err := sqlx.Connect("mysql", source)
+	if err != nil {
+		t.Skipf("connect to mysql failed, err: %v", err)
+	}
+	defer db.Close()
+
+	dao := ResMetricDailyDao{Orm: orm.InitOrm(db), IDGen: idgen.New(db, idgen.DefaultMaxRetryCount)}
+	kt := kit.New()
+	resIDs := []string{"test-upsert-1", "test-upsert-2"}
+	delExpr := tools.ExpressionAnd(tools.RuleIn("res_id", resIDs))
+
+	upsert := func(models ...tableresmetric.ResMetricDailyTable) {
+		if _, err := dao.Orm.AutoTxn(kt, func(tx *sqlx.Tx, _ *orm.TxnOption) (interface{}, error) {
+			return dao.BatchUpsertWithTx(kt, tx, models)
+		}); err != nil {
+			t.Fatalf("upsert res metric daily failed, err: %v", err)
+		}
+	}
+	list := func() map[string]float64 {
+		result, err := dao.List(kt, &types.ListOption{Filter: delExpr, Page: &core.BasePage{Limit: 10}})
+		if err != nil {
+			t.Fatalf("list res metric daily failed, err: %v", err)
+		}
+
+		values := make(map[string]float64, len(result.Details))
+		for _, one := range result.Details {
+			if _, exists := values[one.ResID+"/"+one.Metric]; exists {
+				t.Errorf("duplicate res metric daily: %+v", one)
+			}
+			values[one.ResID+"/"+one.Metric] = one.AvgValue
+		}
+		return values
+	}
+
+	defer dao.Orm.AutoTxn(kt, func(tx *sqlx.Tx, _ *orm.TxnOption) (interface{}, error) {
+		return nil, dao.DeleteWithTx(kt, tx, delExpr)
+	})
+
+	upsert(testDailyModel(resIDs[0], "cpu_usage", 1), testDailyModel(resIDs[1], "cpu_usage", 2))
+	// 重复写入同一天的数据，只保留最新的值
+	upsert(testDailyModel(resIDs[0], "cpu_usage", 10), testDailyModel(resIDs[0], "cpu_usage", 11))
+	upsert(testDailyModel(resIDs[0], "cpu_usage", 11))
+
+	expect := map[string]float64{resIDs[0] + "/cpu_usage": 11, resIDs[1] + "/cpu_usage": 2}
+	if got := list(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("unexpected res metric daily: %v", got)
+	}
+}
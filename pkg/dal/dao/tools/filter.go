@@ -123,10 +123,16 @@ func RuleNotIn[T any](fieldName string, values []T) *filter.AtomRule {
 }
 
 // RuleIDGreaterThan 生成资源字段等于查询的AtomRule，即id > values
+// 用于按ID遍历时要求资源ID按创建顺序递增，需将资源加入ID生成器的orderedIDResources，避免使用号段模式
 func RuleIDGreaterThan(value any) *filter.AtomRule {
 	return &filter.AtomRule{Field: "id", Op: filter.IDGreaterThan.Factory(), Value: value}
 }
 
+// RuleIDLessThan 生成资源字段小于查询的AtomRule，即id < values
+func RuleIDLessThan(value any) *filter.AtomRule {
+	return &filter.AtomRule{Field: "id", Op: filter.IDLessThan.Factory(), Value: value}
+}
+
 // RuleGreaterThan 生成资源字段等于查询的AtomRule，即fieldName > values
 func RuleGreaterThan(fieldName string, value any) *filter.AtomRule {
 	return &filter.AtomRule{Field: fieldName, Op: filter.GreaterThan.Factory(), Value: value}
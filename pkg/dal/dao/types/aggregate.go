@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/runtime/filter"
+)
+
+// AggregateOption defines options to aggregate resources.
+type AggregateOption struct {
+	Filter       *filter.Expression
+	GroupBy      []string
+	Aggregations []core.Aggregation
+	Limit        uint
+}
+
+// Validate aggregate option against the table's column types, group by columns and aggregated
+// fields must be the table's columns, and json column is not supported to be grouped or aggregated.
+func (opt *AggregateOption) Validate(columnTypes map[string]enumor.ColumnType) error {
+	req := &core.AggregateReq{Filter: opt.Filter, GroupBy: opt.GroupBy, Aggregations: opt.Aggregations,
+		Limit: opt.Limit}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if err := opt.Filter.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		return err
+	}
+
+	for _, col := range opt.GroupBy {
+		typ, exists := columnTypes[col]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "group_by column %s is not exist", col)
+		}
+
+		if typ == enumor.Json {
+			return errf.Newf(errf.InvalidParameter, "json column %s is not supported to group by", col)
+		}
+	}
+
+	for _, one := range opt.Aggregations {
+		if len(one.Field) == 0 {
+			continue
+		}
+
+		typ, exists := columnTypes[one.Field]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "aggregation field %s is not exist", one.Field)
+		}
+
+		if typ == enumor.Json {
+			return errf.Newf(errf.InvalidParameter, "json column %s is not supported to aggregate", one.Field)
+		}
+
+		if (one.Op == core.AggSum || one.Op == core.AggAvg) && typ != enumor.Numeric {
+			return errf.Newf(errf.InvalidParameter, "%s aggregation only supports numeric column, %s is %s",
+				one.Op, one.Field, typ)
+		}
+	}
+
+	return nil
+}
+
+// SelectExpr returns the select expression which packs the group by columns and aggregated values of
+// each group into a json object named 'detail', so that the dynamic columns can be scanned at once.
+// Note: the columns and aliases are validated by Validate, so they are safe to be joined into sql.
+func (opt *AggregateOption) SelectExpr() string {
+	pairs := make([]string, 0, len(opt.GroupBy)+len(opt.Aggregations))
+	for _, col := range opt.GroupBy {
+		pairs = append(pairs, fmt.Sprintf("'%s', %s", col, col))
+	}
+
+	for _, one := range opt.Aggregations {
+		field := one.Field
+		if len(field) == 0 {
+			field = "*"
+		}
+		pairs = append(pairs, fmt.Sprintf("'%s', %s(%s)", one.Name(), one.Op.SQLFunc(), field))
+	}
+
+	return fmt.Sprintf("JSON_OBJECT(%s) AS detail", strings.Join(pairs, ", "))
+}
+
+// GroupExpr returns the group by, order by and limit expression of the aggregation.
+func (opt *AggregateOption) GroupExpr() string {
+	limit := opt.Limit
+	if limit == 0 {
+		limit = core.AggregateMaxLimit
+	}
+
+	if len(opt.GroupBy) == 0 {
+		return fmt.Sprintf("LIMIT %d", limit)
+	}
+
+	groupBy := strings.Join(opt.GroupBy, ", ")
+	return fmt.Sprintf("GROUP BY %s ORDER BY %s LIMIT %d", groupBy, groupBy, limit)
+}
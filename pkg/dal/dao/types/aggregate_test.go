@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+var testAggColumnTypes = map[string]enumor.ColumnType{
+	"id":        enumor.String,
+	"region":    enumor.String,
+	"status":    enumor.String,
+	"disk_size": enumor.Numeric,
+	"extension": enumor.Json,
+}
+
+func TestAggregateOptionValidate(t *testing.T) {
+	expr := &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{}}
+
+	valid := []AggregateOption{
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: core.AggCount}}},
+		{Filter: expr, GroupBy: []string{"region", "status"}, Aggregations: []core.Aggregation{
+			{Op: core.AggCount}, {Op: core.AggSum, Field: "disk_size", Alias: "total_size"},
+			{Op: core.AggMax, Field: "status"}}},
+	}
+	for idx, one := range valid {
+		if err := one.Validate(testAggColumnTypes); err != nil {
+			t.Errorf("case %d should be valid, err: %v", idx, err)
+		}
+	}
+
+	invalid := []AggregateOption{
+		// filter is required
+		{Aggregations: []core.Aggregation{{Op: core.AggCount}}},
+		// aggregations is required
+		{Filter: expr, GroupBy: []string{"region"}},
+		// group by column not exist
+		{Filter: expr, GroupBy: []string{"zone"}, Aggregations: []core.Aggregation{{Op: core.AggCount}}},
+		// json column is not supported
+		{Filter: expr, GroupBy: []string{"extension"}, Aggregations: []core.Aggregation{{Op: core.AggCount}}},
+		// sum only supports numeric column
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: core.AggSum, Field: "region"}}},
+		// field is required for sum
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: core.AggSum}}},
+		// unsupported op
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: "stddev", Field: "disk_size"}}},
+		// alias conflicts with group by column
+		{Filter: expr, GroupBy: []string{"region"}, Aggregations: []core.Aggregation{{Op: core.AggCount,
+			Alias: "region"}}},
+		// invalid alias
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: core.AggCount, Alias: "count') , ('x"}}},
+		// limit exceeds max
+		{Filter: expr, Aggregations: []core.Aggregation{{Op: core.AggCount}}, Limit: core.AggregateMaxLimit + 1},
+	}
+	for idx, one := range invalid {
+		if err := one.Validate(testAggColumnTypes); err == nil {
+			t.Errorf("case %d should be invalid", idx)
+		}
+	}
+}
+
+func TestAggregateOptionSQLExpr(t *testing.T) {
+	opt := &AggregateOption{
+		GroupBy: []string{"region", "status"},
+		Aggregations: []core.Aggregation{{Op: core.AggCount},
+			{Op: core.AggSum, Field: "disk_size", Alias: "total_size"}, {Op: core.AggAvg, Field: "disk_size"}},
+		Limit: 10,
+	}
+
+	selectExpr := "JSON_OBJECT('region', region, 'status', status, 'count', COUNT(*), 'total_size', SUM(disk_size), " +
+		"'avg_disk_size', AVG(disk_size)) AS detail"
+	if opt.SelectExpr() != selectExpr {
+		t.Errorf("select expr is invalid, expr: %s", opt.SelectExpr())
+	}
+
+	if opt.GroupExpr() != "GROUP BY region, status ORDER BY region, status LIMIT 10" {
+		t.Errorf("group expr is invalid, expr: %s", opt.GroupExpr())
+	}
+
+	opt.GroupBy = nil
+	opt.Limit = 0
+	if opt.GroupExpr() != "LIMIT 1000" {
+		t.Errorf("group expr without group by is invalid, expr: %s", opt.GroupExpr())
+	}
+}
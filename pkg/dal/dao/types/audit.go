@@ -26,3 +26,15 @@ type ListAuditDetails struct {
 	Count   uint64             `json:"count"`
 	Details []audit.AuditTable `json:"details"`
 }
+
+// ListAuditArchiveDetails list audit archive details.
+type ListAuditArchiveDetails struct {
+	Count   uint64               `json:"count"`
+	Details []audit.ArchiveTable `json:"details"`
+}
+
+// ListAuditCheckpointDetails list audit checkpoint details.
+type ListAuditCheckpointDetails struct {
+	Count   uint64                  `json:"count"`
+	Details []audit.CheckpointTable `json:"details"`
+}
@@ -47,8 +47,9 @@ type ListAccountBillSummaryDailyDetails struct {
 
 // ListAccountBillItemDetails list account bill summary daily details.
 type ListAccountBillItemDetails struct {
-	Count   uint64                      `json:"count,omitempty"`
-	Details []tablebill.AccountBillItem `json:"details,omitempty"`
+	Count      uint64                      `json:"count,omitempty"`
+	Details    []tablebill.AccountBillItem `json:"details,omitempty"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
 }
 
 // ListAccountBillMonthPullTaskDetails list account bill month pull details
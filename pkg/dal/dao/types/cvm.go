@@ -23,6 +23,7 @@ import tablecvm "hcm/pkg/dal/table/cloud/cvm"
 
 // ListCvmDetails list cvm details.
 type ListCvmDetails struct {
-	Count   uint64           `json:"count,omitempty"`
-	Details []tablecvm.Table `json:"details,omitempty"`
+	Count      uint64           `json:"count,omitempty"`
+	Details    []tablecvm.Table `json:"details,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
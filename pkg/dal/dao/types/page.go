@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/runtime/filter"
 )
 
 // PageSQLOption defines the options to generate a sql expression
@@ -35,6 +36,10 @@ type PageSQLOption struct {
 	// 1. If set, then user defined Sort field will be overlapped.
 	// 2. Sort field should always be an indexed field in db.
 	Sort SortOption `json:"sort"`
+	// Cursor defines whether the dao supports cursor pagination, which means the dao locates the
+	// position of the cursor page by ListOption.SQLWhereExpr and returns the next cursor by NextCursor.
+	// the cursor page is rejected by the dao which does not support it.
+	Cursor bool `json:"cursor"`
 }
 
 // SortOption defines how to set the order column when do the BasePage.SQLExpr
@@ -57,6 +62,7 @@ type SortOption struct {
 //  2. if sort is not set, use the default resource's identity 'id' as the sort key.
 //  3. if Sort is set by the system(PageSQLOption.Sort), then use its Sort value
 //     according to the various options.
+//  4. cursor page is only supported when PageSQLOption.Cursor is enabled.
 //
 // see the test case to get more returned example and learn the supported scenarios.
 func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error) {
@@ -75,6 +81,17 @@ func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error)
 		// this is a count query clause.
 		return "", errors.New("page.count is enabled, do not support generate SQL expression")
 	}
+	if bp.IsCursor() {
+		if !ps.Cursor {
+			return "", errors.New("page.use_cursor is not supported by this resource")
+		}
+		// cursor pagination is always ordered by the stable key, and the position is
+		// located by the where expression, see CursorSQLWhereOption.
+		if bp.Limit == 0 {
+			return "", errors.New("page.limit value should >= 1")
+		}
+		return fmt.Sprintf("ORDER BY %s %s LIMIT %d", core.CursorKey, bp.Order.Order(), bp.Limit), nil
+	}
 	if bp.Start == 0 && bp.Limit == 0 {
 		// it means do not need to sort.
 		return "", nil
@@ -110,3 +127,52 @@ func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error)
 	expr = fmt.Sprintf("%s %s LIMIT %d OFFSET %d", expr, bp.Order.Order(), bp.Limit, bp.Start)
 	return expr, nil
 }
+
+// CursorSQLWhereOption returns the sql where option which locates the position of the cursor page,
+// the returned option is the same as the given option if the page is not a cursor page or the first
+// cursor page. the cursor rule is appended to the 'AND' crowned rules of the given option.
+func CursorSQLWhereOption(bp *core.BasePage, opt *filter.SQLWhereOption) (*filter.SQLWhereOption, error) {
+	if bp == nil || !bp.IsCursor() || len(bp.Cursor) == 0 {
+		return opt, nil
+	}
+
+	cursor, err := core.DecodeCursor(bp.Cursor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rule := &filter.AtomRule{Field: core.CursorKey, Op: filter.IDGreaterThan.Factory(), Value: cursor.Value}
+	if cursor.Order == core.Descending {
+		rule.Op = filter.IDLessThan.Factory()
+	}
+
+	merged := new(filter.SQLWhereOption)
+	if opt != nil {
+		*merged = *opt
+	}
+
+	if merged.CrownedOption == nil || len(merged.CrownedOption.Rules) == 0 {
+		merged.CrownedOption = &filter.CrownedOption{CrownedOp: filter.And, Rules: []filter.RuleFactory{rule}}
+		return merged, nil
+	}
+
+	if merged.CrownedOption.CrownedOp != filter.And {
+		return nil, errf.Newf(errf.InvalidParameter, "cursor page does not support %s crowned option",
+			merged.CrownedOption.CrownedOp)
+	}
+
+	rules := make([]filter.RuleFactory, 0, len(merged.CrownedOption.Rules)+1)
+	rules = append(rules, merged.CrownedOption.Rules...)
+	merged.CrownedOption = &filter.CrownedOption{CrownedOp: filter.And, Rules: append(rules, rule)}
+	return merged, nil
+}
+
+// NextCursor returns the cursor of next page based on the last returned resource's id. it returns
+// empty if the page is not a cursor page or there are no more resources.
+func NextCursor(bp *core.BasePage, lastID string, count int) string {
+	if bp == nil || !bp.IsCursor() || count == 0 || uint(count) < bp.Limit {
+		return ""
+	}
+
+	return core.EncodeCursor(lastID, bp.Order)
+}
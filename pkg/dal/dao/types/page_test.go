@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestCursorPageValidate(t *testing.T) {
+	valid := []core.BasePage{
+		{UseCursor: true, Limit: 100},
+		{UseCursor: true, Limit: 100, Sort: "id", Order: core.Descending},
+		{UseCursor: true, Limit: 100, Cursor: core.EncodeCursor("00000010", core.Ascending)},
+		{UseCursor: true, Limit: 100, Order: core.Descending, Cursor: core.EncodeCursor("00000010", core.Descending)},
+	}
+	for idx, one := range valid {
+		if err := one.Validate(); err != nil {
+			t.Errorf("case %d should be valid, err: %v", idx, err)
+		}
+	}
+
+	invalid := []core.BasePage{
+		{UseCursor: true, Count: true},
+		{UseCursor: true, Limit: 100, Start: 100},
+		{UseCursor: true, Limit: 100, Sort: "name"},
+		{UseCursor: true, Limit: 0},
+		{UseCursor: true, Limit: core.DefaultMaxPageLimit + 1},
+		{UseCursor: true, Limit: 100, Cursor: "not-a-cursor"},
+		{UseCursor: true, Limit: 100, Order: core.Descending, Cursor: core.EncodeCursor("00000010", core.Ascending)},
+		{Limit: 100, Cursor: core.EncodeCursor("00000010", core.Ascending)},
+	}
+	for idx, one := range invalid {
+		if err := one.Validate(); err == nil {
+			t.Errorf("case %d should be invalid", idx)
+		}
+	}
+}
+
+func TestCursorPageSQLExpr(t *testing.T) {
+	page := &core.BasePage{UseCursor: true, Limit: 100, Order: core.Descending, Sort: "id"}
+	if _, err := PageSQLExpr(page, DefaultPageSQLOption); err == nil {
+		t.Errorf("cursor page should be rejected by the dao which does not support it")
+	}
+
+	expr, err := PageSQLExpr(page, CursorPageSQLOption)
+	if err != nil {
+		t.Fatalf("generate cursor page sql expr failed, err: %v", err)
+	}
+
+	if expr != "ORDER BY id DESC LIMIT 100" {
+		t.Errorf("cursor page sql expr is invalid, expr: %s", expr)
+	}
+}
+
+func TestCursorSQLWhereOption(t *testing.T) {
+	expr := &filter.Expression{
+		Op:    filter.And,
+		Rules: []filter.RuleFactory{&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: "tcloud"}},
+	}
+
+	// the first cursor page does not need to locate position.
+	opt := ListOption{Filter: expr, Page: &core.BasePage{UseCursor: true, Limit: 100}}
+	where, _, err := opt.SQLWhereExpr(&filter.SQLWhereOption{Priority: filter.Priority{"id"}})
+	if err != nil {
+		t.Fatalf("generate first cursor page where expr failed, err: %v", err)
+	}
+	if strings.Contains(where, "id") {
+		t.Errorf("first cursor page where expr should not contain id rule, where: %s", where)
+	}
+
+	opt.Page.Order = core.Descending
+	opt.Page.Cursor = core.EncodeCursor("00000010", core.Descending)
+	crowned := &filter.SQLWhereOption{
+		Priority: filter.Priority{"id"},
+		CrownedOption: &filter.CrownedOption{
+			CrownedOp: filter.And,
+			Rules:     []filter.RuleFactory{&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: 1}},
+		},
+	}
+	where, value, err := opt.SQLWhereExpr(crowned)
+	if err != nil {
+		t.Fatalf("generate cursor page where expr failed, err: %v", err)
+	}
+	if !strings.HasPrefix(where, "WHERE id < :id_") || !strings.Contains(where, "bk_biz_id = ") {
+		t.Errorf("cursor page where expr is invalid, where: %s", where)
+	}
+	if len(value) != 3 {
+		t.Errorf("cursor page where value is invalid, value: %v", value)
+	}
+	if len(crowned.CrownedOption.Rules) != 1 {
+		t.Errorf("cursor page should not modify the given where option")
+	}
+}
+
+func TestNextCursor(t *testing.T) {
+	page := &core.BasePage{UseCursor: true, Limit: 2, Order: core.Descending}
+	if cursor := NextCursor(page, "00000010", 1); cursor != "" {
+		t.Errorf("last cursor page should not return next cursor, got: %s", cursor)
+	}
+
+	cursor, err := core.DecodeCursor(NextCursor(page, "00000010", 2))
+	if err != nil {
+		t.Fatalf("decode next cursor failed, err: %v", err)
+	}
+	if cursor.Value != "00000010" || cursor.Order != core.Descending {
+		t.Errorf("next cursor is invalid, cursor: %+v", cursor)
+	}
+
+	if cursor := NextCursor(&core.BasePage{Limit: 2}, "00000010", 2); cursor != "" {
+		t.Errorf("offset page should not return next cursor, got: %s", cursor)
+	}
+}
@@ -28,8 +28,9 @@ import (
 
 // ListResult define list result.
 type ListResult[T any] struct {
-	Count   uint64 `json:"count,omitempty"`
-	Details []T    `json:"details,omitempty"`
+	Count      uint64 `json:"count,omitempty"`
+	Details    []T    `json:"details,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ListOption defines options to list resources.
@@ -68,6 +69,21 @@ func (opt ListOption) Validate(eo *filter.ExprOption, po *core.PageOption) error
 	return nil
 }
 
+// SQLWhereExpr generate the sql where expression of the filter, and locate the position of the
+// cursor page if the page uses cursor pagination.
+func (opt ListOption) SQLWhereExpr(whereOpt *filter.SQLWhereOption) (string, map[string]interface{}, error) {
+	if opt.Filter == nil {
+		return "", nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereOpt, err := CursorSQLWhereOption(opt.Page, whereOpt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return opt.Filter.SQLWhereExpr(whereOpt)
+}
+
 // CountOption defines options to count resources.
 type CountOption struct {
 	Filter  *filter.Expression
@@ -132,5 +148,10 @@ var TenantDefaultIgnoredFields = []string{"id", "creator", "created_at"}
 // DefaultPageSQLOption define default page sql option.
 var DefaultPageSQLOption = &PageSQLOption{Sort: SortOption{Sort: "id", IfNotPresent: true}}
 
+// CursorPageSQLOption is the page sql option of the dao which supports cursor pagination.
+// cursor pagination requires ids increasing in the order of creation, the resource using it must be added to
+// orderedIDResources of id generator, so that it is not allocated by segment mode.
+var CursorPageSQLOption = &PageSQLOption{Sort: SortOption{Sort: "id", IfNotPresent: true}, Cursor: true}
+
 // DefaultRelJoinWithoutField 因为rel表join时，id、creator、created_at 在两张表中都有，该字段需要手动设置。
 var DefaultRelJoinWithoutField = []string{"id", "creator", "created_at"}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package migration versioned schema migration, including the embedded sql files and go coded data migrations.
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/kit"
+	migsql "hcm/scripts/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Kind is the kind of migration.
+type Kind string
+
+const (
+	// SQLKind migration defined by sql file.
+	SQLKind Kind = "sql"
+	// GoKind migration defined by go code, usually used to migrate data.
+	GoKind Kind = "go"
+)
+
+// GoMigrateFunc go coded data migration, executed in a transaction with the migration record.
+type GoMigrateFunc func(kt *kit.Kit, tx *sqlx.Tx) error
+
+// Migration defines one versioned migration.
+type Migration struct {
+	// Version is the unique version of migration, migrations are applied in ascending order of version.
+	// sql migration's version is the file name without suffix, e.g. 0033_20250612_tenant.
+	Version string
+	Kind    Kind
+	// Checksum is the sha256 of sql file content, used to find out the applied sql file is modified.
+	Checksum string
+	// SQL is the content of sql file.
+	SQL string
+	// Migrate is the go coded data migration.
+	Migrate GoMigrateFunc
+}
+
+// sqlVerRegexp sql version defined in the file name, e.g. 0033 of 0033_20250612_tenant.sql
+var sqlVerRegexp = regexp.MustCompile(`^(\d{4})_(.+)$`)
+
+// DevSQLVer is the sql version of unreleased migrations, it's replaced by the next sql version when released,
+// e.g. 9999_20251019_1000_xxx is renamed to 0034_20251019_1000_xxx, the part after sql version is kept unchanged.
+const DevSQLVer = 9999
+
+// SQLVer returns the sql version prefix of migration version, used to baseline the db migrated by migrate.sh.
+func (m *Migration) SQLVer() string {
+	match := sqlVerRegexp.FindStringSubmatch(m.Version)
+	if len(match) != 3 {
+		return ""
+	}
+
+	return match[1]
+}
+
+// parseVersion parses version to numeric sql version and the part after it, which identifies the migration
+// whether it's released or not.
+func parseVersion(version string) (int, string, error) {
+	match := sqlVerRegexp.FindStringSubmatch(version)
+	if len(match) != 3 {
+		return 0, "", fmt.Errorf("migration version %s is invalid, should be {SQLVER}_{date}_{desc}", version)
+	}
+
+	sqlVer, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, "", fmt.Errorf("sql version of migration %s is invalid, err: %v", version, err)
+	}
+
+	return sqlVer, match[2], nil
+}
+
+// lessVersion compares versions by numeric sql version first, so unreleased migrations of DevSQLVer are always
+// ordered after the released ones, then by the date and description. invalid versions are ordered at last.
+func lessVersion(a, b string) bool {
+	aVer, aName, aErr := parseVersion(a)
+	bVer, bName, bErr := parseVersion(b)
+	if aErr != nil || bErr != nil {
+		if aErr == nil || bErr == nil {
+			return aErr == nil
+		}
+		return a < b
+	}
+
+	if aVer != bVer {
+		return aVer < bVer
+	}
+
+	return aName < bName
+}
+
+// devVersion returns the version before the released migration is renamed, returns empty if it's unreleased.
+func devVersion(version string) string {
+	sqlVer, name, err := parseVersion(version)
+	if err != nil || sqlVer == DevSQLVer {
+		return ""
+	}
+
+	return fmt.Sprintf("%04d_%s", DevSQLVer, name)
+}
+
+var goMigrations = make(map[string]*Migration)
+
+// Register go coded data migration, should be called in init function.
+// version must be ordered after the sql migration it depends on, e.g. 0034_20251019_fill_xxx.
+func Register(version string, migrate GoMigrateFunc) {
+	if len(version) == 0 || migrate == nil {
+		panic("go migration version and migrate func is required")
+	}
+
+	if _, _, err := parseVersion(version); err != nil {
+		panic(err.Error())
+	}
+
+	if _, exist := goMigrations[version]; exist {
+		panic(fmt.Sprintf("go migration %s is registered repeatedly", version))
+	}
+
+	goMigrations[version] = &Migration{
+		Version: version,
+		Kind:    GoKind,
+		Migrate: migrate,
+	}
+}
+
+// Load returns all migrations of embedded sql files and registered go migrations, ordered by version.
+func Load() ([]*Migration, error) {
+	return load(migsql.Files, goMigrations)
+}
+
+func load(fsys fs.FS, goMigs map[string]*Migration) ([]*Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list sql files failed, err: %v", err)
+	}
+
+	migrations := make([]*Migration, 0, len(names)+len(goMigs))
+	versions := make(map[string]struct{}, len(names)+len(goMigs))
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read sql file %s failed, err: %v", name, err)
+		}
+
+		version := strings.TrimSuffix(path.Base(name), ".sql")
+		if _, _, err = parseVersion(version); err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, &Migration{
+			Version:  version,
+			Kind:     SQLKind,
+			Checksum: hex.EncodeToString(sum[:]),
+			SQL:      string(content),
+		})
+		versions[version] = struct{}{}
+	}
+
+	for version, one := range goMigs {
+		if _, exist := versions[version]; exist {
+			return nil, fmt.Errorf("go migration %s conflicts with sql file", version)
+		}
+		migrations = append(migrations, one)
+		versions[version] = struct{}{}
+	}
+
+	for _, one := range migrations {
+		if dev := devVersion(one.Version); len(dev) != 0 {
+			if _, exist := versions[dev]; exist {
+				return nil, fmt.Errorf("released migration %s conflicts with unreleased %s", one.Version, dev)
+			}
+		}
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return lessVersion(migrations[i].Version, migrations[j].Version)
+	})
+
+	return migrations, nil
+}
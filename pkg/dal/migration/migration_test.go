@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func testMigrations(t *testing.T) []*Migration {
+	fsys := fstest.MapFS{
+		"0001_20230227_2045_init_db.sql": {Data: []byte("create table a (id int);")},
+		"0002_20230329_1510.sql":         {Data: []byte("create table b (id int);")},
+		"9999_20261019_0837_aliyun.sql":  {Data: []byte("create table c (id int);")},
+		"readme.md":                      {Data: []byte("not a migration")},
+	}
+	goMigs := map[string]*Migration{
+		"0002_20230401_fill_b": {
+			Version: "0002_20230401_fill_b",
+			Kind:    GoKind,
+			Migrate: func(kt *kit.Kit, tx *sqlx.Tx) error { return nil },
+		},
+	}
+
+	migrations, err := load(fsys, goMigs)
+	if err != nil {
+		t.Fatalf("load migrations failed, err: %v", err)
+	}
+
+	return migrations
+}
+
+func TestLoad(t *testing.T) {
+	migrations := testMigrations(t)
+
+	expected := []string{"0001_20230227_2045_init_db", "0002_20230329_1510", "0002_20230401_fill_b",
+		"9999_20261019_0837_aliyun"}
+	if len(migrations) != len(expected) {
+		t.Fatalf("expect %d migrations, got %d", len(expected), len(migrations))
+	}
+
+	for idx, one := range migrations {
+		if one.Version != expected[idx] {
+			t.Errorf("expect migration %d is %s, got %s", idx, expected[idx], one.Version)
+		}
+	}
+
+	if migrations[0].Kind != SQLKind || len(migrations[0].Checksum) != 64 {
+		t.Errorf("sql migration kind or checksum is invalid, kind: %s, checksum: %s", migrations[0].Kind,
+			migrations[0].Checksum)
+	}
+
+	if migrations[2].Kind != GoKind || migrations[2].SQLVer() != "0002" {
+		t.Errorf("go migration kind or sql version is invalid, kind: %s, sql ver: %s", migrations[2].Kind,
+			migrations[2].SQLVer())
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("load embedded migrations failed, err: %v", err)
+	}
+
+	if len(migrations) == 0 || migrations[0].Version != "0001_20230227_2045_init_db" {
+		t.Fatalf("embedded migrations should start with the init sql file")
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := testMigrations(t)
+
+	records := map[string]*Record{
+		"0001_20230227_2045_init_db": {Version: "0001_20230227_2045_init_db", Checksum: migrations[0].Checksum},
+	}
+
+	pending, err := plan(migrations, records, "")
+	if err != nil {
+		t.Fatalf("plan failed, err: %v", err)
+	}
+	if len(pending) != 3 || pending[0].Version != "0002_20230329_1510" {
+		t.Errorf("expect 3 pending migrations start with 0002, got %d", len(pending))
+	}
+
+	pending, err = plan(migrations, records, "0002_20230401_fill_b")
+	if err != nil {
+		t.Fatalf("plan to target failed, err: %v", err)
+	}
+	if len(pending) != 2 || pending[1].Version != "0002_20230401_fill_b" {
+		t.Errorf("expect 2 pending migrations until target, got %d", len(pending))
+	}
+
+	if _, err = plan(migrations, records, "0003_not_exist"); err == nil {
+		t.Errorf("plan to not exist target should fail")
+	}
+
+	records["0001_20230227_2045_init_db"].Checksum = "modified"
+	if _, err = plan(migrations, records, ""); err == nil {
+		t.Errorf("plan with modified sql file should fail")
+	}
+
+	records["0001_20230227_2045_init_db"].Checksum = migrations[0].Checksum
+	records["0002_20230329_1510"] = &Record{Version: "0002_20230329_1510", Checksum: migrations[1].Checksum,
+		Dirty: true}
+	if _, err = plan(migrations, records, ""); err == nil {
+		t.Errorf("plan with dirty migration should fail")
+	}
+}
+
+func TestStatus(t *testing.T) {
+	migrations := testMigrations(t)
+
+	records := map[string]*Record{
+		"0001_20230227_2045_init_db": {Version: "0001_20230227_2045_init_db", Kind: SQLKind, Checksum: "modified"},
+		"0002_20230401_fill_b":       {Version: "0002_20230401_fill_b", Kind: GoKind},
+		"9999_20261019_0837_aliyun": {Version: "9999_20261019_0837_aliyun", Kind: SQLKind, Checksum: "modified",
+			Dirty: true},
+		"0100_newer_release": {Version: "0100_newer_release", Kind: SQLKind},
+	}
+
+	expected := []State{Modified, Pending, Applied, Dirty, Unknown}
+	result := status(migrations, records)
+	if len(result) != len(expected) {
+		t.Fatalf("expect %d status, got %d", len(expected), len(result))
+	}
+
+	for idx, one := range result {
+		if one.State != expected[idx] {
+			t.Errorf("expect %s state is %s, got %s", one.Version, expected[idx], one.State)
+		}
+	}
+}
+
+func TestLessVersion(t *testing.T) {
+	ordered := []string{"0002_20230329_1510", "0010_20230821_1949", "0033_20250612_tenant",
+		"9999_20250409_1000_add_tenant_id", "9999_20261019_0837_aliyun", "9999_async_table_tenant", "invalid"}
+	for i := 0; i < len(ordered)-1; i++ {
+		if !lessVersion(ordered[i], ordered[i+1]) || lessVersion(ordered[i+1], ordered[i]) {
+			t.Errorf("expect %s is ordered before %s", ordered[i], ordered[i+1])
+		}
+	}
+}
+
+func TestLoadInvalidVersion(t *testing.T) {
+	fsys := fstest.MapFS{"aliyun.sql": {Data: []byte("create table c (id int);")}}
+	if _, err := load(fsys, nil); err == nil {
+		t.Errorf("load sql file without sql version should fail")
+	}
+
+	fsys = fstest.MapFS{
+		"0034_20261019_0837_aliyun.sql": {Data: []byte("create table c (id int);")},
+		"9999_20261019_0837_aliyun.sql": {Data: []byte("create table c (id int);")},
+	}
+	if _, err := load(fsys, nil); err == nil {
+		t.Errorf("load released sql file with the unreleased one should fail")
+	}
+}
+
+func TestPlanReleased(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_20230227_2045_init_db.sql": {Data: []byte("create table a (id int);")},
+		"0034_20261019_0837_aliyun.sql":  {Data: []byte("create table c (id int);")},
+		"9999_20261019_1340_rbac.sql":    {Data: []byte("create table d (id int);")},
+	}
+	migrations, err := load(fsys, nil)
+	if err != nil {
+		t.Fatalf("load migrations failed, err: %v", err)
+	}
+
+	// aliyun is applied before released, its sql file is changed by the release.
+	records := map[string]*Record{
+		"0001_20230227_2045_init_db": {Version: "0001_20230227_2045_init_db", Kind: SQLKind,
+			Checksum: migrations[0].Checksum},
+		"9999_20261019_0837_aliyun": {Version: "9999_20261019_0837_aliyun", Kind: SQLKind, Checksum: "dev"},
+	}
+
+	pending, err := plan(migrations, records, "")
+	if err != nil {
+		t.Fatalf("plan failed, err: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Version != "9999_20261019_1340_rbac" {
+		t.Errorf("expect only rbac is pending, got %d", len(pending))
+	}
+
+	for _, one := range status(migrations, records) {
+		if one.State != Applied && one.Version != "9999_20261019_1340_rbac" {
+			t.Errorf("expect %s is applied, got %s", one.Version, one.State)
+		}
+	}
+}
+
+func TestBaselineMigrations(t *testing.T) {
+	migrations := testMigrations(t)
+
+	if marked := baselineMigrations(migrations, 1); len(marked) != 1 {
+		t.Errorf("expect 1 migration is marked by sql version 1, got %d", len(marked))
+	}
+
+	if marked := baselineMigrations(migrations, 9990); len(marked) != 2 {
+		t.Errorf("expect unreleased migration is not marked by sql version 9990, got %d", len(marked))
+	}
+
+	if marked := baselineMigrations(migrations, DevSQLVer); len(marked) != 3 {
+		t.Errorf("expect all sql migrations are marked by dev sql version, got %d", len(marked))
+	}
+}
@@ -0,0 +1,595 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// TableName is the table to record applied migrations.
+	TableName = "schema_migrations"
+
+	// lockName mysql named lock, prevents migrating the same db concurrently.
+	lockName = "hcm_schema_migration"
+	// lockTimeoutSec is the max seconds to wait for the migration lock.
+	lockTimeoutSec = 60
+)
+
+const createTableSQL = "create table if not exists `" + TableName + "` (" +
+	"`version` varchar(255) NOT NULL COMMENT '迁移版本'," +
+	"`kind` varchar(16) NOT NULL COMMENT '迁移类型(sql:sql文件 go:go代码数据迁移)'," +
+	"`checksum` varchar(64) NOT NULL DEFAULT '' COMMENT 'sql文件的sha256'," +
+	"`baseline` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否为基线标记，基线标记的迁移未实际执行'," +
+	"`dirty` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否未执行完成，sql文件执行前记录为1，执行成功后置为0'," +
+	"`exec_time_ms` bigint NOT NULL DEFAULT 0 COMMENT '执行耗时(毫秒)'," +
+	"`applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '执行时间'," +
+	"PRIMARY KEY (`version`)" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT='数据库迁移记录表'"
+
+// addDirtyColumnSQL adds the dirty column to the record table created by the release without it.
+const addDirtyColumnSQL = "alter table `" + TableName + "` add column `dirty` tinyint(1) NOT NULL DEFAULT 0 " +
+	"COMMENT '是否未执行完成，sql文件执行前记录为1，执行成功后置为0' after `baseline`"
+
+// Record is the applied migration record.
+type Record struct {
+	Version  string `db:"version"`
+	Kind     Kind   `db:"kind"`
+	Checksum string `db:"checksum"`
+	Baseline bool   `db:"baseline"`
+	// Dirty sql file is started but not finished, ddl statements executed before the failure are committed.
+	Dirty      bool      `db:"dirty"`
+	ExecTimeMS int64     `db:"exec_time_ms"`
+	AppliedAt  time.Time `db:"applied_at"`
+}
+
+// State is the state of migration.
+type State string
+
+const (
+	// Applied migration is applied.
+	Applied State = "applied"
+	// Pending migration is not applied yet.
+	Pending State = "pending"
+	// Modified sql file is modified after it is applied.
+	Modified State = "modified"
+	// Unknown migration is applied, but not found in this release, usually the db is migrated by a newer release.
+	Unknown State = "unknown"
+	// Dirty migration failed in the middle of applying, the db must be fixed manually and repaired by repair action.
+	Dirty State = "dirty"
+)
+
+// Status is the migration status.
+type Status struct {
+	Version string
+	Kind    Kind
+	State   State
+	// Record is the applied record, nil if migration is pending.
+	Record *Record
+}
+
+// Connect to mysql for migration. multi statements is enabled to execute the whole sql file,
+// and read/write timeout is not set, because altering a large table may take a long time.
+func Connect(opt cc.ResourceDB) (*sqlx.DB, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = opt.User
+	cfg.Passwd = opt.Password
+	cfg.Net = "tcp"
+	cfg.Addr = strings.Join(opt.Endpoints, ",")
+	cfg.DBName = opt.Database
+	cfg.ParseTime = true
+	cfg.MultiStatements = true
+	cfg.Timeout = time.Duration(opt.DialTimeoutSec) * time.Second
+	cfg.Params = map[string]string{"charset": "utf8mb4"}
+
+	db, err := sqlx.Connect("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("connect to mysql failed, err: %v", err)
+	}
+
+	return db, nil
+}
+
+// Migrator migrates db schema to the version of migrations.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []*Migration
+}
+
+// NewMigrator new migrator, migrations must be ordered by version.
+func NewMigrator(db *sqlx.DB, migrations []*Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Status returns status of all migrations and the applied records unknown to this release.
+func (m *Migrator) Status(kt *kit.Kit) ([]Status, error) {
+	records, err := m.listRecords(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	return status(m.migrations, records), nil
+}
+
+// Check checks whether all migrations are applied and the applied sql files are not modified.
+func (m *Migrator) Check(kt *kit.Kit) error {
+	records, err := m.listRecords(kt)
+	if err != nil {
+		return err
+	}
+
+	pending, err := plan(m.migrations, records, "")
+	if err != nil {
+		return err
+	}
+
+	if len(pending) != 0 {
+		versions := make([]string, 0, len(pending))
+		for _, one := range pending {
+			versions = append(versions, one.Version)
+		}
+		return fmt.Errorf("db schema is not migrated, %d pending migrations: %s", len(pending),
+			strings.Join(versions, ", "))
+	}
+
+	return nil
+}
+
+// Up applies pending migrations in order, until the target version(inclusive). target is empty means all.
+// if dryRun is true, returns the pending migrations without applying them.
+func (m *Migrator) Up(kt *kit.Kit, target string, dryRun bool) ([]*Migration, error) {
+	if !dryRun {
+		release, err := m.prepare(kt)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	records, err := m.listRecords(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		if err = m.promote(kt, records); err != nil {
+			return nil, err
+		}
+	}
+
+	pending, err := plan(m.migrations, records, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return pending, nil
+	}
+
+	for idx, one := range pending {
+		if err = m.apply(kt, one); err != nil {
+			return pending[:idx], fmt.Errorf("apply migration %s failed, err: %v", one.Version, err)
+		}
+	}
+
+	return pending, nil
+}
+
+// Baseline marks migrations as applied without executing them, used for the db migrated by migrate.sh.
+// migrations whose sql version is less than or equal to sqlVer are marked. sqlVer is empty means using
+// the sql_ver of hcm_version view which is maintained by the sql files.
+func (m *Migrator) Baseline(kt *kit.Kit, sqlVer string, dryRun bool) ([]*Migration, error) {
+	if !dryRun {
+		release, err := m.prepare(kt)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	records, err := m.listRecords(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) != 0 {
+		return nil, fmt.Errorf("%s already has %d records, can not baseline", TableName, len(records))
+	}
+
+	if len(sqlVer) == 0 {
+		if err = m.db.GetContext(kt.Ctx, &sqlVer, "select `sql_ver` from `hcm_version`"); err != nil {
+			return nil, fmt.Errorf("get sql version from hcm_version failed, err: %v", err)
+		}
+	}
+
+	baseVer, err := strconv.Atoi(sqlVer)
+	if err != nil {
+		return nil, fmt.Errorf("sql version %s is invalid, err: %v", sqlVer, err)
+	}
+
+	marked := baselineMigrations(m.migrations, baseVer)
+
+	if dryRun {
+		return marked, nil
+	}
+
+	for idx, one := range marked {
+		if err = m.insertRecord(kt, m.db, one, true, false, 0); err != nil {
+			return marked[:idx], err
+		}
+	}
+
+	return marked, nil
+}
+
+// Repair repairs the dirty migration of version after the db is fixed manually. if retry is true, the dirty record
+// is removed so that up action executes the migration again, the operator must revert the statements executed
+// before the failure, or make sure that they can be executed again. otherwise the migration is marked as applied,
+// the operator must execute the remaining statements manually.
+func (m *Migrator) Repair(kt *kit.Kit, version string, retry bool) error {
+	release, err := m.prepare(kt)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	records, err := m.listRecords(kt)
+	if err != nil {
+		return err
+	}
+
+	record, exists := records[version]
+	if !exists || !record.Dirty {
+		return fmt.Errorf("migration %s is not dirty, only dirty migration can be repaired", version)
+	}
+
+	if retry {
+		_, err = m.db.ExecContext(kt.Ctx, "delete from `"+TableName+"` where `version` = ? and `dirty` = 1",
+			version)
+	} else {
+		_, err = m.db.ExecContext(kt.Ctx, "update `"+TableName+"` set `dirty` = 0 where `version` = ?", version)
+	}
+	if err != nil {
+		return fmt.Errorf("repair migration record %s failed, err: %v", version, err)
+	}
+
+	logs.Infof("repair dirty migration %s success, retry: %t, rid: %s", version, retry, kt.Rid)
+	return nil
+}
+
+// apply executes migration and records it.
+func (m *Migrator) apply(kt *kit.Kit, one *Migration) error {
+	start := time.Now()
+	logs.Infof("start to apply migration %s, rid: %s", one.Version, kt.Rid)
+
+	switch one.Kind {
+	case SQLKind:
+		// sql file manages transaction by itself, and ddl statements are committed implicitly, so the migration
+		// is recorded as dirty before executing, a failure in the middle of the file leaves the dirty record,
+		// which blocks later migrations until it is repaired.
+		if err := m.insertRecord(kt, m.db, one, false, true, 0); err != nil {
+			return err
+		}
+
+		if _, err := m.db.ExecContext(kt.Ctx, one.SQL); err != nil {
+			logs.Errorf("apply migration %s failed, it is marked as dirty, err: %v, rid: %s", one.Version, err,
+				kt.Rid)
+			return fmt.Errorf("%v, migration is marked as dirty, please fix the db manually and run "+
+				"'migrate repair --target %s'", err, one.Version)
+		}
+
+		_, err := m.db.ExecContext(kt.Ctx, "update `"+TableName+"` set `dirty` = 0, `exec_time_ms` = ? "+
+			"where `version` = ?", time.Since(start).Milliseconds(), one.Version)
+		if err != nil {
+			return fmt.Errorf("clear dirty flag of migration record %s failed, err: %v", one.Version, err)
+		}
+
+	case GoKind:
+		tx, err := m.db.BeginTxx(kt.Ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin transaction failed, err: %v", err)
+		}
+
+		if err = one.Migrate(kt, tx); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logs.Errorf("rollback migration %s failed, err: %v, rid: %s", one.Version, rbErr, kt.Rid)
+			}
+			return err
+		}
+
+		if err = m.insertRecord(kt, tx, one, false, false, time.Since(start).Milliseconds()); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logs.Errorf("rollback migration %s failed, err: %v, rid: %s", one.Version, rbErr, kt.Rid)
+			}
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return fmt.Errorf("commit transaction failed, err: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported migration kind: %s", one.Kind)
+	}
+
+	logs.Infof("apply migration %s success, cost: %s, rid: %s", one.Version, time.Since(start), kt.Rid)
+	return nil
+}
+
+// prepare creates the record table and gets the migration lock, returns the function to release the lock.
+func (m *Migrator) prepare(kt *kit.Kit) (func(), error) {
+	if _, err := m.db.ExecContext(kt.Ctx, createTableSQL); err != nil {
+		return nil, fmt.Errorf("create %s table failed, err: %v", TableName, err)
+	}
+
+	release, err := m.lock(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int
+	err = m.db.GetContext(kt.Ctx, &count, "select count(*) from information_schema.columns where "+
+		"table_schema = database() and table_name = ? and column_name = 'dirty'", TableName)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("check dirty column of %s failed, err: %v", TableName, err)
+	}
+
+	if count == 0 {
+		if _, err = m.db.ExecContext(kt.Ctx, addDirtyColumnSQL); err != nil {
+			release()
+			return nil, fmt.Errorf("add dirty column to %s failed, err: %v", TableName, err)
+		}
+	}
+
+	return release, nil
+}
+
+// lock gets the mysql named lock on a dedicated connection, returns the function to release it.
+func (m *Migrator) lock(kt *kit.Kit) (func(), error) {
+	conn, err := m.db.Connx(kt.Ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get db connection failed, err: %v", err)
+	}
+
+	var got sql.NullInt64
+	if err = conn.GetContext(kt.Ctx, &got, "select get_lock(?, ?)", lockName, lockTimeoutSec); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("get migration lock failed, err: %v", err)
+	}
+
+	if !got.Valid || got.Int64 != 1 {
+		conn.Close()
+		return nil, errors.New("get migration lock timeout, another migration may be running")
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(kt.Ctx, "select release_lock(?)", lockName); err != nil {
+			logs.Errorf("release migration lock failed, err: %v, rid: %s", err, kt.Rid)
+		}
+		conn.Close()
+	}, nil
+}
+
+func (m *Migrator) listRecords(kt *kit.Kit) (map[string]*Record, error) {
+	list := make([]*Record, 0)
+	err := m.db.SelectContext(kt.Ctx, &list, "select `version`, `kind`, `checksum`, `baseline`, `dirty`, "+
+		"`exec_time_ms`, `applied_at` from `"+TableName+"`")
+	var mysqlErr *mysql.MySQLError
+	// ER_BAD_FIELD_ERROR, the record table is created by the release without dirty column, it's added by the next
+	// up action, and none of the records is dirty.
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1054 {
+		err = m.db.SelectContext(kt.Ctx, &list, "select `version`, `kind`, `checksum`, `baseline`, "+
+			"`exec_time_ms`, `applied_at` from `"+TableName+"`")
+	}
+	if err != nil {
+		// ER_NO_SUCH_TABLE, db is never migrated by migrator.
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1146 {
+			return make(map[string]*Record), nil
+		}
+		return nil, fmt.Errorf("list %s failed, err: %v", TableName, err)
+	}
+
+	records := make(map[string]*Record, len(list))
+	for _, one := range list {
+		records[one.Version] = one
+	}
+
+	return records, nil
+}
+
+func (m *Migrator) insertRecord(kt *kit.Kit, exec sqlx.ExecerContext, one *Migration, baseline, dirty bool,
+	execTimeMS int64) error {
+
+	_, err := exec.ExecContext(kt.Ctx, "insert into `"+TableName+"` (`version`, `kind`, `checksum`, "+
+		"`baseline`, `dirty`, `exec_time_ms`) values (?, ?, ?, ?, ?, ?)", one.Version, one.Kind, one.Checksum,
+		baseline, dirty, execTimeMS)
+	if err != nil {
+		return fmt.Errorf("insert migration record %s failed, err: %v", one.Version, err)
+	}
+
+	return nil
+}
+
+// promote renames the records of migrations applied before they are released to the released version, so that
+// the released migration is not applied again, and its checksum is updated to the released sql file.
+func (m *Migrator) promote(kt *kit.Kit, records map[string]*Record) error {
+	for _, one := range m.migrations {
+		if _, exist := records[one.Version]; exist {
+			continue
+		}
+
+		dev := devVersion(one.Version)
+		record, exist := records[dev]
+		if len(dev) == 0 || !exist {
+			continue
+		}
+
+		_, err := m.db.ExecContext(kt.Ctx, "update `"+TableName+"` set `version` = ?, `checksum` = ? "+
+			"where `version` = ?", one.Version, one.Checksum, dev)
+		if err != nil {
+			return fmt.Errorf("promote migration record %s to %s failed, err: %v", dev, one.Version, err)
+		}
+
+		record.Version = one.Version
+		record.Checksum = one.Checksum
+		delete(records, dev)
+		records[one.Version] = record
+		logs.Infof("promote migration record %s to released %s, rid: %s", dev, one.Version, kt.Rid)
+	}
+
+	return nil
+}
+
+// baselineMigrations returns sql migrations whose numeric sql version is less than or equal to sqlVer.
+// unreleased migrations are only marked when sqlVer is DevSQLVer, because the released sql versions can
+// not tell which of them are applied.
+func baselineMigrations(migrations []*Migration, sqlVer int) []*Migration {
+	marked := make([]*Migration, 0)
+	for _, one := range migrations {
+		if one.Kind != SQLKind {
+			continue
+		}
+
+		ver, _, err := parseVersion(one.Version)
+		if err != nil || ver > sqlVer {
+			continue
+		}
+		marked = append(marked, one)
+	}
+
+	return marked
+}
+
+// findRecord returns the applied record of migration, released is true if the migration is applied before it's
+// released, its sql file is changed by the release, e.g. the sql_ver of hcm_version, so checksum is not checked.
+func findRecord(records map[string]*Record, one *Migration) (*Record, bool) {
+	if record, exist := records[one.Version]; exist {
+		return record, false
+	}
+
+	dev := devVersion(one.Version)
+	if len(dev) == 0 {
+		return nil, false
+	}
+
+	record, exist := records[dev]
+	if !exist {
+		return nil, false
+	}
+
+	return record, true
+}
+
+// plan returns the pending migrations until target version(inclusive), and checks that there is no dirty
+// migration and applied sql files are not modified.
+func plan(migrations []*Migration, records map[string]*Record, target string) ([]*Migration, error) {
+	for version, record := range records {
+		if record.Dirty {
+			return nil, fmt.Errorf("migration %s is dirty, it failed in the middle of applying, please fix the db "+
+				"manually and run 'migrate repair --target %s' first", version, version)
+		}
+	}
+
+	if len(target) != 0 {
+		found := false
+		for _, one := range migrations {
+			if one.Version == target {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("target migration %s not found", target)
+		}
+	}
+
+	pending := make([]*Migration, 0)
+	for _, one := range migrations {
+		record, released := findRecord(records, one)
+		if record != nil {
+			if one.Kind == SQLKind && !released && record.Checksum != one.Checksum {
+				return nil, fmt.Errorf("sql file of applied migration %s is modified, checksum: %s, applied: %s",
+					one.Version, one.Checksum, record.Checksum)
+			}
+			continue
+		}
+
+		if len(target) != 0 && lessVersion(target, one.Version) {
+			break
+		}
+
+		pending = append(pending, one)
+	}
+
+	return pending, nil
+}
+
+func status(migrations []*Migration, records map[string]*Record) []Status {
+	result := make([]Status, 0, len(migrations))
+	known := make(map[string]struct{}, len(migrations))
+	for _, one := range migrations {
+		record, released := findRecord(records, one)
+		if record == nil {
+			result = append(result, Status{Version: one.Version, Kind: one.Kind, State: Pending})
+			continue
+		}
+		known[record.Version] = struct{}{}
+
+		state := Applied
+		switch {
+		case record.Dirty:
+			state = Dirty
+		case one.Kind == SQLKind && !released && record.Checksum != one.Checksum:
+			state = Modified
+		}
+		result = append(result, Status{Version: one.Version, Kind: one.Kind, State: state, Record: record})
+	}
+
+	unknown := make([]Status, 0)
+	for version, record := range records {
+		if _, exist := known[version]; exist {
+			continue
+		}
+		unknown = append(unknown, Status{Version: version, Kind: record.Kind, State: Unknown, Record: record})
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return lessVersion(unknown[i].Version, unknown[j].Version)
+	})
+
+	return append(result, unknown...)
+}
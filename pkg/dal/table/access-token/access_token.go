@@ -0,0 +1,268 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accesstoken 服务账号及访问令牌相关表
+package accesstoken
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	coretoken "hcm/pkg/api/core/access-token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ServiceAccountColumns defines service_account's columns.
+var ServiceAccountColumns = utils.MergeColumns(nil, ServiceAccountColumnDescriptor)
+
+// ServiceAccountColumnDescriptor is service_account's column descriptors.
+var ServiceAccountColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ServiceAccountTable service_account表，服务账号名称作为服务账号令牌调用时的用户名
+type ServiceAccountTable struct {
+	ID        string     `db:"id" validate:"lte=64" json:"id"`
+	Name      string     `db:"name" validate:"lte=64" json:"name"`
+	Memo      *string    `db:"memo" json:"memo"`
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return service_account table name.
+func (t ServiceAccountTable) TableName() table.Name {
+	return table.ServiceAccountTable
+}
+
+// InsertValidate service_account table when insert.
+func (t ServiceAccountTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate service_account table when update.
+func (t ServiceAccountTable) UpdateValidate() error {
+	if len(t.ID) != 0 {
+		return errors.New("id can not be updated")
+	}
+
+	if len(t.Name) != 0 {
+		return errors.New("name can not be updated")
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// AccessTokenColumns defines access_token's columns.
+var AccessTokenColumns = utils.MergeColumns(nil, AccessTokenColumnDescriptor)
+
+// AccessTokenColumnDescriptor is access_token's column descriptors.
+var AccessTokenColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "owner_type", NamedC: "owner_type", Type: enumor.String},
+	{Column: "owner", NamedC: "owner", Type: enumor.String},
+	{Column: "token_hash", NamedC: "token_hash", Type: enumor.String},
+	{Column: "token_prefix", NamedC: "token_prefix", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.Json},
+	{Column: "expires_at", NamedC: "expires_at", Type: enumor.Time},
+	{Column: "revoked", NamedC: "revoked", Type: enumor.Boolean},
+	{Column: "last_used_at", NamedC: "last_used_at", Type: enumor.Time},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccessTokenTable access_token表，只保存令牌的哈希，令牌原文只在创建时返回
+type AccessTokenTable struct {
+	ID        string              `db:"id" validate:"lte=64" json:"id"`
+	Name      string              `db:"name" validate:"lte=255" json:"name"`
+	OwnerType coretoken.OwnerType `db:"owner_type" validate:"lte=32" json:"owner_type"`
+	// Owner 个人访问令牌为用户名，服务账号令牌为服务账号名称
+	Owner       string `db:"owner" validate:"lte=64" json:"owner"`
+	TokenHash   string `db:"token_hash" validate:"lte=64" json:"token_hash"`
+	TokenPrefix string `db:"token_prefix" validate:"lte=16" json:"token_prefix"`
+	Scope       *Scope `db:"scope" json:"scope"`
+	// ExpiresAt 过期时间，令牌必须设置有效期
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	// Revoked 是否已撤销，撤销后不可恢复
+	Revoked *bool `db:"revoked" json:"revoked"`
+	// LastUsedAt 最近使用时间，未使用过为NULL，由api-server按一定间隔上报更新
+	LastUsedAt *types.Time `db:"last_used_at" json:"last_used_at"`
+	Memo       *string     `db:"memo" json:"memo"`
+	TenantID   string      `db:"tenant_id" json:"tenant_id"`
+	Creator    string      `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser    string      `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt  types.Time  `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt  types.Time  `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return access_token table name.
+func (t AccessTokenTable) TableName() table.Name {
+	return table.AccessTokenTable
+}
+
+// InsertValidate access_token table when insert.
+func (t AccessTokenTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.OwnerType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Owner) == 0 {
+		return errors.New("owner is required")
+	}
+
+	if len(t.TokenHash) == 0 {
+		return errors.New("token_hash is required")
+	}
+
+	if len(t.TokenPrefix) == 0 {
+		return errors.New("token_prefix is required")
+	}
+
+	if t.Scope == nil {
+		return errors.New("scope is required")
+	}
+
+	if err := coretoken.Scope(*t.Scope).Validate(); err != nil {
+		return err
+	}
+
+	if t.ExpiresAt.IsZero() {
+		return errors.New("expires_at is required")
+	}
+
+	if t.Revoked == nil || *t.Revoked {
+		return errors.New("revoked should be false when create")
+	}
+
+	if t.LastUsedAt != nil {
+		return errors.New("last_used_at can not be set when create")
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate access_token table when update, only revoked and memo can be updated, last_used_at is
+// updated separately.
+func (t AccessTokenTable) UpdateValidate() error {
+	if len(t.ID) != 0 || len(t.Name) != 0 || len(t.OwnerType) != 0 || len(t.Owner) != 0 ||
+		len(t.TokenHash) != 0 || len(t.TokenPrefix) != 0 || t.Scope != nil || !t.ExpiresAt.IsZero() ||
+		t.LastUsedAt != nil {
+		return errors.New("only revoked and memo can be updated")
+	}
+
+	if t.Revoked != nil && !*t.Revoked {
+		return errors.New("revoked token can not be restored")
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// Scope is the json of access token scope.
+type Scope coretoken.Scope
+
+// Scan is used to decode raw message which is read from db into Scope.
+func (s *Scope) Scan(raw interface{}) error {
+	return types.Scan(raw, s)
+}
+
+// Value encode the Scope to a json raw, so that it can be stored to db with json raw.
+func (s Scope) Value() (driver.Value, error) {
+	return types.Value(s)
+}
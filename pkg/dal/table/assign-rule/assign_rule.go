@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package assignrule 资源自动分配业务规则表
+package assignrule
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	coreassign "hcm/pkg/api/core/assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AssignRuleColumns defines assign_rule's columns.
+var AssignRuleColumns = utils.MergeColumns(nil, AssignRuleColumnDescriptor)
+
+// AssignRuleColumnDescriptor is assign_rule's column descriptors.
+var AssignRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "bk_cloud_id", NamedC: "bk_cloud_id", Type: enumor.Numeric},
+	{Column: "res_types", NamedC: "res_types", Type: enumor.Json},
+	{Column: "conditions", NamedC: "conditions", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AssignRuleTable assign_rule表，资源同步后按优先级匹配规则，将未分配的资源分配到规则的业务
+type AssignRuleTable struct {
+	ID       string  `db:"id" validate:"lte=64" json:"id"`
+	Name     string  `db:"name" validate:"lte=64" json:"name"`
+	Priority *uint32 `db:"priority" json:"priority"`
+	Enabled  *bool   `db:"enabled" json:"enabled"`
+	BkBizID  int64   `db:"bk_biz_id" json:"bk_biz_id"`
+	// BkCloudID 主机分配到业务时使用的管控区域
+	BkCloudID  *int64            `db:"bk_cloud_id" json:"bk_cloud_id"`
+	ResTypes   types.StringArray `db:"res_types" json:"res_types"`
+	Conditions *Conditions       `db:"conditions" json:"conditions"`
+	Memo       *string           `db:"memo" json:"memo"`
+	TenantID   string            `db:"tenant_id" json:"tenant_id"`
+	Creator    string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser    string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt  types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt  types.Time        `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return assign_rule table name.
+func (t AssignRuleTable) TableName() table.Name {
+	return table.AssignRuleTable
+}
+
+// InsertValidate assign_rule table when insert.
+func (t AssignRuleTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if t.Priority == nil {
+		return errors.New("priority is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if err := coreassign.ValidateResTypes(ConvResTypes(t.ResTypes), t.BkCloudID); err != nil {
+		return err
+	}
+
+	if t.Conditions == nil {
+		return errors.New("conditions is required")
+	}
+
+	if err := coreassign.Conditions(*t.Conditions).Validate(); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate assign_rule table when update.
+func (t AssignRuleTable) UpdateValidate() error {
+	if len(t.ID) != 0 {
+		return errors.New("id can not be updated")
+	}
+
+	if t.BkBizID < 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if t.Conditions != nil {
+		if err := coreassign.Conditions(*t.Conditions).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// ConvResTypes convert res types stored in db to cloud resource types.
+func ConvResTypes(resTypes types.StringArray) []enumor.CloudResourceType {
+	result := make([]enumor.CloudResourceType, 0, len(resTypes))
+	for _, one := range resTypes {
+		result = append(result, enumor.CloudResourceType(one))
+	}
+	return result
+}
+
+// Conditions is the json of assign rule conditions.
+type Conditions coreassign.Conditions
+
+// Scan is used to decode raw message which is read from db into Conditions.
+func (c *Conditions) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the Conditions to a json raw, so that it can be stored to db with json raw.
+func (c Conditions) Value() (driver.Value, error) {
+	return types.Value(c)
+}
@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ArchiveColumns defines all the audit archive table's columns.
+var ArchiveColumns = utils.MergeColumns(nil, ArchiveColumnDescriptor)
+
+// ArchiveColumnDescriptor is ArchiveTable's column descriptors.
+var ArchiveColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "archive_date", NamedC: "archive_date", Type: enumor.String},
+	{Column: "path", NamedC: "path", Type: enumor.String},
+	{Column: "min_audit_id", NamedC: "min_audit_id", Type: enumor.Numeric},
+	{Column: "max_audit_id", NamedC: "max_audit_id", Type: enumor.Numeric},
+	{Column: "min_seq", NamedC: "min_seq", Type: enumor.Numeric},
+	{Column: "max_seq", NamedC: "max_seq", Type: enumor.Numeric},
+	{Column: "start_time", NamedC: "start_time", Type: enumor.String},
+	{Column: "end_time", NamedC: "end_time", Type: enumor.String},
+	{Column: "count", NamedC: "count", Type: enumor.Numeric},
+	{Column: "size", NamedC: "size", Type: enumor.Numeric},
+	{Column: "sha256", NamedC: "sha256", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ArchiveTable 审计归档清单，每个归档文件对应一条记录，记录归档文件中审计的ID及时间范围，用于检索和恢复已归档的审计
+type ArchiveTable struct {
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// ArchiveDate 归档审计的日期，格式：2006-01-02
+	ArchiveDate string `db:"archive_date" json:"archive_date" validate:"len=10"`
+	// Path 归档文件在对象存储中的路径
+	Path       string `db:"path" json:"path" validate:"lte=255"`
+	MinAuditID uint64 `db:"min_audit_id" json:"min_audit_id"`
+	MaxAuditID uint64 `db:"max_audit_id" json:"max_audit_id"`
+	// MinSeq 归档审计在哈希链中的最小序号，只包含未加入哈希链的历史审计时为0
+	MinSeq uint64 `db:"min_seq" json:"min_seq"`
+	// MaxSeq 归档审计在哈希链中的最大序号，只包含未加入哈希链的历史审计时为0
+	MaxSeq uint64 `db:"max_seq" json:"max_seq"`
+	// StartTime 归档审计的最早创建时间，格式：2006-01-02T15:04:05Z07:00
+	StartTime string `db:"start_time" json:"start_time" validate:"lte=64"`
+	// EndTime 归档审计的最晚创建时间，格式：2006-01-02T15:04:05Z07:00
+	EndTime string `db:"end_time" json:"end_time" validate:"lte=64"`
+	Count   uint64 `db:"count" json:"count"`
+	// Size 归档文件大小，单位：字节
+	Size      uint64     `db:"size" json:"size"`
+	Sha256    string     `db:"sha256" json:"sha256" validate:"lte=64"`
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" json:"creator" validate:"lte=64"`
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName is the audit archive's database table name.
+func (a ArchiveTable) TableName() table.Name {
+	return table.AuditArchiveTable
+}
+
+// InsertValidate audit archive table when insert.
+func (a ArchiveTable) InsertValidate() error {
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.Path) == 0 {
+		return errors.New("path is required")
+	}
+
+	if a.Count == 0 {
+		return errors.New("count is required")
+	}
+
+	if a.MinAuditID > a.MaxAuditID {
+		return errors.New("min_audit_id should not be greater than max_audit_id")
+	}
+
+	if a.MinSeq > a.MaxSeq {
+		return errors.New("min_seq should not be greater than max_seq")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(a)
+}
@@ -52,6 +52,9 @@ var AuditColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "rid", NamedC: "rid", Type: enumor.String},
 	{Column: "app_code", NamedC: "app_code", Type: enumor.String},
 	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "seq", NamedC: "seq", Type: enumor.Numeric},
+	{Column: "prev_hash", NamedC: "prev_hash", Type: enumor.String},
+	{Column: "hash", NamedC: "hash", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
 
@@ -71,7 +74,13 @@ type AuditTable struct {
 	Rid        string                   `db:"rid" json:"rid" validate:"lte=64"`
 	AppCode    string                   `db:"app_code" json:"app_code" validate:"lte=64"`
 	Detail     *BasicDetail             `db:"detail" json:"detail" validate:"-"`
-	CreatedAt  types.Time               `db:"created_at" json:"created_at"`
+	// Seq 租户内审计哈希链序号，从1开始递增，历史审计为0
+	Seq uint64 `db:"seq" json:"seq"`
+	// PrevHash 哈希链中上一条审计的哈希
+	PrevHash string `db:"prev_hash" json:"prev_hash"`
+	// Hash 审计内容及上一条审计哈希的哈希
+	Hash      string     `db:"hash" json:"hash"`
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
 	// TenantID 租户ID
 	TenantID string `db:"tenant_id" json:"tenant_id"`
 }
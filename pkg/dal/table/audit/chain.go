@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// chainContent 参与哈希链计算的审计内容，租户ID由哈希链本身区分，不参与计算
+type chainContent struct {
+	Seq        uint64                   `json:"seq"`
+	ResID      string                   `json:"res_id"`
+	CloudResID string                   `json:"cloud_res_id"`
+	ResName    string                   `json:"res_name"`
+	ResType    enumor.AuditResourceType `json:"res_type"`
+	Action     enumor.AuditAction       `json:"action"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	Operator   string                   `json:"operator"`
+	Source     enumor.RequestSourceType `json:"source"`
+	Rid        string                   `json:"rid"`
+	AppCode    string                   `json:"app_code"`
+	Detail     json.RawMessage          `json:"detail"`
+	// CreatedAt 使用时间戳，避免时区及格式差异影响哈希
+	CreatedAt int64 `json:"created_at"`
+}
+
+// ChainHash calculate the hash of the audit content and the previous audit hash in the chain.
+func (a AuditTable) ChainHash() (string, error) {
+	createdAt, err := time.Parse(constant.TimeStdFormat, string(a.CreatedAt))
+	if err != nil {
+		return "", fmt.Errorf("audit created_at %s is invalid, err: %v", a.CreatedAt, err)
+	}
+
+	detail, err := canonicalJSON(a.Detail)
+	if err != nil {
+		return "", fmt.Errorf("canonical audit detail failed, err: %v", err)
+	}
+
+	content, err := json.Marshal(chainContent{
+		Seq:        a.Seq,
+		ResID:      a.ResID,
+		CloudResID: a.CloudResID,
+		ResName:    a.ResName,
+		ResType:    a.ResType,
+		Action:     a.Action,
+		BkBizID:    a.BkBizID,
+		Vendor:     a.Vendor,
+		AccountID:  a.AccountID,
+		Operator:   a.Operator,
+		Source:     a.Source,
+		Rid:        a.Rid,
+		AppCode:    a.AppCode,
+		Detail:     detail,
+		CreatedAt:  createdAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(append([]byte(a.PrevHash), content...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON 将数据转换为规范的json，对象的key按字典序排列，数字统一按float64处理，
+// 与审计详情从数据库读出（BasicDetail 解析为 interface{}）后的结果一致
+func canonicalJSON(v interface{}) (json.RawMessage, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(generic)
+}
+
+// ChainHeadTable 租户的审计哈希链头，写入审计时加锁以保证哈希链的顺序
+type ChainHeadTable struct {
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Seq       uint64     `db:"seq" json:"seq"`
+	Hash      string     `db:"hash" json:"hash"`
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName is the audit chain head's database table name.
+func (h ChainHeadTable) TableName() table.Name {
+	return table.AuditChainHeadTable
+}
+
+// CheckpointColumns defines all the audit checkpoint table's columns.
+var CheckpointColumns = utils.MergeColumns(nil, CheckpointColumnDescriptor)
+
+// CheckpointColumnDescriptor is CheckpointTable's column descriptors.
+var CheckpointColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "seq", NamedC: "seq", Type: enumor.Numeric},
+	{Column: "hash", NamedC: "hash", Type: enumor.String},
+	{Column: "signature", NamedC: "signature", Type: enumor.String},
+	{Column: "path", NamedC: "path", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// CheckpointTable 审计哈希链签名检查点，记录某一时刻哈希链头的序号和哈希，并签名后写入审计归档
+type CheckpointTable struct {
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Seq 检查点对应的审计序号
+	Seq uint64 `db:"seq" json:"seq"`
+	// Hash 检查点对应的审计哈希
+	Hash string `db:"hash" json:"hash" validate:"len=64"`
+	// Signature 检查点签名
+	Signature string `db:"signature" json:"signature" validate:"lte=128"`
+	// Path 检查点文件在对象存储中的路径
+	Path      string     `db:"path" json:"path" validate:"lte=255"`
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" json:"creator" validate:"lte=64"`
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName is the audit checkpoint's database table name.
+func (c CheckpointTable) TableName() table.Name {
+	return table.AuditCheckpointTable
+}
+
+// InsertValidate audit checkpoint table when insert.
+func (c CheckpointTable) InsertValidate() error {
+	if len(c.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if c.Seq == 0 {
+		return errors.New("seq is required")
+	}
+
+	if len(c.Signature) == 0 {
+		return errors.New("signature is required")
+	}
+
+	if len(c.Path) == 0 {
+		return errors.New("path is required")
+	}
+
+	if len(c.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(c)
+}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestChainHashAfterDecode(t *testing.T) {
+	type vpc struct {
+		Name    string  `json:"name"`
+		Zones   []int64 `json:"zones"`
+		Enabled bool    `json:"enabled"`
+		Memo    *string `json:"memo"`
+	}
+
+	one := AuditTable{
+		Seq:      2,
+		ResID:    "00000001",
+		ResType:  enumor.VpcCloudAuditResType,
+		Action:   enumor.Update,
+		BkBizID:  1,
+		Vendor:   enumor.TCloud,
+		Operator: "admin",
+		Source:   enumor.ApiCall,
+		Rid:      "rid",
+		PrevHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		Detail: &BasicDetail{
+			Data:    vpc{Name: "<vpc>&", Zones: []int64{9007199254740993}},
+			Changed: map[string]int{"b": 1, "a": 2},
+		},
+		CreatedAt: "2024-06-01T10:00:00+08:00",
+	}
+
+	hash, err := one.ChainHash()
+	if err != nil {
+		t.Fatalf("calculate chain hash failed, err: %v", err)
+	}
+
+	raw, err := one.Detail.Value()
+	if err != nil {
+		t.Fatalf("encode detail failed, err: %v", err)
+	}
+
+	decoded := one
+	decoded.Detail = new(BasicDetail)
+	if err := decoded.Detail.Scan(raw); err != nil {
+		t.Fatalf("decode detail failed, err: %v", err)
+	}
+	// 数据库中读出的时间格式与写入时可能不同
+	decoded.CreatedAt = "2024-06-01T02:00:00Z"
+
+	decodedHash, err := decoded.ChainHash()
+	if err != nil {
+		t.Fatalf("calculate decoded chain hash failed, err: %v", err)
+	}
+
+	if hash != decodedHash {
+		t.Errorf("chain hash changed after decode, before: %s, after: %s", hash, decodedHash)
+	}
+
+	decoded.Operator = "other"
+	tamperedHash, err := decoded.ChainHash()
+	if err != nil {
+		t.Fatalf("calculate tampered chain hash failed, err: %v", err)
+	}
+
+	if tamperedHash == hash {
+		t.Errorf("chain hash should be changed after audit is tampered")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+)
+
+// OutboxState 审计推送状态
+type OutboxState string
+
+const (
+	// OutboxPending 待推送，包括推送失败待重试
+	OutboxPending OutboxState = "pending"
+	// OutboxFailed 推送失败且超过最大重试次数，不再自动重试
+	OutboxFailed OutboxState = "failed"
+)
+
+// OutboxTable 审计推送发件箱，审计写入时在同一事务内写入，推送到所有外部SIEM成功后删除
+type OutboxTable struct {
+	ID       uint64 `db:"id" json:"id"`
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+	// Seq 审计在租户哈希链中的序号，与租户ID一起确定审计
+	Seq         uint64      `db:"seq" json:"seq"`
+	State       OutboxState `db:"state" json:"state"`
+	RetryCount  uint        `db:"retry_count" json:"retry_count"`
+	NextRetryAt types.Time  `db:"next_retry_at" json:"next_retry_at"`
+	LastError   string      `db:"last_error" json:"last_error"`
+	CreatedAt   types.Time  `db:"created_at" json:"created_at"`
+}
+
+// TableName is the audit outbox's database table name.
+func (o OutboxTable) TableName() table.Name {
+	return table.AuditOutboxTable
+}
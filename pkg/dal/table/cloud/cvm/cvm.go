@@ -55,6 +55,7 @@ var TableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 	{Column: "status", NamedC: "status", Type: enumor.String},
 	{Column: "recycle_status", NamedC: "recycle_status", Type: enumor.String},
+	{Column: "status_changed_at", NamedC: "status_changed_at", Type: enumor.Time},
 	{Column: "private_ipv4_addresses", NamedC: "private_ipv4_addresses", Type: enumor.Json},
 	{Column: "private_ipv6_addresses", NamedC: "private_ipv6_addresses", Type: enumor.Json},
 	{Column: "public_ipv4_addresses", NamedC: "public_ipv4_addresses", Type: enumor.Json},
@@ -92,6 +93,7 @@ type Table struct {
 	Memo                 *string           `db:"memo" json:"memo"`
 	Status               string            `db:"status" validate:"lte=50" json:"status"`
 	RecycleStatus        string            `db:"recycle_status" validate:"lte=32" json:"recycle_status"`
+	StatusChangedAt      types.Time        `db:"status_changed_at" validate:"excluded_unless" json:"status_changed_at"`
 	PrivateIPv4Addresses types.StringArray `db:"private_ipv4_addresses" json:"private_ipv4_addresses"`
 	PrivateIPv6Addresses types.StringArray `db:"private_ipv6_addresses" json:"private_ipv6_addresses"`
 	PublicIPv4Addresses  types.StringArray `db:"public_ipv4_addresses" json:"public_ipv4_addresses"`
@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot 云硬盘快照及定期快照策略相关表
+package disksnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DiskSnapshotColumns defines disk_snapshot's columns.
+var DiskSnapshotColumns = utils.MergeColumns(nil, DiskSnapshotColumnDescriptor)
+
+// DiskSnapshotColumnDescriptor is disk_snapshot's column descriptors.
+var DiskSnapshotColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "disk_id", NamedC: "disk_id", Type: enumor.String},
+	{Column: "cloud_disk_id", NamedC: "cloud_disk_id", Type: enumor.String},
+	{Column: "disk_size", NamedC: "disk_size", Type: enumor.Numeric},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "policy_id", NamedC: "policy_id", Type: enumor.String},
+	{Column: "recycle_status", NamedC: "recycle_status", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DiskSnapshotTable disk_snapshot表，存储云硬盘快照
+type DiskSnapshotTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	CloudID   string        `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	// BkBizID 快照所属业务，与源云硬盘保持一致
+	BkBizID int64  `db:"bk_biz_id" json:"bk_biz_id"`
+	Name    string `db:"name" validate:"lte=255" json:"name"`
+	Region  string `db:"region" validate:"lte=255" json:"region"`
+	Zone    string `db:"zone" validate:"lte=255" json:"zone"`
+	// DiskID 源云硬盘ID，源云硬盘未同步或已删除时为空
+	DiskID      string `db:"disk_id" validate:"lte=64" json:"disk_id"`
+	CloudDiskID string `db:"cloud_disk_id" validate:"lte=255" json:"cloud_disk_id"`
+	// DiskSize 源云硬盘大小，单位GB
+	DiskSize uint64 `db:"disk_size" json:"disk_size"`
+	Status   string `db:"status" validate:"lte=32" json:"status"`
+	// PolicyID 创建该快照的定期快照策略ID，手动创建的快照为空
+	PolicyID string `db:"policy_id" validate:"lte=64" json:"policy_id"`
+	// RecycleStatus 回收状态，源云硬盘回收时快照随之进入回收状态
+	RecycleStatus    string  `db:"recycle_status" validate:"lte=32" json:"recycle_status"`
+	CloudCreatedTime string  `db:"cloud_created_time" validate:"lte=64" json:"cloud_created_time"`
+	Memo             *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	// TenantID 租户ID
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return disk_snapshot table name.
+func (t DiskSnapshotTable) TableName() table.Name {
+	return table.DiskSnapshotTable
+}
+
+// InsertValidate disk_snapshot table when insert.
+func (t DiskSnapshotTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate disk_snapshot table when update.
+func (t DiskSnapshotTable) UpdateValidate() error {
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DiskSnapshotPolicyColumns defines disk_snapshot_policy's columns.
+var DiskSnapshotPolicyColumns = utils.MergeColumns(nil, DiskSnapshotPolicyColumnDescriptor)
+
+// DiskSnapshotPolicyColumnDescriptor is disk_snapshot_policy's column descriptors.
+var DiskSnapshotPolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "hours", NamedC: "hours", Type: enumor.Json},
+	{Column: "week_days", NamedC: "week_days", Type: enumor.Json},
+	{Column: "retention_days", NamedC: "retention_days", Type: enumor.Numeric},
+	{Column: "disk_ids", NamedC: "disk_ids", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DiskSnapshotPolicyTable disk_snapshot_policy表，存储云硬盘定期快照策略及其绑定的云硬盘
+type DiskSnapshotPolicyTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Name      string        `db:"name" validate:"lte=255" json:"name"`
+	// Hours 每天执行快照的整点，取值0-23
+	Hours types.JsonField `db:"hours" json:"hours"`
+	// WeekDays 每周执行快照的日期，取值0-6，0表示周日，为空表示每天执行
+	WeekDays types.JsonField `db:"week_days" json:"week_days"`
+	// RetentionDays 策略创建的快照保留天数，超过保留天数的快照会被自动删除
+	RetentionDays uint64 `db:"retention_days" json:"retention_days"`
+	// DiskIDs 绑定的云硬盘ID列表
+	DiskIDs types.JsonField `db:"disk_ids" json:"disk_ids"`
+	Memo    *string         `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	// TenantID 租户ID
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return disk_snapshot_policy table name.
+func (t DiskSnapshotPolicyTable) TableName() table.Name {
+	return table.DiskSnapshotPolicyTable
+}
+
+// InsertValidate disk_snapshot_policy table when insert.
+func (t DiskSnapshotPolicyTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Hours) == 0 {
+		return errors.New("hours is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate disk_snapshot_policy table when update.
+func (t DiskSnapshotPolicyTable) UpdateValidate() error {
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
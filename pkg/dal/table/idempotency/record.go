@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency 幂等请求相关表
+package idempotency
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RecordColumns defines idempotency_record's columns.
+var RecordColumns = utils.MergeColumns(nil, RecordColumnDescriptor)
+
+// RecordColumnDescriptor is idempotency_record's column descriptors.
+var RecordColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "idem_key", NamedC: "idem_key", Type: enumor.String},
+	{Column: "request_hash", NamedC: "request_hash", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "response", NamedC: "response", Type: enumor.String},
+	{Column: "lock_expired_at", NamedC: "lock_expired_at", Type: enumor.Time},
+	{Column: "expired_at", NamedC: "expired_at", Type: enumor.Time},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RecordTable idempotency_record表，记录携带幂等键的请求的请求摘要及响应结果
+type RecordTable struct {
+	ID            uint64                  `db:"id" json:"id"`
+	TenantID      string                  `db:"tenant_id" json:"tenant_id"`
+	Scope         string                  `db:"scope" json:"scope"`
+	IdemKey       string                  `db:"idem_key" json:"idem_key"`
+	RequestHash   string                  `db:"request_hash" json:"request_hash"`
+	State         enumor.IdempotencyState `db:"state" json:"state"`
+	Response      *string                 `db:"response" json:"response"`
+	LockExpiredAt types.Time              `db:"lock_expired_at" json:"lock_expired_at"`
+	ExpiredAt     types.Time              `db:"expired_at" json:"expired_at"`
+	CreatedAt     types.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt     types.Time              `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return idempotency_record table name.
+func (t RecordTable) TableName() table.Name {
+	return table.IdempotencyRecordTable
+}
@@ -0,0 +1,245 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地鉴权角色及角色绑定相关表
+package rbac
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RoleColumns defines rbac_role's columns.
+var RoleColumns = utils.MergeColumns(nil, RoleColumnDescriptor)
+
+// RoleColumnDescriptor is rbac_role's column descriptors.
+var RoleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "policies", NamedC: "policies", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RoleTable rbac_role表，定义角色授予的资源类型及操作
+type RoleTable struct {
+	ID        string     `db:"id" validate:"lte=64" json:"id"`
+	Name      string     `db:"name" validate:"lte=255" json:"name"`
+	Policies  Policies   `db:"policies" json:"policies"`
+	Memo      *string    `db:"memo" json:"memo"`
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return rbac_role table name.
+func (t RoleTable) TableName() table.Name {
+	return table.RbacRoleTable
+}
+
+// InsertValidate rbac_role table when insert.
+func (t RoleTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := corerbac.ValidatePolicies(t.Policies); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate rbac_role table when update.
+func (t RoleTable) UpdateValidate() error {
+	if len(t.ID) != 0 {
+		return errors.New("id can not be updated")
+	}
+
+	if t.Policies != nil {
+		if err := corerbac.ValidatePolicies(t.Policies); err != nil {
+			return err
+		}
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// Policies is the json array of role policies.
+type Policies []corerbac.Policy
+
+// Scan is used to decode raw message which is read from db into Policies.
+func (p *Policies) Scan(raw interface{}) error {
+	return types.Scan(raw, p)
+}
+
+// Value encode the Policies to a json raw, so that it can be stored to db with json raw.
+func (p Policies) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+
+	return types.Value(p)
+}
+
+// RoleBindingColumns defines rbac_role_binding's columns.
+var RoleBindingColumns = utils.MergeColumns(nil, RoleBindingColumnDescriptor)
+
+// RoleBindingColumnDescriptor is rbac_role_binding's column descriptors.
+var RoleBindingColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "role_id", NamedC: "role_id", Type: enumor.String},
+	{Column: "user", NamedC: "user", Type: enumor.String},
+	{Column: "scope_type", NamedC: "scope_type", Type: enumor.String},
+	{Column: "scope_ids", NamedC: "scope_ids", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RoleBindingTable rbac_role_binding表，将角色在指定范围内授予用户
+type RoleBindingTable struct {
+	ID        string             `db:"id" validate:"lte=64" json:"id"`
+	RoleID    string             `db:"role_id" validate:"lte=64" json:"role_id"`
+	User      string             `db:"user" validate:"lte=64" json:"user"`
+	ScopeType corerbac.ScopeType `db:"scope_type" validate:"lte=16" json:"scope_type"`
+	// ScopeIDs 授权范围ID列表，biz为业务ID，resource为资源ID，global为空
+	ScopeIDs  types.StringArray `db:"scope_ids" json:"scope_ids"`
+	Memo      *string           `db:"memo" json:"memo"`
+	TenantID  string            `db:"tenant_id" json:"tenant_id"`
+	Creator   string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time        `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return rbac_role_binding table name.
+func (t RoleBindingTable) TableName() table.Name {
+	return table.RbacRoleBindingTable
+}
+
+// InsertValidate rbac_role_binding table when insert.
+func (t RoleBindingTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.RoleID) == 0 {
+		return errors.New("role_id is required")
+	}
+
+	if len(t.User) == 0 {
+		return errors.New("user is required")
+	}
+
+	if err := corerbac.ValidateScope(t.ScopeType, t.ScopeIDs); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate rbac_role_binding table when update.
+func (t RoleBindingTable) UpdateValidate() error {
+	if len(t.ID) != 0 {
+		return errors.New("id can not be updated")
+	}
+
+	if len(t.RoleID) != 0 {
+		return errors.New("role_id can not be updated")
+	}
+
+	if len(t.User) != 0 {
+		return errors.New("user can not be updated")
+	}
+
+	if len(t.ScopeType) != 0 {
+		if err := corerbac.ValidateScope(t.ScopeType, t.ScopeIDs); err != nil {
+			return err
+		}
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
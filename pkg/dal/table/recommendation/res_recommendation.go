@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recommendation 资源优化建议相关表
+package recommendation
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResRecommendationColumns defines res_recommendation's columns.
+var ResRecommendationColumns = utils.MergeColumns(nil, ResRecommendationColumnDescriptor)
+
+// ResRecommendationColumnDescriptor is res_recommendation's column descriptors.
+var ResRecommendationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "rec_type", NamedC: "rec_type", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "monthly_saving", NamedC: "monthly_saving", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ResRecommendationTable res_recommendation表，存储闲置、规格过大等资源的优化建议
+type ResRecommendationTable struct {
+	ID         string                   `db:"id" validate:"lte=64" json:"id"`
+	Vendor     enumor.Vendor            `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID  string                   `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID    int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	Region     string                   `db:"region" validate:"lte=255" json:"region"`
+	Zone       string                   `db:"zone" validate:"lte=255" json:"zone"`
+	ResType    enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID      string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	CloudResID string                   `db:"cloud_res_id" validate:"lte=255" json:"cloud_res_id"`
+	ResName    string                   `db:"res_name" validate:"lte=255" json:"res_name"`
+	RecType    enumor.RecommendType     `db:"rec_type" validate:"lte=64" json:"rec_type"`
+	Action     enumor.RecommendAction   `db:"action" validate:"lte=64" json:"action"`
+	// Detail 优化建议的判定依据，如关机天数、监控峰值、建议规格等
+	Detail types.JsonField `db:"detail" json:"detail"`
+	// MonthlySaving 预计每月可节省的费用
+	MonthlySaving float64                `db:"monthly_saving" json:"monthly_saving"`
+	Currency      string                 `db:"currency" validate:"lte=16" json:"currency"`
+	Status        enumor.RecommendStatus `db:"status" validate:"lte=32" json:"status"`
+	// TenantID 租户ID
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return res_recommendation table name.
+func (t ResRecommendationTable) TableName() table.Name {
+	return table.ResRecommendationTable
+}
+
+// InsertValidate res_recommendation table when insert.
+func (t ResRecommendationTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id is required")
+	}
+
+	if err := t.RecType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Status) == 0 {
+		return errors.New("status is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate res_recommendation table when update.
+func (t ResRecommendationTable) UpdateValidate() error {
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// EventDeliveryColumns defines event_delivery's columns.
+var EventDeliveryColumns = utils.MergeColumns(nil, EventDeliveryColumnDescriptor)
+
+// EventDeliveryColumnDescriptor is event_delivery's column descriptors.
+var EventDeliveryColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "subscription_id", NamedC: "subscription_id", Type: enumor.String},
+	{Column: "event_id", NamedC: "event_id", Type: enumor.Numeric},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "retry_count", NamedC: "retry_count", Type: enumor.Numeric},
+	{Column: "next_retry_at", NamedC: "next_retry_at", Type: enumor.Time},
+	{Column: "status_code", NamedC: "status_code", Type: enumor.Numeric},
+	{Column: "last_error", NamedC: "last_error", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// EventDeliveryTable event_delivery表，事件分发时为每个匹配的订阅生成一条推送记录，同时作为推送日志
+type EventDeliveryTable struct {
+	ID             uint64                  `db:"id" json:"id"`
+	TenantID       string                  `db:"tenant_id" json:"tenant_id"`
+	SubscriptionID string                  `db:"subscription_id" json:"subscription_id"`
+	EventID        uint64                  `db:"event_id" json:"event_id"`
+	State          coreevent.DeliveryState `db:"state" json:"state"`
+	RetryCount     uint                    `db:"retry_count" json:"retry_count"`
+	NextRetryAt    types.Time              `db:"next_retry_at" json:"next_retry_at"`
+	StatusCode     int                     `db:"status_code" json:"status_code"`
+	LastError      string                  `db:"last_error" json:"last_error"`
+	CreatedAt      types.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt      types.Time              `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return event_delivery table name.
+func (t EventDeliveryTable) TableName() table.Name {
+	return table.EventDeliveryTable
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resevent 资源变更事件相关表
+package resevent
+
+import (
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// EventState 事件分发状态
+type EventState string
+
+const (
+	// EventPending 待分发到匹配的订阅
+	EventPending EventState = "pending"
+	// EventDispatched 已分发，已为匹配的订阅生成推送记录
+	EventDispatched EventState = "dispatched"
+)
+
+// ResEventColumns defines res_event's columns.
+var ResEventColumns = utils.MergeColumns(nil, ResEventColumnDescriptor)
+
+// ResEventColumnDescriptor is res_event's column descriptors.
+var ResEventColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "event_type", NamedC: "event_type", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "prev_bk_biz_id", NamedC: "prev_bk_biz_id", Type: enumor.Numeric},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "diff", NamedC: "diff", Type: enumor.Json},
+	{Column: "source", NamedC: "source", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResEventTable res_event表，资源变更历史写入时在同一事务内写入，由后台分发到匹配的订阅
+type ResEventTable struct {
+	ID          uint64                   `db:"id" json:"id"`
+	TenantID    string                   `db:"tenant_id" json:"tenant_id"`
+	EventType   coreevent.EventType      `db:"event_type" json:"event_type"`
+	ResType     enumor.CloudResourceType `db:"res_type" json:"res_type"`
+	ResID       string                   `db:"res_id" json:"res_id"`
+	CloudResID  string                   `db:"cloud_res_id" json:"cloud_res_id"`
+	ResName     string                   `db:"res_name" json:"res_name"`
+	Vendor      enumor.Vendor            `db:"vendor" json:"vendor"`
+	AccountID   string                   `db:"account_id" json:"account_id"`
+	BkBizID     int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	PrevBkBizID int64                    `db:"prev_bk_biz_id" json:"prev_bk_biz_id"`
+	Version     uint64                   `db:"version" json:"version"`
+	Diff        types.JsonField          `db:"diff" json:"diff"`
+	Source      enumor.RequestSourceType `db:"source" json:"source"`
+	Rid         string                   `db:"rid" json:"rid"`
+	Operator    string                   `db:"operator" json:"operator"`
+	State       EventState               `db:"state" json:"state"`
+	CreatedAt   types.Time               `db:"created_at" json:"created_at"`
+}
+
+// TableName return res_event table name.
+func (t ResEventTable) TableName() table.Name {
+	return table.ResEventTable
+}
@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resevent
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	coreevent "hcm/pkg/api/core/res-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// EventSubscriptionColumns defines event_subscription's columns.
+var EventSubscriptionColumns = utils.MergeColumns(nil, EventSubscriptionColumnDescriptor)
+
+// EventSubscriptionColumnDescriptor is event_subscription's column descriptors.
+var EventSubscriptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "url", NamedC: "url", Type: enumor.String},
+	{Column: "secret", NamedC: "secret", Type: enumor.String},
+	{Column: "filter", NamedC: "filter", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// EventSubscriptionTable event_subscription表，资源变更事件按过滤条件推送到订阅的 URL
+type EventSubscriptionTable struct {
+	ID   string `db:"id" validate:"lte=64" json:"id"`
+	Name string `db:"name" validate:"lte=64" json:"name"`
+	URL  string `db:"url" validate:"lte=512" json:"url"`
+	// Secret 加密后的请求签名密钥
+	Secret    string     `db:"secret" json:"secret"`
+	Filter    *Filter    `db:"filter" json:"filter"`
+	Enabled   *bool      `db:"enabled" json:"enabled"`
+	Memo      *string    `db:"memo" json:"memo"`
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return event_subscription table name.
+func (t EventSubscriptionTable) TableName() table.Name {
+	return table.EventSubscriptionTable
+}
+
+// InsertValidate event_subscription table when insert.
+func (t EventSubscriptionTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.URL) == 0 {
+		return errors.New("url is required")
+	}
+
+	if len(t.Secret) == 0 {
+		return errors.New("secret is required")
+	}
+
+	if t.Filter == nil {
+		return errors.New("filter is required")
+	}
+
+	if err := coreevent.SubscriptionFilter(*t.Filter).Validate(); err != nil {
+		return err
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// UpdateValidate event_subscription table when update.
+func (t EventSubscriptionTable) UpdateValidate() error {
+	if len(t.ID) != 0 {
+		return errors.New("id can not be updated")
+	}
+
+	if t.Filter != nil {
+		if err := coreevent.SubscriptionFilter(*t.Filter).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validator.ValidateMemo(t.Memo, false); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not be updated")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
+
+// Filter is the json of event subscription filter.
+type Filter coreevent.SubscriptionFilter
+
+// Scan is used to decode raw message which is read from db into Filter.
+func (f *Filter) Scan(raw interface{}) error {
+	return types.Scan(raw, f)
+}
+
+// Value encode the Filter to a json raw, so that it can be stored to db with json raw.
+func (f Filter) Value() (driver.Value, error) {
+	return types.Value(f)
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reshistory 资源变更历史相关表
+package reshistory
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResChangeHistoryColumns defines res_change_history's columns.
+var ResChangeHistoryColumns = utils.MergeColumns(nil, ResChangeHistoryColumnDescriptor)
+
+// ResChangeHistoryColumnDescriptor is res_change_history's column descriptors.
+var ResChangeHistoryColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "snapshot", NamedC: "snapshot", Type: enumor.Json},
+	{Column: "diff", NamedC: "diff", Type: enumor.Json},
+	{Column: "source", NamedC: "source", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResChangeHistoryTable res_change_history表，每次资源变更记录一个版本的快照及相对上一版本的字段级变更
+type ResChangeHistoryTable struct {
+	ID         string                   `db:"id" validate:"lte=64" json:"id"`
+	ResType    enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID      string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	CloudResID string                   `db:"cloud_res_id" validate:"lte=255" json:"cloud_res_id"`
+	ResName    string                   `db:"res_name" validate:"lte=255" json:"res_name"`
+	Vendor     enumor.Vendor            `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID  string                   `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID    int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	// Version 资源版本号，同一资源从1开始递增
+	Version uint64             `db:"version" json:"version"`
+	Action  enumor.AuditAction `db:"action" validate:"lte=16" json:"action"`
+	// Snapshot 变更后的资源快照，删除时为删除前的快照
+	Snapshot types.JsonField `db:"snapshot" json:"snapshot"`
+	// Diff 相对上一版本的字段级变更，资源首个版本为空数组
+	Diff      types.JsonField          `db:"diff" json:"diff"`
+	Source    enumor.RequestSourceType `db:"source" validate:"lte=64" json:"source"`
+	Rid       string                   `db:"rid" validate:"lte=64" json:"rid"`
+	TenantID  string                   `db:"tenant_id" json:"tenant_id"`
+	Creator   string                   `db:"creator" validate:"lte=64" json:"creator"`
+	CreatedAt types.Time               `db:"created_at" validate:"excluded_unless" json:"created_at"`
+}
+
+// TableName return res_change_history table name.
+func (t ResChangeHistoryTable) TableName() table.Name {
+	return table.ResChangeHistoryTable
+}
+
+// InsertValidate res_change_history table when insert.
+func (t ResChangeHistoryTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id is required")
+	}
+
+	if t.Version == 0 {
+		return errors.New("version is required")
+	}
+
+	if len(t.Action) == 0 {
+		return errors.New("action is required")
+	}
+
+	if len(t.Snapshot) == 0 {
+		return errors.New("snapshot is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
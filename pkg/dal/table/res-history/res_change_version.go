@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package reshistory
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResChangeVersionColumns defines res_change_version's columns.
+var ResChangeVersionColumns = utils.MergeColumns(nil, ResChangeVersionColumnDescriptor)
+
+// ResChangeVersionColumnDescriptor is res_change_version's column descriptors.
+var ResChangeVersionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "snapshot", NamedC: "snapshot", Type: enumor.Json},
+}
+
+// ResChangeVersionTable res_change_version表，记录每个资源最新的变更版本及快照，记录变更历史时对其加锁分配版本号，
+// 保证同一资源并发变更时版本号不冲突
+type ResChangeVersionTable struct {
+	ResType enumor.CloudResourceType `db:"res_type" json:"res_type"`
+	ResID   string                   `db:"res_id" json:"res_id"`
+	// Version 资源最新的版本号，0表示还没有变更历史
+	Version uint64 `db:"version" json:"version"`
+	// Snapshot 资源最新版本的快照
+	Snapshot types.JsonField `db:"snapshot" json:"snapshot"`
+}
+
+// TableName return res_change_version table name.
+func (t ResChangeVersionTable) TableName() table.Name {
+	return table.ResChangeVersionTable
+}
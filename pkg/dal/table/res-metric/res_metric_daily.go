@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resmetric 资源监控指标相关表
+package resmetric
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResMetricDailyColumns defines res_metric_daily's columns.
+var ResMetricDailyColumns = utils.MergeColumns(nil, ResMetricDailyColumnDescriptor)
+
+// ResMetricDailyColumnDescriptor is res_metric_daily's column descriptors.
+var ResMetricDailyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "metric", NamedC: "metric", Type: enumor.String},
+	{Column: "stat_date", NamedC: "stat_date", Type: enumor.String},
+	{Column: "avg_value", NamedC: "avg_value", Type: enumor.Numeric},
+	{Column: "max_value", NamedC: "max_value", Type: enumor.Numeric},
+	{Column: "min_value", NamedC: "min_value", Type: enumor.Numeric},
+	{Column: "sample_count", NamedC: "sample_count", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ResMetricDailyTable res_metric_daily表，存储资源监控指标的天级聚合数据
+type ResMetricDailyTable struct {
+	ID         string                   `db:"id" validate:"lte=64" json:"id"`
+	Vendor     enumor.Vendor            `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID  string                   `db:"account_id" validate:"lte=64" json:"account_id"`
+	Region     string                   `db:"region" validate:"lte=32" json:"region"`
+	ResType    enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID      string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	CloudResID string                   `db:"cloud_res_id" validate:"lte=255" json:"cloud_res_id"`
+	Metric     string                   `db:"metric" validate:"lte=64" json:"metric"`
+	// StatDate 统计日期，格式：2006-01-02
+	StatDate    string  `db:"stat_date" validate:"len=10" json:"stat_date"`
+	AvgValue    float64 `db:"avg_value" json:"avg_value"`
+	MaxValue    float64 `db:"max_value" json:"max_value"`
+	MinValue    float64 `db:"min_value" json:"min_value"`
+	SampleCount int64   `db:"sample_count" json:"sample_count"`
+	// TenantID 租户ID
+	TenantID  string     `db:"tenant_id" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return res_metric_daily table name.
+func (t ResMetricDailyTable) TableName() table.Name {
+	return table.ResMetricDailyTable
+}
+
+// InsertValidate res_metric_daily table when insert.
+func (t ResMetricDailyTable) InsertValidate() error {
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id is required")
+	}
+
+	if len(t.Metric) == 0 {
+		return errors.New("metric is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return validator.Validate.Struct(t)
+}
@@ -84,6 +84,8 @@ const (
 	DiskTable Name = "disk"
 	// TCloudRegionTable is tcloud region table's name.
 	TCloudRegionTable Name = "tcloud_region"
+	// AliyunRegionTable is aliyun region table's name.
+	AliyunRegionTable Name = "aliyun_region"
 	// AwsRegionTable is aws region table's name.
 	AwsRegionTable Name = "aws_region"
 	// GcpRegionTable is gcp region table's name.
@@ -209,6 +211,53 @@ const (
 
 	// ResUsageBizRelTable 资源-使用业务关联表
 	ResUsageBizRelTable = "res_usage_biz_rel"
+
+	// ResMetricDailyTable 资源监控指标天级聚合表
+	ResMetricDailyTable Name = "res_metric_daily"
+
+	// ResRecommendationTable 资源优化建议表
+	ResRecommendationTable Name = "res_recommendation"
+
+	// DiskSnapshotTable 云硬盘快照表
+	DiskSnapshotTable Name = "disk_snapshot"
+	// DiskSnapshotPolicyTable 云硬盘定期快照策略表
+	DiskSnapshotPolicyTable Name = "disk_snapshot_policy"
+
+	// ResChangeHistoryTable 资源变更历史表
+	ResChangeHistoryTable Name = "res_change_history"
+	// ResChangeVersionTable 资源最新变更版本表
+	ResChangeVersionTable Name = "res_change_version"
+	// AuditArchiveTable 审计归档清单表
+	AuditArchiveTable Name = "audit_archive"
+	// AuditChainHeadTable 审计哈希链头表
+	AuditChainHeadTable Name = "audit_chain_head"
+	// AuditCheckpointTable 审计哈希链签名检查点表
+	AuditCheckpointTable Name = "audit_checkpoint"
+	// AuditOutboxTable 审计推送发件箱表
+	AuditOutboxTable Name = "audit_outbox"
+
+	// RbacRoleTable 本地鉴权角色表
+	RbacRoleTable Name = "rbac_role"
+	// RbacRoleBindingTable 本地鉴权角色绑定表
+	RbacRoleBindingTable Name = "rbac_role_binding"
+
+	// ServiceAccountTable 服务账号表
+	ServiceAccountTable Name = "service_account"
+	// AccessTokenTable 访问令牌表
+	AccessTokenTable Name = "access_token"
+
+	// AssignRuleTable 资源自动分配业务规则表
+	AssignRuleTable Name = "assign_rule"
+
+	// ResEventTable 资源变更事件发件箱表
+	ResEventTable Name = "res_event"
+	// EventSubscriptionTable 资源变更事件订阅表
+	EventSubscriptionTable Name = "event_subscription"
+	// EventDeliveryTable 资源变更事件推送记录表
+	EventDeliveryTable Name = "event_delivery"
+
+	// IdempotencyRecordTable 幂等请求记录表
+	IdempotencyRecordTable Name = "idempotency_record"
 )
 
 // Validate whether the table name is valid or not.
@@ -257,6 +306,7 @@ var TableMap = map[Name]TableConfig{
 	GcpRegionTable:               {EnableTenant: true},
 	AwsRegionTable:               {},
 	TCloudRegionTable:            {EnableTenant: true},
+	AliyunRegionTable:            {EnableTenant: true},
 	RouteTableTable:              {EnableTenant: true},
 	TCloudRouteTable:             {EnableTenant: true},
 	AwsRouteTable:                {EnableTenant: true},
@@ -322,6 +372,38 @@ var TableMap = map[Name]TableConfig{
 	GlobalConfigTable: {},
 
 	ResUsageBizRelTable: {},
+
+	ResMetricDailyTable:    {EnableTenant: true},
+	ResRecommendationTable: {EnableTenant: true},
+
+	DiskSnapshotTable:       {EnableTenant: true},
+	DiskSnapshotPolicyTable: {EnableTenant: true},
+
+	ResChangeHistoryTable: {EnableTenant: true},
+	ResChangeVersionTable: {},
+	AuditArchiveTable:     {EnableTenant: true},
+	// audit_chain_head、audit_checkpoint 按租户的哈希链维护，由DAO显式指定租户ID，
+	// 避免开启多租户时默认租户不注入租户ID而读写到其他租户的数据
+	AuditChainHeadTable:  {},
+	AuditCheckpointTable: {},
+	// audit_outbox 由后台跨租户推送，由DAO显式指定租户ID
+	AuditOutboxTable: {},
+
+	RbacRoleTable:        {EnableTenant: true},
+	RbacRoleBindingTable: {EnableTenant: true},
+
+	ServiceAccountTable: {EnableTenant: true},
+	AccessTokenTable:    {EnableTenant: true},
+
+	AssignRuleTable: {EnableTenant: true},
+
+	// res_event、event_subscription、event_delivery 由后台跨租户分发、推送，由DAO显式指定租户ID
+	ResEventTable:          {},
+	EventSubscriptionTable: {},
+	EventDeliveryTable:     {},
+
+	// idempotency_record 过期记录跨租户清理，由DAO显式指定租户ID
+	IdempotencyRecordTable: {},
 }
 
 // Register 注册表名
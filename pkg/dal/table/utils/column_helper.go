@@ -160,9 +160,10 @@ type mergeColumnOption struct {
 }
 
 var timeFieldFilter = map[string]bool{
-	"created_at":     true,
-	"rel_created_at": true,
-	"updated_at":     true,
+	"created_at":        true,
+	"rel_created_at":    true,
+	"updated_at":        true,
+	"status_changed_at": true,
 }
 
 // MergeColumns merge table columns together.
@@ -26,6 +26,7 @@ import (
 	_ "net/http/pprof"
 
 	"hcm/pkg/metrics"
+	"hcm/pkg/rest/openapi"
 	"hcm/pkg/runtime/ctl"
 )
 
@@ -40,5 +41,8 @@ func SetCommonHandler(mux *http.ServeMux) http.Handler {
 	// add tools handler
 	mux.HandleFunc("/ctl", ctl.Handler().ServeHTTP)
 
+	// add openapi document handler
+	mux.HandleFunc("/openapi.json", openapi.Handler().ServeHTTP)
+
 	return mux
 }
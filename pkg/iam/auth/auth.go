@@ -21,6 +21,8 @@
 package auth
 
 import (
+	"time"
+
 	asproto "hcm/pkg/api/auth-server"
 	"hcm/pkg/cc"
 	authserver "hcm/pkg/client/auth-server"
@@ -59,6 +61,34 @@ type Authorizer interface {
 
 // NewAuthorizer create an authorizer for iam authorize related operation.
 func NewAuthorizer(sd serviced.Discover, tls cc.TLSConfig) (Authorizer, error) {
+	authClient, err := newAuthClient(sd, tls)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authorizer{
+		authClient: authClient,
+	}, nil
+}
+
+// NewAuthorizerWithCache create an authorizer which caches the authorization decisions, the cached decisions are
+// flushed when the decision cache of auth-server is invalidated.
+func NewAuthorizerWithCache(sd serviced.Discover, tls cc.TLSConfig, opt cc.AuthDecisionCache) (Authorizer, error) {
+	authClient, err := newAuthClient(sd, tls)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := newDecisionCache(opt)
+	go cache.syncRevision(authClient, time.Duration(opt.SyncIntervalSec)*time.Second)
+
+	return &authorizer{
+		authClient: authClient,
+		cache:      cache,
+	}, nil
+}
+
+func newAuthClient(sd serviced.Discover, tls cc.TLSConfig) (*authserver.Client, error) {
 	var tlsC *ssl.TLSConfig
 	if tls.Enable() {
 		tlsC = &ssl.TLSConfig{
@@ -80,32 +110,29 @@ func NewAuthorizer(sd serviced.Discover, tls cc.TLSConfig) (Authorizer, error) {
 		Client:   cli,
 		Discover: discovery.NewAPIDiscovery(cc.AuthServerName, sd),
 	}
-	authClient := authserver.NewClient(c, "v1")
 
-	return &authorizer{
-		authClient: authClient,
-	}, nil
+	return authserver.NewClient(c, "v1"), nil
 }
 
 type authorizer struct {
 	// authClient auth server's client api
 	authClient *authserver.Client
+	// cache is the authorization decision cache, it is nil when decision cache is disabled.
+	cache *decisionCache
 }
 
 // Authorize if user has permission to the resources, returns auth status per resource and for all.
 func (a authorizer) Authorize(kt *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, bool, error) {
-	userInfo := &meta.UserInfo{UserName: kt.User}
-
-	req := &asproto.AuthorizeBatchReq{
-		User:      userInfo,
-		Resources: resources,
+	scope, err := tokenScope(kt)
+	if err != nil {
+		return nil, false, err
 	}
 
-	decisions, err := a.authClient.AuthorizeBatch(kt.Ctx, kt.Header(), req)
+	decisions, err := a.authorizeBatch(kt, false, resources)
 	if err != nil {
-		logs.Errorf("authorize failed, req: %#v, err: %v, rid: %s", req, err, kt.Rid)
 		return nil, false, err
 	}
+	limitDecisionsByScope(scope, resources, decisions)
 
 	authorized := true
 	for _, decision := range decisions {
@@ -120,24 +147,108 @@ func (a authorizer) Authorize(kt *kit.Kit, resources ...meta.ResourceAttribute)
 
 // AuthorizeAny if user has any permission to the resources, returns auth status per resource and for all.
 func (a authorizer) AuthorizeAny(kt *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, error) {
+	scope, err := tokenScope(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions, err := a.authorizeBatch(kt, true, resources)
+	if err != nil {
+		return nil, err
+	}
+	limitDecisionsByScope(scope, resources, decisions)
+
+	return decisions, nil
+}
+
+// authorizeBatch authorize the resources by auth-server, if anyPerm is true, authorize if user has any permission to
+// the resources. When decision cache is enabled, only the resources without cached decision are authorized by
+// auth-server.
+func (a authorizer) authorizeBatch(kt *kit.Kit, anyPerm bool, resources []meta.ResourceAttribute) ([]meta.Decision,
+	error) {
+
+	kind, doAuthorize := "all", a.authClient.AuthorizeBatch
+	if anyPerm {
+		kind, doAuthorize = "any", a.authClient.AuthorizeAnyBatch
+	}
+
 	userInfo := &meta.UserInfo{UserName: kt.User}
 
-	req := &asproto.AuthorizeBatchReq{
-		User:      userInfo,
-		Resources: resources,
+	if a.cache == nil {
+		req := &asproto.AuthorizeBatchReq{User: userInfo, Resources: resources}
+		decisions, err := doAuthorize(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("authorize %s failed, req: %#v, err: %v, rid: %s", kind, req, err, kt.Rid)
+			return nil, err
+		}
+		return decisions, nil
+	}
+
+	generation := a.cache.currentGeneration()
+	decisions := make([]meta.Decision, len(resources))
+	keys := make([]string, len(resources))
+	missIndexes := make([]int, 0)
+	missResources := make([]meta.ResourceAttribute, 0)
+	for idx, res := range resources {
+		key, cacheable := decisionKey(kind, kt, res)
+		if cacheable {
+			if authorized, hit := a.cache.get(key); hit {
+				decisions[idx].Authorized = authorized
+				continue
+			}
+		}
+
+		keys[idx] = key
+		missIndexes = append(missIndexes, idx)
+		missResources = append(missResources, res)
+	}
+
+	cacheMetric.lookups.WithLabelValues(kind, "hit").Add(float64(len(resources) - len(missResources)))
+	cacheMetric.lookups.WithLabelValues(kind, "miss").Add(float64(len(missResources)))
+
+	if len(missResources) == 0 {
+		return decisions, nil
 	}
 
-	decisions, err := a.authClient.AuthorizeAnyBatch(kt.Ctx, kt.Header(), req)
+	req := &asproto.AuthorizeBatchReq{User: userInfo, Resources: missResources}
+	fetched, err := doAuthorize(kt.Ctx, kt.Header(), req)
 	if err != nil {
-		logs.Errorf("authorize any failed, req: %#v, err: %v, rid: %s", req, err, kt.Rid)
+		logs.Errorf("authorize %s failed, req: %#v, err: %v, rid: %s", kind, req, err, kt.Rid)
 		return nil, err
 	}
 
+	if len(fetched) != len(missResources) {
+		logs.Errorf("authorize %s returns %d decisions, but %d resources are requested, rid: %s", kind, len(fetched),
+			len(missResources), kt.Rid)
+		return nil, errf.New(errf.DoAuthorizeFailed, "authorize decisions count mismatch")
+	}
+
+	for i, idx := range missIndexes {
+		decisions[idx] = fetched[i]
+		if len(keys[idx]) != 0 {
+			a.cache.set(keys[idx], fetched[i].Authorized, generation)
+		}
+	}
+
 	return decisions, nil
 }
 
 // AuthorizeWithPerm authorize if user has permission, if not, returns unauthorized error.
 func (a authorizer) AuthorizeWithPerm(kt *kit.Kit, resources ...meta.ResourceAttribute) error {
+	scope, err := tokenScope(kt)
+	if err != nil {
+		return err
+	}
+
+	// 超出访问令牌权限范围的操作，无法通过申请权限获得，直接拒绝
+	if scope != nil {
+		for _, res := range resources {
+			if !scope.Allow(res) {
+				return errf.New(errf.PermissionDenied, "the operation is out of the access token scope")
+			}
+		}
+	}
+
 	_, authorized, err := a.Authorize(kt, resources...)
 	if err != nil {
 		return errf.New(errf.DoAuthorizeFailed, "authorize failed")
@@ -164,6 +275,11 @@ func (a authorizer) ListAuthorizedInstances(kt *kit.Kit, input *meta.ListAuthRes
 		return nil, errf.New(errf.InvalidParameter, "list authorized instances input is invalid")
 	}
 
+	scope, err := tokenScope(kt)
+	if err != nil {
+		return nil, err
+	}
+
 	userInfo := &meta.UserInfo{UserName: kt.User}
 
 	req := &asproto.ListAuthorizedInstancesReq{
@@ -178,7 +294,7 @@ func (a authorizer) ListAuthorizedInstances(kt *kit.Kit, input *meta.ListAuthRes
 		return nil, err
 	}
 
-	return resources, nil
+	return limitInstancesByScope(scope, input, resources), nil
 }
 
 // ListAuthInstWithFilter returns resource filter with authorized instances info & if user has no permission flag.
@@ -0,0 +1,249 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	authserver "hcm/pkg/client/auth-server"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// decisionCache caches the authorization decisions in memory for a short time. The least recently used decisions
+// are evicted when the cache is full, and all the decisions are flushed when the decision cache revision of
+// auth-server is changed, which means the permission policies are changed.
+type decisionCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	// lru is the list of the cache entries, the most recently used entry is at the front.
+	lru *list.List
+	// revision is the latest synced decision cache revision of auth-server.
+	revision int64
+	// generation is increased when the cache is flushed, so that the decisions fetched before the flush are not
+	// cached after it.
+	generation uint64
+}
+
+type cacheEntry struct {
+	key        string
+	authorized bool
+	expireAt   time.Time
+}
+
+func newDecisionCache(opt cc.AuthDecisionCache) *decisionCache {
+	initCacheMetric()
+
+	return &decisionCache{
+		ttl:         time.Duration(opt.TTLSec) * time.Second,
+		negativeTTL: time.Duration(opt.NegativeTTLSec) * time.Second,
+		maxEntries:  int(opt.MaxEntries),
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// decisionKey returns the cache key of the authorization decision, the resource without basic info is not cached.
+func decisionKey(kind string, kt *kit.Kit, res meta.ResourceAttribute) (string, bool) {
+	if res.Basic == nil {
+		return "", false
+	}
+
+	return strings.Join([]string{kind, kt.TenantID, kt.User, string(res.Type), string(res.Action), res.ResourceID,
+		strconv.FormatInt(res.BizID, 10)}, "/"), true
+}
+
+// currentGeneration returns the current generation of the cache, it should be got before fetching the decisions.
+func (c *decisionCache) currentGeneration() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.generation
+}
+
+// get returns the cached decision of the key and whether it is cached.
+func (c *decisionCache) get(key string) (bool, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, exists := c.entries[key]
+	if !exists {
+		return false, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expireAt) {
+		c.removeElement(elem)
+		return false, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.authorized, true
+}
+
+// set caches the decision of the key if the cache is not flushed since the generation.
+func (c *decisionCache) set(key string, authorized bool, generation uint64) {
+	ttl := c.ttl
+	if !authorized {
+		ttl = c.negativeTTL
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if elem, exists := c.entries[key]; exists {
+		entry := elem.Value.(*cacheEntry)
+		entry.authorized, entry.expireAt = authorized, time.Now().Add(ttl)
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	for c.lru.Len() >= c.maxEntries {
+		c.removeElement(c.lru.Back())
+		cacheMetric.evictions.Inc()
+	}
+
+	entry := &cacheEntry{key: key, authorized: authorized, expireAt: time.Now().Add(ttl)}
+	c.entries[key] = c.lru.PushFront(entry)
+	cacheMetric.entries.Set(float64(c.lru.Len()))
+}
+
+func (c *decisionCache) removeElement(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+	cacheMetric.entries.Set(float64(c.lru.Len()))
+}
+
+// updateRevision flushes all the cached decisions if the revision is changed.
+func (c *decisionCache) updateRevision(revision int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if revision == c.revision {
+		return
+	}
+
+	logs.Infof("decision cache revision is changed from %d to %d, flush %d cached decisions", c.revision, revision,
+		c.lru.Len())
+
+	c.revision = revision
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	cacheMetric.entries.Set(0)
+	cacheMetric.flushes.Inc()
+}
+
+// syncRevision syncs the decision cache revision from auth-server periodically.
+func (c *decisionCache) syncRevision(cli *authserver.Client, interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		kt := core.NewBackendKit()
+		revision, err := cli.GetDecisionCacheRevision(kt.Ctx, kt.Header())
+		if err != nil {
+			logs.Errorf("get decision cache revision failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		if revision == nil {
+			continue
+		}
+
+		c.updateRevision(revision.Revision)
+	}
+}
+
+// cacheMetric is used to collect decision cache metrics.
+var cacheMetric *decisionCacheMetric
+
+var cacheMetricOnce sync.Once
+
+func initCacheMetric() {
+	cacheMetricOnce.Do(func() {
+		m := new(decisionCacheMetric)
+
+		m.lookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.AuthDecisionCacheSubSys,
+			Name:      "lookups_total",
+			Help:      "the total count of the decision cache lookups, type is all or any, result is hit or miss",
+		}, []string{"type", "result"})
+		metrics.Register().MustRegister(m.lookups)
+
+		m.evictions = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.AuthDecisionCacheSubSys,
+			Name:      "evictions_total",
+			Help:      "the total count of the decisions evicted because the cache is full",
+		})
+		metrics.Register().MustRegister(m.evictions)
+
+		m.flushes = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.AuthDecisionCacheSubSys,
+			Name:      "flushes_total",
+			Help:      "the total count of the cache flushes caused by decision cache invalidation",
+		})
+		metrics.Register().MustRegister(m.flushes)
+
+		m.entries = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.AuthDecisionCacheSubSys,
+			Name:      "entries",
+			Help:      "the number of the cached decisions",
+		})
+		metrics.Register().MustRegister(m.entries)
+
+		cacheMetric = m
+	})
+}
+
+type decisionCacheMetric struct {
+	// lookups record the count of the decision cache lookups.
+	lookups *prometheus.CounterVec
+
+	// evictions record the count of the evicted decisions.
+	evictions prometheus.Counter
+
+	// flushes record the count of the cache flushes.
+	flushes prometheus.Counter
+
+	// entries record the number of the cached decisions.
+	entries prometheus.Gauge
+}
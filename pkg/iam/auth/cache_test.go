@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"testing"
+
+	"hcm/pkg/cc"
+)
+
+func TestDecisionCache(t *testing.T) {
+	cache := newDecisionCache(cc.AuthDecisionCache{TTLSec: 60, NegativeTTLSec: 60, MaxEntries: 2})
+
+	generation := cache.currentGeneration()
+	cache.set("a", true, generation)
+	cache.set("b", false, generation)
+
+	if authorized, hit := cache.get("b"); !hit || authorized {
+		t.Fatalf("negative decision should be cached, hit: %v, authorized: %v", hit, authorized)
+	}
+
+	// a is the least recently used decision, it is evicted when the cache is full.
+	cache.set("c", true, generation)
+	if _, hit := cache.get("a"); hit {
+		t.Errorf("least recently used decision should be evicted")
+	}
+
+	if authorized, hit := cache.get("c"); !hit || !authorized {
+		t.Errorf("decision c should be cached, hit: %v, authorized: %v", hit, authorized)
+	}
+
+	cache.updateRevision(1)
+	if _, hit := cache.get("c"); hit {
+		t.Errorf("decisions should be flushed after revision changed")
+	}
+
+	// the decision fetched before the flush should not be cached.
+	cache.set("d", true, generation)
+	if _, hit := cache.get("d"); hit {
+		t.Errorf("decision of the stale generation should not be cached")
+	}
+}
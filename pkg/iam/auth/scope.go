@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"strconv"
+
+	coretoken "hcm/pkg/api/core/access-token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// tokenScope returns the access token scope of the request, returns nil if the request is not authenticated by
+// access token.
+func tokenScope(kt *kit.Kit) (*coretoken.Scope, error) {
+	if len(kt.TokenScope) == 0 {
+		return nil, nil
+	}
+
+	scope := new(coretoken.Scope)
+	if err := json.Unmarshal([]byte(kt.TokenScope), scope); err != nil {
+		logs.Errorf("unmarshal access token scope failed, err: %v, scope: %s, rid: %s", err, kt.TokenScope, kt.Rid)
+		return nil, errf.New(errf.DoAuthorizeFailed, "access token scope is invalid")
+	}
+
+	return scope, nil
+}
+
+// limitDecisionsByScope the permission of access token is the intersection of the owner's permission and the
+// token's scope, so resources out of the scope are unauthorized.
+func limitDecisionsByScope(scope *coretoken.Scope, resources []meta.ResourceAttribute, decisions []meta.Decision) {
+	if scope == nil {
+		return
+	}
+
+	for idx := range decisions {
+		if idx < len(resources) && !scope.Allow(resources[idx]) {
+			decisions[idx].Authorized = false
+		}
+	}
+}
+
+// limitInstancesByScope limit the authorized instances by the access token scope.
+func limitInstancesByScope(scope *coretoken.Scope, input *meta.ListAuthResInput,
+	instances *meta.AuthorizedInstances) *meta.AuthorizedInstances {
+
+	if scope == nil {
+		return instances
+	}
+
+	if !scope.AllowAction(input.Type, input.Action) {
+		return &meta.AuthorizedInstances{IDs: make([]string, 0)}
+	}
+
+	if input.Type != meta.Biz || len(scope.BizIDs) == 0 {
+		return instances
+	}
+
+	bizIDs := make([]string, 0, len(scope.BizIDs))
+	for _, bizID := range scope.BizIDs {
+		bizIDs = append(bizIDs, strconv.FormatInt(bizID, 10))
+	}
+
+	if instances.IsAny {
+		return &meta.AuthorizedInstances{IDs: bizIDs}
+	}
+
+	return &meta.AuthorizedInstances{IDs: slice.Intersection(instances.IDs, bizIDs)}
+}
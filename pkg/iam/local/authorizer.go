@@ -0,0 +1,255 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package local
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/sys"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// Authorizer authorize users by the roles, role bindings and resource scopes stored in hcm's own database.
+type Authorizer struct {
+	ds *dataservice.Client
+	// admins have all the permissions, they are used to initialize roles and role bindings.
+	admins map[string]struct{}
+}
+
+// NewAuthorizer create a local authorizer.
+func NewAuthorizer(ds *dataservice.Client, admins []string) (*Authorizer, error) {
+	if ds == nil {
+		return nil, errf.New(errf.InvalidParameter, "data service client is nil")
+	}
+
+	adminMap := make(map[string]struct{}, len(admins))
+	for _, admin := range admins {
+		adminMap[admin] = struct{}{}
+	}
+
+	return &Authorizer{ds: ds, admins: adminMap}, nil
+}
+
+// Authorize if user has permission to the resources, returns auth status per resource and for all.
+func (a *Authorizer) Authorize(kt *kit.Kit, user string, resources ...meta.ResourceAttribute) ([]meta.Decision,
+	bool, error) {
+
+	grants, err := a.listGrants(kt, user)
+	if err != nil {
+		return nil, false, err
+	}
+
+	decisions := make([]meta.Decision, len(resources))
+	authorized := true
+	for idx, res := range resources {
+		decisions[idx].Authorized = grants == nil || grants.Authorize(res)
+		if !decisions[idx].Authorized {
+			authorized = false
+		}
+	}
+
+	return decisions, authorized, nil
+}
+
+// AuthorizeAny if user has any permission to the resources, returns auth status per resource.
+func (a *Authorizer) AuthorizeAny(kt *kit.Kit, user string, resources ...meta.ResourceAttribute) ([]meta.Decision,
+	error) {
+
+	grants, err := a.listGrants(kt, user)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := make([]meta.Decision, len(resources))
+	for idx, res := range resources {
+		decisions[idx].Authorized = grants == nil || grants.AuthorizeAny(res)
+	}
+
+	return decisions, nil
+}
+
+// AuthorizeWithPerm authorize if user has permission, if not, returns unauthorized error with the permissions.
+func (a *Authorizer) AuthorizeWithPerm(kt *kit.Kit, user string, resources ...meta.ResourceAttribute) error {
+	decisions, authorized, err := a.Authorize(kt, user, resources...)
+	if err != nil {
+		return errf.New(errf.DoAuthorizeFailed, "authorize failed")
+	}
+
+	if authorized {
+		return nil
+	}
+
+	denied := make([]meta.ResourceAttribute, 0)
+	for idx, decision := range decisions {
+		if !decision.Authorized {
+			denied = append(denied, resources[idx])
+		}
+	}
+
+	return errf.NewWithPerm(errf.PermissionDenied, "no permission", a.GetPermissionToApply(denied...))
+}
+
+// ListAuthorizedInstances list authorized instances of the user.
+func (a *Authorizer) ListAuthorizedInstances(kt *kit.Kit, user string, input *meta.ListAuthResInput) (
+	*meta.AuthorizedInstances, error) {
+
+	if input == nil || len(input.Action) == 0 || len(input.Type) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "list authorized instances input is invalid")
+	}
+
+	grants, err := a.listGrants(kt, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if grants == nil {
+		return &meta.AuthorizedInstances{IsAny: true}, nil
+	}
+
+	return grants.ListInstances(input.Type, input.Action), nil
+}
+
+// GetPermissionToApply get permissions that user should be granted, every resource type and action is an action
+// in the permission, which is named as `{resource type}:{action}`.
+func (a *Authorizer) GetPermissionToApply(resources ...meta.ResourceAttribute) *meta.IamPermission {
+	permission := &meta.IamPermission{
+		SystemID:   sys.SystemIDHCM,
+		SystemName: sys.SystemNameHCM,
+		Actions:    make([]meta.IamAction, 0),
+	}
+
+	actionIdx := make(map[string]int)
+	for _, res := range resources {
+		if res.Basic == nil || res.Action == meta.SkipAction {
+			continue
+		}
+
+		actionID := fmt.Sprintf("%s:%s", res.Type, res.Action)
+		idx, exists := actionIdx[actionID]
+		if !exists {
+			permission.Actions = append(permission.Actions, meta.IamAction{
+				ID:   actionID,
+				Name: actionID,
+				RelatedResourceTypes: []meta.IamResourceType{{
+					SystemID:   sys.SystemIDHCM,
+					SystemName: sys.SystemNameHCM,
+					Type:       string(res.Type),
+					TypeName:   string(res.Type),
+					Instances:  make([][]meta.IamResourceInstance, 0),
+				}},
+			})
+			idx = len(permission.Actions) - 1
+			actionIdx[actionID] = idx
+		}
+
+		if len(res.ResourceID) == 0 {
+			continue
+		}
+
+		resType := &permission.Actions[idx].RelatedResourceTypes[0]
+		resType.Instances = append(resType.Instances, []meta.IamResourceInstance{{
+			Type:     string(res.Type),
+			TypeName: string(res.Type),
+			ID:       res.ResourceID,
+		}})
+	}
+
+	return permission
+}
+
+// listGrants list the roles granted to the user, returns nil if the user is admin who has all the permissions.
+func (a *Authorizer) listGrants(kt *kit.Kit, user string) (Grants, error) {
+	if len(user) == 0 {
+		return make(Grants, 0), nil
+	}
+
+	if _, exists := a.admins[user]; exists {
+		return nil, nil
+	}
+
+	bindings := make([]corerbac.RoleBinding, 0)
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("user", user),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := a.ds.Global.Rbac.ListRoleBinding(kt, listReq)
+		if err != nil {
+			logs.Errorf("list rbac role binding failed, user: %s, err: %v, rid: %s", user, err, kt.Rid)
+			return nil, err
+		}
+
+		bindings = append(bindings, result.Details...)
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	if len(bindings) == 0 {
+		return make(Grants, 0), nil
+	}
+
+	roleIDs := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		roleIDs = append(roleIDs, binding.RoleID)
+	}
+
+	roleMap := make(map[string]corerbac.Role)
+	for _, ids := range slice.Split(slice.Unique(roleIDs), int(core.DefaultMaxPageLimit)) {
+		listReq = &core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := a.ds.Global.Rbac.ListRole(kt, listReq)
+		if err != nil {
+			logs.Errorf("list rbac role failed, ids: %v, err: %v, rid: %s", ids, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, role := range result.Details {
+			roleMap[role.ID] = role
+		}
+	}
+
+	grants := make(Grants, 0, len(bindings))
+	for _, binding := range bindings {
+		role, exists := roleMap[binding.RoleID]
+		if !exists {
+			continue
+		}
+
+		grants = append(grants, Grant{
+			Policies:  role.Policies,
+			ScopeType: binding.ScopeType,
+			ScopeIDs:  binding.ScopeIDs,
+		})
+	}
+
+	return grants, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package local 本地鉴权引擎，基于hcm数据库中的角色、角色绑定及授权范围进行鉴权，用于未部署蓝鲸权限中心的场景
+package local
+
+import (
+	"strconv"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/tools/slice"
+)
+
+// Grant is a role granted to a user in the scope of the role binding.
+type Grant struct {
+	Policies  []corerbac.Policy
+	ScopeType corerbac.ScopeType
+	// ScopeIDs biz ids of biz scope, or resource ids of resource scope.
+	ScopeIDs []string
+}
+
+// matchPolicy returns whether the role grants the action of the resource type.
+func (g Grant) matchPolicy(resType meta.ResourceType, action meta.Action) bool {
+	for _, policy := range g.Policies {
+		if policy.Match(resType, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchScope returns whether the resource is in the scope of the role binding.
+func (g Grant) matchScope(res meta.ResourceAttribute) bool {
+	switch g.ScopeType {
+	case corerbac.GlobalScope:
+		return true
+
+	case corerbac.BizScope:
+		if res.BizID <= 0 {
+			return false
+		}
+		return slice.IsItemInSlice(g.ScopeIDs, strconv.FormatInt(res.BizID, 10))
+
+	case corerbac.ResourceScope:
+		if len(res.ResourceID) == 0 {
+			return false
+		}
+		return slice.IsItemInSlice(g.ScopeIDs, res.ResourceID)
+
+	default:
+		return false
+	}
+}
+
+// Grants are all the roles granted to a user.
+type Grants []Grant
+
+// Authorize returns whether the user has permission to the resource.
+func (gs Grants) Authorize(res meta.ResourceAttribute) bool {
+	if res.Basic == nil {
+		return false
+	}
+
+	if res.Action == meta.SkipAction {
+		return true
+	}
+
+	for _, grant := range gs {
+		if grant.matchPolicy(res.Type, res.Action) && grant.matchScope(res) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthorizeAny returns whether the user has permission to any instance of the resource type.
+func (gs Grants) AuthorizeAny(res meta.ResourceAttribute) bool {
+	if res.Basic == nil {
+		return false
+	}
+
+	if res.Action == meta.SkipAction {
+		return true
+	}
+
+	for _, grant := range gs {
+		if grant.matchPolicy(res.Type, res.Action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListInstances returns the authorized instances of the resource type with the action.
+// biz scope only provides instances of biz resource type, other resource types authorized by biz scope should be
+// filtered by biz id instead of instance id.
+func (gs Grants) ListInstances(resType meta.ResourceType, action meta.Action) *meta.AuthorizedInstances {
+	ids := make([]string, 0)
+	for _, grant := range gs {
+		if !grant.matchPolicy(resType, action) {
+			continue
+		}
+
+		switch grant.ScopeType {
+		case corerbac.GlobalScope:
+			return &meta.AuthorizedInstances{IsAny: true}
+
+		case corerbac.BizScope:
+			if resType == meta.Biz {
+				ids = append(ids, grant.ScopeIDs...)
+			}
+
+		case corerbac.ResourceScope:
+			ids = append(ids, grant.ScopeIDs...)
+		}
+	}
+
+	return &meta.AuthorizedInstances{IDs: slice.Unique(ids)}
+}
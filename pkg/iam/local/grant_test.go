@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package local
+
+import (
+	"reflect"
+	"testing"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/iam/meta"
+)
+
+func TestGrants(t *testing.T) {
+	grants := Grants{
+		{
+			Policies:  []corerbac.Policy{{ResourceType: meta.Cvm, Actions: []meta.Action{meta.Find, meta.Start}}},
+			ScopeType: corerbac.BizScope,
+			ScopeIDs:  []string{"100"},
+		},
+		{
+			Policies:  []corerbac.Policy{{ResourceType: meta.Account, Actions: []meta.Action{corerbac.Wildcard}}},
+			ScopeType: corerbac.ResourceScope,
+			ScopeIDs:  []string{"acc-1", "acc-2"},
+		},
+		{
+			Policies:  []corerbac.Policy{{ResourceType: meta.Biz, Actions: []meta.Action{meta.Access}}},
+			ScopeType: corerbac.BizScope,
+			ScopeIDs:  []string{"100", "200"},
+		},
+	}
+
+	attr := func(resType meta.ResourceType, action meta.Action, resID string, bizID int64) meta.ResourceAttribute {
+		return meta.ResourceAttribute{
+			Basic: &meta.Basic{Type: resType, Action: action, ResourceID: resID},
+			BizID: bizID,
+		}
+	}
+
+	authCases := []struct {
+		res    meta.ResourceAttribute
+		expect bool
+	}{
+		{res: attr(meta.Cvm, meta.Find, "", 100), expect: true},
+		{res: attr(meta.Cvm, meta.Find, "", 200), expect: false},
+		{res: attr(meta.Cvm, meta.Delete, "", 100), expect: false},
+		{res: attr(meta.Account, meta.Update, "acc-2", 0), expect: true},
+		{res: attr(meta.Account, meta.Update, "acc-3", 0), expect: false},
+		{res: attr(meta.Vpc, meta.SkipAction, "", 0), expect: true},
+	}
+	for idx, c := range authCases {
+		if got := grants.Authorize(c.res); got != c.expect {
+			t.Errorf("case %d authorize %+v expect %v, but got %v", idx, c.res.Basic, c.expect, got)
+		}
+	}
+
+	if !grants.AuthorizeAny(attr(meta.Cvm, meta.Start, "", 0)) {
+		t.Errorf("authorize any cvm start should be authorized")
+	}
+
+	if grants.AuthorizeAny(attr(meta.Vpc, meta.Find, "", 0)) {
+		t.Errorf("authorize any vpc find should not be authorized")
+	}
+
+	listCases := []struct {
+		resType meta.ResourceType
+		action  meta.Action
+		expect  *meta.AuthorizedInstances
+	}{
+		{resType: meta.Biz, action: meta.Access, expect: &meta.AuthorizedInstances{IDs: []string{"100", "200"}}},
+		{resType: meta.Account, action: meta.Find, expect: &meta.AuthorizedInstances{IDs: []string{"acc-1", "acc-2"}}},
+		{resType: meta.Cvm, action: meta.Find, expect: &meta.AuthorizedInstances{IDs: []string{}}},
+	}
+	for idx, c := range listCases {
+		if got := grants.ListInstances(c.resType, c.action); !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("case %d list %s %s instances expect %+v, but got %+v", idx, c.resType, c.action, c.expect, got)
+		}
+	}
+
+	global := append(grants, Grant{
+		Policies:  []corerbac.Policy{{ResourceType: corerbac.Wildcard, Actions: []meta.Action{meta.Find}}},
+		ScopeType: corerbac.GlobalScope,
+	})
+	if got := global.ListInstances(meta.Vpc, meta.Find); !got.IsAny {
+		t.Errorf("list vpc find instances with global scope should be any, but got %+v", got)
+	}
+}
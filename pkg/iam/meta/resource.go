@@ -113,4 +113,16 @@ const (
 
 	// CosBucket cos桶
 	CosBucket ResourceType = "cos_bucket"
+
+	// Rbac 本地鉴权的角色及角色绑定，仅在本地鉴权模式下使用
+	Rbac ResourceType = "rbac"
+
+	// ServiceAccount 服务账号及其访问令牌
+	ServiceAccount ResourceType = "service_account"
+
+	// AssignRule 资源自动分配业务规则
+	AssignRule ResourceType = "assign_rule"
+
+	// EventSubscription 资源变更事件订阅及其推送记录
+	EventSubscription ResourceType = "event_subscription"
 )
@@ -65,6 +65,8 @@ type Kit struct {
 	// 因为来自前端和第三方系统调用的请求均为 ApiCall，所以没必要将该字段暴漏出去，仅同步请求需要设
 	// 置该字段为 BackgroundSync。
 	RequestSource enumor.RequestSourceType
+	// TokenScope 访问令牌权限范围，json格式，仅通过访问令牌认证的请求设置，用于在鉴权时限制请求的权限
+	TokenScope string
 }
 
 // SetTenant set tenant id
@@ -179,6 +181,7 @@ func (kt *Kit) Header() http.Header {
 		constant.AppCodeKey:       []string{kt.AppCode},
 		constant.TenantIDKey:      []string{kt.TenantID},
 		constant.RequestSourceKey: []string{string(kt.RequestSource)},
+		constant.TokenScopeKey:    []string{kt.TokenScope},
 	}
 }
 
@@ -195,6 +198,7 @@ func FromHeader(ctx context.Context, header http.Header) (*Kit, error) {
 		AppCode:       header.Get(constant.AppCodeKey),
 		TenantID:      header.Get(constant.TenantIDKey),
 		RequestSource: enumor.RequestSourceType(header.Get(constant.RequestSourceKey)),
+		TokenScope:    header.Get(constant.TokenScopeKey),
 	}
 
 	if kt.Ctx.Value(constant.RidKey) == nil {
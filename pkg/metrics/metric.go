@@ -65,6 +65,12 @@ const (
 
 	// CloudApiSubSys defines all cloud api related subsystem
 	CloudApiSubSys = "cloudapi"
+
+	// RateLimitSubSys defines the request rate limit related subsystem
+	RateLimitSubSys = "ratelimit"
+
+	// AuthDecisionCacheSubSys defines the authorization decision cache related subsystem
+	AuthDecisionCacheSubSys = "auth_decision_cache"
 )
 
 // labels
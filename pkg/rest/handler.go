@@ -58,6 +58,32 @@ type action struct {
 	Path    string
 	Alias   string
 	Handler func(contexts *Contexts) (reply interface{}, err error)
+	Doc     *RouteDoc
+}
+
+// RouteDoc describes the request and response of the route, it is used to generate the openapi document.
+type RouteDoc struct {
+	summary string
+	reads   interface{}
+	writes  interface{}
+}
+
+// Summary set the summary of the route.
+func (d *RouteDoc) Summary(summary string) *RouteDoc {
+	d.summary = summary
+	return d
+}
+
+// Reads set the request body sample of the route, e.g. new(proto.CreateReq).
+func (d *RouteDoc) Reads(sample interface{}) *RouteDoc {
+	d.reads = sample
+	return d
+}
+
+// Writes set the response data sample of the route, e.g. new(core.CreateResult).
+func (d *RouteDoc) Writes(sample interface{}) *RouteDoc {
+	d.writes = sample
+	return d
 }
 
 // Handler contains all the restfull http handler actions
@@ -71,8 +97,8 @@ func (r *Handler) Path(path string) {
 	r.rootPath = strings.TrimRight(path, "/")
 }
 
-// Add add a http handler
-func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (interface{}, error)) {
+// Add add a http handler, the returned RouteDoc can be used to describe the request and response of the handler.
+func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (interface{}, error)) *RouteDoc {
 	switch verb {
 	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
 	default:
@@ -87,7 +113,9 @@ func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (int
 		panic("add http handler, but got nil http handler")
 	}
 
-	r.actions = append(r.actions, &action{Verb: verb, Path: path, Alias: alias, Handler: handler})
+	doc := new(RouteDoc)
+	r.actions = append(r.actions, &action{Verb: verb, Path: path, Alias: alias, Handler: handler, Doc: doc})
+	return doc
 }
 
 // Load add actions to the restful webservice, and add to the rest container.
@@ -102,25 +130,65 @@ func (r *Handler) Load(ws *restful.WebService) {
 			path = fmt.Sprintf("%s/%s", r.rootPath, strings.TrimLeft(action.Path, "/"))
 		}
 
+		var builder *restful.RouteBuilder
 		switch action.Verb {
 		case http.MethodPost:
-			ws.Route(ws.POST(path).To(r.wrapperAction(action)))
+			builder = ws.POST(path)
 		case http.MethodDelete:
-			ws.Route(ws.DELETE(path).To(r.wrapperAction(action)))
+			builder = ws.DELETE(path)
 		case http.MethodPut:
-			ws.Route(ws.PUT(path).To(r.wrapperAction(action)))
+			builder = ws.PUT(path)
 		case http.MethodGet:
-			ws.Route(ws.GET(path).To(r.wrapperAction(action)))
+			builder = ws.GET(path)
 		case http.MethodPatch:
-			ws.Route(ws.PATCH(path).To(r.wrapperAction(action)))
+			builder = ws.PATCH(path)
 		default:
 			panic(fmt.Sprintf("add handler to webservice, but got unsupport verb: %s .", action.Verb))
 		}
+
+		builder.To(r.wrapperAction(action)).Operation(action.Alias).Doc(action.Doc.summary)
+		if action.Doc.reads != nil {
+			builder.Reads(action.Doc.reads)
+		}
+		if action.Doc.writes != nil {
+			builder.Writes(action.Doc.writes)
+		}
+		ws.Route(builder)
 	}
 
+	registerWebService(ws)
+
 	return
 }
 
+var (
+	webServicesLock sync.Mutex
+	webServices     = make([]*restful.WebService, 0)
+)
+
+// registerWebService record the web service which the handlers are loaded to, so that the openapi document can be
+// generated from the routes of them.
+func registerWebService(ws *restful.WebService) {
+	webServicesLock.Lock()
+	defer webServicesLock.Unlock()
+
+	for _, one := range webServices {
+		if one == ws {
+			return
+		}
+	}
+
+	webServices = append(webServices, ws)
+}
+
+// WebServices returns the web services which the handlers are loaded to.
+func WebServices() []*restful.WebService {
+	webServicesLock.Lock()
+	defer webServicesLock.Unlock()
+
+	return append(make([]*restful.WebService, 0, len(webServices)), webServices...)
+}
+
 func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp *restful.Response) {
 	return func(req *restful.Request, resp *restful.Response) {
 		cts := new(Contexts)
@@ -171,8 +239,15 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 			logs.Infof("%s received restful request, body: %s, rid: %s", action.Alias, compactBody, kt.Rid)
 		}
 
+		handler := action.Handler
+		if key := req.Request.Header.Get(constant.IdempotencyKey); len(key) != 0 && idempotencyStore != nil &&
+			action.Verb == http.MethodPost {
+
+			handler = idempotentHandler(idempotencyStore, key, action.Handler)
+		}
+
 		start := time.Now()
-		reply, err := action.Handler(cts)
+		reply, err := handler(cts)
 		if err != nil {
 			if logs.V(2) {
 				logs.Errorf("do restful request %s failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
@@ -0,0 +1,210 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// maxIdempotencyKeyLength is the max length of idempotency key.
+	maxIdempotencyKeyLength = 128
+	// completeRetryTimes is the max times to record the response of the succeeded request.
+	completeRetryTimes = 3
+	// completeRetryInterval is the base interval between the retries of recording the response.
+	completeRetryInterval = 100 * time.Millisecond
+)
+
+// IdempotencyStore stores the records of the requests with idempotency key.
+type IdempotencyStore interface {
+	// Acquire try to acquire the idempotency key for the request, returns nil if the key is acquired, otherwise
+	// returns the record of the request which holds the key.
+	Acquire(kt *kit.Kit, key *IdempotencyKey) (*IdempotencyRecord, error)
+	// Complete record the response of the request which holds the idempotency key.
+	Complete(kt *kit.Kit, key *IdempotencyKey, response []byte) error
+	// Release release the idempotency key held by the failed request, so that the request can be retried with
+	// the same idempotency key.
+	Release(kt *kit.Kit, key *IdempotencyKey) error
+}
+
+// IdempotencyKey is the idempotency key of a request.
+type IdempotencyKey struct {
+	// Scope is the scope of the idempotency key, the same key of different services, app codes or users are
+	// different keys.
+	Scope string
+	Key   string
+	// RequestHash is the hash of the request method, uri and body, the same key can not be used by requests with
+	// different hashes.
+	RequestHash string
+}
+
+// IdempotencyRecord is the record of the request which holds the idempotency key.
+type IdempotencyRecord struct {
+	RequestHash string
+	State       enumor.IdempotencyState
+	// Response is the json encoded response data of the completed request.
+	Response []byte
+}
+
+var idempotencyStore IdempotencyStore
+
+// SetIdempotencyStore set the idempotency store, after that the POST requests with the Idempotency-Key header are
+// executed only once, and the response of the first request is returned to the replayed requests. It should be
+// called before the service is started.
+func SetIdempotencyStore(store IdempotencyStore) {
+	idempotencyStore = store
+}
+
+// idempotentHandler wrap the handler to execute the request with the idempotency key only once. only the response
+// of the succeeded request is recorded, the failed request releases the key so that it can be retried.
+func idempotentHandler(store IdempotencyStore, key string, handler func(cts *Contexts) (interface{}, error)) func(
+	cts *Contexts) (interface{}, error) {
+
+	return func(cts *Contexts) (interface{}, error) {
+		idemKey, err := newIdempotencyKey(cts, key)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := store.Acquire(cts.Kit, idemKey)
+		if err != nil {
+			logs.Errorf("acquire idempotency key %s failed, err: %v, rid: %s", key, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if record != nil {
+			return replayIdempotentRequest(cts, idemKey, record)
+		}
+
+		reply, err := handler(cts)
+		if err != nil {
+			releaseIdempotencyKey(cts.Kit, store, idemKey)
+			return reply, err
+		}
+
+		if _, ok := reply.(FileDownloadResp); ok {
+			releaseIdempotencyKey(cts.Kit, store, idemKey)
+			return reply, nil
+		}
+
+		response, err := json.Marshal(reply)
+		if err != nil {
+			logs.Errorf("marshal response of idempotency key %s failed, err: %v, rid: %s", key, err, cts.Kit.Rid)
+			releaseIdempotencyKey(cts.Kit, store, idemKey)
+			return reply, nil
+		}
+
+		completeIdempotencyKey(cts.Kit, store, idemKey, response)
+		return reply, nil
+	}
+}
+
+// completeIdempotencyKey record the response of the succeeded request with retry. if it is still failed, the record
+// stays processing and is marked as unknown after the lock timeout, it is never taken over before expired, so the
+// request will not be executed again.
+func completeIdempotencyKey(kt *kit.Kit, store IdempotencyStore, key *IdempotencyKey, response []byte) {
+	var err error
+	for i := 0; i < completeRetryTimes; i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(i) * completeRetryInterval)
+		}
+
+		if err = store.Complete(kt, key, response); err == nil {
+			return
+		}
+	}
+
+	logs.Errorf("complete idempotency key %s failed, retry times: %d, err: %v, rid: %s", key.Key,
+		completeRetryTimes, err, kt.Rid)
+}
+
+func newIdempotencyKey(cts *Contexts, key string) (*IdempotencyKey, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, errf.Newf(errf.InvalidParameter, "%s length should <= %d", constant.IdempotencyKey,
+			maxIdempotencyKeyLength)
+	}
+
+	for _, c := range key {
+		if c < 0x21 || c > 0x7e {
+			return nil, errf.Newf(errf.InvalidParameter, "%s should only contain visible ascii characters",
+				constant.IdempotencyKey)
+		}
+	}
+
+	body, err := cts.RequestBody()
+	if err != nil {
+		logs.Errorf("read request body failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(cts.Request.Request.Method + " " + cts.Request.Request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return &IdempotencyKey{
+		Scope:       fmt.Sprintf("%s/%s/%s", cc.ServiceName(), cts.Kit.AppCode, cts.Kit.User),
+		Key:         key,
+		RequestHash: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func replayIdempotentRequest(cts *Contexts, key *IdempotencyKey, record *IdempotencyRecord) (interface{}, error) {
+	if record.RequestHash != key.RequestHash {
+		return nil, errf.Newf(errf.IdempotencyKeyConflict, "%s %s is already used by a different request",
+			constant.IdempotencyKey, key.Key)
+	}
+
+	switch record.State {
+	case enumor.IdempotencyCompleted:
+	case enumor.IdempotencyUnknown:
+		return nil, errf.Newf(errf.IdempotencyKeyConflict, "result of request with %s %s is unknown, please check "+
+			"the resources before retrying with a new key", constant.IdempotencyKey, key.Key)
+	default:
+		return nil, errf.Newf(errf.IdempotencyKeyConflict, "request with %s %s is processing",
+			constant.IdempotencyKey, key.Key)
+	}
+
+	logs.Infof("replay the response of request with idempotency key %s, rid: %s", key.Key, cts.Kit.Rid)
+
+	cts.resp.Header().Set(constant.IdempotentReplayedKey, "true")
+	if len(record.Response) == 0 {
+		return nil, nil
+	}
+
+	return json.RawMessage(record.Response), nil
+}
+
+func releaseIdempotencyKey(kt *kit.Kit, store IdempotencyStore, key *IdempotencyKey) {
+	// the key can be acquired again after the record expired if it is failed to be released.
+	if err := store.Release(kt, key); err != nil {
+		logs.Errorf("release idempotency key %s failed, err: %v, rid: %s", key.Key, err, kt.Rid)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency implements the idempotency store of rest handler with the idempotency records of data-service,
+// so that the idempotency keys are shared by all the instances of the service.
+package idempotency
+
+import (
+	dsidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/cc"
+	"hcm/pkg/client/data-service/global"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewStore create idempotency store with data-service idempotency record client.
+func NewStore(conf cc.Idempotency, cli *global.IdempotencyClient) rest.IdempotencyStore {
+	return &store{
+		conf: conf,
+		cli:  cli,
+	}
+}
+
+type store struct {
+	conf cc.Idempotency
+	cli  *global.IdempotencyClient
+}
+
+// Acquire try to acquire the idempotency key for the request.
+func (s *store) Acquire(kt *kit.Kit, key *rest.IdempotencyKey) (*rest.IdempotencyRecord, error) {
+	req := &dsidem.AcquireReq{
+		Scope:          key.Scope,
+		Key:            key.Key,
+		RequestHash:    key.RequestHash,
+		TTLSec:         s.conf.TTLSec,
+		LockTimeoutSec: s.conf.LockTimeoutSec,
+	}
+	result, err := s.cli.Acquire(kt, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Acquired {
+		return nil, nil
+	}
+
+	if result.Record == nil {
+		return nil, errf.Newf(errf.Aborted, "idempotency key %s is not acquired, but record is empty", key.Key)
+	}
+
+	return &rest.IdempotencyRecord{
+		RequestHash: result.Record.RequestHash,
+		State:       result.Record.State,
+		Response:    []byte(result.Record.Response),
+	}, nil
+}
+
+// Complete record the response of the request which holds the idempotency key.
+func (s *store) Complete(kt *kit.Kit, key *rest.IdempotencyKey, response []byte) error {
+	req := &dsidem.CompleteReq{
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		Response:    string(response),
+	}
+	return s.cli.Complete(kt, req)
+}
+
+// Release release the idempotency key held by the failed request.
+func (s *store) Release(kt *kit.Kit, key *rest.IdempotencyKey) error {
+	req := &dsidem.ReleaseReq{
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+	}
+	return s.cli.Release(kt, req)
+}
@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+type memIdempotencyStore struct {
+	records map[string]*IdempotencyRecord
+	// completeFails is the times that Complete is failed before succeeded.
+	completeFails int
+}
+
+func (m *memIdempotencyStore) Acquire(_ *kit.Kit, key *IdempotencyKey) (*IdempotencyRecord, error) {
+	if record, exists := m.records[key.Scope+key.Key]; exists {
+		return record, nil
+	}
+
+	m.records[key.Scope+key.Key] = &IdempotencyRecord{RequestHash: key.RequestHash,
+		State: enumor.IdempotencyProcessing}
+	return nil, nil
+}
+
+func (m *memIdempotencyStore) Complete(_ *kit.Kit, key *IdempotencyKey, response []byte) error {
+	if m.completeFails > 0 {
+		m.completeFails--
+		return errors.New("complete failed")
+	}
+
+	record := m.records[key.Scope+key.Key]
+	record.State, record.Response = enumor.IdempotencyCompleted, response
+	return nil
+}
+
+func (m *memIdempotencyStore) Release(_ *kit.Kit, key *IdempotencyKey) error {
+	delete(m.records, key.Scope+key.Key)
+	return nil
+}
+
+func newTestContexts(body string) *Contexts {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cloud/cvms/create", bytes.NewBufferString(body))
+	return &Contexts{
+		Kit:     &kit.Kit{User: "admin", AppCode: "test", Rid: "rid"},
+		Request: restful.NewRequest(req),
+		resp:    restful.NewResponse(httptest.NewRecorder()),
+	}
+}
+
+func TestIdempotentHandler(t *testing.T) {
+	store := &memIdempotencyStore{records: make(map[string]*IdempotencyRecord)}
+	calls := 0
+	handler := func(cts *Contexts) (interface{}, error) {
+		calls++
+		return map[string]string{"id": "00000001"}, nil
+	}
+
+	reply, err := idempotentHandler(store, "key-1", handler)(newTestContexts(`{"name":"a"}`))
+	if err != nil || calls != 1 {
+		t.Fatalf("first request failed, err: %v, calls: %d", err, calls)
+	}
+
+	cts := newTestContexts(`{"name":"a"}`)
+	replayed, err := idempotentHandler(store, "key-1", handler)(cts)
+	if err != nil || calls != 1 {
+		t.Fatalf("replayed request should not be executed, err: %v, calls: %d", err, calls)
+	}
+
+	expected, _ := json.Marshal(reply)
+	if raw, ok := replayed.(json.RawMessage); !ok || string(raw) != string(expected) {
+		t.Fatalf("replayed response %v is not equal to %s", replayed, expected)
+	}
+
+	if cts.resp.Header().Get(constant.IdempotentReplayedKey) != "true" {
+		t.Fatalf("replayed response should be marked")
+	}
+
+	_, err = idempotentHandler(store, "key-1", handler)(newTestContexts(`{"name":"b"}`))
+	if errf.Error(err).Code != errf.IdempotencyKeyConflict {
+		t.Fatalf("reuse key with different body should be conflict, err: %v", err)
+	}
+}
+
+func TestIdempotentHandlerFailed(t *testing.T) {
+	store := &memIdempotencyStore{records: make(map[string]*IdempotencyRecord)}
+	calls := 0
+	handler := func(cts *Contexts) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("create failed")
+		}
+		return nil, nil
+	}
+
+	if _, err := idempotentHandler(store, "key-1", handler)(newTestContexts(`{}`)); err == nil {
+		t.Fatalf("first request should be failed")
+	}
+
+	if _, err := idempotentHandler(store, "key-1", handler)(newTestContexts(`{}`)); err != nil || calls != 2 {
+		t.Fatalf("failed request should be retried, err: %v, calls: %d", err, calls)
+	}
+
+	if _, err := idempotentHandler(store, "key 1", handler)(newTestContexts(`{}`)); err == nil {
+		t.Fatalf("key with space should be invalid")
+	}
+}
+
+func TestIdempotentHandlerCompleteFailed(t *testing.T) {
+	store := &memIdempotencyStore{records: make(map[string]*IdempotencyRecord), completeFails: 1}
+	calls := 0
+	handler := func(cts *Contexts) (interface{}, error) {
+		calls++
+		return map[string]string{"id": "00000001"}, nil
+	}
+
+	if _, err := idempotentHandler(store, "key-1", handler)(newTestContexts(`{}`)); err != nil {
+		t.Fatalf("first request failed, err: %v", err)
+	}
+
+	for _, record := range store.records {
+		if record.State != enumor.IdempotencyCompleted {
+			t.Fatalf("response should be recorded after retry")
+		}
+	}
+
+	store.completeFails = completeRetryTimes
+	if _, err := idempotentHandler(store, "key-2", handler)(newTestContexts(`{}`)); err != nil {
+		t.Fatalf("request should be succeeded even if complete failed, err: %v", err)
+	}
+
+	_, err := idempotentHandler(store, "key-2", handler)(newTestContexts(`{}`))
+	if errf.Error(err).Code != errf.IdempotencyKeyConflict || calls != 2 {
+		t.Fatalf("uncompleted request should not be executed again, err: %v, calls: %d", err, calls)
+	}
+
+	for _, record := range store.records {
+		if record.State == enumor.IdempotencyProcessing {
+			record.State = enumor.IdempotencyUnknown
+		}
+	}
+
+	_, err = idempotentHandler(store, "key-2", handler)(newTestContexts(`{}`))
+	if errf.Error(err).Code != errf.IdempotencyKeyConflict || calls != 2 {
+		t.Fatalf("request with unknown result should not be executed again, err: %v, calls: %d", err, calls)
+	}
+}
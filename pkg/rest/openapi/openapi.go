@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openapi generates the openapi 3 document from the routes registered by rest.Handler, the request and
+// response schemas are generated from the samples set by rest.RouteDoc, including the constraints of validate tags.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/version"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Version is the openapi specification version of the generated document.
+const Version = "3.0.3"
+
+// Document is the openapi document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info is the metadata of the api.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem describes the operations of a path, key is the lower case http method.
+type PathItem map[string]*Operation
+
+// Operation describes an api operation.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a path parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the request body of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes the response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes the content of a media type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+const mimeJSON = "application/json"
+
+// Generate generates the openapi document of the routes registered to the web services.
+func Generate(title string, webServices []*restful.WebService) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version.VERSION},
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+
+	gen := newSchemaGenerator(doc.Components.Schemas)
+	for _, ws := range webServices {
+		for _, route := range ws.Routes() {
+			path, params := convPath(route.Path)
+			if _, exists := doc.Paths[path]; !exists {
+				doc.Paths[path] = make(PathItem)
+			}
+
+			doc.Paths[path][strings.ToLower(route.Method)] = genOperation(gen, route, params)
+		}
+	}
+
+	return doc
+}
+
+// ServiceDocument generates the openapi document of the current service.
+func ServiceDocument() *Document {
+	return Generate(fmt.Sprintf("BlueKing HCM %s", cc.ServiceName()), rest.WebServices())
+}
+
+// Handler returns the http handler which serves the openapi document of the current service.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", mimeJSON)
+		if err := json.NewEncoder(w).Encode(ServiceDocument()); err != nil {
+			logs.Errorf("write openapi document failed, err: %v", err)
+		}
+	})
+}
+
+func genOperation(gen *schemaGenerator, route restful.Route, params []string) *Operation {
+	op := &Operation{
+		OperationID: route.Operation,
+		Summary:     route.Doc,
+		Tags:        genTags(route.Path),
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, param := range params {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     param,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	switch route.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		body := &Schema{Type: "object"}
+		if route.ReadSample != nil {
+			body = gen.schemaOf(route.ReadSample)
+		}
+
+		op.RequestBody = &RequestBody{
+			// delete request may have no body
+			Required: route.Method != http.MethodDelete,
+			Content:  map[string]MediaType{mimeJSON: {Schema: body}},
+		}
+	}
+
+	data := new(Schema)
+	if route.WriteSample != nil {
+		data = gen.schemaOf(route.WriteSample)
+	}
+
+	op.Responses["200"] = &Response{
+		Description: "code is 0 if the request is succeeded, otherwise the message describes the error",
+		Content: map[string]MediaType{mimeJSON: {Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"code":    {Type: "integer", Format: "int32"},
+				"message": {Type: "string"},
+				"data":    data,
+			},
+			Required: []string{"code", "message"},
+		}}},
+	}
+
+	return op
+}
+
+var pathParamRegex = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// convPath converts go-restful path to openapi path, the regex of path parameters are removed.
+func convPath(path string) (string, []string) {
+	params := make([]string, 0)
+	converted := pathParamRegex.ReplaceAllStringFunc(path, func(part string) string {
+		name := strings.TrimSpace(pathParamRegex.FindStringSubmatch(part)[1])
+		params = append(params, name)
+		return "{" + name + "}"
+	})
+
+	return converted, params
+}
+
+// genTags use the first resource segment of path as the tag, e.g. /api/v1/cloud/bizs/{bk_biz_id}/vpcs/list is
+// tagged as vpcs.
+func genTags(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// skip the /api/{version}/{service} prefix
+	if len(segments) > 3 && segments[0] == "api" {
+		segments = segments[3:]
+	}
+
+	for idx := 0; idx < len(segments); idx++ {
+		switch {
+		case segments[idx] == "vendors" || segments[idx] == "bizs":
+			// skip the vendor or biz id after it
+			idx++
+		case len(segments[idx]) == 0 || strings.HasPrefix(segments[idx], "{"):
+		default:
+			return []string{segments[idx]}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+type testBase struct {
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+type testCreateReq struct {
+	testBase `json:",inline"`
+	Name     string          `json:"name" validate:"required,min=1,max=64"`
+	Vendor   string          `json:"vendor" validate:"required,oneof=tcloud aws"`
+	IDs      []string        `json:"ids" validate:"required,min=1,max=100,dive,len=8"`
+	Children []testCreateReq `json:"children,omitempty"`
+	Ignored  string          `json:"-"`
+}
+
+type testCreateResult struct {
+	ID string `json:"id"`
+}
+
+func TestGenerate(t *testing.T) {
+	noop := func(cts *rest.Contexts) (interface{}, error) { return nil, nil }
+
+	h := rest.NewHandler()
+	h.Add("CreateTest", http.MethodPost, "/tests/create", noop).Reads(new(testCreateReq)).
+		Writes(new(testCreateResult))
+	h.Add("GetTest", http.MethodGet, "/bizs/{bk_biz_id}/tests/{id:[0-9]+}", noop)
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1/cloud")
+	h.Load(ws)
+
+	doc := Generate("test", []*restful.WebService{ws})
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("marshal document failed, err: %v", err)
+	}
+
+	get := doc.Paths["/api/v1/cloud/bizs/{bk_biz_id}/tests/{id}"]["get"]
+	if get == nil || len(get.Parameters) != 2 || get.Parameters[1].Name != "id" || get.RequestBody != nil {
+		t.Fatalf("get operation is invalid: %+v", get)
+	}
+
+	create := doc.Paths["/api/v1/cloud/tests/create"]["post"]
+	if create == nil || create.OperationID != "CreateTest" || !reflect.DeepEqual(create.Tags, []string{"tests"}) {
+		t.Fatalf("create operation is invalid: %+v", create)
+	}
+
+	ref := create.RequestBody.Content[mimeJSON].Schema.Ref
+	req := doc.Components.Schemas["openapi.testCreateReq"]
+	if ref != "#/components/schemas/openapi.testCreateReq" || req == nil {
+		t.Fatalf("request schema is not referenced, ref: %s", ref)
+	}
+
+	if !reflect.DeepEqual(req.Required, []string{"name", "vendor", "ids"}) {
+		t.Fatalf("required fields %v are invalid", req.Required)
+	}
+
+	if _, exists := req.Properties["memo"]; !exists {
+		t.Fatalf("fields of embedded struct should be inlined")
+	}
+
+	if _, exists := req.Properties["Ignored"]; exists {
+		t.Fatalf("ignored field should not be generated")
+	}
+
+	if name := req.Properties["name"]; *name.MinLength != 1 || *name.MaxLength != 64 {
+		t.Fatalf("name length constraints are invalid: %+v", name)
+	}
+
+	if vendor := req.Properties["vendor"]; !reflect.DeepEqual(vendor.Enum, []interface{}{"tcloud", "aws"}) {
+		t.Fatalf("vendor enum is invalid: %+v", vendor)
+	}
+
+	ids := req.Properties["ids"]
+	if *ids.MinItems != 1 || *ids.MaxItems != 100 || *ids.Items.MinLength != 8 || *ids.Items.MaxLength != 8 {
+		t.Fatalf("ids constraints are invalid: %+v", ids)
+	}
+
+	if req.Properties["children"].Items.Ref != ref {
+		t.Fatalf("recursive type should refer to itself")
+	}
+
+	data := create.Responses["200"].Content[mimeJSON].Schema.Properties["data"]
+	if data.Ref != "#/components/schemas/openapi.testCreateResult" {
+		t.Fatalf("response data schema is invalid: %+v", data)
+	}
+}
@@ -0,0 +1,332 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the schema object of openapi, which is a subset of json schema.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinLength            *uint64            `json:"minLength,omitempty"`
+	MaxLength            *uint64            `json:"maxLength,omitempty"`
+	MinItems             *uint64            `json:"minItems,omitempty"`
+	MaxItems             *uint64            `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	// pkgPathRegex matches the package path of the type arguments in the name of generic types.
+	pkgPathRegex = regexp.MustCompile(`[\w.\-]+/`)
+	// invalidNameRegex matches the characters which are not allowed in the component name.
+	invalidNameRegex = regexp.MustCompile(`[^A-Za-z0-9._\-]+`)
+)
+
+// schemaGenerator generates schemas of go types, the named struct types are generated as reusable components.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator(schemas map[string]*Schema) *schemaGenerator {
+	return &schemaGenerator{
+		schemas: schemas,
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (g *schemaGenerator) schemaOf(sample interface{}) *Schema {
+	return g.typeSchema(reflect.TypeOf(sample))
+}
+
+func (g *schemaGenerator) typeSchema(typ reflect.Type) *Schema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return new(Schema)
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Minimum: new(float64)}
+	case reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: new(float64)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(typ.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(typ.Elem())}
+	case reflect.Struct:
+		if len(typ.Name()) == 0 {
+			return g.structSchema(typ)
+		}
+
+		name, exists := g.names[typ]
+		if !exists {
+			name = g.componentName(typ)
+			g.names[typ] = name
+			// set the component before generating its fields, so that the recursive types refer to it.
+			g.schemas[name] = new(Schema)
+			*g.schemas[name] = *g.structSchema(typ)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		// interface and other types can be any value.
+		return new(Schema)
+	}
+}
+
+// componentName returns the component name of the named type, e.g. core.ListResultT_cloud.Vpc_, the full package
+// path is used if the name is conflicted.
+func (g *schemaGenerator) componentName(typ reflect.Type) string {
+	pkg := typ.PkgPath()
+	if idx := strings.LastIndex(pkg, "/"); idx >= 0 {
+		pkg = pkg[idx+1:]
+	}
+
+	name := invalidNameRegex.ReplaceAllString(pkg+"."+pkgPathRegex.ReplaceAllString(typ.Name(), ""), "_")
+	if _, exists := g.schemas[name]; !exists {
+		return name
+	}
+
+	return invalidNameRegex.ReplaceAllString(typ.PkgPath()+"."+typ.Name(), "_")
+}
+
+func (g *schemaGenerator) structSchema(typ reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, typ)
+	return schema
+}
+
+func (g *schemaGenerator) addFields(schema *Schema, typ reflect.Type) {
+	for idx := 0; idx < typ.NumField(); idx++ {
+		field := typ.Field(idx)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		// the fields of embedded struct without json name are inlined.
+		if field.Anonymous && len(name) == 0 {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(schema, embedded)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		prop := g.typeSchema(field.Type)
+		if applyValidateTag(prop, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+}
+
+// jsonName returns the json name of the field, skip is true if the field is ignored by json.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// applyValidateTag apply the constraints of validate tag to the schema, returns whether the field is required.
+// the rules after dive are applied to the items of array or the values of map.
+func applyValidateTag(schema *Schema, tag string) bool {
+	if len(tag) == 0 || tag == "-" {
+		return false
+	}
+
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if key == "dive" {
+			switch {
+			case target.Items != nil:
+				target = target.Items
+			case target.AdditionalProperties != nil:
+				target = target.AdditionalProperties
+			default:
+				return required
+			}
+			continue
+		}
+
+		// constraints can not be set to the referenced schema.
+		if len(target.Ref) != 0 {
+			if key == "required" && target == schema {
+				required = true
+			}
+			continue
+		}
+
+		switch key {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "min", "gte":
+			setMin(target, value, false)
+		case "gt":
+			setMin(target, value, true)
+		case "max", "lte":
+			setMax(target, value, false)
+		case "lt":
+			setMax(target, value, true)
+		case "len":
+			setMin(target, value, false)
+			setMax(target, value, false)
+		case "oneof":
+			for _, one := range strings.Fields(value) {
+				target.Enum = append(target.Enum, enumValue(target, one))
+			}
+		case "eq":
+			target.Enum = []interface{}{enumValue(target, value)}
+		case "unique":
+			target.UniqueItems = true
+		case "ip":
+			target.Format = "ip"
+		case "ipv4", "ipv6":
+			target.Format = key
+		case "cidr", "cidrv4", "cidrv6":
+			target.Format = key
+		case "lowercase":
+			target.Pattern = "^[^A-Z]*$"
+		}
+	}
+
+	return required
+}
+
+func setMin(schema *Schema, value string, exclusive bool) {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		schema.MinLength = lengthOf(num, exclusive)
+	case "array":
+		schema.MinItems = lengthOf(num, exclusive)
+	case "integer", "number":
+		schema.Minimum = &num
+		schema.ExclusiveMinimum = exclusive
+	}
+}
+
+func setMax(schema *Schema, value string, exclusive bool) {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	if exclusive && schema.Type != "integer" && schema.Type != "number" {
+		num--
+	}
+
+	switch schema.Type {
+	case "string":
+		schema.MaxLength = lengthOf(num, false)
+	case "array":
+		schema.MaxItems = lengthOf(num, false)
+	case "integer", "number":
+		schema.Maximum = &num
+		schema.ExclusiveMaximum = exclusive
+	}
+}
+
+func lengthOf(num float64, exclusive bool) *uint64 {
+	if num < 0 {
+		num = 0
+	}
+
+	length := uint64(num)
+	if exclusive {
+		length++
+	}
+	return &length
+}
+
+func enumValue(schema *Schema, value string) interface{} {
+	if schema.Type == "integer" || schema.Type == "number" {
+		if num, err := strconv.ParseFloat(value, 64); err == nil {
+			return num
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,284 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ratelimit limits the rest requests with token buckets keyed by user, app code and tenant, the quotas are
+// configured by route groups.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+	"golang.org/x/time/rate"
+)
+
+// Subject is the caller of the request, the request is limited by each dimension of the subject.
+type Subject struct {
+	User     string
+	AppCode  string
+	TenantID string
+}
+
+// Limiter limits the requests with token buckets, each dimension value of each route group has its own bucket.
+type Limiter struct {
+	def    *group
+	groups []*group
+	// exemptApps are the app codes whose requests are not limited.
+	exemptApps map[string]struct{}
+	// sharedApps are the app codes shared by many users, the app dimension is not applied to them.
+	sharedApps map[string]struct{}
+
+	idleTimeout time.Duration
+	lock        sync.Mutex
+	buckets     map[string]*bucket
+}
+
+type group struct {
+	name    string
+	paths   []*regexp.Regexp
+	methods map[string]struct{}
+	rule    cc.RateLimitRule
+}
+
+// match returns whether the request matches the group.
+func (g *group) match(method, path string) bool {
+	if len(g.methods) != 0 {
+		if _, exists := g.methods[method]; !exists {
+			return false
+		}
+	}
+
+	for _, reg := range g.paths {
+		if reg.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// New create a rate limiter with the rate limit options, the idle buckets are cleaned in background.
+func New(conf cc.RateLimit) (*Limiter, error) {
+	l := &Limiter{
+		def:         &group{name: cc.DefaultRateLimitGroup, rule: conf.Default},
+		groups:      make([]*group, 0, len(conf.Groups)),
+		idleTimeout: time.Duration(conf.IdleTimeoutSec) * time.Second,
+		buckets:     make(map[string]*bucket),
+		exemptApps:  make(map[string]struct{}, len(conf.ExemptAppCodes)),
+		sharedApps:  make(map[string]struct{}, len(conf.SharedAppCodes)),
+	}
+
+	for _, app := range conf.ExemptAppCodes {
+		l.exemptApps[app] = struct{}{}
+	}
+
+	for _, app := range conf.SharedAppCodes {
+		l.sharedApps[app] = struct{}{}
+	}
+
+	for _, one := range conf.Groups {
+		g := &group{
+			name:    one.Name,
+			paths:   make([]*regexp.Regexp, 0, len(one.Paths)),
+			methods: make(map[string]struct{}, len(one.Methods)),
+			rule:    one.RateLimitRule,
+		}
+
+		for _, path := range one.Paths {
+			reg, err := regexp.Compile(path)
+			if err != nil {
+				return nil, errf.Newf(errf.InvalidParameter, "rate limit group %s path %s is invalid, err: %v",
+					one.Name, path, err)
+			}
+			g.paths = append(g.paths, reg)
+		}
+
+		for _, method := range one.Methods {
+			g.methods[strings.ToUpper(method)] = struct{}{}
+		}
+
+		l.groups = append(l.groups, g)
+	}
+
+	initMetric()
+	go l.cleanIdleBuckets()
+
+	return l, nil
+}
+
+// Allow reports whether the request of the subject is allowed, if not, it also returns the duration that the subject
+// should wait before retry. The request is allowed only if all the limited dimensions of the subject have tokens.
+// The requests of the exempt app codes are always allowed, and the app dimension is not applied to the shared app
+// codes, because the requests of all the users from web-server use the same app code.
+func (l *Limiter) Allow(method, path string, sub Subject) (bool, time.Duration) {
+	if _, exempt := l.exemptApps[sub.AppCode]; exempt && len(sub.AppCode) != 0 {
+		return true, 0
+	}
+
+	if _, shared := l.sharedApps[sub.AppCode]; shared {
+		sub.AppCode = ""
+	}
+
+	g := l.matchGroup(method, path)
+
+	dimensions := []struct {
+		name  string
+		value string
+		quota *cc.RateLimitQuota
+	}{
+		{name: "user", value: sub.User, quota: g.rule.User},
+		{name: "app", value: sub.AppCode, quota: g.rule.App},
+		{name: "tenant", value: sub.TenantID, quota: g.rule.Tenant},
+	}
+
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(dimensions))
+	for _, dim := range dimensions {
+		if dim.quota == nil || len(dim.value) == 0 {
+			continue
+		}
+
+		r := l.getBucket(g.name+"/"+dim.name+"/"+dim.value, dim.quota, now).ReserveN(now, 1)
+		if r.OK() && r.DelayFrom(now) == 0 {
+			reservations = append(reservations, r)
+			continue
+		}
+
+		delay := time.Second
+		if r.OK() {
+			delay = r.DelayFrom(now)
+			r.CancelAt(now)
+		}
+
+		// give back the tokens consumed by the other dimensions, the request is not executed.
+		for _, reserved := range reservations {
+			reserved.CancelAt(now)
+		}
+
+		limitMetric.requests.WithLabelValues(g.name, "rejected").Inc()
+		limitMetric.rejected.WithLabelValues(g.name, dim.name).Inc()
+		return false, delay
+	}
+
+	limitMetric.requests.WithLabelValues(g.name, "allowed").Inc()
+	return true, 0
+}
+
+// matchGroup returns the first group that matches the request, or the default group if none is matched.
+func (l *Limiter) matchGroup(method, path string) *group {
+	for _, g := range l.groups {
+		if g.match(method, path) {
+			return g
+		}
+	}
+
+	return l.def
+}
+
+func (l *Limiter) getBucket(key string, quota *cc.RateLimitQuota, now time.Time) *rate.Limiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(quota.QPS), int(quota.Burst))}
+		l.buckets[key] = b
+		limitMetric.buckets.Set(float64(len(l.buckets)))
+	}
+	b.lastUsed = now
+
+	return b.limiter
+}
+
+// cleanIdleBuckets removes the buckets that are not used for idle timeout, an idle bucket is full of tokens, so
+// removing it does not change the limit result.
+func (l *Limiter) cleanIdleBuckets() {
+	ticker := time.NewTicker(l.idleTimeout)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		l.lock.Lock()
+		for key, b := range l.buckets {
+			if now.Sub(b.lastUsed) >= l.idleTimeout {
+				delete(l.buckets, key)
+			}
+		}
+		limitMetric.buckets.Set(float64(len(l.buckets)))
+		l.lock.Unlock()
+	}
+}
+
+// Admit checks whether the request of the subject is allowed, if not, it writes the too many requests response with
+// Retry-After header and returns false.
+func (l *Limiter) Admit(w http.ResponseWriter, r *http.Request, sub Subject) bool {
+	allowed, delay := l.Allow(r.Method, r.URL.Path, sub)
+	if allowed {
+		return true
+	}
+
+	retryAfter := int(math.Ceil(delay.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	logs.Warnf("request is rate limited, uri: %s, method: %s, user: %s, app code: %s, tenant: %s, "+
+		"retry after: %ds, rid: %s", r.RequestURI, r.Method, sub.User, sub.AppCode, sub.TenantID, retryAfter,
+		r.Header.Get(constant.RidKey))
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.Header().Set("Content-Type", restful.MIME_JSON)
+	w.WriteHeader(http.StatusTooManyRequests)
+	rest.WriteResp(w, rest.NewBaseResp(errf.TooManyRequests,
+		"too many requests, please retry after "+strconv.Itoa(retryAfter)+" seconds"))
+
+	return false
+}
+
+// Filter returns the restful filter which limits the requests by the caller in the request header.
+func (l *Limiter) Filter() restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		sub := Subject{
+			User:     req.Request.Header.Get(constant.UserKey),
+			AppCode:  req.Request.Header.Get(constant.AppCodeKey),
+			TenantID: req.Request.Header.Get(constant.TenantIDKey),
+		}
+		if !l.Admit(resp.ResponseWriter, req.Request, sub) {
+			return
+		}
+
+		chain.ProcessFilter(req, resp)
+	}
+}
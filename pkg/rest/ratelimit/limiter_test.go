@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/cc"
+)
+
+func TestLimiter(t *testing.T) {
+	conf := cc.RateLimit{
+		Enable: true,
+		Default: cc.RateLimitRule{
+			User: &cc.RateLimitQuota{QPS: 0.001, Burst: 2},
+		},
+		Groups: []cc.RateLimitGroup{
+			{
+				Name:    "cvm_create",
+				Paths:   []string{"^/api/v1/cloud/cvms/create$"},
+				Methods: []string{"post"},
+				RateLimitRule: cc.RateLimitRule{
+					App: &cc.RateLimitQuota{QPS: 0.001, Burst: 1},
+				},
+			},
+		},
+		IdleTimeoutSec: 600,
+		ExemptAppCodes: []string{"hcm"},
+		SharedAppCodes: []string{"hcm-web-server"},
+	}
+	limiter, err := New(conf)
+	if err != nil {
+		t.Fatalf("new limiter failed, err: %v", err)
+	}
+
+	alice := Subject{User: "alice", AppCode: "app"}
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.Allow(http.MethodGet, "/api/v1/cloud/cvms/list", alice); !allowed {
+			t.Fatalf("request %d of default group should be allowed", i)
+		}
+	}
+
+	allowed, delay := limiter.Allow(http.MethodGet, "/api/v1/cloud/cvms/list", alice)
+	if allowed || delay <= 0 {
+		t.Fatalf("request exceeds user quota should be rejected with delay, allowed: %v, delay: %v", allowed, delay)
+	}
+
+	// the buckets of each user are independent.
+	if allowed, _ = limiter.Allow(http.MethodGet, "/api/v1/cloud/cvms/list", Subject{User: "bob"}); !allowed {
+		t.Fatalf("request of another user should be allowed")
+	}
+
+	// the group has its own buckets and only limits the configured dimension.
+	if allowed, _ = limiter.Allow(http.MethodPost, "/api/v1/cloud/cvms/create", alice); !allowed {
+		t.Fatalf("first request of cvm_create group should be allowed")
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cloud/cvms/create", nil)
+	if limiter.Admit(rec, req, Subject{User: "bob", AppCode: "app"}) {
+		t.Fatalf("request exceeds app quota should be rejected")
+	}
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("rejected request status code should be 429, but got %d", rec.Code)
+	}
+
+	if len(rec.Header().Get("Retry-After")) == 0 {
+		t.Errorf("rejected request should have Retry-After header")
+	}
+
+	// the requests of the exempt app are not limited.
+	for i := 0; i < 3; i++ {
+		if !limiter.Admit(httptest.NewRecorder(), req, Subject{User: "bob", AppCode: "hcm"}) {
+			t.Fatalf("request %d of exempt app should be allowed", i)
+		}
+	}
+
+	// the shared app is only limited by user dimension.
+	for _, user := range []string{"carol", "dave"} {
+		if !limiter.Admit(httptest.NewRecorder(), req, Subject{User: user, AppCode: "hcm-web-server"}) {
+			t.Fatalf("request of user %s from shared app should be allowed", user)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"sync"
+
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// limitMetric is used to collect rate limit metrics.
+var limitMetric *metric
+
+var metricOnce sync.Once
+
+func initMetric() {
+	metricOnce.Do(func() {
+		m := new(metric)
+
+		m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.RateLimitSubSys,
+			Name:      "requests_total",
+			Help:      "the total count of the requests checked by rate limiter, result is allowed or rejected",
+		}, []string{"group", "result"})
+		metrics.Register().MustRegister(m.requests)
+
+		m.rejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.RateLimitSubSys,
+			Name:      "rejected_total",
+			Help:      "the total count of the rejected requests by the dimension that exceeds the quota",
+		}, []string{"group", "dimension"})
+		metrics.Register().MustRegister(m.rejected)
+
+		m.buckets = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metrics.RateLimitSubSys,
+			Name:      "buckets",
+			Help:      "the number of the token buckets in use",
+		})
+		metrics.Register().MustRegister(m.buckets)
+
+		limitMetric = m
+	})
+}
+
+type metric struct {
+	// requests record the count of the checked requests.
+	requests *prometheus.CounterVec
+
+	// rejected record the count of the rejected requests by dimension.
+	rejected *prometheus.CounterVec
+
+	// buckets record the number of the token buckets.
+	buckets prometheus.Gauge
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// VerifyAuditChainFunc verify the audit hash chain of the tenant in kit from the start seq.
+type VerifyAuditChainFunc func(kt *kit.Kit, startSeq, limit uint64) (interface{}, error)
+
+// WithVerifyAuditChain init and returns the verify audit hash chain command.
+func WithVerifyAuditChain(verify VerifyAuditChainFunc) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "verify-audit-chain",
+			Usage: "verify the audit hash chain of the tenant and report the breaks",
+			Parameters: []Parameter{{
+				Name:  "tenant_id",
+				Usage: "defines the tenant whose audit hash chain to be verified",
+				Value: new(string),
+			}, {
+				Name:    "start_seq",
+				Usage:   "defines the seq to start verifying from, default is 1",
+				Value:   new(uint64),
+				Default: converter.ValToPtr(uint64(1)),
+			}, {
+				Name:    "limit",
+				Usage:   "defines the max count of audits to be verified, max is 100000, default is 10000",
+				Value:   new(uint64),
+				Default: converter.ValToPtr(uint64(10000)),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				if verify == nil {
+					return nil, errf.New(errf.Aborted, "verify audit chain function is not set")
+				}
+
+				if tenantID, exists := params["tenant_id"]; exists {
+					kt.TenantID = *tenantID.(*string)
+				}
+
+				return verify(kt, *params["start_seq"].(*uint64), *params["limit"].(*uint64))
+			},
+		},
+	}
+
+	return cmd
+}
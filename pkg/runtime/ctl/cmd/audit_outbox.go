@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// AuditOutboxFunc operate the audit streaming outbox.
+type AuditOutboxFunc func(kt *kit.Kit) (interface{}, error)
+
+// WithAuditOutbox init and returns the audit streaming outbox commands, including getting the stats of outbox and
+// retrying the audits which failed to be streamed.
+func WithAuditOutbox(stats, retry AuditOutboxFunc) []Cmd {
+	return []Cmd{
+		&defaultCmd{
+			cmd: &Command{
+				Name:    "audit-outbox-stats",
+				Usage:   "count the audits to be streamed to external SIEM group by state",
+				FromURL: true,
+				Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+					if stats == nil {
+						return nil, errf.New(errf.Aborted, "audit outbox stats function is not set")
+					}
+
+					return stats(kt)
+				},
+			},
+		},
+		&defaultCmd{
+			cmd: &Command{
+				Name:    "retry-failed-audit-outbox",
+				Usage:   "retry streaming the audits which exceed the max retry count",
+				FromURL: true,
+				Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+					if retry == nil {
+						return nil, errf.New(errf.Aborted, "retry audit outbox function is not set")
+					}
+
+					return retry(kt)
+				},
+			},
+		},
+	}
+}
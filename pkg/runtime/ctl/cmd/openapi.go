@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// WithOpenAPI init and returns the command which dumps the openapi document of the service.
+func WithOpenAPI(document func() interface{}) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:    "openapi",
+			Usage:   "dump the openapi 3 document generated from the registered routes",
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				if document == nil {
+					return nil, errf.New(errf.Aborted, "openapi document function is not set")
+				}
+
+				return document(), nil
+			},
+		},
+	}
+
+	return cmd
+}
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
+	"hcm/pkg/rest/openapi"
 	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/serviced"
 )
@@ -77,10 +78,17 @@ func LoadCtl(commands ...cmd.Cmd) error {
 	return nil
 }
 
-// WithBasics init and returns the basic commands(register & deregister & log) that all servers needed.
+// WithBasics init and returns the basic commands(register & deregister & log & openapi) that all servers needed.
 func WithBasics(sd serviced.Service) []cmd.Cmd {
 	return []cmd.Cmd{cmd.WithLog(), cmd.WithRegister(sd), cmd.WithDeregister(sd), cmd.WithEnableMasterSlave(sd),
-		cmd.WithDisableMasterSlave(sd)}
+		cmd.WithDisableMasterSlave(sd), WithOpenAPI()}
+}
+
+// WithOpenAPI init and returns the command which dumps the openapi document of the current service.
+func WithOpenAPI() cmd.Cmd {
+	return cmd.WithOpenAPI(func() interface{} {
+		return openapi.ServiceDocument()
+	})
 }
 
 func (b *Ctl) httpHandler(w http.ResponseWriter, req *http.Request) {
@@ -43,6 +43,8 @@ const (
 	DefaultMaxNotInLimit = uint(500)
 	// DefaultMaxRuleLimit defines the default max number of rules limit
 	DefaultMaxRuleLimit = uint(10)
+	// DefaultMaxDepth defines the default max depth of nested expressions, the top expression's depth is 1.
+	DefaultMaxDepth = uint(5)
 )
 
 // ExprOption defines how to validate an
@@ -66,6 +68,9 @@ type ExprOption struct {
 	// MaxRulesLimit defines the max number of rules an expression allows.
 	// If not set, then use default value: DefaultMaxRuleLimit
 	MaxRulesLimit uint
+	// MaxDepth defines the max depth of nested expressions an expression allows.
+	// If not set, then use default value: DefaultMaxDepth
+	MaxDepth uint
 }
 
 // ExprOptionFunc expr option func defines.
@@ -99,8 +104,15 @@ func MaxRulesLimit(limit uint) ExprOptionFunc {
 	}
 }
 
+// MaxDepth set max depth of nested expressions func.
+func MaxDepth(depth uint) ExprOptionFunc {
+	return func(opt *ExprOption) {
+		opt.MaxDepth = depth
+	}
+}
+
 // NewExprOption new expr option.
-// ExprOptionFunc: RuleFields、MaxInLimit、MaxNotInLimit、MaxRulesLimit
+// ExprOptionFunc: RuleFields、MaxInLimit、MaxNotInLimit、MaxRulesLimit、MaxDepth
 func NewExprOption(opts ...ExprOptionFunc) *ExprOption {
 	exprOpt := new(ExprOption)
 	for _, opt := range opts {
@@ -124,6 +136,11 @@ func (exp Expression) Validate(opt *ExprOption) (hitErr error) {
 		}
 	}()
 
+	return exp.validate(opt, 1)
+}
+
+// validate the expression with its depth in the nested expressions.
+func (exp Expression) validate(opt *ExprOption, depth uint) error {
 	if exp.IsEmpty() {
 		return nil
 	}
@@ -137,10 +154,23 @@ func (exp Expression) Validate(opt *ExprOption) (hitErr error) {
 	}
 
 	maxRules := DefaultMaxRuleLimit
+	maxDepth := DefaultMaxDepth
 	if opt != nil {
 		if opt.MaxRulesLimit > 0 {
 			maxRules = opt.MaxRulesLimit
 		}
+
+		if opt.MaxDepth > 0 {
+			maxDepth = opt.MaxDepth
+		}
+	}
+
+	if depth > maxDepth {
+		return fmt.Errorf("expression is nested too deep, it at most have %d levels", maxDepth)
+	}
+
+	if exp.Op == Not && len(exp.Rules) != 1 {
+		return errors.New("not expression should have exactly one rule")
 	}
 
 	if len(exp.Rules) > int(maxRules) {
@@ -180,6 +210,13 @@ func (exp Expression) Validate(opt *ExprOption) (hitErr error) {
 	}
 
 	for _, one := range exp.Rules {
+		if sub, ok := one.(*Expression); ok {
+			if err := sub.validate(valOpt, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := one.Validate(valOpt); err != nil {
 			return err
 		}
@@ -265,6 +302,11 @@ func (exp *Expression) SQLWhereExpr(opt *SQLWhereOption) (where string, value ma
 		return "", nil, err
 	}
 
+	if exp.Op == Not && len(exp.Rules) != 0 {
+		// regard the not expression as a sub expression, so that it can be crowned like others.
+		exp = &Expression{Op: And, Rules: []RuleFactory{exp}}
+	}
+
 	if opt.CrownedOption == nil || (opt.CrownedOption != nil && len(opt.CrownedOption.Rules) == 0) {
 		if len(exp.Rules) == 0 {
 			return "", nil, nil
@@ -326,6 +368,16 @@ func (exp *Expression) SQLWhereExpr(opt *SQLWhereOption) (where string, value ma
 
 // UnmarshalJSON unmarshal a json raw to this expression
 func (exp *Expression) UnmarshalJSON(raw []byte) error {
+	// the expression can also be a compact string query, compile it to expression.
+	if query := gjson.ParseBytes(raw); query.Type == gjson.String {
+		parsed, err := Parse(query.String())
+		if err != nil {
+			return err
+		}
+		*exp = *parsed
+		return nil
+	}
+
 	parsed := gjson.GetManyBytes(raw, "op", "rules")
 	op := LogicOperator(parsed[0].String())
 	rules := parsed[1]
@@ -45,6 +45,7 @@ func init() {
 	opFactory[NotEqual.Factory()] = NotEqualOp(NotEqual)
 
 	opFactory[IDGreaterThan.Factory()] = IDGreaterThanOp(IDGreaterThan)
+	opFactory[IDLessThan.Factory()] = IDLessThanOp(IDLessThan)
 	opFactory[GreaterThan.Factory()] = GreaterThanOp(GreaterThan)
 	opFactory[GreaterThanEqual.Factory()] = GreaterThanEqualOp(GreaterThanEqual)
 
@@ -73,6 +74,9 @@ const (
 	And LogicOperator = "and"
 	// Or logic operator
 	Or LogicOperator = "or"
+	// Not logic operator, the expression with not operator should have exactly one rule,
+	// which can be an atom rule or a sub expression.
+	Not LogicOperator = "not"
 	// SqlPlaceholder is sql placeholder.
 	SqlPlaceholder = ":"
 	// JSONFieldSeparator is the separator of json field
@@ -89,6 +93,7 @@ func (lo LogicOperator) Validate() error {
 	switch lo {
 	case And:
 	case Or:
+	case Not:
 	default:
 		return fmt.Errorf("unsupported expression's logic operator: %s", lo)
 	}
@@ -125,6 +130,8 @@ const (
 	NotEqual OpType = "neq"
 	// IDGreaterThan is the id field greater than the given value.
 	IDGreaterThan OpType = "id_gt"
+	// IDLessThan is the id field less than the given value.
+	IDLessThan OpType = "id_lt"
 	// GreaterThan operator
 	GreaterThan OpType = "gt"
 	// GreaterThanEqual operator
@@ -182,7 +189,7 @@ func (op OpType) Validate() error {
 	case JSONEqual, JSONNotEqual, JSONIn, JSONContains, JSONOverlaps,
 		JSONContainsPath, JSONNotContainsPath, JSONLength, JSONLengthGreaterThan:
 
-	case IDGreaterThan:
+	case IDGreaterThan, IDLessThan:
 
 	default:
 		return fmt.Errorf("unsupported operator: %s", op)
@@ -353,6 +360,31 @@ func (gt IDGreaterThanOp) SQLExprAndValue(field string, value interface{}) (stri
 		map[string]interface{}{placeholder: value}, nil
 }
 
+// IDLessThanOp is id less than operator
+type IDLessThanOp OpType
+
+// Name is id less than operator
+func (lt IDLessThanOp) Name() OpType {
+	return IDLessThan
+}
+
+// ValidateValue validate id less than value
+func (lt IDLessThanOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	return nil
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub
+// query expression.
+func (lt IDLessThanOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{}, error) {
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	placeholder := fieldPlaceholderName(field)
+	return fmt.Sprintf(`%s < %s%s`, field, SqlPlaceholder, placeholder),
+		map[string]interface{}{placeholder: value}, nil
+}
+
 // GreaterThanOp is greater than operator
 type GreaterThanOp OpType
 
@@ -0,0 +1,413 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxParseDepth is the max nested depth of parentheses and not operators when parsing a query,
+// it only protects the parser from too deep recursion, the depth of the parsed expression is
+// still limited by ExprOption.MaxDepth when it is validated.
+const maxParseDepth = 32
+
+// symbolOps is the operators can be written as symbols in query.
+var symbolOps = map[string]OpType{
+	"=":  Equal,
+	"!=": NotEqual,
+	">":  GreaterThan,
+	">=": GreaterThanEqual,
+	"<":  LessThan,
+	"<=": LessThanEqual,
+}
+
+// Parse compiles a compact string query into an Expression, the syntax is as follows:
+//
+//	query  = or
+//	or     = and { "or" and }
+//	and    = unary { "and" unary }
+//	unary  = "not" unary | "(" query ")" | rule
+//	rule   = field op value
+//	op     = "=" | "!=" | ">" | ">=" | "<" | "<=" | operator name like "in", "nin", "cs", "json_eq"...
+//	value  = string | number | "true" | "false" | "[" [ value { "," value } ] "]"
+//
+// keywords are case-insensitive, string is quoted by double or single quotes and supports the
+// backslash escape. for example:
+//
+//	not bk_biz_id = 3 and (region = "ap-guangzhou" or status in ["STOPPED", "STOPPING"])
+//
+// Note: the parsed expression should be validated before use, just like it is unmarshalled from json.
+func Parse(query string) (*Expression, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return &Expression{Op: And, Rules: make([]RuleFactory, 0)}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	rule, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, p.errorf("unexpected %s", p.peek().value)
+	}
+
+	if expr, ok := rule.(*Expression); ok {
+		return expr, nil
+	}
+
+	return &Expression{Op: And, Rules: []RuleFactory{rule}}, nil
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	stringToken
+	numberToken
+	symbolToken
+	punctToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(query string) ([]token, error) {
+	runes := []rune(query)
+	tokens := make([]token, 0)
+	for idx := 0; idx < len(runes); {
+		char := runes[idx]
+		switch {
+		case unicode.IsSpace(char):
+			idx++
+
+		case strings.ContainsRune("()[],", char):
+			tokens = append(tokens, token{kind: punctToken, value: string(char), pos: idx})
+			idx++
+
+		case strings.ContainsRune("=!<>", char):
+			start := idx
+			idx++
+			if idx < len(runes) && runes[idx] == '=' {
+				idx++
+			}
+			symbol := string(runes[start:idx])
+			if _, exists := symbolOps[symbol]; !exists {
+				return nil, fmt.Errorf("invalid query, unknown operator %s at %d", symbol, start)
+			}
+			tokens = append(tokens, token{kind: symbolToken, value: symbol, pos: start})
+
+		case char == '"' || char == '\'':
+			start := idx
+			value, end, err := scanString(runes, idx)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: stringToken, value: value, pos: start})
+			idx = end
+
+		case char == '-' || unicode.IsDigit(char):
+			start := idx
+			idx++
+			for idx < len(runes) && (unicode.IsDigit(runes[idx]) || strings.ContainsRune(".eE+-", runes[idx])) {
+				idx++
+			}
+			tokens = append(tokens, token{kind: numberToken, value: string(runes[start:idx]), pos: start})
+
+		case isIdentRune(char):
+			start := idx
+			for idx < len(runes) && (isIdentRune(runes[idx]) || unicode.IsDigit(runes[idx])) {
+				idx++
+			}
+			tokens = append(tokens, token{kind: identToken, value: string(runes[start:idx]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("invalid query, unexpected character %q at %d", char, idx)
+		}
+	}
+
+	return tokens, nil
+}
+
+// isIdentRune test if the rune can be part of a field or keyword, json field is separated by dot,
+// and '*' is the wildcard placeholder.
+func isIdentRune(char rune) bool {
+	return char == '_' || char == '.' || char == '*' || unicode.IsLetter(char)
+}
+
+// scanString scan a quoted string start from the quote, returns the unquoted value and the end position.
+func scanString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	builder := strings.Builder{}
+	for idx := start + 1; idx < len(runes); idx++ {
+		switch runes[idx] {
+		case '\\':
+			if idx+1 >= len(runes) {
+				return "", 0, fmt.Errorf("invalid query, unterminated string at %d", start)
+			}
+			idx++
+			builder.WriteRune(runes[idx])
+		case quote:
+			return builder.String(), idx + 1, nil
+		default:
+			builder.WriteRune(runes[idx])
+		}
+	}
+
+	return "", 0, fmt.Errorf("invalid query, unterminated string at %d", start)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) next() *token {
+	tk := p.peek()
+	if tk != nil {
+		p.pos++
+	}
+	return tk
+}
+
+// isKeyword test if the next token is the given keyword or punctuation.
+func (p *parser) isKeyword(keyword string) bool {
+	tk := p.peek()
+	if tk == nil {
+		return false
+	}
+
+	switch tk.kind {
+	case identToken:
+		return strings.EqualFold(tk.value, keyword)
+	case punctToken:
+		return tk.value == keyword
+	default:
+		return false
+	}
+}
+
+func (p *parser) expect(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return p.errorf("expect %s", keyword)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if tk := p.peek(); tk != nil {
+		return fmt.Errorf("invalid query at %d, %s", tk.pos, fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf("invalid query at end, %s", fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr(depth int) (RuleFactory, error) {
+	return p.parseLogic(depth, Or, p.parseAnd)
+}
+
+func (p *parser) parseAnd(depth int) (RuleFactory, error) {
+	return p.parseLogic(depth, And, p.parseUnary)
+}
+
+// parseLogic parse the rules joined by the same logic operator into one expression.
+func (p *parser) parseLogic(depth int, op LogicOperator, parseRule func(int) (RuleFactory, error)) (
+	RuleFactory, error) {
+
+	first, err := parseRule(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []RuleFactory{first}
+	for p.isKeyword(string(op)) {
+		p.pos++
+		rule, err := parseRule(depth)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 1 {
+		return first, nil
+	}
+
+	return &Expression{Op: op, Rules: rules}, nil
+}
+
+func (p *parser) parseUnary(depth int) (RuleFactory, error) {
+	if depth >= maxParseDepth {
+		return nil, p.errorf("query is nested too deep")
+	}
+
+	switch {
+	case p.isKeyword(string(Not)):
+		p.pos++
+		rule, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Expression{Op: Not, Rules: []RuleFactory{rule}}, nil
+
+	case p.isKeyword("("):
+		p.pos++
+		rule, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		// a parenthesized rule is still a sub expression, so that it is not flattened into its parent.
+		if _, ok := rule.(*Expression); !ok {
+			rule = &Expression{Op: And, Rules: []RuleFactory{rule}}
+		}
+		return rule, nil
+
+	default:
+		return p.parseAtom()
+	}
+}
+
+func (p *parser) parseAtom() (RuleFactory, error) {
+	field := p.next()
+	if field == nil || field.kind != identToken {
+		p.pos--
+		return nil, p.errorf("expect field")
+	}
+
+	opToken := p.next()
+	if opToken == nil {
+		return nil, p.errorf("expect operator")
+	}
+
+	var op OpType
+	switch opToken.kind {
+	case symbolToken:
+		op = symbolOps[opToken.value]
+	case identToken:
+		op = OpType(strings.ToLower(opToken.value))
+		if err := op.Validate(); err != nil {
+			p.pos--
+			return nil, p.errorf("%v", err)
+		}
+	default:
+		p.pos--
+		return nil, p.errorf("expect operator")
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isList := value.([]interface{}); !isList && (op == In || op == NotIn) {
+		// in and nin operator's value should be an array, keep the same with json.
+		return nil, fmt.Errorf("invalid query, %s operator's value of %s should be an array", op, field.value)
+	}
+
+	return &AtomRule{Field: field.value, Op: op.Factory(), Value: value}, nil
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	tk := p.next()
+	if tk == nil {
+		return nil, p.errorf("expect value")
+	}
+
+	switch tk.kind {
+	case stringToken:
+		return tk.value, nil
+
+	case numberToken:
+		// keep the same type with numbers unmarshalled from json.
+		number, err := strconv.ParseFloat(tk.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query at %d, invalid number %s", tk.pos, tk.value)
+		}
+		return number, nil
+
+	case identToken:
+		switch strings.ToLower(tk.value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+
+	case punctToken:
+		if tk.value == "[" {
+			return p.parseList()
+		}
+	}
+
+	p.pos--
+	return nil, p.errorf("expect value, but got %s", tk.value)
+}
+
+func (p *parser) parseList() (interface{}, error) {
+	list := make([]interface{}, 0)
+	if p.isKeyword("]") {
+		p.pos++
+		return list, nil
+	}
+
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, isList := value.([]interface{}); isList {
+			return nil, errors.New("invalid query, nested array is not supported")
+		}
+		list = append(list, value)
+
+		if p.isKeyword("]") {
+			p.pos++
+			return list, nil
+		}
+
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// placeholderRegexp matches the random suffix of sql placeholder.
+var placeholderRegexp = regexp.MustCompile(`(:[a-zA-Z0-9_]+?)_[a-zA-Z0-9]{4}\b`)
+
+func TestParse(t *testing.T) {
+	expr, err := Parse(`NOT bk_biz_id = 3 and (region = "ap-guangzhou" or status in ['STOPPED', "STOP\"PING"])`)
+	if err != nil {
+		t.Errorf("parse query failed, err: %v", err)
+		return
+	}
+
+	if expr.Op != And || len(expr.Rules) != 2 {
+		t.Errorf("parsed expression is not expected, op: %s, rules: %d", expr.Op, len(expr.Rules))
+		return
+	}
+
+	not, ok := expr.Rules[0].(*Expression)
+	if !ok || not.Op != Not || len(not.Rules) != 1 {
+		t.Errorf("parsed not expression is not expected, rule: %v", expr.Rules[0])
+		return
+	}
+
+	bizRule := not.Rules[0].(*AtomRule)
+	if bizRule.Field != "bk_biz_id" || bizRule.Op != Equal.Factory() || bizRule.Value != float64(3) {
+		t.Errorf("parsed atom rule is not expected, rule: %v", bizRule)
+		return
+	}
+
+	or, ok := expr.Rules[1].(*Expression)
+	if !ok || or.Op != Or || len(or.Rules) != 2 {
+		t.Errorf("parsed or expression is not expected, rule: %v", expr.Rules[1])
+		return
+	}
+
+	statusRule := or.Rules[1].(*AtomRule)
+	values, ok := statusRule.Value.([]interface{})
+	if statusRule.Op != In.Factory() || !ok || len(values) != 2 || values[1] != `STOP"PING` {
+		t.Errorf("parsed in rule is not expected, rule: %v", statusRule)
+		return
+	}
+
+	fields := RuleFields(map[string]enumor.ColumnType{
+		"bk_biz_id": enumor.Numeric,
+		"region":    enumor.String,
+		"status":    enumor.String,
+	})
+	if err = expr.Validate(NewExprOption(fields, MaxDepth(2))); err != nil {
+		t.Errorf("validate parsed expression failed, err: %v", err)
+		return
+	}
+
+	if err = expr.Validate(NewExprOption(fields, MaxDepth(1))); err == nil {
+		t.Errorf("validate parsed expression should be failed with max depth 1")
+		return
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	queries := []string{
+		`name =`,
+		`name == "a"`,
+		`name unknown "a"`,
+		`name = "a" and`,
+		`(name = "a"`,
+		`name = "a")`,
+		`status in "a"`,
+		`name = "a`,
+		`ids in [[1]]`,
+		`not`,
+	}
+
+	for _, query := range queries {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("parse invalid query %s should be failed", query)
+		}
+	}
+}
+
+func TestNotSQLWhereExpr(t *testing.T) {
+	filter := new(struct {
+		Filter *Expression `json:"filter"`
+	})
+	raw := `{"filter": "not (name = \"jim\" or age > 18) and not id_gt \"x\" = 1"}`
+	if err := json.Unmarshal([]byte(raw), filter); err == nil {
+		t.Errorf("unmarshal invalid query filter should be failed")
+		return
+	}
+
+	raw = `{"filter": "not (name = \"jim\" or age > 18) and not deleted = true"}`
+	if err := json.Unmarshal([]byte(raw), filter); err != nil {
+		t.Errorf("unmarshal query filter failed, err: %v", err)
+		return
+	}
+
+	where, _, err := filter.Filter.SQLWhereExpr(&SQLWhereOption{Priority: []string{"id"}})
+	if err != nil {
+		t.Errorf("generate SQL Where expression failed, err: %v", err)
+		return
+	}
+
+	where = placeholderRegexp.ReplaceAllString(where, "$1")
+	if where != "WHERE (NOT (name = :name OR age > :age)) AND (NOT (deleted = :deleted))" {
+		t.Errorf("generate SQL Not Where expression failed, sql: %v", where)
+		return
+	}
+
+	expr := &Expression{Op: Not, Rules: []RuleFactory{
+		&AtomRule{Field: "name", Op: Equal.Factory(), Value: "jim"},
+		&AtomRule{Field: "age", Op: Equal.Factory(), Value: 18},
+	}}
+	if err = expr.Validate(nil); err == nil {
+		t.Errorf("validate not expression with two rules should be failed")
+		return
+	}
+
+	expr.Rules = expr.Rules[:1]
+	where, _, err = expr.SQLWhereExpr(&SQLWhereOption{Priority: []string{"id"}})
+	if err != nil {
+		t.Errorf("generate SQL Where expression failed, err: %v", err)
+		return
+	}
+
+	where = placeholderRegexp.ReplaceAllString(where, "$1")
+	if where != "WHERE (NOT (name = :name))" {
+		t.Errorf("generate SQL Not Where expression failed, sql: %v", where)
+		return
+	}
+}
@@ -289,6 +289,13 @@ func genMixedSQLWhereExpr(opt *SQLWhereOption, op LogicOperator, rules []RuleFac
 	case Or:
 		return strings.Join(subExpr, " OR "), valueMap, nil
 
+	case Not:
+		notExpr, err := genNotSQLExpr(subExpr)
+		if err != nil {
+			return "", nil, err
+		}
+		return notExpr, valueMap, nil
+
 	default:
 		return "", nil, fmt.Errorf("unsupported expression's logic operator: %s", op)
 	}
@@ -335,11 +342,55 @@ func doSoloSQLWhereExpr(opt *SQLWhereOption, op LogicOperator, rules []RuleFacto
 	case Or:
 		return strings.Join(subExpr, " OR "), valueMap, nil
 
+	case Not:
+		notExpr, err := genNotSQLExpr(subExpr)
+		if err != nil {
+			return "", nil, err
+		}
+		return notExpr, valueMap, nil
+
 	default:
 		return "", nil, fmt.Errorf("unsupported expression's logic operator: %s", op)
 	}
 }
 
+// genNotSQLExpr negate the only one sub expression, sub expression generated by an expression is already
+// wrapped with parentheses, so only the atom rule's expression need to be wrapped.
+func genNotSQLExpr(subExpr []string) (string, error) {
+	if len(subExpr) != 1 {
+		return "", fmt.Errorf("not expression should have exactly one rule, but got %d", len(subExpr))
+	}
+
+	if isWrapped(subExpr[0]) {
+		return "NOT " + subExpr[0], nil
+	}
+
+	return fmt.Sprintf("NOT (%s)", subExpr[0]), nil
+}
+
+// isWrapped test if the whole expression is wrapped by a pair of parentheses.
+func isWrapped(expr string) bool {
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return false
+	}
+
+	depth := 0
+	for idx, char := range expr {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && idx != len(expr)-1 {
+				// the first parenthesis is closed before the end, e.g. (a) AND (b)
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
+
 // rearrangeSoloRulesWithPriority rearrange the query rules with priority, the lower the
 // index of the priority's array, the higher priority of the field during query.
 func rearrangeSoloRulesWithPriority(rules []RuleFactory, priority []string) []RuleFactory {
@@ -370,26 +370,34 @@ const (
 	GcpCloudVendor CloudVendor = "3"
 	// AzureCloudVendor cmdb azure vendor
 	AzureCloudVendor CloudVendor = "4"
+	// OpenStackCloudVendor cmdb enterprise private cloud vendor
+	OpenStackCloudVendor CloudVendor = "5"
+	// AliyunCloudVendor cmdb aliyun vendor
+	AliyunCloudVendor CloudVendor = "9"
 	// HuaWeiCloudVendor cmdb huawei vendor
 	HuaWeiCloudVendor CloudVendor = "15"
 )
 
 // HcmCmdbVendorMap is hcm vendor to cmdb cloud vendor map.
 var HcmCmdbVendorMap = map[enumor.Vendor]CloudVendor{
-	enumor.Aws:    AwsCloudVendor,
-	enumor.TCloud: TCloudCloudVendor,
-	enumor.Gcp:    GcpCloudVendor,
-	enumor.Azure:  AzureCloudVendor,
-	enumor.HuaWei: HuaWeiCloudVendor,
+	enumor.Aws:       AwsCloudVendor,
+	enumor.TCloud:    TCloudCloudVendor,
+	enumor.Gcp:       GcpCloudVendor,
+	enumor.Azure:     AzureCloudVendor,
+	enumor.HuaWei:    HuaWeiCloudVendor,
+	enumor.Aliyun:    AliyunCloudVendor,
+	enumor.OpenStack: OpenStackCloudVendor,
 }
 
 // CmdbHcmVendorMap cmdb vendor to hcm vendor
 var CmdbHcmVendorMap = map[CloudVendor]enumor.Vendor{
-	AwsCloudVendor:    enumor.Aws,
-	TCloudCloudVendor: enumor.TCloud,
-	GcpCloudVendor:    enumor.Gcp,
-	AzureCloudVendor:  enumor.Azure,
-	HuaWeiCloudVendor: enumor.HuaWei,
+	AwsCloudVendor:       enumor.Aws,
+	TCloudCloudVendor:    enumor.TCloud,
+	GcpCloudVendor:       enumor.Gcp,
+	AzureCloudVendor:     enumor.Azure,
+	HuaWeiCloudVendor:    enumor.HuaWei,
+	AliyunCloudVendor:    enumor.Aliyun,
+	OpenStackCloudVendor: enumor.OpenStack,
 }
 
 // CloudHostStatus defines cmdb cloud host status type.
@@ -412,11 +420,13 @@ const (
 
 // HcmCmdbHostStatusMap is hcm vendor to cmdb cloud host status map.
 var HcmCmdbHostStatusMap = map[enumor.Vendor]map[string]CloudHostStatus{
-	enumor.TCloud: TCloudCmdbStatusMap,
-	enumor.Aws:    AwsCmdbStatusMap,
-	enumor.Gcp:    GcpCmdbStatusMap,
-	enumor.Azure:  AzureCmdbStatusMap,
-	enumor.HuaWei: HuaWeiCmdbStatusMap,
+	enumor.TCloud:    TCloudCmdbStatusMap,
+	enumor.Aws:       AwsCmdbStatusMap,
+	enumor.Gcp:       GcpCmdbStatusMap,
+	enumor.Azure:     AzureCmdbStatusMap,
+	enumor.HuaWei:    HuaWeiCmdbStatusMap,
+	enumor.Aliyun:    AliyunCmdbStatusMap,
+	enumor.OpenStack: OpenStackCmdbStatusMap,
 }
 
 // TCloudCmdbStatusMap is tcloud status to cmdb cloud host status map.
@@ -481,6 +491,32 @@ var HuaWeiCmdbStatusMap = map[string]CloudHostStatus{
 	"UNKNOWN":           UnknownCloudHostStatus,
 }
 
+// AliyunCmdbStatusMap is aliyun status to cmdb cloud host status map.
+var AliyunCmdbStatusMap = map[string]CloudHostStatus{
+	"Pending":  UnknownCloudHostStatus,
+	"Starting": StartingCloudHostStatus,
+	"Running":  RunningCloudHostStatus,
+	"Stopping": StoppingCloudHostStatus,
+	"Stopped":  StoppedCloudHostStatus,
+}
+
+// OpenStackCmdbStatusMap is openstack status to cmdb cloud host status map.
+var OpenStackCmdbStatusMap = map[string]CloudHostStatus{
+	"BUILD":             StartingCloudHostStatus,
+	"REBUILD":           StartingCloudHostStatus,
+	"ACTIVE":            RunningCloudHostStatus,
+	"REBOOT":            RunningCloudHostStatus,
+	"HARD_REBOOT":       RunningCloudHostStatus,
+	"SHUTOFF":           StoppedCloudHostStatus,
+	"SUSPENDED":         StoppedCloudHostStatus,
+	"PAUSED":            StoppedCloudHostStatus,
+	"SHELVED":           StoppedCloudHostStatus,
+	"SHELVED_OFFLOADED": StoppedCloudHostStatus,
+	"DELETED":           TerminatedCloudHostStatus,
+	"SOFT_DELETED":      TerminatedCloudHostStatus,
+	"ERROR":             UnknownCloudHostStatus,
+}
+
 // BkAddressing cc主机寻址方式.
 type BkAddressing string
 
@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package siem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+)
+
+// Message is the message produced to kafka compatible message queue.
+type Message struct {
+	Topic string
+	// Key is "<tenant_id>/<seq>", so that messages can be deduplicated.
+	Key   []byte
+	Value []byte
+}
+
+// Producer is the kafka compatible producer interface, hcm does not depend on a specific kafka client, the
+// implementation should be registered by RegisterProducerFactory before kafka sinks are created.
+type Producer interface {
+	// Produce messages in order synchronously, returns error if any of them failed.
+	Produce(kt *kit.Kit, messages []Message) error
+	// Close the producer.
+	Close() error
+}
+
+// ProducerFactory create kafka compatible producer.
+type ProducerFactory func(conf cc.AuditKafkaSink) (Producer, error)
+
+var (
+	factoryLock     sync.RWMutex
+	producerFactory ProducerFactory
+)
+
+// RegisterProducerFactory register the kafka compatible producer factory.
+func RegisterProducerFactory(factory ProducerFactory) {
+	factoryLock.Lock()
+	defer factoryLock.Unlock()
+
+	producerFactory = factory
+}
+
+// kafkaSink publish audits to kafka compatible message queue, one message for each audit.
+type kafkaSink struct {
+	conf     cc.AuditKafkaSink
+	producer Producer
+}
+
+// NewKafkaSink create kafka sink with the registered producer factory.
+func NewKafkaSink(conf cc.AuditKafkaSink) (Sink, error) {
+	factoryLock.RLock()
+	factory := producerFactory
+	factoryLock.RUnlock()
+
+	if factory == nil {
+		return nil, errors.New("kafka producer factory is not registered")
+	}
+
+	producer, err := factory(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaSink{conf: conf, producer: producer}, nil
+}
+
+// Name returns the name of kafka sink.
+func (k *kafkaSink) Name() string {
+	return k.conf.Name
+}
+
+// Send produce audits to the topic.
+func (k *kafkaSink) Send(kt *kit.Kit, audits []audit.AuditTable) error {
+	messages := make([]Message, 0, len(audits))
+	for _, one := range audits {
+		value, err := json.Marshal(one)
+		if err != nil {
+			return fmt.Errorf("marshal audit %d failed, err: %v", one.ID, err)
+		}
+
+		messages = append(messages, Message{
+			Topic: k.conf.Topic,
+			Key:   []byte(fmt.Sprintf("%s/%d", one.TenantID, one.Seq)),
+			Value: value,
+		})
+	}
+
+	return k.producer.Produce(kt, messages)
+}
+
+// Close kafka sink.
+func (k *kafkaSink) Close() error {
+	return k.producer.Close()
+}
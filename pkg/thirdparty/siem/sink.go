@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package siem defines the sinks which publish audits to external SIEM (Security Information and Event Management)
+// systems, including http webhook, RFC5424 syslog and kafka compatible message queue.
+package siem
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+)
+
+// Sink publish audits to external SIEM system.
+type Sink interface {
+	// Name returns the unique name of the sink.
+	Name() string
+	// Send publish audits in order, audits are sent again if any of them failed, so sink should be idempotent or
+	// the receiver should deduplicate them by tenant id and seq.
+	Send(kt *kit.Kit, audits []audit.AuditTable) error
+	// Close release the resources of the sink.
+	Close() error
+}
+
+// NewSinks create all the sinks configured in audit stream setting.
+func NewSinks(conf cc.AuditStream) ([]Sink, error) {
+	sinks := make([]Sink, 0)
+
+	closeAll := func() {
+		for _, one := range sinks {
+			_ = one.Close()
+		}
+	}
+
+	for _, one := range conf.Webhooks {
+		sink, err := NewWebhookSink(one)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("create webhook sink %s failed, err: %v", one.Name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	for _, one := range conf.Syslogs {
+		sink, err := NewSyslogSink(one)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("create syslog sink %s failed, err: %v", one.Name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	for _, one := range conf.Kafkas {
+		sink, err := NewKafkaSink(one)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("create kafka sink %s failed, err: %v", one.Name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if len(sinks) == 0 {
+		return nil, errors.New("no audit sink is configured")
+	}
+
+	return sinks, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package siem
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+)
+
+func testAudits() []audit.AuditTable {
+	return []audit.AuditTable{
+		{ID: 1, TenantID: "default", Seq: 1, ResID: "vpc-1", Operator: "admin", CreatedAt: "2024-06-01T10:00:00+08:00"},
+		{ID: 2, TenantID: "default", Seq: 2, ResID: "vpc-2", Operator: "admin", CreatedAt: "2024-06-01T10:00:01+08:00"},
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	secret := "test-secret"
+	received := make([]audit.AuditTable, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sign := "sha256=" + SignWebhook(secret, r.Header.Get(WebhookTimestampHeader), body)
+		if r.Header.Get(WebhookSignatureHeader) != sign {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		payload := new(WebhookPayload)
+		if err := json.Unmarshal(body, payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, payload.Audits...)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(cc.AuditWebhookSink{Name: "webhook", URL: server.URL, Secret: secret})
+	if err != nil {
+		t.Fatalf("create webhook sink failed, err: %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Send(kit.New(), testAudits()); err != nil {
+		t.Fatalf("send audits failed, err: %v", err)
+	}
+
+	if len(received) != 2 || received[1].Seq != 2 {
+		t.Errorf("received audits are unexpected: %+v", received)
+	}
+
+	wrongSink, err := NewWebhookSink(cc.AuditWebhookSink{Name: "webhook", URL: server.URL, Secret: "wrong"})
+	if err != nil {
+		t.Fatalf("create webhook sink failed, err: %v", err)
+	}
+	defer wrongSink.Close()
+
+	if err := wrongSink.Send(kit.New(), testAudits()); err == nil {
+		t.Errorf("send audits with wrong signature should fail")
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed, err: %v", err)
+	}
+	defer listener.Close()
+
+	messages := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for i := 0; i < 2; i++ {
+			// octet counting framing: MSG-LEN SP SYSLOG-MSG
+			lenStr, err := reader.ReadString(' ')
+			if err != nil {
+				return
+			}
+			length, _ := strconv.Atoi(strings.TrimSpace(lenStr))
+			msg := make([]byte, length)
+			if _, err := io.ReadFull(reader, msg); err != nil {
+				return
+			}
+			messages <- string(msg)
+		}
+	}()
+
+	sink, err := NewSyslogSink(cc.AuditSyslogSink{Name: "syslog", Address: listener.Addr().String(), Facility: 13,
+		AppName: "hcm"})
+	if err != nil {
+		t.Fatalf("create syslog sink failed, err: %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Send(kit.New(), testAudits()); err != nil {
+		t.Fatalf("send audits failed, err: %v", err)
+	}
+
+	for i, one := range testAudits() {
+		msg := <-messages
+		// facility 13 * 8 + severity 6 = 110
+		prefix := "<110>1 " + string(one.CreatedAt) + " "
+		if !strings.HasPrefix(msg, prefix) {
+			t.Errorf("message %d should have prefix %s, got: %s", i, prefix, msg)
+		}
+
+		fields := strings.SplitN(msg, " ", 8)
+		if len(fields) != 8 || fields[3] != "hcm" || fields[5] != "audit" || fields[6] != "-" {
+			t.Errorf("message %d header is invalid: %s", i, msg)
+			continue
+		}
+
+		decoded := new(audit.AuditTable)
+		if err := json.Unmarshal([]byte(fields[7]), decoded); err != nil || decoded.Seq != one.Seq {
+			t.Errorf("message %d body is invalid, err: %v, msg: %s", i, err, msg)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package siem
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/ssl"
+)
+
+const (
+	// syslogSeverityInfo is the informational severity of syslog.
+	syslogSeverityInfo = 6
+	// syslogMsgID is the MSGID of audit syslog messages.
+	syslogMsgID = "audit"
+
+	defaultSyslogTimeoutSec = 10
+)
+
+// syslogSink publish audits as RFC5424 syslog messages over tcp or tls, messages are framed with octet counting
+// (RFC5425/RFC6587), and the MSG part is the json of audit.
+type syslogSink struct {
+	conf     cc.AuditSyslogSink
+	tlsConf  *tls.Config
+	timeout  time.Duration
+	hostname string
+	procID   string
+	lock     sync.Mutex
+	conn     net.Conn
+}
+
+// NewSyslogSink create syslog sink, the connection is established when sending audits.
+func NewSyslogSink(conf cc.AuditSyslogSink) (Sink, error) {
+	sink := &syslogSink{
+		conf:    conf,
+		timeout: time.Duration(conf.TimeoutSec) * time.Second,
+		procID:  strconv.Itoa(os.Getpid()),
+	}
+
+	if sink.timeout == 0 {
+		sink.timeout = defaultSyslogTimeoutSec * time.Second
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = "-"
+	}
+	sink.hostname = hostname
+
+	if conf.EnableTLS {
+		sink.tlsConf = &tls.Config{InsecureSkipVerify: conf.TLS.InsecureSkipVerify}
+		if conf.TLS.Enable() {
+			sink.tlsConf, err = ssl.ClientTLSConfVerify(conf.TLS.InsecureSkipVerify, conf.TLS.CAFile,
+				conf.TLS.CertFile, conf.TLS.KeyFile, conf.TLS.Password)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sink, nil
+}
+
+// Name returns the name of syslog sink.
+func (s *syslogSink) Name() string {
+	return s.conf.Name
+}
+
+// Send write audits to syslog server in order, the connection is closed and re-established on the next sending if
+// any error occurs.
+func (s *syslogSink) Send(kt *kit.Kit, audits []audit.AuditTable) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	buf := new(bytes.Buffer)
+	for i := range audits {
+		msg, err := FormatRFC5424(s.conf.Facility, s.hostname, s.conf.AppName, s.procID, &audits[i])
+		if err != nil {
+			return err
+		}
+		buf.WriteString(strconv.Itoa(len(msg)))
+		buf.WriteByte(' ')
+		buf.Write(msg)
+	}
+
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return fmt.Errorf("connect to syslog %s failed, err: %v", s.conf.Address, err)
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		s.closeConn()
+		return err
+	}
+
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		s.closeConn()
+		return fmt.Errorf("write to syslog %s failed, err: %v", s.conf.Address, err)
+	}
+
+	return nil
+}
+
+func (s *syslogSink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.timeout, KeepAlive: 30 * time.Second}
+	if s.tlsConf != nil {
+		return tls.DialWithDialer(dialer, "tcp", s.conf.Address, s.tlsConf)
+	}
+
+	return dialer.Dial("tcp", s.conf.Address)
+}
+
+func (s *syslogSink) closeConn() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Close syslog sink.
+func (s *syslogSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closeConn()
+	return nil
+}
+
+// FormatRFC5424 format the audit as RFC5424 syslog message without framing, the TIMESTAMP is the created time of
+// audit, and the MSG is the json of audit.
+// e.g: <110>1 2024-06-01T10:00:00+08:00 host hcm 1024 audit - {"id":1,...}
+func FormatRFC5424(facility uint8, hostname, appName, procID string, one *audit.AuditTable) ([]byte, error) {
+	msg, err := json.Marshal(one)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit %d failed, err: %v", one.ID, err)
+	}
+
+	timestamp := string(one.CreatedAt)
+	if len(timestamp) == 0 {
+		timestamp = "-"
+	}
+
+	header := fmt.Sprintf("<%d>1 %s %s %s %s %s - ", int(facility)*8+syslogSeverityInfo, timestamp,
+		syslogHeaderField(hostname, 255), syslogHeaderField(appName, 48), syslogHeaderField(procID, 128), syslogMsgID)
+
+	return append([]byte(header), msg...), nil
+}
+
+// syslogHeaderField returns the valid header field of RFC5424, which is printable ascii without space and has a max
+// length, "-" is used as nil value.
+func syslogHeaderField(value string, maxLen int) string {
+	field := make([]byte, 0, len(value))
+	for i := 0; i < len(value) && len(field) < maxLen; i++ {
+		if value[i] >= 33 && value[i] <= 126 {
+			field = append(field, value[i])
+		}
+	}
+
+	if len(field) == 0 {
+		return "-"
+	}
+
+	return string(field)
+}
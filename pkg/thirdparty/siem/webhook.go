@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package siem
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/tools/ssl"
+)
+
+const (
+	// WebhookSignatureHeader is the header of the request body signature, format: sha256=<hex>.
+	// the signature is hmac-sha256 of "<timestamp>.<body>" with the secret of webhook.
+	WebhookSignatureHeader = "X-Hcm-Signature"
+	// WebhookTimestampHeader is the header of the unix timestamp when the request is signed.
+	WebhookTimestampHeader = "X-Hcm-Timestamp"
+
+	defaultWebhookTimeoutSec = 10
+)
+
+// WebhookPayload is the request body of the audit webhook.
+type WebhookPayload struct {
+	Audits []audit.AuditTable `json:"audits"`
+}
+
+// webhookSink publish audits to http webhook with hmac signature.
+type webhookSink struct {
+	conf   cc.AuditWebhookSink
+	client *http.Client
+}
+
+// NewWebhookSink create http webhook sink.
+func NewWebhookSink(conf cc.AuditWebhookSink) (Sink, error) {
+	cli, err := client.NewClient(&ssl.TLSConfig{
+		InsecureSkipVerify: conf.TLS.InsecureSkipVerify,
+		CertFile:           conf.TLS.CertFile,
+		KeyFile:            conf.TLS.KeyFile,
+		CAFile:             conf.TLS.CAFile,
+		Password:           conf.TLS.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := conf.TimeoutSec
+	if timeout == 0 {
+		timeout = defaultWebhookTimeoutSec
+	}
+	cli.Timeout = time.Duration(timeout) * time.Second
+
+	return &webhookSink{conf: conf, client: cli}, nil
+}
+
+// Name returns the name of webhook sink.
+func (w *webhookSink) Name() string {
+	return w.conf.Name
+}
+
+// Send post all audits in one request, any response status other than 2xx is regarded as failure.
+func (w *webhookSink) Send(kt *kit.Kit, audits []audit.AuditTable) error {
+	body, err := json.Marshal(WebhookPayload{Audits: audits})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, w.conf.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+
+	if len(w.conf.Secret) != 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(w.conf.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responds status %d, body: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// Close webhook sink.
+func (w *webhookSink) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// SignWebhook returns the hex hmac-sha256 signature of "<timestamp>.<body>", receivers can use it to verify the
+// request, and reject the requests whose timestamp is too old to avoid replay.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`res_metric_daily`资源监控指标日汇总表
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_metric_daily` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `vendor` varchar(16) NOT NULL COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL COMMENT '账号ID',
+    `region` varchar(255) NOT NULL DEFAULT '' COMMENT '地域',
+    `res_type` varchar(64) NOT NULL COMMENT '资源类型',
+    `res_id` varchar(64) NOT NULL COMMENT '资源ID',
+    `cloud_res_id` varchar(255) NOT NULL COMMENT '云资源ID',
+    `metric` varchar(64) NOT NULL COMMENT '指标名称',
+    `stat_date` varchar(16) NOT NULL COMMENT '统计日期',
+    `avg_value` double NOT NULL DEFAULT 0 COMMENT '平均值',
+    `max_value` double NOT NULL DEFAULT 0 COMMENT '最大值',
+    `min_value` double NOT NULL DEFAULT 0 COMMENT '最小值',
+    `sample_count` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '采样点数',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建人',
+    `reviser` varchar(64) NOT NULL COMMENT '修改人',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '该记录创建的时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_res_metric_date` (`res_type`, `res_id`, `metric`, `stat_date`, `tenant_id`),
+    KEY `idx_account_id_stat_date` (`account_id`, `stat_date`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源监控指标日汇总表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('res_metric_daily', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`res_recommendation`资源优化建议表
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_recommendation` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `vendor` varchar(16) NOT NULL COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL COMMENT '账号ID',
+    `bk_biz_id` bigint(1) NOT NULL DEFAULT -1 COMMENT '业务ID',
+    `region` varchar(255) NOT NULL DEFAULT '' COMMENT '地域',
+    `zone` varchar(255) NOT NULL DEFAULT '' COMMENT '可用区',
+    `res_type` varchar(64) NOT NULL COMMENT '资源类型',
+    `res_id` varchar(64) NOT NULL COMMENT '资源ID',
+    `cloud_res_id` varchar(255) NOT NULL COMMENT '云资源ID',
+    `res_name` varchar(255) NOT NULL DEFAULT '' COMMENT '资源名称',
+    `rec_type` varchar(64) NOT NULL COMMENT '优化建议类型',
+    `action` varchar(64) NOT NULL COMMENT '处理动作',
+    `detail` json NOT NULL COMMENT '判定依据',
+    `monthly_saving` double NOT NULL DEFAULT 0 COMMENT '预计每月节省费用',
+    `currency` varchar(16) NOT NULL DEFAULT '' COMMENT '币种',
+    `status` varchar(32) NOT NULL COMMENT '状态(pending:待处理,applied:已处理,ignored:已忽略)',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建人',
+    `reviser` varchar(64) NOT NULL COMMENT '修改人',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '该记录创建的时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_res_rec_type` (`res_type`, `res_id`, `rec_type`, `tenant_id`),
+    KEY `idx_account_id_status` (`account_id`, `status`),
+    KEY `idx_bk_biz_id` (`bk_biz_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源优化建议表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('res_recommendation', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`disk_snapshot`云硬盘快照表
+    2. 新增`disk_snapshot_policy`云硬盘定期快照策略表
+*/
+
+START TRANSACTION;
+
+create table if not exists `disk_snapshot` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `vendor` varchar(16) NOT NULL COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL COMMENT '账号ID',
+    `cloud_id` varchar(255) NOT NULL COMMENT '云快照ID',
+    `bk_biz_id` bigint(1) NOT NULL DEFAULT -1 COMMENT '业务ID',
+    `name` varchar(255) NOT NULL DEFAULT '' COMMENT '快照名称',
+    `region` varchar(255) NOT NULL DEFAULT '' COMMENT '地域',
+    `zone` varchar(255) NOT NULL DEFAULT '' COMMENT '可用区',
+    `disk_id` varchar(64) NOT NULL DEFAULT '' COMMENT '云硬盘ID',
+    `cloud_disk_id` varchar(255) NOT NULL DEFAULT '' COMMENT '云硬盘云ID',
+    `disk_size` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '云硬盘大小(GB)',
+    `status` varchar(32) NOT NULL DEFAULT '' COMMENT '快照状态',
+    `policy_id` varchar(64) NOT NULL DEFAULT '' COMMENT '定期快照策略ID',
+    `recycle_status` varchar(32) NOT NULL DEFAULT '' COMMENT '回收状态',
+    `cloud_created_time` varchar(64) NOT NULL DEFAULT '' COMMENT '云上创建时间',
+    `memo` varchar(255) DEFAULT '' COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建人',
+    `reviser` varchar(64) NOT NULL COMMENT '修改人',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '该记录创建的时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_cloud_id_account_id` (`cloud_id`, `account_id`, `tenant_id`),
+    KEY `idx_disk_id` (`disk_id`),
+    KEY `idx_policy_id` (`policy_id`),
+    KEY `idx_account_id_region` (`account_id`, `region`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='云硬盘快照表';
+
+create table if not exists `disk_snapshot_policy` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `vendor` varchar(16) NOT NULL COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL COMMENT '账号ID',
+    `bk_biz_id` bigint(1) NOT NULL DEFAULT -1 COMMENT '业务ID',
+    `name` varchar(255) NOT NULL COMMENT '策略名称',
+    `hours` json NOT NULL COMMENT '每天执行的小时',
+    `week_days` json NOT NULL COMMENT '每周执行的星期，为空表示每天',
+    `retention_days` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '快照保留天数，0表示永久保留',
+    `disk_ids` json NOT NULL COMMENT '绑定的云硬盘ID列表',
+    `memo` varchar(255) DEFAULT '' COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建人',
+    `reviser` varchar(64) NOT NULL COMMENT '修改人',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '该记录创建的时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_account_id` (`account_id`),
+    KEY `idx_bk_biz_id` (`bk_biz_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='云硬盘定期快照策略表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('disk_snapshot', '0'),
+       ('disk_snapshot_policy', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`res_change_history`资源变更历史表
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_change_history` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `res_type` varchar(64) NOT NULL COMMENT '资源类型',
+    `res_id` varchar(64) NOT NULL COMMENT '资源ID',
+    `cloud_res_id` varchar(255) NOT NULL DEFAULT '' COMMENT '云资源ID',
+    `res_name` varchar(255) NOT NULL DEFAULT '' COMMENT '资源名称',
+    `vendor` varchar(16) NOT NULL DEFAULT '' COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL DEFAULT '' COMMENT '账号ID',
+    `bk_biz_id` bigint(1) NOT NULL DEFAULT -1 COMMENT '业务ID',
+    `version` bigint(1) unsigned NOT NULL COMMENT '资源版本号，从1开始递增',
+    `action` varchar(16) NOT NULL COMMENT '变更动作(create/update/delete)',
+    `snapshot` json NOT NULL COMMENT '变更后的资源快照，删除时为删除前的快照',
+    `diff` json NOT NULL COMMENT '相对上一版本的字段级变更，资源首个版本为空数组',
+    `source` varchar(64) NOT NULL DEFAULT '' COMMENT '请求来源',
+    `rid` varchar(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '变更人',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '变更时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_res_type_res_id_version` (`res_type`, `res_id`, `version`, `tenant_id`),
+    KEY `idx_res_type_created_at` (`res_type`, `created_at`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源变更历史表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('res_change_history', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
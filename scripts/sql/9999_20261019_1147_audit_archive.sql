@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`audit_archive`审计归档清单表
+*/
+
+START TRANSACTION;
+
+create table if not exists `audit_archive` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `archive_date` char(10) NOT NULL COMMENT '归档审计的日期，格式：2006-01-02',
+    `path` varchar(255) NOT NULL COMMENT '归档文件在对象存储中的路径',
+    `min_audit_id` bigint(1) unsigned NOT NULL COMMENT '归档审计的最小ID',
+    `max_audit_id` bigint(1) unsigned NOT NULL COMMENT '归档审计的最大ID',
+    `start_time` varchar(64) NOT NULL COMMENT '归档审计的最早创建时间，格式：2006-01-02T15:04:05Z07:00',
+    `end_time` varchar(64) NOT NULL COMMENT '归档审计的最晚创建时间，格式：2006-01-02T15:04:05Z07:00',
+    `count` bigint(1) unsigned NOT NULL COMMENT '归档审计的数量',
+    `size` bigint(1) unsigned NOT NULL COMMENT '归档文件大小，单位：字节',
+    `sha256` varchar(64) NOT NULL COMMENT '归档文件的sha256校验值',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '归档时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_path` (`path`),
+    KEY `idx_archive_date` (`archive_date`),
+    KEY `idx_audit_id` (`min_audit_id`, `max_audit_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='审计归档清单表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('audit_archive', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
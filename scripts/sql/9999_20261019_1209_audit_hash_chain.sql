@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. `audit`表增加哈希链字段`seq`、`prev_hash`、`hash`
+    2. 新增`audit_chain_head`审计哈希链头表
+    3. 新增`audit_checkpoint`审计哈希链签名检查点表
+    4. `audit_archive`表增加归档审计的哈希链序号范围`min_seq`、`max_seq`
+*/
+
+START TRANSACTION;
+
+alter table `audit`
+    add column `seq` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '租户内审计哈希链序号，历史审计为0' after `detail`,
+    add column `prev_hash` char(64) NOT NULL DEFAULT '' COMMENT '哈希链中上一条审计的哈希' after `seq`,
+    add column `hash` char(64) NOT NULL DEFAULT '' COMMENT '审计内容及上一条审计哈希的哈希' after `prev_hash`,
+    add index `idx_tenant_id_seq` (`tenant_id`, `seq`);
+
+alter table `audit_archive`
+    add column `min_seq` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '归档审计在哈希链中的最小序号' after `max_audit_id`,
+    add column `max_seq` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '归档审计在哈希链中的最大序号' after `min_seq`,
+    add index `idx_seq` (`min_seq`, `max_seq`);
+
+create table if not exists `audit_chain_head` (
+    `tenant_id` varchar(64) NOT NULL COMMENT '租户ID',
+    `seq` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '哈希链最新审计的序号',
+    `hash` char(64) NOT NULL DEFAULT '' COMMENT '哈希链最新审计的哈希',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='审计哈希链头表';
+
+create table if not exists `audit_checkpoint` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `seq` bigint(1) unsigned NOT NULL COMMENT '检查点对应的审计序号',
+    `hash` char(64) NOT NULL COMMENT '检查点对应的审计哈希',
+    `signature` varchar(128) NOT NULL COMMENT '检查点签名',
+    `path` varchar(255) NOT NULL COMMENT '检查点文件在对象存储中的路径',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_seq_tenant_id` (`seq`, `tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='审计哈希链签名检查点表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('audit_checkpoint', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`audit_outbox`审计推送发件箱表，审计写入时在同一事务内写入，推送到外部SIEM成功后删除
+*/
+
+START TRANSACTION;
+
+create table if not exists `audit_outbox` (
+    `id` bigint(1) unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID，按ID顺序推送',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '审计所属租户ID',
+    `seq` bigint(1) unsigned NOT NULL COMMENT '审计在租户哈希链中的序号',
+    `state` varchar(16) NOT NULL DEFAULT 'pending' COMMENT '推送状态（枚举值：pending、failed）',
+    `retry_count` int(1) unsigned NOT NULL DEFAULT 0 COMMENT '推送失败的重试次数',
+    `next_retry_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次推送时间',
+    `last_error` varchar(1024) NOT NULL DEFAULT '' COMMENT '最近一次推送失败的原因',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_state_next_retry_at` (`state`, `next_retry_at`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='审计推送发件箱表';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
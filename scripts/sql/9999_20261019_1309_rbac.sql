@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`rbac_role`本地鉴权角色表
+    2. 新增`rbac_role_binding`本地鉴权角色绑定表
+*/
+
+START TRANSACTION;
+
+create table if not exists `rbac_role` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `name` varchar(255) NOT NULL COMMENT '角色名称',
+    `policies` json NOT NULL COMMENT '角色策略，授予的资源类型及操作',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_name` (`name`, `tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='本地鉴权角色表';
+
+create table if not exists `rbac_role_binding` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `role_id` varchar(64) NOT NULL COMMENT '角色ID',
+    `user` varchar(64) NOT NULL COMMENT '被授权的用户',
+    `scope_type` varchar(16) NOT NULL COMMENT '授权范围类型(global/biz/resource)',
+    `scope_ids` json NOT NULL COMMENT '授权范围ID列表，biz为业务ID，resource为资源ID，global为空',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_role_id_user` (`role_id`, `user`, `tenant_id`),
+    KEY `idx_user` (`user`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='本地鉴权角色绑定表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('rbac_role', '0'),
+       ('rbac_role_binding', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`service_account`服务账号表
+    2. 新增`access_token`访问令牌表
+*/
+
+START TRANSACTION;
+
+create table if not exists `service_account` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `name` varchar(64) NOT NULL COMMENT '服务账号名称，作为服务账号令牌调用时的用户名',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_name` (`name`, `tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='服务账号表';
+
+create table if not exists `access_token` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `name` varchar(255) NOT NULL COMMENT '令牌名称',
+    `owner_type` varchar(32) NOT NULL COMMENT '所属者类型(user/service_account)',
+    `owner` varchar(64) NOT NULL COMMENT '所属者，用户名或服务账号名称',
+    `token_hash` varchar(64) NOT NULL COMMENT '令牌的sha256哈希',
+    `token_prefix` varchar(16) NOT NULL COMMENT '令牌前缀，用于识别令牌',
+    `scope` json NOT NULL COMMENT '令牌权限范围，允许的操作及业务',
+    `expires_at` timestamp NOT NULL COMMENT '过期时间',
+    `revoked` boolean NOT NULL DEFAULT false COMMENT '是否已撤销',
+    `last_used_at` timestamp NULL DEFAULT NULL COMMENT '最近使用时间',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_token_hash` (`token_hash`),
+    KEY `idx_owner` (`owner_type`, `owner`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='访问令牌表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('service_account', '0'),
+       ('access_token', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`assign_rule`资源自动分配业务规则表
+*/
+
+START TRANSACTION;
+
+create table if not exists `assign_rule` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `name` varchar(64) NOT NULL COMMENT '规则名称',
+    `priority` int unsigned NOT NULL COMMENT '优先级，数值越小越先匹配',
+    `enabled` boolean NOT NULL DEFAULT true COMMENT '是否启用',
+    `bk_biz_id` bigint NOT NULL COMMENT '分配的业务ID',
+    `bk_cloud_id` bigint DEFAULT NULL COMMENT '主机分配到业务时使用的管控区域ID',
+    `res_types` json NOT NULL COMMENT '规则适用的资源类型',
+    `conditions` json NOT NULL COMMENT '匹配条件',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_name` (`name`, `tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源自动分配业务规则表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('assign_rule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`res_event`资源变更事件发件箱表，资源变更历史写入时在同一事务内写入，由后台分发到匹配的订阅
+    2. 新增`event_subscription`资源变更事件订阅表
+    3. 新增`event_delivery`资源变更事件推送记录表
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_event` (
+    `id` bigint(1) unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID，按ID顺序分发',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `event_type` varchar(64) NOT NULL COMMENT '事件类型',
+    `res_type` varchar(64) NOT NULL COMMENT '资源类型',
+    `res_id` varchar(64) NOT NULL COMMENT '资源ID',
+    `cloud_res_id` varchar(255) NOT NULL DEFAULT '' COMMENT '云资源ID',
+    `res_name` varchar(255) NOT NULL DEFAULT '' COMMENT '资源名称',
+    `vendor` varchar(16) NOT NULL DEFAULT '' COMMENT '云厂商',
+    `account_id` varchar(64) NOT NULL DEFAULT '' COMMENT '账号ID',
+    `bk_biz_id` bigint NOT NULL DEFAULT -1 COMMENT '资源所属业务ID',
+    `prev_bk_biz_id` bigint NOT NULL DEFAULT 0 COMMENT '业务变更前的业务ID',
+    `version` bigint(1) unsigned NOT NULL COMMENT '资源变更历史版本号',
+    `diff` json NOT NULL COMMENT '相对上一版本的字段级变更',
+    `source` varchar(64) NOT NULL DEFAULT '' COMMENT '请求来源',
+    `rid` varchar(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    `operator` varchar(64) NOT NULL DEFAULT '' COMMENT '操作人',
+    `state` varchar(16) NOT NULL DEFAULT 'pending' COMMENT '分发状态（枚举值：pending、dispatched）',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_state_id` (`state`, `id`),
+    KEY `idx_tenant_id_res_id` (`tenant_id`, `res_id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源变更事件发件箱表';
+
+create table if not exists `event_subscription` (
+    `id` varchar(64) NOT NULL COMMENT '唯一ID',
+    `name` varchar(64) NOT NULL COMMENT '订阅名称',
+    `url` varchar(512) NOT NULL COMMENT '事件推送地址',
+    `secret` varchar(512) NOT NULL COMMENT '加密后的请求签名密钥',
+    `filter` json NOT NULL COMMENT '事件过滤条件',
+    `enabled` boolean NOT NULL DEFAULT true COMMENT '是否启用',
+    `memo` varchar(255) DEFAULT NULL COMMENT '备注',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `creator` varchar(64) NOT NULL COMMENT '创建者',
+    `reviser` varchar(64) NOT NULL COMMENT '更新者',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_name` (`name`, `tenant_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源变更事件订阅表';
+
+create table if not exists `event_delivery` (
+    `id` bigint(1) unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `subscription_id` varchar(64) NOT NULL COMMENT '事件订阅ID',
+    `event_id` bigint(1) unsigned NOT NULL COMMENT '资源变更事件ID',
+    `state` varchar(16) NOT NULL DEFAULT 'pending' COMMENT '推送状态（枚举值：pending、succeeded、failed）',
+    `retry_count` int(1) unsigned NOT NULL DEFAULT 0 COMMENT '推送失败的重试次数',
+    `next_retry_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次推送时间',
+    `status_code` int NOT NULL DEFAULT 0 COMMENT '最近一次推送的响应状态码',
+    `last_error` varchar(1024) NOT NULL DEFAULT '' COMMENT '最近一次推送失败的原因',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_state_next_retry_at` (`state`, `next_retry_at`),
+    KEY `idx_tenant_id_subscription_id` (`tenant_id`, `subscription_id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源变更事件推送记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('event_subscription', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
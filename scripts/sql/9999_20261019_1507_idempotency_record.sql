@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`idempotency_record`幂等请求记录表，记录携带幂等键的请求的请求摘要及响应结果
+*/
+
+START TRANSACTION;
+
+create table if not exists `idempotency_record` (
+    `id` bigint(1) unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `tenant_id` varchar(64) NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `scope` varchar(255) NOT NULL COMMENT '幂等键作用域，由服务名、应用编码及用户组成',
+    `idem_key` varchar(128) NOT NULL COMMENT '幂等键',
+    `request_hash` char(64) NOT NULL COMMENT '请求摘要，由请求方法、请求路径及请求体计算得到',
+    `state` varchar(16) NOT NULL DEFAULT 'processing' COMMENT '请求状态（枚举值：processing、completed、unknown）',
+    `response` mediumtext COMMENT '请求成功时的响应数据',
+    `lock_expired_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '请求处理超时时间',
+    `expired_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录过期时间',
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_uk_tenant_id_scope_idem_key` (`tenant_id`, `scope`, `idem_key`),
+    KEY `idx_expired_at` (`expired_at`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='幂等请求记录表';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. 新增`res_change_version`资源最新变更版本表，记录变更历史时对其加锁分配版本号
+    2. 根据`res_change_history`中未删除资源的最新版本初始化`res_change_version`
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_change_version` (
+    `res_type` varchar(64) NOT NULL COMMENT '资源类型',
+    `res_id` varchar(64) NOT NULL COMMENT '资源ID',
+    `version` bigint(1) unsigned NOT NULL DEFAULT 0 COMMENT '资源最新版本号，0表示还没有变更历史',
+    `snapshot` json NOT NULL COMMENT '资源最新版本的快照',
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`res_type`, `res_id`)
+) ENGINE=InnoDB
+  DEFAULT CHARSET=utf8mb4
+  COLLATE=utf8mb4_bin COMMENT='资源最新变更版本表';
+
+insert ignore into `res_change_version` (`res_type`, `res_id`, `version`, `snapshot`)
+select h.`res_type`, h.`res_id`, h.`version`, h.`snapshot`
+from `res_change_history` h
+         join (select `res_type`, `res_id`, max(`version`) as `version`
+               from `res_change_history`
+               group by `res_type`, `res_id`) l
+              on h.`res_type` = l.`res_type` and h.`res_id` = l.`res_id` and h.`version` = l.`version`
+where h.`action` != 'delete';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,40 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=9999,HCMVER=v9.9.9
+
+    Notes:
+    1. `cvm`表新增`status_changed_at`字段，记录主机状态最近一次变更的时间
+    2. 已有主机的状态变更时间未知，使用不早于状态变更时间的`updated_at`初始化，同时显式保留`updated_at`避免其被刷新
+*/
+
+START TRANSACTION;
+
+alter table `cvm`
+    add column `status_changed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '状态变更时间' after `recycle_status`;
+
+update `cvm`
+set `status_changed_at` = `updated_at`,
+    `updated_at`        = `updated_at`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v9.9.9' as `hcm_ver`, '9999' as `sql_ver`;
+
+COMMIT;